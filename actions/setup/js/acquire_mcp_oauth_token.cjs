@@ -0,0 +1,89 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { ERR_API, ERR_CONFIG } = require("./error_codes.cjs");
+
+/**
+ * Acquire an OAuth 2.0 access token for an HTTP MCP server and expose it as the
+ * access_token step output, which the MCP gateway receives through an environment
+ * variable. Uses the refresh_token grant when GH_AW_OAUTH_REFRESH_TOKEN is set
+ * (refresh tokens are obtained once with `gh aw mcp oauth`) and the client_credentials
+ * grant otherwise.
+ *
+ * @returns {Promise<void>}
+ */
+async function main() {
+  const serverName = process.env.GH_AW_OAUTH_SERVER || "MCP server";
+  const tokenUrl = process.env.GH_AW_OAUTH_TOKEN_URL;
+  const clientId = process.env.GH_AW_OAUTH_CLIENT_ID || "";
+  const clientSecret = process.env.GH_AW_OAUTH_CLIENT_SECRET || "";
+  const refreshToken = process.env.GH_AW_OAUTH_REFRESH_TOKEN || "";
+  const scopes = process.env.GH_AW_OAUTH_SCOPES || "";
+  const audience = process.env.GH_AW_OAUTH_AUDIENCE || "";
+
+  if (!tokenUrl) {
+    core.setFailed(`${ERR_CONFIG}: GH_AW_OAUTH_TOKEN_URL is not set for ${serverName}`);
+    return;
+  }
+  if (!refreshToken && !clientSecret) {
+    core.setFailed(`${ERR_CONFIG}: OAuth for ${serverName} needs a refresh-token or a client-secret; check that the referenced secrets are set`);
+    return;
+  }
+
+  const params = new URLSearchParams();
+  if (refreshToken) {
+    params.set("grant_type", "refresh_token");
+    params.set("refresh_token", refreshToken);
+  } else {
+    params.set("grant_type", "client_credentials");
+  }
+  if (clientId) params.set("client_id", clientId);
+  if (clientSecret) params.set("client_secret", clientSecret);
+  if (scopes) params.set("scope", scopes);
+  if (audience) params.set("audience", audience);
+
+  core.info(`Requesting OAuth access token for ${serverName} (${params.get("grant_type")} grant)`);
+
+  let response;
+  try {
+    response = await fetch(tokenUrl, {
+      method: "POST",
+      headers: { "Content-Type": "application/x-www-form-urlencoded", Accept: "application/json" },
+      body: params.toString(),
+    });
+  } catch (error) {
+    const message = error instanceof Error ? error.message : String(error);
+    core.setFailed(`${ERR_API}: OAuth token request for ${serverName} failed: ${message}`);
+    return;
+  }
+
+  const text = await response.text();
+  /** @type {Record<string, any>} */
+  let data;
+  try {
+    data = JSON.parse(text);
+  } catch {
+    // Some providers answer with form-encoded bodies
+    data = Object.fromEntries(new URLSearchParams(text));
+  }
+
+  if (!response.ok || !data.access_token) {
+    const detail = data.error_description || data.error || `HTTP ${response.status}`;
+    core.setFailed(`${ERR_API}: OAuth token request for ${serverName} failed: ${detail}`);
+    return;
+  }
+
+  core.setSecret(data.access_token);
+  if (data.refresh_token) {
+    core.setSecret(data.refresh_token);
+    if (refreshToken && data.refresh_token !== refreshToken) {
+      core.warning(`The OAuth provider for ${serverName} rotated the refresh token; run 'gh aw mcp oauth' again if the stored secret stops working`);
+    }
+  }
+
+  core.setOutput("access_token", data.access_token);
+  const expiry = data.expires_in ? ` (expires in ${data.expires_in}s)` : "";
+  core.info(`Acquired OAuth access token for ${serverName}${expiry}`);
+}
+
+module.exports = { main };
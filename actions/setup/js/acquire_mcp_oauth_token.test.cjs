@@ -0,0 +1,84 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+const mockCore = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+  setSecret: vi.fn(),
+};
+global.core = mockCore;
+
+describe("acquire_mcp_oauth_token.cjs", () => {
+  let main;
+  const originalFetch = global.fetch;
+
+  beforeEach(async () => {
+    vi.clearAllMocks();
+    process.env.GH_AW_OAUTH_SERVER = "notion";
+    process.env.GH_AW_OAUTH_TOKEN_URL = "https://auth.example.com/token";
+    process.env.GH_AW_OAUTH_CLIENT_ID = "client";
+    const module = await import("./acquire_mcp_oauth_token.cjs");
+    main = module.main;
+  });
+
+  afterEach(() => {
+    global.fetch = originalFetch;
+    for (const key of Object.keys(process.env).filter(k => k.startsWith("GH_AW_OAUTH_"))) {
+      delete process.env[key];
+    }
+  });
+
+  /** @param {number} status @param {any} body */
+  const respond = (status, body) => {
+    global.fetch = vi.fn().mockResolvedValue({ ok: status < 400, status, text: async () => JSON.stringify(body) });
+  };
+
+  it("should use the refresh_token grant when a refresh token is set", async () => {
+    process.env.GH_AW_OAUTH_REFRESH_TOKEN = "refresh";
+    process.env.GH_AW_OAUTH_SCOPES = "read write";
+    respond(200, { access_token: "token-123", expires_in: 3600 });
+
+    await main();
+
+    const body = new URLSearchParams(global.fetch.mock.calls[0][1].body);
+    expect(body.get("grant_type")).toBe("refresh_token");
+    expect(body.get("refresh_token")).toBe("refresh");
+    expect(body.get("client_id")).toBe("client");
+    expect(body.get("scope")).toBe("read write");
+    expect(mockCore.setSecret).toHaveBeenCalledWith("token-123");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("access_token", "token-123");
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+
+  it("should use the client_credentials grant without a refresh token", async () => {
+    process.env.GH_AW_OAUTH_CLIENT_SECRET = "secret";
+    respond(200, { access_token: "token-456" });
+
+    await main();
+
+    const body = new URLSearchParams(global.fetch.mock.calls[0][1].body);
+    expect(body.get("grant_type")).toBe("client_credentials");
+    expect(body.get("client_secret")).toBe("secret");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("access_token", "token-456");
+  });
+
+  it("should fail when no credential is available", async () => {
+    global.fetch = vi.fn();
+
+    await main();
+
+    expect(global.fetch).not.toHaveBeenCalled();
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("needs a refresh-token or a client-secret"));
+  });
+
+  it("should fail with the provider error", async () => {
+    process.env.GH_AW_OAUTH_REFRESH_TOKEN = "expired";
+    respond(400, { error: "invalid_grant", error_description: "Refresh token expired" });
+
+    await main();
+
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("Refresh token expired"));
+    expect(mockCore.setOutput).not.toHaveBeenCalled();
+  });
+});
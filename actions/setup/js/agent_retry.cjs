@@ -0,0 +1,162 @@
+// @ts-check
+
+const fs = require("fs");
+
+/**
+ * Retry decision for the agent execution step (retries: in frontmatter).
+ *
+ * Invoked by the retry loop of the agent execution step after a failed attempt.
+ * Classifies the failure from the engine log and, when the category is listed
+ * in GH_AW_RETRY_ON and retries remain, prints the backoff delay in seconds on
+ * stdout and exits 0. Otherwise exits 1 so the step fails with the agent's
+ * original exit code. Diagnostics are written to stderr.
+ *
+ * Environment:
+ *  - GH_AW_RETRY_ATTEMPT: number of retries already performed (0 after the first attempt)
+ *  - GH_AW_RETRY_MAX: maximum number of retries
+ *  - GH_AW_RETRY_BACKOFF: exponential, linear or fixed
+ *  - GH_AW_RETRY_ON: comma-separated retryable categories
+ *  - GH_AW_AGENT_OUTPUT: agent log file of the failed attempt
+ *  - GH_AW_RETRY_LOG_OFFSET: size of the log before the failed attempt started
+ *  - GH_AW_BUDGET_REPORT: budget monitor report; a stopped budget is never retried
+ */
+
+/** Base delay between attempts in seconds */
+const BASE_DELAY_SECONDS = 30;
+
+/** Maximum delay between attempts in seconds */
+const MAX_DELAY_SECONDS = 600;
+
+/**
+ * Failure patterns by category, checked in order.
+ * @type {Array<{category: string, patterns: RegExp[]}>}
+ */
+const FAILURE_PATTERNS = [
+  {
+    category: "rate-limit",
+    patterns: [/rate[ _-]?limit/i, /too many requests/i, /\b429\b/, /quota exceeded/i, /retry[- ]after/i],
+  },
+  {
+    category: "server-error",
+    patterns: [/overloaded/i, /internal server error/i, /service unavailable/i, /bad gateway/i, /gateway timeout/i, /(?:status|code|HTTP)[\s:="]*50[0234]\b/i],
+  },
+  {
+    category: "network",
+    patterns: [/ECONNRESET/, /ETIMEDOUT/, /ECONNREFUSED/, /ENOTFOUND/, /EAI_AGAIN/, /socket hang up/i, /network error/i, /fetch failed/i, /connection (?:reset|refused|closed)/i],
+  },
+];
+
+/**
+ * Classifies an agent failure from its log content
+ * @param {string} content - Engine log of the failed attempt
+ * @returns {string} Failure category (rate-limit, server-error, network) or "unknown"
+ */
+function classifyFailure(content) {
+  for (const { category, patterns } of FAILURE_PATTERNS) {
+    if (patterns.some(pattern => pattern.test(content))) {
+      return category;
+    }
+  }
+  return "unknown";
+}
+
+/**
+ * Computes the delay before the next attempt
+ * @param {number} attempt - Number of retries already performed
+ * @param {string} backoff - exponential, linear or fixed
+ * @returns {number} Delay in seconds
+ */
+function computeBackoffDelay(attempt, backoff) {
+  let delay;
+  switch (backoff) {
+    case "fixed":
+      delay = BASE_DELAY_SECONDS;
+      break;
+    case "linear":
+      delay = BASE_DELAY_SECONDS * (attempt + 1);
+      break;
+    default:
+      delay = BASE_DELAY_SECONDS * Math.pow(2, attempt);
+  }
+  return Math.min(delay, MAX_DELAY_SECONDS);
+}
+
+/**
+ * Decides whether the failed attempt should be retried
+ * @param {Object} options
+ * @param {string} options.content - Engine log of the failed attempt
+ * @param {number} options.attempt - Number of retries already performed
+ * @param {number} options.max - Maximum number of retries
+ * @param {string[]} options.retryOn - Retryable categories
+ * @param {boolean} options.budgetExceeded - Whether the budget monitor stopped the agent
+ * @returns {{retry: boolean, category: string, reason: string}} Decision
+ */
+function decideRetry({ content, attempt, max, retryOn, budgetExceeded }) {
+  if (budgetExceeded) {
+    return { retry: false, category: "budget", reason: "the agent was stopped by the budget monitor" };
+  }
+  const category = classifyFailure(content);
+  if (attempt >= max) {
+    return { retry: false, category, reason: `retry limit of ${max} reached` };
+  }
+  if (!retryOn.includes(category)) {
+    return { retry: false, category, reason: `failure category '${category}' is not retryable` };
+  }
+  return { retry: true, category, reason: `failure category '${category}' is retryable` };
+}
+
+/**
+ * Reads the agent log written since offset, returning an empty string when it is missing
+ * @param {string} logPath - Agent log file
+ * @param {number} offset - Byte offset where the failed attempt started
+ * @returns {string} Log content of the failed attempt
+ */
+function readAgentLog(logPath, offset) {
+  try {
+    const content = fs.readFileSync(logPath);
+    // Fall back to the whole log if it was truncated since the offset was recorded
+    const start = offset > 0 && offset <= content.length ? offset : 0;
+    return content.subarray(start).toString("utf8");
+  } catch {
+    return "";
+  }
+}
+
+function main() {
+  const attempt = parseInt(process.env.GH_AW_RETRY_ATTEMPT || "0", 10) || 0;
+  const max = parseInt(process.env.GH_AW_RETRY_MAX || "0", 10) || 0;
+  const backoff = process.env.GH_AW_RETRY_BACKOFF || "exponential";
+  const retryOn = (process.env.GH_AW_RETRY_ON || "")
+    .split(",")
+    .map(category => category.trim())
+    .filter(Boolean);
+  const budgetReport = process.env.GH_AW_BUDGET_REPORT || "";
+
+  const decision = decideRetry({
+    content: readAgentLog(process.env.GH_AW_AGENT_OUTPUT || "", parseInt(process.env.GH_AW_RETRY_LOG_OFFSET || "0", 10) || 0),
+    attempt,
+    max,
+    retryOn,
+    budgetExceeded: budgetReport !== "" && fs.existsSync(budgetReport),
+  });
+
+  if (!decision.retry) {
+    process.stderr.write(`Not retrying agent: ${decision.reason}\n`);
+    process.exit(1);
+  }
+
+  process.stderr.write(`Retrying agent: ${decision.reason}\n`);
+  process.stdout.write(`${computeBackoffDelay(attempt, backoff)}\n`);
+}
+
+if (require.main === module) {
+  main();
+}
+
+module.exports = {
+  classifyFailure,
+  computeBackoffDelay,
+  decideRetry,
+  readAgentLog,
+  main,
+};
@@ -0,0 +1,113 @@
+import { describe, it, expect, afterEach } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+const { classifyFailure, computeBackoffDelay, decideRetry, readAgentLog } = require("./agent_retry.cjs");
+
+describe("agent_retry.cjs", () => {
+  describe("classifyFailure", () => {
+    it("should detect rate limits", () => {
+      expect(classifyFailure("Error: 429 Too Many Requests")).toBe("rate-limit");
+      expect(classifyFailure('{"type":"error","error":{"type":"rate_limit_error"}}')).toBe("rate-limit");
+    });
+
+    it("should detect server errors", () => {
+      expect(classifyFailure('{"type":"error","error":{"type":"overloaded_error"}}')).toBe("server-error");
+      expect(classifyFailure("request failed with status 503")).toBe("server-error");
+    });
+
+    it("should detect network errors", () => {
+      expect(classifyFailure("Error: read ECONNRESET")).toBe("network");
+      expect(classifyFailure("TypeError: fetch failed")).toBe("network");
+    });
+
+    it("should not treat unrelated numbers as server errors", () => {
+      expect(classifyFailure("Processed 500 files before failing")).toBe("unknown");
+    });
+
+    it("should return unknown for other failures", () => {
+      expect(classifyFailure("Error: tool call failed: permission denied")).toBe("unknown");
+      expect(classifyFailure("")).toBe("unknown");
+    });
+  });
+
+  describe("computeBackoffDelay", () => {
+    it("should double the delay for exponential backoff", () => {
+      expect(computeBackoffDelay(0, "exponential")).toBe(30);
+      expect(computeBackoffDelay(1, "exponential")).toBe(60);
+      expect(computeBackoffDelay(2, "exponential")).toBe(120);
+    });
+
+    it("should grow linearly for linear backoff", () => {
+      expect(computeBackoffDelay(0, "linear")).toBe(30);
+      expect(computeBackoffDelay(2, "linear")).toBe(90);
+    });
+
+    it("should keep the delay constant for fixed backoff", () => {
+      expect(computeBackoffDelay(3, "fixed")).toBe(30);
+    });
+
+    it("should cap the delay", () => {
+      expect(computeBackoffDelay(10, "exponential")).toBe(600);
+    });
+  });
+
+  describe("decideRetry", () => {
+    const base = { attempt: 0, max: 2, retryOn: ["rate-limit", "network"], budgetExceeded: false };
+
+    it("should retry retryable categories", () => {
+      expect(decideRetry({ ...base, content: "429 Too Many Requests" })).toMatchObject({ retry: true, category: "rate-limit" });
+    });
+
+    it("should not retry categories that are not listed", () => {
+      expect(decideRetry({ ...base, content: "overloaded_error" })).toMatchObject({ retry: false, category: "server-error" });
+    });
+
+    it("should not retry unknown failures", () => {
+      expect(decideRetry({ ...base, content: "syntax error" })).toMatchObject({ retry: false, category: "unknown" });
+    });
+
+    it("should stop once the retry limit is reached", () => {
+      const decision = decideRetry({ ...base, attempt: 2, content: "ECONNRESET" });
+      expect(decision.retry).toBe(false);
+      expect(decision.reason).toContain("retry limit");
+    });
+
+    it("should never retry when the budget was exceeded", () => {
+      expect(decideRetry({ ...base, budgetExceeded: true, content: "429" })).toMatchObject({ retry: false, category: "budget" });
+    });
+  });
+
+  describe("readAgentLog", () => {
+    let tmpDir;
+
+    afterEach(() => {
+      if (tmpDir) {
+        fs.rmSync(tmpDir, { recursive: true, force: true });
+        tmpDir = undefined;
+      }
+    });
+
+    it("should only return output written since the offset", () => {
+      tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "agent-retry-"));
+      const logPath = path.join(tmpDir, "agent-stdio.log");
+      const firstAttempt = "429 Too Many Requests\n";
+      fs.writeFileSync(logPath, firstAttempt + "syntax error\n");
+
+      expect(readAgentLog(logPath, Buffer.byteLength(firstAttempt))).toBe("syntax error\n");
+    });
+
+    it("should read the whole log when it was truncated", () => {
+      tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "agent-retry-"));
+      const logPath = path.join(tmpDir, "agent-stdio.log");
+      fs.writeFileSync(logPath, "ECONNRESET\n");
+
+      expect(readAgentLog(logPath, 1000)).toBe("ECONNRESET\n");
+    });
+
+    it("should return an empty string for a missing log", () => {
+      expect(readAgentLog("/nonexistent/agent-stdio.log", 0)).toBe("");
+    });
+  });
+});
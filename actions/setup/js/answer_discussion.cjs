@@ -0,0 +1,327 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { generateFooterWithMessages, generateXMLMarker } = require("./messages_footer.cjs");
+const { generateWorkflowCallIdMarker } = require("./generate_footer.cjs");
+const { getTrackerID } = require("./get_tracker_id.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { parseBoolTemplatable } = require("./templatable.cjs");
+const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
+const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { enforceCommentLimits } = require("./comment_limit_helpers.cjs");
+const { logStagedPreviewInfo } = require("./staged_preview.cjs");
+const { isPayloadUserBot } = require("./resolve_mentions.cjs");
+const { buildWorkflowRunUrl } = require("./workflow_metadata_helpers.cjs");
+const { generateHistoryUrl } = require("./generate_history_link.cjs");
+const { isCategoryAllowed } = require("./discussion_category_helpers.cjs");
+const { ERR_NOT_FOUND } = require("./error_codes.cjs");
+
+/**
+ * Get the discussion details needed to post and accept an answer
+ * @param {any} github - GitHub GraphQL instance
+ * @param {string} owner - Repository owner
+ * @param {string} repo - Repository name
+ * @param {number} discussionNumber - Discussion number
+ * @returns {Promise<{id: string, url: string, locked: boolean, author: {login: string} | null, answer: {id: string} | null, category: {id: string, name: string, slug: string, isAnswerable: boolean}}>} Discussion details
+ */
+async function getDiscussionForAnswer(github, owner, repo, discussionNumber) {
+  const result = await github.graphql(
+    `
+    query($owner: String!, $repo: String!, $num: Int!) {
+      repository(owner: $owner, name: $repo) {
+        discussion(number: $num) {
+          id
+          url
+          locked
+          author {
+            login
+          }
+          answer {
+            id
+          }
+          category {
+            id
+            name
+            slug
+            isAnswerable
+          }
+        }
+      }
+    }`,
+    { owner, repo, num: discussionNumber }
+  );
+
+  const discussion = result?.repository?.discussion;
+  if (!discussion) {
+    throw new Error(`${ERR_NOT_FOUND}: Discussion #${discussionNumber} not found in ${owner}/${repo}`);
+  }
+  return discussion;
+}
+
+/**
+ * Post a comment on a discussion
+ * @param {any} github - GitHub GraphQL instance
+ * @param {string} discussionId - Discussion node ID
+ * @param {string} body - Comment body
+ * @returns {Promise<{id: string, url: string}>} Comment details
+ */
+async function addDiscussionAnswerComment(github, discussionId, body) {
+  const result = await github.graphql(
+    `
+    mutation($dId: ID!, $body: String!) {
+      addDiscussionComment(input: { discussionId: $dId, body: $body }) {
+        comment {
+          id
+          url
+        }
+      }
+    }`,
+    { dId: discussionId, body }
+  );
+
+  return result.addDiscussionComment.comment;
+}
+
+/**
+ * Mark a discussion comment as the accepted answer
+ * @param {any} github - GitHub GraphQL instance
+ * @param {string} commentId - Discussion comment node ID
+ * @returns {Promise<void>}
+ */
+async function markCommentAsAnswer(github, commentId) {
+  await github.graphql(
+    `
+    mutation($id: ID!) {
+      markDiscussionCommentAsAnswer(input: { id: $id }) {
+        discussion {
+          id
+        }
+      }
+    }`,
+    { id: commentId }
+  );
+}
+
+/**
+ * Resolve the discussion number for an answer_discussion message from the target configuration
+ * @param {any} item - The answer_discussion message
+ * @param {string} target - Target configuration: "triggering", "*", or an explicit number
+ * @returns {{success: true, number: number} | {success: false, error: string}} Resolved discussion number
+ */
+function resolveDiscussionNumber(item, target) {
+  if (target === "*") {
+    const number = parseInt(String(item.discussion_number ?? ""), 10);
+    if (isNaN(number) || number <= 0) {
+      return { success: false, error: 'discussion_number is required when target is "*"' };
+    }
+    return { success: true, number };
+  }
+
+  if (target !== "triggering") {
+    const number = parseInt(target, 10);
+    if (isNaN(number) || number <= 0) {
+      return { success: false, error: `Invalid target discussion number: ${target}` };
+    }
+    return { success: true, number };
+  }
+
+  const contextNumber = context.payload?.discussion?.number;
+  if (!contextNumber) {
+    return { success: false, error: "Not in a discussion context; set target to \"*\" and provide discussion_number to answer other discussions" };
+  }
+  return { success: true, number: contextNumber };
+}
+
+/**
+ * Main handler factory for answer_discussion
+ * Returns a message handler function that processes individual answer_discussion messages
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  // Extract configuration
+  const target = String(config.target || "triggering");
+  const maxCount = config.max || 1;
+  const allowedCategories = Array.isArray(config.allowed_categories) ? config.allowed_categories : [];
+  const markAsAnswer = parseBoolTemplatable(config.mark_as_answer, true);
+  const includeFooter = parseBoolTemplatable(config.footer, true);
+  const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
+  const githubClient = await createAuthenticatedGitHubClient(config);
+
+  // Check if we're in staged mode
+  const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
+
+  core.info(`Answer discussion configuration: max=${maxCount}, target=${target}, mark_as_answer=${markAsAnswer}`);
+  if (allowedCategories.length > 0) {
+    core.info(`Allowed categories: ${allowedCategories.join(", ")}`);
+  }
+
+  const callerWorkflowId = process.env.GH_AW_CALLER_WORKFLOW_ID || "";
+  const workflowId = process.env.GH_AW_WORKFLOW_ID || "";
+
+  // Track how many items we've processed for max limit
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single answer_discussion message
+   * @param {Object} item - The answer_discussion message to process
+   * @param {Object} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
+   * @returns {Promise<Object>} Result with success/error status
+   */
+  return async function handleAnswerDiscussion(item, resolvedTemporaryIds) {
+    // Check if we've hit the max limit
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping answer_discussion: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    processedCount++;
+
+    // Resolve and validate target repository
+    const repoResult = resolveAndValidateRepo(item, defaultTargetRepo, allowedRepos, "discussion answer");
+    if (!repoResult.success) {
+      core.warning(`Skipping answer_discussion: ${repoResult.error}`);
+      return {
+        success: false,
+        error: repoResult.error,
+      };
+    }
+    const { repo: itemRepo, repoParts } = repoResult;
+
+    const numberResult = resolveDiscussionNumber(item, target);
+    if (!numberResult.success) {
+      core.warning(`Skipping answer_discussion: ${numberResult.error}`);
+      return {
+        success: false,
+        error: numberResult.error,
+      };
+    }
+    const discussionNumber = numberResult.number;
+
+    try {
+      const discussion = await getDiscussionForAnswer(githubClient, repoParts.owner, repoParts.repo, discussionNumber);
+
+      // Enforce the category allow-list
+      if (!isCategoryAllowed(discussion.category, allowedCategories)) {
+        const error = `Discussion #${discussionNumber} is in category "${discussion.category?.name}", which is not allowed. Allowed categories: ${allowedCategories.join(", ")}`;
+        core.warning(error);
+        return {
+          success: false,
+          error,
+        };
+      }
+
+      if (discussion.locked) {
+        const error = `Discussion #${discussionNumber} is locked`;
+        core.warning(error);
+        return {
+          success: false,
+          error,
+        };
+      }
+
+      // Sanitize the answer with the same pipeline as add_comment, allowing the discussion author
+      // to be @mentioned in the answer.
+      const allowedAliases = [];
+      if (discussion.author?.login && !isPayloadUserBot(discussion.author)) {
+        allowedAliases.push(discussion.author.login);
+      }
+      let processedBody = sanitizeContent(item.body || "", { allowedAliases });
+
+      try {
+        enforceCommentLimits(processedBody);
+      } catch (error) {
+        const errorMessage = getErrorMessage(error);
+        core.warning(`Answer validation failed: ${errorMessage}`);
+        return {
+          success: false,
+          error: errorMessage,
+        };
+      }
+
+      // Add tracker ID and footer
+      const trackerIDComment = getTrackerID("markdown");
+      if (trackerIDComment) {
+        processedBody += "\n\n" + trackerIDComment;
+      }
+
+      const workflowName = process.env.GH_AW_WORKFLOW_NAME || "Workflow";
+      const runUrl = buildWorkflowRunUrl(context, context.repo);
+      if (includeFooter) {
+        const historyUrl =
+          generateHistoryUrl({
+            owner: repoParts.owner,
+            repo: repoParts.repo,
+            itemType: "discussion_comment",
+            workflowCallId: callerWorkflowId,
+            workflowId,
+            serverUrl: context.serverUrl,
+          }) || undefined;
+        const workflowSource = process.env.GH_AW_WORKFLOW_SOURCE ?? "";
+        const workflowSourceURL = process.env.GH_AW_WORKFLOW_SOURCE_URL ?? "";
+        processedBody += generateFooterWithMessages(workflowName, runUrl, workflowSource, workflowSourceURL, undefined, undefined, discussionNumber, historyUrl).trimEnd();
+      } else {
+        processedBody += "\n\n" + generateXMLMarker(workflowName, runUrl);
+      }
+      if (callerWorkflowId) {
+        processedBody += "\n" + generateWorkflowCallIdMarker(callerWorkflowId);
+      }
+
+      // Mark as answer only in answerable (Q&A) categories
+      const canMarkAsAnswer = markAsAnswer && discussion.category?.isAnswerable === true;
+      if (markAsAnswer && !canMarkAsAnswer) {
+        core.warning(`Discussion #${discussionNumber} is in category "${discussion.category?.name}", which does not accept answers; posting a comment only`);
+      }
+      if (canMarkAsAnswer && discussion.answer) {
+        core.info(`Discussion #${discussionNumber} already has an accepted answer; it will be replaced`);
+      }
+
+      // If in staged mode, preview the answer without posting it
+      if (isStaged) {
+        logStagedPreviewInfo(`Would answer discussion #${discussionNumber} in ${itemRepo}`);
+        return {
+          success: true,
+          staged: true,
+          previewInfo: {
+            number: discussionNumber,
+            repo: itemRepo,
+            markAsAnswer: canMarkAsAnswer,
+            bodyLength: processedBody.length,
+          },
+        };
+      }
+
+      const comment = await addDiscussionAnswerComment(githubClient, discussion.id, processedBody);
+      core.info(`Posted answer on discussion #${discussionNumber}: ${comment.url}`);
+
+      if (canMarkAsAnswer) {
+        await markCommentAsAnswer(githubClient, comment.id);
+        core.info(`Marked comment as the answer to discussion #${discussionNumber}`);
+      }
+
+      return {
+        success: true,
+        number: discussionNumber,
+        repo: itemRepo,
+        url: comment.url,
+        answered: canMarkAsAnswer,
+      };
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      core.error(`Failed to answer discussion #${discussionNumber}: ${errorMessage}`);
+      return {
+        success: false,
+        error: errorMessage,
+      };
+    }
+  };
+}
+
+module.exports = { main, resolveDiscussionNumber };
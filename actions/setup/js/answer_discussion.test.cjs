@@ -0,0 +1,182 @@
+// @ts-check
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+const { main, resolveDiscussionNumber } = require("./answer_discussion.cjs");
+
+describe("answer_discussion", () => {
+  let mockCore;
+  let mockGithub;
+  let originalGlobals;
+  let originalEnv;
+  /** @type {any} */
+  let discussion;
+  /** @type {any[]} */
+  let calls;
+
+  beforeEach(() => {
+    originalGlobals = {
+      core: global.core,
+      github: global.github,
+      context: global.context,
+    };
+    originalEnv = {
+      staged: process.env.GH_AW_SAFE_OUTPUTS_STAGED,
+    };
+
+    discussion = {
+      id: "D_kwDOTest123",
+      url: "https://github.com/test-owner/test-repo/discussions/42",
+      locked: false,
+      author: { login: "asker" },
+      answer: null,
+      category: { id: "DIC_qa", name: "Q&A", slug: "q-a", isAnswerable: true },
+    };
+    calls = [];
+
+    mockCore = {
+      infos: /** @type {string[]} */ [],
+      warnings: /** @type {string[]} */ [],
+      errors: /** @type {string[]} */ [],
+      debug: () => {},
+      info: /** @param {string} msg */ msg => mockCore.infos.push(msg),
+      warning: /** @param {string} msg */ msg => mockCore.warnings.push(msg),
+      error: /** @param {string} msg */ msg => mockCore.errors.push(msg),
+      setOutput: () => {},
+      setFailed: () => {},
+    };
+
+    mockGithub = {
+      graphql: async (/** @type {string} */ query, /** @type {any} */ variables) => {
+        if (query.includes("markDiscussionCommentAsAnswer")) {
+          calls.push({ type: "mark", variables });
+          return { markDiscussionCommentAsAnswer: { discussion: { id: discussion.id } } };
+        }
+        if (query.includes("addDiscussionComment")) {
+          calls.push({ type: "comment", variables });
+          return {
+            addDiscussionComment: {
+              comment: { id: "DC_kwDOTest456", url: `${discussion.url}#discussioncomment-456` },
+            },
+          };
+        }
+        calls.push({ type: "query", variables });
+        return { repository: { discussion } };
+      },
+    };
+
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.context = {
+      repo: { owner: "test-owner", repo: "test-repo" },
+      runId: 1,
+      serverUrl: "https://github.com",
+      payload: { discussion: { number: 42 } },
+    };
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+  });
+
+  afterEach(() => {
+    global.core = originalGlobals.core;
+    global.github = originalGlobals.github;
+    global.context = originalGlobals.context;
+    if (originalEnv.staged === undefined) {
+      delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    } else {
+      process.env.GH_AW_SAFE_OUTPUTS_STAGED = originalEnv.staged;
+    }
+  });
+
+  describe("resolveDiscussionNumber", () => {
+    it("should use the triggering discussion by default", () => {
+      expect(resolveDiscussionNumber({ discussion_number: 7 }, "triggering")).toEqual({ success: true, number: 42 });
+    });
+
+    it("should require discussion_number when target is *", () => {
+      expect(resolveDiscussionNumber({ discussion_number: "7" }, "*")).toEqual({ success: true, number: 7 });
+      expect(resolveDiscussionNumber({}, "*").success).toBe(false);
+    });
+
+    it("should use an explicit target number", () => {
+      expect(resolveDiscussionNumber({}, "15")).toEqual({ success: true, number: 15 });
+    });
+  });
+
+  describe("handleAnswerDiscussion", () => {
+    it("should post the answer and mark it as accepted in Q&A categories", async () => {
+      const handler = await main({});
+      const result = await handler({ body: "Run `npm ci` before building." }, {});
+
+      expect(result.success).toBe(true);
+      expect(result.number).toBe(42);
+      expect(result.answered).toBe(true);
+      const comment = calls.find(c => c.type === "comment");
+      expect(comment.variables.dId).toBe("D_kwDOTest123");
+      expect(comment.variables.body).toContain("Run `npm ci` before building.");
+      expect(calls.find(c => c.type === "mark").variables.id).toBe("DC_kwDOTest456");
+    });
+
+    it("should post a comment without marking it in non-answerable categories", async () => {
+      discussion.category = { id: "DIC_general", name: "General", slug: "general", isAnswerable: false };
+      const handler = await main({});
+      const result = await handler({ body: "Here is some context." }, {});
+
+      expect(result.success).toBe(true);
+      expect(result.answered).toBe(false);
+      expect(calls.some(c => c.type === "mark")).toBe(false);
+      expect(mockCore.warnings.some(msg => msg.includes("does not accept answers"))).toBe(true);
+    });
+
+    it("should not mark the answer when mark_as_answer is false", async () => {
+      const handler = await main({ mark_as_answer: false });
+      const result = await handler({ body: "An answer." }, {});
+
+      expect(result.success).toBe(true);
+      expect(calls.some(c => c.type === "mark")).toBe(false);
+    });
+
+    it("should skip discussions outside the allowed categories", async () => {
+      const handler = await main({ allowed_categories: ["Ideas"] });
+      const result = await handler({ body: "An answer." }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("not allowed");
+      expect(calls.some(c => c.type === "comment")).toBe(false);
+    });
+
+    it("should skip locked discussions", async () => {
+      discussion.locked = true;
+      const handler = await main({});
+      const result = await handler({ body: "An answer." }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("locked");
+    });
+
+    it("should sanitize the answer body", async () => {
+      const handler = await main({});
+      await handler({ body: "Thanks @asker and @someone-else" }, {});
+
+      const body = calls.find(c => c.type === "comment").variables.body;
+      expect(body).toContain("@asker");
+      expect(body).toContain("`@someone-else`");
+    });
+
+    it("should enforce the max count", async () => {
+      const handler = await main({ max: 1 });
+      await handler({ body: "First answer." }, {});
+      const result = await handler({ body: "Second answer." }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("Max count of 1 reached");
+    });
+
+    it("should preview without posting in staged mode", async () => {
+      process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+      const handler = await main({});
+      const result = await handler({ body: "An answer." }, {});
+
+      expect(result.success).toBe(true);
+      expect(result.staged).toBe(true);
+      expect(calls.some(c => c.type === "comment")).toBe(false);
+    });
+  });
+});
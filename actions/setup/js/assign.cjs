@@ -0,0 +1,183 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { processItems, sanitizeItems, limitToMaxCount } = require("./safe_output_processor.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { resolveTarget } = require("./safe_output_helpers.cjs");
+const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
+const { logStagedPreviewInfo } = require("./staged_preview.cjs");
+const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
+const { normalizeTeamSlug, filterAllowedTeams, listTeamMembers } = require("./team_helpers.cjs");
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "assign";
+
+// GitHub accepts at most 10 assignees on an issue or pull request
+const MAX_ASSIGNEES = 10;
+
+/**
+ * Main handler factory for assign
+ * Returns a message handler function that processes individual assign messages
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  // Extract configuration
+  const allowedUsers = config.allowed_users ?? [];
+  const allowedTeams = (config.allowed_teams ?? []).map(normalizeTeamSlug);
+  const maxCount = config.max ?? 1;
+  const assignTarget = config.target || "triggering";
+  const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
+  const githubClient = await createAuthenticatedGitHubClient(config);
+
+  // Check if we're in staged mode
+  const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
+
+  core.info(`Assign configuration: max=${maxCount}, target=${assignTarget}`);
+  if (allowedUsers.length > 0) {
+    core.info(`Allowed users: ${allowedUsers.join(", ")}`);
+  }
+  if (allowedTeams.length > 0) {
+    core.info(`Allowed teams: ${allowedTeams.join(", ")}`);
+  }
+  core.info(`Default target repo: ${defaultTargetRepo}`);
+
+  // Track how many items we've processed for max limit
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single assign message
+   * @param {Object} message - The assign message to process
+   * @param {Object} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
+   * @returns {Promise<Object>} Result with success/error status
+   */
+  return async function handleAssign(message, resolvedTemporaryIds) {
+    // Check if we've hit the max limit
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping assign: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    processedCount++;
+
+    // Resolve and validate target repository
+    const repoResult = resolveAndValidateRepo(message, defaultTargetRepo, allowedRepos, "assignment");
+    if (!repoResult.success) {
+      core.warning(`Skipping assign: ${repoResult.error}`);
+      return {
+        success: false,
+        error: repoResult.error,
+      };
+    }
+    const { repo: itemRepo, repoParts } = repoResult;
+
+    // Determine the issue or pull request from the target configuration
+    const targetResult = resolveTarget({
+      targetConfig: assignTarget,
+      item: message,
+      context,
+      itemType: "assignment",
+      supportsPR: true,
+    });
+    if (!targetResult.success) {
+      core.warning(`Skipping assign: ${targetResult.error}`);
+      return {
+        success: false,
+        error: targetResult.error,
+      };
+    }
+    const itemNumber = targetResult.number;
+
+    // Use shared helpers to filter, sanitize, dedupe, and limit
+    const users = processItems(message.assignees ?? [], allowedUsers, MAX_ASSIGNEES);
+    const teams = filterAllowedTeams(message.teams ?? [], allowedTeams, MAX_ASSIGNEES);
+
+    if (users.length === 0 && teams.length === 0) {
+      core.info("No assignees to add");
+      return {
+        success: true,
+        number: itemNumber,
+        assigneesAdded: [],
+        message: "No valid assignees found",
+      };
+    }
+
+    // If in staged mode, preview without executing
+    if (isStaged) {
+      logStagedPreviewInfo(`Would assign users ${JSON.stringify(users)} and members of teams ${JSON.stringify(teams)} to #${itemNumber} in ${itemRepo}`);
+      return {
+        success: true,
+        staged: true,
+        previewInfo: {
+          number: itemNumber,
+          repo: itemRepo,
+          assignees: users,
+          teams,
+        },
+      };
+    }
+
+    try {
+      // GitHub only assigns users: expand the teams into their members
+      const teamMembers = [];
+      for (const team of teams) {
+        try {
+          const members = await listTeamMembers(githubClient, repoParts.owner, team);
+          core.info(`Team ${repoParts.owner}/${team} has ${members.length} member(s)`);
+          teamMembers.push(...members);
+        } catch (error) {
+          const errorMessage = `Failed to list members of team ${repoParts.owner}/${team}: ${getErrorMessage(error)}. The token needs organization members read access.`;
+          core.error(errorMessage);
+          return {
+            success: false,
+            error: errorMessage,
+          };
+        }
+      }
+
+      const assignees = limitToMaxCount(sanitizeItems([...users, ...teamMembers]), MAX_ASSIGNEES);
+      if (assignees.length === 0) {
+        core.info("The requested teams have no members to assign");
+        return {
+          success: true,
+          number: itemNumber,
+          assigneesAdded: [],
+          message: "No valid assignees found",
+        };
+      }
+
+      core.info(`Assigning ${assignees.length} user(s) to #${itemNumber} in ${itemRepo}: ${JSON.stringify(assignees)}`);
+
+      // Pull requests are issues for the assignees API
+      await githubClient.rest.issues.addAssignees({
+        owner: repoParts.owner,
+        repo: repoParts.repo,
+        issue_number: itemNumber,
+        assignees,
+      });
+
+      core.info(`Successfully assigned ${assignees.length} user(s) to #${itemNumber} in ${itemRepo}`);
+
+      return {
+        success: true,
+        number: itemNumber,
+        assigneesAdded: assignees,
+      };
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      core.error(`Failed to assign users: ${errorMessage}`);
+      return {
+        success: false,
+        error: errorMessage,
+      };
+    }
+  };
+}
+
+module.exports = { main };
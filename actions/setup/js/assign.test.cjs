@@ -0,0 +1,161 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+// Mock the global objects that GitHub Actions provides
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  notice: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+  summary: {
+    addRaw: vi.fn().mockReturnThis(),
+    write: vi.fn().mockResolvedValue(),
+  },
+};
+
+const mockGithub = {
+  paginate: vi.fn(),
+  rest: {
+    issues: {
+      addAssignees: vi.fn().mockResolvedValue({}),
+    },
+    teams: {
+      listMembersInOrg: vi.fn(),
+    },
+  },
+};
+
+const mockContext = {
+  eventName: "issues",
+  repo: {
+    owner: "testowner",
+    repo: "testrepo",
+  },
+  payload: {
+    issue: {
+      number: 42,
+    },
+  },
+};
+
+// Set up global mocks before importing the module
+global.core = mockCore;
+global.github = mockGithub;
+global.context = mockContext;
+
+describe("assign (Handler Factory Architecture)", () => {
+  let handler;
+
+  beforeEach(async () => {
+    vi.clearAllMocks();
+    global.context = mockContext;
+    mockGithub.paginate.mockResolvedValue([{ login: "member1" }, { login: "user1" }]);
+
+    const { main } = require("./assign.cjs");
+    handler = await main({
+      max: 5,
+      allowed_users: ["user1", "user2"],
+      allowed_teams: ["triage-team"],
+    });
+  });
+
+  it("should assign allowed users to the triggering issue", async () => {
+    const result = await handler({ type: "assign", assignees: ["user1", "intruder"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.number).toBe(42);
+    expect(result.assigneesAdded).toEqual(["user1"]);
+    expect(mockGithub.rest.issues.addAssignees).toHaveBeenCalledWith({
+      owner: "testowner",
+      repo: "testrepo",
+      issue_number: 42,
+      assignees: ["user1"],
+    });
+  });
+
+  it("should expand allowed teams into their members", async () => {
+    const result = await handler({ type: "assign", assignees: ["user1"], teams: ["testowner/triage-team"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.assigneesAdded).toEqual(["user1", "member1"]);
+    expect(mockGithub.paginate).toHaveBeenCalledWith(mockGithub.rest.teams.listMembersInOrg, {
+      org: "testowner",
+      team_slug: "triage-team",
+      per_page: 100,
+    });
+  });
+
+  it("should not expand teams that are not allowed", async () => {
+    const result = await handler({ type: "assign", teams: ["admins"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.assigneesAdded).toEqual([]);
+    expect(mockGithub.paginate).not.toHaveBeenCalled();
+    expect(mockGithub.rest.issues.addAssignees).not.toHaveBeenCalled();
+  });
+
+  it("should fail with a permission hint when team members cannot be listed", async () => {
+    mockGithub.paginate.mockRejectedValueOnce(new Error("Resource not accessible by integration"));
+
+    const result = await handler({ type: "assign", teams: ["triage-team"] }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("organization members read access");
+    expect(mockGithub.rest.issues.addAssignees).not.toHaveBeenCalled();
+  });
+
+  it("should assign pull requests in pull request context", async () => {
+    global.context = { ...mockContext, eventName: "pull_request", payload: { pull_request: { number: 7 } } };
+
+    const result = await handler({ type: "assign", assignees: ["user2"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.number).toBe(7);
+  });
+
+  it("should use the item number from the message when target is *", async () => {
+    const { main } = require("./assign.cjs");
+    const anyHandler = await main({ target: "*" });
+
+    const result = await anyHandler({ type: "assign", assignees: ["anyone"], item_number: 99 }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.number).toBe(99);
+    expect(result.assigneesAdded).toEqual(["anyone"]);
+  });
+
+  it("should enforce max count limit", async () => {
+    const { main } = require("./assign.cjs");
+    const limitedHandler = await main({});
+
+    expect((await limitedHandler({ type: "assign", assignees: ["user1"] }, {})).success).toBe(true);
+    const result = await limitedHandler({ type: "assign", assignees: ["user2"] }, {});
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Max count");
+  });
+
+  it("should preview in staged mode without calling API", async () => {
+    const originalEnv = process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+
+    try {
+      const { main } = require("./assign.cjs");
+      const stagedHandler = await main({ allowed_teams: ["triage-team"] });
+
+      const result = await stagedHandler({ type: "assign", teams: ["triage-team"] }, {});
+
+      expect(result.success).toBe(true);
+      expect(result.staged).toBe(true);
+      expect(result.previewInfo.teams).toEqual(["triage-team"]);
+      expect(mockGithub.paginate).not.toHaveBeenCalled();
+    } finally {
+      if (originalEnv === undefined) {
+        delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+      } else {
+        process.env.GH_AW_SAFE_OUTPUTS_STAGED = originalEnv;
+      }
+    }
+  });
+});
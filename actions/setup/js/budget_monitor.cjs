@@ -0,0 +1,224 @@
+// @ts-check
+
+const fs = require("fs");
+const path = require("path");
+const { execFileSync } = require("child_process");
+
+/**
+ * Budget monitor for agentic workflows (limits: in frontmatter).
+ *
+ * Runs in the background next to the agent execution step, periodically reads
+ * the agent logs and stops the agent once the configured token or cost budget
+ * is exceeded. The reason is written to GH_AW_BUDGET_REPORT so that the log
+ * parser can surface it in the step summary and fail the job.
+ *
+ * Environment:
+ *  - GH_AW_ENGINE_ID: engine identifier (claude, copilot, codex, gemini)
+ *  - GH_AW_AGENT_OUTPUT: agent log file or directory to watch
+ *  - GH_AW_BUDGET_REPORT: path of the report written when a limit is exceeded
+ *  - GH_AW_AGENT_PID_FILE: file with the PID of the shell running the engine command
+ *  - GH_AW_MAX_TOKENS: maximum total tokens (optional)
+ *  - GH_AW_MAX_COST_USD: maximum cost in USD (optional)
+ */
+
+const POLL_INTERVAL_MS = 5000;
+
+/**
+ * Extracts token usage and cost from agent log content.
+ * Supports stream-json usage records (Claude, Copilot), OpenAI-style usage
+ * records and Codex token count lines.
+ *
+ * @param {string} content - Agent log content
+ * @returns {{tokens: number, costUSD: number}} Usage totals
+ */
+function extractUsage(content) {
+  let tokens = 0;
+  let costUSD = 0;
+
+  for (const line of content.split("\n")) {
+    const trimmed = line.trim();
+    if (!trimmed.startsWith("{")) {
+      continue;
+    }
+    let entry;
+    try {
+      entry = JSON.parse(trimmed);
+    } catch {
+      continue;
+    }
+    if (!entry || typeof entry !== "object") {
+      continue;
+    }
+
+    if (typeof entry.total_cost_usd === "number") {
+      costUSD = Math.max(costUSD, entry.total_cost_usd);
+    }
+
+    // The final result record repeats the totals of the assistant messages
+    if (entry.type === "result") {
+      continue;
+    }
+
+    const usage = entry.usage || entry.message?.usage;
+    if (usage && typeof usage === "object") {
+      tokens += (usage.input_tokens || 0) + (usage.output_tokens || 0);
+      tokens += (usage.prompt_tokens || 0) + (usage.completion_tokens || 0);
+    }
+  }
+
+  // Codex reports running totals: TokenCount(TokenCountEvent { ... total_tokens: 13281 ...
+  for (const match of content.matchAll(/total_tokens:\s*(\d+)/g)) {
+    tokens = Math.max(tokens, parseInt(match[1], 10));
+  }
+  const finalTokensMatch = content.match(/tokens used\n([\d,]+)/);
+  if (finalTokensMatch) {
+    tokens = Math.max(tokens, parseInt(finalTokensMatch[1].replace(/,/g, ""), 10));
+  }
+
+  return { tokens, costUSD };
+}
+
+/**
+ * Checks usage against the configured limits.
+ *
+ * @param {{tokens: number, costUSD: number}} usage - Current usage
+ * @param {{maxTokens?: number, maxCostUSD?: number}} limits - Configured limits
+ * @returns {string|null} Reason the budget was exceeded, or null when within limits
+ */
+function checkLimits(usage, limits) {
+  if (limits.maxTokens && usage.tokens > limits.maxTokens) {
+    return `max-tokens limit of ${limits.maxTokens} exceeded (${usage.tokens} tokens used)`;
+  }
+  if (limits.maxCostUSD && usage.costUSD > limits.maxCostUSD) {
+    return `max-cost-usd limit of $${limits.maxCostUSD} exceeded ($${usage.costUSD.toFixed(4)} spent)`;
+  }
+  return null;
+}
+
+/**
+ * Reads the agent log content from a file or all .log/.txt files in a directory.
+ *
+ * @param {string} logPath - Log file or directory
+ * @returns {string} Log content (empty when not available yet)
+ */
+function readAgentLogs(logPath) {
+  if (!fs.existsSync(logPath)) {
+    return "";
+  }
+  if (!fs.statSync(logPath).isDirectory()) {
+    return fs.readFileSync(logPath, "utf8");
+  }
+  return fs
+    .readdirSync(logPath)
+    .filter(file => file.endsWith(".log") || file.endsWith(".txt"))
+    .sort()
+    .map(file => fs.readFileSync(path.join(logPath, file), "utf8"))
+    .join("\n");
+}
+
+/**
+ * Stops the agent by signaling the processes started by the agent execution
+ * step, whose shell PID is recorded in the PID file. Only the children of that
+ * shell are signaled, never other processes of the runner. The agent runs
+ * either inside the firewall (awf, started with sudo) or directly as the engine
+ * CLI, so sudo is tried first to reach root-owned processes.
+ *
+ * @param {string} pidFile - File with the PID of the agent step shell
+ * @returns {boolean} Whether a PID was found to signal
+ */
+function stopAgent(pidFile) {
+  let pid = NaN;
+  try {
+    pid = parseInt(fs.readFileSync(pidFile, "utf8").trim(), 10);
+  } catch {
+    // The agent step has not started yet
+  }
+  if (!Number.isInteger(pid) || pid <= 1) {
+    console.log(`Budget monitor: no agent PID in ${pidFile}, cannot stop agent`);
+    return false;
+  }
+
+  const attempts = [
+    ["sudo", ["-n", "pkill", "-TERM", "-P", String(pid)]],
+    ["pkill", ["-TERM", "-P", String(pid)]],
+  ];
+  for (const [command, args] of attempts) {
+    try {
+      execFileSync(command, args, { stdio: "ignore" });
+      return true;
+    } catch {
+      // sudo is unavailable, or pkill exits non-zero when no process matched
+    }
+  }
+  return true;
+}
+
+/**
+ * Polls the agent logs until a limit is exceeded.
+ */
+function main() {
+  const logPath = process.env.GH_AW_AGENT_OUTPUT || "";
+  const reportPath = process.env.GH_AW_BUDGET_REPORT || "/tmp/gh-aw/budget-exceeded.json";
+  const engineId = process.env.GH_AW_ENGINE_ID || "agent";
+  const pidFile = process.env.GH_AW_AGENT_PID_FILE || "/tmp/gh-aw/agent.pid";
+  const limits = {
+    maxTokens: parseInt(process.env.GH_AW_MAX_TOKENS || "0", 10) || 0,
+    maxCostUSD: parseFloat(process.env.GH_AW_MAX_COST_USD || "0") || 0,
+  };
+
+  if (!logPath || (!limits.maxTokens && !limits.maxCostUSD)) {
+    console.log("Budget monitor: no limits configured");
+    return;
+  }
+
+  console.log(`Budget monitor: watching ${engineId} logs in ${logPath} (max tokens: ${limits.maxTokens || "none"}, max cost: ${limits.maxCostUSD ? "$" + limits.maxCostUSD : "none"})`);
+
+  const timer = setInterval(() => {
+    let usage;
+    try {
+      usage = extractUsage(readAgentLogs(logPath));
+    } catch (error) {
+      console.log(`Budget monitor: failed to read logs: ${error instanceof Error ? error.message : String(error)}`);
+      return;
+    }
+
+    const reason = checkLimits(usage, limits);
+    if (!reason) {
+      return;
+    }
+
+    clearInterval(timer);
+    console.log(`Budget monitor: ${reason}, stopping agent`);
+    fs.mkdirSync(path.dirname(reportPath), { recursive: true });
+    fs.writeFileSync(
+      reportPath,
+      JSON.stringify(
+        {
+          reason,
+          tokens: usage.tokens,
+          cost_usd: usage.costUSD,
+          limits: { max_tokens: limits.maxTokens || null, max_cost_usd: limits.maxCostUSD || null },
+        },
+        null,
+        2
+      )
+    );
+    stopAgent(pidFile);
+  }, POLL_INTERVAL_MS);
+}
+
+// Export for testing
+if (typeof module !== "undefined" && module.exports) {
+  module.exports = {
+    extractUsage,
+    checkLimits,
+    readAgentLogs,
+    stopAgent,
+    main,
+  };
+}
+
+// Run main if called directly
+if (require.main === module) {
+  main();
+}
@@ -0,0 +1,93 @@
+import { describe, it, expect, afterEach } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+const { extractUsage, checkLimits, readAgentLogs, stopAgent } = require("./budget_monitor.cjs");
+
+describe("budget_monitor.cjs", () => {
+  describe("extractUsage", () => {
+    it("should sum usage from stream-json assistant messages", () => {
+      const content = [
+        JSON.stringify({ type: "system", subtype: "init" }),
+        JSON.stringify({ type: "assistant", message: { usage: { input_tokens: 100, output_tokens: 50 } } }),
+        JSON.stringify({ type: "assistant", message: { usage: { input_tokens: 200, output_tokens: 25 } } }),
+        JSON.stringify({ type: "result", usage: { input_tokens: 300, output_tokens: 75 }, total_cost_usd: 0.42 }),
+      ].join("\n");
+
+      expect(extractUsage(content)).toEqual({ tokens: 375, costUSD: 0.42 });
+    });
+
+    it("should sum OpenAI-style usage records", () => {
+      const content = [JSON.stringify({ usage: { prompt_tokens: 10, completion_tokens: 5 } }), JSON.stringify({ usage: { prompt_tokens: 20, completion_tokens: 5 } })].join("\n");
+
+      expect(extractUsage(content).tokens).toBe(40);
+    });
+
+    it("should use the highest codex token count", () => {
+      const content = "TokenCount(TokenCountEvent { total_tokens: 1200 })\nTokenCount(TokenCountEvent { total_tokens: 5400 })\ntokens used\n5,500\n";
+
+      expect(extractUsage(content).tokens).toBe(5500);
+    });
+
+    it("should ignore non-JSON lines", () => {
+      expect(extractUsage("starting agent\n{not json\n")).toEqual({ tokens: 0, costUSD: 0 });
+    });
+  });
+
+  describe("checkLimits", () => {
+    it("should return null when within limits", () => {
+      expect(checkLimits({ tokens: 100, costUSD: 0.1 }, { maxTokens: 1000, maxCostUSD: 1 })).toBeNull();
+    });
+
+    it("should report exceeded token limit", () => {
+      expect(checkLimits({ tokens: 1500, costUSD: 0 }, { maxTokens: 1000 })).toBe("max-tokens limit of 1000 exceeded (1500 tokens used)");
+    });
+
+    it("should report exceeded cost limit", () => {
+      expect(checkLimits({ tokens: 10, costUSD: 5.5 }, { maxTokens: 1000, maxCostUSD: 5 })).toBe("max-cost-usd limit of $5 exceeded ($5.5000 spent)");
+    });
+  });
+
+  describe("readAgentLogs", () => {
+    let tmpDir;
+
+    afterEach(() => {
+      if (tmpDir) {
+        fs.rmSync(tmpDir, { recursive: true, force: true });
+      }
+    });
+
+    it("should return empty content when the log does not exist yet", () => {
+      expect(readAgentLogs("/non/existent/agent.log")).toBe("");
+    });
+
+    it("should concatenate log files from a directory", () => {
+      tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "budget-monitor-"));
+      fs.writeFileSync(path.join(tmpDir, "b.log"), "second");
+      fs.writeFileSync(path.join(tmpDir, "a.log"), "first");
+      fs.writeFileSync(path.join(tmpDir, "ignored.json"), "{}");
+
+      expect(readAgentLogs(tmpDir)).toBe("first\nsecond");
+    });
+  });
+
+  describe("stopAgent", () => {
+    let tmpDir;
+
+    afterEach(() => {
+      if (tmpDir) {
+        fs.rmSync(tmpDir, { recursive: true, force: true });
+      }
+    });
+
+    it("should not signal anything without an agent PID", () => {
+      expect(stopAgent("/non/existent/agent.pid")).toBe(false);
+
+      tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "budget-monitor-"));
+      const pidFile = path.join(tmpDir, "agent.pid");
+      fs.writeFileSync(pidFile, "1\n");
+      expect(stopAgent(pidFile)).toBe(false);
+    });
+  });
+});
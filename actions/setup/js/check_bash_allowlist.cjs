@@ -0,0 +1,264 @@
+// @ts-check
+
+/**
+ * Check Bash Allow-list
+ *
+ * Claude Code PreToolUse hook that enforces bash allow-list entries with argument
+ * patterns, such as "git push origin agent/*". Claude's own permission rules only
+ * match command prefixes, so the compiler grants the literal prefix of each pattern
+ * ("git push origin") and this hook rejects commands under that prefix that do not
+ * match one of the allow-list entries.
+ *
+ * The hook reads the tool call from stdin ({"tool_name": "Bash", "tool_input": {"command": "..."}})
+ * and the allow-list from the JSON file given as first argument. It exits with code 2
+ * and a reason on stderr to block a command; Claude reports the reason to the agent.
+ *
+ * Matching:
+ * - Commands and entries are split into words; quotes are removed
+ * - In a word, "*" matches any characters and "?" a single character, except ":"
+ * - A wildcard never matches a leading "+", so git refspecs such as "+agent/x:main"
+ *   cannot slip through a branch pattern such as "agent/*"
+ * - A final "*" word matches any remaining arguments
+ * - Entries ending with ":*" allow every command starting with the words before it
+ * - Each command of a list or pipeline (;, &&, ||, |, &) is checked separately
+ * - Commands under a pattern prefix may not contain $ expansions or backticks
+ */
+
+const fs = require("fs");
+
+/**
+ * @typedef {Object} CommandSegment
+ * @property {string[]} words - Words of the command, quotes removed
+ * @property {boolean} hasExpansion - Whether the command contains $ expansions or backticks
+ * @property {string} text - Command text
+ */
+
+/**
+ * Split a command line into commands and words, like a (simplified) shell
+ * @param {string} commandLine - Command line
+ * @returns {CommandSegment[]} Commands separated by ;, &, | and newlines
+ */
+function splitCommandLine(commandLine) {
+  /** @type {CommandSegment[]} */
+  const segments = [];
+  /** @type {string[]} */
+  let words = [];
+  let word = "";
+  let inWord = false;
+  let hasExpansion = false;
+  let text = "";
+  /** @type {string | null} */
+  let quote = null;
+
+  const endWord = () => {
+    if (inWord) {
+      words.push(word);
+    }
+    word = "";
+    inWord = false;
+  };
+  const endSegment = () => {
+    endWord();
+    if (words.length > 0) {
+      segments.push({ words, hasExpansion, text: text.trim() });
+    }
+    words = [];
+    hasExpansion = false;
+    text = "";
+  };
+
+  for (let i = 0; i < commandLine.length; i++) {
+    const ch = commandLine[i];
+    if (quote === "'") {
+      text += ch;
+      if (ch === "'") {
+        quote = null;
+      } else {
+        word += ch;
+      }
+      continue;
+    }
+    if (ch === "\\" && i + 1 < commandLine.length) {
+      text += ch + commandLine[i + 1];
+      word += commandLine[++i];
+      inWord = true;
+      continue;
+    }
+    if (ch === "$" || ch === "`") {
+      hasExpansion = true;
+    }
+    if (quote === '"') {
+      text += ch;
+      if (ch === '"') {
+        quote = null;
+      } else {
+        word += ch;
+      }
+      continue;
+    }
+    if (ch === "'" || ch === '"') {
+      text += ch;
+      quote = ch;
+      inWord = true;
+      continue;
+    }
+    if (ch === ";" || ch === "&" || ch === "|" || ch === "\n") {
+      endSegment();
+      continue;
+    }
+    text += ch;
+    if (ch === " " || ch === "\t") {
+      endWord();
+      continue;
+    }
+    word += ch;
+    inWord = true;
+  }
+  endSegment();
+
+  return segments;
+}
+
+/**
+ * Convert a glob word to an anchored regular expression. Wildcards do not match ":"
+ * and a word only starts with "+" when the glob does.
+ * @param {string} glob - Word with * and ? wildcards
+ * @returns {RegExp}
+ */
+function globToRegExp(glob) {
+  const source = glob
+    .split("")
+    .map(ch => (ch === "*" ? "[^:]*" : ch === "?" ? "[^:]" : ch.replace(/[.+^${}()|[\]\\]/g, "\\$&")))
+    .join("");
+  const noLeadingPlus = glob.startsWith("+") ? "" : "(?!\\+)";
+  return new RegExp(`^${noLeadingPlus}${source}$`, "s");
+}
+
+/**
+ * @param {string} word
+ * @returns {boolean} Whether the word contains a wildcard
+ */
+function hasWildcard(word) {
+  return word.includes("*") || word.includes("?");
+}
+
+/**
+ * Check whether command words match pattern words
+ * @param {string[]} patternWords - Words of the allow-list entry
+ * @param {string[]} words - Words of the command
+ * @returns {boolean}
+ */
+function matchesPattern(patternWords, words) {
+  const last = patternWords.length - 1;
+  if (patternWords[last] === "*") {
+    if (words.length < last) {
+      return false;
+    }
+    return patternWords.slice(0, last).every((p, i) => globToRegExp(p).test(words[i]));
+  }
+  return words.length === patternWords.length && patternWords.every((p, i) => globToRegExp(p).test(words[i]));
+}
+
+/**
+ * @param {string[]} prefix
+ * @param {string[]} words
+ * @returns {boolean} Whether words start with prefix
+ */
+function startsWithWords(prefix, words) {
+  return prefix.length <= words.length && prefix.every((p, i) => p === words[i]);
+}
+
+/**
+ * @typedef {Object} AllowListEntry
+ * @property {"prefix" | "pattern" | "exact"} kind - How the entry matches
+ * @property {string[]} words - Words of the entry (without the trailing ":*" for prefixes)
+ * @property {string[]} literalPrefix - Leading words without wildcards (patterns only)
+ */
+
+/**
+ * Parse the bash allow-list entries
+ * @param {string[]} entries - Allow-list entries from the bash tool configuration
+ * @returns {AllowListEntry[]}
+ */
+function parseAllowList(entries) {
+  return entries.map(entry => {
+    if (entry.endsWith(":*") && !hasWildcard(entry.slice(0, -2))) {
+      return { kind: "prefix", words: splitCommandLine(entry.slice(0, -2)).flatMap(s => s.words), literalPrefix: [] };
+    }
+    const words = splitCommandLine(entry).flatMap(s => s.words);
+    // The literal prefix ends at the first word with a wildcard or quote, like the
+    // Bash(<prefix>:*) rule the compiler grants for the pattern
+    const rawWords = entry.trim().split(/\s+/);
+    const end = rawWords.findIndex(w => /[*?"']/.test(w));
+    if (end > 0 && words.some(hasWildcard)) {
+      return { kind: "pattern", words, literalPrefix: rawWords.slice(0, end) };
+    }
+    return { kind: "exact", words, literalPrefix: [] };
+  });
+}
+
+/**
+ * Check a command line against the allow-list
+ * @param {string} commandLine - Bash command requested by the agent
+ * @param {string[]} entries - Allow-list entries
+ * @returns {string | null} Reason the command is blocked, or null when it is allowed
+ */
+function checkCommand(commandLine, entries) {
+  const allowList = parseAllowList(entries);
+  const patterns = allowList.filter(e => e.kind === "pattern");
+
+  for (const segment of splitCommandLine(commandLine)) {
+    const governing = patterns.filter(p => startsWithWords(p.literalPrefix, segment.words));
+    if (governing.length === 0) {
+      // Not restricted by an argument pattern; Claude's permission rules apply
+      continue;
+    }
+    const allowed = governing.map(p => p.words.join(" ")).join(", ");
+    if (segment.hasExpansion) {
+      return `Command "${segment.text}" uses $ expansions or backticks, which are not allowed for commands restricted by argument patterns (allowed: ${allowed})`;
+    }
+    const permitted = allowList.some(entry => {
+      switch (entry.kind) {
+        case "prefix":
+          return startsWithWords(entry.words, segment.words);
+        case "pattern":
+          return matchesPattern(entry.words, segment.words);
+        default:
+          return entry.words.length === segment.words.length && startsWithWords(entry.words, segment.words);
+      }
+    });
+    if (!permitted) {
+      return `Command "${segment.text}" is not allowed by the bash allow-list (allowed: ${allowed})`;
+    }
+  }
+  return null;
+}
+
+function main() {
+  const allowListPath = process.argv[2];
+  /** @type {string[]} */
+  const entries = JSON.parse(fs.readFileSync(allowListPath, "utf8"));
+  const input = JSON.parse(fs.readFileSync(0, "utf8") || "{}");
+  const command = input?.tool_input?.command;
+  if (input?.tool_name !== "Bash" || typeof command !== "string") {
+    return;
+  }
+
+  const reason = checkCommand(command, entries);
+  if (reason) {
+    process.stderr.write(reason + "\n");
+    process.exitCode = 2;
+  }
+}
+
+if (require.main === module) {
+  try {
+    main();
+  } catch (error) {
+    // Fail closed: a broken allow-list must not let restricted commands through
+    process.stderr.write(`Failed to check the bash allow-list: ${error instanceof Error ? error.message : String(error)}\n`);
+    process.exitCode = 2;
+  }
+}
+
+module.exports = { splitCommandLine, matchesPattern, parseAllowList, checkCommand };
@@ -0,0 +1,79 @@
+import { describe, it, expect } from "vitest";
+
+const { splitCommandLine, matchesPattern, parseAllowList, checkCommand } = require("./check_bash_allowlist.cjs");
+
+describe("check_bash_allowlist.cjs", () => {
+  describe("splitCommandLine", () => {
+    it("should split lists and pipelines into commands and remove quotes", () => {
+      const segments = splitCommandLine(`git add . && git commit -m "fix: a b" | cat; echo 'x $y'`);
+      expect(segments.map(s => s.words)).toEqual([["git", "add", "."], ["git", "commit", "-m", "fix: a b"], ["cat"], ["echo", "x $y"]]);
+      expect(segments.map(s => s.hasExpansion)).toEqual([false, false, false, false]);
+    });
+
+    it("should flag expansions outside single quotes", () => {
+      expect(splitCommandLine(`git push origin "agent/$(id)"`)[0].hasExpansion).toBe(true);
+      expect(splitCommandLine("git push origin `id`")[0].hasExpansion).toBe(true);
+    });
+  });
+
+  describe("parseAllowList", () => {
+    it("should classify entries", () => {
+      expect(parseAllowList(["git add:*", "git push origin agent/*", "git status"])).toEqual([
+        { kind: "prefix", words: ["git", "add"], literalPrefix: [] },
+        { kind: "pattern", words: ["git", "push", "origin", "agent/*"], literalPrefix: ["git", "push", "origin"] },
+        { kind: "exact", words: ["git", "status"], literalPrefix: [] },
+      ]);
+    });
+
+    it("should treat a trailing :* as a prefix rule, not a pattern", () => {
+      expect(parseAllowList(["npm run test:*"])).toEqual([{ kind: "prefix", words: ["npm", "run", "test"], literalPrefix: [] }]);
+      expect(checkCommand("npm run test --watch", ["npm run test:*", "git push origin agent/*"])).toBeNull();
+    });
+  });
+
+  describe("matchesPattern", () => {
+    it("should match wildcards within words", () => {
+      expect(matchesPattern(["npm", "run", "test:*"], ["npm", "run", "test:unit"])).toBe(true);
+      expect(matchesPattern(["npm", "run", "test:*"], ["npm", "run", "build"])).toBe(false);
+      expect(matchesPattern(["npm", "run", "test:*"], ["npm", "run", "test:unit", "--watch"])).toBe(false);
+    });
+
+    it("should not match refspec syntax with wildcards", () => {
+      expect(matchesPattern(["git", "push", "origin", "agent/*"], ["git", "push", "origin", "agent/x:main"])).toBe(false);
+      expect(matchesPattern(["git", "push", "origin", "*/x"], ["git", "push", "origin", "+agent/x"])).toBe(false);
+      expect(matchesPattern(["git", "push", "origin", "agent/?"], ["git", "push", "origin", "agent/:"])).toBe(false);
+    });
+
+    it("should match any remaining arguments with a final * word", () => {
+      expect(matchesPattern(["echo", "*"], ["echo"])).toBe(true);
+      expect(matchesPattern(["echo", "*"], ["echo", "a", "b"])).toBe(true);
+    });
+  });
+
+  describe("checkCommand", () => {
+    const entries = ["git push origin agent/*", "npm run test:*", "git add:*", "git status"];
+
+    it("should allow commands matching a pattern", () => {
+      expect(checkCommand("git push origin agent/fix-1", entries)).toBeNull();
+      expect(checkCommand("npm run test:unit", entries)).toBeNull();
+      expect(checkCommand("git add . && git push origin agent/fix-1", entries)).toBeNull();
+    });
+
+    it("should block other commands under a pattern prefix", () => {
+      expect(checkCommand("git push origin main", entries)).toBe('Command "git push origin main" is not allowed by the bash allow-list (allowed: git push origin agent/*)');
+      expect(checkCommand("git status; git push origin agent/x --force", entries)).toContain("is not allowed by the bash allow-list");
+      expect(checkCommand("npm run build", entries)).toContain("allowed: npm run test:*");
+      expect(checkCommand("git push origin agent/x:main", entries)).toContain("is not allowed by the bash allow-list");
+      expect(checkCommand("git push origin +agent/x:main", entries)).toContain("is not allowed by the bash allow-list");
+    });
+
+    it("should block expansions in restricted commands", () => {
+      expect(checkCommand('git push origin "agent/$(git rev-parse HEAD)"', entries)).toContain("uses $ expansions or backticks");
+    });
+
+    it("should leave commands without a pattern to Claude's permission rules", () => {
+      expect(checkCommand("git status", entries)).toBeNull();
+      expect(checkCommand("rm -rf $HOME", entries)).toBeNull();
+    });
+  });
+});
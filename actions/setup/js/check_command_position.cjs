@@ -2,6 +2,18 @@
 /// <reference types="@actions/github-script" />
 
 const { ERR_API, ERR_CONFIG, ERR_VALIDATION } = require("./error_codes.cjs");
+const { sanitizeIncomingText } = require("./sanitize_incoming_text.cjs");
+
+/**
+ * Extract the arguments that follow a slash command on its line
+ * @param {string} text - Triggering text that starts with the command
+ * @param {string} command - Matched command including the leading slash
+ * @returns {string} Sanitized arguments, or an empty string when there are none
+ */
+function extractCommandArgs(text, command) {
+  const firstLine = text.slice(command.length).split(/\r?\n/)[0];
+  return sanitizeIncomingText(firstLine.trim());
+}
 
 /**
  * Check if command is the first word in the triggering text
@@ -58,6 +70,7 @@ async function main() {
       core.info(`Event ${eventName} does not require command position check`);
       core.setOutput("command_position_ok", "true");
       core.setOutput("matched_command", "");
+      core.setOutput("command_args", "");
       return;
     }
 
@@ -80,18 +93,22 @@ async function main() {
     }
 
     if (matchedCommand) {
+      const commandArgs = extractCommandArgs(trimmedText, `/${matchedCommand}`);
       core.info(`✓ Command '/${matchedCommand}' matched at the start of the text`);
+      core.info(`Command arguments: ${commandArgs || "(none)"}`);
       core.setOutput("command_position_ok", "true");
       core.setOutput("matched_command", matchedCommand);
+      core.setOutput("command_args", commandArgs);
     } else {
       const expectedCommands = commands.map(c => `/${c}`).join(", ");
       core.warning(`⚠️ None of the commands [${expectedCommands}] matched the first word (found: '${firstWord}'). Workflow will be skipped.`);
       core.setOutput("command_position_ok", "false");
       core.setOutput("matched_command", "");
+      core.setOutput("command_args", "");
     }
   } catch (error) {
     core.setFailed(`${ERR_API}: ${getErrorMessage(error)}`);
   }
 }
 
-module.exports = { main };
+module.exports = { main, extractCommandArgs };
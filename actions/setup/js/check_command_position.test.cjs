@@ -114,5 +114,27 @@ const mockCore = {
           (mockContext.payload = { comment: { body: "/discuss-bot analyze this" } }),
           await eval(`(async () => { ${checkCommandPositionScript}; await main(); })()`),
           expect(mockCore.setOutput).toHaveBeenCalledWith("command_position_ok", "true"));
+      }),
+      it("should expose the arguments on the command line", async () => {
+        ((process.env.GH_AW_COMMANDS = JSON.stringify(["summarize"])),
+          (mockContext.eventName = "issue_comment"),
+          (mockContext.payload = { comment: { body: "/summarize  last 10 comments\nPlease keep it short." } }),
+          await eval(`(async () => { ${checkCommandPositionScript}; await main(); })()`),
+          expect(mockCore.setOutput).toHaveBeenCalledWith("matched_command", "summarize"),
+          expect(mockCore.setOutput).toHaveBeenCalledWith("command_args", "last 10 comments"));
+      }),
+      it("should sanitize command arguments", async () => {
+        ((process.env.GH_AW_COMMANDS = JSON.stringify(["summarize"])),
+          (mockContext.eventName = "issue_comment"),
+          (mockContext.payload = { comment: { body: "/summarize for @octocat" } }),
+          await eval(`(async () => { ${checkCommandPositionScript}; await main(); })()`),
+          expect(mockCore.setOutput).toHaveBeenCalledWith("command_args", "for `@octocat`"));
+      }),
+      it("should set empty arguments when the command has none", async () => {
+        ((process.env.GH_AW_COMMANDS = JSON.stringify(["summarize"])),
+          (mockContext.eventName = "issue_comment"),
+          (mockContext.payload = { comment: { body: "/summarize\nDetails below" } }),
+          await eval(`(async () => { ${checkCommandPositionScript}; await main(); })()`),
+          expect(mockCore.setOutput).toHaveBeenCalledWith("command_args", ""));
       }));
   }));
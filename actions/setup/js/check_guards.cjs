@@ -0,0 +1,114 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/**
+ * Spam and abuse guards for issue, pull request, discussion and comment triggers.
+ *
+ * Environment variables:
+ * - GH_AW_GUARD_BLOCKED_USERS: comma-separated logins that may never trigger the workflow
+ * - GH_AW_GUARD_ASSOCIATIONS: comma-separated author associations allowed to trigger the workflow
+ *   (owner, member, collaborator, contributor, first-time-contributor, first-timer, none)
+ * - GH_AW_GUARD_MIN_ACCOUNT_AGE: minimum age of the actor's GitHub account in days
+ *
+ * Sets guards_ok to "true" when the workflow may proceed and "false" otherwise.
+ * Other events (schedule, workflow_dispatch, ...) are not guarded.
+ */
+
+/** Payload properties holding the content that triggered each guarded event */
+const GUARDED_EVENT_CONTENT = {
+  issues: ["issue"],
+  issue_comment: ["comment"],
+  pull_request: ["pull_request"],
+  pull_request_target: ["pull_request"],
+  pull_request_review: ["review"],
+  pull_request_review_comment: ["comment"],
+  discussion: ["discussion"],
+  discussion_comment: ["comment"],
+};
+
+/**
+ * @param {string | undefined} value - Comma-separated list
+ * @returns {string[]} Lower-case, trimmed entries
+ */
+function parseList(value) {
+  return (value || "")
+    .split(",")
+    .map(item => item.trim().toLowerCase())
+    .filter(Boolean);
+}
+
+/**
+ * Converts a payload author_association (e.g. FIRST_TIME_CONTRIBUTOR) to the frontmatter form
+ * @param {string} association
+ * @returns {string}
+ */
+function normalizeAssociation(association) {
+  return association.toLowerCase().replace(/_/g, "-");
+}
+
+/**
+ * @param {string} reason - Why the workflow is skipped
+ */
+function reject(reason) {
+  core.info(`❌ ${reason}. Workflow will be skipped.`);
+  core.setOutput("guards_ok", "false");
+  core.setOutput("error_message", `Workflow skipped: ${reason}`);
+}
+
+async function main() {
+  const { eventName, actor, payload } = context;
+  const contentKeys = GUARDED_EVENT_CONTENT[/** @type {keyof typeof GUARDED_EVENT_CONTENT} */ (eventName)];
+  if (!contentKeys) {
+    core.info(`✅ Event '${eventName}' is not guarded, workflow will proceed`);
+    core.setOutput("guards_ok", "true");
+    return;
+  }
+
+  const content = contentKeys.map(key => payload?.[key]).find(Boolean);
+  const author = content?.user?.login || actor;
+  core.info(`Checking guards for actor '${actor}' (author '${author}') on event '${eventName}'`);
+
+  // Block-list: matches the actor and the author of the triggering content
+  const blockedUsers = parseList(process.env.GH_AW_GUARD_BLOCKED_USERS);
+  const blocked = [actor, author].find(login => login && blockedUsers.includes(login.toLowerCase()));
+  if (blocked) {
+    reject(`User '${blocked}' is blocked`);
+    return;
+  }
+
+  // Association: read from the triggering content. When the actor is not its author (for
+  // example when labeling someone else's issue) the actor already needed triage access.
+  const associations = parseList(process.env.GH_AW_GUARD_ASSOCIATIONS);
+  if (associations.length > 0 && author.toLowerCase() === actor.toLowerCase()) {
+    const association = normalizeAssociation(content?.author_association || "NONE");
+    if (!associations.includes(association)) {
+      reject(`User '${actor}' has association '${association}', required: ${associations.join(", ")}`);
+      return;
+    }
+    core.info(`✅ Association '${association}' is allowed`);
+  }
+
+  // Minimum account age
+  const minAccountAge = parseInt(process.env.GH_AW_GUARD_MIN_ACCOUNT_AGE || "0", 10);
+  if (minAccountAge > 0) {
+    try {
+      const { data: user } = await github.rest.users.getByUsername({ username: actor });
+      const ageDays = (Date.now() - new Date(user.created_at).getTime()) / (24 * 60 * 60 * 1000);
+      if (ageDays < minAccountAge) {
+        reject(`Account '${actor}' is ${Math.floor(ageDays)} days old, minimum is ${minAccountAge} days`);
+        return;
+      }
+      core.info(`✅ Account '${actor}' is ${Math.floor(ageDays)} days old`);
+    } catch (error) {
+      // Fail open like the rate limit check so an API hiccup does not block legitimate users
+      core.warning(`⚠️ Could not check account age of '${actor}': ${getErrorMessage(error)}`);
+    }
+  }
+
+  core.info("✅ All guards passed, workflow will proceed");
+  core.setOutput("guards_ok", "true");
+}
+
+module.exports = { main };
@@ -0,0 +1,125 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+describe("check_guards.cjs", () => {
+  let mockCore;
+  let mockGithub;
+  let mockContext;
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      setOutput: vi.fn(),
+    };
+
+    mockGithub = {
+      rest: {
+        users: {
+          getByUsername: vi.fn().mockResolvedValue({ data: { created_at: "2020-01-01T00:00:00Z" } }),
+        },
+      },
+    };
+
+    mockContext = {
+      actor: "test-user",
+      eventName: "issue_comment",
+      payload: {
+        comment: { user: { login: "test-user" }, author_association: "CONTRIBUTOR" },
+      },
+    };
+
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.context = mockContext;
+
+    delete process.env.GH_AW_GUARD_BLOCKED_USERS;
+    delete process.env.GH_AW_GUARD_ASSOCIATIONS;
+    delete process.env.GH_AW_GUARD_MIN_ACCOUNT_AGE;
+
+    vi.resetModules();
+  });
+
+  afterEach(() => {
+    vi.clearAllMocks();
+    delete global.core;
+    delete global.github;
+    delete global.context;
+  });
+
+  const run = async () => {
+    const { main } = await import("./check_guards.cjs");
+    await main();
+  };
+
+  it("should not guard other events", async () => {
+    process.env.GH_AW_GUARD_BLOCKED_USERS = "test-user";
+    mockContext.eventName = "workflow_dispatch";
+
+    await run();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("guards_ok", "true");
+  });
+
+  it("should skip blocked users case-insensitively", async () => {
+    process.env.GH_AW_GUARD_BLOCKED_USERS = "spammer, Test-User";
+
+    await run();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("guards_ok", "false");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("error_message", "Workflow skipped: User 'test-user' is blocked");
+  });
+
+  it("should check the author association of the triggering content", async () => {
+    process.env.GH_AW_GUARD_ASSOCIATIONS = "owner,member,collaborator";
+
+    await run();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("guards_ok", "false");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("error_message", expect.stringContaining("association 'contributor'"));
+  });
+
+  it("should normalize first-time contributor associations", async () => {
+    process.env.GH_AW_GUARD_ASSOCIATIONS = "first-time-contributor";
+    mockContext.payload.comment.author_association = "FIRST_TIME_CONTRIBUTOR";
+
+    await run();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("guards_ok", "true");
+  });
+
+  it("should skip the association check when the actor is not the author", async () => {
+    process.env.GH_AW_GUARD_ASSOCIATIONS = "member";
+    mockContext.eventName = "issues";
+    mockContext.payload = { issue: { user: { login: "someone-else" }, author_association: "NONE" } };
+
+    await run();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("guards_ok", "true");
+  });
+
+  it("should skip accounts younger than the minimum age", async () => {
+    process.env.GH_AW_GUARD_MIN_ACCOUNT_AGE = "30";
+    mockGithub.rest.users.getByUsername.mockResolvedValue({ data: { created_at: new Date(Date.now() - 2 * 24 * 60 * 60 * 1000).toISOString() } });
+
+    await run();
+
+    expect(mockGithub.rest.users.getByUsername).toHaveBeenCalledWith({ username: "test-user" });
+    expect(mockCore.setOutput).toHaveBeenCalledWith("guards_ok", "false");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("error_message", "Workflow skipped: Account 'test-user' is 2 days old, minimum is 30 days");
+  });
+
+  it("should allow old enough accounts and fail open on API errors", async () => {
+    process.env.GH_AW_GUARD_MIN_ACCOUNT_AGE = "30";
+
+    await run();
+    expect(mockCore.setOutput).toHaveBeenCalledWith("guards_ok", "true");
+
+    vi.clearAllMocks();
+    vi.resetModules();
+    mockGithub.rest.users.getByUsername.mockRejectedValue(new Error("Not Found"));
+
+    await run();
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Not Found"));
+    expect(mockCore.setOutput).toHaveBeenCalledWith("guards_ok", "true");
+  });
+});
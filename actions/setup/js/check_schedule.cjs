@@ -0,0 +1,111 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { ERR_CONFIG } = require("./error_codes.cjs");
+
+/**
+ * @typedef {Object} ScheduleCheck
+ * @property {string} [timezone] - IANA timezone the schedule was written in
+ * @property {number} [utc_offset] - Offset in minutes east of UTC the cron entry was compiled for
+ * @property {number} [jitter] - Maximum random delay in seconds
+ */
+
+/**
+ * Returns the offset of a timezone from UTC, in minutes east of UTC, at the given instant
+ * @param {string} timezone - IANA timezone name
+ * @param {Date} date - Instant to evaluate
+ * @returns {number}
+ */
+function getUTCOffsetMinutes(timezone, date) {
+  const parts = new Intl.DateTimeFormat("en-US", {
+    timeZone: timezone,
+    hourCycle: "h23",
+    year: "numeric",
+    month: "numeric",
+    day: "numeric",
+    hour: "numeric",
+    minute: "numeric",
+  }).formatToParts(date);
+  /** @param {string} type */
+  const get = type => Number(parts.find(part => part.type === type)?.value);
+  const localAsUTC = Date.UTC(get("year"), get("month") - 1, get("day"), get("hour"), get("minute"));
+  const truncated = Math.floor(date.getTime() / 60000) * 60000;
+  return Math.round((localAsUTC - truncated) / 60000);
+}
+
+/**
+ * Formats an offset in minutes east of UTC as UTC+HH:MM
+ * @param {number} offset
+ * @returns {string}
+ */
+function formatUTCOffset(offset) {
+  const sign = offset < 0 ? "-" : "+";
+  const abs = Math.abs(offset);
+  return `UTC${sign}${String(Math.floor(abs / 60)).padStart(2, "0")}:${String(abs % 60).padStart(2, "0")}`;
+}
+
+/**
+ * Applies schedule jitter and skips runs from cron entries compiled for a UTC offset that is not in effect.
+ * Timezones with daylight saving time compile to one UTC cron entry per offset, so exactly one of
+ * them matches local time on any given day.
+ * @param {{ now?: () => Date, sleep?: (ms: number) => Promise<void>, random?: () => number }} [deps]
+ */
+async function main(deps = {}) {
+  const now = deps.now || (() => new Date());
+  const sleep = deps.sleep || (ms => new Promise(resolve => setTimeout(resolve, ms)));
+  const random = deps.random || Math.random;
+
+  if (context.eventName !== "schedule") {
+    core.info(`Event '${context.eventName}' is not a schedule, skipping schedule checks`);
+    core.setOutput("schedule_ok", "true");
+    return;
+  }
+
+  /** @type {Record<string, ScheduleCheck[]>} */
+  let checks;
+  try {
+    checks = JSON.parse(process.env.GH_AW_SCHEDULE_CHECKS || "{}");
+  } catch (error) {
+    core.setFailed(`${ERR_CONFIG}: Configuration error: GH_AW_SCHEDULE_CHECKS is not valid JSON: ${error instanceof Error ? error.message : String(error)}`);
+    return;
+  }
+
+  const cron = context.payload.schedule;
+  const entries = (cron && checks[cron]) || [];
+  if (entries.length === 0) {
+    core.info(`No schedule checks for cron '${cron}'`);
+    core.setOutput("schedule_ok", "true");
+    return;
+  }
+
+  const current = now();
+  const matching = entries.filter(entry => {
+    if (!entry.timezone || entry.utc_offset === undefined) {
+      return true;
+    }
+    const offset = getUTCOffsetMinutes(entry.timezone, current);
+    if (offset === entry.utc_offset) {
+      core.info(`Cron '${cron}' matches ${entry.timezone} at ${formatUTCOffset(offset)}`);
+      return true;
+    }
+    core.info(`Cron '${cron}' targets ${entry.timezone} at ${formatUTCOffset(entry.utc_offset)}, but ${entry.timezone} is currently ${formatUTCOffset(offset)}`);
+    return false;
+  });
+
+  if (matching.length === 0) {
+    core.info(`⏭️ Skipping run: cron '${cron}' is the entry for a UTC offset that is not currently in effect`);
+    core.setOutput("schedule_ok", "false");
+    return;
+  }
+
+  const jitter = Math.max(0, ...matching.map(entry => entry.jitter || 0));
+  if (jitter > 0) {
+    const delaySeconds = Math.floor(random() * jitter);
+    core.info(`Delaying run by ${delaySeconds}s (jitter up to ${jitter}s)`);
+    await sleep(delaySeconds * 1000);
+  }
+
+  core.setOutput("schedule_ok", "true");
+}
+
+module.exports = { main, getUTCOffsetMinutes, formatUTCOffset };
@@ -0,0 +1,135 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+describe("check_schedule.cjs", () => {
+  let mockCore;
+  let mockContext;
+  let noSleep;
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn(),
+    };
+
+    mockContext = {
+      eventName: "schedule",
+      payload: { schedule: "0 8 * * 1-5" },
+      repo: { owner: "test-owner", repo: "test-repo" },
+    };
+
+    global.core = mockCore;
+    global.context = mockContext;
+    noSleep = vi.fn(async () => {});
+
+    delete process.env.GH_AW_SCHEDULE_CHECKS;
+    vi.resetModules();
+  });
+
+  afterEach(() => {
+    vi.clearAllMocks();
+    delete global.core;
+    delete global.context;
+    delete process.env.GH_AW_SCHEDULE_CHECKS;
+  });
+
+  const parisChecks = JSON.stringify({
+    "0 8 * * 1-5": [{ timezone: "Europe/Paris", utc_offset: 60 }],
+    "0 7 * * 1-5": [{ timezone: "Europe/Paris", utc_offset: 120 }],
+  });
+
+  it("should pass for non-schedule events", async () => {
+    mockContext.eventName = "workflow_dispatch";
+    process.env.GH_AW_SCHEDULE_CHECKS = parisChecks;
+
+    const { main } = await import("./check_schedule.cjs");
+    await main({ sleep: noSleep });
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("schedule_ok", "true");
+    expect(noSleep).not.toHaveBeenCalled();
+  });
+
+  it("should run the winter entry when standard time is in effect", async () => {
+    process.env.GH_AW_SCHEDULE_CHECKS = parisChecks;
+
+    const { main } = await import("./check_schedule.cjs");
+    await main({ now: () => new Date("2026-01-12T08:03:00Z"), sleep: noSleep });
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("schedule_ok", "true");
+  });
+
+  it("should skip the winter entry while summer time is in effect", async () => {
+    process.env.GH_AW_SCHEDULE_CHECKS = parisChecks;
+
+    const { main } = await import("./check_schedule.cjs");
+    await main({ now: () => new Date("2026-07-13T08:03:00Z"), sleep: noSleep });
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("schedule_ok", "false");
+  });
+
+  it("should run the summer entry while summer time is in effect", async () => {
+    mockContext.payload.schedule = "0 7 * * 1-5";
+    process.env.GH_AW_SCHEDULE_CHECKS = parisChecks;
+
+    const { main } = await import("./check_schedule.cjs");
+    await main({ now: () => new Date("2026-07-13T07:02:00Z"), sleep: noSleep });
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("schedule_ok", "true");
+  });
+
+  it("should pass crons without checks", async () => {
+    mockContext.payload.schedule = "30 2 * * *";
+    process.env.GH_AW_SCHEDULE_CHECKS = parisChecks;
+
+    const { main } = await import("./check_schedule.cjs");
+    await main({ sleep: noSleep });
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("schedule_ok", "true");
+  });
+
+  it("should sleep for a random delay bounded by jitter", async () => {
+    process.env.GH_AW_SCHEDULE_CHECKS = JSON.stringify({ "0 8 * * 1-5": [{ jitter: 900 }] });
+
+    const { main } = await import("./check_schedule.cjs");
+    await main({ sleep: noSleep, random: () => 0.5 });
+
+    expect(noSleep).toHaveBeenCalledWith(450000);
+    expect(mockCore.setOutput).toHaveBeenCalledWith("schedule_ok", "true");
+  });
+
+  it("should not sleep when the run is skipped", async () => {
+    process.env.GH_AW_SCHEDULE_CHECKS = JSON.stringify({
+      "0 8 * * 1-5": [{ timezone: "Europe/Paris", utc_offset: 60, jitter: 900 }],
+    });
+
+    const { main } = await import("./check_schedule.cjs");
+    await main({ now: () => new Date("2026-07-13T08:03:00Z"), sleep: noSleep });
+
+    expect(noSleep).not.toHaveBeenCalled();
+    expect(mockCore.setOutput).toHaveBeenCalledWith("schedule_ok", "false");
+  });
+
+  it("should fail on invalid configuration", async () => {
+    process.env.GH_AW_SCHEDULE_CHECKS = "{not json";
+
+    const { main } = await import("./check_schedule.cjs");
+    await main({ sleep: noSleep });
+
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("GH_AW_SCHEDULE_CHECKS is not valid JSON"));
+  });
+
+  describe("getUTCOffsetMinutes", () => {
+    it("should compute offsets for daylight saving and fractional zones", async () => {
+      const { getUTCOffsetMinutes, formatUTCOffset } = await import("./check_schedule.cjs");
+
+      expect(getUTCOffsetMinutes("Europe/Paris", new Date("2026-01-15T12:00:00Z"))).toBe(60);
+      expect(getUTCOffsetMinutes("Europe/Paris", new Date("2026-07-15T12:00:00Z"))).toBe(120);
+      expect(getUTCOffsetMinutes("America/New_York", new Date("2026-01-15T12:00:00Z"))).toBe(-300);
+      expect(getUTCOffsetMinutes("Asia/Kolkata", new Date("2026-01-15T12:00:00Z"))).toBe(330);
+      expect(formatUTCOffset(-300)).toBe("UTC-05:00");
+      expect(formatUTCOffset(330)).toBe("UTC+05:30");
+    });
+  });
+});
@@ -32,6 +32,16 @@ async function main() {
 
   if (currentTime >= stopTimeDate) {
     core.warning(`⏰ Stop time reached. Workflow execution will be prevented by activation job.`);
+    core.notice(`Workflow "${workflowName}" expired at ${stopTime} (stop-after). The agent job was skipped.`);
+    await core.summary
+      .addRaw(
+        `## ⏰ Workflow Expired
+
+The stop-after time for **${workflowName}** (${stopTime}) has passed, so the agent job was skipped.
+
+To extend the workflow, update \`on.stop-after\` and recompile with \`gh aw compile --refresh-stop-time\`, or remove \`stop-after\` to run indefinitely.`
+      )
+      .write();
     core.setOutput("stop_time_ok", "false");
     return;
   }
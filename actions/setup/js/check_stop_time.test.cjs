@@ -92,6 +92,9 @@ const mockCore = {
             (process.env.GH_AW_WORKFLOW_NAME = "test-workflow"),
             await eval(`(async () => { ${checkStopTimeScript}; await main(); })()`),
             expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Stop time reached")),
+            expect(mockCore.notice).toHaveBeenCalledWith(expect.stringContaining("expired")),
+            expect(mockCore.summary.addRaw).toHaveBeenCalledWith(expect.stringContaining("--refresh-stop-time")),
+            expect(mockCore.summary.write).toHaveBeenCalled(),
             expect(mockGithub.rest.actions.listRepoWorkflows).not.toHaveBeenCalled(),
             expect(mockGithub.rest.actions.disableWorkflow).not.toHaveBeenCalled(),
             expect(mockCore.setOutput).toHaveBeenCalledWith("stop_time_ok", "false"),
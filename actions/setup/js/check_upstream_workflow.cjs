@@ -0,0 +1,84 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const fs = require("fs");
+
+/**
+ * Default location of the event recorded by the upstream run, downloaded from its activation artifact
+ */
+const UPSTREAM_EVENT_PATH = "/tmp/gh-aw/upstream/event.json";
+
+/**
+ * Check that the upstream agentic workflow (needs:) ran for an item this workflow handles.
+ * Reads the item triggers from GH_AW_UPSTREAM_EVENTS as a JSON object mapping each event
+ * name to its activity types (an empty list accepts every type).
+ * Sets upstream_ok to true when the event recorded by the upstream run matches them, and
+ * exposes the recorded event as upstream_event and upstream_payload for the prompt.
+ * Runs that were not triggered by workflow_run (e.g. manual dispatches) always proceed.
+ * @param {{eventPath?: string}} [options]
+ */
+async function main(options = {}) {
+  const eventPath = options.eventPath || UPSTREAM_EVENT_PATH;
+
+  if (context.eventName !== "workflow_run") {
+    core.info(`✅ Triggered by ${context.eventName}, not by the upstream workflow. Workflow will proceed.`);
+    core.setOutput("upstream_ok", "true");
+    core.setOutput("upstream_event", "");
+    core.setOutput("upstream_payload", "");
+    return;
+  }
+
+  const skip = (/** @type {string} */ reason) => {
+    core.info(`❌ ${reason}. Workflow will be skipped.`);
+    core.setOutput("upstream_ok", "false");
+    core.setOutput("upstream_event", "");
+    core.setOutput("upstream_payload", "");
+  };
+
+  const upstreamRun = context.payload?.workflow_run || {};
+  core.info(`Checking upstream run ${upstreamRun.id} of '${upstreamRun.name}' (${upstreamRun.conclusion})`);
+
+  /** @type {Record<string, string[]>} */
+  let events = {};
+  try {
+    events = JSON.parse(process.env.GH_AW_UPSTREAM_EVENTS || "{}");
+  } catch (error) {
+    core.setFailed(`Failed to parse GH_AW_UPSTREAM_EVENTS: ${error instanceof Error ? error.message : String(error)}`);
+    return;
+  }
+
+  if (!fs.existsSync(eventPath)) {
+    // The upstream activation job did not run, e.g. because its own pre-activation checks failed
+    skip("The upstream run has no recorded event");
+    return;
+  }
+
+  /** @type {{event_name?: string, payload?: any}} */
+  let recorded;
+  try {
+    recorded = JSON.parse(fs.readFileSync(eventPath, "utf8"));
+  } catch (error) {
+    skip(`Failed to read the upstream event: ${error instanceof Error ? error.message : String(error)}`);
+    return;
+  }
+
+  const eventName = recorded.event_name || "";
+  const payload = recorded.payload || {};
+  const types = events[eventName];
+  if (!types) {
+    skip(`The upstream run was triggered by ${eventName || "an unknown event"}, which is not one of: ${Object.keys(events).join(", ")}`);
+    return;
+  }
+  if (types.length > 0 && !types.includes(payload.action)) {
+    skip(`The upstream ${eventName} event has type '${payload.action}', which is not one of: ${types.join(", ")}`);
+    return;
+  }
+
+  const item = payload.issue || payload.pull_request || payload.discussion;
+  core.info(`✅ The upstream run handled ${eventName}${payload.action ? `.${payload.action}` : ""}${item?.number ? ` for #${item.number}` : ""}. Workflow will proceed.`);
+  core.setOutput("upstream_ok", "true");
+  core.setOutput("upstream_event", eventName);
+  core.setOutput("upstream_payload", JSON.stringify(payload));
+}
+
+module.exports = { main, UPSTREAM_EVENT_PATH };
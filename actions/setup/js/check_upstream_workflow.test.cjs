@@ -0,0 +1,101 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+describe("check_upstream_workflow.cjs", () => {
+  let mockCore;
+  let mockContext;
+  let tmpDir;
+  let eventPath;
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn(),
+    };
+
+    mockContext = {
+      eventName: "workflow_run",
+      payload: { workflow_run: { id: 42, name: "Issue Triage", conclusion: "success" } },
+      repo: { owner: "test-owner", repo: "test-repo" },
+    };
+
+    global.core = mockCore;
+    global.context = mockContext;
+
+    tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "upstream-"));
+    eventPath = path.join(tmpDir, "event.json");
+    process.env.GH_AW_UPSTREAM_EVENTS = JSON.stringify({ issues: ["opened", "reopened"], issue_comment: [] });
+    vi.resetModules();
+  });
+
+  afterEach(() => {
+    vi.clearAllMocks();
+    delete global.core;
+    delete global.context;
+    delete process.env.GH_AW_UPSTREAM_EVENTS;
+    fs.rmSync(tmpDir, { recursive: true, force: true });
+  });
+
+  const writeEvent = (eventName, payload) => fs.writeFileSync(eventPath, JSON.stringify({ event_name: eventName, payload }));
+
+  it("should proceed for runs not triggered by workflow_run", async () => {
+    mockContext.eventName = "workflow_dispatch";
+
+    const { main } = await import("./check_upstream_workflow.cjs");
+    await main({ eventPath });
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("upstream_ok", "true");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("upstream_payload", "");
+  });
+
+  it("should expose the upstream event when it matches", async () => {
+    const payload = { action: "opened", issue: { number: 7, title: "Crash on start" } };
+    writeEvent("issues", payload);
+
+    const { main } = await import("./check_upstream_workflow.cjs");
+    await main({ eventPath });
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("upstream_ok", "true");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("upstream_event", "issues");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("upstream_payload", JSON.stringify(payload));
+  });
+
+  it("should accept every type when none are listed", async () => {
+    writeEvent("issue_comment", { action: "edited", issue: { number: 7 } });
+
+    const { main } = await import("./check_upstream_workflow.cjs");
+    await main({ eventPath });
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("upstream_ok", "true");
+  });
+
+  it("should skip when the upstream event type does not match", async () => {
+    writeEvent("issues", { action: "labeled", issue: { number: 7 } });
+
+    const { main } = await import("./check_upstream_workflow.cjs");
+    await main({ eventPath });
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("upstream_ok", "false");
+  });
+
+  it("should skip when the upstream run was triggered by another event", async () => {
+    writeEvent("workflow_dispatch", { inputs: {} });
+
+    const { main } = await import("./check_upstream_workflow.cjs");
+    await main({ eventPath });
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("upstream_ok", "false");
+  });
+
+  it("should skip when the upstream run has no recorded event", async () => {
+    const { main } = await import("./check_upstream_workflow.cjs");
+    await main({ eventPath });
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("upstream_ok", "false");
+  });
+});
@@ -20,9 +20,9 @@ async function main() {
     return;
   }
 
-  // Construct file paths
-  const workflowBasename = workflowFile.replace(".lock.yml", "");
-  const workflowMdPath = `.github/workflows/${workflowBasename}.md`;
+  // Construct file paths (GH_AW_WORKFLOW_MD_FILE is set when a custom lock file name pattern is used)
+  const workflowMdFile = process.env.GH_AW_WORKFLOW_MD_FILE || `${workflowFile.replace(".lock.yml", "")}.md`;
+  const workflowMdPath = `.github/workflows/${workflowMdFile}`;
   const lockFilePath = `.github/workflows/${workflowFile}`;
 
   core.info(`Checking workflow timestamps using GitHub API:`);
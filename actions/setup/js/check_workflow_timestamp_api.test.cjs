@@ -47,6 +47,7 @@ describe("check_workflow_timestamp_api.cjs", () => {
   beforeEach(async () => {
     vi.clearAllMocks();
     delete process.env.GH_AW_WORKFLOW_FILE;
+    delete process.env.GH_AW_WORKFLOW_MD_FILE;
 
     // Dynamically import the module to get fresh instance
     const module = await import("./check_workflow_timestamp_api.cjs");
@@ -60,6 +61,19 @@ describe("check_workflow_timestamp_api.cjs", () => {
     });
   });
 
+  describe("when a custom lock file name is used", () => {
+    it("should read the source path from GH_AW_WORKFLOW_MD_FILE", async () => {
+      process.env.GH_AW_WORKFLOW_FILE = "aw-test.yml";
+      process.env.GH_AW_WORKFLOW_MD_FILE = "test.md";
+      mockGithub.rest.repos.listCommits.mockResolvedValueOnce({ data: [] }).mockResolvedValueOnce({ data: [] });
+
+      await main();
+
+      expect(mockCore.info).toHaveBeenCalledWith(expect.stringContaining("Source: .github/workflows/test.md"));
+      expect(mockCore.info).toHaveBeenCalledWith(expect.stringContaining("Lock file: .github/workflows/aw-test.yml"));
+    });
+  });
+
   describe("when files do not exist in git", () => {
     beforeEach(() => {
       process.env.GH_AW_WORKFLOW_FILE = "test.lock.yml";
@@ -12,6 +12,7 @@ const { getTrackerID } = require("./get_tracker_id.cjs");
 const { sanitizeTitle, applyTitlePrefix } = require("./sanitize_title.cjs");
 const { generateTemporaryId, isTemporaryId, normalizeTemporaryId, getOrGenerateTemporaryId, replaceTemporaryIdReferences } = require("./temporary_id.cjs");
 const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
+const { isCategoryAllowed } = require("./discussion_category_helpers.cjs");
 const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
 const { removeDuplicateTitleFromDescription } = require("./remove_duplicate_title.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
@@ -307,6 +308,7 @@ async function main(config = {}) {
   const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
   const titlePrefix = config.title_prefix || "";
   const configCategory = config.category || "";
+  const allowedCategories = Array.isArray(config.allowed_categories) ? config.allowed_categories : [];
   const maxCount = config.max || 10;
   const expiresHours = config.expires ? parseInt(String(config.expires), 10) : 0;
   const fallbackToIssue = config.fallback_to_issue !== false; // Default to true
@@ -334,6 +336,9 @@ async function main(config = {}) {
   if (allowedRepos.size > 0) {
     core.info(`Allowed repos: ${Array.from(allowedRepos).join(", ")}`);
   }
+  if (allowedCategories.length > 0) {
+    core.info(`Allowed categories: ${allowedCategories.join(", ")}`);
+  }
   if (fallbackToIssue) {
     core.info("Fallback to issue enabled: will create an issue if discussion creation fails due to permissions");
   }
@@ -432,7 +437,7 @@ async function main(config = {}) {
         const enhancedError =
           `Failed to fetch repository information for '${qualifiedItemRepo}': ${errorMessage}. ` +
           `This may indicate that discussions are not enabled for this repository. ` +
-          `Please verify that discussions are enabled in the repository settings at https://github.com/${qualifiedItemRepo}/settings.`;
+          `Please verify that discussions are enabled in the repository settings at ${process.env.GITHUB_SERVER_URL || "https://github.com"}/${qualifiedItemRepo}/settings.`;
         core.error(enhancedError);
         return {
           success: false,
@@ -455,6 +460,17 @@ async function main(config = {}) {
     const categoryId = resolvedCategory.id;
     core.info(`Using category: ${resolvedCategory.name} (${resolvedCategory.matchType})`);
 
+    // Enforce the category allow-list, including fallback categories
+    const categoryDetails = repoInfo.discussionCategories.find(cat => cat.id === categoryId);
+    if (!isCategoryAllowed(categoryDetails, allowedCategories)) {
+      const error = `Discussion category "${resolvedCategory.name}" is not allowed. Allowed categories: ${allowedCategories.join(", ")}`;
+      core.warning(`Skipping discussion: ${error}`);
+      return {
+        success: false,
+        error,
+      };
+    }
+
     // Get or generate the temporary ID for this discussion
     const tempIdResult = getOrGenerateTemporaryId(message, "discussion");
     if (tempIdResult.error) {
@@ -645,7 +661,7 @@ async function main(config = {}) {
         `Failed to create discussion in '${qualifiedItemRepo}': ${errorMessage}. ` +
         `Common causes: (1) Discussions not enabled in repository settings, ` +
         `(2) Invalid category ID, or (3) Insufficient permissions. ` +
-        `Verify discussions are enabled at https://github.com/${qualifiedItemRepo}/settings and check the category configuration.`;
+        `Verify discussions are enabled at ${process.env.GITHUB_SERVER_URL || "https://github.com"}/${qualifiedItemRepo}/settings and check the category configuration.`;
       core.error(enhancedError);
       return {
         success: false,
@@ -40,6 +40,7 @@ const { renderTemplate } = require("./messages_core.cjs");
 const { createExpirationLine, addExpirationToFooter } = require("./ephemerals.cjs");
 const { MAX_SUB_ISSUES, getSubIssueCount } = require("./sub_issue_helpers.cjs");
 const { closeOlderIssues } = require("./close_older_issues.cjs");
+const { computeTitleFingerprint, generateFingerprintMarker, findDuplicateIssue } = require("./issue_dedupe.cjs");
 const { parseBoolTemplatable } = require("./templatable.cjs");
 const { tryEnforceArrayLimit } = require("./limit_enforcement_helpers.cjs");
 const fs = require("fs");
@@ -217,6 +218,8 @@ async function main(config = {}) {
   const groupEnabled = parseBoolTemplatable(config.group, false);
   const closeOlderIssuesEnabled = parseBoolTemplatable(config.close_older_issues, false);
   const includeFooter = parseBoolTemplatable(config.footer, true);
+  const dedupeBy = config.dedupe_by ?? "";
+  const dedupeWindowHours = config.dedupe_window ? parseInt(String(config.dedupe_window), 10) : 0;
 
   // Create an authenticated GitHub client. Uses config["github-token"] when set
   // (for cross-repository operations), otherwise falls back to the step-level github.
@@ -251,6 +254,9 @@ async function main(config = {}) {
   if (closeOlderIssuesEnabled) {
     core.info(`Close older issues enabled: older issues with same workflow-id marker will be closed`);
   }
+  if (dedupeBy) {
+    core.info(`Issue deduplication enabled: by=${dedupeBy}, window=${dedupeWindowHours} hours`);
+  }
 
   // Track how many items we've processed for max limit
   let processedCount = 0;
@@ -475,6 +481,11 @@ async function main(config = {}) {
     if (callerWorkflowId) {
       bodyLines.push(generateWorkflowCallIdMarker(callerWorkflowId));
     }
+    // Add the fingerprint marker so later runs can find this issue instead of opening a duplicate
+    const fingerprint = dedupeBy ? computeTitleFingerprint(title, titlePrefix) : "";
+    if (fingerprint) {
+      bodyLines.push(generateFingerprintMarker(fingerprint));
+    }
 
     bodyLines.push("");
     const body = bodyLines.join("\n").trim();
@@ -504,6 +515,43 @@ async function main(config = {}) {
       };
     }
 
+    // Comment on an open issue with the same fingerprint created by this workflow instead of opening a duplicate
+    if (fingerprint && workflowId) {
+      const duplicate = await findDuplicateIssue(githubClient, {
+        owner: repoParts.owner,
+        repo: repoParts.repo,
+        fingerprint,
+        workflowId,
+        callerWorkflowId,
+        windowHours: dedupeWindowHours,
+      });
+      if (duplicate) {
+        try {
+          await githubClient.rest.issues.createComment({
+            owner: repoParts.owner,
+            repo: repoParts.repo,
+            issue_number: duplicate.number,
+            body,
+          });
+          core.info(`Commented on duplicate issue ${qualifiedItemRepo}#${duplicate.number} instead of creating a new issue: ${duplicate.html_url}`);
+          temporaryIdMap.set(normalizeTemporaryId(String(temporaryId)), { repo: qualifiedItemRepo, number: duplicate.number });
+          return {
+            success: true,
+            deduplicated: true,
+            repo: qualifiedItemRepo,
+            number: duplicate.number,
+            url: duplicate.html_url,
+            temporaryId: temporaryId,
+            _repo: qualifiedItemRepo,
+          };
+        } catch (error) {
+          core.warning(`Failed to comment on duplicate issue #${duplicate.number}, creating a new issue: ${getErrorMessage(error)}`);
+        }
+      }
+    } else if (fingerprint) {
+      core.warning("Issue deduplication enabled but GH_AW_WORKFLOW_ID environment variable not set - skipping");
+    }
+
     try {
       const { data: issue } = await githubClient.rest.issues.create({
         owner: repoParts.owner,
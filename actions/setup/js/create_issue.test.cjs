@@ -438,4 +438,46 @@ describe("create_issue", () => {
       expect(result.error).toContain("received 6");
     });
   });
+
+  describe("dedupe", () => {
+    const { computeTitleFingerprint, generateFingerprintMarker } = require("./issue_dedupe.cjs");
+    const fingerprint = computeTitleFingerprint("[bot] Flaky test", "[bot] ");
+
+    it("should add the fingerprint marker to new issues", async () => {
+      const handler = await main({ title_prefix: "[bot] ", dedupe_by: "title-hash", dedupe_window: 720 });
+      const result = await handler({ title: "Flaky test", body: "Details" });
+
+      expect(result.success).toBe(true);
+      expect(result.deduplicated).toBeUndefined();
+      expect(mockGithub.rest.search.issuesAndPullRequests).toHaveBeenCalledWith(expect.objectContaining({ q: expect.stringContaining(`"gh-aw-issue-fingerprint: ${fingerprint}" in:body created:>=`) }));
+      expect(mockGithub.rest.issues.create).toHaveBeenCalledWith(expect.objectContaining({ body: expect.stringContaining(generateFingerprintMarker(fingerprint)) }));
+    });
+
+    it("should comment on an existing issue with the same fingerprint", async () => {
+      mockGithub.rest.search.issuesAndPullRequests.mockResolvedValue({
+        data: {
+          items: [
+            { number: 7, html_url: "https://github.com/test-owner/test-repo/issues/7", body: `Old\n<!-- gh-aw-workflow-id: other-workflow -->\n${generateFingerprintMarker(fingerprint)}` },
+            { number: 5, html_url: "https://github.com/test-owner/test-repo/issues/5", body: `Old\n<!-- gh-aw-workflow-id: test-workflow -->\n${generateFingerprintMarker(fingerprint)}` },
+          ],
+        },
+      });
+      const handler = await main({ title_prefix: "[bot] ", dedupe_by: "title-hash", dedupe_window: 720 });
+      const result = await handler({ title: "flaky   TEST", body: "Seen again", temporary_id: "aw_abc123" });
+
+      expect(result.success).toBe(true);
+      expect(result.deduplicated).toBe(true);
+      expect(result.number).toBe(5);
+      expect(mockGithub.rest.issues.create).not.toHaveBeenCalled();
+      expect(mockGithub.rest.issues.createComment).toHaveBeenCalledWith(expect.objectContaining({ issue_number: 5, body: expect.stringContaining("Seen again") }));
+    });
+
+    it("should not search for duplicates when dedupe is not configured", async () => {
+      const handler = await main({});
+      await handler({ title: "Flaky test", body: "Details" });
+
+      expect(mockGithub.rest.search.issuesAndPullRequests).not.toHaveBeenCalled();
+      expect(mockGithub.rest.issues.create).toHaveBeenCalledWith(expect.objectContaining({ body: expect.not.stringContaining("gh-aw-issue-fingerprint") }));
+    });
+  });
 });
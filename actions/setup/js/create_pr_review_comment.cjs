@@ -10,15 +10,33 @@ const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_help
 const { sanitizeContent } = require("./sanitize_content.cjs");
 const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
 const { buildWorkflowRunUrl } = require("./workflow_metadata_helpers.cjs");
+const { fetchPullRequestDiffHunks, validateDiffAnchor } = require("./pr_diff_anchors.cjs");
 
 /** @type {string} Safe output type handled by this module */
 const HANDLER_TYPE = "create_pull_request_review_comment";
 
+/**
+ * Append a GitHub suggestion block to a review comment body.
+ * The fence is longer than any backtick run in the suggestion so that code
+ * containing fences cannot close the block early.
+ * @param {string} body - Comment body
+ * @param {string} suggestion - Replacement for the commented lines
+ * @returns {string} Body with the suggestion block
+ */
+function appendSuggestion(body, suggestion) {
+  const longestRun = Math.max(0, ...(suggestion.match(/`+/g) || []).map(run => run.length));
+  const fence = "`".repeat(Math.max(3, longestRun + 1));
+  const code = suggestion.endsWith("\n") ? suggestion.slice(0, -1) : suggestion;
+  return `${body}\n\n${fence}suggestion\n${code}\n${fence}`;
+}
+
 /**
  * Main handler factory for create_pull_request_review_comment
  * Returns a message handler function that validates and buffers individual review comments.
  * Comments are buffered in the PR review buffer (passed via config._prReviewBuffer) and
- * submitted as a single PR review after all messages have been processed.
+ * submitted as a single PR review after all messages have been processed. Each comment is
+ * checked against the PR diff first, since one comment outside the diff would make GitHub
+ * reject the whole review.
  *
  * @type {HandlerFactoryFunction}
  */
@@ -49,6 +67,32 @@ async function main(config = {}) {
   // Track how many items we've processed for max limit
   let processedCount = 0;
 
+  /**
+   * Diff hunks by file path for each pull request, fetched once per PR.
+   * A null value means the diff could not be fetched and anchors are not checked.
+   * @type {Map<string, Promise<Map<string, import('./pr_diff_anchors.cjs').DiffHunk[] | null> | null>>}
+   */
+  const diffCache = new Map();
+
+  /**
+   * Get the diff hunks of a pull request
+   * @param {{owner: string, repo: string}} repoParts - Repository owner and name
+   * @param {number} pullRequestNumber - Pull request number
+   */
+  function getDiffHunks(repoParts, pullRequestNumber) {
+    const key = `${repoParts.owner}/${repoParts.repo}#${pullRequestNumber}`;
+    if (!diffCache.has(key)) {
+      diffCache.set(
+        key,
+        fetchPullRequestDiffHunks(githubClient, repoParts.owner, repoParts.repo, pullRequestNumber).catch(error => {
+          core.warning(`Could not fetch the diff of PR #${pullRequestNumber} to validate comment lines: ${getErrorMessage(error)}`);
+          return null;
+        })
+      );
+    }
+    return diffCache.get(key);
+  }
+
   // Extract triggering context for footer generation
   const triggeringIssueNumber = context.payload?.issue?.number && !context.payload?.issue?.pull_request ? context.payload.issue.number : undefined;
   const triggeringPRNumber = context.payload?.pull_request?.number || (context.payload?.issue?.pull_request ? context.payload.issue.number : undefined);
@@ -261,6 +305,25 @@ async function main(config = {}) {
       };
     }
 
+    // Suggestions replace the commented lines of the new version of the file
+    const suggestion = commentItem.suggestion;
+    if (suggestion !== undefined && suggestion !== null) {
+      if (typeof suggestion !== "string") {
+        core.warning('Invalid field "suggestion" in review comment item (must be a string)');
+        return {
+          success: false,
+          error: 'Invalid field "suggestion"',
+        };
+      }
+      if (side !== "RIGHT") {
+        core.warning(`Suggestions can only be made on the RIGHT side of the diff, got ${side}`);
+        return {
+          success: false,
+          error: "Suggestions require side RIGHT",
+        };
+      }
+    }
+
     // Set the review context (first comment sets it)
     // Reject comments targeting a different repo/PR than the first comment
     const existingCtx = buffer.getReviewContext();
@@ -272,6 +335,19 @@ async function main(config = {}) {
       };
     }
 
+    // Check that the comment is anchored to lines of the PR diff
+    const diffFiles = await getDiffHunks(repoParts, pullRequestNumber);
+    if (diffFiles) {
+      const anchorError = validateDiffAnchor(diffFiles, { path: commentItem.path, line, start_line: startLine, side });
+      if (anchorError) {
+        core.warning(`Skipping review comment: ${anchorError}`);
+        return {
+          success: false,
+          error: anchorError,
+        };
+      }
+    }
+
     buffer.setReviewContext({
       repo: itemRepo,
       repoParts: repoParts,
@@ -284,7 +360,7 @@ async function main(config = {}) {
     const bufferedComment = {
       path: commentItem.path,
       line: line,
-      body: sanitizeContent(commentItem.body.trim()),
+      body: sanitizeContent(typeof suggestion === "string" ? appendSuggestion(commentItem.body.trim(), suggestion) : commentItem.body.trim()),
       side: side,
     };
 
@@ -294,7 +370,7 @@ async function main(config = {}) {
 
     buffer.addComment(bufferedComment);
 
-    core.info(`Buffered review comment on PR #${pullRequestNumber} in ${itemRepo} at ${commentItem.path}:${line}${startLine ? ` (lines ${startLine}-${line})` : ""} [${side}]`);
+    core.info(`Buffered review comment on PR #${pullRequestNumber} in ${itemRepo} at ${commentItem.path}:${line}${startLine ? ` (lines ${startLine}-${line})` : ""} [${side}]${typeof suggestion === "string" ? " with suggestion" : ""}`);
 
     return {
       success: true,
@@ -305,4 +381,4 @@ async function main(config = {}) {
   };
 }
 
-module.exports = { main };
+module.exports = { main, appendSuggestion };
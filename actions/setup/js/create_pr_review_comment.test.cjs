@@ -37,7 +37,9 @@ const mockGithub = {
   rest: {
     pulls: {
       createReviewComment: vi.fn(),
+      createReview: vi.fn(),
       get: vi.fn(),
+      listFiles: vi.fn(),
     },
   },
 };
@@ -472,4 +474,94 @@ describe("create_pr_review_comment.cjs", () => {
     // Footer context is set on the buffer for review-level footer generation
     expect(buffer.getBufferedCount()).toBe(1);
   });
+
+  describe("suggestions", () => {
+    it("should append a suggestion block to the comment body", async () => {
+      mockGithub.rest.pulls.createReview.mockResolvedValueOnce({ data: { id: 1, html_url: "https://github.com/testowner/testrepo/pull/123#pullrequestreview-1" } });
+      const handler = await createHandler();
+      const result = await handler(
+        {
+          type: "create_pull_request_review_comment",
+          path: "src/main.js",
+          line: 11,
+          start_line: 10,
+          body: "Use const here.",
+          suggestion: "const a = 1;\nconst b = 2;\n",
+        },
+        {}
+      );
+
+      expect(result.success).toBe(true);
+      await buffer.submitReview();
+      const comment = mockGithub.rest.pulls.createReview.mock.calls[0][0].comments[0];
+      expect(comment.body).toBe("Use const here.\n\n```suggestion\nconst a = 1;\nconst b = 2;\n```");
+      expect(comment.start_line).toBe(10);
+    });
+
+    it("should use a longer fence when the suggestion contains backticks", async () => {
+      const { appendSuggestion } = require("./create_pr_review_comment.cjs");
+      expect(appendSuggestion("Fix docs.", "```js\ncode\n```")).toBe("Fix docs.\n\n````suggestion\n```js\ncode\n```\n````");
+    });
+
+    it("should reject suggestions on the LEFT side", async () => {
+      const handler = await createHandler();
+      const result = await handler({ type: "create_pull_request_review_comment", path: "src/main.js", line: 10, side: "LEFT", body: "Restore this.", suggestion: "x" }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("Suggestions require side RIGHT");
+      expect(buffer.getBufferedCount()).toBe(0);
+    });
+  });
+
+  describe("diff anchor validation", () => {
+    beforeEach(() => {
+      mockGithub.rest.pulls.listFiles.mockResolvedValueOnce({
+        data: [
+          { filename: "src/main.js", patch: "@@ -8,3 +8,4 @@ function main() {\n   a();\n-  b();\n+  c();\n+  d();\n   e();" },
+          { filename: "assets/logo.png" },
+        ],
+      });
+    });
+
+    it("should buffer comments on lines in the diff", async () => {
+      const handler = await createHandler();
+      const result = await handler({ type: "create_pull_request_review_comment", path: "src/main.js", line: 10, start_line: 9, body: "Looks off." }, {});
+
+      expect(result.success).toBe(true);
+      expect(mockGithub.rest.pulls.listFiles).toHaveBeenCalledWith(expect.objectContaining({ owner: "testowner", repo: "testrepo", pull_number: 123 }));
+    });
+
+    it("should skip comments on lines outside the diff", async () => {
+      const handler = await createHandler();
+      const result = await handler({ type: "create_pull_request_review_comment", path: "src/main.js", line: 40, body: "Unrelated." }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain('Line 40 of "src/main.js" is not part of the diff on the RIGHT side (lines in the diff: 8-11)');
+      expect(buffer.getBufferedCount()).toBe(0);
+    });
+
+    it("should skip comments on files that are not changed", async () => {
+      const handler = await createHandler();
+      const result = await handler({ type: "create_pull_request_review_comment", path: "src/other.js", line: 1, body: "Hmm." }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain('File "src/other.js" is not changed in the pull request');
+    });
+
+    it("should accept comments on files without a patch", async () => {
+      const handler = await createHandler();
+      const result = await handler({ type: "create_pull_request_review_comment", path: "assets/logo.png", line: 1, body: "New logo." }, {});
+
+      expect(result.success).toBe(true);
+    });
+
+    it("should fetch the diff once per pull request", async () => {
+      const handler = await createHandler();
+      await handler({ type: "create_pull_request_review_comment", path: "src/main.js", line: 9, body: "One." }, {});
+      await handler({ type: "create_pull_request_review_comment", path: "src/main.js", line: 11, body: "Two." }, {});
+
+      expect(mockGithub.rest.pulls.listFiles).toHaveBeenCalledTimes(1);
+      expect(buffer.getBufferedCount()).toBe(2);
+    });
+  });
 });
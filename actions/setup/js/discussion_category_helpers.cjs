@@ -0,0 +1,31 @@
+// @ts-check
+
+/**
+ * Helpers for discussion category allow-lists (allowed-categories in
+ * create-discussion and answer-discussion configuration).
+ */
+
+/**
+ * Checks whether a discussion category is in the allow-list.
+ * Entries match the category ID exactly, or its name or slug case-insensitively.
+ * An empty allow-list allows every category.
+ * @param {{id?: string, name?: string, slug?: string} | null | undefined} category - Discussion category
+ * @param {string[]} allowedCategories - Allowed category IDs, names, or slugs
+ * @returns {boolean} True when the category is allowed
+ */
+function isCategoryAllowed(category, allowedCategories) {
+  if (!allowedCategories || allowedCategories.length === 0) {
+    return true;
+  }
+  if (!category) {
+    return false;
+  }
+  const name = (category.name || "").toLowerCase();
+  const slug = (category.slug || "").toLowerCase();
+  return allowedCategories.some(allowed => {
+    const normalized = String(allowed).toLowerCase();
+    return allowed === category.id || (name !== "" && normalized === name) || (slug !== "" && normalized === slug);
+  });
+}
+
+module.exports = { isCategoryAllowed };
@@ -0,0 +1,27 @@
+import { describe, it, expect } from "vitest";
+
+const { isCategoryAllowed } = require("./discussion_category_helpers.cjs");
+
+describe("discussion_category_helpers.cjs", () => {
+  const category = { id: "DIC_kwDOABC123", name: "Q&A", slug: "q-a" };
+
+  it("should allow every category when the allow-list is empty", () => {
+    expect(isCategoryAllowed(category, [])).toBe(true);
+    expect(isCategoryAllowed(undefined, [])).toBe(true);
+  });
+
+  it("should match the category ID exactly", () => {
+    expect(isCategoryAllowed(category, ["DIC_kwDOABC123"])).toBe(true);
+    expect(isCategoryAllowed(category, ["dic_kwdoabc123"])).toBe(false);
+  });
+
+  it("should match the category name or slug case-insensitively", () => {
+    expect(isCategoryAllowed(category, ["q&a"])).toBe(true);
+    expect(isCategoryAllowed(category, ["Q-A"])).toBe(true);
+  });
+
+  it("should reject categories that are not in the allow-list", () => {
+    expect(isCategoryAllowed(category, ["General", "Ideas"])).toBe(false);
+    expect(isCategoryAllowed(null, ["General"])).toBe(false);
+  });
+});
@@ -84,13 +84,15 @@ async function computeFrontmatterHash(workflowPath, options = {}) {
 function extractFrontmatterAndBody(content) {
   const lines = content.split("\n");
 
-  if (lines.length === 0 || lines[0].trim() !== "---") {
+  // YAML frontmatter is delimited by ---, TOML frontmatter by +++
+  const delimiter = lines.length > 0 ? lines[0].trim() : "";
+  if (delimiter !== "---" && delimiter !== "+++") {
     return { frontmatterText: "", markdown: content };
   }
 
   let endIndex = -1;
   for (let i = 1; i < lines.length; i++) {
-    if (lines[i].trim() === "---") {
+    if (lines[i].trim() === delimiter) {
       endIndex = i;
       break;
     }
@@ -164,7 +166,7 @@ async function processImportsTextBased(frontmatterText, baseDir, visited = new S
 
 /**
  * Extract imports field from frontmatter text using simple text parsing
- * Only extracts array items under "imports:" key
+ * Only extracts array items under "imports:" key, or the strings of an "imports = [...]" TOML array
  * @param {string} frontmatterText - The frontmatter text
  * @returns {string[]} Array of import paths
  */
@@ -182,6 +184,22 @@ function extractImportsFromText(frontmatterText) {
     // Skip empty lines and comments
     if (!trimmed || trimmed.startsWith("#")) continue;
 
+    // TOML frontmatter lists imports as imports = ["a.md", "b.md"], possibly over several lines
+    const tomlMatch = trimmed.match(/^imports\s*=\s*(\[.*)$/);
+    if (tomlMatch) {
+      let array = tomlMatch[1];
+      for (let j = i + 1; !array.includes("]") && j < lines.length; j++) {
+        array += "\n" + lines[j];
+      }
+      for (const item of array.matchAll(/"([^"]*)"|'([^']*)'/g)) {
+        const importPath = (item[1] || "") + (item[2] || "");
+        if (importPath) {
+          imports.push(importPath);
+        }
+      }
+      break;
+    }
+
     // Check if this is the imports: key
     if (trimmed.startsWith("imports:")) {
       inImports = true;
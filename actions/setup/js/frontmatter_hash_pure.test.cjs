@@ -54,6 +54,18 @@ imports:
       expect(result.frontmatterText).toContain("imports:");
       expect(result.frontmatterText).toContain("- shared/test.md");
     });
+
+    it("should extract TOML frontmatter", () => {
+      const content = `+++
+engine = "copilot"
++++
+
+# Body`;
+
+      const result = extractFrontmatterAndBody(content);
+      expect(result.frontmatterText).toBe('engine = "copilot"');
+      expect(result.markdown).toContain("# Body");
+    });
   });
 
   describe("extractImportsFromText", () => {
@@ -85,6 +97,18 @@ description: Test`;
       expect(result).toEqual(["shared/test.md", "shared/common.md"]);
     });
 
+    it("should extract imports from a TOML array", () => {
+      const frontmatterText = `engine = "copilot"
+imports = [
+  "shared/test.md", # first
+  'shared/common.md',
+]
+description = "Test"`;
+
+      const result = extractImportsFromText(frontmatterText);
+      expect(result).toEqual(["shared/test.md", "shared/common.md"]);
+    });
+
     it("should stop at next top-level key", () => {
       const frontmatterText = `imports:
   - shared/test.md
@@ -12,8 +12,9 @@ const validateLockdownRequirements = require("./validate_lockdown_requirements.c
  * Generate aw_info.json with workflow run metadata.
  * Reads compile-time values from environment variables (GH_AW_INFO_*) and
  * runtime values from the GitHub Actions context. Validates required context
- * variables, writes to /tmp/gh-aw/aw_info.json, sets the model output, and
- * prints the agent overview in the step summary.
+ * variables, writes to /tmp/gh-aw/aw_info.json, records the event payload in
+ * /tmp/gh-aw/event.json, sets the model output, and prints the agent overview
+ * in the step summary.
  *
  * @param {typeof import('@actions/core')} core - GitHub Actions core library
  * @param {object} ctx - GitHub Actions context object
@@ -93,6 +94,11 @@ async function main(core, ctx) {
   core.info("Generated aw_info.json at: " + tmpPath);
   core.info(JSON.stringify(awInfo, null, 2));
 
+  // Record the triggering event so `gh aw replay` can re-dispatch the run with the same payload
+  const eventPath = TMP_GH_AW_PATH + "/event.json";
+  fs.writeFileSync(eventPath, JSON.stringify({ event_name: ctx.eventName, payload: ctx.payload || {} }, null, 2));
+  core.info("Recorded event payload at: " + eventPath);
+
   // Set model as output for reuse in other steps/jobs
   core.setOutput("model", awInfo.model);
 
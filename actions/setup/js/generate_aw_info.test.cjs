@@ -97,6 +97,17 @@ describe("generate_aw_info.cjs", () => {
     expect(awInfo.created_at).toBeTruthy();
   });
 
+  it("should record the event payload for replay", async () => {
+    const payload = { action: "opened", issue: { number: 7 } };
+    await main(mockCore, { ...mockContext, eventName: "issues", payload });
+
+    const eventPath = "/tmp/gh-aw/event.json";
+    expect(fs.existsSync(eventPath)).toBe(true);
+    const recorded = JSON.parse(fs.readFileSync(eventPath, "utf8"));
+    expect(recorded).toEqual({ event_name: "issues", payload });
+    fs.unlinkSync(eventPath);
+  });
+
   it("should set model output", async () => {
     await main(mockCore, mockContext);
 
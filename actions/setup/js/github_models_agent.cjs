@@ -0,0 +1,371 @@
+// @ts-check
+
+const fs = require("fs");
+
+/**
+ * Agent loop of the github-models engine.
+ *
+ * Sends the prompt to the GitHub Models chat completions API, authenticated with the
+ * workflow token (models: read), and runs the tool calls the model requests against the
+ * MCP servers of the MCP gateway until the model answers without calling a tool.
+ *
+ * Every event is written to stdout as one JSON line (init, message, tool_use, tool_result,
+ * result), which the compiler's log parser reads for turns, tokens and tool calls.
+ *
+ * Environment:
+ *  - GH_AW_PROMPT: rendered prompt file
+ *  - GH_AW_GITHUB_MODELS_TOKEN: token with the models: read permission
+ *  - GH_AW_GITHUB_MODELS_MODEL: model ID (default: openai/gpt-4.1)
+ *  - GH_AW_GITHUB_MODELS_ENDPOINT: inference endpoint (default: https://models.github.ai/inference)
+ *  - GH_AW_MCP_CONFIG: MCP server configuration written by convert_gateway_config_github_models.sh (optional)
+ *  - GH_AW_MAX_TURNS: maximum number of model requests (default: 30)
+ */
+
+const DEFAULT_MODEL = "openai/gpt-4.1";
+const DEFAULT_ENDPOINT = "https://models.github.ai/inference";
+const DEFAULT_MAX_TURNS = 30;
+const MAX_TOOL_RESULT_CHARS = 50000;
+const MAX_RATE_LIMIT_RETRIES = 3;
+
+const SYSTEM_PROMPT = [
+  "You are an autonomous agent running in a GitHub Actions workflow.",
+  "Complete the task by calling the available tools. You cannot run shell commands or edit files,",
+  "so every action and every result must go through a tool call.",
+  "When the task is done, reply with a short summary of what you did.",
+].join(" ");
+
+/**
+ * Writes one JSONL event to stdout
+ * @param {Record<string, any>} event - Event to log
+ */
+function logEvent(event) {
+  process.stdout.write(JSON.stringify(event) + "\n");
+}
+
+/**
+ * Builds the function name the model uses for an MCP tool. Names are limited to
+ * [A-Za-z0-9_-] and 64 characters by the chat completions API.
+ * @param {string} server - MCP server name
+ * @param {string} tool - Tool name
+ * @returns {string} Function name
+ */
+function toFunctionName(server, tool) {
+  return `${server}__${tool}`.replace(/[^A-Za-z0-9_-]/g, "_").slice(0, 64);
+}
+
+/**
+ * Parses the body of an MCP streamable HTTP response, which is either JSON or a
+ * server-sent event stream whose data lines carry JSON-RPC messages
+ * @param {string} body - Response body
+ * @param {string} contentType - Response content type
+ * @returns {any} JSON-RPC message
+ */
+function parseMCPResponse(body, contentType) {
+  if (!contentType.includes("text/event-stream")) {
+    return JSON.parse(body);
+  }
+  let message = null;
+  for (const line of body.split(/\r?\n/)) {
+    if (!line.startsWith("data:")) {
+      continue;
+    }
+    const data = line.slice(5).trim();
+    if (!data) {
+      continue;
+    }
+    const parsed = JSON.parse(data);
+    if (parsed.result !== undefined || parsed.error !== undefined) {
+      message = parsed;
+    }
+  }
+  if (!message) {
+    throw new Error("MCP response stream contained no result");
+  }
+  return message;
+}
+
+/**
+ * Minimal MCP client for servers behind the MCP gateway (streamable HTTP transport)
+ */
+class MCPHttpClient {
+  /**
+   * @param {string} name - Server name
+   * @param {string} url - Server URL
+   * @param {Record<string, string>} headers - Request headers, e.g. the gateway API key
+   */
+  constructor(name, url, headers) {
+    this.name = name;
+    this.url = url;
+    this.headers = headers;
+    this.nextId = 1;
+    /** @type {string | null} */
+    this.sessionId = null;
+  }
+
+  /**
+   * Posts a JSON-RPC message
+   * @param {Record<string, any>} message - JSON-RPC message
+   * @returns {Promise<Response>} HTTP response
+   */
+  async post(message) {
+    /** @type {Record<string, string>} */
+    const headers = {
+      ...this.headers,
+      "Content-Type": "application/json",
+      Accept: "application/json, text/event-stream",
+    };
+    if (this.sessionId) {
+      headers["Mcp-Session-Id"] = this.sessionId;
+    }
+    const response = await fetch(this.url, { method: "POST", headers, body: JSON.stringify({ jsonrpc: "2.0", ...message }) });
+    const sessionId = response.headers.get("mcp-session-id");
+    if (sessionId) {
+      this.sessionId = sessionId;
+    }
+    return response;
+  }
+
+  /**
+   * Sends a JSON-RPC request and returns its result
+   * @param {string} method - Method name
+   * @param {Record<string, any>} params - Method parameters
+   * @returns {Promise<any>} Result
+   */
+  async request(method, params) {
+    const response = await this.post({ id: this.nextId++, method, params });
+    const body = await response.text();
+    if (!response.ok) {
+      throw new Error(`${this.name}: ${method} failed with HTTP ${response.status}: ${body.slice(0, 500)}`);
+    }
+    const message = parseMCPResponse(body, response.headers.get("content-type") || "");
+    if (message.error) {
+      throw new Error(`${this.name}: ${method} failed: ${message.error.message || JSON.stringify(message.error)}`);
+    }
+    return message.result;
+  }
+
+  /**
+   * Sends a JSON-RPC notification
+   * @param {string} method - Method name
+   * @returns {Promise<void>}
+   */
+  async notify(method) {
+    const response = await this.post({ method });
+    await response.text();
+  }
+}
+
+/**
+ * Connects to the MCP servers of the configuration file and lists their tools
+ * @param {string} configPath - MCP configuration file ({"mcpServers": {name: {url, headers}}})
+ * @returns {Promise<{tools: Array<any>, routes: Map<string, {client: MCPHttpClient, tool: string}>}>} Tools for the model and their servers
+ */
+async function loadMCPTools(configPath) {
+  /** @type {Array<any>} */
+  const tools = [];
+  /** @type {Map<string, {client: MCPHttpClient, tool: string}>} */
+  const routes = new Map();
+  if (!configPath || !fs.existsSync(configPath)) {
+    return { tools, routes };
+  }
+
+  const config = JSON.parse(fs.readFileSync(configPath, "utf8"));
+  for (const [name, server] of Object.entries(config.mcpServers || {})) {
+    if (!server || !server.url) {
+      continue;
+    }
+    const client = new MCPHttpClient(name, server.url, server.headers || {});
+    try {
+      await client.request("initialize", {
+        protocolVersion: "2025-03-26",
+        capabilities: {},
+        clientInfo: { name: "gh-aw-github-models", version: "1.0.0" },
+      });
+      await client.notify("notifications/initialized");
+      const result = await client.request("tools/list", {});
+      for (const tool of result.tools || []) {
+        const functionName = toFunctionName(name, tool.name);
+        routes.set(functionName, { client, tool: tool.name });
+        tools.push({
+          type: "function",
+          function: {
+            name: functionName,
+            description: (tool.description || "").slice(0, 1024),
+            parameters: tool.inputSchema || { type: "object", properties: {} },
+          },
+        });
+      }
+    } catch (error) {
+      process.stderr.write(`Skipping MCP server ${name}: ${error instanceof Error ? error.message : String(error)}\n`);
+    }
+  }
+  return { tools, routes };
+}
+
+/**
+ * Converts an MCP tool result to the text returned to the model
+ * @param {any} result - tools/call result
+ * @returns {string} Tool output
+ */
+function formatToolResult(result) {
+  const text = (result?.content || [])
+    .map(/** @param {any} item */ item => (item.type === "text" ? item.text : JSON.stringify(item)))
+    .join("\n");
+  const output = result?.isError ? `Error: ${text}` : text;
+  if (output.length <= MAX_TOOL_RESULT_CHARS) {
+    return output;
+  }
+  return output.slice(0, MAX_TOOL_RESULT_CHARS) + `\n[... ${output.length - MAX_TOOL_RESULT_CHARS} characters truncated ...]`;
+}
+
+/**
+ * Requests a chat completion, waiting and retrying when rate limited
+ * @param {string} endpoint - Inference endpoint
+ * @param {string} token - Token with models: read
+ * @param {Record<string, any>} body - Request body
+ * @param {string} [provider] - Provider name used in messages
+ * @returns {Promise<any>} Completion
+ */
+async function chatCompletion(endpoint, token, body, provider = "GitHub Models") {
+  for (let attempt = 0; ; attempt++) {
+    const response = await fetch(`${endpoint.replace(/\/+$/, "")}/chat/completions`, {
+      method: "POST",
+      headers: {
+        Authorization: `Bearer ${token}`,
+        "Content-Type": "application/json",
+        Accept: "application/json",
+      },
+      body: JSON.stringify(body),
+    });
+    if (response.status === 429 && attempt < MAX_RATE_LIMIT_RETRIES) {
+      const retryAfter = parseInt(response.headers.get("retry-after") || "", 10);
+      const waitSeconds = Number.isFinite(retryAfter) ? Math.min(retryAfter, 120) : 10 * (attempt + 1);
+      process.stderr.write(`${provider} rate limit reached, retrying in ${waitSeconds}s\n`);
+      await response.text();
+      await new Promise(resolve => setTimeout(resolve, waitSeconds * 1000));
+      continue;
+    }
+    const text = await response.text();
+    if (!response.ok) {
+      throw new Error(`${provider} request failed with HTTP ${response.status}: ${text.slice(0, 1000)}`);
+    }
+    return JSON.parse(text);
+  }
+}
+
+/**
+ * Runs the agent loop
+ * @param {{prompt: string, model: string, endpoint: string, token: string, maxTurns: number, tools: Array<any>, routes: Map<string, {client: MCPHttpClient, tool: string}>, provider?: string}} options - Agent options
+ * @returns {Promise<{status: string, turns: number, inputTokens: number, outputTokens: number, toolCalls: number}>} Run statistics
+ */
+async function runAgent({ prompt, model, endpoint, token, maxTurns, tools, routes, provider = "GitHub Models" }) {
+  /** @type {Array<any>} */
+  const messages = [
+    { role: "system", content: SYSTEM_PROMPT },
+    { role: "user", content: prompt },
+  ];
+  const stats = { status: "max_turns", turns: 0, inputTokens: 0, outputTokens: 0, toolCalls: 0 };
+
+  while (stats.turns < maxTurns) {
+    stats.turns++;
+    /** @type {Record<string, any>} */
+    const body = { model, messages };
+    if (tools.length > 0) {
+      body.tools = tools;
+    }
+    const completion = await chatCompletion(endpoint, token, body, provider);
+    stats.inputTokens += completion.usage?.prompt_tokens || 0;
+    stats.outputTokens += completion.usage?.completion_tokens || 0;
+
+    const message = completion.choices?.[0]?.message;
+    if (!message) {
+      throw new Error(`${provider} returned no message`);
+    }
+    messages.push(message);
+    if (message.content) {
+      logEvent({ type: "message", role: "assistant", content: message.content });
+    }
+
+    const toolCalls = message.tool_calls || [];
+    if (toolCalls.length === 0) {
+      stats.status = "success";
+      return stats;
+    }
+
+    for (const toolCall of toolCalls) {
+      stats.toolCalls++;
+      const name = toolCall.function?.name || "";
+      let args = {};
+      let output;
+      let status = "success";
+      try {
+        args = JSON.parse(toolCall.function?.arguments || "{}");
+        const route = routes.get(name);
+        if (!route) {
+          throw new Error(`unknown tool ${name}`);
+        }
+        logEvent({ type: "tool_use", tool_name: name, tool_id: toolCall.id, parameters: args });
+        const result = await route.client.request("tools/call", { name: route.tool, arguments: args });
+        output = formatToolResult(result);
+        if (result?.isError) {
+          status = "error";
+        }
+      } catch (error) {
+        output = `Error: ${error instanceof Error ? error.message : String(error)}`;
+        status = "error";
+      }
+      logEvent({ type: "tool_result", tool_id: toolCall.id, status, output: output.slice(0, 2000) });
+      messages.push({ role: "tool", tool_call_id: toolCall.id, content: output });
+    }
+  }
+  return stats;
+}
+
+async function main() {
+  const promptPath = process.env.GH_AW_PROMPT || "";
+  const token = process.env.GH_AW_GITHUB_MODELS_TOKEN || "";
+  const model = process.env.GH_AW_GITHUB_MODELS_MODEL || DEFAULT_MODEL;
+  const endpoint = process.env.GH_AW_GITHUB_MODELS_ENDPOINT || DEFAULT_ENDPOINT;
+  const maxTurns = parseInt(process.env.GH_AW_MAX_TURNS || "", 10) || DEFAULT_MAX_TURNS;
+
+  if (!promptPath || !token) {
+    process.stderr.write("GH_AW_PROMPT and GH_AW_GITHUB_MODELS_TOKEN are required\n");
+    process.exit(1);
+  }
+
+  const prompt = fs.readFileSync(promptPath, "utf8");
+  const { tools, routes } = await loadMCPTools(process.env.GH_AW_MCP_CONFIG || "");
+  logEvent({ type: "init", model, tools: tools.map(tool => tool.function.name) });
+
+  try {
+    const stats = await runAgent({ prompt, model, endpoint, token, maxTurns, tools, routes });
+    logEvent({
+      type: "result",
+      status: stats.status,
+      stats: { turns: stats.turns, input_tokens: stats.inputTokens, output_tokens: stats.outputTokens, tool_calls: stats.toolCalls },
+    });
+    if (stats.status !== "success") {
+      process.stderr.write(`Stopped after ${stats.turns} turns (max-turns: ${maxTurns})\n`);
+      process.exit(1);
+    }
+  } catch (error) {
+    const message = error instanceof Error ? error.message : String(error);
+    logEvent({ type: "result", status: "error", error: message });
+    process.stderr.write(message + "\n");
+    process.exit(1);
+  }
+}
+
+if (require.main === module) {
+  main();
+}
+
+module.exports = {
+  logEvent,
+  toFunctionName,
+  parseMCPResponse,
+  formatToolResult,
+  loadMCPTools,
+  runAgent,
+  main,
+};
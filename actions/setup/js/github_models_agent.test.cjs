@@ -0,0 +1,141 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+/**
+ * Builds a fetch Response with JSON body
+ * @param {any} body
+ * @param {Record<string, string>} [headers]
+ */
+function jsonResponse(body, headers = {}) {
+  return new Response(JSON.stringify(body), { status: 200, headers: { "content-type": "application/json", ...headers } });
+}
+
+describe("github_models_agent.cjs", () => {
+  let agent;
+  const originalFetch = global.fetch;
+
+  beforeEach(async () => {
+    vi.clearAllMocks();
+    agent = await import("./github_models_agent.cjs");
+  });
+
+  afterEach(() => {
+    global.fetch = originalFetch;
+    vi.restoreAllMocks();
+  });
+
+  describe("toFunctionName", () => {
+    it("joins server and tool names", () => {
+      expect(agent.toFunctionName("github", "issue_read")).toBe("github__issue_read");
+    });
+
+    it("replaces unsupported characters and limits the length", () => {
+      expect(agent.toFunctionName("my.server", "do thing")).toBe("my_server__do_thing");
+      expect(agent.toFunctionName("s", "x".repeat(100))).toHaveLength(64);
+    });
+  });
+
+  describe("parseMCPResponse", () => {
+    it("parses JSON responses", () => {
+      expect(agent.parseMCPResponse('{"jsonrpc":"2.0","id":1,"result":{"ok":true}}', "application/json").result).toEqual({ ok: true });
+    });
+
+    it("parses the result of an event stream", () => {
+      const body = 'event: message\ndata: {"jsonrpc":"2.0","method":"notifications/progress"}\n\ndata: {"jsonrpc":"2.0","id":1,"result":{"tools":[]}}\n\n';
+      expect(agent.parseMCPResponse(body, "text/event-stream").result).toEqual({ tools: [] });
+    });
+
+    it("fails when the stream has no result", () => {
+      expect(() => agent.parseMCPResponse("data: \n\n", "text/event-stream")).toThrow("no result");
+    });
+  });
+
+  describe("formatToolResult", () => {
+    it("joins text content", () => {
+      expect(agent.formatToolResult({ content: [{ type: "text", text: "a" }, { type: "text", text: "b" }] })).toBe("a\nb");
+    });
+
+    it("marks errors", () => {
+      expect(agent.formatToolResult({ isError: true, content: [{ type: "text", text: "boom" }] })).toBe("Error: boom");
+    });
+
+    it("truncates long output", () => {
+      const output = agent.formatToolResult({ content: [{ type: "text", text: "x".repeat(60000) }] });
+      expect(output).toContain("characters truncated");
+      expect(output.length).toBeLessThan(51000);
+    });
+  });
+
+  describe("loadMCPTools", () => {
+    it("returns no tools without a config file", async () => {
+      const { tools, routes } = await agent.loadMCPTools("");
+      expect(tools).toEqual([]);
+      expect(routes.size).toBe(0);
+    });
+
+    it("lists the tools of each server", async () => {
+      const dir = fs.mkdtempSync(path.join(os.tmpdir(), "github-models-"));
+      const configPath = path.join(dir, "mcp.json");
+      fs.writeFileSync(configPath, JSON.stringify({ mcpServers: { github: { url: "http://gateway/mcp/github", headers: { Authorization: "key" } } } }));
+
+      global.fetch = vi.fn(async (_url, init) => {
+        const message = JSON.parse(init.body);
+        expect(init.headers.Authorization).toBe("key");
+        if (message.method === "initialize") {
+          return jsonResponse({ jsonrpc: "2.0", id: message.id, result: {} }, { "mcp-session-id": "session-1" });
+        }
+        expect(init.headers["Mcp-Session-Id"]).toBe("session-1");
+        if (message.method === "tools/list") {
+          return jsonResponse({ jsonrpc: "2.0", id: message.id, result: { tools: [{ name: "issue_read", description: "Read an issue", inputSchema: { type: "object" } }] } });
+        }
+        return new Response("", { status: 202 });
+      });
+
+      const { tools, routes } = await agent.loadMCPTools(configPath);
+      expect(tools).toEqual([{ type: "function", function: { name: "github__issue_read", description: "Read an issue", parameters: { type: "object" } } }]);
+      expect(routes.get("github__issue_read").tool).toBe("issue_read");
+      fs.rmSync(dir, { recursive: true, force: true });
+    });
+  });
+
+  describe("runAgent", () => {
+    it("runs tool calls until the model answers", async () => {
+      vi.spyOn(process.stdout, "write").mockImplementation(() => true);
+      const client = { request: vi.fn(async () => ({ content: [{ type: "text", text: "issue body" }] })) };
+      const routes = new Map([["github__issue_read", { client, tool: "issue_read" }]]);
+
+      const completions = [
+        {
+          choices: [{ message: { role: "assistant", content: null, tool_calls: [{ id: "call_1", type: "function", function: { name: "github__issue_read", arguments: '{"issue_number":1}' } }] } }],
+          usage: { prompt_tokens: 100, completion_tokens: 10 },
+        },
+        { choices: [{ message: { role: "assistant", content: "Done" } }], usage: { prompt_tokens: 150, completion_tokens: 5 } },
+      ];
+      global.fetch = vi.fn(async (url, init) => {
+        expect(url).toBe("https://models.example/inference/chat/completions");
+        expect(init.headers.Authorization).toBe("Bearer token");
+        return jsonResponse(completions.shift());
+      });
+
+      const stats = await agent.runAgent({ prompt: "Read issue 1", model: "openai/gpt-4.1", endpoint: "https://models.example/inference", token: "token", maxTurns: 5, tools: [], routes });
+
+      expect(stats).toEqual({ status: "success", turns: 2, inputTokens: 250, outputTokens: 15, toolCalls: 1 });
+      expect(client.request).toHaveBeenCalledWith("tools/call", { name: "issue_read", arguments: { issue_number: 1 } });
+      const secondRequest = JSON.parse(global.fetch.mock.calls[1][1].body);
+      expect(secondRequest.messages.at(-1)).toEqual({ role: "tool", tool_call_id: "call_1", content: "issue body" });
+    });
+
+    it("stops at max turns", async () => {
+      vi.spyOn(process.stdout, "write").mockImplementation(() => true);
+      global.fetch = vi.fn(async () =>
+        jsonResponse({ choices: [{ message: { role: "assistant", content: null, tool_calls: [{ id: "call", type: "function", function: { name: "unknown", arguments: "{}" } }] } }] })
+      );
+
+      const stats = await agent.runAgent({ prompt: "Loop", model: "m", endpoint: "https://models.example", token: "token", maxTurns: 2, tools: [], routes: new Map() });
+      expect(stats.status).toBe("max_turns");
+      expect(stats.turns).toBe(2);
+    });
+  });
+});
@@ -5,11 +5,14 @@
 // Interpolates GitHub Actions expressions and renders template conditionals in the prompt file.
 // This combines variable interpolation and template filtering into a single step.
 
+const crypto = require("crypto");
 const fs = require("fs");
 const { isTruthy } = require("./is_truthy.cjs");
 const { processRuntimeImports } = require("./runtime_import.cjs");
+const { filterTriggerSections } = require("./trigger_sections.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_API, ERR_CONFIG, ERR_VALIDATION } = require("./error_codes.cjs");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
 
 /**
  * Interpolates variables in the prompt content
@@ -134,6 +137,31 @@ function renderMarkdownTemplate(markdown) {
 /**
  * Main function for prompt variable interpolation and template rendering
  */
+/**
+ * Records a hash of the prompt template in aw_info.json so runs can be correlated with the
+ * prompt version that produced them. The hash is taken after runtime imports and before any
+ * per-run values are interpolated, so it only changes when the workflow prompt changes.
+ * @param {string} content - The prompt content after runtime imports
+ * @param {string} [awInfoPath] - Path to aw_info.json
+ * @returns {string} - The recorded hash, or an empty string when aw_info.json is missing
+ */
+function recordPromptHash(content, awInfoPath = TMP_GH_AW_PATH + "/aw_info.json") {
+  if (!fs.existsSync(awInfoPath)) {
+    core.info(`[recordPromptHash] ${awInfoPath} not found, skipping prompt hash`);
+    return "";
+  }
+  try {
+    const awInfo = JSON.parse(fs.readFileSync(awInfoPath, "utf8"));
+    awInfo.prompt_hash = crypto.createHash("sha256").update(content).digest("hex").substring(0, 12);
+    fs.writeFileSync(awInfoPath, JSON.stringify(awInfo, null, 2));
+    core.info(`[recordPromptHash] Recorded prompt hash ${awInfo.prompt_hash} in ${awInfoPath}`);
+    return awInfo.prompt_hash;
+  } catch (error) {
+    core.info(`[recordPromptHash] Failed to record prompt hash: ${getErrorMessage(error)}`);
+    return "";
+  }
+}
+
 async function main() {
   try {
     core.info("========================================");
@@ -162,6 +190,13 @@ async function main() {
     core.info(`[main] Original content length: ${originalLength} characters`);
     core.info(`[main] First 200 characters: ${content.substring(0, 200).replace(/\n/g, "\\n")}`);
 
+    // Keep only the inlined trigger sections (## on: <event>) of the current event.
+    // Runtime-imported files are filtered as they are imported.
+    if (process.env.GH_AW_TRIGGER_SECTIONS === "true") {
+      core.info(`[main] Selecting trigger sections for event: ${context.eventName}`);
+      content = filterTriggerSections(content, context.eventName);
+    }
+
     // Step 1: Process runtime imports (files and URLs)
     core.info("\n========================================");
     core.info("[main] STEP 1: Runtime Imports");
@@ -183,6 +218,7 @@ async function main() {
     } else {
       core.info("No runtime import macros found, skipping runtime import processing");
     }
+    recordPromptHash(content);
 
     // Step 2: Interpolate variables
     core.info("\n========================================");
@@ -190,8 +226,9 @@ async function main() {
     core.info("========================================");
     /** @type {Record<string, string>} */
     const variables = {};
+    const exprPrefix = (process.env.GH_AW_EXPRESSION_PREFIX || "GH_AW_") + "EXPR_";
     for (const [key, value] of Object.entries(process.env)) {
-      if (key.startsWith("GH_AW_EXPR_")) {
+      if (key.startsWith(exprPrefix)) {
         variables[key] = value || "";
       }
     }
@@ -261,4 +298,4 @@ async function main() {
   }
 }
 
-module.exports = { main };
+module.exports = { main, recordPromptHash };
@@ -127,3 +127,33 @@ describe("interpolate_prompt", () => {
         }));
     }));
 });
+describe("recordPromptHash", () => {
+  let tmpDir, awInfoPath;
+  beforeEach(() => {
+    tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "prompt-hash-test-"));
+    awInfoPath = path.join(tmpDir, "aw_info.json");
+  });
+  afterEach(() => {
+    fs.rmSync(tmpDir, { recursive: true, force: true });
+  });
+
+  it("should record a short prompt hash in aw_info.json", () => {
+    const { recordPromptHash } = require("./interpolate_prompt.cjs");
+    fs.writeFileSync(awInfoPath, JSON.stringify({ engine_id: "copilot", model: "gpt-5" }));
+
+    const hash = recordPromptHash("# Daily report\n", awInfoPath);
+
+    expect(hash).toMatch(/^[0-9a-f]{12}$/);
+    const awInfo = JSON.parse(fs.readFileSync(awInfoPath, "utf8"));
+    expect(awInfo.prompt_hash).toBe(hash);
+    expect(awInfo.model).toBe("gpt-5");
+    expect(recordPromptHash("# Daily report\n", awInfoPath)).toBe(hash);
+    expect(recordPromptHash("# Weekly report\n", awInfoPath)).not.toBe(hash);
+  });
+
+  it("should skip when aw_info.json does not exist", () => {
+    const { recordPromptHash } = require("./interpolate_prompt.cjs");
+    expect(recordPromptHash("# Daily report\n", awInfoPath)).toBe("");
+    expect(fs.existsSync(awInfoPath)).toBe(false);
+  });
+});
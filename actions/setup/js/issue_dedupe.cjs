@@ -0,0 +1,79 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const crypto = require("crypto");
+const { generateWorkflowIdMarker, generateWorkflowCallIdMarker } = require("./generate_footer.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/**
+ * Maximum number of search results checked for a duplicate issue
+ */
+const MAX_DUPLICATE_CANDIDATES = 20;
+
+/**
+ * Computes the title-hash fingerprint of an issue title. The title prefix is removed and
+ * case and whitespace are normalized, so small formatting changes still match.
+ * @param {string} title - Issue title, including the title prefix
+ * @param {string} [titlePrefix] - Configured title prefix
+ * @returns {string} 16 hex characters of the SHA-256 hash of the normalized title
+ */
+function computeTitleFingerprint(title, titlePrefix = "") {
+  let normalized = title.trim();
+  if (titlePrefix && normalized.startsWith(titlePrefix.trim())) {
+    normalized = normalized.slice(titlePrefix.trim().length);
+  }
+  normalized = normalized.toLowerCase().replace(/\s+/g, " ").trim();
+  return crypto.createHash("sha256").update(normalized).digest("hex").slice(0, 16);
+}
+
+/**
+ * Generates the XML comment marker recording the fingerprint of an issue
+ * @param {string} fingerprint - Issue fingerprint
+ * @returns {string} Fingerprint marker
+ */
+function generateFingerprintMarker(fingerprint) {
+  return `<!-- gh-aw-issue-fingerprint: ${fingerprint} -->`;
+}
+
+/**
+ * Searches for an open issue created by the same workflow with the same fingerprint
+ * @param {any} githubClient - Authenticated GitHub client
+ * @param {object} params - Search parameters
+ * @param {string} params.owner - Repository owner
+ * @param {string} params.repo - Repository name
+ * @param {string} params.fingerprint - Fingerprint of the new issue
+ * @param {string} params.workflowId - Workflow ID of the current workflow
+ * @param {string} [params.callerWorkflowId] - Calling workflow identity, matched instead of the workflow ID when set
+ * @param {number} params.windowHours - Only issues created within this many hours are considered
+ * @returns {Promise<{number: number, html_url: string, title: string}|null>} The most recent duplicate, or null
+ */
+async function findDuplicateIssue(githubClient, { owner, repo, fingerprint, workflowId, callerWorkflowId, windowHours }) {
+  const fingerprintMarker = generateFingerprintMarker(fingerprint);
+  const workflowMarker = callerWorkflowId ? generateWorkflowCallIdMarker(callerWorkflowId) : generateWorkflowIdMarker(workflowId);
+  const since = new Date(Date.now() - windowHours * 60 * 60 * 1000).toISOString().replace(/\.\d{3}Z$/, "Z");
+  const searchQuery = `repo:${owner}/${repo} is:issue is:open "gh-aw-issue-fingerprint: ${fingerprint}" in:body created:>=${since}`;
+  core.info(`Searching for duplicate issues: ${searchQuery}`);
+
+  try {
+    const { data } = await githubClient.rest.search.issuesAndPullRequests({
+      q: searchQuery,
+      per_page: MAX_DUPLICATE_CANDIDATES,
+      sort: "created",
+      order: "desc",
+    });
+
+    // The search matches words, so check the exact markers of this workflow
+    const duplicate = (data?.items ?? []).find(item => !item.pull_request && item.body?.includes(fingerprintMarker) && item.body?.includes(workflowMarker));
+    if (!duplicate) {
+      core.info(`No duplicate issue found for fingerprint ${fingerprint}`);
+      return null;
+    }
+    core.info(`Found duplicate issue #${duplicate.number} for fingerprint ${fingerprint}`);
+    return { number: duplicate.number, html_url: duplicate.html_url, title: duplicate.title };
+  } catch (error) {
+    core.warning(`Could not search for duplicate issues: ${getErrorMessage(error)}`);
+    return null;
+  }
+}
+
+module.exports = { computeTitleFingerprint, generateFingerprintMarker, findDuplicateIssue, MAX_DUPLICATE_CANDIDATES };
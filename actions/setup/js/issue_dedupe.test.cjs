@@ -0,0 +1,82 @@
+// @ts-check
+
+import { describe, it, expect, beforeEach, vi } from "vitest";
+import { computeTitleFingerprint, generateFingerprintMarker, findDuplicateIssue, MAX_DUPLICATE_CANDIDATES } from "./issue_dedupe.cjs";
+
+// Mock globals
+global.core = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+};
+
+describe("issue_dedupe", () => {
+  let mockGithub;
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+    mockGithub = {
+      rest: {
+        search: {
+          issuesAndPullRequests: vi.fn(),
+        },
+      },
+    };
+  });
+
+  describe("computeTitleFingerprint", () => {
+    it("should ignore the title prefix, case and whitespace", () => {
+      const fingerprint = computeTitleFingerprint("[bot] Flaky test in CI", "[bot] ");
+      expect(fingerprint).toBe(computeTitleFingerprint("  flaky   TEST in ci "));
+      expect(fingerprint.length).toBe(16);
+    });
+
+    it("should differ for different titles", () => {
+      expect(computeTitleFingerprint("Flaky test")).not.toBe(computeTitleFingerprint("Broken build"));
+    });
+  });
+
+  describe("findDuplicateIssue", () => {
+    const fingerprint = computeTitleFingerprint("Flaky test");
+    const params = { owner: "owner", repo: "repo", fingerprint, workflowId: "triage", windowHours: 24 };
+
+    it("should return the issue with the fingerprint and workflow markers", async () => {
+      mockGithub.rest.search.issuesAndPullRequests.mockResolvedValue({
+        data: {
+          items: [
+            { number: 3, title: "PR", html_url: "https://github.com/owner/repo/pull/3", pull_request: {}, body: `<!-- gh-aw-workflow-id: triage -->\n${generateFingerprintMarker(fingerprint)}` },
+            { number: 2, title: "Flaky test", html_url: "https://github.com/owner/repo/issues/2", body: `<!-- gh-aw-workflow-id: triage -->\n${generateFingerprintMarker(fingerprint)}` },
+          ],
+        },
+      });
+
+      const duplicate = await findDuplicateIssue(mockGithub, params);
+
+      expect(duplicate).toEqual({ number: 2, html_url: "https://github.com/owner/repo/issues/2", title: "Flaky test" });
+      const query = mockGithub.rest.search.issuesAndPullRequests.mock.calls[0][0];
+      expect(query.per_page).toBe(MAX_DUPLICATE_CANDIDATES);
+      expect(query.q).toContain(`repo:owner/repo is:issue is:open "gh-aw-issue-fingerprint: ${fingerprint}" in:body created:>=`);
+    });
+
+    it("should match the caller workflow when set", async () => {
+      mockGithub.rest.search.issuesAndPullRequests.mockResolvedValue({
+        data: {
+          items: [{ number: 2, title: "Flaky test", html_url: "https://github.com/owner/repo/issues/2", body: `<!-- gh-aw-workflow-id: triage -->\n${generateFingerprintMarker(fingerprint)}` }],
+        },
+      });
+
+      const duplicate = await findDuplicateIssue(mockGithub, { ...params, callerWorkflowId: "owner/repo/caller" });
+
+      expect(duplicate).toBe(null);
+    });
+
+    it("should return null when the search fails", async () => {
+      mockGithub.rest.search.issuesAndPullRequests.mockRejectedValue(new Error("rate limited"));
+
+      const duplicate = await findDuplicateIssue(mockGithub, params);
+
+      expect(duplicate).toBe(null);
+      expect(global.core.warning).toHaveBeenCalledWith("Could not search for duplicate issues: rate limited");
+    });
+  });
+});
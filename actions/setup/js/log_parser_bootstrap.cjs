@@ -2,9 +2,39 @@
 /// <reference types="@actions/github-script" />
 
 const { generatePlainTextSummary, generateCopilotCliStyleSummary, wrapAgentLogInSection, formatSafeOutputsPreview } = require("./log_parser_shared.cjs");
+const { generateToolTimelineSummary } = require("./tool_timeline.cjs");
+const { writeTranscript } = require("./transcript.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_API, ERR_CONFIG, ERR_VALIDATION } = require("./error_codes.cjs");
 
+/**
+ * Extracts the final assistant message and token usage from parsed log entries.
+ *
+ * @param {Array<any>} logEntries - Parsed log entries
+ * @returns {{finalSummary: string, tokens: number, costUSD: number}} Run summary
+ */
+function extractRunSummary(logEntries) {
+  let finalSummary = "";
+  for (let i = logEntries.length - 1; i >= 0 && !finalSummary; i--) {
+    const entry = logEntries[i];
+    if (entry?.type !== "assistant" || !Array.isArray(entry.message?.content)) {
+      continue;
+    }
+    finalSummary = entry.message.content
+      .filter(part => part?.type === "text" && typeof part.text === "string")
+      .map(part => part.text)
+      .join("\n")
+      .trim();
+  }
+
+  const lastEntry = logEntries[logEntries.length - 1];
+  const usage = lastEntry?.usage || {};
+  const tokens = (usage.input_tokens || 0) + (usage.output_tokens || 0) + (usage.cache_creation_input_tokens || 0) + (usage.cache_read_input_tokens || 0);
+  const costUSD = typeof lastEntry?.total_cost_usd === "number" ? lastEntry.total_cost_usd : 0;
+
+  return { finalSummary, tokens, costUSD };
+}
+
 /**
  * Bootstrap helper for log parser entry points.
  * Handles common logic for environment variable lookup, file existence checks,
@@ -89,6 +119,20 @@ async function runLogParser(options) {
       logEntries = result.logEntries || null;
     }
 
+    // Expose the final agent message and token usage for the run summary comment
+    if (logEntries && Array.isArray(logEntries) && logEntries.length > 0) {
+      const runSummary = extractRunSummary(logEntries);
+      core.setOutput("final_summary", runSummary.finalSummary);
+      core.setOutput("token_usage", JSON.stringify({ tokens: runSummary.tokens, cost_usd: runSummary.costUSD }));
+
+      // Write the engine-independent transcript read by `gh aw logs` and `gh aw audit`
+      try {
+        writeTranscript(logEntries, parserName);
+      } catch (error) {
+        core.warning(`Failed to write transcript: ${getErrorMessage(error)}`);
+      }
+    }
+
     if (markdown) {
       // Read safe outputs file if available
       let safeOutputsContent = "";
@@ -133,8 +177,12 @@ async function runLogParser(options) {
           open: true,
         });
 
-        // Add safe outputs preview to step summary
+        // Add the tool call timeline and safe outputs preview to step summary
         let fullMarkdown = wrappedAgentLog;
+        const toolTimeline = generateToolTimelineSummary(logEntries);
+        if (toolTimeline) {
+          fullMarkdown += "\n" + toolTimeline;
+        }
         if (safeOutputsContent) {
           const safeOutputsMarkdown = formatSafeOutputsPreview(safeOutputsContent, { isPlainText: false });
           if (safeOutputsMarkdown) {
@@ -191,6 +239,22 @@ async function runLogParser(options) {
     if (maxTurnsHit) {
       core.setFailed(`${ERR_VALIDATION}: Agent execution stopped: max-turns limit reached. The agent did not complete its task successfully.`);
     }
+
+    // Handle budget limits (limits: max-tokens / max-cost-usd) enforced by the budget monitor
+    const budgetReportPath = process.env.GH_AW_BUDGET_REPORT || "/tmp/gh-aw/budget-exceeded.json";
+    if (fs.existsSync(budgetReportPath)) {
+      let reason = "budget limit exceeded";
+      try {
+        const report = JSON.parse(fs.readFileSync(budgetReportPath, "utf8"));
+        if (report && report.reason) {
+          reason = report.reason;
+        }
+      } catch (error) {
+        core.warning(`Failed to read budget report: ${getErrorMessage(error)}`);
+      }
+      core.summary.addRaw(`\n## ⛔ Budget limit exceeded\n\nThe agent was stopped by the budget monitor: ${reason}.\n`).write();
+      core.setFailed(`${ERR_VALIDATION}: Agent execution stopped: ${reason}. The agent did not complete its task successfully.`);
+    }
   } catch (error) {
     core.setFailed(`${ERR_API}: ${error instanceof Error ? error.message : String(error)}`);
   }
@@ -200,5 +264,6 @@ async function runLogParser(options) {
 if (typeof module !== "undefined" && module.exports) {
   module.exports = {
     runLogParser,
+    extractRunSummary,
   };
 }
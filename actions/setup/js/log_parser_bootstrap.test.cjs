@@ -122,6 +122,53 @@ describe("log_parser_bootstrap.cjs", () => {
             fs.unlinkSync(logFile),
             fs.rmdirSync(tmpDir));
         }),
+        it("should fail and report when the budget monitor stopped the agent", () => {
+          const tmpDir = fs.mkdtempSync(path.join(__dirname, "test-")),
+            logFile = path.join(tmpDir, "test.log"),
+            reportFile = path.join(tmpDir, "budget-exceeded.json");
+          (fs.writeFileSync(logFile, "content"),
+            fs.writeFileSync(reportFile, JSON.stringify({ reason: "max-tokens limit of 1000 exceeded (1500 tokens used)" })),
+            (process.env.GH_AW_AGENT_OUTPUT = logFile),
+            (process.env.GH_AW_BUDGET_REPORT = reportFile));
+          const mockParseLog = vi.fn().mockReturnValue({ markdown: "## Result\n", mcpFailures: [], maxTurnsHit: !1 });
+          (runLogParser({ parseLog: mockParseLog, parserName: "TestParser" }),
+            expect(mockCore.summary.addRaw).toHaveBeenCalledWith(expect.stringContaining("Budget limit exceeded")),
+            expect(mockCore.setFailed).toHaveBeenCalledWith(`${ERR_VALIDATION}: Agent execution stopped: max-tokens limit of 1000 exceeded (1500 tokens used). The agent did not complete its task successfully.`),
+            fs.unlinkSync(logFile),
+            fs.unlinkSync(reportFile),
+            fs.rmdirSync(tmpDir));
+        }),
+        it("should append the tool call timeline to the step summary", () => {
+          const tmpDir = fs.mkdtempSync(path.join(__dirname, "test-")),
+            logFile = path.join(tmpDir, "test.log");
+          (fs.writeFileSync(logFile, "content"), (process.env.GH_AW_AGENT_OUTPUT = logFile));
+          const logEntries = [
+              { type: "assistant", message: { content: [{ type: "tool_use", id: "t1", name: "mcp__github__list_issues", input: {} }] } },
+              { type: "user", message: { content: [{ type: "tool_result", tool_use_id: "t1", content: "[]", duration_ms: 1500 }] } },
+            ],
+            mockParseLog = vi.fn().mockReturnValue({ markdown: "## Result\n", mcpFailures: [], maxTurnsHit: !1, logEntries });
+          (runLogParser({ parseLog: mockParseLog, parserName: "TestParser" }),
+            expect(mockCore.summary.addRaw).toHaveBeenCalledWith(expect.stringContaining("<summary>Tool Call Timeline</summary>")),
+            expect(mockCore.summary.addRaw).toHaveBeenCalledWith(expect.stringContaining("github.list_issues (2s) :t1, 0, 1500")),
+            fs.unlinkSync(logFile),
+            fs.rmdirSync(tmpDir));
+        }),
+        it("should expose final summary and token usage outputs", () => {
+          const tmpDir = fs.mkdtempSync(path.join(__dirname, "test-")),
+            logFile = path.join(tmpDir, "test.log");
+          (fs.writeFileSync(logFile, "content"), (process.env.GH_AW_AGENT_OUTPUT = logFile));
+          const logEntries = [
+            { type: "assistant", message: { content: [{ type: "text", text: "Working on it" }] } },
+            { type: "assistant", message: { content: [{ type: "text", text: "Done: fixed the bug." }] } },
+            { type: "result", usage: { input_tokens: 100, output_tokens: 20 }, total_cost_usd: 0.25 },
+          ];
+          const mockParseLog = vi.fn().mockReturnValue({ markdown: "## Result\n", mcpFailures: [], maxTurnsHit: !1, logEntries });
+          (runLogParser({ parseLog: mockParseLog, parserName: "TestParser" }),
+            expect(mockCore.setOutput).toHaveBeenCalledWith("final_summary", "Done: fixed the bug."),
+            expect(mockCore.setOutput).toHaveBeenCalledWith("token_usage", JSON.stringify({ tokens: 120, cost_usd: 0.25 })),
+            fs.unlinkSync(logFile),
+            fs.rmdirSync(tmpDir));
+        }),
         it("should read and concatenate multiple log files from directory when supportsDirectories is true", () => {
           const tmpDir = fs.mkdtempSync(path.join(__dirname, "test-")),
             logFile1 = path.join(tmpDir, "1.log"),
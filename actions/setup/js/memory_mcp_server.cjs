@@ -0,0 +1,235 @@
+// @ts-check
+
+/**
+ * Memory MCP Server Module
+ *
+ * This module provides the MCP server behind the `memory:` frontmatter field.
+ * It exposes a constrained key-value store persisted as memory.json in a directory
+ * that the compiled workflow restores before the agent runs and saves afterwards
+ * (actions cache, repo-memory branch, or gist).
+ *
+ * Usage:
+ *   node memory_mcp_server.cjs /path/to/memory-dir
+ *
+ * Limits are read from the environment:
+ *   GH_AW_MEMORY_MAX_KEYS - maximum number of keys (default: 100)
+ *   GH_AW_MEMORY_MAX_VALUE_SIZE - maximum size of a single value in bytes (default: 1024)
+ */
+
+const fs = require("fs");
+const path = require("path");
+
+const { createServer, registerTool, start } = require("./mcp_server_core.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_VALIDATION } = require("./error_codes.cjs");
+
+const MEMORY_STORE_FILE = "memory.json";
+const DEFAULT_MAX_KEYS = 100;
+const DEFAULT_MAX_VALUE_SIZE = 1024;
+const KEY_PATTERN = /^[A-Za-z0-9][A-Za-z0-9._:/-]{0,127}$/;
+
+/**
+ * @typedef {Object} MemoryLimits
+ * @property {number} maxKeys - Maximum number of keys in the store
+ * @property {number} maxValueSize - Maximum size of a single value in bytes
+ */
+
+/**
+ * Read the store limits from the environment
+ * @returns {MemoryLimits}
+ */
+function getLimitsFromEnv() {
+  const maxKeys = parseInt(process.env.GH_AW_MEMORY_MAX_KEYS || "", 10);
+  const maxValueSize = parseInt(process.env.GH_AW_MEMORY_MAX_VALUE_SIZE || "", 10);
+  return {
+    maxKeys: maxKeys > 0 ? maxKeys : DEFAULT_MAX_KEYS,
+    maxValueSize: maxValueSize > 0 ? maxValueSize : DEFAULT_MAX_VALUE_SIZE,
+  };
+}
+
+/**
+ * Validate a key against the allowed key format
+ * @param {unknown} key - Key to validate
+ * @returns {string} The validated key
+ */
+function validateKey(key) {
+  if (typeof key !== "string" || !KEY_PATTERN.test(key)) {
+    throw new Error(`${ERR_VALIDATION}: Invalid key ${JSON.stringify(key)}. Keys must be 1-128 characters of letters, digits, '.', '_', ':', '/' or '-' and start with a letter or digit`);
+  }
+  return key;
+}
+
+/**
+ * Create a key-value store backed by memory.json in the given directory
+ * @param {string} memoryDir - Directory holding memory.json
+ * @param {MemoryLimits} limits - Store limits
+ */
+function createMemoryStore(memoryDir, limits) {
+  const storePath = path.join(memoryDir, MEMORY_STORE_FILE);
+
+  /**
+   * @returns {Record<string, string>}
+   */
+  function load() {
+    if (!fs.existsSync(storePath)) {
+      return {};
+    }
+    const content = fs.readFileSync(storePath, "utf8");
+    if (!content.trim()) {
+      return {};
+    }
+    const data = JSON.parse(content);
+    if (!data || typeof data !== "object" || Array.isArray(data)) {
+      throw new Error(`${ERR_VALIDATION}: ${storePath} must contain a JSON object`);
+    }
+    return data;
+  }
+
+  /**
+   * Write the store atomically so an interrupted write never corrupts it
+   * @param {Record<string, string>} data
+   */
+  function save(data) {
+    fs.mkdirSync(memoryDir, { recursive: true });
+    const tmpPath = `${storePath}.tmp`;
+    fs.writeFileSync(tmpPath, JSON.stringify(data, null, 2) + "\n");
+    fs.renameSync(tmpPath, storePath);
+  }
+
+  return {
+    /**
+     * @param {unknown} key
+     * @returns {{key: string, found: boolean, value?: string}}
+     */
+    get(key) {
+      const validKey = validateKey(key);
+      const data = load();
+      if (!Object.prototype.hasOwnProperty.call(data, validKey)) {
+        return { key: validKey, found: false };
+      }
+      return { key: validKey, found: true, value: data[validKey] };
+    },
+
+    /**
+     * @param {unknown} key
+     * @param {unknown} value
+     * @returns {{key: string, stored: boolean}}
+     */
+    set(key, value) {
+      const validKey = validateKey(key);
+      if (typeof value !== "string") {
+        throw new Error(`${ERR_VALIDATION}: value must be a string. Serialize structured data as JSON`);
+      }
+      const size = Buffer.byteLength(value, "utf8");
+      if (size > limits.maxValueSize) {
+        throw new Error(`${ERR_VALIDATION}: value for key '${validKey}' is ${size} bytes, which exceeds the limit of ${limits.maxValueSize} bytes`);
+      }
+      const data = load();
+      if (!Object.prototype.hasOwnProperty.call(data, validKey) && Object.keys(data).length >= limits.maxKeys) {
+        throw new Error(`${ERR_VALIDATION}: memory already holds the maximum of ${limits.maxKeys} keys. Delete keys that are no longer needed first`);
+      }
+      data[validKey] = value;
+      save(data);
+      return { key: validKey, stored: true };
+    },
+
+    /**
+     * @param {unknown} key
+     * @returns {{key: string, deleted: boolean}}
+     */
+    delete(key) {
+      const validKey = validateKey(key);
+      const data = load();
+      if (!Object.prototype.hasOwnProperty.call(data, validKey)) {
+        return { key: validKey, deleted: false };
+      }
+      delete data[validKey];
+      save(data);
+      return { key: validKey, deleted: true };
+    },
+
+    /**
+     * @param {unknown} [prefix]
+     * @returns {{keys: string[], count: number, maxKeys: number}}
+     */
+    list(prefix) {
+      const keys = Object.keys(load())
+        .filter(key => typeof prefix !== "string" || key.startsWith(prefix))
+        .sort();
+      return { keys, count: keys.length, maxKeys: limits.maxKeys };
+    },
+  };
+}
+
+/**
+ * Wrap a result object in MCP text content
+ * @param {Object} result
+ */
+function textResult(result) {
+  return { content: [{ type: "text", text: JSON.stringify(result) }] };
+}
+
+/**
+ * Start the memory MCP server for the given directory
+ * @param {string} memoryDir - Directory holding memory.json
+ * @param {MemoryLimits} [limits] - Store limits (defaults to the environment)
+ */
+function startMemoryServer(memoryDir, limits = getLimitsFromEnv()) {
+  const server = createServer({ name: "memory", version: "1.0.0" });
+  const store = createMemoryStore(memoryDir, limits);
+
+  const keySchema = { type: "string", description: "Key (letters, digits, '.', '_', ':', '/' or '-'; at most 128 characters)" };
+
+  registerTool(server, {
+    name: "memory_get",
+    description: "Read the value stored under a key in the persistent memory. Returns found: false when the key does not exist.",
+    inputSchema: { type: "object", properties: { key: keySchema }, required: ["key"] },
+    handler: args => textResult(store.get(args.key)),
+  });
+  registerTool(server, {
+    name: "memory_set",
+    description: `Store a string value under a key in the persistent memory so that later runs of this workflow can read it. Values are limited to ${limits.maxValueSize} bytes and the memory to ${limits.maxKeys} keys.`,
+    inputSchema: {
+      type: "object",
+      properties: { key: keySchema, value: { type: "string", description: "Value to store. Serialize structured data as JSON." } },
+      required: ["key", "value"],
+    },
+    handler: args => textResult(store.set(args.key, args.value)),
+  });
+  registerTool(server, {
+    name: "memory_delete",
+    description: "Delete a key from the persistent memory.",
+    inputSchema: { type: "object", properties: { key: keySchema }, required: ["key"] },
+    handler: args => textResult(store.delete(args.key)),
+  });
+  registerTool(server, {
+    name: "memory_list",
+    description: "List the keys stored in the persistent memory, optionally only those starting with a prefix.",
+    inputSchema: { type: "object", properties: { prefix: { type: "string", description: "Only list keys starting with this prefix" } } },
+    handler: args => textResult(store.list(args.prefix)),
+  });
+
+  start(server);
+}
+
+// If run directly, start the server with command-line arguments
+if (require.main === module) {
+  const memoryDir = process.argv[2];
+  if (!memoryDir) {
+    console.error("Usage: node memory_mcp_server.cjs <memory-dir>");
+    process.exit(1);
+  }
+
+  try {
+    startMemoryServer(memoryDir);
+  } catch (error) {
+    console.error(`Error starting memory server: ${getErrorMessage(error)}`);
+    process.exit(1);
+  }
+}
+
+module.exports = {
+  createMemoryStore,
+  getLimitsFromEnv,
+  startMemoryServer,
+};
@@ -0,0 +1,92 @@
+// @ts-check
+
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import fs from "fs";
+import path from "path";
+import os from "os";
+
+const { createMemoryStore, getLimitsFromEnv } = require("./memory_mcp_server.cjs");
+
+describe("createMemoryStore", () => {
+  let tempDir = "";
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), "memory-mcp-test-"));
+  });
+
+  afterEach(() => {
+    if (tempDir && fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it("stores, reads and deletes values", () => {
+    const store = createMemoryStore(tempDir, { maxKeys: 10, maxValueSize: 100 });
+
+    expect(store.get("seen/issues")).toEqual({ key: "seen/issues", found: false });
+    expect(store.set("seen/issues", "[1,2,3]")).toEqual({ key: "seen/issues", stored: true });
+    expect(store.get("seen/issues")).toEqual({ key: "seen/issues", found: true, value: "[1,2,3]" });
+
+    const persisted = JSON.parse(fs.readFileSync(path.join(tempDir, "memory.json"), "utf8"));
+    expect(persisted).toEqual({ "seen/issues": "[1,2,3]" });
+
+    expect(store.delete("seen/issues")).toEqual({ key: "seen/issues", deleted: true });
+    expect(store.delete("seen/issues")).toEqual({ key: "seen/issues", deleted: false });
+  });
+
+  it("lists keys sorted and filtered by prefix", () => {
+    const store = createMemoryStore(tempDir, { maxKeys: 10, maxValueSize: 100 });
+    store.set("runs:last", "2026-10-01");
+    store.set("issues:42", "triaged");
+    store.set("issues:7", "closed");
+
+    expect(store.list()).toEqual({ keys: ["issues:42", "issues:7", "runs:last"], count: 3, maxKeys: 10 });
+    expect(store.list("issues:").keys).toEqual(["issues:42", "issues:7"]);
+  });
+
+  it("loads a store restored from a previous run", () => {
+    fs.writeFileSync(path.join(tempDir, "memory.json"), JSON.stringify({ conclusion: "flaky test" }));
+    const store = createMemoryStore(tempDir, { maxKeys: 10, maxValueSize: 100 });
+
+    expect(store.get("conclusion").value).toBe("flaky test");
+  });
+
+  it("rejects invalid keys and non-string values", () => {
+    const store = createMemoryStore(tempDir, { maxKeys: 10, maxValueSize: 100 });
+
+    expect(() => store.set("../escape", "x")).toThrow(/Invalid key/);
+    expect(() => store.get("")).toThrow(/Invalid key/);
+    expect(() => store.set("key", { nested: true })).toThrow(/value must be a string/);
+  });
+
+  it("enforces the value size and key count limits", () => {
+    const store = createMemoryStore(tempDir, { maxKeys: 2, maxValueSize: 5 });
+
+    expect(() => store.set("a", "123456")).toThrow(/exceeds the limit of 5 bytes/);
+    store.set("a", "1");
+    store.set("b", "2");
+    expect(() => store.set("c", "3")).toThrow(/maximum of 2 keys/);
+    // Overwriting an existing key is allowed at the limit
+    expect(store.set("a", "11")).toEqual({ key: "a", stored: true });
+  });
+});
+
+describe("getLimitsFromEnv", () => {
+  const originalEnv = { ...process.env };
+
+  afterEach(() => {
+    process.env = { ...originalEnv };
+  });
+
+  it("uses defaults when unset or invalid", () => {
+    delete process.env.GH_AW_MEMORY_MAX_KEYS;
+    process.env.GH_AW_MEMORY_MAX_VALUE_SIZE = "not-a-number";
+    expect(getLimitsFromEnv()).toEqual({ maxKeys: 100, maxValueSize: 1024 });
+  });
+
+  it("reads limits from the environment", () => {
+    process.env.GH_AW_MEMORY_MAX_KEYS = "50";
+    process.env.GH_AW_MEMORY_MAX_VALUE_SIZE = "2048";
+    expect(getLimitsFromEnv()).toEqual({ maxKeys: 50, maxValueSize: 2048 });
+  });
+});
@@ -0,0 +1,143 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+// This script posts a signed JSON summary of the workflow run to the webhook
+// configured with notifications.webhook. The summary contains the agent
+// conclusion, run duration, token usage and the safe outputs produced by the
+// agent, so platform teams can aggregate agent activity without polling GitHub.
+//
+// The payload is signed with HMAC-SHA256 using notifications.secret and the
+// signature is sent in the X-GH-AW-Signature-256 header as "sha256=<hex>",
+// the same scheme GitHub uses for its own webhooks.
+
+const crypto = require("crypto");
+const { loadAgentOutput } = require("./load_agent_output.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { parseTokenUsage, countOutputTypes } = require("./summary_comment.cjs");
+
+/** Timeout of the webhook request in milliseconds */
+const WEBHOOK_TIMEOUT_MS = 10000;
+
+/** Notification events by agent job result */
+const EVENTS_BY_RESULT = {
+  success: "completed",
+  failure: "failed",
+  cancelled: "cancelled",
+};
+
+/**
+ * Computes the signature header value of a payload
+ * @param {string} payload - Raw request body
+ * @param {string} secret - Signing secret
+ * @returns {string} Signature in the form "sha256=<hex>"
+ */
+function signPayload(payload, secret) {
+  return "sha256=" + crypto.createHmac("sha256", secret).update(payload).digest("hex");
+}
+
+/**
+ * Looks up when the workflow run started, to report its duration
+ * @returns {Promise<string|undefined>} ISO timestamp, or undefined when it cannot be read
+ */
+async function getRunStartedAt() {
+  try {
+    const { data } = await github.rest.actions.getWorkflowRun({
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      run_id: context.runId,
+    });
+    return data.run_started_at || data.created_at;
+  } catch (error) {
+    core.warning(`Failed to read the workflow run start time: ${getErrorMessage(error)}`);
+    return undefined;
+  }
+}
+
+/**
+ * Builds the notification payload
+ * @param {Object} options
+ * @param {string} options.conclusion - Agent job result
+ * @param {string|undefined} options.startedAt - Run start time (ISO timestamp)
+ * @param {Date} options.completedAt - Notification time
+ * @param {{tokens: number, cost_usd: number}|null} options.usage - Token usage
+ * @param {Array<{type: string}>} options.items - Safe output items produced by the agent
+ * @returns {Record<string, any>} Payload
+ */
+function buildWebhookPayload({ conclusion, startedAt, completedAt, usage, items }) {
+  const started = startedAt ? new Date(startedAt) : undefined;
+  const durationSeconds = started && !isNaN(started.getTime()) ? Math.max(0, Math.round((completedAt.getTime() - started.getTime()) / 1000)) : null;
+
+  return {
+    event: EVENTS_BY_RESULT[/** @type {keyof typeof EVENTS_BY_RESULT} */ (conclusion)] || conclusion,
+    conclusion,
+    workflow: {
+      name: process.env.GH_AW_WORKFLOW_NAME || "",
+      id: process.env.GH_AW_WORKFLOW_ID || "",
+    },
+    repository: `${context.repo.owner}/${context.repo.repo}`,
+    run: {
+      id: context.runId,
+      attempt: Number(process.env.GITHUB_RUN_ATTEMPT || 1),
+      url: process.env.GH_AW_RUN_URL || "",
+      trigger: context.eventName,
+      actor: context.actor,
+      started_at: started && durationSeconds !== null ? started.toISOString() : null,
+      completed_at: completedAt.toISOString(),
+      duration_seconds: durationSeconds,
+    },
+    usage: {
+      tokens: usage ? usage.tokens : null,
+      cost_usd: usage ? usage.cost_usd : null,
+    },
+    safe_outputs: Object.fromEntries(countOutputTypes(items)),
+  };
+}
+
+async function main() {
+  const webhookUrl = process.env.GH_AW_WEBHOOK_URL || "";
+  if (!webhookUrl.startsWith("https://")) {
+    core.warning("notifications.webhook is not set to an https:// URL, skipping webhook notification");
+    return;
+  }
+
+  const secret = process.env.GH_AW_WEBHOOK_SECRET || "";
+  if (!secret) {
+    core.warning("The webhook signing secret is empty (set the GH_AW_WEBHOOK_SECRET secret or notifications.secret), skipping webhook notification");
+    return;
+  }
+
+  const agentOutput = loadAgentOutput();
+  const payload = buildWebhookPayload({
+    conclusion: process.env.GH_AW_AGENT_CONCLUSION || "failure",
+    startedAt: await getRunStartedAt(),
+    completedAt: new Date(),
+    usage: parseTokenUsage(process.env.GH_AW_TOKEN_USAGE),
+    items: agentOutput.success ? agentOutput.items || [] : [],
+  });
+  const body = JSON.stringify(payload);
+
+  core.info(`Posting ${payload.event} notification to webhook`);
+  try {
+    const response = await fetch(webhookUrl, {
+      method: "POST",
+      headers: {
+        "Content-Type": "application/json",
+        "User-Agent": "gh-aw-webhook",
+        "X-GH-AW-Event": payload.event,
+        "X-GH-AW-Signature-256": signPayload(body, secret),
+      },
+      body,
+      signal: AbortSignal.timeout(WEBHOOK_TIMEOUT_MS),
+    });
+    if (!response.ok) {
+      core.warning(`Webhook responded with HTTP ${response.status}`);
+      return;
+    }
+    core.info(`Webhook responded with HTTP ${response.status}`);
+  } catch (error) {
+    // Don't fail the run - notifications are informational
+    core.warning(`Failed to post webhook notification: ${getErrorMessage(error)}`);
+  }
+}
+
+module.exports = { main, signPayload, buildWebhookPayload };
@@ -0,0 +1,143 @@
+// @ts-check
+
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import crypto from "crypto";
+import fs from "fs";
+import path from "path";
+import os from "os";
+
+describe("notify_webhook", () => {
+  let mockCore;
+  let mockGithub;
+  let mockFetch;
+  let originalEnv;
+  let originalFetch;
+  let tempDir;
+
+  beforeEach(() => {
+    originalEnv = { ...process.env };
+    originalFetch = global.fetch;
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), "notify-webhook-test-"));
+
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setOutput: vi.fn(),
+    };
+
+    mockGithub = {
+      rest: {
+        actions: {
+          getWorkflowRun: vi.fn().mockResolvedValue({ data: { run_started_at: "2026-01-01T10:00:00Z" } }),
+        },
+      },
+    };
+
+    mockFetch = vi.fn().mockResolvedValue({ ok: true, status: 204 });
+
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.fetch = mockFetch;
+    global.context = {
+      repo: { owner: "test-owner", repo: "test-repo" },
+      runId: 123,
+      eventName: "issues",
+      actor: "octocat",
+      payload: {},
+    };
+
+    const agentOutputFile = path.join(tempDir, "agent_output.json");
+    fs.writeFileSync(agentOutputFile, JSON.stringify({ items: [{ type: "add_labels" }, { type: "add_labels" }, { type: "create_issue" }] }));
+    process.env.GH_AW_AGENT_OUTPUT = agentOutputFile;
+    process.env.GH_AW_WEBHOOK_URL = "https://hooks.example.com/gh-aw";
+    process.env.GH_AW_WEBHOOK_SECRET = "s3cret";
+    process.env.GH_AW_WORKFLOW_NAME = "Triage";
+    process.env.GH_AW_WORKFLOW_ID = "triage";
+    process.env.GH_AW_RUN_URL = "https://github.com/test-owner/test-repo/actions/runs/123";
+    process.env.GH_AW_AGENT_CONCLUSION = "success";
+    process.env.GH_AW_TOKEN_USAGE = JSON.stringify({ tokens: 12345, cost_usd: 0.5 });
+  });
+
+  afterEach(() => {
+    for (const key of Object.keys(process.env)) {
+      if (!(key in originalEnv)) {
+        delete process.env[key];
+      }
+    }
+    Object.assign(process.env, originalEnv);
+    global.fetch = originalFetch;
+    fs.rmSync(tempDir, { recursive: true, force: true });
+    delete global.core;
+    delete global.github;
+    delete global.context;
+    vi.resetModules();
+  });
+
+  it("should post a signed run summary", async () => {
+    const { main } = await import("./notify_webhook.cjs");
+    await main();
+
+    expect(mockFetch).toHaveBeenCalledTimes(1);
+    const [url, request] = mockFetch.mock.calls[0];
+    expect(url).toBe("https://hooks.example.com/gh-aw");
+    expect(request.method).toBe("POST");
+
+    const expectedSignature = "sha256=" + crypto.createHmac("sha256", "s3cret").update(request.body).digest("hex");
+    expect(request.headers["X-GH-AW-Signature-256"]).toBe(expectedSignature);
+    expect(request.headers["X-GH-AW-Event"]).toBe("completed");
+
+    const payload = JSON.parse(request.body);
+    expect(payload.event).toBe("completed");
+    expect(payload.conclusion).toBe("success");
+    expect(payload.workflow).toEqual({ name: "Triage", id: "triage" });
+    expect(payload.repository).toBe("test-owner/test-repo");
+    expect(payload.run.id).toBe(123);
+    expect(payload.run.started_at).toBe("2026-01-01T10:00:00.000Z");
+    expect(payload.run.duration_seconds).toBeGreaterThan(0);
+    expect(payload.usage).toEqual({ tokens: 12345, cost_usd: 0.5 });
+    expect(payload.safe_outputs).toEqual({ add_labels: 2, create_issue: 1 });
+  });
+
+  it("should map failures to the failed event", async () => {
+    process.env.GH_AW_AGENT_CONCLUSION = "failure";
+    delete process.env.GH_AW_TOKEN_USAGE;
+
+    const { main } = await import("./notify_webhook.cjs");
+    await main();
+
+    const payload = JSON.parse(mockFetch.mock.calls[0][1].body);
+    expect(payload.event).toBe("failed");
+    expect(payload.usage).toEqual({ tokens: null, cost_usd: null });
+  });
+
+  it("should skip the notification without a signing secret", async () => {
+    process.env.GH_AW_WEBHOOK_SECRET = "";
+
+    const { main } = await import("./notify_webhook.cjs");
+    await main();
+
+    expect(mockFetch).not.toHaveBeenCalled();
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("signing secret is empty"));
+  });
+
+  it("should not fail the run when the webhook is unreachable", async () => {
+    mockFetch.mockRejectedValue(new Error("connect ECONNREFUSED"));
+
+    const { main } = await import("./notify_webhook.cjs");
+    await main();
+
+    expect(mockCore.warning).toHaveBeenCalledWith("Failed to post webhook notification: connect ECONNREFUSED");
+  });
+
+  it("should report a missing duration when the run cannot be read", async () => {
+    mockGithub.rest.actions.getWorkflowRun.mockRejectedValue(new Error("Resource not accessible"));
+
+    const { main } = await import("./notify_webhook.cjs");
+    await main();
+
+    const payload = JSON.parse(mockFetch.mock.calls[0][1].body);
+    expect(payload.run.started_at).toBeNull();
+    expect(payload.run.duration_seconds).toBeNull();
+  });
+});
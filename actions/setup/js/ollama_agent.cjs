@@ -0,0 +1,77 @@
+// @ts-check
+
+const fs = require("fs");
+const { logEvent, loadMCPTools, runAgent } = require("./github_models_agent.cjs");
+
+/**
+ * Agent loop of the ollama engine.
+ *
+ * Runs the github-models agent loop against the OpenAI-compatible chat completions API of a
+ * self-hosted Ollama server (<endpoint>/v1), so no request leaves the runner's network.
+ * Events are written to stdout in the same JSONL format as github_models_agent.cjs.
+ *
+ * Environment:
+ *  - GH_AW_PROMPT: rendered prompt file
+ *  - GH_AW_OLLAMA_ENDPOINT: Ollama server URL (default: http://localhost:11434)
+ *  - GH_AW_OLLAMA_MODEL: model name (default: llama3.1)
+ *  - GH_AW_MCP_CONFIG: MCP server configuration written by convert_gateway_config_github_models.sh (optional)
+ *  - GH_AW_MAX_TURNS: maximum number of model requests (default: 30)
+ */
+
+const DEFAULT_ENDPOINT = "http://localhost:11434";
+const DEFAULT_MODEL = "llama3.1";
+const DEFAULT_MAX_TURNS = 30;
+
+/**
+ * Returns the OpenAI-compatible API base of an Ollama server
+ * @param {string} endpoint - Ollama server URL
+ * @returns {string} API base URL
+ */
+function openAIBaseURL(endpoint) {
+  const base = endpoint.replace(/\/+$/, "");
+  return base.endsWith("/v1") ? base : `${base}/v1`;
+}
+
+async function main() {
+  const promptPath = process.env.GH_AW_PROMPT || "";
+  const endpoint = process.env.GH_AW_OLLAMA_ENDPOINT || DEFAULT_ENDPOINT;
+  const model = process.env.GH_AW_OLLAMA_MODEL || DEFAULT_MODEL;
+  const maxTurns = parseInt(process.env.GH_AW_MAX_TURNS || "", 10) || DEFAULT_MAX_TURNS;
+
+  if (!promptPath) {
+    process.stderr.write("GH_AW_PROMPT is required\n");
+    process.exit(1);
+  }
+
+  const prompt = fs.readFileSync(promptPath, "utf8");
+  const { tools, routes } = await loadMCPTools(process.env.GH_AW_MCP_CONFIG || "");
+  logEvent({ type: "init", model, endpoint, tools: tools.map(tool => tool.function.name) });
+
+  try {
+    // Ollama ignores the bearer token, but the OpenAI-compatible API expects one to be sent
+    const stats = await runAgent({ prompt, model, endpoint: openAIBaseURL(endpoint), token: "ollama", maxTurns, tools, routes, provider: "Ollama" });
+    logEvent({
+      type: "result",
+      status: stats.status,
+      stats: { turns: stats.turns, input_tokens: stats.inputTokens, output_tokens: stats.outputTokens, tool_calls: stats.toolCalls },
+    });
+    if (stats.status !== "success") {
+      process.stderr.write(`Stopped after ${stats.turns} turns (max-turns: ${maxTurns})\n`);
+      process.exit(1);
+    }
+  } catch (error) {
+    const message = error instanceof Error ? error.message : String(error);
+    logEvent({ type: "result", status: "error", error: message });
+    process.stderr.write(message + "\n");
+    process.exit(1);
+  }
+}
+
+if (require.main === module) {
+  main();
+}
+
+module.exports = {
+  openAIBaseURL,
+  main,
+};
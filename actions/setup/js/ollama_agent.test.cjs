@@ -0,0 +1,43 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+describe("ollama_agent.cjs", () => {
+  let agent;
+  let githubModelsAgent;
+  const originalFetch = global.fetch;
+
+  beforeEach(async () => {
+    vi.clearAllMocks();
+    agent = await import("./ollama_agent.cjs");
+    githubModelsAgent = await import("./github_models_agent.cjs");
+  });
+
+  afterEach(() => {
+    global.fetch = originalFetch;
+    vi.restoreAllMocks();
+  });
+
+  describe("openAIBaseURL", () => {
+    it("appends the OpenAI-compatible API path", () => {
+      expect(agent.openAIBaseURL("http://localhost:11434")).toBe("http://localhost:11434/v1");
+      expect(agent.openAIBaseURL("http://localhost:11434/")).toBe("http://localhost:11434/v1");
+    });
+
+    it("keeps endpoints that already point at the API", () => {
+      expect(agent.openAIBaseURL("http://ollama.internal:11434/v1")).toBe("http://ollama.internal:11434/v1");
+    });
+  });
+
+  describe("runAgent with the Ollama provider", () => {
+    it("names the provider in errors", async () => {
+      vi.spyOn(process.stdout, "write").mockImplementation(() => true);
+      global.fetch = vi.fn(async url => {
+        expect(url).toBe("http://localhost:11434/v1/chat/completions");
+        return new Response('{"error":"model not found"}', { status: 404 });
+      });
+
+      await expect(
+        githubModelsAgent.runAgent({ prompt: "Hi", model: "llama3.1", endpoint: agent.openAIBaseURL("http://localhost:11434"), token: "ollama", maxTurns: 1, tools: [], routes: new Map(), provider: "Ollama" })
+      ).rejects.toThrow("Ollama request failed with HTTP 404");
+    });
+  });
+});
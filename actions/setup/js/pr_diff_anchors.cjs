@@ -0,0 +1,139 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * PR Diff Anchors
+ *
+ * GitHub rejects a whole pull request review when any of its inline comments is
+ * anchored to a line that is not part of the diff. These helpers parse the patches
+ * of a pull request's changed files so that review comments can be validated
+ * before they are buffered, and invalid comments skipped individually.
+ *
+ * A line can be commented on when it appears in a diff hunk: added and context
+ * lines on the RIGHT side (new file), deleted and context lines on the LEFT side
+ * (old file). A multi-line comment must start and end in the same hunk.
+ */
+
+/** Number of files requested per page when listing changed files */
+const FILES_PER_PAGE = 100;
+
+/** Maximum number of pages fetched (the API lists at most 3000 files) */
+const MAX_FILE_PAGES = 30;
+
+/**
+ * @typedef {Object} DiffHunk
+ * @property {Set<number>} left - Line numbers of the old file shown in the hunk
+ * @property {Set<number>} right - Line numbers of the new file shown in the hunk
+ */
+
+/**
+ * Parse the unified diff patch of a file into hunks
+ * @param {string} patch - Patch text as returned by pulls.listFiles
+ * @returns {DiffHunk[]} Hunks with the commentable lines of each side
+ */
+function parsePatchHunks(patch) {
+  /** @type {DiffHunk[]} */
+  const hunks = [];
+  /** @type {DiffHunk | null} */
+  let hunk = null;
+  let leftLine = 0;
+  let rightLine = 0;
+
+  for (const line of patch.split("\n")) {
+    const header = line.match(/^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@/);
+    if (header) {
+      hunk = { left: new Set(), right: new Set() };
+      hunks.push(hunk);
+      leftLine = parseInt(header[1], 10);
+      rightLine = parseInt(header[2], 10);
+      continue;
+    }
+    if (!hunk) {
+      continue;
+    }
+    if (line.startsWith("+")) {
+      hunk.right.add(rightLine++);
+    } else if (line.startsWith("-")) {
+      hunk.left.add(leftLine++);
+    } else if (line.startsWith(" ")) {
+      hunk.left.add(leftLine++);
+      hunk.right.add(rightLine++);
+    }
+    // "\ No newline at end of file" markers do not advance either side
+  }
+
+  return hunks;
+}
+
+/**
+ * Fetch the diff hunks of every file changed by a pull request
+ * @param {any} githubClient - Authenticated GitHub client
+ * @param {string} owner - Repository owner
+ * @param {string} repo - Repository name
+ * @param {number} pullNumber - Pull request number
+ * @returns {Promise<Map<string, DiffHunk[] | null>>} Hunks by file path; null when the API returns no patch (binary or very large files)
+ */
+async function fetchPullRequestDiffHunks(githubClient, owner, repo, pullNumber) {
+  /** @type {Map<string, DiffHunk[] | null>} */
+  const files = new Map();
+  for (let page = 1; page <= MAX_FILE_PAGES; page++) {
+    const { data } = await githubClient.rest.pulls.listFiles({ owner, repo, pull_number: pullNumber, per_page: FILES_PER_PAGE, page });
+    for (const file of data) {
+      files.set(file.filename, typeof file.patch === "string" ? parsePatchHunks(file.patch) : null);
+    }
+    if (data.length < FILES_PER_PAGE) {
+      break;
+    }
+  }
+  return files;
+}
+
+/**
+ * Summarize line numbers as ranges, e.g. "3-5, 9"
+ * @param {number[]} lines - Line numbers
+ * @returns {string}
+ */
+function formatLineRanges(lines) {
+  const sorted = [...lines].sort((a, b) => a - b);
+  /** @type {string[]} */
+  const ranges = [];
+  for (let i = 0; i < sorted.length; i++) {
+    const start = sorted[i];
+    while (i + 1 < sorted.length && sorted[i + 1] === sorted[i] + 1) {
+      i++;
+    }
+    ranges.push(start === sorted[i] ? `${start}` : `${start}-${sorted[i]}`);
+  }
+  return ranges.join(", ");
+}
+
+/**
+ * Check that a review comment is anchored to lines of the pull request diff
+ * @param {Map<string, DiffHunk[] | null>} diffFiles - Hunks by file path, from fetchPullRequestDiffHunks
+ * @param {{path: string, line: number, start_line?: number, side: string}} anchor - Comment anchor
+ * @returns {string | null} Error message, or null when the anchor is valid or cannot be checked
+ */
+function validateDiffAnchor(diffFiles, anchor) {
+  const { path, line, start_line: startLine, side } = anchor;
+  if (!diffFiles.has(path)) {
+    return `File "${path}" is not changed in the pull request`;
+  }
+  const hunks = diffFiles.get(path);
+  if (!hunks) {
+    // No patch is available for binary or very large files; let the API decide
+    return null;
+  }
+
+  const sideKey = side === "LEFT" ? "left" : "right";
+  const hunk = hunks.find(h => h[sideKey].has(line));
+  if (!hunk) {
+    const shown = hunks.flatMap(h => [...h[sideKey]]);
+    return `Line ${line} of "${path}" is not part of the diff on the ${side} side (lines in the diff: ${formatLineRanges(shown) || "none"})`;
+  }
+  if (startLine !== undefined && !hunk[sideKey].has(startLine)) {
+    return `Lines ${startLine}-${line} of "${path}" do not fall within a single diff hunk on the ${side} side (hunk lines: ${formatLineRanges([...hunk[sideKey]])})`;
+  }
+  return null;
+}
+
+module.exports = { parsePatchHunks, fetchPullRequestDiffHunks, formatLineRanges, validateDiffAnchor };
@@ -0,0 +1,68 @@
+import { describe, it, expect, vi } from "vitest";
+
+const { parsePatchHunks, fetchPullRequestDiffHunks, formatLineRanges, validateDiffAnchor } = require("./pr_diff_anchors.cjs");
+
+describe("pr_diff_anchors.cjs", () => {
+  const patch = ["@@ -1,3 +1,3 @@", " const a = 1;", "-const b = 2;", "+const b = 3;", " const c = 4;", "@@ -20,2 +20,3 @@ function f() {", "   return a;", "+  // done", " }", "\\ No newline at end of file"].join("\n");
+
+  describe("parsePatchHunks", () => {
+    it("should collect the lines of each side per hunk", () => {
+      const hunks = parsePatchHunks(patch);
+      expect(hunks).toHaveLength(2);
+      expect([...hunks[0].left]).toEqual([1, 2, 3]);
+      expect([...hunks[0].right]).toEqual([1, 2, 3]);
+      expect([...hunks[1].left]).toEqual([20, 21]);
+      expect([...hunks[1].right]).toEqual([20, 21, 22]);
+    });
+  });
+
+  describe("formatLineRanges", () => {
+    it("should collapse consecutive lines", () => {
+      expect(formatLineRanges([9, 3, 4, 5, 12, 11])).toBe("3-5, 9, 11-12");
+      expect(formatLineRanges([])).toBe("");
+    });
+  });
+
+  describe("validateDiffAnchor", () => {
+    const diffFiles = new Map([
+      ["src/a.js", parsePatchHunks(patch)],
+      ["image.png", null],
+    ]);
+
+    it("should accept lines in a hunk", () => {
+      expect(validateDiffAnchor(diffFiles, { path: "src/a.js", line: 22, side: "RIGHT" })).toBeNull();
+      expect(validateDiffAnchor(diffFiles, { path: "src/a.js", line: 3, start_line: 1, side: "LEFT" })).toBeNull();
+    });
+
+    it("should reject lines outside the diff", () => {
+      expect(validateDiffAnchor(diffFiles, { path: "src/a.js", line: 22, side: "LEFT" })).toBe('Line 22 of "src/a.js" is not part of the diff on the LEFT side (lines in the diff: 1-3, 20-21)');
+    });
+
+    it("should reject ranges spanning hunks", () => {
+      expect(validateDiffAnchor(diffFiles, { path: "src/a.js", line: 21, start_line: 2, side: "RIGHT" })).toContain("do not fall within a single diff hunk");
+    });
+
+    it("should reject files that are not changed and accept files without a patch", () => {
+      expect(validateDiffAnchor(diffFiles, { path: "src/b.js", line: 1, side: "RIGHT" })).toBe('File "src/b.js" is not changed in the pull request');
+      expect(validateDiffAnchor(diffFiles, { path: "image.png", line: 1, side: "RIGHT" })).toBeNull();
+    });
+  });
+
+  describe("fetchPullRequestDiffHunks", () => {
+    it("should page through the changed files", async () => {
+      const firstPage = Array.from({ length: 100 }, (_, i) => ({ filename: `file${i}.js`, patch: "@@ -1 +1 @@\n-a\n+b" }));
+      const listFiles = vi
+        .fn()
+        .mockResolvedValueOnce({ data: firstPage })
+        .mockResolvedValueOnce({ data: [{ filename: "last.bin" }] });
+
+      const files = await fetchPullRequestDiffHunks({ rest: { pulls: { listFiles } } }, "owner", "repo", 7);
+
+      expect(listFiles).toHaveBeenCalledTimes(2);
+      expect(listFiles).toHaveBeenLastCalledWith({ owner: "owner", repo: "repo", pull_number: 7, per_page: 100, page: 2 });
+      expect(files.size).toBe(101);
+      expect(files.get("last.bin")).toBeNull();
+      expect([...files.get("file0.js")[0].right]).toEqual([1]);
+    });
+  });
+});
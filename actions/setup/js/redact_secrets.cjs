@@ -4,7 +4,9 @@
 /**
  * Redacts secrets from files in /tmp/gh-aw and /opt/gh-aw directories before uploading artifacts
  * This script processes all .txt, .json, .log, .md, .mdx, .yml, .jsonl files under /tmp/gh-aw and /opt/gh-aw
- * and redacts any strings matching the actual secret values provided via environment variables.
+ * and redacts any strings matching the actual secret values provided via environment variables,
+ * the built-in credential patterns, and the custom patterns from secret-masking.patterns.
+ * Redaction counts are reported in the step summary.
  */
 const fs = require("fs");
 const path = require("path");
@@ -72,21 +74,72 @@ const BUILT_IN_PATTERNS = [
 
   // Anthropic tokens
   { name: "Anthropic API Key", pattern: /sk-ant-api03-[a-zA-Z0-9_-]{95}/g },
+
+  // Slack tokens
+  { name: "Slack Token", pattern: /xox[abprs]-[0-9A-Za-z-]{10,250}/g },
+  { name: "Slack Webhook URL", pattern: /https:\/\/hooks\.slack\.com\/services\/T[0-9A-Z]{8,12}\/B[0-9A-Z]{8,12}\/[0-9A-Za-z]{24}/g },
+
+  // Stripe tokens
+  { name: "Stripe Secret Key", pattern: /[sr]k_live_[0-9a-zA-Z]{24,99}/g },
+
+  // npm tokens
+  { name: "npm Access Token", pattern: /npm_[0-9a-zA-Z]{36}/g },
+
+  // Private keys
+  { name: "Private Key", pattern: /-----BEGIN [A-Z ]{0,20}PRIVATE KEY-----[\s\S]{1,8192}?-----END [A-Z ]{0,20}PRIVATE KEY-----/g },
 ];
 
 /**
- * Detects and redacts secrets matching built-in patterns
+ * Parses the custom redaction patterns configured in secret-masking.patterns
+ * @param {string | undefined} patternsJSON - JSON array of regular expression sources
+ * @returns {{name: string, pattern: RegExp}[]} Compiled patterns; invalid entries are skipped with a warning
+ */
+function parseCustomPatterns(patternsJSON) {
+  if (!patternsJSON) {
+    return [];
+  }
+  let sources;
+  try {
+    sources = JSON.parse(patternsJSON);
+  } catch (error) {
+    core.warning(`Failed to parse GH_AW_SECRET_PATTERNS: ${getErrorMessage(error)}`);
+    return [];
+  }
+  if (!Array.isArray(sources)) {
+    core.warning("GH_AW_SECRET_PATTERNS must be a JSON array of regular expressions");
+    return [];
+  }
+  const patterns = [];
+  for (const source of sources) {
+    if (typeof source !== "string" || source === "") {
+      continue;
+    }
+    try {
+      patterns.push({ name: `Custom pattern /${source}/`, pattern: new RegExp(source, "g") });
+    } catch (error) {
+      core.warning(`Skipping invalid custom secret pattern /${source}/: ${getErrorMessage(error)}`);
+    }
+  }
+  return patterns;
+}
+
+/**
+ * Detects and redacts secrets matching built-in patterns, followed by any custom patterns
  * @param {string} content - File content to process
- * @returns {{content: string, redactionCount: number, detectedPatterns: string[]}} Redacted content, count, and detected pattern types
+ * @param {{name: string, pattern: RegExp}[]} [customPatterns] - Additional patterns from secret-masking.patterns
+ * @returns {{content: string, redactionCount: number, detectedPatterns: string[], patternCounts: Record<string, number>}} Redacted content, count, detected pattern types, and count per pattern type
  */
-function redactBuiltInPatterns(content) {
+function redactBuiltInPatterns(content, customPatterns = []) {
   let redactionCount = 0;
   let redacted = content;
   const detectedPatterns = [];
+  /** @type {Record<string, number>} */
+  const patternCounts = {};
 
-  for (const { name, pattern } of BUILT_IN_PATTERNS) {
-    const matches = redacted.match(pattern);
-    if (matches && matches.length > 0) {
+  for (const { name, pattern } of [...BUILT_IN_PATTERNS, ...customPatterns]) {
+    // Skip zero-length matches so a pattern like /a*/ cannot loop or redact nothing
+    const matches = (redacted.match(pattern) || []).filter(match => match.length > 0);
+    if (matches.length > 0) {
       // Redact each match with fixed-length string
       const replacement = "***REDACTED***";
       for (const match of matches) {
@@ -94,11 +147,12 @@ function redactBuiltInPatterns(content) {
       }
       redactionCount += matches.length;
       detectedPatterns.push(name);
+      patternCounts[name] = matches.length;
       core.info(`Redacted ${matches.length} occurrence(s) of ${name}`);
     }
   }
 
-  return { content: redacted, redactionCount, detectedPatterns };
+  return { content: redacted, redactionCount, detectedPatterns, patternCounts };
 }
 
 /**
@@ -137,21 +191,29 @@ function redactSecrets(content, secretValues) {
  * Process a single file for secret redaction
  * @param {string} filePath - Path to the file
  * @param {string[]} secretValues - Array of secret values to redact
+ * @param {{name: string, pattern: RegExp}[]} customPatterns - Custom patterns from secret-masking.patterns
+ * @param {Record<string, number>} counts - Redaction counts per type, updated in place
  * @returns {number} Number of redactions made
  */
-function processFile(filePath, secretValues) {
+function processFile(filePath, secretValues, customPatterns, counts) {
   try {
     const content = fs.readFileSync(filePath, "utf8");
 
-    // First, redact built-in patterns
-    const builtInResult = redactBuiltInPatterns(content);
+    // First, redact built-in and custom patterns
+    const builtInResult = redactBuiltInPatterns(content, customPatterns);
     let redacted = builtInResult.content;
     let totalRedactions = builtInResult.redactionCount;
+    for (const [name, count] of Object.entries(builtInResult.patternCounts)) {
+      counts[name] = (counts[name] || 0) + count;
+    }
 
     // Then, redact custom secrets
     const customResult = redactSecrets(redacted, secretValues);
     redacted = customResult.content;
     totalRedactions += customResult.redactionCount;
+    if (customResult.redactionCount > 0) {
+      counts["Workflow secret value"] = (counts["Workflow secret value"] || 0) + customResult.redactionCount;
+    }
 
     if (totalRedactions > 0) {
       fs.writeFileSync(filePath, redacted, "utf8");
@@ -164,6 +226,23 @@ function processFile(filePath, secretValues) {
   }
 }
 
+/**
+ * Builds the step summary reporting redaction counts per type
+ * @param {Record<string, number>} counts - Redaction counts per type
+ * @param {number} filesWithRedactions - Number of files that contained secrets
+ * @returns {string} Markdown summary
+ */
+function buildRedactionSummary(counts, filesWithRedactions) {
+  const total = Object.values(counts).reduce((sum, count) => sum + count, 0);
+  let summary = "## 🔒 Secret Redaction\n\n";
+  summary += `Redacted ${total} secret-like value(s) in ${filesWithRedactions} file(s) before artifact upload.\n\n`;
+  summary += "| Type | Redactions |\n|------|------------|\n";
+  for (const [name, count] of Object.entries(counts).sort((a, b) => b[1] - a[1])) {
+    summary += `| ${name.replace(/\|/g, "\\|")} | ${count} |\n`;
+  }
+  return summary;
+}
+
 /**
  * Main function
  */
@@ -193,6 +272,11 @@ async function main() {
       core.info(`Found ${secretValues.length} custom secret(s) to redact`);
     }
 
+    const customPatterns = parseCustomPatterns(process.env.GH_AW_SECRET_PATTERNS);
+    if (customPatterns.length > 0) {
+      core.info(`Loaded ${customPatterns.length} custom secret pattern(s)`);
+    }
+
     // Always scan for built-in patterns, even if there are no custom secrets
     core.info("Scanning for built-in credential patterns and custom secrets");
 
@@ -204,9 +288,11 @@ async function main() {
     core.info(`Found ${files.length} file(s) to scan for secrets (${tmpFiles.length} in /tmp/gh-aw, ${optFiles.length} in /opt/gh-aw)`);
     let totalRedactions = 0;
     let filesWithRedactions = 0;
+    /** @type {Record<string, number>} */
+    const counts = {};
     // Process each file
     for (const file of files) {
-      const redactionCount = processFile(file, secretValues);
+      const redactionCount = processFile(file, secretValues, customPatterns, counts);
       if (redactionCount > 0) {
         filesWithRedactions++;
         totalRedactions += redactionCount;
@@ -214,6 +300,7 @@ async function main() {
     }
     if (totalRedactions > 0) {
       core.info(`Secret redaction complete: ${totalRedactions} redaction(s) in ${filesWithRedactions} file(s)`);
+      await core.summary.addRaw(buildRedactionSummary(counts, filesWithRedactions)).write();
     } else {
       core.info("Secret redaction complete: no secrets found");
     }
@@ -222,4 +309,4 @@ async function main() {
   }
 }
 
-module.exports = { main, redactSecrets, redactBuiltInPatterns, BUILT_IN_PATTERNS };
+module.exports = { main, redactSecrets, redactBuiltInPatterns, parseCustomPatterns, buildRedactionSummary, BUILT_IN_PATTERNS };
@@ -0,0 +1,152 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { processItems } = require("./safe_output_processor.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { resolveTarget } = require("./safe_output_helpers.cjs");
+const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
+const { logStagedPreviewInfo } = require("./staged_preview.cjs");
+const { createAuthenticatedGitHubClient } = require("./handler_auth.cjs");
+const { normalizeTeamSlug, filterAllowedTeams } = require("./team_helpers.cjs");
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "request_review";
+
+/**
+ * Main handler factory for request_review
+ * Returns a message handler function that processes individual request_review messages
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  // Extract configuration
+  const allowedUsers = config.allowed_users ?? [];
+  const allowedTeams = (config.allowed_teams ?? []).map(normalizeTeamSlug);
+  const maxCount = config.max ?? 3;
+  const reviewTarget = config.target || "triggering";
+  const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
+  const githubClient = await createAuthenticatedGitHubClient(config);
+
+  // Check if we're in staged mode
+  const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
+
+  core.info(`Request review configuration: max=${maxCount}, target=${reviewTarget}`);
+  if (allowedUsers.length > 0) {
+    core.info(`Allowed users: ${allowedUsers.join(", ")}`);
+  }
+  if (allowedTeams.length > 0) {
+    core.info(`Allowed teams: ${allowedTeams.join(", ")}`);
+  }
+  core.info(`Default target repo: ${defaultTargetRepo}`);
+
+  // Track how many items we've processed for max limit
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single request_review message
+   * @param {Object} message - The request_review message to process
+   * @param {Object} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
+   * @returns {Promise<Object>} Result with success/error status
+   */
+  return async function handleRequestReview(message, resolvedTemporaryIds) {
+    // Check if we've hit the max limit
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping request_review: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    processedCount++;
+
+    // Resolve and validate target repository
+    const repoResult = resolveAndValidateRepo(message, defaultTargetRepo, allowedRepos, "review request");
+    if (!repoResult.success) {
+      core.warning(`Skipping request_review: ${repoResult.error}`);
+      return {
+        success: false,
+        error: repoResult.error,
+      };
+    }
+    const { repo: itemRepo, repoParts } = repoResult;
+
+    // Determine the pull request from the target configuration
+    const targetResult = resolveTarget({
+      targetConfig: reviewTarget,
+      item: message,
+      context,
+      itemType: "review request",
+    });
+    if (!targetResult.success) {
+      core.warning(`Skipping request_review: ${targetResult.error}`);
+      return {
+        success: false,
+        error: targetResult.error,
+      };
+    }
+    const prNumber = targetResult.number;
+
+    // Use shared helper to filter, sanitize, dedupe, and limit
+    const reviewers = processItems(message.reviewers ?? [], allowedUsers, maxCount);
+    const teamReviewers = filterAllowedTeams(message.team_reviewers ?? [], allowedTeams, maxCount);
+
+    if (reviewers.length === 0 && teamReviewers.length === 0) {
+      core.info("No reviewers to request");
+      return {
+        success: true,
+        prNumber,
+        reviewersRequested: [],
+        teamsRequested: [],
+        message: "No valid reviewers found",
+      };
+    }
+
+    core.info(`Requesting reviews on PR #${prNumber} in ${itemRepo}: users=${JSON.stringify(reviewers)}, teams=${JSON.stringify(teamReviewers)}`);
+
+    // If in staged mode, preview without executing
+    if (isStaged) {
+      logStagedPreviewInfo(`Would request reviews on PR #${prNumber} in ${itemRepo}`);
+      return {
+        success: true,
+        staged: true,
+        previewInfo: {
+          number: prNumber,
+          repo: itemRepo,
+          reviewers,
+          teamReviewers,
+        },
+      };
+    }
+
+    try {
+      await githubClient.rest.pulls.requestReviewers({
+        owner: repoParts.owner,
+        repo: repoParts.repo,
+        pull_number: prNumber,
+        reviewers,
+        team_reviewers: teamReviewers,
+      });
+      core.info(`Successfully requested ${reviewers.length + teamReviewers.length} review(s) on PR #${prNumber} in ${itemRepo}`);
+
+      return {
+        success: true,
+        prNumber,
+        reviewersRequested: reviewers,
+        teamsRequested: teamReviewers,
+      };
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      core.error(`Failed to request reviews: ${errorMessage}`);
+      return {
+        success: false,
+        error: errorMessage,
+      };
+    }
+  };
+}
+
+module.exports = { main };
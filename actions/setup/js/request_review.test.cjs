@@ -0,0 +1,164 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+// Mock the global objects that GitHub Actions provides
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  notice: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+  summary: {
+    addRaw: vi.fn().mockReturnThis(),
+    write: vi.fn().mockResolvedValue(),
+  },
+};
+
+const mockGithub = {
+  rest: {
+    pulls: {
+      requestReviewers: vi.fn().mockResolvedValue({}),
+    },
+  },
+};
+
+const mockContext = {
+  eventName: "pull_request",
+  repo: {
+    owner: "testowner",
+    repo: "testrepo",
+  },
+  payload: {
+    pull_request: {
+      number: 123,
+    },
+  },
+};
+
+// Set up global mocks before importing the module
+global.core = mockCore;
+global.github = mockGithub;
+global.context = mockContext;
+
+describe("request_review (Handler Factory Architecture)", () => {
+  let handler;
+
+  beforeEach(async () => {
+    vi.clearAllMocks();
+    global.context = mockContext;
+
+    const { main } = require("./request_review.cjs");
+    handler = await main({
+      max: 5,
+      allowed_users: ["user1", "user2"],
+      allowed_teams: ["docs-team"],
+    });
+  });
+
+  it("should request reviews from users and teams", async () => {
+    const result = await handler({ type: "request_review", reviewers: ["user1"], team_reviewers: ["@testowner/docs-team"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.prNumber).toBe(123);
+    expect(result.reviewersRequested).toEqual(["user1"]);
+    expect(result.teamsRequested).toEqual(["docs-team"]);
+    expect(mockGithub.rest.pulls.requestReviewers).toHaveBeenCalledWith({
+      owner: "testowner",
+      repo: "testrepo",
+      pull_number: 123,
+      reviewers: ["user1"],
+      team_reviewers: ["docs-team"],
+    });
+  });
+
+  it("should filter users and teams that are not allowed", async () => {
+    const result = await handler({ type: "request_review", reviewers: ["user2", "intruder"], team_reviewers: ["admins"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.reviewersRequested).toEqual(["user2"]);
+    expect(result.teamsRequested).toEqual([]);
+  });
+
+  it("should reject every team when no allowed-teams are configured", async () => {
+    const { main } = require("./request_review.cjs");
+    const usersOnlyHandler = await main({ max: 5 });
+
+    const result = await usersOnlyHandler({ type: "request_review", reviewers: ["anyone"], team_reviewers: ["docs-team"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.reviewersRequested).toEqual(["anyone"]);
+    expect(result.teamsRequested).toEqual([]);
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("no allowed-teams are configured"));
+  });
+
+  it("should not call the API when nothing is allowed", async () => {
+    const result = await handler({ type: "request_review", reviewers: ["intruder"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.message).toContain("No valid reviewers found");
+    expect(mockGithub.rest.pulls.requestReviewers).not.toHaveBeenCalled();
+  });
+
+  it("should use the pull request number from the message when target is *", async () => {
+    const { main } = require("./request_review.cjs");
+    const anyHandler = await main({ target: "*" });
+
+    const result = await anyHandler({ type: "request_review", reviewers: ["user1"], pull_request_number: 456 }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.prNumber).toBe(456);
+  });
+
+  it("should skip outside of pull request context with the triggering target", async () => {
+    global.context = { ...mockContext, eventName: "issues", payload: { issue: { number: 1 } } };
+
+    const result = await handler({ type: "request_review", reviewers: ["user1"] }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("not running in pull request context");
+    expect(mockGithub.rest.pulls.requestReviewers).not.toHaveBeenCalled();
+  });
+
+  it("should enforce max count limit", async () => {
+    const { main } = require("./request_review.cjs");
+    const limitedHandler = await main({ max: 1 });
+
+    expect((await limitedHandler({ type: "request_review", reviewers: ["user1"] }, {})).success).toBe(true);
+    const result = await limitedHandler({ type: "request_review", reviewers: ["user2"] }, {});
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Max count");
+  });
+
+  it("should handle API errors gracefully", async () => {
+    mockGithub.rest.pulls.requestReviewers.mockRejectedValueOnce(new Error("Reviews may only be requested from collaborators"));
+
+    const result = await handler({ type: "request_review", reviewers: ["user1"] }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("collaborators");
+  });
+
+  it("should preview in staged mode without calling API", async () => {
+    const originalEnv = process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+
+    try {
+      const { main } = require("./request_review.cjs");
+      const stagedHandler = await main({ allowed_teams: ["docs-team"] });
+
+      const result = await stagedHandler({ type: "request_review", team_reviewers: ["docs-team"] }, {});
+
+      expect(result.success).toBe(true);
+      expect(result.staged).toBe(true);
+      expect(result.previewInfo.teamReviewers).toEqual(["docs-team"]);
+      expect(mockGithub.rest.pulls.requestReviewers).not.toHaveBeenCalled();
+    } finally {
+      if (originalEnv === undefined) {
+        delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+      } else {
+        process.env.GH_AW_SAFE_OUTPUTS_STAGED = originalEnv;
+      }
+    }
+  });
+});
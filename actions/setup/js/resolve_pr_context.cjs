@@ -0,0 +1,98 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/** Maximum number of changed files listed in the changed_files output */
+const MAX_CHANGED_FILES = 300;
+
+/** Number of files requested per page when listing changed files */
+const FILES_PER_PAGE = 100;
+
+/**
+ * Outputs set by this step. All of them are set (possibly empty) so that prompt placeholders
+ * always resolve.
+ */
+const OUTPUT_NAMES = ["number", "head_sha", "head_ref", "base_sha", "base_ref", "changed_files", "changed_files_count", "additions", "deletions"];
+
+/**
+ * Determines the pull request number associated with the triggering event.
+ * Comments on pull requests arrive as issue_comment events whose issue has a pull_request field.
+ *
+ * @param {any} payload - The event payload
+ * @returns {number|null} The pull request number, or null when the event has no pull request
+ */
+function getPullRequestNumber(payload) {
+  if (payload?.pull_request?.number) {
+    return payload.pull_request.number;
+  }
+  if (payload?.issue?.pull_request && payload.issue.number) {
+    return payload.issue.number;
+  }
+  return null;
+}
+
+/**
+ * Lists the files changed by a pull request, up to MAX_CHANGED_FILES
+ * @param {string} owner - Repository owner
+ * @param {string} repo - Repository name
+ * @param {number} pullNumber - Pull request number
+ * @returns {Promise<string[]>} Changed file paths
+ */
+async function listChangedFiles(owner, repo, pullNumber) {
+  /** @type {string[]} */
+  const files = [];
+  for (let page = 1; files.length < MAX_CHANGED_FILES; page++) {
+    const { data } = await github.rest.pulls.listFiles({ owner, repo, pull_number: pullNumber, per_page: FILES_PER_PAGE, page });
+    for (const file of data) {
+      files.push(file.filename);
+    }
+    if (data.length < FILES_PER_PAGE) {
+      break;
+    }
+  }
+  return files.slice(0, MAX_CHANGED_FILES);
+}
+
+async function main() {
+  /** @type {Record<string, string>} */
+  const outputs = Object.fromEntries(OUTPUT_NAMES.map(name => [name, ""]));
+
+  const pullNumber = getPullRequestNumber(context.payload);
+  if (!pullNumber) {
+    core.info(`Event '${context.eventName}' is not associated with a pull request; pull request context outputs are empty`);
+    for (const name of OUTPUT_NAMES) {
+      core.setOutput(name, "");
+    }
+    return;
+  }
+
+  const { owner, repo } = context.repo;
+  try {
+    const { data: pullRequest } = await github.rest.pulls.get({ owner, repo, pull_number: pullNumber });
+    outputs.number = String(pullRequest.number);
+    outputs.head_sha = pullRequest.head?.sha || "";
+    outputs.head_ref = pullRequest.head?.ref || "";
+    outputs.base_sha = pullRequest.base?.sha || "";
+    outputs.base_ref = pullRequest.base?.ref || "";
+    outputs.changed_files_count = String(pullRequest.changed_files ?? "");
+    outputs.additions = String(pullRequest.additions ?? "");
+    outputs.deletions = String(pullRequest.deletions ?? "");
+
+    const files = await listChangedFiles(owner, repo, pullNumber);
+    outputs.changed_files = files.join("\n");
+    if (pullRequest.changed_files > files.length) {
+      core.info(`Listing the first ${files.length} of ${pullRequest.changed_files} changed files`);
+    }
+    core.info(`Resolved pull request #${pullNumber}: head ${outputs.head_sha}, ${outputs.changed_files_count} files changed (+${outputs.additions}/-${outputs.deletions})`);
+  } catch (error) {
+    core.warning(`Failed to resolve pull request #${pullNumber}: ${getErrorMessage(error)}`);
+    outputs.number = String(pullNumber);
+  }
+
+  for (const name of OUTPUT_NAMES) {
+    core.setOutput(name, outputs[name]);
+  }
+}
+
+module.exports = { main, getPullRequestNumber, listChangedFiles, MAX_CHANGED_FILES };
@@ -0,0 +1,119 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const mockCore = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  setOutput: vi.fn(),
+};
+const mockGithub = {
+  rest: {
+    pulls: {
+      get: vi.fn(),
+      listFiles: vi.fn(),
+    },
+  },
+};
+const mockContext = {
+  eventName: "issue_comment",
+  repo: { owner: "test-owner", repo: "test-repo" },
+  payload: {},
+};
+global.core = mockCore;
+global.github = mockGithub;
+global.context = mockContext;
+
+describe("resolve_pr_context.cjs", () => {
+  let main, getPullRequestNumber, MAX_CHANGED_FILES;
+
+  beforeEach(async () => {
+    vi.clearAllMocks();
+    mockContext.eventName = "issue_comment";
+    mockContext.payload = {};
+    const module = await import("./resolve_pr_context.cjs");
+    main = module.main;
+    getPullRequestNumber = module.getPullRequestNumber;
+    MAX_CHANGED_FILES = module.MAX_CHANGED_FILES;
+  });
+
+  /** @returns {Record<string, string>} */
+  const outputs = () => Object.fromEntries(mockCore.setOutput.mock.calls);
+
+  describe("getPullRequestNumber", () => {
+    it("should use the pull request of pull request events", () => {
+      expect(getPullRequestNumber({ pull_request: { number: 7 } })).toBe(7);
+    });
+
+    it("should use the issue number of comments on pull requests", () => {
+      expect(getPullRequestNumber({ issue: { number: 12, pull_request: { url: "https://api.github.com/pulls/12" } } })).toBe(12);
+    });
+
+    it("should return null for comments on issues", () => {
+      expect(getPullRequestNumber({ issue: { number: 12 } })).toBeNull();
+      expect(getPullRequestNumber({})).toBeNull();
+    });
+  });
+
+  it("should resolve the pull request of a comment", async () => {
+    mockContext.payload = { issue: { number: 42, pull_request: {} } };
+    mockGithub.rest.pulls.get.mockResolvedValue({
+      data: {
+        number: 42,
+        head: { sha: "abc123", ref: "feature" },
+        base: { sha: "def456", ref: "main" },
+        changed_files: 2,
+        additions: 10,
+        deletions: 3,
+      },
+    });
+    mockGithub.rest.pulls.listFiles.mockResolvedValue({ data: [{ filename: "src/a.go" }, { filename: "README.md" }] });
+
+    await main();
+
+    expect(mockGithub.rest.pulls.get).toHaveBeenCalledWith({ owner: "test-owner", repo: "test-repo", pull_number: 42 });
+    expect(outputs()).toEqual({
+      number: "42",
+      head_sha: "abc123",
+      head_ref: "feature",
+      base_sha: "def456",
+      base_ref: "main",
+      changed_files: "src/a.go\nREADME.md",
+      changed_files_count: "2",
+      additions: "10",
+      deletions: "3",
+    });
+  });
+
+  it("should cap the changed files list", async () => {
+    mockContext.payload = { pull_request: { number: 1 } };
+    mockGithub.rest.pulls.get.mockResolvedValue({ data: { number: 1, head: {}, base: {}, changed_files: 1000, additions: 1, deletions: 1 } });
+    mockGithub.rest.pulls.listFiles.mockImplementation(async ({ page, per_page }) => ({
+      data: Array.from({ length: per_page }, (_, i) => ({ filename: `file-${page}-${i}` })),
+    }));
+
+    await main();
+
+    expect(outputs().changed_files.split("\n")).toHaveLength(MAX_CHANGED_FILES);
+    expect(outputs().changed_files_count).toBe("1000");
+  });
+
+  it("should set empty outputs when the event has no pull request", async () => {
+    mockContext.payload = { issue: { number: 5 } };
+
+    await main();
+
+    expect(mockGithub.rest.pulls.get).not.toHaveBeenCalled();
+    expect(Object.values(outputs()).every(value => value === "")).toBe(true);
+    expect(outputs()).toHaveProperty("head_sha", "");
+  });
+
+  it("should warn and keep the number when the API call fails", async () => {
+    mockContext.payload = { issue: { number: 9, pull_request: {} } };
+    mockGithub.rest.pulls.get.mockRejectedValue(new Error("Not Found"));
+
+    await main();
+
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Not Found"));
+    expect(outputs().number).toBe("9");
+    expect(outputs().head_sha).toBe("");
+  });
+});
@@ -8,6 +8,7 @@
 
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_API, ERR_CONFIG, ERR_PARSE, ERR_SYSTEM, ERR_VALIDATION } = require("./error_codes.cjs");
+const { filterTriggerSections } = require("./trigger_sections.cjs");
 
 const fs = require("fs");
 const path = require("path");
@@ -15,12 +16,22 @@ const https = require("https");
 const http = require("http");
 
 /**
- * Checks if a file starts with front matter (---\n)
+ * Returns the prefix the compiler uses for expression environment variables
+ * (expressions.env-prefix in the workflow frontmatter, GH_AW_ by default)
+ * @returns {string}
+ */
+function getExpressionPrefix() {
+  return process.env.GH_AW_EXPRESSION_PREFIX || "GH_AW_";
+}
+
+/**
+ * Checks if a file starts with YAML (---\n) or TOML (+++\n) front matter
  * @param {string} content - The file content to check
  * @returns {boolean} - True if content starts with front matter
  */
 function hasFrontMatter(content) {
-  return content.trimStart().startsWith("---\n") || content.trimStart().startsWith("---\r\n");
+  const trimmed = content.trimStart();
+  return ["---", "+++"].some(delimiter => trimmed.startsWith(`${delimiter}\n`) || trimmed.startsWith(`${delimiter}\r\n`));
 }
 
 /**
@@ -228,6 +239,32 @@ function isSafeExpression(expr) {
   return false;
 }
 
+/**
+ * Returns the event payload that expressions are evaluated against. Runs dispatched by
+ * `gh aw replay` carry the recorded payload of the original run in the aw_replay_payload input,
+ * and workflows that declare `needs:` receive the event of the upstream run in GH_AW_UPSTREAM_PAYLOAD.
+ * @returns {any} - The event payload
+ */
+function getEventPayload() {
+  const replayed = context.payload?.inputs?.aw_replay_payload;
+  if (replayed) {
+    try {
+      return JSON.parse(replayed);
+    } catch {
+      core.warning("Failed to parse the aw_replay_payload input; using the actual event payload");
+    }
+  }
+  const upstream = process.env.GH_AW_UPSTREAM_PAYLOAD;
+  if (upstream) {
+    try {
+      return JSON.parse(upstream);
+    } catch {
+      core.warning("Failed to parse GH_AW_UPSTREAM_PAYLOAD; using the actual event payload");
+    }
+  }
+  return context.payload || {};
+}
+
 /**
  * Evaluates a safe GitHub Actions expression at runtime
  * @param {string} expr - The expression to evaluate (without ${{ }})
@@ -271,11 +308,12 @@ function evaluateExpression(expr) {
 
   // Check if this is a needs.* or steps.* expression that should be looked up from environment variables
   // The compiler extracts these expressions and makes them available as GH_AW_* environment variables
+  // (or with the workflow's expressions.env-prefix)
   // For example: needs.search_issues.outputs.issue_list → GH_AW_NEEDS_SEARCH_ISSUES_OUTPUTS_ISSUE_LIST
   if (trimmed.startsWith("needs.") || trimmed.startsWith("steps.")) {
     // Convert expression to environment variable name
     // e.g., "needs.search_issues.outputs.issue_list" → "GH_AW_NEEDS_SEARCH_ISSUES_OUTPUTS_ISSUE_LIST"
-    const envVarName = "GH_AW_" + trimmed.toUpperCase().replace(/\./g, "_");
+    const envVarName = getExpressionPrefix() + trimmed.toUpperCase().replace(/\./g, "_");
     const envValue = process.env[envVarName];
     if (envValue !== undefined && envValue !== null && envValue !== "") {
       return envValue;
@@ -300,7 +338,7 @@ function evaluateExpression(expr) {
           server_url: process.env.GITHUB_SERVER_URL || "https://github.com",
           workflow: context.workflow,
           workspace: process.env.GITHUB_WORKSPACE || "",
-          event: context.payload || {},
+          event: getEventPayload(),
         },
         env: process.env,
         inputs: context.payload?.inputs || {},
@@ -582,14 +620,15 @@ async function processUrlImport(url, optional, startLine, endLine) {
   // Check for front matter and warn
   if (hasFrontMatter(content)) {
     core.debug(`URL ${url} contains front matter which will be ignored in runtime import`);
-    // Remove front matter (everything between first --- and second ---)
+    // Remove front matter (everything between first --- and second ---, or +++ for TOML)
+    const delimiter = content.trimStart().slice(0, 3);
     const lines = content.split("\n");
     let inFrontMatter = false;
     let frontMatterCount = 0;
     const processedLines = [];
 
     for (const line of lines) {
-      if (line.trim() === "---" || line.trim() === "---\r") {
+      if (line.trim() === delimiter) {
         frontMatterCount++;
         if (frontMatterCount === 1) {
           inFrontMatter = true;
@@ -694,33 +733,35 @@ function extractAndReplacePlaceholders(content) {
 /**
  * Generates a placeholder name from a GitHub expression
  * @param {string} expr - The GitHub expression (e.g., "github.event.issue.number")
- * @returns {string} - The placeholder name (e.g., "GH_AW_GITHUB_EVENT_ISSUE_NUMBER")
+ * @returns {string} - The placeholder name (e.g., "GH_AW_GITHUB_EVENT_ISSUE_NUMBER"), using the configured expression prefix
  */
 function generatePlaceholderName(expr) {
+  const prefix = getExpressionPrefix();
+
   // Check if it's a simple property access chain (e.g., github.event.issue.number)
   const simplePattern = /^[a-zA-Z][a-zA-Z0-9_.]*$/;
 
   if (simplePattern.test(expr)) {
     // Convert dots to underscores and uppercase
     // e.g., "github.event.issue.number" -> "GH_AW_GITHUB_EVENT_ISSUE_NUMBER"
-    return "GH_AW_" + expr.replace(/\./g, "_").toUpperCase();
+    return prefix + expr.replace(/\./g, "_").toUpperCase();
   }
 
   // For boolean literals, use special placeholders
   if (expr === "true") {
-    return "GH_AW_TRUE";
+    return prefix + "TRUE";
   }
   if (expr === "false") {
-    return "GH_AW_FALSE";
+    return prefix + "FALSE";
   }
   if (expr === "null") {
-    return "GH_AW_NULL";
+    return prefix + "NULL";
   }
 
   // For complex expressions or unknown variables, create a generic placeholder
   // Replace non-alphanumeric characters with underscores
   const sanitized = expr.replace(/[^a-zA-Z0-9_]/g, "_").toUpperCase();
-  return "GH_AW_" + sanitized;
+  return prefix + sanitized;
 }
 
 /**
@@ -843,14 +884,15 @@ async function processRuntimeImport(filepathOrUrl, optional, workspaceDir, start
   // Check for front matter and warn
   if (hasFrontMatter(content)) {
     core.debug(`File ${filepath} contains front matter which will be ignored in runtime import`);
-    // Remove front matter (everything between first --- and second ---)
+    // Remove front matter (everything between first --- and second ---, or +++ for TOML)
+    const delimiter = content.trimStart().slice(0, 3);
     const lines = content.split("\n");
     let inFrontMatter = false;
     let frontMatterCount = 0;
     const processedLines = [];
 
     for (const line of lines) {
-      if (line.trim() === "---" || line.trim() === "---\r") {
+      if (line.trim() === delimiter) {
         frontMatterCount++;
         if (frontMatterCount === 1) {
           inFrontMatter = true;
@@ -870,6 +912,12 @@ async function processRuntimeImport(filepathOrUrl, optional, workspaceDir, start
   // Remove XML comments
   content = removeXMLComments(content);
 
+  // Keep only the trigger sections (## on: <event>) of the current event, before any
+  // expression values are rendered into the content
+  if (process.env.GH_AW_TRIGGER_SECTIONS === "true" && typeof context !== "undefined") {
+    content = filterTriggerSections(content, context.eventName);
+  }
+
   // Wrap expressions in template conditionals
   // This handles {{#if expression}} where expression is not already wrapped in ${{ }}
   content = wrapExpressionsInTemplateConditionals(content);
@@ -1000,4 +1048,5 @@ module.exports = {
   wrapExpressionsInTemplateConditionals,
   extractAndReplacePlaceholders,
   generatePlaceholderName,
+  getExpressionPrefix,
 };
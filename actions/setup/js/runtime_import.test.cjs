@@ -29,6 +29,9 @@ describe("runtime_import", () => {
         it("should detect front matter with leading whitespace", () => {
           expect(hasFrontMatter("  \n  ---\ntitle: Test\n---\nContent")).toBe(!0);
         }),
+        it("should detect TOML front matter", () => {
+          expect(hasFrontMatter('+++\ntitle = "Test"\n+++\nContent')).toBe(!0);
+        }),
         it("should not detect front matter in the middle", () => {
           expect(hasFrontMatter("Some content\n---\ntitle: Test\n---")).toBe(!1);
         }),
@@ -311,6 +314,24 @@ describe("runtime_import", () => {
         expect(evaluateExpression("github.nonexistent")).toContain("${{");
         expect(evaluateExpression("inputs.toString")).toContain("${{");
       });
+      it("should evaluate event expressions against a replayed payload", () => {
+        global.context.payload = {
+          inputs: { aw_replay_event: "issues", aw_replay_payload: JSON.stringify({ issue: { number: 7, title: "Replayed" } }) },
+        };
+        expect(evaluateExpression("github.event.issue.number")).toBe("7");
+        expect(evaluateExpression("github.event.issue.title")).toBe("Replayed");
+        expect(evaluateExpression("inputs.aw_replay_event")).toBe("issues");
+      });
+      it("should evaluate event expressions against the upstream payload", () => {
+        global.context.payload = { workflow_run: { id: 42 } };
+        process.env.GH_AW_UPSTREAM_PAYLOAD = JSON.stringify({ issue: { number: 9, title: "Upstream" } });
+        try {
+          expect(evaluateExpression("github.event.issue.number")).toBe("9");
+          expect(evaluateExpression("github.event.issue.title")).toBe("Upstream");
+        } finally {
+          delete process.env.GH_AW_UPSTREAM_PAYLOAD;
+        }
+      });
       it("should handle array access safely with bounds checking", () => {
         // Test with actual array in context
         global.context = {
@@ -376,6 +397,12 @@ describe("runtime_import", () => {
             expect(result).not.toContain("title: Test"),
             expect(core.debug).toHaveBeenCalledWith(`File workflows/${filepath} contains front matter which will be ignored in runtime import`));
         }),
+        it("should remove TOML front matter", async () => {
+          const filepath = "with-toml-frontmatter.md";
+          fs.writeFileSync(path.join(workflowsDir, filepath), '+++\ntitle = "Test"\n+++\n\n# Content\n\nActual content.');
+          const result = await processRuntimeImport(filepath, !1, tempDir);
+          (expect(result).toContain("# Content"), expect(result).toContain("Actual content."), expect(result).not.toContain('title = "Test"'));
+        }),
         it("should remove XML comments", async () => {
           fs.writeFileSync(path.join(workflowsDir, "with-comments.md"), "# Title\n\n\x3c!-- This is a comment --\x3e\n\nContent here.");
           const result = await processRuntimeImport("with-comments.md", !1, tempDir);
@@ -774,6 +801,30 @@ describe("runtime_import", () => {
         });
       });
 
+      describe("expression prefix", () => {
+        const { generatePlaceholderName } = require("./runtime_import.cjs");
+
+        afterEach(() => {
+          delete process.env.GH_AW_EXPRESSION_PREFIX;
+          delete process.env.TRIAGE_NEEDS_BUILD_OUTPUTS_VERSION;
+        });
+
+        it("should name placeholders with the default prefix", () => {
+          expect(generatePlaceholderName("github.event.issue.number")).toBe("GH_AW_GITHUB_EVENT_ISSUE_NUMBER");
+        });
+
+        it("should name placeholders with the configured prefix", () => {
+          process.env.GH_AW_EXPRESSION_PREFIX = "TRIAGE_";
+          expect(generatePlaceholderName("github.event.issue.number")).toBe("TRIAGE_GITHUB_EVENT_ISSUE_NUMBER");
+        });
+
+        it("should read needs outputs from prefixed environment variables", () => {
+          process.env.GH_AW_EXPRESSION_PREFIX = "TRIAGE_";
+          process.env.TRIAGE_NEEDS_BUILD_OUTPUTS_VERSION = "1.2.3";
+          expect(evaluateExpression("needs.build.outputs.version")).toBe("1.2.3");
+        });
+      });
+
       describe("processExpressions", () => {
         it("should render safe expressions in content", () => {
           const content = "Actor: ${{ github.actor }}, Run: ${{ github.run_id }}";
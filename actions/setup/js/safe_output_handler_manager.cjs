@@ -20,6 +20,7 @@ const { getIssuesToAssignCopilot } = require("./create_issue.cjs");
 const { createReviewBuffer } = require("./pr_review_buffer.cjs");
 const { sanitizeContent } = require("./sanitize_content.cjs");
 const { createManifestLogger, ensureManifestExists, extractCreatedItemFromResult } = require("./safe_output_manifest.cjs");
+const { evaluateItemCondition } = require("./safe_output_item_condition.cjs");
 
 /**
  * Handler map configuration
@@ -31,6 +32,7 @@ const HANDLER_MAP = {
   create_discussion: "./create_discussion.cjs",
   close_issue: "./close_issue.cjs",
   close_discussion: "./close_discussion.cjs",
+  answer_discussion: "./answer_discussion.cjs",
   add_labels: "./add_labels.cjs",
   remove_labels: "./remove_labels.cjs",
   update_issue: "./update_issue.cjs",
@@ -49,8 +51,10 @@ const HANDLER_MAP = {
   hide_comment: "./hide_comment.cjs",
   set_issue_type: "./set_issue_type.cjs",
   add_reviewer: "./add_reviewer.cjs",
+  request_review: "./request_review.cjs",
   assign_milestone: "./assign_milestone.cjs",
   assign_to_user: "./assign_to_user.cjs",
+  assign: "./assign.cjs",
   unassign_from_user: "./unassign_from_user.cjs",
   create_code_scanning_alert: "./create_code_scanning_alert.cjs",
   autofix_code_scanning_alert: "./autofix_code_scanning_alert.cjs",
@@ -100,6 +104,46 @@ function loadConfig() {
   }
 }
 
+/**
+ * Rendered values of an if: condition that evaluated to false
+ */
+const FALSE_CONDITION_VALUES = new Set(["false", "", "0", "null"]);
+
+/**
+ * Get the safe output types whose if: condition evaluated to false.
+ * The compiler renders each condition as a GitHub Actions expression inside the handler
+ * config, so by the time this step runs the value is already "true" or "false".
+ * @param {Object} config - Safe outputs configuration
+ * @returns {Set<string>} Types that must not be processed
+ */
+function getConditionallyDisabledTypes(config) {
+  const disabled = new Set();
+  for (const [type, handlerConfig] of Object.entries(config)) {
+    if (handlerConfig && typeof handlerConfig === "object" && "if" in handlerConfig) {
+      if (FALSE_CONDITION_VALUES.has(String(handlerConfig.if).trim().toLowerCase())) {
+        disabled.add(type);
+      }
+    }
+  }
+  return disabled;
+}
+
+/**
+ * Get the post-run if: conditions of the safe output types whose condition references item fields.
+ * These are evaluated per message, against the fields the agent set on it.
+ * @param {Object} config - Safe outputs configuration
+ * @returns {Map<string, {condition: string, fields: Object}>} Conditions by type
+ */
+function getItemConditions(config) {
+  const conditions = new Map();
+  for (const [type, handlerConfig] of Object.entries(config)) {
+    if (handlerConfig && typeof handlerConfig === "object" && typeof handlerConfig.item_condition === "string") {
+      conditions.set(type, { condition: handlerConfig.item_condition, fields: handlerConfig.item_fields || {} });
+    }
+  }
+  return conditions;
+}
+
 /** @type {Set<string>} Handler types that participate in the PR review buffer */
 const PR_REVIEW_HANDLER_TYPES = new Set(["create_pull_request_review_comment", "submit_pull_request_review"]);
 
@@ -115,7 +159,14 @@ async function loadHandlers(config, prReviewBuffer) {
 
   core.info("Loading and initializing safe output handlers based on configuration...");
 
+  const disabledTypes = getConditionallyDisabledTypes(config);
+
   for (const [type, handlerPath] of Object.entries(HANDLER_MAP)) {
+    if (disabledTypes.has(type)) {
+      core.info(`⏭ Handler disabled: ${type} (its if condition evaluated to false)`);
+      continue;
+    }
+
     // Check if this safe output type is enabled in the config
     // The presence of the config key indicates the handler should be loaded
     if (config[type]) {
@@ -215,9 +266,11 @@ function collectMissingMessages(messages) {
  * @param {Map<string, Function>} messageHandlers - Map of message handler functions
  * @param {Array<Object>} messages - Array of safe output messages
  * @param {((item: {type: string, url?: string, number?: number, repo?: string, temporaryId?: string}) => void)|null} [onItemCreated] - Optional callback invoked after each successful create operation (for manifest logging)
+ * @param {Set<string>} [disabledTypes] - Types whose if: condition evaluated to false; their messages are skipped
+ * @param {Map<string, {condition: string, fields: Object}>} [itemConditions] - Post-run conditions by type; messages that do not match are skipped
  * @returns {Promise<{success: boolean, results: Array<any>, temporaryIdMap: Object, outputsWithUnresolvedIds: Array<any>, missings: Object, codePushFailures: Array<{type: string, error: string}>}>}
  */
-async function processMessages(messageHandlers, messages, onItemCreated = null) {
+async function processMessages(messageHandlers, messages, onItemCreated = null, disabledTypes = new Set(), itemConditions = new Map()) {
   const results = [];
 
   // Collect missing_tool and missing_data messages first
@@ -273,6 +326,19 @@ async function processMessages(messageHandlers, messages, onItemCreated = null)
     const messageHandler = messageHandlers.get(messageType);
 
     if (!messageHandler) {
+      // Skip types whose if: condition evaluated to false
+      if (disabledTypes.has(messageType)) {
+        core.info(`⏭ Message ${i + 1} (${messageType}) skipped — its if condition evaluated to false`);
+        results.push({
+          type: messageType,
+          messageIndex: i,
+          success: false,
+          skipped: true,
+          reason: "Disabled by if condition",
+        });
+        continue;
+      }
+
       // Check if this message type is handled by a standalone step
       if (STANDALONE_STEP_TYPES.has(messageType)) {
         // Silently skip - this is handled by a dedicated step
@@ -300,6 +366,28 @@ async function processMessages(messageHandlers, messages, onItemCreated = null)
       continue;
     }
 
+    // Skip messages whose fields do not match the post-run if: condition of their type
+    const itemCondition = itemConditions.get(messageType);
+    if (itemCondition) {
+      let matches = false;
+      try {
+        matches = evaluateItemCondition(itemCondition.condition, message, itemCondition.fields);
+      } catch (error) {
+        core.warning(`Could not evaluate the if condition of message ${i + 1} (${messageType}): ${getErrorMessage(error)}`);
+      }
+      if (!matches) {
+        core.info(`⏭ Message ${i + 1} (${messageType}) skipped — its fields do not match: ${itemCondition.condition}`);
+        results.push({
+          type: messageType,
+          messageIndex: i,
+          success: false,
+          skipped: true,
+          reason: "Item did not match if condition",
+        });
+        continue;
+      }
+    }
+
     try {
       core.info(`Processing message ${i + 1}/${messages.length}: ${messageType}`);
 
@@ -849,7 +937,7 @@ async function main() {
     const logCreatedItem = isStaged ? null : createManifestLogger();
 
     // Process all messages in order of appearance
-    const processingResult = await processMessages(messageHandlers, agentOutput.items, logCreatedItem);
+    const processingResult = await processMessages(messageHandlers, agentOutput.items, logCreatedItem, getConditionallyDisabledTypes(config), getItemConditions(config));
 
     // Finalize buffered PR review — submit when comments or metadata exist
     if (prReviewBuffer.hasBufferedComments() || prReviewBuffer.hasReviewMetadata()) {
@@ -900,6 +988,8 @@ async function main() {
     const deferredCount = processingResult.results.filter(r => r.deferred).length;
     const skippedStandaloneResults = processingResult.results.filter(r => r.skipped && r.reason === "Handled by standalone step");
     const skippedNoHandlerResults = processingResult.results.filter(r => !r.success && !r.skipped && r.error?.includes("No handler loaded"));
+    const skippedConditionResults = processingResult.results.filter(r => r.skipped && r.reason === "Disabled by if condition");
+    const skippedItemConditionResults = processingResult.results.filter(r => r.skipped && r.reason === "Item did not match if condition");
 
     core.info(`\n=== Processing Summary ===`);
     core.info(`Total messages: ${processingResult.results.length}`);
@@ -916,6 +1006,16 @@ async function main() {
       const standaloneTypes = [...new Set(skippedStandaloneResults.map(r => r.type))];
       core.info(`  Types: ${standaloneTypes.join(", ")}`);
     }
+    if (skippedConditionResults.length > 0) {
+      core.info(`Skipped (if condition false): ${skippedConditionResults.length}`);
+      const conditionTypes = [...new Set(skippedConditionResults.map(r => r.type))];
+      core.info(`  Types: ${conditionTypes.join(", ")}`);
+    }
+    if (skippedItemConditionResults.length > 0) {
+      core.info(`Skipped (item did not match if condition): ${skippedItemConditionResults.length}`);
+      const itemConditionTypes = [...new Set(skippedItemConditionResults.map(r => r.type))];
+      core.info(`  Types: ${itemConditionTypes.join(", ")}`);
+    }
     if (skippedNoHandlerResults.length > 0) {
       core.warning(`Skipped (no handler): ${skippedNoHandlerResults.length}`);
       const noHandlerTypes = [...new Set(skippedNoHandlerResults.map(r => r.type))];
@@ -1006,4 +1106,4 @@ async function main() {
   }
 }
 
-module.exports = { main, loadConfig, loadHandlers, processMessages };
+module.exports = { main, loadConfig, loadHandlers, processMessages, getConditionallyDisabledTypes, getItemConditions };
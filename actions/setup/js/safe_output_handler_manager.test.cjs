@@ -1,7 +1,7 @@
 // @ts-check
 
 import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
-import { loadConfig, loadHandlers, processMessages } from "./safe_output_handler_manager.cjs";
+import { loadConfig, loadHandlers, processMessages, getConditionallyDisabledTypes, getItemConditions } from "./safe_output_handler_manager.cjs";
 
 describe("Safe Output Handler Manager", () => {
   beforeEach(() => {
@@ -112,7 +112,70 @@ describe("Safe Output Handler Manager", () => {
     });
   });
 
+  describe("getConditionallyDisabledTypes", () => {
+    it("should disable types whose if condition evaluated to false", () => {
+      const config = {
+        create_issue: { max: 1, if: "false" },
+        add_comment: { max: 1, if: "true" },
+        add_labels: { max: 3 },
+        update_issue: { if: "" },
+      };
+
+      const disabled = getConditionallyDisabledTypes(config);
+
+      expect([...disabled].sort()).toEqual(["create_issue", "update_issue"]);
+    });
+  });
+
+  describe("getItemConditions", () => {
+    it("should collect post-run conditions with their fields", () => {
+      const fields = { severity: { type: "string", enum: ["low", "high"] } };
+      const config = {
+        create_issue: { max: 1, item_condition: "item.severity >= 'high'", item_fields: fields },
+        add_comment: { max: 1, if: "true" },
+      };
+
+      const conditions = getItemConditions(config);
+
+      expect([...conditions.keys()]).toEqual(["create_issue"]);
+      expect(conditions.get("create_issue")).toEqual({ condition: "item.severity >= 'high'", fields });
+    });
+  });
+
   describe("processMessages", () => {
+    it("should skip messages of types disabled by their if condition", async () => {
+      const messages = [
+        { type: "create_issue", title: "Issue" },
+        { type: "add_comment", body: "Comment" },
+      ];
+
+      const mockHandler = vi.fn().mockResolvedValue({ success: true });
+      const handlers = new Map([["add_comment", mockHandler]]);
+
+      const result = await processMessages(handlers, messages, null, new Set(["create_issue"]));
+
+      expect(mockHandler).toHaveBeenCalledTimes(1);
+      expect(result.results[0]).toMatchObject({ type: "create_issue", skipped: true, reason: "Disabled by if condition" });
+      expect(core.warning).not.toHaveBeenCalledWith(expect.stringContaining("No handler loaded"));
+    });
+
+    it("should skip messages whose fields do not match the post-run condition", async () => {
+      const messages = [
+        { type: "create_issue", title: "Minor", severity: "low" },
+        { type: "create_issue", title: "Major", severity: "critical" },
+      ];
+
+      const mockHandler = vi.fn().mockResolvedValue({ success: true });
+      const handlers = new Map([["create_issue", mockHandler]]);
+      const conditions = new Map([["create_issue", { condition: "item.severity >= 'high'", fields: { severity: { type: "string", enum: ["low", "medium", "high", "critical"] } } }]]);
+
+      const result = await processMessages(handlers, messages, null, new Set(), conditions);
+
+      expect(mockHandler).toHaveBeenCalledTimes(1);
+      expect(mockHandler.mock.calls[0][0]).toMatchObject({ title: "Major" });
+      expect(result.results[0]).toMatchObject({ type: "create_issue", skipped: true, reason: "Item did not match if condition" });
+    });
+
     it("should process messages in order of appearance", async () => {
       const messages = [
         { type: "add_comment", body: "Comment" },
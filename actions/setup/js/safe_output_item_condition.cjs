@@ -0,0 +1,217 @@
+// @ts-check
+
+/**
+ * Post-run if: conditions for safe output items.
+ *
+ * A safe output whose if: condition references item fields (e.g. item.severity >= 'high')
+ * is evaluated per item after the agent run, against the fields the agent set on the item.
+ * The compiler validates the condition and passes it with the declared fields in the handler
+ * config (item_condition, item_fields).
+ *
+ * Grammar (a subset of GitHub Actions expressions):
+ *   condition  := or
+ *   or         := and ("||" and)*
+ *   and        := unary ("&&" unary)*
+ *   unary      := "!" unary | "(" or ")" | comparison
+ *   comparison := operand (("==" | "!=" | "<" | "<=" | ">" | ">=") operand)?
+ *   operand    := item.<field> | 'string' | number | true | false | null
+ *
+ * Like GitHub Actions, strings compare case-insensitively. Ordering comparisons on a field
+ * declared with an enum follow the enum order, so item.severity >= 'high' matches high and
+ * every value listed after it.
+ */
+
+/**
+ * @typedef {{type?: string, enum?: string[]}} ItemField
+ * @typedef {{kind: "field", name: string} | {kind: "literal", value: any}} Operand
+ */
+
+const TOKEN_PATTERN = /\s*(&&|\|\||==|!=|<=|>=|<|>|!|\(|\)|item\.[A-Za-z_][A-Za-z0-9_]*|'(?:[^']|'')*'|-?\d+(?:\.\d+)?|true|false|null)/y;
+
+/**
+ * @param {string} condition
+ * @returns {string[]}
+ */
+function tokenize(condition) {
+  const tokens = [];
+  TOKEN_PATTERN.lastIndex = 0;
+  while (TOKEN_PATTERN.lastIndex < condition.length) {
+    if (/^\s*$/.test(condition.slice(TOKEN_PATTERN.lastIndex))) break;
+    const start = TOKEN_PATTERN.lastIndex;
+    const match = TOKEN_PATTERN.exec(condition);
+    if (!match) {
+      throw new Error(`Unexpected input at position ${start} in condition: ${condition}`);
+    }
+    tokens.push(match[1]);
+  }
+  return tokens;
+}
+
+/**
+ * @param {string} token
+ * @returns {Operand | null}
+ */
+function parseOperand(token) {
+  if (token === undefined) return null;
+  if (token.startsWith("item.")) return { kind: "field", name: token.slice(5) };
+  if (token.startsWith("'")) return { kind: "literal", value: token.slice(1, -1).replace(/''/g, "'") };
+  if (token === "true" || token === "false") return { kind: "literal", value: token === "true" };
+  if (token === "null") return { kind: "literal", value: null };
+  if (/^-?\d/.test(token)) return { kind: "literal", value: Number(token) };
+  return null;
+}
+
+/**
+ * GitHub Actions truthiness: false, 0, '', null and missing values are falsy
+ * @param {any} value
+ * @returns {boolean}
+ */
+function isTruthy(value) {
+  return value !== undefined && value !== null && value !== false && value !== 0 && value !== "";
+}
+
+/**
+ * Brings a value into comparable form: enum values become their position, strings are lower-cased
+ * @param {any} value
+ * @param {string[] | undefined} enumValues - Enum of the field being compared, if any
+ * @returns {any}
+ */
+function normalize(value, enumValues) {
+  if (typeof value === "string") {
+    if (enumValues) {
+      return enumValues.findIndex(entry => entry.toLowerCase() === value.toLowerCase());
+    }
+    return value.toLowerCase();
+  }
+  return value === undefined ? null : value;
+}
+
+/**
+ * @param {string} operator
+ * @param {any} left
+ * @param {any} right
+ * @returns {boolean}
+ */
+function compare(operator, left, right) {
+  switch (operator) {
+    case "==":
+      return left === right;
+    case "!=":
+      return left !== right;
+  }
+  // Ordering is only defined between two numbers (including enum positions); a value outside
+  // the enum has position -1 and never matches
+  if (typeof left !== "number" || typeof right !== "number" || left < 0 || right < 0) {
+    return false;
+  }
+  switch (operator) {
+    case "<":
+      return left < right;
+    case "<=":
+      return left <= right;
+    case ">":
+      return left > right;
+    default:
+      return left >= right;
+  }
+}
+
+/**
+ * Evaluates a post-run condition against a safe output item
+ * @param {string} condition - Condition validated by the compiler
+ * @param {Object} item - Safe output message written by the agent
+ * @param {Object<string, ItemField>} [fields] - Declared item fields
+ * @returns {boolean}
+ */
+function evaluateItemCondition(condition, item, fields = {}) {
+  const tokens = tokenize(condition);
+  let pos = 0;
+
+  /** @param {Operand} operand */
+  const valueOf = operand => (operand.kind === "field" ? item?.[operand.name] : operand.value);
+
+  /**
+   * Enum used to compare the two operands: the enum of whichever side is a field that declares one
+   * @param {Operand} left
+   * @param {Operand} right
+   */
+  const enumFor = (left, right) => {
+    for (const operand of [left, right]) {
+      if (operand.kind === "field" && fields[operand.name]?.enum?.length) {
+        return fields[operand.name].enum;
+      }
+    }
+    return undefined;
+  };
+
+  /** @returns {boolean} */
+  const parseOr = () => {
+    let result = parseAnd();
+    while (tokens[pos] === "||") {
+      pos++;
+      const right = parseAnd();
+      result = result || right;
+    }
+    return result;
+  };
+
+  /** @returns {boolean} */
+  const parseAnd = () => {
+    let result = parseUnary();
+    while (tokens[pos] === "&&") {
+      pos++;
+      const right = parseUnary();
+      result = result && right;
+    }
+    return result;
+  };
+
+  /** @returns {boolean} */
+  const parseUnary = () => {
+    if (tokens[pos] === "!") {
+      pos++;
+      return !parseUnary();
+    }
+    if (tokens[pos] === "(") {
+      pos++;
+      const result = parseOr();
+      if (tokens[pos] !== ")") {
+        throw new Error(`Expected ')' in condition: ${condition}`);
+      }
+      pos++;
+      return result;
+    }
+    return parseComparison();
+  };
+
+  /** @returns {boolean} */
+  const parseComparison = () => {
+    const left = parseOperand(tokens[pos]);
+    if (!left) {
+      throw new Error(`Expected an item field or a literal in condition: ${condition}`);
+    }
+    pos++;
+
+    const operator = tokens[pos];
+    if (!["==", "!=", "<", "<=", ">", ">="].includes(operator)) {
+      return isTruthy(valueOf(left));
+    }
+    pos++;
+    const right = parseOperand(tokens[pos]);
+    if (!right) {
+      throw new Error(`Expected an item field or a literal after '${operator}' in condition: ${condition}`);
+    }
+    pos++;
+
+    const enumValues = enumFor(left, right);
+    return compare(operator, normalize(valueOf(left), enumValues), normalize(valueOf(right), enumValues));
+  };
+
+  const result = parseOr();
+  if (pos < tokens.length) {
+    throw new Error(`Unexpected '${tokens[pos]}' in condition: ${condition}`);
+  }
+  return result;
+}
+
+module.exports = { evaluateItemCondition };
@@ -0,0 +1,40 @@
+import { describe, it, expect } from "vitest";
+import { evaluateItemCondition } from "./safe_output_item_condition.cjs";
+
+describe("safe_output_item_condition.cjs", () => {
+  const fields = {
+    severity: { type: "string", enum: ["low", "medium", "high", "critical"] },
+    score: { type: "number" },
+    confirmed: { type: "boolean" },
+  };
+
+  it("should order enum fields by their declared values", () => {
+    expect(evaluateItemCondition("item.severity >= 'high'", { severity: "critical" }, fields)).toBe(true);
+    expect(evaluateItemCondition("item.severity >= 'high'", { severity: "HIGH" }, fields)).toBe(true);
+    expect(evaluateItemCondition("item.severity >= 'high'", { severity: "medium" }, fields)).toBe(false);
+    expect(evaluateItemCondition("item.severity < 'medium'", { severity: "unknown" }, fields)).toBe(false);
+  });
+
+  it("should not match missing fields", () => {
+    expect(evaluateItemCondition("item.severity >= 'high'", {}, fields)).toBe(false);
+    expect(evaluateItemCondition("item.score > 0", {}, fields)).toBe(false);
+    expect(evaluateItemCondition("item.severity == null", {}, fields)).toBe(true);
+  });
+
+  it("should support numbers, booleans and logical operators", () => {
+    expect(evaluateItemCondition("item.score > 7.5 && item.confirmed", { score: 8, confirmed: true }, fields)).toBe(true);
+    expect(evaluateItemCondition("item.score > 7.5 && item.confirmed", { score: 8, confirmed: false }, fields)).toBe(false);
+    expect(evaluateItemCondition("!(item.score <= 3) || item.confirmed == true", { score: 1, confirmed: true }, fields)).toBe(true);
+  });
+
+  it("should compare strings case-insensitively and unescape quotes", () => {
+    expect(evaluateItemCondition("item.area == 'Docs'", { area: "docs" })).toBe(true);
+    expect(evaluateItemCondition("item.area != 'it''s'", { area: "it's" })).toBe(false);
+  });
+
+  it("should reject expressions outside the item grammar", () => {
+    expect(() => evaluateItemCondition("github.actor == 'octocat'", {}, fields)).toThrow("Unexpected input");
+    expect(() => evaluateItemCondition("item.score >", {}, fields)).toThrow("Expected an item field or a literal");
+    expect(() => evaluateItemCondition("(item.confirmed", {}, fields)).toThrow("Expected ')'");
+  });
+});
@@ -19,6 +19,7 @@ const CREATE_ITEM_TYPES = new Set([
   "create_issue",
   "add_comment",
   "create_discussion",
+  "answer_discussion",
   "create_pull_request",
   "create_project",
   "create_project_status_update",
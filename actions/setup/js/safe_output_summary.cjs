@@ -70,6 +70,17 @@ function generateSafeOutputSummary(options) {
         summary += `**Labels:** ${message.labels.join(", ")}\n\n`;
       }
     }
+
+    // Show the before/after values of the fields an update changed
+    if (Array.isArray(result.changes) && result.changes.length > 0) {
+      /** @param {string} value */
+      const cell = value => (value === "" ? "_(none)_" : String(value).replace(/\|/g, "\\|").replace(/\r?\n/g, " "));
+      summary += `**Changes:**\n\n| Field | Before | After |\n| --- | --- | --- |\n`;
+      for (const change of result.changes) {
+        summary += `| ${change.field} | ${cell(change.before)} | ${cell(change.after)} |\n`;
+      }
+      summary += `\n`;
+    }
   } else if (error) {
     // Show error information
     summary += `**Error:** ${error}\n\n`;
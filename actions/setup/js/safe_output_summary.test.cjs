@@ -104,6 +104,26 @@ describe("safe_output_summary", () => {
       expect(summary.length).toBeLessThan(longBody.length + 1000);
     });
 
+    it("should render a before/after table for update changes", () => {
+      const summary = generateSafeOutputSummary({
+        type: "update_issue",
+        messageIndex: 1,
+        success: true,
+        result: {
+          number: 100,
+          url: "https://github.com/owner/repo/issues/100",
+          changes: [
+            { field: "labels", before: "", after: "bug|triage" },
+            { field: "status", before: "open", after: "closed" },
+          ],
+        },
+      });
+
+      expect(summary).toContain("| Field | Before | After |");
+      expect(summary).toContain("| labels | _(none)_ | bug\\|triage |");
+      expect(summary).toContain("| status | open | closed |");
+    });
+
     it("should use 6-backtick fences for body content containing backticks", () => {
       const bodyWithBackticks = "Here is some code:\n```javascript\nconsole.log('hello');\n```\nEnd of body.";
 
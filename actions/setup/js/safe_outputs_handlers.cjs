@@ -87,11 +87,13 @@ function createHandlers(server, appendSafeOutput, config = {}) {
    * Handler for upload_asset tool
    */
   const uploadAssetHandler = args => {
+    // Assets are published to an orphaned branch unless target is "artifact"
+    const artifactTarget = config.upload_asset?.target === "artifact";
     const branchName = process.env.GH_AW_ASSETS_BRANCH;
-    if (!branchName) throw new Error(`${ERR_CONFIG}: GH_AW_ASSETS_BRANCH not set`);
+    if (!artifactTarget && !branchName) throw new Error(`${ERR_CONFIG}: GH_AW_ASSETS_BRANCH not set`);
 
     // Normalize the branch name to ensure it's a valid git branch name
-    const normalizedBranchName = normalizeBranchName(branchName);
+    const normalizedBranchName = artifactTarget ? "" : normalizeBranchName(branchName);
 
     const { path: filePath } = args;
 
@@ -162,16 +164,23 @@ function createHandlers(server, appendSafeOutput, config = {}) {
     const githubServer = process.env.GITHUB_SERVER_URL || "https://github.com";
     const repo = process.env.GITHUB_REPOSITORY || "owner/repo";
     let url;
-    try {
-      const serverHostname = new URL(githubServer).hostname;
-      if (serverHostname === "github.com") {
-        url = `https://raw.githubusercontent.com/${repo}/${normalizedBranchName}/${targetFileName}`;
-      } else {
-        // GitHub Enterprise Server - raw content is served from the same host with /raw/ path
+    if (artifactTarget) {
+      // The artifact is created after the agent finishes, so link to the run page that lists it; the
+      // upload_assets job exposes the download URL returned by the upload as its artifact_url output
+      const runId = process.env.GITHUB_RUN_ID || "0";
+      url = `${githubServer}/${repo}/actions/runs/${runId}#artifacts`;
+    } else {
+      try {
+        const serverHostname = new URL(githubServer).hostname;
+        if (serverHostname === "github.com") {
+          url = `https://raw.githubusercontent.com/${repo}/${normalizedBranchName}/${targetFileName}`;
+        } else {
+          // GitHub Enterprise Server - raw content is served from the same host with /raw/ path
+          url = `${githubServer}/${repo}/raw/${normalizedBranchName}/${targetFileName}`;
+        }
+      } catch {
         url = `${githubServer}/${repo}/raw/${normalizedBranchName}/${targetFileName}`;
       }
-    } catch {
-      url = `${githubServer}/${repo}/raw/${normalizedBranchName}/${targetFileName}`;
     }
 
     // Create entry for safe outputs
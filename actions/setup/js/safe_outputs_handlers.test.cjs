@@ -192,6 +192,24 @@ describe("safe_outputs_handlers", () => {
       expect(resultData.result).toContain("https://");
     });
 
+    it("should return the workflow run artifacts URL when target is artifact", () => {
+      delete process.env.GH_AW_ASSETS_BRANCH;
+      process.env.GITHUB_SERVER_URL = "https://github.com";
+      process.env.GITHUB_REPOSITORY = "myorg/myrepo";
+      process.env.GITHUB_RUN_ID = "12345";
+
+      const testFile = path.join(testWorkspaceDir, "chart.png");
+      fs.writeFileSync(testFile, "test content");
+
+      handlers = createHandlers(mockServer, mockAppendSafeOutput, { upload_asset: { target: "artifact" } });
+      const result = handlers.uploadAssetHandler({ path: testFile });
+
+      const entry = mockAppendSafeOutput.mock.calls[0][0];
+      expect(entry.url).toBe("https://github.com/myorg/myrepo/actions/runs/12345#artifacts");
+      expect(JSON.parse(result.content[0].text).result).toBe(entry.url);
+      delete process.env.GITHUB_RUN_ID;
+    });
+
     it("should throw error if GH_AW_ASSETS_BRANCH not set", () => {
       delete process.env.GH_AW_ASSETS_BRANCH;
 
@@ -33,6 +33,49 @@ const { balanceCodeRegions } = require("./markdown_code_region_balancer.cjs");
  * @property {number} [maxBotMentions] - Maximum bot trigger references before filtering (default: 10)
  */
 
+/** @type {{ path: string, sanitize: (content: string) => string } | null} */
+let customSanitizer = null;
+
+/**
+ * Loads the safe-outputs.sanitize.script module named by GH_AW_SANITIZE_SCRIPT.
+ * The module exports the sanitizer function, either directly or as `sanitize`.
+ * @returns {((content: string) => string) | null} The custom sanitizer, or null when none is configured
+ */
+function loadCustomSanitizer() {
+  const scriptPath = process.env.GH_AW_SANITIZE_SCRIPT;
+  if (!scriptPath) {
+    return null;
+  }
+  if (customSanitizer && customSanitizer.path === scriptPath) {
+    return customSanitizer.sanitize;
+  }
+  const loaded = require(scriptPath);
+  const sanitize = typeof loaded === "function" ? loaded : loaded && loaded.sanitize;
+  if (typeof sanitize !== "function") {
+    throw new Error(`Custom sanitizer ${scriptPath} must export a function (module.exports = content => ...)`);
+  }
+  customSanitizer = { path: scriptPath, sanitize };
+  return sanitize;
+}
+
+/**
+ * Runs the safe-outputs.sanitize.script module on content that already passed the built-in rules.
+ * Errors are not swallowed: unscrubbed content must not be published.
+ * @param {string} content - Sanitized content
+ * @returns {string} Content scrubbed by the custom sanitizer
+ */
+function applyCustomSanitizer(content) {
+  const sanitize = loadCustomSanitizer();
+  if (!sanitize || !content) {
+    return content;
+  }
+  const result = sanitize(content);
+  if (typeof result !== "string") {
+    throw new Error(`Custom sanitizer ${process.env.GH_AW_SANITIZE_SCRIPT} must return a string, got ${typeof result}`);
+  }
+  return result;
+}
+
 /**
  * Sanitizes content for safe output in GitHub Actions with optional mention filtering
  * @param {string} content - The content to sanitize
@@ -57,9 +100,14 @@ function sanitizeContent(content, maxLengthOrOptions) {
     maxBotMentions = maxLengthOrOptions.maxBotMentions;
   }
 
+  // safe-outputs.sanitize.mentions: neutralize escapes every mention, ignoring allowed aliases
+  if (process.env.GH_AW_SANITIZE_MENTIONS === "neutralize") {
+    allowedAliasesLowercase = [];
+  }
+
   // If no allowed aliases specified, use core sanitization (which neutralizes all mentions)
   if (allowedAliasesLowercase.length === 0) {
-    return sanitizeContentCore(content, maxLength, maxBotMentions);
+    return applyCustomSanitizer(sanitizeContentCore(content, maxLength, maxBotMentions));
   }
 
   // If allowed aliases are specified, we need custom mention filtering
@@ -113,7 +161,7 @@ function sanitizeContent(content, maxLengthOrOptions) {
   // This repairs markdown where AI models generate nested code blocks at the same indentation
   sanitized = balanceCodeRegions(sanitized);
 
-  return sanitized.trim();
+  return applyCustomSanitizer(sanitized.trim());
 
   /**
    * Neutralize @mentions with selective filtering
@@ -1,4 +1,7 @@
 import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import os from "os";
+import path from "path";
 
 describe("sanitize_content.cjs", () => {
   let mockCore;
@@ -27,6 +30,10 @@ describe("sanitize_content.cjs", () => {
     delete process.env.GITHUB_SERVER_URL;
     delete process.env.GITHUB_API_URL;
     delete process.env.GITHUB_REPOSITORY;
+    delete process.env.GH_AW_SANITIZE_ALLOWED_HTML;
+    delete process.env.GH_AW_SANITIZE_MAX_LENGTH;
+    delete process.env.GH_AW_SANITIZE_MENTIONS;
+    delete process.env.GH_AW_SANITIZE_SCRIPT;
   });
 
   describe("basic sanitization", () => {
@@ -1075,6 +1082,94 @@ describe("sanitize_content.cjs", () => {
     });
   });
 
+  describe("sanitize configuration", () => {
+    it("should replace the allowed HTML tags with GH_AW_SANITIZE_ALLOWED_HTML", () => {
+      process.env.GH_AW_SANITIZE_ALLOWED_HTML = "details, summary";
+      const result = sanitizeContent("<details><summary>Log</summary><b>bold</b></details>");
+
+      expect(result).toBe("<details><summary>Log</summary>(b)bold(/b)</details>");
+    });
+
+    it("should ignore tags outside the default list in GH_AW_SANITIZE_ALLOWED_HTML", () => {
+      process.env.GH_AW_SANITIZE_ALLOWED_HTML = "b,img";
+      const result = sanitizeContent('<b>bold</b><img src="https://example.com/x.png">');
+
+      expect(result).toContain("<b>bold</b>");
+      expect(result).not.toContain("<img");
+    });
+
+    it("should convert every tag when GH_AW_SANITIZE_ALLOWED_HTML is empty", () => {
+      process.env.GH_AW_SANITIZE_ALLOWED_HTML = "";
+      const result = sanitizeContent("<b>bold</b>");
+
+      expect(result).toBe("(b)bold(/b)");
+    });
+
+    it("should cap content length with GH_AW_SANITIZE_MAX_LENGTH", () => {
+      process.env.GH_AW_SANITIZE_MAX_LENGTH = "100";
+      const result = sanitizeContent("x".repeat(200));
+
+      expect(result).toBe("x".repeat(100) + "\n[Content truncated due to length]");
+    });
+
+    it("should not raise an explicit lower max length", () => {
+      process.env.GH_AW_SANITIZE_MAX_LENGTH = "1000";
+      const result = sanitizeContent("x".repeat(200), 100);
+
+      expect(result).toContain("[Content truncated due to length]");
+    });
+
+    it("should neutralize allowed aliases when GH_AW_SANITIZE_MENTIONS is neutralize", () => {
+      process.env.GH_AW_SANITIZE_MENTIONS = "neutralize";
+      const result = sanitizeContent("Hello @octocat", { allowedAliases: ["octocat"] });
+
+      expect(result).toBe("Hello `@octocat`");
+    });
+  });
+
+  describe("custom sanitizer script", () => {
+    let scriptDir;
+
+    /**
+     * Writes a custom sanitizer module and points GH_AW_SANITIZE_SCRIPT at it
+     * @param {string} name
+     * @param {string} source
+     */
+    function useScript(name, source) {
+      const scriptPath = path.join(scriptDir, name);
+      fs.writeFileSync(scriptPath, source);
+      process.env.GH_AW_SANITIZE_SCRIPT = scriptPath;
+    }
+
+    beforeEach(() => {
+      scriptDir = fs.mkdtempSync(path.join(os.tmpdir(), "custom-sanitizer-"));
+    });
+
+    afterEach(() => {
+      fs.rmSync(scriptDir, { recursive: true, force: true });
+    });
+
+    it("runs after the built-in rules", () => {
+      useScript("hosts.cjs", 'module.exports = content => content.replace(/\\b[a-z0-9-]+\\.corp\\.example\\b/g, "[internal host]");');
+      expect(sanitizeContent("Failed on build01.corp.example, cc @octocat")).toBe("Failed on [internal host], cc `@octocat`");
+    });
+
+    it("runs on the allowed aliases path", () => {
+      useScript("customers.cjs", 'module.exports.sanitize = content => content.replace(/CUST-\\d+/g, "CUST-****");');
+      expect(sanitizeContent("Hello @octocat about CUST-1234", { allowedAliases: ["octocat"] })).toBe("Hello @octocat about CUST-****");
+    });
+
+    it("fails when the module does not export a function", () => {
+      useScript("invalid.cjs", "module.exports = { name: 'x' };");
+      expect(() => sanitizeContent("text")).toThrow("must export a function");
+    });
+
+    it("fails when the sanitizer does not return a string", () => {
+      useScript("undefined.cjs", "module.exports = () => undefined;");
+      expect(() => sanitizeContent("text")).toThrow("must return a string");
+    });
+  });
+
   describe("combined sanitization", () => {
     it("should apply all sanitizations correctly", () => {
       const input = `  
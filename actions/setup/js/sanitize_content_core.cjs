@@ -332,53 +332,74 @@ function removeXmlComments(s) {
   return s;
 }
 
+/**
+ * Safe HTML tags supported by GitHub Flavored Markdown that survive sanitization by default:
+ * b, blockquote, br, code, details, em, h1–h6, hr, i, li, ol, p, pre, strong, sub, summary, sup, table, tbody, td, th, thead, tr, ul
+ * Plus GFM inline tags: abbr, del, ins, kbd, mark, s, span
+ */
+const DEFAULT_ALLOWED_HTML_TAGS = [
+  "abbr",
+  "b",
+  "blockquote",
+  "br",
+  "code",
+  "del",
+  "details",
+  "em",
+  "h1",
+  "h2",
+  "h3",
+  "h4",
+  "h5",
+  "h6",
+  "hr",
+  "i",
+  "ins",
+  "kbd",
+  "li",
+  "mark",
+  "ol",
+  "p",
+  "pre",
+  "s",
+  "span",
+  "strong",
+  "sub",
+  "summary",
+  "sup",
+  "table",
+  "tbody",
+  "td",
+  "th",
+  "thead",
+  "tr",
+  "ul",
+];
+
+/**
+ * Build the list of HTML tags that survive sanitization.
+ * GH_AW_SANITIZE_ALLOWED_HTML (from safe-outputs.sanitize.allowed-html) replaces the default list
+ * with a subset of it; an empty value converts every tag.
+ * @returns {string[]} Array of lowercase tag names
+ */
+function buildAllowedHtmlTags() {
+  const allowedHtmlEnv = process.env.GH_AW_SANITIZE_ALLOWED_HTML;
+  if (allowedHtmlEnv === undefined) {
+    return DEFAULT_ALLOWED_HTML_TAGS;
+  }
+  return allowedHtmlEnv
+    .split(",")
+    .map(tag => tag.trim().toLowerCase())
+    .filter(tag => DEFAULT_ALLOWED_HTML_TAGS.includes(tag));
+}
+
 /**
  * Converts XML/HTML tags to parentheses format to prevent injection
  * @param {string} s - The string to process
  * @returns {string} The string with XML tags converted to parentheses
  */
 function convertXmlTags(s) {
-  // Allow safe HTML tags supported by GitHub Flavored Markdown:
-  // b, blockquote, br, code, details, em, h1–h6, hr, i, li, ol, p, pre, strong, sub, summary, sup, table, tbody, td, th, thead, tr, ul
-  // Plus GFM inline tags: abbr, del, ins, kbd, mark, s, span
-  const allowedTags = [
-    "abbr",
-    "b",
-    "blockquote",
-    "br",
-    "code",
-    "del",
-    "details",
-    "em",
-    "h1",
-    "h2",
-    "h3",
-    "h4",
-    "h5",
-    "h6",
-    "hr",
-    "i",
-    "ins",
-    "kbd",
-    "li",
-    "mark",
-    "ol",
-    "p",
-    "pre",
-    "s",
-    "span",
-    "strong",
-    "sub",
-    "summary",
-    "sup",
-    "table",
-    "tbody",
-    "td",
-    "th",
-    "thead",
-    "tr",
-    "ul",
-  ];
+  const allowedTags = buildAllowedHtmlTags();
 
   // First, process CDATA sections specially - convert tags inside them and the CDATA markers
   s = s.replace(/<!\[CDATA\[([\s\S]*?)\]\]>/g, (match, content) => {
@@ -616,11 +637,16 @@ function neutralizeGitHubReferences(s, allowedRepos) {
 /**
  * Apply truncation limits to content
  * @param {string} content - The content to truncate
- * @param {number} [maxLength] - Maximum length of content (default: 524288)
+ * @param {number} [maxLength] - Maximum length of content (default: 524288, capped by GH_AW_SANITIZE_MAX_LENGTH)
  * @returns {string} The truncated content
  */
 function applyTruncation(content, maxLength) {
   maxLength = maxLength || 524288;
+  // safe-outputs.sanitize.max-length caps every sanitized field
+  const configuredMaxLength = parseInt(process.env.GH_AW_SANITIZE_MAX_LENGTH || "", 10);
+  if (configuredMaxLength > 0 && configuredMaxLength < maxLength) {
+    maxLength = configuredMaxLength;
+  }
   const lines = content.split("\n");
   const maxLines = 65000;
 
@@ -834,6 +860,8 @@ module.exports = {
   neutralizeGitHubReferences,
   removeXmlComments,
   convertXmlTags,
+  DEFAULT_ALLOWED_HTML_TAGS,
+  buildAllowedHtmlTags,
   neutralizeBotTriggers,
   MAX_BOT_TRIGGER_REFERENCES,
   neutralizeTemplateDelimiters,
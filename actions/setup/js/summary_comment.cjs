@@ -0,0 +1,191 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+// This script posts (or updates) a single sticky comment on the triggering
+// issue or pull request summarizing the workflow run (summary-comment: true).
+// The comment contains the agent's final summary, token usage, the safe outputs
+// produced by the agent and links to the run and its artifacts.
+
+const { loadAgentOutput } = require("./load_agent_output.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+
+/** Maximum length of the agent summary included in the comment */
+const MAX_SUMMARY_LENGTH = 10000;
+
+/**
+ * Builds the hidden marker used to find the sticky comment of a workflow
+ * @param {string} workflowId - Workflow identifier
+ * @returns {string} XML comment marker
+ */
+function generateSummaryCommentMarker(workflowId) {
+  return `<!-- gh-aw-summary-comment: ${workflowId} -->`;
+}
+
+/**
+ * Resolves the issue or pull request number of the triggering item
+ * @returns {number|undefined} Issue/PR number, or undefined when the event has no issue or PR
+ */
+function getTriggeringItemNumber() {
+  return context.payload?.issue?.number || context.payload?.pull_request?.number;
+}
+
+/**
+ * Parses the token usage reported by the log parser
+ * @param {string|undefined} value - JSON string with tokens and cost_usd
+ * @returns {{tokens: number, cost_usd: number}|null} Token usage or null
+ */
+function parseTokenUsage(value) {
+  if (!value) {
+    return null;
+  }
+  try {
+    const usage = JSON.parse(value);
+    if (usage && typeof usage === "object" && (usage.tokens || usage.cost_usd)) {
+      return { tokens: usage.tokens || 0, cost_usd: usage.cost_usd || 0 };
+    }
+  } catch (error) {
+    core.warning(`Failed to parse token usage: ${getErrorMessage(error)}`);
+  }
+  return null;
+}
+
+/**
+ * Counts safe output items by type
+ * @param {Array<{type: string}>} items - Agent output items
+ * @returns {Map<string, number>} Count per type, in order of first appearance
+ */
+function countOutputTypes(items) {
+  const counts = new Map();
+  for (const item of items) {
+    if (item && typeof item.type === "string") {
+      counts.set(item.type, (counts.get(item.type) || 0) + 1);
+    }
+  }
+  return counts;
+}
+
+/**
+ * Builds the body of the summary comment
+ * @param {Object} options
+ * @param {string} options.workflowName - Workflow name
+ * @param {string} options.runUrl - Workflow run URL
+ * @param {string} options.conclusion - Agent job conclusion
+ * @param {string} options.summary - Agent final summary (untrusted)
+ * @param {{tokens: number, cost_usd: number}|null} options.usage - Token usage
+ * @param {Array<{type: string}>} options.items - Safe output items produced by the agent
+ * @param {string} options.workflowId - Workflow identifier for the sticky marker
+ * @returns {string} Comment body
+ */
+function buildSummaryCommentBody({ workflowName, runUrl, conclusion, summary, usage, items, workflowId }) {
+  const statusIcon = conclusion === "success" ? "✅" : conclusion === "cancelled" ? "⚠️" : "❌";
+  let body = `## ${statusIcon} ${workflowName} — run summary\n\n`;
+  body += `**Status:** ${conclusion}\n\n`;
+
+  if (summary && summary.trim()) {
+    let text = summary.trim();
+    if (text.length > MAX_SUMMARY_LENGTH) {
+      text = text.substring(0, MAX_SUMMARY_LENGTH) + "\n\n…(truncated)";
+    }
+    body += `### Summary\n\n${sanitizeContent(text)}\n\n`;
+  }
+
+  const counts = countOutputTypes(items);
+  if (counts.size > 0) {
+    body += "### Safe outputs\n\n";
+    for (const [type, count] of counts) {
+      body += `- \`${type}\`: ${count}\n`;
+    }
+    body += "\n";
+  }
+
+  if (usage) {
+    const parts = [];
+    if (usage.tokens) {
+      parts.push(`${usage.tokens.toLocaleString("en-US")} tokens`);
+    }
+    if (usage.cost_usd) {
+      parts.push(`$${usage.cost_usd.toFixed(4)}`);
+    }
+    body += `**Usage:** ${parts.join(" · ")}\n\n`;
+  }
+
+  body += `[View run](${runUrl}) · [Artifacts](${runUrl}#artifacts)\n\n`;
+  body += generateSummaryCommentMarker(workflowId);
+  return body;
+}
+
+/**
+ * Finds the existing sticky summary comment on an issue or pull request
+ * @param {number} issueNumber - Issue/PR number
+ * @param {string} marker - Sticky comment marker
+ * @returns {Promise<number|undefined>} Comment ID if found
+ */
+async function findSummaryComment(issueNumber, marker) {
+  const comments = await github.paginate(github.rest.issues.listComments, {
+    owner: context.repo.owner,
+    repo: context.repo.repo,
+    issue_number: issueNumber,
+    per_page: 100,
+  });
+  const existing = comments.find(comment => comment.body && comment.body.includes(marker));
+  return existing?.id;
+}
+
+async function main() {
+  const issueNumber = getTriggeringItemNumber();
+  if (!issueNumber) {
+    core.info("No triggering issue or pull request, skipping summary comment");
+    return;
+  }
+
+  const workflowName = process.env.GH_AW_WORKFLOW_NAME || "Workflow";
+  const workflowId = process.env.GH_AW_WORKFLOW_ID || workflowName;
+  const runUrl = process.env.GH_AW_RUN_URL || "";
+  const conclusion = process.env.GH_AW_AGENT_CONCLUSION || "failure";
+
+  const agentOutput = loadAgentOutput();
+  const body = buildSummaryCommentBody({
+    workflowName,
+    runUrl,
+    conclusion,
+    summary: process.env.GH_AW_AGENT_SUMMARY || "",
+    usage: parseTokenUsage(process.env.GH_AW_TOKEN_USAGE),
+    items: agentOutput.success ? agentOutput.items || [] : [],
+    workflowId,
+  });
+
+  try {
+    const commentId = await findSummaryComment(issueNumber, generateSummaryCommentMarker(workflowId));
+    if (commentId) {
+      core.info(`Updating summary comment ${commentId} on #${issueNumber}`);
+      const { data } = await github.rest.issues.updateComment({
+        owner: context.repo.owner,
+        repo: context.repo.repo,
+        comment_id: commentId,
+        body,
+      });
+      core.setOutput("comment_url", data.html_url);
+    } else {
+      core.info(`Creating summary comment on #${issueNumber}`);
+      const { data } = await github.rest.issues.createComment({
+        owner: context.repo.owner,
+        repo: context.repo.repo,
+        issue_number: issueNumber,
+        body,
+      });
+      core.setOutput("comment_url", data.html_url);
+    }
+  } catch (error) {
+    // Don't fail the job - the summary comment is informational
+    core.warning(`Failed to post summary comment: ${getErrorMessage(error)}`);
+  }
+}
+
+module.exports = {
+  main,
+  buildSummaryCommentBody,
+  generateSummaryCommentMarker,
+  parseTokenUsage,
+  countOutputTypes,
+};
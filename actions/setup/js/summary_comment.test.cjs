@@ -0,0 +1,121 @@
+// @ts-check
+
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import path from "path";
+import os from "os";
+
+describe("summary_comment", () => {
+  let mockCore;
+  let mockGithub;
+  let originalEnv;
+  let tempDir;
+
+  beforeEach(() => {
+    originalEnv = { ...process.env };
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), "summary-comment-test-"));
+
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setOutput: vi.fn(),
+    };
+
+    mockGithub = {
+      paginate: vi.fn().mockResolvedValue([]),
+      rest: {
+        issues: {
+          listComments: vi.fn(),
+          createComment: vi.fn().mockResolvedValue({ data: { html_url: "https://github.com/test-owner/test-repo/issues/42#issuecomment-1" } }),
+          updateComment: vi.fn().mockResolvedValue({ data: { html_url: "https://github.com/test-owner/test-repo/issues/42#issuecomment-7" } }),
+        },
+      },
+    };
+
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.context = {
+      repo: { owner: "test-owner", repo: "test-repo" },
+      payload: { issue: { number: 42 } },
+    };
+
+    const agentOutputFile = path.join(tempDir, "agent_output.json");
+    fs.writeFileSync(agentOutputFile, JSON.stringify({ items: [{ type: "add_labels" }, { type: "add_labels" }, { type: "create_issue" }] }));
+    process.env.GH_AW_AGENT_OUTPUT = agentOutputFile;
+    process.env.GH_AW_WORKFLOW_NAME = "Triage";
+    process.env.GH_AW_WORKFLOW_ID = "triage";
+    process.env.GH_AW_RUN_URL = "https://github.com/test-owner/test-repo/actions/runs/123";
+    process.env.GH_AW_AGENT_CONCLUSION = "success";
+    process.env.GH_AW_AGENT_SUMMARY = "Labeled the issue as a bug.";
+    process.env.GH_AW_TOKEN_USAGE = JSON.stringify({ tokens: 12345, cost_usd: 0.5 });
+  });
+
+  afterEach(() => {
+    for (const key of Object.keys(process.env)) {
+      if (!(key in originalEnv)) {
+        delete process.env[key];
+      }
+    }
+    Object.assign(process.env, originalEnv);
+    fs.rmSync(tempDir, { recursive: true, force: true });
+    delete global.core;
+    delete global.github;
+    delete global.context;
+    vi.clearAllMocks();
+  });
+
+  it("creates a sticky comment with summary, outputs, usage and links", async () => {
+    const { main } = await import("./summary_comment.cjs");
+    await main();
+
+    expect(mockGithub.rest.issues.createComment).toHaveBeenCalledTimes(1);
+    const body = mockGithub.rest.issues.createComment.mock.calls[0][0].body;
+    expect(body).toContain("## ✅ Triage — run summary");
+    expect(body).toContain("Labeled the issue as a bug.");
+    expect(body).toContain("- `add_labels`: 2");
+    expect(body).toContain("- `create_issue`: 1");
+    expect(body).toContain("12,345 tokens");
+    expect(body).toContain("$0.5000");
+    expect(body).toContain("[Artifacts](https://github.com/test-owner/test-repo/actions/runs/123#artifacts)");
+    expect(body).toContain("<!-- gh-aw-summary-comment: triage -->");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("comment_url", "https://github.com/test-owner/test-repo/issues/42#issuecomment-1");
+  });
+
+  it("updates the existing sticky comment", async () => {
+    mockGithub.paginate.mockResolvedValue([{ id: 7, body: "old\n<!-- gh-aw-summary-comment: triage -->" }]);
+
+    const { main } = await import("./summary_comment.cjs");
+    await main();
+
+    expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+    expect(mockGithub.rest.issues.updateComment).toHaveBeenCalledWith(expect.objectContaining({ comment_id: 7 }));
+  });
+
+  it("skips events without a triggering issue or pull request", async () => {
+    global.context.payload = {};
+
+    const { main } = await import("./summary_comment.cjs");
+    await main();
+
+    expect(mockGithub.paginate).not.toHaveBeenCalled();
+    expect(mockCore.info).toHaveBeenCalledWith("No triggering issue or pull request, skipping summary comment");
+  });
+
+  it("warns instead of failing when the API call fails", async () => {
+    mockGithub.rest.issues.createComment.mockRejectedValue(new Error("Resource not accessible"));
+
+    const { main } = await import("./summary_comment.cjs");
+    await main();
+
+    expect(mockCore.warning).toHaveBeenCalledWith("Failed to post summary comment: Resource not accessible");
+  });
+
+  it("ignores malformed token usage", async () => {
+    const { parseTokenUsage } = await import("./summary_comment.cjs");
+
+    expect(parseTokenUsage("not json")).toBeNull();
+    expect(parseTokenUsage(JSON.stringify({ tokens: 0, cost_usd: 0 }))).toBeNull();
+    expect(parseTokenUsage(JSON.stringify({ tokens: 10 }))).toEqual({ tokens: 10, cost_usd: 0 });
+  });
+});
@@ -0,0 +1,61 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Helpers for team allow-lists (allowed-teams in request-review and
+ * assign configuration).
+ */
+
+const { processItems } = require("./safe_output_processor.cjs");
+
+/**
+ * Normalizes a team reference to its slug: "@octo-org/docs-team" and
+ * "octo-org/docs-team" both become "docs-team".
+ * @param {any} team - Team reference from the agent output or the configuration
+ * @returns {string} Lowercase team slug
+ */
+function normalizeTeamSlug(team) {
+  const value = String(team ?? "")
+    .trim()
+    .replace(/^@/, "");
+  return value.slice(value.lastIndexOf("/") + 1).toLowerCase();
+}
+
+/**
+ * Filters requested teams against the allow-list. Unlike users, teams are only
+ * allowed when they are listed explicitly: an empty allow-list rejects every team.
+ * @param {any[]} requestedTeams - Teams from the agent output
+ * @param {string[]} allowedTeams - Normalized allowed team slugs
+ * @param {number} maxCount - Maximum number of teams
+ * @returns {string[]} Allowed team slugs
+ */
+function filterAllowedTeams(requestedTeams, allowedTeams, maxCount) {
+  const teams = requestedTeams.map(normalizeTeamSlug).filter(Boolean);
+  if (teams.length === 0) {
+    return [];
+  }
+  if (allowedTeams.length === 0) {
+    core.warning(`Ignoring teams ${JSON.stringify(teams)}: no allowed-teams are configured`);
+    return [];
+  }
+  return processItems(teams, allowedTeams, maxCount);
+}
+
+/**
+ * Lists the logins of the members of an organization team. The token needs
+ * organization members read access, which the GITHUB_TOKEN does not have.
+ * @param {any} githubClient - Authenticated GitHub client
+ * @param {string} org - Organization owning the team
+ * @param {string} teamSlug - Team slug
+ * @returns {Promise<string[]>} Member logins
+ */
+async function listTeamMembers(githubClient, org, teamSlug) {
+  const members = await githubClient.paginate(githubClient.rest.teams.listMembersInOrg, {
+    org,
+    team_slug: teamSlug,
+    per_page: 100,
+  });
+  return members.map(member => member.login);
+}
+
+module.exports = { normalizeTeamSlug, filterAllowedTeams, listTeamMembers };
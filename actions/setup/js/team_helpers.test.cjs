@@ -0,0 +1,27 @@
+import { describe, it, expect, vi } from "vitest";
+
+global.core = { info: vi.fn(), warning: vi.fn() };
+
+const { normalizeTeamSlug, filterAllowedTeams } = require("./team_helpers.cjs");
+
+describe("team_helpers.cjs", () => {
+  it("should normalize team references to lowercase slugs", () => {
+    expect(normalizeTeamSlug("docs-team")).toBe("docs-team");
+    expect(normalizeTeamSlug("@octo-org/Docs-Team")).toBe("docs-team");
+    expect(normalizeTeamSlug(" octo-org/docs-team ")).toBe("docs-team");
+    expect(normalizeTeamSlug(null)).toBe("");
+  });
+
+  it("should keep only allowed teams", () => {
+    expect(filterAllowedTeams(["@octo-org/docs-team", "admins", "docs-team"], ["docs-team"], 5)).toEqual(["docs-team"]);
+  });
+
+  it("should reject every team when the allow-list is empty", () => {
+    expect(filterAllowedTeams(["docs-team"], [], 5)).toEqual([]);
+    expect(global.core.warning).toHaveBeenCalledWith(expect.stringContaining("no allowed-teams are configured"));
+  });
+
+  it("should limit the number of teams", () => {
+    expect(filterAllowedTeams(["a", "b", "c"], ["a", "b", "c"], 2)).toEqual(["a", "b"]);
+  });
+});
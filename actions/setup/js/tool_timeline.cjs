@@ -0,0 +1,209 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { formatDuration, formatMcpName } = require("./log_parser_shared.cjs");
+
+/** Maximum number of tool calls drawn in the timeline */
+const MAX_TIMELINE_TOOL_CALLS = 100;
+
+/**
+ * @typedef {Object} ToolCall
+ * @property {string} name - Display name of the tool (e.g., github::list_issues)
+ * @property {string} section - Timeline section (MCP server name or "builtin")
+ * @property {number} start - Start offset in milliseconds from the first tool call
+ * @property {number|null} duration - Duration in milliseconds, or null when unknown
+ * @property {boolean} failed - Whether the tool call returned an error
+ */
+
+/**
+ * Parses an entry timestamp into milliseconds since the epoch
+ * @param {any} value - Timestamp value (ISO string or number)
+ * @returns {number|null} Milliseconds, or null when the timestamp is missing or invalid
+ */
+function parseTimestamp(value) {
+  if (typeof value === "number" && Number.isFinite(value)) {
+    return value;
+  }
+  if (typeof value === "string" && value) {
+    const parsed = Date.parse(value);
+    return Number.isNaN(parsed) ? null : parsed;
+  }
+  return null;
+}
+
+/**
+ * Collects tool calls with their timing and outcome from parsed log entries.
+ * Entries with timestamps are placed at their recorded time. Otherwise calls are laid out
+ * one after another using the durations reported in the tool results.
+ *
+ * @param {Array<any>} logEntries - Parsed log entries
+ * @returns {ToolCall[]} Tool calls in transcript order
+ */
+function collectToolCalls(logEntries) {
+  /** @type {Map<string, {result: any, timestamp: number|null}>} */
+  const results = new Map();
+  for (const entry of logEntries) {
+    if (entry?.type !== "user" || !Array.isArray(entry.message?.content)) {
+      continue;
+    }
+    for (const content of entry.message.content) {
+      if (content?.type === "tool_result" && content.tool_use_id) {
+        results.set(content.tool_use_id, { result: content, timestamp: parseTimestamp(entry.timestamp) });
+      }
+    }
+  }
+
+  /** @type {ToolCall[]} */
+  const calls = [];
+  let origin = null;
+  let cursor = 0;
+  for (const entry of logEntries) {
+    if (entry?.type !== "assistant" || !Array.isArray(entry.message?.content)) {
+      continue;
+    }
+    const entryTime = parseTimestamp(entry.timestamp);
+    for (const content of entry.message.content) {
+      if (content?.type !== "tool_use" || !content.name) {
+        continue;
+      }
+      const paired = results.get(content.id);
+      const result = paired?.result;
+
+      let start = cursor;
+      if (entryTime !== null) {
+        origin = origin ?? entryTime;
+        start = Math.max(entryTime - origin, 0);
+      }
+
+      let duration = null;
+      if (typeof result?.duration_ms === "number" && result.duration_ms >= 0) {
+        duration = result.duration_ms;
+      } else if (entryTime !== null && paired?.timestamp !== null && paired?.timestamp !== undefined) {
+        duration = Math.max(paired.timestamp - entryTime, 0);
+      }
+
+      const name = formatMcpName(content.name);
+      const separator = name.indexOf("::");
+      calls.push({
+        name,
+        section: separator > 0 ? name.substring(0, separator) : "builtin",
+        start,
+        duration,
+        failed: result?.is_error === true,
+      });
+      cursor = start + (duration ?? 0);
+    }
+  }
+  return calls;
+}
+
+/**
+ * Formats a tool call duration, keeping sub-second durations visible
+ * @param {number} ms - Duration in milliseconds
+ * @returns {string} Formatted duration (e.g., "250ms", "3s")
+ */
+function formatCallDuration(ms) {
+  return ms < 1000 ? `${Math.round(ms)}ms` : formatDuration(ms);
+}
+
+/**
+ * Escapes a label for use as a Mermaid gantt task or section name
+ * @param {string} label - Raw label
+ * @returns {string} Label without characters that Mermaid treats as syntax
+ */
+function escapeMermaidLabel(label) {
+  return label
+    .replace(/::/g, ".")
+    .replace(/[:;#\n\r]/g, " ")
+    .trim();
+}
+
+/**
+ * Generates a step summary section with a Mermaid gantt chart of the agent's tool calls,
+ * their durations, and failures.
+ *
+ * @param {Array<any>} logEntries - Parsed log entries
+ * @returns {string} Markdown section, or an empty string when there are no tool calls
+ */
+function generateToolTimelineSummary(logEntries) {
+  if (!Array.isArray(logEntries)) {
+    return "";
+  }
+  const calls = collectToolCalls(logEntries);
+  if (calls.length === 0) {
+    return "";
+  }
+
+  const shown = calls.slice(0, MAX_TIMELINE_TOOL_CALLS);
+  const failed = calls.filter(call => call.failed);
+  const totalDuration = calls.reduce((sum, call) => sum + (call.duration ?? 0), 0);
+
+  const lines = [];
+  lines.push("<details>");
+  lines.push("<summary>Tool Call Timeline</summary>");
+  lines.push("");
+
+  let stats = `${calls.length} tool call${calls.length === 1 ? "" : "s"}`;
+  if (failed.length > 0) {
+    stats += `, ${failed.length} failed`;
+  }
+  const totalText = formatDuration(totalDuration);
+  if (totalText) {
+    stats += `, ${totalText} in tools`;
+  }
+  lines.push(`**${stats}**`);
+  lines.push("");
+
+  lines.push("```mermaid");
+  lines.push("gantt");
+  lines.push("  dateFormat x");
+  lines.push("  axisFormat %M:%S");
+  // Group calls by section so each MCP server gets one row group; bars keep their start times
+  /** @type {Map<string, Array<{call: ToolCall, index: number}>>} */
+  const sections = new Map();
+  shown.forEach((call, index) => {
+    const sectionCalls = sections.get(call.section) || [];
+    sectionCalls.push({ call, index });
+    sections.set(call.section, sectionCalls);
+  });
+  for (const [section, sectionCalls] of sections) {
+    lines.push(`  section ${escapeMermaidLabel(section)}`);
+    for (const { call, index } of sectionCalls) {
+      const durationText = call.duration !== null ? formatCallDuration(call.duration) : "";
+      const label = escapeMermaidLabel(durationText ? `${call.name} (${durationText})` : call.name);
+      const tags = [];
+      if (call.failed) {
+        tags.push("crit");
+      }
+      if (call.duration === null) {
+        tags.push("milestone");
+      }
+      const end = call.start + (call.duration ?? 0);
+      lines.push(`  ${label} :${[...tags, `t${index + 1}`, String(call.start), String(end)].join(", ")}`);
+    }
+  }
+  lines.push("```");
+
+  if (calls.length > shown.length) {
+    lines.push("");
+    lines.push(`*Showing the first ${shown.length} of ${calls.length} tool calls.*`);
+  }
+
+  if (failed.length > 0) {
+    lines.push("");
+    lines.push("Failed tool calls:");
+    for (const call of failed) {
+      lines.push(`- \`${call.name}\``);
+    }
+  }
+
+  lines.push("");
+  lines.push("</details>");
+  return lines.join("\n");
+}
+
+module.exports = {
+  collectToolCalls,
+  escapeMermaidLabel,
+  generateToolTimelineSummary,
+};
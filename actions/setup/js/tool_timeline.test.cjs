@@ -0,0 +1,92 @@
+import { describe, it, expect } from "vitest";
+import { collectToolCalls, escapeMermaidLabel, generateToolTimelineSummary } from "./tool_timeline.cjs";
+
+/**
+ * @param {Array<{id: string, name: string}>} uses
+ * @param {Array<any>} results
+ */
+const conversation = (uses, results) => [
+  { type: "assistant", message: { content: uses.map(use => ({ type: "tool_use", ...use })) } },
+  { type: "user", message: { content: results.map(result => ({ type: "tool_result", ...result })) } },
+];
+
+describe("tool_timeline.cjs", () => {
+  describe("collectToolCalls", () => {
+    it("should lay out calls sequentially using reported durations", () => {
+      const entries = conversation(
+        [
+          { id: "a", name: "mcp__github__list_issues" },
+          { id: "b", name: "Bash" },
+        ],
+        [
+          { tool_use_id: "a", duration_ms: 1200 },
+          { tool_use_id: "b", duration_ms: 300, is_error: true },
+        ]
+      );
+
+      const calls = collectToolCalls(entries);
+
+      expect(calls).toEqual([
+        { name: "github::list_issues", section: "github", start: 0, duration: 1200, failed: false },
+        { name: "Bash", section: "builtin", start: 1200, duration: 300, failed: true },
+      ]);
+    });
+
+    it("should use entry timestamps when available", () => {
+      const entries = [
+        { type: "assistant", timestamp: "2026-01-01T00:00:00Z", message: { content: [{ type: "tool_use", id: "a", name: "Bash" }] } },
+        { type: "user", timestamp: "2026-01-01T00:00:02Z", message: { content: [{ type: "tool_result", tool_use_id: "a" }] } },
+        { type: "assistant", timestamp: "2026-01-01T00:00:05Z", message: { content: [{ type: "tool_use", id: "b", name: "Bash" }] } },
+      ];
+
+      const calls = collectToolCalls(entries);
+
+      expect(calls[0]).toMatchObject({ start: 0, duration: 2000 });
+      expect(calls[1]).toMatchObject({ start: 5000, duration: null });
+    });
+  });
+
+  describe("generateToolTimelineSummary", () => {
+    it("should return an empty string without tool calls", () => {
+      expect(generateToolTimelineSummary([{ type: "assistant", message: { content: [{ type: "text", text: "hi" }] } }])).toBe("");
+      expect(generateToolTimelineSummary(null)).toBe("");
+    });
+
+    it("should render a mermaid gantt chart with failures marked", () => {
+      const entries = conversation(
+        [
+          { id: "a", name: "mcp__github__list_issues" },
+          { id: "b", name: "Bash" },
+          { id: "c", name: "mcp__github__get_issue" },
+        ],
+        [
+          { tool_use_id: "a", duration_ms: 1200 },
+          { tool_use_id: "b", duration_ms: 250, is_error: true },
+        ]
+      );
+
+      const summary = generateToolTimelineSummary(entries);
+
+      expect(summary).toContain("<summary>Tool Call Timeline</summary>");
+      expect(summary).toContain("**3 tool calls, 1 failed, 1s in tools**");
+      expect(summary).toContain("```mermaid\ngantt\n  dateFormat x");
+      expect(summary).toContain("  section github\n  github.list_issues (1s) :t1, 0, 1200\n  github.get_issue :milestone, t3, 1450, 1450");
+      expect(summary).toContain("  section builtin\n  Bash (250ms) :crit, t2, 1200, 1450");
+      expect(summary).toContain("Failed tool calls:\n- `Bash`");
+    });
+
+    it("should limit the number of charted calls", () => {
+      const uses = Array.from({ length: 105 }, (_, i) => ({ id: `t${i}`, name: "Bash" }));
+      const summary = generateToolTimelineSummary(conversation(uses, []));
+
+      expect(summary).toContain("*Showing the first 100 of 105 tool calls.*");
+      expect(summary).not.toContain(":milestone, t101,");
+    });
+  });
+
+  describe("escapeMermaidLabel", () => {
+    it("should remove mermaid syntax characters", () => {
+      expect(escapeMermaidLabel("github::search: a;b #1")).toBe("github.search  a b  1");
+    });
+  });
+});
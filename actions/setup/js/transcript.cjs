@@ -0,0 +1,197 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Canonical Transcript
+ *
+ * Each engine writes its log in its own shape. The engine log parsers convert them to
+ * common log entries (system/assistant/user/result entries with Claude-style content parts);
+ * this module normalizes those entries into the canonical transcript.json artifact that
+ * `gh aw logs` and `gh aw audit` read regardless of the engine.
+ *
+ * Format (version 1):
+ * {
+ *   "version": 1,
+ *   "engine": "claude",
+ *   "model": "claude-sonnet-4",
+ *   "messages": [{ "role": "assistant", "text": "..." }],
+ *   "tool_calls": [{ "id": "...", "name": "github::list_issues", "input": {...}, "output": "...", "is_error": false, "duration_ms": 1200 }],
+ *   "tokens": { "input": 0, "output": 0, "cache_read": 0, "cache_write": 0, "total": 0 },
+ *   "cost_usd": 0,
+ *   "turns": 3,
+ *   "duration_ms": 45000
+ * }
+ */
+
+const { formatMcpName } = require("./log_parser_shared.cjs");
+
+/** Version of the canonical transcript format */
+const TRANSCRIPT_VERSION = 1;
+
+/** Maximum length of a tool call output kept in the transcript */
+const MAX_TOOL_OUTPUT_LENGTH = 2000;
+
+/**
+ * @typedef {Object} TranscriptToolCall
+ * @property {string} id - Tool call ID
+ * @property {string} name - Tool name (MCP tools as server::tool)
+ * @property {any} input - Tool input
+ * @property {string} output - Tool output, truncated to MAX_TOOL_OUTPUT_LENGTH characters
+ * @property {boolean} is_error - Whether the tool call failed
+ * @property {number|null} duration_ms - Duration in milliseconds, or null when unknown
+ */
+
+/**
+ * @typedef {Object} Transcript
+ * @property {number} version - Format version
+ * @property {string} engine - Engine ID
+ * @property {string|null} model - Model reported by the engine
+ * @property {Array<{role: string, text: string}>} messages - Text messages in order
+ * @property {TranscriptToolCall[]} tool_calls - Tool calls in order
+ * @property {{input: number, output: number, cache_read: number, cache_write: number, total: number}} tokens - Token usage
+ * @property {number} cost_usd - Cost reported by the engine
+ * @property {number} turns - Number of turns
+ * @property {number|null} duration_ms - Run duration reported by the engine, or null when unknown
+ */
+
+/**
+ * Converts tool result content to text
+ * @param {any} content - Tool result content (string or content parts)
+ * @returns {string}
+ */
+function toolResultText(content) {
+  if (typeof content === "string") {
+    return content;
+  }
+  if (Array.isArray(content)) {
+    return content
+      .map(part => (typeof part === "string" ? part : typeof part?.text === "string" ? part.text : ""))
+      .filter(Boolean)
+      .join("\n");
+  }
+  return content == null ? "" : JSON.stringify(content);
+}
+
+/**
+ * @param {any} value
+ * @returns {number} The value when it is a non-negative finite number, otherwise 0
+ */
+function count(value) {
+  return typeof value === "number" && Number.isFinite(value) && value >= 0 ? value : 0;
+}
+
+/**
+ * Builds the canonical transcript from parsed log entries
+ * @param {Array<any>} logEntries - Log entries returned by an engine log parser
+ * @param {string} engine - Engine ID
+ * @returns {Transcript}
+ */
+function buildTranscript(logEntries, engine) {
+  /** @type {Map<string, {content: any, is_error: boolean, duration_ms: number|null, timestamp: number|null}>} */
+  const results = new Map();
+  for (const entry of logEntries) {
+    if (entry?.type !== "user" || !Array.isArray(entry.message?.content)) {
+      continue;
+    }
+    for (const part of entry.message.content) {
+      if (part?.type === "tool_result" && part.tool_use_id) {
+        const timestamp = entry.timestamp ? Date.parse(entry.timestamp) : NaN;
+        results.set(part.tool_use_id, {
+          content: part.content,
+          is_error: part.is_error === true,
+          duration_ms: typeof part.duration_ms === "number" ? part.duration_ms : null,
+          timestamp: Number.isNaN(timestamp) ? null : timestamp,
+        });
+      }
+    }
+  }
+
+  /** @type {Transcript} */
+  const transcript = {
+    version: TRANSCRIPT_VERSION,
+    engine,
+    model: null,
+    messages: [],
+    tool_calls: [],
+    tokens: { input: 0, output: 0, cache_read: 0, cache_write: 0, total: 0 },
+    cost_usd: 0,
+    turns: 0,
+    duration_ms: null,
+  };
+
+  let assistantTurns = 0;
+  for (const entry of logEntries) {
+    if (entry?.type === "system" && entry.subtype === "init" && typeof entry.model === "string") {
+      transcript.model = entry.model;
+      continue;
+    }
+
+    if (entry?.type === "result") {
+      const usage = entry.usage || {};
+      transcript.tokens.input = count(usage.input_tokens);
+      transcript.tokens.output = count(usage.output_tokens);
+      transcript.tokens.cache_read = count(usage.cache_read_input_tokens);
+      transcript.tokens.cache_write = count(usage.cache_creation_input_tokens);
+      transcript.cost_usd = count(entry.total_cost_usd);
+      transcript.turns = count(entry.num_turns);
+      transcript.duration_ms = typeof entry.duration_ms === "number" ? entry.duration_ms : null;
+      continue;
+    }
+
+    if ((entry?.type !== "assistant" && entry?.type !== "user") || !Array.isArray(entry.message?.content)) {
+      continue;
+    }
+    if (entry.type === "assistant") {
+      assistantTurns++;
+    }
+
+    const entryTime = entry.timestamp ? Date.parse(entry.timestamp) : NaN;
+    for (const part of entry.message.content) {
+      if (part?.type === "text" && typeof part.text === "string" && part.text.trim()) {
+        transcript.messages.push({ role: entry.type, text: part.text });
+      } else if (entry.type === "assistant" && part?.type === "tool_use" && part.name) {
+        const result = results.get(part.id);
+        let duration = result?.duration_ms ?? null;
+        if (duration === null && result?.timestamp != null && !Number.isNaN(entryTime)) {
+          duration = Math.max(result.timestamp - entryTime, 0);
+        }
+        transcript.tool_calls.push({
+          id: String(part.id ?? ""),
+          name: formatMcpName(part.name),
+          input: part.input ?? {},
+          output: toolResultText(result?.content).substring(0, MAX_TOOL_OUTPUT_LENGTH),
+          is_error: result?.is_error === true,
+          duration_ms: duration,
+        });
+      }
+    }
+  }
+
+  const tokens = transcript.tokens;
+  tokens.total = tokens.input + tokens.output + tokens.cache_read + tokens.cache_write;
+  if (transcript.turns === 0) {
+    transcript.turns = assistantTurns;
+  }
+  return transcript;
+}
+
+/**
+ * Writes the canonical transcript to the path in GH_AW_TRANSCRIPT, when set
+ * @param {Array<any>} logEntries - Log entries returned by an engine log parser
+ * @param {string} parserName - Name of the parser, used when GH_AW_ENGINE_ID is not set
+ */
+function writeTranscript(logEntries, parserName) {
+  const transcriptPath = process.env.GH_AW_TRANSCRIPT;
+  if (!transcriptPath) {
+    return;
+  }
+  const fs = require("fs");
+  const path = require("path");
+  const engine = process.env.GH_AW_ENGINE_ID || parserName.toLowerCase();
+  const transcript = buildTranscript(logEntries, engine);
+  fs.mkdirSync(path.dirname(transcriptPath), { recursive: true });
+  fs.writeFileSync(transcriptPath, JSON.stringify(transcript, null, 2));
+  core.info(`Wrote canonical transcript to ${transcriptPath} (${transcript.messages.length} messages, ${transcript.tool_calls.length} tool calls)`);
+}
+
+module.exports = { TRANSCRIPT_VERSION, buildTranscript, writeTranscript };
@@ -0,0 +1,66 @@
+import { describe, it, expect } from "vitest";
+import { buildTranscript, TRANSCRIPT_VERSION } from "./transcript.cjs";
+
+describe("transcript.cjs", () => {
+  describe("buildTranscript", () => {
+    it("should normalize messages, tool calls, tokens and timings", () => {
+      const entries = [
+        { type: "system", subtype: "init", model: "claude-sonnet-4" },
+        {
+          type: "assistant",
+          message: {
+            content: [
+              { type: "text", text: "Looking at the issues." },
+              { type: "tool_use", id: "a", name: "mcp__github__list_issues", input: { state: "open" } },
+            ],
+          },
+        },
+        { type: "user", message: { content: [{ type: "tool_result", tool_use_id: "a", content: [{ type: "text", text: "[]" }], duration_ms: 1200 }] } },
+        { type: "assistant", message: { content: [{ type: "tool_use", id: "b", name: "Bash", input: { command: "false" } }] } },
+        { type: "user", message: { content: [{ type: "tool_result", tool_use_id: "b", content: "exit 1", is_error: true }] } },
+        { type: "assistant", message: { content: [{ type: "text", text: "No open issues." }] } },
+        {
+          type: "result",
+          num_turns: 3,
+          duration_ms: 45000,
+          total_cost_usd: 0.12,
+          usage: { input_tokens: 100, output_tokens: 20, cache_read_input_tokens: 50, cache_creation_input_tokens: 5 },
+        },
+      ];
+
+      const transcript = buildTranscript(entries, "claude");
+
+      expect(transcript).toEqual({
+        version: TRANSCRIPT_VERSION,
+        engine: "claude",
+        model: "claude-sonnet-4",
+        messages: [
+          { role: "assistant", text: "Looking at the issues." },
+          { role: "assistant", text: "No open issues." },
+        ],
+        tool_calls: [
+          { id: "a", name: "github::list_issues", input: { state: "open" }, output: "[]", is_error: false, duration_ms: 1200 },
+          { id: "b", name: "Bash", input: { command: "false" }, output: "exit 1", is_error: true, duration_ms: null },
+        ],
+        tokens: { input: 100, output: 20, cache_read: 50, cache_write: 5, total: 175 },
+        cost_usd: 0.12,
+        turns: 3,
+        duration_ms: 45000,
+      });
+    });
+
+    it("should derive durations from timestamps and count turns without a result entry", () => {
+      const entries = [
+        { type: "assistant", timestamp: "2026-01-01T00:00:00.000Z", message: { content: [{ type: "tool_use", id: "a", name: "shell", input: {} }] } },
+        { type: "user", timestamp: "2026-01-01T00:00:02.500Z", message: { content: [{ type: "tool_result", tool_use_id: "a", content: "ok" }] } },
+      ];
+
+      const transcript = buildTranscript(entries, "codex");
+
+      expect(transcript.tool_calls[0].duration_ms).toBe(2500);
+      expect(transcript.turns).toBe(1);
+      expect(transcript.model).toBeNull();
+      expect(transcript.tokens.total).toBe(0);
+    });
+  });
+});
@@ -0,0 +1,63 @@
+// @ts-check
+
+/**
+ * Pattern matching a trigger section heading such as "## on: issues" or "### on: issues, pull_request".
+ * Captures the heading marks and the comma-separated event names.
+ */
+const TRIGGER_SECTION_HEADING = /^ {0,3}(#{1,6})[ \t]+on:[ \t]*(.+?)[ \t]*#*[ \t]*$/i;
+
+/**
+ * Pattern matching any ATX heading, capturing its marks
+ */
+const HEADING = /^ {0,3}(#{1,6})[ \t]+/;
+
+/**
+ * Keeps the trigger sections of a prompt that apply to the current event and removes the others.
+ * A trigger section starts at a heading whose text is "on: <event>[, <event>...]" and ends at the
+ * next heading of the same or a higher level. The heading itself is dropped, since it only selects
+ * the event. Content outside trigger sections and inside fenced code blocks is left unchanged.
+ * @param {string} content - The markdown content
+ * @param {string} eventName - The name of the event that triggered the run
+ * @returns {string} - The content with the sections for other events removed
+ */
+function filterTriggerSections(content, eventName) {
+  const lines = content.split("\n");
+  const result = [];
+  let inFence = false;
+  /** @type {{level: number, keep: boolean} | null} */
+  let section = null;
+  let afterHeading = false;
+
+  for (const line of lines) {
+    const trimmed = line.trim();
+    // Drop the blank line after a removed trigger heading along with the heading
+    if (afterHeading) {
+      afterHeading = false;
+      if (trimmed === "") {
+        continue;
+      }
+    }
+    if (trimmed.startsWith("```") || trimmed.startsWith("~~~")) {
+      inFence = !inFence;
+    } else if (!inFence) {
+      const heading = line.match(HEADING);
+      if (heading && section && heading[1].length <= section.level) {
+        section = null;
+      }
+      const trigger = line.match(TRIGGER_SECTION_HEADING);
+      if (trigger && !section) {
+        const events = trigger[2].split(",").map(event => event.trim());
+        section = { level: trigger[1].length, keep: events.includes(eventName) };
+        afterHeading = true;
+        continue;
+      }
+    }
+    if (!section || section.keep) {
+      result.push(line);
+    }
+  }
+
+  return result.join("\n");
+}
+
+module.exports = { filterTriggerSections };
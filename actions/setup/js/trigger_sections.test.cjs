@@ -0,0 +1,39 @@
+import { describe, it, expect } from "vitest";
+
+const { filterTriggerSections } = require("./trigger_sections.cjs");
+
+describe("trigger_sections.cjs", () => {
+  describe("filterTriggerSections", () => {
+    const prompt = ["# Triage", "", "Shared instructions.", "", "## on: issues", "", "Label the issue.", "", "### Details", "", "Issue details.", "", "## on: schedule, workflow_dispatch", "", "Review open issues.", "", "## Output", "", "Be brief."].join("\n");
+
+    it("should keep the section of the current event without its heading", () => {
+      expect(filterTriggerSections(prompt, "issues")).toBe(["# Triage", "", "Shared instructions.", "", "Label the issue.", "", "### Details", "", "Issue details.", "", "## Output", "", "Be brief."].join("\n"));
+    });
+
+    it("should match any event listed in the heading", () => {
+      const result = filterTriggerSections(prompt, "workflow_dispatch");
+      expect(result).toContain("Review open issues.");
+      expect(result).not.toContain("Label the issue.");
+      expect(result).not.toContain("on: schedule");
+    });
+
+    it("should remove every trigger section for other events", () => {
+      const result = filterTriggerSections(prompt, "issue_comment");
+      expect(result).not.toContain("Label the issue.");
+      expect(result).not.toContain("Issue details.");
+      expect(result).not.toContain("Review open issues.");
+      expect(result).toContain("Shared instructions.");
+      expect(result).toContain("Be brief.");
+    });
+
+    it("should ignore headings in fenced code blocks", () => {
+      const content = ["```markdown", "## on: issues", "```", "Text"].join("\n");
+      expect(filterTriggerSections(content, "schedule")).toBe(content);
+    });
+
+    it("should leave content without trigger sections unchanged", () => {
+      const content = "# Title\n\n## Online: docs\n\nText";
+      expect(filterTriggerSections(content, "issues")).toBe(content);
+    });
+  });
+});
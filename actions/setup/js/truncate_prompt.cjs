@@ -0,0 +1,88 @@
+// @ts-check
+
+const fs = require("fs");
+
+/**
+ * Prompt truncation for the context budget (context: in frontmatter).
+ *
+ * Runs after the prompt has been rendered and truncates it in place when it is
+ * longer than GH_AW_CONTEXT_MAX_CHARS, using the configured strategy:
+ *  - head: keep the beginning of the prompt
+ *  - tail: keep the end of the prompt
+ *  - summarize: keep the beginning and the end, replacing the middle with a note
+ *
+ * Environment:
+ *  - GH_AW_PROMPT: rendered prompt file
+ *  - GH_AW_CONTEXT_STRATEGY: head, tail or summarize
+ *  - GH_AW_CONTEXT_MAX_CHARS: maximum prompt size in characters
+ */
+
+/**
+ * Builds the note inserted where content was removed
+ * @param {number} omitted - Number of characters removed
+ * @returns {string} Truncation note
+ */
+function truncationNote(omitted) {
+  return `\n\n[... ${omitted} characters omitted to fit the context budget ...]\n\n`;
+}
+
+/**
+ * Truncates the prompt to the character budget
+ * @param {string} content - Rendered prompt
+ * @param {string} strategy - head, tail or summarize
+ * @param {number} maxChars - Maximum prompt size in characters
+ * @returns {{content: string, omitted: number}} Truncated prompt and number of removed characters
+ */
+function truncatePrompt(content, strategy, maxChars) {
+  if (content.length <= maxChars) {
+    return { content, omitted: 0 };
+  }
+
+  // Reserve room for the note so the result stays within the budget
+  const noteLength = truncationNote(content.length).length;
+  const keep = Math.max(maxChars - noteLength, 0);
+  const omitted = content.length - keep;
+  const note = truncationNote(omitted);
+
+  switch (strategy) {
+    case "tail":
+      return { content: note.trimStart() + content.slice(content.length - keep), omitted };
+    case "summarize": {
+      const headLength = Math.ceil(keep / 2);
+      const tailLength = keep - headLength;
+      return { content: content.slice(0, headLength) + note + content.slice(content.length - tailLength), omitted };
+    }
+    default:
+      return { content: content.slice(0, keep) + note.trimEnd() + "\n", omitted };
+  }
+}
+
+function main() {
+  const promptPath = process.env.GH_AW_PROMPT || "";
+  const strategy = process.env.GH_AW_CONTEXT_STRATEGY || "head";
+  const maxChars = parseInt(process.env.GH_AW_CONTEXT_MAX_CHARS || "0", 10) || 0;
+
+  if (!promptPath || maxChars <= 0) {
+    process.stderr.write("GH_AW_PROMPT and GH_AW_CONTEXT_MAX_CHARS are required\n");
+    process.exit(1);
+  }
+
+  const original = fs.readFileSync(promptPath, "utf8");
+  const result = truncatePrompt(original, strategy, maxChars);
+  if (result.omitted === 0) {
+    process.stdout.write(`Prompt size ${original.length} is within the context budget of ${maxChars} characters\n`);
+    return;
+  }
+
+  fs.writeFileSync(promptPath, result.content);
+  process.stdout.write(`Truncated prompt from ${original.length} to ${result.content.length} characters (strategy: ${strategy})\n`);
+}
+
+if (require.main === module) {
+  main();
+}
+
+module.exports = {
+  truncatePrompt,
+  main,
+};
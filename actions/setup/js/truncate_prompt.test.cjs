@@ -0,0 +1,41 @@
+import { describe, it, expect } from "vitest";
+
+const { truncatePrompt } = require("./truncate_prompt.cjs");
+
+describe("truncate_prompt.cjs", () => {
+  describe("truncatePrompt", () => {
+    const content = "BEGIN " + "x".repeat(1000) + " END";
+
+    it("should leave prompts within the budget unchanged", () => {
+      expect(truncatePrompt("short prompt", "head", 100)).toEqual({ content: "short prompt", omitted: 0 });
+    });
+
+    it("should keep the beginning with the head strategy", () => {
+      const result = truncatePrompt(content, "head", 200);
+      expect(result.content.startsWith("BEGIN ")).toBe(true);
+      expect(result.content).not.toContain(" END");
+      expect(result.content).toContain("characters omitted to fit the context budget");
+      expect(result.content.length).toBeLessThanOrEqual(200);
+    });
+
+    it("should keep the end with the tail strategy", () => {
+      const result = truncatePrompt(content, "tail", 200);
+      expect(result.content.endsWith(" END")).toBe(true);
+      expect(result.content).not.toContain("BEGIN");
+      expect(result.content.length).toBeLessThanOrEqual(200);
+    });
+
+    it("should keep both ends with the summarize strategy", () => {
+      const result = truncatePrompt(content, "summarize", 200);
+      expect(result.content.startsWith("BEGIN ")).toBe(true);
+      expect(result.content.endsWith(" END")).toBe(true);
+      expect(result.content).toContain(`[... ${result.omitted} characters omitted`);
+      expect(result.content.length).toBeLessThanOrEqual(200);
+    });
+
+    it("should report the number of removed characters", () => {
+      const result = truncatePrompt(content, "head", 200);
+      expect(result.omitted).toBeGreaterThan(content.length - 200);
+    });
+  });
+});
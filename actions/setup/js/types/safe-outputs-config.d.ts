@@ -27,9 +27,21 @@ interface CreateDiscussionConfig extends SafeOutputConfig {
   "category-id"?: string;
   "target-repo"?: string;
   "allowed-repos"?: string[];
+  "allowed-categories"?: string[];
   footer?: boolean;
 }
 
+/**
+ * Configuration for answering GitHub discussions
+ */
+interface AnswerDiscussionConfig extends SafeOutputConfig {
+  target?: string;
+  "target-repo"?: string;
+  "allowed-repos"?: string[];
+  "allowed-categories"?: string[];
+  "mark-as-answer"?: boolean;
+}
+
 /**
  * Configuration for closing GitHub discussions
  */
@@ -157,6 +169,28 @@ interface AddReviewerConfig extends SafeOutputConfig {
   target?: string;
 }
 
+/**
+ * Configuration for requesting pull request reviews from users and teams
+ */
+interface RequestReviewConfig extends SafeOutputConfig {
+  target?: string;
+  "target-repo"?: string;
+  "allowed-repos"?: string[];
+  "allowed-users"?: string[];
+  "allowed-teams"?: string[];
+}
+
+/**
+ * Configuration for assigning users and team members to issues and pull requests
+ */
+interface AssignConfig extends SafeOutputConfig {
+  target?: string;
+  "target-repo"?: string;
+  "allowed-repos"?: string[];
+  "allowed-users"?: string[];
+  "allowed-teams"?: string[];
+}
+
 /**
  * Configuration for updating issues
  */
@@ -311,6 +345,7 @@ type SpecificSafeOutputConfig =
   | CreateDiscussionConfig
   | UpdateDiscussionConfig
   | CloseDiscussionConfig
+  | AnswerDiscussionConfig
   | CloseIssueConfig
   | ClosePullRequestConfig
   | MarkPullRequestAsReadyForReviewConfig
@@ -322,6 +357,8 @@ type SpecificSafeOutputConfig =
   | AutofixCodeScanningAlertConfig
   | AddLabelsConfig
   | AddReviewerConfig
+  | RequestReviewConfig
+  | AssignConfig
   | UpdateIssueConfig
   | UpdatePullRequestConfig
   | PushToPullRequestBranchConfig
@@ -347,6 +384,7 @@ export {
   CreateDiscussionConfig,
   UpdateDiscussionConfig,
   CloseDiscussionConfig,
+  AnswerDiscussionConfig,
   CloseIssueConfig,
   ClosePullRequestConfig,
   MarkPullRequestAsReadyForReviewConfig,
@@ -358,6 +396,8 @@ export {
   AutofixCodeScanningAlertConfig,
   AddLabelsConfig,
   AddReviewerConfig,
+  RequestReviewConfig,
+  AssignConfig,
   UpdateIssueConfig,
   UpdatePullRequestConfig,
   PushToPullRequestBranchConfig,
@@ -79,6 +79,17 @@ interface CloseDiscussionItem extends BaseSafeOutputItem {
   discussion_number?: number | string;
 }
 
+/**
+ * JSONL item for answering a GitHub discussion
+ */
+interface AnswerDiscussionItem extends BaseSafeOutputItem {
+  type: "answer_discussion";
+  /** Answer body in Markdown */
+  body: string;
+  /** Optional discussion number (required when the target is "*") */
+  discussion_number?: number | string;
+}
+
 /**
  * JSONL item for closing a GitHub issue
  */
@@ -207,6 +218,32 @@ interface AddReviewerItem extends BaseSafeOutputItem {
   pull_request_number?: number | string;
 }
 
+/**
+ * JSONL item for requesting reviews on a pull request
+ */
+interface RequestReviewItem extends BaseSafeOutputItem {
+  type: "request_review";
+  /** GitHub usernames to request reviews from */
+  reviewers?: string[];
+  /** Team slugs to request reviews from */
+  team_reviewers?: string[];
+  /** Pull request number (optional - uses triggering PR if not provided) */
+  pull_request_number?: number | string;
+}
+
+/**
+ * JSONL item for assigning users and team members to an issue or pull request
+ */
+interface AssignItem extends BaseSafeOutputItem {
+  type: "assign";
+  /** GitHub usernames to assign */
+  assignees?: string[];
+  /** Team slugs whose members are assigned */
+  teams?: string[];
+  /** Issue or pull request number (optional - uses triggering item if not provided) */
+  item_number?: number | string;
+}
+
 /**
  * JSONL item for updating an issue
  */
@@ -407,6 +444,7 @@ type SafeOutputItem =
   | CreateDiscussionItem
   | UpdateDiscussionItem
   | CloseDiscussionItem
+  | AnswerDiscussionItem
   | CloseIssueItem
   | ClosePullRequestItem
   | MarkPullRequestAsReadyForReviewItem
@@ -417,6 +455,8 @@ type SafeOutputItem =
   | AddLabelsItem
   | RemoveLabelsItem
   | AddReviewerItem
+  | RequestReviewItem
+  | AssignItem
   | UpdateIssueItem
   | UpdatePullRequestItem
   | PushToPrBranchItem
@@ -449,6 +489,7 @@ export {
   CreateDiscussionItem,
   UpdateDiscussionItem,
   CloseDiscussionItem,
+  AnswerDiscussionItem,
   CloseIssueItem,
   ClosePullRequestItem,
   MarkPullRequestAsReadyForReviewItem,
@@ -459,6 +500,8 @@ export {
   AddLabelsItem,
   RemoveLabelsItem,
   AddReviewerItem,
+  RequestReviewItem,
+  AssignItem,
   UpdateIssueItem,
   UpdatePullRequestItem,
   PushToPrBranchItem,
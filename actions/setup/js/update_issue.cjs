@@ -29,6 +29,75 @@ const MAX_LABELS = 10;
 /** @type {number} Maximum number of assignees allowed per issue */
 const MAX_ASSIGNEES = 5;
 
+/**
+ * Update data keys of each field that can be restricted with allowed_fields,
+ * using the agent output field names as keys
+ * @type {Record<string, string[]>}
+ */
+const RESTRICTABLE_FIELDS = {
+  status: ["state"],
+  title: ["title"],
+  body: ["_rawBody", "_operation"],
+  labels: ["labels"],
+  assignees: ["assignees"],
+  milestone: ["milestone"],
+};
+
+/**
+ * Returns a display value of an issue field for the before/after summary
+ * @param {any} issue - Issue returned by the GitHub API
+ * @param {string} field - Update data key (state, title, body, labels, assignees, milestone)
+ * @returns {string}
+ */
+function describeIssueField(issue, field) {
+  const value = issue[field];
+  switch (field) {
+    case "labels":
+      return (value || []).map(/** @param {any} label */ label => (typeof label === "string" ? label : label.name)).join(", ");
+    case "assignees":
+      return (value || []).map(/** @param {any} assignee */ assignee => assignee.login).join(", ");
+    case "milestone":
+      return value ? `${value.title || ""} (#${value.number})`.trim() : "";
+    case "body":
+      return `${(value || "").length} characters`;
+    default:
+      return value == null ? "" : String(value);
+  }
+}
+
+/**
+ * Lists the fields an update changed with their values before and after the update
+ * @param {any} before - Issue before the update
+ * @param {any} after - Issue after the update
+ * @param {string[]} fields - Update data keys sent to the API
+ * @returns {Array<{field: string, before: string, after: string}>}
+ */
+function diffIssueFields(before, after, fields) {
+  const changes = [];
+  for (const field of fields) {
+    const beforeValue = describeIssueField(before, field);
+    const afterValue = describeIssueField(after, field);
+    if (beforeValue !== afterValue || (field === "body" && before.body !== after.body)) {
+      changes.push({ field: field === "state" ? "status" : field, before: beforeValue, after: afterValue });
+    }
+  }
+  return changes;
+}
+
+/**
+ * Merges the labels requested by the agent with the current labels of the issue.
+ * Only labels in the allow-list are added or removed; every other label is kept.
+ * @param {any[]} currentLabels - Labels currently on the issue
+ * @param {string[]} requestedLabels - Labels requested by the agent (already filtered by the allow-list)
+ * @param {string[]} allowedLabels - Labels the agent may add or remove
+ * @returns {string[]}
+ */
+function mergeAllowedLabels(currentLabels, requestedLabels, allowedLabels) {
+  const allowed = new Set(allowedLabels.map(label => label.toLowerCase()));
+  const kept = currentLabels.map(label => (typeof label === "string" ? label : label.name)).filter(name => !allowed.has(name.toLowerCase()));
+  return [...kept, ...requestedLabels];
+}
+
 /**
  * Execute the issue update API call
  * @param {any} github - GitHub API client
@@ -46,69 +115,73 @@ async function executeIssueUpdate(github, context, issueNumber, updateData) {
   const titlePrefix = updateData._titlePrefix || "";
 
   // Remove internal fields
-  const { _operation, _rawBody, _includeFooter, _titlePrefix, _workflowRepo, ...apiData } = updateData;
-
-  // Fetch current issue if needed (title prefix validation or body update)
-  if (titlePrefix || rawBody !== undefined) {
-    const { data: currentIssue } = await github.rest.issues.get({
-      owner: context.repo.owner,
-      repo: context.repo.repo,
-      issue_number: issueNumber,
-    });
+  const { _operation, _rawBody, _includeFooter, _titlePrefix, _workflowRepo, _allowedLabels, ...apiData } = updateData;
 
-    // Validate title prefix if specified
-    if (titlePrefix) {
-      const currentTitle = currentIssue.title || "";
-      if (!currentTitle.startsWith(titlePrefix)) {
-        throw new Error(`${ERR_VALIDATION}: Issue title "${currentTitle}" does not start with required prefix "${titlePrefix}"`);
-      }
-      core.info(`✓ Title prefix validation passed: "${titlePrefix}"`);
+  // Read the current issue: it is validated against the title prefix, merged with the
+  // requested changes and compared with the updated issue for the step summary
+  const { data: currentIssue } = await github.rest.issues.get({
+    owner: context.repo.owner,
+    repo: context.repo.repo,
+    issue_number: issueNumber,
+  });
+
+  if (apiData.labels !== undefined && _allowedLabels) {
+    apiData.labels = mergeAllowedLabels(currentIssue.labels || [], apiData.labels, _allowedLabels);
+    core.info(`Merged labels with the current issue labels: ${JSON.stringify(apiData.labels)}`);
+  }
+
+  // Validate title prefix if specified
+  if (titlePrefix) {
+    const currentTitle = currentIssue.title || "";
+    if (!currentTitle.startsWith(titlePrefix)) {
+      throw new Error(`${ERR_VALIDATION}: Issue title "${currentTitle}" does not start with required prefix "${titlePrefix}"`);
     }
+    core.info(`✓ Title prefix validation passed: "${titlePrefix}"`);
+  }
 
-    if (rawBody !== undefined) {
-      // Load and apply temporary project URL replacements FIRST
-      // This resolves any temporary project IDs (e.g., #aw_abc123def456) to actual project URLs
-      const temporaryProjectMap = loadTemporaryProjectMap();
-      if (temporaryProjectMap.size > 0) {
-        rawBody = replaceTemporaryProjectReferences(rawBody, temporaryProjectMap);
-        core.debug(`Applied ${temporaryProjectMap.size} temporary project URL replacement(s)`);
-      }
+  if (rawBody !== undefined) {
+    // Load and apply temporary project URL replacements FIRST
+    // This resolves any temporary project IDs (e.g., #aw_abc123def456) to actual project URLs
+    const temporaryProjectMap = loadTemporaryProjectMap();
+    if (temporaryProjectMap.size > 0) {
+      rawBody = replaceTemporaryProjectReferences(rawBody, temporaryProjectMap);
+      core.debug(`Applied ${temporaryProjectMap.size} temporary project URL replacement(s)`);
+    }
+
+    const currentBody = currentIssue.body || "";
+
+    // Get workflow run URL for AI attribution.
+    // Use the original workflow repo (_workflowRepo) rather than context.repo, because
+    // context may be effectiveContext with repo overridden to a cross-repo target.
+    const workflowName = process.env.GH_AW_WORKFLOW_NAME || "GitHub Agentic Workflow";
+    const workflowId = process.env.GH_AW_WORKFLOW_ID || "";
+    const callerWorkflowId = process.env.GH_AW_CALLER_WORKFLOW_ID || "";
+    const workflowRepo = _workflowRepo || context.repo;
+    const runUrl = buildWorkflowRunUrl(context, workflowRepo);
 
-      const currentBody = currentIssue.body || "";
-
-      // Get workflow run URL for AI attribution.
-      // Use the original workflow repo (_workflowRepo) rather than context.repo, because
-      // context may be effectiveContext with repo overridden to a cross-repo target.
-      const workflowName = process.env.GH_AW_WORKFLOW_NAME || "GitHub Agentic Workflow";
-      const workflowId = process.env.GH_AW_WORKFLOW_ID || "";
-      const callerWorkflowId = process.env.GH_AW_CALLER_WORKFLOW_ID || "";
-      const workflowRepo = _workflowRepo || context.repo;
-      const runUrl = buildWorkflowRunUrl(context, workflowRepo);
-
-      const historyUrl =
-        generateHistoryUrl({
-          owner: context.repo.owner,
-          repo: context.repo.repo,
-          itemType: "issue",
-          workflowCallId: callerWorkflowId,
-          workflowId,
-          serverUrl: context.serverUrl,
-        }) || undefined;
-
-      // Use helper to update body (handles all operations including replace)
-      apiData.body = updateBody({
-        currentBody,
-        newContent: rawBody,
-        operation,
-        workflowName,
-        runUrl,
+    const historyUrl =
+      generateHistoryUrl({
+        owner: context.repo.owner,
+        repo: context.repo.repo,
+        itemType: "issue",
+        workflowCallId: callerWorkflowId,
         workflowId,
-        includeFooter, // Pass footer flag to helper
-        historyUrl,
-      });
+        serverUrl: context.serverUrl,
+      }) || undefined;
 
-      core.info(`Will update body (length: ${apiData.body.length})`);
-    }
+    // Use helper to update body (handles all operations including replace)
+    apiData.body = updateBody({
+      currentBody,
+      newContent: rawBody,
+      operation,
+      workflowName,
+      runUrl,
+      workflowId,
+      includeFooter, // Pass footer flag to helper
+      historyUrl,
+    });
+
+    core.info(`Will update body (length: ${apiData.body.length})`);
   }
 
   const { data: issue } = await github.rest.issues.update({
@@ -118,7 +191,7 @@ async function executeIssueUpdate(github, context, issueNumber, updateData) {
     ...apiData,
   });
 
-  return issue;
+  return { ...issue, changes: diffIssueFields(currentIssue, issue, Object.keys(apiData)) };
 }
 
 /**
@@ -173,6 +246,28 @@ function buildIssueUpdateData(item, config) {
     updateData.milestone = item.milestone;
   }
 
+  // Drop the fields the frontmatter does not allow the agent to change
+  if (Array.isArray(config.allowed_fields)) {
+    for (const [field, keys] of Object.entries(RESTRICTABLE_FIELDS)) {
+      if (updateData[keys[0]] !== undefined && !config.allowed_fields.includes(field)) {
+        core.warning(`Field "${field}" is not allowed by safe-outputs configuration (allowed: ${config.allowed_fields.join(", ")})`);
+        keys.forEach(key => delete updateData[key]);
+      }
+    }
+  }
+
+  // Keep only the allowed labels; executeIssueUpdate merges them with the current labels
+  if (Array.isArray(config.allowed_labels) && updateData.labels !== undefined) {
+    const allowed = new Set(config.allowed_labels.map(label => label.toLowerCase()));
+    const requested = updateData.labels.map(String);
+    const rejected = requested.filter(label => !allowed.has(label.toLowerCase()));
+    if (rejected.length > 0) {
+      core.warning(`Labels not in the allowed-labels list were ignored: ${rejected.join(", ")}`);
+    }
+    updateData.labels = requested.filter(label => allowed.has(label.toLowerCase()));
+    updateData._allowedLabels = config.allowed_labels;
+  }
+
   // Enforce max limits on labels and assignees before API calls
   const labelsLimitResult = tryEnforceArrayLimit(updateData.labels, MAX_LABELS, "labels");
   if (!labelsLimitResult.success) {
@@ -200,13 +295,23 @@ function buildIssueUpdateData(item, config) {
 /**
  * Format success result for issue update
  * Uses the standard format helper for consistency across update handlers
+ * and adds the before/after values of the changed fields for the step summary
  */
-const formatIssueSuccessResult = createStandardFormatResult({
+const formatStandardIssueResult = createStandardFormatResult({
   numberField: "number",
   urlField: "url",
   urlSource: "html_url",
 });
 
+/**
+ * @param {number} itemNumber - Issue number
+ * @param {any} updatedItem - Issue returned by executeIssueUpdate
+ * @returns {Object}
+ */
+function formatIssueSuccessResult(itemNumber, updatedItem) {
+  return { ...formatStandardIssueResult(itemNumber, updatedItem), changes: updatedItem.changes || [] };
+}
+
 /**
  * Main handler factory for update_issue
  * Returns a message handler function that processes individual update_issue messages
@@ -222,4 +327,4 @@ const main = createUpdateHandlerFactory({
   formatSuccessResult: formatIssueSuccessResult,
 });
 
-module.exports = { main, buildIssueUpdateData };
+module.exports = { main, buildIssueUpdateData, mergeAllowedLabels, diffIssueFields };
@@ -884,3 +884,86 @@ describe("update_issue.cjs - cross-repo and operation integration", () => {
     expect(capturedBody).toContain("<!-- gh-aw-island-end:test-workflow -->");
   });
 });
+
+describe("update_issue.cjs - allowed_fields and allowed_labels configuration", () => {
+  beforeEach(async () => {
+    vi.clearAllMocks();
+    vi.resetModules();
+    process.env.GH_AW_WORKFLOW_NAME = "Test Workflow";
+    process.env.GH_AW_WORKFLOW_ID = "test-workflow";
+  });
+
+  it("should drop fields that are not in allowed_fields", async () => {
+    const { buildIssueUpdateData } = await import("./update_issue.cjs");
+
+    const item = { title: "New title", body: "New body", status: "closed", labels: ["bug"] };
+    const result = buildIssueUpdateData(item, { allowed_fields: ["labels"] });
+
+    expect(result.success).toBe(true);
+    expect(result.data.labels).toEqual(["bug"]);
+    expect(result.data.title).toBeUndefined();
+    expect(result.data.state).toBeUndefined();
+    expect(result.data._rawBody).toBeUndefined();
+    expect(result.data._operation).toBeUndefined();
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining('Field "title" is not allowed'));
+  });
+
+  it("should keep every field when allowed_fields is not configured", async () => {
+    const { buildIssueUpdateData } = await import("./update_issue.cjs");
+
+    const result = buildIssueUpdateData({ title: "New title", milestone: 3 }, {});
+
+    expect(result.data.title).toBe("New title");
+    expect(result.data.milestone).toBe(3);
+  });
+
+  it("should ignore labels that are not in allowed_labels", async () => {
+    const { buildIssueUpdateData } = await import("./update_issue.cjs");
+
+    const result = buildIssueUpdateData({ labels: ["Bug", "wontfix"] }, { allowed_labels: ["bug", "triage"] });
+
+    expect(result.data.labels).toEqual(["Bug"]);
+    expect(result.data._allowedLabels).toEqual(["bug", "triage"]);
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("wontfix"));
+  });
+
+  it("should merge allowed labels with the current labels of the issue", async () => {
+    const { mergeAllowedLabels } = await import("./update_issue.cjs");
+
+    const labels = mergeAllowedLabels([{ name: "bug" }, { name: "priority: high" }], ["triage"], ["bug", "triage"]);
+
+    expect(labels).toEqual(["priority: high", "triage"]);
+  });
+
+  it("should read, modify and write labels and report the before/after diff", async () => {
+    let capturedLabels;
+
+    mockGithub.rest.issues.get.mockResolvedValue({
+      data: { number: 100, title: "Test", body: "Body", labels: [{ name: "bug" }, { name: "priority: high" }], html_url: "https://github.com/testowner/testrepo/issues/100" },
+    });
+    mockGithub.rest.issues.update.mockImplementation(async ({ labels }) => {
+      capturedLabels = labels;
+      return { data: { number: 100, title: "Test", body: "Body", labels: labels.map(name => ({ name })), html_url: "https://github.com/testowner/testrepo/issues/100" } };
+    });
+
+    const { main } = await import("./update_issue.cjs");
+    const handler = await main({ target: "*", allowed_fields: ["labels"], allowed_labels: ["bug", "triage"] });
+    const result = await handler({ issue_number: 100, title: "Ignored", labels: ["triage"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(capturedLabels).toEqual(["priority: high", "triage"]);
+    expect(result.changes).toEqual([{ field: "labels", before: "bug, priority: high", after: "priority: high, triage" }]);
+  });
+
+  it("should report only the fields whose value changed", async () => {
+    const { diffIssueFields } = await import("./update_issue.cjs");
+
+    const before = { title: "Same", state: "open", assignees: [{ login: "octocat" }], milestone: null };
+    const after = { title: "Same", state: "closed", assignees: [{ login: "octocat" }], milestone: { number: 2, title: "v1" } };
+
+    expect(diffIssueFields(before, after, ["title", "state", "assignees", "milestone"])).toEqual([
+      { field: "status", before: "open", after: "closed" },
+      { field: "milestone", before: "", after: "v1 (#2)" },
+    ]);
+  });
+});
@@ -56,10 +56,93 @@ function normalizeBranchName(branchName) {
   return normalized;
 }
 
+/**
+ * Verifies upload-asset items and copies them into the directory uploaded as a workflow artifact.
+ * Used when safe-outputs.upload-asset.target is "artifact".
+ *
+ * @param {any[]} uploadItems - The upload_asset items from the agent output
+ * @param {string} publishDir - Directory that is uploaded as the workflow artifact
+ * @param {boolean} isStaged - Whether staged mode is enabled
+ * @returns {number|null} Number of published assets, or null if publishing failed
+ */
+function publishAssetsToDirectory(uploadItems, publishDir, isStaged) {
+  fs.mkdirSync(publishDir, { recursive: true });
+
+  let uploadCount = 0;
+  for (const asset of uploadItems) {
+    const { fileName, sha, size, targetFileName } = asset;
+
+    if (!fileName || !sha || !targetFileName) {
+      core.setFailed(`${ERR_VALIDATION}: Invalid asset entry missing required fields: ${JSON.stringify(asset)}`);
+      return null;
+    }
+
+    const assetSourcePath = path.join("/tmp/gh-aw/safeoutputs/assets", fileName);
+    if (!fs.existsSync(assetSourcePath)) {
+      core.setFailed(`${ERR_SYSTEM}: Asset file not found: ${assetSourcePath}`);
+      return null;
+    }
+
+    // Verify SHA matches
+    const fileContent = fs.readFileSync(assetSourcePath);
+    const computedSha = crypto.createHash("sha256").update(fileContent).digest("hex");
+    if (computedSha !== sha) {
+      core.setFailed(`${ERR_VALIDATION}: SHA mismatch for ${fileName}: expected ${sha}, got ${computedSha}`);
+      return null;
+    }
+
+    const targetPath = path.join(publishDir, path.basename(targetFileName));
+    if (fs.existsSync(targetPath)) {
+      core.info(`Asset ${targetFileName} already staged, skipping`);
+      continue;
+    }
+
+    // In staged mode nothing is written, so the artifact upload finds no files
+    if (!isStaged) {
+      fs.copyFileSync(assetSourcePath, targetPath);
+    }
+    uploadCount++;
+    core.info(`Staged asset: ${targetFileName} (${size} bytes)`);
+  }
+
+  if (isStaged) {
+    core.summary.addRaw("## 🎭 Staged Mode: Asset Publication Preview");
+  } else {
+    core.summary.addRaw("## Assets").addRaw(`Successfully published **${uploadCount}** assets as a workflow artifact`).addRaw("");
+  }
+  for (const asset of uploadItems) {
+    if (asset.fileName && asset.targetFileName) {
+      core.summary.addRaw(`- \`${asset.fileName}\` → \`${asset.targetFileName}\` (${asset.size} bytes)`);
+    }
+  }
+  core.summary.write();
+
+  return uploadCount;
+}
+
 async function main() {
   // Check if we're in staged mode
   const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
 
+  // Artifact target: verify assets and stage them for the workflow artifact upload step
+  if (process.env.GH_AW_ASSETS_TARGET === "artifact") {
+    const publishDir = process.env.GH_AW_ASSETS_PUBLISH_DIR || "/tmp/gh-aw/safeoutputs/published-assets";
+    const result = loadAgentOutput();
+    const uploadItems = result.success ? result.items.filter(/** @param {any} item */ item => item.type === "upload_asset") : [];
+    if (uploadItems.length === 0) {
+      core.info("No upload-asset items found in agent output");
+      core.setOutput("upload_count", "0");
+      return;
+    }
+
+    core.info(`Found ${uploadItems.length} upload-asset item(s), publishing as workflow artifact`);
+    const uploadCount = publishAssetsToDirectory(uploadItems, publishDir, isStaged);
+    if (uploadCount !== null) {
+      core.setOutput("upload_count", uploadCount.toString());
+    }
+    return;
+  }
+
   // Get the branch name from environment variable (required)
   const branchName = process.env.GH_AW_ASSETS_BRANCH;
   if (!branchName || typeof branchName !== "string") {
@@ -12,7 +12,7 @@ const mockCore = { debug: vi.fn(), info: vi.fn(), notice: vi.fn(), warning: vi.f
       },
       executeScript = async () => ((global.core = mockCore), (global.exec = mockExec), await eval(`(async () => { ${uploadAssetsScript}; await main(); })()`));
     (beforeEach(() => {
-      (vi.clearAllMocks(), delete process.env.GH_AW_ASSETS_BRANCH, delete process.env.GH_AW_AGENT_OUTPUT, delete process.env.GH_AW_SAFE_OUTPUTS_STAGED);
+      (vi.clearAllMocks(), delete process.env.GH_AW_ASSETS_BRANCH, delete process.env.GH_AW_ASSETS_TARGET, delete process.env.GH_AW_ASSETS_PUBLISH_DIR, delete process.env.GH_AW_AGENT_OUTPUT, delete process.env.GH_AW_SAFE_OUTPUTS_STAGED);
       const scriptPath = path.join(__dirname, "upload_assets.cjs");
       ((uploadAssetsScript = fs.readFileSync(scriptPath, "utf8")), (mockExec = { exec: vi.fn().mockResolvedValue(0) }));
     }),
@@ -136,5 +136,39 @@ const mockCore = { debug: vi.fn(), info: vi.fn(), notice: vi.fn(), warning: vi.f
               fs.existsSync(assetPath) && fs.unlinkSync(assetPath),
               fs.existsSync("test.png") && fs.unlinkSync("test.png"));
           }));
+      }),
+      describe("artifact target", () => {
+        it("should stage verified assets for the artifact upload without using git", async () => {
+          ((process.env.GH_AW_ASSETS_BRANCH = "assets/test-workflow"), (process.env.GH_AW_ASSETS_TARGET = "artifact"), (process.env.GH_AW_SAFE_OUTPUTS_STAGED = "false"));
+          const publishDir = path.join("/tmp", `test_published_assets_${Date.now()}`);
+          process.env.GH_AW_ASSETS_PUBLISH_DIR = publishDir;
+          const assetDir = "/tmp/gh-aw/safeoutputs/assets";
+          fs.existsSync(assetDir) || fs.mkdirSync(assetDir, { recursive: !0 });
+          const assetPath = path.join(assetDir, "chart.png");
+          fs.writeFileSync(assetPath, "fake png data");
+          const crypto = require("crypto"),
+            fileContent = fs.readFileSync(assetPath),
+            sha = crypto.createHash("sha256").update(fileContent).digest("hex");
+          (setAgentOutput({ items: [{ type: "upload_asset", fileName: "chart.png", sha, size: fileContent.length, targetFileName: `${sha}.png`, url: "https://github.com/owner/repo/actions/runs/1#artifacts" }] }),
+            await executeScript(),
+            expect(mockCore.setFailed).not.toHaveBeenCalled(),
+            expect(mockExec.exec).not.toHaveBeenCalled(),
+            expect(fs.existsSync(path.join(publishDir, `${sha}.png`))).toBe(!0),
+            expect(mockCore.setOutput).toHaveBeenCalledWith("upload_count", "1"),
+            fs.rmSync(publishDir, { recursive: !0, force: !0 }),
+            fs.existsSync(assetPath) && fs.unlinkSync(assetPath));
+        }),
+          it("should fail on SHA mismatch", async () => {
+            ((process.env.GH_AW_ASSETS_TARGET = "artifact"), (process.env.GH_AW_ASSETS_PUBLISH_DIR = path.join("/tmp", `test_published_assets_${Date.now()}`)));
+            const assetDir = "/tmp/gh-aw/safeoutputs/assets";
+            fs.existsSync(assetDir) || fs.mkdirSync(assetDir, { recursive: !0 });
+            const assetPath = path.join(assetDir, "chart.png");
+            (fs.writeFileSync(assetPath, "fake png data"),
+              setAgentOutput({ items: [{ type: "upload_asset", fileName: "chart.png", sha: "deadbeef", size: 13, targetFileName: "deadbeef.png" }] }),
+              await executeScript(),
+              expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("SHA mismatch")),
+              fs.rmSync(process.env.GH_AW_ASSETS_PUBLISH_DIR, { recursive: !0, force: !0 }),
+              fs.existsSync(assetPath) && fs.unlinkSync(assetPath));
+          }));
       }));
   }));
@@ -0,0 +1,90 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Validates that the workflow runs on the GitHub Enterprise host it was compiled for (github-host:).
+ *
+ * Compares the runner's GITHUB_SERVER_URL with GH_AW_GITHUB_HOST and, for GitHub Enterprise
+ * Server, reads the installed version from the meta endpoint and fails when it is older than
+ * GH_AW_GHES_MIN_VERSION.
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_CONFIG, ERR_VALIDATION } = require("./error_codes.cjs");
+
+/**
+ * Extracts the host (with port, if any) from a server URL
+ * @param {string} serverUrl - Server URL such as https://github.example.com
+ * @returns {string} Lowercase host, or an empty string when the URL is invalid
+ */
+function getServerHost(serverUrl) {
+  try {
+    return new URL(serverUrl).host.toLowerCase();
+  } catch {
+    return "";
+  }
+}
+
+/**
+ * Compares two dotted version strings numerically
+ * @param {string} a - First version (e.g. 3.16.2)
+ * @param {string} b - Second version (e.g. 3.14)
+ * @returns {number} Negative if a < b, zero if equal, positive if a > b
+ */
+function compareVersions(a, b) {
+  const partsA = a.split(".").map(part => parseInt(part, 10) || 0);
+  const partsB = b.split(".").map(part => parseInt(part, 10) || 0);
+  const length = Math.max(partsA.length, partsB.length);
+  for (let i = 0; i < length; i++) {
+    const diff = (partsA[i] || 0) - (partsB[i] || 0);
+    if (diff !== 0) {
+      return diff;
+    }
+  }
+  return 0;
+}
+
+async function main() {
+  const expectedHost = (process.env.GH_AW_GITHUB_HOST || "").toLowerCase();
+  const minVersion = process.env.GH_AW_GHES_MIN_VERSION || "";
+  const serverUrl = process.env.GITHUB_SERVER_URL || "https://github.com";
+
+  if (!expectedHost) {
+    core.setFailed(`${ERR_CONFIG}: Configuration error: GH_AW_GITHUB_HOST not available.`);
+    return;
+  }
+
+  const actualHost = getServerHost(serverUrl);
+  if (actualHost !== expectedHost) {
+    core.setFailed(`${ERR_VALIDATION}: This workflow was compiled for github-host ${expectedHost} but is running on ${actualHost || serverUrl}. Recompile it with the matching github-host setting.`);
+    return;
+  }
+  core.info(`✓ Running on GitHub host ${actualHost}`);
+
+  if (!minVersion) {
+    return;
+  }
+
+  let installedVersion;
+  try {
+    const { data } = await github.request("GET /meta");
+    installedVersion = data && data.installed_version;
+  } catch (error) {
+    core.setFailed(`${ERR_VALIDATION}: Failed to read the GitHub Enterprise Server version from ${process.env.GITHUB_API_URL || serverUrl}: ${getErrorMessage(error)}`);
+    return;
+  }
+
+  if (!installedVersion) {
+    core.setFailed(`${ERR_VALIDATION}: ${actualHost} did not report a GitHub Enterprise Server version. GHE.com hosts do not need a minimum version check.`);
+    return;
+  }
+
+  if (compareVersions(installedVersion, minVersion) < 0) {
+    core.setFailed(`${ERR_VALIDATION}: GitHub Enterprise Server ${installedVersion} is not supported. This workflow requires version ${minVersion} or later.`);
+    return;
+  }
+
+  core.info(`✓ GitHub Enterprise Server ${installedVersion} meets the minimum version ${minVersion}`);
+}
+
+module.exports = { main, getServerHost, compareVersions };
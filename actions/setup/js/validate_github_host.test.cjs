@@ -0,0 +1,98 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const mockCore = {
+  info: vi.fn(),
+  setFailed: vi.fn(),
+};
+
+const mockGithub = {
+  request: vi.fn(),
+};
+
+global.core = mockCore;
+global.github = mockGithub;
+
+const { main, getServerHost, compareVersions } = require("./validate_github_host.cjs");
+
+describe("validate_github_host.cjs", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    process.env.GH_AW_GITHUB_HOST = "github.example.com";
+    process.env.GH_AW_GHES_MIN_VERSION = "3.14";
+    process.env.GITHUB_SERVER_URL = "https://github.example.com";
+  });
+
+  describe("getServerHost", () => {
+    it("should extract the host from a server URL", () => {
+      expect(getServerHost("https://GitHub.Example.com")).toBe("github.example.com");
+      expect(getServerHost("https://github.example.com:8443/")).toBe("github.example.com:8443");
+    });
+
+    it("should return an empty string for invalid URLs", () => {
+      expect(getServerHost("not a url")).toBe("");
+    });
+  });
+
+  describe("compareVersions", () => {
+    it("should compare versions numerically", () => {
+      expect(compareVersions("3.16.2", "3.14")).toBeGreaterThan(0);
+      expect(compareVersions("3.9", "3.14")).toBeLessThan(0);
+      expect(compareVersions("3.14.0", "3.14")).toBe(0);
+    });
+  });
+
+  describe("main", () => {
+    it("should pass when the host and version match", async () => {
+      mockGithub.request.mockResolvedValue({ data: { installed_version: "3.16.1" } });
+
+      await main();
+
+      expect(mockGithub.request).toHaveBeenCalledWith("GET /meta");
+      expect(mockCore.setFailed).not.toHaveBeenCalled();
+    });
+
+    it("should fail when running on a different host", async () => {
+      process.env.GITHUB_SERVER_URL = "https://github.com";
+
+      await main();
+
+      expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("compiled for github-host github.example.com but is running on github.com"));
+      expect(mockGithub.request).not.toHaveBeenCalled();
+    });
+
+    it("should fail when the server version is too old", async () => {
+      mockGithub.request.mockResolvedValue({ data: { installed_version: "3.12.4" } });
+
+      await main();
+
+      expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("GitHub Enterprise Server 3.12.4 is not supported"));
+    });
+
+    it("should fail when the server does not report a version", async () => {
+      mockGithub.request.mockResolvedValue({ data: {} });
+
+      await main();
+
+      expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("did not report a GitHub Enterprise Server version"));
+    });
+
+    it("should skip the version check for GHE.com hosts", async () => {
+      process.env.GH_AW_GITHUB_HOST = "octocorp.ghe.com";
+      process.env.GITHUB_SERVER_URL = "https://octocorp.ghe.com";
+      delete process.env.GH_AW_GHES_MIN_VERSION;
+
+      await main();
+
+      expect(mockGithub.request).not.toHaveBeenCalled();
+      expect(mockCore.setFailed).not.toHaveBeenCalled();
+    });
+
+    it("should fail when the meta request fails", async () => {
+      mockGithub.request.mockRejectedValue(new Error("Not Found"));
+
+      await main();
+
+      expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("Not Found"));
+    });
+  });
+});
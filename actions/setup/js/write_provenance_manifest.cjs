@@ -0,0 +1,82 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const crypto = require("crypto");
+const fs = require("fs");
+const path = require("path");
+const { TMP_GH_AW_PATH } = require("./constants.cjs");
+
+/**
+ * Returns the hex SHA-256 of a file, or an empty string when it cannot be read
+ * @param {string} filePath - Path of the file to hash
+ * @returns {string}
+ */
+function hashFile(filePath) {
+  try {
+    return crypto.createHash("sha256").update(fs.readFileSync(filePath)).digest("hex");
+  } catch {
+    return "";
+  }
+}
+
+/**
+ * Adds the sha256 of every source that exists in the workspace checkout. Remote imports
+ * (workflowspecs) are not checked out and keep only their pinned path.
+ * @param {Array<{path: string, sha256?: string}> | undefined} sources - Imports or includes from the manifest
+ * @param {string} workspace - Repository checkout directory
+ */
+function hashSources(sources, workspace) {
+  for (const source of sources || []) {
+    const sha256 = hashFile(path.join(workspace, source.path));
+    if (sha256) {
+      source.sha256 = sha256;
+    }
+  }
+}
+
+/**
+ * Write the provenance manifest of this run to /tmp/gh-aw/provenance.json.
+ * Reads the compile-time manifest embedded in the lock file from GH_AW_PROVENANCE_MANIFEST
+ * and adds the content hashes of the markdown source, its imports and includes as checked
+ * out for this run, and of the rendered prompt.
+ *
+ * @param {typeof import('@actions/core')} core - GitHub Actions core library
+ * @param {object} ctx - GitHub Actions context object
+ * @returns {Promise<void>}
+ */
+async function main(core, ctx) {
+  /** @type {Record<string, any>} */
+  let manifest;
+  try {
+    manifest = JSON.parse(process.env.GH_AW_PROVENANCE_MANIFEST || "{}");
+  } catch {
+    core.warning("Failed to parse GH_AW_PROVENANCE_MANIFEST, writing a runtime-only manifest");
+    manifest = {};
+  }
+
+  const workspace = process.env.GITHUB_WORKSPACE || process.cwd();
+  if (manifest.path) {
+    const sourceHash = hashFile(path.join(workspace, manifest.path));
+    if (sourceHash) {
+      manifest.source_hash = sourceHash;
+    } else {
+      core.info(`Markdown source ${manifest.path} not found in the checkout, skipping source hash`);
+    }
+  }
+  hashSources(manifest.imports, workspace);
+  hashSources(manifest.includes, workspace);
+
+  const promptHash = hashFile(process.env.GH_AW_PROMPT || `${TMP_GH_AW_PATH}/aw-prompts/prompt.txt`);
+  if (promptHash) {
+    manifest.prompt_hash = promptHash;
+  }
+  manifest.commit = ctx.sha;
+  manifest.run_id = ctx.runId;
+
+  const manifestPath = `${TMP_GH_AW_PATH}/provenance.json`;
+  fs.mkdirSync(TMP_GH_AW_PATH, { recursive: true });
+  fs.writeFileSync(manifestPath, JSON.stringify(manifest, null, 2) + "\n");
+  core.info(`Wrote provenance manifest to ${manifestPath}`);
+}
+
+module.exports = { main, hashFile };
@@ -0,0 +1,86 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import crypto from "crypto";
+import fs from "fs";
+import os from "os";
+import path from "path";
+
+const mockCore = {
+  info: vi.fn(),
+  warning: vi.fn(),
+};
+
+const mockContext = {
+  runId: 12345,
+  sha: "abc123def456",
+};
+
+const sha256 = content => crypto.createHash("sha256").update(content).digest("hex");
+
+describe("write_provenance_manifest.cjs", () => {
+  let main;
+  let workspace;
+  let promptPath;
+  const manifestPath = "/tmp/gh-aw/provenance.json";
+
+  beforeEach(async () => {
+    vi.clearAllMocks();
+    workspace = fs.mkdtempSync(path.join(os.tmpdir(), "provenance-"));
+    fs.mkdirSync(path.join(workspace, ".github/workflows/shared"), { recursive: true });
+    fs.writeFileSync(path.join(workspace, ".github/workflows/triage.md"), "# Triage\n");
+    fs.writeFileSync(path.join(workspace, ".github/workflows/shared/tools.md"), "Shared tools\n");
+    promptPath = path.join(workspace, "prompt.txt");
+    fs.writeFileSync(promptPath, "Rendered prompt\n");
+
+    process.env.GITHUB_WORKSPACE = workspace;
+    process.env.GH_AW_PROMPT = promptPath;
+    process.env.GH_AW_PROVENANCE_MANIFEST = JSON.stringify({
+      schema_version: "v1",
+      path: ".github/workflows/triage.md",
+      frontmatter_hash: "f".repeat(64),
+      engine: { id: "claude", version: "2.0.0" },
+      imports: [{ path: ".github/workflows/shared/tools.md" }, { path: "octo/lib/shared/x.md@v1" }],
+    });
+
+    ({ main } = await import("./write_provenance_manifest.cjs"));
+  });
+
+  afterEach(() => {
+    fs.rmSync(workspace, { recursive: true, force: true });
+    delete process.env.GITHUB_WORKSPACE;
+    delete process.env.GH_AW_PROMPT;
+    delete process.env.GH_AW_PROVENANCE_MANIFEST;
+  });
+
+  it("adds runtime hashes to the compile-time manifest", async () => {
+    await main(mockCore, mockContext);
+
+    const manifest = JSON.parse(fs.readFileSync(manifestPath, "utf8"));
+    expect(manifest.frontmatter_hash).toBe("f".repeat(64));
+    expect(manifest.engine).toEqual({ id: "claude", version: "2.0.0" });
+    expect(manifest.source_hash).toBe(sha256("# Triage\n"));
+    expect(manifest.prompt_hash).toBe(sha256("Rendered prompt\n"));
+    expect(manifest.imports).toEqual([{ path: ".github/workflows/shared/tools.md", sha256: sha256("Shared tools\n") }, { path: "octo/lib/shared/x.md@v1" }]);
+    expect(manifest.commit).toBe("abc123def456");
+    expect(manifest.run_id).toBe(12345);
+  });
+
+  it("skips the source hash when the markdown is not checked out", async () => {
+    fs.rmSync(path.join(workspace, ".github/workflows/triage.md"));
+
+    await main(mockCore, mockContext);
+
+    const manifest = JSON.parse(fs.readFileSync(manifestPath, "utf8"));
+    expect(manifest.source_hash).toBeUndefined();
+    expect(mockCore.info).toHaveBeenCalledWith(expect.stringContaining("not found in the checkout"));
+  });
+
+  it("tolerates a malformed manifest", async () => {
+    process.env.GH_AW_PROVENANCE_MANIFEST = "{not json";
+
+    await main(mockCore, mockContext);
+
+    expect(mockCore.warning).toHaveBeenCalled();
+    const manifest = JSON.parse(fs.readFileSync(manifestPath, "utf8"));
+    expect(manifest.run_id).toBe(12345);
+  });
+});
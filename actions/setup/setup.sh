@@ -24,6 +24,11 @@ create_dir() {
   fi
 }
 
+# Later workflow steps write to /opt/gh-aw without sudo, so hand it to the runner user on macOS
+if [[ "$(uname -s)" == "Darwin" ]]; then
+  create_dir /opt/gh-aw
+fi
+
 # Get destination from input or use default
 DESTINATION="${INPUT_DESTINATION:-/opt/gh-aw/actions}"
 
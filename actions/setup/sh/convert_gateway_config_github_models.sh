@@ -0,0 +1,88 @@
+#!/usr/bin/env bash
+# Convert MCP Gateway Configuration to GitHub Models Agent Format
+# This script converts the gateway's standard HTTP-based MCP configuration
+# to the JSON file read by the github-models agent (github_models_agent.cjs)
+#
+# The agent connects to each server with the streamable HTTP transport, so only
+# the URL and the headers of each server are kept.
+
+set -e
+
+# Required environment variables:
+# - MCP_GATEWAY_OUTPUT: Path to gateway output configuration file
+# - MCP_GATEWAY_DOMAIN: Domain to use for MCP server URLs (e.g., host.docker.internal)
+# - MCP_GATEWAY_PORT: Port for MCP gateway (e.g., 80)
+
+if [ -z "$MCP_GATEWAY_OUTPUT" ]; then
+  echo "ERROR: MCP_GATEWAY_OUTPUT environment variable is required"
+  exit 1
+fi
+
+if [ ! -f "$MCP_GATEWAY_OUTPUT" ]; then
+  echo "ERROR: Gateway output file not found: $MCP_GATEWAY_OUTPUT"
+  exit 1
+fi
+
+if [ -z "$MCP_GATEWAY_DOMAIN" ]; then
+  echo "ERROR: MCP_GATEWAY_DOMAIN environment variable is required"
+  exit 1
+fi
+
+if [ -z "$MCP_GATEWAY_PORT" ]; then
+  echo "ERROR: MCP_GATEWAY_PORT environment variable is required"
+  exit 1
+fi
+
+echo "Converting gateway configuration to GitHub Models agent format..."
+echo "Input: $MCP_GATEWAY_OUTPUT"
+echo "Target domain: $MCP_GATEWAY_DOMAIN:$MCP_GATEWAY_PORT"
+
+# Convert gateway output to the agent format
+# Gateway format:
+# {
+#   "mcpServers": {
+#     "server-name": {
+#       "type": "http",
+#       "url": "http://domain:port/mcp/server-name",
+#       "headers": {
+#         "Authorization": "apiKey"
+#       }
+#     }
+#   }
+# }
+#
+# Agent format:
+# {
+#   "mcpServers": {
+#     "server-name": {
+#       "url": "http://domain:port/mcp/server-name",
+#       "headers": {
+#         "Authorization": "apiKey"
+#       }
+#     }
+#   }
+# }
+#
+# URLs must use the correct domain (host.docker.internal) for container access
+
+# Build the correct URL prefix using the configured domain and port
+URL_PREFIX="http://${MCP_GATEWAY_DOMAIN}:${MCP_GATEWAY_PORT}"
+
+GITHUB_MODELS_CONFIG_FILE="/tmp/gh-aw/mcp-config/github-models-mcp.json"
+
+mkdir -p "$(dirname "$GITHUB_MODELS_CONFIG_FILE")"
+
+jq --arg urlPrefix "$URL_PREFIX" '
+  .mcpServers |= with_entries(
+    .value |= {
+      # Fix the URL to use the correct domain
+      url: (.url | sub("^http://[^/]+/mcp/"; $urlPrefix + "/mcp/")),
+      headers: (.headers // {})
+    }
+  )
+' "$MCP_GATEWAY_OUTPUT" > "$GITHUB_MODELS_CONFIG_FILE"
+
+echo "GitHub Models agent configuration written to $GITHUB_MODELS_CONFIG_FILE"
+echo ""
+echo "Converted configuration:"
+cat "$GITHUB_MODELS_CONFIG_FILE"
@@ -9,16 +9,26 @@
 # Arguments:
 #   VERSION - Optional Copilot CLI version to install (default: latest release)
 #
+# Environment:
+#   GH_AW_COPILOT_MIRROR_URL - Optional base URL of an internal mirror of the GitHub
+#                              releases (the tarball is fetched from <mirror>/<version>/;
+#                              checksums always come from the official release)
+#   GH_AW_COPILOT_CACHE_DIR  - Optional directory holding a previously downloaded binary
+#                              tarball; populated after a successful verification
+#
 # Security features:
 #   - Downloads binary directly from GitHub releases (no installer script execution)
-#   - Verifies SHA256 checksum against official SHA256SUMS.txt
+#   - Verifies SHA256 checksum against official SHA256SUMS.txt, never a mirrored copy
 #   - Fails fast if checksum verification fails
+#   - Cached binaries are verified against freshly downloaded checksums on every run
 
 set -euo pipefail
 
 # Configuration
 VERSION="${1:-}"
 COPILOT_REPO="github/copilot-cli"
+MIRROR_URL="${GH_AW_COPILOT_MIRROR_URL:-}"
+CACHE_DIR="${GH_AW_COPILOT_CACHE_DIR:-}"
 INSTALL_DIR="/usr/local/bin"
 COPILOT_DIR="/home/runner/.copilot"
 
@@ -63,9 +73,17 @@ else
   BASE_URL="https://github.com/${COPILOT_REPO}/releases/download/${VERSION}"
 fi
 
-TARBALL_URL="${BASE_URL}/${TARBALL_NAME}"
+# Checksums always come from the official release so a compromised mirror
+# cannot serve checksums matching a tampered tarball
 CHECKSUMS_URL="${BASE_URL}/SHA256SUMS.txt"
 
+# Download the tarball from the internal mirror when configured
+if [ -n "$MIRROR_URL" ]; then
+  BASE_URL="${MIRROR_URL%/}/${VERSION:-latest}"
+fi
+
+TARBALL_URL="${BASE_URL}/${TARBALL_NAME}"
+
 echo "Installing GitHub Copilot CLI${VERSION:+ version $VERSION} (os: ${OS}, arch: ${ARCH})..."
 
 # Platform-portable SHA256 function
@@ -85,13 +103,19 @@ sha256_hash() {
 TEMP_DIR=$(mktemp -d)
 trap 'rm -rf "$TEMP_DIR"' EXIT
 
-# Download checksums
+# Download checksums (always fetched fresh so cached binaries are checked against the published values)
 echo "Downloading checksums from ${CHECKSUMS_URL}..."
 curl -fsSL --retry 3 --retry-delay 5 -o "${TEMP_DIR}/SHA256SUMS.txt" "${CHECKSUMS_URL}"
 
-# Download binary tarball
-echo "Downloading binary from ${TARBALL_URL}..."
-curl -fsSL --retry 3 --retry-delay 5 -o "${TEMP_DIR}/${TARBALL_NAME}" "${TARBALL_URL}"
+if [ -n "$CACHE_DIR" ] && [ -f "${CACHE_DIR}/${TARBALL_NAME}" ]; then
+  # Reuse the cached binary tarball (still verified below)
+  echo "Using cached binary from ${CACHE_DIR}..."
+  cp "${CACHE_DIR}/${TARBALL_NAME}" "${TEMP_DIR}/"
+else
+  # Download binary tarball
+  echo "Downloading binary from ${TARBALL_URL}..."
+  curl -fsSL --retry 3 --retry-delay 5 -o "${TEMP_DIR}/${TARBALL_NAME}" "${TARBALL_URL}"
+fi
 
 # Verify checksum
 echo "Verifying SHA256 checksum for ${TARBALL_NAME}..."
@@ -114,6 +138,12 @@ fi
 
 echo "✓ Checksum verification passed for ${TARBALL_NAME}"
 
+# Save the verified binary tarball for later runs
+if [ -n "$CACHE_DIR" ]; then
+  mkdir -p "$CACHE_DIR"
+  cp "${TEMP_DIR}/${TARBALL_NAME}" "${CACHE_DIR}/"
+fi
+
 # Extract and install binary
 echo "Installing binary to ${INSTALL_DIR}..."
 sudo tar -xz -C "${INSTALL_DIR}" -f "${TEMP_DIR}/${TARBALL_NAME}"
@@ -0,0 +1,80 @@
+#!/usr/bin/env bash
+# Install an npm-distributed engine CLI with package integrity verification
+# Usage: install_npm_cli.sh PACKAGE VERSION
+#
+# This script downloads the package tarball with `npm pack`, verifies it against
+# the pinned integrity value, and installs the verified tarball globally.
+#
+# Arguments:
+#   PACKAGE - npm package name (e.g., @anthropic-ai/claude-code)
+#   VERSION - Exact package version to install (e.g., 2.0.14)
+#
+# Environment:
+#   GH_AW_NPM_INTEGRITY - Expected Subresource Integrity value (e.g., sha512-...)
+#   GH_AW_NPM_REGISTRY  - Optional npm registry URL (internal mirror)
+#
+# Security features:
+#   - Verifies the package tarball against a pinned integrity value before installation
+#   - Fails fast if the tarball does not match
+#   - Never installs directly from the registry by name
+
+set -euo pipefail
+
+PACKAGE="${1:-}"
+VERSION="${2:-}"
+INTEGRITY="${GH_AW_NPM_INTEGRITY:-}"
+REGISTRY="${GH_AW_NPM_REGISTRY:-}"
+
+if [ -z "$PACKAGE" ] || [ -z "$VERSION" ]; then
+  echo "ERROR: Package and version are required"
+  echo "Usage: $0 PACKAGE VERSION"
+  exit 1
+fi
+
+if [ -z "$INTEGRITY" ]; then
+  echo "ERROR: GH_AW_NPM_INTEGRITY is required"
+  exit 1
+fi
+
+NPM_ARGS=()
+if [ -n "$REGISTRY" ]; then
+  NPM_ARGS+=(--registry "$REGISTRY")
+fi
+
+# Determine the hash algorithm from the SRI prefix
+ALGORITHM="${INTEGRITY%%-*}"
+case "$ALGORITHM" in
+  sha512|sha384|sha256) ;;
+  *) echo "ERROR: Unsupported integrity algorithm: ${ALGORITHM}"; exit 1 ;;
+esac
+
+# Create temp directory with cleanup on exit
+TEMP_DIR=$(mktemp -d)
+trap 'rm -rf "$TEMP_DIR"' EXIT
+
+echo "Downloading ${PACKAGE}@${VERSION}${REGISTRY:+ from ${REGISTRY}}..."
+TARBALL_NAME=$(npm pack "${PACKAGE}@${VERSION}" "${NPM_ARGS[@]}" --pack-destination "$TEMP_DIR" --silent | tail -n 1)
+TARBALL="${TEMP_DIR}/${TARBALL_NAME}"
+
+if [ ! -f "$TARBALL" ]; then
+  echo "ERROR: npm pack did not produce a tarball for ${PACKAGE}@${VERSION}"
+  exit 1
+fi
+
+# Verify integrity
+echo "Verifying ${ALGORITHM} integrity for ${TARBALL_NAME}..."
+ACTUAL_INTEGRITY="${ALGORITHM}-$(openssl dgst "-${ALGORITHM}" -binary "$TARBALL" | openssl base64 -A)"
+
+if [ "$INTEGRITY" != "$ACTUAL_INTEGRITY" ]; then
+  echo "ERROR: Integrity verification failed!"
+  echo "  Expected: $INTEGRITY"
+  echo "  Got:      $ACTUAL_INTEGRITY"
+  echo "  The downloaded package may be corrupted or tampered with"
+  exit 1
+fi
+
+echo "✓ Integrity verification passed for ${TARBALL_NAME}"
+
+# Install the verified tarball
+npm install -g "${NPM_ARGS[@]}" "$TARBALL"
+echo "✓ ${PACKAGE}@${VERSION} installation complete"
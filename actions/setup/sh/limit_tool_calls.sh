@@ -0,0 +1,37 @@
+#!/usr/bin/env bash
+# Claude Code PreToolUse hook that stops the agent once it exceeds GH_AW_MAX_TOOL_CALLS.
+# Each invocation counts one tool call. When the limit is exceeded the hook returns
+# {"continue": false}, which ends the session instead of running the tool.
+#
+# Environment:
+#   GH_AW_MAX_TOOL_CALLS        - Maximum number of tool calls (required)
+#   GH_AW_TOOL_CALL_COUNT_FILE  - Counter file (default: /tmp/gh-aw/tool-call-limit/count)
+
+set -euo pipefail
+
+# The hook input (tool name and arguments) is not needed to count calls
+cat > /dev/null || true
+
+max_tool_calls="${GH_AW_MAX_TOOL_CALLS:-}"
+if ! [[ "$max_tool_calls" =~ ^[0-9]+$ ]] || [ "$max_tool_calls" -eq 0 ]; then
+  exit 0
+fi
+
+count_file="${GH_AW_TOOL_CALL_COUNT_FILE:-/tmp/gh-aw/tool-call-limit/count}"
+mkdir -p "$(dirname "$count_file")"
+
+# Tool calls can run in parallel, so update the counter under a lock
+exec 9>>"${count_file}.lock"
+flock 9
+count=$(cat "$count_file" 2>/dev/null || echo 0)
+if ! [[ "$count" =~ ^[0-9]+$ ]]; then
+  count=0
+fi
+count=$((count + 1))
+echo "$count" > "$count_file"
+flock -u 9
+
+if [ "$count" -gt "$max_tool_calls" ]; then
+  echo "{\"continue\": false, \"stopReason\": \"Stopped after reaching the limit of ${max_tool_calls} tool calls (engine.max-tool-calls)\"}"
+fi
+exit 0
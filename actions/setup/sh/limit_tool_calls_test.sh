@@ -0,0 +1,53 @@
+#!/usr/bin/env bash
+# Tests for limit_tool_calls.sh
+# Run: bash limit_tool_calls_test.sh
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+HOOK_SCRIPT="${SCRIPT_DIR}/limit_tool_calls.sh"
+
+TESTS_PASSED=0
+TESTS_FAILED=0
+
+TMP_DIR="$(mktemp -d)"
+trap 'rm -rf "$TMP_DIR"' EXIT
+
+# run_hook runs the hook once with the given limit and prints its stdout
+run_hook() {
+  echo '{"tool_name":"Bash"}' | GH_AW_MAX_TOOL_CALLS="$1" GH_AW_TOOL_CALL_COUNT_FILE="$TMP_DIR/count" bash "$HOOK_SCRIPT"
+}
+
+assert_equals() {
+  local name="$1"
+  local expected="$2"
+  local actual="$3"
+  if [ "$actual" = "$expected" ]; then
+    echo "✓ $name"
+    TESTS_PASSED=$((TESTS_PASSED + 1))
+  else
+    echo "✗ $name"
+    echo "  Expected: '$expected'"
+    echo "  Got:      '$actual'"
+    TESTS_FAILED=$((TESTS_FAILED + 1))
+  fi
+}
+
+echo "Running limit_tool_calls.sh tests..."
+echo
+
+assert_equals "first call within limit" "" "$(run_hook 2)"
+assert_equals "second call within limit" "" "$(run_hook 2)"
+output="$(run_hook 2)"
+assert_equals "third call stops the agent" "true" "$(echo "$output" | grep -q '"continue": false' && echo true || echo false)"
+assert_equals "counter records every call" "3" "$(cat "$TMP_DIR/count")"
+
+rm -f "$TMP_DIR/count"
+assert_equals "missing limit allows calls" "" "$(run_hook "")"
+assert_equals "missing limit does not count" "false" "$([ -f "$TMP_DIR/count" ] && echo true || echo false)"
+
+echo
+echo "Tests passed: $TESTS_PASSED"
+echo "Tests failed: $TESTS_FAILED"
+
+if [ "$TESTS_FAILED" -gt 0 ]; then
+  exit 1
+fi
@@ -369,6 +369,11 @@ case "$ENGINE_TYPE" in
     echo "Using Gemini converter..."
     bash /opt/gh-aw/actions/convert_gateway_config_gemini.sh
     ;;
+  github-models|ollama)
+    # The ollama agent shares the github-models agent loop and its MCP configuration format
+    echo "Using GitHub Models converter..."
+    bash /opt/gh-aw/actions/convert_gateway_config_github_models.sh
+    ;;
   *)
     echo "No agent-specific converter found for engine: $ENGINE_TYPE"
     echo "Using gateway output directly"
@@ -0,0 +1,69 @@
+#!/usr/bin/env bash
+# Start Resource Sampler
+# This script starts a background process that samples runner CPU, memory, disk and network
+# usage every GH_AW_RESOURCE_SAMPLE_INTERVAL seconds and appends one JSON object per sample
+# to GH_AW_RESOURCE_SAMPLES. The samples are read by `gh aw logs --resources`.
+#
+# Each sample has the form:
+#   {"timestamp":"...","cpu_count":4,"cpu_percent":37.5,"memory_used_bytes":...,"memory_total_bytes":...,
+#    "disk_used_bytes":...,"disk_total_bytes":...,"network_rx_bytes":...,"network_tx_bytes":...}
+#
+# cpu_percent is the share of all cores in use since the previous sample. Network counters are
+# cumulative totals of all interfaces except loopback since the runner booted.
+#
+# The sampler PID is written to the sampler-pid step output.
+
+set -e
+
+SAMPLES_FILE="${GH_AW_RESOURCE_SAMPLES:-/tmp/gh-aw/resource-usage.jsonl}"
+INTERVAL="${GH_AW_RESOURCE_SAMPLE_INTERVAL:-5}"
+DISK_PATH="${GITHUB_WORKSPACE:-/}"
+
+if [ ! -r /proc/stat ] || [ ! -r /proc/meminfo ] || [ ! -r /proc/net/dev ]; then
+  echo "Resource sampling requires /proc and is not supported on this runner"
+  exit 0
+fi
+
+# Prints the total and idle CPU jiffies across all cores
+read_cpu() {
+  awk '/^cpu / { total = 0; for (i = 2; i <= NF; i++) total += $i; print total, $5 + $6 }' /proc/stat
+}
+
+# Appends one sample to the samples file, using the CPU counters of the previous sample
+write_sample() {
+  local prev_total="$1" prev_idle="$2" total="$3" idle="$4"
+  local cpu_percent mem_total mem_available disk_total disk_used rx tx
+
+  cpu_percent=$(awk -v dt="$((total - prev_total))" -v di="$((idle - prev_idle))" 'BEGIN { if (dt > 0) printf "%.1f", (dt - di) * 100 / dt; else print 0 }')
+  read -r mem_total mem_available < <(awk '/^MemTotal:/ { t = $2 * 1024 } /^MemAvailable:/ { a = $2 * 1024 } END { printf "%.0f %.0f\n", t, a }' /proc/meminfo)
+  read -r disk_total disk_used < <(df -P -B1 "$DISK_PATH" 2>/dev/null | awk 'NR == 2 { print $2, $3 }')
+  read -r rx tx < <(awk 'NR > 2 { sub(":", " "); if ($1 != "lo") { rx += $2; tx += $10 } } END { printf "%.0f %.0f\n", rx, tx }' /proc/net/dev)
+
+  printf '{"timestamp":"%s","cpu_count":%d,"cpu_percent":%s,"memory_used_bytes":%d,"memory_total_bytes":%d,"disk_used_bytes":%d,"disk_total_bytes":%d,"network_rx_bytes":%d,"network_tx_bytes":%d}\n' \
+    "$(date -u +%Y-%m-%dT%H:%M:%SZ)" "$(nproc)" "$cpu_percent" "$((mem_total - mem_available))" "$mem_total" \
+    "${disk_used:-0}" "${disk_total:-0}" "$rx" "$tx" >> "$SAMPLES_FILE"
+}
+
+sample_loop() {
+  local prev_total prev_idle total idle
+  read -r prev_total prev_idle < <(read_cpu)
+  while true; do
+    sleep "$INTERVAL"
+    read -r total idle < <(read_cpu)
+    write_sample "$prev_total" "$prev_idle" "$total" "$idle"
+    prev_total="$total"
+    prev_idle="$idle"
+  done
+}
+
+mkdir -p "$(dirname "$SAMPLES_FILE")"
+: > "$SAMPLES_FILE"
+
+sample_loop > /dev/null 2>&1 &
+SAMPLER_PID=$!
+disown "$SAMPLER_PID" 2>/dev/null || true
+
+echo "Resource sampler started (pid $SAMPLER_PID, interval ${INTERVAL}s, samples $SAMPLES_FILE)"
+if [ -n "$GITHUB_OUTPUT" ]; then
+  echo "sampler-pid=$SAMPLER_PID" >> "$GITHUB_OUTPUT"
+fi
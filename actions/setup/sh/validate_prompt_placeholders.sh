@@ -14,10 +14,15 @@ fi
 echo "🔍 Validating prompt placeholders..."
 
 # Check for unreplaced environment variable placeholders (format: __GH_AW_*__)
-if grep -q "__GH_AW_" "$PROMPT_FILE"; then
+# Workflows with expressions.env-prefix name their placeholders with that prefix instead
+PLACEHOLDER_PATTERN="__GH_AW_"
+if [ -n "$GH_AW_EXPRESSION_PREFIX" ] && [ "$GH_AW_EXPRESSION_PREFIX" != "GH_AW_" ]; then
+    PLACEHOLDER_PATTERN="__GH_AW_\|__${GH_AW_EXPRESSION_PREFIX}"
+fi
+if grep -q "$PLACEHOLDER_PATTERN" "$PROMPT_FILE"; then
     echo "❌ Error: Found unreplaced placeholders in prompt file:"
     echo ""
-    grep -n "__GH_AW_" "$PROMPT_FILE" | head -20
+    grep -n "$PLACEHOLDER_PATTERN" "$PROMPT_FILE" | head -20
     echo ""
     echo "These placeholders should have been replaced with their actual values."
     echo "This indicates a problem with the placeholder substitution step."
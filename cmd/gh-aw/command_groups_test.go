@@ -23,12 +23,14 @@ func TestCommandGroupAssignments(t *testing.T) {
 		{name: "remove command in setup group", commandName: "remove", expectedGroup: "setup", shouldHaveGroup: true},
 		{name: "update command in setup group", commandName: "update", expectedGroup: "setup", shouldHaveGroup: true},
 		{name: "secrets command in setup group", commandName: "secrets", expectedGroup: "setup", shouldHaveGroup: true},
+		{name: "import command in setup group", commandName: "import", expectedGroup: "setup", shouldHaveGroup: true},
 
 		// Development Commands
 		{name: "compile command in development group", commandName: "compile", expectedGroup: "development", shouldHaveGroup: true},
 		{name: "mcp command in development group", commandName: "mcp", expectedGroup: "development", shouldHaveGroup: true},
 		{name: "status command in development group", commandName: "status", expectedGroup: "development", shouldHaveGroup: true},
 		{name: "fix command in development group", commandName: "fix", expectedGroup: "development", shouldHaveGroup: true},
+		{name: "migrate command in development group", commandName: "migrate", expectedGroup: "development", shouldHaveGroup: true},
 
 		// Execution Commands
 		{name: "run command in execution group", commandName: "run", expectedGroup: "execution", shouldHaveGroup: true},
@@ -179,18 +179,35 @@ Examples:
 var enableCmd = &cobra.Command{
 	Use:   "enable [workflow]...",
 	Short: "Enable agentic workflows",
-	Long: `Enable one or more workflows by ID, or all workflows if no IDs are provided.
+	Long: `Enable one or more workflows by ID or glob pattern, or all workflows if no IDs are provided.
 
 ` + cli.WorkflowIDExplanation + `
 
+Use --expired to re-enable the workflows that were disabled with 'disable --until'
+and whose date has passed; this is suitable for a scheduled job.
+
 Examples:
   ` + string(constants.CLIExtensionPrefix) + ` enable                    # Enable all workflows
+  ` + string(constants.CLIExtensionPrefix) + ` enable --all              # Enable all workflows
   ` + string(constants.CLIExtensionPrefix) + ` enable ci-doctor         # Enable specific workflow
   ` + string(constants.CLIExtensionPrefix) + ` enable ci-doctor.md      # Enable specific workflow (alternative format)
   ` + string(constants.CLIExtensionPrefix) + ` enable ci-doctor daily   # Enable multiple workflows
+  ` + string(constants.CLIExtensionPrefix) + ` enable 'daily-*'         # Enable workflows matching a glob pattern
+  ` + string(constants.CLIExtensionPrefix) + ` enable --expired         # Re-enable workflows whose --until date has passed
   ` + string(constants.CLIExtensionPrefix) + ` enable ci-doctor --repo owner/repo  # Enable workflow in specific repository`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		repoOverride, _ := cmd.Flags().GetString("repo")
+		all, _ := cmd.Flags().GetBool("all")
+		expired, _ := cmd.Flags().GetBool("expired")
+		if (all || expired) && len(args) > 0 {
+			return errors.New("cannot combine workflow IDs with --all or --expired")
+		}
+		if all && expired {
+			return errors.New("cannot combine --all and --expired")
+		}
+		if expired {
+			return cli.EnableExpiredWorkflows(repoOverride)
+		}
 		return cli.EnableWorkflowsByNames(args, repoOverride)
 	},
 }
@@ -198,18 +215,34 @@ Examples:
 var disableCmd = &cobra.Command{
 	Use:   "disable [workflow]...",
 	Short: "Disable agentic workflows and cancel any in-progress runs",
-	Long: `Disable one or more workflows by ID, or all workflows if no IDs are provided.
+	Long: `Disable one or more workflows by ID or glob pattern, or all workflows if no IDs are provided.
 Any in-progress runs will be cancelled before disabling.
 ` + cli.WorkflowIDExplanation + `
 
+Use --until to disable workflows temporarily, for example while investigating an agent
+that misbehaves. The date is recorded in the GH_AW_DISABLED_UNTIL repository variable and
+'` + string(constants.CLIExtensionPrefix) + ` enable --expired' re-enables the workflows once it has passed.
+
 Examples:
   ` + string(constants.CLIExtensionPrefix) + ` disable                    # Disable all workflows
+  ` + string(constants.CLIExtensionPrefix) + ` disable --all              # Disable all workflows
   ` + string(constants.CLIExtensionPrefix) + ` disable ci-doctor         # Disable specific workflow
   ` + string(constants.CLIExtensionPrefix) + ` disable ci-doctor.md      # Disable specific workflow (alternative format)
   ` + string(constants.CLIExtensionPrefix) + ` disable ci-doctor daily   # Disable multiple workflows
+  ` + string(constants.CLIExtensionPrefix) + ` disable 'daily-*'         # Disable workflows matching a glob pattern
+  ` + string(constants.CLIExtensionPrefix) + ` disable --all --until +1d # Disable all workflows for one day
+  ` + string(constants.CLIExtensionPrefix) + ` disable ci-doctor --until 2026-01-31  # Disable until a date
   ` + string(constants.CLIExtensionPrefix) + ` disable ci-doctor --repo owner/repo  # Disable workflow in specific repository`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		repoOverride, _ := cmd.Flags().GetString("repo")
+		all, _ := cmd.Flags().GetBool("all")
+		until, _ := cmd.Flags().GetString("until")
+		if all && len(args) > 0 {
+			return errors.New("cannot combine workflow IDs with --all")
+		}
+		if until != "" {
+			return cli.DisableWorkflowsUntil(args, until, repoOverride)
+		}
 		return cli.DisableWorkflowsByNames(args, repoOverride)
 	},
 }
@@ -229,7 +262,7 @@ The --dependabot flag generates dependency manifests when dependencies are detec
   - For Go: Creates go.mod for go install/get packages
   - Creates .github/dependabot.yml with all detected ecosystems
   - Use --force to overwrite existing dependabot.yml
-  - Cannot be used with specific workflow files or custom --dir
+  - Cannot be used with specific workflow files, custom --dir or --output-dir
   - Only processes workflows in the default .github/workflows directory
 
 Examples:
@@ -238,18 +271,30 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` compile ci-doctor daily-plan  # Compile multiple workflows
   ` + string(constants.CLIExtensionPrefix) + ` compile workflow.md        # Compile by file path
   ` + string(constants.CLIExtensionPrefix) + ` compile --dir custom/workflows  # Compile from custom directory
+  ` + string(constants.CLIExtensionPrefix) + ` compile --output-dir ../other-repo/.github/workflows  # Write lock files to another directory
+  ` + string(constants.CLIExtensionPrefix) + ` compile --lock-file-name 'aw-{name}.yml'  # Name lock files aw-<workflow>.yml
+  ` + string(constants.CLIExtensionPrefix) + ` compile --policy aw-policy.yml  # Check against a local organization policy
   ` + string(constants.CLIExtensionPrefix) + ` compile --watch ci-doctor     # Watch and auto-compile
   ` + string(constants.CLIExtensionPrefix) + ` compile --trial --logical-repo owner/repo  # Compile for trial mode
+  ` + string(constants.CLIExtensionPrefix) + ` compile --github-host github.example.com  # Compile for GitHub Enterprise Server
+  ` + string(constants.CLIExtensionPrefix) + ` compile --explain-profile ci-doctor  # Show the expanded permission profile
+  ` + string(constants.CLIExtensionPrefix) + ` compile --explain-strict ci-doctor   # Show strict rule levels and which rules fired
+  ` + string(constants.CLIExtensionPrefix) + ` compile --verify-mcp ci-doctor     # Check allowed MCP tool names against the running servers
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot        # Generate Dependabot manifests
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot --force  # Force overwrite existing dependabot.yml`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		engineOverride, _ := cmd.Flags().GetString("engine")
+		githubHost, _ := cmd.Flags().GetString("github-host")
 		actionMode, _ := cmd.Flags().GetString("action-mode")
 		actionTag, _ := cmd.Flags().GetString("action-tag")
 		validate, _ := cmd.Flags().GetBool("validate")
 		watch, _ := cmd.Flags().GetBool("watch")
 		dir, _ := cmd.Flags().GetString("dir")
 		workflowsDir, _ := cmd.Flags().GetString("workflows-dir")
+		outputDir, _ := cmd.Flags().GetString("output-dir")
+		lockFileName, _ := cmd.Flags().GetString("lock-file-name")
+		policy, _ := cmd.Flags().GetString("policy")
+		noOrgPolicy, _ := cmd.Flags().GetBool("no-org-policy")
 		noEmit, _ := cmd.Flags().GetBool("no-emit")
 		purge, _ := cmd.Flags().GetBool("purge")
 		strict, _ := cmd.Flags().GetBool("strict")
@@ -266,6 +311,11 @@ Examples:
 		fix, _ := cmd.Flags().GetBool("fix")
 		stats, _ := cmd.Flags().GetBool("stats")
 		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		explainProfile, _ := cmd.Flags().GetBool("explain-profile")
+		explainStrict, _ := cmd.Flags().GetBool("explain-strict")
+		splitScripts, _ := cmd.Flags().GetBool("split-scripts")
+		expressionMap, _ := cmd.Flags().GetBool("expression-map")
+		verifyMCP, _ := cmd.Flags().GetBool("verify-mcp")
 		noCheckUpdate, _ := cmd.Flags().GetBool("no-check-update")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		if err := validateEngine(engineOverride); err != nil {
@@ -297,11 +347,16 @@ Examples:
 			MarkdownFiles:          args,
 			Verbose:                verbose,
 			EngineOverride:         engineOverride,
+			GitHubHost:             githubHost,
 			ActionMode:             actionMode,
 			ActionTag:              actionTag,
 			Validate:               validate,
 			Watch:                  watch,
 			WorkflowDir:            workflowDir,
+			OutputDir:              outputDir,
+			LockFileName:           lockFileName,
+			Policy:                 policy,
+			NoOrgPolicy:            noOrgPolicy,
 			SkipInstructions:       false, // Deprecated field, kept for backward compatibility
 			NoEmit:                 noEmit,
 			Purge:                  purge,
@@ -318,6 +373,11 @@ Examples:
 			JSONOutput:             jsonOutput,
 			Stats:                  stats,
 			FailFast:               failFast,
+			ExplainProfile:         explainProfile,
+			ExplainStrict:          explainStrict,
+			SplitScripts:           splitScripts,
+			ExpressionMap:          expressionMap,
+			VerifyMCP:              verifyMCP,
 		}
 		if _, err := cli.CompileWorkflows(cmd.Context(), config); err != nil {
 			// Return error as-is without additional formatting
@@ -359,6 +419,7 @@ Examples:
   gh aw run daily-perf-improver --enable-if-needed # Enable if disabled, run, then restore state
   gh aw run daily-perf-improver --auto-merge-prs # Auto-merge any PRs created during execution
   gh aw run daily-perf-improver -F name=value -F env=prod  # Pass workflow inputs
+  gh aw run daily-perf-improver -f name=value --watch  # Dispatch, stream progress, exit with the run's conclusion
   gh aw run daily-perf-improver --push  # Commit and push workflow files before running
   gh aw run daily-perf-improver --dry-run  # Validate without actually running`,
 	Args: cobra.ArbitraryArgs,
@@ -370,6 +431,9 @@ Examples:
 		refOverride, _ := cmd.Flags().GetString("ref")
 		autoMergePRs, _ := cmd.Flags().GetBool("auto-merge-prs")
 		inputs, _ := cmd.Flags().GetStringArray("raw-field")
+		fields, _ := cmd.Flags().GetStringArray("field")
+		inputs = append(inputs, fields...)
+		watch, _ := cmd.Flags().GetBool("watch")
 		push, _ := cmd.Flags().GetBool("push")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
@@ -394,6 +458,9 @@ Examples:
 			if len(inputs) > 0 {
 				return errors.New("workflow inputs cannot be specified in interactive mode (they will be collected interactively)")
 			}
+			if watch {
+				return errors.New("--watch flag is not supported in interactive mode")
+			}
 
 			return cli.RunWorkflowInteractively(cmd.Context(), verboseFlag, repoOverride, refOverride, autoMergePRs, push, engineOverride, dryRun)
 		}
@@ -409,6 +476,7 @@ Examples:
 			Inputs:         inputs,
 			Verbose:        verboseFlag,
 			DryRun:         dryRun,
+			Watch:          watch,
 		})
 	},
 }
@@ -418,8 +486,11 @@ var versionCmd = &cobra.Command{
 	Short: "Show gh aw extension version information",
 	Long: `Show the installed version of the gh aw extension.
 
+Use 'version check' to check workflows for compatibility with this version.
+
 Examples:
-  ` + string(constants.CLIExtensionPrefix) + ` version   # Print the current version`,
+  ` + string(constants.CLIExtensionPrefix) + ` version         # Print the current version
+  ` + string(constants.CLIExtensionPrefix) + ` version check   # Check workflows against this version`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "%s version %s\n", string(constants.CLIExtensionPrefix), version)
 		return nil
@@ -626,6 +697,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 
 	// Add AI flag to compile and add commands
 	compileCmd.Flags().StringP("engine", "e", "", "Override AI engine (claude, codex, copilot, custom)")
+	compileCmd.Flags().String("github-host", "", "Compile for a GitHub Enterprise Server or GHE.com host (overrides github-host in frontmatter)")
 	compileCmd.Flags().String("action-mode", "", "Action script inlining mode (inline, dev, release). Auto-detected if not specified")
 	compileCmd.Flags().String("action-tag", "", "Override action SHA or tag for actions/setup (overrides action-mode to release). Accepts full SHA or tag name")
 	compileCmd.Flags().Bool("validate", false, "Enable GitHub Actions workflow schema validation, container image validation, and action SHA validation")
@@ -633,6 +705,11 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().StringP("dir", "d", "", "Workflow directory (default: .github/workflows)")
 	compileCmd.Flags().String("workflows-dir", "", "Deprecated: use --dir instead")
 	_ = compileCmd.Flags().MarkDeprecated("workflows-dir", "use --dir instead")
+	compileCmd.Flags().String("output-dir", "", "Directory to write lock files to (default: next to each markdown file)")
+	compileCmd.Flags().String("lock-file-name", "", "Lock file name pattern where {name} is the markdown file name without .md (default: {name}.lock.yml, or lock-file-name in .aw/config.yml)")
+	compileCmd.Flags().String("policy", "", "Organization policy file to enforce instead of the aw-policy.yml published in the organization's .github repository")
+	compileCmd.Flags().Bool("no-org-policy", false, "Compile without the organization policy, for example when it cannot be fetched offline")
+	compileCmd.MarkFlagsMutuallyExclusive("policy", "no-org-policy")
 	compileCmd.Flags().Bool("no-emit", false, "Validate workflow without generating lock files")
 	compileCmd.Flags().Bool("purge", false, "Delete .lock.yml files that were not regenerated during compilation (only when no specific files are specified)")
 	compileCmd.Flags().Bool("strict", false, "Override frontmatter to enforce strict mode validation for all workflows (enforces action pinning, network config, safe-outputs, refuses write permissions and deprecated fields). Note: Workflows default to strict mode unless frontmatter sets strict: false")
@@ -649,6 +726,11 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().BoolP("json", "j", false, "Output results in JSON format")
 	compileCmd.Flags().Bool("stats", false, "Display statistics table sorted by file size (shows jobs, steps, scripts, and shells)")
 	compileCmd.Flags().Bool("fail-fast", false, "Stop at the first validation error instead of collecting all errors")
+	compileCmd.Flags().Bool("split-scripts", false, "Move generated helper files (safe-output tool schemas, safe-input tools) out of lock files into versioned files under .github/aw/scripts/")
+	compileCmd.Flags().Bool("expression-map", false, "Write each workflow's markdown expression to environment variable mapping table next to its lock file as <name>.expressions.json")
+	compileCmd.Flags().Bool("explain-profile", false, "Show the permissions, tools, and safe outputs each workflow's permission profile expands to")
+	compileCmd.Flags().Bool("explain-strict", false, "Show each workflow's strict rule levels (off, warn, error) and which strict mode rules fired")
+	compileCmd.Flags().Bool("verify-mcp", false, "Launch each MCP server with an allowed tool list and fail compilation if an allowed tool name is not advertised by the server")
 	compileCmd.Flags().Bool("no-check-update", false, "Skip checking for gh-aw updates")
 	compileCmd.MarkFlagsMutuallyExclusive("dir", "workflows-dir")
 
@@ -656,6 +738,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.ValidArgsFunction = cli.CompleteWorkflowNames
 	cli.RegisterEngineFlagCompletion(compileCmd)
 	cli.RegisterDirFlagCompletion(compileCmd, "dir")
+	cli.RegisterDirFlagCompletion(compileCmd, "output-dir")
 
 	rootCmd.AddCommand(compileCmd)
 
@@ -667,6 +750,10 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	// Add flags to enable/disable commands
 	enableCmd.Flags().StringP("repo", "r", "", "Target repository ([HOST/]owner/repo format). Defaults to current repository")
 	disableCmd.Flags().StringP("repo", "r", "", "Target repository ([HOST/]owner/repo format). Defaults to current repository")
+	enableCmd.Flags().Bool("all", false, "Enable all agentic workflows")
+	enableCmd.Flags().Bool("expired", false, "Re-enable workflows whose 'disable --until' date has passed")
+	disableCmd.Flags().Bool("all", false, "Disable all agentic workflows")
+	disableCmd.Flags().String("until", "", "Re-enable date recorded for 'enable --expired' (YYYY-MM-DD, RFC3339, or relative like +3d)")
 	// Register completions for enable/disable commands
 	enableCmd.ValidArgsFunction = cli.CompleteWorkflowNames
 	disableCmd.ValidArgsFunction = cli.CompleteWorkflowNames
@@ -679,6 +766,8 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	runCmd.Flags().String("ref", "", "Branch or tag name to run the workflow on (default: current branch)")
 	runCmd.Flags().Bool("auto-merge-prs", false, "Auto-merge any pull requests created during the workflow execution")
 	runCmd.Flags().StringArrayP("raw-field", "F", []string{}, "Add a string parameter in key=value format (can be used multiple times)")
+	runCmd.Flags().StringArrayP("field", "f", []string{}, "Add a workflow input in key=value format (same as --raw-field, can be used multiple times)")
+	runCmd.Flags().BoolP("watch", "w", false, "Wait for the triggered run, stream its progress, and exit with the run's conclusion (0 success, 1 failure, 2 cancelled, 3 timed out)")
 	runCmd.Flags().Bool("push", false, "Commit and push workflow files (including transitive imports) before running")
 	runCmd.Flags().Bool("dry-run", false, "Validate workflow without actually triggering execution on GitHub Actions")
 	// Register completions for run command
@@ -700,12 +789,20 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	prCmd := cli.NewPRCommand()
 	secretsCmd := cli.NewSecretsCommand()
 	fixCmd := cli.NewFixCommand()
+	migrateCmd := cli.NewMigrateCommand()
 	upgradeCmd := cli.NewUpgradeCommand()
+	upgradeActionsCmd := cli.NewUpgradeActionsCommand()
 	completionCmd := cli.NewCompletionCommand()
 	hashCmd := cli.NewHashCommand()
 	projectCmd := cli.NewProjectCommand()
 	checksCmd := cli.NewChecksCommand()
 	validateCmd := cli.NewValidateCommand(validateEngine)
+	importCmd := cli.NewImportCommand()
+	packageCmd := cli.NewPackageCommand()
+	diffCmd := cli.NewDiffCommand()
+	testCmd := cli.NewTestCommand()
+	replayCmd := cli.NewReplayCommand()
+	configCmd := cli.NewConfigCommand()
 
 	// Assign commands to groups
 	// Setup Commands
@@ -716,7 +813,10 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	removeCmd.GroupID = "setup"
 	updateCmd.GroupID = "setup"
 	upgradeCmd.GroupID = "setup"
+	upgradeActionsCmd.GroupID = "setup"
 	secretsCmd.GroupID = "setup"
+	configCmd.GroupID = "setup"
+	importCmd.GroupID = "setup"
 
 	// Development Commands
 	compileCmd.GroupID = "development"
@@ -725,12 +825,17 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	statusCmd.GroupID = "development"
 	listCmd.GroupID = "development"
 	fixCmd.GroupID = "development"
+	migrateCmd.GroupID = "development"
+	packageCmd.GroupID = "development"
+	diffCmd.GroupID = "development"
+	testCmd.GroupID = "development"
 
 	// Execution Commands
 	runCmd.GroupID = "execution"
 	enableCmd.GroupID = "execution"
 	disableCmd.GroupID = "execution"
 	trialCmd.GroupID = "execution"
+	replayCmd.GroupID = "execution"
 
 	// Analysis Commands
 	logsCmd.GroupID = "analysis"
@@ -752,9 +857,11 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	rootCmd.AddCommand(addWizardCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(upgradeCmd)
+	rootCmd.AddCommand(upgradeActionsCmd)
 	rootCmd.AddCommand(trialCmd)
 	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(importCmd)
 
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(removeCmd)
@@ -769,9 +876,16 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	rootCmd.AddCommand(mcpCmd)
 	rootCmd.AddCommand(mcpServerCmd)
 	rootCmd.AddCommand(prCmd)
+	versionCmd.AddCommand(cli.NewVersionCheckCommand())
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(secretsCmd)
+	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(fixCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(packageCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(testCmd)
+	rootCmd.AddCommand(replayCmd)
 	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(hashCmd)
@@ -789,6 +903,13 @@ func main() {
 	workflow.SetIsRelease(isRelease == "true")
 
 	if err := rootCmd.Execute(); err != nil {
+		// Watched runs exit with the code of the run's conclusion
+		var conclusionErr *cli.RunConclusionError
+		if errors.As(err, &conclusionErr) {
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(err.Error()))
+			os.Exit(conclusionErr.ExitCode())
+		}
+
 		errMsg := err.Error()
 		// Check if error is already formatted to avoid double formatting:
 		// - Contains suggestions (FormatErrorWithSuggestions)
@@ -58,10 +58,24 @@ func ResolveWorkflows(workflows []string, verbose bool) (*ResolvedWorkflows, err
 		}
 	}
 
+	// Load catalogs used to resolve bare workflow names
+	repoConfig, err := loadRepoConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse workflow specifications
 	parsedSpecs := []*WorkflowSpec{}
 
 	for _, workflow := range workflows {
+		if isBareWorkflowName(workflow) && len(repoConfig.Catalogs) > 0 {
+			expanded, err := expandCatalogWorkflowSpec(workflow, repoConfig.Catalogs, verbose)
+			if err != nil {
+				return nil, err
+			}
+			workflow = expanded
+		}
+
 		spec, err := parseWorkflowSpec(workflow)
 		if err != nil {
 			return nil, fmt.Errorf("invalid workflow specification '%s': %w", workflow, err)
@@ -158,6 +172,40 @@ func ResolveWorkflows(workflows []string, verbose bool) (*ResolvedWorkflows, err
 	}, nil
 }
 
+// isBareWorkflowName reports whether a spec is a plain workflow name such as
+// "ci-doctor" or "ci-doctor@v1", with no repository or path component.
+func isBareWorkflowName(spec string) bool {
+	name, _, _ := strings.Cut(spec, "@")
+	return name != "" && !strings.ContainsAny(name, `/\`) && !strings.HasSuffix(name, ".md") && !isLocalWorkflowPath(name)
+}
+
+// expandCatalogWorkflowSpec resolves a bare workflow name against the catalogs
+// configured in .aw/config.yml. With a single catalog the name is expanded directly;
+// with several, the first catalog that contains the workflow is used.
+func expandCatalogWorkflowSpec(workflow string, catalogs []string, verbose bool) (string, error) {
+	if len(catalogs) == 1 {
+		expanded := catalogs[0] + "/" + workflow
+		resolutionLog.Printf("Expanded catalog workflow %s to %s", workflow, expanded)
+		return expanded, nil
+	}
+
+	for _, catalog := range catalogs {
+		expanded := catalog + "/" + workflow
+		spec, err := parseWorkflowSpec(expanded)
+		if err != nil {
+			return "", fmt.Errorf("invalid workflow specification '%s': %w", expanded, err)
+		}
+		if _, err := FetchWorkflowFromSource(spec, verbose); err != nil {
+			resolutionLog.Printf("Workflow %s not found in catalog %s: %v", workflow, catalog, err)
+			continue
+		}
+		resolutionLog.Printf("Expanded catalog workflow %s to %s", workflow, expanded)
+		return expanded, nil
+	}
+
+	return "", fmt.Errorf("workflow '%s' not found in catalogs: %s", workflow, strings.Join(catalogs, ", "))
+}
+
 // expandLocalWildcardWorkflows expands wildcard workflow specifications for local workflows only.
 func expandLocalWildcardWorkflows(specs []*WorkflowSpec, verbose bool) ([]*WorkflowSpec, error) {
 	expandedWorkflows := []*WorkflowSpec{}
@@ -50,6 +50,11 @@ This command:
 - Extracts missing tool reports
 - Generates a concise Markdown report
 
+With --deprecations, no run ID is needed. Instead, all workflow sources in the
+workflow directory are scanned for deprecated keys, experimental features, legacy
+engine names, and model IDs past their sunset dates. The result is a prioritized
+migration report with per-file fix commands.
+
 Examples:
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890     # Audit run with ID 1234567890
   ` + string(constants.CLIExtensionPrefix) + ` audit https://github.com/owner/repo/actions/runs/1234567890  # Audit from run URL
@@ -59,9 +64,28 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` audit https://github.example.com/owner/repo/actions/runs/1234567890  # Audit from GitHub Enterprise
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 -o ./audit-reports  # Custom output directory
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 -v  # Verbose output
-  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --parse  # Parse agent logs and firewall logs, generating log.md and firewall.md`,
-		Args: cobra.ExactArgs(1),
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --parse  # Parse agent logs and firewall logs, generating log.md and firewall.md
+  ` + string(constants.CLIExtensionPrefix) + ` audit --deprecations  # Scan all workflows for deprecations
+  ` + string(constants.CLIExtensionPrefix) + ` audit --deprecations --json  # Deprecation report in JSON format`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if deprecations, _ := cmd.Flags().GetBool("deprecations"); deprecations {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+
+			if deprecations, _ := cmd.Flags().GetBool("deprecations"); deprecations {
+				dir, _ := cmd.Flags().GetString("dir")
+				return RunDeprecationAudit(DeprecationAuditConfig{
+					WorkflowDir: dir,
+					JSONOutput:  jsonOutput,
+					Verbose:     verbose,
+				})
+			}
+
 			runIDOrURL := args[0]
 
 			// Parse run information from input (either numeric ID or URL)
@@ -72,9 +96,8 @@ Examples:
 			}
 
 			outputDir, _ := cmd.Flags().GetString("output")
-			verbose, _ := cmd.Flags().GetBool("verbose")
-			jsonOutput, _ := cmd.Flags().GetBool("json")
 			parse, _ := cmd.Flags().GetBool("parse")
+			otelExport, _ := cmd.Flags().GetString("otel")
 
 			return AuditWorkflowRun(
 				cmd.Context(),
@@ -88,6 +111,7 @@ Examples:
 				jsonOutput,
 				components.JobID,
 				components.StepNumber,
+				otelExport,
 			)
 		},
 	}
@@ -96,9 +120,13 @@ Examples:
 	addOutputFlag(cmd, defaultLogsOutputDir)
 	addJSONFlag(cmd)
 	cmd.Flags().Bool("parse", false, "Run JavaScript parsers on agent logs and firewall logs, writing Markdown to log.md and firewall.md")
+	cmd.Flags().String("otel", "", "Export the run, its jobs, steps and tool calls as OpenTelemetry traces to an OTLP/HTTP endpoint (http(s)://...) or an OTLP/JSON file")
+	cmd.Flags().Bool("deprecations", false, "Scan workflow sources for deprecations instead of auditing a run")
+	cmd.Flags().StringP("dir", "d", "", "Workflow directory for --deprecations (default: .github/workflows)")
 
 	// Register completions for audit command
 	RegisterDirFlagCompletion(cmd, "output")
+	RegisterDirFlagCompletion(cmd, "dir")
 
 	return cmd
 }
@@ -119,7 +147,7 @@ func isPermissionError(err error) bool {
 // AuditWorkflowRun audits a single workflow run and generates a report
 // If jobID is provided (>0), focuses audit on that specific job
 // If stepNumber is provided (>0), extracts output for that specific step
-func AuditWorkflowRun(ctx context.Context, runID int64, owner, repo, hostname string, outputDir string, verbose bool, parse bool, jsonOutput bool, jobID int64, stepNumber int) error {
+func AuditWorkflowRun(ctx context.Context, runID int64, owner, repo, hostname string, outputDir string, verbose bool, parse bool, jsonOutput bool, jobID int64, stepNumber int, otelExport string) error {
 	auditLog.Printf("Starting audit for workflow run: runID=%d, owner=%s, repo=%s, jobID=%d, stepNumber=%d", runID, owner, repo, jobID, stepNumber)
 
 	// Check context cancellation at the start
@@ -333,6 +361,15 @@ func AuditWorkflowRun(ctx context.Context, runID int64, owner, repo, hostname st
 	// Build structured audit data
 	auditData := buildAuditData(processedRun, metrics, mcpToolUsage)
 
+	// Export OpenTelemetry traces if requested
+	if otelExport != "" {
+		tracedRun := processedRun
+		tracedRun.MCPToolUsage = mcpToolUsage
+		if err := exportOTelTraces(otelExport, []ProcessedRun{tracedRun}, verbose); err != nil {
+			return err
+		}
+	}
+
 	// Render output based on format preference
 	if jsonOutput {
 		if err := renderJSON(auditData); err != nil {
@@ -0,0 +1,359 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var auditDeprecationsLog = logger.New("cli:audit_deprecations")
+
+// Deprecation finding categories
+const (
+	DeprecationCategoryField        = "deprecated-field"
+	DeprecationCategorySyntax       = "deprecated-syntax"
+	DeprecationCategoryEngine       = "engine"
+	DeprecationCategoryModel        = "model-sunset"
+	DeprecationCategoryExperimental = "experimental"
+)
+
+// Deprecation finding priorities, ordered from most to least urgent
+const (
+	DeprecationPriorityHigh   = "high"
+	DeprecationPriorityMedium = "medium"
+	DeprecationPriorityLow    = "low"
+)
+
+// modelSunsetWarningWindow is how far ahead of a sunset date a model is reported
+const modelSunsetWarningWindow = 90 * 24 * time.Hour
+
+// ModelSunset describes a model ID that is scheduled for (or past) retirement
+type ModelSunset struct {
+	Model       string // Model identifier as used in engine.model
+	SunsetDate  string // Retirement date in YYYY-MM-DD format
+	Replacement string // Recommended replacement model
+}
+
+// knownModelSunsets lists model IDs with published retirement dates
+var knownModelSunsets = []ModelSunset{
+	{Model: "gpt-5-mini", SunsetDate: "2026-01-17", Replacement: string(constants.DefaultCopilotDetectionModel)},
+	{Model: "claude-3-sonnet-20240229", SunsetDate: "2025-07-21", Replacement: "claude-sonnet-4"},
+	{Model: "claude-3-5-sonnet-20240620", SunsetDate: "2025-10-22", Replacement: "claude-sonnet-4"},
+	{Model: "claude-3-5-sonnet-20241022", SunsetDate: "2025-10-22", Replacement: "claude-sonnet-4"},
+}
+
+// experimentalFrontmatterKeys maps top-level frontmatter keys to the experimental feature they enable
+var experimentalFrontmatterKeys = map[string]string{
+	"safe-inputs":  "safe-inputs",
+	"plugins":      "plugins",
+	"dependencies": "dependencies (APM)",
+	"rate-limit":   "rate-limit",
+}
+
+// DeprecationFinding represents a single deprecation or migration issue found in a workflow
+type DeprecationFinding struct {
+	File       string `json:"file" console:"header:File"`
+	Priority   string `json:"priority" console:"header:Priority"`
+	Category   string `json:"category" console:"header:Category"`
+	Key        string `json:"key" console:"header:Key"`
+	Message    string `json:"message" console:"header:Message,maxlen:60"`
+	FixCommand string `json:"fix_command,omitempty" console:"header:Fix"`
+}
+
+// DeprecationReport is the structured result of a repository-wide deprecation scan
+type DeprecationReport struct {
+	WorkflowDir   string               `json:"workflow_dir"`
+	FilesScanned  int                  `json:"files_scanned"`
+	FilesAffected int                  `json:"files_affected"`
+	Findings      []DeprecationFinding `json:"findings"`
+}
+
+// DeprecationAuditConfig holds configuration for the deprecation audit
+type DeprecationAuditConfig struct {
+	WorkflowDir string
+	JSONOutput  bool
+	Verbose     bool
+}
+
+// RunDeprecationAudit scans all workflow sources for deprecations and prints a prioritized report
+func RunDeprecationAudit(config DeprecationAuditConfig) error {
+	auditDeprecationsLog.Printf("Running deprecation audit: dir=%s, json=%v", config.WorkflowDir, config.JSONOutput)
+
+	workflowDir := config.WorkflowDir
+	if workflowDir == "" {
+		workflowDir = getWorkflowsDir()
+	}
+
+	files, err := getMarkdownWorkflowFiles(workflowDir)
+	if err != nil {
+		return err
+	}
+
+	report, err := scanWorkflowDeprecations(files, time.Now())
+	if err != nil {
+		return err
+	}
+	report.WorkflowDir = workflowDir
+
+	if config.JSONOutput {
+		jsonBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	renderDeprecationReport(report)
+	return nil
+}
+
+// scanWorkflowDeprecations inspects each workflow file and returns findings sorted by priority
+func scanWorkflowDeprecations(files []string, now time.Time) (*DeprecationReport, error) {
+	deprecatedFields, err := parser.GetMainWorkflowDeprecatedFields()
+	if err != nil {
+		return nil, err
+	}
+	codemods := GetAllCodemods()
+
+	report := &DeprecationReport{Findings: []DeprecationFinding{}}
+	affected := make(map[string]bool)
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		report.FilesScanned++
+
+		findings := findWorkflowDeprecations(filepath.Base(file), string(content), deprecatedFields, codemods, now)
+		if len(findings) > 0 {
+			affected[file] = true
+			report.Findings = append(report.Findings, findings...)
+		}
+	}
+	report.FilesAffected = len(affected)
+
+	sortDeprecationFindings(report.Findings)
+	auditDeprecationsLog.Printf("Deprecation scan complete: files=%d, affected=%d, findings=%d", report.FilesScanned, report.FilesAffected, len(report.Findings))
+	return report, nil
+}
+
+// findWorkflowDeprecations returns all deprecation findings for a single workflow's content
+func findWorkflowDeprecations(fileName, content string, deprecatedFields []parser.DeprecatedField, codemods []Codemod, now time.Time) []DeprecationFinding {
+	result, err := parser.ExtractFrontmatterFromContent(content)
+	if err != nil {
+		auditDeprecationsLog.Printf("Skipping %s: failed to parse frontmatter: %v", fileName, err)
+		return nil
+	}
+	frontmatter := result.Frontmatter
+	workflowID := strings.TrimSuffix(fileName, ".md")
+	fixCommand := fmt.Sprintf("%s fix %s --write", string(constants.CLIExtensionPrefix), workflowID)
+
+	var findings []DeprecationFinding
+
+	// Syntax migrations that a codemod can apply automatically. Codemods are
+	// applied in sequence (as 'fix' does) so later checks see the migrated result.
+	fixedContent := content
+	fixedFrontmatter := frontmatter
+	for _, codemod := range codemods {
+		newContent, applied, err := codemod.Apply(fixedContent, fixedFrontmatter)
+		if err != nil || !applied {
+			continue
+		}
+		findings = append(findings, DeprecationFinding{
+			File:       fileName,
+			Priority:   DeprecationPriorityHigh,
+			Category:   DeprecationCategorySyntax,
+			Key:        codemod.ID,
+			Message:    codemod.Description,
+			FixCommand: fixCommand,
+		})
+		fixedContent = newContent
+		if fixedResult, err := parser.ExtractFrontmatterFromContent(fixedContent); err == nil {
+			fixedFrontmatter = fixedResult.Frontmatter
+		}
+	}
+
+	// Schema-deprecated top-level fields that no codemod migrates need a manual fix
+	for _, field := range parser.FindDeprecatedFieldsInFrontmatter(fixedFrontmatter, deprecatedFields) {
+		message := fmt.Sprintf("'%s' is deprecated", field.Name)
+		if field.Replacement != "" {
+			message = fmt.Sprintf("'%s' is deprecated, use '%s' instead", field.Name, field.Replacement)
+		}
+		findings = append(findings, DeprecationFinding{
+			File:     fileName,
+			Priority: DeprecationPriorityHigh,
+			Category: DeprecationCategoryField,
+			Key:      field.Name,
+			Message:  message,
+		})
+	}
+
+	findings = append(findings, findEngineDeprecations(fileName, frontmatter, now)...)
+
+	// Experimental features that may change without notice
+	for key, feature := range experimentalFrontmatterKeys {
+		if _, exists := frontmatter[key]; exists {
+			findings = append(findings, DeprecationFinding{
+				File:     fileName,
+				Priority: DeprecationPriorityLow,
+				Category: DeprecationCategoryExperimental,
+				Key:      key,
+				Message:  "Uses experimental feature: " + feature,
+			})
+		}
+	}
+	if features, ok := frontmatter["features"].(map[string]any); ok {
+		for flag := range features {
+			findings = append(findings, DeprecationFinding{
+				File:     fileName,
+				Priority: DeprecationPriorityLow,
+				Category: DeprecationCategoryExperimental,
+				Key:      "features." + flag,
+				Message:  fmt.Sprintf("Feature flag '%s' is experimental and may be removed", flag),
+			})
+		}
+	}
+
+	return findings
+}
+
+// findEngineDeprecations reports legacy engine identifiers, experimental engines, and sunset models
+func findEngineDeprecations(fileName string, frontmatter map[string]any, now time.Time) []DeprecationFinding {
+	compiler := &workflow.Compiler{}
+	engineSetting, engineConfig := compiler.ExtractEngineConfig(frontmatter)
+	if engineSetting == "" && engineConfig == nil {
+		return nil
+	}
+
+	engineID := engineSetting
+	if engineConfig != nil && engineConfig.ID != "" {
+		engineID = engineConfig.ID
+	}
+
+	var findings []DeprecationFinding
+	registry := workflow.GetGlobalEngineRegistry()
+
+	if engineID != "" && !registry.IsValidEngine(engineID) {
+		if engine, err := registry.GetEngineByPrefix(engineID); err == nil {
+			findings = append(findings, DeprecationFinding{
+				File:     fileName,
+				Priority: DeprecationPriorityHigh,
+				Category: DeprecationCategoryEngine,
+				Key:      "engine",
+				Message:  fmt.Sprintf("Engine '%s' is a legacy alias scheduled for removal, use '%s' instead", engineID, engine.GetID()),
+			})
+		}
+	} else if engine, err := registry.GetEngine(engineID); err == nil && engine.IsExperimental() {
+		findings = append(findings, DeprecationFinding{
+			File:     fileName,
+			Priority: DeprecationPriorityLow,
+			Category: DeprecationCategoryExperimental,
+			Key:      "engine",
+			Message:  fmt.Sprintf("Engine '%s' is experimental", engineID),
+		})
+	}
+
+	if engineConfig == nil || engineConfig.Model == "" {
+		return findings
+	}
+
+	for _, sunset := range knownModelSunsets {
+		if !strings.EqualFold(sunset.Model, engineConfig.Model) {
+			continue
+		}
+		sunsetDate, err := time.Parse("2006-01-02", sunset.SunsetDate)
+		if err != nil {
+			continue
+		}
+		switch {
+		case !now.Before(sunsetDate):
+			findings = append(findings, DeprecationFinding{
+				File:     fileName,
+				Priority: DeprecationPriorityHigh,
+				Category: DeprecationCategoryModel,
+				Key:      "engine.model",
+				Message:  fmt.Sprintf("Model '%s' was retired on %s, use '%s' instead", sunset.Model, sunset.SunsetDate, sunset.Replacement),
+			})
+		case sunsetDate.Sub(now) <= modelSunsetWarningWindow:
+			findings = append(findings, DeprecationFinding{
+				File:     fileName,
+				Priority: DeprecationPriorityMedium,
+				Category: DeprecationCategoryModel,
+				Key:      "engine.model",
+				Message:  fmt.Sprintf("Model '%s' will be retired on %s, migrate to '%s'", sunset.Model, sunset.SunsetDate, sunset.Replacement),
+			})
+		}
+	}
+
+	return findings
+}
+
+// deprecationPriorityRank returns a sort rank for a priority (lower is more urgent)
+func deprecationPriorityRank(priority string) int {
+	switch priority {
+	case DeprecationPriorityHigh:
+		return 0
+	case DeprecationPriorityMedium:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// sortDeprecationFindings orders findings by priority, then file, then key for stable output
+func sortDeprecationFindings(findings []DeprecationFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		ri, rj := deprecationPriorityRank(findings[i].Priority), deprecationPriorityRank(findings[j].Priority)
+		if ri != rj {
+			return ri < rj
+		}
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Key < findings[j].Key
+	})
+}
+
+// renderDeprecationReport prints the deprecation report as a table followed by fix commands
+func renderDeprecationReport(report *DeprecationReport) {
+	if len(report.Findings) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("✓ No deprecations found in %d workflow files", report.FilesScanned)))
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Deprecation Report (%s)", report.WorkflowDir)))
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprint(os.Stderr, console.RenderStruct(report.Findings))
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Found %d issue(s) in %d of %d workflow files", len(report.Findings), report.FilesAffected, report.FilesScanned)))
+
+	// Collect unique fix commands in report order
+	var commands []string
+	seen := make(map[string]bool)
+	for _, finding := range report.Findings {
+		if finding.FixCommand != "" && !seen[finding.FixCommand] {
+			seen[finding.FixCommand] = true
+			commands = append(commands, finding.FixCommand)
+		}
+	}
+	if len(commands) > 0 {
+		fmt.Fprintln(os.Stderr, "")
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("To apply automatic fixes, run:"))
+		fmt.Fprintln(os.Stderr, "")
+		for _, command := range commands {
+			fmt.Fprintf(os.Stderr, "  %s\n", command)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindWorkflowDeprecations(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		content      string
+		wantKeys     []string
+		wantCategory string
+		wantPriority string
+		wantFix      bool
+	}{
+		{
+			name: "codemod-fixable deprecated field",
+			content: `---
+on: workflow_dispatch
+timeout_minutes: 30
+---
+
+# Test`,
+			wantKeys:     []string{"timeout-minutes-migration"},
+			wantCategory: DeprecationCategorySyntax,
+			wantPriority: DeprecationPriorityHigh,
+			wantFix:      true,
+		},
+		{
+			name: "retired model",
+			content: `---
+on: workflow_dispatch
+engine:
+  id: copilot
+  model: gpt-5-mini
+---
+
+# Test`,
+			wantKeys:     []string{"engine.model"},
+			wantCategory: DeprecationCategoryModel,
+			wantPriority: DeprecationPriorityHigh,
+		},
+		{
+			name: "experimental feature flag",
+			content: `---
+on: workflow_dispatch
+features:
+  my-flag: true
+---
+
+# Test`,
+			wantKeys:     []string{"features.my-flag"},
+			wantCategory: DeprecationCategoryExperimental,
+			wantPriority: DeprecationPriorityLow,
+		},
+		{
+			name: "clean workflow",
+			content: `---
+on: workflow_dispatch
+engine: copilot
+---
+
+# Test`,
+		},
+	}
+
+	codemods := GetAllCodemods()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := findWorkflowDeprecations("test.md", tt.content, nil, codemods, now)
+
+			var keys []string
+			for _, f := range findings {
+				keys = append(keys, f.Key)
+			}
+			assert.Equal(t, tt.wantKeys, keys, "Finding keys should match")
+
+			if len(tt.wantKeys) == 0 {
+				return
+			}
+			assert.Equal(t, tt.wantCategory, findings[0].Category, "Category should match")
+			assert.Equal(t, tt.wantPriority, findings[0].Priority, "Priority should match")
+			if tt.wantFix {
+				assert.Equal(t, "gh aw fix test --write", findings[0].FixCommand, "Fix command should target the workflow")
+			} else {
+				assert.Empty(t, findings[0].FixCommand, "Finding should have no automatic fix")
+			}
+		})
+	}
+}
+
+func TestFindEngineDeprecations_UpcomingSunset(t *testing.T) {
+	sunset, err := time.Parse("2006-01-02", knownModelSunsets[0].SunsetDate)
+	require.NoError(t, err, "Sunset date should parse")
+
+	frontmatter := map[string]any{
+		"engine": map[string]any{"id": "copilot", "model": knownModelSunsets[0].Model},
+	}
+
+	findings := findEngineDeprecations("test.md", frontmatter, sunset.Add(-30*24*time.Hour))
+	require.Len(t, findings, 1, "Should report upcoming sunset")
+	assert.Equal(t, DeprecationPriorityMedium, findings[0].Priority, "Upcoming sunset should be medium priority")
+
+	findings = findEngineDeprecations("test.md", frontmatter, sunset.Add(-365*24*time.Hour))
+	assert.Empty(t, findings, "Distant sunset should not be reported")
+}
+
+func TestScanWorkflowDeprecations_SortsByPriority(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a-experimental.md": "---\non: workflow_dispatch\nfeatures:\n  flag: true\n---\n\n# A\n",
+		"b-deprecated.md":   "---\non: workflow_dispatch\ntimeout_minutes: 10\n---\n\n# B\n",
+		"c-clean.md":        "---\non: workflow_dispatch\n---\n\n# C\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600), "Should write test workflow")
+		paths = append(paths, path)
+	}
+
+	report, err := scanWorkflowDeprecations(paths, time.Now())
+	require.NoError(t, err, "Scan should succeed")
+
+	assert.Equal(t, 3, report.FilesScanned, "Should scan all files")
+	assert.Equal(t, 2, report.FilesAffected, "Should count affected files")
+	require.Len(t, report.Findings, 2, "Should report one finding per affected file")
+	assert.Equal(t, "b-deprecated.md", report.Findings[0].File, "High priority findings should come first")
+	assert.Equal(t, "a-experimental.md", report.Findings[1].File, "Low priority findings should come last")
+}
@@ -29,6 +29,9 @@ func parseFrontmatterLines(content string) ([]string, string, error) {
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to parse frontmatter: %w", err)
 	}
+	if result.TOML {
+		return nil, "", parser.ErrTOMLFrontmatterNotEditable
+	}
 	return result.FrontmatterLines, result.Markdown, nil
 }
 
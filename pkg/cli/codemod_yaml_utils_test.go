@@ -534,6 +534,14 @@ on: workflow_dispatch
 			},
 			wantApplied: false,
 		},
+		{
+			name:    "TOML frontmatter is refused instead of rewritten as YAML",
+			content: "+++\ntimeout_minutes = 30\n+++\n\n# Test",
+			transform: func(lines []string) ([]string, bool) {
+				return append(lines, "timeout-minutes: 30"), true
+			},
+			wantErr: true,
+		},
 		{
 			name: "markdown body preserved",
 			content: `---
@@ -290,10 +290,17 @@ func NewWorkflow(workflowName string, verbose bool, force bool) error {
 	return nil
 }
 
-// createWorkflowTemplate generates a concise workflow template with essential options
+// createWorkflowTemplate generates a concise workflow template with essential options.
+// Release builds record their version in gh-aw-version so that later compilers can warn
+// about compatibility.
 func createWorkflowTemplate(workflowName string) string {
+	versionField := ""
+	if workflow.IsRelease() {
+		versionField = "# Version of gh-aw that created this workflow\ngh-aw-version: " + version + "\n\n"
+	}
+
 	return `---
-# Trigger - when should this workflow run?
+` + versionField + `# Trigger - when should this workflow run?
 on:
   workflow_dispatch:  # Manual trigger
 
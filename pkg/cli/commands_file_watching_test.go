@@ -266,7 +266,7 @@ func TestHandleFileDeleted(t *testing.T) {
 		// Simulate the markdown file path
 		markdownFile := filepath.Join(workflowsDir, "deleted-workflow.md")
 
-		handleFileDeleted(markdownFile, true)
+		handleFileDeleted(workflow.NewCompiler(), markdownFile, true)
 
 		// Check that lock file was removed
 		if _, err := os.Stat(lockFile); !os.IsNotExist(err) {
@@ -281,7 +281,7 @@ func TestHandleFileDeleted(t *testing.T) {
 		txtFile := filepath.Join(tempDir, "test.txt")
 
 		// This should not error (no-op for non-markdown files)
-		handleFileDeleted(txtFile, true)
+		handleFileDeleted(workflow.NewCompiler(), txtFile, true)
 	})
 
 	t.Run("handle deleted file without corresponding lock", func(t *testing.T) {
@@ -292,7 +292,7 @@ func TestHandleFileDeleted(t *testing.T) {
 		// Test deleting a markdown file that doesn't have a corresponding lock file
 		markdownFile := filepath.Join(workflowsDir, "no-lock.md")
 
-		handleFileDeleted(markdownFile, false)
+		handleFileDeleted(workflow.NewCompiler(), markdownFile, false)
 	})
 
 	t.Run("handle deleted file verbose mode", func(t *testing.T) {
@@ -307,7 +307,7 @@ func TestHandleFileDeleted(t *testing.T) {
 		markdownFile := filepath.Join(workflowsDir, "verbose-test.md")
 
 		// Test verbose mode
-		handleFileDeleted(markdownFile, true)
+		handleFileDeleted(workflow.NewCompiler(), markdownFile, true)
 	})
 
 	t.Run("handle deleted file with permission error", func(t *testing.T) {
@@ -328,7 +328,7 @@ func TestHandleFileDeleted(t *testing.T) {
 
 		// This might error due to permissions, but should handle gracefully
 		// The important thing is that it doesn't panic
-		handleFileDeleted(markdownFile, false)
+		handleFileDeleted(workflow.NewCompiler(), markdownFile, false)
 	})
 }
 
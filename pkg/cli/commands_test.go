@@ -222,7 +222,7 @@ func TestRemoveWorkflows(t *testing.T) {
 }
 
 func TestStatusWorkflows(t *testing.T) {
-	err := StatusWorkflows("test-pattern", false, false, "", "", "")
+	err := StatusWorkflows("test-pattern", false, false, "", "", "", false)
 
 	// Should not error since it's a stub implementation
 	if err != nil {
@@ -370,8 +370,8 @@ Test workflow for command existence.`
 			_, err := CompileWorkflows(context.Background(), config)
 			return err
 		}, false, "CompileWorkflows"},
-		{func() error { return RemoveWorkflows("nonexistent", false) }, false, "RemoveWorkflows"},                    // Should handle missing directory gracefully
-		{func() error { return StatusWorkflows("nonexistent", false, false, "", "", "") }, false, "StatusWorkflows"}, // Should handle missing directory gracefully
+		{func() error { return RemoveWorkflows("nonexistent", false) }, false, "RemoveWorkflows"},                           // Should handle missing directory gracefully
+		{func() error { return StatusWorkflows("nonexistent", false, false, "", "", "", false) }, false, "StatusWorkflows"}, // Should handle missing directory gracefully
 		{func() error {
 			return RunWorkflowOnGitHub(context.Background(), "", RunOptions{})
 		}, true, "RunWorkflowOnGitHub"}, // Should error with empty workflow name
@@ -87,12 +87,12 @@ func runBatchPoutine(workflowDir string, verbose bool, strict bool) error {
 }
 
 // purgeOrphanedLockFiles removes orphaned .lock.yml files
-// These are lock files that exist but don't have a corresponding .md file
-func purgeOrphanedLockFiles(workflowsDir string, expectedLockFiles []string, verbose bool) error {
-	compileBatchOperationsLog.Printf("Purging orphaned lock files in %s", workflowsDir)
+// These are lock files matching lockFileGlob that don't have a corresponding .md file
+func purgeOrphanedLockFiles(lockFileGlob string, expectedLockFiles []string, verbose bool) error {
+	compileBatchOperationsLog.Printf("Purging orphaned lock files matching %s", lockFileGlob)
 
-	// Find all existing .lock.yml files
-	existingLockFiles, err := filepath.Glob(filepath.Join(workflowsDir, "*.lock.yml"))
+	// Find all existing lock files
+	existingLockFiles, err := filepath.Glob(lockFileGlob)
 	if err != nil {
 		return fmt.Errorf("failed to find existing lock files: %w", err)
 	}
@@ -263,6 +263,15 @@ func TestCompileWorkflows_DependabotValidation(t *testing.T) {
 			expectError: true,
 			errorMsg:    "cannot be used with custom --dir",
 		},
+		{
+			name: "dependabot with output dir",
+			config: CompileConfig{
+				Dependabot: true,
+				OutputDir:  "out",
+			},
+			expectError: true,
+			errorMsg:    "cannot be used with --output-dir",
+		},
 		{
 			name: "dependabot with default settings",
 			config: CompileConfig{
@@ -311,6 +320,22 @@ func TestCompileWorkflows_PurgeValidation(t *testing.T) {
 	}
 }
 
+// TestCompileWorkflows_LockFileNameValidation tests lock file name pattern validation
+// Uses the fast validateCompileConfig function instead of full compilation
+func TestCompileWorkflows_LockFileNameValidation(t *testing.T) {
+	if err := validateCompileConfig(CompileConfig{LockFileName: "aw-{name}.yml"}); err != nil {
+		t.Errorf("Expected valid pattern to pass, got: %v", err)
+	}
+
+	err := validateCompileConfig(CompileConfig{LockFileName: "generated/{name}.lock.yml"})
+	if err == nil {
+		t.Fatal("Expected error for a pattern with a directory, got nil")
+	}
+	if !strings.Contains(err.Error(), "--lock-file-name") {
+		t.Errorf("Expected error naming the flag, got: %v", err)
+	}
+}
+
 // TestCompileWorkflows_WorkflowDirValidation tests workflow directory validation
 // Uses the fast validateCompileConfig function instead of full compilation
 func TestCompileWorkflows_WorkflowDirValidation(t *testing.T) {
@@ -21,6 +21,8 @@
 //   - configureCompilerFlags() - Sets validation, strict mode, trial mode flags
 //   - setupActionMode() - Configures action script inlining mode
 //   - setupRepositoryContext() - Sets repository slug for schedule scattering
+//   - setupActionPinOverrides() - Applies the repository's action pin overrides and mirrors
+//   - setupRemoteImportPolicy() - Applies the repository's trust policy for remote imports
 //
 // These functions abstract compiler setup, allowing the main compile
 // orchestrator to focus on coordination while these handle configuration.
@@ -33,7 +35,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -94,7 +98,14 @@ func createAndConfigureCompiler(config CompileConfig) *workflow.Compiler {
 	compiler := workflow.NewCompiler(
 		workflow.WithVerbose(config.Verbose),
 		workflow.WithEngineOverride(config.EngineOverride),
+		workflow.WithGitHubHost(config.GitHubHost),
 		workflow.WithFailFast(config.FailFast),
+		workflow.WithRepoConfig(config.RepoConfig),
+		workflow.WithOrgPolicy(config.OrgPolicy),
+		workflow.WithSplitScripts(config.SplitScripts),
+		workflow.WithExpressionMap(config.ExpressionMap),
+		workflow.WithOutputDir(config.OutputDir),
+		workflow.WithLockFileNamePattern(config.LockFileName),
 	)
 	compileCompilerSetupLog.Print("Created compiler instance")
 
@@ -107,6 +118,12 @@ func createAndConfigureCompiler(config CompileConfig) *workflow.Compiler {
 	// Set up repository context
 	setupRepositoryContext(compiler)
 
+	// Apply action pins maintained by upgrade-actions and the mirrors from the repository config
+	setupActionPinOverrides(config.RepoConfig)
+
+	// Apply the trust policy for imports from other repositories
+	setupRemoteImportPolicy(config.RepoConfig)
+
 	return compiler
 }
 
@@ -193,6 +210,43 @@ func setupRepositoryContext(compiler *workflow.Compiler) {
 	}
 }
 
+// setupActionPinOverrides loads .github/aw/action-pins.json from the repository root so that
+// pins refreshed by upgrade-actions take precedence over the pins embedded in gh-aw, and applies
+// the action-pins mirrors from the repository config
+func setupActionPinOverrides(repoConfig *workflow.RepoConfig) {
+	repoRoot, err := findGitRoot()
+	if err != nil {
+		repoRoot = "."
+	}
+	if err := workflow.LoadActionPinOverrides(repoRoot); err != nil {
+		compileCompilerSetupLog.Printf("Failed to load action pin overrides: %v", err)
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Ignoring action pin overrides: %v", err)))
+		workflow.SetActionPinOverrides(nil)
+	}
+
+	// Mirrors were validated when the repository config was loaded
+	var mirrors map[string]string
+	if repoConfig != nil {
+		mirrors = repoConfig.ActionPins
+	}
+	workflow.SetActionPinMirrors(mirrors)
+}
+
+// setupRemoteImportPolicy applies the remote-imports policies from the repository config:
+// require-pinned rejects remote imports without a full commit SHA, and lock verifies remote
+// content against .aw/imports.lock at the repository root
+func setupRemoteImportPolicy(repoConfig *workflow.RepoConfig) {
+	policy := parser.RemoteImportPolicy{RequirePinned: repoConfig.RemoteImportPolicyEnabled("require-pinned")}
+	if repoConfig.RemoteImportPolicyEnabled("lock") {
+		repoRoot, err := findGitRoot()
+		if err != nil {
+			repoRoot = "."
+		}
+		policy.LockFile = filepath.Join(repoRoot, parser.ImportLockPath)
+	}
+	parser.SetRemoteImportPolicy(policy)
+}
+
 // validateActionModeConfig validates the action mode configuration
 func validateActionModeConfig(actionMode string) error {
 	if actionMode == "" {
@@ -3,6 +3,7 @@ package cli
 import (
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/workflow"
 )
 
 var compileConfigLog = logger.New("cli:compile_config")
@@ -12,9 +13,14 @@ type CompileConfig struct {
 	MarkdownFiles          []string // Files to compile (empty for all files)
 	Verbose                bool     // Enable verbose output
 	EngineOverride         string   // Override AI engine setting
+	GitHubHost             string   // Override github-host setting (GitHub Enterprise hostname)
 	Validate               bool     // Enable schema validation
 	Watch                  bool     // Enable watch mode
 	WorkflowDir            string   // Custom workflow directory
+	OutputDir              string   // Directory lock files are written to (default: next to the markdown source)
+	LockFileName           string   // Lock file name pattern, e.g. {name}.lock.yml (overrides lock-file-name in .aw/config.yml)
+	Policy                 string   // Local organization policy file used instead of the published one
+	NoOrgPolicy            bool     // Compile without the organization policy (e.g. offline)
 	SkipInstructions       bool     // Deprecated: Instructions are no longer written during compilation
 	NoEmit                 bool     // Validate without generating lock files
 	Purge                  bool     // Remove orphaned lock files
@@ -33,6 +39,14 @@ type CompileConfig struct {
 	ActionTag              string   // Override action SHA or tag for actions/setup (overrides action-mode to release)
 	Stats                  bool     // Display statistics table sorted by file size
 	FailFast               bool     // Stop at first error instead of collecting all errors
+	ExplainProfile         bool     // Show how permission profiles expand after compilation
+	ExplainStrict          bool     // Show strict rule levels and the strict mode rules that fired
+	SplitScripts           bool     // Move generated helper files out of lock files into .github/aw/scripts/
+	ExpressionMap          bool     // Write the expression mapping table next to each lock file
+	VerifyMCP              bool     // Launch MCP servers and check that their allowed tool names are advertised
+
+	RepoConfig *workflow.RepoConfig // Repository defaults from .aw/config.yml (loaded by CompileWorkflows)
+	OrgPolicy  *workflow.OrgPolicy  // Organization policy enforced on every workflow (loaded by CompileWorkflows)
 }
 
 // WorkflowFailure represents a failed workflow with its error count
@@ -39,8 +39,6 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/github/gh-aw/pkg/stringutil"
-
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/workflow"
@@ -248,9 +246,9 @@ func compileModifiedFilesWithDependencies(compiler *workflow.Compiler, depGraph
 }
 
 // handleFileDeleted handles the deletion of a markdown file by removing its corresponding lock file
-func handleFileDeleted(mdFile string, verbose bool) {
+func handleFileDeleted(compiler *workflow.Compiler, mdFile string, verbose bool) {
 	// Regular workflow file - generate the corresponding lock file path
-	lockFile := stringutil.MarkdownToLockFile(mdFile)
+	lockFile := compiler.LockFilePath(mdFile)
 
 	// Check if the lock file exists and remove it
 	if _, err := os.Stat(lockFile); err == nil {
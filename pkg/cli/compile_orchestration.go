@@ -26,8 +26,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-
-	"github.com/github/gh-aw/pkg/stringutil"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
@@ -98,6 +99,9 @@ func compileSpecificFiles(
 			config.NoEmit, false, false, false, // Disable per-file security tools
 			config.Strict, shouldValidate,
 		)
+		if fileResult.success && config.VerifyMCP {
+			verifyMCPAllowedTools(&fileResult, config.Verbose, config.JSONOutput)
+		}
 
 		if !fileResult.success {
 			errorCount++
@@ -163,13 +167,23 @@ func compileSpecificFiles(
 	// Display schedule warnings
 	displayScheduleWarnings(compiler, config.JSONOutput)
 
+	// Display permission profile expansions if requested
+	if config.ExplainProfile {
+		displayProfileExpansions(compiler, config.JSONOutput)
+	}
+
+	// Display strict rule levels and findings if requested
+	if config.ExplainStrict {
+		displayStrictReports(compiler, config.JSONOutput)
+	}
+
 	// Post-processing
 	if err := runPostProcessing(compiler, workflowDataList, config, compiledCount); err != nil {
 		return workflowDataList, err
 	}
 
 	// Output results
-	if err := outputResults(stats, validationResults, config); err != nil {
+	if err := outputResults(compiler, stats, validationResults, config); err != nil {
 		return workflowDataList, err
 	}
 
@@ -227,7 +241,7 @@ func compileAllFilesInDirectory(
 	// Handle purge logic: collect existing files before compilation
 	var purgeData *purgeTrackingData
 	if config.Purge {
-		purgeData = collectPurgeData(workflowsDir, mdFiles, config.Verbose)
+		purgeData = collectPurgeData(compiler, workflowsDir, mdFiles, config.Verbose)
 	}
 
 	// Enable validation automatically when force-refresh-action-pins is used
@@ -253,6 +267,9 @@ func compileAllFilesInDirectory(
 			config.NoEmit, false, false, false, // Disable per-file security tools
 			config.Strict, shouldValidate,
 		)
+		if fileResult.success && config.VerifyMCP {
+			verifyMCPAllowedTools(&fileResult, config.Verbose, config.JSONOutput)
+		}
 
 		if !fileResult.success {
 			errorCount++
@@ -316,6 +333,16 @@ func compileAllFilesInDirectory(
 	// Display schedule warnings
 	displayScheduleWarnings(compiler, config.JSONOutput)
 
+	// Display permission profile expansions if requested
+	if config.ExplainProfile {
+		displayProfileExpansions(compiler, config.JSONOutput)
+	}
+
+	// Display strict rule levels and findings if requested
+	if config.ExplainStrict {
+		displayStrictReports(compiler, config.JSONOutput)
+	}
+
 	if config.Verbose {
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Successfully compiled %d out of %d workflow files", successCount, len(mdFiles))))
 	}
@@ -331,7 +358,7 @@ func compileAllFilesInDirectory(
 	}
 
 	// Output results
-	if err := outputResults(stats, validationResults, config); err != nil {
+	if err := outputResults(compiler, stats, validationResults, config); err != nil {
 		return workflowDataList, err
 	}
 
@@ -345,22 +372,23 @@ func compileAllFilesInDirectory(
 
 // purgeTrackingData holds data needed for purge operations
 type purgeTrackingData struct {
+	lockFileGlob         string
 	existingLockFiles    []string
 	existingInvalidFiles []string
 	expectedLockFiles    []string
 }
 
 // collectPurgeData collects existing files for purge operations
-func collectPurgeData(workflowsDir string, mdFiles []string, verbose bool) *purgeTrackingData {
-	data := &purgeTrackingData{}
+func collectPurgeData(compiler *workflow.Compiler, workflowsDir string, mdFiles []string, verbose bool) *purgeTrackingData {
+	data := &purgeTrackingData{lockFileGlob: compiler.LockFileGlob(workflowsDir)}
 
 	// Find all existing files
-	data.existingLockFiles, _ = filepath.Glob(filepath.Join(workflowsDir, "*.lock.yml"))
+	data.existingLockFiles, _ = filepath.Glob(data.lockFileGlob)
 	data.existingInvalidFiles, _ = filepath.Glob(filepath.Join(workflowsDir, "*.invalid.yml"))
 
 	// Create expected files list
 	for _, mdFile := range mdFiles {
-		lockFile := stringutil.MarkdownToLockFile(mdFile)
+		lockFile := compiler.LockFilePath(mdFile)
 		data.expectedLockFiles = append(data.expectedLockFiles, lockFile)
 	}
 
@@ -379,7 +407,7 @@ func collectPurgeData(workflowsDir string, mdFiles []string, verbose bool) *purg
 // runPurgeOperations runs all purge operations
 func runPurgeOperations(workflowsDir string, data *purgeTrackingData, verbose bool) {
 	// Errors from purge operations are logged but don't stop compilation
-	_ = purgeOrphanedLockFiles(workflowsDir, data.expectedLockFiles, verbose)
+	_ = purgeOrphanedLockFiles(data.lockFileGlob, data.expectedLockFiles, verbose)
 	_ = purgeInvalidFiles(workflowsDir, verbose)
 }
 
@@ -393,6 +421,72 @@ func displayScheduleWarnings(compiler *workflow.Compiler, jsonOutput bool) {
 	}
 }
 
+// displayProfileExpansions shows how each compiled workflow's permission profile expanded
+func displayProfileExpansions(compiler *workflow.Compiler, jsonOutput bool) {
+	if jsonOutput {
+		return
+	}
+	expansions := compiler.GetProfileExpansions()
+	if len(expansions) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No compiled workflows use a permission profile"))
+		return
+	}
+	for _, expansion := range expansions {
+		header := fmt.Sprintf("Profile '%s' in %s", expansion.Profile, filepath.Base(expansion.WorkflowPath))
+		if profile, ok := workflow.GetPermissionProfile(expansion.Profile); ok {
+			header += ": " + profile.Description
+		}
+		fmt.Fprintln(os.Stderr, console.FormatSectionHeader(header))
+		fmt.Fprint(os.Stderr, expansion.Expanded)
+		if len(expansion.Overrides) > 0 {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Overridden by frontmatter: "+strings.Join(expansion.Overrides, ", ")))
+		}
+	}
+}
+
+// displayStrictReports shows the strict rule levels of each compiled workflow and the rules that fired
+func displayStrictReports(compiler *workflow.Compiler, jsonOutput bool) {
+	if jsonOutput {
+		return
+	}
+	reports := compiler.GetStrictReports()
+	if len(reports) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No workflows were checked against strict mode rules"))
+		return
+	}
+	for _, report := range reports {
+		mode := "strict mode"
+		if !report.Strict {
+			mode = "non-strict mode"
+		}
+		fmt.Fprintln(os.Stderr, console.FormatSectionHeader(fmt.Sprintf("Strict rules for %s (%s)", filepath.Base(report.WorkflowPath), mode)))
+
+		config := console.TableConfig{Headers: []string{"Category", "Level", "Source", "Findings"}}
+		for _, category := range workflow.StrictRuleCategories {
+			source := "default"
+			if slices.Contains(report.Overrides, category) {
+				source = "strict-rules"
+			}
+			findings := 0
+			for _, finding := range report.Findings {
+				if finding.Category == category {
+					findings++
+				}
+			}
+			config.Rows = append(config.Rows, []string{category, string(report.Levels[category]), source, strconv.Itoa(findings)})
+		}
+		fmt.Fprint(os.Stderr, console.RenderTable(config))
+
+		if len(report.Findings) == 0 {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No strict mode rules fired"))
+			continue
+		}
+		for _, finding := range report.Findings {
+			fmt.Fprintln(os.Stderr, console.FormatListItem(fmt.Sprintf("[%s, %s] %s", finding.Category, finding.Level, finding.Message)))
+		}
+	}
+}
+
 // runPostProcessing runs post-processing for specific files compilation
 func runPostProcessing(
 	compiler *workflow.Compiler,
@@ -457,15 +551,20 @@ func runPostProcessingForDirectory(
 		}
 	}
 
-	// Generate maintenance workflow if needed
-	// Skip maintenance workflow generation when using custom --dir option
-	if !config.NoEmit && config.WorkflowDir == "" {
+	// Generate maintenance and warm cache workflows if needed
+	// Skip their generation when using custom --dir or --output-dir options
+	if !config.NoEmit && config.WorkflowDir == "" && config.OutputDir == "" {
 		absWorkflowDir := getAbsoluteWorkflowDir(workflowsDir, gitRoot)
 		if err := generateMaintenanceWorkflowWrapper(compiler, workflowDataList, absWorkflowDir, config.Verbose, config.Strict); err != nil {
 			if config.Strict {
 				return err
 			}
 		}
+		if err := generateWarmCacheWorkflowWrapper(compiler, workflowDataList, absWorkflowDir, config.Verbose, config.Strict); err != nil {
+			if config.Strict {
+				return err
+			}
+		}
 	}
 
 	// Save action cache (errors are logged but non-fatal)
@@ -476,6 +575,7 @@ func runPostProcessingForDirectory(
 
 // outputResults outputs compilation results in the requested format
 func outputResults(
+	compiler *workflow.Compiler,
 	stats *CompilationStats,
 	validationResults *[]ValidationResult,
 	config CompileConfig,
@@ -484,7 +584,7 @@ func outputResults(
 	if config.Stats && !config.NoEmit && !config.JSONOutput {
 		var statsList []*WorkflowStats
 		if len(config.MarkdownFiles) > 0 {
-			statsList = collectWorkflowStatisticsWrapper(config.MarkdownFiles)
+			statsList = collectWorkflowStatisticsWrapper(compiler, config.MarkdownFiles)
 		}
 		displayStatsTable(statsList)
 	}
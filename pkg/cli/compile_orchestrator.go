@@ -36,6 +36,30 @@ func CompileWorkflows(ctx context.Context, config CompileConfig) ([]*workflow.Wo
 		return nil, err
 	}
 
+	// Load repository defaults and enable the linters they request
+	if config.RepoConfig == nil {
+		repoConfig, err := loadRepoConfig()
+		if err != nil {
+			return nil, err
+		}
+		config.RepoConfig = repoConfig
+	}
+	if err := applyMessageCatalog(config.RepoConfig); err != nil {
+		return nil, err
+	}
+	config.Actionlint = config.Actionlint || config.RepoConfig.LintEnabled("actionlint")
+	config.Zizmor = config.Zizmor || config.RepoConfig.LintEnabled("zizmor")
+	config.Poutine = config.Poutine || config.RepoConfig.LintEnabled("poutine")
+
+	// Load the organization policy every workflow must satisfy
+	if config.OrgPolicy == nil {
+		orgPolicy, err := loadOrgPolicy(config.Policy, config.NoOrgPolicy, config.Verbose)
+		if err != nil {
+			return nil, err
+		}
+		config.OrgPolicy = orgPolicy
+	}
+
 	// Initialize actionlint statistics if actionlint is enabled
 	if config.Actionlint && !config.NoEmit {
 		initActionlintStats()
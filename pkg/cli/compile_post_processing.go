@@ -1,7 +1,7 @@
 // This file provides post-processing operations for workflow compilation.
 //
 // This file contains functions that perform post-compilation operations such as
-// generating Dependabot manifests and maintenance workflows.
+// generating Dependabot manifests, maintenance and warm cache workflows.
 //
 // # Organization Rationale
 //
@@ -16,6 +16,7 @@
 // Generation:
 //   - generateDependabotManifestsWrapper() - Generate Dependabot manifests
 //   - generateMaintenanceWorkflowWrapper() - Generate maintenance workflow
+//   - generateWarmCacheWorkflowWrapper() - Generate warm cache workflow
 //
 // Statistics:
 //   - collectWorkflowStatisticsWrapper() - Collect workflow statistics
@@ -32,7 +33,6 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
-	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -80,8 +80,29 @@ func generateMaintenanceWorkflowWrapper(
 	return nil
 }
 
+// generateWarmCacheWorkflowWrapper generates warm cache workflow if any workflow sets warm-cache
+func generateWarmCacheWorkflowWrapper(
+	compiler *workflow.Compiler,
+	workflowDataList []*workflow.WorkflowData,
+	workflowsDir string,
+	verbose bool,
+	strict bool,
+) error {
+	compilePostProcessingLog.Print("Generating warm cache workflow")
+
+	if err := workflow.GenerateWarmCacheWorkflow(workflowDataList, workflowsDir, compiler.GetVersion(), compiler.GetActionMode(), compiler.GetActionTag(), verbose); err != nil {
+		if strict {
+			return fmt.Errorf("failed to generate warm cache workflow: %w", err)
+		}
+		// Non-strict mode: just report as warning
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to generate warm cache workflow: %v", err)))
+	}
+
+	return nil
+}
+
 // collectWorkflowStatisticsWrapper collects and returns workflow statistics
-func collectWorkflowStatisticsWrapper(markdownFiles []string) []*WorkflowStats {
+func collectWorkflowStatisticsWrapper(compiler *workflow.Compiler, markdownFiles []string) []*WorkflowStats {
 	compilePostProcessingLog.Printf("Collecting workflow statistics for %d files", len(markdownFiles))
 
 	var statsList []*WorkflowStats
@@ -90,7 +111,7 @@ func collectWorkflowStatisticsWrapper(markdownFiles []string) []*WorkflowStats {
 		if err != nil {
 			continue // Skip files that couldn't be resolved
 		}
-		lockFile := stringutil.MarkdownToLockFile(resolvedFile)
+		lockFile := compiler.LockFilePath(resolvedFile)
 		if workflowStats, err := collectWorkflowStats(lockFile); err == nil {
 			statsList = append(statsList, workflowStats)
 		}
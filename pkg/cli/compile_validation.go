@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 
 	"github.com/github/gh-aw/pkg/logger"
-	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/workflow"
 	"github.com/goccy/go-yaml"
 )
@@ -65,7 +64,7 @@ func CompileWorkflowWithValidation(compiler *workflow.Compiler, filePath string,
 	}
 
 	// Always validate that the generated lock file is valid YAML (CLI requirement)
-	lockFile := stringutil.MarkdownToLockFile(filePath)
+	lockFile := compiler.LockFilePath(filePath)
 	if _, err := os.Stat(lockFile); err != nil {
 		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit mode)")
 		// Lock file doesn't exist (likely due to no-emit), skip YAML validation
@@ -133,7 +132,7 @@ func CompileWorkflowDataWithValidation(compiler *workflow.Compiler, workflowData
 	}
 
 	// Always validate that the generated lock file is valid YAML (CLI requirement)
-	lockFile := stringutil.MarkdownToLockFile(filePath)
+	lockFile := compiler.LockFilePath(filePath)
 	if _, err := os.Stat(lockFile); err != nil {
 		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit mode)")
 		// Lock file doesn't exist (likely due to no-emit), skip YAML validation
@@ -204,6 +203,10 @@ func validateCompileConfig(config CompileConfig) error {
 			compileValidationLog.Printf("Config validation failed: dependabot with custom dir: %s", config.WorkflowDir)
 			return errors.New("--dependabot flag cannot be used with custom --dir")
 		}
+		if config.OutputDir != "" {
+			compileValidationLog.Printf("Config validation failed: dependabot with output dir: %s", config.OutputDir)
+			return errors.New("--dependabot flag cannot be used with --output-dir")
+		}
 	}
 
 	// Validate purge flag usage
@@ -218,6 +221,14 @@ func validateCompileConfig(config CompileConfig) error {
 		return fmt.Errorf("--dir must be a relative path, got: %s", config.WorkflowDir)
 	}
 
+	// Validate lock file name pattern
+	if config.LockFileName != "" {
+		if err := workflow.ValidateLockFileNamePattern(config.LockFileName); err != nil {
+			compileValidationLog.Printf("Config validation failed: %v", err)
+			return fmt.Errorf("--lock-file-name: %w", err)
+		}
+	}
+
 	compileValidationLog.Print("Config validation successful")
 	return nil
 }
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var compileVerifyMCPLog = logger.New("cli:compile_verify_mcp")
+
+// verifyMCPAllowedTools implements compile --verify-mcp. It launches every MCP server of a
+// compiled workflow that restricts its tools with allowed:, and marks the compilation as failed
+// when an allowed tool name is not advertised by the server. Servers that cannot be started,
+// for example because a secret is only available in Actions, are reported as warnings.
+func verifyMCPAllowedTools(fileResult *compileWorkflowFileResult, verbose bool, jsonOutput bool) {
+	if fileResult.workflowData == nil {
+		return
+	}
+
+	mcpConfigs, err := parser.ExtractMCPConfigurations(workflowMCPFrontmatter(fileResult.workflowData), "")
+	if err != nil {
+		compileVerifyMCPLog.Printf("Failed to extract MCP configurations: %v", err)
+		return
+	}
+
+	for _, config := range filterOutSafeOutputs(mcpConfigs) {
+		// GitHub tool names are validated against the known toolsets during compilation
+		if config.Name == "github" || len(config.Allowed) == 0 || slices.Contains(config.Allowed, "*") {
+			continue
+		}
+
+		compileVerifyMCPLog.Printf("Verifying %d allowed tools of MCP server %s", len(config.Allowed), config.Name)
+		if verbose && !jsonOutput {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Verifying allowed tools of MCP server '%s' (%s)", config.Name, config.Type)))
+		}
+
+		info, err := connectToMCPServer(config, verbose && !jsonOutput)
+		if err != nil {
+			fileResult.validationResult.Warnings = append(fileResult.validationResult.Warnings, CompileValidationError{
+				Type:    "mcp_verification_warning",
+				Message: fmt.Sprintf("could not start MCP server '%s' to verify its allowed tools: %v", config.Name, err),
+			})
+			if !jsonOutput {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not start MCP server '%s' to verify its allowed tools: %v", config.Name, err)))
+			}
+			continue
+		}
+
+		for _, message := range findUnadvertisedMCPTools(config, info) {
+			fileResult.success = false
+			fileResult.validationResult.Valid = false
+			fileResult.validationResult.Errors = append(fileResult.validationResult.Errors, CompileValidationError{
+				Type:    "mcp_tool_error",
+				Message: message,
+			})
+		}
+	}
+}
+
+// workflowMCPFrontmatter rebuilds the tools and mcp-servers sections read by
+// parser.ExtractMCPConfigurations from the merged tools of a compiled workflow,
+// which also hold the custom MCP servers of the workflow and its imports
+func workflowMCPFrontmatter(workflowData *workflow.WorkflowData) map[string]any {
+	tools := make(map[string]any)
+	mcpServers := make(map[string]any)
+	for name, value := range workflowData.Tools {
+		serverConfig, ok := value.(map[string]any)
+		if ok && name != "github" && name != "playwright" && name != "serena" && isCustomMCPServerConfig(serverConfig) {
+			mcpServers[name] = serverConfig
+			continue
+		}
+		tools[name] = value
+	}
+	return map[string]any{"tools": tools, "mcp-servers": mcpServers}
+}
+
+// isCustomMCPServerConfig reports whether a tool configuration describes how to run or reach an MCP server
+func isCustomMCPServerConfig(toolConfig map[string]any) bool {
+	for _, key := range []string{"command", "container", "url"} {
+		if _, ok := toolConfig[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// findUnadvertisedMCPTools returns one error message for each allowed tool of an MCP server
+// that is missing from the tools the server advertises, suggesting the closest tool names
+func findUnadvertisedMCPTools(config parser.MCPServerConfig, info *parser.MCPServerInfo) []string {
+	advertised := make([]string, 0, len(info.Tools))
+	for _, tool := range info.Tools {
+		advertised = append(advertised, tool.Name)
+	}
+
+	var messages []string
+	for _, allowed := range sliceutil.Deduplicate(config.Allowed) {
+		if slices.Contains(advertised, allowed) {
+			continue
+		}
+		message := fmt.Sprintf("MCP server '%s' does not advertise allowed tool '%s'", config.Name, allowed)
+		if suggestions := parser.FindClosestMatches(allowed, advertised, 3); len(suggestions) > 0 {
+			message += fmt.Sprintf(". Did you mean: %s?", strings.Join(suggestions, ", "))
+		} else if len(advertised) > 0 {
+			sorted := slices.Clone(advertised)
+			slices.Sort(sorted)
+			message += ". Available tools: " + strings.Join(sorted, ", ")
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
@@ -0,0 +1,76 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindUnadvertisedMCPTools(t *testing.T) {
+	info := &parser.MCPServerInfo{Tools: []*mcp.Tool{{Name: "search_nodes"}, {Name: "create_entities"}, {Name: "read_graph"}}}
+
+	tests := []struct {
+		name     string
+		allowed  []string
+		expected []string
+	}{
+		{
+			name:    "all tools advertised",
+			allowed: []string{"create_entities", "search_nodes"},
+		},
+		{
+			name:     "typo with suggestion",
+			allowed:  []string{"create_entities", "search_node"},
+			expected: []string{"MCP server 'memory' does not advertise allowed tool 'search_node'. Did you mean: search_nodes?"},
+		},
+		{
+			name:     "unknown tool lists available tools",
+			allowed:  []string{"delete_everything", "delete_everything"},
+			expected: []string{"MCP server 'memory' does not advertise allowed tool 'delete_everything'. Available tools: create_entities, read_graph, search_nodes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := findUnadvertisedMCPTools(parser.MCPServerConfig{Name: "memory", Allowed: tt.allowed}, info)
+			assert.Equal(t, tt.expected, messages, "unadvertised tool messages")
+		})
+	}
+}
+
+func TestVerifyMCPAllowedToolsServerUnavailable(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "verify-mcp-*")
+	workflowPath := filepath.Join(tmpDir, "verify.md")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+mcp-servers:
+  memory:
+    command: /nonexistent/memory-server
+    allowed: [create_entities, search_nodes]
+---
+
+Remember things.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	workflowData, err := workflow.NewCompiler().ParseWorkflowFile(workflowPath)
+	require.NoError(t, err, "workflow should parse")
+
+	fileResult := compileWorkflowFileResult{workflowData: workflowData, success: true, validationResult: ValidationResult{Valid: true}}
+	verifyMCPAllowedTools(&fileResult, false, true)
+
+	assert.True(t, fileResult.success, "a server that cannot be started should not fail compilation")
+	require.Len(t, fileResult.validationResult.Warnings, 1, "a warning should be recorded")
+	assert.Contains(t, fileResult.validationResult.Warnings[0].Message, "could not start MCP server 'memory'", "warning message")
+}
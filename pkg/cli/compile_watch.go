@@ -185,7 +185,7 @@ func watchAndCompileWorkflows(markdownFile string, compiler *workflow.Compiler,
 			switch {
 			case event.Has(fsnotify.Remove):
 				// Handle file deletion
-				handleFileDeleted(event.Name, verbose)
+				handleFileDeleted(compiler, event.Name, verbose)
 				// Remove from dependency graph
 				depGraph.RemoveWorkflow(event.Name)
 			case event.Has(fsnotify.Write) || event.Has(fsnotify.Create):
@@ -29,7 +29,6 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
-	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -70,7 +69,7 @@ func compileWorkflowFile(
 	}
 
 	// Generate lock file name
-	lockFile := stringutil.MarkdownToLockFile(resolvedFile)
+	lockFile := compiler.LockFilePath(resolvedFile)
 	result.lockFile = lockFile
 	if !noEmit {
 		result.validationResult.CompiledFile = lockFile
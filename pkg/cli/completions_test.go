@@ -223,7 +223,7 @@ func TestValidEngineNames(t *testing.T) {
 	assert.NotEmpty(t, engines, "Engine names list should not be empty")
 
 	// Verify expected engines are present
-	expectedEngines := []string{"copilot", "claude", "codex", "gemini"}
+	expectedEngines := []string{"copilot", "claude", "codex", "gemini", "github-models", "ollama"}
 	for _, expected := range expectedEngines {
 		assert.Contains(t, engines, expected, "Expected engine '%s' to be in the list", expected)
 	}
@@ -240,7 +240,7 @@ func TestCompleteEngineNames(t *testing.T) {
 		{
 			name:       "empty prefix returns all engines",
 			toComplete: "",
-			wantLen:    4, // copilot, claude, codex, gemini
+			wantLen:    6, // copilot, claude, codex, gemini, github-models, ollama
 		},
 		{
 			name:       "c prefix returns claude, codex, copilot",
@@ -0,0 +1,340 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/messages"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var configCommandLog = logger.New("cli:config_command")
+
+// repoConfigKeys lists the keys accepted by `config get` and `config set`, in display order
+var repoConfigKeys = []string{"engine", "strict", "strict-rules", "catalogs", "lint", "artifact-retention-days", "messages", "lock-file-name", "action-pins", "health", "remote-imports"}
+
+// NewConfigCommand creates the config command with get and set subcommands
+func NewConfigCommand() *cobra.Command {
+	configCommandLog.Print("Creating config command with subcommands")
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Read and write repository defaults in " + workflow.RepoConfigPath,
+		Long: `Read and write repository-level defaults stored in ` + workflow.RepoConfigPath + `.
+
+The compiler applies these defaults beneath workflow frontmatter: a value set in a
+workflow (or by a CLI flag) always wins over the repository default.
+
+Available keys:
+  • engine                  - Default AI engine for workflows without 'engine:'
+  • strict                  - Default strict mode for workflows without 'strict:'
+  • strict-rules            - Comma-separated category=level list (levels: off, warn, error)
+  • catalogs                - Comma-separated owner/repo list searched by 'add <workflow-name>'
+  • lint                    - Comma-separated scanners compile runs by default (actionlint, zizmor, poutine)
+  • artifact-retention-days - Retention in days for uploaded agent artifacts
+  • messages                - Message catalog file overriding compiler diagnostics (e.g., .aw/messages/de.yml)
+  • action-pins             - Comma-separated action=mirror@sha list replacing actions with pinned mirrors
+  • health                  - Comma-separated metric=threshold list used by 'status --health'
+  • remote-imports          - Comma-separated trust policies for imports from other repositories (require-pinned, lock)
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` config get engine
+  ` + string(constants.CLIExtensionPrefix) + ` config set engine claude
+  ` + string(constants.CLIExtensionPrefix) + ` config set lint actionlint,zizmor
+  ` + string(constants.CLIExtensionPrefix) + ` config set strict-rules network=warn,pinning=error
+  ` + string(constants.CLIExtensionPrefix) + ` config set catalogs ""          # Clear a value`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newConfigGetSubcommand())
+	cmd.AddCommand(newConfigSetSubcommand())
+
+	return cmd
+}
+
+func newConfigGetSubcommand() *cobra.Command {
+	return &cobra.Command{
+		Use:       "get [key]",
+		Short:     "Print a repository default, or all of them when no key is given",
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: repoConfigKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			gitRoot, err := findGitRoot()
+			if err != nil {
+				return errors.New("config requires a git repository")
+			}
+			config, err := workflow.LoadRepoConfig(gitRoot)
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				for _, key := range repoConfigKeys {
+					value, _ := getRepoConfigValue(config, key)
+					fmt.Fprintf(os.Stdout, "%s: %s\n", key, value)
+				}
+				return nil
+			}
+
+			value, err := getRepoConfigValue(config, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(os.Stdout, value)
+			return nil
+		},
+	}
+}
+
+func newConfigSetSubcommand() *cobra.Command {
+	return &cobra.Command{
+		Use:       "set <key> <value>",
+		Short:     "Set a repository default (an empty value clears it)",
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: repoConfigKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			gitRoot, err := findGitRoot()
+			if err != nil {
+				return errors.New("config requires a git repository")
+			}
+			config, err := workflow.LoadRepoConfig(gitRoot)
+			if err != nil {
+				return err
+			}
+
+			if err := setRepoConfigValue(config, args[0], args[1]); err != nil {
+				return err
+			}
+			if err := config.Save(gitRoot); err != nil {
+				return err
+			}
+
+			configCommandLog.Printf("Set %s=%q", args[0], args[1])
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Set %s in %s", args[0], workflow.RepoConfigPath)))
+			return nil
+		},
+	}
+}
+
+// getRepoConfigValue formats a single configuration value for display.
+// Unset values are returned as an empty string.
+func getRepoConfigValue(config *workflow.RepoConfig, key string) (string, error) {
+	switch key {
+	case "engine":
+		return config.Engine, nil
+	case "strict":
+		if config.Strict == nil {
+			return "", nil
+		}
+		return strconv.FormatBool(*config.Strict), nil
+	case "strict-rules":
+		var rules []string
+		for _, category := range workflow.StrictRuleCategories {
+			if level, ok := config.StrictRules[category]; ok {
+				rules = append(rules, category+"="+level)
+			}
+		}
+		return strings.Join(rules, ","), nil
+	case "catalogs":
+		return strings.Join(config.Catalogs, ","), nil
+	case "lint":
+		return strings.Join(config.Lint, ","), nil
+	case "artifact-retention-days":
+		if config.ArtifactRetentionDays == 0 {
+			return "", nil
+		}
+		return strconv.Itoa(config.ArtifactRetentionDays), nil
+	case "messages":
+		return config.Messages, nil
+	case "lock-file-name":
+		return config.LockFileName, nil
+	case "action-pins":
+		actions := make([]string, 0, len(config.ActionPins))
+		for action := range config.ActionPins {
+			actions = append(actions, action)
+		}
+		sort.Strings(actions)
+		pins := make([]string, 0, len(actions))
+		for _, action := range actions {
+			pins = append(pins, action+"="+config.ActionPins[action])
+		}
+		return strings.Join(pins, ","), nil
+	case "health":
+		if config.Health == nil {
+			return "", nil
+		}
+		var thresholds []string
+		for _, metric := range workflow.HealthThresholdKeys {
+			if value := *healthThresholdField(config.Health, metric); value > 0 {
+				thresholds = append(thresholds, metric+"="+strconv.FormatFloat(value, 'f', -1, 64))
+			}
+		}
+		return strings.Join(thresholds, ","), nil
+	case "remote-imports":
+		return strings.Join(config.RemoteImports, ","), nil
+	default:
+		return "", unknownRepoConfigKeyError(key)
+	}
+}
+
+// setRepoConfigValue parses and stores a single configuration value.
+// An empty value clears the key. The result is validated when saved.
+func setRepoConfigValue(config *workflow.RepoConfig, key string, value string) error {
+	switch key {
+	case "engine":
+		config.Engine = value
+	case "strict":
+		if value == "" {
+			config.Strict = nil
+			return nil
+		}
+		strict, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("strict: expected true or false, got '%s'", value)
+		}
+		config.Strict = &strict
+	case "strict-rules":
+		config.StrictRules = nil
+		for _, rule := range splitConfigList(value) {
+			category, level, ok := strings.Cut(rule, "=")
+			if !ok {
+				return fmt.Errorf("strict-rules: expected category=level, got '%s'", rule)
+			}
+			if config.StrictRules == nil {
+				config.StrictRules = make(map[string]string)
+			}
+			config.StrictRules[strings.TrimSpace(category)] = strings.TrimSpace(level)
+		}
+	case "catalogs":
+		config.Catalogs = splitConfigList(value)
+	case "lint":
+		config.Lint = splitConfigList(value)
+	case "artifact-retention-days":
+		if value == "" {
+			config.ArtifactRetentionDays = 0
+			return nil
+		}
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 1 {
+			return fmt.Errorf("artifact-retention-days: expected a positive number of days, got '%s'", value)
+		}
+		config.ArtifactRetentionDays = days
+	case "messages":
+		config.Messages = value
+	case "lock-file-name":
+		config.LockFileName = value
+	case "action-pins":
+		config.ActionPins = nil
+		for _, pin := range splitConfigList(value) {
+			action, mirror, ok := strings.Cut(pin, "=")
+			if !ok {
+				return fmt.Errorf("action-pins: expected action=mirror@sha, got '%s'", pin)
+			}
+			if config.ActionPins == nil {
+				config.ActionPins = make(map[string]string)
+			}
+			config.ActionPins[strings.TrimSpace(action)] = strings.TrimSpace(mirror)
+		}
+	case "health":
+		config.Health = nil
+		for _, threshold := range splitConfigList(value) {
+			metric, number, ok := strings.Cut(threshold, "=")
+			if !ok {
+				return fmt.Errorf("health: expected metric=threshold, got '%s'", threshold)
+			}
+			if config.Health == nil {
+				config.Health = &workflow.HealthThresholds{}
+			}
+			field := healthThresholdField(config.Health, strings.TrimSpace(metric))
+			if field == nil {
+				return fmt.Errorf("health: unknown metric '%s'. Valid metrics: %s", strings.TrimSpace(metric), strings.Join(workflow.HealthThresholdKeys, ", "))
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+			if err != nil {
+				return fmt.Errorf("health: expected a number for %s, got '%s'", strings.TrimSpace(metric), number)
+			}
+			*field = parsed
+		}
+	case "remote-imports":
+		config.RemoteImports = splitConfigList(value)
+	default:
+		return unknownRepoConfigKeyError(key)
+	}
+	return nil
+}
+
+// healthThresholdField returns the threshold stored under a health: key, or nil for an unknown key
+func healthThresholdField(thresholds *workflow.HealthThresholds, metric string) *float64 {
+	switch metric {
+	case "failure-rate":
+		return &thresholds.FailureRate
+	case "timeout-rate":
+		return &thresholds.TimeoutRate
+	case "avg-cost":
+		return &thresholds.AvgCost
+	case "sanitizer-rejections":
+		return &thresholds.SanitizerRejections
+	default:
+		return nil
+	}
+}
+
+// splitConfigList splits a comma-separated value, dropping empty entries
+func splitConfigList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func unknownRepoConfigKeyError(key string) error {
+	return fmt.Errorf("unknown config key '%s'. Valid keys: %s", key, strings.Join(repoConfigKeys, ", "))
+}
+
+// loadRepoConfig loads the repository defaults for the current git repository.
+// Outside a git repository it returns an empty configuration.
+func loadRepoConfig() (*workflow.RepoConfig, error) {
+	gitRoot, err := findGitRoot()
+	if err != nil {
+		configCommandLog.Printf("Not in a git repository, using empty repo config: %v", err)
+		return &workflow.RepoConfig{}, nil
+	}
+	return workflow.LoadRepoConfig(gitRoot)
+}
+
+// applyMessageCatalog activates the diagnostic message overrides named by the
+// GH_AW_MESSAGES environment variable or, failing that, the messages: key of the
+// repository configuration. Without either, the English defaults are used.
+func applyMessageCatalog(config *workflow.RepoConfig) error {
+	path := os.Getenv(messages.CatalogEnvVar)
+	if path == "" && config != nil && config.Messages != "" {
+		gitRoot, err := findGitRoot()
+		if err != nil {
+			return fmt.Errorf("messages: %w", err)
+		}
+		path = filepath.Join(gitRoot, config.Messages)
+	}
+	if path == "" {
+		return messages.SetOverrides(nil)
+	}
+
+	configCommandLog.Printf("Loading message catalog: %s", path)
+	catalog, err := messages.LoadCatalogFile(path)
+	if err != nil {
+		return err
+	}
+	return messages.SetOverrides(catalog)
+}
@@ -0,0 +1,106 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAndGetRepoConfigValue(t *testing.T) {
+	tests := []struct {
+		key      string
+		value    string
+		expected string
+	}{
+		{key: "engine", value: "claude", expected: "claude"},
+		{key: "strict", value: "false", expected: "false"},
+		{key: "catalogs", value: "githubnext/agentics, myorg/workflows", expected: "githubnext/agentics,myorg/workflows"},
+		{key: "lint", value: "actionlint,zizmor", expected: "actionlint,zizmor"},
+		{key: "artifact-retention-days", value: "14", expected: "14"},
+		{key: "strict-rules", value: "pinning=error, network=warn", expected: "network=warn,pinning=error"},
+		{key: "messages", value: ".aw/messages/de.yml", expected: ".aw/messages/de.yml"},
+		{key: "lock-file-name", value: "aw-{name}.yml", expected: "aw-{name}.yml"},
+		{
+			key:      "action-pins",
+			value:    "actions/setup-node=my-org/setup-node@1111111111111111111111111111111111111111, actions/checkout=my-org/checkout@2222222222222222222222222222222222222222",
+			expected: "actions/checkout=my-org/checkout@2222222222222222222222222222222222222222,actions/setup-node=my-org/setup-node@1111111111111111111111111111111111111111",
+		},
+		{key: "remote-imports", value: "require-pinned, lock", expected: "require-pinned,lock"},
+		{key: "health", value: "avg-cost=0.5, failure-rate=10", expected: "failure-rate=10,avg-cost=0.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			config := &workflow.RepoConfig{}
+			require.NoError(t, setRepoConfigValue(config, tt.key, tt.value), "value should be accepted")
+			require.NoError(t, config.Validate(), "config should be valid")
+
+			value, err := getRepoConfigValue(config, tt.key)
+			require.NoError(t, err, "value should be readable")
+			assert.Equal(t, tt.expected, value, "value should round-trip")
+
+			require.NoError(t, setRepoConfigValue(config, tt.key, ""), "empty value should clear the key")
+			value, err = getRepoConfigValue(config, tt.key)
+			require.NoError(t, err, "cleared value should be readable")
+			assert.Empty(t, value, "cleared value should be empty")
+		})
+	}
+}
+
+func TestSetRepoConfigValueErrors(t *testing.T) {
+	config := &workflow.RepoConfig{}
+
+	err := setRepoConfigValue(config, "engines", "claude")
+	require.Error(t, err, "unknown keys should be rejected")
+	assert.Contains(t, err.Error(), "Valid keys: engine, strict", "error should list valid keys")
+
+	err = setRepoConfigValue(config, "strict", "maybe")
+	require.Error(t, err, "non-boolean strict should be rejected")
+
+	err = setRepoConfigValue(config, "artifact-retention-days", "0")
+	require.Error(t, err, "zero retention should be rejected")
+
+	err = setRepoConfigValue(config, "strict-rules", "network")
+	require.Error(t, err, "strict rules without a level should be rejected")
+
+	err = setRepoConfigValue(config, "health", "cost=1")
+	require.Error(t, err, "unknown health metrics should be rejected")
+	assert.Contains(t, err.Error(), "Valid metrics: failure-rate", "error should list valid metrics")
+
+	require.NoError(t, setRepoConfigValue(config, "strict-rules", "network=loud"), "levels are checked on validation")
+	require.Error(t, config.Validate(), "unknown strict rule levels should be rejected")
+
+	config = &workflow.RepoConfig{}
+	require.NoError(t, setRepoConfigValue(config, "messages", "../messages.yml"), "paths are checked on validation")
+	require.Error(t, config.Validate(), "catalogs outside the repository should be rejected")
+}
+
+func TestIsBareWorkflowName(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected bool
+	}{
+		{spec: "ci-doctor", expected: true},
+		{spec: "ci-doctor@v1.0.0", expected: true},
+		{spec: "githubnext/agentics/ci-doctor", expected: false},
+		{spec: "./ci-doctor.md", expected: false},
+		{spec: "ci-doctor.md", expected: false},
+		{spec: "https://github.com/githubnext/agentics/blob/main/workflows/ci-doctor.md", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isBareWorkflowName(tt.spec), "bare name detection should match")
+		})
+	}
+}
+
+func TestExpandCatalogWorkflowSpecSingleCatalog(t *testing.T) {
+	expanded, err := expandCatalogWorkflowSpec("ci-doctor@v1", []string{"githubnext/agentics"}, false)
+	require.NoError(t, err, "single catalog should expand without lookups")
+	assert.Equal(t, "githubnext/agentics/ci-doctor@v1", expanded, "name should be prefixed with the catalog")
+}
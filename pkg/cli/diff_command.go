@@ -0,0 +1,371 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+var diffCommandLog = logger.New("cli:diff_command")
+
+// diffSections lists the compared sections in display order
+var diffSections = []string{"Engine", "Triggers", "Permissions", "Jobs", "Tools", "Safe outputs", "Network"}
+
+// DiffConfig holds configuration for the diff command
+type DiffConfig struct {
+	BaseWorkflow string // Workflow ID or path for the base side
+	HeadWorkflow string // Workflow ID or path for the head side (defaults to BaseWorkflow)
+	BaseRef      string // Git ref to read the base workflow from (empty for the working tree)
+	HeadRef      string // Git ref to read the head workflow from (empty for the working tree)
+	JSON         bool
+	Verbose      bool
+}
+
+// DiffChange is a single semantic difference between two compiled workflows
+type DiffChange struct {
+	Section string `json:"section"`
+	Kind    string `json:"kind"` // "added", "removed", or "changed"
+	Key     string `json:"key"`
+	Before  string `json:"before,omitempty"`
+	After   string `json:"after,omitempty"`
+}
+
+// WorkflowDiff is the semantic difference between a base and a head workflow
+type WorkflowDiff struct {
+	Base    string       `json:"base"`
+	Head    string       `json:"head"`
+	Changes []DiffChange `json:"changes"`
+}
+
+// workflowSnapshot holds the reviewable parts of a compiled workflow, keyed per section
+type workflowSnapshot map[string]map[string]string
+
+// NewDiffCommand creates the diff command
+func NewDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <workflow> [other-workflow]",
+		Short: "Show a semantic diff between two compiled agentic workflows",
+		Long: `Compile two versions of an agentic workflow in memory and show what changed in
+the resulting GitHub Actions workflow: engine, triggers, job permissions, jobs,
+tools, safe outputs, and allowed network domains.
+
+Compare two workflow files, or the same workflow at two git refs with --base and
+--head. A side without a ref is read from the working tree. Imports are always
+resolved from the working tree. Nothing is written to disk.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` diff issue-triage issue-triage-v2        # Compare two workflows
+  ` + string(constants.CLIExtensionPrefix) + ` diff issue-triage --base main            # Working tree against main
+  ` + string(constants.CLIExtensionPrefix) + ` diff issue-triage --base v1.0 --head v1.1  # Compare two refs
+  ` + string(constants.CLIExtensionPrefix) + ` diff issue-triage --base HEAD~1 --json    # Output changes as JSON`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			baseRef, _ := cmd.Flags().GetString("base")
+			headRef, _ := cmd.Flags().GetString("head")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			config := DiffConfig{
+				BaseWorkflow: args[0],
+				HeadWorkflow: args[0],
+				BaseRef:      baseRef,
+				HeadRef:      headRef,
+				JSON:         jsonOutput,
+				Verbose:      verbose,
+			}
+			if len(args) == 2 {
+				config.HeadWorkflow = args[1]
+			}
+			return RunDiff(config)
+		},
+	}
+
+	cmd.Flags().String("base", "", "Git ref to read the base workflow from (default: working tree)")
+	cmd.Flags().String("head", "", "Git ref to read the head workflow from (default: working tree)")
+	addJSONFlag(cmd)
+	cmd.ValidArgsFunction = CompleteWorkflowNames
+
+	return cmd
+}
+
+// RunDiff compiles both sides of the comparison and prints their semantic diff
+func RunDiff(config DiffConfig) error {
+	diffCommandLog.Printf("Diffing workflows: base=%s@%s, head=%s@%s", config.BaseWorkflow, config.BaseRef, config.HeadWorkflow, config.HeadRef)
+
+	if config.BaseWorkflow == config.HeadWorkflow && config.BaseRef == config.HeadRef {
+		return errors.New("nothing to compare: pass a second workflow or a git ref with --base or --head")
+	}
+
+	baseLabel, base, err := loadDiffSnapshot(config.BaseWorkflow, config.BaseRef, config.Verbose)
+	if err != nil {
+		return err
+	}
+	headLabel, head, err := loadDiffSnapshot(config.HeadWorkflow, config.HeadRef, config.Verbose)
+	if err != nil {
+		return err
+	}
+
+	result := WorkflowDiff{
+		Base:    baseLabel,
+		Head:    headLabel,
+		Changes: diffSnapshots(base, head),
+	}
+
+	if config.JSON {
+		jsonBytes, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal diff: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	fmt.Print(renderWorkflowDiff(result))
+	return nil
+}
+
+// loadDiffSnapshot reads a workflow from the working tree or a git ref, compiles it in
+// memory, and returns a display label with its snapshot
+func loadDiffSnapshot(workflowName, ref string, verbose bool) (string, workflowSnapshot, error) {
+	markdownPath, err := resolveWorkflowFile(workflowName, verbose)
+	if err != nil {
+		if ref == "" {
+			return "", nil, err
+		}
+		// The workflow may not exist in the working tree at all, e.g. when it was deleted
+		markdownPath = workflowName
+		if !strings.HasSuffix(markdownPath, ".md") {
+			markdownPath = filepath.Join(getWorkflowsDir(), markdownPath+".md")
+		}
+		if markdownPath, err = filepath.Abs(markdownPath); err != nil {
+			return "", nil, fmt.Errorf("failed to resolve %s: %w", workflowName, err)
+		}
+	}
+
+	label := markdownPath
+	if relPath, err := getRepositoryRelativePath(markdownPath); err == nil {
+		label = relPath
+	}
+
+	var content []byte
+	if ref == "" {
+		content, err = os.ReadFile(markdownPath)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read %s: %w", markdownPath, err)
+		}
+	} else {
+		content, err = readFileAtRef(markdownPath, ref)
+		if err != nil {
+			return "", nil, err
+		}
+		label += "@" + ref
+	}
+
+	snapshot, err := compileDiffSnapshot(string(content), markdownPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to compile %s: %w", label, err)
+	}
+	return label, snapshot, nil
+}
+
+// readFileAtRef returns the content of a file as of the given git ref
+func readFileAtRef(path, ref string) ([]byte, error) {
+	repoRoot, err := findGitRootForPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s at %s requires a git repository: %w", path, ref, err)
+	}
+	relPath, err := filepath.Rel(repoRoot, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relative path: %w", err)
+	}
+
+	diffCommandLog.Printf("Reading %s at ref %s", relPath, ref)
+	cmd := exec.Command("git", "-C", repoRoot, "show", ref+":"+filepath.ToSlash(relPath))
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("failed to read %s at %s: %s", relPath, ref, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to read %s at %s: %w", relPath, ref, err)
+	}
+	return output, nil
+}
+
+// compileDiffSnapshot compiles workflow markdown in memory and extracts its reviewable parts
+func compileDiffSnapshot(content, markdownPath string) (workflowSnapshot, error) {
+	compiler := workflow.NewCompiler(
+		workflow.WithNoEmit(true),
+		workflow.WithSkipValidation(true),
+	)
+	compiler.SetQuiet(true)
+
+	workflowData, err := compiler.ParseWorkflowString(content, markdownPath)
+	if err != nil {
+		return nil, err
+	}
+	lockYAML, err := compiler.CompileToYAML(workflowData, markdownPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var lock map[string]any
+	if err := yaml.Unmarshal([]byte(lockYAML), &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse compiled workflow: %w", err)
+	}
+
+	snapshot := workflowSnapshot{}
+	for _, section := range diffSections {
+		snapshot[section] = map[string]string{}
+	}
+
+	snapshot["Engine"]["id"] = workflowData.AI
+	if workflowData.EngineConfig != nil {
+		if workflowData.EngineConfig.Model != "" {
+			snapshot["Engine"]["model"] = workflowData.EngineConfig.Model
+		}
+		if workflowData.EngineConfig.Version != "" {
+			snapshot["Engine"]["version"] = workflowData.EngineConfig.Version
+		}
+	}
+
+	if on, ok := lock["on"].(map[string]any); ok {
+		for event, eventConfig := range on {
+			snapshot["Triggers"][event] = formatDiffValue(eventConfig)
+		}
+	}
+
+	addDiffPermissions(snapshot["Permissions"], "workflow", lock["permissions"])
+	if jobs, ok := lock["jobs"].(map[string]any); ok {
+		for jobName, job := range jobs {
+			snapshot["Jobs"][jobName] = ""
+			if jobMap, ok := job.(map[string]any); ok {
+				addDiffPermissions(snapshot["Permissions"], jobName, jobMap["permissions"])
+			}
+		}
+	}
+
+	for toolName, toolConfig := range workflowData.Tools {
+		snapshot["Tools"][toolName] = formatDiffValue(toolConfig)
+	}
+
+	for _, safeOutput := range workflow.GetEnabledSafeOutputToolNames(workflowData.SafeOutputs) {
+		snapshot["Safe outputs"][safeOutput] = ""
+	}
+
+	if workflowData.NetworkPermissions != nil {
+		for _, domain := range workflowData.NetworkPermissions.Allowed {
+			snapshot["Network"][domain] = ""
+		}
+	}
+
+	return snapshot, nil
+}
+
+// addDiffPermissions records a permissions block under "<scope>.<permission>" keys.
+// Shorthand blocks such as "read-all" are recorded under the scope itself.
+func addDiffPermissions(permissions map[string]string, scope string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for permission, level := range v {
+			permissions[scope+"."+permission] = fmt.Sprint(level)
+		}
+	case string:
+		permissions[scope] = v
+	}
+}
+
+// formatDiffValue renders a configuration value on a single line for comparison
+func formatDiffValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(jsonBytes)
+}
+
+// diffSnapshots returns the changes from base to head in section order, sorted by key
+func diffSnapshots(base, head workflowSnapshot) []DiffChange {
+	changes := []DiffChange{}
+	for _, section := range diffSections {
+		before, after := base[section], head[section]
+		keys := slices.Collect(maps.Keys(before))
+		for key := range after {
+			if _, exists := before[key]; !exists {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			beforeValue, inBefore := before[key]
+			afterValue, inAfter := after[key]
+			switch {
+			case !inBefore:
+				changes = append(changes, DiffChange{Section: section, Kind: "added", Key: key, After: afterValue})
+			case !inAfter:
+				changes = append(changes, DiffChange{Section: section, Kind: "removed", Key: key, Before: beforeValue})
+			case beforeValue != afterValue:
+				changes = append(changes, DiffChange{Section: section, Kind: "changed", Key: key, Before: beforeValue, After: afterValue})
+			}
+		}
+	}
+	return changes
+}
+
+// renderWorkflowDiff formats a diff for the terminal, grouped by section
+func renderWorkflowDiff(result WorkflowDiff) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s\n", console.FormatInfoMessage(fmt.Sprintf("Comparing %s → %s", result.Base, result.Head)))
+
+	if len(result.Changes) == 0 {
+		fmt.Fprintf(&sb, "%s\n", console.FormatSuccessMessage("No semantic differences"))
+		return sb.String()
+	}
+
+	currentSection := ""
+	for _, change := range result.Changes {
+		if change.Section != currentSection {
+			currentSection = change.Section
+			fmt.Fprintf(&sb, "\n%s\n", console.FormatSectionHeader(currentSection))
+		}
+		switch change.Kind {
+		case "added":
+			fmt.Fprintf(&sb, "  + %s\n", formatDiffEntry(change.Key, change.After))
+		case "removed":
+			fmt.Fprintf(&sb, "  - %s\n", formatDiffEntry(change.Key, change.Before))
+		default:
+			fmt.Fprintf(&sb, "  ~ %s: %s → %s\n", change.Key, change.Before, change.After)
+		}
+	}
+
+	fmt.Fprintf(&sb, "\n%s\n", console.FormatInfoMessage(fmt.Sprintf("%d change(s)", len(result.Changes))))
+	return sb.String()
+}
+
+// formatDiffEntry formats an added or removed key with its value when it has one
+func formatDiffEntry(key, value string) string {
+	if value == "" {
+		return key
+	}
+	return key + ": " + value
+}
@@ -0,0 +1,103 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	base := workflowSnapshot{
+		"Triggers":     {"issues": `{"types":["opened"]}`},
+		"Permissions":  {"agent.contents": "read", "agent.issues": "read"},
+		"Safe outputs": {"add_comment": ""},
+	}
+	head := workflowSnapshot{
+		"Triggers":     {"issues": `{"types":["opened","edited"]}`, "pull_request": ""},
+		"Permissions":  {"agent.contents": "read"},
+		"Safe outputs": {"add_comment": "", "add_labels": ""},
+	}
+
+	changes := diffSnapshots(base, head)
+	assert.Equal(t, []DiffChange{
+		{Section: "Triggers", Kind: "changed", Key: "issues", Before: `{"types":["opened"]}`, After: `{"types":["opened","edited"]}`},
+		{Section: "Triggers", Kind: "added", Key: "pull_request"},
+		{Section: "Permissions", Kind: "removed", Key: "agent.issues", Before: "read"},
+		{Section: "Safe outputs", Kind: "added", Key: "add_labels"},
+	}, changes, "changes should be grouped by section and sorted by key")
+
+	assert.Empty(t, diffSnapshots(base, base), "identical snapshots should have no changes")
+}
+
+func TestCompileDiffSnapshot(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "diff-command-test")
+	markdownPath := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+tools:
+  github:
+    toolsets: [issues]
+network:
+  allowed: [defaults, python]
+safe-outputs:
+  add-comment:
+---
+
+Triage the issue.
+`
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0644), "should write workflow file")
+
+	snapshot, err := compileDiffSnapshot(content, markdownPath)
+	require.NoError(t, err, "workflow should compile")
+
+	assert.Equal(t, "copilot", snapshot["Engine"]["id"], "engine should be recorded")
+	assert.Equal(t, `{"types":["opened"]}`, snapshot["Triggers"]["issues"], "trigger config should be recorded")
+	assert.Equal(t, "read", snapshot["Permissions"]["agent.issues"], "agent job permissions should be recorded")
+	assert.Contains(t, snapshot["Jobs"], "safe_outputs", "compiled jobs should be recorded")
+	assert.Equal(t, `{"toolsets":["issues"]}`, snapshot["Tools"]["github"], "tool config should be recorded")
+	assert.Contains(t, snapshot["Safe outputs"], "add_comment", "safe outputs should be recorded")
+	assert.Contains(t, snapshot["Network"], "python", "network ecosystems should be recorded")
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err, "should read temp dir")
+	assert.Len(t, entries, 1, "diff should not write lock files")
+}
+
+func TestRenderWorkflowDiff(t *testing.T) {
+	output := renderWorkflowDiff(WorkflowDiff{
+		Base: "triage.md@main",
+		Head: "triage.md",
+		Changes: []DiffChange{
+			{Section: "Permissions", Kind: "changed", Key: "agent.issues", Before: "read", After: "write"},
+			{Section: "Tools", Kind: "added", Key: "web-fetch"},
+			{Section: "Tools", Kind: "removed", Key: "bash", Before: `["ls"]`},
+		},
+	})
+
+	assert.Contains(t, output, "triage.md@main → triage.md", "output should name both sides")
+	assert.Contains(t, output, "~ agent.issues: read → write", "changed entries should show both values")
+	assert.Contains(t, output, "+ web-fetch", "added entries should be marked")
+	assert.Contains(t, output, `- bash: ["ls"]`, "removed entries should show the old value")
+	assert.Contains(t, output, "3 change(s)", "output should count changes")
+
+	empty := renderWorkflowDiff(WorkflowDiff{Base: "a.md", Head: "b.md"})
+	assert.Contains(t, empty, "No semantic differences", "empty diff should say so")
+}
+
+func TestRunDiffRequiresComparison(t *testing.T) {
+	err := RunDiff(DiffConfig{BaseWorkflow: "triage", HeadWorkflow: "triage"})
+	require.Error(t, err, "diffing a workflow against itself should fail")
+	assert.Contains(t, err.Error(), "nothing to compare", "error should explain how to compare")
+}
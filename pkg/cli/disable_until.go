@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var disableUntilLog = logger.New("cli:disable_until")
+
+// disabledUntilVariable is the repository variable recording when temporarily disabled workflows
+// should be re-enabled. Its value is a JSON object mapping workflow IDs to RFC3339 timestamps.
+const disabledUntilVariable = "GH_AW_DISABLED_UNTIL"
+
+// DisableWorkflowsUntil disables workflows and records the date after which
+// `gh aw enable --expired` re-enables them
+func DisableWorkflowsUntil(workflowNames []string, until string, repoOverride string) error {
+	disableUntilLog.Printf("DisableWorkflowsUntil called: workflow_count=%d, until=%s, repo=%s", len(workflowNames), until, repoOverride)
+
+	untilTime, err := parseDisableUntil(until, time.Now())
+	if err != nil {
+		return err
+	}
+
+	disabled, toggleErr := toggleWorkflowsByNames(workflowNames, false, repoOverride)
+	if len(disabled) == 0 {
+		return toggleErr
+	}
+
+	metadata, err := readDisabledUntil(repoOverride)
+	if err != nil {
+		return err
+	}
+	for _, name := range disabled {
+		metadata[name] = untilTime.UTC().Format(time.RFC3339)
+	}
+	if err := writeDisabledUntil(metadata, repoOverride); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Recorded re-enable date %s in the %s repository variable", untilTime.UTC().Format(time.RFC3339), disabledUntilVariable)))
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Run 'gh aw enable --expired' (for example from a scheduled workflow) to re-enable them once the date has passed"))
+	return toggleErr
+}
+
+// EnableExpiredWorkflows re-enables the workflows whose disabled-until date has passed
+func EnableExpiredWorkflows(repoOverride string) error {
+	disableUntilLog.Printf("EnableExpiredWorkflows called: repo=%s", repoOverride)
+
+	metadata, err := readDisabledUntil(repoOverride)
+	if err != nil {
+		return err
+	}
+
+	expired := expiredDisabledWorkflows(metadata, time.Now())
+	if len(expired) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No temporarily disabled workflows are due to be re-enabled"))
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Re-enabling %d workflow(s) whose disable period has ended: %s", len(expired), strings.Join(expired, ", "))))
+	return EnableWorkflowsByNames(expired, repoOverride)
+}
+
+// parseDisableUntil parses the --until value. It accepts a date (YYYY-MM-DD, interpreted as
+// midnight UTC), an RFC3339 timestamp, or a relative offset such as "+3d" or "+12h".
+func parseDisableUntil(until string, now time.Time) (time.Time, error) {
+	until = strings.TrimSpace(until)
+	if until == "" {
+		return time.Time{}, errors.New("--until requires a date")
+	}
+
+	resolved, err := workflow.ResolveRelativeDate(until, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --until value '%s': %w", until, err)
+	}
+
+	var untilTime time.Time
+	if t, err := time.Parse(time.RFC3339, resolved); err == nil {
+		untilTime = t
+	} else if t, err := time.Parse(time.DateOnly, resolved); err == nil {
+		untilTime = t
+	} else {
+		return time.Time{}, fmt.Errorf("invalid --until value '%s': expected YYYY-MM-DD, an RFC3339 timestamp, or a relative offset like +3d", until)
+	}
+
+	if !untilTime.After(now) {
+		return time.Time{}, fmt.Errorf("invalid --until value '%s': date must be in the future", until)
+	}
+	return untilTime, nil
+}
+
+// expiredDisabledWorkflows returns the sorted workflow IDs whose re-enable date is not after now.
+// Entries with an unparsable date are treated as expired so they do not stay disabled forever.
+func expiredDisabledWorkflows(metadata map[string]string, now time.Time) []string {
+	var expired []string
+	for name, until := range metadata {
+		untilTime, err := time.Parse(time.RFC3339, until)
+		if err != nil || !untilTime.After(now) {
+			expired = append(expired, name)
+		}
+	}
+	sort.Strings(expired)
+	return expired
+}
+
+// clearDisabledUntil removes the re-enable dates of workflows that have been enabled
+func clearDisabledUntil(workflowNames []string, repoOverride string) error {
+	metadata, err := readDisabledUntil(repoOverride)
+	if err != nil {
+		return err
+	}
+	if !removeDisabledUntilEntries(metadata, workflowNames) {
+		return nil
+	}
+	return writeDisabledUntil(metadata, repoOverride)
+}
+
+// removeDisabledUntilEntries deletes the given workflows from the metadata and reports whether anything changed
+func removeDisabledUntilEntries(metadata map[string]string, workflowNames []string) bool {
+	changed := false
+	for _, name := range workflowNames {
+		if _, ok := metadata[name]; ok {
+			delete(metadata, name)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// parseDisabledUntil decodes the value of the disabled-until repository variable
+func parseDisabledUntil(value string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if strings.TrimSpace(value) == "" {
+		return metadata, nil
+	}
+	if err := json.Unmarshal([]byte(value), &metadata); err != nil {
+		return nil, fmt.Errorf("invalid %s repository variable: %w", disabledUntilVariable, err)
+	}
+	return metadata, nil
+}
+
+// readDisabledUntil reads the disabled-until metadata, returning an empty map when the variable is not set
+func readDisabledUntil(repoOverride string) (map[string]string, error) {
+	args := []string{"variable", "get", disabledUntilVariable}
+	if repoOverride != "" {
+		args = append(args, "--repo", repoOverride)
+	}
+	output, err := workflow.ExecGH(args...).CombinedOutput()
+	if err != nil {
+		// gh reports a missing variable as "variable ... was not found"
+		if strings.Contains(strings.ToLower(string(output)), "not found") {
+			disableUntilLog.Print("Disabled-until variable is not set")
+			return make(map[string]string), nil
+		}
+		return nil, fmt.Errorf("failed to read %s repository variable: %s", disabledUntilVariable, strings.TrimSpace(string(output)))
+	}
+	return parseDisabledUntil(string(output))
+}
+
+// writeDisabledUntil stores the disabled-until metadata, deleting the variable once it is empty
+func writeDisabledUntil(metadata map[string]string, repoOverride string) error {
+	var args []string
+	if len(metadata) == 0 {
+		args = []string{"variable", "delete", disabledUntilVariable}
+	} else {
+		// json.Marshal sorts map keys, keeping the variable value stable
+		value, err := json.Marshal(metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s repository variable: %w", disabledUntilVariable, err)
+		}
+		args = []string{"variable", "set", disabledUntilVariable, "--body", string(value)}
+	}
+	if repoOverride != "" {
+		args = append(args, "--repo", repoOverride)
+	}
+
+	disableUntilLog.Printf("Writing disabled-until metadata: entries=%d", len(metadata))
+	if output, err := workflow.ExecGH(args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to update %s repository variable: %s", disabledUntilVariable, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDisableUntil(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		until   string
+		want    time.Time
+		wantErr string
+	}{
+		{name: "date", until: "2026-03-12", want: time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp", until: "2026-03-10T18:30:00Z", want: time.Date(2026, 3, 10, 18, 30, 0, 0, time.UTC)},
+		{name: "relative days", until: "+3d", want: time.Date(2026, 3, 13, 12, 0, 0, 0, time.UTC)},
+		{name: "relative hours", until: "+12h", want: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{name: "past date", until: "2026-03-01", wantErr: "must be in the future"},
+		{name: "invalid", until: "next week", wantErr: "expected YYYY-MM-DD"},
+		{name: "empty", until: " ", wantErr: "requires a date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDisableUntil(tt.until, now)
+			if tt.wantErr != "" {
+				require.Error(t, err, "should reject %q", tt.until)
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "should parse %q", tt.until)
+			assert.True(t, tt.want.Equal(got), "expected %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestDisabledUntilMetadata(t *testing.T) {
+	metadata, err := parseDisabledUntil(`{"ci-doctor":"2026-03-09T00:00:00Z","daily-news":"2026-03-20T00:00:00Z","broken":"soon"}`)
+	require.NoError(t, err, "should parse the variable value")
+
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []string{"broken", "ci-doctor"}, expiredDisabledWorkflows(metadata, now), "past and unparsable dates should be expired")
+
+	assert.True(t, removeDisabledUntilEntries(metadata, []string{"ci-doctor", "unknown"}), "removing a recorded workflow should change the metadata")
+	assert.False(t, removeDisabledUntilEntries(metadata, []string{"unknown"}), "removing an unknown workflow should not change the metadata")
+	assert.Equal(t, map[string]string{"daily-news": "2026-03-20T00:00:00Z", "broken": "soon"}, metadata, "only removed entries should be deleted")
+
+	empty, err := parseDisabledUntil("")
+	require.NoError(t, err, "an unset variable should be empty")
+	assert.Empty(t, empty, "an unset variable should have no entries")
+
+	_, err = parseDisabledUntil("not json")
+	assert.Error(t, err, "invalid JSON should be reported")
+}
+
+func TestExpandWorkflowNamePatterns(t *testing.T) {
+	mdFiles := []string{
+		".github/workflows/ci-doctor.md",
+		".github/workflows/daily-news.md",
+		".github/workflows/daily-plan.md",
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		{name: "plain names", patterns: []string{"ci-doctor", "daily-news.md"}, want: []string{"ci-doctor", "daily-news"}},
+		{name: "glob", patterns: []string{"daily-*"}, want: []string{"daily-news", "daily-plan"}},
+		{name: "deduplicated", patterns: []string{"daily-news", "daily-*"}, want: []string{"daily-news", "daily-plan"}},
+		{name: "no match is kept", patterns: []string{"weekly-*"}, want: []string{"weekly-*"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expandWorkflowNamePatterns(tt.patterns, mdFiles), "patterns should expand to workflow IDs")
+		})
+	}
+}
@@ -19,20 +19,29 @@ import (
 
 var enableLog = logger.New("cli:enable")
 
-// EnableWorkflowsByNames enables workflows by specific names, or all if no names provided
+// EnableWorkflowsByNames enables workflows by specific names or glob patterns, or all if no names provided
 func EnableWorkflowsByNames(workflowNames []string, repoOverride string) error {
 	enableLog.Printf("EnableWorkflowsByNames called: workflow_count=%d, repo=%s", len(workflowNames), repoOverride)
-	return toggleWorkflowsByNames(workflowNames, true, repoOverride)
+	enabled, err := toggleWorkflowsByNames(workflowNames, true, repoOverride)
+	// Enabled workflows no longer need to be re-enabled automatically
+	if len(enabled) > 0 {
+		if clearErr := clearDisabledUntil(enabled, repoOverride); clearErr != nil {
+			enableLog.Printf("Failed to clear disabled-until metadata: %v", clearErr)
+		}
+	}
+	return err
 }
 
-// DisableWorkflowsByNames disables workflows by specific names, or all if no names provided
+// DisableWorkflowsByNames disables workflows by specific names or glob patterns, or all if no names provided
 func DisableWorkflowsByNames(workflowNames []string, repoOverride string) error {
 	enableLog.Printf("DisableWorkflowsByNames called: workflow_count=%d, repo=%s", len(workflowNames), repoOverride)
-	return toggleWorkflowsByNames(workflowNames, false, repoOverride)
+	_, err := toggleWorkflowsByNames(workflowNames, false, repoOverride)
+	return err
 }
 
-// toggleWorkflowsByNames toggles workflows by specific names, or all if no names provided
-func toggleWorkflowsByNames(workflowNames []string, enable bool, repoOverride string) error {
+// toggleWorkflowsByNames toggles workflows by specific names or glob patterns, or all if no names
+// provided. It returns the workflows that are in the requested state afterwards.
+func toggleWorkflowsByNames(workflowNames []string, enable bool, repoOverride string) ([]string, error) {
 	action := "enable"
 	if !enable {
 		action = "disable"
@@ -47,11 +56,11 @@ func toggleWorkflowsByNames(workflowNames []string, enable bool, repoOverride st
 		// Get all workflow names and process them
 		mdFiles, err := getMarkdownWorkflowFiles("")
 		if err != nil {
-			return fmt.Errorf("no workflow files found to %s: %w", action, err)
+			return nil, fmt.Errorf("no workflow files found to %s: %w", action, err)
 		}
 
 		if len(mdFiles) == 0 {
-			return fmt.Errorf("no markdown workflow files found to %s", action)
+			return nil, fmt.Errorf("no markdown workflow files found to %s", action)
 		}
 
 		// Extract all workflow names
@@ -68,15 +77,18 @@ func toggleWorkflowsByNames(workflowNames []string, enable bool, repoOverride st
 
 	// Check if gh CLI is available
 	if !isGHCLIAvailable() {
-		return errors.New("GitHub CLI (gh) is required but not available")
+		return nil, errors.New("GitHub CLI (gh) is required but not available")
 	}
 
 	// Get the core set of workflows from markdown files in .github/workflows
 	mdFiles, err := getMarkdownWorkflowFiles("")
 	if err != nil {
-		return fmt.Errorf("no workflow files found to %s: %w", action, err)
+		return nil, fmt.Errorf("no workflow files found to %s: %w", action, err)
 	}
 
+	// Expand glob patterns such as "daily-*" to the matching workflow IDs
+	workflowNames = expandWorkflowNamePatterns(workflowNames, mdFiles)
+
 	// Get GitHub workflows status for comparison; warn but continue if unavailable
 	enableLog.Print("Fetching GitHub workflows status for comparison")
 	githubWorkflows, err := fetchGitHubWorkflows(repoOverride, false)
@@ -98,6 +110,8 @@ func toggleWorkflowsByNames(workflowNames []string, enable bool, repoOverride st
 
 	var targets []workflowTarget
 	var notFoundNames []string
+	// Workflows already in the requested state
+	var unchanged []string
 
 	// Find matching workflows by name
 	for _, workflowName := range workflowNames {
@@ -134,11 +148,13 @@ func toggleWorkflowsByNames(workflowNames []string, enable bool, repoOverride st
 					if enable && githubWorkflow.State == "active" {
 						// Already enabled
 						fmt.Fprintf(os.Stderr, "Workflow %s is already enabled\n", name)
+						unchanged = append(unchanged, name)
 						continue
 					}
 					if !enable && githubWorkflow.State == "disabled_manually" {
 						// Already disabled
 						fmt.Fprintf(os.Stderr, "Workflow %s is already disabled\n", name)
+						unchanged = append(unchanged, name)
 						continue
 					}
 				}
@@ -180,7 +196,7 @@ func toggleWorkflowsByNames(workflowNames []string, enable bool, repoOverride st
 			}
 		}
 
-		return errors.New(console.FormatErrorWithSuggestions(
+		return nil, errors.New(console.FormatErrorWithSuggestions(
 			"workflows not found: "+strings.Join(notFoundNames, ", "),
 			suggestions,
 		))
@@ -190,7 +206,7 @@ func toggleWorkflowsByNames(workflowNames []string, enable bool, repoOverride st
 	if len(targets) == 0 {
 		enableLog.Printf("No workflows need to be %sd - all already in desired state", action)
 		fmt.Fprintf(os.Stderr, "All specified workflows are already %sd\n", action)
-		return nil
+		return unchanged, nil
 	}
 
 	enableLog.Printf("Proceeding to %s %d workflows", action, len(targets))
@@ -202,6 +218,7 @@ func toggleWorkflowsByNames(workflowNames []string, enable bool, repoOverride st
 
 	// Perform the action
 	var failures []string
+	toggled := unchanged
 
 	for _, t := range targets {
 		var cmd *exec.Cmd
@@ -258,19 +275,53 @@ func toggleWorkflowsByNames(workflowNames []string, enable bool, repoOverride st
 			failures = append(failures, t.Name)
 		} else {
 			fmt.Fprintf(os.Stderr, "%sd workflow: %s\n", strings.ToUpper(action[:1])+action[1:], t.Name)
+			toggled = append(toggled, t.Name)
 		}
 	}
 
 	// Return error if any workflows failed to be processed
 	if len(failures) > 0 {
 		if enable {
-			return fmt.Errorf("failed to enable %d workflow(s): %s", len(failures), strings.Join(failures, ", "))
+			return toggled, fmt.Errorf("failed to enable %d workflow(s): %s", len(failures), strings.Join(failures, ", "))
 		} else {
-			return fmt.Errorf("failed to disable %d workflow(s): %s", len(failures), strings.Join(failures, ", "))
+			return toggled, fmt.Errorf("failed to disable %d workflow(s): %s", len(failures), strings.Join(failures, ", "))
 		}
 	}
 
-	return nil
+	return toggled, nil
+}
+
+// expandWorkflowNamePatterns replaces glob patterns (e.g. "daily-*") with the IDs of the matching
+// workflows. Names without glob characters, and patterns that match nothing, are kept as-is so
+// they are reported as not found.
+func expandWorkflowNamePatterns(workflowNames []string, mdFiles []string) []string {
+	var expanded []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			expanded = append(expanded, name)
+		}
+	}
+	for _, pattern := range workflowNames {
+		if !strings.ContainsAny(pattern, "*?[") {
+			add(normalizeWorkflowID(pattern))
+			continue
+		}
+		matched := false
+		for _, file := range mdFiles {
+			name := normalizeWorkflowID(filepath.Base(file))
+			if ok, err := filepath.Match(pattern, name); err == nil && ok {
+				matched = true
+				add(name)
+			}
+		}
+		enableLog.Printf("Expanded pattern %q: matched=%v", pattern, matched)
+		if !matched {
+			add(pattern)
+		}
+	}
+	return expanded
 }
 
 // DisableAllWorkflowsExcept disables all workflows except the specified ones
@@ -25,6 +25,9 @@ func UpdateFieldInFrontmatter(content, fieldName, fieldValue string) (string, er
 		frontmatterEditorLog.Printf("Failed to parse frontmatter: %v", err)
 		return "", fmt.Errorf("failed to parse frontmatter: %w", err)
 	}
+	if result.TOML {
+		return "", parser.ErrTOMLFrontmatterNotEditable
+	}
 
 	// Try to preserve original frontmatter formatting by manually updating the field
 	if len(result.FrontmatterLines) > 0 {
@@ -147,6 +150,9 @@ func addFieldToFrontmatter(content, fieldName, fieldValue string) (string, error
 	if err != nil {
 		return "", fmt.Errorf("failed to parse frontmatter: %w", err)
 	}
+	if result.TOML {
+		return "", parser.ErrTOMLFrontmatterNotEditable
+	}
 
 	// Try to preserve original frontmatter formatting by manually inserting the field
 	if len(result.FrontmatterLines) > 0 {
@@ -221,6 +227,9 @@ func RemoveFieldFromOnTrigger(content, fieldName string) (string, error) {
 		// Field doesn't exist, return content unchanged
 		return content, nil
 	}
+	if result.TOML {
+		return "", parser.ErrTOMLFrontmatterNotEditable
+	}
 
 	// Work with raw frontmatter lines to preserve formatting
 	if len(result.FrontmatterLines) > 0 {
@@ -323,6 +332,9 @@ func SetFieldInOnTrigger(content, fieldName, fieldValue string) (string, error)
 		// No frontmatter, cannot set nested field without 'on' block
 		return "", errors.New("no frontmatter found, cannot set field in 'on' trigger")
 	}
+	if result.TOML {
+		return "", parser.ErrTOMLFrontmatterNotEditable
+	}
 
 	// Check if 'on' field exists
 	onValue, exists := result.Frontmatter["on"]
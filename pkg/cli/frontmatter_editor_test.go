@@ -3,8 +3,11 @@
 package cli
 
 import (
+	"errors"
 	"strings"
 	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
 )
 
 func TestRemoveFieldFromOnTrigger(t *testing.T) {
@@ -212,3 +215,31 @@ permissions:
 		})
 	}
 }
+
+func TestFrontmatterEditorsRejectTOML(t *testing.T) {
+	content := "+++\nengine = \"copilot\"\nimports = [\"shared/a.md\"]\n\n[on]\nworkflow_dispatch = {}\nstop-after = \"+24h\"\n+++\n# Body"
+
+	editors := map[string]func() (string, error){
+		"UpdateFieldInFrontmatter": func() (string, error) { return UpdateFieldInFrontmatter(content, "source", "owner/repo/a.md@v1") },
+		"addFieldToFrontmatter":    func() (string, error) { return addFieldToFrontmatter(content, "source", "owner/repo/a.md@v1") },
+		"RemoveFieldFromOnTrigger": func() (string, error) { return RemoveFieldFromOnTrigger(content, "stop-after") },
+		"SetFieldInOnTrigger":      func() (string, error) { return SetFieldInOnTrigger(content, "stop-after", "+48h") },
+		"processImportsWithWorkflowSpec": func() (string, error) {
+			return processImportsWithWorkflowSpec(content, &WorkflowSpec{RepoSpec: RepoSpec{RepoSlug: "owner/repo"}, WorkflowPath: "workflows/a.md"}, "abc123", false)
+		},
+	}
+	for name, edit := range editors {
+		t.Run(name, func(t *testing.T) {
+			result, err := edit()
+			if !errors.Is(err, parser.ErrTOMLFrontmatterNotEditable) {
+				t.Fatalf("Expected TOML frontmatter to be refused, got result %q and error %v", result, err)
+			}
+		})
+	}
+
+	// Removing a field that is not set leaves TOML workflows untouched
+	result, err := RemoveFieldFromOnTrigger(content, "reaction")
+	if err != nil || result != content {
+		t.Errorf("Expected unchanged content without error, got %q, %v", result, err)
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+var importCommandLog = logger.New("cli:import_command")
+
+// ImportConfig holds configuration for the import command
+type ImportConfig struct {
+	SourcePath string // Path to the GitHub Actions workflow YAML to convert
+	OutputPath string // Destination markdown file (default: alongside the source)
+	Force      bool   // Overwrite an existing destination file
+	Verbose    bool
+}
+
+// NewImportCommand creates the import command
+func NewImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <workflow-yaml>",
+		Short: "Convert a GitHub Actions workflow that runs an agent action into an agentic workflow",
+		Long: `Convert an existing hand-written GitHub Actions workflow that invokes an agent
+action into agentic workflow markdown (frontmatter + prompt).
+
+Supported agent actions:
+  - ` + strings.Join(supportedAgentActionNames(), "\n  - ") + `
+
+The converter maps:
+  - Triggers, permissions, env, concurrency, and run-name to frontmatter
+  - The agent job's runs-on, timeout-minutes, environment, container, and services
+  - The action's prompt input to the markdown body
+  - Model, max turns, and allowed tools to engine and tools configuration
+  - Steps before the agent to 'steps:' and steps after it to 'post-steps:'
+  - Other jobs to 'jobs:'
+
+Write permissions are downgraded to read because agentic workflows perform writes
+through safe-outputs. Constructs that cannot be expressed are reported so they can
+be reviewed before compiling.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` import .github/workflows/claude-review.yml           # Writes .github/workflows/claude-review.md
+  ` + string(constants.CLIExtensionPrefix) + ` import legacy.yml -o .github/workflows/triage.md     # Custom output path
+  ` + string(constants.CLIExtensionPrefix) + ` import .github/workflows/claude-review.yml --force   # Overwrite existing markdown`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputPath, _ := cmd.Flags().GetString("output")
+			force, _ := cmd.Flags().GetBool("force")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			return RunImport(ImportConfig{
+				SourcePath: args[0],
+				OutputPath: outputPath,
+				Force:      force,
+				Verbose:    verbose,
+			})
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Output markdown file (default: source path with .md extension)")
+	cmd.Flags().BoolP("force", "f", false, "Overwrite the output file if it already exists")
+
+	return cmd
+}
+
+// RunImport converts a GitHub Actions workflow YAML file into agentic workflow markdown
+func RunImport(config ImportConfig) error {
+	importCommandLog.Printf("Importing workflow: source=%s, output=%s, force=%v", config.SourcePath, config.OutputPath, config.Force)
+
+	lower := strings.ToLower(config.SourcePath)
+	if strings.HasSuffix(lower, ".lock.yml") {
+		return fmt.Errorf("%s is a compiled agentic workflow; edit its markdown source instead", config.SourcePath)
+	}
+	if !strings.HasSuffix(lower, ".yml") && !strings.HasSuffix(lower, ".yaml") {
+		return fmt.Errorf("expected a .yml or .yaml workflow file, got %s", config.SourcePath)
+	}
+
+	content, err := os.ReadFile(config.SourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", config.SourcePath, err)
+	}
+
+	result, err := ConvertActionsWorkflowToMarkdown(content)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s: %w", config.SourcePath, err)
+	}
+
+	outputPath := config.OutputPath
+	if outputPath == "" {
+		outputPath = strings.TrimSuffix(config.SourcePath, filepath.Ext(config.SourcePath)) + ".md"
+	}
+	if _, err := os.Stat(outputPath); err == nil && !config.Force {
+		return fmt.Errorf("%s already exists. Use --force to overwrite", outputPath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(outputPath, []byte(result.Markdown), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Imported %s (%s engine, job '%s') to %s", config.SourcePath, result.EngineID, result.AgentJob, outputPath)))
+
+	if len(result.Issues) > 0 {
+		fmt.Fprintln(os.Stderr, "")
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("%d construct(s) need review:", len(result.Issues))))
+		for _, issue := range result.Issues {
+			fmt.Fprintf(os.Stderr, "  • %s: %s\n", issue.Location, issue.Message)
+		}
+	}
+
+	workflowID := strings.TrimSuffix(filepath.Base(outputPath), ".md")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Next steps:"))
+	fmt.Fprintf(os.Stderr, "  1. Review %s\n", outputPath)
+	fmt.Fprintf(os.Stderr, "  2. Run '%s compile %s'\n", string(constants.CLIExtensionPrefix), workflowID)
+	fmt.Fprintf(os.Stderr, "  3. Delete %s once the compiled workflow replaces it\n", config.SourcePath)
+
+	return nil
+}
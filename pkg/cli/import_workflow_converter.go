@@ -0,0 +1,432 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/goccy/go-yaml"
+)
+
+var importConverterLog = logger.New("cli:import_workflow_converter")
+
+// agentActionMapping describes how a third-party agent action maps onto gh-aw frontmatter
+type agentActionMapping struct {
+	Action        string   // Action repository (without @ref), e.g. "anthropics/claude-code-action"
+	EngineID      string   // gh-aw engine identifier
+	PromptInputs  []string // Inputs that carry the agent prompt, in priority order
+	ModelInput    string   // Input that selects the model, if any
+	MaxTurnsInput string   // Input that limits agent turns, if any
+	ToolsInput    string   // Input that lists allowed tools, if any
+	AuthInputs    []string // Authentication inputs that gh-aw handles through engine secrets
+}
+
+// knownAgentActions lists the agent actions that can be converted into agentic workflows
+var knownAgentActions = []agentActionMapping{
+	{
+		Action:        "anthropics/claude-code-action",
+		EngineID:      "claude",
+		PromptInputs:  []string{"prompt", "direct_prompt", "override_prompt"},
+		ModelInput:    "model",
+		MaxTurnsInput: "max_turns",
+		ToolsInput:    "allowed_tools",
+		AuthInputs:    []string{"anthropic_api_key", "claude_code_oauth_token", "github_token"},
+	},
+	{
+		Action:       "openai/codex-action",
+		EngineID:     "codex",
+		PromptInputs: []string{"prompt"},
+		ModelInput:   "model",
+		AuthInputs:   []string{"openai-api-key", "openai_api_key"},
+	},
+	{
+		Action:       "google-github-actions/run-gemini-cli",
+		EngineID:     "gemini",
+		PromptInputs: []string{"prompt"},
+		AuthInputs:   []string{"gemini_api_key", "google_api_key", "gcp_workload_identity_provider", "gcp_service_account"},
+	},
+}
+
+// passthroughJobKeys are agent job keys copied verbatim into top-level frontmatter
+var passthroughJobKeys = []string{"runs-on", "timeout-minutes", "environment", "container", "services", "concurrency", "if"}
+
+// unsupportedJobKeys are agent job keys that have no frontmatter equivalent
+var unsupportedJobKeys = []string{"strategy", "outputs", "defaults", "continue-on-error"}
+
+// bashToolPattern matches Claude-style Bash(...) tool specifications
+var bashToolPattern = regexp.MustCompile(`^Bash\((.*)\)$`)
+
+// templateExpressionPattern matches GitHub Actions expressions
+var templateExpressionPattern = regexp.MustCompile(`\$\{\{[^}]*\}\}`)
+
+// ImportIssue describes a construct in the source workflow that could not be converted faithfully
+type ImportIssue struct {
+	Location string // Path of the construct in the source YAML (e.g. "jobs.review.strategy")
+	Message  string // What was dropped or changed and why
+}
+
+// ImportResult is the output of converting a GitHub Actions workflow into agentic markdown
+type ImportResult struct {
+	Markdown string        // Generated frontmatter + prompt markdown
+	EngineID string        // Engine detected from the agent action
+	AgentJob string        // ID of the job that contained the agent action
+	Issues   []ImportIssue // Constructs that need manual review
+}
+
+// ConvertActionsWorkflowToMarkdown converts a hand-written GitHub Actions workflow that
+// invokes a known agent action into gh-aw frontmatter and a markdown prompt.
+func ConvertActionsWorkflowToMarkdown(content []byte) (*ImportResult, error) {
+	var doc yaml.MapSlice
+	if err := yaml.UnmarshalWithOptions(content, &doc, yaml.UseOrderedMap()); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow YAML: %w", err)
+	}
+
+	jobs, ok := mapSliceValue(doc, "jobs").(yaml.MapSlice)
+	if !ok || len(jobs) == 0 {
+		return nil, errors.New("workflow has no jobs")
+	}
+
+	jobID, job, stepIndex, mapping := findAgentStep(jobs)
+	if mapping == nil {
+		return nil, fmt.Errorf("no supported agent action found (supported: %s)", strings.Join(supportedAgentActionNames(), ", "))
+	}
+	importConverterLog.Printf("Found agent action %s in job %s (step %d)", mapping.Action, jobID, stepIndex)
+
+	result := &ImportResult{EngineID: mapping.EngineID, AgentJob: jobID}
+	var frontmatter yaml.MapSlice
+	var name string
+
+	// Top-level workflow keys
+	for _, item := range doc {
+		key := fmt.Sprint(item.Key)
+		switch key {
+		case "name":
+			name = fmt.Sprint(item.Value)
+			frontmatter = append(frontmatter, item)
+		case "on", "run-name", "concurrency", "env":
+			frontmatter = append(frontmatter, item)
+		case "permissions":
+			frontmatter = append(frontmatter, yaml.MapItem{Key: "permissions", Value: convertPermissions(item.Value, "permissions", result)})
+		case "jobs":
+			// Handled below
+		default:
+			result.addIssue(key, "top-level key is not supported in agentic workflow frontmatter and was dropped")
+		}
+	}
+
+	// Agent job permissions take precedence over workflow-level permissions
+	if perms := mapSliceValue(job, "permissions"); perms != nil {
+		frontmatter = setMapSliceValue(frontmatter, "permissions", convertPermissions(perms, "jobs."+jobID+".permissions", result))
+	}
+
+	// Agent job configuration
+	for _, key := range passthroughJobKeys {
+		if value := mapSliceValue(job, key); value != nil {
+			if key == "concurrency" && mapSliceValue(frontmatter, "concurrency") != nil {
+				result.addIssue("jobs."+jobID+".concurrency", "job-level concurrency conflicts with workflow-level concurrency and was dropped")
+				continue
+			}
+			frontmatter = setMapSliceValue(frontmatter, key, value)
+		}
+	}
+	for _, key := range unsupportedJobKeys {
+		if mapSliceValue(job, key) != nil {
+			result.addIssue("jobs."+jobID+"."+key, "not supported for the agent job and was dropped")
+		}
+	}
+	if needs := mapSliceValue(job, "needs"); needs != nil {
+		result.addIssue("jobs."+jobID+".needs", "the agent job runs after activation; dependencies on other jobs were dropped")
+	}
+	if env, ok := mapSliceValue(job, "env").(yaml.MapSlice); ok {
+		// Job-level env takes precedence over workflow-level env with the same name
+		workflowEnv, _ := mapSliceValue(frontmatter, "env").(yaml.MapSlice)
+		merged := append(yaml.MapSlice{}, workflowEnv...)
+		for _, item := range env {
+			merged = setMapSliceValue(merged, fmt.Sprint(item.Key), item.Value)
+		}
+		frontmatter = setMapSliceValue(frontmatter, "env", merged)
+	}
+
+	// Engine configuration from the agent step
+	steps, _ := mapSliceValue(job, "steps").([]any)
+	agentStep, _ := steps[stepIndex].(yaml.MapSlice)
+	engine, tools, prompt := convertAgentStep(agentStep, mapping, fmt.Sprintf("jobs.%s.steps[%d]", jobID, stepIndex), result)
+	frontmatter = append(frontmatter, yaml.MapItem{Key: "engine", Value: engine})
+	if len(tools) > 0 {
+		frontmatter = append(frontmatter, yaml.MapItem{Key: "tools", Value: tools})
+	}
+
+	// Steps before the agent become setup steps; steps after it become post-steps
+	if pre := filterImportedSteps(steps[:stepIndex]); len(pre) > 0 {
+		frontmatter = append(frontmatter, yaml.MapItem{Key: "steps", Value: pre})
+	}
+	if post := filterImportedSteps(steps[stepIndex+1:]); len(post) > 0 {
+		frontmatter = append(frontmatter, yaml.MapItem{Key: "post-steps", Value: post})
+		result.addIssue("jobs."+jobID+".steps", "steps after the agent were moved to post-steps; write operations should use safe-outputs instead")
+	}
+
+	// Remaining jobs are carried over as custom jobs, with dependencies on the agent job
+	// pointing at the generated agent job
+	var otherJobs yaml.MapSlice
+	for _, item := range jobs {
+		otherID := fmt.Sprint(item.Key)
+		if otherID == jobID {
+			continue
+		}
+		if otherJob, ok := item.Value.(yaml.MapSlice); ok {
+			item.Value = rewriteAgentJobNeeds(otherJob, jobID)
+			if referencesJobOutputs(otherJob, jobID) {
+				result.addIssue("jobs."+otherID, fmt.Sprintf("references needs.%s outputs, which the generated '%s' job does not provide", jobID, constants.AgentJobName))
+			}
+		}
+		otherJobs = append(otherJobs, item)
+	}
+	if len(otherJobs) > 0 {
+		frontmatter = append(frontmatter, yaml.MapItem{Key: "jobs", Value: otherJobs})
+		result.addIssue("jobs", fmt.Sprintf("%d additional job(s) were copied to 'jobs:'; dependencies on '%s' now point at the '%s' job", len(otherJobs), jobID, constants.AgentJobName))
+	}
+
+	if prompt == "" {
+		prompt = "TODO: describe the task for the agent."
+		result.addIssue(fmt.Sprintf("jobs.%s.steps[%d].with", jobID, stepIndex), "no prompt input found; add the agent instructions to the markdown body")
+	}
+	if expressions := templateExpressionPattern.FindAllString(prompt, -1); len(expressions) > 0 {
+		result.addIssue("prompt", fmt.Sprintf("prompt contains %d GitHub Actions expression(s); compile will reject any that are not on the allowed list", len(expressions)))
+	}
+
+	frontmatterYAML, err := yaml.MarshalWithOptions(frontmatter, workflow.DefaultMarshalOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate frontmatter: %w", err)
+	}
+
+	var md strings.Builder
+	md.WriteString("---\n")
+	md.WriteString(workflow.UnquoteYAMLKey(string(frontmatterYAML), "on"))
+	md.WriteString("---\n\n")
+	if name != "" {
+		md.WriteString("# " + name + "\n\n")
+	}
+	md.WriteString(strings.TrimSpace(prompt))
+	md.WriteString("\n")
+	result.Markdown = md.String()
+
+	importConverterLog.Printf("Converted workflow: engine=%s, issues=%d", result.EngineID, len(result.Issues))
+	return result, nil
+}
+
+// findAgentStep returns the first job and step index that use a known agent action
+func findAgentStep(jobs yaml.MapSlice) (string, yaml.MapSlice, int, *agentActionMapping) {
+	for _, item := range jobs {
+		job, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+		steps, _ := mapSliceValue(job, "steps").([]any)
+		for i, rawStep := range steps {
+			step, ok := rawStep.(yaml.MapSlice)
+			if !ok {
+				continue
+			}
+			uses, _ := mapSliceValue(step, "uses").(string)
+			action, _, _ := strings.Cut(uses, "@")
+			for j := range knownAgentActions {
+				if strings.EqualFold(action, knownAgentActions[j].Action) {
+					return fmt.Sprint(item.Key), job, i, &knownAgentActions[j]
+				}
+			}
+		}
+	}
+	return "", nil, -1, nil
+}
+
+// convertAgentStep extracts engine configuration, tools, and the prompt from an agent step
+func convertAgentStep(step yaml.MapSlice, mapping *agentActionMapping, location string, result *ImportResult) (yaml.MapSlice, yaml.MapSlice, string) {
+	engine := yaml.MapSlice{{Key: "id", Value: mapping.EngineID}}
+	var tools yaml.MapSlice
+	var prompt string
+
+	with, _ := mapSliceValue(step, "with").(yaml.MapSlice)
+	for _, input := range with {
+		key := fmt.Sprint(input.Key)
+		value := fmt.Sprint(input.Value)
+		switch {
+		case prompt == "" && sliceutil.Contains(mapping.PromptInputs, key):
+			prompt = value
+		case key == mapping.ModelInput && mapping.ModelInput != "":
+			engine = append(engine, yaml.MapItem{Key: "model", Value: value})
+		case key == mapping.MaxTurnsInput && mapping.MaxTurnsInput != "":
+			engine = append(engine, yaml.MapItem{Key: "max-turns", Value: input.Value})
+		case key == mapping.ToolsInput && mapping.ToolsInput != "":
+			tools = convertAllowedTools(value, location+".with."+key, result)
+		case sliceutil.Contains(mapping.AuthInputs, key):
+			// Engine secrets are wired automatically by the compiler
+		default:
+			result.addIssue(location+".with."+key, "agent action input has no frontmatter equivalent and was dropped")
+		}
+	}
+	if env, ok := mapSliceValue(step, "env").(yaml.MapSlice); ok && len(env) > 0 {
+		engine = append(engine, yaml.MapItem{Key: "env", Value: env})
+	}
+
+	return engine, tools, prompt
+}
+
+// convertAllowedTools maps a comma or newline separated Claude tool list onto gh-aw tools
+func convertAllowedTools(value, location string, result *ImportResult) yaml.MapSlice {
+	var bashCommands []any
+	var tools yaml.MapSlice
+	for _, raw := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' }) {
+		tool := strings.TrimSpace(raw)
+		if tool == "" {
+			continue
+		}
+		if match := bashToolPattern.FindStringSubmatch(tool); match != nil {
+			bashCommands = append(bashCommands, match[1])
+			continue
+		}
+		switch tool {
+		case "Bash":
+			bashCommands = append(bashCommands, "*")
+		case "Edit", "MultiEdit", "Write":
+			if mapSliceValue(tools, "edit") == nil {
+				tools = append(tools, yaml.MapItem{Key: "edit", Value: nil})
+			}
+		case "WebFetch":
+			tools = append(tools, yaml.MapItem{Key: "web-fetch", Value: nil})
+		case "WebSearch":
+			tools = append(tools, yaml.MapItem{Key: "web-search", Value: nil})
+		case "Read", "Glob", "Grep", "LS":
+			// Read-only file tools are always available
+		default:
+			result.addIssue(location, fmt.Sprintf("tool '%s' has no gh-aw equivalent and was dropped", tool))
+		}
+	}
+	if len(bashCommands) > 0 {
+		tools = append(yaml.MapSlice{{Key: "bash", Value: bashCommands}}, tools...)
+	}
+	return tools
+}
+
+// convertPermissions downgrades write permissions to read, because agentic workflows
+// perform writes through safe-outputs rather than from the agent job.
+func convertPermissions(value any, location string, result *ImportResult) any {
+	switch v := value.(type) {
+	case string:
+		if v == "write-all" {
+			result.addIssue(location, "write-all was downgraded to read-all; use safe-outputs for write operations")
+			return "read-all"
+		}
+		return v
+	case yaml.MapSlice:
+		converted := make(yaml.MapSlice, 0, len(v))
+		var downgraded []string
+		for _, item := range v {
+			if fmt.Sprint(item.Value) == "write" {
+				downgraded = append(downgraded, fmt.Sprint(item.Key))
+				item.Value = "read"
+			}
+			converted = append(converted, item)
+		}
+		if len(downgraded) > 0 {
+			sort.Strings(downgraded)
+			result.addIssue(location, fmt.Sprintf("write access to %s was downgraded to read; use safe-outputs for write operations", strings.Join(downgraded, ", ")))
+		}
+		return converted
+	default:
+		return value
+	}
+}
+
+// filterImportedSteps drops checkout steps, which the compiler generates itself
+func filterImportedSteps(steps []any) []any {
+	var filtered []any
+	for _, rawStep := range steps {
+		if step, ok := rawStep.(yaml.MapSlice); ok {
+			uses, _ := mapSliceValue(step, "uses").(string)
+			if strings.HasPrefix(uses, "actions/checkout@") {
+				continue
+			}
+		}
+		filtered = append(filtered, rawStep)
+	}
+	return filtered
+}
+
+// rewriteAgentJobNeeds returns job with needs: entries naming the original agent job replaced
+// by the generated agent job
+func rewriteAgentJobNeeds(job yaml.MapSlice, agentJobID string) yaml.MapSlice {
+	agentJob := string(constants.AgentJobName)
+	switch needs := mapSliceValue(job, "needs").(type) {
+	case string:
+		if needs == agentJobID {
+			return setMapSliceValue(append(yaml.MapSlice{}, job...), "needs", agentJob)
+		}
+	case []any:
+		var rewritten []any
+		changed := false
+		for _, need := range needs {
+			if fmt.Sprint(need) == agentJobID {
+				need = agentJob
+				changed = true
+			}
+			if !slices.Contains(rewritten, need) {
+				rewritten = append(rewritten, need)
+			}
+		}
+		if changed {
+			return setMapSliceValue(append(yaml.MapSlice{}, job...), "needs", rewritten)
+		}
+	}
+	return job
+}
+
+// referencesJobOutputs reports whether any value in job uses a needs.<jobID>. expression
+func referencesJobOutputs(job yaml.MapSlice, jobID string) bool {
+	data, err := yaml.Marshal(job)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), "needs."+jobID+".")
+}
+
+// supportedAgentActionNames returns the action names that can be imported
+func supportedAgentActionNames() []string {
+	names := make([]string, 0, len(knownAgentActions))
+	for _, mapping := range knownAgentActions {
+		names = append(names, mapping.Action)
+	}
+	return names
+}
+
+// addIssue records a construct that needs manual review
+func (r *ImportResult) addIssue(location, message string) {
+	r.Issues = append(r.Issues, ImportIssue{Location: location, Message: message})
+}
+
+// mapSliceValue returns the value for key in an ordered map, or nil when absent
+func mapSliceValue(m yaml.MapSlice, key string) any {
+	for _, item := range m {
+		if fmt.Sprint(item.Key) == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+// setMapSliceValue replaces the value for key in an ordered map, appending it when absent
+func setMapSliceValue(m yaml.MapSlice, key string, value any) yaml.MapSlice {
+	for i, item := range m {
+		if fmt.Sprint(item.Key) == key {
+			m[i].Value = value
+			return m
+		}
+	}
+	return append(m, yaml.MapItem{Key: key, Value: value})
+}
@@ -0,0 +1,200 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const claudeActionWorkflow = `name: Claude Review
+on:
+  pull_request:
+    types: [opened]
+permissions:
+  contents: read
+  pull-requests: write
+jobs:
+  review:
+    runs-on: ubuntu-latest
+    timeout-minutes: 15
+    strategy:
+      matrix:
+        node: [20]
+    steps:
+      - uses: actions/checkout@v4
+      - name: Install deps
+        run: npm ci
+      - uses: anthropics/claude-code-action@v1
+        with:
+          anthropic_api_key: ${{ secrets.ANTHROPIC_API_KEY }}
+          model: claude-sonnet-4
+          max_turns: 10
+          allowed_tools: "Bash(git diff:*),Edit,CustomTool"
+          prompt: |
+            Review the pull request and summarize the changes.
+          use_sticky_comment: true
+      - name: Report
+        run: echo done
+`
+
+func TestConvertActionsWorkflowToMarkdown(t *testing.T) {
+	result, err := ConvertActionsWorkflowToMarkdown([]byte(claudeActionWorkflow))
+	require.NoError(t, err, "Conversion should succeed")
+
+	assert.Equal(t, "claude", result.EngineID, "Engine should be detected from the action")
+	assert.Equal(t, "review", result.AgentJob, "Agent job should be detected")
+
+	parsed, err := parser.ExtractFrontmatterFromContent(result.Markdown)
+	require.NoError(t, err, "Generated markdown should have valid frontmatter")
+	fm := parsed.Frontmatter
+
+	assert.Equal(t, "Claude Review", fm["name"], "Name should be preserved")
+	assert.Equal(t, "ubuntu-latest", fm["runs-on"], "runs-on should come from the agent job")
+	assert.Contains(t, parsed.Markdown, "Review the pull request", "Prompt should become the markdown body")
+
+	perms, ok := fm["permissions"].(map[string]any)
+	require.True(t, ok, "Permissions should be a map")
+	assert.Equal(t, "read", perms["pull-requests"], "Write permissions should be downgraded")
+
+	engine, ok := fm["engine"].(map[string]any)
+	require.True(t, ok, "Engine should be an object")
+	assert.Equal(t, "claude", engine["id"], "Engine ID should be set")
+	assert.Equal(t, "claude-sonnet-4", engine["model"], "Model should be mapped")
+
+	tools, ok := fm["tools"].(map[string]any)
+	require.True(t, ok, "Tools should be mapped")
+	assert.Equal(t, []any{"git diff:*"}, tools["bash"], "Bash tool patterns should be mapped")
+	assert.Contains(t, tools, "edit", "Edit tool should be mapped")
+
+	steps, ok := fm["steps"].([]any)
+	require.True(t, ok, "Pre-agent steps should be carried over")
+	assert.Len(t, steps, 1, "Checkout step should be dropped")
+	assert.Contains(t, fm, "post-steps", "Post-agent steps should be carried over")
+
+	var locations []string
+	for _, issue := range result.Issues {
+		locations = append(locations, issue.Location)
+	}
+	assert.Contains(t, locations, "permissions", "Downgraded permissions should be flagged")
+	assert.Contains(t, locations, "jobs.review.strategy", "Matrix strategy should be flagged")
+	assert.Contains(t, locations, "jobs.review.steps[2].with.use_sticky_comment", "Unmapped action inputs should be flagged")
+	assert.Contains(t, locations, "jobs.review.steps[2].with.allowed_tools", "Unmapped tools should be flagged")
+}
+
+func TestConvertActionsWorkflowToMarkdown_NoAgentAction(t *testing.T) {
+	content := `name: CI
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`
+	_, err := ConvertActionsWorkflowToMarkdown([]byte(content))
+	require.Error(t, err, "Conversion should fail without an agent action")
+	assert.Contains(t, err.Error(), "no supported agent action", "Error should explain what is missing")
+}
+
+func TestConvertActionsWorkflowToMarkdown_EnvPrecedence(t *testing.T) {
+	content := `name: Env
+on: push
+env:
+  SHARED: workflow
+  WORKFLOW_ONLY: "1"
+jobs:
+  agent-job:
+    runs-on: ubuntu-latest
+    env:
+      SHARED: job
+      JOB_ONLY: "2"
+    steps:
+      - uses: openai/codex-action@v1
+        with:
+          prompt: Do the task.
+`
+	result, err := ConvertActionsWorkflowToMarkdown([]byte(content))
+	require.NoError(t, err, "Conversion should succeed")
+
+	parsed, err := parser.ExtractFrontmatterFromContent(result.Markdown)
+	require.NoError(t, err, "Generated markdown should have valid frontmatter")
+	assert.Equal(t, map[string]any{"SHARED": "job", "WORKFLOW_ONLY": "1", "JOB_ONLY": "2"}, parsed.Frontmatter["env"], "Job env should override workflow env without duplicating keys")
+	assert.Equal(t, 1, strings.Count(result.Markdown, "SHARED:"), "Overridden env key should appear once")
+}
+
+func TestConvertActionsWorkflowToMarkdown_RewritesAgentNeeds(t *testing.T) {
+	content := `name: Needs
+on: push
+jobs:
+  review:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: openai/codex-action@v1
+        with:
+          prompt: Review the code.
+  notify:
+    needs: review
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo notified
+  report:
+    needs: [build, review]
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ${{ needs.review.outputs.summary }}
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`
+	result, err := ConvertActionsWorkflowToMarkdown([]byte(content))
+	require.NoError(t, err, "Conversion should succeed")
+
+	parsed, err := parser.ExtractFrontmatterFromContent(result.Markdown)
+	require.NoError(t, err, "Generated markdown should have valid frontmatter")
+	jobs, ok := parsed.Frontmatter["jobs"].(map[string]any)
+	require.True(t, ok, "Other jobs should be carried over")
+
+	notify, ok := jobs["notify"].(map[string]any)
+	require.True(t, ok, "notify job should be carried over")
+	assert.Equal(t, "agent", notify["needs"], "String needs on the agent job should point at the agent job")
+
+	report, ok := jobs["report"].(map[string]any)
+	require.True(t, ok, "report job should be carried over")
+	assert.Equal(t, []any{"build", "agent"}, report["needs"], "List needs on the agent job should point at the agent job")
+
+	build, ok := jobs["build"].(map[string]any)
+	require.True(t, ok, "build job should be carried over")
+	assert.NotContains(t, build, "needs", "Jobs without needs should be left alone")
+
+	var reportIssues []string
+	for _, issue := range result.Issues {
+		if issue.Location == "jobs.report" {
+			reportIssues = append(reportIssues, issue.Message)
+		}
+	}
+	require.Len(t, reportIssues, 1, "Use of the agent job outputs should be flagged")
+	assert.Contains(t, reportIssues[0], "needs.review outputs", "Issue should name the dangling outputs")
+}
+
+func TestRunImport(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "claude-review.yml")
+	require.NoError(t, os.WriteFile(source, []byte(claudeActionWorkflow), 0600), "Should write source workflow")
+
+	require.NoError(t, RunImport(ImportConfig{SourcePath: source}), "Import should succeed")
+	assert.FileExists(t, filepath.Join(dir, "claude-review.md"), "Markdown should be written next to the source")
+
+	err := RunImport(ImportConfig{SourcePath: source})
+	require.Error(t, err, "Import should refuse to overwrite without --force")
+	require.NoError(t, RunImport(ImportConfig{SourcePath: source, Force: true}), "Import should overwrite with --force")
+
+	err = RunImport(ImportConfig{SourcePath: filepath.Join(dir, "test.lock.yml")})
+	assert.Error(t, err, "Import should reject compiled lock files")
+}
@@ -94,6 +94,9 @@ func processImportsWithWorkflowSpec(content string, workflow *WorkflowSpec, comm
 	}
 
 	importsLog.Printf("Found %d imports to process", len(imports))
+	if result.TOML {
+		return "", parser.ErrTOMLFrontmatterNotEditable
+	}
 
 	// Process each import and replace with workflowspec format
 	processedImports := make([]string, 0, len(imports))
@@ -200,8 +203,9 @@ func processIncludesWithWorkflowSpec(content string, workflow *WorkflowSpec, com
 				continue
 			}
 
-			// Check for cycle detection
-			if visited[filePath] {
+			// Check for cycle detection (parameterized includes may repeat with different values)
+			alreadyVisited := visited[filePath]
+			if alreadyVisited && len(directive.Params) == 0 {
 				if verbose {
 					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Cycle detected for include: %s, skipping", filePath)))
 				}
@@ -221,13 +225,15 @@ func processIncludesWithWorkflowSpec(content string, workflow *WorkflowSpec, com
 
 			// Write the updated @include directive
 			if isOptional {
-				result.WriteString("{{#import? " + workflowSpec + "}}\n")
+				result.WriteString("{{#import? " + workflowSpec + directive.FormatParams() + "}}\n")
 			} else {
-				result.WriteString("{{#import " + workflowSpec + "}}\n")
+				result.WriteString("{{#import " + workflowSpec + directive.FormatParams() + "}}\n")
 			}
 
 			// Add file to queue for processing nested includes
-			queue = append(queue, fileToProcess{path: filePath})
+			if !alreadyVisited {
+				queue = append(queue, fileToProcess{path: filePath})
+			}
 		} else {
 			// Regular line, pass through
 			result.WriteString(line + "\n")
@@ -366,9 +372,9 @@ func processIncludesInContent(content string, workflow *WorkflowSpec, commitSHA
 
 			// Write the updated import directive
 			if isOptional {
-				result.WriteString("{{#import? " + workflowSpec + "}}\n")
+				result.WriteString("{{#import? " + workflowSpec + directive.FormatParams() + "}}\n")
 			} else {
-				result.WriteString("{{#import " + workflowSpec + "}}\n")
+				result.WriteString("{{#import " + workflowSpec + directive.FormatParams() + "}}\n")
 			}
 		} else {
 			// Regular line, pass through
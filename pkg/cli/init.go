@@ -21,6 +21,9 @@ type InitOptions struct {
 	CodespaceEnabled bool
 	Completions      bool
 	CreatePR         bool
+	Engine           string // Engine for .aw/config.yml and the secrets check (default: existing config, then copilot)
+	Minimal          bool   // Only create the workflows directory, repository config, and dispatcher agent
+	Full             bool   // Also install the sample shared includes into .github/workflows/shared
 	RootCmd          CommandProvider
 }
 
@@ -58,6 +61,17 @@ func InitRepository(opts InitOptions) error {
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Configured .gitattributes"))
 	}
 
+	// Create the workflows directory and repository configuration
+	if err := ensureWorkflowsDir(opts.Verbose); err != nil {
+		initLog.Printf("Failed to create workflows directory: %v", err)
+		return fmt.Errorf("failed to create workflows directory: %w", err)
+	}
+	engine := resolveInitEngine(opts.Engine)
+	if err := ensureRepoConfigFile(engine, opts.Verbose); err != nil {
+		initLog.Printf("Failed to create repository config: %v", err)
+		return fmt.Errorf("failed to create %s: %w", workflow.RepoConfigPath, err)
+	}
+
 	// Write dispatcher agent
 	initLog.Print("Writing agentic workflows dispatcher agent")
 	if err := ensureAgenticWorkflowsDispatcher(opts.Verbose, false); err != nil {
@@ -75,8 +89,17 @@ func InitRepository(opts InitOptions) error {
 		return fmt.Errorf("failed to delete setup agentic workflows agent: %w", err)
 	}
 
-	// Configure MCP if requested
-	if opts.MCP {
+	// Install the sample shared includes
+	if opts.Full {
+		initLog.Print("Installing sample shared includes")
+		if err := ensureSharedIncludes(opts.Verbose); err != nil {
+			initLog.Printf("Failed to install shared includes: %v", err)
+			return fmt.Errorf("failed to install shared includes: %w", err)
+		}
+	}
+
+	// Configure MCP if requested (never in minimal mode)
+	if opts.MCP && !opts.Minimal {
 		initLog.Print("Configuring GitHub Copilot Agent MCP integration")
 
 		// Detect action mode for setup steps generation
@@ -116,16 +139,18 @@ func InitRepository(opts InitOptions) error {
 		}
 	}
 
-	// Configure VSCode settings
-	initLog.Print("Configuring VSCode settings")
+	// Configure VSCode settings (skipped in minimal mode)
+	if !opts.Minimal {
+		initLog.Print("Configuring VSCode settings")
 
-	// Update .vscode/settings.json
-	if err := ensureVSCodeSettings(opts.Verbose); err != nil {
-		initLog.Printf("Failed to update VSCode settings: %v", err)
-		return fmt.Errorf("failed to update VSCode settings: %w", err)
-	}
-	if opts.Verbose {
-		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Updated .vscode/settings.json"))
+		// Update .vscode/settings.json
+		if err := ensureVSCodeSettings(opts.Verbose); err != nil {
+			initLog.Printf("Failed to update VSCode settings: %v", err)
+			return fmt.Errorf("failed to update VSCode settings: %w", err)
+		}
+		if opts.Verbose {
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Updated .vscode/settings.json"))
+		}
 	}
 
 	// Install shell completions if requested
@@ -149,6 +174,11 @@ func InitRepository(opts InitOptions) error {
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to generate maintenance workflow: %v", err)))
 	}
 
+	// Report required secrets that are missing from the repository (skipped in minimal mode)
+	if !opts.Minimal {
+		verifyInitSecrets(engine)
+	}
+
 	initLog.Print("Repository initialization completed successfully")
 
 	// If --create-pull-request is enabled, create branch, commit, push, and create PR
@@ -158,6 +188,7 @@ func InitRepository(opts InitOptions) error {
 
 		prBody := "This PR initializes the repository for agentic workflows by:\n" +
 			"- Configuring .gitattributes\n" +
+			"- Creating .github/workflows and " + workflow.RepoConfigPath + "\n" +
 			"- Creating GitHub Copilot custom instructions\n" +
 			"- Setting up workflow prompts and agents"
 		if _, err := CreatePRWithChanges("init-agentic-workflows", "chore: initialize agentic workflows", "Initialize agentic workflows", prBody, opts.Verbose); err != nil {
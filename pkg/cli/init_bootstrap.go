@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var initBootstrapLog = logger.New("cli:init_bootstrap")
+
+// sharedIncludesFS holds the sample shared includes installed by `init --full`
+//
+//go:embed templates/shared/*.md
+var sharedIncludesFS embed.FS
+
+// repoConfigTemplate is the starter .aw/config.yml written by init; %s is the default engine
+const repoConfigTemplate = `# Repository defaults for agentic workflows.
+# Workflow frontmatter and CLI flags always take precedence over these values.
+# Read and change them with: gh aw config get|set <key>
+engine: %s
+`
+
+// ensureWorkflowsDir creates .github/workflows if it does not exist
+func ensureWorkflowsDir(verbose bool) error {
+	gitRoot, err := findGitRoot()
+	if err != nil {
+		return err
+	}
+
+	workflowsDir := filepath.Join(gitRoot, ".github", "workflows")
+	if _, err := os.Stat(workflowsDir); err == nil {
+		initBootstrapLog.Print(".github/workflows already exists")
+		return nil
+	}
+
+	initBootstrapLog.Print("Creating .github/workflows")
+	if err := os.MkdirAll(workflowsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create .github/workflows: %w", err)
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Created .github/workflows"))
+	}
+	return nil
+}
+
+// ensureRepoConfigFile writes a starter .aw/config.yml selecting the given engine.
+// An existing configuration is validated and left unchanged.
+func ensureRepoConfigFile(engine string, verbose bool) error {
+	gitRoot, err := findGitRoot()
+	if err != nil {
+		return err
+	}
+
+	configPath := filepath.Join(gitRoot, workflow.RepoConfigPath)
+	if _, err := os.Stat(configPath); err == nil {
+		initBootstrapLog.Printf("%s already exists", workflow.RepoConfigPath)
+		_, err := workflow.LoadRepoConfig(gitRoot)
+		return err
+	}
+
+	config := &workflow.RepoConfig{Engine: engine}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	initBootstrapLog.Printf("Creating %s with engine %s", workflow.RepoConfigPath, engine)
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(configPath), err)
+	}
+	if err := os.WriteFile(configPath, fmt.Appendf(nil, repoConfigTemplate, engine), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", workflow.RepoConfigPath, err)
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Created "+workflow.RepoConfigPath))
+	}
+	return nil
+}
+
+// ensureSharedIncludes installs the sample shared includes into .github/workflows/shared.
+// Files that already exist are not overwritten.
+func ensureSharedIncludes(verbose bool) error {
+	gitRoot, err := findGitRoot()
+	if err != nil {
+		return err
+	}
+
+	sharedDir := filepath.Join(gitRoot, ".github", "workflows", "shared")
+	if err := os.MkdirAll(sharedDir, 0755); err != nil {
+		return fmt.Errorf("failed to create .github/workflows/shared: %w", err)
+	}
+
+	entries, err := fs.ReadDir(sharedIncludesFS, "templates/shared")
+	if err != nil {
+		return fmt.Errorf("failed to read sample shared includes: %w", err)
+	}
+
+	for _, entry := range entries {
+		target := filepath.Join(sharedDir, entry.Name())
+		if _, err := os.Stat(target); err == nil {
+			initBootstrapLog.Printf("Shared include already exists, skipping: %s", entry.Name())
+			continue
+		}
+
+		content, err := sharedIncludesFS.ReadFile("templates/shared/" + entry.Name())
+		if err != nil {
+			return fmt.Errorf("failed to read sample shared include %s: %w", entry.Name(), err)
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return fmt.Errorf("failed to write .github/workflows/shared/%s: %w", entry.Name(), err)
+		}
+		initBootstrapLog.Printf("Installed shared include: %s", entry.Name())
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Created .github/workflows/shared/"+entry.Name()))
+		}
+	}
+	return nil
+}
+
+// resolveInitEngine returns the engine init configures: the --engine flag, then the
+// engine of an existing .aw/config.yml, then the default Copilot engine
+func resolveInitEngine(engineFlag string) string {
+	if engineFlag != "" {
+		return engineFlag
+	}
+	if gitRoot, err := findGitRoot(); err == nil {
+		if config, err := workflow.LoadRepoConfig(gitRoot); err == nil && config.Engine != "" {
+			return config.Engine
+		}
+	}
+	return string(constants.CopilotEngine)
+}
+
+// verifyInitSecrets reports the required secrets for the engine that are missing from
+// the repository. It never fails init: when secrets cannot be listed (no remote, no
+// gh authentication) it prints a warning pointing to `secrets bootstrap`.
+func verifyInitSecrets(engine string) {
+	initBootstrapLog.Printf("Verifying required secrets for engine: %s", engine)
+
+	repoSlug, err := GetCurrentRepoSlug()
+	if err != nil {
+		initBootstrapLog.Printf("Could not detect repository: %v", err)
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Skipped secrets verification: could not detect the GitHub repository. Run '"+string(constants.CLIExtensionPrefix)+" secrets bootstrap' once the repository is pushed."))
+		return
+	}
+
+	existingSecrets, err := getExistingSecretsInRepo(repoSlug)
+	if err != nil {
+		initBootstrapLog.Printf("Could not list secrets for %s: %v", repoSlug, err)
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Skipped secrets verification: unable to list repository secrets. Run '"+string(constants.CLIExtensionPrefix)+" secrets bootstrap' to check them."))
+		return
+	}
+
+	requirements := getSecretRequirementsForEngine(engine, true, false)
+	missing := getMissingRequiredSecrets(requirements, existingSecrets)
+	if len(missing) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("All required secrets for %s are configured", engine)))
+		return
+	}
+
+	initBootstrapLog.Printf("Found %d missing required secrets", len(missing))
+	displayMissingSecrets(missing, repoSlug, existingSecrets)
+}
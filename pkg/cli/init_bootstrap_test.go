@@ -0,0 +1,85 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chdirToNewGitRepo changes to a fresh git repository for the duration of the test
+func chdirToNewGitRepo(t *testing.T) string {
+	t.Helper()
+	tempDir := testutil.TempDir(t, "test-init-bootstrap-*")
+	oldWd, err := os.Getwd()
+	require.NoError(t, err, "should get working directory")
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+	require.NoError(t, os.Chdir(tempDir), "should change to temp directory")
+	require.NoError(t, exec.Command("git", "init").Run(), "should init git repo")
+	return tempDir
+}
+
+func TestEnsureWorkflowsDirAndRepoConfig(t *testing.T) {
+	tempDir := chdirToNewGitRepo(t)
+
+	require.NoError(t, ensureWorkflowsDir(false), "should create workflows directory")
+	assert.DirExists(t, filepath.Join(tempDir, ".github", "workflows"), "workflows directory should exist")
+
+	require.NoError(t, ensureRepoConfigFile("claude", false), "should create repository config")
+	config, err := workflow.LoadRepoConfig(tempDir)
+	require.NoError(t, err, "generated config should load")
+	assert.Equal(t, "claude", config.Engine, "generated config should select the engine")
+	assert.Equal(t, "claude", resolveInitEngine(""), "engine should be read back from the config")
+	assert.Equal(t, "codex", resolveInitEngine("codex"), "--engine should take precedence")
+
+	// An existing configuration is kept
+	require.NoError(t, ensureRepoConfigFile("copilot", false), "existing config should be accepted")
+	config, err = workflow.LoadRepoConfig(tempDir)
+	require.NoError(t, err, "config should still load")
+	assert.Equal(t, "claude", config.Engine, "existing config should not be overwritten")
+}
+
+func TestEnsureRepoConfigFileRejectsUnknownEngine(t *testing.T) {
+	tempDir := chdirToNewGitRepo(t)
+
+	err := ensureRepoConfigFile("unknown-engine", false)
+	require.Error(t, err, "unknown engine should be rejected")
+	assert.NoFileExists(t, filepath.Join(tempDir, workflow.RepoConfigPath), "no config should be written")
+}
+
+func TestEnsureSharedIncludes(t *testing.T) {
+	tempDir := chdirToNewGitRepo(t)
+	sharedDir := filepath.Join(tempDir, ".github", "workflows", "shared")
+
+	require.NoError(t, os.MkdirAll(sharedDir, 0755), "should create shared directory")
+	customized := []byte("## Reporting\n\nOur own guidelines.\n")
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "reporting.md"), customized, 0644), "should write existing include")
+
+	require.NoError(t, ensureSharedIncludes(false), "should install shared includes")
+
+	for _, name := range []string{"reporting.md", "keep-it-short.md", "jqschema.md"} {
+		assert.FileExists(t, filepath.Join(sharedDir, name), "shared include %s should be installed", name)
+	}
+	content, err := os.ReadFile(filepath.Join(sharedDir, "reporting.md"))
+	require.NoError(t, err, "should read existing include")
+	assert.Equal(t, customized, content, "existing include should not be overwritten")
+}
+
+func TestInitCommandModeFlags(t *testing.T) {
+	cmd := NewInitCommand()
+	require.NotNil(t, cmd.Flags().Lookup("minimal"), "minimal flag should be defined")
+	require.NotNil(t, cmd.Flags().Lookup("full"), "full flag should be defined")
+
+	cmd.SetArgs([]string{"--minimal", "--full"})
+	cmd.SetOut(os.Stderr)
+	err := cmd.Execute()
+	require.Error(t, err, "--minimal and --full should be mutually exclusive")
+	assert.Contains(t, err.Error(), "none of the others can be", "error should explain the conflict")
+}
@@ -28,10 +28,19 @@ Interactive Mode (default):
 
 This command:
 - Configures .gitattributes to mark .lock.yml files as generated
+- Creates .github/workflows and the repository config file .aw/config.yml (if missing)
 - Creates the dispatcher agent at .github/agents/agentic-workflows.agent.md
 - Removes old prompt files from .github/prompts/ if they exist
 - Configures VSCode settings (.vscode/settings.json)
 - Generates/updates .github/workflows/agentics-maintenance.yml if any workflows use expires field for discussions or issues
+- Verifies that the secrets required by the engine are configured in the repository (report only)
+
+With --minimal flag:
+- Only configures .gitattributes, .github/workflows, .aw/config.yml, and the dispatcher agent
+- Skips MCP configuration, VSCode settings, and secrets verification
+
+With --full flag:
+- Also installs sample shared includes into .github/workflows/shared/ (existing files are kept)
 
 By default (without --no-mcp):
 - Creates .github/workflows/copilot-setup-steps.yml with gh-aw installation steps
@@ -63,6 +72,8 @@ To create, update or debug automated agentic actions using github, playwright, a
 Examples:
   ` + string(constants.CLIExtensionPrefix) + ` init                                # Interactive mode
   ` + string(constants.CLIExtensionPrefix) + ` init -v                             # Interactive with verbose output
+  ` + string(constants.CLIExtensionPrefix) + ` init --minimal                      # Only the essential files
+  ` + string(constants.CLIExtensionPrefix) + ` init --full                         # Also install sample shared includes
   ` + string(constants.CLIExtensionPrefix) + ` init --no-mcp                       # Skip MCP configuration
   ` + string(constants.CLIExtensionPrefix) + ` init --codespaces                   # Configure Codespaces
   ` + string(constants.CLIExtensionPrefix) + ` init --codespaces repo1,repo2       # Codespaces with additional repos
@@ -78,6 +89,9 @@ Examples:
 			createPRFlag, _ := cmd.Flags().GetBool("create-pull-request")
 			prFlagAlias, _ := cmd.Flags().GetBool("pr")
 			createPR := createPRFlag || prFlagAlias // Support both --create-pull-request and --pr
+			engine, _ := cmd.Flags().GetString("engine")
+			minimal, _ := cmd.Flags().GetBool("minimal")
+			full, _ := cmd.Flags().GetBool("full")
 
 			// Determine MCP state: default true, unless --no-mcp is specified
 			// --mcp flag is kept for backward compatibility (hidden from help)
@@ -100,7 +114,7 @@ Examples:
 				}
 			}
 
-			initCommandLog.Printf("Executing init command: verbose=%v, mcp=%v, codespaces=%v, codespaceEnabled=%v, completions=%v, createPR=%v", verbose, mcp, codespaceRepos, codespaceEnabled, completions, createPR)
+			initCommandLog.Printf("Executing init command: verbose=%v, mcp=%v, codespaces=%v, codespaceEnabled=%v, completions=%v, createPR=%v, minimal=%v, full=%v", verbose, mcp, codespaceRepos, codespaceEnabled, completions, createPR, minimal, full)
 			opts := InitOptions{
 				Verbose:          verbose,
 				MCP:              mcp,
@@ -108,6 +122,9 @@ Examples:
 				CodespaceEnabled: codespaceEnabled,
 				Completions:      completions,
 				CreatePR:         createPR,
+				Engine:           engine,
+				Minimal:          minimal,
+				Full:             full,
 				RootCmd:          cmd.Root(),
 			}
 			if err := InitRepository(opts); err != nil {
@@ -132,6 +149,9 @@ Examples:
 	cmd.Flags().Bool("mcp", false, "Configure GitHub Copilot Agent MCP server integration (deprecated, MCP is enabled by default)")
 	// Hide the deprecated --mcp flag from help (kept for backward compatibility)
 	_ = cmd.Flags().MarkHidden("mcp")
+	cmd.Flags().Bool("minimal", false, "Only create the workflows directory, repository config, and dispatcher agent")
+	cmd.Flags().Bool("full", false, "Also install sample shared includes into .github/workflows/shared")
+	cmd.MarkFlagsMutuallyExclusive("minimal", "full")
 
 	return cmd
 }
@@ -26,8 +26,8 @@ type LogAnalysis interface {
 	AddMetrics(other LogAnalysis)
 }
 
-// LogParser is a function type that parses a single log file
-type LogParser[T LogAnalysis] func(logPath string, verbose bool) (T, error)
+// LogAnalysisParser is a function type that parses a single firewall or domain log file
+type LogAnalysisParser[T LogAnalysis] func(logPath string, verbose bool) (T, error)
 
 // aggregateLogFiles is a generic helper that aggregates multiple log files
 // It handles file discovery, parsing, domain deduplication, and sorting
@@ -35,7 +35,7 @@ func aggregateLogFiles[T LogAnalysis](
 	logsDir string,
 	globPattern string,
 	verbose bool,
-	parser LogParser[T],
+	parser LogAnalysisParser[T],
 	newAnalysis func() T,
 ) (T, error) {
 	logAggregationLog.Printf("Aggregating log files: dir=%s, pattern=%s", logsDir, globPattern)
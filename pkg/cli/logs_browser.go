@@ -0,0 +1,717 @@
+// This file provides the interactive run browser of the logs command.
+//
+// With --browse, the logs command opens a terminal UI over the runs that were
+// already downloaded into the output directory: the list of runs on the left and
+// the agent transcript of the selected run on the right. Tool calls in stream-json
+// transcripts are folded into a single line holding the call and its result, the
+// transcript can be searched, and the selected run can be opened in the browser.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/github/gh-aw/pkg/fileutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/styles"
+	"github.com/github/gh-aw/pkg/tty"
+)
+
+var logsBrowserLog = logger.New("cli:logs_browser")
+
+// browserRun is a downloaded run listed in the browser
+type browserRun struct {
+	ID           int64
+	WorkflowName string
+	Conclusion   string
+	URL          string
+	CreatedAt    time.Time
+	Dir          string
+}
+
+// transcriptEntry is a single transcript line, or a tool call folded with its result
+type transcriptEntry struct {
+	Tool  string   // Tool name for tool calls, empty for plain lines
+	Lines []string // The line itself, or the call and result lines of a tool call
+}
+
+// runLogsBrowser opens the interactive browser over the runs downloaded into outputDir
+func runLogsBrowser(outputDir, workflowName string) error {
+	if !tty.IsStdoutTerminal() {
+		return errors.New("--browse requires an interactive terminal")
+	}
+
+	runs, err := loadBrowserRuns(outputDir, workflowName)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		return fmt.Errorf("no downloaded runs found in %s. Run the logs command without --browse first", outputDir)
+	}
+
+	logsBrowserLog.Printf("Browsing %d runs in %s", len(runs), outputDir)
+	if _, err := tea.NewProgram(newLogsBrowserModel(runs), tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("failed to run logs browser: %w", err)
+	}
+	return nil
+}
+
+// loadBrowserRuns returns the runs downloaded into outputDir, newest run first
+func loadBrowserRuns(outputDir, workflowName string) ([]browserRun, error) {
+	runs, err := findGrepRuns(outputDir, LogGrepOptions{WorkflowName: workflowName})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]browserRun, 0, len(runs))
+	for _, run := range runs {
+		entry := browserRun{ID: run.id, WorkflowName: run.workflowName, Dir: run.dir}
+		if data, err := os.ReadFile(filepath.Join(run.dir, runSummaryFileName)); err == nil {
+			var summary RunSummary
+			if err := json.Unmarshal(data, &summary); err == nil {
+				entry.Conclusion = summary.Run.Conclusion
+				entry.URL = summary.Run.URL
+				entry.CreatedAt = summary.Run.CreatedAt
+			}
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// findBrowserTranscript returns the agent transcript of a run directory, using the
+// engine recorded in aw_info.json to locate it
+func findBrowserTranscript(runDir string) (string, bool) {
+	if engine := extractEngineFromAwInfo(filepath.Join(runDir, "aw_info.json"), false); engine != nil {
+		if path, found := findAgentLogFile(runDir, engine); found {
+			return path, true
+		}
+	}
+	stdioLog := filepath.Join(runDir, "agent-stdio.log")
+	if fileutil.FileExists(stdioLog) {
+		return stdioLog, true
+	}
+	return "", false
+}
+
+// loadBrowserTranscript reads the agent transcript of a run
+func loadBrowserTranscript(run browserRun) ([]transcriptEntry, error) {
+	path, found := findBrowserTranscript(run.Dir)
+	if !found {
+		return nil, nil
+	}
+	logsBrowserLog.Printf("Loading transcript of run %d: %s", run.ID, path)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxTranscriptLineSize)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return buildTranscriptEntries(lines), nil
+}
+
+// buildTranscriptEntries groups transcript lines into entries. Each tool call starts an
+// entry and its result is appended to it, so that the pair can be folded as one.
+func buildTranscriptEntries(lines []string) []transcriptEntry {
+	entries := make([]transcriptEntry, 0, len(lines))
+	calls := make(map[string]int)
+	for _, line := range lines {
+		if event, ok := parseTranscriptToolEvent(line); ok {
+			if event.kind == "tool_use" {
+				if event.id != "" {
+					calls[event.id] = len(entries)
+				}
+				entries = append(entries, transcriptEntry{Tool: event.name, Lines: []string{line}})
+				continue
+			}
+			if index, found := calls[event.id]; found {
+				entries[index].Lines = append(entries[index].Lines, line)
+				continue
+			}
+		}
+		entries = append(entries, transcriptEntry{Lines: []string{line}})
+	}
+	return entries
+}
+
+// browserPane identifies the pane that receives navigation keys
+type browserPane int
+
+const (
+	runsPane browserPane = iota
+	transcriptPane
+)
+
+// logsBrowserHelp lists the key bindings in the footer
+const logsBrowserHelp = "↑/↓ move · enter open/fold · tab switch pane · a fold all · / search · n/N next/prev · o open in browser · q quit"
+
+// logsBrowserModel is the Bubble Tea model of the logs browser
+type logsBrowserModel struct {
+	runs     []browserRun
+	visible  []int // Indexes into runs that pass the run filter
+	selected int   // Index into visible
+	filter   string
+
+	loadedRun int64
+	entries   []transcriptEntry
+	expanded  map[int]bool
+	cursor    int // Selected transcript entry
+	offset    int // First transcript line shown
+	query     string
+
+	focus     browserPane
+	searching bool
+	search    textinput.Model
+	status    string
+
+	width  int
+	height int
+
+	loadTranscript func(browserRun) ([]transcriptEntry, error)
+	openURL        func(string) error
+}
+
+// newLogsBrowserModel creates the browser model with the first run selected
+func newLogsBrowserModel(runs []browserRun) logsBrowserModel {
+	search := textinput.New()
+	search.Prompt = "/"
+
+	m := logsBrowserModel{
+		runs:           runs,
+		expanded:       make(map[int]bool),
+		search:         search,
+		width:          120,
+		height:         30,
+		loadTranscript: loadBrowserTranscript,
+		openURL:        openInBrowser,
+	}
+	m.applyRunFilter("")
+	return m
+}
+
+// Init initializes the browser model
+func (m logsBrowserModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles key presses and terminal resizes
+func (m logsBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollToCursor()
+		return m, nil
+	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		return m.updateKey(msg)
+	}
+	return m, nil
+}
+
+// updateSearch handles keys while the search input is open
+func (m logsBrowserModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.searching = false
+		m.search.Blur()
+		if m.focus == runsPane {
+			m.applyRunFilter(m.search.Value())
+		} else {
+			m.query = m.search.Value()
+			m.findMatch(m.cursor, 1)
+		}
+		return m, nil
+	case "esc", "ctrl+c":
+		m.searching = false
+		m.search.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	return m, cmd
+}
+
+// updateKey handles navigation keys
+func (m logsBrowserModel) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "tab":
+		if m.focus == runsPane {
+			m.openSelectedRun()
+		} else {
+			m.focus = runsPane
+		}
+		return m, nil
+	case "/":
+		m.searching = true
+		m.search.SetValue("")
+		return m, m.search.Focus()
+	case "o":
+		m.openSelectedRunInBrowser()
+		return m, nil
+	}
+
+	if m.focus == runsPane {
+		switch msg.String() {
+		case "up", "k":
+			m.moveSelection(-1)
+		case "down", "j":
+			m.moveSelection(1)
+		case "enter", "right", "l":
+			m.openSelectedRun()
+		case "esc":
+			m.applyRunFilter("")
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup", "ctrl+u":
+		m.moveCursor(-m.transcriptHeight())
+	case "pgdown", "ctrl+d":
+		m.moveCursor(m.transcriptHeight())
+	case "home", "g":
+		m.moveCursor(-len(m.entries))
+	case "end", "G":
+		m.moveCursor(len(m.entries))
+	case "enter", " ":
+		if m.cursor < len(m.entries) && m.entries[m.cursor].Tool != "" {
+			m.expanded[m.cursor] = !m.expanded[m.cursor]
+			m.scrollToCursor()
+		}
+	case "a":
+		m.toggleAll()
+	case "n":
+		m.findMatch(m.cursor+1, 1)
+	case "N":
+		m.findMatch(m.cursor-1, -1)
+	case "esc", "left", "h":
+		m.focus = runsPane
+	}
+	return m, nil
+}
+
+// applyRunFilter keeps the runs whose ID, workflow name or conclusion contain filter
+func (m *logsBrowserModel) applyRunFilter(filter string) {
+	m.filter = filter
+	m.visible = nil
+	needle := strings.ToLower(filter)
+	for i, run := range m.runs {
+		haystack := strings.ToLower(strconv.FormatInt(run.ID, 10) + " " + run.WorkflowName + " " + run.Conclusion)
+		if strings.Contains(haystack, needle) {
+			m.visible = append(m.visible, i)
+		}
+	}
+	m.selected = 0
+	if len(m.visible) == 0 {
+		m.status = fmt.Sprintf("No runs match %q", filter)
+	}
+}
+
+// selectedRun returns the run under the list cursor
+func (m logsBrowserModel) selectedRun() (browserRun, bool) {
+	if m.selected < 0 || m.selected >= len(m.visible) {
+		return browserRun{}, false
+	}
+	return m.runs[m.visible[m.selected]], true
+}
+
+// moveSelection moves the list cursor by delta runs
+func (m *logsBrowserModel) moveSelection(delta int) {
+	m.selected = max(0, min(len(m.visible)-1, m.selected+delta))
+}
+
+// openSelectedRun loads the transcript of the selected run and focuses the transcript pane
+func (m *logsBrowserModel) openSelectedRun() {
+	run, ok := m.selectedRun()
+	if !ok {
+		return
+	}
+	m.focus = transcriptPane
+	if run.ID == m.loadedRun {
+		return
+	}
+
+	entries, err := m.loadTranscript(run)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.loadedRun = run.ID
+	m.entries = entries
+	m.expanded = make(map[int]bool)
+	m.cursor = 0
+	m.offset = 0
+	if len(entries) == 0 {
+		m.status = fmt.Sprintf("No agent transcript found for run %d", run.ID)
+	}
+}
+
+// openSelectedRunInBrowser opens the GitHub page of the selected run
+func (m *logsBrowserModel) openSelectedRunInBrowser() {
+	run, ok := m.selectedRun()
+	if !ok {
+		return
+	}
+	if run.URL == "" {
+		m.status = fmt.Sprintf("Run %d has no URL in %s", run.ID, runSummaryFileName)
+		return
+	}
+	if err := m.openURL(run.URL); err != nil {
+		m.status = "Failed to open browser: " + err.Error()
+		return
+	}
+	m.status = "Opened " + run.URL
+}
+
+// moveCursor moves the transcript cursor by delta entries
+func (m *logsBrowserModel) moveCursor(delta int) {
+	if len(m.entries) == 0 {
+		return
+	}
+	m.cursor = max(0, min(len(m.entries)-1, m.cursor+delta))
+	m.scrollToCursor()
+}
+
+// toggleAll expands every tool call, or folds them all when any is expanded
+func (m *logsBrowserModel) toggleAll() {
+	anyExpanded := false
+	for _, expanded := range m.expanded {
+		anyExpanded = anyExpanded || expanded
+	}
+	m.expanded = make(map[int]bool)
+	if !anyExpanded {
+		for i, entry := range m.entries {
+			if entry.Tool != "" {
+				m.expanded[i] = true
+			}
+		}
+	}
+	m.scrollToCursor()
+}
+
+// findMatch moves the cursor to the next entry containing the search query, starting at
+// from and searching in direction dir (1 or -1). Matching tool calls are expanded.
+func (m *logsBrowserModel) findMatch(from, dir int) {
+	if m.query == "" || len(m.entries) == 0 {
+		return
+	}
+	needle := strings.ToLower(m.query)
+	for step := range len(m.entries) {
+		i := ((from+dir*step)%len(m.entries) + len(m.entries)) % len(m.entries)
+		if entryContains(m.entries[i], needle) {
+			m.cursor = i
+			if m.entries[i].Tool != "" {
+				m.expanded[i] = true
+			}
+			m.scrollToCursor()
+			return
+		}
+	}
+	m.status = fmt.Sprintf("No matches for %q", m.query)
+}
+
+// entryContains reports whether any line of the entry contains the lowercase needle
+func entryContains(entry transcriptEntry, needle string) bool {
+	if strings.Contains(strings.ToLower(entry.Tool), needle) {
+		return true
+	}
+	for _, line := range entry.Lines {
+		if strings.Contains(strings.ToLower(line), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// entryLineCount returns the number of lines an entry takes in the transcript pane
+func (m logsBrowserModel) entryLineCount(i int) int {
+	if m.entries[i].Tool != "" && m.expanded[i] {
+		return 1 + len(m.entries[i].Lines)
+	}
+	return 1
+}
+
+// scrollToCursor adjusts the transcript offset so the selected entry is visible
+func (m *logsBrowserModel) scrollToCursor() {
+	if len(m.entries) == 0 {
+		return
+	}
+	start := 0
+	for i := range m.cursor {
+		start += m.entryLineCount(i)
+	}
+	height := m.transcriptHeight()
+	end := start + min(m.entryLineCount(m.cursor), height)
+	if start < m.offset {
+		m.offset = start
+	} else if end > m.offset+height {
+		m.offset = end - height
+	}
+}
+
+// transcriptHeight returns the number of transcript lines that fit in the pane
+func (m logsBrowserModel) transcriptHeight() int {
+	// Header, footer and the pane borders
+	return max(1, m.height-4)
+}
+
+// runsWidth returns the width of the run list pane, borders included
+func (m logsBrowserModel) runsWidth() int {
+	return max(24, min(48, m.width/3))
+}
+
+// View renders the run list, the transcript and the footer
+func (m logsBrowserModel) View() string {
+	titleStyle := lipgloss.NewStyle().Foreground(styles.ColorInfo).Bold(true)
+	title := titleStyle.Render(fmt.Sprintf("Agentic workflow runs (%d)", len(m.runs)))
+	if m.filter != "" {
+		title += lipgloss.NewStyle().Foreground(styles.ColorComment).Render(fmt.Sprintf("  filter: %s", m.filter))
+	}
+
+	runsWidth := m.runsWidth()
+	transcriptWidth := max(20, m.width-runsWidth)
+	height := m.transcriptHeight()
+
+	panes := lipgloss.JoinHorizontal(lipgloss.Top,
+		m.paneStyle(runsPane, runsWidth, height).Render(m.renderRuns(runsWidth-2, height)),
+		m.paneStyle(transcriptPane, transcriptWidth, height).Render(m.renderTranscript(transcriptWidth-2, height)),
+	)
+
+	footer := lipgloss.NewStyle().Foreground(styles.ColorComment).Render(stringutil.Truncate(logsBrowserHelp, max(4, m.width)))
+	if m.searching {
+		footer = m.search.View()
+	} else if m.status != "" {
+		footer = lipgloss.NewStyle().Foreground(styles.ColorWarning).Render(stringutil.Truncate(m.status, max(4, m.width)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, panes, footer)
+}
+
+// paneStyle returns the bordered style of a pane, highlighting the focused one
+func (m logsBrowserModel) paneStyle(pane browserPane, width, height int) lipgloss.Style {
+	borderColor := styles.ColorBorder
+	if m.focus == pane {
+		borderColor = styles.ColorInfo
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Width(width - 2).
+		Height(height)
+}
+
+// renderRuns renders the visible part of the run list
+func (m logsBrowserModel) renderRuns(width, height int) string {
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.ColorSuccess).Bold(true)
+
+	first := max(0, m.selected-height+1)
+	var lines []string
+	for i := first; i < len(m.visible) && len(lines) < height; i++ {
+		run := m.runs[m.visible[i]]
+		label := fmt.Sprintf("%s %d %s", conclusionSymbol(run.Conclusion), run.ID, run.WorkflowName)
+		if !run.CreatedAt.IsZero() {
+			label += " · " + run.CreatedAt.Format("01-02 15:04")
+		}
+		if i == m.selected {
+			lines = append(lines, selectedStyle.Render("> "+truncateDisplay(label, width-2)))
+		} else {
+			lines = append(lines, "  "+truncateDisplay(label, width-2))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderTranscript renders the visible part of the transcript of the loaded run
+func (m logsBrowserModel) renderTranscript(width, height int) string {
+	if len(m.entries) == 0 {
+		return lipgloss.NewStyle().Foreground(styles.ColorComment).Render("Select a run and press enter to show its transcript")
+	}
+
+	cursorStyle := lipgloss.NewStyle().Foreground(styles.ColorSuccess).Bold(true)
+	toolStyle := lipgloss.NewStyle().Foreground(styles.ColorPurple)
+
+	var lines []string
+	for i, entry := range m.entries {
+		var entryLines []string
+		switch {
+		case entry.Tool == "":
+			entryLines = []string{describeTranscriptLine(entry.Lines[0])}
+		case m.expanded[i]:
+			entryLines = append(entryLines, "▾ ["+entry.Tool+"]")
+			for _, line := range entry.Lines {
+				entryLines = append(entryLines, "    "+describeTranscriptLine(line))
+			}
+		default:
+			// Folded tool calls show their input; the result is shown when expanded
+			header := "▸ [" + entry.Tool + "] " + strings.TrimPrefix(describeTranscriptLine(entry.Lines[0]), entry.Tool+" ")
+			if len(entry.Lines) == 1 {
+				header += " (no result)"
+			}
+			entryLines = []string{header}
+		}
+		for j, line := range entryLines {
+			// Styles are applied after truncation, which removes escape sequences
+			line = truncateDisplay(line, width)
+			switch {
+			case i == m.cursor && j == 0:
+				line = cursorStyle.Render(line)
+			case entry.Tool != "" && j == 0:
+				line = toolStyle.Render(line)
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) >= m.offset+height {
+			break
+		}
+	}
+
+	end := min(len(lines), m.offset+height)
+	if m.offset >= end {
+		return ""
+	}
+	return strings.Join(lines[m.offset:end], "\n")
+}
+
+// describeTranscriptLine returns a readable form of a stream-json transcript line: the text
+// of messages, the name and input of tool calls and the content of tool results. Other
+// lines are returned unchanged.
+func describeTranscriptLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") {
+		return line
+	}
+	var event struct {
+		Message struct {
+			Content []struct {
+				Type    string          `json:"type"`
+				Text    string          `json:"text"`
+				Name    string          `json:"name"`
+				Input   json.RawMessage `json:"input"`
+				Content json.RawMessage `json:"content"`
+			} `json:"content"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(trimmed), &event); err != nil {
+		return line
+	}
+
+	var parts []string
+	for _, item := range event.Message.Content {
+		switch item.Type {
+		case "text":
+			parts = append(parts, item.Text)
+		case "tool_use":
+			parts = append(parts, item.Name+" "+string(item.Input))
+		case "tool_result":
+			parts = append(parts, "→ "+toolResultText(item.Content))
+		}
+	}
+	if len(parts) == 0 {
+		return line
+	}
+	return strings.Join(parts, " ")
+}
+
+// toolResultText returns the text of a tool result, which is either a string or a list of
+// content blocks
+func toolResultText(content json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(content, &text); err == nil {
+		return text
+	}
+	var blocks []struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(content, &blocks); err == nil {
+		texts := make([]string, 0, len(blocks))
+		for _, block := range blocks {
+			texts = append(texts, block.Text)
+		}
+		return strings.Join(texts, " ")
+	}
+	return string(content)
+}
+
+// conclusionSymbol returns a one-character status for a run conclusion
+func conclusionSymbol(conclusion string) string {
+	switch conclusion {
+	case "success":
+		return "✓"
+	case "failure", "timed_out", "startup_failure":
+		return "✗"
+	case "cancelled", "skipped":
+		return "-"
+	default:
+		return "•"
+	}
+}
+
+// truncateDisplay fits a transcript line on one row of the given width. Escape
+// sequences and tabs are removed so that they cannot break the layout.
+func truncateDisplay(line string, width int) string {
+	line = strings.NewReplacer("\t", "    ", "\r", "", "\n", " ").Replace(stringutil.StripANSI(line))
+	if lipgloss.Width(line) <= width {
+		return line
+	}
+	runes := []rune(line)
+	if len(runes) > width {
+		runes = runes[:max(0, width)]
+	}
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// openInBrowser opens a URL with GH_BROWSER or BROWSER when set, or with the default
+// browser of the operating system
+func openInBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch {
+	case os.Getenv("GH_BROWSER") != "":
+		cmd = exec.Command(os.Getenv("GH_BROWSER"), url)
+	case os.Getenv("BROWSER") != "":
+		cmd = exec.Command(os.Getenv("BROWSER"), url)
+	case runtime.GOOS == "darwin":
+		cmd = exec.Command("open", url)
+	case runtime.GOOS == "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	logsBrowserLog.Printf("Opening %s with %s", url, cmd.Path)
+	return cmd.Start()
+}
@@ -0,0 +1,190 @@
+//go:build !integration
+
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const browserTranscript = `Starting agent
+{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"make test"}}]}}
+{"type":"assistant","message":{"content":[{"type":"text","text":"Waiting for the tests"}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"t1","content":"FAIL: TestParse"}]}}
+{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t2","name":"mcp__github__get_issue","input":{"issue_number":1}}]}}
+Done`
+
+func TestBuildTranscriptEntries(t *testing.T) {
+	entries := buildTranscriptEntries(strings.Split(browserTranscript, "\n"))
+	require.Len(t, entries, 5, "Tool results should be folded into their call")
+
+	assert.Empty(t, entries[0].Tool)
+	assert.Equal(t, "Bash", entries[1].Tool)
+	require.Len(t, entries[1].Lines, 2, "The Bash call should hold its result")
+	assert.Contains(t, entries[1].Lines[1], "FAIL: TestParse")
+	assert.Empty(t, entries[2].Tool, "Text between a call and its result stays a separate line")
+	assert.Equal(t, "mcp__github__get_issue", entries[3].Tool)
+	assert.Len(t, entries[3].Lines, 1, "A call without result is a single line")
+	assert.Equal(t, "Done", entries[4].Lines[0])
+}
+
+func TestLoadBrowserRuns(t *testing.T) {
+	outputDir := t.TempDir()
+	now := time.Now().UTC()
+	writeGrepRun(t, outputDir, 100, "Triage", now.Add(-time.Hour), map[string]string{"agent-stdio.log": browserTranscript})
+	writeGrepRun(t, outputDir, 200, "Daily Report", now, nil)
+
+	runs, err := loadBrowserRuns(outputDir, "")
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, int64(200), runs[0].ID, "Newest run should come first")
+	assert.Equal(t, "Triage", runs[1].WorkflowName)
+
+	entries, err := loadBrowserTranscript(runs[1])
+	require.NoError(t, err)
+	assert.Len(t, entries, 5, "Should fall back to agent-stdio.log without aw_info.json")
+
+	entries, err = loadBrowserTranscript(runs[0])
+	require.NoError(t, err)
+	assert.Empty(t, entries, "A run without transcript has no entries")
+
+	runs, err = loadBrowserRuns(outputDir, "triage")
+	require.NoError(t, err)
+	require.Len(t, runs, 1, "Should filter on the workflow name")
+}
+
+// newTestLogsBrowser returns a browser over two runs whose transcripts come from memory
+func newTestLogsBrowser(t *testing.T) (logsBrowserModel, *[]string) {
+	t.Helper()
+	runs := []browserRun{
+		{ID: 200, WorkflowName: "Triage", Conclusion: "failure", URL: "https://github.com/octo/repo/actions/runs/200"},
+		{ID: 100, WorkflowName: "Daily Report", Conclusion: "success"},
+	}
+	m := newLogsBrowserModel(runs)
+	m.loadTranscript = func(run browserRun) ([]transcriptEntry, error) {
+		if run.ID == 100 {
+			return nil, errors.New("transcript unavailable")
+		}
+		return buildTranscriptEntries(strings.Split(browserTranscript, "\n")), nil
+	}
+	var opened []string
+	m.openURL = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+	return m, &opened
+}
+
+// pressKeys sends key presses to the browser and returns the resulting model
+func pressKeys(m logsBrowserModel, keys ...string) logsBrowserModel {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		model, _ := m.Update(msg)
+		m = model.(logsBrowserModel)
+	}
+	return m
+}
+
+func TestLogsBrowserNavigation(t *testing.T) {
+	t.Run("open run and fold tool calls", func(t *testing.T) {
+		m, _ := newTestLogsBrowser(t)
+		m = pressKeys(m, "enter")
+		assert.Equal(t, transcriptPane, m.focus, "Enter should focus the transcript")
+		require.Len(t, m.entries, 5)
+
+		view := m.View()
+		assert.Contains(t, view, `[Bash] {"command":"make test"}`, "Tool calls should be folded by default")
+		assert.Contains(t, view, "(no result)", "Calls without result should be marked")
+		assert.NotContains(t, view, "FAIL: TestParse")
+
+		m = pressKeys(m, "down", "enter")
+		assert.True(t, m.expanded[1], "Enter should expand the tool call under the cursor")
+		assert.Contains(t, m.View(), "FAIL: TestParse")
+
+		m = pressKeys(m, "a")
+		assert.Empty(t, m.expanded, "a should fold everything when something is expanded")
+		m = pressKeys(m, "a")
+		assert.True(t, m.expanded[1] && m.expanded[3], "a should expand every tool call")
+	})
+
+	t.Run("search transcript", func(t *testing.T) {
+		m, _ := newTestLogsBrowser(t)
+		m = pressKeys(m, "enter", "/", "t", "e", "s", "t", "p", "a", "r", "s", "e", "enter")
+		assert.Equal(t, "testparse", m.query)
+		assert.Equal(t, 1, m.cursor, "Search should jump to the tool call containing the match")
+		assert.True(t, m.expanded[1], "The matching tool call should be expanded")
+
+		m = pressKeys(m, "/", "n", "o", "p", "e", "enter")
+		assert.Contains(t, m.status, "No matches")
+	})
+
+	t.Run("filter runs", func(t *testing.T) {
+		m, _ := newTestLogsBrowser(t)
+		m = pressKeys(m, "/", "d", "a", "i", "l", "y", "enter")
+		require.Len(t, m.visible, 1)
+		run, ok := m.selectedRun()
+		require.True(t, ok)
+		assert.Equal(t, int64(100), run.ID)
+
+		m = pressKeys(m, "enter")
+		assert.Contains(t, m.status, "transcript unavailable", "Load errors should be shown in the footer")
+
+		m = pressKeys(m, "tab", "esc")
+		assert.Len(t, m.visible, 2, "esc should clear the run filter")
+	})
+
+	t.Run("open in browser", func(t *testing.T) {
+		m, opened := newTestLogsBrowser(t)
+		m = pressKeys(m, "o")
+		assert.Equal(t, []string{"https://github.com/octo/repo/actions/runs/200"}, *opened)
+
+		m = pressKeys(m, "down", "o")
+		assert.Len(t, *opened, 1, "Runs without URL cannot be opened")
+		assert.Contains(t, m.status, "has no URL")
+	})
+}
+
+func TestLogsBrowserScrolling(t *testing.T) {
+	m, _ := newTestLogsBrowser(t)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 7})
+	m = pressKeys(model.(logsBrowserModel), "enter", "G")
+
+	assert.Equal(t, 4, m.cursor)
+	assert.Equal(t, 2, m.offset, "The last entry should be scrolled into view")
+	assert.Contains(t, m.View(), "Done")
+}
+
+func TestTruncateDisplay(t *testing.T) {
+	assert.Equal(t, "short", truncateDisplay("short", 10))
+	assert.Equal(t, "abcd…", truncateDisplay("abcdefgh", 5))
+	assert.Equal(t, "    x", truncateDisplay("\tx", 10), "Tabs should be expanded")
+	assert.Equal(t, "red", truncateDisplay("\x1b[31mred\x1b[0m", 10), "Escape sequences should be removed")
+}
+
+func TestDescribeTranscriptLine(t *testing.T) {
+	lines := strings.Split(browserTranscript, "\n")
+	assert.Equal(t, "Starting agent", describeTranscriptLine(lines[0]), "Plain lines are unchanged")
+	assert.Equal(t, `Bash {"command":"make test"}`, describeTranscriptLine(lines[1]))
+	assert.Equal(t, "Waiting for the tests", describeTranscriptLine(lines[2]))
+	assert.Equal(t, "→ FAIL: TestParse", describeTranscriptLine(lines[3]))
+	assert.Equal(t, "→ first second", describeTranscriptLine(`{"type":"user","message":{"content":[{"type":"tool_result","content":[{"type":"text","text":"first"},{"type":"text","text":"second"}]}]}}`))
+	assert.Equal(t, `{"type":"system"}`, describeTranscriptLine(`{"type":"system"}`), "Events without content are unchanged")
+}
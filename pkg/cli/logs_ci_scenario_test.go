@@ -50,6 +50,10 @@ func TestLogsJSONOutputWithNoRuns(t *testing.T) {
 		10,                           // timeout
 		"summary.json",               // summaryFile
 		"",                           // safeOutputType
+		"",                           // otelExport
+		false,                        // engineReport
+		"",                           // reportFile
+		false,                        // resources
 	)
 
 	// Restore stdout and read output
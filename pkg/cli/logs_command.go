@@ -12,6 +12,7 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -85,6 +86,19 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` logs --parse                   # Parse logs and generate Markdown reports
   ` + string(constants.CLIExtensionPrefix) + ` logs --json                    # Output metrics in JSON format
   ` + string(constants.CLIExtensionPrefix) + ` logs --parse --json            # Generate both Markdown and JSON
+  ` + string(constants.CLIExtensionPrefix) + ` logs --resources               # Show runner CPU, memory, disk and network usage
+
+  # Transcript search (runs already downloaded to the output directory)
+  ` + string(constants.CLIExtensionPrefix) + ` logs --grep "rate limit"          # Search agent transcripts and step logs
+  ` + string(constants.CLIExtensionPrefix) + ` logs --grep "403" -C 2 --tool bash  # Show context, only Bash tool output
+  ` + string(constants.CLIExtensionPrefix) + ` logs --grep "panic" --since -1w     # Only runs from the last week
+  ` + string(constants.CLIExtensionPrefix) + ` logs --grep "timeout" --run 1234567 # Only a specific run
+
+  # Interactive browser (runs already downloaded to the output directory)
+  ` + string(constants.CLIExtensionPrefix) + ` logs --browse                      # Browse runs and transcripts with folded tool calls
+
+  # Run-to-run comparison (base run first, then the run to evaluate)
+  ` + string(constants.CLIExtensionPrefix) + ` logs --compare 1234567,1234599      # Diff tokens, duration, tool calls, errors and safe outputs
 
   # Cross-repository
   ` + string(constants.CLIExtensionPrefix) + ` logs weekly-research --repo owner/repo  # Download logs from specific repository`,
@@ -138,6 +152,10 @@ Examples:
 			repoOverride, _ := cmd.Flags().GetString("repo")
 			summaryFile, _ := cmd.Flags().GetString("summary-file")
 			safeOutputType, _ := cmd.Flags().GetString("safe-output")
+			otelExport, _ := cmd.Flags().GetString("otel")
+			engineReport, _ := cmd.Flags().GetBool("engine-report")
+			reportFile, _ := cmd.Flags().GetString("report")
+			resources, _ := cmd.Flags().GetBool("resources")
 
 			// Resolve relative dates to absolute dates for GitHub CLI
 			now := time.Now()
@@ -160,6 +178,18 @@ Examples:
 				logsCommandLog.Printf("Resolved end date to: %s", endDate)
 			}
 
+			if grepPattern, _ := cmd.Flags().GetString("grep"); grepPattern != "" {
+				return runLogsGrep(cmd, grepPattern, workflowName, outputDir, jsonOutput)
+			}
+
+			if browse, _ := cmd.Flags().GetBool("browse"); browse {
+				return runLogsBrowser(outputDir, workflowName)
+			}
+
+			if compareRunIDs, _ := cmd.Flags().GetInt64Slice("compare"); len(compareRunIDs) > 0 {
+				return runLogsCompare(cmd.Context(), compareRunIDs, outputDir, repoOverride, verbose, jsonOutput)
+			}
+
 			// Validate engine parameter using the engine registry
 			if engine != "" {
 				logsCommandLog.Printf("Validating engine parameter: %s", engine)
@@ -172,7 +202,7 @@ Examples:
 
 			logsCommandLog.Printf("Executing logs download: workflow=%s, count=%d, engine=%s", workflowName, count, engine)
 
-			return DownloadWorkflowLogs(cmd.Context(), workflowName, count, startDate, endDate, outputDir, engine, ref, beforeRunID, afterRunID, repoOverride, verbose, toolGraph, noStaged, firewallOnly, noFirewall, parse, jsonOutput, timeout, summaryFile, safeOutputType)
+			return DownloadWorkflowLogs(cmd.Context(), workflowName, count, startDate, endDate, outputDir, engine, ref, beforeRunID, afterRunID, repoOverride, verbose, toolGraph, noStaged, firewallOnly, noFirewall, parse, jsonOutput, timeout, summaryFile, safeOutputType, otelExport, engineReport, reportFile, resources)
 		},
 	}
 
@@ -195,7 +225,22 @@ Examples:
 	addJSONFlag(logsCmd)
 	logsCmd.Flags().Int("timeout", 0, "Download timeout in seconds (0 = no timeout)")
 	logsCmd.Flags().String("summary-file", "summary.json", "Path to write the summary JSON file relative to output directory (use empty string to disable)")
+	logsCmd.Flags().String("otel", "", "Export runs, jobs, steps and tool calls as OpenTelemetry traces to an OTLP/HTTP endpoint (http(s)://...) or an OTLP/JSON file")
+	logsCmd.Flags().String("report", "", "Write a standalone HTML report of the downloaded runs (timeline, tool calls, token usage, errors, safe outputs) to this file")
+	logsCmd.Flags().Bool("engine-report", false, "Show the model, engine CLI version and prompt version behind each run and flag scheduled workflows that shifted models")
+	logsCmd.Flags().Bool("resources", false, "Show the CPU, memory, disk and network usage recorded by workflows with resource-telemetry enabled and flag runs close to the runner's limits")
+	logsCmd.Flags().String("grep", "", "Search transcripts of already downloaded runs for a regular expression instead of downloading runs")
+	logsCmd.Flags().IntP("context", "C", 0, "Number of context lines to show around each --grep match")
+	logsCmd.Flags().String("tool", "", "Only show --grep matches from calls to this tool (e.g., bash, github)")
+	logsCmd.Flags().String("since", "", "Only search runs created after this date with --grep (YYYY-MM-DD or delta like -1d, -1w, -1mo)")
+	logsCmd.Flags().Int64Slice("run", nil, "Only search these run IDs with --grep (repeatable)")
+	logsCmd.Flags().Int64Slice("compare", nil, "Compare the metrics of two runs (--compare <base-run-id>,<head-run-id>) and highlight regressions instead of downloading a list of runs")
 	logsCmd.MarkFlagsMutuallyExclusive("firewall", "no-firewall")
+	logsCmd.Flags().Bool("browse", false, "Browse already downloaded runs in an interactive terminal UI (run list, transcript with folded tool calls, search) instead of downloading runs")
+	logsCmd.MarkFlagsMutuallyExclusive("grep", "compare")
+	logsCmd.MarkFlagsMutuallyExclusive("browse", "grep")
+	logsCmd.MarkFlagsMutuallyExclusive("browse", "compare")
+	logsCmd.MarkFlagsMutuallyExclusive("browse", "json")
 
 	// Register completions for logs command
 	logsCmd.ValidArgsFunction = CompleteWorkflowNames
@@ -250,3 +295,51 @@ Examples:
 // parseAgentLog runs the JavaScript log parser on agent logs and writes markdown to log.md
 
 // parseFirewallLogs runs the JavaScript firewall log parser and writes markdown to firewall.md
+
+// runLogsGrep searches the transcripts of runs already downloaded into outputDir
+func runLogsGrep(cmd *cobra.Command, pattern, workflowName, outputDir string, jsonOutput bool) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid --grep pattern '%s': %w", pattern, err)
+	}
+
+	contextLines, _ := cmd.Flags().GetInt("context")
+	if contextLines < 0 {
+		return fmt.Errorf("--context must be non-negative, got %d", contextLines)
+	}
+	tool, _ := cmd.Flags().GetString("tool")
+	since, _ := cmd.Flags().GetString("since")
+	runIDs, _ := cmd.Flags().GetInt64Slice("run")
+
+	opts := LogGrepOptions{
+		Pattern:      re,
+		Context:      contextLines,
+		Tool:         tool,
+		RunIDs:       runIDs,
+		WorkflowName: workflowName,
+	}
+	if since != "" {
+		resolvedSince, err := workflow.ResolveRelativeDate(since, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid since format '%s': %w", since, err)
+		}
+		opts.Since, err = parseGrepSince(resolvedSince)
+		if err != nil {
+			return fmt.Errorf("invalid since format '%s': %w", since, err)
+		}
+	}
+
+	matches, err := SearchLogTranscripts(outputDir, opts)
+	if err != nil {
+		return err
+	}
+	return renderLogGrepMatches(matches, jsonOutput)
+}
+
+// parseGrepSince parses an absolute --since date (RFC 3339 timestamp or YYYY-MM-DD)
+func parseGrepSince(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
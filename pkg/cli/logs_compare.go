@@ -0,0 +1,280 @@
+// This file provides run-to-run comparison for the logs command.
+//
+// With --compare <base>,<head>, the logs command downloads (or loads from the
+// output directory) two runs and diffs their parsed metrics: token usage,
+// duration, turns, tool calls per tool, errors, and the safe outputs each run
+// produced. Significant regressions in the head run are highlighted so prompt
+// changes can be evaluated against a known-good run.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var logsCompareLog = logger.New("cli:logs_compare")
+
+// compareRegressionThreshold is the relative increase in token usage, duration,
+// turns or tool calls that is reported as a regression
+const compareRegressionThreshold = 0.2
+
+// RunComparison is the metric diff between a base run and a head run
+type RunComparison struct {
+	BaseRunID   int64              `json:"base_run_id" console:"-"`
+	HeadRunID   int64              `json:"head_run_id" console:"-"`
+	Metrics     []RunComparisonRow `json:"metrics" console:"title:Run Metrics"`
+	ToolCalls   []RunComparisonRow `json:"tool_calls,omitempty" console:"title:Tool Calls,omitempty"`
+	SafeOutputs []RunComparisonRow `json:"safe_outputs,omitempty" console:"title:Safe Outputs,omitempty"`
+	Regressions []string           `json:"regressions,omitempty" console:"-"`
+}
+
+// RunComparisonRow compares one metric between the base and head runs
+type RunComparisonRow struct {
+	Name       string `json:"name" console:"header:Name"`
+	Base       int    `json:"base" console:"header:Base"`
+	Head       int    `json:"head" console:"header:Head"`
+	Change     string `json:"change" console:"header:Change"`
+	Regression bool   `json:"regression,omitempty" console:"-"`
+}
+
+// runLogsCompare downloads the two runs (reusing cached summaries) and renders their diff
+func runLogsCompare(ctx context.Context, runIDs []int64, outputDir, repoOverride string, verbose, jsonOutput bool) error {
+	if len(runIDs) != 2 {
+		return fmt.Errorf("--compare takes exactly two run IDs (base,head), got %d", len(runIDs))
+	}
+	if runIDs[0] == runIDs[1] {
+		return fmt.Errorf("--compare needs two different runs, got %d twice", runIDs[0])
+	}
+	logsCompareLog.Printf("Comparing runs: base=%d, head=%d", runIDs[0], runIDs[1])
+
+	var owner, repo string
+	if repoOverride != "" {
+		parts := strings.SplitN(repoOverride, "/", 2)
+		if len(parts) == 2 {
+			owner, repo = parts[0], parts[1]
+		}
+	}
+
+	runs := make([]WorkflowRun, 0, len(runIDs))
+	for _, runID := range runIDs {
+		// Runs that were already processed are compared offline from their cached summary
+		if _, ok := loadRunSummary(filepath.Join(outputDir, fmt.Sprintf("run-%d", runID)), verbose); ok {
+			runs = append(runs, WorkflowRun{DatabaseID: runID})
+			continue
+		}
+		run, err := fetchWorkflowRunMetadata(runID, owner, repo, "", verbose)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, run)
+	}
+
+	results := downloadRunArtifactsConcurrent(ctx, runs, outputDir, verbose, len(runs), repoOverride)
+	if len(results) != len(runs) {
+		return errors.New("run comparison was interrupted")
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			return fmt.Errorf("failed to download run %d: %w", result.Run.DatabaseID, result.Error)
+		}
+	}
+
+	comparison := buildRunComparison(results[0], results[1])
+	return renderRunComparison(comparison, jsonOutput)
+}
+
+// buildRunComparison diffs the metrics of two processed runs
+func buildRunComparison(base, head DownloadResult) *RunComparison {
+	comparison := &RunComparison{
+		BaseRunID: base.Run.DatabaseID,
+		HeadRunID: head.Run.DatabaseID,
+	}
+
+	// Totals grow with the work done, so only a significant relative increase is flagged
+	addScaled := func(name string, baseValue, headValue int) {
+		row := newRunComparisonRow(name, baseValue, headValue)
+		row.Regression = baseValue > 0 && float64(headValue-baseValue) > float64(baseValue)*compareRegressionThreshold
+		comparison.addMetric(row)
+	}
+	// Failures should not happen at all, so any increase is flagged
+	addCount := func(name string, baseValue, headValue int) {
+		row := newRunComparisonRow(name, baseValue, headValue)
+		row.Regression = headValue > baseValue
+		comparison.addMetric(row)
+	}
+
+	addScaled("Token Usage", base.Metrics.TokenUsage, head.Metrics.TokenUsage)
+	addScaled("Duration (s)", int(comparedRunDuration(base.Run).Seconds()), int(comparedRunDuration(head.Run).Seconds()))
+	addScaled("Turns", base.Metrics.Turns, head.Metrics.Turns)
+	addScaled("Tool Calls", totalToolCalls(base.Metrics), totalToolCalls(head.Metrics))
+	addCount("Errors", base.Run.ErrorCount, head.Run.ErrorCount)
+	addCount("Warnings", base.Run.WarningCount, head.Run.WarningCount)
+	addCount("MCP Failures", len(base.MCPFailures), len(head.MCPFailures))
+	addCount("Missing Tools", len(base.MissingTools), len(head.MissingTools))
+	addCount("Missing Data", len(base.MissingData), len(head.MissingData))
+
+	baseTools := toolCallCounts(base.Metrics)
+	headTools := toolCallCounts(head.Metrics)
+	for _, name := range unionKeys(baseTools, headTools) {
+		row := newRunComparisonRow(name, baseTools[name], headTools[name])
+		row.Regression = baseTools[name] > 0 && float64(headTools[name]-baseTools[name]) > float64(baseTools[name])*compareRegressionThreshold
+		comparison.ToolCalls = append(comparison.ToolCalls, row)
+		if row.Regression {
+			comparison.Regressions = append(comparison.Regressions, fmt.Sprintf("Calls to %s went from %d to %d", name, row.Base, row.Head))
+		}
+	}
+
+	baseOutputs := safeOutputTypeCounts(base.LogsPath)
+	headOutputs := safeOutputTypeCounts(head.LogsPath)
+	for _, name := range unionKeys(baseOutputs, headOutputs) {
+		// A safe output type the base run produced but the head run did not is lost work
+		row := newRunComparisonRow(name, baseOutputs[name], headOutputs[name])
+		row.Regression = baseOutputs[name] > 0 && headOutputs[name] == 0
+		comparison.SafeOutputs = append(comparison.SafeOutputs, row)
+		if row.Regression {
+			comparison.Regressions = append(comparison.Regressions, fmt.Sprintf("Run %d produced no %s output (base run produced %d)", comparison.HeadRunID, name, row.Base))
+		}
+	}
+
+	logsCompareLog.Printf("Built run comparison: tools=%d, safe_outputs=%d, regressions=%d", len(comparison.ToolCalls), len(comparison.SafeOutputs), len(comparison.Regressions))
+	return comparison
+}
+
+// addMetric appends a run metric row and records it when it regressed
+func (c *RunComparison) addMetric(row RunComparisonRow) {
+	c.Metrics = append(c.Metrics, row)
+	if row.Regression {
+		c.Regressions = append(c.Regressions, fmt.Sprintf("%s went from %d to %d (%s)", row.Name, row.Base, row.Head, row.Change))
+	}
+}
+
+// newRunComparisonRow builds a row with the change from base to head
+func newRunComparisonRow(name string, baseValue, headValue int) RunComparisonRow {
+	return RunComparisonRow{
+		Name:   name,
+		Base:   baseValue,
+		Head:   headValue,
+		Change: formatComparisonChange(baseValue, headValue),
+	}
+}
+
+// formatComparisonChange renders the difference between two values, e.g. "+120 (+25%)"
+func formatComparisonChange(baseValue, headValue int) string {
+	delta := headValue - baseValue
+	switch {
+	case delta == 0:
+		return "="
+	case baseValue == 0:
+		return fmt.Sprintf("%+d (new)", delta)
+	default:
+		percent := math.Round(float64(delta) / float64(baseValue) * 100)
+		return fmt.Sprintf("%+d (%+.0f%%)", delta, percent)
+	}
+}
+
+// comparedRunDuration returns the run duration, computing it from the run timestamps
+// when the summary did not record one
+func comparedRunDuration(run WorkflowRun) time.Duration {
+	if run.Duration > 0 {
+		return run.Duration
+	}
+	if !run.StartedAt.IsZero() && run.UpdatedAt.After(run.StartedAt) {
+		return run.UpdatedAt.Sub(run.StartedAt)
+	}
+	return 0
+}
+
+// totalToolCalls sums the calls of every tool in the run
+func totalToolCalls(metrics LogMetrics) int {
+	total := 0
+	for _, call := range metrics.ToolCalls {
+		total += call.CallCount
+	}
+	return total
+}
+
+// toolCallCounts returns the number of calls per tool
+func toolCallCounts(metrics LogMetrics) map[string]int {
+	counts := make(map[string]int, len(metrics.ToolCalls))
+	for _, call := range metrics.ToolCalls {
+		counts[call.Name] += call.CallCount
+	}
+	return counts
+}
+
+// safeOutputTypeCounts returns the number of safe output items per type in the run's agent output
+func safeOutputTypeCounts(runDir string) map[string]int {
+	counts := make(map[string]int)
+	if runDir == "" {
+		return counts
+	}
+	for _, item := range readSafeOutputItems(runDir) {
+		if item.Type != "" {
+			counts[item.Type]++
+		}
+	}
+	return counts
+}
+
+// unionKeys returns the keys of both maps in sorted order
+func unionKeys(a, b map[string]int) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var keys []string
+	for _, m := range []map[string]int{a, b} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// renderRunComparison prints the comparison as JSON or as tables followed by regression warnings
+func renderRunComparison(comparison *RunComparison, jsonOutput bool) error {
+	if jsonOutput {
+		data, err := json.MarshalIndent(comparison, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal run comparison: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	markRegressions := func(rows []RunComparisonRow) {
+		for i := range rows {
+			if rows[i].Regression {
+				rows[i].Change += " ⚠"
+			}
+		}
+	}
+	markRegressions(comparison.Metrics)
+	markRegressions(comparison.ToolCalls)
+	markRegressions(comparison.SafeOutputs)
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Comparing run %d (base) with run %d (head)", comparison.BaseRunID, comparison.HeadRunID)))
+	fmt.Print(console.RenderStruct(comparison))
+
+	if len(comparison.Regressions) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("No significant regressions"))
+		return nil
+	}
+	for _, regression := range comparison.Regressions {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(regression))
+	}
+	return nil
+}
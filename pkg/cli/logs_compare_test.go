@@ -0,0 +1,147 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCompareAgentOutput(t *testing.T, dir, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, constants.AgentOutputFilename), []byte(content), 0644))
+}
+
+func TestBuildRunComparison(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "logs-compare")
+	baseDir := filepath.Join(tmpDir, "run-100")
+	headDir := filepath.Join(tmpDir, "run-200")
+	writeCompareAgentOutput(t, baseDir, `{"items":[{"type":"create_issue","title":"a"},{"type":"add_comment","body":"b"}]}`)
+	writeCompareAgentOutput(t, headDir, `{"items":[{"type":"add_comment","body":"b"},{"type":"add_comment","body":"c"}]}`)
+
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	base := DownloadResult{
+		Run: WorkflowRun{DatabaseID: 100, StartedAt: start, UpdatedAt: start.Add(100 * time.Second)},
+		Metrics: LogMetrics{
+			TokenUsage: 1000,
+			Turns:      5,
+			ToolCalls: []workflow.ToolCallInfo{
+				{Name: "github::issue_read", CallCount: 4},
+				{Name: "bash", CallCount: 2},
+			},
+		},
+		LogsPath: baseDir,
+	}
+	head := DownloadResult{
+		Run: WorkflowRun{DatabaseID: 200, Duration: 110 * time.Second, ErrorCount: 1},
+		Metrics: LogMetrics{
+			TokenUsage: 1500,
+			Turns:      5,
+			ToolCalls: []workflow.ToolCallInfo{
+				{Name: "github::issue_read", CallCount: 9},
+				{Name: "edit", CallCount: 1},
+			},
+		},
+		MCPFailures: []MCPFailureReport{{ServerName: "github", Status: "failed"}},
+		LogsPath:    headDir,
+	}
+
+	comparison := buildRunComparison(base, head)
+	assert.Equal(t, int64(100), comparison.BaseRunID)
+	assert.Equal(t, int64(200), comparison.HeadRunID)
+
+	metrics := make(map[string]RunComparisonRow)
+	for _, row := range comparison.Metrics {
+		metrics[row.Name] = row
+	}
+	assert.Equal(t, RunComparisonRow{Name: "Token Usage", Base: 1000, Head: 1500, Change: "+500 (+50%)", Regression: true}, metrics["Token Usage"])
+	assert.Equal(t, RunComparisonRow{Name: "Duration (s)", Base: 100, Head: 110, Change: "+10 (+10%)"}, metrics["Duration (s)"], "Increase under the threshold should not be a regression")
+	assert.Equal(t, RunComparisonRow{Name: "Turns", Base: 5, Head: 5, Change: "="}, metrics["Turns"])
+	assert.Equal(t, RunComparisonRow{Name: "Tool Calls", Base: 6, Head: 10, Change: "+4 (+67%)", Regression: true}, metrics["Tool Calls"])
+	assert.True(t, metrics["Errors"].Regression, "Any new error should be a regression")
+	assert.True(t, metrics["MCP Failures"].Regression, "Any new MCP failure should be a regression")
+	assert.False(t, metrics["Missing Tools"].Regression)
+
+	assert.Equal(t, []RunComparisonRow{
+		{Name: "bash", Base: 2, Head: 0, Change: "-2 (-100%)"},
+		{Name: "edit", Base: 0, Head: 1, Change: "+1 (new)"},
+		{Name: "github::issue_read", Base: 4, Head: 9, Change: "+5 (+125%)", Regression: true},
+	}, comparison.ToolCalls, "Tool calls should be compared per tool")
+
+	assert.Equal(t, []RunComparisonRow{
+		{Name: "add_comment", Base: 1, Head: 2, Change: "+1 (+100%)"},
+		{Name: "create_issue", Base: 1, Head: 0, Change: "-1 (-100%)", Regression: true},
+	}, comparison.SafeOutputs, "Safe outputs should be compared per type")
+
+	assert.Equal(t, []string{
+		"Token Usage went from 1000 to 1500 (+500 (+50%))",
+		"Tool Calls went from 6 to 10 (+4 (+67%))",
+		"Errors went from 0 to 1 (+1 (new))",
+		"MCP Failures went from 0 to 1 (+1 (new))",
+		"Calls to github::issue_read went from 4 to 9",
+		"Run 200 produced no create_issue output (base run produced 1)",
+	}, comparison.Regressions)
+}
+
+func TestRunLogsCompareValidatesRunIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		runIDs  []int64
+		wantErr string
+	}{
+		{name: "one run", runIDs: []int64{1}, wantErr: "exactly two run IDs"},
+		{name: "three runs", runIDs: []int64{1, 2, 3}, wantErr: "exactly two run IDs"},
+		{name: "same run twice", runIDs: []int64{5, 5}, wantErr: "two different runs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runLogsCompare(context.Background(), tt.runIDs, t.TempDir(), "", false, true)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunLogsCompareUsesCachedSummaries(t *testing.T) {
+	outputDir := testutil.TempDir(t, "logs-compare-cached")
+	for _, runID := range []int64{300, 400} {
+		runDir := filepath.Join(outputDir, fmt.Sprintf("run-%d", runID))
+		require.NoError(t, os.MkdirAll(runDir, 0755))
+		summary := &RunSummary{
+			CLIVersion: GetVersion(),
+			RunID:      runID,
+			Run:        WorkflowRun{DatabaseID: runID},
+			Metrics:    LogMetrics{TokenUsage: int(runID)},
+		}
+		require.NoError(t, saveRunSummary(runDir, summary, false))
+	}
+
+	// Redirect stdout to capture JSON output
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runLogsCompare(context.Background(), []int64{300, 400}, outputDir, "", false, true)
+
+	w.Close()
+	os.Stdout = oldStdout
+	captured, _ := io.ReadAll(r)
+	output := string(captured)
+
+	require.NoError(t, err, "Cached runs should be compared without contacting GitHub")
+	assert.Contains(t, output, `"base_run_id": 300`)
+	assert.Contains(t, output, `"change": "+100 (+33%)"`)
+}
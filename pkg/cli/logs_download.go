@@ -23,6 +23,7 @@ import (
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/fileutil"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/tty"
 	"github.com/github/gh-aw/pkg/workflow"
 )
 
@@ -207,6 +208,49 @@ func flattenUnifiedArtifact(outputDir string, verbose bool) error {
 	return nil
 }
 
+// stripArtifactNamePrefix renames artifact directories downloaded from a workflow that sets
+// artifacts.prefix back to their unprefixed names. The prefix is detected from the activation
+// artifact, which every compiled workflow uploads.
+func stripArtifactNamePrefix(outputDir string, verbose bool) error {
+	if _, err := os.Stat(filepath.Join(outputDir, "activation")); err == nil {
+		return nil
+	}
+
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		return err
+	}
+	prefix := ""
+	for _, entry := range entries {
+		if name, found := strings.CutSuffix(entry.Name(), "-activation"); found && entry.IsDir() && name != "" {
+			prefix = name + "-"
+			break
+		}
+	}
+	if prefix == "" {
+		return nil
+	}
+
+	logsDownloadLog.Printf("Stripping artifact name prefix %q in %s", prefix, outputDir)
+	for _, entry := range entries {
+		name, found := strings.CutPrefix(entry.Name(), prefix)
+		if !found || name == "" {
+			continue
+		}
+		dest := filepath.Join(outputDir, name)
+		if _, err := os.Stat(dest); err == nil {
+			continue
+		}
+		if err := os.Rename(filepath.Join(outputDir, entry.Name()), dest); err != nil {
+			return fmt.Errorf("failed to rename %s: %w", entry.Name(), err)
+		}
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Removed artifact name prefix '%s'", strings.TrimSuffix(prefix, "-"))))
+	}
+	return nil
+}
+
 // flattenActivationArtifact flattens the activation artifact directory structure
 // The activation artifact contains aw_info.json and aw-prompts/prompt.txt
 // This function moves those files to the root output directory and removes the nested structure
@@ -391,14 +435,14 @@ func downloadWorkflowRunLogs(runID int64, outputDir string, verbose bool, owner,
 		args = append(args, "--hostname", hostname)
 	}
 
-	output, err := workflow.RunGH("Downloading workflow logs...", args...)
-	if err != nil {
+	// Stream the zip straight to disk instead of buffering the whole archive in memory
+	if err := downloadGHOutputToFile(tmpZip, "Downloading workflow logs...", args...); err != nil {
 		// Check for authentication errors
 		if strings.Contains(err.Error(), "exit status 4") {
 			return errors.New("GitHub CLI authentication required. Run 'gh auth login' first")
 		}
 		// If logs are not found or run has no logs, this is not a critical error
-		if strings.Contains(string(output), "not found") || strings.Contains(err.Error(), "410") {
+		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "410") {
 			if verbose {
 				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("No logs found for run %d (may be expired or unavailable)", runID)))
 			}
@@ -407,11 +451,6 @@ func downloadWorkflowRunLogs(runID int64, outputDir string, verbose bool, owner,
 		return fmt.Errorf("failed to download workflow run logs for run %d: %w", runID, err)
 	}
 
-	// Write the downloaded zip content to temporary file
-	if err := os.WriteFile(tmpZip, output, 0644); err != nil {
-		return fmt.Errorf("failed to write logs zip file: %w", err)
-	}
-
 	// Create a subdirectory for workflow logs to keep the run directory organized
 	workflowLogsDir := filepath.Join(outputDir, "workflow-logs")
 	if err := os.MkdirAll(workflowLogsDir, 0755); err != nil {
@@ -430,8 +469,117 @@ func downloadWorkflowRunLogs(runID int64, outputDir string, verbose bool, owner,
 	return nil
 }
 
-// unzipFile extracts a zip file to a destination directory
+// downloadGHOutputToFile runs a gh CLI command and streams its stdout into destPath.
+// Stderr is captured and included in the returned error so callers can inspect it.
+func downloadGHOutputToFile(destPath string, spinnerMessage string, args ...string) (downloadErr error) {
+	file, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", destPath, err)
+	}
+	defer func() {
+		if err := file.Close(); downloadErr == nil && err != nil {
+			downloadErr = fmt.Errorf("failed to close %s: %w", destPath, err)
+		}
+	}()
+
+	var stderr strings.Builder
+	cmd := workflow.ExecGH(args...)
+	cmd.Stdout = file
+	cmd.Stderr = &stderr
+
+	if tty.IsStderrTerminal() {
+		spinner := console.NewSpinner(spinnerMessage)
+		spinner.Start()
+		defer spinner.Stop()
+	}
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// ZipExtractionLimits caps what a downloaded zip archive may expand to on disk.
+// A zero value for any field disables that particular check.
+type ZipExtractionLimits struct {
+	// MaxEntries is the maximum number of entries (files and directories) in the archive
+	MaxEntries int
+	// MaxTotalSize is the maximum number of bytes extracted from the whole archive
+	MaxTotalSize int64
+	// MaxFileSize is the maximum number of bytes extracted from a single entry
+	MaxFileSize int64
+	// MaxCompressionRatio is the maximum uncompressed/compressed ratio of a single entry
+	MaxCompressionRatio float64
+}
+
+const (
+	defaultZipMaxEntries          = 10000
+	defaultZipMaxTotalSize        = 4 * 1024 * 1024 * 1024 // 4GB
+	defaultZipMaxFileSize         = 1 * 1024 * 1024 * 1024 // 1GB
+	defaultZipMaxCompressionRatio = 1000
+	// Small entries are exempt from the compression ratio check: a few kilobytes of
+	// highly repetitive log output legitimately compress far better than 1000:1.
+	zipRatioCheckMinSize = 1 * 1024 * 1024 // 1MB
+)
+
+// DefaultZipExtractionLimits returns the limits applied to downloaded log archives
+func DefaultZipExtractionLimits() ZipExtractionLimits {
+	return ZipExtractionLimits{
+		MaxEntries:          defaultZipMaxEntries,
+		MaxTotalSize:        defaultZipMaxTotalSize,
+		MaxFileSize:         defaultZipMaxFileSize,
+		MaxCompressionRatio: defaultZipMaxCompressionRatio,
+	}
+}
+
+// zipExtractionLimitsFromEnv returns the default limits overridden by the
+// GH_AW_LOGS_ZIP_MAX_ENTRIES, GH_AW_LOGS_ZIP_MAX_TOTAL_SIZE, GH_AW_LOGS_ZIP_MAX_FILE_SIZE
+// and GH_AW_LOGS_ZIP_MAX_RATIO environment variables. Invalid values are ignored.
+func zipExtractionLimitsFromEnv() ZipExtractionLimits {
+	limits := DefaultZipExtractionLimits()
+	if v, ok := parseZipLimitEnv("GH_AW_LOGS_ZIP_MAX_ENTRIES"); ok {
+		limits.MaxEntries = int(v)
+	}
+	if v, ok := parseZipLimitEnv("GH_AW_LOGS_ZIP_MAX_TOTAL_SIZE"); ok {
+		limits.MaxTotalSize = v
+	}
+	if v, ok := parseZipLimitEnv("GH_AW_LOGS_ZIP_MAX_FILE_SIZE"); ok {
+		limits.MaxFileSize = v
+	}
+	if v, ok := parseZipLimitEnv("GH_AW_LOGS_ZIP_MAX_RATIO"); ok {
+		limits.MaxCompressionRatio = float64(v)
+	}
+	return limits
+}
+
+// parseZipLimitEnv parses a non-negative integer limit from an environment variable
+func parseZipLimitEnv(name string) (int64, bool) {
+	raw := strings.TrimSpace(os.Getenv(name))
+	if raw == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || v < 0 {
+		logsDownloadLog.Printf("Ignoring invalid %s=%q", name, raw)
+		return 0, false
+	}
+	return v, true
+}
+
+// unzipFile extracts a zip file to a destination directory using the default
+// extraction limits (overridable through the environment)
 func unzipFile(zipPath, destDir string, verbose bool) error {
+	return unzipFileWithLimits(zipPath, destDir, zipExtractionLimitsFromEnv(), verbose)
+}
+
+// unzipFileWithLimits extracts a zip file to a destination directory, streaming each
+// entry to disk and aborting as soon as the archive exceeds one of the limits
+func unzipFileWithLimits(zipPath, destDir string, limits ZipExtractionLimits, verbose bool) error {
+	logsDownloadLog.Printf("Extracting zip: path=%s, dest=%s, limits=%+v", zipPath, destDir, limits)
+
 	// Open the zip file
 	r, err := zip.OpenReader(zipPath)
 	if err != nil {
@@ -439,9 +587,25 @@ func unzipFile(zipPath, destDir string, verbose bool) error {
 	}
 	defer r.Close()
 
-	// Extract each file in the zip
+	if limits.MaxEntries > 0 && len(r.File) > limits.MaxEntries {
+		return fmt.Errorf("zip file has too many entries: %d (limit %d)", len(r.File), limits.MaxEntries)
+	}
+
+	// Reject archives whose headers already declare more data than allowed before writing anything
+	if limits.MaxTotalSize > 0 {
+		var declared uint64
+		for _, f := range r.File {
+			declared += f.UncompressedSize64
+			if declared > uint64(limits.MaxTotalSize) {
+				return fmt.Errorf("zip file too large: declared uncompressed size exceeds %d bytes", limits.MaxTotalSize)
+			}
+		}
+	}
+
+	// Extract each file in the zip, tracking the bytes actually written since headers can lie
+	budget := &zipExtractionBudget{limits: limits}
 	for _, f := range r.File {
-		if err := extractZipFile(f, destDir, verbose); err != nil {
+		if err := extractZipEntry(f, destDir, budget, verbose); err != nil {
 			return err
 		}
 	}
@@ -449,8 +613,19 @@ func unzipFile(zipPath, destDir string, verbose bool) error {
 	return nil
 }
 
-// extractZipFile extracts a single file from a zip archive
-func extractZipFile(f *zip.File, destDir string, verbose bool) (extractErr error) {
+// zipExtractionBudget tracks how much of the total size limit has been used
+type zipExtractionBudget struct {
+	limits  ZipExtractionLimits
+	written int64
+}
+
+// extractZipFile extracts a single file from a zip archive using the default limits
+func extractZipFile(f *zip.File, destDir string, verbose bool) error {
+	return extractZipEntry(f, destDir, &zipExtractionBudget{limits: DefaultZipExtractionLimits()}, verbose)
+}
+
+// extractZipEntry extracts a single file from a zip archive within the remaining budget
+func extractZipEntry(f *zip.File, destDir string, budget *zipExtractionBudget, verbose bool) (extractErr error) {
 	// #nosec G305 - Path traversal is prevented by filepath.Clean and prefix check below
 	// Validate file name doesn't contain path traversal attempts
 	cleanName := filepath.Clean(f.Name)
@@ -476,11 +651,26 @@ func extractZipFile(f *zip.File, destDir string, verbose bool) (extractErr error
 		return os.MkdirAll(filePath, os.ModePerm)
 	}
 
-	// Decompression bomb protection - limit individual file size to 1GB
-	// #nosec G110 - Decompression bomb is mitigated by size check below
-	const maxFileSize = 1 * 1024 * 1024 * 1024 // 1GB
-	if f.UncompressedSize64 > maxFileSize {
-		return fmt.Errorf("file too large in zip (>1GB): %s (%d bytes)", f.Name, f.UncompressedSize64)
+	// Decompression bomb protection based on the declared sizes
+	// #nosec G110 - Decompression bomb is mitigated by the size checks below
+	limits := budget.limits
+	if limits.MaxFileSize > 0 && f.UncompressedSize64 > uint64(limits.MaxFileSize) {
+		return fmt.Errorf("file too large in zip: %s (%d bytes, limit %d)", f.Name, f.UncompressedSize64, limits.MaxFileSize)
+	}
+	if err := checkZipCompressionRatio(f.Name, f.UncompressedSize64, f.CompressedSize64, limits); err != nil {
+		return err
+	}
+
+	// The entry may write at most the smaller of the per-file limit and the remaining total budget
+	maxBytes := int64(-1)
+	if limits.MaxFileSize > 0 {
+		maxBytes = limits.MaxFileSize
+	}
+	if limits.MaxTotalSize > 0 {
+		remaining := max(limits.MaxTotalSize-budget.written, 0)
+		if maxBytes < 0 || remaining < maxBytes {
+			maxBytes = remaining
+		}
 	}
 
 	// Create parent directory if needed
@@ -507,23 +697,45 @@ func extractZipFile(f *zip.File, destDir string, verbose bool) (extractErr error
 		if err := destFile.Close(); extractErr == nil && err != nil {
 			extractErr = fmt.Errorf("failed to close destination file: %w", err)
 		}
+		// Don't leave truncated output behind when extraction was aborted
+		if extractErr != nil {
+			_ = os.Remove(filePath)
+		}
 	}()
 
-	// Copy the content with size limit enforcement
-	// Use LimitReader to prevent reading more than declared size
-	limitedReader := io.LimitReader(srcFile, int64(maxFileSize))
-	written, err := io.Copy(destFile, limitedReader)
+	// Stream the content, reading at most one byte past the limit so overruns are detected
+	var src io.Reader = srcFile
+	if maxBytes >= 0 {
+		src = io.LimitReader(srcFile, maxBytes+1)
+	}
+	written, err := io.Copy(destFile, src)
+	budget.written += written
 	if err != nil {
-		extractErr = fmt.Errorf("failed to extract file: %w", err)
-		return extractErr
+		return fmt.Errorf("failed to extract file: %w", err)
 	}
 
-	// Verify we didn't exceed the size limit
-	if uint64(written) > maxFileSize {
-		extractErr = fmt.Errorf("file extraction exceeded size limit: %s", f.Name)
-		return extractErr
+	// Verify the bytes actually written (headers may understate the real size)
+	if maxBytes >= 0 && written > maxBytes {
+		if limits.MaxFileSize > 0 && written > limits.MaxFileSize {
+			return fmt.Errorf("file extraction exceeded size limit: %s (limit %d bytes)", f.Name, limits.MaxFileSize)
+		}
+		return fmt.Errorf("zip extraction exceeded total size limit of %d bytes at %s", limits.MaxTotalSize, f.Name)
 	}
+	return checkZipCompressionRatio(f.Name, uint64(written), f.CompressedSize64, limits)
+}
 
+// checkZipCompressionRatio rejects entries that expand suspiciously well, which is the
+// signature of a decompression bomb
+func checkZipCompressionRatio(name string, uncompressed, compressed uint64, limits ZipExtractionLimits) error {
+	if limits.MaxCompressionRatio <= 0 || uncompressed < zipRatioCheckMinSize {
+		return nil
+	}
+	if compressed == 0 {
+		return fmt.Errorf("suspicious zip entry: %s expands to %d bytes from no compressed data", name, uncompressed)
+	}
+	if ratio := float64(uncompressed) / float64(compressed); ratio > limits.MaxCompressionRatio {
+		return fmt.Errorf("suspicious compression ratio in zip: %s (%.0f:1, limit %.0f:1)", name, ratio, limits.MaxCompressionRatio)
+	}
 	return nil
 }
 
@@ -653,6 +865,11 @@ func downloadRunArtifacts(runID int64, outputDir string, verbose bool, owner, re
 		spinner.StopWithMessage(fmt.Sprintf("✓ Downloaded artifacts for run %d", runID))
 	}
 
+	// Remove the workflow's artifacts.prefix so artifacts are found under their usual names
+	if err := stripArtifactNamePrefix(outputDir, verbose); err != nil {
+		return fmt.Errorf("failed to strip artifact name prefix: %w", err)
+	}
+
 	// Flatten single-file artifacts
 	if err := flattenSingleFileArtifacts(outputDir, verbose); err != nil {
 		return fmt.Errorf("failed to flatten artifacts: %w", err)
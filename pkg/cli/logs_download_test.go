@@ -21,7 +21,7 @@ func TestDownloadWorkflowLogs(t *testing.T) {
 	// Test the DownloadWorkflowLogs function
 	// This should either fail with auth error (if not authenticated)
 	// or succeed with no results (if authenticated but no workflows match)
-	err := DownloadWorkflowLogs(context.Background(), "", 1, "", "", "./test-logs", "", "", 0, 0, "", false, false, false, false, false, false, false, 0, "summary.json", "")
+	err := DownloadWorkflowLogs(context.Background(), "", 1, "", "", "./test-logs", "", "", 0, 0, "", false, false, false, false, false, false, false, 0, "summary.json", "", "", false, "", false)
 
 	// If GitHub CLI is authenticated, the function may succeed but find no results
 	// If not authenticated, it should return an auth error
@@ -209,7 +209,7 @@ func TestDownloadWorkflowLogsWithEngineFilter(t *testing.T) {
 			if !tt.expectError {
 				// For valid engines, test that the function can be called without panic
 				// It may still fail with auth errors, which is expected
-				err := DownloadWorkflowLogs(context.Background(), "", 1, "", "", "./test-logs", tt.engine, "", 0, 0, "", false, false, false, false, false, false, false, 0, "summary.json", "")
+				err := DownloadWorkflowLogs(context.Background(), "", 1, "", "", "./test-logs", tt.engine, "", 0, 0, "", false, false, false, false, false, false, false, 0, "summary.json", "", "", false, "", false)
 
 				// Clean up any created directories
 				os.RemoveAll("./test-logs")
@@ -599,3 +599,37 @@ func TestCountParameterBehavior(t *testing.T) {
 		})
 	}
 }
+
+func TestStripArtifactNamePrefix(t *testing.T) {
+	outputDir := testutil.TempDir(t, "strip-prefix-*")
+	for _, dir := range []string{"triage-bot-activation", "triage-bot-agent-output", "triage-bot-safe-output-items", "unrelated"} {
+		if err := os.MkdirAll(filepath.Join(outputDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	if err := stripArtifactNamePrefix(outputDir, false); err != nil {
+		t.Fatalf("stripArtifactNamePrefix() error: %v", err)
+	}
+
+	for _, dir := range []string{"activation", "agent-output", "safe-output-items", "unrelated"} {
+		if !fileutil.DirExists(filepath.Join(outputDir, dir)) {
+			t.Errorf("Expected directory %s to exist after stripping the prefix", dir)
+		}
+	}
+	if fileutil.DirExists(filepath.Join(outputDir, "triage-bot-activation")) {
+		t.Error("Expected prefixed activation directory to be renamed")
+	}
+
+	// Without a prefixed activation artifact nothing is renamed
+	plainDir := testutil.TempDir(t, "strip-prefix-plain-*")
+	if err := os.MkdirAll(filepath.Join(plainDir, "x-agent-output"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := stripArtifactNamePrefix(plainDir, false); err != nil {
+		t.Fatalf("stripArtifactNamePrefix() error: %v", err)
+	}
+	if !fileutil.DirExists(filepath.Join(plainDir, "x-agent-output")) {
+		t.Error("Expected directory to keep its name when no prefix is detected")
+	}
+}
@@ -0,0 +1,157 @@
+// This file provides the engine report for the logs command.
+//
+// The report lists the model, engine CLI version, and prompt version recorded in
+// each run's aw_info.json, and flags scheduled workflows whose model changed
+// between consecutive runs while the prompt stayed the same. Such shifts usually
+// come from a repository variable or an engine default changing underneath the
+// workflow and are a common cause of unexplained behavior regressions.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var logsEngineReportLog = logger.New("cli:logs_engine_report")
+
+// engineDefaultModel is shown for runs that did not record a model, meaning the
+// engine picked its own default
+const engineDefaultModel = "(engine default)"
+
+// EngineReport contains the engine metadata breakdown produced by --engine-report
+type EngineReport struct {
+	Runs        []EngineReportRun `json:"runs" console:"title:Engine Metadata by Run"`
+	ModelShifts []ModelShift      `json:"model_shifts,omitempty" console:"title:⚠️  Scheduled Model Shifts,omitempty"`
+}
+
+// EngineReportRun describes the engine that produced a single run
+type EngineReportRun struct {
+	DatabaseID   int64  `json:"database_id" console:"header:Run ID"`
+	WorkflowName string `json:"workflow_name" console:"header:Workflow"`
+	Event        string `json:"event,omitempty" console:"header:Event,omitempty"`
+	Engine       string `json:"engine,omitempty" console:"header:Engine,default:-"`
+	Model        string `json:"model,omitempty" console:"header:Model,default:(engine default)"`
+	AgentVersion string `json:"agent_version,omitempty" console:"header:Engine Version,default:-"`
+	PromptHash   string `json:"prompt_hash,omitempty" console:"header:Prompt,default:-"`
+	CLIVersion   string `json:"cli_version,omitempty" console:"header:gh-aw,default:-"`
+	CreatedAt    string `json:"created_at,omitempty" console:"header:Created"`
+}
+
+// ModelShift records a scheduled workflow whose model changed between two consecutive runs
+type ModelShift struct {
+	WorkflowName  string `json:"workflow_name" console:"header:Workflow"`
+	PreviousRunID int64  `json:"previous_run_id" console:"header:Previous Run"`
+	PreviousModel string `json:"previous_model" console:"header:Previous Model"`
+	RunID         int64  `json:"run_id" console:"header:Run"`
+	Model         string `json:"model" console:"header:Model"`
+	PromptHash    string `json:"prompt_hash,omitempty" console:"header:Prompt,default:-"`
+}
+
+// buildEngineReport reads aw_info.json for each processed run and builds the engine report.
+// Runs without aw_info.json are listed without engine metadata and never produce shifts.
+func buildEngineReport(processedRuns []ProcessedRun) *EngineReport {
+	report := &EngineReport{Runs: make([]EngineReportRun, 0, len(processedRuns))}
+	infos := make(map[int64]*AwInfo, len(processedRuns))
+
+	for _, pr := range processedRuns {
+		run := pr.Run
+		entry := EngineReportRun{
+			DatabaseID:   run.DatabaseID,
+			WorkflowName: run.WorkflowName,
+			Event:        run.Event,
+		}
+		if !run.CreatedAt.IsZero() {
+			entry.CreatedAt = run.CreatedAt.Format("2006-01-02 15:04")
+		}
+		if run.LogsPath != "" {
+			if info, err := parseAwInfo(filepath.Join(run.LogsPath, "aw_info.json"), false); err == nil && info != nil {
+				infos[run.DatabaseID] = info
+				entry.Engine = info.EngineID
+				entry.Model = info.Model
+				entry.AgentVersion = info.AgentVersion
+				entry.PromptHash = info.PromptHash
+				entry.CLIVersion = info.CLIVersion
+			}
+		}
+		report.Runs = append(report.Runs, entry)
+	}
+
+	report.ModelShifts = detectScheduledModelShifts(processedRuns, infos)
+	logsEngineReportLog.Printf("Built engine report: runs=%d, with metadata=%d, model shifts=%d", len(report.Runs), len(infos), len(report.ModelShifts))
+	return report
+}
+
+// detectScheduledModelShifts compares consecutive scheduled runs of each workflow, oldest
+// first, and reports every model change that was not accompanied by a prompt change.
+// A changed prompt hash means the workflow itself was edited, so the change is not silent.
+func detectScheduledModelShifts(processedRuns []ProcessedRun, infos map[int64]*AwInfo) []ModelShift {
+	byWorkflow := make(map[string][]WorkflowRun)
+	for _, pr := range processedRuns {
+		if pr.Run.Event != "schedule" || infos[pr.Run.DatabaseID] == nil {
+			continue
+		}
+		byWorkflow[pr.Run.WorkflowName] = append(byWorkflow[pr.Run.WorkflowName], pr.Run)
+	}
+
+	workflowNames := make([]string, 0, len(byWorkflow))
+	for name := range byWorkflow {
+		workflowNames = append(workflowNames, name)
+	}
+	sort.Strings(workflowNames)
+
+	var shifts []ModelShift
+	for _, name := range workflowNames {
+		runs := byWorkflow[name]
+		sort.SliceStable(runs, func(i, j int) bool {
+			if runs[i].CreatedAt.Equal(runs[j].CreatedAt) {
+				return runs[i].DatabaseID < runs[j].DatabaseID
+			}
+			return runs[i].CreatedAt.Before(runs[j].CreatedAt)
+		})
+
+		for i := 1; i < len(runs); i++ {
+			prev := infos[runs[i-1].DatabaseID]
+			curr := infos[runs[i].DatabaseID]
+			if prev.Model == curr.Model {
+				continue
+			}
+			if prev.PromptHash != "" && curr.PromptHash != "" && prev.PromptHash != curr.PromptHash {
+				continue
+			}
+			shifts = append(shifts, ModelShift{
+				WorkflowName:  name,
+				PreviousRunID: runs[i-1].DatabaseID,
+				PreviousModel: displayModel(prev.Model),
+				RunID:         runs[i].DatabaseID,
+				Model:         displayModel(curr.Model),
+				PromptHash:    curr.PromptHash,
+			})
+		}
+	}
+	return shifts
+}
+
+// displayModel returns the model name, or a placeholder when the engine default was used
+func displayModel(model string) string {
+	if model == "" {
+		return engineDefaultModel
+	}
+	return model
+}
+
+// renderEngineReportWarnings prints one warning per silent model shift to stderr
+func renderEngineReportWarnings(report *EngineReport) {
+	if report == nil {
+		return
+	}
+	for _, shift := range report.ModelShifts {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Scheduled workflow '%s' shifted from %s to %s between runs %d and %d without a prompt change",
+			shift.WorkflowName, shift.PreviousModel, shift.Model, shift.PreviousRunID, shift.RunID)))
+	}
+}
@@ -0,0 +1,85 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// engineReportRun creates a processed run whose logs directory holds the given aw_info.json
+func engineReportRun(t *testing.T, id int64, event string, createdAt time.Time, info *AwInfo) ProcessedRun {
+	t.Helper()
+	logsPath := filepath.Join(testutil.TempDir(t, "engine-report-*"), "run")
+	require.NoError(t, os.MkdirAll(logsPath, 0755), "should create logs directory")
+	if info != nil {
+		content, err := json.Marshal(info)
+		require.NoError(t, err, "should marshal aw_info.json")
+		require.NoError(t, os.WriteFile(filepath.Join(logsPath, "aw_info.json"), content, 0644), "should write aw_info.json")
+	}
+	return ProcessedRun{Run: WorkflowRun{
+		DatabaseID:   id,
+		WorkflowName: "Daily Report",
+		Event:        event,
+		CreatedAt:    createdAt,
+		LogsPath:     logsPath,
+	}}
+}
+
+func TestBuildEngineReport(t *testing.T) {
+	day := time.Date(2026, 5, 1, 6, 0, 0, 0, time.UTC)
+	info := func(model, promptHash string) *AwInfo {
+		return &AwInfo{EngineID: "copilot", Model: model, AgentVersion: "0.0.400", PromptHash: promptHash, CLIVersion: "1.2.3"}
+	}
+
+	// Runs are listed newest first, as returned by the GitHub API
+	runs := []ProcessedRun{
+		engineReportRun(t, 6, "schedule", day.Add(5*24*time.Hour), info("gpt-5", "bbbb")),
+		engineReportRun(t, 5, "schedule", day.Add(4*24*time.Hour), info("gpt-4.1", "bbbb")),
+		engineReportRun(t, 4, "workflow_dispatch", day.Add(3*24*time.Hour), info("o3", "aaaa")),
+		engineReportRun(t, 3, "schedule", day.Add(2*24*time.Hour), info("gpt-4.1", "aaaa")),
+		engineReportRun(t, 2, "schedule", day.Add(24*time.Hour), info("", "aaaa")),
+		engineReportRun(t, 1, "schedule", day, nil),
+	}
+
+	report := buildEngineReport(runs)
+	require.NotNil(t, report, "report should be built")
+	require.Len(t, report.Runs, 6, "every run should be listed")
+	assert.Equal(t, EngineReportRun{
+		DatabaseID:   6,
+		WorkflowName: "Daily Report",
+		Event:        "schedule",
+		Engine:       "copilot",
+		Model:        "gpt-5",
+		AgentVersion: "0.0.400",
+		PromptHash:   "bbbb",
+		CLIVersion:   "1.2.3",
+		CreatedAt:    "2026-05-06 06:00",
+	}, report.Runs[0], "run should carry its engine metadata")
+	assert.Empty(t, report.Runs[5].Model, "run without aw_info.json should have no metadata")
+
+	// 2 -> 3 is a silent shift away from the engine default, 3 -> 5 changed the prompt,
+	// 5 -> 6 is a silent shift, and the manual run 4 is not compared
+	assert.Equal(t, []ModelShift{
+		{WorkflowName: "Daily Report", PreviousRunID: 2, PreviousModel: "(engine default)", RunID: 3, Model: "gpt-4.1", PromptHash: "aaaa"},
+		{WorkflowName: "Daily Report", PreviousRunID: 5, PreviousModel: "gpt-4.1", RunID: 6, Model: "gpt-5", PromptHash: "bbbb"},
+	}, report.ModelShifts, "only silent model changes between scheduled runs should be flagged")
+}
+
+func TestBuildEngineReportWithoutPromptHash(t *testing.T) {
+	day := time.Date(2026, 5, 1, 6, 0, 0, 0, time.UTC)
+	runs := []ProcessedRun{
+		engineReportRun(t, 2, "schedule", day.Add(time.Hour), &AwInfo{EngineID: "claude", Model: "claude-sonnet-4"}),
+		engineReportRun(t, 1, "schedule", day, &AwInfo{EngineID: "claude", Model: "claude-opus-4"}),
+	}
+
+	report := buildEngineReport(runs)
+	assert.Len(t, report.ModelShifts, 1, "runs from before prompt hashes were recorded should still be compared")
+}
@@ -265,3 +265,118 @@ func TestExtractZipFileErrorHandling(t *testing.T) {
 		// This prevents silent data loss that could occur if Close() errors were ignored.
 	})
 }
+
+// writeTestZip writes a zip archive containing the given entries to a temporary file
+func writeTestZip(t *testing.T, entries map[string][]byte) string {
+	t.Helper()
+	zipPath := filepath.Join(t.TempDir(), "test.zip")
+	zipFile, err := os.Create(zipPath)
+	require.NoError(t, err, "Failed to create zip file")
+	defer zipFile.Close()
+
+	zipWriter := zip.NewWriter(zipFile)
+	for name, content := range entries {
+		writer, err := zipWriter.Create(name)
+		require.NoError(t, err, "Failed to create %s in zip", name)
+		_, err = writer.Write(content)
+		require.NoError(t, err, "Failed to write %s to zip", name)
+	}
+	require.NoError(t, zipWriter.Close(), "Failed to close zip writer")
+	return zipPath
+}
+
+// TestUnzipFileWithLimits tests that extraction limits are enforced
+func TestUnzipFileWithLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   map[string][]byte
+		limits    ZipExtractionLimits
+		wantErr   string
+		wantFiles []string
+	}{
+		{
+			name:      "within limits",
+			entries:   map[string][]byte{"a.txt": []byte("alpha"), "b/c.txt": []byte("charlie")},
+			limits:    DefaultZipExtractionLimits(),
+			wantFiles: []string{"a.txt", "b/c.txt"},
+		},
+		{
+			name:    "too many entries",
+			entries: map[string][]byte{"a.txt": []byte("a"), "b.txt": []byte("b"), "c.txt": []byte("c")},
+			limits:  ZipExtractionLimits{MaxEntries: 2},
+			wantErr: "too many entries: 3 (limit 2)",
+		},
+		{
+			name:    "single file too large",
+			entries: map[string][]byte{"big.txt": bytes.Repeat([]byte("x"), 100)},
+			limits:  ZipExtractionLimits{MaxFileSize: 50},
+			wantErr: "file too large in zip: big.txt",
+		},
+		{
+			name:    "total size too large",
+			entries: map[string][]byte{"a.txt": bytes.Repeat([]byte("a"), 60), "b.txt": bytes.Repeat([]byte("b"), 60)},
+			limits:  ZipExtractionLimits{MaxTotalSize: 100},
+			wantErr: "declared uncompressed size exceeds 100 bytes",
+		},
+		{
+			name:    "compression ratio bomb",
+			entries: map[string][]byte{"bomb.txt": make([]byte, 4*1024*1024)},
+			limits:  ZipExtractionLimits{MaxCompressionRatio: 100},
+			wantErr: "suspicious compression ratio in zip: bomb.txt",
+		},
+		{
+			name:      "small repetitive files are exempt from the ratio check",
+			entries:   map[string][]byte{"repeat.log": make([]byte, 64*1024)},
+			limits:    ZipExtractionLimits{MaxCompressionRatio: 10},
+			wantFiles: []string{"repeat.log"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zipPath := writeTestZip(t, tt.entries)
+			destDir := t.TempDir()
+
+			err := unzipFileWithLimits(zipPath, destDir, tt.limits, false)
+			if tt.wantErr != "" {
+				require.Error(t, err, "extraction should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "extraction should succeed")
+			for _, name := range tt.wantFiles {
+				assert.FileExists(t, filepath.Join(destDir, name), "extracted file should exist")
+			}
+		})
+	}
+}
+
+// TestExtractZipEntryEnforcesTotalBudget tests that bytes actually written count against the total limit
+func TestExtractZipEntryEnforcesTotalBudget(t *testing.T) {
+	zipPath := writeTestZip(t, map[string][]byte{"data.txt": bytes.Repeat([]byte("d"), 80)})
+	zipReader, err := zip.OpenReader(zipPath)
+	require.NoError(t, err, "Failed to open zip")
+	defer zipReader.Close()
+
+	destDir := t.TempDir()
+	budget := &zipExtractionBudget{limits: ZipExtractionLimits{MaxTotalSize: 100}, written: 50}
+
+	err = extractZipEntry(zipReader.File[0], destDir, budget, false)
+	require.Error(t, err, "extraction should exceed the remaining budget")
+	assert.Contains(t, err.Error(), "exceeded total size limit of 100 bytes", "error message")
+	assert.NoFileExists(t, filepath.Join(destDir, "data.txt"), "partially extracted file should be removed")
+}
+
+// TestZipExtractionLimitsFromEnv tests environment overrides of the extraction limits
+func TestZipExtractionLimitsFromEnv(t *testing.T) {
+	t.Setenv("GH_AW_LOGS_ZIP_MAX_ENTRIES", "50")
+	t.Setenv("GH_AW_LOGS_ZIP_MAX_TOTAL_SIZE", "0")
+	t.Setenv("GH_AW_LOGS_ZIP_MAX_FILE_SIZE", "not-a-number")
+	t.Setenv("GH_AW_LOGS_ZIP_MAX_RATIO", "250")
+
+	limits := zipExtractionLimitsFromEnv()
+	assert.Equal(t, 50, limits.MaxEntries, "entries should be overridden")
+	assert.Equal(t, int64(0), limits.MaxTotalSize, "zero should disable the total size limit")
+	assert.Equal(t, DefaultZipExtractionLimits().MaxFileSize, limits.MaxFileSize, "invalid values should be ignored")
+	assert.InDelta(t, 250.0, limits.MaxCompressionRatio, 0.001, "ratio should be overridden")
+}
@@ -0,0 +1,382 @@
+// This file provides transcript search for the logs command.
+//
+// With --grep, the logs command searches the runs that were already downloaded
+// into the output directory instead of contacting GitHub. Agent transcripts
+// (*.log and *.jsonl files) and the per-step workflow logs are scanned line by
+// line, and every match is printed with the run, job and step it came from.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var logsGrepLog = logger.New("cli:logs_grep")
+
+// agentJobName is used to attribute transcript files that are not part of the workflow logs
+const agentJobName = "agent"
+
+// maxTranscriptLineSize bounds the length of a single transcript line (stream-json lines can be large)
+const maxTranscriptLineSize = 10 * 1024 * 1024
+
+// LogGrepOptions configures a transcript search across downloaded runs
+type LogGrepOptions struct {
+	Pattern      *regexp.Regexp
+	Context      int       // Number of lines to show before and after each match
+	Tool         string    // Only keep matches produced by or mentioning this tool
+	Since        time.Time // Only search runs created at or after this time
+	RunIDs       []int64   // Only search these runs
+	WorkflowName string    // Only search runs of this workflow
+}
+
+// LogGrepMatch is a single transcript line matching the search pattern
+type LogGrepMatch struct {
+	RunID        int64    `json:"run_id"`
+	WorkflowName string   `json:"workflow_name,omitempty"`
+	Job          string   `json:"job,omitempty"`
+	Step         string   `json:"step,omitempty"`
+	Tool         string   `json:"tool,omitempty"`
+	File         string   `json:"file"`
+	Line         int      `json:"line"`
+	Text         string   `json:"text"`
+	Before       []string `json:"before,omitempty"`
+	After        []string `json:"after,omitempty"`
+}
+
+// grepRun is a downloaded run directory selected for searching
+type grepRun struct {
+	id           int64
+	dir          string
+	workflowName string
+}
+
+// SearchLogTranscripts searches the transcripts of runs downloaded into outputDir
+func SearchLogTranscripts(outputDir string, opts LogGrepOptions) ([]LogGrepMatch, error) {
+	logsGrepLog.Printf("Searching transcripts: dir=%s, pattern=%s, tool=%s, runs=%v", outputDir, opts.Pattern, opts.Tool, opts.RunIDs)
+
+	runs, err := findGrepRuns(outputDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []LogGrepMatch
+	for _, run := range runs {
+		files, err := findTranscriptFiles(run.dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list transcripts for run %d: %w", run.id, err)
+		}
+		for _, file := range files {
+			fileMatches, err := grepTranscriptFile(file, opts)
+			if err != nil {
+				return nil, err
+			}
+			rel, _ := filepath.Rel(run.dir, file)
+			job, step := attributeTranscriptFile(filepath.ToSlash(rel))
+			for i := range fileMatches {
+				fileMatches[i].RunID = run.id
+				fileMatches[i].WorkflowName = run.workflowName
+				fileMatches[i].Job = job
+				fileMatches[i].Step = step
+				fileMatches[i].File = filepath.ToSlash(rel)
+			}
+			matches = append(matches, fileMatches...)
+		}
+	}
+
+	logsGrepLog.Printf("Found %d matches in %d runs", len(matches), len(runs))
+	return matches, nil
+}
+
+// findGrepRuns returns the run-<id> directories in outputDir that pass the run filters,
+// newest run first
+func findGrepRuns(outputDir string, opts LogGrepOptions) ([]grepRun, error) {
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no downloaded runs found in %s. Run the logs command without --grep first", outputDir)
+		}
+		return nil, fmt.Errorf("failed to read output directory: %w", err)
+	}
+
+	var runs []grepRun
+	for _, entry := range entries {
+		idStr, ok := strings.CutPrefix(entry.Name(), "run-")
+		if !entry.IsDir() || !ok {
+			continue
+		}
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		if len(opts.RunIDs) > 0 && !slices.Contains(opts.RunIDs, id) {
+			continue
+		}
+
+		run := grepRun{id: id, dir: filepath.Join(outputDir, entry.Name())}
+		var createdAt time.Time
+		if data, err := os.ReadFile(filepath.Join(run.dir, runSummaryFileName)); err == nil {
+			var summary RunSummary
+			if err := json.Unmarshal(data, &summary); err == nil {
+				run.workflowName = summary.Run.WorkflowName
+				createdAt = summary.Run.CreatedAt
+			}
+		}
+
+		// Runs without a summary cannot be dated or named, so they are only kept when not filtering on those
+		if !opts.Since.IsZero() && (createdAt.IsZero() || createdAt.Before(opts.Since)) {
+			logsGrepLog.Printf("Skipping run %d: created %s before --since", id, createdAt)
+			continue
+		}
+		if opts.WorkflowName != "" && !strings.EqualFold(run.workflowName, opts.WorkflowName) {
+			continue
+		}
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool { return runs[i].id > runs[j].id })
+	return runs, nil
+}
+
+// findTranscriptFiles returns the agent transcripts and per-step workflow logs in a run directory
+func findTranscriptFiles(runDir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(runDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(runDir, path)
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if parts[0] == "workflow-logs" {
+			// Only per-step logs (workflow-logs/<job>/<n>_<step>.txt); the top-level
+			// per-job files repeat the same content
+			if len(parts) == 3 && strings.HasSuffix(d.Name(), ".txt") {
+				files = append(files, path)
+			}
+			return nil
+		}
+		if ext := filepath.Ext(d.Name()); ext == ".log" || ext == ".jsonl" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// stepLogPrefix matches the "<n>_" ordering prefix of step log file names
+var stepLogPrefix = regexp.MustCompile(`^\d+_`)
+
+// attributeTranscriptFile derives the job and step a transcript file belongs to
+// from its path relative to the run directory
+func attributeTranscriptFile(rel string) (job, step string) {
+	parts := strings.Split(rel, "/")
+	if len(parts) == 3 && parts[0] == "workflow-logs" {
+		return parts[1], stepLogPrefix.ReplaceAllString(strings.TrimSuffix(parts[2], ".txt"), "")
+	}
+	return agentJobName, ""
+}
+
+// grepTranscriptFile scans a single file and returns the matching lines with context
+func grepTranscriptFile(path string, opts LogGrepOptions) ([]LogGrepMatch, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var matches []LogGrepMatch
+	var before []string
+	// Indexes of matches still collecting trailing context lines
+	var pending []int
+	toolIDs := make(map[string]string)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxTranscriptLineSize)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+
+		if opts.Context > 0 {
+			stillPending := pending[:0]
+			for _, idx := range pending {
+				matches[idx].After = append(matches[idx].After, line)
+				if len(matches[idx].After) < opts.Context {
+					stillPending = append(stillPending, idx)
+				}
+			}
+			pending = stillPending
+		}
+
+		tool := transcriptLineTool(line, toolIDs)
+		if opts.Pattern.MatchString(line) && matchesToolFilter(line, tool, opts.Tool) {
+			matches = append(matches, LogGrepMatch{
+				Line:   lineNumber,
+				Text:   line,
+				Tool:   tool,
+				Before: slices.Clone(before),
+			})
+			if opts.Context > 0 {
+				pending = append(pending, len(matches)-1)
+			}
+		}
+
+		if opts.Context > 0 {
+			before = append(before, line)
+			if len(before) > opts.Context {
+				before = before[1:]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return matches, nil
+}
+
+// transcriptLineTool returns the tool a stream-json transcript line belongs to.
+// Tool calls are recorded by ID so that their results are attributed to the same tool.
+// Plain-text lines return an empty string.
+func transcriptLineTool(line string, toolIDs map[string]string) string {
+	event, ok := parseTranscriptToolEvent(line)
+	if !ok {
+		return ""
+	}
+	if event.kind == "tool_use" {
+		if event.id != "" {
+			toolIDs[event.id] = event.name
+		}
+		return event.name
+	}
+	return toolIDs[event.id]
+}
+
+// transcriptToolEvent is a tool call ("tool_use") or tool result ("tool_result") in a
+// stream-json transcript line. For results, id is the ID of the call they answer.
+type transcriptToolEvent struct {
+	kind string
+	id   string
+	name string
+}
+
+// parseTranscriptToolEvent returns the first tool call or tool result of a stream-json
+// transcript line
+func parseTranscriptToolEvent(line string) (transcriptToolEvent, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") || !strings.Contains(trimmed, "tool_") {
+		return transcriptToolEvent{}, false
+	}
+	var event struct {
+		Message struct {
+			Content []struct {
+				Type      string `json:"type"`
+				ID        string `json:"id"`
+				Name      string `json:"name"`
+				ToolUseID string `json:"tool_use_id"`
+			} `json:"content"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(trimmed), &event); err != nil {
+		return transcriptToolEvent{}, false
+	}
+	for _, item := range event.Message.Content {
+		switch item.Type {
+		case "tool_use":
+			return transcriptToolEvent{kind: item.Type, id: item.ID, name: item.Name}, true
+		case "tool_result":
+			return transcriptToolEvent{kind: item.Type, id: item.ToolUseID}, true
+		}
+	}
+	return transcriptToolEvent{}, false
+}
+
+// matchesToolFilter reports whether a line passes the --tool filter. Lines attributed to a
+// tool match on the tool name; other lines match when they mention the tool.
+func matchesToolFilter(line, tool, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	filter = strings.ToLower(filter)
+	if tool != "" {
+		return strings.Contains(strings.ToLower(tool), filter)
+	}
+	return strings.Contains(strings.ToLower(line), filter)
+}
+
+// maxGrepExcerptLength truncates long lines (such as stream-json events) in text output
+const maxGrepExcerptLength = 240
+
+// renderLogGrepMatches prints matches grouped by run and file
+func renderLogGrepMatches(matches []LogGrepMatch, jsonOutput bool) error {
+	if jsonOutput {
+		data, err := json.MarshalIndent(struct {
+			Total   int            `json:"total"`
+			Matches []LogGrepMatch `json:"matches"`
+		}{Total: len(matches), Matches: matches}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal matches: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(matches) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No matches found"))
+		return nil
+	}
+
+	lastHeader := ""
+	for _, m := range matches {
+		header := fmt.Sprintf("Run %d", m.RunID)
+		if m.WorkflowName != "" {
+			header += " · " + m.WorkflowName
+		}
+		header += " · " + m.Job
+		if m.Step != "" {
+			header += " › " + m.Step
+		}
+		header += " (" + m.File + ")"
+		if header != lastHeader {
+			if lastHeader != "" {
+				fmt.Println()
+			}
+			fmt.Println(console.FormatSectionHeader(header))
+			lastHeader = header
+		} else if len(m.Before) > 0 || len(m.After) > 0 {
+			fmt.Println("--")
+		}
+
+		for i, line := range m.Before {
+			fmt.Printf("%d-  %s\n", m.Line-len(m.Before)+i, stringutil.Truncate(line, maxGrepExcerptLength))
+		}
+		text := stringutil.Truncate(m.Text, maxGrepExcerptLength)
+		if m.Tool != "" {
+			text = "[" + m.Tool + "] " + text
+		}
+		fmt.Printf("%d:  %s\n", m.Line, text)
+		for i, line := range m.After {
+			fmt.Printf("%d-  %s\n", m.Line+i+1, stringutil.Truncate(line, maxGrepExcerptLength))
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("%d matches", len(matches))))
+	return nil
+}
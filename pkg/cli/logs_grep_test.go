@@ -0,0 +1,128 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeGrepRun creates a downloaded run directory with a run summary and the given files
+func writeGrepRun(t *testing.T, outputDir string, runID int64, workflowName string, createdAt time.Time, files map[string]string) {
+	t.Helper()
+	runDir := filepath.Join(outputDir, fmt.Sprintf("run-%d", runID))
+	summary := RunSummary{RunID: runID, Run: WorkflowRun{DatabaseID: runID, WorkflowName: workflowName, CreatedAt: createdAt}}
+	data, err := json.Marshal(summary)
+	require.NoError(t, err, "should marshal summary")
+	require.NoError(t, os.MkdirAll(runDir, 0755), "should create run dir")
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, runSummaryFileName), data, 0644), "should write summary")
+	for name, content := range files {
+		path := filepath.Join(runDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755), "should create parent dir")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644), "should write %s", name)
+	}
+}
+
+func TestSearchLogTranscripts(t *testing.T) {
+	outputDir := t.TempDir()
+	now := time.Now().UTC()
+
+	transcript := `{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t1","name":"Bash","input":{"command":"gh api /rate_limit"}}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"t1","content":"HTTP 403: rate limit exceeded"}]}}
+{"type":"assistant","message":{"content":[{"type":"tool_use","id":"t2","name":"mcp__github__search_issues","input":{"query":"rate limit"}}]}}
+The agent hit a rate limit and stopped
+`
+	writeGrepRun(t, outputDir, 200, "Triage", now.Add(-time.Hour), map[string]string{
+		"agent-stdio.log":                         transcript,
+		"workflow-logs/agent/4_Execute agent.txt": "setup\nError: rate limit exceeded\ndone\n",
+		"workflow-logs/0_agent.txt":               "Error: rate limit exceeded\n",
+		"prompt.txt":                              "Watch out for the rate limit\n",
+	})
+	writeGrepRun(t, outputDir, 100, "Daily Report", now.Add(-30*24*time.Hour), map[string]string{
+		"agent-stdio.log": "old run rate limit\n",
+	})
+
+	tests := []struct {
+		name      string
+		opts      LogGrepOptions
+		wantLines []string // "<run>:<file>:<line>"
+	}{
+		{
+			name: "all runs newest first",
+			opts: LogGrepOptions{Pattern: regexp.MustCompile(`rate limit`)},
+			wantLines: []string{
+				"200:agent-stdio.log:2",
+				"200:agent-stdio.log:3",
+				"200:agent-stdio.log:4",
+				"200:workflow-logs/agent/4_Execute agent.txt:2",
+				"100:agent-stdio.log:1",
+			},
+		},
+		{
+			name:      "tool filter matches tool results",
+			opts:      LogGrepOptions{Pattern: regexp.MustCompile(`rate`), Tool: "bash"},
+			wantLines: []string{"200:agent-stdio.log:1", "200:agent-stdio.log:2"},
+		},
+		{
+			name:      "since excludes older runs",
+			opts:      LogGrepOptions{Pattern: regexp.MustCompile(`rate limit`), Since: now.Add(-24 * time.Hour), Tool: "search_issues"},
+			wantLines: []string{"200:agent-stdio.log:3"},
+		},
+		{
+			name:      "run filter",
+			opts:      LogGrepOptions{Pattern: regexp.MustCompile(`rate limit`), RunIDs: []int64{100}},
+			wantLines: []string{"100:agent-stdio.log:1"},
+		},
+		{
+			name:      "workflow filter",
+			opts:      LogGrepOptions{Pattern: regexp.MustCompile(`rate limit`), WorkflowName: "daily report"},
+			wantLines: []string{"100:agent-stdio.log:1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := SearchLogTranscripts(outputDir, tt.opts)
+			require.NoError(t, err, "search should succeed")
+
+			var got []string
+			for _, m := range matches {
+				got = append(got, fmt.Sprintf("%d:%s:%d", m.RunID, m.File, m.Line))
+			}
+			assert.Equal(t, tt.wantLines, got, "matched lines")
+		})
+	}
+}
+
+func TestSearchLogTranscriptsAttribution(t *testing.T) {
+	outputDir := t.TempDir()
+	writeGrepRun(t, outputDir, 300, "Triage", time.Now(), map[string]string{
+		"workflow-logs/agent/4_Execute agent.txt": "one\ntwo\nmatch here\nthree\nfour\n",
+	})
+
+	matches, err := SearchLogTranscripts(outputDir, LogGrepOptions{Pattern: regexp.MustCompile(`match`), Context: 2})
+	require.NoError(t, err, "search should succeed")
+	require.Len(t, matches, 1, "should find one match")
+
+	m := matches[0]
+	assert.Equal(t, "Triage", m.WorkflowName, "workflow name")
+	assert.Equal(t, "agent", m.Job, "job")
+	assert.Equal(t, "Execute agent", m.Step, "step")
+	assert.Equal(t, 3, m.Line, "line number")
+	assert.Equal(t, []string{"one", "two"}, m.Before, "leading context")
+	assert.Equal(t, []string{"three", "four"}, m.After, "trailing context")
+}
+
+func TestSearchLogTranscriptsMissingOutputDir(t *testing.T) {
+	_, err := SearchLogTranscripts(filepath.Join(t.TempDir(), "missing"), LogGrepOptions{Pattern: regexp.MustCompile(`x`)})
+	require.Error(t, err, "should fail without downloaded runs")
+	assert.Contains(t, err.Error(), "no downloaded runs found", "error message")
+}
@@ -0,0 +1,213 @@
+// This file provides the standalone HTML report of the logs command (--report).
+//
+// The report is a single HTML file with inline styles and no scripts or external
+// assets, so it can be attached to an incident review and opened by teammates who
+// do not have the CLI installed. It shows the run summary, a job timeline per run,
+// token usage, tool calls, errors and the safe outputs each run produced.
+
+package cli
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var logsHTMLReportLog = logger.New("cli:logs_html_report")
+
+//go:embed templates/logs_report.html.tmpl
+var logsReportTemplateText string
+
+var logsReportTemplate = template.Must(template.New("logs_report").Funcs(template.FuncMap{
+	"number":   console.FormatNumber,
+	"percent":  func(value float64) string { return fmt.Sprintf("%.2f%%", value) },
+	"datetime": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04:05 UTC") },
+}).Parse(logsReportTemplateText))
+
+// maxSafeOutputSummaryLength bounds the text shown for each safe output item
+const maxSafeOutputSummaryLength = 120
+
+// htmlReportData is the data rendered by the HTML report template
+type htmlReportData struct {
+	GeneratedAt       time.Time
+	Summary           LogsSummary
+	Runs              []htmlReportRun
+	ToolUsage         []ToolUsageSummary
+	ErrorsAndWarnings []ErrorSummary
+	MissingTools      []MissingToolSummary
+	MCPFailures       []MCPFailureSummary
+}
+
+// htmlReportRun holds the per-run sections of the HTML report
+type htmlReportRun struct {
+	RunData
+	TokenWidth  float64 // Token usage as a percentage of the largest token usage in the report
+	Timeline    []htmlReportBar
+	ToolCalls   []MCPToolCall
+	SafeOutputs []htmlReportSafeOutput
+}
+
+// htmlReportBar is a job positioned on the run timeline, in percent of the run duration
+type htmlReportBar struct {
+	Name       string
+	Conclusion string
+	Duration   string
+	Offset     float64
+	Width      float64
+}
+
+// htmlReportSafeOutput is a safe output item produced by a run
+type htmlReportSafeOutput struct {
+	Type    string
+	Summary string
+}
+
+// writeHTMLReport renders the downloaded runs into a standalone HTML file at path
+func writeHTMLReport(path string, logsData LogsData, processedRuns []ProcessedRun, verbose bool) error {
+	logsHTMLReportLog.Printf("Writing HTML report for %d runs to %s", len(processedRuns), path)
+
+	data := buildHTMLReportData(logsData, processedRuns)
+	var content strings.Builder
+	if err := logsReportTemplate.Execute(&content, data); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(content.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Wrote %d bytes of HTML", content.Len())))
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Wrote HTML report for %d run(s) to %s", len(processedRuns), path)))
+	return nil
+}
+
+// buildHTMLReportData combines the aggregated logs data with the per-run details
+// (jobs, tool calls and safe outputs) that only the processed runs carry
+func buildHTMLReportData(logsData LogsData, processedRuns []ProcessedRun) htmlReportData {
+	processedByID := make(map[int64]ProcessedRun, len(processedRuns))
+	for _, pr := range processedRuns {
+		processedByID[pr.Run.DatabaseID] = pr
+	}
+
+	maxTokens := 0
+	for _, run := range logsData.Runs {
+		maxTokens = max(maxTokens, run.TokenUsage)
+	}
+
+	runs := make([]htmlReportRun, 0, len(logsData.Runs))
+	for _, run := range logsData.Runs {
+		reportRun := htmlReportRun{RunData: run}
+		if maxTokens > 0 {
+			reportRun.TokenWidth = float64(run.TokenUsage) * 100 / float64(maxTokens)
+		}
+		if pr, ok := processedByID[run.DatabaseID]; ok {
+			reportRun.Timeline = buildRunTimeline(pr.JobDetails)
+			if pr.MCPToolUsage != nil {
+				reportRun.ToolCalls = pr.MCPToolUsage.ToolCalls
+			}
+			reportRun.SafeOutputs = readSafeOutputItems(pr.Run.LogsPath)
+		}
+		runs = append(runs, reportRun)
+	}
+
+	return htmlReportData{
+		GeneratedAt:       time.Now(),
+		Summary:           logsData.Summary,
+		Runs:              runs,
+		ToolUsage:         logsData.ToolUsage,
+		ErrorsAndWarnings: logsData.ErrorsAndWarnings,
+		MissingTools:      logsData.MissingTools,
+		MCPFailures:       logsData.MCPFailures,
+	}
+}
+
+// buildRunTimeline positions each started job relative to the first job start and the
+// last job completion of the run
+func buildRunTimeline(jobs []JobInfoWithDuration) []htmlReportBar {
+	var start, end time.Time
+	for _, job := range jobs {
+		if job.StartedAt.IsZero() {
+			continue
+		}
+		if start.IsZero() || job.StartedAt.Before(start) {
+			start = job.StartedAt
+		}
+		if completed := completedOrStart(job.StartedAt, job.CompletedAt); completed.After(end) {
+			end = completed
+		}
+	}
+	total := end.Sub(start)
+	if total <= 0 {
+		return nil
+	}
+
+	bars := make([]htmlReportBar, 0, len(jobs))
+	for _, job := range jobs {
+		if job.StartedAt.IsZero() {
+			continue
+		}
+		duration := completedOrStart(job.StartedAt, job.CompletedAt).Sub(job.StartedAt)
+		bars = append(bars, htmlReportBar{
+			Name:       job.Name,
+			Conclusion: job.Conclusion,
+			Duration:   duration.Round(time.Second).String(),
+			Offset:     float64(job.StartedAt.Sub(start)) * 100 / float64(total),
+			Width:      max(float64(duration)*100/float64(total), 0.5),
+		})
+	}
+	return bars
+}
+
+// readSafeOutputItems returns the type and a short summary of each item in the run's
+// agent output. Runs without agent output produce no items.
+func readSafeOutputItems(runDir string) []htmlReportSafeOutput {
+	agentOutputPath, ok := findRunAgentOutputPath(runDir)
+	if !ok {
+		return nil
+	}
+	content, err := os.ReadFile(agentOutputPath)
+	if err != nil {
+		logsHTMLReportLog.Printf("Failed to read %s: %v", agentOutputPath, err)
+		return nil
+	}
+
+	var agentOutput struct {
+		Items []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal(content, &agentOutput); err != nil {
+		logsHTMLReportLog.Printf("Failed to parse %s: %v", agentOutputPath, err)
+		return nil
+	}
+
+	items := make([]htmlReportSafeOutput, 0, len(agentOutput.Items))
+	for _, item := range agentOutput.Items {
+		itemType, _ := item["type"].(string)
+		items = append(items, htmlReportSafeOutput{Type: itemType, Summary: safeOutputItemSummary(item)})
+	}
+	return items
+}
+
+// safeOutputItemSummary returns the first descriptive field of a safe output item
+func safeOutputItemSummary(item map[string]any) string {
+	for _, key := range []string{"title", "body", "message", "reason", "tool", "branch"} {
+		if text, ok := item[key].(string); ok && strings.TrimSpace(text) != "" {
+			return stringutil.Truncate(strings.Join(strings.Fields(text), " "), maxSafeOutputSummaryLength)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRunTimeline(t *testing.T) {
+	bars := buildRunTimeline(otelTestRun().JobDetails)
+	require.Len(t, bars, 2, "should place both jobs on the timeline")
+
+	assert.Equal(t, htmlReportBar{Name: "activation", Conclusion: "success", Duration: "1m0s", Offset: 0, Width: 25}, bars[0], "activation bar")
+	assert.Equal(t, htmlReportBar{Name: "agent", Conclusion: "failure", Duration: "3m0s", Offset: 25, Width: 75}, bars[1], "agent bar")
+	assert.Nil(t, buildRunTimeline(nil), "runs without jobs have no timeline")
+}
+
+func TestWriteHTMLReport(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "logs-html-report")
+	runDir := filepath.Join(tmpDir, "run-4242")
+	require.NoError(t, os.MkdirAll(runDir, 0755), "should create run dir")
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "agent_output.json"), []byte(`{"items": [
+		{"type": "create_issue", "title": "Flaky test <b>detected</b>", "body": "details"},
+		{"type": "noop", "message": "nothing else to do"}
+	]}`), 0644), "should write agent output")
+
+	run := otelTestRun()
+	run.Run.LogsPath = runDir
+	run.Run.URL = "https://github.com/owner/repo/actions/runs/4242"
+	processedRuns := []ProcessedRun{run}
+
+	reportPath := filepath.Join(tmpDir, "reports", "incident.html")
+	require.NoError(t, writeHTMLReport(reportPath, buildLogsData(processedRuns, tmpDir, nil), processedRuns, false), "should write report")
+
+	content, err := os.ReadFile(reportPath)
+	require.NoError(t, err, "report should be written")
+	report := string(content)
+
+	assert.Contains(t, report, "<!DOCTYPE html>", "report should be a standalone HTML document")
+	assert.NotContains(t, report, "<script", "report should not need scripts")
+	assert.Contains(t, report, `href="https://github.com/owner/repo/actions/runs/4242"`, "report should link the run")
+	assert.Contains(t, report, `style="left: 25.00%; width: 75.00%"`, "agent job should be placed on the timeline")
+	assert.Contains(t, report, `style="width: 100.00%"`, "token chart should scale to the largest run")
+	assert.Contains(t, report, "issue_read", "report should list tool calls")
+	assert.Contains(t, report, "create_issue", "report should list safe outputs")
+	assert.Contains(t, report, "Flaky test &lt;b&gt;detected&lt;/b&gt;", "safe output text should be escaped")
+	assert.Contains(t, report, "nothing else to do", "report should summarize each safe output")
+}
@@ -58,6 +58,10 @@ func TestLogsJSONOutputBeforeStderr(t *testing.T) {
 		10,                                // timeout
 		"summary.json",                    // summaryFile
 		"",                                // safeOutputType
+		"",                                // otelExport
+		false,                             // engineReport
+		"",                                // reportFile
+		false,                             // resources
 	)
 
 	// Close writers first
@@ -177,7 +181,11 @@ func TestLogsJSONAndStderrRedirected(t *testing.T) {
 		true, // jsonOutput
 		10,
 		"summary.json",
-		"", // safeOutputType
+		"",    // safeOutputType
+		"",    // otelExport
+		false, // engineReport
+		"",    // reportFile
+		false, // resources
 	)
 
 	// Close the writer
@@ -55,18 +55,19 @@ func extractLogMetrics(logDir string, verbose bool, workflowPath ...string) (Log
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Detected GitHub Copilot coding agent run, using specialized parser"))
 	}
 
-	// First check for aw_info.json to determine the engine
-	var detectedEngine workflow.CodingAgentEngine
+	// First check for aw_info.json to determine the engine. The parser is looked up by the
+	// recorded engine ID, so engines with a registered LogParser work without a workflow engine.
+	engineID := ""
 	infoFilePath := filepath.Join(logDir, "aw_info.json")
 	logsMetricsLog.Printf("Checking for aw_info.json at: %s", infoFilePath)
 	if _, err := os.Stat(infoFilePath); err == nil {
 		logsMetricsLog.Print("Found aw_info.json, extracting engine")
 		// aw_info.json exists, try to extract engine information
-		if engine := extractEngineFromAwInfo(infoFilePath, verbose); engine != nil {
-			detectedEngine = engine
-			logsMetricsLog.Printf("Detected engine: %s", engine.GetID())
+		if info, err := parseAwInfo(infoFilePath, verbose); err == nil && info.EngineID != "" {
+			engineID = info.EngineID
+			logsMetricsLog.Printf("Detected engine: %s", engineID)
 			if verbose {
-				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Detected engine from aw_info.json: "+engine.GetID()))
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Detected engine from aw_info.json: "+engineID))
 			}
 		} else {
 			logsMetricsLog.Print("Failed to extract engine from aw_info.json")
@@ -81,6 +82,11 @@ func extractLogMetrics(logDir string, verbose bool, workflowPath ...string) (Log
 		}
 	}
 
+	logParser := resolveLogParser(engineID, isGitHubCopilotCodingAgent)
+	if logParser == nil && engineID != "" && verbose {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("No log parser registered for engine: "+engineID))
+	}
+
 	// Check for safe_output.jsonl artifact file
 	awOutputPath := filepath.Join(logDir, "safe_output.jsonl")
 	if _, err := os.Stat(awOutputPath); err == nil {
@@ -128,8 +134,54 @@ func extractLogMetrics(logDir string, verbose bool, workflowPath ...string) (Log
 		}
 	}
 
-	// Walk through all files in the log directory
-	err := filepath.Walk(logDir, func(path string, info os.FileInfo, err error) error {
+	// Prefer the engine-independent transcript written by the log parser step
+	transcript, transcriptErr := loadTranscript(logDir)
+	if transcriptErr != nil && !errors.Is(transcriptErr, os.ErrNotExist) {
+		logsMetricsLog.Printf("Ignoring transcript: %v", transcriptErr)
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Ignoring transcript, falling back to engine logs: %v", transcriptErr)))
+		}
+	}
+
+	var err error
+	if transcript != nil {
+		logsMetricsLog.Printf("Using transcript: engine=%s, tool_calls=%d", transcript.Engine, len(transcript.ToolCalls))
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Using canonical transcript for metrics: "+constants.TranscriptFilename))
+		}
+		metrics = transcript.Metrics()
+	} else {
+		err = extractEngineLogMetrics(logDir, logParser, &metrics, verbose)
+	}
+
+	// Try to parse gateway.jsonl if it exists
+	gatewayMetrics, gatewayErr := parseGatewayLogs(logDir, verbose)
+	if gatewayErr == nil && gatewayMetrics != nil {
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Successfully parsed gateway.jsonl"))
+		}
+		// We've successfully parsed gateway metrics, but we don't add them to the main metrics
+		// structure since they're tracked separately and displayed in their own table
+		logsMetricsLog.Printf("Parsed gateway.jsonl: %d servers, %d requests",
+			len(gatewayMetrics.Servers), gatewayMetrics.TotalRequests)
+	} else if gatewayErr != nil && !strings.Contains(gatewayErr.Error(), "not found") {
+		// Only log if it's an error other than "not found"
+		logsMetricsLog.Printf("Failed to parse gateway.jsonl: %v", gatewayErr)
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to parse gateway.jsonl: %v", gatewayErr)))
+		}
+	}
+
+	if logsMetricsLog.Enabled() {
+		logsMetricsLog.Printf("Metrics extraction completed: tokens=%d, cost=%.4f, turns=%d",
+			metrics.TokenUsage, metrics.EstimatedCost, metrics.Turns)
+	}
+	return metrics, err
+}
+
+// extractEngineLogMetrics aggregates the metrics of the engine log files in the log directory
+func extractEngineLogMetrics(logDir string, logParser LogParser, metrics *LogMetrics, verbose bool) error {
+	return filepath.Walk(logDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -146,7 +198,7 @@ func extractLogMetrics(logDir string, verbose bool, workflowPath ...string) (Log
 			!strings.Contains(fileName, "aw_output") &&
 			fileName != constants.AgentOutputFilename {
 
-			fileMetrics, err := parseLogFileWithEngine(path, detectedEngine, isGitHubCopilotCodingAgent, verbose)
+			fileMetrics, err := parseLogFileWithParser(path, logParser, verbose)
 			if err != nil && verbose {
 				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to parse log file %s: %v", path, err)))
 				return nil // Continue processing other files
@@ -168,30 +220,6 @@ func extractLogMetrics(logDir string, verbose bool, workflowPath ...string) (Log
 
 		return nil
 	})
-
-	// Try to parse gateway.jsonl if it exists
-	gatewayMetrics, gatewayErr := parseGatewayLogs(logDir, verbose)
-	if gatewayErr == nil && gatewayMetrics != nil {
-		if verbose {
-			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Successfully parsed gateway.jsonl"))
-		}
-		// We've successfully parsed gateway metrics, but we don't add them to the main metrics
-		// structure since they're tracked separately and displayed in their own table
-		logsMetricsLog.Printf("Parsed gateway.jsonl: %d servers, %d requests",
-			len(gatewayMetrics.Servers), gatewayMetrics.TotalRequests)
-	} else if gatewayErr != nil && !strings.Contains(gatewayErr.Error(), "not found") {
-		// Only log if it's an error other than "not found"
-		logsMetricsLog.Printf("Failed to parse gateway.jsonl: %v", gatewayErr)
-		if verbose {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to parse gateway.jsonl: %v", gatewayErr)))
-		}
-	}
-
-	if logsMetricsLog.Enabled() {
-		logsMetricsLog.Printf("Metrics extraction completed: tokens=%d, cost=%.4f, turns=%d",
-			metrics.TokenUsage, metrics.EstimatedCost, metrics.Turns)
-	}
-	return metrics, err
 }
 
 // ExtractLogMetricsFromRun extracts log metrics from a processed run's log directory
@@ -211,7 +211,18 @@ type DownloadResult struct {
 
 // JobInfo represents basic information about a workflow job
 type JobInfo struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Conclusion  string     `json:"conclusion"`
+	StartedAt   time.Time  `json:"started_at,omitzero"`
+	CompletedAt time.Time  `json:"completed_at,omitzero"`
+	Steps       []StepInfo `json:"steps,omitempty"`
+}
+
+// StepInfo represents basic information about a job step
+type StepInfo struct {
 	Name        string    `json:"name"`
+	Number      int       `json:"number"`
 	Status      string    `json:"status"`
 	Conclusion  string    `json:"conclusion"`
 	StartedAt   time.Time `json:"started_at,omitzero"`
@@ -235,7 +246,9 @@ type AwInfo struct {
 	EngineName      string      `json:"engine_name"`
 	Model           string      `json:"model"`
 	Version         string      `json:"version"`
-	CLIVersion      string      `json:"cli_version,omitempty"` // gh-aw CLI version
+	AgentVersion    string      `json:"agent_version,omitempty"` // Engine CLI version installed for the run
+	PromptHash      string      `json:"prompt_hash,omitempty"`   // Content hash of the compiled prompt
+	CLIVersion      string      `json:"cli_version,omitempty"`   // gh-aw CLI version
 	WorkflowName    string      `json:"workflow_name"`
 	Staged          bool        `json:"staged"`
 	AwfVersion      string      `json:"awf_version,omitempty"`      // AWF firewall version (new name)
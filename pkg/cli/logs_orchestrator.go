@@ -40,7 +40,7 @@ func getMaxConcurrentDownloads() int {
 }
 
 // DownloadWorkflowLogs downloads and analyzes workflow logs with metrics
-func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, startDate, endDate, outputDir, engine, ref string, beforeRunID, afterRunID int64, repoOverride string, verbose bool, toolGraph bool, noStaged bool, firewallOnly bool, noFirewall bool, parse bool, jsonOutput bool, timeout int, summaryFile string, safeOutputType string) error {
+func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, startDate, endDate, outputDir, engine, ref string, beforeRunID, afterRunID int64, repoOverride string, verbose bool, toolGraph bool, noStaged bool, firewallOnly bool, noFirewall bool, parse bool, jsonOutput bool, timeout int, summaryFile string, safeOutputType string, otelExport string, engineReport bool, reportFile string, resources bool) error {
 	logsOrchestratorLog.Printf("Starting workflow log download: workflow=%s, count=%d, startDate=%s, endDate=%s, outputDir=%s, summaryFile=%s, safeOutputType=%s", workflowName, count, startDate, endDate, outputDir, summaryFile, safeOutputType)
 
 	// Ensure .github/aw/logs/.gitignore exists on every invocation
@@ -475,6 +475,12 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 	// Build structured logs data
 	logsOrchestratorLog.Printf("Building logs data from %d processed runs (continuation=%t)", len(processedRuns), continuation != nil)
 	logsData := buildLogsData(processedRuns, outputDir, continuation)
+	if engineReport {
+		logsData.EngineReport = buildEngineReport(processedRuns)
+	}
+	if resources {
+		logsData.ResourceReport = buildResourceReport(processedRuns)
+	}
 
 	// Write summary file if requested (default behavior unless disabled with empty string)
 	if summaryFile != "" {
@@ -484,6 +490,20 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 		}
 	}
 
+	// Write the standalone HTML report if requested
+	if reportFile != "" {
+		if err := writeHTMLReport(reportFile, logsData, processedRuns, verbose); err != nil {
+			return err
+		}
+	}
+
+	// Export OpenTelemetry traces if requested
+	if otelExport != "" {
+		if err := exportOTelTraces(otelExport, processedRuns, verbose); err != nil {
+			return err
+		}
+	}
+
 	// Render output based on format preference
 	if jsonOutput {
 		if err := renderLogsJSON(logsData); err != nil {
@@ -491,6 +511,8 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 		}
 	} else {
 		renderLogsConsole(logsData)
+		renderEngineReportWarnings(logsData.EngineReport)
+		renderResourceReportWarnings(logsData.ResourceReport)
 
 		// Display aggregated gateway metrics if any runs have gateway.jsonl files
 		displayAggregatedGatewayMetrics(processedRuns, outputDir, verbose)
@@ -813,25 +835,30 @@ func normalizeSafeOutputType(safeOutputType string) string {
 	return strings.ReplaceAll(safeOutputType, "-", "_")
 }
 
+// findRunAgentOutputPath returns the path of agent_output.json in a run directory,
+// supporting both the flattened form and the old artifact directory form
+func findRunAgentOutputPath(runDir string) (string, bool) {
+	agentOutputPath := filepath.Join(runDir, constants.AgentOutputFilename)
+	if stat, err := os.Stat(agentOutputPath); err == nil && !stat.IsDir() {
+		return agentOutputPath, true
+	}
+	oldPath := filepath.Join(runDir, constants.AgentOutputArtifactName, constants.AgentOutputArtifactName)
+	if _, err := os.Stat(oldPath); err == nil {
+		return oldPath, true
+	}
+	return "", false
+}
+
 // runContainsSafeOutputType checks if a run's agent_output.json contains a specific safe output type
 func runContainsSafeOutputType(runDir string, safeOutputType string, verbose bool) (bool, error) {
 	logsOrchestratorLog.Printf("Checking run for safe output type: dir=%s, type=%s", runDir, safeOutputType)
 	// Normalize the type for comparison (convert dashes to underscores)
 	normalizedType := normalizeSafeOutputType(safeOutputType)
 
-	// Look for agent_output.json in the run directory
-	agentOutputPath := filepath.Join(runDir, constants.AgentOutputFilename)
-
-	// Support both new flattened form and old directory form
-	if stat, err := os.Stat(agentOutputPath); err != nil || stat.IsDir() {
-		// Try old structure
-		oldPath := filepath.Join(runDir, constants.AgentOutputArtifactName, constants.AgentOutputArtifactName)
-		if _, err := os.Stat(oldPath); err == nil {
-			agentOutputPath = oldPath
-		} else {
-			// No agent_output.json found
-			return false, nil
-		}
+	agentOutputPath, ok := findRunAgentOutputPath(runDir)
+	if !ok {
+		// No agent_output.json found
+		return false, nil
 	}
 
 	// Read the file
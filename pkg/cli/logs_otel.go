@@ -0,0 +1,358 @@
+// This file provides OpenTelemetry trace export for the logs and audit commands.
+//
+// Each workflow run becomes one trace: a root span for the run, a child span per
+// job, a child span per job step, and a span per MCP tool call recorded by the
+// gateway. Traces are encoded as OTLP/JSON (ExportTraceServiceRequest) and either
+// written to a file or posted to an OTLP/HTTP endpoint.
+//
+// Trace and span IDs are derived from the run ID, so exporting the same run twice
+// produces the same IDs and backends can deduplicate it.
+
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var logsOTelLog = logger.New("cli:logs_otel")
+
+// otelExportTimeout bounds how long an OTLP/HTTP export may take
+const otelExportTimeout = 30 * time.Second
+
+// OTLP span kinds and status codes (from the OTLP protobuf definitions)
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindClient   = 3
+	otlpStatusOK         = 1
+	otlpStatusError      = 2
+)
+
+// otlpExportRequest is the OTLP/JSON ExportTraceServiceRequest payload
+type otlpExportRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+// otlpAnyValue holds exactly one of the typed values; int64 values are strings in OTLP/JSON
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func otlpString(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func otlpInt(key string, value int64) otlpKeyValue {
+	s := strconv.FormatInt(value, 10)
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{IntValue: &s}}
+}
+
+func otlpDouble(key string, value float64) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{DoubleValue: &value}}
+}
+
+func otlpTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// otelID derives a stable hex ID of the given byte length from its parts
+func otelID(length int, parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:length])
+}
+
+// otlpConclusionStatus maps a GitHub Actions conclusion to an OTLP span status
+func otlpConclusionStatus(conclusion string) otlpStatus {
+	switch {
+	case conclusion == "":
+		return otlpStatus{}
+	case isFailureConclusion(conclusion):
+		return otlpStatus{Code: otlpStatusError, Message: conclusion}
+	default:
+		return otlpStatus{Code: otlpStatusOK}
+	}
+}
+
+// buildOTelTraces converts processed runs into an OTLP trace export request
+func buildOTelTraces(runs []ProcessedRun) otlpExportRequest {
+	var spans []otlpSpan
+	for _, pr := range runs {
+		spans = append(spans, buildRunSpans(pr)...)
+	}
+	logsOTelLog.Printf("Built %d spans for %d runs", len(spans), len(runs))
+
+	return otlpExportRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{Attributes: []otlpKeyValue{
+				otlpString("service.name", "gh-aw"),
+				otlpString("service.version", GetVersion()),
+			}},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/github/gh-aw/pkg/cli", Version: GetVersion()},
+				Spans: spans,
+			}},
+		}},
+	}
+}
+
+// buildRunSpans builds the spans for one workflow run
+func buildRunSpans(pr ProcessedRun) []otlpSpan {
+	run := pr.Run
+	runID := strconv.FormatInt(run.DatabaseID, 10)
+	traceID := otelID(16, "run", runID)
+	rootID := otelID(8, "run", runID, "root")
+
+	start := run.StartedAt
+	if start.IsZero() {
+		start = run.CreatedAt
+	}
+	end := run.UpdatedAt
+	if run.Duration > 0 {
+		end = start.Add(run.Duration)
+	}
+	if end.Before(start) {
+		end = start
+	}
+
+	root := otlpSpan{
+		TraceID:           traceID,
+		SpanID:            rootID,
+		Name:              run.WorkflowName,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: otlpTime(start),
+		EndTimeUnixNano:   otlpTime(end),
+		Attributes: []otlpKeyValue{
+			otlpInt("cicd.pipeline.run.id", run.DatabaseID),
+			otlpString("cicd.pipeline.name", run.WorkflowName),
+			otlpString("github.workflow.path", run.WorkflowPath),
+			otlpString("github.event", run.Event),
+			otlpString("vcs.ref.head.name", run.HeadBranch),
+			otlpString("vcs.ref.head.revision", run.HeadSha),
+			otlpString("url.full", run.URL),
+			otlpString("github.conclusion", run.Conclusion),
+			otlpInt("gh_aw.token_usage", int64(run.TokenUsage)),
+			otlpDouble("gh_aw.estimated_cost", run.EstimatedCost),
+			otlpInt("gh_aw.turns", int64(run.Turns)),
+			otlpInt("gh_aw.errors", int64(run.ErrorCount)),
+			otlpInt("gh_aw.warnings", int64(run.WarningCount)),
+			otlpInt("gh_aw.missing_tools", int64(run.MissingToolCount)),
+			otlpInt("gh_aw.safe_items", int64(run.SafeItemsCount)),
+		},
+		Status: otlpConclusionStatus(run.Conclusion),
+	}
+	spans := []otlpSpan{root}
+
+	// Tool calls are attributed to the agent job when it is present
+	toolParentID := rootID
+
+	for i, job := range pr.JobDetails {
+		if job.StartedAt.IsZero() {
+			continue
+		}
+		jobID := otelID(8, "run", runID, "job", strconv.Itoa(i), job.Name)
+		if job.Name == "agent" {
+			toolParentID = jobID
+		}
+		spans = append(spans, otlpSpan{
+			TraceID:           traceID,
+			SpanID:            jobID,
+			ParentSpanID:      rootID,
+			Name:              job.Name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: otlpTime(job.StartedAt),
+			EndTimeUnixNano:   otlpTime(completedOrStart(job.StartedAt, job.CompletedAt)),
+			Attributes:        []otlpKeyValue{otlpString("cicd.pipeline.task.name", job.Name)},
+			Status:            otlpConclusionStatus(job.Conclusion),
+		})
+
+		for _, step := range job.Steps {
+			if step.StartedAt.IsZero() {
+				continue
+			}
+			spans = append(spans, otlpSpan{
+				TraceID:           traceID,
+				SpanID:            otelID(8, "run", runID, "job", strconv.Itoa(i), "step", strconv.Itoa(step.Number)),
+				ParentSpanID:      jobID,
+				Name:              step.Name,
+				Kind:              otlpSpanKindInternal,
+				StartTimeUnixNano: otlpTime(step.StartedAt),
+				EndTimeUnixNano:   otlpTime(completedOrStart(step.StartedAt, step.CompletedAt)),
+				Attributes:        []otlpKeyValue{otlpInt("gh_aw.step.number", int64(step.Number))},
+				Status:            otlpConclusionStatus(step.Conclusion),
+			})
+		}
+	}
+
+	if pr.MCPToolUsage != nil {
+		for i, call := range pr.MCPToolUsage.ToolCalls {
+			callStart, err := time.Parse(time.RFC3339Nano, call.Timestamp)
+			if err != nil {
+				logsOTelLog.Printf("Skipping tool call with invalid timestamp %q: %v", call.Timestamp, err)
+				continue
+			}
+			callEnd := callStart
+			if d, err := time.ParseDuration(call.Duration); err == nil {
+				callEnd = callStart.Add(d)
+			}
+
+			status := otlpStatus{}
+			switch call.Status {
+			case "success":
+				status = otlpStatus{Code: otlpStatusOK}
+			case "error":
+				status = otlpStatus{Code: otlpStatusError, Message: call.Error}
+			}
+
+			spans = append(spans, otlpSpan{
+				TraceID:           traceID,
+				SpanID:            otelID(8, "run", runID, "tool", strconv.Itoa(i)),
+				ParentSpanID:      toolParentID,
+				Name:              call.ServerName + "." + call.ToolName,
+				Kind:              otlpSpanKindClient,
+				StartTimeUnixNano: otlpTime(callStart),
+				EndTimeUnixNano:   otlpTime(callEnd),
+				Attributes: []otlpKeyValue{
+					otlpString("gen_ai.tool.name", call.ToolName),
+					otlpString("mcp.server.name", call.ServerName),
+					otlpInt("gh_aw.tool.input_size", int64(call.InputSize)),
+					otlpInt("gh_aw.tool.output_size", int64(call.OutputSize)),
+				},
+				Status: status,
+			})
+		}
+	}
+
+	return spans
+}
+
+func completedOrStart(start, completed time.Time) time.Time {
+	if completed.Before(start) {
+		return start
+	}
+	return completed
+}
+
+// exportOTelTraces writes traces for the given runs to an OTLP/HTTP endpoint
+// (when target is an http(s) URL) or to a JSON file
+func exportOTelTraces(target string, runs []ProcessedRun, verbose bool) error {
+	if len(runs) == 0 {
+		logsOTelLog.Print("No runs to export")
+		return nil
+	}
+
+	data, err := json.Marshal(buildOTelTraces(runs))
+	if err != nil {
+		return fmt.Errorf("failed to encode OTLP traces: %w", err)
+	}
+
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		if err := postOTelTraces(target, data); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Exported traces for %d run(s) to %s", len(runs), target)))
+		return nil
+	}
+
+	if dir := filepath.Dir(target); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", target, err)
+		}
+	}
+	if err := os.WriteFile(target, data, 0644); err != nil {
+		return fmt.Errorf("failed to write OTLP traces: %w", err)
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Wrote %d bytes of OTLP/JSON traces", len(data))))
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Wrote traces for %d run(s) to %s", len(runs), target)))
+	return nil
+}
+
+// postOTelTraces sends an OTLP/JSON payload to an OTLP/HTTP collector.
+// A bare endpoint gets the standard /v1/traces path appended, and headers from
+// OTEL_EXPORTER_OTLP_HEADERS (key=value,key=value) are added to the request.
+func postOTelTraces(endpoint string, data []byte) error {
+	url := endpoint
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url = strings.TrimSuffix(url, "/") + "/v1/traces"
+	}
+	logsOTelLog.Printf("Posting %d bytes of OTLP traces to %s", len(data), url)
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid OTLP endpoint %s: %w", endpoint, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for header := range strings.SplitSeq(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"), ",") {
+		if key, value, ok := strings.Cut(header, "="); ok && strings.TrimSpace(key) != "" {
+			req.Header.Set(strings.TrimSpace(key), strings.TrimSpace(value))
+		}
+	}
+
+	client := &http.Client{Timeout: otelExportTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export traces to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("OTLP endpoint %s rejected traces: %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func otelTestRun() ProcessedRun {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	return ProcessedRun{
+		Run: WorkflowRun{
+			DatabaseID:   4242,
+			WorkflowName: "Issue Triage",
+			Conclusion:   "failure",
+			StartedAt:    start,
+			Duration:     5 * time.Minute,
+			TokenUsage:   1200,
+		},
+		JobDetails: []JobInfoWithDuration{
+			{JobInfo: JobInfo{Name: "activation", Conclusion: "success", StartedAt: start, CompletedAt: start.Add(time.Minute)}},
+			{JobInfo: JobInfo{
+				Name:        "agent",
+				Conclusion:  "failure",
+				StartedAt:   start.Add(time.Minute),
+				CompletedAt: start.Add(4 * time.Minute),
+				Steps: []StepInfo{
+					{Name: "Execute agent", Number: 3, Conclusion: "failure", StartedAt: start.Add(2 * time.Minute), CompletedAt: start.Add(3 * time.Minute)},
+				},
+			}},
+		},
+		MCPToolUsage: &MCPToolUsageData{
+			ToolCalls: []MCPToolCall{
+				{Timestamp: start.Add(150 * time.Second).Format(time.RFC3339Nano), ServerName: "github", ToolName: "issue_read", Duration: "250ms", Status: "success"},
+				{Timestamp: "not-a-time", ServerName: "github", ToolName: "search_issues", Status: "success"},
+			},
+		},
+	}
+}
+
+func TestBuildRunSpans(t *testing.T) {
+	spans := buildRunSpans(otelTestRun())
+	require.Len(t, spans, 5, "should build run, two job, one step and one tool call span")
+
+	root, activation, agent, step, tool := spans[0], spans[1], spans[2], spans[3], spans[4]
+
+	assert.Equal(t, "Issue Triage", root.Name, "root span should be named after the workflow")
+	assert.Empty(t, root.ParentSpanID, "root span should have no parent")
+	assert.Equal(t, otlpStatusError, root.Status.Code, "failed run should have error status")
+	assert.Len(t, root.TraceID, 32, "trace ID should be 16 hex bytes")
+	assert.Len(t, root.SpanID, 16, "span ID should be 8 hex bytes")
+
+	assert.Equal(t, root.SpanID, activation.ParentSpanID, "jobs should be children of the run")
+	assert.Equal(t, otlpStatusOK, activation.Status.Code, "successful job should have ok status")
+	assert.Equal(t, agent.SpanID, step.ParentSpanID, "steps should be children of their job")
+	assert.Equal(t, "Execute agent", step.Name, "step span should be named after the step")
+
+	assert.Equal(t, "github.issue_read", tool.Name, "tool span should name the server and tool")
+	assert.Equal(t, agent.SpanID, tool.ParentSpanID, "tool calls should be children of the agent job")
+	assert.Equal(t, otlpSpanKindClient, tool.Kind, "tool calls should be client spans")
+	start := time.Date(2026, 3, 1, 10, 2, 30, 0, time.UTC)
+	assert.Equal(t, otlpTime(start), tool.StartTimeUnixNano, "tool call should start at its timestamp")
+	assert.Equal(t, otlpTime(start.Add(250*time.Millisecond)), tool.EndTimeUnixNano, "tool call should end after its duration")
+
+	again := buildRunSpans(otelTestRun())
+	assert.Equal(t, spans, again, "IDs should be stable across exports")
+}
+
+func TestExportOTelTracesToFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "otel-export-test")
+	target := filepath.Join(tmpDir, "traces", "runs.json")
+
+	require.NoError(t, exportOTelTraces(target, []ProcessedRun{otelTestRun()}, false), "export should succeed")
+
+	data, err := os.ReadFile(target)
+	require.NoError(t, err, "trace file should be written")
+
+	var request otlpExportRequest
+	require.NoError(t, json.Unmarshal(data, &request), "trace file should be OTLP/JSON")
+	require.Len(t, request.ResourceSpans, 1, "should have one resource")
+	assert.Equal(t, "service.name", request.ResourceSpans[0].Resource.Attributes[0].Key, "resource should name the service")
+	assert.Len(t, request.ResourceSpans[0].ScopeSpans[0].Spans, 5, "all spans should be exported")
+	assert.Contains(t, string(data), `"intValue":"4242"`, "int attributes should be encoded as strings")
+}
+
+func TestExportOTelTracesToEndpoint(t *testing.T) {
+	var gotPath, gotContentType, gotAuth string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotContentType = r.Header.Get("Content-Type")
+		gotAuth = r.Header.Get("Authorization")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer token")
+	require.NoError(t, exportOTelTraces(server.URL, []ProcessedRun{otelTestRun()}, false), "export should succeed")
+
+	assert.Equal(t, "/v1/traces", gotPath, "bare endpoint should get the traces path")
+	assert.Equal(t, "application/json", gotContentType, "payload should be JSON")
+	assert.Equal(t, "Bearer token", gotAuth, "OTEL_EXPORTER_OTLP_HEADERS should be sent")
+	assert.Contains(t, string(gotBody), `"resourceSpans"`, "payload should be an export request")
+
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer rejecting.Close()
+
+	err := exportOTelTraces(rejecting.URL+"/v1/traces", []ProcessedRun{otelTestRun()}, false)
+	require.Error(t, err, "rejected export should fail")
+	assert.Contains(t, err.Error(), "400", "error should include the status")
+}
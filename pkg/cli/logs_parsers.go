@@ -0,0 +1,129 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (logs_parsers.go) defines the pluggable log parser interface used
+// to extract metrics from agent logs.
+//
+// Key responsibilities:
+//   - Defining the LogParser interface implemented per engine
+//   - Registering the built-in parsers for every workflow engine and for the
+//     GitHub Copilot coding agent
+//   - Looking up the parser for the engine recorded in aw_info.json
+//
+// New engines or log formats register a parser with RegisterLogParser instead
+// of changing the logs command.
+
+package cli
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var logParsersLog = logger.New("cli:logs_parsers")
+
+// CopilotCodingAgentLogParserID identifies the parser for GitHub Copilot coding agent runs,
+// which are detected from the run rather than from aw_info.json
+const CopilotCodingAgentLogParserID = "copilot-coding-agent"
+
+// LogParser extracts metrics (token usage, cost, turns and tool calls) from an agent log
+type LogParser interface {
+	// ID returns the engine ID the parser handles, as recorded in aw_info.json
+	ID() string
+	// ParseLogMetrics extracts metrics from the content of a single log file
+	ParseLogMetrics(logContent string, verbose bool) LogMetrics
+}
+
+// LogParserRegistry holds the log parsers by engine ID
+type LogParserRegistry struct {
+	mu      sync.RWMutex
+	parsers map[string]LogParser
+}
+
+var (
+	globalLogParserRegistry *LogParserRegistry
+	logParserRegistryOnce   sync.Once
+)
+
+// GetLogParserRegistry returns the global log parser registry with the built-in parsers registered
+func GetLogParserRegistry() *LogParserRegistry {
+	logParserRegistryOnce.Do(func() {
+		globalLogParserRegistry = NewLogParserRegistry()
+	})
+	return globalLogParserRegistry
+}
+
+// NewLogParserRegistry creates a registry with a parser for every registered workflow engine
+// and for the GitHub Copilot coding agent
+func NewLogParserRegistry() *LogParserRegistry {
+	registry := &LogParserRegistry{parsers: make(map[string]LogParser)}
+
+	engines := workflow.GetGlobalEngineRegistry()
+	for _, id := range engines.GetSupportedEngines() {
+		if engine, err := engines.GetEngine(id); err == nil {
+			registry.Register(engineLogParser{engine: engine})
+		}
+	}
+	registry.Register(copilotCodingAgentLogParser{})
+
+	return registry
+}
+
+// Register adds a parser to the registry, replacing any parser registered for the same engine ID
+func (r *LogParserRegistry) Register(parser LogParser) {
+	logParsersLog.Printf("Registering log parser: id=%s", parser.ID())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parsers[parser.ID()] = parser
+}
+
+// Get returns the parser registered for an engine ID
+func (r *LogParserRegistry) Get(id string) (LogParser, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	parser, ok := r.parsers[id]
+	return parser, ok
+}
+
+// IDs returns the engine IDs with a registered parser in sorted order
+func (r *LogParserRegistry) IDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.parsers))
+	for id := range r.parsers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// RegisterLogParser registers a parser in the global registry. Use it to support a new engine
+// or to replace the built-in parser of an engine whose log format changed.
+func RegisterLogParser(parser LogParser) {
+	GetLogParserRegistry().Register(parser)
+}
+
+// engineLogParser parses logs with the engine's own ParseLogMetrics implementation
+type engineLogParser struct {
+	engine workflow.CodingAgentEngine
+}
+
+func (p engineLogParser) ID() string {
+	return p.engine.GetID()
+}
+
+func (p engineLogParser) ParseLogMetrics(logContent string, verbose bool) LogMetrics {
+	return p.engine.ParseLogMetrics(logContent, verbose)
+}
+
+// copilotCodingAgentLogParser parses GitHub Copilot coding agent logs
+type copilotCodingAgentLogParser struct{}
+
+func (copilotCodingAgentLogParser) ID() string {
+	return CopilotCodingAgentLogParserID
+}
+
+func (copilotCodingAgentLogParser) ParseLogMetrics(logContent string, verbose bool) LogMetrics {
+	return ParseCopilotCodingAgentLogMetrics(logContent, verbose)
+}
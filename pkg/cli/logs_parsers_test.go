@@ -0,0 +1,71 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lineCountLogParser is a test parser that reports one turn per log line
+type lineCountLogParser struct {
+	id string
+}
+
+func (p lineCountLogParser) ID() string {
+	return p.id
+}
+
+func (p lineCountLogParser) ParseLogMetrics(logContent string, verbose bool) LogMetrics {
+	return LogMetrics{Turns: len(strings.Split(strings.TrimSpace(logContent), "\n")), TokenUsage: 42}
+}
+
+func TestNewLogParserRegistry(t *testing.T) {
+	registry := NewLogParserRegistry()
+
+	for _, id := range []string{"claude", "codex", "copilot", "gemini", CopilotCodingAgentLogParserID} {
+		parser, ok := registry.Get(id)
+		require.True(t, ok, "built-in parser for %s should be registered", id)
+		assert.Equal(t, id, parser.ID(), "parser ID")
+	}
+
+	_, ok := registry.Get("unknown")
+	assert.False(t, ok, "unknown engines should have no parser")
+
+	registry.Register(lineCountLogParser{id: "claude"})
+	parser, _ := registry.Get("claude")
+	assert.IsType(t, lineCountLogParser{}, parser, "registering should replace the built-in parser")
+	assert.Contains(t, registry.IDs(), CopilotCodingAgentLogParserID, "IDs should list registered parsers")
+}
+
+func TestResolveLogParser(t *testing.T) {
+	parser := resolveLogParser("claude", false)
+	require.NotNil(t, parser, "claude should resolve to a parser")
+	assert.Equal(t, "claude", parser.ID(), "engine parser")
+
+	parser = resolveLogParser("claude", true)
+	require.NotNil(t, parser, "coding agent runs should resolve to a parser")
+	assert.Equal(t, CopilotCodingAgentLogParserID, parser.ID(), "coding agent detection takes precedence")
+
+	assert.Nil(t, resolveLogParser("", false), "runs without engine have no parser")
+	assert.Nil(t, resolveLogParser("not-registered", false), "unregistered engines have no parser")
+}
+
+func TestExtractLogMetricsUsesRegisteredParser(t *testing.T) {
+	RegisterLogParser(lineCountLogParser{id: "test-line-count-engine"})
+
+	logDir := testutil.TempDir(t, "log-parser-*")
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "aw_info.json"), []byte(`{"engine_id": "test-line-count-engine"}`), 0644), "should write aw_info.json")
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "agent-stdio.log"), []byte("one\ntwo\nthree\n"), 0644), "should write log")
+
+	metrics, err := extractLogMetrics(logDir, false)
+	require.NoError(t, err, "should extract metrics")
+	assert.Equal(t, 3, metrics.Turns, "turns from the registered parser")
+	assert.Equal(t, 42, metrics.TokenUsage, "token usage from the registered parser")
+}
@@ -3,7 +3,7 @@
 // functionality for various AI engines (Claude, Copilot, Codex, Custom).
 //
 // Key responsibilities:
-//   - Parsing log files using engine-specific parsers
+//   - Parsing log files using the registered engine-specific parsers (see logs_parsers.go)
 //   - Detecting and parsing GitHub Copilot coding agent logs
 //   - Falling back to generic parser when engine is unknown
 
@@ -22,7 +22,33 @@ var logsParsingEnginesLog = logger.New("cli:logs_parsing_engines")
 
 // parseLogFileWithEngine parses a log file using a specific engine or falls back to auto-detection
 func parseLogFileWithEngine(filePath string, detectedEngine workflow.CodingAgentEngine, isGitHubCopilotCodingAgent bool, verbose bool) (LogMetrics, error) {
-	logsParsingEnginesLog.Printf("Parsing log file: %s, isGitHubCopilotCodingAgent=%v", filePath, isGitHubCopilotCodingAgent)
+	engineID := ""
+	if detectedEngine != nil {
+		engineID = detectedEngine.GetID()
+	}
+	return parseLogFileWithParser(filePath, resolveLogParser(engineID, isGitHubCopilotCodingAgent), verbose)
+}
+
+// resolveLogParser returns the registered parser for a run, or nil when no parser handles its engine
+func resolveLogParser(engineID string, isGitHubCopilotCodingAgent bool) LogParser {
+	// GitHub Copilot coding agent runs have no aw_info.json engine and use a specialized parser
+	if isGitHubCopilotCodingAgent {
+		engineID = CopilotCodingAgentLogParserID
+	}
+	if engineID == "" {
+		return nil
+	}
+	parser, ok := GetLogParserRegistry().Get(engineID)
+	if !ok {
+		logsParsingEnginesLog.Printf("No log parser registered for engine: %s", engineID)
+		return nil
+	}
+	return parser
+}
+
+// parseLogFileWithParser parses a log file with the given parser, returning empty metrics when parser is nil
+func parseLogFileWithParser(filePath string, parser LogParser, verbose bool) (LogMetrics, error) {
+	logsParsingEnginesLog.Printf("Parsing log file: %s", filePath)
 	// Read the entire log file at once to avoid JSON parsing issues from chunked reading
 	content, err := os.ReadFile(filePath)
 	if err != nil {
@@ -33,16 +59,9 @@ func parseLogFileWithEngine(filePath string, detectedEngine workflow.CodingAgent
 	logContent := string(content)
 	logsParsingEnginesLog.Printf("Read %d bytes from log file", len(logContent))
 
-	// If this is a GitHub Copilot coding agent run, use the specialized parser
-	if isGitHubCopilotCodingAgent {
-		logsParsingEnginesLog.Print("Using GitHub Copilot coding agent parser")
-		return ParseCopilotCodingAgentLogMetrics(logContent, verbose), nil
-	}
-
-	// If we have a detected engine from aw_info.json, use it directly
-	if detectedEngine != nil {
-		logsParsingEnginesLog.Printf("Using detected engine: %s", detectedEngine.GetID())
-		return detectedEngine.ParseLogMetrics(logContent, verbose), nil
+	if parser != nil {
+		logsParsingEnginesLog.Printf("Using log parser: %s", parser.ID())
+		return parser.ParseLogMetrics(logContent, verbose), nil
 	}
 
 	// No aw_info.json metadata available - use fallback parser with common error patterns
@@ -31,6 +31,8 @@ type LogsData struct {
 	AccessLog         *AccessLogSummary          `json:"access_log,omitempty" console:"title:Access Log Analysis,omitempty"`
 	FirewallLog       *FirewallLogSummary        `json:"firewall_log,omitempty" console:"title:🔥 Firewall Log Analysis,omitempty"`
 	RedactedDomains   *RedactedDomainsLogSummary `json:"redacted_domains,omitempty" console:"title:🔒 Redacted URL Domains,omitempty"`
+	EngineReport      *EngineReport              `json:"engine_report,omitempty" console:"title:🤖 Engine Report,omitempty"`
+	ResourceReport    *ResourceReport            `json:"resource_report,omitempty" console:"title:📈 Runner Resources,omitempty"`
 	Continuation      *ContinuationData          `json:"continuation,omitempty" console:"-"`
 	LogsLocation      string                     `json:"logs_location" console:"-"`
 }
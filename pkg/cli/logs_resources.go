@@ -0,0 +1,171 @@
+// This file provides the runner resource report for the logs command.
+//
+// Workflows with resource-telemetry enabled run a background sampler in the agent
+// job that records CPU, memory, disk and network usage to resource-usage.jsonl,
+// which is uploaded with the agent artifacts. The report summarizes the samples of
+// each run so runners can be sized to what the agent actually uses.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/timeutil"
+)
+
+var logsResourcesLog = logger.New("cli:logs_resources")
+
+const (
+	// resourceUsageFileName is the sampler output in the agent artifacts
+	resourceUsageFileName = "resource-usage.jsonl"
+	// resourcePressureRatio is the share of memory or disk above which a run is flagged
+	resourcePressureRatio = 0.9
+)
+
+// ResourceSample is a single line written by the resource sampler
+type ResourceSample struct {
+	Timestamp        time.Time `json:"timestamp"`
+	CPUCount         int       `json:"cpu_count"`
+	CPUPercent       float64   `json:"cpu_percent"`
+	MemoryUsedBytes  int64     `json:"memory_used_bytes"`
+	MemoryTotalBytes int64     `json:"memory_total_bytes"`
+	DiskUsedBytes    int64     `json:"disk_used_bytes"`
+	DiskTotalBytes   int64     `json:"disk_total_bytes"`
+	NetworkRxBytes   int64     `json:"network_rx_bytes"`
+	NetworkTxBytes   int64     `json:"network_tx_bytes"`
+}
+
+// ResourceReport contains the runner resource usage produced by --resources
+type ResourceReport struct {
+	Runs []ResourceReportRun `json:"runs" console:"title:Resource Usage by Run"`
+}
+
+// ResourceReportRun summarizes the resource samples of a single run
+type ResourceReportRun struct {
+	DatabaseID       int64   `json:"database_id" console:"header:Run ID"`
+	WorkflowName     string  `json:"workflow_name" console:"header:Workflow"`
+	Samples          int     `json:"samples" console:"header:Samples"`
+	Duration         string  `json:"duration,omitempty" console:"header:Sampled,default:-"`
+	CPUCount         int     `json:"cpu_count,omitempty" console:"header:CPUs,default:-"`
+	AvgCPUPercent    float64 `json:"avg_cpu_percent" console:"header:Avg CPU %"`
+	PeakCPUPercent   float64 `json:"peak_cpu_percent" console:"header:Peak CPU %"`
+	PeakMemoryBytes  int64   `json:"peak_memory_bytes" console:"header:Peak Memory,format:filesize"`
+	MemoryTotalBytes int64   `json:"memory_total_bytes" console:"header:Memory,format:filesize"`
+	PeakDiskBytes    int64   `json:"peak_disk_bytes" console:"header:Peak Disk,format:filesize"`
+	DiskTotalBytes   int64   `json:"disk_total_bytes" console:"header:Disk,format:filesize"`
+	NetworkRxBytes   int64   `json:"network_rx_bytes" console:"header:Net In,format:filesize"`
+	NetworkTxBytes   int64   `json:"network_tx_bytes" console:"header:Net Out,format:filesize"`
+}
+
+// buildResourceReport reads the resource samples of each processed run and builds the report.
+// Runs without samples, such as runs of workflows without resource-telemetry, are left out.
+func buildResourceReport(processedRuns []ProcessedRun) *ResourceReport {
+	report := &ResourceReport{Runs: make([]ResourceReportRun, 0, len(processedRuns))}
+
+	for _, pr := range processedRuns {
+		run := pr.Run
+		if run.LogsPath == "" {
+			continue
+		}
+		samples, err := parseResourceSamples(filepath.Join(run.LogsPath, resourceUsageFileName))
+		if err != nil {
+			logsResourcesLog.Printf("No resource samples for run %d: %v", run.DatabaseID, err)
+			continue
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		entry := summarizeResourceSamples(samples)
+		entry.DatabaseID = run.DatabaseID
+		entry.WorkflowName = run.WorkflowName
+		report.Runs = append(report.Runs, entry)
+	}
+
+	logsResourcesLog.Printf("Built resource report: runs=%d, with samples=%d", len(processedRuns), len(report.Runs))
+	return report
+}
+
+// parseResourceSamples reads a resource-usage.jsonl file, skipping lines that are not valid
+// samples such as a line cut off when the sampler was stopped
+func parseResourceSamples(path string) ([]ResourceSample, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var samples []ResourceSample
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var sample ResourceSample
+		if err := json.Unmarshal(scanner.Bytes(), &sample); err != nil {
+			logsResourcesLog.Printf("Skipping invalid resource sample in %s: %v", path, err)
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read resource samples: %w", err)
+	}
+	return samples, nil
+}
+
+// summarizeResourceSamples computes the average and peak usage of a run's samples.
+// Network counters are totals since boot, so the traffic of the run is the difference
+// between the last and the first sample.
+func summarizeResourceSamples(samples []ResourceSample) ResourceReportRun {
+	first := samples[0]
+	last := samples[len(samples)-1]
+	entry := ResourceReportRun{
+		Samples:          len(samples),
+		CPUCount:         last.CPUCount,
+		MemoryTotalBytes: last.MemoryTotalBytes,
+		DiskTotalBytes:   last.DiskTotalBytes,
+		NetworkRxBytes:   max(last.NetworkRxBytes-first.NetworkRxBytes, 0),
+		NetworkTxBytes:   max(last.NetworkTxBytes-first.NetworkTxBytes, 0),
+	}
+	if !first.Timestamp.IsZero() && last.Timestamp.After(first.Timestamp) {
+		entry.Duration = timeutil.FormatDuration(last.Timestamp.Sub(first.Timestamp))
+	}
+
+	var totalCPU float64
+	for _, sample := range samples {
+		totalCPU += sample.CPUPercent
+		entry.PeakCPUPercent = max(entry.PeakCPUPercent, sample.CPUPercent)
+		entry.PeakMemoryBytes = max(entry.PeakMemoryBytes, sample.MemoryUsedBytes)
+		entry.PeakDiskBytes = max(entry.PeakDiskBytes, sample.DiskUsedBytes)
+	}
+	entry.AvgCPUPercent = math.Round(totalCPU/float64(len(samples))*10) / 10
+	entry.PeakCPUPercent = math.Round(entry.PeakCPUPercent*10) / 10
+	return entry
+}
+
+// renderResourceReportWarnings prints a warning to stderr for each run that came close to running
+// out of memory or disk, and a hint when no run recorded resource samples
+func renderResourceReportWarnings(report *ResourceReport) {
+	if report == nil {
+		return
+	}
+	if len(report.Runs) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No resource samples found. Add 'resource-telemetry: true' to the workflow frontmatter to record runner resource usage."))
+		return
+	}
+	for _, run := range report.Runs {
+		if run.MemoryTotalBytes > 0 && float64(run.PeakMemoryBytes) >= resourcePressureRatio*float64(run.MemoryTotalBytes) {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Run %d of '%s' used %s of %s memory; consider a larger runner",
+				run.DatabaseID, run.WorkflowName, console.FormatFileSize(run.PeakMemoryBytes), console.FormatFileSize(run.MemoryTotalBytes))))
+		}
+		if run.DiskTotalBytes > 0 && float64(run.PeakDiskBytes) >= resourcePressureRatio*float64(run.DiskTotalBytes) {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Run %d of '%s' used %s of %s disk; consider a larger runner or cleaning up the workspace",
+				run.DatabaseID, run.WorkflowName, console.FormatFileSize(run.PeakDiskBytes), console.FormatFileSize(run.DiskTotalBytes))))
+		}
+	}
+}
@@ -0,0 +1,55 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resourceReportRun creates a processed run whose logs directory holds the given resource samples
+func resourceReportRun(t *testing.T, id int64, samples string) ProcessedRun {
+	t.Helper()
+	logsPath := filepath.Join(testutil.TempDir(t, "resource-report-*"), "run")
+	require.NoError(t, os.MkdirAll(logsPath, 0755), "should create logs directory")
+	if samples != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(logsPath, resourceUsageFileName), []byte(samples), 0644), "should write resource samples")
+	}
+	return ProcessedRun{Run: WorkflowRun{DatabaseID: id, WorkflowName: "Build", LogsPath: logsPath}}
+}
+
+func TestBuildResourceReport(t *testing.T) {
+	samples := `{"timestamp":"2026-05-01T06:00:00Z","cpu_count":4,"cpu_percent":20.0,"memory_used_bytes":1000,"memory_total_bytes":16000,"disk_used_bytes":5000,"disk_total_bytes":10000,"network_rx_bytes":100,"network_tx_bytes":10}
+{"timestamp":"2026-05-01T06:00:05Z","cpu_count":4,"cpu_percent":95.5,"memory_used_bytes":4000,"memory_total_bytes":16000,"disk_used_bytes":7000,"disk_total_bytes":10000,"network_rx_bytes":600,"network_tx_bytes":60}
+{"timestamp":"2026-05-01T06:01:40Z","cpu_count":4,"cpu_percent":40.0,"memory_used_bytes":2000,"memory_total_bytes":16000,"disk_used_bytes":6000,"disk_total_bytes":10000,"network_rx_bytes":1100,"network_tx_bytes":110}
+{"timestamp":"2026-05-01T06:01:4`
+
+	runs := []ProcessedRun{
+		resourceReportRun(t, 2, samples),
+		resourceReportRun(t, 1, ""),
+	}
+
+	report := buildResourceReport(runs)
+	require.NotNil(t, report, "report should be built")
+	require.Len(t, report.Runs, 1, "only runs with samples should be listed")
+	assert.Equal(t, ResourceReportRun{
+		DatabaseID:       2,
+		WorkflowName:     "Build",
+		Samples:          3,
+		Duration:         "1.7m",
+		CPUCount:         4,
+		AvgCPUPercent:    51.8,
+		PeakCPUPercent:   95.5,
+		PeakMemoryBytes:  4000,
+		MemoryTotalBytes: 16000,
+		PeakDiskBytes:    7000,
+		DiskTotalBytes:   10000,
+		NetworkRxBytes:   1000,
+		NetworkTxBytes:   100,
+	}, report.Runs[0], "run summary")
+}
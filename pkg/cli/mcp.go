@@ -24,11 +24,13 @@ Available subcommands:
   • list-tools - List available tools for a specific MCP server
   • inspect    - Inspect MCP servers and list available tools, resources, and roots
   • add        - Add an MCP tool to an agentic workflow
+  • oauth      - Obtain a refresh token for an OAuth MCP server with the device flow
 
 Examples:
   gh aw mcp list                              # List all workflows with MCP servers
   gh aw mcp inspect weekly-research           # Inspect MCP servers in workflow
   gh aw mcp add my-workflow tavily            # Add Tavily MCP server to workflow
+  gh aw mcp oauth my-workflow notion          # Authorize an OAuth MCP server
   gh aw mcp inspect weekly-research --server github --tool create_issue  # Inspect specific tool`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
@@ -40,6 +42,7 @@ Examples:
 	cmd.AddCommand(NewMCPListSubcommand())
 	cmd.AddCommand(NewMCPListToolsSubcommand())
 	cmd.AddCommand(NewMCPInspectSubcommand())
+	cmd.AddCommand(NewMCPOAuthSubcommand())
 
 	return cmd
 }
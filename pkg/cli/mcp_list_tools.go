@@ -1,8 +1,10 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -20,9 +22,33 @@ const (
 	maxDescriptionLength = 60
 )
 
+// MCPListToolsOptions holds the options for the mcp list-tools command
+type MCPListToolsOptions struct {
+	Filter     string // Only show tools whose name matches (substring, or glob when it contains * ? [)
+	ShowSchema bool   // Print the JSON input schema of each tool
+	JSONOutput bool   // Print tools as JSON instead of a table
+	Verbose    bool
+}
+
+// MCPToolListing is the JSON representation of a tool returned by mcp list-tools
+type MCPToolListing struct {
+	Server      string `json:"server"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Allowed     bool   `json:"allowed"`
+	InputSchema any    `json:"input_schema,omitempty"`
+}
+
 // ListToolsForMCP lists available tools for a specific MCP server
 func ListToolsForMCP(workflowFile string, mcpServerName string, verbose bool) error {
-	mcpListToolsLog.Printf("Listing tools for MCP server: %s, workflow: %s", mcpServerName, workflowFile)
+	return ListToolsForMCPWithOptions(workflowFile, mcpServerName, MCPListToolsOptions{Verbose: verbose})
+}
+
+// ListToolsForMCPWithOptions lists available tools for a specific MCP server, or for every
+// MCP server configured in the workflow when mcpServerName is empty
+func ListToolsForMCPWithOptions(workflowFile string, mcpServerName string, opts MCPListToolsOptions) error {
+	verbose := opts.Verbose
+	mcpListToolsLog.Printf("Listing tools for MCP server: %s, workflow: %s, filter: %q", mcpServerName, workflowFile, opts.Filter)
 	workflowsDir := getWorkflowsDir()
 
 	// If no workflow file specified, search for workflows containing the MCP server
@@ -46,6 +72,10 @@ func ListToolsForMCP(workflowFile string, mcpServerName string, verbose bool) er
 		workflowPath = filepath.Join(cwd, workflowPath)
 	}
 
+	if mcpServerName == "" {
+		return listToolsForAllMCPServers(workflowPath, opts)
+	}
+
 	if verbose {
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Looking for MCP server '%s' in: %s", mcpServerName, workflowPath)))
 	}
@@ -91,12 +121,144 @@ func ListToolsForMCP(workflowFile string, mcpServerName string, verbose bool) er
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Successfully connected to MCP server"))
 	}
 
+	filtered := filterMCPTools(info, opts.Filter)
+	if opts.JSONOutput {
+		return printMCPToolListingsJSON(buildMCPToolListings([]*parser.MCPServerInfo{filtered}))
+	}
+
 	// Display the tools
-	displayToolsList(info, verbose)
+	displayToolsList(filtered, verbose)
+	if opts.ShowSchema {
+		displayToolSchemas(filtered)
+	}
+
+	return nil
+}
+
+// listToolsForAllMCPServers connects to every MCP server configured in the workflow and lists its tools
+func listToolsForAllMCPServers(workflowPath string, opts MCPListToolsOptions) error {
+	_, mcpConfigs, err := loadWorkflowMCPConfigs(workflowPath, "")
+	if err != nil {
+		return err
+	}
+
+	if len(mcpConfigs) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("No MCP servers configured in workflow '%s'", filepath.Base(workflowPath))))
+		return nil
+	}
+
+	mcpListToolsLog.Printf("Listing tools for %d MCP servers", len(mcpConfigs))
+
+	var infos []*parser.MCPServerInfo
+	for _, config := range mcpConfigs {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("📡 Connecting to MCP server: %s (%s)", config.Name, config.Type)))
+		info, err := connectToMCPServer(config, opts.Verbose)
+		if err != nil {
+			// Keep going so one broken server does not hide the tools of the others
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to connect to MCP server '%s': %v", config.Name, err)))
+			continue
+		}
+		infos = append(infos, filterMCPTools(info, opts.Filter))
+	}
+
+	if opts.JSONOutput {
+		return printMCPToolListingsJSON(buildMCPToolListings(infos))
+	}
+
+	for _, info := range infos {
+		fmt.Fprintf(os.Stderr, "\n%s\n", console.FormatSectionHeader(info.Config.Name))
+		displayToolsList(info, opts.Verbose)
+		if opts.ShowSchema {
+			displayToolSchemas(info)
+		}
+	}
+	return nil
+}
+
+// filterMCPTools returns a copy of the server info that only contains tools matching the filter.
+// A filter containing glob characters is matched against the whole tool name, otherwise it is a
+// case-insensitive substring match.
+func filterMCPTools(info *parser.MCPServerInfo, filter string) *parser.MCPServerInfo {
+	if filter == "" {
+		return info
+	}
+
+	filtered := *info
+	filtered.Tools = nil
+	isGlob := strings.ContainsAny(filter, "*?[")
+	for _, tool := range info.Tools {
+		var matches bool
+		if isGlob {
+			matches, _ = path.Match(filter, tool.Name)
+		} else {
+			matches = strings.Contains(strings.ToLower(tool.Name), strings.ToLower(filter))
+		}
+		if matches {
+			filtered.Tools = append(filtered.Tools, tool)
+		}
+	}
+
+	mcpListToolsLog.Printf("Filter %q matched %d of %d tools on %s", filter, len(filtered.Tools), len(info.Tools), info.Config.Name)
+	return &filtered
+}
 
+// isMCPToolAllowed reports whether the workflow's allowed list permits the tool
+func isMCPToolAllowed(config parser.MCPServerConfig, toolName string) bool {
+	if len(config.Allowed) == 0 {
+		return true
+	}
+	for _, allowed := range config.Allowed {
+		if allowed == "*" || allowed == toolName {
+			return true
+		}
+	}
+	return false
+}
+
+// buildMCPToolListings flattens the tools of the given servers into JSON listings
+func buildMCPToolListings(infos []*parser.MCPServerInfo) []MCPToolListing {
+	listings := []MCPToolListing{}
+	for _, info := range infos {
+		for _, tool := range info.Tools {
+			listings = append(listings, MCPToolListing{
+				Server:      info.Config.Name,
+				Name:        tool.Name,
+				Description: tool.Description,
+				Allowed:     isMCPToolAllowed(info.Config, tool.Name),
+				InputSchema: tool.InputSchema,
+			})
+		}
+	}
+	return listings
+}
+
+// printMCPToolListingsJSON writes the tool listings to stdout as JSON
+func printMCPToolListingsJSON(listings []MCPToolListing) error {
+	data, err := json.MarshalIndent(listings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tools to JSON: %w", err)
+	}
+	fmt.Println(string(data))
 	return nil
 }
 
+// displayToolSchemas prints the JSON input schema of each tool
+func displayToolSchemas(info *parser.MCPServerInfo) {
+	for _, tool := range info.Tools {
+		fmt.Fprintf(os.Stderr, "\n%s\n", console.FormatSectionHeader("📥 "+tool.Name))
+		if tool.InputSchema == nil {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No input schema defined"))
+			continue
+		}
+		schemaJSON, err := json.MarshalIndent(tool.InputSchema, "", "  ")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Error displaying input schema: %v", err)))
+			continue
+		}
+		fmt.Println(string(schemaJSON))
+	}
+}
+
 // findWorkflowsWithMCPServer searches for workflows containing a specific MCP server
 func findWorkflowsWithMCPServer(workflowsDir string, mcpServerName string, verbose bool) error {
 	// Scan workflows for MCP configurations, filtering by server name
@@ -161,6 +323,10 @@ func displayToolsList(info *parser.MCPServerInfo, verbose bool) {
 
 // NewMCPListToolsSubcommand creates the mcp list-tools subcommand
 func NewMCPListToolsSubcommand() *cobra.Command {
+	var filter string
+	var showSchema bool
+	var allServers bool
+
 	cmd := &cobra.Command{
 		Use:   "list-tools <server> [workflow]",
 		Short: "List available tools for a specific MCP server",
@@ -174,31 +340,58 @@ The workflow-id-or-file can be:
 - A workflow ID (basename without .md extension, e.g., "weekly-research")
 - A file path (e.g., "weekly-research.md" or ".github/workflows/weekly-research.md")
 
+Use --all to list the tools of every MCP server configured in a workflow, --schema
+to print each tool's JSON input schema, and --filter to narrow the list down when
+deciding what to put in a server's 'allowed:' list.
+
 Examples:
   gh aw mcp list-tools github                    # Find workflows with 'github' MCP server
   gh aw mcp list-tools github weekly-research    # List tools for 'github' server in weekly-research.md
   gh aw mcp list-tools safe-outputs issue-triage # List tools for 'safe-outputs' server in issue-triage.md
   gh aw mcp list-tools playwright test-workflow -v  # Verbose output with tool descriptions
+  gh aw mcp list-tools --all weekly-research     # List tools for every MCP server in weekly-research.md
+  gh aw mcp list-tools github weekly-research --filter issue --schema  # Issue tools with input schemas
+  gh aw mcp list-tools github weekly-research --filter 'get_*' --json  # Matching tools as JSON
 
 The command will:
 - Parse the workflow to find the specified MCP server configuration
 - Connect to the MCP server using the same logic as 'mcp inspect'
 - Display available tools with their descriptions and allowance status`,
-		Args: cobra.RangeArgs(1, 2),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if allServers {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.RangeArgs(1, 2)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			mcpServerName := args[0]
-			var workflowFile string
-			if len(args) > 1 {
-				workflowFile = args[1]
+			var mcpServerName, workflowFile string
+			if allServers {
+				workflowFile = args[0]
+			} else {
+				mcpServerName = args[0]
+				if len(args) > 1 {
+					workflowFile = args[1]
+				}
 			}
 
 			verbose, _ := cmd.Flags().GetBool("verbose")
-
-			return ListToolsForMCP(workflowFile, mcpServerName, verbose)
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+
+			return ListToolsForMCPWithOptions(workflowFile, mcpServerName, MCPListToolsOptions{
+				Filter:     filter,
+				ShowSchema: showSchema,
+				JSONOutput: jsonOutput,
+				Verbose:    verbose,
+			})
 		},
 		ValidArgsFunction: completeMCPListToolsArgs,
 	}
 
+	cmd.Flags().StringVar(&filter, "filter", "", "Only show tools whose name contains the text or matches the glob pattern (e.g. 'issue', 'get_*')")
+	cmd.Flags().BoolVar(&showSchema, "schema", false, "Print the JSON input schema of each tool")
+	cmd.Flags().BoolVar(&allServers, "all", false, "List tools for every MCP server configured in the workflow (takes the workflow as the only argument)")
+	addJSONFlag(cmd)
+
 	return cmd
 }
 
@@ -209,6 +402,10 @@ var commonMCPServerNames = []string{"github", "playwright", "serena", "tavily",
 func completeMCPListToolsArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	// First argument: MCP server names are not easily discoverable without a workflow
 	// For now, provide no file completion but suggest common server names
+	// With --all the only argument is the workflow
+	if allServers, _ := cmd.Flags().GetBool("all"); allServers {
+		return CompleteWorkflowNames(cmd, args, toComplete)
+	}
 	if len(args) == 0 {
 		filtered := sliceutil.Filter(commonMCPServerNames, func(s string) bool {
 			return toComplete == "" || strings.HasPrefix(s, toComplete)
@@ -328,4 +328,90 @@ func TestNewMCPListToolsSubcommand(t *testing.T) {
 	if cmd.Short != "List available tools for a specific MCP server" {
 		t.Errorf("Expected Short description, got: %s", cmd.Short)
 	}
+
+	for _, flag := range []string{"filter", "schema", "all", "json"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("Expected --%s flag to be defined", flag)
+		}
+	}
+
+	if err := cmd.Args(cmd, []string{"github", "my-workflow"}); err != nil {
+		t.Errorf("Expected server and workflow arguments to be accepted, got: %v", err)
+	}
+	if err := cmd.Flags().Set("all", "true"); err != nil {
+		t.Fatalf("Failed to set --all: %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"github", "my-workflow"}); err == nil {
+		t.Error("Expected --all to accept only the workflow argument")
+	}
+}
+
+func TestFilterMCPTools(t *testing.T) {
+	info := &parser.MCPServerInfo{
+		Config: parser.MCPServerConfig{Name: "github", Allowed: []string{"get_issue"}},
+		Tools: []*mcp.Tool{
+			{Name: "get_issue", Description: "Get an issue"},
+			{Name: "list_issues", Description: "List issues"},
+			{Name: "get_pull_request", Description: "Get a pull request"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		filter   string
+		expected []string
+	}{
+		{name: "empty filter keeps all tools", filter: "", expected: []string{"get_issue", "list_issues", "get_pull_request"}},
+		{name: "substring match is case-insensitive", filter: "ISSUE", expected: []string{"get_issue", "list_issues"}},
+		{name: "glob matches whole name", filter: "get_*", expected: []string{"get_issue", "get_pull_request"}},
+		{name: "no match", filter: "delete", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := filterMCPTools(info, tt.filter)
+			var names []string
+			for _, tool := range filtered.Tools {
+				names = append(names, tool.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected tools %v, got %v", tt.expected, names)
+			}
+		})
+	}
+
+	if len(info.Tools) != 3 {
+		t.Errorf("Filtering should not modify the original server info, got %d tools", len(info.Tools))
+	}
+}
+
+func TestBuildMCPToolListings(t *testing.T) {
+	schema := map[string]any{"type": "object", "properties": map[string]any{"owner": map[string]any{"type": "string"}}}
+	infos := []*parser.MCPServerInfo{
+		{
+			Config: parser.MCPServerConfig{Name: "github", Allowed: []string{"get_issue"}},
+			Tools: []*mcp.Tool{
+				{Name: "get_issue", Description: "Get an issue", InputSchema: schema},
+				{Name: "list_issues", Description: "List issues"},
+			},
+		},
+		{
+			Config: parser.MCPServerConfig{Name: "docs"},
+			Tools:  []*mcp.Tool{{Name: "search"}},
+		},
+	}
+
+	listings := buildMCPToolListings(infos)
+	if len(listings) != 3 {
+		t.Fatalf("Expected 3 listings, got %d", len(listings))
+	}
+	if listings[0].Server != "github" || !listings[0].Allowed || listings[0].InputSchema == nil {
+		t.Errorf("Expected allowed github get_issue listing with schema, got %+v", listings[0])
+	}
+	if listings[1].Allowed {
+		t.Errorf("Expected list_issues to be outside the allowed list, got %+v", listings[1])
+	}
+	if listings[2].Server != "docs" || !listings[2].Allowed {
+		t.Errorf("Expected docs tool to be allowed without an allowed list, got %+v", listings[2])
+	}
 }
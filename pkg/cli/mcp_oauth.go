@@ -0,0 +1,271 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/types"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var mcpOAuthLog = logger.New("cli:mcp_oauth")
+
+// deviceCodeGrantType is the grant type used to poll the token endpoint in the device flow (RFC 8628)
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// defaultDevicePollInterval is the polling interval used when the server does not send one
+const defaultDevicePollInterval = 5 * time.Second
+
+// MCPOAuthOptions holds the options for the mcp oauth command
+type MCPOAuthOptions struct {
+	SecretName string // Repository secret to store the refresh token in
+	Repo       string // Repository to set the secret in (owner/repo)
+	Print      bool   // Print the refresh token instead of storing it
+	Verbose    bool
+}
+
+// deviceAuthorization is the response of the device authorization endpoint
+type deviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// deviceTokenResponse is the response of the token endpoint while polling
+type deviceTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// NewMCPOAuthSubcommand creates the mcp oauth subcommand
+func NewMCPOAuthSubcommand() *cobra.Command {
+	var opts MCPOAuthOptions
+
+	cmd := &cobra.Command{
+		Use:   "oauth <workflow> <server>",
+		Short: "Obtain a refresh token for an OAuth MCP server with the device flow",
+		Long: `Authorize an HTTP MCP server that uses OAuth with the device flow (RFC 8628)
+and store the resulting refresh token as a repository secret.
+
+The server must set oauth.device-authorization-url, oauth.token-url and oauth.client-id.
+When the workflow runs, the compiled workflow exchanges the refresh token for an access token
+before the MCP gateway starts.
+
+By default the refresh token is stored in the secret referenced by oauth.refresh-token
+(for example ${{ secrets.NOTION_REFRESH_TOKEN }}) using 'gh secret set'.
+
+Examples:
+  gh aw mcp oauth weekly-research notion                       # Authorize and store the refresh token
+  gh aw mcp oauth weekly-research notion --secret NOTION_TOKEN # Store the refresh token in a specific secret
+  gh aw mcp oauth weekly-research notion --print               # Print the refresh token instead`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Verbose, _ = cmd.Flags().GetBool("verbose")
+			opts.Repo, _ = cmd.Flags().GetString("repo")
+			return RunMCPOAuth(cmd.Context(), args[0], args[1], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.SecretName, "secret", "", "Repository secret to store the refresh token in (default: the secret referenced by oauth.refresh-token)")
+	addRepoFlag(cmd)
+	cmd.Flags().BoolVar(&opts.Print, "print", false, "Print the refresh token to stdout instead of storing it as a secret")
+
+	return cmd
+}
+
+// RunMCPOAuth runs the device flow for an MCP server of a workflow and stores the refresh token
+func RunMCPOAuth(ctx context.Context, workflowFile, serverName string, opts MCPOAuthOptions) error {
+	mcpOAuthLog.Printf("Running device flow: workflow=%s, server=%s", workflowFile, serverName)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	workflowPath, err := ResolveWorkflowPath(workflowFile)
+	if err != nil {
+		return err
+	}
+	_, mcpConfigs, err := loadWorkflowMCPConfigs(workflowPath, serverName)
+	if err != nil {
+		return err
+	}
+
+	var oauth *types.MCPOAuthConfig
+	for _, config := range mcpConfigs {
+		if strings.EqualFold(config.Name, serverName) {
+			oauth = config.OAuth
+			break
+		}
+	}
+	if oauth == nil {
+		return fmt.Errorf("MCP server '%s' in %s has no oauth configuration", serverName, filepath.Base(workflowPath))
+	}
+	if oauth.DeviceAuthorizationURL == "" || oauth.TokenURL == "" || oauth.ClientID == "" {
+		return fmt.Errorf("MCP server '%s' must set oauth.device-authorization-url, oauth.token-url and oauth.client-id to use the device flow", serverName)
+	}
+	if strings.Contains(oauth.ClientID, "${{") {
+		return fmt.Errorf("oauth.client-id of MCP server '%s' must be a literal value to use the device flow", serverName)
+	}
+
+	secretName := opts.SecretName
+	if secretName == "" && !opts.Print {
+		secretName = workflow.ExtractSecretName(oauth.RefreshToken)
+		if secretName == "" {
+			return fmt.Errorf("oauth.refresh-token of MCP server '%s' does not reference a secret; use --secret or --print", serverName)
+		}
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	device, err := requestDeviceAuthorization(ctx, client, oauth)
+	if err != nil {
+		return err
+	}
+
+	verificationURI := device.VerificationURI
+	if device.VerificationURIComplete != "" {
+		verificationURI = device.VerificationURIComplete
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Open %s and enter the code: %s", verificationURI, device.UserCode)))
+
+	interval := defaultDevicePollInterval
+	if device.Interval > 0 {
+		interval = time.Duration(device.Interval) * time.Second
+	}
+	if device.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(device.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	refreshToken, err := pollDeviceToken(ctx, client, oauth, device.DeviceCode, interval)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Authorized MCP server '%s'", serverName)))
+
+	if opts.Print {
+		fmt.Println(refreshToken)
+		return nil
+	}
+
+	args := []string{"secret", "set", secretName, "--body", refreshToken}
+	if opts.Repo != "" {
+		args = append(args, "--repo", opts.Repo)
+	}
+	if output, err := workflow.RunGHCombined("Setting secret...", args...); err != nil {
+		return fmt.Errorf("failed to set secret %s: %w\n%s", secretName, err, strings.TrimSpace(string(output)))
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Stored the refresh token in secret "+secretName))
+	return nil
+}
+
+// requestDeviceAuthorization starts the device flow at the device authorization endpoint
+func requestDeviceAuthorization(ctx context.Context, client *http.Client, oauth *types.MCPOAuthConfig) (*deviceAuthorization, error) {
+	form := url.Values{"client_id": {oauth.ClientID}}
+	if len(oauth.Scopes) > 0 {
+		form.Set("scope", strings.Join(oauth.Scopes, " "))
+	}
+	if oauth.Audience != "" {
+		form.Set("audience", oauth.Audience)
+	}
+
+	status, body, err := postOAuthForm(ctx, client, oauth.DeviceAuthorizationURL, form)
+	if err != nil {
+		return nil, fmt.Errorf("device authorization request failed: %w", err)
+	}
+	if status >= 300 {
+		return nil, fmt.Errorf("device authorization request failed with HTTP %d: %s", status, strings.TrimSpace(string(body)))
+	}
+
+	var device deviceAuthorization
+	if err := json.Unmarshal(body, &device); err != nil {
+		return nil, fmt.Errorf("failed to parse device authorization response: %w", err)
+	}
+	if device.DeviceCode == "" || device.UserCode == "" || device.VerificationURI == "" {
+		return nil, errors.New("device authorization response is missing device_code, user_code or verification_uri")
+	}
+	mcpOAuthLog.Printf("Device authorization started: expires_in=%d, interval=%d", device.ExpiresIn, device.Interval)
+	return &device, nil
+}
+
+// pollDeviceToken polls the token endpoint until the user approves the device, following the
+// authorization_pending and slow_down responses of RFC 8628, and returns the refresh token
+func pollDeviceToken(ctx context.Context, client *http.Client, oauth *types.MCPOAuthConfig, deviceCode string, interval time.Duration) (string, error) {
+	form := url.Values{
+		"grant_type":  {deviceCodeGrantType},
+		"device_code": {deviceCode},
+		"client_id":   {oauth.ClientID},
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", errors.New("device authorization expired before it was approved")
+		case <-time.After(interval):
+		}
+
+		_, body, err := postOAuthForm(ctx, client, oauth.TokenURL, form)
+		if err != nil {
+			return "", fmt.Errorf("token request failed: %w", err)
+		}
+		var token deviceTokenResponse
+		if err := json.Unmarshal(body, &token); err != nil {
+			return "", fmt.Errorf("failed to parse token response: %w", err)
+		}
+
+		switch token.Error {
+		case "":
+			if token.RefreshToken == "" {
+				return "", errors.New("the authorization server did not issue a refresh token; request the offline_access scope or use client credentials")
+			}
+			return token.RefreshToken, nil
+		case "authorization_pending":
+			mcpOAuthLog.Print("Authorization pending")
+		case "slow_down":
+			interval += 5 * time.Second
+			mcpOAuthLog.Printf("Slowing down polling to %s", interval)
+		default:
+			if token.ErrorDescription != "" {
+				return "", fmt.Errorf("device authorization failed: %s (%s)", token.ErrorDescription, token.Error)
+			}
+			return "", fmt.Errorf("device authorization failed: %s", token.Error)
+		}
+	}
+}
+
+// postOAuthForm posts a form-encoded OAuth request and returns the status code and body
+func postOAuthForm(ctx context.Context, client *http.Client, endpoint string, form url.Values) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
@@ -0,0 +1,96 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceFlow(t *testing.T) {
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm(), "device request should be form-encoded")
+		assert.Equal(t, "client", r.PostForm.Get("client_id"), "device request should send the client id")
+		assert.Equal(t, "read offline_access", r.PostForm.Get("scope"), "device request should send the scopes")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"device_code":      "device-123",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": "https://auth.example.com/device",
+			"expires_in":       600,
+			"interval":         5,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm(), "token request should be form-encoded")
+		assert.Equal(t, deviceCodeGrantType, r.PostForm.Get("grant_type"), "token request should use the device code grant")
+		assert.Equal(t, "device-123", r.PostForm.Get("device_code"), "token request should send the device code")
+		polls++
+		if polls == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "authorization_pending"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "access", "refresh_token": "refresh-456"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	oauth := &types.MCPOAuthConfig{
+		DeviceAuthorizationURL: server.URL + "/device",
+		TokenURL:               server.URL + "/token",
+		ClientID:               "client",
+		Scopes:                 []string{"read", "offline_access"},
+	}
+
+	device, err := requestDeviceAuthorization(context.Background(), server.Client(), oauth)
+	require.NoError(t, err, "device authorization should succeed")
+	assert.Equal(t, "ABCD-EFGH", device.UserCode, "user code should be returned")
+
+	refreshToken, err := pollDeviceToken(context.Background(), server.Client(), oauth, device.DeviceCode, time.Millisecond)
+	require.NoError(t, err, "polling should succeed once the device is approved")
+	assert.Equal(t, "refresh-456", refreshToken, "refresh token should be returned")
+	assert.Equal(t, 2, polls, "token endpoint should be polled until approval")
+}
+
+func TestPollDeviceTokenErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		response map[string]any
+		wantErr  string
+	}{
+		{
+			name:     "access denied",
+			response: map[string]any{"error": "access_denied", "error_description": "The user denied the request"},
+			wantErr:  "The user denied the request",
+		},
+		{
+			name:     "no refresh token",
+			response: map[string]any{"access_token": "access"},
+			wantErr:  "did not issue a refresh token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(tt.response)
+			}))
+			defer server.Close()
+
+			oauth := &types.MCPOAuthConfig{TokenURL: server.URL, ClientID: "client"}
+			_, err := pollDeviceToken(context.Background(), server.Client(), oauth, "device", time.Millisecond)
+			require.Error(t, err, "polling should fail")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the failure")
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+var migrateLog = logger.New("cli:migrate_command")
+
+// migrateCommentPrefix starts the YAML comment added above each migrated field
+const migrateCommentPrefix = "# gh aw migrate"
+
+// MigrateConfig contains configuration for the migrate command
+type MigrateConfig struct {
+	DryRun      bool
+	Verbose     bool
+	WorkflowDir string // Custom workflow directory
+}
+
+// NewMigrateCommand creates the migrate command
+func NewMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate deprecated frontmatter fields to the current schema",
+		Long: `Migrate deprecated frontmatter fields in all workflow Markdown files to the current schema.
+
+When the frontmatter schema changes between gh-aw releases, this command rewrites
+deprecated fields in every Markdown file under the workflow directory, including shared
+imports in subdirectories, so the workflows compile again without chasing errors one by one.
+
+The migrations are the codemods of '` + string(constants.CLIExtensionPrefix) + ` fix'. Above each rewritten field a YAML
+comment records what changed and the version that introduced the change, for example:
+
+  # gh aw migrate (0.1.0): Replaces deprecated 'timeout_minutes' field with 'timeout-minutes'
+  timeout-minutes: 30
+
+Review the comments and remove them once the migration is committed.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` migrate                     # Migrate all workflows
+  ` + string(constants.CLIExtensionPrefix) + ` migrate --dry-run           # Show what would be migrated
+  ` + string(constants.CLIExtensionPrefix) + ` migrate --dir custom/workflows # Migrate workflows in custom directory`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			dir, _ := cmd.Flags().GetString("dir")
+
+			return RunMigrate(MigrateConfig{
+				DryRun:      dryRun,
+				Verbose:     verbose,
+				WorkflowDir: dir,
+			})
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Show what would be migrated without writing files")
+	cmd.Flags().StringP("dir", "d", "", "Workflow directory (default: .github/workflows)")
+	RegisterDirFlagCompletion(cmd, "dir")
+
+	return cmd
+}
+
+// RunMigrate migrates deprecated frontmatter fields in all workflow Markdown files
+func RunMigrate(config MigrateConfig) error {
+	workflowDir := config.WorkflowDir
+	if workflowDir == "" {
+		workflowDir = getWorkflowsDir()
+	} else {
+		workflowDir = filepath.Clean(workflowDir)
+	}
+	migrateLog.Printf("Running migrate command: dir=%s, dryRun=%v", workflowDir, config.DryRun)
+
+	if _, err := os.Stat(workflowDir); os.IsNotExist(err) {
+		return fmt.Errorf("no %s directory found", workflowDir)
+	}
+
+	var files []string
+	err := filepath.WalkDir(workflowDir, func(path string, d os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") || !isWorkflowFile(path) {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find workflow files: %w", err)
+	}
+
+	if len(files) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No workflow files found."))
+		return nil
+	}
+
+	codemods := GetAllCodemods()
+	var migrated int
+	var failed []string
+	for _, file := range files {
+		relPath, err := filepath.Rel(workflowDir, file)
+		if err != nil {
+			relPath = file
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("Error reading %s: %v", relPath, err)))
+			failed = append(failed, relPath)
+			continue
+		}
+
+		newContent, applied, err := migrateWorkflowContent(string(content), codemods)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("Error migrating %s: %v", relPath, err)))
+			failed = append(failed, relPath)
+			continue
+		}
+		if len(applied) == 0 {
+			if config.Verbose {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("  %s - up to date", relPath)))
+			}
+			continue
+		}
+
+		migrated++
+		if config.DryRun {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(relPath))
+		} else {
+			if err := os.WriteFile(file, []byte(newContent), 0600); err != nil {
+				fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("Error writing %s: %v", relPath, err)))
+				failed = append(failed, relPath)
+				continue
+			}
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(relPath))
+		}
+		for _, name := range applied {
+			fmt.Fprintf(os.Stderr, "    • %s\n", name)
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "")
+	switch {
+	case migrated == 0:
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("All %d workflow files are up to date", len(files))))
+	case config.DryRun:
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Would migrate %d of %d workflow files. Run '%s migrate' to apply.", migrated, len(files), string(constants.CLIExtensionPrefix))))
+	default:
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Migrated %d of %d workflow files. Recompile with '%s compile'.", migrated, len(files), string(constants.CLIExtensionPrefix))))
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to migrate %d workflow file(s): %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// migrateWorkflowContent applies the codemods to content and adds a comment above the first
+// frontmatter line each applied codemod changed. It returns the migrated content and the
+// names of the applied codemods.
+func migrateWorkflowContent(content string, codemods []Codemod) (string, []string, error) {
+	var applied []string
+	current := content
+	for _, codemod := range codemods {
+		before, err := parser.ExtractFrontmatterFromContent(current)
+		if err != nil {
+			return content, nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+		}
+		if len(before.Frontmatter) == 0 {
+			// Not a workflow or shared import, nothing to migrate
+			return content, nil, nil
+		}
+
+		newContent, ok, err := codemod.Apply(current, before.Frontmatter)
+		if err != nil {
+			return content, nil, fmt.Errorf("codemod %s failed: %w", codemod.ID, err)
+		}
+		if !ok || newContent == current {
+			continue
+		}
+		migrateLog.Printf("Applied codemod: %s", codemod.ID)
+
+		annotated, err := annotateMigration(before.FrontmatterLines, newContent, codemod)
+		if err != nil {
+			return content, nil, fmt.Errorf("codemod %s produced invalid frontmatter: %w", codemod.ID, err)
+		}
+		current = annotated
+		applied = append(applied, codemod.Name)
+	}
+	// Codemods rebuild the content without its final newline
+	if len(applied) > 0 && strings.HasSuffix(content, "\n") && !strings.HasSuffix(current, "\n") {
+		current += "\n"
+	}
+	return current, applied, nil
+}
+
+// annotateMigration inserts the migration comment of codemod above the first frontmatter line
+// that differs from beforeLines. Changes outside the frontmatter are noted at its top.
+func annotateMigration(beforeLines []string, content string, codemod Codemod) (string, error) {
+	frontmatterLines, markdown, err := parseFrontmatterLines(content)
+	if err != nil {
+		return "", err
+	}
+
+	index := 0
+	for index < len(beforeLines) && index < len(frontmatterLines) && beforeLines[index] == frontmatterLines[index] {
+		index++
+	}
+	if index == len(beforeLines) && index == len(frontmatterLines) {
+		index = 0
+	}
+	// A comment inside a block scalar would become part of its value, so note the change
+	// above the key that starts the block instead
+	index = blockScalarStart(frontmatterLines, index)
+
+	indent := ""
+	if index < len(frontmatterLines) {
+		indent = getIndentation(frontmatterLines[index])
+	}
+	comment := migrateCommentPrefix
+	if codemod.IntroducedIn != "" {
+		comment += " (" + codemod.IntroducedIn + ")"
+	}
+	comment = indent + comment + ": " + codemod.Description
+
+	lines := make([]string, 0, len(frontmatterLines)+1)
+	lines = append(lines, frontmatterLines[:index]...)
+	lines = append(lines, comment)
+	lines = append(lines, frontmatterLines[index:]...)
+	return reconstructContent(lines, markdown), nil
+}
+
+// blockScalarStart returns the index of the key line that starts the block scalar (| or >)
+// containing lines[index], or index when the line is not part of a block scalar.
+func blockScalarStart(lines []string, index int) int {
+	if index >= len(lines) {
+		return index
+	}
+	indent := len(getIndentation(lines[index]))
+	for i := index - 1; i >= 0; i-- {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" {
+			continue
+		}
+		lineIndent := len(getIndentation(lines[i]))
+		if lineIndent >= indent {
+			continue
+		}
+		value := trimmed
+		if _, after, found := strings.Cut(trimmed, ":"); found {
+			value = strings.TrimSpace(after)
+		}
+		if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			return i
+		}
+		indent = lineIndent
+		if indent == 0 {
+			break
+		}
+	}
+	return index
+}
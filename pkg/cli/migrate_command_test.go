@@ -0,0 +1,94 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateWorkflowContent(t *testing.T) {
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+timeout_minutes: 30
+---
+
+# Test Workflow
+`
+
+	result, applied, err := migrateWorkflowContent(content, []Codemod{getTimeoutMinutesCodemod()})
+	require.NoError(t, err, "migration should succeed")
+	assert.Equal(t, []string{"Migrate timeout_minutes to timeout-minutes"}, applied, "applied codemods")
+	assert.Equal(t, `---
+on: workflow_dispatch
+permissions:
+  contents: read
+# gh aw migrate (0.1.0): Replaces deprecated 'timeout_minutes' field with 'timeout-minutes'
+timeout-minutes: 30
+---
+
+# Test Workflow
+`, result, "migrated field should be annotated")
+
+	again, applied, err := migrateWorkflowContent(result, GetAllCodemods())
+	require.NoError(t, err, "second migration should succeed")
+	assert.Empty(t, applied, "migration should be idempotent")
+	assert.Equal(t, result, again, "content should be unchanged")
+}
+
+func TestMigrateWorkflowContentWithoutFrontmatter(t *testing.T) {
+	content := "# Shared instructions\n\nUse timeout_minutes: 30 in examples.\n"
+	result, applied, err := migrateWorkflowContent(content, GetAllCodemods())
+	require.NoError(t, err, "migration should succeed")
+	assert.Empty(t, applied, "files without frontmatter are not migrated")
+	assert.Equal(t, content, result, "content should be unchanged")
+}
+
+func TestBlockScalarStart(t *testing.T) {
+	lines := []string{
+		"steps:",
+		"  - name: Build",
+		"    run: |",
+		"      make build",
+		"      make test",
+		"timeout-minutes: 10",
+	}
+	assert.Equal(t, 2, blockScalarStart(lines, 4), "line inside a block scalar moves to its key")
+	assert.Equal(t, 1, blockScalarStart(lines, 1), "list item is not in a block scalar")
+	assert.Equal(t, 5, blockScalarStart(lines, 5), "top-level key is not in a block scalar")
+}
+
+func TestRunMigrate(t *testing.T) {
+	tmpDir := t.TempDir()
+	sharedDir := filepath.Join(tmpDir, "shared")
+	require.NoError(t, os.MkdirAll(sharedDir, 0755), "should create shared dir")
+
+	workflow := "---\non: workflow_dispatch\ntimeout_minutes: 30\n---\n\n# Workflow\n"
+	shared := "---\ntimeout_minutes: 15\n---\n\n# Shared\n"
+	current := "---\non: workflow_dispatch\ntimeout-minutes: 30\n---\n\n# Current\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "workflow.md"), []byte(workflow), 0644), "should write workflow")
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "settings.md"), []byte(shared), 0644), "should write shared import")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "current.md"), []byte(current), 0644), "should write current workflow")
+
+	require.NoError(t, RunMigrate(MigrateConfig{DryRun: true, WorkflowDir: tmpDir}), "dry run should succeed")
+	content, err := os.ReadFile(filepath.Join(tmpDir, "workflow.md"))
+	require.NoError(t, err, "should read workflow")
+	assert.Equal(t, workflow, string(content), "dry run should not write files")
+
+	require.NoError(t, RunMigrate(MigrateConfig{WorkflowDir: tmpDir}), "migration should succeed")
+	for _, path := range []string{filepath.Join(tmpDir, "workflow.md"), filepath.Join(sharedDir, "settings.md")} {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err, "should read %s", path)
+		assert.Contains(t, string(content), migrateCommentPrefix, "%s should note the migration", path)
+		assert.NotContains(t, string(content), "timeout_minutes:", "%s should be migrated", path)
+	}
+	content, err = os.ReadFile(filepath.Join(tmpDir, "current.md"))
+	require.NoError(t, err, "should read current workflow")
+	assert.Equal(t, current, string(content), "up-to-date workflows should not be rewritten")
+}
@@ -0,0 +1,65 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (org_policy.go) loads the organization policy enforced by compile.
+//
+// The policy is read from aw-policy.yml in the .github repository of the
+// organization that owns the current repository. The --policy flag reads a local
+// file instead, so policy changes can be tested before they are published.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var orgPolicyLog = logger.New("cli:org_policy")
+
+// loadOrgPolicy returns the policy from policyPath when set, and otherwise the policy
+// published by the organization of the current repository. It returns nil when no
+// policy applies or when skip is set (--no-org-policy). A published policy that cannot
+// be fetched fails compilation, so that the policy cannot be bypassed by a network error.
+func loadOrgPolicy(policyPath string, skip bool, verbose bool) (*workflow.OrgPolicy, error) {
+	if policyPath != "" {
+		orgPolicyLog.Printf("Loading policy from file: %s", policyPath)
+		return workflow.LoadOrgPolicyFile(policyPath)
+	}
+	if skip {
+		orgPolicyLog.Print("Organization policy skipped with --no-org-policy")
+		return nil, nil
+	}
+
+	slug := getRepositorySlugFromRemote()
+	owner, _, ok := strings.Cut(slug, "/")
+	if !ok || owner == "" {
+		orgPolicyLog.Print("No GitHub remote, skipping organization policy")
+		return nil, nil
+	}
+
+	source := fmt.Sprintf("%s/%s/%s", owner, workflow.OrgPolicyRepo, workflow.OrgPolicyPath)
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage("Fetching organization policy "+source))
+	}
+	content, err := parser.DownloadFileFromGitHub(owner, workflow.OrgPolicyRepo, workflow.OrgPolicyPath, "HEAD")
+	if err != nil {
+		if isOrgPolicyNotFound(err) {
+			orgPolicyLog.Printf("No organization policy at %s", source)
+			return nil, nil
+		}
+		orgPolicyLog.Printf("Failed to fetch organization policy %s: %v", source, err)
+		return nil, fmt.Errorf("failed to fetch organization policy %s: %w. Use --no-org-policy to compile without it", source, err)
+	}
+
+	return workflow.ParseOrgPolicy(content, source)
+}
+
+// isOrgPolicyNotFound reports whether a download error means the organization has no policy
+func isOrgPolicyNotFound(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "404") || strings.Contains(message, "not found")
+}
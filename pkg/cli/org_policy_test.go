@@ -0,0 +1,45 @@
+//go:build !integration
+
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrgPolicyFromFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "org-policy-*")
+
+	policyFile := filepath.Join(tmpDir, "aw-policy.yml")
+	require.NoError(t, os.WriteFile(policyFile, []byte("allowed-engines: [copilot]\n"), 0644), "should write policy")
+	policy, err := loadOrgPolicy(policyFile, false, false)
+	require.NoError(t, err, "local policy should load")
+	require.NotNil(t, policy, "local policy should be returned")
+	assert.Equal(t, []string{"copilot"}, policy.AllowedEngines, "allowed engines")
+	assert.Equal(t, policyFile, policy.Source, "source should be the local file")
+
+	invalidFile := filepath.Join(tmpDir, "invalid.yml")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("allowed-engines: [unknown]\n"), 0644), "should write policy")
+	_, err = loadOrgPolicy(invalidFile, false, false)
+	require.Error(t, err, "invalid local policy should fail")
+
+	_, err = loadOrgPolicy(filepath.Join(tmpDir, "missing.yml"), false, false)
+	require.Error(t, err, "missing local policy should fail")
+}
+
+func TestLoadOrgPolicySkipped(t *testing.T) {
+	policy, err := loadOrgPolicy("", true, false)
+	require.NoError(t, err, "skipping the policy should not fetch it")
+	assert.Nil(t, policy, "no policy should apply with --no-org-policy")
+}
+
+func TestIsOrgPolicyNotFound(t *testing.T) {
+	assert.True(t, isOrgPolicyNotFound(errors.New("HTTP 404: Not Found (https://api.github.com/repos/octo-org/.github/contents/aw-policy.yml)")), "404 means no policy")
+	assert.False(t, isOrgPolicyNotFound(errors.New("HTTP 401: Bad credentials")), "auth errors are not a missing policy")
+}
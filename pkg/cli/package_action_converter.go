@@ -0,0 +1,540 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/goccy/go-yaml"
+)
+
+var packageConverterLog = logger.New("cli:package_action_converter")
+
+// compositeStepKeys are the step keys supported by composite actions, in output order
+var compositeStepKeys = []string{"name", "id", "if", "uses", "with", "run", "shell", "env", "working-directory", "continue-on-error"}
+
+// droppedJobKeys are job keys that have no composite action equivalent
+var droppedJobKeys = []string{"container", "services", "strategy", "environment", "concurrency", "timeout-minutes"}
+
+var (
+	expressionPattern  = regexp.MustCompile(`\$\{\{(.*?)\}\}`)
+	needsOutputPattern = regexp.MustCompile(`\bneeds\.([A-Za-z_][\w-]*)\.outputs\.([A-Za-z_][\w-]*)`)
+	needsResultPattern = regexp.MustCompile(`\bneeds\.([A-Za-z_][\w-]*)\.result\b`)
+	stepRefPattern     = regexp.MustCompile(`\bsteps\.([A-Za-z_][\w-]*)\.`)
+	secretRefPattern   = regexp.MustCompile(`\bsecrets\.([A-Za-z_]\w*)`)
+	eventInputsPattern = regexp.MustCompile(`\bgithub\.event\.inputs\.`)
+)
+
+// PackageIssue describes a construct in the compiled workflow that a composite action cannot express
+type PackageIssue struct {
+	Location string // Path of the construct in the lock file (e.g. "jobs.agent.timeout-minutes")
+	Message  string // What was dropped or changed and why
+}
+
+// PackageResult is the output of converting a compiled workflow into a composite action
+type PackageResult struct {
+	ActionYAML  string            // Generated action.yml content
+	Readme      string            // Generated README.md content
+	Inputs      []string          // Action input names, workflow inputs first
+	Secrets     map[string]string // Secret name -> action input that carries it
+	Permissions map[string]string // Union of the job permissions the calling job must grant
+	Jobs        []string          // Jobs flattened into the action, in execution order
+	Issues      []PackageIssue    // Constructs that were dropped or approximated
+}
+
+// PackageOptions configures composite action generation
+type PackageOptions struct {
+	Name        string // Action name (defaults to the workflow name)
+	Description string // Action description
+	Source      string // Workflow source path, recorded in the generated header
+	UsesRef     string // Reference shown in the README usage example (e.g. "octo-org/agents/actions/triage@main")
+}
+
+// compositeConverter holds the state threaded through the job flattening pass
+type compositeConverter struct {
+	result     *PackageResult
+	jobOutputs map[string]map[string]string // job -> output -> rewritten expression
+}
+
+// ConvertLockFileToCompositeAction flattens the jobs of a compiled agentic workflow into the
+// steps of a composite action. Jobs run sequentially in dependency order; job conditions and
+// results are tracked with marker steps so that needs.<job>.result and needs.<job>.outputs
+// references keep working. Secrets become action inputs because composite actions cannot
+// read the secrets context, and workflow_dispatch/workflow_call inputs become action inputs.
+func ConvertLockFileToCompositeAction(lockContent []byte, opts PackageOptions) (*PackageResult, error) {
+	var doc yaml.MapSlice
+	if err := yaml.UnmarshalWithOptions(lockContent, &doc, yaml.UseOrderedMap()); err != nil {
+		return nil, fmt.Errorf("failed to parse compiled workflow: %w", err)
+	}
+
+	jobs, ok := mapSliceValue(doc, "jobs").(yaml.MapSlice)
+	if !ok || len(jobs) == 0 {
+		return nil, errors.New("compiled workflow has no jobs")
+	}
+
+	order, err := orderJobsByNeeds(jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := &compositeConverter{
+		result: &PackageResult{
+			Secrets:     map[string]string{},
+			Permissions: map[string]string{},
+			Jobs:        order,
+		},
+		jobOutputs: map[string]map[string]string{},
+	}
+	result := conv.result
+
+	name := opts.Name
+	if name == "" {
+		name, _ = mapSliceValue(doc, "name").(string)
+	}
+	description := opts.Description
+	if description == "" {
+		description = "Agentic workflow " + name + " packaged as a composite action"
+	}
+
+	inputs := workflowInputsToActionInputs(mapSliceValue(doc, "on"), result)
+	workflowEnv, _ := mapSliceValue(doc, "env").(yaml.MapSlice)
+	if mapSliceValue(doc, "concurrency") != nil {
+		result.addIssue("concurrency", "workflow concurrency was dropped; set concurrency on the calling job instead")
+	}
+
+	var steps []any
+	for _, jobID := range order {
+		job, _ := mapSliceValue(jobs, jobID).(yaml.MapSlice)
+		jobSteps, err := conv.convertJob(jobID, job, workflowEnv)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, jobSteps...)
+	}
+
+	// Secrets become optional inputs; GITHUB_TOKEN maps to github.token instead
+	secretNames := make([]string, 0, len(result.Secrets))
+	for secret := range result.Secrets {
+		secretNames = append(secretNames, secret)
+	}
+	sort.Strings(secretNames)
+	for _, secret := range secretNames {
+		inputName := result.Secrets[secret]
+		if mapSliceValue(inputs, inputName) != nil {
+			return nil, fmt.Errorf("secret %s maps to action input '%s', which is already a workflow input", secret, inputName)
+		}
+		inputs = append(inputs, yaml.MapItem{Key: inputName, Value: yaml.MapSlice{
+			{Key: "description", Value: fmt.Sprintf("Value of the %s secret", secret)},
+			{Key: "required", Value: false},
+		}})
+		result.Inputs = append(result.Inputs, inputName)
+	}
+
+	action := yaml.MapSlice{
+		{Key: "name", Value: name},
+		{Key: "description", Value: description},
+	}
+	if len(inputs) > 0 {
+		action = append(action, yaml.MapItem{Key: "inputs", Value: inputs})
+	}
+	action = append(action, yaml.MapItem{Key: "runs", Value: yaml.MapSlice{
+		{Key: "using", Value: "composite"},
+		{Key: "steps", Value: steps},
+	}})
+
+	actionYAML, err := yaml.MarshalWithOptions(action, workflow.DefaultMarshalOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate action.yml: %w", err)
+	}
+
+	var out strings.Builder
+	out.WriteString("# This file was generated by gh-aw package")
+	if opts.Source != "" {
+		out.WriteString(" from " + opts.Source)
+	}
+	out.WriteString(". DO NOT EDIT.\n")
+	if len(result.Permissions) > 0 {
+		out.WriteString("#\n# The calling job must grant these permissions:\n")
+		for _, scope := range sortedPermissionScopes(result.Permissions) {
+			fmt.Fprintf(&out, "#   %s: %s\n", scope, result.Permissions[scope])
+		}
+	}
+	out.WriteString("\n")
+	out.Write(actionYAML)
+	result.ActionYAML = out.String()
+	result.Readme = buildPackageReadme(name, description, opts.UsesRef, result, inputs)
+
+	packageConverterLog.Printf("Converted %d jobs into %d composite steps: inputs=%d, issues=%d", len(order), len(steps), len(result.Inputs), len(result.Issues))
+	return result, nil
+}
+
+// orderJobsByNeeds returns job IDs in dependency order, keeping lock file order among independent jobs
+func orderJobsByNeeds(jobs yaml.MapSlice) ([]string, error) {
+	deps := map[string][]string{}
+	var ids []string
+	for _, item := range jobs {
+		id := fmt.Sprint(item.Key)
+		ids = append(ids, id)
+		job, _ := item.Value.(yaml.MapSlice)
+		switch needs := mapSliceValue(job, "needs").(type) {
+		case string:
+			deps[id] = []string{needs}
+		case []any:
+			for _, need := range needs {
+				deps[id] = append(deps[id], fmt.Sprint(need))
+			}
+		}
+	}
+
+	var order []string
+	done := map[string]bool{}
+	for len(order) < len(ids) {
+		progressed := false
+		for _, id := range ids {
+			if done[id] {
+				continue
+			}
+			ready := true
+			for _, dep := range deps[id] {
+				if mapSliceValue(jobs, dep) == nil {
+					return nil, fmt.Errorf("job '%s' needs unknown job '%s'", id, dep)
+				}
+				if !done[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				order = append(order, id)
+				done[id] = true
+				progressed = true
+			}
+		}
+		if !progressed {
+			return nil, errors.New("compiled workflow has a dependency cycle between jobs")
+		}
+	}
+	return order, nil
+}
+
+// convertJob flattens one job into composite steps wrapped by start and result marker steps
+func (c *compositeConverter) convertJob(jobID string, job yaml.MapSlice, workflowEnv yaml.MapSlice) ([]any, error) {
+	location := "jobs." + jobID
+	for _, key := range droppedJobKeys {
+		if mapSliceValue(job, key) != nil {
+			c.result.addIssue(location+"."+key, "not supported by composite actions and was dropped")
+		}
+	}
+	if perms, ok := mapSliceValue(job, "permissions").(yaml.MapSlice); ok {
+		for _, item := range perms {
+			scope, level := fmt.Sprint(item.Key), fmt.Sprint(item.Value)
+			if c.result.Permissions[scope] != "write" {
+				c.result.Permissions[scope] = level
+			}
+		}
+	}
+
+	rawSteps, _ := mapSliceValue(job, "steps").([]any)
+	stepIDs := map[string]bool{}
+	for _, rawStep := range rawSteps {
+		if step, ok := rawStep.(yaml.MapSlice); ok {
+			if id, ok := mapSliceValue(step, "id").(string); ok {
+				stepIDs[id] = true
+			}
+		}
+	}
+	rewrite := func(expr string) string {
+		return c.rewriteExpression(jobID, stepIDs, expr)
+	}
+
+	jobEnv := mergeEnv(workflowEnv, nil)
+	if env, ok := mapSliceValue(job, "env").(yaml.MapSlice); ok {
+		jobEnv = mergeEnv(jobEnv, env)
+	}
+
+	startID, resultID := compositeMarkerIDs(jobID)
+	startStep := yaml.MapSlice{{Key: "name", Value: fmt.Sprintf("Start %s", jobID)}, {Key: "id", Value: startID}}
+	if cond := jobCondition(job); cond != "" {
+		startStep = append(startStep, yaml.MapItem{Key: "if", Value: rewrite(cond)})
+	}
+	startStep = append(startStep,
+		yaml.MapItem{Key: "run", Value: `echo "started=true" >> "$GITHUB_OUTPUT"`},
+		yaml.MapItem{Key: "shell", Value: "bash"},
+	)
+	started := fmt.Sprintf("steps.%s.outputs.started == 'true'", startID)
+	steps := []any{startStep}
+
+	for i, rawStep := range rawSteps {
+		step, ok := rawStep.(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+		stepLocation := fmt.Sprintf("%s.steps[%d]", location, i)
+		if uses, ok := mapSliceValue(step, "uses").(string); ok && (strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://")) {
+			return nil, fmt.Errorf("%s uses %s, which consumers of the action cannot resolve; package with a release action tag", stepLocation, uses)
+		}
+
+		converted := yaml.MapSlice{}
+		for _, key := range compositeStepKeys {
+			value := mapSliceValue(step, key)
+			switch key {
+			case "id":
+				if id, ok := value.(string); ok {
+					value = jobID + "_" + id
+				}
+			case "if":
+				cond := started
+				if stepCond, ok := value.(string); ok && strings.TrimSpace(stepCond) != "" {
+					cond = fmt.Sprintf("%s && (%s)", started, rewrite(stripExpressionWrapper(stepCond)))
+				}
+				value = cond
+			case "shell":
+				if value == nil && mapSliceValue(step, "run") != nil {
+					value = "bash"
+				}
+			case "env":
+				env, _ := value.(yaml.MapSlice)
+				if merged := mergeEnv(jobEnv, env); len(merged) > 0 {
+					value = rewriteValue(merged, rewrite)
+				} else {
+					value = nil
+				}
+			default:
+				value = rewriteValue(value, rewrite)
+			}
+			if value != nil {
+				converted = append(converted, yaml.MapItem{Key: key, Value: value})
+			}
+		}
+		for _, item := range step {
+			key := fmt.Sprint(item.Key)
+			if !sliceutil.Contains(compositeStepKeys, key) {
+				c.result.addIssue(stepLocation+"."+key, "not supported by composite action steps and was dropped")
+			}
+		}
+		steps = append(steps, converted)
+	}
+
+	// Record the job result so later jobs can read needs.<job>.result
+	steps = append(steps, yaml.MapSlice{
+		{Key: "name", Value: fmt.Sprintf("Finish %s", jobID)},
+		{Key: "id", Value: resultID},
+		{Key: "if", Value: "always() && " + started},
+		{Key: "run", Value: `echo "result=${{ cancelled() && 'cancelled' || failure() && 'failure' || 'success' }}" >> "$GITHUB_OUTPUT"`},
+		{Key: "shell", Value: "bash"},
+	})
+
+	// Job outputs are resolved after step IDs are prefixed so dependents can inline them
+	outputs := map[string]string{}
+	if jobOutputs, ok := mapSliceValue(job, "outputs").(yaml.MapSlice); ok {
+		for _, item := range jobOutputs {
+			key, value := fmt.Sprint(item.Key), strings.TrimSpace(fmt.Sprint(item.Value))
+			if !strings.Contains(value, "${{") {
+				outputs[key] = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+				continue
+			}
+			match := expressionPattern.FindStringSubmatch(value)
+			if match[0] != value {
+				c.result.addIssue(location+".outputs."+key, "output mixes text and expressions and resolves to an empty string")
+				outputs[key] = "''"
+				continue
+			}
+			// Job outputs are strings, so boolean expressions are formatted to match
+			outputs[key] = "format('{0}', " + rewrite(strings.TrimSpace(match[1])) + ")"
+		}
+	}
+	c.jobOutputs[jobID] = outputs
+
+	return steps, nil
+}
+
+// rewriteExpression maps job-scoped expression references onto the flattened composite steps
+func (c *compositeConverter) rewriteExpression(jobID string, stepIDs map[string]bool, expr string) string {
+	expr = stepRefPattern.ReplaceAllStringFunc(expr, func(ref string) string {
+		id := stepRefPattern.FindStringSubmatch(ref)[1]
+		if !stepIDs[id] {
+			return ref
+		}
+		return "steps." + jobID + "_" + id + "."
+	})
+	expr = needsOutputPattern.ReplaceAllStringFunc(expr, func(ref string) string {
+		match := needsOutputPattern.FindStringSubmatch(ref)
+		if value, ok := c.jobOutputs[match[1]][match[2]]; ok {
+			return value
+		}
+		c.result.addIssue("jobs."+jobID, fmt.Sprintf("reference to undefined output needs.%s.outputs.%s resolves to an empty string", match[1], match[2]))
+		return "''"
+	})
+	expr = needsResultPattern.ReplaceAllStringFunc(expr, func(ref string) string {
+		_, resultID := compositeMarkerIDs(needsResultPattern.FindStringSubmatch(ref)[1])
+		return fmt.Sprintf("(steps.%s.outputs.result || 'skipped')", resultID)
+	})
+	expr = secretRefPattern.ReplaceAllStringFunc(expr, func(ref string) string {
+		secret := secretRefPattern.FindStringSubmatch(ref)[1]
+		if secret == "GITHUB_TOKEN" {
+			return "github.token"
+		}
+		inputName := strings.ReplaceAll(strings.ToLower(secret), "_", "-")
+		c.result.Secrets[secret] = inputName
+		return "inputs." + inputName
+	})
+	return eventInputsPattern.ReplaceAllString(expr, "inputs.")
+}
+
+// rewriteValue applies rewrite to every ${{ }} expression in a step value
+func rewriteValue(value any, rewrite func(string) string) any {
+	switch v := value.(type) {
+	case string:
+		return expressionPattern.ReplaceAllStringFunc(v, func(expr string) string {
+			inner := expressionPattern.FindStringSubmatch(expr)[1]
+			return "${{ " + rewrite(strings.TrimSpace(inner)) + " }}"
+		})
+	case yaml.MapSlice:
+		rewritten := make(yaml.MapSlice, 0, len(v))
+		for _, item := range v {
+			rewritten = append(rewritten, yaml.MapItem{Key: item.Key, Value: rewriteValue(item.Value, rewrite)})
+		}
+		return rewritten
+	default:
+		return value
+	}
+}
+
+// workflowInputsToActionInputs maps workflow_dispatch and workflow_call inputs onto action inputs
+func workflowInputsToActionInputs(on any, result *PackageResult) yaml.MapSlice {
+	triggers, _ := on.(yaml.MapSlice)
+	var inputs yaml.MapSlice
+	for _, trigger := range []string{"workflow_dispatch", "workflow_call"} {
+		config, _ := mapSliceValue(triggers, trigger).(yaml.MapSlice)
+		declared, _ := mapSliceValue(config, "inputs").(yaml.MapSlice)
+		for _, item := range declared {
+			inputName := fmt.Sprint(item.Key)
+			if mapSliceValue(inputs, inputName) != nil {
+				continue
+			}
+			spec, _ := item.Value.(yaml.MapSlice)
+			input := yaml.MapSlice{}
+			description, _ := mapSliceValue(spec, "description").(string)
+			if options, ok := mapSliceValue(spec, "options").([]any); ok && len(options) > 0 {
+				values := make([]string, 0, len(options))
+				for _, option := range options {
+					values = append(values, fmt.Sprint(option))
+				}
+				description = strings.TrimSpace(fmt.Sprintf("%s (one of: %s)", description, strings.Join(values, ", ")))
+			}
+			if description != "" {
+				input = append(input, yaml.MapItem{Key: "description", Value: description})
+			}
+			if required, ok := mapSliceValue(spec, "required").(bool); ok {
+				input = append(input, yaml.MapItem{Key: "required", Value: required})
+			}
+			if def := mapSliceValue(spec, "default"); def != nil {
+				input = append(input, yaml.MapItem{Key: "default", Value: fmt.Sprint(def)})
+			}
+			inputs = append(inputs, yaml.MapItem{Key: inputName, Value: input})
+			result.Inputs = append(result.Inputs, inputName)
+		}
+	}
+	return inputs
+}
+
+// buildPackageReadme renders usage documentation for the generated composite action
+func buildPackageReadme(name, description, usesRef string, result *PackageResult, inputs yaml.MapSlice) string {
+	if usesRef == "" {
+		usesRef = "OWNER/REPO/PATH@REF"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n%s\n\n", name, description)
+	b.WriteString("This composite action was generated by `gh aw package`. Re-run the command after changing the workflow instead of editing `action.yml`.\n\n")
+	b.WriteString("## Usage\n\n```yaml\njobs:\n  agent:\n    runs-on: ubuntu-latest\n")
+	if len(result.Permissions) > 0 {
+		b.WriteString("    permissions:\n")
+		for _, scope := range sortedPermissionScopes(result.Permissions) {
+			fmt.Fprintf(&b, "      %s: %s\n", scope, result.Permissions[scope])
+		}
+	}
+	fmt.Fprintf(&b, "    steps:\n      - uses: %s\n", usesRef)
+	if len(inputs) > 0 {
+		b.WriteString("        with:\n")
+		for _, item := range inputs {
+			inputName := fmt.Sprint(item.Key)
+			value := "..."
+			for secret, secretInput := range result.Secrets {
+				if secretInput == inputName {
+					value = fmt.Sprintf("${{ secrets.%s }}", secret)
+				}
+			}
+			fmt.Fprintf(&b, "          %s: %s\n", inputName, value)
+		}
+	}
+	b.WriteString("```\n")
+
+	if len(inputs) > 0 {
+		b.WriteString("\n## Inputs\n\n| Name | Required | Description |\n| --- | --- | --- |\n")
+		for _, item := range inputs {
+			spec, _ := item.Value.(yaml.MapSlice)
+			required, _ := mapSliceValue(spec, "required").(bool)
+			inputDescription, _ := mapSliceValue(spec, "description").(string)
+			fmt.Fprintf(&b, "| `%s` | %v | %s |\n", item.Key, required, inputDescription)
+		}
+	}
+
+	if len(result.Issues) > 0 {
+		b.WriteString("\n## Differences from the workflow\n\n")
+		for _, issue := range result.Issues {
+			fmt.Fprintf(&b, "- `%s`: %s\n", issue.Location, issue.Message)
+		}
+	}
+	return b.String()
+}
+
+// compositeMarkerIDs returns the IDs of the marker steps that record a job's start and result
+func compositeMarkerIDs(jobID string) (string, string) {
+	return "job_" + jobID + "_started", "job_" + jobID + "_result"
+}
+
+// jobCondition returns a job's if condition without the ${{ }} wrapper
+func jobCondition(job yaml.MapSlice) string {
+	cond, _ := mapSliceValue(job, "if").(string)
+	return stripExpressionWrapper(cond)
+}
+
+// stripExpressionWrapper removes a ${{ }} wrapper around a whole condition
+func stripExpressionWrapper(cond string) string {
+	cond = strings.TrimSpace(cond)
+	if strings.HasPrefix(cond, "${{") && strings.HasSuffix(cond, "}}") && strings.Count(cond, "${{") == 1 {
+		return strings.TrimSpace(cond[3 : len(cond)-2])
+	}
+	return cond
+}
+
+// mergeEnv returns base overlaid with override, keeping the order of first appearance
+func mergeEnv(base, override yaml.MapSlice) yaml.MapSlice {
+	merged := make(yaml.MapSlice, 0, len(base)+len(override))
+	merged = append(merged, base...)
+	for _, item := range override {
+		merged = setMapSliceValue(merged, fmt.Sprint(item.Key), item.Value)
+	}
+	return merged
+}
+
+// sortedPermissionScopes returns permission scopes in alphabetical order
+func sortedPermissionScopes(permissions map[string]string) []string {
+	scopes := make([]string, 0, len(permissions))
+	for scope := range permissions {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// addIssue records a construct that was dropped or approximated
+func (r *PackageResult) addIssue(location, message string) {
+	r.Issues = append(r.Issues, PackageIssue{Location: location, Message: message})
+}
@@ -0,0 +1,166 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const packageLockWorkflow = `name: "Triage"
+"on":
+  workflow_dispatch:
+    inputs:
+      issue:
+        description: Issue number to triage
+        required: true
+      mode:
+        description: Triage mode
+        type: choice
+        options: [quick, full]
+        default: quick
+env:
+  GH_AW_LEVEL: info
+concurrency:
+  group: triage
+jobs:
+  agent:
+    needs: activation
+    if: needs.activation.outputs.activated == 'true'
+    runs-on: ubuntu-latest
+    timeout-minutes: 20
+    permissions:
+      contents: read
+      issues: read
+    outputs:
+      output: ${{ steps.collect.outputs.output }}
+    steps:
+      - name: Run agent
+        id: collect
+        run: agent --issue "${{ github.event.inputs.issue }}" --mode "${{ inputs.mode }}"
+        env:
+          API_KEY: ${{ secrets.ANTHROPIC_API_KEY }}
+  activation:
+    runs-on: ubuntu-slim
+    outputs:
+      activated: ${{ steps.check.outputs.allowed == 'true' }}
+      comment_id: ""
+    steps:
+      - name: Check
+        id: check
+        uses: actions/github-script@ed597411d8f924073f98dfc5c65a23a2325f34cd # v8
+        with:
+          github-token: ${{ secrets.GITHUB_TOKEN }}
+          script: core.setOutput('allowed', 'true')
+  conclusion:
+    needs: [activation, agent]
+    if: always() && needs.agent.result != 'skipped'
+    permissions:
+      issues: write
+    steps:
+      - name: Report
+        if: always()
+        run: echo "${{ needs.agent.outputs.output }} ${{ needs.agent.result }}"
+`
+
+func TestConvertLockFileToCompositeAction(t *testing.T) {
+	result, err := ConvertLockFileToCompositeAction([]byte(packageLockWorkflow), PackageOptions{Source: ".github/workflows/triage.md"})
+	require.NoError(t, err, "Conversion should succeed")
+
+	assert.Equal(t, []string{"activation", "agent", "conclusion"}, result.Jobs, "Jobs should run in dependency order")
+	assert.Equal(t, []string{"issue", "mode", "anthropic-api-key"}, result.Inputs, "Workflow inputs should precede secret inputs")
+	assert.Equal(t, map[string]string{"ANTHROPIC_API_KEY": "anthropic-api-key"}, result.Secrets, "GITHUB_TOKEN should not become an input")
+	assert.Equal(t, map[string]string{"contents": "read", "issues": "write"}, result.Permissions, "Permissions should be the union of job permissions")
+	assert.Contains(t, result.ActionYAML, "from .github/workflows/triage.md", "Header should record the source workflow")
+	assert.Contains(t, result.ActionYAML, "#   issues: write", "Header should list required permissions")
+
+	var action struct {
+		Name   string `yaml:"name"`
+		Inputs map[string]struct {
+			Description string `yaml:"description"`
+			Required    bool   `yaml:"required"`
+			Default     string `yaml:"default"`
+		} `yaml:"inputs"`
+		Runs struct {
+			Using string           `yaml:"using"`
+			Steps []map[string]any `yaml:"steps"`
+		} `yaml:"runs"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(result.ActionYAML), &action), "Generated action.yml should be valid YAML")
+
+	assert.Equal(t, "Triage", action.Name, "Name should default to the workflow name")
+	assert.Equal(t, "composite", action.Runs.Using, "Action should be a composite action")
+	assert.True(t, action.Inputs["issue"].Required, "Required workflow inputs should stay required")
+	assert.Equal(t, "Triage mode (one of: quick, full)", action.Inputs["mode"].Description, "Choice options should be described")
+	assert.Equal(t, "quick", action.Inputs["mode"].Default, "Defaults should be preserved")
+	require.Len(t, action.Runs.Steps, 9, "Each job should contribute its steps plus start and finish markers")
+
+	steps := map[string]map[string]any{}
+	for _, step := range action.Runs.Steps {
+		steps[step["name"].(string)] = step
+	}
+
+	check := steps["Check"]
+	assert.Equal(t, "activation_check", check["id"], "Step IDs should be prefixed with the job ID")
+	assert.Equal(t, "${{ github.token }}", check["with"].(map[string]any)["github-token"], "GITHUB_TOKEN should map to github.token")
+
+	assert.Equal(t, "format('{0}', steps.activation_check.outputs.allowed == 'true') == 'true'", steps["Start agent"]["if"], "Job conditions should inline needs outputs")
+
+	run := steps["Run agent"]
+	assert.Equal(t, "bash", run["shell"], "Run steps should default to bash")
+	assert.Equal(t, "steps.job_agent_started.outputs.started == 'true'", run["if"], "Steps should be gated on their job starting")
+	assert.Equal(t, `agent --issue "${{ inputs.issue }}" --mode "${{ inputs.mode }}"`, run["run"], "Event inputs should map to action inputs")
+	assert.Equal(t, map[string]any{"GH_AW_LEVEL": "info", "API_KEY": "${{ inputs.anthropic-api-key }}"}, run["env"], "Secrets should map to inputs and workflow env should be merged")
+
+	report := steps["Report"]
+	assert.Equal(t, "steps.job_conclusion_started.outputs.started == 'true' && (always())", report["if"], "Step conditions should be combined with the job gate")
+	assert.Equal(t, `echo "${{ format('{0}', steps.agent_collect.outputs.output) }} ${{ (steps.job_agent_result.outputs.result || 'skipped') }}"`, report["run"], "needs references should resolve to marker and step outputs")
+	assert.Equal(t, "always() && (steps.job_agent_result.outputs.result || 'skipped') != 'skipped'", steps["Start conclusion"]["if"], "Job results should come from finish markers")
+
+	var locations []string
+	for _, issue := range result.Issues {
+		locations = append(locations, issue.Location)
+	}
+	assert.ElementsMatch(t, []string{"concurrency", "jobs.agent.timeout-minutes"}, locations, "Dropped constructs should be reported")
+	assert.Contains(t, result.Readme, "anthropic-api-key: ${{ secrets.ANTHROPIC_API_KEY }}", "README should show how to pass secrets")
+}
+
+func TestConvertLockFileToCompositeActionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		lock    string
+		wantErr string
+	}{
+		{
+			name:    "no jobs",
+			lock:    "name: Empty\n",
+			wantErr: "compiled workflow has no jobs",
+		},
+		{
+			name:    "unknown dependency",
+			lock:    "jobs:\n  agent:\n    needs: missing\n    steps: []\n",
+			wantErr: "job 'agent' needs unknown job 'missing'",
+		},
+		{
+			name:    "dependency cycle",
+			lock:    "jobs:\n  a:\n    needs: b\n  b:\n    needs: a\n",
+			wantErr: "dependency cycle",
+		},
+		{
+			name:    "local action",
+			lock:    "jobs:\n  agent:\n    steps:\n      - uses: ./actions/setup\n",
+			wantErr: "uses ./actions/setup, which consumers of the action cannot resolve",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertLockFileToCompositeAction([]byte(tt.lock), PackageOptions{})
+			require.Error(t, err, "Conversion should fail")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error should explain the failure")
+		})
+	}
+}
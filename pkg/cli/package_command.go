@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var packageCommandLog = logger.New("cli:package_command")
+
+// PackageConfig holds configuration for the package command
+type PackageConfig struct {
+	WorkflowName string // Workflow ID or path to its markdown file
+	OutputDir    string // Directory that receives action.yml and README.md (default: actions/<workflow-id>)
+	ActionTag    string // Release tag or SHA for gh-aw actions referenced by the package
+	Force        bool   // Overwrite an existing action.yml
+	Verbose      bool
+}
+
+// NewPackageCommand creates the package command
+func NewPackageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "package <workflow>",
+		Short: "Bundle an agentic workflow into a reusable composite action",
+		Long: `Compile an agentic workflow and bundle it into a composite action (action.yml
+and README.md) so other repositories can run the agent with 'uses:' instead of
+copying its markdown.
+
+The generated action:
+  - Runs the workflow's jobs as sequential steps on the calling job's runner
+  - Inlines the prompt, so consumers do not need the markdown source
+  - Exposes workflow_dispatch and workflow_call inputs as action inputs
+  - Exposes each referenced secret as an optional input (composite actions cannot read secrets)
+  - Lists the permissions the calling job must grant
+
+Job settings with no composite action equivalent (timeouts, containers, services,
+environments, concurrency) are dropped and reported.
+
+gh-aw actions are referenced by release tag. Development builds must pass
+--action-tag because they have no release to reference.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` package issue-triage                              # Writes actions/issue-triage/action.yml
+  ` + string(constants.CLIExtensionPrefix) + ` package issue-triage -o .github/actions/triage    # Custom output directory
+  ` + string(constants.CLIExtensionPrefix) + ` package issue-triage --action-tag v0.40.0         # Reference a specific gh-aw release`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputDir, _ := cmd.Flags().GetString("output")
+			actionTag, _ := cmd.Flags().GetString("action-tag")
+			force, _ := cmd.Flags().GetBool("force")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			return RunPackage(PackageConfig{
+				WorkflowName: args[0],
+				OutputDir:    outputDir,
+				ActionTag:    actionTag,
+				Force:        force,
+				Verbose:      verbose,
+			})
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Output directory for action.yml and README.md (default: actions/<workflow-id>)")
+	cmd.Flags().String("action-tag", "", "Release tag or SHA of the gh-aw actions referenced by the package (required for development builds)")
+	cmd.Flags().BoolP("force", "f", false, "Overwrite an existing action.yml")
+	cmd.ValidArgsFunction = CompleteWorkflowNames
+
+	return cmd
+}
+
+// RunPackage compiles a workflow and writes it as a composite action
+func RunPackage(config PackageConfig) error {
+	packageCommandLog.Printf("Packaging workflow: name=%s, output=%s, actionTag=%s", config.WorkflowName, config.OutputDir, config.ActionTag)
+
+	markdownPath, err := resolveWorkflowFile(config.WorkflowName, config.Verbose)
+	if err != nil {
+		return err
+	}
+	workflowID := normalizeWorkflowID(markdownPath)
+
+	// Consumers cannot resolve local action paths, so the package references released actions
+	compiler := workflow.NewCompiler(workflow.WithVerbose(config.Verbose))
+	compiler.SetActionMode(workflow.ActionModeRelease)
+	if config.ActionTag != "" {
+		compiler.SetActionTag(config.ActionTag)
+	}
+	setupRepositoryContext(compiler)
+
+	workflowData, err := compiler.ParseWorkflowFile(markdownPath)
+	if err != nil {
+		return err
+	}
+	// Consumers do not have the markdown sources, so the prompt and imports are inlined
+	if workflowData.AgentFile != "" {
+		return fmt.Errorf("%s imports agent file %s, which cannot be packaged because it is read at runtime", markdownPath, workflowData.AgentFile)
+	}
+	workflowData.InlinedImports = true
+	lockYAML, err := compiler.CompileToYAML(workflowData, markdownPath)
+	if err != nil {
+		return err
+	}
+
+	sourcePath := markdownPath
+	if relPath, err := getRepositoryRelativePath(markdownPath); err == nil {
+		sourcePath = relPath
+	}
+
+	outputDir := config.OutputDir
+	if outputDir == "" {
+		outputDir = filepath.Join("actions", workflowID)
+	}
+
+	repoSlug, actionDir := "OWNER/REPO", "PATH"
+	if slug := getRepositorySlugFromRemote(); slug != "" {
+		repoSlug = slug
+	}
+	if !filepath.IsAbs(outputDir) {
+		actionDir = filepath.ToSlash(filepath.Clean(outputDir))
+	}
+	usesRef := repoSlug + "/" + actionDir + "@main"
+
+	result, err := ConvertLockFileToCompositeAction([]byte(lockYAML), PackageOptions{
+		Name:        workflowData.Name,
+		Description: workflowData.Description,
+		Source:      sourcePath,
+		UsesRef:     usesRef,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to package %s: %w", workflowID, err)
+	}
+
+	actionPath := filepath.Join(outputDir, "action.yml")
+	if _, err := os.Stat(actionPath); err == nil && !config.Force {
+		return fmt.Errorf("%s already exists. Use --force to overwrite", actionPath)
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(actionPath, []byte(result.ActionYAML), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", actionPath, err)
+	}
+	readmePath := filepath.Join(outputDir, "README.md")
+	if err := os.WriteFile(readmePath, []byte(result.Readme), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", readmePath, err)
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Packaged %s (%d jobs) as a composite action in %s", workflowID, len(result.Jobs), outputDir)))
+	if len(result.Inputs) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Inputs: "+strings.Join(result.Inputs, ", ")))
+	}
+	if len(result.Permissions) > 0 {
+		var perms []string
+		for _, scope := range sortedPermissionScopes(result.Permissions) {
+			perms = append(perms, scope+": "+result.Permissions[scope])
+		}
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Calling jobs must grant: "+strings.Join(perms, ", ")))
+	}
+
+	if len(result.Issues) > 0 {
+		fmt.Fprintln(os.Stderr, "")
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("%d construct(s) were dropped or approximated:", len(result.Issues))))
+		for _, issue := range result.Issues {
+			fmt.Fprintf(os.Stderr, "  • %s: %s\n", issue.Location, issue.Message)
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Use it from another repository with:"))
+	fmt.Fprintf(os.Stderr, "  - uses: %s\n", usesRef)
+
+	return nil
+}
@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var replayCommandLog = logger.New("cli:replay_command")
+
+// replayEventFileName is the file in the activation artifact that holds the recorded event
+const replayEventFileName = "event.json"
+
+// maxReplayInputsSize is GitHub's limit on the combined size of workflow_dispatch inputs
+const maxReplayInputsSize = 65535
+
+// replayPayloadOptionalKeys are payload objects that are dropped, in order, when a recorded
+// payload is too large to fit in a workflow_dispatch input
+var replayPayloadOptionalKeys = []string{"enterprise", "installation", "organization", "repository"}
+
+// ReplayConfig holds configuration for the replay command
+type ReplayConfig struct {
+	RunID    int64
+	Owner    string
+	Repo     string
+	Hostname string
+	Ref      string // Ref to dispatch; defaults to the head branch of the original run
+	DryRun   bool
+	Verbose  bool
+}
+
+// RecordedEvent is the event payload recorded by the activation job of a run
+type RecordedEvent struct {
+	EventName string         `json:"event_name"`
+	Payload   map[string]any `json:"payload"`
+}
+
+// NewReplayCommand creates the replay command
+func NewReplayCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay <run-id>",
+		Short: "Re-run a workflow with the event payload recorded by a previous run",
+		Long: `Fetch the event payload and inputs recorded by a previous workflow run and dispatch the
+workflow again with that payload, to reproduce agent behavior on the same input.
+
+Runs triggered by workflow_dispatch are dispatched again with the same inputs. Runs
+triggered by any other event need replay support compiled into the workflow:
+
+  on:
+    issues:
+      types: [opened]
+    replay: true
+
+With replay: true, the recorded payload is passed through workflow_dispatch inputs
+and prompt expressions on github.event read from it. Job conditions and the
+workflow's steps still see the workflow_dispatch event.
+
+This command accepts a numeric run ID or a GitHub Actions run URL.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` replay 1234567890                # Replay a run
+  ` + string(constants.CLIExtensionPrefix) + ` replay 1234567890 --ref my-fix   # Replay against another branch
+  ` + string(constants.CLIExtensionPrefix) + ` replay 1234567890 --dry-run      # Show what would be dispatched
+  ` + string(constants.CLIExtensionPrefix) + ` replay https://github.com/owner/repo/actions/runs/1234567890`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			components, err := parser.ParseRunURLExtended(args[0])
+			if err != nil {
+				return err
+			}
+			repoOverride, _ := cmd.Flags().GetString("repo")
+			ref, _ := cmd.Flags().GetString("ref")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			config := ReplayConfig{
+				RunID:    components.Number,
+				Owner:    components.Owner,
+				Repo:     components.Repo,
+				Hostname: components.Host,
+				Ref:      ref,
+				DryRun:   dryRun,
+				Verbose:  verbose,
+			}
+			if repoOverride != "" {
+				owner, repo, hostname, err := parseReplayRepo(repoOverride)
+				if err != nil {
+					return err
+				}
+				config.Owner, config.Repo, config.Hostname = owner, repo, hostname
+			}
+			return RunReplay(config)
+		},
+	}
+
+	addRepoFlag(cmd)
+	cmd.Flags().String("ref", "", "Branch or tag to dispatch (default: the head branch of the original run)")
+	cmd.Flags().Bool("dry-run", false, "Show the dispatch that would be made without running it")
+
+	return cmd
+}
+
+// parseReplayRepo splits a [HOST/]owner/repo value
+func parseReplayRepo(value string) (owner, repo, hostname string, err error) {
+	parts := strings.Split(value, "/")
+	switch len(parts) {
+	case 2:
+		owner, repo = parts[0], parts[1]
+	case 3:
+		hostname, owner, repo = parts[0], parts[1], parts[2]
+	default:
+		return "", "", "", fmt.Errorf("invalid repository %q: expected [HOST/]owner/repo", value)
+	}
+	if owner == "" || repo == "" {
+		return "", "", "", fmt.Errorf("invalid repository %q: expected [HOST/]owner/repo", value)
+	}
+	return owner, repo, hostname, nil
+}
+
+// RunReplay dispatches a workflow again with the event recorded by a previous run
+func RunReplay(config ReplayConfig) error {
+	replayCommandLog.Printf("Replaying run %d", config.RunID)
+
+	run, err := fetchWorkflowRunMetadata(config.RunID, config.Owner, config.Repo, config.Hostname, config.Verbose)
+	if err != nil {
+		return err
+	}
+	lockFileName := filepath.Base(run.WorkflowPath)
+	if !strings.HasSuffix(lockFileName, ".lock.yml") {
+		return fmt.Errorf("run %d is not an agentic workflow run (workflow file: %s)", config.RunID, run.WorkflowPath)
+	}
+
+	repoSlug := replayRepoSlug(config)
+	event, err := downloadRecordedEvent(config.RunID, repoSlug, config.Verbose)
+	if err != nil {
+		return err
+	}
+
+	ref := config.Ref
+	if ref == "" {
+		ref = run.HeadBranch
+	}
+
+	inputs, err := buildReplayInputs(event)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"workflow", "run", lockFileName}
+	if repoSlug != "" {
+		args = append(args, "--repo", repoSlug)
+	}
+	if ref != "" {
+		args = append(args, "--ref", ref)
+	}
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, "-f", name+"="+inputs[name])
+	}
+
+	summary := fmt.Sprintf("Replaying %s event from run %d of %s", event.EventName, config.RunID, lockFileName)
+	if ref != "" {
+		summary += " on " + ref
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(summary))
+
+	if config.DryRun {
+		for _, name := range names {
+			value := inputs[name]
+			if len(value) > 80 {
+				value = fmt.Sprintf("%s... (%d bytes)", value[:80], len(inputs[name]))
+			}
+			fmt.Fprintln(os.Stderr, console.FormatListItem(name+"="+value))
+		}
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Dry run: workflow not dispatched"))
+		return nil
+	}
+
+	output, err := workflow.RunGHCombined("Dispatching workflow...", args...)
+	if err != nil {
+		if strings.Contains(string(output), "Unexpected inputs") {
+			return fmt.Errorf("%s does not accept replay inputs; add 'replay: true' under 'on:' and recompile the workflow: %s", lockFileName, strings.TrimSpace(string(output)))
+		}
+		return fmt.Errorf("failed to dispatch workflow: %w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+
+	runInfo, err := getLatestWorkflowRunWithRetry(lockFileName, repoSlug, config.Verbose)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Workflow dispatched"))
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Replay started: "+runInfo.URL))
+	return nil
+}
+
+// replayRepoSlug returns the --repo value for gh commands, or an empty string for the
+// current repository
+func replayRepoSlug(config ReplayConfig) string {
+	if config.Owner == "" || config.Repo == "" {
+		return ""
+	}
+	if config.Hostname != "" && config.Hostname != "github.com" {
+		return config.Hostname + "/" + config.Owner + "/" + config.Repo
+	}
+	return config.Owner + "/" + config.Repo
+}
+
+// downloadRecordedEvent downloads the activation artifact of a run and reads its recorded event
+func downloadRecordedEvent(runID int64, repoSlug string, verbose bool) (*RecordedEvent, error) {
+	tempDir, err := os.MkdirTemp("", "gh-aw-replay-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// The pattern also matches activation artifacts renamed by artifacts.prefix
+	args := []string{"run", "download", strconv.FormatInt(runID, 10), "--pattern", "*activation", "--dir", tempDir}
+	if repoSlug != "" {
+		args = append(args, "--repo", repoSlug)
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage("Executing: gh "+strings.Join(args, " ")))
+	}
+	output, err := workflow.RunGHCombined("Downloading activation artifact...", args...)
+	if err != nil {
+		if strings.Contains(string(output), "no valid artifacts") || strings.Contains(string(output), "no artifact matches") {
+			return nil, fmt.Errorf("run %d has no activation artifact; it may have expired or the run did not reach the activation job", runID)
+		}
+		return nil, fmt.Errorf("failed to download activation artifact: %w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+
+	var eventPath string
+	_ = filepath.Walk(tempDir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && info.Name() == replayEventFileName && eventPath == "" {
+			eventPath = path
+		}
+		return nil
+	})
+	if eventPath == "" {
+		return nil, fmt.Errorf("run %d did not record its event payload; recompile the workflow with a newer version of gh-aw to enable replay", runID)
+	}
+	return readRecordedEvent(eventPath)
+}
+
+// readRecordedEvent parses a recorded event file. When the recorded run was itself a replay,
+// the original event is returned.
+func readRecordedEvent(path string) (*RecordedEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorded event: %w", err)
+	}
+	var event RecordedEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse recorded event: %w", err)
+	}
+	if event.EventName == "" {
+		return nil, errors.New("recorded event does not have an event name")
+	}
+
+	if event.EventName == "workflow_dispatch" {
+		inputs, _ := event.Payload["inputs"].(map[string]any)
+		replayedName, _ := inputs[workflow.ReplayEventInputName].(string)
+		replayedPayload, _ := inputs[workflow.ReplayPayloadInputName].(string)
+		if replayedName != "" && replayedPayload != "" {
+			var payload map[string]any
+			if err := json.Unmarshal([]byte(replayedPayload), &payload); err != nil {
+				return nil, fmt.Errorf("failed to parse replayed payload: %w", err)
+			}
+			replayCommandLog.Printf("Recorded run was a replay of a %s event", replayedName)
+			return &RecordedEvent{EventName: replayedName, Payload: payload}, nil
+		}
+	}
+	return &event, nil
+}
+
+// buildReplayInputs returns the workflow_dispatch inputs that reproduce a recorded event.
+// workflow_dispatch runs are dispatched with their original inputs; other events are passed
+// through the replay inputs.
+func buildReplayInputs(event *RecordedEvent) (map[string]string, error) {
+	inputs := map[string]string{}
+
+	if event.EventName == "workflow_dispatch" {
+		original, _ := event.Payload["inputs"].(map[string]any)
+		for name, value := range original {
+			if name == workflow.ReplayEventInputName || name == workflow.ReplayPayloadInputName {
+				continue
+			}
+			if s, ok := value.(string); ok {
+				inputs[name] = s
+			} else if value != nil {
+				inputs[name] = fmt.Sprint(value)
+			}
+		}
+		return inputs, nil
+	}
+
+	payload := make(map[string]any, len(event.Payload))
+	for key, value := range event.Payload {
+		payload[key] = value
+	}
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event payload: %w", err)
+	}
+	for _, key := range replayPayloadOptionalKeys {
+		if len(encoded)+len(event.EventName) <= maxReplayInputsSize {
+			break
+		}
+		if _, exists := payload[key]; !exists {
+			continue
+		}
+		replayCommandLog.Printf("Dropping %s from payload to fit workflow_dispatch inputs", key)
+		delete(payload, key)
+		if encoded, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("failed to encode event payload: %w", err)
+		}
+	}
+	if len(encoded)+len(event.EventName) > maxReplayInputsSize {
+		return nil, fmt.Errorf("the recorded %s payload is %d bytes, which exceeds the %d byte limit for workflow_dispatch inputs", event.EventName, len(encoded), maxReplayInputsSize)
+	}
+
+	inputs[workflow.ReplayEventInputName] = event.EventName
+	inputs[workflow.ReplayPayloadInputName] = string(encoded)
+	return inputs, nil
+}
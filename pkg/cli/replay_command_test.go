@@ -0,0 +1,104 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRecordedEvent(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "replay-event")
+
+	write := func(name string, event any) string {
+		data, err := json.Marshal(event)
+		require.NoError(t, err, "should encode event")
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.WriteFile(path, data, 0644), "should write event")
+		return path
+	}
+
+	event, err := readRecordedEvent(write("issues.json", map[string]any{
+		"event_name": "issues",
+		"payload":    map[string]any{"action": "opened"},
+	}))
+	require.NoError(t, err, "recorded event should parse")
+	assert.Equal(t, "issues", event.EventName, "event name should be read")
+	assert.Equal(t, "opened", event.Payload["action"], "payload should be read")
+
+	event, err = readRecordedEvent(write("replayed.json", map[string]any{
+		"event_name": "workflow_dispatch",
+		"payload": map[string]any{"inputs": map[string]any{
+			"aw_replay_event":   "issue_comment",
+			"aw_replay_payload": `{"comment":{"id":5}}`,
+		}},
+	}))
+	require.NoError(t, err, "replayed event should parse")
+	assert.Equal(t, "issue_comment", event.EventName, "replay of a replay should use the original event")
+	assert.Equal(t, map[string]any{"id": float64(5)}, event.Payload["comment"], "original payload should be unwrapped")
+
+	_, err = readRecordedEvent(write("nameless.json", map[string]any{"payload": map[string]any{}}))
+	require.Error(t, err, "event without a name should be rejected")
+}
+
+func TestBuildReplayInputs(t *testing.T) {
+	t.Run("workflow_dispatch keeps original inputs", func(t *testing.T) {
+		inputs, err := buildReplayInputs(&RecordedEvent{
+			EventName: "workflow_dispatch",
+			Payload:   map[string]any{"inputs": map[string]any{"topic": "ci", "count": float64(3), "aw_replay_event": ""}},
+		})
+		require.NoError(t, err, "inputs should build")
+		assert.Equal(t, map[string]string{"topic": "ci", "count": "3"}, inputs, "original inputs should be dispatched")
+	})
+
+	t.Run("other events use replay inputs", func(t *testing.T) {
+		inputs, err := buildReplayInputs(&RecordedEvent{
+			EventName: "issues",
+			Payload:   map[string]any{"action": "opened", "issue": map[string]any{"number": float64(7)}},
+		})
+		require.NoError(t, err, "inputs should build")
+		assert.Equal(t, "issues", inputs["aw_replay_event"], "event name should be passed")
+		assert.JSONEq(t, `{"action":"opened","issue":{"number":7}}`, inputs["aw_replay_payload"], "payload should be passed as JSON")
+	})
+
+	t.Run("large payloads drop optional objects", func(t *testing.T) {
+		inputs, err := buildReplayInputs(&RecordedEvent{
+			EventName: "issues",
+			Payload: map[string]any{
+				"issue":      map[string]any{"number": float64(7)},
+				"repository": map[string]any{"description": strings.Repeat("x", maxReplayInputsSize)},
+			},
+		})
+		require.NoError(t, err, "payload should fit once the repository is dropped")
+		assert.JSONEq(t, `{"issue":{"number":7}}`, inputs["aw_replay_payload"], "repository should be dropped")
+	})
+
+	t.Run("payload too large", func(t *testing.T) {
+		_, err := buildReplayInputs(&RecordedEvent{
+			EventName: "issues",
+			Payload:   map[string]any{"issue": map[string]any{"body": strings.Repeat("x", maxReplayInputsSize)}},
+		})
+		require.Error(t, err, "oversized payload should be rejected")
+		assert.Contains(t, err.Error(), "exceeds", "error should mention the limit")
+	})
+}
+
+func TestParseReplayRepo(t *testing.T) {
+	owner, repo, host, err := parseReplayRepo("octo/repo")
+	require.NoError(t, err, "owner/repo should parse")
+	assert.Equal(t, []string{"octo", "repo", ""}, []string{owner, repo, host}, "parts should match")
+
+	owner, repo, host, err = parseReplayRepo("ghe.example.com/octo/repo")
+	require.NoError(t, err, "host/owner/repo should parse")
+	assert.Equal(t, []string{"octo", "repo", "ghe.example.com"}, []string{owner, repo, host}, "parts should match")
+
+	_, _, _, err = parseReplayRepo("octo")
+	require.Error(t, err, "missing repo should be rejected")
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var runWatchLog = logger.New("cli:run_watch")
+
+// watchPollIntervalSeconds is how often the live run view refreshes
+const watchPollIntervalSeconds = 5
+
+// RunConclusionError reports a watched workflow run that did not succeed.
+// The CLI exits with ExitCode so scripts can branch on the run's conclusion.
+type RunConclusionError struct {
+	RunID      int64
+	Conclusion string
+}
+
+// Error implements the error interface
+func (e *RunConclusionError) Error() string {
+	return fmt.Sprintf("workflow run %d completed with conclusion '%s'", e.RunID, e.Conclusion)
+}
+
+// ExitCode returns the process exit code for the run's conclusion
+func (e *RunConclusionError) ExitCode() int {
+	return runConclusionExitCode(e.Conclusion)
+}
+
+// runConclusionExitCode maps a workflow run conclusion to a process exit code:
+// 0 for success, neutral and skipped, 2 for cancelled, 3 for timed_out, and 1 otherwise
+func runConclusionExitCode(conclusion string) int {
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return 0
+	case "cancelled":
+		return 2
+	case "timed_out":
+		return 3
+	default:
+		return 1
+	}
+}
+
+// parseRunConclusion extracts the status and conclusion from `gh run view --json status,conclusion` output
+func parseRunConclusion(output []byte) (status string, conclusion string, err error) {
+	var run struct {
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	}
+	if err := json.Unmarshal(output, &run); err != nil {
+		return "", "", fmt.Errorf("failed to parse workflow run status: %w", err)
+	}
+	return run.Status, run.Conclusion, nil
+}
+
+// watchWorkflowRun attaches the live run view to a triggered workflow run and waits for it to complete.
+// Returns a RunConclusionError when the run does not succeed.
+func watchWorkflowRun(ctx context.Context, runID int64, repoOverride string, verbose bool) error {
+	runIDStr := strconv.FormatInt(runID, 10)
+	runWatchLog.Printf("Watching workflow run: id=%d, repo=%s", runID, repoOverride)
+
+	// Stop watching on Ctrl+C instead of exiting, so callers can still clean up (e.g. restore a disabled workflow)
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	args := []string{"run", "watch", runIDStr, "--interval", strconv.Itoa(watchPollIntervalSeconds)}
+	if repoOverride != "" {
+		args = append(args, "--repo", repoOverride)
+	}
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatCommandMessage("gh "+strings.Join(args, " ")))
+	}
+
+	cmd := workflow.ExecGHContext(ctx, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Stopped watching; the workflow run continues on GitHub Actions"))
+			return ctx.Err()
+		}
+		// gh run watch exits non-zero in some failure modes; the conclusion below is authoritative
+		runWatchLog.Printf("gh run watch exited with error: %v", err)
+	}
+
+	viewArgs := []string{"run", "view", runIDStr, "--json", "status,conclusion"}
+	if repoOverride != "" {
+		viewArgs = append(viewArgs, "--repo", repoOverride)
+	}
+	output, err := workflow.ExecGHContext(ctx, viewArgs...).Output()
+	if err != nil {
+		return fmt.Errorf("failed to get conclusion of workflow run %d: %w", runID, err)
+	}
+	status, conclusion, err := parseRunConclusion(output)
+	if err != nil {
+		return err
+	}
+	if status != "completed" {
+		return fmt.Errorf("workflow run %d is still %s", runID, status)
+	}
+
+	runWatchLog.Printf("Workflow run %d completed: conclusion=%s", runID, conclusion)
+	if runConclusionExitCode(conclusion) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Workflow run %d completed: %s", runID, conclusion)))
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("💡 To analyze this run, use: %s audit %d", string(constants.CLIExtensionPrefix), runID)))
+	return &RunConclusionError{RunID: runID, Conclusion: conclusion}
+}
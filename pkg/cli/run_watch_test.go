@@ -0,0 +1,60 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunConclusionExitCode(t *testing.T) {
+	tests := []struct {
+		conclusion string
+		expected   int
+	}{
+		{conclusion: "success", expected: 0},
+		{conclusion: "neutral", expected: 0},
+		{conclusion: "skipped", expected: 0},
+		{conclusion: "failure", expected: 1},
+		{conclusion: "action_required", expected: 1},
+		{conclusion: "startup_failure", expected: 1},
+		{conclusion: "cancelled", expected: 2},
+		{conclusion: "timed_out", expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.conclusion, func(t *testing.T) {
+			assert.Equal(t, tt.expected, runConclusionExitCode(tt.conclusion), "Exit code should match the conclusion")
+		})
+	}
+}
+
+func TestRunConclusionError(t *testing.T) {
+	err := &RunConclusionError{RunID: 12345, Conclusion: "cancelled"}
+	assert.Equal(t, "workflow run 12345 completed with conclusion 'cancelled'", err.Error(), "Error should name the run and conclusion")
+	assert.Equal(t, 2, err.ExitCode(), "Cancelled runs should exit with code 2")
+}
+
+func TestParseRunConclusion(t *testing.T) {
+	status, conclusion, err := parseRunConclusion([]byte(`{"status":"completed","conclusion":"failure"}`))
+	require.NoError(t, err, "Valid JSON should parse")
+	assert.Equal(t, "completed", status, "Status should be parsed")
+	assert.Equal(t, "failure", conclusion, "Conclusion should be parsed")
+
+	_, _, err = parseRunConclusion([]byte("not json"))
+	require.Error(t, err, "Invalid JSON should fail")
+	assert.Contains(t, err.Error(), "failed to parse workflow run status", "Error should describe the parse failure")
+}
+
+func TestRunWorkflowsOnGitHubWatchRequiresSingleRun(t *testing.T) {
+	err := RunWorkflowsOnGitHub(context.Background(), []string{"a", "b"}, RunOptions{Watch: true})
+	require.Error(t, err, "Watching multiple workflows should fail")
+	assert.Contains(t, err.Error(), "--watch can only be used with a single workflow", "Error should explain the restriction")
+
+	err = RunWorkflowsOnGitHub(context.Background(), []string{"a"}, RunOptions{Watch: true, RepeatCount: 2})
+	require.Error(t, err, "Watching repeated runs should fail")
+	assert.Contains(t, err.Error(), "without --repeat", "Error should explain the restriction")
+}
@@ -30,6 +30,7 @@ type RunOptions struct {
 	AutoMergePRs      bool     // Auto-merge PRs created during execution
 	Push              bool     // Commit and push workflow files before running
 	WaitForCompletion bool     // Wait for workflow completion
+	Watch             bool     // Attach the live run view and return the run's conclusion
 	RepeatCount       int      // Number of times to repeat (0 = run once)
 	Inputs            []string // Workflow inputs in key=value format
 	Verbose           bool     // Enable verbose output
@@ -129,8 +130,6 @@ func RunWorkflowOnGitHub(ctx context.Context, workflowIdOrName string, opts RunO
 	}
 
 	// Handle --enable flag logic: check workflow state and enable if needed
-	var wasDisabled bool
-	var workflowID int64
 	if opts.Enable {
 		// Get current workflow status
 		wf, err := getWorkflowStatus(workflowIdOrName, opts.RepoOverride, opts.Verbose)
@@ -142,9 +141,7 @@ func RunWorkflowOnGitHub(ctx context.Context, workflowIdOrName string, opts RunO
 
 		// If we successfully got workflow status, check if it needs enabling
 		if err == nil {
-			workflowID = wf.ID
 			if wf.State == "disabled_manually" {
-				wasDisabled = true
 				executionLog.Printf("Workflow %s is disabled, temporarily enabling for this run (id=%d)", workflowIdOrName, wf.ID)
 				if opts.Verbose {
 					fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Workflow '%s' is disabled, enabling it temporarily...", workflowIdOrName)))
@@ -160,6 +157,8 @@ func RunWorkflowOnGitHub(ctx context.Context, workflowIdOrName string, opts RunO
 					return fmt.Errorf("failed to enable workflow '%s': %w", workflowIdOrName, err)
 				}
 				fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Enabled workflow: "+workflowIdOrName))
+				// Disable the workflow again on every exit path, including failed or interrupted watches
+				defer restoreWorkflowState(workflowIdOrName, wf.ID, opts.RepoOverride, opts.Verbose)
 			} else {
 				executionLog.Printf("Workflow %s is already enabled (state=%s)", workflowIdOrName, wf.State)
 			}
@@ -331,12 +330,6 @@ func RunWorkflowOnGitHub(ctx context.Context, workflowIdOrName string, opts RunO
 			fmt.Fprintln(os.Stderr, console.FormatCommandMessage(strings.Join(cmdParts, " ")))
 		}
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("✓ Validation passed for workflow: %s (dry run - not executed)", lockFileName)))
-
-		// Restore workflow state if it was disabled and we enabled it
-		if opts.Enable && wasDisabled && workflowID != 0 {
-			restoreWorkflowState(workflowIdOrName, workflowID, opts.RepoOverride, opts.Verbose)
-		}
-
 		return nil
 	}
 
@@ -371,11 +364,6 @@ func RunWorkflowOnGitHub(ctx context.Context, workflowIdOrName string, opts RunO
 			fmt.Fprintf(os.Stderr, "%s", exitError.Stderr)
 		}
 
-		// Restore workflow state if it was disabled and we enabled it (even on error)
-		if opts.Enable && wasDisabled && workflowID != 0 {
-			restoreWorkflowState(workflowIdOrName, workflowID, opts.RepoOverride, opts.Verbose)
-		}
-
 		// Check if this is a permission error in a codespace
 		errorMsg := err.Error() + " " + stderrOutput
 		if isRunningInCodespace() && is403PermissionError(errorMsg) {
@@ -466,12 +454,17 @@ func RunWorkflowOnGitHub(ctx context.Context, workflowIdOrName string, opts RunO
 		}
 	}
 
-	// Restore workflow state if it was disabled and we enabled it
-	if opts.Enable && wasDisabled && workflowID != 0 {
-		restoreWorkflowState(workflowIdOrName, workflowID, opts.RepoOverride, opts.Verbose)
+	// Attach the live run view and report the run's conclusion if requested
+	var watchErr error
+	if opts.Watch {
+		if runErr != nil {
+			watchErr = fmt.Errorf("could not find the triggered workflow run to watch: %w", runErr)
+		} else {
+			watchErr = watchWorkflowRun(ctx, runInfo.DatabaseID, opts.RepoOverride, opts.Verbose)
+		}
 	}
 
-	return nil
+	return watchErr
 }
 
 // RunWorkflowsOnGitHub runs multiple agentic workflows on GitHub Actions, optionally repeating a specified number of times
@@ -488,6 +481,10 @@ func RunWorkflowsOnGitHub(ctx context.Context, workflowNames []string, opts RunO
 	default:
 	}
 
+	if opts.Watch && (len(workflowNames) > 1 || opts.RepeatCount > 0) {
+		return errors.New("--watch can only be used with a single workflow and without --repeat")
+	}
+
 	// Validate all workflows exist and are runnable before starting
 	for _, workflowName := range workflowNames {
 		if workflowName == "" {
@@ -15,6 +15,11 @@ func NewStatusCommand() *cobra.Command {
 Displays a table with workflow name, AI engine, compilation status, enabled/disabled state,
 and time remaining until expiration (if stop-after is configured).
 
+With --health, each workflow is scored from 0 to 100 using its runs of the last 30 days:
+failure rate, timeout rate, and, for runs downloaded with 'logs' or 'audit', average cost
+and safe output items rejected by the sanitizer. Workflows exceeding a threshold are
+flagged as needing attention. Thresholds are configured under health: in .aw/config.yml.
+
 The optional pattern argument filters workflows by name (case-insensitive substring match).
 
 Examples:
@@ -23,7 +28,8 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` status --json                    # Output in JSON format
   ` + string(constants.CLIExtensionPrefix) + ` status --ref main                # Show latest run status for main branch
   ` + string(constants.CLIExtensionPrefix) + ` status --label automation        # Show workflows with 'automation' label
-  ` + string(constants.CLIExtensionPrefix) + ` status --repo owner/other-repo   # Check status in different repository`,
+  ` + string(constants.CLIExtensionPrefix) + ` status --repo owner/other-repo   # Check status in different repository
+  ` + string(constants.CLIExtensionPrefix) + ` status --health                  # Score workflows from their recent runs`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var pattern string
 			if len(args) > 0 {
@@ -34,7 +40,8 @@ Examples:
 			ref, _ := cmd.Flags().GetString("ref")
 			labelFilter, _ := cmd.Flags().GetString("label")
 			repoOverride, _ := cmd.Flags().GetString("repo")
-			return StatusWorkflows(pattern, verbose, jsonFlag, ref, labelFilter, repoOverride)
+			health, _ := cmd.Flags().GetBool("health")
+			return StatusWorkflows(pattern, verbose, jsonFlag, ref, labelFilter, repoOverride, health)
 		},
 	}
 
@@ -42,6 +49,7 @@ Examples:
 	cmd.Flags().StringP("repo", "r", "", "Target repository ([HOST/]owner/repo format). Defaults to current repository")
 	cmd.Flags().String("ref", "", "Filter runs by branch or tag name (e.g., main, v1.0.0)")
 	cmd.Flags().String("label", "", "Filter workflows by label")
+	cmd.Flags().Bool("health", false, "Score workflow health from recent runs and flag workflows needing attention")
 
 	// Register completions for status command
 	cmd.ValidArgsFunction = CompleteWorkflowNames
@@ -22,15 +22,18 @@ var statusLog = logger.New("cli:status_command")
 
 // WorkflowStatus represents the status of a single workflow for JSON output
 type WorkflowStatus struct {
-	Workflow      string   `json:"workflow" console:"header:Workflow"`
-	EngineID      string   `json:"engine_id" console:"header:Engine"`
-	Compiled      string   `json:"compiled" console:"header:Compiled"`
-	Status        string   `json:"status" console:"header:Status"`
-	TimeRemaining string   `json:"time_remaining" console:"header:Time Remaining"`
-	Labels        []string `json:"labels,omitempty" console:"header:Labels,omitempty"`
-	On            any      `json:"on,omitempty" console:"-"`
-	RunStatus     string   `json:"run_status,omitempty" console:"header:Run Status,omitempty"`
-	RunConclusion string   `json:"run_conclusion,omitempty" console:"header:Run Conclusion,omitempty"`
+	Workflow      string               `json:"workflow" console:"header:Workflow"`
+	EngineID      string               `json:"engine_id" console:"header:Engine"`
+	Compiled      string               `json:"compiled" console:"header:Compiled"`
+	Status        string               `json:"status" console:"header:Status"`
+	TimeRemaining string               `json:"time_remaining" console:"header:Time Remaining"`
+	Retention     string               `json:"artifact_retention,omitempty" console:"header:Retention,omitempty"`
+	Labels        []string             `json:"labels,omitempty" console:"header:Labels,omitempty"`
+	On            any                  `json:"on,omitempty" console:"-"`
+	RunStatus     string               `json:"run_status,omitempty" console:"header:Run Status,omitempty"`
+	RunConclusion string               `json:"run_conclusion,omitempty" console:"header:Run Conclusion,omitempty"`
+	Health        string               `json:"-" console:"header:Health,omitempty"`
+	HealthScore   *WorkflowHealthScore `json:"health,omitempty" console:"-"`
 }
 
 // GetWorkflowStatuses retrieves workflow status information and returns it as a slice.
@@ -90,6 +93,7 @@ func GetWorkflowStatuses(pattern string, ref string, labelFilter string, repoOve
 		lockFile := stringutil.MarkdownToLockFile(file)
 		compiled := "N/A"
 		timeRemaining := "N/A"
+		retention := ""
 
 		if _, err := os.Stat(lockFile); err == nil {
 			// Check if up to date using hash comparison
@@ -99,6 +103,8 @@ func GetWorkflowStatuses(pattern string, ref string, labelFilter string, repoOve
 			if stopTime := workflow.ExtractStopTimeFromLockFile(lockFile); stopTime != "" {
 				timeRemaining = calculateTimeRemaining(stopTime)
 			}
+
+			retention = formatArtifactRetention(workflow.ExtractArtifactRetentionFromLockFile(lockFile))
 		}
 
 		// Get GitHub workflow status
@@ -162,6 +168,7 @@ func GetWorkflowStatuses(pattern string, ref string, labelFilter string, repoOve
 			Compiled:      compiled,
 			Status:        status,
 			TimeRemaining: timeRemaining,
+			Retention:     retention,
 			Labels:        labels,
 			On:            onField,
 			RunStatus:     runStatus,
@@ -172,8 +179,8 @@ func GetWorkflowStatuses(pattern string, ref string, labelFilter string, repoOve
 	return statuses, nil
 }
 
-func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string, labelFilter string, repoOverride string) error {
-	statusLog.Printf("Checking workflow status: pattern=%s, jsonOutput=%v, ref=%s, labelFilter=%s, repo=%s", pattern, jsonOutput, ref, labelFilter, repoOverride)
+func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string, labelFilter string, repoOverride string, health bool) error {
+	statusLog.Printf("Checking workflow status: pattern=%s, jsonOutput=%v, ref=%s, labelFilter=%s, repo=%s, health=%v", pattern, jsonOutput, ref, labelFilter, repoOverride, health)
 	if verbose && !jsonOutput {
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Checking status of workflow files"))
 		if pattern != "" {
@@ -199,6 +206,15 @@ func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string,
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Successfully fetched status for %d workflows", len(statuses))))
 	}
 
+	if health && len(statuses) > 0 {
+		if verbose && !jsonOutput {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Scoring workflow health from runs of the last %d days...", statusHealthDays)))
+		}
+		if err := addWorkflowHealth(statuses, repoOverride, verbose); err != nil {
+			return err
+		}
+	}
+
 	// Handle output
 	if jsonOutput {
 		// Output JSON
@@ -219,12 +235,29 @@ func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string,
 	// Render the table using struct-based rendering
 	fmt.Print(console.RenderStruct(statuses))
 
+	if health {
+		printWorkflowsNeedingAttention(statuses)
+	}
+
 	return nil
 }
 
 // Removed duplicate code - now everything goes through GetWorkflowStatuses
 
 // calculateTimeRemaining calculates and formats the time remaining until stop-time
+// formatArtifactRetention describes how long a workflow's artifacts are kept, e.g. "7d" or
+// "default" when uploads use the repository's retention setting
+func formatArtifactRetention(maxDays int, usesDefault bool, uploads int) string {
+	switch {
+	case uploads == 0:
+		return ""
+	case usesDefault:
+		return "default"
+	default:
+		return fmt.Sprintf("%dd", maxDays)
+	}
+}
+
 func calculateTimeRemaining(stopTimeStr string) string {
 	if stopTimeStr == "" {
 		return "N/A"
@@ -31,7 +31,7 @@ func TestStatusWorkflows_JSONOutput(t *testing.T) {
 
 	// Test JSON output without pattern
 	t.Run("JSON output without pattern", func(t *testing.T) {
-		err := StatusWorkflows("", false, true, "", "", "")
+		err := StatusWorkflows("", false, true, "", "", "", false)
 		if err != nil {
 			t.Errorf("StatusWorkflows with JSON flag failed: %v", err)
 		}
@@ -41,7 +41,7 @@ func TestStatusWorkflows_JSONOutput(t *testing.T) {
 
 	// Test JSON output with pattern
 	t.Run("JSON output with pattern", func(t *testing.T) {
-		err := StatusWorkflows("smoke", false, true, "", "", "")
+		err := StatusWorkflows("smoke", false, true, "", "", "", false)
 		if err != nil {
 			t.Errorf("StatusWorkflows with JSON flag and pattern failed: %v", err)
 		}
@@ -521,14 +521,36 @@ func TestWorkflowStatus_ConsoleRenderingWithRunStatus(t *testing.T) {
 func TestStatusWorkflows_WithRepoOverride(t *testing.T) {
 	// This test verifies that the function accepts the repoOverride parameter
 	// and doesn't error out. It should work in the current repository context.
-	err := StatusWorkflows("", false, true, "", "", "")
+	err := StatusWorkflows("", false, true, "", "", "", false)
 	if err != nil {
 		t.Errorf("StatusWorkflows with empty repoOverride should not error: %v", err)
 	}
 
 	// Test with a non-empty repo override (will fail gracefully if repo doesn't exist)
 	// We expect this to either succeed or fail gracefully without panicking
-	_ = StatusWorkflows("", false, true, "", "", "nonexistent/repo")
+	_ = StatusWorkflows("", false, true, "", "", "nonexistent/repo", false)
 	// Note: We don't check error here because it's expected to fail for a nonexistent repo
 	// The important part is that the parameter is accepted and used
 }
+
+func TestFormatArtifactRetention(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxDays     int
+		usesDefault bool
+		uploads     int
+		want        string
+	}{
+		{name: "no uploads", want: ""},
+		{name: "explicit retention", maxDays: 7, uploads: 3, want: "7d"},
+		{name: "some uploads use the repository default", maxDays: 1, usesDefault: true, uploads: 2, want: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatArtifactRetention(tt.maxDays, tt.usesDefault, tt.uploads); got != tt.want {
+				t.Errorf("formatArtifactRetention() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var statusHealthLog = logger.New("cli:status_health")
+
+// statusHealthDays is the period of recent runs `status --health` scores
+const statusHealthDays = 30
+
+// defaultHealthThresholds are used for every metric .aw/config.yml does not configure under health:
+var defaultHealthThresholds = workflow.HealthThresholds{
+	FailureRate:         20,
+	TimeoutRate:         10,
+	AvgCost:             1,
+	SanitizerRejections: 1,
+}
+
+// WorkflowHealthScore is the health of a workflow computed from its recent runs.
+// Each metric costs up to 25 points of the score of 100, in proportion to how close it is
+// to its threshold; a workflow needs attention when any metric exceeds its threshold.
+type WorkflowHealthScore struct {
+	Score               int      `json:"score"`
+	Runs                int      `json:"runs"`
+	FailureRate         float64  `json:"failure_rate"`                   // Percentage of completed runs that failed
+	TimeoutRate         float64  `json:"timeout_rate"`                   // Percentage of completed runs that timed out
+	AvgCost             *float64 `json:"avg_cost,omitempty"`             // Average cost in USD of the runs with downloaded logs
+	SanitizerRejections *float64 `json:"sanitizer_rejections,omitempty"` // Average rejected safe output items of the runs with downloaded logs
+	NeedsAttention      bool     `json:"needs_attention"`
+	Reasons             []string `json:"reasons,omitempty"` // Thresholds the workflow exceeds
+}
+
+// resolveHealthThresholds fills the thresholds the repository does not configure with the defaults
+func resolveHealthThresholds(configured *workflow.HealthThresholds) workflow.HealthThresholds {
+	thresholds := defaultHealthThresholds
+	if configured == nil {
+		return thresholds
+	}
+	if configured.FailureRate > 0 {
+		thresholds.FailureRate = configured.FailureRate
+	}
+	if configured.TimeoutRate > 0 {
+		thresholds.TimeoutRate = configured.TimeoutRate
+	}
+	if configured.AvgCost > 0 {
+		thresholds.AvgCost = configured.AvgCost
+	}
+	if configured.SanitizerRejections > 0 {
+		thresholds.SanitizerRejections = configured.SanitizerRejections
+	}
+	return thresholds
+}
+
+// runHealthDetails holds the metrics of a run that are only known from its downloaded logs
+type runHealthDetails struct {
+	cost       float64
+	timedOut   bool
+	rejections int
+}
+
+// loadRunHealthDetails reads the metrics of a run from the logs downloaded by `logs` or `audit`.
+// It returns false when the run has not been downloaded.
+func loadRunHealthDetails(logsDir string, runID int64) (runHealthDetails, bool) {
+	runDir := filepath.Join(logsDir, fmt.Sprintf("run-%d", runID))
+	summary, ok := loadRunSummary(runDir, false)
+	if !ok {
+		return runHealthDetails{}, false
+	}
+
+	details := runHealthDetails{cost: summary.Run.EstimatedCost}
+	for _, job := range summary.JobDetails {
+		if job.Conclusion == "timed_out" {
+			details.timedOut = true
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(runDir, constants.AgentOutputFilename)); err == nil {
+		var agentOutput struct {
+			Errors []string `json:"errors"`
+		}
+		if err := json.Unmarshal(content, &agentOutput); err == nil {
+			details.rejections = len(agentOutput.Errors)
+		}
+	}
+	return details, true
+}
+
+// CalculateWorkflowHealthScore scores a workflow from its recent runs. Cost and sanitizer
+// rejections come from the downloaded logs of the runs and are left out when none are available.
+func CalculateWorkflowHealthScore(runs []WorkflowRun, details map[int64]runHealthDetails, thresholds workflow.HealthThresholds) *WorkflowHealthScore {
+	health := &WorkflowHealthScore{}
+
+	var failures, timeouts, withDetails, rejections int
+	var cost float64
+	for _, run := range runs {
+		if run.Conclusion == "" {
+			// Still in progress
+			continue
+		}
+		health.Runs++
+
+		detail, hasDetails := details[run.DatabaseID]
+		switch {
+		case run.Conclusion == "timed_out" || detail.timedOut:
+			timeouts++
+			failures++
+		case run.Conclusion == "failure" || run.Conclusion == "startup_failure":
+			failures++
+		}
+		if hasDetails {
+			withDetails++
+			cost += detail.cost
+			rejections += detail.rejections
+		}
+	}
+
+	if health.Runs == 0 {
+		health.Score = 100
+		return health
+	}
+
+	health.FailureRate = float64(failures) / float64(health.Runs) * 100
+	health.TimeoutRate = float64(timeouts) / float64(health.Runs) * 100
+	if withDetails > 0 {
+		avgCost := cost / float64(withDetails)
+		avgRejections := float64(rejections) / float64(withDetails)
+		health.AvgCost = &avgCost
+		health.SanitizerRejections = &avgRejections
+	}
+
+	penalty := 0.0
+	check := func(value, threshold float64, reason string) {
+		penalty += 25 * math.Min(value/threshold, 1)
+		if value > threshold {
+			health.NeedsAttention = true
+			health.Reasons = append(health.Reasons, reason)
+		}
+	}
+	check(health.FailureRate, thresholds.FailureRate,
+		fmt.Sprintf("failure rate %.0f%% above %.0f%%", health.FailureRate, thresholds.FailureRate))
+	check(health.TimeoutRate, thresholds.TimeoutRate,
+		fmt.Sprintf("timeout rate %.0f%% above %.0f%%", health.TimeoutRate, thresholds.TimeoutRate))
+	if health.AvgCost != nil {
+		check(*health.AvgCost, thresholds.AvgCost,
+			fmt.Sprintf("average cost $%.3f above $%.3f", *health.AvgCost, thresholds.AvgCost))
+		check(*health.SanitizerRejections, thresholds.SanitizerRejections,
+			fmt.Sprintf("%.1f sanitizer rejections per run above %g", *health.SanitizerRejections, thresholds.SanitizerRejections))
+	}
+	health.Score = int(math.Round(100 - penalty))
+
+	statusHealthLog.Printf("Scored %d runs: score=%d, failureRate=%.1f, timeoutRate=%.1f, needsAttention=%v",
+		health.Runs, health.Score, health.FailureRate, health.TimeoutRate, health.NeedsAttention)
+	return health
+}
+
+// addWorkflowHealth scores every workflow in statuses from its runs of the last statusHealthDays days
+func addWorkflowHealth(statuses []WorkflowStatus, repoOverride string, verbose bool) error {
+	config, err := loadRepoConfig()
+	if err != nil {
+		return err
+	}
+	thresholds := resolveHealthThresholds(config.Health)
+
+	startDate := time.Now().AddDate(0, 0, -statusHealthDays).Format("2006-01-02")
+	runs, err := fetchWorkflowRuns("", startDate, repoOverride, verbose)
+	if err != nil {
+		return fmt.Errorf("failed to fetch workflow runs: %w", err)
+	}
+	statusHealthLog.Printf("Scoring %d workflows from %d runs since %s", len(statuses), len(runs), startDate)
+
+	// Runs are matched to workflows by lock file, since run names come from the name: field
+	runsByWorkflow := make(map[string][]WorkflowRun)
+	details := make(map[int64]runHealthDetails)
+	for _, run := range runs {
+		name := strings.TrimSuffix(filepath.Base(run.WorkflowPath), ".lock.yml")
+		runsByWorkflow[name] = append(runsByWorkflow[name], run)
+		if detail, ok := loadRunHealthDetails(defaultLogsOutputDir, run.DatabaseID); ok {
+			details[run.DatabaseID] = detail
+		}
+	}
+
+	for i := range statuses {
+		health := CalculateWorkflowHealthScore(runsByWorkflow[statuses[i].Workflow], details, thresholds)
+		statuses[i].HealthScore = health
+		statuses[i].Health = formatHealthScore(health)
+	}
+	return nil
+}
+
+// formatHealthScore renders a health score for the status table
+func formatHealthScore(health *WorkflowHealthScore) string {
+	if health.Runs == 0 {
+		return "-"
+	}
+	if health.NeedsAttention {
+		return "⚠ " + strconv.Itoa(health.Score)
+	}
+	return strconv.Itoa(health.Score)
+}
+
+// printWorkflowsNeedingAttention lists the workflows whose health exceeds a threshold, with the reasons
+func printWorkflowsNeedingAttention(statuses []WorkflowStatus) {
+	attention := 0
+	for _, status := range statuses {
+		if status.HealthScore == nil || !status.HealthScore.NeedsAttention {
+			continue
+		}
+		attention++
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("%s needs attention: %s", status.Workflow, strings.Join(status.HealthScore.Reasons, ", "))))
+	}
+	if attention == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("All workflows are within the health thresholds (last %d days)", statusHealthDays)))
+		return
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Thresholds can be configured under health: in %s", workflow.RepoConfigPath)))
+}
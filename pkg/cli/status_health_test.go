@@ -0,0 +1,101 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveHealthThresholds(t *testing.T) {
+	assert.Equal(t, defaultHealthThresholds, resolveHealthThresholds(nil), "missing config should use the defaults")
+
+	thresholds := resolveHealthThresholds(&workflow.HealthThresholds{FailureRate: 5, AvgCost: 0.25})
+	assert.InDelta(t, 5.0, thresholds.FailureRate, 0.001, "configured failure rate should be used")
+	assert.InDelta(t, 0.25, thresholds.AvgCost, 0.001, "configured cost should be used")
+	assert.InDelta(t, defaultHealthThresholds.TimeoutRate, thresholds.TimeoutRate, 0.001, "unset metrics should use the defaults")
+}
+
+func TestCalculateWorkflowHealthScore(t *testing.T) {
+	thresholds := defaultHealthThresholds
+
+	t.Run("healthy workflow", func(t *testing.T) {
+		runs := []WorkflowRun{{DatabaseID: 1, Conclusion: "success"}, {DatabaseID: 2, Conclusion: "success"}}
+		health := CalculateWorkflowHealthScore(runs, nil, thresholds)
+		assert.Equal(t, 100, health.Score, "successful runs should score 100")
+		assert.False(t, health.NeedsAttention, "healthy workflow should not need attention")
+		assert.Nil(t, health.AvgCost, "cost should be unknown without downloaded logs")
+		assert.Equal(t, "100", formatHealthScore(health), "score should be rendered")
+	})
+
+	t.Run("failing and timing out", func(t *testing.T) {
+		runs := []WorkflowRun{
+			{DatabaseID: 1, Conclusion: "success"},
+			{DatabaseID: 2, Conclusion: "failure"},
+			{DatabaseID: 3, Conclusion: "timed_out"},
+			{DatabaseID: 4, Conclusion: "success"},
+			{DatabaseID: 5, Conclusion: ""},
+		}
+		health := CalculateWorkflowHealthScore(runs, nil, thresholds)
+		assert.Equal(t, 4, health.Runs, "in-progress runs should not be scored")
+		assert.InDelta(t, 50.0, health.FailureRate, 0.001, "timeouts should count as failures")
+		assert.InDelta(t, 25.0, health.TimeoutRate, 0.001, "timeout rate should be computed")
+		assert.Equal(t, 50, health.Score, "both exceeded metrics should cost their full weight")
+		assert.True(t, health.NeedsAttention, "workflow should need attention")
+		assert.Equal(t, []string{"failure rate 50% above 20%", "timeout rate 25% above 10%"}, health.Reasons, "reasons should name the exceeded thresholds")
+		assert.Equal(t, "⚠ 50", formatHealthScore(health), "attention should be highlighted")
+	})
+
+	t.Run("cost and sanitizer rejections from downloaded logs", func(t *testing.T) {
+		runs := []WorkflowRun{{DatabaseID: 1, Conclusion: "success"}, {DatabaseID: 2, Conclusion: "success"}}
+		details := map[int64]runHealthDetails{
+			1: {cost: 3, rejections: 4},
+		}
+		health := CalculateWorkflowHealthScore(runs, details, thresholds)
+		require.NotNil(t, health.AvgCost, "cost should be known from the downloaded run")
+		assert.InDelta(t, 3.0, *health.AvgCost, 0.001, "cost should average the downloaded runs")
+		assert.InDelta(t, 4.0, *health.SanitizerRejections, 0.001, "rejections should average the downloaded runs")
+		assert.Equal(t, 50, health.Score, "exceeded cost and rejections should lower the score")
+		assert.Len(t, health.Reasons, 2, "both thresholds should be reported")
+	})
+
+	t.Run("no runs", func(t *testing.T) {
+		health := CalculateWorkflowHealthScore(nil, nil, thresholds)
+		assert.Equal(t, 0, health.Runs, "there should be no runs")
+		assert.Equal(t, "-", formatHealthScore(health), "workflows without runs should not be scored")
+	})
+}
+
+func TestLoadRunHealthDetails(t *testing.T) {
+	logsDir := testutil.TempDir(t, "status-health-test")
+	runDir := filepath.Join(logsDir, "run-42")
+	require.NoError(t, os.MkdirAll(runDir, 0755), "should create run directory")
+
+	_, ok := loadRunHealthDetails(logsDir, 42)
+	assert.False(t, ok, "runs without a summary should not have details")
+
+	summary := &RunSummary{
+		CLIVersion: GetVersion(),
+		RunID:      42,
+		Run:        WorkflowRun{DatabaseID: 42, EstimatedCost: 0.75},
+		JobDetails: []JobInfoWithDuration{{JobInfo: JobInfo{Name: "agent", Conclusion: "timed_out"}}},
+	}
+	require.NoError(t, saveRunSummary(runDir, summary, false), "should save run summary")
+	agentOutput, err := json.Marshal(map[string]any{"items": []any{}, "errors": []string{"Line 1: invalid", "Line 2: invalid"}})
+	require.NoError(t, err, "should marshal agent output")
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, constants.AgentOutputFilename), agentOutput, 0644), "should write agent output")
+
+	details, ok := loadRunHealthDetails(logsDir, 42)
+	require.True(t, ok, "downloaded run should have details")
+	assert.InDelta(t, 0.75, details.cost, 0.001, "cost should come from the run summary")
+	assert.True(t, details.timedOut, "timed out jobs should be detected")
+	assert.Equal(t, 2, details.rejections, "sanitizer rejections should come from agent_output.json")
+}
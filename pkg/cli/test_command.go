@@ -0,0 +1,462 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var testCommandLog = logger.New("cli:test_command")
+
+// workflowTestsDir is the directory, relative to the workflows directory, that holds one
+// fixture directory per workflow
+const workflowTestsDir = "tests"
+
+// goldenFileSuffix is appended to a fixture's base name to form its golden file name
+const goldenFileSuffix = ".golden"
+
+// WorkflowTestConfig holds configuration for the test command
+type WorkflowTestConfig struct {
+	Workflows   []string // Workflow IDs or paths; empty tests every workflow with fixtures
+	FixturesDir string   // Fixture directory override (only with a single workflow)
+	Update      bool     // Rewrite golden files instead of comparing against them
+	JSON        bool
+	Verbose     bool
+}
+
+// WorkflowTestFixture is a fixture file: the event that triggers the workflow plus the
+// context values the prompt and conditions may reference. A file without any of these keys
+// is treated as a raw event payload whose event name is the first dot-separated part of the
+// file name (e.g. issues.bug-report.json).
+type WorkflowTestFixture struct {
+	EventName  string         `json:"event_name"`
+	Payload    map[string]any `json:"payload"`
+	Actor      string         `json:"actor,omitempty"`
+	Repository string         `json:"repository,omitempty"`
+	Ref        string         `json:"ref,omitempty"`
+	Inputs     map[string]any `json:"inputs,omitempty"`
+	Needs      map[string]any `json:"needs,omitempty"`
+	Vars       map[string]any `json:"vars,omitempty"`
+	Expect     *struct {
+		Activated *bool `json:"activated,omitempty"`
+	} `json:"expect,omitempty"`
+}
+
+// WorkflowTestResult is the outcome of running one fixture
+type WorkflowTestResult struct {
+	Workflow  string `json:"workflow"`
+	Fixture   string `json:"fixture"`
+	Activated bool   `json:"activated"`
+	Reason    string `json:"reason,omitempty"`
+	Passed    bool   `json:"passed"`
+	Updated   bool   `json:"updated,omitempty"`
+	Failure   string `json:"failure,omitempty"`
+}
+
+// NewTestCommand creates the test command
+func NewTestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test [workflow]...",
+		Short: "Test workflow prompts and activation against fixture events without running an engine",
+		Long: `Render the prompt and compute the activation decision of agentic workflows for
+fixture event payloads, and compare the result with golden files. No AI engine is
+called and nothing is sent to GitHub, so prompt templates can be tested in CI like code.
+
+Fixtures are JSON files in .github/workflows/tests/<workflow>/. A fixture either holds
+a raw event payload (the event name is taken from the file name, e.g.
+issues.bug-report.json) or an object with event_name, payload, and optional actor,
+repository, ref, inputs, needs, vars, and expect.activated fields.
+
+Each fixture has a golden file next to it (<fixture>.golden) recording whether the
+workflow activates and the rendered prompt. Run with --update to create or refresh
+golden files after an intended change.
+
+Checks that need the GitHub API, such as team membership, are assumed to pass.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` test                          # Test every workflow that has fixtures
+  ` + string(constants.CLIExtensionPrefix) + ` test issue-triage             # Test one workflow
+  ` + string(constants.CLIExtensionPrefix) + ` test issue-triage --update    # Rewrite its golden files
+  ` + string(constants.CLIExtensionPrefix) + ` test issue-triage --fixtures ./fixtures --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fixturesDir, _ := cmd.Flags().GetString("fixtures")
+			update, _ := cmd.Flags().GetBool("update")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			return RunWorkflowTests(WorkflowTestConfig{
+				Workflows:   args,
+				FixturesDir: fixturesDir,
+				Update:      update,
+				JSON:        jsonOutput,
+				Verbose:     verbose,
+			})
+		},
+	}
+
+	cmd.Flags().String("fixtures", "", "Directory containing the fixture files (default: .github/workflows/tests/<workflow>)")
+	cmd.Flags().BoolP("update", "u", false, "Create or rewrite golden files from the current results")
+	addJSONFlag(cmd)
+	cmd.ValidArgsFunction = CompleteWorkflowNames
+
+	return cmd
+}
+
+// RunWorkflowTests runs every fixture of the selected workflows and reports the results.
+// It returns an error when any fixture fails.
+func RunWorkflowTests(config WorkflowTestConfig) error {
+	if config.FixturesDir != "" && len(config.Workflows) != 1 {
+		return errors.New("--fixtures requires exactly one workflow")
+	}
+
+	workflowPaths, err := resolveWorkflowsToTest(config.Workflows, config.Verbose)
+	if err != nil {
+		return err
+	}
+
+	var results []WorkflowTestResult
+	for _, markdownPath := range workflowPaths {
+		fixturesDir := config.FixturesDir
+		if fixturesDir == "" {
+			fixturesDir = filepath.Join(filepath.Dir(markdownPath), workflowTestsDir, normalizeWorkflowID(markdownPath))
+		}
+		workflowResults, err := runWorkflowFixtures(markdownPath, fixturesDir, config.Update, config.Verbose)
+		if err != nil {
+			return err
+		}
+		results = append(results, workflowResults...)
+	}
+
+	if config.JSON {
+		if results == nil {
+			results = []WorkflowTestResult{}
+		}
+		jsonBytes, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal test results: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+	} else {
+		renderWorkflowTestResults(results)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if !result.Passed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d fixture(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// resolveWorkflowsToTest returns the markdown paths of the workflows to test. Without explicit
+// workflows it returns every workflow that has a fixtures directory.
+func resolveWorkflowsToTest(workflows []string, verbose bool) ([]string, error) {
+	if len(workflows) > 0 {
+		paths := make([]string, 0, len(workflows))
+		for _, name := range workflows {
+			path, err := resolveWorkflowFile(name, verbose)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, path)
+		}
+		return paths, nil
+	}
+
+	mdFiles, err := getMarkdownWorkflowFiles("")
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, file := range mdFiles {
+		fixturesDir := filepath.Join(filepath.Dir(file), workflowTestsDir, normalizeWorkflowID(file))
+		if info, err := os.Stat(fixturesDir); err == nil && info.IsDir() {
+			paths = append(paths, file)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no workflow fixtures found; add JSON fixtures under %s/<workflow>/", filepath.Join(getWorkflowsDir(), workflowTestsDir))
+	}
+	return paths, nil
+}
+
+// runWorkflowFixtures compiles a workflow in memory and runs each of its fixtures
+func runWorkflowFixtures(markdownPath, fixturesDir string, update, verbose bool) ([]WorkflowTestResult, error) {
+	workflowID := normalizeWorkflowID(markdownPath)
+	fixtures, err := filepath.Glob(filepath.Join(fixturesDir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fixtures in %s: %w", fixturesDir, err)
+	}
+	if len(fixtures) == 0 {
+		return nil, fmt.Errorf("no JSON fixtures found in %s", fixturesDir)
+	}
+	sort.Strings(fixtures)
+	testCommandLog.Printf("Running %d fixture(s) for %s", len(fixtures), workflowID)
+
+	compiler := workflow.NewCompiler(
+		workflow.WithNoEmit(true),
+		workflow.WithSkipValidation(true),
+	)
+	compiler.SetQuiet(true)
+
+	content, err := os.ReadFile(markdownPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", markdownPath, err)
+	}
+	workflowData, err := compiler.ParseWorkflowString(string(content), markdownPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %s: %w", markdownPath, err)
+	}
+	lockYAML, err := compiler.CompileToYAML(workflowData, markdownPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %s: %w", markdownPath, err)
+	}
+
+	results := make([]WorkflowTestResult, 0, len(fixtures))
+	for _, fixturePath := range fixtures {
+		result := runWorkflowFixture(workflowData, markdownPath, lockYAML, fixturePath, update)
+		result.Workflow = workflowID
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Ran fixture %s: activated=%t", fixturePath, result.Activated)))
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// runWorkflowFixture evaluates one fixture and compares the outcome with its golden file
+func runWorkflowFixture(workflowData *workflow.WorkflowData, markdownPath, lockYAML, fixturePath string, update bool) WorkflowTestResult {
+	fixtureName := strings.TrimSuffix(filepath.Base(fixturePath), ".json")
+	result := WorkflowTestResult{Fixture: fixtureName}
+
+	fixture, err := loadWorkflowTestFixture(fixturePath)
+	if err != nil {
+		result.Failure = err.Error()
+		return result
+	}
+	contexts := buildFixtureContexts(fixture, workflowData)
+
+	decision, err := workflow.EvaluateActivation(lockYAML, contexts)
+	if err != nil {
+		result.Failure = err.Error()
+		return result
+	}
+	result.Activated = decision.Activated
+	result.Reason = decision.Reason
+
+	if fixture.Expect != nil && fixture.Expect.Activated != nil && *fixture.Expect.Activated != decision.Activated {
+		result.Failure = fmt.Sprintf("expected activated=%t, got activated=%t", *fixture.Expect.Activated, decision.Activated)
+		if decision.Reason != "" {
+			result.Failure += " (" + decision.Reason + ")"
+		}
+		return result
+	}
+
+	prompt := ""
+	if decision.Activated {
+		prompt, err = workflow.RenderPromptPreview(workflowData, markdownPath, contexts)
+		if err != nil {
+			result.Failure = err.Error()
+			return result
+		}
+	}
+	actual := formatGoldenOutput(decision, prompt)
+
+	goldenPath := strings.TrimSuffix(fixturePath, ".json") + goldenFileSuffix
+	if update {
+		if err := os.WriteFile(goldenPath, []byte(actual), 0644); err != nil {
+			result.Failure = fmt.Sprintf("failed to write %s: %v", goldenPath, err)
+			return result
+		}
+		result.Passed = true
+		result.Updated = true
+		return result
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			result.Failure = fmt.Sprintf("golden file %s does not exist; run with --update to create it", filepath.Base(goldenPath))
+		} else {
+			result.Failure = fmt.Sprintf("failed to read %s: %v", goldenPath, err)
+		}
+		return result
+	}
+	if mismatch := describeGoldenMismatch(string(expected), actual); mismatch != "" {
+		result.Failure = mismatch
+		return result
+	}
+
+	result.Passed = true
+	return result
+}
+
+// loadWorkflowTestFixture reads a fixture file, accepting both the fixture object and a raw
+// event payload
+func loadWorkflowTestFixture(path string) (*WorkflowTestFixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid fixture JSON: %w", err)
+	}
+
+	fixture := &WorkflowTestFixture{}
+	_, hasEventName := raw["event_name"]
+	_, hasPayload := raw["payload"]
+	if hasEventName || hasPayload {
+		if err := json.Unmarshal(data, fixture); err != nil {
+			return nil, fmt.Errorf("invalid fixture: %w", err)
+		}
+	} else {
+		fixture.EventName, _, _ = strings.Cut(filepath.Base(path), ".")
+		fixture.Payload = raw
+	}
+
+	if fixture.EventName == "" {
+		return nil, errors.New("fixture does not set event_name")
+	}
+	if fixture.Payload == nil {
+		fixture.Payload = map[string]any{}
+	}
+	return fixture, nil
+}
+
+// buildFixtureContexts builds the expression contexts a run triggered by the fixture would see
+func buildFixtureContexts(fixture *WorkflowTestFixture, workflowData *workflow.WorkflowData) map[string]any {
+	payload := fixture.Payload
+
+	actor := fixture.Actor
+	if actor == "" {
+		if sender, ok := payload["sender"].(map[string]any); ok {
+			actor, _ = sender["login"].(string)
+		}
+	}
+	repository := fixture.Repository
+	if repository == "" {
+		if repo, ok := payload["repository"].(map[string]any); ok {
+			repository, _ = repo["full_name"].(string)
+		}
+	}
+	owner, _, _ := strings.Cut(repository, "/")
+
+	inputs := fixture.Inputs
+	if inputs == nil {
+		inputs, _ = payload["inputs"].(map[string]any)
+	}
+	if inputs == nil {
+		inputs = map[string]any{}
+	}
+
+	github := map[string]any{
+		"event_name":       fixture.EventName,
+		"event":            payload,
+		"actor":            actor,
+		"triggering_actor": actor,
+		"repository":       repository,
+		"repository_owner": owner,
+		"ref":              fixture.Ref,
+		"workflow":         workflowData.Name,
+		"server_url":       "https://github.com",
+		"api_url":          "https://api.github.com",
+	}
+	if pr, ok := payload["pull_request"].(map[string]any); ok {
+		if head, ok := pr["head"].(map[string]any); ok {
+			github["head_ref"] = head["ref"]
+		}
+		if base, ok := pr["base"].(map[string]any); ok {
+			github["base_ref"] = base["ref"]
+		}
+	}
+
+	needs := fixture.Needs
+	if needs == nil {
+		needs = map[string]any{}
+	}
+	vars := fixture.Vars
+	if vars == nil {
+		vars = map[string]any{}
+	}
+
+	return map[string]any{
+		"github":  github,
+		"inputs":  inputs,
+		"needs":   needs,
+		"vars":    vars,
+		"env":     map[string]any{},
+		"secrets": map[string]any{},
+		"steps":   map[string]any{},
+	}
+}
+
+// formatGoldenOutput renders the golden file content for an activation decision and prompt
+func formatGoldenOutput(decision *workflow.ActivationDecision, prompt string) string {
+	if !decision.Activated {
+		return fmt.Sprintf("activated: false\nreason: %s\n", decision.Reason)
+	}
+	return "activated: true\n---\n" + prompt
+}
+
+// describeGoldenMismatch returns a description of the first difference between the golden
+// content and the actual output, or an empty string when they match
+func describeGoldenMismatch(expected, actual string) string {
+	if expected == actual {
+		return ""
+	}
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+	for i := 0; i < max(len(expectedLines), len(actualLines)); i++ {
+		var want, got string
+		if i < len(expectedLines) {
+			want = expectedLines[i]
+		}
+		if i < len(actualLines) {
+			got = actualLines[i]
+		}
+		if want != got || i >= len(expectedLines) || i >= len(actualLines) {
+			return fmt.Sprintf("output differs from golden file at line %d:\n  golden: %q\n  actual: %q", i+1, want, got)
+		}
+	}
+	return "output differs from golden file"
+}
+
+// renderWorkflowTestResults prints one line per fixture followed by a summary
+func renderWorkflowTestResults(results []WorkflowTestResult) {
+	passed := 0
+	for _, result := range results {
+		label := result.Workflow + "/" + result.Fixture
+		switch {
+		case result.Updated:
+			passed++
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Updated golden file for "+label))
+		case result.Passed:
+			passed++
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(label))
+		default:
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(label+": "+result.Failure))
+		}
+	}
+	summary := fmt.Sprintf("%d passed, %d failed", passed, len(results)-passed)
+	if passed == len(results) {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(summary))
+	} else {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(summary))
+	}
+}
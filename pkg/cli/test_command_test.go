@@ -0,0 +1,111 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCommandWorkflow = `---
+on:
+  issues:
+    types: [opened]
+engine: copilot
+permissions:
+  contents: read
+safe-outputs:
+  add-comment:
+---
+
+# Triage
+
+Triage issue #${{ github.event.issue.number }} in ${{ github.repository }}.
+`
+
+// setupWorkflowTestRepo creates a repository with one workflow and its fixtures directory
+func setupWorkflowTestRepo(t *testing.T, fixtures map[string]string) string {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "test-command")
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	fixturesDir := filepath.Join(workflowsDir, "tests", "triage")
+	require.NoError(t, os.MkdirAll(fixturesDir, 0755), "should create fixtures dir")
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "triage.md"), []byte(testCommandWorkflow), 0644), "should write workflow")
+	for name, content := range fixtures {
+		require.NoError(t, os.WriteFile(filepath.Join(fixturesDir, name), []byte(content), 0644), "should write fixture")
+	}
+	t.Chdir(tmpDir)
+	return fixturesDir
+}
+
+func TestRunWorkflowTestsGoldenFlow(t *testing.T) {
+	fixturesDir := setupWorkflowTestRepo(t, map[string]string{
+		"issues.opened.json": `{"action":"opened","issue":{"number":42},"repository":{"full_name":"octo/repo"}}`,
+		"closed.json":        `{"event_name":"issues","payload":{"action":"closed"},"expect":{"activated":false}}`,
+	})
+
+	err := RunWorkflowTests(WorkflowTestConfig{})
+	require.Error(t, err, "missing golden files should fail")
+	assert.Contains(t, err.Error(), "2 of 2 fixture(s) failed", "both fixtures should fail")
+
+	require.NoError(t, RunWorkflowTests(WorkflowTestConfig{Update: true}), "update should write golden files")
+
+	golden, err := os.ReadFile(filepath.Join(fixturesDir, "issues.opened.golden"))
+	require.NoError(t, err, "golden file should exist")
+	assert.Equal(t, "activated: true\n---\n# Triage\n\nTriage issue #42 in octo/repo.\n", string(golden), "golden should hold the rendered prompt")
+
+	golden, err = os.ReadFile(filepath.Join(fixturesDir, "closed.golden"))
+	require.NoError(t, err, "golden file should exist")
+	assert.Equal(t, "activated: false\nreason: issues activity type 'closed' is not in types [opened]\n", string(golden), "golden should hold the skip reason")
+
+	require.NoError(t, RunWorkflowTests(WorkflowTestConfig{Workflows: []string{"triage"}}), "fixtures should match their golden files")
+
+	require.NoError(t, os.WriteFile(filepath.Join(fixturesDir, "issues.opened.json"), []byte(`{"action":"opened","issue":{"number":7},"repository":{"full_name":"octo/repo"}}`), 0644), "should rewrite fixture")
+	err = RunWorkflowTests(WorkflowTestConfig{Workflows: []string{"triage"}})
+	require.Error(t, err, "changed prompt should fail")
+	assert.Contains(t, err.Error(), "1 of 2 fixture(s) failed", "only the changed fixture should fail")
+}
+
+func TestRunWorkflowTestsExpectation(t *testing.T) {
+	setupWorkflowTestRepo(t, map[string]string{
+		"wrong.json": `{"event_name":"issues","payload":{"action":"closed"},"expect":{"activated":true}}`,
+	})
+
+	err := RunWorkflowTests(WorkflowTestConfig{Update: true})
+	require.Error(t, err, "a failed expectation should fail even with --update")
+}
+
+func TestLoadWorkflowTestFixture(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-command-fixture")
+
+	rawPath := filepath.Join(tmpDir, "pull_request.opened.json")
+	require.NoError(t, os.WriteFile(rawPath, []byte(`{"action":"opened","number":3}`), 0644), "should write fixture")
+	fixture, err := loadWorkflowTestFixture(rawPath)
+	require.NoError(t, err, "raw payload should load")
+	assert.Equal(t, "pull_request", fixture.EventName, "event name should come from the file name")
+	assert.Equal(t, "opened", fixture.Payload["action"], "payload should be the file content")
+
+	envelopePath := filepath.Join(tmpDir, "dispatch.json")
+	require.NoError(t, os.WriteFile(envelopePath, []byte(`{"event_name":"workflow_dispatch","inputs":{"topic":"ci"},"actor":"octocat"}`), 0644), "should write fixture")
+	fixture, err = loadWorkflowTestFixture(envelopePath)
+	require.NoError(t, err, "fixture object should load")
+	assert.Equal(t, "workflow_dispatch", fixture.EventName, "event name should be read from the fixture")
+	assert.Equal(t, "ci", fixture.Inputs["topic"], "inputs should be read from the fixture")
+	assert.NotNil(t, fixture.Payload, "payload should default to an empty object")
+
+	badPath := filepath.Join(tmpDir, "bad.json")
+	require.NoError(t, os.WriteFile(badPath, []byte(`{"event_name":`), 0644), "should write fixture")
+	_, err = loadWorkflowTestFixture(badPath)
+	require.Error(t, err, "invalid JSON should fail")
+}
+
+func TestRunWorkflowTestsFixturesRequiresOneWorkflow(t *testing.T) {
+	err := RunWorkflowTests(WorkflowTestConfig{FixturesDir: "fixtures"})
+	require.Error(t, err, "--fixtures without a workflow should fail")
+	assert.Contains(t, err.Error(), "exactly one workflow", "error should explain the restriction")
+}
@@ -0,0 +1,159 @@
+// This file provides the engine-independent agent transcript for the logs and audit commands.
+//
+// The log parser step of the agent job normalizes the engine log into transcript.json
+// (messages, tool calls, token usage and timings in one shape for every engine) and uploads
+// it with the agent artifacts. When a run has a transcript, its metrics are read from it
+// instead of from the engine-specific log, so every engine is reported the same way.
+// Runs without a transcript (older runs, engines without a log parser) fall back to the
+// engine log parsers.
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var transcriptLog = logger.New("cli:transcript")
+
+// transcriptVersion is the transcript format version this build understands
+const transcriptVersion = 1
+
+// Transcript is the engine-independent agent transcript (transcript.json)
+type Transcript struct {
+	Version    int                  `json:"version"`
+	Engine     string               `json:"engine"`
+	Model      string               `json:"model,omitempty"`
+	Messages   []TranscriptMessage  `json:"messages"`
+	ToolCalls  []TranscriptToolCall `json:"tool_calls"`
+	Tokens     TranscriptTokens     `json:"tokens"`
+	CostUSD    float64              `json:"cost_usd"`
+	Turns      int                  `json:"turns"`
+	DurationMS *int64               `json:"duration_ms,omitempty"`
+}
+
+// TranscriptMessage is a text message of the transcript
+type TranscriptMessage struct {
+	Role string `json:"role"`
+	Text string `json:"text"`
+}
+
+// TranscriptToolCall is a tool call of the transcript with its (truncated) output
+type TranscriptToolCall struct {
+	ID         string          `json:"id"`
+	Name       string          `json:"name"`
+	Input      json.RawMessage `json:"input,omitempty"`
+	Output     string          `json:"output"`
+	IsError    bool            `json:"is_error"`
+	DurationMS *int64          `json:"duration_ms,omitempty"`
+}
+
+// TranscriptTokens is the token usage of the transcript
+type TranscriptTokens struct {
+	Input      int `json:"input"`
+	Output     int `json:"output"`
+	CacheRead  int `json:"cache_read"`
+	CacheWrite int `json:"cache_write"`
+	Total      int `json:"total"`
+}
+
+// findTranscriptFile searches the run directory for transcript.json (artifacts may be nested)
+func findTranscriptFile(logDir string) (string, bool) {
+	var foundPath string
+	_ = filepath.Walk(logDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info == nil {
+			return nil
+		}
+		if !info.IsDir() && info.Name() == constants.TranscriptFilename {
+			foundPath = path
+			return errors.New("stop") // sentinel to stop walking early
+		}
+		return nil
+	})
+	return foundPath, foundPath != ""
+}
+
+// loadTranscript reads and validates the transcript of a run directory
+func loadTranscript(logDir string) (*Transcript, error) {
+	path, found := findTranscriptFile(logDir)
+	if !found {
+		return nil, os.ErrNotExist
+	}
+	transcriptLog.Printf("Loading transcript: %s", path)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var transcript Transcript
+	if err := json.Unmarshal(content, &transcript); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if transcript.Version != transcriptVersion {
+		return nil, fmt.Errorf("unsupported transcript version %d in %s (expected %d)", transcript.Version, path, transcriptVersion)
+	}
+	return &transcript, nil
+}
+
+// Metrics converts the transcript to the log metrics reported by the logs and audit commands
+func (t *Transcript) Metrics() workflow.LogMetrics {
+	var metrics workflow.LogMetrics
+	metrics.TokenUsage = t.Tokens.Total
+	metrics.EstimatedCost = t.CostUSD
+	metrics.Turns = t.Turns
+
+	toolCallMap := make(map[string]*workflow.ToolCallInfo)
+	var sequence []string
+	for _, call := range t.ToolCalls {
+		name := transcriptToolName(call.Name)
+		sequence = append(sequence, name)
+
+		// Estimate token count (rough approximation: 1 token = ~4 characters)
+		inputSize := len(call.Input) / 4
+		outputSize := len(call.Output) / 4
+		var duration time.Duration
+		if call.DurationMS != nil {
+			duration = time.Duration(*call.DurationMS) * time.Millisecond
+		}
+
+		info, exists := toolCallMap[name]
+		if !exists {
+			info = &workflow.ToolCallInfo{Name: name}
+			toolCallMap[name] = info
+		}
+		info.CallCount++
+		info.MaxInputSize = max(info.MaxInputSize, inputSize)
+		info.MaxOutputSize = max(info.MaxOutputSize, outputSize)
+		info.MaxDuration = max(info.MaxDuration, duration)
+	}
+	if len(sequence) > 0 {
+		metrics.ToolSequences = [][]string{sequence}
+	}
+
+	for _, info := range toolCallMap {
+		metrics.ToolCalls = append(metrics.ToolCalls, *info)
+	}
+	sort.Slice(metrics.ToolCalls, func(i, j int) bool {
+		return metrics.ToolCalls[i].Name < metrics.ToolCalls[j].Name
+	})
+	return metrics
+}
+
+// transcriptToolName converts a transcript tool name to the name used by the engine log
+// parsers (see workflow.PrettifyToolName), so runs with and without a transcript compare
+func transcriptToolName(name string) string {
+	if server, tool, isMCP := strings.Cut(name, "::"); isMCP {
+		return server + "_" + tool
+	}
+	return workflow.PrettifyToolName(name)
+}
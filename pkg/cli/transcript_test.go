@@ -0,0 +1,83 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTranscript = `{
+  "version": 1,
+  "engine": "codex",
+  "model": "gpt-5",
+  "messages": [{ "role": "assistant", "text": "Done." }],
+  "tool_calls": [
+    { "id": "a", "name": "github::list_issues", "input": { "state": "open" }, "output": "[]", "is_error": false, "duration_ms": 1200 },
+    { "id": "b", "name": "Bash", "input": { "command": "ls" }, "output": "README.md", "is_error": false, "duration_ms": null },
+    { "id": "c", "name": "github::list_issues", "input": {}, "output": "[]", "is_error": true, "duration_ms": 3000 }
+  ],
+  "tokens": { "input": 100, "output": 20, "cache_read": 50, "cache_write": 5, "total": 175 },
+  "cost_usd": 0.12,
+  "turns": 3,
+  "duration_ms": 45000
+}`
+
+func TestTranscriptMetrics(t *testing.T) {
+	logDir := testutil.TempDir(t, "test-transcript-*")
+	nested := filepath.Join(logDir, "agent-artifacts")
+	require.NoError(t, os.MkdirAll(nested, 0755), "should create artifact directory")
+	require.NoError(t, os.WriteFile(filepath.Join(nested, "transcript.json"), []byte(testTranscript), 0644), "should write transcript")
+
+	transcript, err := loadTranscript(logDir)
+	require.NoError(t, err, "nested transcript should be found")
+	assert.Equal(t, "codex", transcript.Engine, "engine should be read")
+	assert.Len(t, transcript.Messages, 1, "messages should be read")
+
+	metrics := transcript.Metrics()
+	assert.Equal(t, 175, metrics.TokenUsage, "token usage should be the total")
+	assert.InDelta(t, 0.12, metrics.EstimatedCost, 0.0001, "cost should be read")
+	assert.Equal(t, 3, metrics.Turns, "turns should be read")
+	assert.Equal(t, [][]string{{"github_list_issues", "bash", "github_list_issues"}}, metrics.ToolSequences, "sequence should keep the call order")
+	require.Len(t, metrics.ToolCalls, 2, "calls should be grouped by tool")
+	assert.Equal(t, "bash", metrics.ToolCalls[0].Name, "tool calls should be sorted by name")
+	assert.Equal(t, "github_list_issues", metrics.ToolCalls[1].Name, "MCP tool names should match the engine parsers")
+	assert.Equal(t, 2, metrics.ToolCalls[1].CallCount, "calls should be counted")
+	assert.Equal(t, 3*time.Second, metrics.ToolCalls[1].MaxDuration, "maximum duration should be kept")
+}
+
+func TestExtractLogMetricsPrefersTranscript(t *testing.T) {
+	logDir := testutil.TempDir(t, "test-transcript-*")
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "aw_info.json"), []byte(`{"engine_id": "claude"}`), 0644), "should write aw_info.json")
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "agent-stdio.log"),
+		[]byte(`[{"type":"result","num_turns":9,"total_cost_usd":5,"usage":{"input_tokens":9999,"output_tokens":1}}]`), 0644), "should write engine log")
+
+	metrics, err := extractLogMetrics(logDir, false)
+	require.NoError(t, err, "engine log metrics should be extracted")
+	assert.Equal(t, 9, metrics.Turns, "engine log should be used without a transcript")
+
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "transcript.json"), []byte(testTranscript), 0644), "should write transcript")
+	metrics, err = extractLogMetrics(logDir, false)
+	require.NoError(t, err, "transcript metrics should be extracted")
+	assert.Equal(t, 3, metrics.Turns, "transcript should take precedence")
+	assert.Equal(t, 175, metrics.TokenUsage, "token usage should come from the transcript")
+}
+
+func TestLoadTranscriptRejectsUnknownVersion(t *testing.T) {
+	logDir := testutil.TempDir(t, "test-transcript-*")
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "transcript.json"), []byte(`{"version": 2}`), 0644), "should write transcript")
+
+	_, err := loadTranscript(logDir)
+	require.Error(t, err, "unknown version should be rejected")
+	assert.Contains(t, err.Error(), "unsupported transcript version 2", "error should name the version")
+
+	metrics, err := extractLogMetrics(logDir, false)
+	require.NoError(t, err, "metrics should fall back to engine logs")
+	assert.Zero(t, metrics.Turns, "no engine logs means no turns")
+}
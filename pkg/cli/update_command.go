@@ -7,6 +7,7 @@ import (
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 	"github.com/spf13/cobra"
 )
 
@@ -32,6 +33,10 @@ For workflow updates, it fetches the latest version based on the current ref:
 - If the ref is a branch, it fetches the latest commit from that branch
 - If the ref is a commit SHA, it fetches the latest commit from the default branch
 
+When the lock policy of remote-imports is enabled in .aw/config.yml, update recompiles
+all workflows and records the current content of their remote imports in .aw/imports.lock.
+This is the only way to accept remote import content that changed since it was recorded.
+
 For extension updates, action updates, agent files, and codemods, use 'gh aw upgrade'.
 
 ` + WorkflowIDExplanation + `
@@ -114,6 +119,15 @@ func RunUpdateWorkflows(workflowNames []string, allowMajor, force, verbose bool,
 
 	var firstErr error
 
+	// Remote import content fetched during the update replaces the hashes in .aw/imports.lock
+	repoConfig, err := loadRepoConfig()
+	if err != nil {
+		return err
+	}
+	setupRemoteImportPolicy(repoConfig)
+	parser.SetAcceptRemoteImportChanges(true)
+	defer parser.SetAcceptRemoteImportChanges(false)
+
 	if err := UpdateWorkflows(workflowNames, allowMajor, force, verbose, engineOverride, workflowsDir, noStopAfter, stopAfter, noMerge, noCompile); err != nil {
 		firstErr = fmt.Errorf("workflow update failed: %w", err)
 	}
@@ -133,5 +147,11 @@ func RunUpdateWorkflows(workflowNames []string, allowMajor, force, verbose bool,
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: Failed to update action references in workflow files: %v", err)))
 	}
 
+	if repoConfig.RemoteImportPolicyEnabled("lock") && !noCompile {
+		if err := refreshImportsLock(workflowsDir, engineOverride, verbose); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
 	return firstErr
 }
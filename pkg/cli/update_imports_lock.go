@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+// refreshImportsLock recompiles every workflow so that the current content of their remote
+// imports is recorded in .aw/imports.lock. It runs while gh aw update accepts remote import
+// changes, covering workflows without a source field that the update did not recompile.
+func refreshImportsLock(workflowsDir, engineOverride string, verbose bool) error {
+	mdFiles, err := getMarkdownWorkflowFiles(workflowsDir)
+	if err != nil {
+		return err
+	}
+	updateLog.Printf("Refreshing %s for %d workflows", parser.ImportLockPath, len(mdFiles))
+
+	failed := 0
+	for _, file := range mdFiles {
+		if err := compileWorkflowWithRefresh(file, verbose, true, engineOverride, false); err != nil {
+			failed++
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to recompile %s: %v", file, err)))
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d workflow(s) could not be recompiled; their remote imports were not recorded in %s", failed, parser.ImportLockPath)
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Recorded remote import content in "+parser.ImportLockPath))
+	return nil
+}
@@ -0,0 +1,329 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var upgradeActionsLog = logger.New("cli:upgrade_actions_command")
+
+// UpgradeActionsConfig contains configuration for the upgrade-actions command
+type UpgradeActionsConfig struct {
+	Verbose     bool
+	WorkflowDir string
+	AllowMajor  bool
+	Check       bool
+	NoCompile   bool
+	JSON        bool
+}
+
+// ActionPinUpdate describes a pinned action with a newer release available
+type ActionPinUpdate struct {
+	Repo           string `json:"repo"`
+	CurrentVersion string `json:"current_version"`
+	CurrentSHA     string `json:"current_sha"`
+	LatestVersion  string `json:"latest_version"`
+	LatestSHA      string `json:"latest_sha"`
+}
+
+// NewUpgradeActionsCommand creates the upgrade-actions command
+func NewUpgradeActionsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade-actions",
+		Short: "Refresh the pinned action SHAs used in lock files and recompile workflows",
+		Long: `Refresh the action pins used by the compiler without waiting for a new gh-aw release.
+
+For every action the compiler pins (actions/checkout, actions/github-script, ...), this command
+looks up the latest release tag and its commit SHA. Newer pins are written to
+` + workflow.ActionPinOverridesFile + `, which takes precedence over the pins embedded in gh-aw,
+and all workflows are recompiled so lock files reference the new SHAs.
+
+By default updates stay within the pinned major version, so only compatible releases (including
+security fixes) are picked up. Use --major to allow major version upgrades.
+
+Use --check in CI to fail when pinned actions are outdated without modifying any files.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` upgrade-actions                        # Refresh pins and recompile all workflows
+  ` + string(constants.CLIExtensionPrefix) + ` upgrade-actions --check                # Exit with an error if any pin is outdated
+  ` + string(constants.CLIExtensionPrefix) + ` upgrade-actions --major                # Also allow major version upgrades
+  ` + string(constants.CLIExtensionPrefix) + ` upgrade-actions --no-compile           # Update the pins without recompiling
+  ` + string(constants.CLIExtensionPrefix) + ` upgrade-actions --create-pull-request  # Refresh pins and open a pull request`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			dir, _ := cmd.Flags().GetString("dir")
+			allowMajor, _ := cmd.Flags().GetBool("major")
+			check, _ := cmd.Flags().GetBool("check")
+			noCompile, _ := cmd.Flags().GetBool("no-compile")
+			createPRFlag, _ := cmd.Flags().GetBool("create-pull-request")
+			prFlagAlias, _ := cmd.Flags().GetBool("pr")
+			createPR := createPRFlag || prFlagAlias
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+
+			if check && createPR {
+				return errors.New("--check cannot be combined with --create-pull-request")
+			}
+
+			if createPR {
+				if err := PreflightCheckForCreatePR(verbose); err != nil {
+					return err
+				}
+			}
+
+			updates, err := RunUpgradeActions(UpgradeActionsConfig{
+				Verbose:     verbose,
+				WorkflowDir: dir,
+				AllowMajor:  allowMajor,
+				Check:       check,
+				NoCompile:   noCompile,
+				JSON:        jsonOutput,
+			})
+			if err != nil {
+				return err
+			}
+
+			if createPR && len(updates) > 0 {
+				prBody := "This PR refreshes the pinned GitHub Actions SHAs in " + workflow.ActionPinOverridesFile +
+					" to their latest releases and recompiles all workflows."
+				_, err := CreatePRWithChanges("upgrade-action-pins", "chore: upgrade pinned actions",
+					"Upgrade pinned actions", prBody, verbose)
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("dir", "d", "", "Workflow directory (default: .github/workflows)")
+	cmd.Flags().Bool("major", false, "Allow major version upgrades")
+	cmd.Flags().Bool("check", false, "Report outdated pins and exit with an error instead of updating them")
+	cmd.Flags().Bool("no-compile", false, "Skip recompiling workflows (do not modify lock files)")
+	cmd.Flags().Bool("create-pull-request", false, "Create a pull request with the updated pins")
+	cmd.Flags().Bool("pr", false, "Alias for --create-pull-request")
+	_ = cmd.Flags().MarkHidden("pr") // Hide the short alias from help output
+	addJSONFlag(cmd)
+
+	// Register completions
+	RegisterDirFlagCompletion(cmd, "dir")
+
+	return cmd
+}
+
+// RunUpgradeActions checks the compiler's action pins against the latest releases, writes newer
+// pins to the repository overrides file and recompiles workflows. It returns the updates found.
+// In check mode nothing is written and outdated pins are reported as an error.
+func RunUpgradeActions(config UpgradeActionsConfig) ([]ActionPinUpdate, error) {
+	upgradeActionsLog.Printf("Running upgrade-actions: check=%v, allowMajor=%v, noCompile=%v", config.Check, config.AllowMajor, config.NoCompile)
+
+	repoRoot, err := findGitRoot()
+	if err != nil {
+		repoRoot = "."
+	}
+	overridesPath := filepath.Join(repoRoot, workflow.ActionPinOverridesFile)
+
+	overrides, err := readActionPinOverrides(overridesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.JSON {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Checking pinned GitHub Actions for new releases..."))
+	}
+	updates, failed := findActionPinUpdates(currentActionPins(overrides), config.AllowMajor, config.Verbose)
+
+	if config.JSON {
+		data, err := json.MarshalIndent(updates, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal action pin updates: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		renderActionPinUpdates(updates, failed, config.Check)
+	}
+
+	if config.Check {
+		if len(updates) > 0 {
+			return updates, fmt.Errorf("%d pinned action(s) are outdated; run '%s upgrade-actions' to update them", len(updates), constants.CLIExtensionPrefix)
+		}
+		return updates, nil
+	}
+
+	if len(updates) == 0 {
+		return updates, nil
+	}
+
+	applyActionPinUpdates(overrides, updates)
+	if err := writeActionPinOverrides(overridesPath, overrides); err != nil {
+		return nil, err
+	}
+	if !config.JSON {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Updated "+workflow.ActionPinOverridesFile))
+	}
+
+	if !config.NoCompile {
+		if !config.JSON {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Compiling all workflows..."))
+		}
+		recompileAllWorkflows(config.WorkflowDir, config.Verbose)
+	}
+
+	return updates, nil
+}
+
+// readActionPinOverrides loads the overrides file, returning an empty table when it does not exist
+func readActionPinOverrides(path string) (*actionsLockFile, error) {
+	overrides := &actionsLockFile{Entries: make(map[string]actionsLockEntry)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return overrides, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read action pin overrides: %w", err)
+	}
+	if err := json.Unmarshal(data, overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse action pin overrides: %w", err)
+	}
+	if overrides.Entries == nil {
+		overrides.Entries = make(map[string]actionsLockEntry)
+	}
+	return overrides, nil
+}
+
+// writeActionPinOverrides writes the overrides file with entries sorted by key
+func writeActionPinOverrides(path string, overrides *actionsLockFile) error {
+	data, err := marshalActionsLockSorted(overrides)
+	if err != nil {
+		return fmt.Errorf("failed to marshal action pin overrides: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for action pin overrides: %w", err)
+	}
+	data = append(data, '\n')
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write action pin overrides: %w", err)
+	}
+	return nil
+}
+
+// currentActionPins returns the pin the compiler currently uses for each action repository,
+// i.e. the latest version among the embedded pins and the overrides, sorted by repository
+func currentActionPins(overrides *actionsLockFile) []workflow.ActionPin {
+	pins := make([]workflow.ActionPin, 0, len(overrides.Entries))
+	for _, entry := range overrides.Entries {
+		pins = append(pins, workflow.ActionPin{Repo: entry.Repo, Version: entry.Version, SHA: entry.SHA})
+	}
+	workflow.SetActionPinOverrides(pins)
+
+	repos := make(map[string]bool)
+	for _, pin := range workflow.GetEmbeddedActionPins() {
+		repos[pin.Repo] = true
+	}
+	for _, pin := range pins {
+		repos[pin.Repo] = true
+	}
+
+	current := make([]workflow.ActionPin, 0, len(repos))
+	for repo := range repos {
+		if pin, ok := workflow.GetActionPinByRepo(repo); ok {
+			current = append(current, pin)
+		}
+	}
+	sort.Slice(current, func(i, j int) bool { return current[i].Repo < current[j].Repo })
+	return current
+}
+
+// findActionPinUpdates looks up the latest release of each pinned action and returns the pins
+// whose version or SHA differs, along with the repositories that could not be checked
+func findActionPinUpdates(pins []workflow.ActionPin, allowMajor, verbose bool) ([]ActionPinUpdate, []string) {
+	var updates []ActionPinUpdate
+	var failed []string
+
+	for _, pin := range pins {
+		latestVersion, latestSHA, err := getLatestActionReleaseFn(pin.Repo, pin.Version, allowMajor, verbose)
+		if err != nil {
+			upgradeActionsLog.Printf("Failed to check %s: %v", pin.Repo, err)
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to check %s: %v", pin.Repo, err)))
+			}
+			failed = append(failed, pin.Repo)
+			continue
+		}
+		if latestVersion == pin.Version && latestSHA == pin.SHA {
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("%s@%s is up to date", pin.Repo, pin.Version)))
+			}
+			continue
+		}
+		upgradeActionsLog.Printf("Update available for %s: %s -> %s", pin.Repo, pin.Version, latestVersion)
+		updates = append(updates, ActionPinUpdate{
+			Repo:           pin.Repo,
+			CurrentVersion: pin.Version,
+			CurrentSHA:     pin.SHA,
+			LatestVersion:  latestVersion,
+			LatestSHA:      latestSHA,
+		})
+	}
+
+	return updates, failed
+}
+
+// applyActionPinUpdates records each update in the overrides, replacing any older override for
+// the same repository
+func applyActionPinUpdates(overrides *actionsLockFile, updates []ActionPinUpdate) {
+	for _, update := range updates {
+		for key, entry := range overrides.Entries {
+			if entry.Repo == update.Repo {
+				delete(overrides.Entries, key)
+			}
+		}
+		overrides.Entries[update.Repo+"@"+update.LatestVersion] = actionsLockEntry{
+			Repo:    update.Repo,
+			Version: update.LatestVersion,
+			SHA:     update.LatestSHA,
+		}
+	}
+}
+
+// renderActionPinUpdates prints the updates found and the actions that could not be checked
+func renderActionPinUpdates(updates []ActionPinUpdate, failed []string, check bool) {
+	fmt.Fprintln(os.Stderr, "")
+	if len(updates) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("All pinned actions are up to date"))
+	} else {
+		verb := "Updating"
+		if check {
+			verb = "Outdated"
+		}
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("%s %d pinned action(s):", verb, len(updates))))
+		for _, update := range updates {
+			fmt.Fprintln(os.Stderr, console.FormatListItem(fmt.Sprintf("%s %s (%s) → %s (%s)",
+				update.Repo, update.CurrentVersion, shortSHA(update.CurrentSHA), update.LatestVersion, shortSHA(update.LatestSHA))))
+		}
+	}
+
+	if len(failed) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to check %d action(s):", len(failed))))
+		for _, repo := range failed {
+			fmt.Fprintln(os.Stderr, console.FormatListItem(repo))
+		}
+	}
+	fmt.Fprintln(os.Stderr, "")
+}
+
+// shortSHA returns the first 7 characters of a commit SHA
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
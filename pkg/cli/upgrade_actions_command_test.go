@@ -0,0 +1,98 @@
+//go:build !integration
+
+package cli
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindActionPinUpdates(t *testing.T) {
+	orig := getLatestActionReleaseFn
+	defer func() { getLatestActionReleaseFn = orig }()
+
+	var allowMajorSeen []bool
+	getLatestActionReleaseFn = func(repo, currentVersion string, allowMajor, verbose bool) (string, string, error) {
+		allowMajorSeen = append(allowMajorSeen, allowMajor)
+		switch repo {
+		case "actions/checkout":
+			return "v6.0.3", "1111111111111111111111111111111111111111", nil
+		case "actions/github-script":
+			// Tag moved to a new commit without a new version
+			return "v8", "2222222222222222222222222222222222222222", nil
+		case "actions/setup-go":
+			return currentVersion, "3333333333333333333333333333333333333333", nil
+		default:
+			return "", "", errors.New("not found")
+		}
+	}
+
+	pins := []workflow.ActionPin{
+		{Repo: "actions/checkout", Version: "v6.0.2", SHA: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+		{Repo: "actions/github-script", Version: "v8", SHA: "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"},
+		{Repo: "actions/setup-go", Version: "v6.3.0", SHA: "3333333333333333333333333333333333333333"},
+		{Repo: "example/missing", Version: "v1", SHA: "cccccccccccccccccccccccccccccccccccccccc"},
+	}
+
+	updates, failed := findActionPinUpdates(pins, false, false)
+	require.Len(t, updates, 2, "checkout and github-script should have updates")
+	assert.Equal(t, ActionPinUpdate{
+		Repo:           "actions/checkout",
+		CurrentVersion: "v6.0.2",
+		CurrentSHA:     "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		LatestVersion:  "v6.0.3",
+		LatestSHA:      "1111111111111111111111111111111111111111",
+	}, updates[0], "newer release should be reported")
+	assert.Equal(t, "v8", updates[1].LatestVersion, "moved tag should keep its version")
+	assert.Equal(t, "2222222222222222222222222222222222222222", updates[1].LatestSHA, "moved tag should report the new SHA")
+	assert.Equal(t, []string{"example/missing"}, failed, "failed lookups should be reported")
+	assert.NotContains(t, allowMajorSeen, true, "major upgrades should not be allowed by default")
+}
+
+func TestActionPinOverridesRoundTrip(t *testing.T) {
+	path := filepath.Join(testutil.TempDir(t, "upgrade-actions-test"), ".github", "aw", "action-pins.json")
+
+	overrides, err := readActionPinOverrides(path)
+	require.NoError(t, err, "missing overrides file should not be an error")
+	assert.Empty(t, overrides.Entries, "missing overrides file should be empty")
+
+	overrides.Entries["actions/checkout@v6.0.2"] = actionsLockEntry{Repo: "actions/checkout", Version: "v6.0.2", SHA: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}
+	applyActionPinUpdates(overrides, []ActionPinUpdate{
+		{Repo: "actions/checkout", CurrentVersion: "v6.0.2", LatestVersion: "v6.0.3", LatestSHA: "1111111111111111111111111111111111111111"},
+		{Repo: "actions/setup-go", CurrentVersion: "v6.3.0", LatestVersion: "v6.4.0", LatestSHA: "2222222222222222222222222222222222222222"},
+	})
+	require.NoError(t, writeActionPinOverrides(path, overrides), "overrides should be written")
+
+	reloaded, err := readActionPinOverrides(path)
+	require.NoError(t, err, "overrides should be readable")
+	assert.Equal(t, map[string]actionsLockEntry{
+		"actions/checkout@v6.0.3": {Repo: "actions/checkout", Version: "v6.0.3", SHA: "1111111111111111111111111111111111111111"},
+		"actions/setup-go@v6.4.0": {Repo: "actions/setup-go", Version: "v6.4.0", SHA: "2222222222222222222222222222222222222222"},
+	}, reloaded.Entries, "older override for the same repository should be replaced")
+}
+
+func TestCurrentActionPinsPrefersOverrides(t *testing.T) {
+	t.Cleanup(func() { workflow.SetActionPinOverrides(nil) })
+
+	overrides := &actionsLockFile{Entries: map[string]actionsLockEntry{
+		"actions/checkout@v99.0.0": {Repo: "actions/checkout", Version: "v99.0.0", SHA: "1111111111111111111111111111111111111111"},
+	}}
+	pins := currentActionPins(overrides)
+
+	var checkout *workflow.ActionPin
+	for i := range pins {
+		if pins[i].Repo == "actions/checkout" {
+			require.Nil(t, checkout, "each repository should be listed once")
+			checkout = &pins[i]
+		}
+	}
+	require.NotNil(t, checkout, "checkout should be listed")
+	assert.Equal(t, "v99.0.0", checkout.Version, "override should take precedence over the embedded pin")
+	assert.Greater(t, len(pins), 1, "embedded pins should still be listed")
+}
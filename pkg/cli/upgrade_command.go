@@ -219,33 +219,7 @@ func runUpgradeCommand(verbose bool, workflowDir string, noFix bool, noCompile b
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Compiling all workflows..."))
 		upgradeLog.Print("Compiling all workflows")
 
-		// Create and configure compiler
-		compiler := createAndConfigureCompiler(CompileConfig{
-			Verbose:     verbose,
-			WorkflowDir: workflowDir,
-		})
-
-		// Determine workflow directory
-		workflowsDir := workflowDir
-		if workflowsDir == "" {
-			workflowsDir = ".github/workflows"
-		}
-
-		// Compile all workflow files
-		stats, compileErr := compileAllWorkflowFiles(compiler, workflowsDir, verbose)
-		if compileErr != nil {
-			upgradeLog.Printf("Failed to compile workflows: %v", compileErr)
-			// Don't fail the upgrade if compilation fails - this is non-critical
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: Failed to compile workflows: %v", compileErr)))
-		} else if stats != nil {
-			// Print compilation summary
-			if verbose {
-				fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("✓ Compiled %d workflow(s)", stats.Total-stats.Errors)))
-			}
-			if stats.Errors > 0 {
-				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: %d workflow(s) failed to compile", stats.Errors)))
-			}
-		}
+		recompileAllWorkflows(workflowDir, verbose)
 	} else {
 		if noFix {
 			upgradeLog.Print("Skipping compilation (--no-fix specified)")
@@ -267,6 +241,38 @@ func runUpgradeCommand(verbose bool, workflowDir string, noFix bool, noCompile b
 	return nil
 }
 
+// recompileAllWorkflows compiles every workflow in workflowDir (default: .github/workflows).
+// Compilation failures are reported as warnings rather than errors.
+func recompileAllWorkflows(workflowDir string, verbose bool) {
+	// Create and configure compiler
+	compiler := createAndConfigureCompiler(CompileConfig{
+		Verbose:     verbose,
+		WorkflowDir: workflowDir,
+	})
+
+	// Determine workflow directory
+	workflowsDir := workflowDir
+	if workflowsDir == "" {
+		workflowsDir = ".github/workflows"
+	}
+
+	// Compile all workflow files
+	stats, compileErr := compileAllWorkflowFiles(compiler, workflowsDir, verbose)
+	if compileErr != nil {
+		upgradeLog.Printf("Failed to compile workflows: %v", compileErr)
+		// Don't fail the upgrade if compilation fails - this is non-critical
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: Failed to compile workflows: %v", compileErr)))
+	} else if stats != nil {
+		// Print compilation summary
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("✓ Compiled %d workflow(s)", stats.Total-stats.Errors)))
+		}
+		if stats.Errors > 0 {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: %d workflow(s) failed to compile", stats.Errors)))
+		}
+	}
+}
+
 // updateAgentFiles updates the dispatcher agent file to the latest template
 func updateAgentFiles(verbose bool) error {
 	// Update dispatcher agent
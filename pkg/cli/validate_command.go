@@ -40,6 +40,8 @@ Examples:
 			jsonOutput, _ := cmd.Flags().GetBool("json")
 			failFast, _ := cmd.Flags().GetBool("fail-fast")
 			stats, _ := cmd.Flags().GetBool("stats")
+			policy, _ := cmd.Flags().GetString("policy")
+			noOrgPolicy, _ := cmd.Flags().GetBool("no-org-policy")
 			noCheckUpdate, _ := cmd.Flags().GetBool("no-check-update")
 			verbose, _ := cmd.Flags().GetBool("verbose")
 
@@ -66,6 +68,8 @@ Examples:
 				JSONOutput:     jsonOutput,
 				FailFast:       failFast,
 				Stats:          stats,
+				Policy:         policy,
+				NoOrgPolicy:    noOrgPolicy,
 			}
 			if _, err := CompileWorkflows(context.Background(), config); err != nil {
 				return err
@@ -80,6 +84,9 @@ Examples:
 	cmd.Flags().BoolP("json", "j", false, "Output results in JSON format")
 	cmd.Flags().Bool("fail-fast", false, "Stop at the first validation error instead of collecting all errors")
 	cmd.Flags().Bool("stats", false, "Display statistics table sorted by file size")
+	cmd.Flags().String("policy", "", "Organization policy file to enforce instead of the aw-policy.yml published in the organization's .github repository")
+	cmd.Flags().Bool("no-org-policy", false, "Validate without the organization policy, for example when it cannot be fetched offline")
+	cmd.MarkFlagsMutuallyExclusive("policy", "no-org-policy")
 	cmd.Flags().Bool("no-check-update", false, "Skip checking for gh-aw updates")
 
 	// Register completions
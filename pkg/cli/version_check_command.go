@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var versionCheckLog = logger.New("cli:version_check")
+
+// NewVersionCheckCommand creates the version check subcommand
+func NewVersionCheckCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check [workflow]...",
+		Short: "Check workflows for compatibility with this gh aw version",
+		Long: `Check that workflows are compatible with the installed version of gh aw.
+
+Workflows record the gh-aw version that created them in the gh-aw-version
+frontmatter field, and may require a minimum compiler version with min-version.
+For each workflow, this command reports:
+- An error when this version is older than the workflow's min-version
+- A warning when this version is older than the version that created the workflow,
+  since the workflow may use fields this version does not know
+- A warning when this version is much newer than the version that created the
+  workflow, since compiled behavior may have changed
+
+The same checks run when workflows are compiled. Development builds skip them.
+
+When called without arguments, checks all workflows in .github/workflows.
+
+` + WorkflowIDExplanation + `
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` version check                # Check all workflows
+  ` + string(constants.CLIExtensionPrefix) + ` version check ci-doctor      # Check a specific workflow`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			return RunVersionCheck(args, verbose)
+		},
+	}
+
+	return cmd
+}
+
+// RunVersionCheck checks the given workflows, or all workflows when none are given,
+// against the current gh aw version
+func RunVersionCheck(workflows []string, verbose bool) error {
+	versionCheckLog.Printf("Checking workflow versions: workflows=%v, version=%s, release=%v", workflows, GetVersion(), workflow.IsRelease())
+	fmt.Fprintf(os.Stderr, "%s version %s\n", string(constants.CLIExtensionPrefix), GetVersion())
+
+	var files []string
+	if len(workflows) == 0 {
+		mdFiles, err := getMarkdownWorkflowFiles("")
+		if err != nil {
+			return err
+		}
+		files = mdFiles
+	} else {
+		for _, name := range workflows {
+			path, err := resolveWorkflowFile(name, verbose)
+			if err != nil {
+				return err
+			}
+			files = append(files, path)
+		}
+	}
+
+	if !workflow.IsRelease() {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("This is a development build; compatibility is only checked by release builds"))
+		return nil
+	}
+
+	return checkWorkflowFileVersions(files, GetVersion(), verbose)
+}
+
+// checkWorkflowFileVersions reports the compatibility of each workflow file with compilerVersion,
+// returning an error when any workflow cannot be compiled by that version
+func checkWorkflowFileVersions(files []string, compilerVersion string, verbose bool) error {
+	var failed []string
+	checked := 0
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".md")
+
+		versions, err := readWorkflowVersions(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("%s: %v", name, err)))
+			failed = append(failed, name)
+			continue
+		}
+		if versions == (workflow.WorkflowVersions{}) {
+			console.LogVerbose(verbose, name+": no gh-aw-version or min-version, skipping")
+			continue
+		}
+		checked++
+
+		warnings, err := workflow.CheckWorkflowVersions(versions, compilerVersion)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("%s: %v", name, err)))
+			failed = append(failed, name)
+			continue
+		}
+		for _, warning := range warnings {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("%s: %s", name, warning)))
+		}
+		if len(warnings) == 0 {
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(name+": compatible"))
+		}
+	}
+
+	versionCheckLog.Printf("Checked %d of %d workflows, %d incompatible", checked, len(files), len(failed))
+	if checked == 0 && len(failed) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No workflows declare gh-aw-version or min-version"))
+	}
+	if len(failed) > 0 {
+		return errors.New("incompatible workflows: " + strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// readWorkflowVersions reads the gh-aw-version and min-version fields of a workflow file
+func readWorkflowVersions(file string) (workflow.WorkflowVersions, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return workflow.WorkflowVersions{}, fmt.Errorf("failed to read workflow: %w", err)
+	}
+	result, err := parser.ExtractFrontmatterFromContent(string(content))
+	if err != nil {
+		return workflow.WorkflowVersions{}, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	return workflow.ExtractWorkflowVersions(result.Frontmatter)
+}
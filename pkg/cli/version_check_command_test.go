@@ -0,0 +1,34 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWorkflowFileVersions(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "version-check-test")
+	writeWorkflow := func(name, frontmatter string) string {
+		path := filepath.Join(tmpDir, name+".md")
+		require.NoError(t, os.WriteFile(path, []byte("---\n"+frontmatter+"on: workflow_dispatch\n---\n\nDo things.\n"), 0644), "should write workflow")
+		return path
+	}
+
+	unannotated := writeWorkflow("unannotated", "")
+	current := writeWorkflow("current", "gh-aw-version: v0.40.0\n")
+	newer := writeWorkflow("newer", "gh-aw-version: v0.45.0\n")
+	required := writeWorkflow("required", "min-version: v0.41.0\n")
+	invalid := writeWorkflow("invalid", "min-version: soon\n")
+
+	require.NoError(t, checkWorkflowFileVersions([]string{unannotated, current, newer}, "v0.40.1", false), "warnings should not fail the check")
+
+	err := checkWorkflowFileVersions([]string{current, required, invalid}, "v0.40.1", false)
+	require.Error(t, err, "unmet min-version and invalid versions should fail the check")
+	assert.Equal(t, "incompatible workflows: required, invalid", err.Error(), "error should list the incompatible workflows")
+}
@@ -0,0 +1,189 @@
+package compiler
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var log = logger.New("compiler:compiler")
+
+// compileMu serializes Compile calls: the in-memory import files are process-wide parser state
+var compileMu sync.Mutex
+
+// diagnosticLocationPattern matches the IDE-parseable "file:line:column: severity: message" lines in compiler errors
+var diagnosticLocationPattern = regexp.MustCompile(`^(.+?):(\d+):(\d+): (error|warning|info): (.*)$`)
+
+// DefaultFilename is the virtual filename used when Options.Filename is empty
+const DefaultFilename = "workflow.md"
+
+// Options configures a single Compile call
+type Options struct {
+	// Filename is the virtual path of the workflow source, used in diagnostics, for the
+	// lock file name, and as the base directory for imports. Defaults to DefaultFilename.
+	Filename string
+	// Files provides imported files in memory, keyed by path relative to the directory of
+	// Filename (for example "shared/tools.md" when Filename is "workflow.md").
+	Files map[string][]byte
+	// Engine overrides the engine set in frontmatter (claude, codex, copilot, custom)
+	Engine string
+	// GitHubHost compiles for a GitHub Enterprise Server or GHE.com host
+	GitHubHost string
+	// Strict enforces strict mode validation regardless of the frontmatter setting
+	Strict bool
+	// Version is the gh-aw version recorded in the generated YAML. Defaults to the library build version.
+	Version string
+}
+
+// Workflow is the result of a successful compilation
+type Workflow struct {
+	Name         string // Workflow name from frontmatter or the first markdown heading
+	Description  string // Description from frontmatter
+	WorkflowID   string // Identifier derived from the filename
+	Engine       string // Engine ID the workflow runs with
+	LockFilename string // Conventional lock file name for the source (e.g. "triage.lock.yml")
+	YAML         string // Generated GitHub Actions workflow
+	WarningCount int    // Number of warnings the compiler reported, including ones without a Diagnostic
+}
+
+// Severity is the severity of a Diagnostic
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Diagnostic is a compiler message about the workflow source
+type Diagnostic struct {
+	Severity Severity
+	File     string // Path of the file the message applies to (the workflow or an import)
+	Line     int    // 1-based line, or 0 when unknown
+	Column   int    // 1-based column, or 0 when unknown
+	Message  string
+}
+
+// Compile compiles workflow markdown into GitHub Actions YAML without writing to disk.
+//
+// On failure it returns a nil Workflow, the error, and diagnostics describing the error
+// with source locations where available. Shared workflows (no 'on' field) cannot be
+// compiled on their own and return an error. Warnings are returned as diagnostics of
+// SeverityWarning alongside a successful result.
+func Compile(ctx context.Context, src []byte, opts Options) (*Workflow, []Diagnostic, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	filename := opts.Filename
+	if filename == "" {
+		filename = DefaultFilename
+	}
+	log.Printf("Compiling %s (%d bytes, %d in-memory files)", filename, len(src), len(opts.Files))
+
+	compileMu.Lock()
+	defer compileMu.Unlock()
+
+	if len(opts.Files) > 0 {
+		parser.SetVirtualFiles(resolveVirtualFiles(filename, opts.Files))
+		defer parser.ClearVirtualFiles()
+	}
+
+	compilerOpts := []workflow.CompilerOption{
+		workflow.WithNoEmit(true),
+		workflow.WithSkipValidation(true),
+		workflow.WithWorkflowIdentifier(strings.TrimSuffix(filepath.Base(filename), ".md")),
+		workflow.WithEngineOverride(opts.Engine),
+		workflow.WithGitHubHost(opts.GitHubHost),
+		workflow.WithStrictMode(opts.Strict),
+	}
+	if opts.Version != "" {
+		compilerOpts = append(compilerOpts, workflow.WithVersion(opts.Version))
+	}
+	c := workflow.NewCompiler(compilerOpts...)
+	c.SetQuiet(true)
+
+	workflowData, err := c.ParseWorkflowString(string(src), filename)
+	if err != nil {
+		log.Printf("Parsing %s failed: %v", filename, err)
+		return nil, diagnosticsFromError(err, filename), err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	yamlContent, err := c.CompileToYAML(workflowData, filename)
+	if err != nil {
+		log.Printf("Compiling %s failed: %v", filename, err)
+		return nil, diagnosticsFromError(err, filename), err
+	}
+
+	var diagnostics []Diagnostic
+	for _, warning := range c.GetScheduleWarnings() {
+		diagnostics = append(diagnostics, Diagnostic{Severity: SeverityWarning, File: filename, Message: warning})
+	}
+
+	result := &Workflow{
+		Name:         workflowData.Name,
+		Description:  workflowData.Description,
+		WorkflowID:   workflowData.WorkflowID,
+		Engine:       workflowData.AI,
+		LockFilename: stringutil.MarkdownToLockFile(filename),
+		YAML:         yamlContent,
+		WarningCount: c.GetWarningCount(),
+	}
+	log.Printf("Compiled %s: %d bytes of YAML, %d diagnostics", filename, len(yamlContent), len(diagnostics))
+	return result, diagnostics, nil
+}
+
+// resolveVirtualFiles keys in-memory files by the paths the import resolver produces,
+// which are joined to the directory of the workflow source
+func resolveVirtualFiles(filename string, files map[string][]byte) map[string][]byte {
+	baseDir := filepath.Dir(filepath.Clean(filename))
+	resolved := make(map[string][]byte, len(files))
+	for path, content := range files {
+		resolved[filepath.Join(baseDir, path)] = content
+	}
+	return resolved
+}
+
+// diagnosticsFromError converts a compiler error into diagnostics, extracting the
+// "file:line:column: severity: message" locations the compiler embeds in its errors
+func diagnosticsFromError(err error, filename string) []Diagnostic {
+	var sharedErr *workflow.SharedWorkflowError
+	if errors.As(err, &sharedErr) {
+		return []Diagnostic{{Severity: SeverityError, File: filename, Message: sharedErr.Error()}}
+	}
+
+	message := stringutil.StripANSI(err.Error())
+	var diagnostics []Diagnostic
+	for line := range strings.SplitSeq(message, "\n") {
+		matches := diagnosticLocationPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		lineNum, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: Severity(matches[4]),
+			File:     matches[1],
+			Line:     lineNum,
+			Column:   column,
+			Message:  matches[5],
+		})
+	}
+	if len(diagnostics) == 0 {
+		diagnostics = append(diagnostics, Diagnostic{Severity: SeverityError, File: filename, Message: strings.TrimSpace(message)})
+	}
+	return diagnostics
+}
@@ -0,0 +1,148 @@
+//go:build !integration
+
+package compiler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const basicWorkflow = `---
+name: Issue Triage
+description: Triage new issues
+on:
+  issues:
+    types: [opened]
+engine: copilot
+---
+
+# Issue Triage
+
+Triage the issue.
+`
+
+func TestCompile(t *testing.T) {
+	wf, diagnostics, err := Compile(context.Background(), []byte(basicWorkflow), Options{Filename: "triage.md"})
+	require.NoError(t, err, "workflow should compile")
+	assert.Empty(t, diagnostics, "no diagnostics expected")
+
+	assert.Equal(t, "Issue Triage", wf.Name, "name from frontmatter")
+	assert.Equal(t, "Triage new issues", wf.Description, "description from frontmatter")
+	assert.Equal(t, "triage", wf.WorkflowID, "workflow ID from filename")
+	assert.Equal(t, "copilot", wf.Engine, "engine from frontmatter")
+	assert.Equal(t, "triage.lock.yml", wf.LockFilename, "lock file name")
+	assert.Contains(t, wf.YAML, `name: "Issue Triage"`, "generated YAML should contain the workflow name")
+	assert.Contains(t, wf.YAML, "issues:", "generated YAML should contain the trigger")
+}
+
+func TestCompileDoesNotWriteFiles(t *testing.T) {
+	dir := t.TempDir()
+	workflowsDir := filepath.Join(dir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "should create workflows dir")
+
+	_, _, err := Compile(context.Background(), []byte(basicWorkflow), Options{Filename: filepath.Join(workflowsDir, "triage.md")})
+	require.NoError(t, err, "workflow should compile")
+
+	entries, err := os.ReadDir(workflowsDir)
+	require.NoError(t, err, "should read workflows dir")
+	assert.Empty(t, entries, "Compile must not write lock files or other artifacts")
+}
+
+func TestCompileEngineOverride(t *testing.T) {
+	wf, _, err := Compile(context.Background(), []byte(basicWorkflow), Options{Engine: "claude"})
+	require.NoError(t, err, "workflow should compile")
+	assert.Equal(t, "claude", wf.Engine, "engine option should override frontmatter")
+	assert.Equal(t, "workflow.lock.yml", wf.LockFilename, "default filename should be used")
+}
+
+func TestCompileWithInMemoryImports(t *testing.T) {
+	src := `---
+on: workflow_dispatch
+engine: copilot
+imports:
+  - shared/tools.md
+---
+
+Use the shared tools.
+`
+	files := map[string][]byte{
+		"shared/tools.md": []byte("---\ntools:\n  web-fetch:\n---\n\nShared instructions.\n"),
+	}
+
+	wf, _, err := Compile(context.Background(), []byte(src), Options{Filename: ".github/workflows/imports.md", Files: files})
+	require.NoError(t, err, "workflow with in-memory import should compile")
+	assert.Contains(t, wf.YAML, "shared/tools.md", "lock file should record the import")
+
+	_, _, err = Compile(context.Background(), []byte(src), Options{Filename: ".github/workflows/imports.md"})
+	require.Error(t, err, "import should not resolve once the in-memory files are gone")
+}
+
+func TestCompileDiagnostics(t *testing.T) {
+	tests := []struct {
+		name            string
+		src             string
+		messageContains string
+		expectLine      bool
+	}{
+		{
+			name:            "invalid YAML frontmatter",
+			src:             "---\non: [issues\n---\n\nBody\n",
+			messageContains: "",
+			expectLine:      true,
+		},
+		{
+			name:            "unknown frontmatter field",
+			src:             "---\non: issues\nnot-a-field: true\n---\n\nBody\n",
+			messageContains: "not-a-field",
+			expectLine:      true,
+		},
+		{
+			name:            "shared workflow",
+			src:             "---\ntools:\n  web-fetch:\n---\n\nShared\n",
+			messageContains: "shared",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, diagnostics, err := Compile(context.Background(), []byte(tt.src), Options{Filename: "bad.md"})
+			require.Error(t, err, "compilation should fail")
+			assert.Nil(t, wf, "no workflow on failure")
+			require.NotEmpty(t, diagnostics, "failure should produce diagnostics")
+
+			diagnostic := diagnostics[0]
+			assert.Equal(t, SeverityError, diagnostic.Severity, "diagnostic severity")
+			assert.Contains(t, diagnostic.File, "bad.md", "diagnostic file")
+			assert.Contains(t, diagnostic.Message, tt.messageContains, "diagnostic message")
+			if tt.expectLine {
+				assert.Positive(t, diagnostic.Line, "diagnostic should carry a line")
+			}
+		})
+	}
+}
+
+func TestCompileCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := Compile(ctx, []byte(basicWorkflow), Options{})
+	assert.True(t, errors.Is(err, context.Canceled), "canceled context should abort compilation")
+}
+
+func TestDiagnosticsFromError(t *testing.T) {
+	err := errors.New("bad.md:3:5: error: first problem\n  3 | code\nimports/x.md:1:1: warning: second problem")
+	diagnostics := diagnosticsFromError(err, "bad.md")
+
+	require.Len(t, diagnostics, 2, "each located line should produce a diagnostic")
+	assert.Equal(t, Diagnostic{Severity: SeverityError, File: "bad.md", Line: 3, Column: 5, Message: "first problem"}, diagnostics[0])
+	assert.Equal(t, Diagnostic{Severity: SeverityWarning, File: "imports/x.md", Line: 1, Column: 1, Message: "second problem"}, diagnostics[1])
+
+	plain := diagnosticsFromError(errors.New("something failed"), "bad.md")
+	assert.Equal(t, []Diagnostic{{Severity: SeverityError, File: "bad.md", Message: "something failed"}}, plain)
+}
@@ -0,0 +1,44 @@
+// Package compiler provides a stable Go API for compiling agentic workflow
+// markdown into GitHub Actions YAML, for tools that embed gh-aw as a library.
+//
+// Unlike the gh aw compile command, this package never writes lock files, action
+// caches, or other artifacts to disk. The workflow source is passed in memory and
+// imported files can be supplied in memory through Options.Files, so the
+// markdown-to-YAML transformation can run without a repository checkout.
+//
+// # Basic Usage
+//
+//	src := []byte("---\non: issues\nengine: copilot\n---\n\nTriage the issue.\n")
+//	wf, diags, err := compiler.Compile(ctx, src, compiler.Options{Filename: "triage.md"})
+//	if err != nil {
+//		for _, d := range diags {
+//			fmt.Printf("%s:%d:%d: %s: %s\n", d.File, d.Line, d.Column, d.Severity, d.Message)
+//		}
+//		return err
+//	}
+//	os.WriteFile(wf.LockFilename, []byte(wf.YAML), 0o644)
+//
+// # Imports
+//
+// Imports are resolved relative to the directory of Options.Filename. Files
+// present in Options.Files take precedence; other local imports are read from
+// disk, and workflowspec imports (owner/repo/path@ref) are fetched from GitHub.
+//
+// # Diagnostics
+//
+// Compile errors are returned both as an error and as Diagnostic values with the
+// file, line, and column the compiler reported. Schedule warnings are returned as
+// diagnostics; other compiler warnings are still printed to stderr and are counted
+// in Workflow.WarningCount.
+//
+// # Concurrency
+//
+// Compile may be called from multiple goroutines. Calls are serialized because
+// import resolution shares process-wide state.
+//
+// # Stability
+//
+// The exported identifiers in this package follow semantic versioning. The
+// pkg/workflow and pkg/parser packages it wraps are internal implementation
+// details and may change between releases.
+package compiler
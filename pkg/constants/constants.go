@@ -310,6 +310,14 @@ const (
 	// for selecting the model. Setting this env var is equivalent to passing --model to the CLI.
 	GeminiCLIModelEnvVar = "GEMINI_MODEL"
 
+	// GitHubModelsModelEnvVar is the environment variable read by the github-models agent script
+	// for selecting the model (e.g., openai/gpt-4.1).
+	GitHubModelsModelEnvVar = "GH_AW_GITHUB_MODELS_MODEL"
+
+	// OllamaModelEnvVar is the environment variable read by the ollama agent script
+	// for selecting the model (e.g., llama3.1:70b).
+	OllamaModelEnvVar = "GH_AW_OLLAMA_MODEL"
+
 	// Common environment variable names used across all engines
 
 	// EnvVarPrompt is the path to the workflow prompt file
@@ -324,6 +332,9 @@ const (
 	// EnvVarMaxTurns is the maximum number of turns for agent execution
 	EnvVarMaxTurns = "GH_AW_MAX_TURNS"
 
+	// EnvVarMaxToolCalls is the maximum number of tool calls for agent execution
+	EnvVarMaxToolCalls = "GH_AW_MAX_TOOL_CALLS"
+
 	// EnvVarStartupTimeout is the tool startup timeout in seconds
 	EnvVarStartupTimeout = "GH_AW_STARTUP_TIMEOUT"
 
@@ -343,6 +354,9 @@ const DefaultGeminiVersion Version = "latest"
 // DefaultGitHubMCPServerVersion is the default version of the GitHub MCP server Docker image
 const DefaultGitHubMCPServerVersion Version = "v0.31.0"
 
+// MinimumGHESVersion is the oldest GitHub Enterprise Server release supported by compiled workflows
+const MinimumGHESVersion Version = "3.14"
+
 // DefaultFirewallVersion is the default version of the gh-aw-firewall (AWF) binary
 const DefaultFirewallVersion Version = "v0.23.0"
 
@@ -593,10 +607,14 @@ const PreActivationJobName JobName = "pre_activation"
 const DetectionJobName JobName = "detection"
 const SafeOutputArtifactName = "safe-output"
 const AgentOutputArtifactName = "agent-output"
+const ProvenanceArtifactName = "provenance"
 
 // AgentOutputFilename is the filename of the agent output JSON file
 const AgentOutputFilename = "agent_output.json"
 
+// TranscriptFilename is the filename of the engine-independent agent transcript
+const TranscriptFilename = "transcript.json"
+
 // MCPServerID represents a built-in MCP server identifier.
 // This semantic type distinguishes MCP server IDs from arbitrary strings,
 // preventing accidental mixing of server identifiers with other string types.
@@ -647,6 +665,9 @@ const CheckCommandPositionStepID StepID = "check_command_position"
 const CheckRateLimitStepID StepID = "check_rate_limit"
 const CheckSkipRolesStepID StepID = "check_skip_roles"
 const CheckSkipBotsStepID StepID = "check_skip_bots"
+const CheckScheduleStepID StepID = "check_schedule"
+const CheckUpstreamWorkflowStepID StepID = "check_upstream_workflow"
+const CheckGuardsStepID StepID = "check_guards"
 
 // Output names for pre-activation job steps
 const IsTeamMemberOutput = "is_team_member"
@@ -655,9 +676,15 @@ const SkipCheckOkOutput = "skip_check_ok"
 const SkipNoMatchCheckOkOutput = "skip_no_match_check_ok"
 const CommandPositionOkOutput = "command_position_ok"
 const MatchedCommandOutput = "matched_command"
+const CommandArgsOutput = "command_args"
 const RateLimitOkOutput = "rate_limit_ok"
 const SkipRolesOkOutput = "skip_roles_ok"
 const SkipBotsOkOutput = "skip_bots_ok"
+const ScheduleOkOutput = "schedule_ok"
+const UpstreamOkOutput = "upstream_ok"
+const UpstreamEventOutput = "upstream_event"
+const UpstreamPayloadOutput = "upstream_payload"
+const GuardsOkOutput = "guards_ok"
 const ActivatedOutput = "activated"
 
 // Rate limit defaults
@@ -674,6 +701,10 @@ const (
 	CodexEngine EngineName = "codex"
 	// GeminiEngine is the Google Gemini engine identifier
 	GeminiEngine EngineName = "gemini"
+	// GitHubModelsEngine is the GitHub Models engine identifier
+	GitHubModelsEngine EngineName = "github-models"
+	// OllamaEngine is the self-hosted Ollama engine identifier
+	OllamaEngine EngineName = "ollama"
 )
 
 // AgenticEngines lists all supported agentic engine names
@@ -941,33 +972,42 @@ var IgnoredFrontmatterFields = []string{"user-invokable"}
 // The compiler enforces these restrictions at compile time with clear error messages.
 //
 // Forbidden fields fall into these categories:
-//   - Workflow triggers: on (defines it as a main workflow)
-//   - Workflow execution: command, run-name, runs-on, concurrency, if, timeout-minutes, timeout_minutes
-//   - Workflow metadata: name, tracker-id, strict
-//   - Workflow features: container, env, environment, sandbox, features
-//   - Access control: roles, github-token
+//   - Workflow triggers: on (defines it as a main workflow), needs
+//   - Workflow execution: command, run-name, runs-on, concurrency, if, timeout-minutes, timeout_minutes, timeouts, continuation, notifications
+//   - Workflow metadata: name, tracker-id, strict, strict-rules, profile
+//   - Workflow features: container, env, environment, sandbox, features, warm-cache, expressions
+//   - Access control: roles, github-token, auth
 //
 // All other fields defined in main_workflow_schema.json can be used in shared workflows
 // and will be properly imported and merged when the shared workflow is imported.
 var SharedWorkflowForbiddenFields = []string{
 	"on",              // Trigger field - only for main workflows
+	"auth",            // GitHub App authentication
 	"command",         // Command for workflow execution
 	"concurrency",     // Concurrency control
 	"container",       // Container configuration
+	"continuation",    // Automatic continuation of long-running tasks
 	"env",             // Environment variables
 	"environment",     // Deployment environment
+	"expressions",     // Markdown expression translation
 	"features",        // Feature flags
 	"github-token",    // GitHub token configuration
 	"if",              // Conditional execution
 	"name",            // Workflow name
+	"needs",           // Upstream agentic workflow dependency
+	"notifications",   // Run summary webhook
+	"profile",         // Permission profile
 	"roles",           // Role requirements
 	"run-name",        // Run display name
 	"runs-on",         // Runner specification
 	"sandbox",         // Sandbox configuration
 	"strict",          // Strict mode
+	"strict-rules",    // Strict mode rule levels
 	"timeout-minutes", // Timeout in minutes
 	"timeout_minutes", // Timeout in minutes (underscore variant)
+	"timeouts",        // Per-phase timeouts
 	"tracker-id",      // Tracker ID
+	"warm-cache",      // Warm cache workflow opt-in
 }
 
 func GetWorkflowDir() string {
@@ -0,0 +1,46 @@
+package messages
+
+// Diagnostic codes. AW0xxx are parser diagnostics, AW1xxx compiler warnings and
+// AW2xxx compiler errors. Codes are never reused.
+const (
+	FrontmatterNotClosed     Code = "AW0001"
+	ImportsInvalidType       Code = "AW0002"
+	ImportItemInvalidType    Code = "AW0003"
+	ImportObjectMissingPath  Code = "AW0004"
+	ImportPathNotString      Code = "AW0005"
+	ImportInputsNotObject    Code = "AW0006"
+	ImportLockFile           Code = "AW0007"
+	SandboxAgentDisabled     Code = "AW1001"
+	IDTokenWritePermission   Code = "AW1002"
+	EngineOverridden         Code = "AW1003"
+	ToolsIgnoredForEngine    Code = "AW1004"
+	WebSearchUnsupported     Code = "AW1005"
+	ContainerImageValidation Code = "AW1006"
+	AgentFileMissing         Code = "AW2001"
+	AgentFileInaccessible    Code = "AW2002"
+	InvalidActionMode        Code = "AW2003"
+	ThreatDetectionSandbox   Code = "AW2004"
+	OrgPolicyViolation       Code = "AW2005"
+)
+
+// defaultCatalog holds the English text of every diagnostic
+var defaultCatalog = map[Code]string{
+	FrontmatterNotClosed:     "frontmatter not properly closed",
+	ImportsInvalidType:       "imports field must be an array of strings or objects",
+	ImportItemInvalidType:    "import item must be a string or an object with 'path' field",
+	ImportObjectMissingPath:  "import object must have a 'path' field",
+	ImportPathNotString:      "import 'path' must be a string",
+	ImportInputsNotObject:    "import 'inputs' must be an object",
+	ImportLockFile:           "cannot import .lock.yml files: '{path}'. Lock files are compiled outputs from gh-aw. Import the source .md file instead",
+	SandboxAgentDisabled:     "⚠️  WARNING: Agent sandbox disabled (sandbox.agent: false). This removes firewall protection. The AI agent will have direct network access without firewall filtering. The MCP gateway remains enabled. Only use this for testing or in controlled environments where you trust the AI agent completely.",
+	IDTokenWritePermission:   "This workflow grants id-token: write permission\nOIDC tokens can authenticate to cloud providers (AWS, Azure, GCP).\nEnsure proper audience validation and trust policies are configured.",
+	EngineOverridden:         "Command line --engine {override} overrides markdown file engine: {engine}",
+	ToolsIgnoredForEngine:    "'tools' section ignored when using engine: {engine} ({engine_name} doesn't support MCP tool allow-listing)",
+	WebSearchUnsupported:     "Engine '{engine}' does not support the web-search tool. See https://github.github.com/gh-aw/guides/web-search/ for alternatives.",
+	ContainerImageValidation: "container image validation failed: {error}",
+	AgentFileMissing:         "agent file '{path}' does not exist. Ensure the file exists in the repository and is properly imported.",
+	AgentFileInaccessible:    "failed to access agent file '{path}': {error}",
+	InvalidActionMode:        "invalid action-mode feature flag '{mode}'. Must be 'dev', 'release', or 'script'",
+	ThreatDetectionSandbox:   "threat detection requires sandbox.agent to be enabled. Threat detection runs inside the agent sandbox (AWF) with fully blocked network. Either enable sandbox.agent or use 'threat-detection: false' to disable the threat-detection configuration in safe-outputs.",
+	OrgPolicyViolation:       "workflow violates the organization policy {source}:\n{violations}\nChange the workflow, or ask the organization to update the policy",
+}
@@ -0,0 +1,196 @@
+// Package messages provides the message catalog for user-facing parser and
+// compiler diagnostics.
+//
+// Every cataloged diagnostic has a stable code (for example AW1001) and an English
+// default text. The text of any code can be overridden with a catalog file, which
+// is how organizations ship translated messages:
+//
+//	# .aw/messages/de.yml
+//	AW1001: "Agent-Sandbox deaktiviert (sandbox.agent: false). ..."
+//	AW2001: "Agent-Datei '{path}' existiert nicht."
+//
+// Messages use named placeholders such as {path}, which are filled from Args.
+// An override may use any subset of the placeholders of the English default, in any
+// order. Codes never change meaning once released; a reworded diagnostic keeps its code.
+package messages
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var log = logger.New("messages:messages")
+
+// CatalogEnvVar names the environment variable that points to a catalog file.
+// It takes precedence over the messages: key of the repository configuration.
+const CatalogEnvVar = "GH_AW_MESSAGES"
+
+// Code is the stable identifier of a diagnostic
+type Code string
+
+// Args holds the values of the named placeholders of a message
+type Args map[string]any
+
+var (
+	mu        sync.RWMutex
+	overrides map[Code]string
+)
+
+// placeholderPattern matches {name} placeholders
+var placeholderPattern = regexp.MustCompile(`\{([a-z][a-z0-9_]*)\}`)
+
+// Text returns the message for code in the active catalog with its placeholders filled.
+// Unknown codes return the code itself so a missing entry is visible but harmless.
+func Text(code Code, args Args) string {
+	template, ok := lookup(code)
+	if !ok {
+		log.Printf("Unknown message code: %s", code)
+		return string(code)
+	}
+	return placeholderPattern.ReplaceAllStringFunc(template, func(match string) string {
+		name := match[1 : len(match)-1]
+		if value, ok := args[name]; ok {
+			return fmt.Sprint(value)
+		}
+		return match
+	})
+}
+
+// Format returns the message for code followed by the code in brackets, so users
+// can find the entry to override
+func Format(code Code, args Args) string {
+	return Text(code, args) + " [" + string(code) + "]"
+}
+
+// Error is a diagnostic error carrying its stable code
+type Error struct {
+	Code    Code
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError returns a diagnostic error whose message is Format(code, args)
+func NewError(code Code, args Args) error {
+	return &Error{Code: code, Message: Format(code, args)}
+}
+
+// CodeOf returns the code of the first diagnostic error in err's chain, if any
+func CodeOf(err error) (Code, bool) {
+	var diagErr *Error
+	if errors.As(err, &diagErr) {
+		return diagErr.Code, true
+	}
+	return "", false
+}
+
+// Default returns the English text of code
+func Default(code Code) (string, bool) {
+	text, ok := defaultCatalog[code]
+	return text, ok
+}
+
+// Codes returns every cataloged code in ascending order
+func Codes() []Code {
+	codes := slices.Collect(maps.Keys(defaultCatalog))
+	slices.Sort(codes)
+	return codes
+}
+
+// lookup returns the override for code, falling back to the English default
+func lookup(code Code) (string, bool) {
+	mu.RLock()
+	override, ok := overrides[code]
+	mu.RUnlock()
+	if ok {
+		return override, true
+	}
+	return Default(code)
+}
+
+// SetOverrides replaces the active overrides after validating them against the catalog.
+// Passing nil restores the English defaults.
+func SetOverrides(catalog map[Code]string) error {
+	if err := ValidateOverrides(catalog); err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	overrides = maps.Clone(catalog)
+	log.Printf("Applied %d message overrides", len(catalog))
+	return nil
+}
+
+// ValidateOverrides checks that every override targets a known code and only uses
+// placeholders that the English default provides
+func ValidateOverrides(catalog map[Code]string) error {
+	var problems []string
+	for code, text := range catalog {
+		defaultText, ok := defaultCatalog[code]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown message code '%s'", code))
+			continue
+		}
+		allowed := placeholders(defaultText)
+		for _, name := range placeholders(text) {
+			if !slices.Contains(allowed, name) {
+				problems = append(problems, fmt.Sprintf("%s: unknown placeholder {%s} (available: %s)", code, name, formatPlaceholders(allowed)))
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return errors.New("invalid message catalog: " + strings.Join(problems, "; "))
+}
+
+// LoadCatalogFile reads a YAML catalog file mapping codes to messages
+func LoadCatalogFile(path string) (map[Code]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message catalog %s: %w", path, err)
+	}
+	var catalog map[Code]string
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse message catalog %s: %w", path, err)
+	}
+	if err := ValidateOverrides(catalog); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+// placeholders returns the placeholder names used in a message, in order of appearance
+func placeholders(text string) []string {
+	var names []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+func formatPlaceholders(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	formatted := make([]string, len(names))
+	for i, name := range names {
+		formatted[i] = "{" + name + "}"
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,107 @@
+//go:build !integration
+
+package messages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestText(t *testing.T) {
+	t.Cleanup(func() { _ = SetOverrides(nil) })
+
+	assert.Equal(t, "agent file 'agents/triage.md' does not exist. Ensure the file exists in the repository and is properly imported.",
+		Text(AgentFileMissing, Args{"path": "agents/triage.md"}), "should fill placeholders in the English default")
+	assert.Equal(t, "AW9999", Text("AW9999", nil), "unknown codes should return the code")
+	assert.Equal(t, "frontmatter not properly closed [AW0001]", Format(FrontmatterNotClosed, nil), "Format should append the code")
+
+	require.NoError(t, SetOverrides(map[Code]string{
+		EngineOverridden: "Die Engine {engine} wird durch --engine {override} ersetzt",
+	}), "should accept a valid override")
+	assert.Equal(t, "Die Engine claude wird durch --engine copilot ersetzt [AW1003]",
+		Format(EngineOverridden, Args{"override": "copilot", "engine": "claude"}), "should use the override with reordered placeholders")
+	assert.Equal(t, "frontmatter not properly closed", Text(FrontmatterNotClosed, nil), "codes without override should keep the default")
+
+	require.NoError(t, SetOverrides(nil), "should reset overrides")
+	assert.Contains(t, Text(EngineOverridden, Args{"override": "copilot", "engine": "claude"}), "Command line --engine copilot", "reset should restore the default")
+}
+
+func TestValidateOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog map[Code]string
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			catalog: map[Code]string{AgentFileMissing: "Agent-Datei '{path}' fehlt"},
+		},
+		{
+			name:    "subset of placeholders",
+			catalog: map[Code]string{AgentFileInaccessible: "Agent-Datei nicht lesbar: {error}"},
+		},
+		{
+			name:    "unknown code",
+			catalog: map[Code]string{"AW9999": "x"},
+			wantErr: "unknown message code 'AW9999'",
+		},
+		{
+			name:    "unknown placeholder",
+			catalog: map[Code]string{AgentFileMissing: "Agent-Datei '{file}' fehlt"},
+			wantErr: "AW2001: unknown placeholder {file} (available: {path})",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOverrides(tt.catalog)
+			if tt.wantErr == "" {
+				require.NoError(t, err, "catalog should be valid")
+				return
+			}
+			require.Error(t, err, "catalog should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error message")
+		})
+	}
+}
+
+func TestLoadCatalogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "de.yml")
+	require.NoError(t, os.WriteFile(path, []byte("AW0001: \"Frontmatter nicht korrekt abgeschlossen\"\nAW2003: \"Ungültiger action-mode '{mode}'\"\n"), 0644), "should write catalog")
+
+	catalog, err := LoadCatalogFile(path)
+	require.NoError(t, err, "should load catalog")
+	assert.Equal(t, map[Code]string{
+		FrontmatterNotClosed: "Frontmatter nicht korrekt abgeschlossen",
+		InvalidActionMode:    "Ungültiger action-mode '{mode}'",
+	}, catalog, "catalog entries")
+
+	_, err = LoadCatalogFile(filepath.Join(t.TempDir(), "missing.yml"))
+	require.Error(t, err, "missing file should fail")
+}
+
+func TestNewError(t *testing.T) {
+	err := fmt.Errorf("failed to parse: %w", NewError(ImportPathNotString, nil))
+
+	code, ok := CodeOf(err)
+	require.True(t, ok, "should find the code through wrapping")
+	assert.Equal(t, ImportPathNotString, code, "code")
+	assert.Equal(t, "failed to parse: import 'path' must be a string [AW0005]", err.Error(), "message")
+
+	_, ok = CodeOf(errors.New("plain"))
+	assert.False(t, ok, "plain errors have no code")
+}
+
+func TestDefaultCatalogPlaceholders(t *testing.T) {
+	for _, code := range Codes() {
+		text, _ := Default(code)
+		assert.NotEmpty(t, text, "%s should have a default text", code)
+		assert.Regexp(t, `^AW\d{4}$`, string(code), "codes should follow the AWnnnn format")
+	}
+}
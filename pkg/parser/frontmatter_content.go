@@ -3,13 +3,13 @@ package parser
 import (
 	"bufio"
 	"bytes"
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
+	"github.com/github/gh-aw/pkg/messages"
 	"github.com/goccy/go-yaml"
 )
 
@@ -18,8 +18,9 @@ type FrontmatterResult struct {
 	Frontmatter map[string]any
 	Markdown    string
 	// Additional fields for error context
-	FrontmatterLines []string // Original frontmatter lines for error context
+	FrontmatterLines []string // Original YAML frontmatter lines for error context (empty for TOML frontmatter)
 	FrontmatterStart int      // Line number where frontmatter starts (1-based)
+	TOML             bool     // Frontmatter is TOML delimited by +++, which the YAML editors cannot rewrite
 }
 
 // ExtractFrontmatterFromContent parses YAML frontmatter from markdown content string
@@ -27,8 +28,12 @@ func ExtractFrontmatterFromContent(content string) (*FrontmatterResult, error) {
 	log.Printf("Extracting frontmatter from content: size=%d bytes", len(content))
 	lines := strings.Split(content, "\n")
 
-	// Check if file starts with frontmatter delimiter
-	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+	// Check if file starts with a YAML (---) or TOML (+++) frontmatter delimiter
+	delimiter := ""
+	if len(lines) > 0 {
+		delimiter = FrontmatterDelimiter(lines[0])
+	}
+	if delimiter == "" {
 		log.Print("No frontmatter delimiter found, returning content as markdown")
 		// No frontmatter, return entire content as markdown
 		return &FrontmatterResult{
@@ -42,14 +47,25 @@ func ExtractFrontmatterFromContent(content string) (*FrontmatterResult, error) {
 	// Find end of frontmatter
 	endIndex := -1
 	for i := 1; i < len(lines); i++ {
-		if strings.TrimSpace(lines[i]) == "---" {
+		if strings.TrimSpace(lines[i]) == delimiter {
 			endIndex = i
 			break
 		}
 	}
 
 	if endIndex == -1 {
-		return nil, errors.New("frontmatter not properly closed")
+		return nil, messages.NewError(messages.FrontmatterNotClosed, nil)
+	}
+
+	// Extract markdown content (everything after the closing delimiter)
+	var markdownLines []string
+	if endIndex+1 < len(lines) {
+		markdownLines = lines[endIndex+1:]
+	}
+	markdown := strings.Join(markdownLines, "\n")
+
+	if delimiter == TOMLFrontmatterDelimiter {
+		return extractTOMLFrontmatter(lines[1:endIndex], markdown)
 	}
 
 	// Extract frontmatter YAML
@@ -73,12 +89,12 @@ func ExtractFrontmatterFromContent(content string) (*FrontmatterResult, error) {
 		frontmatter = make(map[string]any)
 	}
 
-	// Extract markdown content (everything after the closing ---)
-	var markdownLines []string
-	if endIndex+1 < len(lines) {
-		markdownLines = lines[endIndex+1:]
+	// Give every alias its own copy of the anchored value and drop the x- keys that
+	// only exist to hold anchor definitions
+	if hasYAMLAnchors(frontmatterYAML) {
+		frontmatter = expandYAMLAliases(frontmatter).(map[string]any)
 	}
-	markdown := strings.Join(markdownLines, "\n")
+	removeExtensionFields(frontmatter)
 
 	log.Printf("Successfully extracted frontmatter: fields=%d, markdown_size=%d bytes", len(frontmatter), len(markdown))
 	return &FrontmatterResult{
@@ -89,6 +105,29 @@ func ExtractFrontmatterFromContent(content string) (*FrontmatterResult, error) {
 	}, nil
 }
 
+// extractTOMLFrontmatter parses TOML frontmatter into the same representation as YAML
+// frontmatter, so that schema validation and compilation do not depend on the format
+func extractTOMLFrontmatter(frontmatterLines []string, markdown string) (*FrontmatterResult, error) {
+	frontmatterTOML := strings.ReplaceAll(strings.Join(frontmatterLines, "\n"), "\u00A0", " ")
+
+	frontmatter, err := ParseTOMLFrontmatter(frontmatterTOML)
+	if err != nil {
+		// FrontmatterStart is 2 (line 2 is where frontmatter content starts after opening +++)
+		formattedErr := FormatTOMLError(err, 2, frontmatterTOML)
+		return nil, fmt.Errorf("failed to parse frontmatter:\n%s", formattedErr)
+	}
+	removeExtensionFields(frontmatter)
+
+	log.Printf("Successfully extracted TOML frontmatter: fields=%d, markdown_size=%d bytes", len(frontmatter), len(markdown))
+	return &FrontmatterResult{
+		Frontmatter:      frontmatter,
+		Markdown:         strings.TrimSpace(markdown),
+		FrontmatterLines: []string{},
+		FrontmatterStart: 2,
+		TOML:             true,
+	}, nil
+}
+
 // ExtractMarkdownSection extracts a specific section from markdown content
 // Supports H1-H3 headers and proper nesting (matches bash implementation)
 func ExtractMarkdownSection(content, sectionName string) (string, error) {
@@ -5,7 +5,6 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/messages"
 )
 
 var frontmatterHashLog = logger.New("parser:frontmatter_hash")
@@ -171,7 +171,7 @@ func ComputeFrontmatterHashFromFileWithReader(filePath string, cache *ImportCach
 // already-read file content and pre-parsed frontmatter map (may be nil).
 func computeFrontmatterHashFromContent(content string, parsedFrontmatter map[string]any, filePath string, cache *ImportCache, fileReader FileReader) (string, error) {
 	// Extract frontmatter and markdown as text (no YAML parsing)
-	frontmatterText, markdown, err := extractFrontmatterAndBodyText(content)
+	frontmatterText, markdown, delimiter, err := extractFrontmatterAndBodyText(content)
 	if err != nil {
 		return "", fmt.Errorf("failed to extract frontmatter: %w", err)
 	}
@@ -195,7 +195,7 @@ func computeFrontmatterHashFromContent(content string, parsedFrontmatter map[str
 	}
 
 	// Compute hash using text-based approach with custom file reader
-	return computeFrontmatterHashTextBasedWithReader(frontmatterText, fullBody, baseDir, cache, relevantExpressions, fileReader)
+	return computeFrontmatterHashTextBasedWithReader(frontmatterText, delimiter, fullBody, baseDir, cache, relevantExpressions, fileReader)
 }
 
 // extractRelevantTemplateExpressions extracts template expressions from markdown
@@ -233,43 +233,47 @@ func extractRelevantTemplateExpressions(markdown string) []string {
 }
 
 // extractFrontmatterAndBodyText extracts frontmatter as raw text without parsing YAML
-// Returns: frontmatterText, markdownBody, error
-func extractFrontmatterAndBodyText(content string) (string, string, error) {
+// Returns: frontmatterText, markdownBody, frontmatter delimiter ("" without frontmatter), error
+func extractFrontmatterAndBodyText(content string) (string, string, string, error) {
 	// Normalize CRLF to LF so that files with Windows line-endings produce the
 	// same frontmatter text (and therefore the same hash) as equivalent LF files.
 	content = strings.ReplaceAll(content, "\r\n", "\n")
 
 	lines := strings.Split(content, "\n")
 
-	// Check if content starts with frontmatter delimiter
-	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+	// Check if content starts with a YAML (---) or TOML (+++) frontmatter delimiter
+	delimiter := ""
+	if len(lines) > 0 {
+		delimiter = FrontmatterDelimiter(lines[0])
+	}
+	if delimiter == "" {
 		// No frontmatter
-		return "", content, nil
+		return "", content, "", nil
 	}
 
 	// Find end of frontmatter
 	endIndex := -1
 	for i := 1; i < len(lines); i++ {
-		if strings.TrimSpace(lines[i]) == "---" {
+		if strings.TrimSpace(lines[i]) == delimiter {
 			endIndex = i
 			break
 		}
 	}
 
 	if endIndex == -1 {
-		return "", "", errors.New("frontmatter not properly closed")
+		return "", "", "", messages.NewError(messages.FrontmatterNotClosed, nil)
 	}
 
-	// Extract frontmatter text (lines between --- delimiters)
+	// Extract frontmatter text (lines between the delimiters)
 	frontmatterText := strings.Join(lines[1:endIndex], "\n")
 
-	// Extract markdown body (everything after closing ---)
+	// Extract markdown body (everything after the closing delimiter)
 	var markdown string
 	if endIndex+1 < len(lines) {
 		markdown = strings.Join(lines[endIndex+1:], "\n")
 	}
 
-	return frontmatterText, markdown, nil
+	return frontmatterText, markdown, delimiter, nil
 }
 
 // normalizeFrontmatterText normalizes frontmatter text for consistent hashing
@@ -282,8 +286,12 @@ func normalizeFrontmatterText(text string) string {
 }
 
 // extractImportsFromText extracts import paths from frontmatter text using simple text parsing
-// Only extracts array items under "imports:" key
-func extractImportsFromText(frontmatterText string) []string {
+// Only extracts array items under "imports:" key; TOML frontmatter is parsed instead
+func extractImportsFromText(frontmatterText, delimiter string) []string {
+	if delimiter == TOMLFrontmatterDelimiter {
+		return extractImportsFromTOML(frontmatterText)
+	}
+
 	var imports []string
 	lines := strings.Split(frontmatterText, "\n")
 
@@ -331,14 +339,31 @@ func extractImportsFromText(frontmatterText string) []string {
 	return imports
 }
 
+// extractImportsFromTOML extracts the import paths listed by TOML frontmatter. Frontmatter that
+// does not parse has no imports; the compiler reports the syntax error.
+func extractImportsFromTOML(frontmatterText string) []string {
+	frontmatter, err := ParseTOMLFrontmatter(frontmatterText)
+	if err != nil {
+		return nil
+	}
+	items, _ := frontmatter["imports"].([]any)
+	var imports []string
+	for _, item := range items {
+		if importPath, ok := item.(string); ok && importPath != "" {
+			imports = append(imports, importPath)
+		}
+	}
+	return imports
+}
+
 // processImportsTextBased processes imports from frontmatter using text-based parsing
 // Returns: importedFiles (list of import paths), importedFrontmatterTexts (list of frontmatter texts)
-func processImportsTextBased(frontmatterText, baseDir string, visited map[string]bool, fileReader FileReader) ([]string, []string, error) {
+func processImportsTextBased(frontmatterText, delimiter, baseDir string, visited map[string]bool, fileReader FileReader) ([]string, []string, error) {
 	var importedFiles []string
 	var importedFrontmatterTexts []string
 
 	// Extract imports from frontmatter text
-	imports := extractImportsFromText(frontmatterText)
+	imports := extractImportsFromText(frontmatterText, delimiter)
 
 	if len(imports) == 0 {
 		return importedFiles, importedFrontmatterTexts, nil
@@ -365,7 +390,7 @@ func processImportsTextBased(frontmatterText, baseDir string, visited map[string
 		}
 
 		// Extract frontmatter text from imported file
-		importFrontmatterText, _, err := extractFrontmatterAndBodyText(string(content))
+		importFrontmatterText, _, importDelimiter, err := extractFrontmatterAndBodyText(string(content))
 		if err != nil {
 			// Skip files with invalid frontmatter
 			continue
@@ -377,7 +402,7 @@ func processImportsTextBased(frontmatterText, baseDir string, visited map[string
 
 		// Recursively process imports in the imported file
 		importBaseDir := filepath.Dir(fullPath)
-		nestedFiles, nestedTexts, err := processImportsTextBased(importFrontmatterText, importBaseDir, visited, fileReader)
+		nestedFiles, nestedTexts, err := processImportsTextBased(importFrontmatterText, importDelimiter, importBaseDir, visited, fileReader)
 		if err != nil {
 			// Continue processing other imports even if one fails
 			continue
@@ -394,12 +419,12 @@ func processImportsTextBased(frontmatterText, baseDir string, visited map[string
 // computeFrontmatterHashTextBasedWithReader computes the hash using text-based approach with custom file reader.
 // When markdown is non-empty, it is included as the full body text in the canonical data (used for
 // inlined-imports mode where the entire body is compiled into the lock file).
-func computeFrontmatterHashTextBasedWithReader(frontmatterText, markdown, baseDir string, cache *ImportCache, expressions []string, fileReader FileReader) (string, error) {
+func computeFrontmatterHashTextBasedWithReader(frontmatterText, delimiter, markdown, baseDir string, cache *ImportCache, expressions []string, fileReader FileReader) (string, error) {
 	frontmatterHashLog.Print("Computing frontmatter hash using text-based approach")
 
 	// Process imports using text-based parsing with custom file reader
 	visited := make(map[string]bool)
-	importedFiles, importedFrontmatterTexts, err := processImportsTextBased(frontmatterText, baseDir, visited, fileReader)
+	importedFiles, importedFrontmatterTexts, err := processImportsTextBased(frontmatterText, delimiter, baseDir, visited, fileReader)
 	if err != nil {
 		return "", fmt.Errorf("failed to process imports: %w", err)
 	}
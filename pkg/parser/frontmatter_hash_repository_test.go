@@ -64,18 +64,14 @@ func TestAllRepositoryWorkflowHashes(t *testing.T) {
 	t.Logf("\nSuccessfully computed hashes for %d workflows", len(hashMap))
 
 	// Write hash reference file for cross-language validation
-	referenceFile := filepath.Join(repoRoot, "tmp", "workflow-hashes-reference.txt")
-	tmpDir := filepath.Dir(referenceFile)
-	if err := os.MkdirAll(tmpDir, 0755); err == nil {
-		f, err := os.Create(referenceFile)
-		if err == nil {
-			defer f.Close()
-			for name, hash := range hashMap {
-				f.WriteString(name + ": " + hash + "\n")
-			}
-			t.Logf("\nWrote hash reference to: %s", referenceFile)
-		}
+	referenceFile := filepath.Join(t.TempDir(), "workflow-hashes-reference.txt")
+	f, err := os.Create(referenceFile)
+	require.NoError(t, err, "Should create hash reference file")
+	defer f.Close()
+	for name, hash := range hashMap {
+		f.WriteString(name + ": " + hash + "\n")
 	}
+	t.Logf("\nWrote hash reference to: %s", referenceFile)
 }
 
 // TestHashConsistencyAcrossLockFiles validates that hashes in lock files
@@ -6,12 +6,12 @@ package parser
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"maps"
 	"path"
 	"strings"
 
+	"github.com/github/gh-aw/pkg/messages"
 	"github.com/goccy/go-yaml"
 )
 
@@ -38,23 +38,23 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 				// Object import with path and optional inputs
 				pathValue, hasPath := importItem["path"]
 				if !hasPath {
-					return nil, errors.New("import object must have a 'path' field")
+					return nil, messages.NewError(messages.ImportObjectMissingPath, nil)
 				}
 				pathStr, ok := pathValue.(string)
 				if !ok {
-					return nil, errors.New("import 'path' must be a string")
+					return nil, messages.NewError(messages.ImportPathNotString, nil)
 				}
 				var inputs map[string]any
 				if inputsValue, hasInputs := importItem["inputs"]; hasInputs {
 					if inputsMap, ok := inputsValue.(map[string]any); ok {
 						inputs = inputsMap
 					} else {
-						return nil, errors.New("import 'inputs' must be an object")
+						return nil, messages.NewError(messages.ImportInputsNotObject, nil)
 					}
 				}
 				importSpecs = append(importSpecs, ImportSpec{Path: pathStr, Inputs: inputs})
 			default:
-				return nil, errors.New("import item must be a string or an object with 'path' field")
+				return nil, messages.NewError(messages.ImportItemInvalidType, nil)
 			}
 		}
 	case []string:
@@ -62,7 +62,7 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 			importSpecs = append(importSpecs, ImportSpec{Path: s})
 		}
 	default:
-		return nil, errors.New("imports field must be an array of strings or objects")
+		return nil, messages.NewError(messages.ImportsInvalidType, nil)
 	}
 
 	if len(importSpecs) == 0 {
@@ -129,11 +129,11 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 					FilePath:   workflowFilePath,
 					Line:       line,
 					Column:     column,
-					Cause:      errors.New("cannot import .lock.yml files. Lock files are compiled outputs from gh-aw. Import the source .md file instead"),
+					Cause:      messages.NewError(messages.ImportLockFile, messages.Args{"path": importPath}),
 				}
 				return nil, FormatImportError(importErr, yamlContent)
 			}
-			return nil, fmt.Errorf("cannot import .lock.yml files: '%s'. Lock files are compiled outputs from gh-aw. Import the source .md file instead", importPath)
+			return nil, messages.NewError(messages.ImportLockFile, messages.Args{"path": importPath})
 		}
 
 		// Track remote origin for workflowspec imports so nested relative imports
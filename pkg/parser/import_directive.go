@@ -9,9 +9,9 @@ import (
 
 var importDirectiveLog = logger.New("parser:import_directive")
 
-// IncludeDirectivePattern matches @include, @import (deprecated), or {{#import (new) directives
-// The colon after #import is optional and ignored if present
-var IncludeDirectivePattern = regexp.MustCompile(`^(?:@(?:include|import)(\?)?\s+(.+)|{{#import(\?)?\s*:?\s*(.+?)\s*}})$`)
+// IncludeDirectivePattern matches @include, @import (deprecated), or {{#import / {{#include (new) directives
+// The colon after #import or #include is optional and ignored if present
+var IncludeDirectivePattern = regexp.MustCompile(`^(?:@(?:include|import)(\?)?\s+(.+)|{{#(?:import|include)(\?)?\s*:?\s*(.+?)\s*}})$`)
 
 // LegacyIncludeDirectivePattern matches only the deprecated @include and @import directives
 var LegacyIncludeDirectivePattern = regexp.MustCompile(`^@(?:include|import)(\?)?\s+(.+)$`)
@@ -22,6 +22,7 @@ type ImportDirectiveMatch struct {
 	Path       string
 	IsLegacy   bool
 	Original   string
+	Params     map[string]string // Include parameters (key="value"), nil when the directive has none
 }
 
 // ParseImportDirective parses an import directive and returns its components
@@ -40,6 +41,7 @@ func ParseImportDirective(line string) *ImportDirectiveMatch {
 
 	var isOptional bool
 	var path string
+	var params map[string]string
 
 	if isLegacy {
 		// Legacy syntax: @include? path or @import? path
@@ -47,10 +49,11 @@ func ParseImportDirective(line string) *ImportDirectiveMatch {
 		isOptional = matches[1] == "?"
 		path = strings.TrimSpace(matches[2])
 	} else {
-		// New syntax: {{#import?: path}} or {{#import: path}} (colon is optional)
-		// Group 3: optional marker, Group 4: path
+		// New syntax: {{#import?: path}} or {{#import: path}} (colon is optional),
+		// optionally followed by include parameters: {{#include path key="value"}}
+		// Group 3: optional marker, Group 4: path and parameters
 		isOptional = matches[3] == "?"
-		path = strings.TrimSpace(matches[4])
+		path, params = splitIncludeParams(strings.TrimSpace(matches[4]))
 	}
 
 	match := &ImportDirectiveMatch{
@@ -58,7 +61,8 @@ func ParseImportDirective(line string) *ImportDirectiveMatch {
 		Path:       path,
 		IsLegacy:   isLegacy,
 		Original:   trimmedLine,
+		Params:     params,
 	}
-	importDirectiveLog.Printf("Parsed import directive: path=%s, optional=%t, legacy=%t", path, isOptional, isLegacy)
+	importDirectiveLog.Printf("Parsed import directive: path=%s, optional=%t, legacy=%t, params=%d", path, isOptional, isLegacy, len(params))
 	return match
 }
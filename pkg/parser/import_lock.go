@@ -0,0 +1,152 @@
+//go:build !js && !wasm
+
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/github/gh-aw/pkg/gitutil"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var importLockLog = logger.New("parser:import_lock")
+
+// ImportLockPath is the location of the remote import lock file, relative to the git root
+const ImportLockPath = ".aw/imports.lock"
+
+// importLockHeader is written above the entries of the lock file
+const importLockHeader = "# Content hashes of remote imports, verified by gh aw compile.\n# Run 'gh aw update' to accept changed remote content.\n"
+
+// RemoteImportPolicy is the trust policy applied to imports fetched from other repositories
+type RemoteImportPolicy struct {
+	RequirePinned bool   // Imports must reference a full 40-character commit SHA
+	LockFile      string // Path of the lock file recording content hashes; empty disables verification
+}
+
+// ImportLock records the content hash of every remote import, keyed by its workflowspec
+// (owner/repo/path@ref)
+type ImportLock struct {
+	Imports map[string]string `yaml:"imports"`
+}
+
+var (
+	remoteImportPolicyMu sync.Mutex
+	remoteImportPolicy   RemoteImportPolicy
+	// acceptRemoteImportChanges makes verification record new and changed content instead of failing
+	acceptRemoteImportChanges bool
+)
+
+// SetRemoteImportPolicy sets the trust policy for remote imports of subsequent compilations
+func SetRemoteImportPolicy(policy RemoteImportPolicy) {
+	remoteImportPolicyMu.Lock()
+	defer remoteImportPolicyMu.Unlock()
+	importLockLog.Printf("Setting remote import policy: require_pinned=%v, lock_file=%s", policy.RequirePinned, policy.LockFile)
+	remoteImportPolicy = policy
+}
+
+// SetAcceptRemoteImportChanges controls whether new or changed remote content is recorded in the
+// lock file (gh aw update) or fails compilation (the default)
+func SetAcceptRemoteImportChanges(accept bool) {
+	remoteImportPolicyMu.Lock()
+	defer remoteImportPolicyMu.Unlock()
+	acceptRemoteImportChanges = accept
+}
+
+// LoadImportLock reads an import lock file. A missing file yields an empty lock.
+func LoadImportLock(path string) (*ImportLock, error) {
+	lock := &ImportLock{Imports: make(map[string]string)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return lock, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", ImportLockPath, err)
+	}
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ImportLockPath, err)
+	}
+	if lock.Imports == nil {
+		lock.Imports = make(map[string]string)
+	}
+	return lock, nil
+}
+
+// Save writes the import lock file, creating its directory if needed
+func (l *ImportLock) Save(path string) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", ImportLockPath, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append([]byte(importLockHeader), data...), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ImportLockPath, err)
+	}
+	return nil
+}
+
+// importContentHash returns the hash recorded for remote import content
+func importContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// checkRemoteImportRef enforces the require-pinned policy on the ref of a remote import
+func checkRemoteImportRef(spec, ref string) error {
+	remoteImportPolicyMu.Lock()
+	requirePinned := remoteImportPolicy.RequirePinned
+	remoteImportPolicyMu.Unlock()
+
+	if !requirePinned || (len(ref) == 40 && gitutil.IsHexString(ref)) {
+		return nil
+	}
+	return fmt.Errorf("remote import %s must be pinned to a full 40-character commit SHA, got ref '%s' (remote-imports: require-pinned in .aw/config.yml)", spec, ref)
+}
+
+// verifyRemoteImport checks remote import content against the hash recorded in the lock file.
+// Imports without an entry and changed content fail unless changes are being accepted by
+// gh aw update, so compilation never writes the lock file.
+func verifyRemoteImport(spec string, content []byte) error {
+	remoteImportPolicyMu.Lock()
+	lockFile := remoteImportPolicy.LockFile
+	accept := acceptRemoteImportChanges
+	remoteImportPolicyMu.Unlock()
+
+	if lockFile == "" {
+		return nil
+	}
+
+	key, _, _ := strings.Cut(spec, "#")
+	hash := importContentHash(content)
+
+	lock, err := LoadImportLock(lockFile)
+	if err != nil {
+		return err
+	}
+	recorded, exists := lock.Imports[key]
+	if recorded == hash {
+		importLockLog.Printf("Verified remote import: %s", key)
+		return nil
+	}
+	if !accept {
+		if !exists {
+			return fmt.Errorf("remote import %s is not recorded in %s (fetched %s). Review the imported content and run 'gh aw update' to record it",
+				key, ImportLockPath, hash)
+		}
+		return fmt.Errorf("content of remote import %s changed since it was recorded in %s (recorded %s, fetched %s). Review the upstream change and run 'gh aw update' to accept it",
+			key, ImportLockPath, recorded, hash)
+	}
+
+	importLockLog.Printf("Recording remote import: %s (%s)", key, hash)
+	lock.Imports[key] = hash
+	return lock.Save(lockFile)
+}
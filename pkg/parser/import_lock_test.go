@@ -0,0 +1,102 @@
+//go:build !integration
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRemoteImportRef(t *testing.T) {
+	t.Cleanup(func() { SetRemoteImportPolicy(RemoteImportPolicy{}) })
+	const sha = "08c6903cd8c0fde910a37f88322edcfb5dd907a8"
+
+	SetRemoteImportPolicy(RemoteImportPolicy{})
+	require.NoError(t, checkRemoteImportRef("owner/repo/shared/tools.md", "main"), "refs should not be checked without the policy")
+
+	SetRemoteImportPolicy(RemoteImportPolicy{RequirePinned: true})
+	require.NoError(t, checkRemoteImportRef("owner/repo/shared/tools.md", sha), "full commit SHAs should be accepted")
+	for _, ref := range []string{"main", "v1.2.0", sha[:12]} {
+		err := checkRemoteImportRef("owner/repo/shared/tools.md", ref)
+		require.Error(t, err, "ref %s should be rejected", ref)
+		assert.Contains(t, err.Error(), "must be pinned to a full 40-character commit SHA", "error should explain the policy")
+	}
+}
+
+func TestVerifyRemoteImport(t *testing.T) {
+	t.Cleanup(func() {
+		SetRemoteImportPolicy(RemoteImportPolicy{})
+		SetAcceptRemoteImportChanges(false)
+	})
+	lockFile := filepath.Join(t.TempDir(), ".aw", "imports.lock")
+	const spec = "owner/repo/shared/tools.md@v1"
+
+	SetRemoteImportPolicy(RemoteImportPolicy{})
+	require.NoError(t, verifyRemoteImport(spec, []byte("a")), "content should not be verified without a lock file")
+	assert.NoFileExists(t, lockFile, "lock file should not be written without the policy")
+
+	SetRemoteImportPolicy(RemoteImportPolicy{LockFile: lockFile})
+	err := verifyRemoteImport(spec+"#Tools", []byte("original"))
+	require.Error(t, err, "unrecorded import should fail")
+	assert.Contains(t, err.Error(), "is not recorded in .aw/imports.lock", "error should explain the missing entry")
+	assert.Contains(t, err.Error(), "gh aw update", "error should point at gh aw update")
+	assert.NoFileExists(t, lockFile, "compilation should not write the lock file")
+
+	SetAcceptRemoteImportChanges(true)
+	require.NoError(t, verifyRemoteImport(spec+"#Tools", []byte("original")), "update should record the import")
+	SetAcceptRemoteImportChanges(false)
+	lock, err := LoadImportLock(lockFile)
+	require.NoError(t, err, "lock file should load")
+	assert.Equal(t, map[string]string{spec: importContentHash([]byte("original"))}, lock.Imports, "import should be recorded without the section")
+
+	require.NoError(t, verifyRemoteImport(spec, []byte("original")), "unchanged content should verify")
+
+	err = verifyRemoteImport(spec, []byte("changed"))
+	require.Error(t, err, "changed content should fail")
+	assert.Contains(t, err.Error(), "changed since it was recorded in .aw/imports.lock", "error should explain the change")
+	assert.Contains(t, err.Error(), "gh aw update", "error should point at gh aw update")
+
+	SetAcceptRemoteImportChanges(true)
+	require.NoError(t, verifyRemoteImport(spec, []byte("changed")), "update should accept changed content")
+	lock, err = LoadImportLock(lockFile)
+	require.NoError(t, err, "lock file should load")
+	assert.Equal(t, importContentHash([]byte("changed")), lock.Imports[spec], "accepted content should replace the hash")
+
+	data, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "lock file should be readable")
+	assert.Contains(t, string(data), "# Content hashes of remote imports", "lock file should start with its header")
+}
+
+func TestRemoteImportLockWithCache(t *testing.T) {
+	t.Cleanup(func() {
+		SetRemoteImportPolicy(RemoteImportPolicy{})
+		SetAcceptRemoteImportChanges(false)
+	})
+	const sha = "08c6903cd8c0fde910a37f88322edcfb5dd907a8"
+	repoRoot := t.TempDir()
+	lockFile := filepath.Join(repoRoot, ImportLockPath)
+	cache := NewImportCache(repoRoot)
+	cachedPath, err := cache.Set("owner", "repo", "shared/tools.md", sha, []byte("tools"))
+	require.NoError(t, err, "should seed the import cache")
+
+	SetRemoteImportPolicy(RemoteImportPolicy{RequirePinned: true, LockFile: lockFile})
+	_, err = ResolveIncludePath("owner/repo/shared/tools.md@"+sha, repoRoot, cache)
+	require.Error(t, err, "unrecorded cached import should fail verification")
+	assert.NoFileExists(t, lockFile, "compilation should not record the import")
+
+	SetAcceptRemoteImportChanges(true)
+	path, err := ResolveIncludePath("owner/repo/shared/tools.md@"+sha, repoRoot, cache)
+	require.NoError(t, err, "pinned cached import should resolve while recording")
+	assert.Equal(t, cachedPath, path, "cached file should be used")
+	assert.FileExists(t, lockFile, "import should be recorded")
+	SetAcceptRemoteImportChanges(false)
+
+	require.NoError(t, os.WriteFile(cachedPath, []byte("tampered"), 0644), "should modify the cached import")
+	_, err = ResolveIncludePath("owner/repo/shared/tools.md@"+sha, repoRoot, cache)
+	require.Error(t, err, "modified cached import should fail verification")
+	assert.Contains(t, err.Error(), "changed since it was recorded", "error should explain the change")
+}
@@ -83,3 +83,9 @@ func ProcessImportsFromFrontmatterWithSource(frontmatter map[string]any, baseDir
 	}
 	return result, nil
 }
+
+// IsWorkflowSpec reports whether an import path refers to a remote workflowspec
+// (owner/repo/path[@ref]) rather than a file in the repository
+func IsWorkflowSpec(path string) bool {
+	return isWorkflowSpec(path)
+}
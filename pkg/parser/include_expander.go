@@ -47,6 +47,10 @@ func ExpandIncludesWithManifest(content, baseDir string, extractTools bool) (str
 	// Convert visited map to slice of file paths (make them relative to baseDir if possible)
 	var includedFiles []string
 	for filePath := range visited {
+		// Skip parameterized include instances, their path is recorded separately
+		if strings.Contains(filePath, includeInstanceSeparator) {
+			continue
+		}
 		// Try to make path relative to baseDir for cleaner output
 		relPath, err := filepath.Rel(baseDir, filePath)
 		if err == nil && !strings.HasPrefix(relPath, "..") {
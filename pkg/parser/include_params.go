@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var includeParamsLog = logger.New("parser:include_params")
+
+// IncludeParamExpressionPattern matches ${{ github.aw.params.<key> }} placeholders in included markdown
+var IncludeParamExpressionPattern = regexp.MustCompile(`\$\{\{\s*github\.aw\.params\.([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+// includeParamKeyPattern matches a valid include parameter name
+var includeParamKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*`)
+
+// splitIncludeParams splits the target of a {{#include}} directive into the include path and its
+// parameters. When the text after the path does not parse as parameters, the whole value is
+// treated as the path so existing directives keep their meaning.
+func splitIncludeParams(value string) (string, map[string]string) {
+	index := strings.IndexAny(value, " \t")
+	if index < 0 {
+		return value, nil
+	}
+
+	params, err := parseIncludeParams(value[index+1:])
+	if err != nil {
+		includeParamsLog.Printf("Treating %q as an include path: %v", value, err)
+		return value, nil
+	}
+	return value[:index], params
+}
+
+// parseIncludeParams parses whitespace separated key=value and key="quoted value" pairs.
+// Quoted values support \" and \\ escapes.
+func parseIncludeParams(text string) (map[string]string, error) {
+	params := make(map[string]string)
+	rest := strings.TrimLeft(text, " \t")
+	for rest != "" {
+		key := includeParamKeyPattern.FindString(rest)
+		if key == "" || !strings.HasPrefix(rest[len(key):], "=") {
+			return nil, fmt.Errorf("expected key=value, got %q", rest)
+		}
+		if _, exists := params[key]; exists {
+			return nil, fmt.Errorf("duplicate include parameter '%s'", key)
+		}
+		rest = rest[len(key)+1:]
+
+		var value strings.Builder
+		if strings.HasPrefix(rest, `"`) {
+			closed := false
+			i := 1
+			for ; i < len(rest); i++ {
+				switch rest[i] {
+				case '\\':
+					if i+1 < len(rest) && (rest[i+1] == '"' || rest[i+1] == '\\') {
+						i++
+					}
+					value.WriteByte(rest[i])
+				case '"':
+					closed = true
+				default:
+					value.WriteByte(rest[i])
+				}
+				if closed {
+					break
+				}
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated quoted value for include parameter '%s'", key)
+			}
+			rest = rest[i+1:]
+			if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+				return nil, fmt.Errorf("expected whitespace after include parameter '%s'", key)
+			}
+		} else {
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 || strings.Contains(rest[:end], `"`) {
+				return nil, fmt.Errorf("invalid value for include parameter '%s'", key)
+			}
+			value.WriteString(rest[:end])
+			rest = rest[end:]
+		}
+
+		params[key] = value.String()
+		rest = strings.TrimLeft(rest, " \t")
+	}
+	return params, nil
+}
+
+// FormatParams renders the directive parameters back into directive syntax, in key order,
+// with a leading space (e.g., ` depth="2" title="Security review"`). It returns an empty
+// string when the directive has no parameters.
+func (m *ImportDirectiveMatch) FormatParams() string {
+	keys := make([]string, 0, len(m.Params))
+	for key := range m.Params {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var builder strings.Builder
+	for _, key := range keys {
+		escaped := strings.ReplaceAll(strings.ReplaceAll(m.Params[key], `\`, `\\`), `"`, `\"`)
+		fmt.Fprintf(&builder, ` %s="%s"`, key, escaped)
+	}
+	return builder.String()
+}
+
+// includeInstanceKey identifies one instantiation of an included file for repeated include
+// detection. Includes without parameters are keyed by their path so the included files
+// manifest keeps listing plain paths; parameterized includes are keyed by path and parameters
+// so the same snippet can be included several times with different values.
+func includeInstanceKey(fullPath string, directive *ImportDirectiveMatch) string {
+	if len(directive.Params) == 0 {
+		return fullPath
+	}
+	return fullPath + includeInstanceSeparator + directive.FormatParams()
+}
+
+// includeInstanceSeparator separates the path from the parameters in an include instance key
+const includeInstanceSeparator = "\x00"
+
+// substituteIncludeParams replaces ${{ github.aw.params.<key> }} placeholders in included
+// markdown with the include parameters.
+//
+// Escaping rules: values are inserted as literal text and cannot contain "{{" or "}}", so a
+// parameter cannot inject GitHub Actions expressions, template conditionals or further
+// import directives into the prompt. Every placeholder must have a parameter and every
+// parameter must be used, which catches typos on either side.
+func substituteIncludeParams(content string, params map[string]string, filePath string) (string, error) {
+	for key, value := range params {
+		if strings.Contains(value, "{{") || strings.Contains(value, "}}") {
+			return "", fmt.Errorf("include parameter '%s' for %s cannot contain '{{' or '}}': parameters are inserted as plain text", key, filePath)
+		}
+	}
+
+	var missing []string
+	used := make(map[string]bool)
+	substituted := IncludeParamExpressionPattern.ReplaceAllStringFunc(content, func(match string) string {
+		key := IncludeParamExpressionPattern.FindStringSubmatch(match)[1]
+		value, ok := params[key]
+		if !ok {
+			if !slices.Contains(missing, key) {
+				missing = append(missing, key)
+			}
+			return match
+		}
+		used[key] = true
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%s requires include parameter(s) %s. Example: {{#include %s %s=\"...\"}}", filePath, strings.Join(missing, ", "), filePath, missing[0])
+	}
+
+	var unused []string
+	for key := range params {
+		if !used[key] {
+			unused = append(unused, key)
+		}
+	}
+	if len(unused) > 0 {
+		slices.Sort(unused)
+		return "", fmt.Errorf("unknown include parameter(s) %s: %s does not reference them with ${{ github.aw.params.<name> }}", strings.Join(unused, ", "), filePath)
+	}
+
+	if len(params) > 0 {
+		includeParamsLog.Printf("Substituted %d include parameters in %s", len(params), filePath)
+	}
+	return substituted, nil
+}
@@ -0,0 +1,151 @@
+//go:build !integration
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImportDirectiveParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantPath   string
+		wantParams map[string]string
+	}{
+		{
+			name:     "include without parameters",
+			input:    "{{#include snippets/review.md}}",
+			wantPath: "snippets/review.md",
+		},
+		{
+			name:       "quoted and bare parameters",
+			input:      `{{#include snippets/review.md title="Security review" depth=2}}`,
+			wantPath:   "snippets/review.md",
+			wantParams: map[string]string{"title": "Security review", "depth": "2"},
+		},
+		{
+			name:       "optional include with section and colon",
+			input:      `{{#include?: snippets/review.md#Checklist title="Review"}}`,
+			wantPath:   "snippets/review.md#Checklist",
+			wantParams: map[string]string{"title": "Review"},
+		},
+		{
+			name:       "import accepts parameters too",
+			input:      `{{#import snippets/review.md focus=auth}}`,
+			wantPath:   "snippets/review.md",
+			wantParams: map[string]string{"focus": "auth"},
+		},
+		{
+			name:       "escaped quotes and backslashes",
+			input:      `{{#include snippets/review.md title="Say \"hi\" C:\\tmp"}}`,
+			wantPath:   "snippets/review.md",
+			wantParams: map[string]string{"title": `Say "hi" C:\tmp`},
+		},
+		{
+			name:     "text that is not parameters stays part of the path",
+			input:    `{{#include snippets/my review.md}}`,
+			wantPath: "snippets/my review.md",
+		},
+		{
+			name:     "unterminated quote stays part of the path",
+			input:    `{{#include snippets/review.md title="Security}}`,
+			wantPath: `snippets/review.md title="Security`,
+		},
+		{
+			name:     "duplicate parameter stays part of the path",
+			input:    `{{#include snippets/review.md a=1 a=2}}`,
+			wantPath: "snippets/review.md a=1 a=2",
+		},
+		{
+			name:     "legacy syntax does not take parameters",
+			input:    `@include snippets/review.md title=x`,
+			wantPath: "snippets/review.md title=x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directive := ParseImportDirective(tt.input)
+			require.NotNil(t, directive, "directive should match")
+			assert.Equal(t, tt.wantPath, directive.Path, "path")
+			assert.Equal(t, tt.wantParams, directive.Params, "params")
+		})
+	}
+}
+
+func TestImportDirectiveFormatParams(t *testing.T) {
+	directive := ParseImportDirective(`{{#include review.md title="Say \"hi\"" depth=2}}`)
+	require.NotNil(t, directive, "directive should match")
+	assert.Equal(t, ` depth="2" title="Say \"hi\""`, directive.FormatParams(), "params should be sorted and quoted")
+
+	reparsed := ParseImportDirective("{{#include review.md" + directive.FormatParams() + "}}")
+	require.NotNil(t, reparsed, "formatted directive should match")
+	assert.Equal(t, directive.Params, reparsed.Params, "formatted params should round-trip")
+
+	assert.Empty(t, ParseImportDirective("{{#include review.md}}").FormatParams(), "no params formats to nothing")
+}
+
+func TestSubstituteIncludeParams(t *testing.T) {
+	content := "## ${{ github.aw.params.title }}\n\nReview up to ${{github.aw.params.depth}} levels. ${{ github.event.issue.number }}\n"
+
+	result, err := substituteIncludeParams(content, map[string]string{"title": "Security review", "depth": "2"}, "review.md")
+	require.NoError(t, err, "substitution should succeed")
+	assert.Equal(t, "## Security review\n\nReview up to 2 levels. ${{ github.event.issue.number }}\n", result, "other expressions are left alone")
+
+	_, err = substituteIncludeParams(content, map[string]string{"title": "Security review"}, "review.md")
+	require.Error(t, err, "missing parameter should fail")
+	assert.Contains(t, err.Error(), "requires include parameter(s) depth", "error should name the missing parameter")
+
+	_, err = substituteIncludeParams(content, map[string]string{"title": "x", "depth": "1", "dept": "2"}, "review.md")
+	require.Error(t, err, "unknown parameter should fail")
+	assert.Contains(t, err.Error(), "unknown include parameter(s) dept", "error should name the unknown parameter")
+
+	for _, value := range []string{"${{ secrets.TOKEN }}", "{{#import other.md}}", "a }} b"} {
+		_, err = substituteIncludeParams(content, map[string]string{"title": value, "depth": "1"}, "review.md")
+		require.Error(t, err, "value %q should be rejected", value)
+		assert.Contains(t, err.Error(), "cannot contain '{{' or '}}'", "error should explain the escaping rule")
+	}
+}
+
+func TestExpandIncludesWithParams(t *testing.T) {
+	tempDir := t.TempDir()
+	snippetsDir := filepath.Join(tempDir, "snippets")
+	require.NoError(t, os.MkdirAll(snippetsDir, 0755), "should create snippets dir")
+	require.NoError(t, os.WriteFile(filepath.Join(snippetsDir, "review.md"), []byte(`---
+description: Review checklist
+---
+
+## ${{ github.aw.params.title }}
+
+Review up to ${{ github.aw.params.depth }} levels deep.
+`), 0644), "should write snippet")
+
+	content := `# Main
+
+{{#include snippets/review.md title="Security review" depth=2}}
+{{#include snippets/review.md title="Performance review" depth=1}}
+{{#include snippets/review.md title="Security review" depth=2}}
+`
+	expanded, files, err := ExpandIncludesWithManifest(content, tempDir, false)
+	require.NoError(t, err, "expansion should succeed")
+	assert.Equal(t, `# Main
+
+## Security review
+
+Review up to 2 levels deep.
+## Performance review
+
+Review up to 1 levels deep.
+`, expanded, "each distinct parameter set should be expanded once")
+	assert.Equal(t, []string{"snippets/review.md"}, files, "manifest should list the snippet path once")
+
+	_, _, err = ExpandIncludesWithManifest("{{#include snippets/review.md title=x}}\n", tempDir, false)
+	require.Error(t, err, "missing parameter should fail expansion")
+	assert.Contains(t, err.Error(), "requires include parameter(s) depth", "error should name the missing parameter")
+}
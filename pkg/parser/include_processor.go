@@ -71,8 +71,10 @@ func processIncludesWithVisited(content, baseDir string, extractTools bool, visi
 				return "", fmt.Errorf("failed to resolve required include '%s': %w", filePath, err)
 			}
 
-			// Check for repeated imports using the resolved full path
-			if visited[fullPath] {
+			// Check for repeated imports using the resolved full path (and parameters, so a
+			// snippet can be included several times with different values)
+			instanceKey := includeInstanceKey(fullPath, directive)
+			if visited[instanceKey] {
 				includeLog.Printf("Skipping already included file: %s", fullPath)
 				if !extractTools {
 					fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Already included: %s, skipping", filePath)))
@@ -82,6 +84,7 @@ func processIncludesWithVisited(content, baseDir string, extractTools bool, visi
 
 			// Mark as visited using the resolved full path
 			includeLog.Printf("Processing include file: %s", fullPath)
+			visited[instanceKey] = true
 			visited[fullPath] = true
 
 			// Process the included file
@@ -91,6 +94,14 @@ func processIncludesWithVisited(content, baseDir string, extractTools bool, visi
 				return "", fmt.Errorf("failed to process included file '%s': %w", fullPath, err)
 			}
 
+			// Substitute include parameters (tools extraction only reads frontmatter)
+			if !extractTools {
+				includedContent, err = substituteIncludeParams(includedContent, directive.Params, filePath)
+				if err != nil {
+					return "", err
+				}
+			}
+
 			if extractTools {
 				// For tools mode, add each JSON on a separate line
 				result.WriteString(includedContent + "\n")
@@ -107,6 +107,14 @@ func LocateJSONPathInYAMLWithAdditionalProperties(yamlContent string, jsonPath s
 	return LocateJSONPathInYAML(yamlContent, jsonPath)
 }
 
+// locateJSONPathInFrontmatter finds the position of a JSON path in YAML or TOML frontmatter
+func locateJSONPathInFrontmatter(frontmatterContent string, isTOML bool, jsonPath string, errorMessage string) JSONPathLocation {
+	if isTOML {
+		return LocateJSONPathInTOMLWithAdditionalProperties(frontmatterContent, jsonPath, errorMessage)
+	}
+	return LocateJSONPathInYAMLWithAdditionalProperties(frontmatterContent, jsonPath, errorMessage)
+}
+
 // findPathInYAMLLines finds a JSON path in YAML content using line-by-line analysis
 func findPathInYAMLLines(yamlContent string, pathSegments []PathSegment) JSONPathLocation {
 	lines := strings.Split(yamlContent, "\n")
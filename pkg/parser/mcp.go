@@ -23,7 +23,7 @@ var ValidMCPTypes = []string{"stdio", "http", "local"}
 // Returns true for "stdio", "http", and "local" (which is an alias for "stdio").
 func IsMCPType(typeStr string) bool {
 	switch typeStr {
-	case "stdio", "http", "local":
+	case "stdio", "http", "local", "docker":
 		return true
 	default:
 		return false
@@ -511,6 +511,12 @@ func ParseMCPConfig(toolName string, mcpSection any, toolConfig map[string]any)
 		return config, fmt.Errorf("mcp configuration must be a map or JSON string, got %T. Example:\nmcp-servers:\n  %s:\n    command: \"npx @my/tool\"\n    args: [\"--port\", \"3000\"]", v, toolName)
 	}
 
+	// Docker servers are containerized stdio servers with generated runtime arguments
+	mcpConfig, err := ExpandDockerMCPConfig(toolName, mcpConfig)
+	if err != nil {
+		return config, err
+	}
+
 	// Extract type (explicit or inferred)
 	if typeVal, hasType := mcpConfig["type"]; hasType {
 		if typeStr, ok := typeVal.(string); ok {
@@ -598,6 +604,15 @@ func ParseMCPConfig(toolName string, mcpSection any, toolConfig map[string]any)
 					}
 				}
 
+				// Add docker runtime args before the image
+				if args, hasArgs := mcpConfig["args"].([]any); hasArgs {
+					for _, arg := range args {
+						if argStr, ok := arg.(string); ok {
+							config.Args = append(config.Args, argStr)
+						}
+					}
+				}
+
 				// Add entrypoint override if specified
 				if entrypoint, hasEntrypoint := mcpConfig["entrypoint"]; hasEntrypoint {
 					if entrypointStr, ok := entrypoint.(string); ok {
@@ -724,9 +739,39 @@ func ParseMCPConfig(toolName string, mcpSection any, toolConfig map[string]any)
 			}
 		}
 
+		// Extract OAuth token acquisition settings
+		if oauth, hasOAuth := mcpConfig["oauth"].(map[string]any); hasOAuth {
+			config.OAuth = parseMCPOAuth(oauth)
+		}
+
 	default:
 		return config, fmt.Errorf("unsupported MCP type '%s' for tool '%s'. Valid types are: stdio, http. Example:\nmcp-servers:\n  %s:\n    type: stdio\n    command: \"npx @my/tool\"\n    args: [\"--port\", \"3000\"]", config.Type, toolName, toolName)
 	}
 
 	return config, nil
 }
+
+// parseMCPOAuth extracts the oauth section of an HTTP MCP server. Validation happens in
+// the compiler, so fields with unexpected types are ignored here.
+func parseMCPOAuth(oauth map[string]any) *types.MCPOAuthConfig {
+	str := func(key string) string {
+		value, _ := oauth[key].(string)
+		return value
+	}
+	config := &types.MCPOAuthConfig{
+		TokenURL:               str("token-url"),
+		DeviceAuthorizationURL: str("device-authorization-url"),
+		ClientID:               str("client-id"),
+		ClientSecret:           str("client-secret"),
+		RefreshToken:           str("refresh-token"),
+		Audience:               str("audience"),
+	}
+	if scopes, ok := oauth["scopes"].([]any); ok {
+		for _, scope := range scopes {
+			if scopeStr, ok := scope.(string); ok {
+				config.Scopes = append(config.Scopes, scopeStr)
+			}
+		}
+	}
+	return config
+}
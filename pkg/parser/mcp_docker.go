@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// dockerDigestPattern matches an image reference pinned to a sha256 digest
+	dockerDigestPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9/:_.-]*@sha256:[a-f0-9]{64}$`)
+	// dockerMemoryPattern matches docker --memory values such as 512m or 2g
+	dockerMemoryPattern = regexp.MustCompile(`^[0-9]+[bkmg]?$`)
+	// dockerCPUsPattern matches docker --cpus values such as 1 or 0.5
+	dockerCPUsPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+)
+
+// dockerOnlyFields are the fields of a docker MCP server that have no stdio equivalent
+var dockerOnlyFields = []string{"image", "resources", "read-only-rootfs"}
+
+// ExpandDockerMCPConfig converts a `type: docker` MCP server into the equivalent containerized
+// stdio configuration. The image must be pinned to a digest. Resource limits and the read-only
+// root filesystem (enabled by default) become docker runtime arguments placed before any args
+// from the configuration. Configurations of other types are returned unchanged.
+func ExpandDockerMCPConfig(toolName string, mcpConfig map[string]any) (map[string]any, error) {
+	if mcpConfig["type"] != "docker" {
+		return mcpConfig, nil
+	}
+	mcpLog.Printf("Expanding docker MCP server: %s", toolName)
+
+	for _, field := range []string{"command", "container", "version", "url", "headers"} {
+		if _, exists := mcpConfig[field]; exists {
+			return nil, fmt.Errorf("docker MCP server '%s' cannot use '%s'. Set the digest-pinned image with 'image' instead", toolName, field)
+		}
+	}
+
+	image, _ := mcpConfig["image"].(string)
+	if image == "" {
+		return nil, fmt.Errorf("docker MCP server '%s' missing required 'image' field. Example:\n"+
+			"mcp-servers:\n"+
+			"  %s:\n"+
+			"    type: docker\n"+
+			"    image: \"ghcr.io/org/server@sha256:<digest>\"", toolName, toolName)
+	}
+	if !dockerDigestPattern.MatchString(image) {
+		return nil, fmt.Errorf("docker MCP server '%s' image '%s' must be pinned to a digest (image@sha256:<64 hex characters>). "+
+			"Find the digest with: docker buildx imagetools inspect %s", toolName, image, image)
+	}
+
+	var runtimeArgs []string
+	if resources, exists := mcpConfig["resources"]; exists {
+		resourcesMap, ok := resources.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("docker MCP server '%s' resources must be an object, got %T", toolName, resources)
+		}
+		for key, value := range resourcesMap {
+			switch key {
+			case "cpus":
+				cpus := formatDockerResourceValue(value)
+				if !dockerCPUsPattern.MatchString(cpus) {
+					return nil, fmt.Errorf("docker MCP server '%s' resources.cpus must be a number such as 1 or 0.5, got %v", toolName, value)
+				}
+			case "memory":
+				memory := strings.ToLower(formatDockerResourceValue(value))
+				if !dockerMemoryPattern.MatchString(memory) {
+					return nil, fmt.Errorf("docker MCP server '%s' resources.memory must be a size such as 512m or 2g, got %v", toolName, value)
+				}
+			default:
+				return nil, fmt.Errorf("unknown property '%s' in resources of docker MCP server '%s'. Valid properties are: cpus, memory", key, toolName)
+			}
+		}
+		if cpus, exists := resourcesMap["cpus"]; exists {
+			runtimeArgs = append(runtimeArgs, "--cpus", formatDockerResourceValue(cpus))
+		}
+		if memory, exists := resourcesMap["memory"]; exists {
+			runtimeArgs = append(runtimeArgs, "--memory", strings.ToLower(formatDockerResourceValue(memory)))
+		}
+	}
+
+	readOnly := true
+	if value, exists := mcpConfig["read-only-rootfs"]; exists {
+		enabled, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("docker MCP server '%s' read-only-rootfs must be a boolean, got %T", toolName, value)
+		}
+		readOnly = enabled
+	}
+	if readOnly {
+		// Servers commonly need scratch space even with a read-only root filesystem
+		runtimeArgs = append(runtimeArgs, "--read-only", "--tmpfs", "/tmp")
+	}
+
+	expanded := make(map[string]any, len(mcpConfig))
+	for key, value := range mcpConfig {
+		expanded[key] = value
+	}
+	for _, field := range dockerOnlyFields {
+		delete(expanded, field)
+	}
+	expanded["type"] = "stdio"
+	expanded["container"] = image
+
+	args := make([]any, 0, len(runtimeArgs))
+	for _, arg := range runtimeArgs {
+		args = append(args, arg)
+	}
+	if userArgs, exists := mcpConfig["args"]; exists {
+		userArgsSlice, ok := userArgs.([]any)
+		if !ok {
+			return nil, fmt.Errorf("docker MCP server '%s' args must be an array, got %T", toolName, userArgs)
+		}
+		args = append(args, userArgsSlice...)
+	}
+	if len(args) > 0 {
+		expanded["args"] = args
+	}
+
+	mcpLog.Printf("Expanded docker MCP server %s: image=%s, runtime_args=%v", toolName, image, runtimeArgs)
+	return expanded, nil
+}
+
+// formatDockerResourceValue formats a resource limit given as a YAML string or number
+func formatDockerResourceValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,108 @@
+//go:build !integration
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDockerDigestImage = "ghcr.io/org/scanner@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestExpandDockerMCPConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]any
+		wantArgs []any
+		wantErr  string
+	}{
+		{
+			name: "resource limits and read-only root filesystem",
+			config: map[string]any{
+				"type":      "docker",
+				"image":     testDockerDigestImage,
+				"resources": map[string]any{"cpus": 1.5, "memory": "512M"},
+				"args":      []any{"--network", "none"},
+			},
+			wantArgs: []any{"--cpus", "1.5", "--memory", "512m", "--read-only", "--tmpfs", "/tmp", "--network", "none"},
+		},
+		{
+			name: "writable root filesystem",
+			config: map[string]any{
+				"type":             "docker",
+				"image":            testDockerDigestImage,
+				"read-only-rootfs": false,
+			},
+			wantArgs: nil,
+		},
+		{
+			name:    "tag without digest",
+			config:  map[string]any{"type": "docker", "image": "ghcr.io/org/scanner:latest"},
+			wantErr: "must be pinned to a digest",
+		},
+		{
+			name:    "missing image",
+			config:  map[string]any{"type": "docker"},
+			wantErr: "missing required 'image' field",
+		},
+		{
+			name:    "container field",
+			config:  map[string]any{"type": "docker", "image": testDockerDigestImage, "container": "ghcr.io/org/scanner"},
+			wantErr: "cannot use 'container'",
+		},
+		{
+			name:    "invalid memory",
+			config:  map[string]any{"type": "docker", "image": testDockerDigestImage, "resources": map[string]any{"memory": "lots"}},
+			wantErr: "resources.memory must be a size",
+		},
+		{
+			name:    "unknown resource",
+			config:  map[string]any{"type": "docker", "image": testDockerDigestImage, "resources": map[string]any{"gpus": "all"}},
+			wantErr: "unknown property 'gpus'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded, err := ExpandDockerMCPConfig("scanner", tt.config)
+			if tt.wantErr != "" {
+				require.Error(t, err, "expansion should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "expansion should succeed")
+			assert.Equal(t, "stdio", expanded["type"], "docker servers should become stdio servers")
+			assert.Equal(t, testDockerDigestImage, expanded["container"], "image should become the container")
+			assert.NotContains(t, expanded, "image", "docker-only fields should be removed")
+			assert.NotContains(t, expanded, "resources", "docker-only fields should be removed")
+			if tt.wantArgs == nil {
+				assert.NotContains(t, expanded, "args", "no runtime args should be generated")
+			} else {
+				assert.Equal(t, tt.wantArgs, expanded["args"], "runtime args should precede configured args")
+			}
+		})
+	}
+}
+
+func TestExpandDockerMCPConfigOtherTypes(t *testing.T) {
+	config := map[string]any{"type": "stdio", "container": "mcp/tool"}
+	expanded, err := ExpandDockerMCPConfig("tool", config)
+	require.NoError(t, err, "non-docker servers should not fail")
+	assert.Equal(t, config, expanded, "non-docker servers should be unchanged")
+}
+
+func TestParseMCPConfigDocker(t *testing.T) {
+	toolConfig := map[string]any{
+		"type":           "docker",
+		"image":          testDockerDigestImage,
+		"resources":      map[string]any{"memory": "1g"},
+		"entrypointArgs": []any{"serve"},
+	}
+	config, err := ParseMCPConfig("scanner", toolConfig, toolConfig)
+	require.NoError(t, err, "docker server should parse")
+	assert.Equal(t, "stdio", config.Type, "docker server should be a stdio server")
+	assert.Equal(t, "docker", config.Command, "docker server should run with docker")
+	assert.Equal(t, []string{"run", "--rm", "-i", "--memory", "1g", "--read-only", "--tmpfs", "/tmp", testDockerDigestImage, "serve"}, config.Args, "docker run args should include the runtime limits")
+}
@@ -152,6 +152,12 @@ func ResolveIncludePath(filePath, baseDir string, cache *ImportCache) (string, e
 		return "", fmt.Errorf("security: path %s must be within .github folder (resolves to: %s)", filePath, relativePath)
 	}
 
+	// Files provided in memory (see SetVirtualFiles) take precedence over the filesystem
+	if VirtualFileExists(fullPath) {
+		remoteLog.Printf("Resolved to virtual file: %s", fullPath)
+		return fullPath, nil
+	}
+
 	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
 		remoteLog.Printf("Local file not found: %s", fullPath)
 		// Return a simple error that will be wrapped with source location by the caller
@@ -235,6 +241,10 @@ func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, e
 	filePath := strings.Join(slashParts[2:], "/")
 	remoteLog.Printf("Parsed workflowspec: owner=%s, repo=%s, file=%s, ref=%s", owner, repo, filePath, ref)
 
+	if err := checkRemoteImportRef(pathPart, ref); err != nil {
+		return "", err
+	}
+
 	// Resolve ref to SHA for cache lookup
 	var sha string
 	if cache != nil {
@@ -251,6 +261,13 @@ func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, e
 			// Check cache using SHA
 			if cachedPath, found := cache.Get(owner, repo, filePath, sha); found {
 				remoteLog.Printf("Using cached import: %s/%s/%s@%s (SHA: %s)", owner, repo, filePath, ref, sha)
+				cachedContent, err := os.ReadFile(cachedPath)
+				if err != nil {
+					return "", fmt.Errorf("failed to read cached import %s: %w", cachedPath, err)
+				}
+				if err := verifyRemoteImport(cleanSpec, cachedContent); err != nil {
+					return "", err
+				}
 				return cachedPath, nil
 			}
 		}
@@ -264,6 +281,10 @@ func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, e
 	}
 	remoteLog.Printf("Successfully downloaded file: size=%d bytes", len(content))
 
+	if err := verifyRemoteImport(cleanSpec, content); err != nil {
+		return "", err
+	}
+
 	// If cache is available and we have a SHA, store in cache
 	if cache != nil && sha != "" {
 		cachedPath, err := cache.Set(owner, repo, filePath, sha, content)
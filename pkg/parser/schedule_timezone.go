@@ -0,0 +1,244 @@
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var scheduleTimezoneLog = logger.New("parser:schedule_timezone")
+
+// This file converts cron expressions written in a local timezone into UTC cron
+// expressions, which is the only timezone GitHub Actions schedules support.
+
+// TimezoneUTCOffsets returns the distinct UTC offsets, in minutes east of UTC, that the
+// location observes during the given year, sorted ascending. Zones without daylight
+// saving time return a single offset.
+func TimezoneUTCOffsets(loc *time.Location, year int) []int {
+	var offsets []int
+	// Offset transitions happen at most a few times a year, so sampling every day is enough
+	for t := time.Date(year, time.January, 1, 12, 0, 0, 0, loc); t.Year() == year; t = t.AddDate(0, 0, 1) {
+		_, offsetSeconds := t.Zone()
+		offset := offsetSeconds / 60
+		if !slices.Contains(offsets, offset) {
+			offsets = append(offsets, offset)
+		}
+	}
+	slices.Sort(offsets)
+	scheduleTimezoneLog.Printf("Timezone %s observes offsets %v in %d", loc, offsets, year)
+	return offsets
+}
+
+// FormatUTCOffset formats an offset in minutes east of UTC as "UTC+HH:MM"
+func FormatUTCOffset(offsetMinutes int) string {
+	sign := "+"
+	if offsetMinutes < 0 {
+		sign = "-"
+		offsetMinutes = -offsetMinutes
+	}
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, offsetMinutes/60, offsetMinutes%60)
+}
+
+// ConvertCronToUTC converts a cron expression that is written in local time with the given
+// UTC offset (in minutes east of UTC) into one or more equivalent UTC cron expressions.
+//
+// Whole-hour offsets keep the minute field and shift the hour field. Other offsets also
+// shift minutes, which can require several cron entries. When a time moves across
+// midnight, the day-of-week field is shifted as well; this is rejected when the
+// day-of-month or month fields are restricted because the shift cannot be expressed.
+//
+// Example:
+//
+//	ConvertCronToUTC("0 9 * * 1-5", 60)   // returns ["0 8 * * 1-5"]
+//	ConvertCronToUTC("0 1 * * 1", 120)    // returns ["0 23 * * 0"]
+func ConvertCronToUTC(cron string, offsetMinutes int) ([]string, error) {
+	fields := strings.Fields(cron)
+	if len(fields) != 5 || !IsCronExpression(cron) {
+		return nil, fmt.Errorf("invalid cron expression '%s': must have exactly 5 fields (minute hour day-of-month month day-of-week)", cron)
+	}
+	if offsetMinutes == 0 {
+		return []string{cron}, nil
+	}
+
+	minutes, err := expandCronField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minute field in '%s': %w", cron, err)
+	}
+	hours, err := expandCronField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hour field in '%s': %w", cron, err)
+	}
+	weekdays, err := expandCronField(fields[4], 0, 7)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field in '%s': %w", cron, err)
+	}
+	for i, day := range weekdays {
+		weekdays[i] = day % 7
+	}
+	dayRestricted := fields[2] != "*" || fields[3] != "*"
+	everyDay := fields[4] == "*" && !dayRestricted
+	keepMinutes := offsetMinutes%60 == 0
+
+	// Group the converted hours by (day shift, minute), keyed so the output is deterministic
+	type slot struct {
+		dayShift int
+		minute   int
+	}
+	hoursBySlot := make(map[slot][]int)
+	for _, hour := range hours {
+		for _, minute := range minutes {
+			total := hour*60 + minute - offsetMinutes
+			dayShift := 0
+			for total < 0 {
+				total += 24 * 60
+				dayShift--
+			}
+			for total >= 24*60 {
+				total -= 24 * 60
+				dayShift++
+			}
+			if everyDay {
+				dayShift = 0
+			}
+			key := slot{dayShift: dayShift, minute: total % 60}
+			if keepMinutes {
+				key.minute = -1
+			}
+			if utcHour := total / 60; !slices.Contains(hoursBySlot[key], utcHour) {
+				hoursBySlot[key] = append(hoursBySlot[key], utcHour)
+			}
+		}
+	}
+
+	// Merge minutes that share the same day shift and hours into a single entry
+	type entry struct {
+		dayShift int
+		hours    string
+		minutes  []int
+	}
+	var entries []entry
+	for _, key := range slices.SortedFunc(maps.Keys(hoursBySlot), func(a, b slot) int {
+		if a.dayShift != b.dayShift {
+			return a.dayShift - b.dayShift
+		}
+		return a.minute - b.minute
+	}) {
+		hourList := hoursBySlot[key]
+		slices.Sort(hourList)
+		hourField := formatCronField(hourList, 0, 23)
+		merged := false
+		for i := range entries {
+			if entries[i].dayShift == key.dayShift && entries[i].hours == hourField {
+				entries[i].minutes = append(entries[i].minutes, key.minute)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			entries = append(entries, entry{dayShift: key.dayShift, hours: hourField, minutes: []int{key.minute}})
+		}
+	}
+
+	var result []string
+	for _, e := range entries {
+		weekdayField := fields[4]
+		if e.dayShift != 0 {
+			if dayRestricted {
+				return nil, fmt.Errorf("cannot convert '%s' to UTC: the schedule crosses midnight in UTC, which cannot be expressed when day-of-month or month is restricted", cron)
+			}
+			shifted := make([]int, 0, len(weekdays))
+			for _, day := range weekdays {
+				if d := ((day+e.dayShift)%7 + 7) % 7; !slices.Contains(shifted, d) {
+					shifted = append(shifted, d)
+				}
+			}
+			slices.Sort(shifted)
+			weekdayField = formatCronField(shifted, 0, 6)
+		}
+		minuteField := fields[0]
+		if !keepMinutes {
+			minuteField = formatCronField(e.minutes, 0, 59)
+		}
+		converted := strings.Join([]string{minuteField, e.hours, fields[2], fields[3], weekdayField}, " ")
+		if !slices.Contains(result, converted) {
+			result = append(result, converted)
+		}
+	}
+
+	scheduleTimezoneLog.Printf("Converted cron %q at %s to UTC: %v", cron, FormatUTCOffset(offsetMinutes), result)
+	return result, nil
+}
+
+// expandCronField expands a numeric cron field (e.g. "*", "1-5", "*/15", "0,30") into the
+// sorted list of values it matches
+func expandCronField(field string, minValue, maxValue int) ([]int, error) {
+	var values []int
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, step := part, 1
+		if base, stepStr, ok := strings.Cut(part, "/"); ok {
+			parsed, err := strconv.Atoi(stepStr)
+			if err != nil || parsed <= 0 {
+				return nil, fmt.Errorf("invalid step '%s'", part)
+			}
+			rangePart, step = base, parsed
+		}
+
+		start, end := minValue, maxValue
+		if rangePart != "*" {
+			startStr, endStr, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = strconv.Atoi(startStr); err != nil {
+				return nil, fmt.Errorf("invalid value '%s'", part)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(endStr); err != nil {
+					return nil, fmt.Errorf("invalid range '%s'", part)
+				}
+			} else if step > 1 {
+				// "N/S" means every S starting at N
+				end = maxValue
+			}
+		}
+		if start < minValue || end > maxValue || start > end {
+			return nil, fmt.Errorf("value '%s' out of range %d-%d", part, minValue, maxValue)
+		}
+		for v := start; v <= end; v += step {
+			if !slices.Contains(values, v) {
+				values = append(values, v)
+			}
+		}
+	}
+	slices.Sort(values)
+	return values, nil
+}
+
+// formatCronField renders sorted values as a compact cron field, using "*" for the full
+// range and "a-b" for consecutive runs
+func formatCronField(values []int, minValue, maxValue int) string {
+	if len(values) == maxValue-minValue+1 {
+		return "*"
+	}
+	var parts []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		switch {
+		case j == i:
+			parts = append(parts, strconv.Itoa(values[i]))
+		case j == i+1:
+			parts = append(parts, strconv.Itoa(values[i]), strconv.Itoa(values[j]))
+		default:
+			parts = append(parts, fmt.Sprintf("%d-%d", values[i], values[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,144 @@
+//go:build !integration
+
+package parser
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConvertCronToUTC(t *testing.T) {
+	tests := []struct {
+		name           string
+		cron           string
+		offset         int
+		expected       []string
+		errorSubstring string
+	}{
+		{
+			name:     "zero offset is unchanged",
+			cron:     "0 9 * * 1-5",
+			offset:   0,
+			expected: []string{"0 9 * * 1-5"},
+		},
+		{
+			name:     "whole hour offset shifts hours",
+			cron:     "0 9 * * 1-5",
+			offset:   60,
+			expected: []string{"0 8 * * 1-5"},
+		},
+		{
+			name:     "crossing midnight shifts weekday back",
+			cron:     "0 1 * * 1",
+			offset:   120,
+			expected: []string{"0 23 * * 0"},
+		},
+		{
+			name:     "negative offset crossing midnight shifts weekday forward",
+			cron:     "0 22 * * 5",
+			offset:   -300,
+			expected: []string{"0 3 * * 6"},
+		},
+		{
+			name:     "daily schedule ignores day shift",
+			cron:     "30 1 * * *",
+			offset:   120,
+			expected: []string{"30 23 * * *"},
+		},
+		{
+			name:     "fractional offset splits minutes",
+			cron:     "0,45 9 * * *",
+			offset:   330,
+			expected: []string{"15 4 * * *", "30 3 * * *"},
+		},
+		{
+			name:     "hourly schedule splits across days",
+			cron:     "0 * * * 1",
+			offset:   -300,
+			expected: []string{"0 5-23 * * 1", "0 0-4 * * 2"},
+		},
+		{
+			name:     "day of month without midnight crossing",
+			cron:     "0 9 1 * *",
+			offset:   60,
+			expected: []string{"0 8 1 * *"},
+		},
+		{
+			name:           "day of month crossing midnight",
+			cron:           "0 1 1 * *",
+			offset:         120,
+			errorSubstring: "crosses midnight",
+		},
+		{
+			name:           "invalid cron",
+			cron:           "daily",
+			offset:         60,
+			errorSubstring: "invalid cron expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ConvertCronToUTC(tt.cron, tt.offset)
+			if tt.errorSubstring != "" {
+				if err == nil {
+					t.Fatalf("ConvertCronToUTC(%q, %d) expected error containing %q, got %v", tt.cron, tt.offset, tt.errorSubstring, result)
+				}
+				if !strings.Contains(err.Error(), tt.errorSubstring) {
+					t.Errorf("ConvertCronToUTC(%q, %d) error = %q, want substring %q", tt.cron, tt.offset, err.Error(), tt.errorSubstring)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConvertCronToUTC(%q, %d) unexpected error: %v", tt.cron, tt.offset, err)
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("ConvertCronToUTC(%q, %d) = %v, want %v", tt.cron, tt.offset, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimezoneUTCOffsets(t *testing.T) {
+	tests := []struct {
+		timezone string
+		expected []int
+	}{
+		{"UTC", []int{0}},
+		{"Europe/Paris", []int{60, 120}},
+		{"America/New_York", []int{-300, -240}},
+		{"Asia/Kolkata", []int{330}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.timezone)
+			if err != nil {
+				t.Skipf("timezone %s not available: %v", tt.timezone, err)
+			}
+			if offsets := TimezoneUTCOffsets(loc, 2026); !slices.Equal(offsets, tt.expected) {
+				t.Errorf("TimezoneUTCOffsets(%s) = %v, want %v", tt.timezone, offsets, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatUTCOffset(t *testing.T) {
+	tests := []struct {
+		offset   int
+		expected string
+	}{
+		{0, "UTC+00:00"},
+		{60, "UTC+01:00"},
+		{-300, "UTC-05:00"},
+		{330, "UTC+05:30"},
+	}
+
+	for _, tt := range tests {
+		if result := FormatUTCOffset(tt.offset); result != tt.expected {
+			t.Errorf("FormatUTCOffset(%d) = %q, want %q", tt.offset, result, tt.expected)
+		}
+	}
+}
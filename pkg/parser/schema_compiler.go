@@ -213,6 +213,7 @@ func validateWithSchemaAndLocation(frontmatter map[string]any, schemaJSON, conte
 	var contextLines []string
 	var frontmatterContent string
 	var frontmatterStart = 2 // Default: frontmatter starts at line 2
+	var isTOML bool
 
 	// Sanitize the path to prevent path traversal attacks
 	cleanPath := filepath.Clean(filePath)
@@ -227,6 +228,7 @@ func validateWithSchemaAndLocation(frontmatter map[string]any, schemaJSON, conte
 			if frontmatterStartIdx >= 0 && frontmatterEndIdx > frontmatterStartIdx {
 				frontmatterContent = actualFrontmatterContent
 				frontmatterStart = frontmatterStartIdx + 2 // +2 because we skip the opening "---" and use 1-based indexing
+				isTOML = strings.TrimSpace(lines[frontmatterStartIdx]) == TOMLFrontmatterDelimiter
 
 				// Use the frontmatter section plus a bit of context as context lines
 				contextStart := max(0, frontmatterStartIdx)
@@ -253,12 +255,12 @@ func validateWithSchemaAndLocation(frontmatter map[string]any, schemaJSON, conte
 		if len(jsonPaths) > 0 && frontmatterContent != "" {
 			detailLines := make([]string, 0, len(jsonPaths))
 			for _, pathInfo := range jsonPaths {
-				detailLines = append(detailLines, formatSchemaFailureDetail(pathInfo, schemaJSON, frontmatterContent, frontmatterStart))
+				detailLines = append(detailLines, formatSchemaFailureDetail(pathInfo, schemaJSON, frontmatterContent, frontmatterStart, isTOML))
 			}
 
 			// Use the first error path for primary context rendering.
 			primaryPath := jsonPaths[0]
-			location := LocateJSONPathInYAMLWithAdditionalProperties(frontmatterContent, primaryPath.Path, primaryPath.Message)
+			location := locateJSONPathInFrontmatter(frontmatterContent, isTOML, primaryPath.Path, primaryPath.Message)
 
 			if location.Found {
 				// Adjust line number to account for frontmatter position in file
@@ -351,13 +353,26 @@ func validateWithSchemaAndLocation(frontmatter map[string]any, schemaJSON, conte
 	return err
 }
 
-func formatSchemaFailureDetail(pathInfo JSONPathInfo, schemaJSON, frontmatterContent string, frontmatterStart int) string {
+func formatSchemaFailureDetail(pathInfo JSONPathInfo, schemaJSON, frontmatterContent string, frontmatterStart int, isTOML bool) string {
 	path := pathInfo.Path
 	if path == "" {
 		path = "/"
 	}
 
-	location := LocateJSONPathInYAMLWithAdditionalProperties(frontmatterContent, pathInfo.Path, pathInfo.Message)
+	location := locateJSONPathInFrontmatter(frontmatterContent, isTOML, pathInfo.Path, pathInfo.Message)
+
+	// Values expanded from a YAML anchor are reported at the alias or merge key that
+	// pulled them in, with a note pointing at the anchor definition. TOML has no anchors.
+	var anchorNote string
+	if !isTOML {
+		anchorResolver := newYAMLAnchorResolver(frontmatterContent)
+		propertyNames := extractAdditionalPropertyNames(pathInfo.Message)
+		if anchorLocation := locateAnchorUse(anchorResolver, pathInfo.Path, propertyNames); anchorLocation.Found {
+			location = anchorLocation
+		}
+		anchorNote = describeAnchorOrigins(anchorResolver, pathInfo.Path, propertyNames, frontmatterStart-1)
+	}
+
 	line := frontmatterStart
 	column := 1
 	if location.Found {
@@ -375,5 +390,8 @@ func formatSchemaFailureDetail(pathInfo JSONPathInfo, schemaJSON, frontmatterCon
 	if suggestions != "" {
 		message = message + ". " + suggestions
 	}
+	if anchorNote != "" {
+		message = message + " (" + anchorNote + ")"
+	}
 	return fmt.Sprintf("at '%s' (line %d, column %d): %s", path, line, column, message)
 }
@@ -166,10 +166,11 @@ func findFrontmatterBounds(lines []string) (startIdx int, endIdx int, frontmatte
 	startIdx = -1
 	endIdx = -1
 
-	// Look for the opening "---"
+	// Look for the opening "---" (or "+++" for TOML frontmatter)
+	delimiter := ""
 	for i, line := range lines {
 		trimmed := strings.TrimSpace(line)
-		if trimmed == "---" {
+		if delimiter = FrontmatterDelimiter(trimmed); delimiter != "" {
 			startIdx = i
 			break
 		}
@@ -184,10 +185,10 @@ func findFrontmatterBounds(lines []string) (startIdx int, endIdx int, frontmatte
 		return -1, -1, ""
 	}
 
-	// Look for the closing "---"
+	// Look for the matching closing delimiter
 	for i := startIdx + 1; i < len(lines); i++ {
 		trimmed := strings.TrimSpace(lines[i])
-		if trimmed == "---" {
+		if trimmed == delimiter {
 			endIdx = i
 			break
 		}
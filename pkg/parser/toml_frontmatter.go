@@ -0,0 +1,303 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+var tomlFrontmatterLog = logger.New("parser:toml_frontmatter")
+
+const (
+	// YAMLFrontmatterDelimiter opens and closes YAML frontmatter
+	YAMLFrontmatterDelimiter = "---"
+	// TOMLFrontmatterDelimiter opens and closes TOML frontmatter, as in Hugo and Zola
+	TOMLFrontmatterDelimiter = "+++"
+)
+
+// FrontmatterDelimiter returns the delimiter when line opens a frontmatter block, or ""
+func FrontmatterDelimiter(line string) string {
+	switch strings.TrimSpace(line) {
+	case YAMLFrontmatterDelimiter:
+		return YAMLFrontmatterDelimiter
+	case TOMLFrontmatterDelimiter:
+		return TOMLFrontmatterDelimiter
+	}
+	return ""
+}
+
+// ErrTOMLFrontmatterNotEditable is returned by the frontmatter editors, which write YAML, for
+// workflows with TOML frontmatter
+var ErrTOMLFrontmatterNotEditable = errors.New("TOML (+++) frontmatter cannot be edited automatically; edit the workflow by hand or convert its frontmatter to YAML (---)")
+
+// TOMLError is a TOML syntax error at a position of the TOML source (1-based)
+type TOMLError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *TOMLError) Error() string {
+	return fmt.Sprintf("[%d:%d] %s", e.Line, e.Column, e.Message)
+}
+
+// ParseTOMLFrontmatter parses TOML frontmatter into the representation YAML frontmatter is
+// decoded into: tables become map[string]any, arrays []any, non-negative integers uint64,
+// negative integers int64, floats float64, and dates and times strings.
+func ParseTOMLFrontmatter(content string) (map[string]any, error) {
+	var root map[string]any
+	if err := toml.Unmarshal([]byte(content), &root); err != nil {
+		tomlFrontmatterLog.Printf("Failed to parse TOML: %v", err)
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			line, column := decodeErr.Position()
+			return nil, &TOMLError{Line: line, Column: column, Message: strings.TrimPrefix(decodeErr.Error(), "toml: ")}
+		}
+		return nil, err
+	}
+	if root == nil {
+		root = make(map[string]any)
+	}
+	return normalizeTOMLValue(root).(map[string]any), nil
+}
+
+// normalizeTOMLValue converts decoded TOML values to the types YAML frontmatter decodes to
+func normalizeTOMLValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeTOMLValue(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeTOMLValue(item)
+		}
+		return v
+	case int64:
+		if v >= 0 {
+			return uint64(v)
+		}
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case toml.LocalDateTime:
+		return v.String()
+	case toml.LocalDate:
+		return v.String()
+	case toml.LocalTime:
+		return v.String()
+	}
+	return value
+}
+
+// FormatTOMLError formats a TOML error with source context in the layout of FormatYAMLError.
+// frontmatterLineOffset is the file line where the TOML source starts.
+func FormatTOMLError(err error, frontmatterLineOffset int, sourceTOML string) string {
+	tomlErr, ok := err.(*TOMLError)
+	if !ok {
+		return err.Error()
+	}
+
+	offset := frontmatterLineOffset - 1
+	var formatted strings.Builder
+	fmt.Fprintf(&formatted, "[%d:%d] %s", tomlErr.Line+offset, tomlErr.Column, tomlErr.Message)
+
+	lines := strings.Split(sourceTOML, "\n")
+	first := max(1, tomlErr.Line-3)
+	last := min(len(lines), tomlErr.Line+1)
+	for line := first; line <= last; line++ {
+		text := strings.TrimSuffix(lines[line-1], "\r")
+		if line == tomlErr.Line {
+			fmt.Fprintf(&formatted, "\n>%3d | %s", line+offset, text)
+			fmt.Fprintf(&formatted, "\n%s^", strings.Repeat(" ", 6+tomlErr.Column))
+		} else {
+			fmt.Fprintf(&formatted, "\n%4d | %s", line+offset, text)
+		}
+	}
+	return formatted.String()
+}
+
+// LocateJSONPathInTOML finds the line/column position of a JSON path in TOML source. When the
+// path itself is not written in the source (e.g. a default), the closest parent is returned.
+func LocateJSONPathInTOML(tomlContent string, jsonPath string) JSONPathLocation {
+	_, positions, err := parseTOML(tomlContent)
+	if err != nil || jsonPath == "" {
+		return JSONPathLocation{Line: 1, Column: 1, Found: true}
+	}
+
+	for path := jsonPath; path != ""; path = path[:strings.LastIndex(path, "/")] {
+		if location, ok := positions[path]; ok {
+			return location
+		}
+	}
+	return JSONPathLocation{Line: 1, Column: 1, Found: true}
+}
+
+// LocateJSONPathInTOMLWithAdditionalProperties finds the position of a JSON path in TOML source,
+// pointing additional properties errors at the first property that is not allowed
+func LocateJSONPathInTOMLWithAdditionalProperties(tomlContent string, jsonPath string, errorMessage string) JSONPathLocation {
+	if propertyNames := extractAdditionalPropertyNames(errorMessage); len(propertyNames) > 0 {
+		_, positions, err := parseTOML(tomlContent)
+		if err == nil {
+			for _, name := range propertyNames {
+				if location, ok := positions[jsonPath+"/"+name]; ok {
+					return location
+				}
+			}
+		}
+	}
+	return LocateJSONPathInTOML(tomlContent, jsonPath)
+}
+
+// tomlPositions records the position of every key, table header and array element of TOML
+// source, keyed by the JSON path schema validation errors use (e.g. /steps/1/with)
+type tomlPositions struct {
+	parser    unstable.Parser
+	positions map[string]JSONPathLocation
+	arrays    map[string]int // number of [[array]] tables seen, keyed by JSON path
+}
+
+func parseTOML(content string) (map[string]any, map[string]JSONPathLocation, error) {
+	root, err := ParseTOMLFrontmatter(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := &tomlPositions{
+		positions: make(map[string]JSONPathLocation),
+		arrays:    make(map[string]int),
+	}
+	p.parser.Reset([]byte(content))
+
+	var table string
+	for p.parser.NextExpression() {
+		expr := p.parser.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			table = p.recordTableHeader(expr)
+		case unstable.KeyValue:
+			p.recordKeyValue(table, expr)
+		}
+	}
+	if err := p.parser.Error(); err != nil {
+		return nil, nil, err
+	}
+	return root, p.positions, nil
+}
+
+// location returns the position where node starts; ok is false for nodes without source bytes
+func (p *tomlPositions) location(node *unstable.Node) (JSONPathLocation, bool) {
+	if node.Raw.Length == 0 {
+		// Arrays do not carry their source range, so they are located at their first element
+		children := node.Children()
+		for children.Next() {
+			if child := children.Node(); child.Kind != unstable.Comment {
+				return p.location(child)
+			}
+		}
+		return JSONPathLocation{}, false
+	}
+	start := p.parser.Shape(node.Raw).Start
+	return JSONPathLocation{Line: start.Line, Column: start.Column, Found: true}, true
+}
+
+func (p *tomlPositions) record(path string, location JSONPathLocation) {
+	if _, exists := p.positions[path]; !exists {
+		p.positions[path] = location
+	}
+}
+
+// recordTableHeader records a [table] or [[array]] header and returns the JSON path of its table
+func (p *tomlPositions) recordTableHeader(header *unstable.Node) string {
+	var keys []*unstable.Node
+	for it := header.Key(); it.Next(); {
+		if it.Node().Kind == unstable.Key {
+			keys = append(keys, it.Node())
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+
+	keyLocation, _ := p.location(keys[0])
+	// The header itself starts at its opening brackets
+	headerLocation := keyLocation
+	source := p.parser.Raw(unstable.Range{Length: keys[0].Raw.Offset})
+	for i := len(source) - 1; i >= 0 && strings.ContainsRune(" \t[", rune(source[i])); i-- {
+		if source[i] == '[' {
+			headerLocation.Column = keyLocation.Column - (len(source) - i)
+		}
+	}
+
+	var path string
+	for i, key := range keys {
+		path += "/" + string(key.Data)
+		if i == len(keys)-1 && header.Kind == unstable.ArrayTable {
+			p.record(path, keyLocation)
+			index := p.arrays[path]
+			p.arrays[path] = index + 1
+			path += "/" + strconv.Itoa(index)
+			p.record(path, headerLocation)
+			return path
+		}
+		if count, ok := p.arrays[path]; ok {
+			// Sub-tables of an array of tables belong to its last element
+			path += "/" + strconv.Itoa(count-1)
+		}
+		p.record(path, keyLocation)
+	}
+	return path
+}
+
+// recordKeyValue records key = value in the table at tablePath, including the elements of
+// array and inline table values
+func (p *tomlPositions) recordKeyValue(tablePath string, keyValue *unstable.Node) {
+	path := tablePath
+	var keyLocation JSONPathLocation
+	for it := keyValue.Key(); it.Next(); {
+		key := it.Node()
+		if key.Kind != unstable.Key {
+			continue
+		}
+		if path == tablePath {
+			keyLocation, _ = p.location(key)
+		}
+		path += "/" + string(key.Data)
+		p.record(path, keyLocation)
+	}
+	p.recordValue(path, keyValue.Value())
+}
+
+func (p *tomlPositions) recordValue(path string, value *unstable.Node) {
+	switch value.Kind {
+	case unstable.InlineTable:
+		children := value.Children()
+		for children.Next() {
+			if child := children.Node(); child.Kind == unstable.KeyValue {
+				p.recordKeyValue(path, child)
+			}
+		}
+	case unstable.Array:
+		index := 0
+		children := value.Children()
+		for children.Next() {
+			child := children.Node()
+			if child.Kind == unstable.Comment {
+				continue
+			}
+			elementPath := path + "/" + strconv.Itoa(index)
+			if location, ok := p.location(child); ok {
+				p.record(elementPath, location)
+			}
+			p.recordValue(elementPath, child)
+			index++
+		}
+	}
+}
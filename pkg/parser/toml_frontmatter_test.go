@@ -0,0 +1,223 @@
+//go:build !integration
+
+package parser
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tomlWorkflowFrontmatter = `# Issue triage
+name = "Triage"
+engine = "copilot"
+timeout-minutes = 10
+
+[on]
+workflow_dispatch = {}
+issues.types = ["opened", "reopened"]
+
+[permissions]
+contents = "read"
+
+[[steps]]
+name = "Setup"
+run = """
+echo one
+echo two"""
+
+[[steps]]
+name = "Checkout"
+uses = 'actions/checkout@v5'
+[steps.with]
+fetch-depth = 0
+`
+
+func TestParseTOMLFrontmatter(t *testing.T) {
+	frontmatter, err := ParseTOMLFrontmatter(tomlWorkflowFrontmatter)
+	require.NoError(t, err, "Valid TOML should parse")
+
+	expected := map[string]any{
+		"name":            "Triage",
+		"engine":          "copilot",
+		"timeout-minutes": uint64(10),
+		"on": map[string]any{
+			"workflow_dispatch": map[string]any{},
+			"issues":            map[string]any{"types": []any{"opened", "reopened"}},
+		},
+		"permissions": map[string]any{"contents": "read"},
+		"steps": []any{
+			map[string]any{"name": "Setup", "run": "echo one\necho two"},
+			map[string]any{"name": "Checkout", "uses": "actions/checkout@v5", "with": map[string]any{"fetch-depth": uint64(0)}},
+		},
+	}
+	assert.Equal(t, expected, frontmatter, "TOML should decode like the equivalent YAML")
+}
+
+func TestParseTOMLFrontmatterValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		toml     string
+		expected any
+	}{
+		{name: "negative integer", toml: "v = -3", expected: int64(-3)},
+		{name: "underscores", toml: "v = 1_000", expected: uint64(1000)},
+		{name: "hexadecimal", toml: "v = 0x1F", expected: uint64(31)},
+		{name: "float", toml: "v = 2.5e1", expected: 25.0},
+		{name: "infinity", toml: "v = -inf", expected: math.Inf(-1)},
+		{name: "boolean", toml: "v = true", expected: true},
+		{name: "date time", toml: "v = 2026-01-02T03:04:05Z", expected: "2026-01-02T03:04:05Z"},
+		{name: "local date time", toml: "v = 2026-01-02 03:04:05", expected: "2026-01-02T03:04:05"},
+		{name: "escapes", toml: `v = "a\tb\u00e9"`, expected: "a\tbé"},
+		{name: "literal string", toml: `v = 'C:\path'`, expected: `C:\path`},
+		{name: "line ending backslash", toml: "v = \"\"\"\na \\\n   b\"\"\"", expected: "a b"},
+		{name: "multi-line array", toml: "v = [\n  1, # one\n  2,\n]", expected: []any{uint64(1), uint64(2)}},
+		{name: "inline table", toml: `v = { a = 1, b.c = "d" }`, expected: map[string]any{"a": uint64(1), "b": map[string]any{"c": "d"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter, err := ParseTOMLFrontmatter(tt.toml)
+			require.NoError(t, err, "Value should parse")
+			assert.Equal(t, tt.expected, frontmatter["v"], "Decoded value")
+		})
+	}
+}
+
+func TestParseTOMLFrontmatterErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		toml     string
+		expected string
+	}{
+		{name: "missing value", toml: "a = 1\nb =", expected: "[3:3] expected value, not end of input"},
+		{name: "duplicate key", toml: "a = 1\na = 2", expected: "[3:1] key a is already defined"},
+		{name: "duplicate table", toml: "[x]\n[x]", expected: "[3:2] table x already exists"},
+		{name: "extended inline table", toml: "b = {c = 1}\n[b]", expected: "[3:2] key b should be a table, not a value"},
+		{name: "unterminated string", toml: `a = "text`, expected: "[2:9] unterminated basic string"},
+		{name: "trailing content", toml: "a = 1 b", expected: "[2:7] expected newline but got U+0062 'b'"},
+		{name: "invalid value", toml: "a = yes", expected: "[2:5] unexpected character U+0079 'y' at start of value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTOMLFrontmatter(tt.toml)
+			require.Error(t, err, "Invalid TOML should fail")
+			// Frontmatter starts on line 2, after the opening +++
+			assert.Contains(t, FormatTOMLError(err, 2, tt.toml), tt.expected, "Error should point at the TOML source line")
+		})
+	}
+}
+
+func TestFormatTOMLErrorContext(t *testing.T) {
+	source := "name = \"x\"\nengine = copilot"
+	_, err := ParseTOMLFrontmatter(source)
+	require.Error(t, err, "Bare string should fail")
+
+	expected := "[3:10] unexpected character U+0063 'c' at start of value\n" +
+		"   2 | name = \"x\"\n" +
+		">  3 | engine = copilot\n" +
+		"                ^"
+	assert.Equal(t, expected, FormatTOMLError(err, 2, source), "Error should show the source context")
+}
+
+func TestLocateJSONPathInTOML(t *testing.T) {
+	tests := []struct {
+		path         string
+		expectedLine int
+		expectedCol  int
+	}{
+		{path: "/name", expectedLine: 2, expectedCol: 1},
+		{path: "/on", expectedLine: 6, expectedCol: 2},
+		{path: "/on/issues/types/1", expectedLine: 8, expectedCol: 27},
+		{path: "/steps/1/with/fetch-depth", expectedLine: 23, expectedCol: 1},
+		{path: "/permissions/issues", expectedLine: 10, expectedCol: 2},
+		{path: "/steps/1", expectedLine: 19, expectedCol: 1},
+		{path: "/steps/0/run", expectedLine: 15, expectedCol: 1},
+		{path: "/on/workflow_dispatch", expectedLine: 7, expectedCol: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			location := LocateJSONPathInTOML(tomlWorkflowFrontmatter, tt.path)
+			assert.True(t, location.Found, "Location should be found")
+			assert.Equal(t, tt.expectedLine, location.Line, "Line")
+			assert.Equal(t, tt.expectedCol, location.Column, "Column")
+		})
+	}
+
+	location := LocateJSONPathInTOMLWithAdditionalProperties(tomlWorkflowFrontmatter, "/permissions", "additional properties 'contents' not allowed")
+	assert.Equal(t, 11, location.Line, "Additional property should be located")
+}
+
+func TestExtractFrontmatterFromContentTOML(t *testing.T) {
+	content := "+++\n" + tomlWorkflowFrontmatter + "x-note = \"ignored\"\n+++\n\n# Triage\n\nTriage the issue.\n"
+
+	result, err := ExtractFrontmatterFromContent(content)
+	require.NoError(t, err, "TOML frontmatter should be extracted")
+	assert.Equal(t, "Triage", result.Frontmatter["name"], "Frontmatter field")
+	assert.NotContains(t, result.Frontmatter, "x-note", "Extension fields should be removed")
+	assert.Equal(t, "# Triage\n\nTriage the issue.", result.Markdown, "Markdown body")
+	assert.Empty(t, result.FrontmatterLines, "YAML frontmatter lines should not be set for TOML")
+	assert.Equal(t, 2, result.FrontmatterStart, "Frontmatter start line")
+
+	_, err = ExtractFrontmatterFromContent("+++\nname = \"x\"\n---\n# Body")
+	require.Error(t, err, "A YAML delimiter should not close TOML frontmatter")
+
+	_, err = ExtractFrontmatterFromContent("+++\nname = \"x\"\nengine = copilot\n+++\n# Body")
+	require.Error(t, err, "Invalid TOML should fail")
+	assert.Contains(t, err.Error(), "[3:10]", "Error should point at the file line")
+}
+
+func TestValidateWithSchemaAndLocationTOML(t *testing.T) {
+	content := `+++
+on = "daily"
+
+[safe-outputs.create-issue]
+bogus = true
++++
+# Body`
+	filePath := filepath.Join(t.TempDir(), "workflow.md")
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+
+	result, err := ExtractFrontmatterFromContent(content)
+	require.NoError(t, err)
+
+	err = validateWithSchemaAndLocation(result.Frontmatter, mainWorkflowSchema, "main workflow file", filePath)
+	require.Error(t, err, "Unknown property should fail validation")
+	assert.Contains(t, err.Error(), "at '/safe-outputs/create-issue' (line 5, column 1)", "Error should point at the TOML line")
+}
+
+func TestExtractImportsFromTextTOML(t *testing.T) {
+	frontmatterText := `engine = "copilot"
+imports = [
+  "shared/test.md", # first
+  'shared/common.md',
+]
+description = "Test"`
+
+	assert.Equal(t, []string{"shared/test.md", "shared/common.md"}, extractImportsFromText(frontmatterText, TOMLFrontmatterDelimiter), "TOML imports should be extracted")
+	assert.Equal(t, []string{"shared/a.md"}, extractImportsFromText(`imports = ["shared/a.md"]`, TOMLFrontmatterDelimiter), "Single-line TOML imports should be extracted")
+	assert.Equal(t, []string{"shared/b.md"}, extractImportsFromText("imports = [\"shared/b.md\"] # \"shared/c.md\"", TOMLFrontmatterDelimiter), "Strings in comments should be ignored")
+	assert.Empty(t, extractImportsFromText("description = \"imports = ['shared/x.md']\"", TOMLFrontmatterDelimiter), "Strings of other keys should be ignored")
+}
+
+func TestUpdateWorkflowFrontmatterTOML(t *testing.T) {
+	content := "+++\nengine = \"copilot\"\n+++\n# Body\n"
+	filePath := filepath.Join(t.TempDir(), "workflow.md")
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+
+	err := UpdateWorkflowFrontmatter(filePath, func(frontmatter map[string]any) error {
+		frontmatter["source"] = "owner/repo/workflow.md@v1"
+		return nil
+	}, false)
+	require.ErrorIs(t, err, ErrTOMLFrontmatterNotEditable, "TOML frontmatter should not be rewritten as YAML")
+
+	written, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(written), "Workflow file should be left unchanged")
+}
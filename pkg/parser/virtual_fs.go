@@ -2,14 +2,46 @@ package parser
 
 import "os"
 
+// virtualFiles holds in-memory file contents used for import resolution when a
+// workflow is compiled without a checkout (Wasm builds and the pkg/compiler API).
+// Keys are resolved file paths (e.g. "shared/elastic-tools.md").
+var virtualFiles map[string][]byte
+
+// SetVirtualFiles populates the virtual filesystem for import resolution.
+// Call this before compiling a workflow that uses imports.
+// The keys should be file paths relative to the workflow directory
+// (e.g. "shared/elastic-tools.md").
+func SetVirtualFiles(files map[string][]byte) {
+	virtualFiles = files
+}
+
+// ClearVirtualFiles removes all virtual files.
+func ClearVirtualFiles() {
+	virtualFiles = nil
+}
+
+// VirtualFileExists checks if a path exists in the virtual filesystem.
+func VirtualFileExists(path string) bool {
+	if virtualFiles == nil {
+		return false
+	}
+	_, ok := virtualFiles[path]
+	return ok
+}
+
 // readFileFunc is the function used to read file contents throughout the parser.
-// In wasm builds, this is overridden to read from a virtual filesystem
-// populated by the browser via SetVirtualFiles.
-var readFileFunc = os.ReadFile
+// It checks the virtual filesystem first and falls back to disk. In wasm builds,
+// this is overridden to read only from the virtual filesystem.
+var readFileFunc = func(path string) ([]byte, error) {
+	if content, ok := virtualFiles[path]; ok {
+		return content, nil
+	}
+	return os.ReadFile(path)
+}
 
 // ReadFile reads a file using the parser's file reading function, which
-// checks the virtual filesystem first in wasm builds. Use this instead of
-// os.ReadFile when reading files that may be provided as virtual files.
+// checks the virtual filesystem first. Use this instead of os.ReadFile when
+// reading files that may be provided as virtual files.
 func ReadFile(path string) ([]byte, error) {
 	return readFileFunc(path)
 }
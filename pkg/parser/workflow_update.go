@@ -27,6 +27,9 @@ func UpdateWorkflowFrontmatter(workflowPath string, updateFunc func(frontmatter
 	if err != nil {
 		return fmt.Errorf("failed to parse frontmatter: %w", err)
 	}
+	if result.TOML {
+		return fmt.Errorf("failed to update %s: %w", workflowPath, ErrTOMLFrontmatterNotEditable)
+	}
 
 	// Ensure frontmatter map exists
 	if result.Frontmatter == nil {
@@ -0,0 +1,250 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml/ast"
+	yamlparser "github.com/goccy/go-yaml/parser"
+)
+
+var yamlAnchorsLog = logger.New("parser:yaml_anchors")
+
+// yamlAnchorPattern matches an anchor definition (&name) in YAML source. It is a cheap
+// pre-check; the AST walk below is what actually resolves anchors.
+var yamlAnchorPattern = regexp.MustCompile(`(?:^|[\s\[{,:-])&[^\s\[\]{},]+`)
+
+// ExtensionFieldPrefix marks top-level frontmatter keys that only hold YAML anchors.
+// Keys such as "x-defaults:" are removed before schema validation and compilation, the
+// same convention docker compose uses for reusable fragments.
+const ExtensionFieldPrefix = "x-"
+
+// hasYAMLAnchors reports whether the YAML source defines any anchor
+func hasYAMLAnchors(yamlContent string) bool {
+	return yamlAnchorPattern.MatchString(yamlContent)
+}
+
+// expandYAMLAliases returns a deep copy of a decoded YAML value.
+// The decoder expands aliases and merge keys (<<: *name) but every use of an alias shares
+// the map or slice of its anchor, so changing one occurrence would change all of them.
+func expandYAMLAliases(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, item := range v {
+			copied[key] = expandYAMLAliases(item)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = expandYAMLAliases(item)
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
+// removeExtensionFields deletes the top-level x- keys that hold anchor definitions
+func removeExtensionFields(frontmatter map[string]any) {
+	for key := range frontmatter {
+		if strings.HasPrefix(key, ExtensionFieldPrefix) {
+			yamlAnchorsLog.Printf("Removing extension field: %s", key)
+			delete(frontmatter, key)
+		}
+	}
+}
+
+// yamlAnchorOrigin describes where a value reached through an alias was defined
+type yamlAnchorOrigin struct {
+	Anchor      string // Anchor name without the leading &
+	DefinedLine int    // Line of the &anchor definition (1-based, relative to the YAML source)
+	UsedLine    int    // Line of the *alias or <<: merge that pulled the value in
+	UsedColumn  int    // Column of the *alias or <<: merge
+	Merged      bool   // True when the value came in through a <<: merge key
+}
+
+// describe renders the origin for schema error messages, shifting lines by lineOffset
+func (o *yamlAnchorOrigin) describe(lineOffset int) string {
+	use := "referenced"
+	if o.Merged {
+		use = "merged"
+	}
+	return fmt.Sprintf("anchor '&%s' defined at line %d, %s at line %d", o.Anchor, o.DefinedLine+lineOffset, use, o.UsedLine+lineOffset)
+}
+
+// yamlAnchorResolver walks the YAML AST along JSON paths, following aliases and merge keys
+type yamlAnchorResolver struct {
+	root    ast.Node
+	anchors map[string]*ast.AnchorNode
+}
+
+// newYAMLAnchorResolver parses the YAML source and indexes its anchors.
+// It returns nil when the source has no anchors or cannot be parsed.
+func newYAMLAnchorResolver(yamlContent string) *yamlAnchorResolver {
+	if !hasYAMLAnchors(yamlContent) {
+		return nil
+	}
+	file, err := yamlparser.ParseBytes([]byte(yamlContent), 0)
+	if err != nil || len(file.Docs) == 0 || file.Docs[0].Body == nil {
+		yamlAnchorsLog.Printf("Could not parse YAML for anchor resolution: %v", err)
+		return nil
+	}
+
+	resolver := &yamlAnchorResolver{anchors: make(map[string]*ast.AnchorNode)}
+	for _, node := range ast.Filter(ast.AnchorType, file.Docs[0].Body) {
+		if anchor, ok := node.(*ast.AnchorNode); ok && anchor.Name != nil {
+			resolver.anchors[anchor.Name.String()] = anchor
+		}
+	}
+	if len(resolver.anchors) == 0 {
+		return nil
+	}
+	resolver.root = file.Docs[0].Body
+	yamlAnchorsLog.Printf("Indexed %d YAML anchors", len(resolver.anchors))
+	return resolver
+}
+
+// resolve follows the JSON path through the document and returns the innermost anchor the
+// path passed through, or nil when the value is written out in place
+func (r *yamlAnchorResolver) resolve(jsonPath string) *yamlAnchorOrigin {
+	node := r.root
+	var origin *yamlAnchorOrigin
+	for _, segment := range parseJSONPath(jsonPath) {
+		node, origin = r.deref(node, origin)
+		var found ast.Node
+		var via *yamlAnchorOrigin
+		switch segment.Type {
+		case "key":
+			found, via = r.lookupKey(node, segment.Value)
+		case "index":
+			if seq, ok := node.(*ast.SequenceNode); ok && segment.Index < len(seq.Values) {
+				found = seq.Values[segment.Index]
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		if via != nil {
+			origin = via
+		}
+		node = found
+	}
+	_, origin = r.deref(node, origin)
+	return origin
+}
+
+// deref unwraps anchors, tags and aliases, recording the alias as the new origin
+func (r *yamlAnchorResolver) deref(node ast.Node, origin *yamlAnchorOrigin) (ast.Node, *yamlAnchorOrigin) {
+	for range len(r.anchors) + 1 {
+		switch n := node.(type) {
+		case *ast.AnchorNode:
+			node = n.Value
+		case *ast.TagNode:
+			node = n.Value
+		case *ast.AliasNode:
+			anchor := r.anchors[n.Value.String()]
+			if anchor == nil {
+				return node, origin
+			}
+			origin = &yamlAnchorOrigin{
+				Anchor:      anchor.Name.String(),
+				DefinedLine: anchor.GetToken().Position.Line,
+				UsedLine:    n.GetToken().Position.Line,
+				UsedColumn:  n.GetToken().Position.Column,
+			}
+			node = anchor.Value
+		default:
+			return node, origin
+		}
+	}
+	return node, origin
+}
+
+// lookupKey finds a mapping key, searching merged mappings when the key is not set directly.
+// Keys written out in the mapping win over merged keys, as in YAML.
+func (r *yamlAnchorResolver) lookupKey(node ast.Node, key string) (ast.Node, *yamlAnchorOrigin) {
+	var values []*ast.MappingValueNode
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		values = n.Values
+	case *ast.MappingValueNode:
+		values = []*ast.MappingValueNode{n}
+	default:
+		return nil, nil
+	}
+
+	var merges []*ast.MappingValueNode
+	for _, value := range values {
+		if _, isMerge := value.Key.(*ast.MergeKeyNode); isMerge {
+			merges = append(merges, value)
+			continue
+		}
+		if value.Key.String() == key {
+			return value.Value, nil
+		}
+	}
+
+	for _, merge := range merges {
+		sources := []ast.Node{merge.Value}
+		if seq, ok := merge.Value.(*ast.SequenceNode); ok {
+			sources = seq.Values
+		}
+		for _, source := range sources {
+			target, origin := r.deref(source, nil)
+			found, via := r.lookupKey(target, key)
+			if found == nil {
+				continue
+			}
+			// Report the merge at this level; fall back to a nested merge for inline mappings
+			if origin == nil {
+				origin = via
+			}
+			if origin != nil {
+				origin.Merged = true
+			}
+			return found, origin
+		}
+	}
+	return nil, nil
+}
+
+// describeAnchorOrigins returns a note naming the anchors that a failing path or its
+// additional properties came from, or "" when none of them came through an alias
+func describeAnchorOrigins(resolver *yamlAnchorResolver, jsonPath string, propertyNames []string, lineOffset int) string {
+	if resolver == nil {
+		return ""
+	}
+	if origin := resolver.resolve(jsonPath); origin != nil {
+		return "value comes from " + origin.describe(lineOffset)
+	}
+	var notes []string
+	for _, name := range propertyNames {
+		if origin := resolver.resolve(jsonPath + "/" + name); origin != nil {
+			notes = append(notes, fmt.Sprintf("'%s' comes from %s", name, origin.describe(lineOffset)))
+		}
+	}
+	return strings.Join(notes, "; ")
+}
+
+// locateAnchorUse returns the location of the alias or merge key that brought the value at
+// jsonPath into the document, so errors point at the expanded location
+func locateAnchorUse(resolver *yamlAnchorResolver, jsonPath string, propertyNames []string) JSONPathLocation {
+	if resolver == nil {
+		return JSONPathLocation{Line: 1, Column: 1, Found: false}
+	}
+	origin := resolver.resolve(jsonPath)
+	for _, name := range propertyNames {
+		if origin != nil {
+			break
+		}
+		origin = resolver.resolve(jsonPath + "/" + name)
+	}
+	if origin == nil {
+		return JSONPathLocation{Line: 1, Column: 1, Found: false}
+	}
+	return JSONPathLocation{Line: origin.UsedLine, Column: origin.UsedColumn, Found: true}
+}
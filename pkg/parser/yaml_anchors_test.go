@@ -0,0 +1,128 @@
+//go:build !integration
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const anchoredFrontmatter = `on: daily
+x-labels: &labels [triage]
+x-defaults:
+  issue: &issue-defaults
+    labels: [bot]
+    max: 2
+safe-outputs:
+  create-issue:
+    <<: *issue-defaults
+    title-prefix: "[a] "
+  create-discussion:
+    labels: *labels
+tools:
+  github:
+    toolsets: &toolsets [default]`
+
+func TestExtractFrontmatterExpandsAnchors(t *testing.T) {
+	content := "---\n" + anchoredFrontmatter + "\n---\n# Body"
+
+	result, err := ExtractFrontmatterFromContent(content)
+	require.NoError(t, err, "Anchored frontmatter should parse")
+
+	assert.NotContains(t, result.Frontmatter, "x-defaults", "x- keys should be removed")
+
+	createIssue := result.Frontmatter["safe-outputs"].(map[string]any)["create-issue"].(map[string]any)
+	assert.Equal(t, []any{"bot"}, createIssue["labels"], "Merged keys should be expanded")
+	assert.Equal(t, "[a] ", createIssue["title-prefix"], "Keys written in place should be kept")
+	assert.NotContains(t, createIssue, "<<", "Merge keys should not survive expansion")
+}
+
+func TestExtractFrontmatterAliasesDoNotShareValues(t *testing.T) {
+	content := `---
+on: daily
+x-labels: &labels
+  names: [bot]
+safe-outputs:
+  create-issue:
+    extra: *labels
+  create-discussion:
+    extra: *labels
+---
+# Body`
+
+	result, err := ExtractFrontmatterFromContent(content)
+	require.NoError(t, err)
+
+	safeOutputs := result.Frontmatter["safe-outputs"].(map[string]any)
+	issueExtra := safeOutputs["create-issue"].(map[string]any)["extra"].(map[string]any)
+	discussionExtra := safeOutputs["create-discussion"].(map[string]any)["extra"].(map[string]any)
+
+	issueExtra["names"] = []any{"changed"}
+	assert.Equal(t, []any{"bot"}, discussionExtra["names"], "Each alias should get its own copy of the anchored value")
+}
+
+func TestYAMLAnchorResolver(t *testing.T) {
+	resolver := newYAMLAnchorResolver(anchoredFrontmatter)
+	require.NotNil(t, resolver, "Resolver should index anchors")
+
+	tests := []struct {
+		name       string
+		path       string
+		wantAnchor string
+		wantDef    int
+		wantUse    int
+		wantMerged bool
+	}{
+		{name: "merged key", path: "/safe-outputs/create-issue/labels", wantAnchor: "issue-defaults", wantDef: 4, wantUse: 9, wantMerged: true},
+		{name: "aliased value", path: "/safe-outputs/create-discussion/labels", wantAnchor: "labels", wantDef: 2, wantUse: 12},
+		{name: "key written in place", path: "/safe-outputs/create-issue/title-prefix"},
+		{name: "anchor definition", path: "/tools/github/toolsets"},
+		{name: "missing path", path: "/safe-outputs/missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := resolver.resolve(tt.path)
+			if tt.wantAnchor == "" {
+				assert.Nil(t, origin, "Path should not resolve through an anchor")
+				return
+			}
+			require.NotNil(t, origin, "Path should resolve through an anchor")
+			assert.Equal(t, tt.wantAnchor, origin.Anchor)
+			assert.Equal(t, tt.wantDef, origin.DefinedLine, "Anchor definition line")
+			assert.Equal(t, tt.wantUse, origin.UsedLine, "Alias use line")
+			assert.Equal(t, tt.wantMerged, origin.Merged)
+		})
+	}
+
+	assert.Nil(t, newYAMLAnchorResolver("on: daily\nname: a&b"), "Resolver should be nil without anchors")
+}
+
+func TestValidateWithSchemaAndLocationReportsAnchorOrigin(t *testing.T) {
+	content := `---
+on: daily
+x-defaults:
+  issue: &issue-defaults
+    labels: [bot]
+    bogus: true
+safe-outputs:
+  create-issue:
+    <<: *issue-defaults
+---
+# Body`
+	filePath := filepath.Join(t.TempDir(), "workflow.md")
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+
+	result, err := ExtractFrontmatterFromContent(content)
+	require.NoError(t, err)
+
+	err = validateWithSchemaAndLocation(result.Frontmatter, mainWorkflowSchema, "main workflow file", filePath)
+	require.Error(t, err, "Unknown merged property should fail validation")
+	assert.Contains(t, err.Error(), "at '/safe-outputs/create-issue' (line 9, column 9)", "Error should point at the merge")
+	assert.Contains(t, err.Error(), "'bogus' comes from anchor '&issue-defaults' defined at line 4, merged at line 9", "Error should name the anchor definition")
+	assert.NotContains(t, err.Error(), "Unknown property: x-defaults", "x- keys should not be reported as unknown")
+}
@@ -16,6 +16,7 @@ type BaseMCPServerConfig struct {
 	// HTTP-specific fields
 	URL     string            `json:"url,omitempty" yaml:"url,omitempty"`         // URL for HTTP mode MCP servers
 	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"` // HTTP headers for HTTP mode
+	OAuth   *MCPOAuthConfig   `json:"oauth,omitempty" yaml:"oauth,omitempty"`     // OAuth 2.0 token acquisition for HTTP mode
 
 	// Container-specific fields
 	Container      string   `json:"container,omitempty" yaml:"container,omitempty"`           // Container image for the MCP server
@@ -23,3 +24,17 @@ type BaseMCPServerConfig struct {
 	EntrypointArgs []string `json:"entrypointArgs,omitempty" yaml:"entrypointArgs,omitempty"` // Arguments passed to container entrypoint
 	Mounts         []string `json:"mounts,omitempty" yaml:"mounts,omitempty"`                 // Volume mounts for container (format: "source:dest:mode")
 }
+
+// MCPOAuthConfig configures OAuth 2.0 access token acquisition for an HTTP MCP server.
+// The token is requested at runtime with the refresh_token grant when a refresh token is
+// configured, or with the client_credentials grant otherwise. The device authorization URL
+// is only used by `gh aw mcp oauth` to obtain a refresh token interactively.
+type MCPOAuthConfig struct {
+	TokenURL               string   `json:"token-url" yaml:"token-url"`                                                   // Token endpoint of the authorization server
+	DeviceAuthorizationURL string   `json:"device-authorization-url,omitempty" yaml:"device-authorization-url,omitempty"` // Device authorization endpoint (RFC 8628)
+	ClientID               string   `json:"client-id,omitempty" yaml:"client-id,omitempty"`                               // OAuth client identifier
+	ClientSecret           string   `json:"client-secret,omitempty" yaml:"client-secret,omitempty"`                       // Client secret, usually a secrets expression
+	RefreshToken           string   `json:"refresh-token,omitempty" yaml:"refresh-token,omitempty"`                       // Refresh token, usually a secrets expression
+	Scopes                 []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`                                     // Requested scopes
+	Audience               string   `json:"audience,omitempty" yaml:"audience,omitempty"`                                 // Optional audience or resource indicator
+}
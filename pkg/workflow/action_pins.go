@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
@@ -44,17 +45,176 @@ type ActionPinsData struct {
 	Entries map[string]ActionPin `json:"entries"` // key: "repo@version"
 }
 
+// ActionPinOverridesFile is the repository-relative file holding action pins that take
+// precedence over the embedded pins. It is maintained by gh aw upgrade-actions so that
+// repositories can pick up action security updates without waiting for a gh-aw release.
+const ActionPinOverridesFile = ".github/aw/action-pins.json"
+
 var (
 	// cachedActionPins holds the parsed and sorted action pins
 	cachedActionPins []ActionPin
 	// actionPinsOnce ensures the action pins are loaded only once
 	actionPinsOnce sync.Once
+
+	// effectiveActionPins holds the embedded pins merged with the repository overrides,
+	// or nil when no overrides are set
+	effectiveActionPins []ActionPin
+	// actionPinMirrors maps an action repository to its mirror reference (repo@sha),
+	// set from the action-pins key of the repository config
+	actionPinMirrors map[string]string
+	// actionPinOverridesMu guards effectiveActionPins and actionPinMirrors
+	actionPinOverridesMu sync.RWMutex
 )
 
-// getActionPins returns the action pins from the embedded JSON
+// getActionPins returns the action pins used for compilation: the embedded pins merged with
+// any repository overrides set by SetActionPinOverrides
+func getActionPins() []ActionPin {
+	actionPinOverridesMu.RLock()
+	defer actionPinOverridesMu.RUnlock()
+	if effectiveActionPins != nil {
+		return effectiveActionPins
+	}
+	return getEmbeddedActionPins()
+}
+
+// GetEmbeddedActionPins returns a copy of the action pins embedded in this gh-aw release
+func GetEmbeddedActionPins() []ActionPin {
+	return append([]ActionPin(nil), getEmbeddedActionPins()...)
+}
+
+// SetActionPinOverrides replaces the repository overrides. An override replaces the embedded pin
+// with the same repo and version; overrides for newer versions are added alongside the embedded
+// pins, so lookups that pick the latest version of a repository select them.
+func SetActionPinOverrides(overrides []ActionPin) {
+	actionPinOverridesMu.Lock()
+	defer actionPinOverridesMu.Unlock()
+
+	if len(overrides) == 0 {
+		effectiveActionPins = nil
+		return
+	}
+
+	merged := make(map[string]ActionPin)
+	for _, pin := range getEmbeddedActionPins() {
+		merged[formatActionCacheKey(pin.Repo, pin.Version)] = pin
+	}
+	for _, pin := range overrides {
+		merged[formatActionCacheKey(pin.Repo, pin.Version)] = pin
+	}
+
+	pins := make([]ActionPin, 0, len(merged))
+	for _, pin := range merged {
+		pins = append(pins, pin)
+	}
+	sortActionPins(pins)
+	actionPinsLog.Printf("Applied %d action pin overrides (%d effective pins)", len(overrides), len(pins))
+	effectiveActionPins = pins
+}
+
+// LoadActionPinOverrides reads ActionPinOverridesFile under repoRoot and applies it with
+// SetActionPinOverrides. A missing file clears any previous overrides.
+func LoadActionPinOverrides(repoRoot string) error {
+	data, err := os.ReadFile(filepath.Join(repoRoot, ActionPinOverridesFile))
+	if os.IsNotExist(err) {
+		SetActionPinOverrides(nil)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", ActionPinOverridesFile, err)
+	}
+
+	var overrides ActionPinsData
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", ActionPinOverridesFile, err)
+	}
+	pins := make([]ActionPin, 0, len(overrides.Entries))
+	for key, pin := range overrides.Entries {
+		if pin.Repo == "" || pin.Version == "" || !isValidFullSHA(pin.SHA) {
+			return fmt.Errorf("invalid entry %q in %s: repo, version and a full commit SHA are required", key, ActionPinOverridesFile)
+		}
+		pins = append(pins, pin)
+	}
+	SetActionPinOverrides(pins)
+	return nil
+}
+
+// ValidateActionPinMirrors checks the action-pins entries of the repository config: keys are
+// action repositories (owner/repo or owner/repo/path) and values are mirror references pinned
+// to a full commit SHA, e.g. my-org/checkout@<sha>.
+func ValidateActionPinMirrors(mirrors map[string]string) error {
+	actions := make([]string, 0, len(mirrors))
+	for action := range mirrors {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	for _, action := range actions {
+		owner, repo, ok := strings.Cut(action, "/")
+		if !ok || owner == "" || repo == "" || strings.ContainsAny(action, "@ ") {
+			return fmt.Errorf("action-pins: '%s' must be an action repository in owner/repo format", action)
+		}
+		if _, _, err := parseActionPinMirror(mirrors[action]); err != nil {
+			return fmt.Errorf("action-pins: %s: %w", action, err)
+		}
+	}
+	return nil
+}
+
+// parseActionPinMirror splits a mirror reference into its repository and commit SHA.
+// Mirrors are referenced by uses: on the same GitHub host, so a host prefix is rejected.
+func parseActionPinMirror(ref string) (string, string, error) {
+	repo, sha, ok := strings.Cut(ref, "@")
+	owner, _, hasRepo := strings.Cut(repo, "/")
+	if strings.ContainsAny(owner, ".:") {
+		return "", "", fmt.Errorf("mirror '%s' must not include a host: mirrors must be in <owner>/<repo>@<sha> format, which uses: resolves on the workflow's GitHub host (including GitHub Enterprise Server)", ref)
+	}
+	if !ok || !hasRepo || owner == "" || strings.ContainsAny(repo, " \t") {
+		return "", "", fmt.Errorf("mirror '%s' must be in <owner>/<repo>@<sha> format", ref)
+	}
+	if !isValidFullSHA(sha) {
+		return "", "", fmt.Errorf("mirror '%s' must be pinned to a full 40-character commit SHA", ref)
+	}
+	return repo, sha, nil
+}
+
+// SetActionPinMirrors replaces the action mirrors. A mirrored action is emitted as the mirror
+// reference for every version, taking precedence over the embedded pins and the overrides.
+// The mirrors must have been checked with ValidateActionPinMirrors.
+func SetActionPinMirrors(mirrors map[string]string) {
+	actionPinOverridesMu.Lock()
+	defer actionPinOverridesMu.Unlock()
+
+	if len(mirrors) == 0 {
+		actionPinMirrors = nil
+		return
+	}
+	actionPinMirrors = make(map[string]string, len(mirrors))
+	for action, ref := range mirrors {
+		actionPinMirrors[action] = ref
+	}
+	actionPinsLog.Printf("Applied %d action pin mirrors", len(mirrors))
+}
+
+// getActionPinMirror returns the mirror repository and SHA for an action, if it is mirrored
+func getActionPinMirror(actionRepo string) (string, string, bool) {
+	actionPinOverridesMu.RLock()
+	ref, ok := actionPinMirrors[actionRepo]
+	actionPinOverridesMu.RUnlock()
+	if !ok {
+		return "", "", false
+	}
+	repo, sha, err := parseActionPinMirror(ref)
+	if err != nil {
+		actionPinsLog.Printf("Ignoring invalid mirror for %s: %v", actionRepo, err)
+		return "", "", false
+	}
+	return repo, sha, true
+}
+
+// getEmbeddedActionPins returns the action pins from the embedded JSON
 // Returns a sorted slice of action pins (by version descending, then by repo name)
 // The data is parsed once on first call and cached for subsequent calls
-func getActionPins() []ActionPin {
+func getEmbeddedActionPins() []ActionPin {
 	actionPinsOnce.Do(func() {
 		actionPinsLog.Print("Unmarshaling action pins from embedded JSON (first call, will be cached)")
 
@@ -92,16 +252,7 @@ func getActionPins() []ActionPin {
 			pins = append(pins, pin)
 		}
 
-		// Sort by version (descending) then by repo name (ascending)
-		// Use standard library sort for better performance O(n log n) vs O(n²)
-		sort.Slice(pins, func(i, j int) bool {
-			// Compare versions first (descending order - higher version first)
-			if pins[i].Version != pins[j].Version {
-				return pins[i].Version > pins[j].Version
-			}
-			// Same version, sort by repo name (ascending order)
-			return pins[i].Repo < pins[j].Repo
-		})
+		sortActionPins(pins)
 
 		actionPinsLog.Printf("Successfully unmarshaled and sorted %d action pins from JSON", len(pins))
 		cachedActionPins = pins
@@ -110,6 +261,19 @@ func getActionPins() []ActionPin {
 	return cachedActionPins
 }
 
+// sortActionPins sorts pins by version (descending) then by repo name (ascending)
+func sortActionPins(pins []ActionPin) {
+	// Use standard library sort for better performance O(n log n) vs O(n²)
+	sort.Slice(pins, func(i, j int) bool {
+		// Compare versions first (descending order - higher version first)
+		if pins[i].Version != pins[j].Version {
+			return pins[i].Version > pins[j].Version
+		}
+		// Same version, sort by repo name (ascending order)
+		return pins[i].Repo < pins[j].Repo
+	})
+}
+
 // sortPinsByVersion sorts action pins by version in descending order (highest first).
 // This function returns a new sorted slice without modifying the input.
 // This is an immutable operation for better safety and clarity.
@@ -151,6 +315,10 @@ func GetActionPin(actionRepo string) string {
 
 	// Return the latest version (first after sorting)
 	latestPin := sortedPins[0]
+	if mirrorRepo, mirrorSHA, ok := getActionPinMirror(actionRepo); ok {
+		actionPinsLog.Printf("Using mirror %s for %s", mirrorRepo, actionRepo)
+		return formatActionReference(mirrorRepo, mirrorSHA, latestPin.Version)
+	}
 	return formatActionReference(actionRepo, latestPin.SHA, latestPin.Version)
 }
 
@@ -161,6 +329,12 @@ func GetActionPin(actionRepo string) string {
 func GetActionPinWithData(actionRepo, version string, data *WorkflowData) (string, error) {
 	actionPinsLog.Printf("Resolving action pin: repo=%s, version=%s, strict_mode=%t", actionRepo, version, data.StrictMode)
 
+	// Mirrors from the repository config replace the action for every version
+	if mirrorRepo, mirrorSHA, ok := getActionPinMirror(actionRepo); ok {
+		actionPinsLog.Printf("Using mirror %s for %s@%s", mirrorRepo, actionRepo, version)
+		return formatActionReference(mirrorRepo, mirrorSHA, version), nil
+	}
+
 	// Check if version is already a full 40-character SHA
 	isAlreadySHA := isValidFullSHA(version)
 
@@ -257,7 +431,7 @@ func GetActionPinWithData(actionRepo, version string, data *WorkflowData) (strin
 				cacheKey := formatActionCacheKey(actionRepo, version)
 
 				// Only emit warning if we haven't already warned about this action
-				if !data.ActionPinWarnings[cacheKey] {
+				if !data.ActionPinWarnings[cacheKey] && !data.NoActionPinWarnings {
 					warningMsg := fmt.Sprintf("Unable to resolve %s@%s dynamically, using hardcoded pin for %s@%s",
 						actionRepo, version, actionRepo, selectedPin.Version)
 					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
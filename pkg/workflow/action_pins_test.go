@@ -5,6 +5,7 @@ package workflow
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
@@ -1631,3 +1632,122 @@ func TestSliceToStepsErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+// TestLoadActionPinOverrides verifies that repository overrides take precedence over embedded pins
+func TestLoadActionPinOverrides(t *testing.T) {
+	t.Cleanup(func() { SetActionPinOverrides(nil) })
+
+	repoRoot := testutil.TempDir(t, "action-pin-overrides-test")
+	if err := LoadActionPinOverrides(repoRoot); err != nil {
+		t.Fatalf("LoadActionPinOverrides() without a file should succeed, got %v", err)
+	}
+	embedded := GetActionPin("actions/checkout")
+
+	overridesPath := filepath.Join(repoRoot, ActionPinOverridesFile)
+	if err := os.MkdirAll(filepath.Dir(overridesPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	overrides := `{
+  "entries": {
+    "actions/checkout@v99.0.0": {
+      "repo": "actions/checkout",
+      "version": "v99.0.0",
+      "sha": "1111111111111111111111111111111111111111"
+    }
+  }
+}
+`
+	if err := os.WriteFile(overridesPath, []byte(overrides), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadActionPinOverrides(repoRoot); err != nil {
+		t.Fatalf("LoadActionPinOverrides() error = %v", err)
+	}
+
+	want := "actions/checkout@1111111111111111111111111111111111111111 # v99.0.0"
+	if got := GetActionPin("actions/checkout"); got != want {
+		t.Errorf("GetActionPin() with override = %q, want %q", got, want)
+	}
+	if got := len(GetEmbeddedActionPins()); got != len(getActionPins())-1 {
+		t.Errorf("override should add one pin to the %d embedded pins, got %d effective pins", got, len(getActionPins()))
+	}
+
+	// Invalid entries are rejected
+	if err := os.WriteFile(overridesPath, []byte(`{"entries": {"actions/checkout@v1": {"repo": "actions/checkout", "version": "v1", "sha": "abc"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadActionPinOverrides(repoRoot); err == nil {
+		t.Error("LoadActionPinOverrides() should reject an entry without a full SHA")
+	}
+
+	// Removing the file restores the embedded pins
+	if err := os.Remove(overridesPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadActionPinOverrides(repoRoot); err != nil {
+		t.Fatalf("LoadActionPinOverrides() error = %v", err)
+	}
+	if got := GetActionPin("actions/checkout"); got != embedded {
+		t.Errorf("GetActionPin() after removing overrides = %q, want %q", got, embedded)
+	}
+}
+
+func TestActionPinMirrors(t *testing.T) {
+	t.Cleanup(func() { SetActionPinMirrors(nil) })
+
+	mirror := "my-org/checkout@2222222222222222222222222222222222222222"
+	if err := ValidateActionPinMirrors(map[string]string{"actions/checkout": mirror}); err != nil {
+		t.Fatalf("ValidateActionPinMirrors() error = %v", err)
+	}
+	SetActionPinMirrors(map[string]string{"actions/checkout": mirror})
+
+	embedded := GetActionPin("actions/checkout")
+	version := embedded[strings.LastIndex(embedded, "# ")+2:]
+	if want := mirror + " # " + version; embedded != want {
+		t.Errorf("GetActionPin() with mirror = %q, want %q", embedded, want)
+	}
+
+	got, err := GetActionPinWithData("actions/checkout", "v4", &WorkflowData{StrictMode: true})
+	if err != nil {
+		t.Fatalf("GetActionPinWithData() error = %v", err)
+	}
+	if want := mirror + " # v4"; got != want {
+		t.Errorf("GetActionPinWithData() with mirror = %q, want %q", got, want)
+	}
+
+	if got := GetActionPin("actions/github-script"); strings.HasPrefix(got, "my-org/") {
+		t.Errorf("GetActionPin() should not mirror other actions, got %q", got)
+	}
+
+	invalid := map[string]map[string]string{
+		"must be pinned to a full 40-character commit SHA": {"actions/checkout": "my-org/checkout@v5"},
+		"must be in <owner>/<repo>@<sha> format":           {"actions/checkout": "my-org/checkout"},
+		"must not include a host":                          {"actions/checkout": "ghe.example.com/actions/checkout@2222222222222222222222222222222222222222"},
+		"must be an action repository in owner/repo":       {"actions/checkout@v5": mirror},
+	}
+	for wantErr, mirrors := range invalid {
+		err := ValidateActionPinMirrors(mirrors)
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("ValidateActionPinMirrors(%v) error = %v, want %q", mirrors, err, wantErr)
+		}
+	}
+}
+
+func TestActionPinMirrorWithHostNamesOwnerRepoForm(t *testing.T) {
+	for _, mirror := range []string{
+		"ghe.example.com/actions/checkout",
+		"ghe.example.com/actions/checkout@2222222222222222222222222222222222222222",
+		"ghe.example.com:8443/actions/checkout@2222222222222222222222222222222222222222",
+	} {
+		err := ValidateActionPinMirrors(map[string]string{"actions/checkout": mirror})
+		if err == nil {
+			t.Errorf("ValidateActionPinMirrors(%q) should reject the host prefix", mirror)
+			continue
+		}
+		for _, want := range []string{"must not include a host", "<owner>/<repo>@<sha>"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("ValidateActionPinMirrors(%q) error = %v, want %q", mirror, err, want)
+			}
+		}
+	}
+}
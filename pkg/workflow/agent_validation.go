@@ -13,6 +13,7 @@
 //   - validateAgentFile() - Validates custom agent file exists
 //   - validateMaxTurnsSupport() - Validates max-turns feature support
 //   - validateMaxContinuationsSupport() - Validates max-continuations feature support
+//   - validateMaxToolCallsSupport() - Validates max-tool-calls feature support
 //   - validateWebSearchSupport() - Validates web-search feature support (warning)
 //   - validateWorkflowRunBranches() - Validates workflow_run has branch restrictions
 //
@@ -49,6 +50,7 @@ import (
 	"path/filepath"
 
 	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/messages"
 	"github.com/goccy/go-yaml"
 )
 
@@ -83,11 +85,11 @@ func (c *Compiler) validateAgentFile(workflowData *WorkflowData, markdownPath st
 	if _, err := os.Stat(fullAgentPath); err != nil {
 		if os.IsNotExist(err) {
 			return formatCompilerError(markdownPath, "error",
-				fmt.Sprintf("agent file '%s' does not exist. Ensure the file exists in the repository and is properly imported.", agentPath), nil)
+				messages.Format(messages.AgentFileMissing, messages.Args{"path": agentPath}), nil)
 		}
 		// Other error (permissions, etc.)
 		return formatCompilerError(markdownPath, "error",
-			fmt.Sprintf("failed to access agent file '%s': %v", agentPath, err), err)
+			messages.Format(messages.AgentFileInaccessible, messages.Args{"path": agentPath, "error": err}), err)
 	}
 
 	if c.verbose {
@@ -146,6 +148,25 @@ func (c *Compiler) validateMaxContinuationsSupport(frontmatter map[string]any, e
 	return nil
 }
 
+// validateMaxToolCallsSupport validates that max-tool-calls is only used with engines that support this feature
+func (c *Compiler) validateMaxToolCallsSupport(frontmatter map[string]any, engine CodingAgentEngine) error {
+	_, engineConfig := c.ExtractEngineConfig(frontmatter)
+
+	if engineConfig == nil || engineConfig.MaxToolCalls == 0 {
+		// No max-tool-calls specified, no validation needed
+		return nil
+	}
+
+	agentValidationLog.Printf("Validating max-tool-calls support: engine=%s, maxToolCalls=%d", engine.GetID(), engineConfig.MaxToolCalls)
+
+	if !engine.SupportsMaxToolCalls() {
+		agentValidationLog.Printf("Engine %s does not support max-tool-calls feature", engine.GetID())
+		return fmt.Errorf("max-tool-calls not supported: engine '%s' does not support the max-tool-calls feature", engine.GetID())
+	}
+
+	return nil
+}
+
 // validateWebSearchSupport validates that web-search tool is only used with engines that support this feature
 func (c *Compiler) validateWebSearchSupport(tools map[string]any, engine CodingAgentEngine) {
 	// Check if web-search tool is requested
@@ -161,7 +182,7 @@ func (c *Compiler) validateWebSearchSupport(tools map[string]any, engine CodingA
 	// web-search is specified, check if the engine supports it
 	if !engine.SupportsWebSearch() {
 		agentValidationLog.Printf("Engine %s does not natively support web-search tool, emitting warning", engine.GetID())
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Engine '%s' does not support the web-search tool. See https://github.github.com/gh-aw/guides/web-search/ for alternatives.", engine.GetID())))
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(messages.Format(messages.WebSearchUnsupported, messages.Args{"engine": engine.GetID()})))
 		c.IncrementWarningCount()
 	}
 }
@@ -173,6 +194,12 @@ func (c *Compiler) validateWorkflowRunBranches(workflowData *WorkflowData, markd
 		return nil
 	}
 
+	// The workflow_run trigger generated from needs: follows an agentic workflow of the same
+	// repository, and the pre-activation job checks the event that upstream run handled
+	if workflowData.WorkflowNeeds != nil {
+		return nil
+	}
+
 	agentValidationLog.Print("Validating workflow_run triggers for branch restrictions")
 
 	// Parse the On field as YAML to check for workflow_run
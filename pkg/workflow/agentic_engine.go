@@ -38,8 +38,11 @@ type GitHubActionStep []string
 //   CapabilityProvider (feature detection - optional)
 //   ├── SupportsToolsAllowlist()
 //   ├── SupportsMaxTurns()
+//   ├── SupportsMaxToolCalls()
 //   ├── SupportsWebFetch()
-//   └── SupportsWebSearch()
+//   ├── SupportsWebSearch()
+//   ├── SupportsBashAllowlist()
+//   └── SupportsWorkspaceAccess()
 //
 //   WorkflowExecutor (compilation - required)
 //   ├── GetDeclaredOutputFiles()
@@ -117,6 +120,9 @@ type CapabilityProvider interface {
 	// SupportsWebSearch returns true if this engine has built-in support for the web-search tool
 	SupportsWebSearch() bool
 
+	// SupportsBashAllowlist returns true if this engine can restrict the bash tool to specific commands
+	SupportsBashAllowlist() bool
+
 	// SupportsPlugins returns true if this engine supports plugin installation
 	// When true, plugins can be installed using the engine's plugin install command
 	SupportsPlugins() bool
@@ -124,6 +130,13 @@ type CapabilityProvider interface {
 	// SupportsMaxContinuations returns true if this engine supports the max-continuations feature
 	// When true, max-continuations > 1 enables autopilot/multi-run mode for the engine
 	SupportsMaxContinuations() bool
+
+	// SupportsMaxToolCalls returns true if this engine can stop the agent after a number of tool calls
+	SupportsMaxToolCalls() bool
+
+	// SupportsWorkspaceAccess returns true if the agent reads the repository working tree with its
+	// own tools. Engines limited to MCP tools only need a checkout when a tool or step uses it.
+	SupportsWorkspaceAccess() bool
 }
 
 // WorkflowExecutor handles workflow compilation and execution
@@ -216,9 +229,12 @@ type BaseEngine struct {
 	supportsToolsAllowlist   bool
 	supportsMaxTurns         bool
 	supportsMaxContinuations bool
+	supportsMaxToolCalls     bool
 	supportsWebFetch         bool
 	supportsWebSearch        bool
+	supportsBashAllowlist    bool
 	supportsPlugins          bool
+	supportsWorkspaceAccess  bool
 	llmGatewayPort           int
 }
 
@@ -254,6 +270,10 @@ func (e *BaseEngine) SupportsWebSearch() bool {
 	return e.supportsWebSearch
 }
 
+func (e *BaseEngine) SupportsBashAllowlist() bool {
+	return e.supportsBashAllowlist
+}
+
 func (e *BaseEngine) SupportsPlugins() bool {
 	return e.supportsPlugins
 }
@@ -262,6 +282,14 @@ func (e *BaseEngine) SupportsMaxContinuations() bool {
 	return e.supportsMaxContinuations
 }
 
+func (e *BaseEngine) SupportsMaxToolCalls() bool {
+	return e.supportsMaxToolCalls
+}
+
+func (e *BaseEngine) SupportsWorkspaceAccess() bool {
+	return e.supportsWorkspaceAccess
+}
+
 func (e *BaseEngine) getLLMGatewayPort() int {
 	return e.llmGatewayPort
 }
@@ -357,6 +385,8 @@ func NewEngineRegistry() *EngineRegistry {
 	registry.Register(NewCodexEngine())
 	registry.Register(NewCopilotEngine())
 	registry.Register(NewGeminiEngine())
+	registry.Register(NewGitHubModelsEngine())
+	registry.Register(NewOllamaEngine())
 
 	agenticEngineLog.Printf("Registered %d engines", len(registry.engines))
 	return registry
@@ -0,0 +1,59 @@
+package workflow
+
+import (
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var answerDiscussionLog = logger.New("workflow:answer_discussion")
+
+// AnswerDiscussionConfig holds configuration for answering GitHub discussions from agent output
+type AnswerDiscussionConfig struct {
+	BaseSafeOutputConfig   `yaml:",inline"`
+	SafeOutputTargetConfig `yaml:",inline"`
+	AllowedCategories      []string `yaml:"allowed-categories,omitempty"` // Discussion categories (ID, name, or slug) that can be answered. If omitted, any category is allowed.
+	MarkAsAnswer           *string  `yaml:"mark-as-answer,omitempty"`     // When true (default), marks the posted comment as the accepted answer in answerable categories
+}
+
+// parseAnswerDiscussionConfig handles answer-discussion configuration
+func (c *Compiler) parseAnswerDiscussionConfig(outputMap map[string]any) *AnswerDiscussionConfig {
+	configData, exists := outputMap["answer-discussion"]
+	if !exists {
+		return nil
+	}
+
+	answerDiscussionLog.Print("Parsing answer-discussion configuration")
+	config := &AnswerDiscussionConfig{}
+
+	configMap, ok := configData.(map[string]any)
+	if !ok {
+		// If configData is nil or not a map, still set the default max
+		config.Max = defaultIntStr(1)
+		return config
+	}
+
+	// Parse target config (target, target-repo, allowed-repos) with validation
+	targetConfig, isInvalid := ParseTargetConfig(configMap)
+	if isInvalid {
+		return nil // Invalid configuration (e.g., wildcard target-repo), return nil to cause validation error
+	}
+	config.SafeOutputTargetConfig = targetConfig
+
+	// Parse allowed-categories
+	config.AllowedCategories = ParseStringArrayFromConfig(configMap, "allowed-categories", answerDiscussionLog)
+
+	// Pre-process templatable bool fields
+	if err := preprocessBoolFieldAsString(configMap, "mark-as-answer", answerDiscussionLog); err != nil {
+		answerDiscussionLog.Printf("Invalid mark-as-answer value: %v", err)
+		return nil
+	}
+	if markAsAnswer, ok := configMap["mark-as-answer"].(string); ok {
+		config.MarkAsAnswer = &markAsAnswer
+	}
+
+	// Parse common base fields with default max of 1
+	c.parseBaseSafeOutputConfig(configMap, &config.BaseSafeOutputConfig, 1)
+
+	answerDiscussionLog.Printf("Parsed answer-discussion config: max=%v, target=%s, allowed_categories=%d",
+		config.Max, config.Target, len(config.AllowedCategories))
+	return config
+}
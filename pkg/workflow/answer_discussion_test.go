@@ -0,0 +1,104 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnswerDiscussionConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		outputMap map[string]any
+		expected  *AnswerDiscussionConfig
+	}{
+		{
+			name:      "not configured",
+			outputMap: map[string]any{},
+		},
+		{
+			name:      "null config uses defaults",
+			outputMap: map[string]any{"answer-discussion": nil},
+			expected:  &AnswerDiscussionConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Max: defaultIntStr(1)}},
+		},
+		{
+			name: "all options",
+			outputMap: map[string]any{"answer-discussion": map[string]any{
+				"target":             "*",
+				"allowed-categories": []any{"Q&A", "help"},
+				"mark-as-answer":     false,
+				"max":                3,
+			}},
+			expected: &AnswerDiscussionConfig{
+				BaseSafeOutputConfig:   BaseSafeOutputConfig{Max: defaultIntStr(3)},
+				SafeOutputTargetConfig: SafeOutputTargetConfig{Target: "*"},
+				AllowedCategories:      []string{"Q&A", "help"},
+				MarkAsAnswer:           strPtr("false"),
+			},
+		},
+		{
+			name: "cross-repository target",
+			outputMap: map[string]any{"answer-discussion": map[string]any{
+				"target-repo":   "octo-org/community",
+				"allowed-repos": []any{"octo-org/support"},
+			}},
+			expected: &AnswerDiscussionConfig{
+				BaseSafeOutputConfig:   BaseSafeOutputConfig{Max: defaultIntStr(1)},
+				SafeOutputTargetConfig: SafeOutputTargetConfig{TargetRepoSlug: "octo-org/community", AllowedRepos: []string{"octo-org/support"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			config := compiler.parseAnswerDiscussionConfig(tt.outputMap)
+			assert.Equal(t, tt.expected, config, "Answer discussion config should match")
+		})
+	}
+}
+
+func TestAnswerDiscussionCompiled(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "answer-discussion-*")
+	workflowsDir := filepath.Join(tmpDir, constants.GetWorkflowDir())
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows directory")
+
+	content := `---
+on:
+  discussion:
+    types: [created]
+permissions:
+  contents: read
+  discussions: read
+engine: claude
+safe-outputs:
+  answer-discussion:
+    allowed-categories: [help]
+  create-discussion:
+    allowed-categories: [Announcements, Ideas]
+---
+
+# Answer discussion test
+`
+	workflowFile := filepath.Join(workflowsDir, "answer-discussion.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "Workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "answer-discussion.lock.yml"))
+	require.NoError(t, err, "Lock file should be created")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, `\"answer_discussion\":{`, "Handler config should include answer_discussion")
+	assert.Contains(t, lock, `\"allowed_categories\":[\"help\"]`, "Answer allow-list should be passed to the handler")
+	assert.Contains(t, lock, `\"allowed_categories\":[\"Announcements\",\"Ideas\"]`, "Create allow-list should be passed to the handler")
+	assert.Contains(t, lock, "discussions: write", "Safe outputs job should be able to write discussions")
+}
@@ -0,0 +1,231 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var artifactsConfigLog = logger.New("workflow:artifacts_config")
+
+// artifactPrefixPattern restricts artifacts.prefix to characters that are valid in artifact
+// names and directory names alike
+var artifactPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ArtifactsConfig holds the per-workflow artifact settings from the artifacts: frontmatter field
+type ArtifactsConfig struct {
+	RetentionDays int    `yaml:"retention-days,omitempty"` // retention-days applied to every upload-artifact step
+	Prefix        string `yaml:"prefix,omitempty"`         // prefix prepended to every artifact name
+}
+
+// parseArtifactsConfig parses the artifacts: frontmatter field. It returns nil when the
+// field is absent.
+func parseArtifactsConfig(frontmatter map[string]any) (*ArtifactsConfig, error) {
+	raw, exists := frontmatter["artifacts"]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+	artifactsMap, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("artifacts: must be an object, got %T", raw)
+	}
+
+	config := &ArtifactsConfig{}
+	if value, exists := artifactsMap["retention-days"]; exists {
+		days, ok := parseIntValue(value)
+		if !ok {
+			return nil, fmt.Errorf("artifacts.retention-days: must be an integer, got %v", value)
+		}
+		if err := validateIntRange(days, 1, maxArtifactRetentionDays, "artifacts.retention-days"); err != nil {
+			return nil, err
+		}
+		config.RetentionDays = days
+	}
+	if value, exists := artifactsMap["prefix"]; exists {
+		prefix, ok := value.(string)
+		if !ok || !artifactPrefixPattern.MatchString(prefix) {
+			return nil, fmt.Errorf("artifacts.prefix: must start with a letter or digit and contain only letters, digits, '.', '_' and '-', got %v", value)
+		}
+		config.Prefix = strings.TrimSuffix(prefix, "-")
+	}
+
+	artifactsConfigLog.Printf("Parsed artifacts config: retention-days=%d, prefix=%q", config.RetentionDays, config.Prefix)
+	return config, nil
+}
+
+// artifactStep describes an upload-artifact or download-artifact step found in compiled YAML
+type artifactStep struct {
+	upload    bool
+	keyIndent int // indentation of the step's keys (uses:, with:, ...)
+	start     int // index of the step's first line
+	end       int // index one past the step's last line
+}
+
+// findArtifactSteps locates every upload-artifact and download-artifact step in lines
+func findArtifactSteps(lines []string) []artifactStep {
+	var steps []artifactStep
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		trimmed = strings.TrimPrefix(trimmed, "- ")
+		upload := strings.HasPrefix(trimmed, "uses: actions/upload-artifact@")
+		if !upload && !strings.HasPrefix(trimmed, "uses: actions/download-artifact@") {
+			continue
+		}
+
+		keyIndent := len(line) - len(strings.TrimLeft(line, " "))
+		if strings.HasPrefix(strings.TrimSpace(line), "- ") {
+			keyIndent += 2
+		}
+		step := artifactStep{upload: upload, keyIndent: keyIndent, start: i, end: len(lines)}
+
+		// The step starts at the list item marker at keyIndent-2
+		for j := i; j >= 0; j-- {
+			if lineIndent(lines[j]) == keyIndent-2 && strings.HasPrefix(strings.TrimSpace(lines[j]), "- ") {
+				step.start = j
+				break
+			}
+			if strings.TrimSpace(lines[j]) != "" && lineIndent(lines[j]) < keyIndent-2 {
+				break
+			}
+		}
+		// The step ends at the next line that is not nested under its keys
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) != "" && lineIndent(lines[j]) < keyIndent {
+				step.end = j
+				break
+			}
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// lineIndent returns the number of leading spaces of line
+func lineIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// applyArtifactsConfig rewrites the upload-artifact and download-artifact steps of a compiled
+// workflow so every artifact name carries the configured prefix and every upload uses the
+// configured retention-days. Downloads are prefixed too, so artifacts still match up between
+// the jobs of a run.
+func applyArtifactsConfig(yamlContent string, config *ArtifactsConfig) string {
+	if config == nil || (config.RetentionDays == 0 && config.Prefix == "") {
+		return yamlContent
+	}
+
+	lines := strings.Split(yamlContent, "\n")
+	steps := findArtifactSteps(lines)
+	artifactsConfigLog.Printf("Applying artifacts config to %d artifact steps", len(steps))
+
+	// Process steps from the bottom up so inserted lines do not shift later steps
+	for s := len(steps) - 1; s >= 0; s-- {
+		step := steps[s]
+
+		withLine := -1
+		for j := step.start; j < step.end; j++ {
+			trimmed := strings.TrimPrefix(strings.TrimSpace(lines[j]), "- ")
+			if strings.HasPrefix(trimmed, "with:") && (lineIndent(lines[j]) == step.keyIndent || lineIndent(lines[j]) == step.keyIndent-2) {
+				withLine = j
+				break
+			}
+		}
+		if withLine < 0 {
+			continue
+		}
+
+		childIndent := -1
+		withEnd := step.end
+		retentionLine := -1
+		for j := withLine + 1; j < step.end; j++ {
+			if strings.TrimSpace(lines[j]) == "" {
+				continue
+			}
+			indent := lineIndent(lines[j])
+			if indent <= step.keyIndent {
+				withEnd = j
+				break
+			}
+			if childIndent < 0 {
+				childIndent = indent
+			}
+			if indent != childIndent {
+				continue
+			}
+			key, value, found := strings.Cut(strings.TrimSpace(lines[j]), ":")
+			if !found {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			switch key {
+			case "name", "pattern":
+				if config.Prefix != "" && value != "" {
+					lines[j] = strings.Repeat(" ", indent) + key + ": " + prefixArtifactName(config.Prefix, value)
+				}
+			case "retention-days":
+				retentionLine = j
+			}
+		}
+
+		if !step.upload || config.RetentionDays == 0 {
+			continue
+		}
+		if childIndent < 0 {
+			childIndent = step.keyIndent + 2
+		}
+		retention := fmt.Sprintf("%sretention-days: %d", strings.Repeat(" ", childIndent), config.RetentionDays)
+		if retentionLine >= 0 {
+			lines[retentionLine] = retention
+			continue
+		}
+		// Insert after the last non-empty line of the with: block
+		insertAt := withEnd
+		for insertAt > withLine+1 && strings.TrimSpace(lines[insertAt-1]) == "" {
+			insertAt--
+		}
+		lines = append(lines[:insertAt], append([]string{retention}, lines[insertAt:]...)...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// prefixArtifactName prepends prefix to an artifact name value, keeping any surrounding quotes
+func prefixArtifactName(prefix, value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return string(value[0]) + prefix + "-" + value[1:]
+	}
+	return prefix + "-" + value
+}
+
+// ExtractArtifactRetentionFromLockFile reports the retention of the artifacts uploaded by a
+// compiled workflow. It returns the largest retention-days set on an upload step, and
+// usesDefault when at least one upload falls back to the repository's retention setting.
+func ExtractArtifactRetentionFromLockFile(lockFilePath string) (maxDays int, usesDefault bool, uploads int) {
+	content, err := os.ReadFile(lockFilePath)
+	if err != nil {
+		return 0, false, 0
+	}
+	lines := strings.Split(string(content), "\n")
+	for _, step := range findArtifactSteps(lines) {
+		if !step.upload {
+			continue
+		}
+		uploads++
+		days := 0
+		for j := step.start; j < step.end; j++ {
+			if value, found := strings.CutPrefix(strings.TrimSpace(lines[j]), "retention-days:"); found {
+				days, _ = strconv.Atoi(strings.TrimSpace(value))
+				break
+			}
+		}
+		if days == 0 {
+			usesDefault = true
+		}
+		maxDays = max(maxDays, days)
+	}
+	return maxDays, usesDefault, uploads
+}
@@ -0,0 +1,151 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArtifactsConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		want        *ArtifactsConfig
+		errorMsg    string
+	}{
+		{
+			name:        "absent",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "retention and prefix",
+			frontmatter: map[string]any{"artifacts": map[string]any{"retention-days": 7, "prefix": "triage-bot"}},
+			want:        &ArtifactsConfig{RetentionDays: 7, Prefix: "triage-bot"},
+		},
+		{
+			name:        "trailing dash is dropped",
+			frontmatter: map[string]any{"artifacts": map[string]any{"prefix": "bot-"}},
+			want:        &ArtifactsConfig{Prefix: "bot"},
+		},
+		{
+			name:        "retention out of range",
+			frontmatter: map[string]any{"artifacts": map[string]any{"retention-days": 91}},
+			errorMsg:    "artifacts.retention-days must be between 1 and 90",
+		},
+		{
+			name:        "invalid prefix",
+			frontmatter: map[string]any{"artifacts": map[string]any{"prefix": "my bot"}},
+			errorMsg:    "artifacts.prefix",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseArtifactsConfig(tt.frontmatter)
+			if tt.errorMsg != "" {
+				require.Error(t, err, "invalid config should be rejected")
+				assert.Contains(t, err.Error(), tt.errorMsg, "error should name the field")
+				return
+			}
+			require.NoError(t, err, "valid config should parse")
+			assert.Equal(t, tt.want, got, "parsed config should match")
+		})
+	}
+}
+
+func TestApplyArtifactsConfig(t *testing.T) {
+	input := `    steps:
+      - name: Upload agent output
+        if: always()
+        uses: actions/upload-artifact@abc # v7
+        with:
+          name: agent-output
+          path: /tmp/out.json
+          if-no-files-found: ignore
+      - name: Upload activation
+        uses: actions/upload-artifact@abc # v7
+        with:
+          name: "activation"
+          path: /tmp/aw_info.json
+          retention-days: 1
+      - name: Download safe outputs
+        uses: actions/download-artifact@def # v8
+        with:
+          pattern: safe-output*
+          path: /tmp/safe
+      - name: Checkout
+        uses: actions/checkout@123 # v6
+        with:
+          name: untouched`
+
+	want := `    steps:
+      - name: Upload agent output
+        if: always()
+        uses: actions/upload-artifact@abc # v7
+        with:
+          name: bot-agent-output
+          path: /tmp/out.json
+          if-no-files-found: ignore
+          retention-days: 5
+      - name: Upload activation
+        uses: actions/upload-artifact@abc # v7
+        with:
+          name: "bot-activation"
+          path: /tmp/aw_info.json
+          retention-days: 5
+      - name: Download safe outputs
+        uses: actions/download-artifact@def # v8
+        with:
+          pattern: bot-safe-output*
+          path: /tmp/safe
+      - name: Checkout
+        uses: actions/checkout@123 # v6
+        with:
+          name: untouched`
+
+	assert.Equal(t, want, applyArtifactsConfig(input, &ArtifactsConfig{RetentionDays: 5, Prefix: "bot"}), "artifact steps should be rewritten")
+	assert.Equal(t, input, applyArtifactsConfig(input, nil), "nil config should leave the YAML unchanged")
+}
+
+func TestArtifactsConfigCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "artifacts-config-test")
+	workflowPath := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on: issues
+engine: copilot
+permissions:
+  contents: read
+artifacts:
+  retention-days: 7
+  prefix: triage-bot
+safe-outputs:
+  add-comment:
+---
+
+Triage the issue.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockPath := stringutil.MarkdownToLockFile(workflowPath)
+	lockContent, err := os.ReadFile(lockPath)
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "name: triage-bot-activation\n", "activation artifact should be prefixed")
+	assert.Contains(t, lock, "name: triage-bot-agent-output\n", "agent output artifact should be prefixed")
+	assert.NotContains(t, lock, "          name: agent-output\n", "no artifact should keep its unprefixed name")
+	assert.NotContains(t, lock, "retention-days: 1\n", "workflow retention should override feature defaults")
+
+	maxDays, usesDefault, uploads := ExtractArtifactRetentionFromLockFile(lockPath)
+	assert.Equal(t, 7, maxDays, "every upload should keep artifacts for 7 days")
+	assert.False(t, usesDefault, "no upload should fall back to the repository default")
+	assert.Positive(t, uploads, "uploads should be counted")
+}
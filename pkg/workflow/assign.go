@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"errors"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var assignLog = logger.New("workflow:assign")
+
+// AssignConfig holds configuration for assigning users and team members to issues and pull requests
+type AssignConfig struct {
+	BaseSafeOutputConfig   `yaml:",inline"`
+	SafeOutputTargetConfig `yaml:",inline"`
+	AllowedUsers           []string `yaml:"allowed-users,omitempty"` // Users that can be assigned. If omitted, any user is allowed.
+	AllowedTeams           []string `yaml:"allowed-teams,omitempty"` // Team slugs whose members can be assigned. If omitted, no team can be assigned.
+}
+
+// parseAssignConfig handles assign configuration
+func (c *Compiler) parseAssignConfig(outputMap map[string]any) *AssignConfig {
+	configData, exists := outputMap["assign"]
+	if !exists {
+		return nil
+	}
+
+	assignLog.Print("Parsing assign configuration")
+	config := &AssignConfig{}
+
+	configMap, ok := configData.(map[string]any)
+	if !ok {
+		// If configData is nil or not a map, still set the default max
+		config.Max = defaultIntStr(1)
+		return config
+	}
+
+	// Parse target config (target, target-repo, allowed-repos) with validation
+	targetConfig, isInvalid := ParseTargetConfig(configMap)
+	if isInvalid {
+		return nil // Invalid configuration (e.g., wildcard target-repo), return nil to cause validation error
+	}
+	config.SafeOutputTargetConfig = targetConfig
+
+	config.AllowedUsers = ParseStringArrayFromConfig(configMap, "allowed-users", assignLog)
+	config.AllowedTeams = ParseStringArrayFromConfig(configMap, "allowed-teams", assignLog)
+
+	// Parse common base fields with default max of 1
+	c.parseBaseSafeOutputConfig(configMap, &config.BaseSafeOutputConfig, 1)
+
+	assignLog.Printf("Parsed assign config: max=%v, target=%s, allowed_users=%d, allowed_teams=%d",
+		config.Max, config.Target, len(config.AllowedUsers), len(config.AllowedTeams))
+	return config
+}
+
+// assignNeedsTeamMembers reports whether the assign handler expands teams into their members,
+// which requires the organization members read permission
+func assignNeedsTeamMembers(config *SafeOutputsConfig) bool {
+	return config != nil && config.Assign != nil && len(config.Assign.AllowedTeams) > 0
+}
+
+// validateTeamSafeOutputs checks that request-review and assign have a token able to work with
+// teams when allowed-teams is configured. The GITHUB_TOKEN of a workflow run is not an organization
+// member: it can neither request reviews from teams nor list team members.
+func validateTeamSafeOutputs(config *SafeOutputsConfig) error {
+	if config == nil {
+		return nil
+	}
+	hasSharedToken := config.GitHubToken != "" || config.GitHubApp != nil
+
+	if config.RequestReview != nil && len(config.RequestReview.AllowedTeams) > 0 && !hasSharedToken && config.RequestReview.GitHubToken == "" {
+		return errors.New("safe-outputs.request-review.allowed-teams requires a github-token or safe-outputs.github-app: the GITHUB_TOKEN cannot request reviews from teams")
+	}
+	if assignNeedsTeamMembers(config) && !hasSharedToken && config.Assign.GitHubToken == "" {
+		return errors.New("safe-outputs.assign.allowed-teams requires a github-token or safe-outputs.github-app with organization members read access: the GITHUB_TOKEN cannot list team members")
+	}
+
+	assignLog.Print("Validated team allow-lists of request-review and assign")
+	return nil
+}
@@ -0,0 +1,184 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAssignConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		outputMap map[string]any
+		expected  *AssignConfig
+	}{
+		{
+			name:      "not configured",
+			outputMap: map[string]any{},
+		},
+		{
+			name:      "null config uses defaults",
+			outputMap: map[string]any{"assign": nil},
+			expected:  &AssignConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Max: defaultIntStr(1)}},
+		},
+		{
+			name: "allow-lists",
+			outputMap: map[string]any{"assign": map[string]any{
+				"allowed-users": []any{"octocat"},
+				"allowed-teams": []any{"triage-team", "docs-team"},
+				"max":           3,
+			}},
+			expected: &AssignConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: defaultIntStr(3)},
+				AllowedUsers:         []string{"octocat"},
+				AllowedTeams:         []string{"triage-team", "docs-team"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			config := compiler.parseAssignConfig(tt.outputMap)
+			assert.Equal(t, tt.expected, config, "Assign config should match")
+		})
+	}
+}
+
+func TestValidateTeamSafeOutputs(t *testing.T) {
+	teams := []string{"triage-team"}
+	tests := []struct {
+		name    string
+		config  *SafeOutputsConfig
+		wantErr string
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name:   "users only need no token",
+			config: &SafeOutputsConfig{RequestReview: &RequestReviewConfig{AllowedUsers: []string{"octocat"}}, Assign: &AssignConfig{}},
+		},
+		{
+			name:    "team reviews without token",
+			config:  &SafeOutputsConfig{RequestReview: &RequestReviewConfig{AllowedTeams: teams}},
+			wantErr: "safe-outputs.request-review.allowed-teams requires a github-token",
+		},
+		{
+			name:    "team assignments without token",
+			config:  &SafeOutputsConfig{Assign: &AssignConfig{AllowedTeams: teams}},
+			wantErr: "safe-outputs.assign.allowed-teams requires a github-token",
+		},
+		{
+			name:   "per-type token",
+			config: &SafeOutputsConfig{Assign: &AssignConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{GitHubToken: "${{ secrets.TEAMS_TOKEN }}"}, AllowedTeams: teams}},
+		},
+		{
+			name:   "safe-outputs token",
+			config: &SafeOutputsConfig{GitHubToken: "${{ secrets.TEAMS_TOKEN }}", RequestReview: &RequestReviewConfig{AllowedTeams: teams}, Assign: &AssignConfig{AllowedTeams: teams}},
+		},
+		{
+			name:   "safe-outputs github-app",
+			config: &SafeOutputsConfig{GitHubApp: &GitHubAppConfig{AppID: "${{ vars.APP_ID }}"}, Assign: &AssignConfig{AllowedTeams: teams}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTeamSafeOutputs(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRequestReviewAndAssignCompiled(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "request-review-assign-*")
+	workflowsDir := filepath.Join(tmpDir, constants.GetWorkflowDir())
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows directory")
+
+	compile := func(name, content string) (string, error) {
+		workflowFile := filepath.Join(workflowsDir, name+".md")
+		require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+		if err := NewCompiler().CompileWorkflow(workflowFile); err != nil {
+			return "", err
+		}
+		lockContent, err := os.ReadFile(filepath.Join(workflowsDir, name+".lock.yml"))
+		require.NoError(t, err, "Lock file should be created")
+		return string(lockContent), nil
+	}
+
+	t.Run("handlers and permissions", func(t *testing.T) {
+		lock, err := compile("review-assign", `---
+on:
+  pull_request:
+    types: [opened]
+permissions:
+  contents: read
+engine: claude
+safe-outputs:
+  request-review:
+    allowed-users: [octocat]
+  assign:
+    allowed-users: [mona]
+---
+
+# Route the pull request
+`)
+		require.NoError(t, err, "Workflow should compile")
+
+		assert.Contains(t, lock, `\"request_review\":{`, "Handler config should include request_review")
+		assert.Contains(t, lock, `\"assign\":{`, "Handler config should include assign")
+		assert.Contains(t, lock, `\"allowed_users\":[\"octocat\"]`, "Review allow-list should be passed to the handler")
+		assert.Contains(t, lock, "pull-requests: write", "Safe outputs job should be able to request reviews")
+		assert.Contains(t, lock, "issues: write", "Safe outputs job should be able to assign issues")
+	})
+
+	t.Run("teams without token are rejected", func(t *testing.T) {
+		_, err := compile("teams-no-token", `---
+on: issues
+permissions:
+  contents: read
+engine: claude
+safe-outputs:
+  assign:
+    allowed-teams: [triage-team]
+---
+
+# Triage
+`)
+		require.Error(t, err, "Team assignments need a token that can list team members")
+		assert.Contains(t, err.Error(), "safe-outputs.assign.allowed-teams")
+	})
+
+	t.Run("github-app token can read team members", func(t *testing.T) {
+		lock, err := compile("teams-app", `---
+on: issues
+permissions:
+  contents: read
+engine: claude
+safe-outputs:
+  github-app:
+    app-id: ${{ vars.APP_ID }}
+    private-key: ${{ secrets.APP_PRIVATE_KEY }}
+  assign:
+    allowed-teams: [triage-team]
+---
+
+# Triage
+`)
+		require.NoError(t, err, "Workflow should compile")
+		assert.Contains(t, lock, "permission-members: read", "The minted token should be able to list team members")
+	})
+}
@@ -0,0 +1,155 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var authConfigLog = logger.New("workflow:auth_config")
+
+// authExample is appended to auth: parsing errors
+const authExample = "Example:\nauth:\n  app-id: ${{ vars.APP_ID }}\n  private-key-secret: APP_PRIVATE_KEY"
+
+// secretNamePattern matches a GitHub Actions secret name
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// AuthConfig holds the workflow-wide GitHub App authentication (auth:). The app mints an
+// installation token for every generated GitHub API interaction that does not configure
+// its own github-token or github-app: activation reactions and status comments, the
+// GitHub MCP server and the safe outputs job.
+//
+// Example:
+//
+//	auth:
+//	  app-id: ${{ vars.APP_ID }}
+//	  private-key-secret: APP_PRIVATE_KEY
+type AuthConfig struct {
+	AppID            string   `json:"app-id"`                 // GitHub App ID, literal or expression (e.g., "${{ vars.APP_ID }}")
+	PrivateKeySecret string   `json:"private-key-secret"`     // Name of the secret holding the GitHub App private key
+	Owner            string   `json:"owner,omitempty"`        // Optional: owner of the installation (defaults to the repository owner)
+	Repositories     []string `json:"repositories,omitempty"` // Optional: repositories the token can access (defaults to the current repository)
+}
+
+// GitHubApp returns the token minting configuration of the auth app
+func (a *AuthConfig) GitHubApp() *GitHubAppConfig {
+	return &GitHubAppConfig{
+		AppID:        a.AppID,
+		PrivateKey:   fmt.Sprintf("${{ secrets.%s }}", a.PrivateKeySecret),
+		Owner:        a.Owner,
+		Repositories: a.Repositories,
+	}
+}
+
+// extractAuthConfig extracts and validates the auth: configuration from frontmatter
+func (c *Compiler) extractAuthConfig(frontmatter map[string]any) (*AuthConfig, error) {
+	value, exists := frontmatter["auth"]
+	if !exists || value == nil {
+		return nil, nil
+	}
+
+	authMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("auth must be an object, got %T. %s", value, authExample)
+	}
+
+	config := &AuthConfig{}
+	switch appID := authMap["app-id"].(type) {
+	case string:
+		config.AppID = strings.TrimSpace(appID)
+	default:
+		if id, ok := parseIntValue(appID); ok {
+			config.AppID = strconv.Itoa(id)
+		}
+	}
+	if config.AppID == "" {
+		return nil, fmt.Errorf("auth.app-id is required. %s", authExample)
+	}
+	if _, err := strconv.Atoi(config.AppID); err != nil && !strings.HasPrefix(config.AppID, "${{") {
+		return nil, fmt.Errorf("auth.app-id must be a numeric app ID or an expression such as '${{ vars.APP_ID }}', got '%s'", config.AppID)
+	}
+
+	secret, _ := authMap["private-key-secret"].(string)
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return nil, fmt.Errorf("auth.private-key-secret is required: name the secret holding the GitHub App private key. %s", authExample)
+	}
+	if !secretNamePattern.MatchString(secret) {
+		return nil, fmt.Errorf("auth.private-key-secret must be a secret name such as APP_PRIVATE_KEY, got '%s'", secret)
+	}
+	if strings.HasPrefix(strings.ToUpper(secret), "GITHUB_") {
+		return nil, fmt.Errorf("auth.private-key-secret '%s' cannot start with GITHUB_: GitHub reserves that prefix and such secrets cannot be created", secret)
+	}
+	config.PrivateKeySecret = secret
+
+	if owner, ok := authMap["owner"].(string); ok {
+		config.Owner = owner
+	}
+	if repos, ok := authMap["repositories"].([]any); ok {
+		for _, repo := range repos {
+			if repoStr, ok := repo.(string); ok {
+				config.Repositories = append(config.Repositories, repoStr)
+			}
+		}
+	}
+
+	authConfigLog.Printf("Extracted auth: app-id=%s, private-key-secret=%s, repositories=%d", config.AppID, config.PrivateKeySecret, len(config.Repositories))
+	return config, nil
+}
+
+// applyAuthGitHubApp makes the auth app the token source of every generated GitHub API
+// interaction that has no explicit github-token or github-app of its own
+func applyAuthGitHubApp(data *WorkflowData) {
+	if data.Auth == nil {
+		return
+	}
+	app := data.Auth.GitHubApp()
+
+	if data.ActivationGitHubApp == nil && data.ActivationGitHubToken == "" {
+		authConfigLog.Print("Using auth app for activation reactions and status comments")
+		data.ActivationGitHubApp = app
+	}
+
+	if data.SafeOutputs != nil && data.SafeOutputs.GitHubApp == nil && data.SafeOutputs.GitHubToken == "" {
+		authConfigLog.Print("Using auth app for safe outputs")
+		data.SafeOutputs.GitHubApp = app
+	}
+
+	githubTool, exists := data.Tools["github"]
+	if !exists {
+		return
+	}
+	toolConfig := map[string]any{}
+	switch config := githubTool.(type) {
+	case nil:
+	case map[string]any:
+		if _, hasToken := config["github-token"]; hasToken {
+			return
+		}
+		if _, hasApp := config["github-app"]; hasApp {
+			return
+		}
+		maps.Copy(toolConfig, config)
+	default:
+		// github: false (disabled) or other shorthand values keep their meaning
+		return
+	}
+	authConfigLog.Print("Using auth app for the GitHub MCP server")
+	appMap := map[string]any{"app-id": app.AppID, "private-key": app.PrivateKey}
+	if app.Owner != "" {
+		appMap["owner"] = app.Owner
+	}
+	if len(app.Repositories) > 0 {
+		repos := make([]any, 0, len(app.Repositories))
+		for _, repo := range app.Repositories {
+			repos = append(repos, repo)
+		}
+		appMap["repositories"] = repos
+	}
+	toolConfig["github-app"] = appMap
+	data.Tools["github"] = toolConfig
+}
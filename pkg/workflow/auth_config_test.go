@@ -0,0 +1,153 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractAuthConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *AuthConfig
+		wantErr     string
+	}{
+		{
+			name:        "no auth",
+			frontmatter: map[string]any{},
+		},
+		{
+			name: "expression app id",
+			frontmatter: map[string]any{"auth": map[string]any{
+				"app-id": "${{ vars.APP_ID }}", "private-key-secret": "APP_PRIVATE_KEY", "repositories": []any{"repo1", "repo2"},
+			}},
+			expected: &AuthConfig{AppID: "${{ vars.APP_ID }}", PrivateKeySecret: "APP_PRIVATE_KEY", Repositories: []string{"repo1", "repo2"}},
+		},
+		{
+			name:        "numeric app id",
+			frontmatter: map[string]any{"auth": map[string]any{"app-id": 123456, "private-key-secret": "APP_KEY"}},
+			expected:    &AuthConfig{AppID: "123456", PrivateKeySecret: "APP_KEY"},
+		},
+		{
+			name:        "auth must be an object",
+			frontmatter: map[string]any{"auth": "app"},
+			wantErr:     "auth must be an object",
+		},
+		{
+			name:        "app id is required",
+			frontmatter: map[string]any{"auth": map[string]any{"private-key-secret": "APP_PRIVATE_KEY"}},
+			wantErr:     "auth.app-id is required",
+		},
+		{
+			name:        "app id must be numeric or an expression",
+			frontmatter: map[string]any{"auth": map[string]any{"app-id": "my-app", "private-key-secret": "APP_PRIVATE_KEY"}},
+			wantErr:     "auth.app-id must be a numeric app ID or an expression",
+		},
+		{
+			name:        "private key secret is required",
+			frontmatter: map[string]any{"auth": map[string]any{"app-id": 1}},
+			wantErr:     "auth.private-key-secret is required",
+		},
+		{
+			name:        "private key secret must be a secret name",
+			frontmatter: map[string]any{"auth": map[string]any{"app-id": 1, "private-key-secret": "${{ secrets.APP_PRIVATE_KEY }}"}},
+			wantErr:     "auth.private-key-secret must be a secret name",
+		},
+		{
+			name:        "reserved secret prefix",
+			frontmatter: map[string]any{"auth": map[string]any{"app-id": 1, "private-key-secret": "GITHUB_APP_KEY"}},
+			wantErr:     "cannot start with GITHUB_",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := NewCompiler().extractAuthConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tt.expected, config, "auth config")
+		})
+	}
+}
+
+func TestApplyAuthGitHubApp(t *testing.T) {
+	auth := &AuthConfig{AppID: "1", PrivateKeySecret: "APP_PRIVATE_KEY"}
+	app := &GitHubAppConfig{AppID: "1", PrivateKey: "${{ secrets.APP_PRIVATE_KEY }}"}
+
+	data := &WorkflowData{
+		Auth:        auth,
+		SafeOutputs: &SafeOutputsConfig{},
+		Tools:       map[string]any{"github": map[string]any{"toolsets": []any{"issues"}}},
+	}
+	applyAuthGitHubApp(data)
+	assert.Equal(t, app, data.ActivationGitHubApp, "activation should use the auth app")
+	assert.Equal(t, app, data.SafeOutputs.GitHubApp, "safe outputs should use the auth app")
+	assert.Equal(t, map[string]any{
+		"toolsets":   []any{"issues"},
+		"github-app": map[string]any{"app-id": "1", "private-key": "${{ secrets.APP_PRIVATE_KEY }}"},
+	}, data.Tools["github"], "GitHub MCP server should use the auth app")
+
+	explicit := &WorkflowData{
+		Auth:                  auth,
+		ActivationGitHubToken: "${{ secrets.ACTIVATION_TOKEN }}",
+		SafeOutputs:           &SafeOutputsConfig{GitHubToken: "${{ secrets.SAFE_OUTPUTS_TOKEN }}"},
+		Tools:                 map[string]any{"github": map[string]any{"github-token": "${{ secrets.MCP_TOKEN }}"}},
+	}
+	applyAuthGitHubApp(explicit)
+	assert.Nil(t, explicit.ActivationGitHubApp, "explicit activation token should win")
+	assert.Nil(t, explicit.SafeOutputs.GitHubApp, "explicit safe outputs token should win")
+	assert.NotContains(t, explicit.Tools["github"], "github-app", "explicit GitHub MCP token should win")
+
+	disabled := &WorkflowData{Auth: auth, Tools: map[string]any{"github": false}}
+	applyAuthGitHubApp(disabled)
+	assert.Equal(t, false, disabled.Tools["github"], "disabled GitHub tool should stay disabled")
+}
+
+func TestCompileWorkflowWithAuth(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "auth-config-test")
+	workflowFile := filepath.Join(tmpDir, "auth.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on:
+  issues:
+    types: [opened]
+  reaction: eyes
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+auth:
+  app-id: ${{ vars.APP_ID }}
+  private-key-secret: APP_PRIVATE_KEY
+tools:
+  github:
+    toolsets: [issues]
+safe-outputs:
+  add-comment:
+---
+
+# Auth
+`), 0644), "should write workflow")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "should compile workflow")
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "auth.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	for _, stepID := range []string{"activation-app-token", "github-mcp-app-token", "safe-outputs-app-token"} {
+		assert.Contains(t, lock, "id: "+stepID, "lock file should mint the %s token", stepID)
+	}
+	// activation, agent, safe_outputs and conclusion jobs each mint their own token
+	assert.Equal(t, 4, strings.Count(lock, "private-key: ${{ secrets.APP_PRIVATE_KEY }}"), "every token should be minted with the auth private key")
+}
@@ -154,7 +154,7 @@ func BuildAWFArgs(config AWFCommandConfig) []string {
 	// Use double-quoted form (via shellDoubleQuoteArg) so wildcards like *.domain.com are
 	// treated as plain arguments rather than shell globs, fixing ShellCheck SC1003, while
 	// still escaping $, `, \, and " to prevent unintended shell expansion.
-	awfArgs = append(awfArgs, "--allow-domains", shellDoubleQuoteArg(config.AllowedDomains))
+	awfArgs = append(awfArgs, "--allow-domains", appendAllowedDomainsVars(shellDoubleQuoteArg(config.AllowedDomains), config.WorkflowData.NetworkPermissions))
 
 	// Add blocked domains if specified
 	blockedDomains := formatBlockedDomains(config.WorkflowData.NetworkPermissions)
@@ -182,6 +182,12 @@ func BuildAWFArgs(config AWFCommandConfig) []string {
 	awfArgs = append(awfArgs, "--image-tag", awfImageTag)
 	awfHelpersLog.Printf("Pinned AWF image tag to %s", awfImageTag)
 
+	// Run the agent in the digest-pinned engine container instead of the default AWF agent image
+	if engineConfig := config.WorkflowData.EngineConfig; engineConfig != nil && engineConfig.Container != "" {
+		awfArgs = append(awfArgs, "--agent-image", engineConfig.Container)
+		awfHelpersLog.Printf("Using engine container as agent image: %s", engineConfig.Container)
+	}
+
 	// Skip pulling images since they are pre-downloaded
 	awfArgs = append(awfArgs, "--skip-pull")
 	awfHelpersLog.Print("Using --skip-pull since images are pre-downloaded")
@@ -0,0 +1,109 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+)
+
+// Bash allow-list entries are command based ("git", "git add:*", "git status"), but they
+// can also restrict the arguments of a command with wildcards, for example
+// "git push origin agent/*" or "npm run test:*". Engines whose permission rules only
+// match command prefixes grant the literal prefix of such an argument pattern
+// ("git push origin") and enforce the pattern itself with a validating hook.
+
+// isBashArgumentPattern reports whether a bash allow-list entry restricts the arguments
+// of a command with * or ? wildcards. Entries ending in ":*" without other wildcards are
+// plain prefix rules, and the command name itself may not be a pattern or quoted.
+func isBashArgumentPattern(entry string) bool {
+	words := strings.Fields(entry)
+	if len(words) < 2 || strings.ContainsAny(words[0], "*?\"'") {
+		return false
+	}
+	if rest, isPrefixRule := strings.CutSuffix(entry, ":*"); isPrefixRule && !strings.ContainsAny(rest, "*?") {
+		return false
+	}
+	return strings.ContainsAny(entry, "*?")
+}
+
+// bashArgumentPatternPrefix returns the leading words of an argument pattern that
+// contain no wildcards, e.g. "git push origin" for "git push origin agent/*"
+func bashArgumentPatternPrefix(entry string) string {
+	words := strings.Fields(entry)
+	for i, word := range words {
+		if strings.ContainsAny(word, "*?\"'") {
+			return strings.Join(words[:i], " ")
+		}
+	}
+	return entry
+}
+
+// isBashAnyArgumentsPattern reports whether the only wildcard of an argument pattern is a
+// final "*" argument ("echo *"). Such entries allow any further arguments, which command
+// prefix rules grant on every engine.
+func isBashAnyArgumentsPattern(entry string) bool {
+	words := strings.Fields(entry)
+	return words[len(words)-1] == "*" && !strings.ContainsAny(strings.Join(words[:len(words)-1], " "), "*?")
+}
+
+// bashAllowListWithArgumentPatterns returns the bash allow-list when it contains
+// argument patterns that need to be enforced, or nil when it does not (including
+// when all commands are allowed with "*" or ":*")
+func bashAllowListWithArgumentPatterns(tools map[string]any) []string {
+	bashCommands, ok := tools["bash"].([]any)
+	if !ok {
+		return nil
+	}
+
+	var entries []string
+	hasPattern := false
+	for _, cmd := range bashCommands {
+		cmdStr, ok := cmd.(string)
+		if !ok {
+			continue
+		}
+		if cmdStr == "*" || cmdStr == ":*" {
+			return nil
+		}
+		if isBashArgumentPattern(cmdStr) {
+			hasPattern = true
+		}
+		entries = append(entries, cmdStr)
+	}
+
+	if !hasPattern {
+		return nil
+	}
+	return entries
+}
+
+// validateBashArgumentPatternSupport reports bash argument patterns on engines other than
+// Claude, which receive the entries unchanged and cannot enforce them. The finding is a
+// tools rule (an error in strict mode); outside strict mode it is printed as a warning.
+func (c *Compiler) validateBashArgumentPatternSupport(engineID string, tools map[string]any) error {
+	if engineID == "claude" {
+		return nil
+	}
+	var patterns []string
+	for _, entry := range bashAllowListWithArgumentPatterns(tools) {
+		if isBashArgumentPattern(entry) && !isBashAnyArgumentsPattern(entry) {
+			patterns = append(patterns, entry)
+		}
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("bash argument patterns (%s) are only enforced on the claude engine; the %s engine does not check command arguments against them. Use command prefixes such as \"git push:*\" or switch to the claude engine. See: https://github.github.com/gh-aw/reference/tools/", strings.Join(patterns, ", "), engineID)
+	if c.strictLevel(StrictRuleTools) == StrictLevelOff {
+		if _, disabled := c.strictRules[StrictRuleTools]; !disabled {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(message))
+			c.IncrementWarningCount()
+		}
+		return nil
+	}
+	return c.applyStrictRule(StrictRuleTools, errors.New("strict mode: "+message))
+}
@@ -0,0 +1,142 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBashArgumentPattern(t *testing.T) {
+	tests := []struct {
+		entry    string
+		expected bool
+		prefix   string
+	}{
+		{entry: "git push origin agent/*", expected: true, prefix: "git push origin"},
+		{entry: "find . -name '*.md'", expected: true, prefix: "find . -name"},
+		{entry: "cat pkg/**/*.go", expected: true, prefix: "cat"},
+		{entry: "npm run test:*", expected: false},
+		{entry: "git add:*", expected: false},
+		{entry: "git status", expected: false},
+		{entry: "git", expected: false},
+		{entry: "*", expected: false},
+		{entry: "*.sh --help", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isBashArgumentPattern(tt.entry), "argument pattern detection")
+			if tt.expected {
+				assert.Equal(t, tt.prefix, bashArgumentPatternPrefix(tt.entry), "literal prefix")
+			}
+		})
+	}
+}
+
+func TestBashAllowListWithArgumentPatterns(t *testing.T) {
+	assert.Nil(t, bashAllowListWithArgumentPatterns(map[string]any{"bash": []any{"git status", "npm run test:*"}}), "plain entries need no hook")
+	assert.Nil(t, bashAllowListWithArgumentPatterns(map[string]any{"bash": []any{"git push origin agent/*", "*"}}), "wildcard allows every command")
+	assert.Equal(t,
+		[]string{"git status", "git push origin agent/*"},
+		bashAllowListWithArgumentPatterns(map[string]any{"bash": []any{"git status", "git push origin agent/*"}}),
+		"allow-list with patterns should be returned in full")
+}
+
+func TestClaudeBashArgumentPatterns(t *testing.T) {
+	engine := NewClaudeEngine()
+	allowed := engine.computeAllowedClaudeToolsString(map[string]any{
+		"bash": []any{"git push origin agent/*", "git push origin release/*", "git status"},
+	}, nil, nil)
+	assert.Contains(t, allowed, "Bash(git push origin:*)", "pattern should be granted by its literal prefix")
+	assert.NotContains(t, allowed, "Bash(git push origin agent/*)", "pattern should not be passed to Claude")
+	assert.Contains(t, allowed, "Bash(git status)", "plain entries should be unchanged")
+
+	tmpDir := testutil.TempDir(t, "bash-patterns-test")
+	workflowPath := filepath.Join(tmpDir, "release.md")
+	content := `---
+on: workflow_dispatch
+engine:
+  id: claude
+  max-tool-calls: 50
+permissions:
+  contents: read
+tools:
+  bash:
+    - "git push origin agent/*"
+    - "git status"
+---
+
+Push the branch.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	for _, expected := range []string{
+		"--settings " + claudeHooksSettingsPath,
+		"cp /opt/gh-aw/actions/check_bash_allowlist.cjs",
+		"cp /opt/gh-aw/actions/limit_tool_calls.sh",
+		`"matcher":"Bash"`,
+		`"matcher":"*"`,
+		`"git push origin agent/*","git status"`,
+	} {
+		assert.Contains(t, lock, expected, "lock file should contain %q", expected)
+	}
+}
+
+func TestBashArgumentPatternsRequireClaudeEngine(t *testing.T) {
+	tests := []struct {
+		name        string
+		engine      string
+		strict      bool
+		bash        string
+		expectError bool
+	}{
+		{name: "copilot pattern in strict mode", engine: "copilot", strict: true, bash: `"git push origin agent/*"`, expectError: true},
+		{name: "codex pattern in strict mode", engine: "codex", strict: true, bash: `"cat docs/*.md"`, expectError: true},
+		{name: "copilot pattern outside strict mode", engine: "copilot", strict: false, bash: `"git push origin agent/*"`},
+		{name: "copilot prefix rule in strict mode", engine: "copilot", strict: true, bash: `"npm run test:*"`},
+		{name: "copilot trailing wildcard in strict mode", engine: "copilot", strict: true, bash: `"jq *"`},
+		{name: "claude pattern in strict mode", engine: "claude", strict: true, bash: `"git push origin agent/*"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "bash-patterns-engine-test")
+			workflowPath := filepath.Join(tmpDir, "patterns.md")
+			content := fmt.Sprintf(`---
+on: workflow_dispatch
+engine: %s
+strict: %v
+permissions:
+  contents: read
+tools:
+  bash:
+    - %s
+---
+
+Run the command.
+`, tt.engine, tt.strict, tt.bash)
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+			err := NewCompiler().CompileWorkflow(workflowPath)
+			if tt.expectError {
+				require.Error(t, err, "argument patterns should be rejected for the %s engine", tt.engine)
+				assert.Contains(t, err.Error(), "only enforced on the claude engine", "error should name the supported engine")
+				return
+			}
+			require.NoError(t, err, "workflow should compile")
+		})
+	}
+}
@@ -847,7 +847,7 @@ func (c *Compiler) buildUpdateCacheMemoryJob(data *WorkflowData, threatDetection
 	job := &Job{
 		Name:        "update_cache_memory",
 		DisplayName: "", // No display name - job ID is sufficient
-		RunsOn:      "runs-on: ubuntu-latest",
+		RunsOn:      c.formatSelfHostedRunsOn(data, "ubuntu-latest"),
 		If:          jobCondition,
 		Permissions: permissions,
 		Needs:       []string{string(constants.AgentJobName)},
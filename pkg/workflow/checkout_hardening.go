@@ -0,0 +1,155 @@
+// This file provides the checkout hardening pass for generated workflows.
+//
+// # Checkout Hardening
+//
+// Every actions/checkout step written to the lock file (frontmatter steps, imported steps,
+// post-steps, custom jobs, safe-outputs steps and safe jobs) gets 'persist-credentials: false'
+// unless the step sets persist-credentials itself. Without it, actions/checkout stores the
+// token in .git/config where any later step, including the agent, can read it.
+//
+// The agent job checkout itself is minimal: it is shallow by default, can be narrowed with
+// the 'checkout:' frontmatter (fetch-depth, sparse-checkout), and is dropped when nothing in
+// the agent job needs the working tree (see agentNeedsWorkingTree). Prompt files and runtime
+// imports do not need it: they are read from the .github checkout of the activation job.
+
+package workflow
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var checkoutHardeningLog = logger.New("workflow:checkout_hardening")
+
+// isCheckoutAction reports whether a uses value references actions/checkout,
+// ignoring the ref and any pin comment (e.g. "actions/checkout@abc123 # v4")
+func isCheckoutAction(uses string) bool {
+	uses = strings.TrimSpace(strings.SplitN(uses, " #", 2)[0])
+	return uses == "actions/checkout" || strings.HasPrefix(uses, "actions/checkout@")
+}
+
+// HardenCheckoutStep returns a copy of an actions/checkout step with 'persist-credentials: false'
+// added when the step does not set persist-credentials. Other steps, and checkouts that set
+// persist-credentials explicitly, are returned unchanged.
+func HardenCheckoutStep(step *WorkflowStep) *WorkflowStep {
+	if step == nil || !isCheckoutAction(step.Uses) {
+		return step
+	}
+	if _, hasPersistCredentials := step.With["persist-credentials"]; hasPersistCredentials {
+		return step
+	}
+
+	checkoutHardeningLog.Printf("Adding persist-credentials: false to checkout step: %s", step.Name)
+	result := step.Clone()
+	if result.With == nil {
+		result.With = make(map[string]any)
+	}
+	result.With["persist-credentials"] = false
+	return result
+}
+
+// HardenCheckoutSteps applies HardenCheckoutStep to every step of the slice
+func HardenCheckoutSteps(steps []*WorkflowStep) []*WorkflowStep {
+	if steps == nil {
+		return nil
+	}
+	result := make([]*WorkflowStep, 0, len(steps))
+	for _, step := range steps {
+		result = append(result, HardenCheckoutStep(step))
+	}
+	return result
+}
+
+// hardenCheckoutStepValues applies the checkout hardening to untyped steps. It is used when
+// steps cannot be converted to WorkflowStep, so that a malformed step elsewhere in the list
+// does not leave checkouts with persisted credentials.
+func hardenCheckoutStepValues(steps []any) []any {
+	if steps == nil {
+		return nil
+	}
+	result := make([]any, 0, len(steps))
+	for _, step := range steps {
+		stepMap, ok := step.(map[string]any)
+		if !ok {
+			result = append(result, step)
+			continue
+		}
+		uses, _ := stepMap["uses"].(string)
+		if !isCheckoutAction(uses) {
+			result = append(result, step)
+			continue
+		}
+
+		withMap, hasWith := stepMap["with"].(map[string]any)
+		if _, isSet := withMap["persist-credentials"]; isSet || (stepMap["with"] != nil && !hasWith) {
+			// Explicit settings and unexpected with formats are reported by validateCheckoutPersistCredentials
+			result = append(result, step)
+			continue
+		}
+
+		checkoutHardeningLog.Printf("Adding persist-credentials: false to untyped checkout step: %v", stepMap["name"])
+		hardened := make(map[string]any, len(stepMap))
+		maps.Copy(hardened, stepMap)
+		hardenedWith := make(map[string]any, len(withMap)+1)
+		maps.Copy(hardenedWith, withMap)
+		hardenedWith["persist-credentials"] = false
+		hardened["with"] = hardenedWith
+		result = append(result, hardened)
+	}
+	return result
+}
+
+// workingTreeFreeTools are the tools that never read the repository working tree. edit and
+// bash are listed because engines limited to MCP tools cannot run them; the compiler adds
+// them by default whenever the sandbox is enabled.
+var workingTreeFreeTools = map[string]bool{
+	"github":       true,
+	"playwright":   true,
+	"web-fetch":    true,
+	"web-search":   true,
+	"cache-memory": true,
+	"repo-memory":  true,
+	"edit":         true,
+	"bash":         true,
+}
+
+// agentNeedsWorkingTree reports whether anything in the agent job reads the repository
+// working tree. When nothing does, the repository checkout and the git configuration
+// steps are dropped from the agent job.
+func (c *Compiler) agentNeedsWorkingTree(data *WorkflowData) bool {
+	// The coding agent CLIs can always read files with their own tools
+	engineID := ""
+	if data.EngineConfig != nil {
+		engineID = data.EngineConfig.ID
+	}
+	if engineID == "" {
+		engineID = data.AI
+	}
+	if engine, err := c.engineRegistry.GetEngine(engineID); err != nil || engine.SupportsWorkspaceAccess() {
+		return true
+	}
+
+	// MCP servers such as serena, agentic-workflows or custom servers may work on repository files
+	for tool := range data.Tools {
+		if !workingTreeFreeTools[tool] {
+			checkoutHardeningLog.Printf("Working tree needed by tool: %s", tool)
+			return true
+		}
+	}
+
+	// Pull request safe outputs build their patch from the working tree
+	if usesPatchesAndCheckouts(data.SafeOutputs) {
+		checkoutHardeningLog.Print("Working tree needed by pull request safe outputs")
+		return true
+	}
+
+	// Custom steps, safe-inputs scripts and an explicit default checkout configuration may rely on the repository
+	if data.CustomSteps != "" || data.SafeInputs != nil || NewCheckoutManager(data.CheckoutConfigs).GetDefaultCheckoutOverride() != nil {
+		return true
+	}
+
+	checkoutHardeningLog.Printf("No tool needs the working tree for engine %s", engineID)
+	return false
+}
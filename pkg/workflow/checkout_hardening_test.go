@@ -0,0 +1,216 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHardenCheckoutStep(t *testing.T) {
+	tests := []struct {
+		name            string
+		step            *WorkflowStep
+		wantPersist     any
+		wantPersistSet  bool
+		wantSamePointer bool
+	}{
+		{
+			name:           "checkout without with block",
+			step:           &WorkflowStep{Name: "Checkout", Uses: "actions/checkout@v4"},
+			wantPersist:    false,
+			wantPersistSet: true,
+		},
+		{
+			name:           "pinned checkout keeps other inputs",
+			step:           &WorkflowStep{Uses: "actions/checkout@11bd71901bbe5b1630ceea73d27597364c9af683 # v4.2.2", With: map[string]any{"fetch-depth": 1}},
+			wantPersist:    false,
+			wantPersistSet: true,
+		},
+		{
+			name:            "explicit persist-credentials is kept",
+			step:            &WorkflowStep{Uses: "actions/checkout@v4", With: map[string]any{"persist-credentials": true}},
+			wantPersist:     true,
+			wantPersistSet:  true,
+			wantSamePointer: true,
+		},
+		{
+			name:            "non-checkout step is unchanged",
+			step:            &WorkflowStep{Uses: "actions/checkout-extra@v1"},
+			wantSamePointer: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.step.Clone()
+			result := HardenCheckoutStep(tt.step)
+
+			persist, ok := result.With["persist-credentials"]
+			assert.Equal(t, tt.wantPersistSet, ok, "persist-credentials presence mismatch")
+			assert.Equal(t, tt.wantPersist, persist, "persist-credentials value mismatch")
+			if tt.wantSamePointer {
+				assert.Same(t, tt.step, result, "Step should be returned unchanged")
+			}
+			assert.Equal(t, original, tt.step, "The input step should not be modified")
+			if _, hadFetchDepth := tt.step.With["fetch-depth"]; hadFetchDepth {
+				assert.Equal(t, 1, result.With["fetch-depth"], "Other inputs should be kept")
+			}
+		})
+	}
+
+	assert.Nil(t, HardenCheckoutStep(nil))
+	assert.Nil(t, HardenCheckoutSteps(nil))
+}
+
+func TestHardenCheckoutStepValues(t *testing.T) {
+	steps := []any{
+		"not a step",
+		map[string]any{"name": "Checkout", "uses": "actions/checkout@v4"},
+		map[string]any{"uses": "actions/checkout@v4", "with": map[string]any{"path": "docs"}},
+		map[string]any{"uses": "actions/checkout@v4", "with": map[string]any{"persist-credentials": true}},
+		map[string]any{"run": "echo hi"},
+	}
+
+	hardened := hardenCheckoutStepValues(steps)
+	require.Len(t, hardened, len(steps), "every step should be kept")
+	assert.Equal(t, "not a step", hardened[0], "non-map steps are kept as is")
+	assert.Equal(t, map[string]any{"persist-credentials": false}, hardened[1].(map[string]any)["with"], "checkout without with should be hardened")
+	assert.Equal(t, map[string]any{"path": "docs", "persist-credentials": false}, hardened[2].(map[string]any)["with"], "checkout with other inputs should be hardened")
+	assert.Equal(t, map[string]any{"persist-credentials": true}, hardened[3].(map[string]any)["with"], "explicit settings are left to the validation")
+	assert.Equal(t, steps[4], hardened[4], "other steps are unchanged")
+	assert.Nil(t, steps[1].(map[string]any)["with"], "input steps should not be modified")
+}
+
+func TestProcessAndMergePostStepsHardensUntypedSteps(t *testing.T) {
+	frontmatter := map[string]any{
+		"post-steps": []any{
+			"not a step",
+			map[string]any{"name": "Checkout docs", "uses": "actions/checkout@v4"},
+		},
+	}
+	workflowData := &WorkflowData{}
+	NewCompiler().processAndMergePostSteps(frontmatter, workflowData)
+	assert.Contains(t, workflowData.PostSteps, "persist-credentials: false", "checkouts should be hardened even when steps cannot be typed")
+}
+
+func TestAgentNeedsWorkingTree(t *testing.T) {
+	compiler := NewCompiler()
+
+	tests := []struct {
+		name     string
+		data     *WorkflowData
+		expected bool
+	}{
+		{
+			name:     "coding agent engine",
+			data:     &WorkflowData{AI: "copilot"},
+			expected: true,
+		},
+		{
+			name:     "mcp-only engine with github tool",
+			data:     &WorkflowData{EngineConfig: &EngineConfig{ID: "github-models"}, Tools: map[string]any{"github": nil, "edit": nil, "bash": nil}},
+			expected: false,
+		},
+		{
+			name:     "mcp-only engine with a repository MCP server",
+			data:     &WorkflowData{EngineConfig: &EngineConfig{ID: "ollama"}, Tools: map[string]any{"serena": nil}},
+			expected: true,
+		},
+		{
+			name:     "mcp-only engine with create-pull-request",
+			data:     &WorkflowData{EngineConfig: &EngineConfig{ID: "ollama"}, SafeOutputs: &SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{}}},
+			expected: true,
+		},
+		{
+			name:     "mcp-only engine with custom steps",
+			data:     &WorkflowData{EngineConfig: &EngineConfig{ID: "ollama"}, CustomSteps: "steps:\n  - run: make\n"},
+			expected: true,
+		},
+		{
+			name:     "unknown engine",
+			data:     &WorkflowData{AI: "unknown"},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, compiler.agentNeedsWorkingTree(tt.data))
+		})
+	}
+}
+
+func TestCheckoutHardeningCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "checkout-hardening-test")
+
+	t.Run("custom step checkouts are hardened", func(t *testing.T) {
+		content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+steps:
+  - name: Checkout docs
+    uses: actions/checkout@v4
+    with:
+      repository: octo-org/docs
+      path: docs
+---
+
+# Review
+
+Review the docs.
+`
+		lock := compileCheckoutHardeningWorkflow(t, tmpDir, "custom-steps.md", content)
+
+		stepIndex := strings.Index(lock, "name: Checkout docs")
+		require.GreaterOrEqual(t, stepIndex, 0, "Custom checkout step should be in the lock file")
+		nextStep := strings.Index(lock[stepIndex:], "- name:")
+		require.Positive(t, nextStep)
+		assert.Contains(t, lock[stepIndex:stepIndex+nextStep], "persist-credentials: false", "Custom checkout should be hardened")
+	})
+
+	t.Run("checkout is dropped when no tool needs the working tree", func(t *testing.T) {
+		content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+  issues: read
+engine: github-models
+tools:
+  github:
+    toolsets: [issues]
+---
+
+# Triage
+
+Summarize open issues.
+`
+		lock := compileCheckoutHardeningWorkflow(t, tmpDir, "no-working-tree.md", content)
+
+		agentJob := lock[strings.Index(lock, "\n  agent:"):]
+		if end := strings.Index(agentJob[1:], "\n  conclusion:"); end > 0 {
+			agentJob = agentJob[:end]
+		}
+		assert.NotContains(t, agentJob, "name: Checkout repository", "Agent job should not check out the repository")
+		assert.NotContains(t, agentJob, "name: Configure Git credentials", "Git configuration needs the working tree")
+	})
+}
+
+func compileCheckoutHardeningWorkflow(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	workflowPath := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644))
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(strings.TrimSuffix(workflowPath, ".md") + ".lock.yml")
+	require.NoError(t, err)
+	return string(lockContent)
+}
@@ -268,14 +268,8 @@ func (cm *CheckoutManager) GenerateCheckoutAppTokenInvalidationSteps(c *Compiler
 			continue
 		}
 		checkoutManagerLog.Printf("Generating app token invalidation step for checkout index=%d", i)
-		rawSteps := c.buildGitHubAppTokenInvalidationStep()
 		stepID := fmt.Sprintf("checkout-app-token-%d", i)
-		for _, step := range rawSteps {
-			modified := strings.ReplaceAll(step, "steps.safe-outputs-app-token.outputs.token", "steps."+stepID+".outputs.token")
-			// Update step name to indicate it's for checkout
-			modified = strings.ReplaceAll(modified, "Invalidate GitHub App token", fmt.Sprintf("Invalidate checkout app token (%d)", i))
-			steps = append(steps, modified)
-		}
+		steps = append(steps, c.buildGitHubAppTokenInvalidationStep(stepID, fmt.Sprintf("Invalidate checkout app token (%d)", i))...)
 	}
 	return steps
 }
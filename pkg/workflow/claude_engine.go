@@ -21,15 +21,18 @@ type ClaudeEngine struct {
 func NewClaudeEngine() *ClaudeEngine {
 	return &ClaudeEngine{
 		BaseEngine: BaseEngine{
-			id:                     "claude",
-			displayName:            "Claude Code",
-			description:            "Uses Claude Code with full MCP tool support and allow-listing",
-			experimental:           false,
-			supportsToolsAllowlist: true,
-			supportsMaxTurns:       true, // Claude supports max-turns feature
-			supportsWebFetch:       true, // Claude has built-in WebFetch support
-			supportsWebSearch:      true, // Claude has built-in WebSearch support
-			llmGatewayPort:         constants.ClaudeLLMGatewayPort,
+			id:                      "claude",
+			displayName:             "Claude Code",
+			description:             "Uses Claude Code with full MCP tool support and allow-listing",
+			experimental:            false,
+			supportsToolsAllowlist:  true,
+			supportsMaxTurns:        true, // Claude supports max-turns feature
+			supportsMaxToolCalls:    true, // Enforced with a PreToolUse hook
+			supportsWebFetch:        true, // Claude has built-in WebFetch support
+			supportsWebSearch:       true, // Claude has built-in WebSearch support
+			supportsBashAllowlist:   true,
+			supportsWorkspaceAccess: true, // Read, Glob and Grep are always available
+			llmGatewayPort:          constants.ClaudeLLMGatewayPort,
 		},
 	}
 }
@@ -114,6 +117,7 @@ func (e *ClaudeEngine) GetInstallationSteps(workflowData *WorkflowData) []GitHub
 		config.CliName,
 		true, // Include Node.js setup
 	)
+	npmSteps = applyNpmEngineInstallOptions(npmSteps, config.NpmPackage, claudeVersion, config.CliName, workflowData)
 
 	if len(npmSteps) > 0 {
 		steps = append(steps, npmSteps[0]) // Setup Node.js step
@@ -179,6 +183,20 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 		claudeArgs = append(claudeArgs, "--max-turns", workflowData.EngineConfig.MaxTurns)
 	}
 
+	// Enforce max-tool-calls and bash argument patterns with PreToolUse hooks configured
+	// through a settings file (the Claude CLI has no flags for them); the file is written
+	// by the step added below
+	maxToolCalls := 0
+	if workflowData.EngineConfig != nil {
+		maxToolCalls = workflowData.EngineConfig.MaxToolCalls
+	}
+	bashAllowList := bashAllowListWithArgumentPatterns(workflowData.Tools)
+	if maxToolCalls > 0 || len(bashAllowList) > 0 {
+		claudeLog.Printf("Configuring hooks: max_tool_calls=%d, bash_allow_list=%d", maxToolCalls, len(bashAllowList))
+		claudeArgs = append(claudeArgs, "--settings", claudeHooksSettingsPath)
+		steps = append(steps, generateClaudeHooksStep(maxToolCalls > 0, bashAllowList))
+	}
+
 	// Add MCP configuration only if there are MCP servers
 	if HasMCPServers(workflowData) {
 		claudeLog.Print("Adding MCP configuration")
@@ -193,6 +211,9 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 	// - Path-specific tools: Read(/tmp/gh-aw/cache-memory/*)
 	// The --tools flag only supports basic tool names (e.g., "Bash,Edit,Read") without patterns.
 	allowedTools := e.computeAllowedClaudeToolsString(workflowData.Tools, workflowData.SafeOutputs, workflowData.CacheMemoryConfig)
+	if workflowData.Continuation != nil {
+		allowedTools = addContinuationClaudeTools(allowedTools)
+	}
 	if allowedTools != "" {
 		claudeArgs = append(claudeArgs, "--allowed-tools", allowedTools)
 	}
@@ -365,6 +386,9 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 	// Add GH_AW_SAFE_OUTPUTS if output is needed
 	applySafeOutputEnvToMap(env, workflowData)
 
+	// Add network.allowed entries resolved from repository or organization variables
+	applyAllowedDomainsVarsEnvToMap(env, workflowData)
+
 	// Add GH_AW_STARTUP_TIMEOUT environment variable (in seconds) if startup-timeout is specified
 	if workflowData.ToolsStartupTimeout > 0 {
 		env["GH_AW_STARTUP_TIMEOUT"] = strconv.Itoa(workflowData.ToolsStartupTimeout)
@@ -379,6 +403,10 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 		env["GH_AW_MAX_TURNS"] = workflowData.EngineConfig.MaxTurns
 	}
 
+	if maxToolCalls > 0 {
+		env[constants.EnvVarMaxToolCalls] = strconv.Itoa(maxToolCalls)
+	}
+
 	// Set the model environment variable.
 	// When model is configured, use the native ANTHROPIC_MODEL env var - the Claude CLI reads it
 	// directly, avoiding the need to embed the value in the shell command (which would fail
@@ -437,13 +465,7 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 	}
 
 	// Add timeout at step level (GitHub Actions standard)
-	if workflowData.TimeoutMinutes != "" {
-		// Strip timeout-minutes prefix
-		timeoutValue := strings.TrimPrefix(workflowData.TimeoutMinutes, "timeout-minutes: ")
-		stepLines = append(stepLines, "        timeout-minutes: "+timeoutValue)
-	} else {
-		stepLines = append(stepLines, fmt.Sprintf("        timeout-minutes: %d", int(constants.DefaultAgenticWorkflowTimeout/time.Minute))) // Default timeout for agentic workflows
-	}
+	stepLines = append(stepLines, agentStepTimeoutLine(workflowData))
 
 	// Filter environment variables to only include allowed secrets
 	// This is a security measure to prevent exposing unnecessary secrets to the AWF container
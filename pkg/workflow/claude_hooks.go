@@ -0,0 +1,76 @@
+package workflow
+
+import "encoding/json"
+
+const (
+	// claudeHooksDir holds the hook scripts and settings passed to Claude with --settings
+	claudeHooksDir = "/tmp/gh-aw/claude-hooks"
+	// claudeHooksSettingsPath is the Claude settings file registering the hooks
+	claudeHooksSettingsPath = claudeHooksDir + "/settings.json"
+	// claudeBashAllowListPath is the bash allow-list checked by check_bash_allowlist.cjs
+	claudeBashAllowListPath = claudeHooksDir + "/bash_allowlist.json"
+)
+
+// claudeHooksSettings returns the Claude settings JSON registering the PreToolUse hooks:
+// limit_tool_calls.sh for every tool (engine.max-tool-calls) and check_bash_allowlist.cjs
+// for Bash (bash allow-list entries with argument patterns)
+func claudeHooksSettings(limitToolCalls bool, checkBashAllowList bool) string {
+	var preToolUse []any
+	if limitToolCalls {
+		preToolUse = append(preToolUse, map[string]any{
+			"matcher": "*",
+			"hooks": []any{
+				map[string]any{
+					"type":    "command",
+					"command": "bash " + claudeHooksDir + "/limit_tool_calls.sh",
+				},
+			},
+		})
+	}
+	if checkBashAllowList {
+		preToolUse = append(preToolUse, map[string]any{
+			"matcher": "Bash",
+			"hooks": []any{
+				map[string]any{
+					"type":    "command",
+					"command": "node " + claudeHooksDir + "/check_bash_allowlist.cjs " + claudeBashAllowListPath,
+				},
+			},
+		})
+	}
+	settings := map[string]any{
+		"hooks": map[string]any{
+			"PreToolUse": preToolUse,
+		},
+	}
+	data, _ := json.Marshal(settings)
+	return string(data)
+}
+
+// generateClaudeHooksStep generates the step that installs the Claude hooks.
+// The hook scripts are copied next to the settings file so they are reachable inside the sandbox.
+func generateClaudeHooksStep(limitToolCalls bool, bashAllowList []string) GitHubActionStep {
+	step := GitHubActionStep{
+		"      - name: Configure Claude hooks",
+		"        run: |",
+		"          mkdir -p " + claudeHooksDir,
+	}
+	if limitToolCalls {
+		step = append(step, "          cp /opt/gh-aw/actions/limit_tool_calls.sh "+claudeHooksDir+"/limit_tool_calls.sh")
+	}
+	if len(bashAllowList) > 0 {
+		allowList, _ := json.Marshal(bashAllowList)
+		step = append(step,
+			"          cp /opt/gh-aw/actions/check_bash_allowlist.cjs "+claudeHooksDir+"/check_bash_allowlist.cjs",
+			"          cat > "+claudeBashAllowListPath+" << 'GH_AW_BASH_ALLOWLIST_EOF'",
+			"          "+string(allowList),
+			"          GH_AW_BASH_ALLOWLIST_EOF",
+		)
+	}
+	step = append(step,
+		"          cat > "+claudeHooksSettingsPath+" << 'GH_AW_CLAUDE_HOOKS_EOF'",
+		"          "+claudeHooksSettings(limitToolCalls, len(bashAllowList) > 0),
+		"          GH_AW_CLAUDE_HOOKS_EOF",
+	)
+	return step
+}
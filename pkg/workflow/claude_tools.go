@@ -77,8 +77,15 @@ func (e *ClaudeEngine) expandNeutralToolsToClaudeTools(tools map[string]any) map
 	}
 
 	if _, hasWebFetch := tools["web-fetch"]; hasWebFetch {
-		// web-fetch -> WebFetch
-		claudeAllowed["WebFetch"] = nil
+		if webSearchDomains := webSearchAllowedDomains(tools); len(webSearchDomains) > 0 {
+			// web-fetch limited to the web-search domains -> WebFetch(domain:...)
+			for _, domain := range webSearchDomains {
+				claudeAllowed[fmt.Sprintf("WebFetch(domain:%s)", domain)] = nil
+			}
+		} else {
+			// web-fetch -> WebFetch
+			claudeAllowed["WebFetch"] = nil
+		}
 	}
 
 	if _, hasWebSearch := tools["web-search"]; hasWebSearch {
@@ -240,6 +247,15 @@ func (e *ClaudeEngine) computeAllowedClaudeToolsString(tools map[string]any, saf
 								// Add individual bash commands with Bash() prefix
 								for _, cmd := range bashCommands {
 									if cmdStr, ok := cmd.(string); ok {
+										if isBashArgumentPattern(cmdStr) {
+											// Claude rules only match prefixes: grant the literal prefix of the
+											// pattern and let the bash allow-list hook enforce the arguments
+											prefixRule := fmt.Sprintf("Bash(%s:*)", bashArgumentPatternPrefix(cmdStr))
+											if !slices.Contains(allowedTools, prefixRule) {
+												allowedTools = append(allowedTools, prefixRule)
+											}
+											continue
+										}
 										allowedTools = append(allowedTools, fmt.Sprintf("Bash(%s)", cmdStr))
 									}
 								}
@@ -33,15 +33,16 @@ type CodexEngine struct {
 func NewCodexEngine() *CodexEngine {
 	return &CodexEngine{
 		BaseEngine: BaseEngine{
-			id:                     "codex",
-			displayName:            "Codex",
-			description:            "Uses OpenAI Codex CLI with MCP server support",
-			experimental:           false,
-			supportsToolsAllowlist: true,
-			supportsMaxTurns:       false, // Codex does not support max-turns feature
-			supportsWebFetch:       false, // Codex does not have built-in web-fetch support
-			supportsWebSearch:      true,  // Codex has built-in web-search support
-			llmGatewayPort:         constants.CodexLLMGatewayPort,
+			id:                      "codex",
+			displayName:             "Codex",
+			description:             "Uses OpenAI Codex CLI with MCP server support",
+			experimental:            false,
+			supportsToolsAllowlist:  true,
+			supportsMaxTurns:        false, // Codex does not support max-turns feature
+			supportsWebFetch:        false, // Codex does not have built-in web-fetch support
+			supportsWebSearch:       true,  // Codex has built-in web-search support
+			supportsWorkspaceAccess: true,
+			llmGatewayPort:          constants.CodexLLMGatewayPort,
 		},
 	}
 }
@@ -275,6 +276,9 @@ mkdir -p "$CODEX_HOME/logs"
 	// Add GH_AW_SAFE_OUTPUTS if output is needed
 	applySafeOutputEnvToMap(env, workflowData)
 
+	// Add network.allowed entries resolved from repository or organization variables
+	applyAllowedDomainsVarsEnvToMap(env, workflowData)
+
 	// Add GH_AW_STARTUP_TIMEOUT environment variable (in seconds) if startup-timeout is specified
 	if workflowData.ToolsStartupTimeout > 0 {
 		env["GH_AW_STARTUP_TIMEOUT"] = strconv.Itoa(workflowData.ToolsStartupTimeout)
@@ -12,7 +12,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
-	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/messages"
 )
 
 var log = logger.New("workflow:compiler")
@@ -96,6 +96,22 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate github.event expressions against the payloads of the workflow triggers
+	log.Printf("Validating event payload expressions")
+	if err := c.validateEventPayloadExpressions(workflowData, markdownPath); err != nil {
+		return err
+	}
+
+	// Validate that trigger sections (## on: <event>) name triggers of the workflow
+	log.Printf("Validating trigger sections")
+	if err := c.validateTriggerSections(workflowData, markdownPath); err != nil {
+		return err
+	}
+
+	// Warn about prompt instructions that no configured tool allows
+	log.Printf("Validating prompt tool reachability")
+	c.validatePromptToolReachability(workflowData, markdownPath)
+
 	// Validate expressions in runtime-import files at compile time
 	log.Printf("Validating runtime-import files")
 	// Go up from .github/workflows/file.md to repo root
@@ -118,7 +134,7 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 			if actionModeStr, ok := actionModeVal.(string); ok && actionModeStr != "" {
 				mode := ActionMode(actionModeStr)
 				if !mode.IsValid() {
-					return formatCompilerError(markdownPath, "error", fmt.Sprintf("invalid action-mode feature flag '%s'. Must be 'dev', 'release', or 'script'", actionModeStr), nil)
+					return formatCompilerError(markdownPath, "error", messages.Format(messages.InvalidActionMode, messages.Args{"mode": actionModeStr}), nil)
 				}
 				log.Printf("Overriding action mode from feature flag: %s", mode)
 				c.SetActionMode(mode)
@@ -126,12 +142,37 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		}
 	}
 
+	// Validate pull_request_target hardening before the generic permission check so
+	// head checkout combined with write permissions gets the more specific error
+	log.Printf("Validating pull_request_target hardening")
+	if err := validatePullRequestTarget(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate dangerous permissions
 	log.Printf("Validating dangerous permissions")
 	if err := validateDangerousPermissions(workflowData); err != nil {
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate step timeouts against their job timeouts
+	log.Printf("Validating step timeouts")
+	if err := validateTimeouts(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate organization policy (allowed engines, permissions, network, banned tools)
+	log.Printf("Validating organization policy")
+	if err := c.validateOrgPolicy(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate engine container image pinning and sandbox requirements
+	log.Printf("Validating engine container")
+	if err := validateEngineContainer(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate agent file exists if specified in engine config
 	log.Printf("Validating agent file if specified")
 	if err := c.validateAgentFile(workflowData, markdownPath); err != nil {
@@ -150,12 +191,45 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate cross-repository create-issue token exchange configuration
+	log.Printf("Validating safe-outputs create-issue github-app")
+	if err := validateCreateIssueGitHubApp(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate create-issue deduplication configuration
+	log.Printf("Validating safe-outputs create-issue dedupe")
+	if err := validateCreateIssueDedupe(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate that team reviews and team assignments have a token able to use teams
+	log.Printf("Validating safe-outputs team allow-lists")
+	if err := validateTeamSafeOutputs(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate safe-outputs allowed-domains configuration
 	log.Printf("Validating safe-outputs allowed-domains")
 	if err := c.validateSafeOutputsAllowedDomains(workflowData.SafeOutputs); err != nil {
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate safe-outputs upload-asset configuration
+	log.Printf("Validating safe-outputs upload-asset configuration")
+	if err := validateUploadAssetConfig(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate safe-outputs sanitize configuration
+	log.Printf("Validating safe-outputs sanitize configuration")
+	if err := validateSanitizeConfig(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	if err := c.loadSanitizeScript(workflowData, markdownPath); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate network allowed domains configuration
 	log.Printf("Validating network allowed domains")
 	if err := c.validateNetworkAllowedDomains(workflowData.NetworkPermissions); err != nil {
@@ -168,6 +242,18 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate GitHub Enterprise host compatibility
+	log.Printf("Validating github-host")
+	if err := validateGitHubHost(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate secret-masking patterns
+	log.Printf("Validating secret-masking patterns")
+	if err := validateSecretMaskingPatterns(workflowData.SecretMasking); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate labels configuration
 	log.Printf("Validating labels")
 	if err := validateLabels(workflowData); err != nil {
@@ -207,15 +293,33 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		}
 	}
 
+	// Emit warnings for engine features that may not exist on self-hosted runners
+	for _, warning := range c.selfHostedRunnerWarnings(workflowData) {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warning))
+		c.IncrementWarningCount()
+	}
+
+	// Emit warnings when the rendered prompt likely exceeds the engine's context window
+	for _, warning := range c.promptBudgetWarnings(workflowData) {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warning))
+		c.IncrementWarningCount()
+	}
+
+	// Emit warnings for Linux-only features used on Windows or macOS runners
+	for _, warning := range c.nonLinuxRunnerWarnings(workflowData) {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warning))
+		c.IncrementWarningCount()
+	}
+
 	// Emit warning for sandbox.agent: false (disables agent sandbox firewall)
 	if isAgentSandboxDisabled(workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("⚠️  WARNING: Agent sandbox disabled (sandbox.agent: false). This removes firewall protection. The AI agent will have direct network access without firewall filtering. The MCP gateway remains enabled. Only use this for testing or in controlled environments where you trust the AI agent completely."))
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(messages.Format(messages.SandboxAgentDisabled, nil)))
 		c.IncrementWarningCount()
 	}
 
 	// Validate: threat detection requires sandbox.agent to be enabled (detection runs inside AWF)
 	if workflowData.SafeOutputs != nil && workflowData.SafeOutputs.ThreatDetection != nil && isAgentSandboxDisabled(workflowData) {
-		return formatCompilerError(markdownPath, "error", "threat detection requires sandbox.agent to be enabled. Threat detection runs inside the agent sandbox (AWF) with fully blocked network. Either enable sandbox.agent or use 'threat-detection: false' to disable the threat-detection configuration in safe-outputs.", errors.New("threat detection requires sandbox.agent"))
+		return formatCompilerError(markdownPath, "error", messages.Format(messages.ThreatDetectionSandbox, nil), errors.New("threat detection requires sandbox.agent"))
 	}
 
 	// Emit warning when assign-to-agent is used with github-app: but no explicit github-token:.
@@ -235,34 +339,39 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 
 	// Emit experimental warning for safe-inputs feature
 	if IsSafeInputsEnabled(workflowData.SafeInputs, workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: safe-inputs"))
-		c.IncrementWarningCount()
+		if err := c.reportExperimentalFeature("Using experimental feature: safe-inputs"); err != nil {
+			return formatCompilerError(markdownPath, "error", err.Error(), err)
+		}
 	}
 
 	// Emit experimental warning for plugins feature
 	if workflowData.PluginInfo != nil && len(workflowData.PluginInfo.Plugins) > 0 {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: plugins"))
-		c.IncrementWarningCount()
+		if err := c.reportExperimentalFeature("Using experimental feature: plugins"); err != nil {
+			return formatCompilerError(markdownPath, "error", err.Error(), err)
+		}
 	}
 
 	// Emit experimental warning for dependencies (APM) feature
 	if workflowData.APMDependencies != nil && len(workflowData.APMDependencies.Packages) > 0 {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: dependencies (APM)"))
-		c.IncrementWarningCount()
+		if err := c.reportExperimentalFeature("Using experimental feature: dependencies (APM)"); err != nil {
+			return formatCompilerError(markdownPath, "error", err.Error(), err)
+		}
 	}
 
 	// Emit experimental warning for rate-limit feature
 	if workflowData.RateLimit != nil {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: rate-limit"))
-		c.IncrementWarningCount()
+		if err := c.reportExperimentalFeature("Using experimental feature: rate-limit"); err != nil {
+			return formatCompilerError(markdownPath, "error", err.Error(), err)
+		}
 	}
 
 	// Emit experimental warning for tools.github guard policy (repos/min-integrity)
 	if workflowData.ParsedTools != nil && workflowData.ParsedTools.GitHub != nil {
 		github := workflowData.ParsedTools.GitHub
 		if github.Repos != nil || github.MinIntegrity != "" {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: tools.github guard policy (repos/min-integrity)"))
-			c.IncrementWarningCount()
+			if err := c.reportExperimentalFeature("Using experimental feature: tools.github guard policy (repos/min-integrity)"); err != nil {
+				return formatCompilerError(markdownPath, "error", err.Error(), err)
+			}
 		}
 	}
 
@@ -319,10 +428,7 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		if permissions != nil {
 			level, exists := permissions.Get(PermissionIdToken)
 			if exists && level == PermissionWrite {
-				warningMsg := `This workflow grants id-token: write permission
-OIDC tokens can authenticate to cloud providers (AWS, Azure, GCP).
-Ensure proper audience validation and trust policies are configured.`
-				fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", warningMsg))
+				fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", messages.Format(messages.IDTokenWritePermission, nil)))
 				c.IncrementWarningCount()
 			}
 		}
@@ -370,6 +476,11 @@ Ensure proper audience validation and trust policies are configured.`
 		}
 	}
 
+	// Validate permissions for continuation
+	if err := validateContinuationPermissions(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), nil)
+	}
+
 	// Validate dispatch-workflow configuration (independent of agentic-workflows tool)
 	log.Print("Validating dispatch-workflow configuration")
 	if err := c.validateDispatchWorkflow(workflowData, markdownPath); err != nil {
@@ -434,7 +545,7 @@ func (c *Compiler) generateAndValidateYAML(workflowData *WorkflowData, markdownP
 		if err := c.validateContainerImages(workflowData); err != nil {
 			// Treat container image validation failures as warnings, not errors
 			// This is because validation may fail due to auth issues locally (e.g., private registries)
-			fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", fmt.Sprintf("container image validation failed: %v", err)))
+			fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", messages.Format(messages.ContainerImageValidation, messages.Args{"error": err})))
 			c.IncrementWarningCount()
 		}
 
@@ -484,18 +595,36 @@ func (c *Compiler) writeWorkflowOutput(lockFile, yamlContent string, markdownPat
 
 		// Only write if content has changed
 		if !contentUnchanged {
+			if c.outputDir != "" {
+				if err := os.MkdirAll(filepath.Dir(lockFile), 0755); err != nil {
+					return formatCompilerError(lockFile, "error", fmt.Sprintf("failed to create output directory: %v", err), err)
+				}
+			}
 			if err := os.WriteFile(lockFile, []byte(yamlContent), 0644); err != nil {
 				return formatCompilerError(lockFile, "error", fmt.Sprintf("failed to write lock file: %v", err), err)
 			}
 			log.Print("Lock file written successfully")
 		}
 
+		// Write (or clean up) helper files factored out by --split-scripts
+		if err := c.writeSplitScriptFiles(lockFile); err != nil {
+			return formatCompilerError(lockFile, "error", err.Error(), err)
+		}
+
+		// Write the expression mapping table requested by --expression-map
+		if err := c.writeExpressionMapFile(lockFile); err != nil {
+			return formatCompilerError(lockFile, "error", err.Error(), err)
+		}
+
 		// Validate file size after writing
 		if lockFileInfo, err := os.Stat(lockFile); err == nil {
 			if lockFileInfo.Size() > MaxLockFileSize {
 				lockSize := console.FormatFileSize(lockFileInfo.Size())
 				maxSize := console.FormatFileSize(MaxLockFileSize)
 				warningMsg := fmt.Sprintf("Generated lock file size (%s) exceeds recommended maximum size (%s)", lockSize, maxSize)
+				if !c.splitScripts {
+					warningMsg += ". Use --split-scripts to move generated helper files into " + SplitScriptsDir
+				}
 				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
 			}
 		}
@@ -548,6 +677,12 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	// Reset schedule friendly formats for this compilation
 	c.scheduleFriendlyFormats = nil
 
+	// Reset split helper files for this compilation
+	c.resetSplitScripts()
+
+	// Reset the expression mapping table for this compilation
+	c.expressionMap = nil
+
 	// Reset the artifact manager for this compilation
 	if c.artifactManager == nil {
 		c.artifactManager = NewArtifactManager()
@@ -556,7 +691,7 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	}
 
 	// Generate lock file name
-	lockFile := stringutil.MarkdownToLockFile(markdownPath)
+	lockFile := c.LockFilePath(markdownPath)
 
 	// Sanitize the lock file path to prevent path traversal attacks
 	lockFile = filepath.Clean(lockFile)
@@ -3,6 +3,7 @@ package workflow
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
@@ -13,6 +14,12 @@ import (
 
 var compilerActivationJobLog = logger.New("workflow:compiler_activation_job")
 
+// pullRequestContextOutputs are the outputs of the pr-context step (see resolve_pr_context.cjs)
+var pullRequestContextOutputs = []string{
+	"number", "head_sha", "head_ref", "base_sha", "base_ref",
+	"changed_files", "changed_files_count", "additions", "deletions",
+}
+
 // buildActivationJob creates the activation job that handles timestamp checking, reactions, and locking.
 // This job depends on the pre-activation job if it exists, and runs before the main agent job.
 func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreated bool, workflowRunRepoSafety string, lockFilename string) (*Job, error) {
@@ -60,6 +67,12 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 		compilerActivationJobLog.Printf("Skipped validate-secret step (engine does not require secret validation)")
 	}
 
+	// Verify the workflow runs on the configured GitHub Enterprise host with a supported server version
+	if hostSteps := c.generateGitHubHostValidationStep(data); len(hostSteps) > 0 {
+		steps = append(steps, hostSteps...)
+		compilerActivationJobLog.Printf("Added GitHub Enterprise host validation step for %s", data.GitHubHost.Host)
+	}
+
 	// Checkout .github and .agents folders for accessing workflow configurations and runtime imports
 	// This is needed for prompt generation which may reference runtime imports from .github folder
 	// Always add this checkout in activation job since it needs access to workflow files for runtime imports
@@ -107,6 +120,10 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/github-script")))
 	steps = append(steps, "        env:\n")
 	steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_FILE: \"%s\"\n", lockFilename))
+	// Lock files named by a custom lock-file-name pattern cannot be mapped back to their source
+	if mdFilename := filepath.Base(c.markdownPath); c.markdownPath != "" && lockFilename != stringutil.MarkdownToLockFile(mdFilename) {
+		steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_MD_FILE: \"%s\"\n", mdFilename))
+	}
 	steps = append(steps, "        with:\n")
 	steps = append(steps, "          script: |\n")
 	steps = append(steps, generateGitHubScriptWithRequire("check_workflow_timestamp_api.cjs"))
@@ -138,6 +155,24 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 		outputs["body"] = "${{ steps.sanitized.outputs.body }}"
 	}
 
+	// Resolve the triggering pull request when the markdown references it. For comments on a
+	// pull request the event payload only carries the issue, so this step looks up the head SHA,
+	// changed files, and diff stats through the API and exposes them as steps.pr-context.outputs.*
+	// (and needs.activation.outputs.pr_* for downstream jobs). Outputs are empty when the event
+	// is not associated with a pull request.
+	if data.NeedsPRContext {
+		steps = append(steps, "      - name: Resolve pull request context\n")
+		steps = append(steps, "        id: pr-context\n")
+		steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/github-script")))
+		steps = append(steps, "        with:\n")
+		steps = append(steps, "          script: |\n")
+		steps = append(steps, generateGitHubScriptWithRequire("resolve_pr_context.cjs"))
+
+		for _, name := range pullRequestContextOutputs {
+			outputs["pr_"+name] = fmt.Sprintf("${{ steps.pr-context.outputs.%s }}", name)
+		}
+	}
+
 	// Add comment with workflow run link if status comments are explicitly enabled
 	if data.StatusComment != nil && *data.StatusComment {
 		reactionCondition := BuildReactionCondition()
@@ -224,15 +259,17 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 		outputs["comment_repo"] = `""`
 	}
 
-	// Add slash_command output if this is a command workflow
-	// This output contains the matched command name from check_command_position step
+	// Add slash_command and slash_command_args outputs if this is a command workflow
+	// These outputs contain the matched command name and its arguments from check_command_position step
 	if len(data.Command) > 0 {
 		if preActivationJobCreated {
-			// Reference the matched_command output from pre_activation job
+			// Reference the matched_command and command_args outputs from pre_activation job
 			outputs["slash_command"] = fmt.Sprintf("${{ needs.%s.outputs.%s }}", string(constants.PreActivationJobName), constants.MatchedCommandOutput)
+			outputs["slash_command_args"] = fmt.Sprintf("${{ needs.%s.outputs.%s }}", string(constants.PreActivationJobName), constants.CommandArgsOutput)
 		} else {
 			// Fallback to steps reference if pre_activation doesn't exist (shouldn't happen for command workflows)
 			outputs["slash_command"] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", constants.CheckCommandPositionStepID, constants.MatchedCommandOutput)
+			outputs["slash_command_args"] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", constants.CheckCommandPositionStepID, constants.CommandArgsOutput)
 		}
 	}
 
@@ -313,7 +350,7 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 	compilerActivationJobLog.Print("Generating prompt in activation job")
 	c.generatePromptInActivationJob(&steps, data, preActivationJobCreated, customJobsBeforeActivation)
 
-	// Upload aw_info.json and prompt.txt as the activation artifact for the agent job to download
+	// Upload aw_info.json, event.json and prompt.txt as the activation artifact for the agent job to download
 	compilerActivationJobLog.Print("Adding activation artifact upload step")
 	steps = append(steps, "      - name: Upload activation artifact\n")
 	steps = append(steps, "        if: success()\n")
@@ -322,9 +359,13 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 	steps = append(steps, "          name: activation\n")
 	steps = append(steps, "          path: |\n")
 	steps = append(steps, "            /tmp/gh-aw/aw_info.json\n")
+	steps = append(steps, "            /tmp/gh-aw/event.json\n")
 	steps = append(steps, "            /tmp/gh-aw/aw-prompts/prompt.txt\n")
 	steps = append(steps, "          retention-days: 1\n")
 
+	// Upload the provenance manifest with the rendered prompt for reproducibility
+	steps = append(steps, c.generateProvenanceManifestSteps(data)...)
+
 	// Set permissions - activation job always needs contents:read for GitHub API access
 	// Also add reaction/comment permissions if reaction or status-comment is configured
 	// Also add issues:write permission if lock-for-agent is enabled (for locking issues)
@@ -352,6 +393,13 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 		permsMap[PermissionIssues] = PermissionWrite
 	}
 
+	// Add pull-requests:read to resolve the pull request context (without downgrading write)
+	if data.NeedsPRContext {
+		if _, exists := permsMap[PermissionPullRequests]; !exists {
+			permsMap[PermissionPullRequests] = PermissionRead
+		}
+	}
+
 	perms := NewPermissionsFromMap(permsMap)
 	permissions := perms.RenderToYAML()
 
@@ -367,13 +415,16 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 		Name:                       string(constants.ActivationJobName),
 		If:                         activationCondition,
 		HasWorkflowRunSafetyChecks: workflowRunRepoSafety != "", // Mark job as having workflow_run safety checks
-		RunsOn:                     c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		RunsOn:                     c.formatAuxiliaryJobRunsOn(data, constants.DefaultActivationJobRunnerImage),
 		Permissions:                permissions,
 		Environment:                environment,
 		Steps:                      steps,
 		Outputs:                    outputs,
 		Needs:                      activationNeeds, // Depend on pre-activation job if it exists
 	}
+	if data.Timeouts != nil {
+		job.TimeoutMinutes = data.Timeouts.Activation
+	}
 
 	return job, nil
 }
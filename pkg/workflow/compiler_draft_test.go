@@ -67,6 +67,27 @@ tools:
 			expectedIf:   "(github.event_name != 'pull_request') || (github.event.pull_request.draft == true)",
 			shouldHaveIf: true,
 		},
+		{
+			name: "pull_request_target with draft: false",
+			frontmatter: `---
+on:
+  pull_request_target:
+    types: [opened, synchronize]
+    draft: false
+
+permissions:
+  contents: read
+  issues: read
+  pull-requests: read
+
+strict: false
+tools:
+  github:
+    allowed: [issue_read]
+---`,
+			expectedIf:   "(github.event_name != 'pull_request_target') || (github.event.pull_request.draft == false)",
+			shouldHaveIf: true,
+		},
 		{
 			name: "pull_request without draft field (no filter)",
 			frontmatter: `---
@@ -274,6 +295,20 @@ func TestCommentOutProcessedFieldsInOnSection(t *testing.T) {
         - cron: "0 9 * * 1"`,
 			description: "Should comment out draft in pull_request while leaving other sections unchanged",
 		},
+		{
+			name: "pull_request_target with draft",
+			input: `on:
+    pull_request_target:
+        draft: false
+        types:
+            - opened`,
+			expected: `on:
+    pull_request_target:
+        # draft: false # Draft filtering applied via job conditions
+        types:
+            - opened`,
+			description: "Should comment out draft in pull_request_target section",
+		},
 		{
 			name: "no pull_request section",
 			input: `on:
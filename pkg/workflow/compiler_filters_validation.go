@@ -7,10 +7,16 @@
 //   - branches and branches-ignore in the same event
 //   - paths and paths-ignore in the same event
 //
+// It also rejects label filters (names) that can never match because the event's
+// types do not include labeled or unlabeled, and workflow_run conclusion filters
+// whose types do not include completed.
+//
 // # Validation Functions
 //
 //   - ValidateEventFilters() - Main entry point for filter validation
 //   - validateFilterExclusivity() - Validates a single event's filter configuration
+//   - validateLabelFilterTypes() - Validates that names filters apply to the event's types
+//   - validateWorkflowRunConclusionTypes() - Validates that conclusions filters apply to completed runs
 //
 // # GitHub Actions Requirements
 //
@@ -18,7 +24,7 @@
 //   - You cannot use both branches and branches-ignore filters for the same event
 //   - You cannot use both paths and paths-ignore filters for the same event
 //
-// These restrictions apply to push and pull_request event filters.
+// These restrictions apply to push, pull_request, pull_request_target, and workflow_run event filters.
 //
 // # When to Add Validation Here
 //
@@ -34,10 +40,17 @@ package workflow
 
 import (
 	"fmt"
+	"slices"
 )
 
 var filterValidationLog = newValidationLogger("filter")
 
+// branchAndPathFilterEvents lists the events that support branches and paths filters
+var branchAndPathFilterEvents = []string{"push", "pull_request", "pull_request_target", "workflow_run"}
+
+// labelFilterEvents lists the events that support names label filters
+var labelFilterEvents = []string{"issues", "pull_request", "pull_request_target", "discussion"}
+
 // ValidateEventFilters checks for GitHub Actions filter mutual exclusivity rules
 func ValidateEventFilters(frontmatter map[string]any) error {
 	filterValidationLog.Print("Validating event filter mutual exclusivity")
@@ -54,18 +67,25 @@ func ValidateEventFilters(frontmatter map[string]any) error {
 		return nil
 	}
 
-	// Check push event
-	if pushVal, exists := onMap["push"]; exists {
-		filterValidationLog.Print("Validating push event filters")
-		if err := validateFilterExclusivity(pushVal, "push"); err != nil {
-			return err
+	for _, eventName := range branchAndPathFilterEvents {
+		if eventVal, exists := onMap[eventName]; exists {
+			filterValidationLog.Printf("Validating %s event filters", eventName)
+			if err := validateFilterExclusivity(eventVal, eventName); err != nil {
+				return err
+			}
 		}
 	}
 
-	// Check pull_request event
-	if prVal, exists := onMap["pull_request"]; exists {
-		filterValidationLog.Print("Validating pull_request event filters")
-		if err := validateFilterExclusivity(prVal, "pull_request"); err != nil {
+	for _, eventName := range labelFilterEvents {
+		if eventVal, exists := onMap[eventName]; exists {
+			if err := validateLabelFilterTypes(eventVal, eventName); err != nil {
+				return err
+			}
+		}
+	}
+
+	if eventVal, exists := onMap["workflow_run"]; exists {
+		if err := validateWorkflowRunConclusionTypes(eventVal); err != nil {
 			return err
 		}
 	}
@@ -103,3 +123,73 @@ func validateFilterExclusivity(eventVal any, eventName string) error {
 	filterValidationLog.Printf("Event '%s' filters are valid", eventName)
 	return nil
 }
+
+// validateLabelFilterTypes validates that a names filter only appears on events whose types
+// include labeled or unlabeled, since the filter is otherwise silently ignored
+func validateLabelFilterTypes(eventVal any, eventName string) error {
+	eventMap, ok := eventVal.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	if _, hasNames := eventMap["names"]; !hasNames {
+		return nil
+	}
+
+	var types []string
+	switch typesVal := eventMap["types"].(type) {
+	case []any:
+		for _, t := range typesVal {
+			if tStr, ok := t.(string); ok {
+				types = append(types, tStr)
+			}
+		}
+	case []string:
+		types = typesVal
+	}
+
+	if slices.Contains(types, "labeled") || slices.Contains(types, "unlabeled") {
+		return nil
+	}
+
+	filterValidationLog.Printf("ERROR: Event '%s' has a 'names' filter without labeled/unlabeled types", eventName)
+	return fmt.Errorf("%s event specifies 'names' but its 'types' do not include 'labeled' or 'unlabeled', so the label filter would never apply. Add 'labeled' and/or 'unlabeled' to 'types', or remove 'names'", eventName)
+}
+
+// validateWorkflowRunConclusionTypes validates that a workflow_run conclusions filter is only
+// combined with types that include completed, since runs have no conclusion before they complete
+func validateWorkflowRunConclusionTypes(eventVal any) error {
+	eventMap, ok := eventVal.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	if _, hasConclusions := eventMap["conclusions"]; !hasConclusions {
+		return nil
+	}
+
+	typesVal, hasTypes := eventMap["types"]
+	if !hasTypes {
+		// Without types, workflow_run triggers on requested and completed runs
+		return nil
+	}
+
+	var types []string
+	switch typesVal := typesVal.(type) {
+	case []any:
+		for _, t := range typesVal {
+			if tStr, ok := t.(string); ok {
+				types = append(types, tStr)
+			}
+		}
+	case []string:
+		types = typesVal
+	}
+
+	if slices.Contains(types, "completed") {
+		return nil
+	}
+
+	filterValidationLog.Print("ERROR: workflow_run has a 'conclusions' filter without the completed type")
+	return fmt.Errorf("workflow_run event specifies 'conclusions' but its 'types' do not include 'completed', so no run would have a conclusion to match. Add 'completed' to 'types', or remove 'conclusions'")
+}
@@ -212,6 +212,97 @@ func TestValidateEventFilters(t *testing.T) {
 			wantErr:     true,
 			errContains: "pull_request",
 		},
+		{
+			name: "invalid both branches and branches-ignore on pull_request_target",
+			frontmatter: map[string]any{
+				"on": map[string]any{
+					"pull_request_target": map[string]any{
+						"branches":        []string{"main"},
+						"branches-ignore": []string{"dev"},
+					},
+				},
+			},
+			wantErr:     true,
+			errContains: "pull_request_target event cannot specify both 'branches' and 'branches-ignore'",
+		},
+		{
+			name: "invalid both branches and branches-ignore on workflow_run",
+			frontmatter: map[string]any{
+				"on": map[string]any{
+					"workflow_run": map[string]any{
+						"workflows":       []string{"CI"},
+						"branches":        []string{"main"},
+						"branches-ignore": []string{"dev"},
+					},
+				},
+			},
+			wantErr:     true,
+			errContains: "workflow_run event cannot specify both 'branches' and 'branches-ignore'",
+		},
+		{
+			name: "valid names with labeled type",
+			frontmatter: map[string]any{
+				"on": map[string]any{
+					"issues": map[string]any{
+						"types": []any{"opened", "labeled"},
+						"names": []any{"bug"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid names without labeled or unlabeled types",
+			frontmatter: map[string]any{
+				"on": map[string]any{
+					"issues": map[string]any{
+						"types": []any{"opened", "edited"},
+						"names": "bug",
+					},
+				},
+			},
+			wantErr:     true,
+			errContains: "issues event specifies 'names'",
+		},
+		{
+			name: "invalid names without types on pull_request_target",
+			frontmatter: map[string]any{
+				"on": map[string]any{
+					"pull_request_target": map[string]any{
+						"names": []any{"safe-to-test"},
+					},
+				},
+			},
+			wantErr:     true,
+			errContains: "pull_request_target event specifies 'names'",
+		},
+		{
+			name: "valid workflow_run conclusions with completed type",
+			frontmatter: map[string]any{
+				"on": map[string]any{
+					"workflow_run": map[string]any{
+						"workflows":   []any{"CI"},
+						"types":       []any{"completed"},
+						"conclusions": []any{"failure"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid workflow_run conclusions without completed type",
+			frontmatter: map[string]any{
+				"on": map[string]any{
+					"workflow_run": map[string]any{
+						"workflows":   []any{"CI"},
+						"types":       []any{"requested"},
+						"conclusions": []any{"failure"},
+					},
+				},
+			},
+			wantErr:     true,
+			errContains: "workflow_run event specifies 'conclusions'",
+		},
 		{
 			name: "valid both push and pull_request without conflicts",
 			frontmatter: map[string]any{
@@ -7,8 +7,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/github/gh-aw/pkg/stringutil"
-
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
@@ -196,7 +194,7 @@ func (c *Compiler) buildJobs(data *WorkflowData, markdownPath string) error {
 	}
 
 	// Extract lock filename for timestamp check
-	lockFilename := filepath.Base(stringutil.MarkdownToLockFile(markdownPath))
+	lockFilename := filepath.Base(c.LockFilePath(markdownPath))
 
 	// Build pre-activation and activation jobs
 	_, activationJobCreated, err := c.buildPreActivationAndActivationJobs(data, frontmatter, lockFilename)
@@ -227,6 +225,16 @@ func (c *Compiler) buildJobs(data *WorkflowData, markdownPath string) error {
 		return err
 	}
 
+	// Build continuation job if continuation is configured
+	if err := c.buildContinuationJobWrapper(data, lockFilename); err != nil {
+		return err
+	}
+
+	// Build webhook notification job if notifications are configured
+	if err := c.buildNotifyWebhookJobWrapper(data); err != nil {
+		return err
+	}
+
 	compilerJobsLog.Print("Successfully built all jobs for workflow")
 	return nil
 }
@@ -243,10 +251,13 @@ func (c *Compiler) buildPreActivationAndActivationJobs(data *WorkflowData, front
 	hasSkipBots := len(data.SkipBots) > 0
 	hasCommandTrigger := len(data.Command) > 0
 	hasRateLimit := data.RateLimit != nil
-	compilerJobsLog.Printf("Job configuration: needsPermissionCheck=%v, hasStopTime=%v, hasSkipIfMatch=%v, hasSkipIfNoMatch=%v, hasSkipRoles=%v, hasSkipBots=%v, hasCommand=%v, hasRateLimit=%v", needsPermissionCheck, hasStopTime, hasSkipIfMatch, hasSkipIfNoMatch, hasSkipRoles, hasSkipBots, hasCommandTrigger, hasRateLimit)
+	hasScheduleChecks := len(data.ScheduleChecks) > 0
+	hasWorkflowNeeds := data.WorkflowNeeds != nil
+	hasGuards := data.Guards.hasGuardChecks()
+	compilerJobsLog.Printf("Job configuration: needsPermissionCheck=%v, hasStopTime=%v, hasSkipIfMatch=%v, hasSkipIfNoMatch=%v, hasSkipRoles=%v, hasSkipBots=%v, hasCommand=%v, hasRateLimit=%v, hasScheduleChecks=%v, hasWorkflowNeeds=%v, hasGuards=%v", needsPermissionCheck, hasStopTime, hasSkipIfMatch, hasSkipIfNoMatch, hasSkipRoles, hasSkipBots, hasCommandTrigger, hasRateLimit, hasScheduleChecks, hasWorkflowNeeds, hasGuards)
 
-	// Build pre-activation job if needed (combines membership checks, stop-time validation, skip-if-match check, skip-if-no-match check, skip-roles check, skip-bots check, rate limit check, and command position check)
-	if needsPermissionCheck || hasStopTime || hasSkipIfMatch || hasSkipIfNoMatch || hasSkipRoles || hasSkipBots || hasCommandTrigger || hasRateLimit {
+	// Build pre-activation job if needed (combines membership checks, stop-time validation, skip-if-match check, skip-if-no-match check, skip-roles check, skip-bots check, rate limit check, guards check, schedule check, and command position check)
+	if needsPermissionCheck || hasStopTime || hasSkipIfMatch || hasSkipIfNoMatch || hasSkipRoles || hasSkipBots || hasCommandTrigger || hasRateLimit || hasScheduleChecks || hasWorkflowNeeds || hasGuards {
 		compilerJobsLog.Print("Building pre-activation job")
 		preActivationJob, err := c.buildPreActivationJob(data, needsPermissionCheck)
 		if err != nil {
@@ -260,8 +271,10 @@ func (c *Compiler) buildPreActivationAndActivationJobs(data *WorkflowData, front
 	}
 
 	// Determine if we need to add workflow_run repository safety check
+	// The frontmatter is read from the source, so the workflow_run trigger generated from
+	// needs: is checked separately
 	var workflowRunRepoSafety string
-	if c.hasWorkflowRunTrigger(frontmatter) {
+	if c.hasWorkflowRunTrigger(frontmatter) || data.WorkflowNeeds != nil {
 		workflowRunRepoSafety = c.buildWorkflowRunRepoSafetyCondition()
 		compilerJobsLog.Print("Adding workflow_run repository safety check")
 	}
@@ -297,8 +310,8 @@ func (c *Compiler) buildMainJobWrapper(data *WorkflowData, activationJobCreated
 	return nil
 }
 
-// buildMemoryManagementJobs builds memory management jobs (push_repo_memory and update_cache_memory).
-// These jobs handle artifact-based memory persistence to git branches and GitHub Actions cache.
+// buildMemoryManagementJobs builds memory management jobs (push_repo_memory, update_cache_memory and update_memory_gist).
+// These jobs handle artifact-based memory persistence to git branches, GitHub Actions cache and gists.
 func (c *Compiler) buildMemoryManagementJobs(data *WorkflowData) error {
 	threatDetectionEnabledForSafeJobs := data.SafeOutputs != nil && data.SafeOutputs.ThreatDetection != nil
 
@@ -314,8 +327,14 @@ func (c *Compiler) buildMemoryManagementJobs(data *WorkflowData) error {
 		return err
 	}
 
+	// Build update_memory_gist job if the runtime memory is stored in a gist
+	updateMemoryGistJobName, err := c.buildUpdateMemoryGistJobWrapper(data, threatDetectionEnabledForSafeJobs)
+	if err != nil {
+		return err
+	}
+
 	// Update conclusion job dependencies
-	if err := c.updateConclusionJobDependencies(pushRepoMemoryJobName, updateCacheMemoryJobName); err != nil {
+	if err := c.updateConclusionJobDependencies(pushRepoMemoryJobName, updateCacheMemoryJobName, updateMemoryGistJobName); err != nil {
 		return err
 	}
 
@@ -380,8 +399,74 @@ func (c *Compiler) buildUpdateCacheMemoryJobWrapper(data *WorkflowData, threatDe
 	return updateCacheMemoryJob.Name, nil
 }
 
+// buildUpdateMemoryGistJobWrapper builds the update_memory_gist job if the runtime memory uses the gist backend.
+// Returns the job name if created, empty string otherwise.
+func (c *Compiler) buildUpdateMemoryGistJobWrapper(data *WorkflowData, threatDetectionEnabled bool) (string, error) {
+	compilerJobsLog.Print("Building update_memory_gist job")
+	updateMemoryGistJob, err := c.buildUpdateMemoryGistJob(data, threatDetectionEnabled)
+	if err != nil {
+		return "", fmt.Errorf("failed to build update_memory_gist job: %w", err)
+	}
+
+	if updateMemoryGistJob == nil {
+		return "", nil
+	}
+
+	if err := c.jobManager.AddJob(updateMemoryGistJob); err != nil {
+		return "", fmt.Errorf("failed to add update_memory_gist job: %w", err)
+	}
+
+	compilerJobsLog.Printf("Successfully added update_memory_gist job: %s", updateMemoryGistJob.Name)
+	return updateMemoryGistJob.Name, nil
+}
+
+// buildContinuationJobWrapper builds the continuation job if continuation is configured
+func (c *Compiler) buildContinuationJobWrapper(data *WorkflowData, lockFilename string) error {
+	threatDetectionEnabled := data.SafeOutputs != nil && data.SafeOutputs.ThreatDetection != nil
+	continuationJob, err := c.buildContinuationJob(data, lockFilename, threatDetectionEnabled)
+	if err != nil {
+		return fmt.Errorf("failed to build %s job: %w", continuationJobName, err)
+	}
+
+	if continuationJob == nil {
+		return nil
+	}
+
+	if err := c.jobManager.AddJob(continuationJob); err != nil {
+		return fmt.Errorf("failed to add %s job: %w", continuationJobName, err)
+	}
+
+	compilerJobsLog.Printf("Successfully added continuation job: %s", continuationJob.Name)
+	return nil
+}
+
+// buildNotifyWebhookJobWrapper builds the notify_webhook job if notifications are configured.
+// The job runs after the conclusion job, when there is one, so the summary covers the whole run.
+func (c *Compiler) buildNotifyWebhookJobWrapper(data *WorkflowData) error {
+	var extraNeeds []string
+	if _, exists := c.jobManager.GetJob("conclusion"); exists {
+		extraNeeds = append(extraNeeds, "conclusion")
+	}
+
+	notifyJob, err := c.buildNotifyWebhookJob(data, extraNeeds)
+	if err != nil {
+		return fmt.Errorf("failed to build %s job: %w", notifyWebhookJobName, err)
+	}
+
+	if notifyJob == nil {
+		return nil
+	}
+
+	if err := c.jobManager.AddJob(notifyJob); err != nil {
+		return fmt.Errorf("failed to add %s job: %w", notifyWebhookJobName, err)
+	}
+
+	compilerJobsLog.Printf("Successfully added notify webhook job: %s", notifyJob.Name)
+	return nil
+}
+
 // updateConclusionJobDependencies updates the conclusion job to depend on memory management jobs if they exist.
-func (c *Compiler) updateConclusionJobDependencies(pushRepoMemoryJobName, updateCacheMemoryJobName string) error {
+func (c *Compiler) updateConclusionJobDependencies(pushRepoMemoryJobName, updateCacheMemoryJobName, updateMemoryGistJobName string) error {
 	conclusionJob, exists := c.jobManager.GetJob("conclusion")
 	if !exists {
 		return nil
@@ -397,6 +482,11 @@ func (c *Compiler) updateConclusionJobDependencies(pushRepoMemoryJobName, update
 		compilerJobsLog.Printf("Added update_cache_memory dependency to conclusion job")
 	}
 
+	if updateMemoryGistJobName != "" {
+		conclusionJob.Needs = append(conclusionJob.Needs, updateMemoryGistJobName)
+		compilerJobsLog.Printf("Added update_memory_gist dependency to conclusion job")
+	}
+
 	return nil
 }
 
@@ -421,7 +511,15 @@ func (c *Compiler) buildCustomJobs(data *WorkflowData, activationJobCreated bool
 		promptReferencedJobs[j] = true
 	}
 
-	for jobName, jobConfig := range data.Jobs {
+	// Process jobs in name order so errors and generated output are stable across runs
+	customJobNames := make([]string, 0, len(data.Jobs))
+	for jobName := range data.Jobs {
+		customJobNames = append(customJobNames, jobName)
+	}
+	sort.Strings(customJobNames)
+
+	for _, jobName := range customJobNames {
+		jobConfig := data.Jobs[jobName]
 		// Skip jobs.pre-activation (or pre_activation) as it's handled specially in buildPreActivationJob
 		if jobName == string(constants.PreActivationJobName) || jobName == "pre-activation" {
 			compilerJobsLog.Printf("Skipping jobs.%s (handled in buildPreActivationJob)", jobName)
@@ -683,8 +781,8 @@ func (c *Compiler) buildCustomJobs(data *WorkflowData, activationJobCreated bool
 									return fmt.Errorf("failed to convert step to typed step for job '%s': %w", jobName, err)
 								}
 
-								// Apply action pinning using type-safe version
-								pinnedStep := ApplyActionPinToTypedStep(typedStep, data)
+								// Apply action pinning and checkout hardening using type-safe version
+								pinnedStep := HardenCheckoutStep(ApplyActionPinToTypedStep(typedStep, data))
 
 								// Convert back to map for YAML generation
 								stepYAML, err := c.convertStepToYAML(pinnedStep.ToMap())
@@ -715,6 +813,7 @@ func (c *Compiler) buildCustomJobs(data *WorkflowData, activationJobCreated bool
 //
 // The checkout step is only skipped when:
 //   - Custom steps already contain a checkout action
+//   - Nothing in the agent job needs the working tree (see agentNeedsWorkingTree)
 //
 // Otherwise, checkout is always added to ensure the agent has access to the repository.
 func (c *Compiler) shouldAddCheckoutStep(data *WorkflowData) bool {
@@ -724,6 +823,12 @@ func (c *Compiler) shouldAddCheckoutStep(data *WorkflowData) bool {
 		return false
 	}
 
+	// Engines limited to MCP tools only get the .github folder unless a tool needs the repository
+	if c.engineRegistry != nil && !c.agentNeedsWorkingTree(data) {
+		log.Print("Skipping checkout step: no tool needs the working tree")
+		return false
+	}
+
 	// Always add checkout to ensure agent has repository access
 	log.Print("Adding checkout step: agent job requires repository access")
 	return true
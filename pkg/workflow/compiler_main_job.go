@@ -19,6 +19,9 @@ func (c *Compiler) buildMainJob(data *WorkflowData, activationJobCreated bool) (
 	log.Printf("Building main job for workflow: %s", data.Name)
 	var steps []string
 
+	// On Windows, align the Git Bash and Node.js views of /opt and /tmp before anything uses them
+	steps = append(steps, buildWindowsPathMappingStep(data)...)
+
 	// Add setup action steps at the beginning of the job
 	setupActionRef := c.resolveActionReference("./actions/setup", data)
 	if setupActionRef != "" || c.actionMode.IsScript() {
@@ -137,6 +140,17 @@ func (c *Compiler) buildMainJob(data *WorkflowData, activationJobCreated bool) (
 		outputs["has_patch"] = "${{ steps.collect_output.outputs.has_patch }}"
 	}
 
+	// Add continuation status for the continuation job
+	if data.Continuation != nil {
+		outputs["continuation"] = "${{ steps.continuation.outputs.status }}"
+	}
+
+	// Add final summary and token usage outputs for the run summary comment
+	maps.Copy(outputs, buildSummaryCommentAgentOutputs(data))
+
+	// Add token usage output for the webhook notification
+	maps.Copy(outputs, buildNotificationsAgentOutputs(data))
+
 	// Add inline detection outputs if threat detection is enabled
 	if data.SafeOutputs != nil && data.SafeOutputs.ThreatDetection != nil {
 		outputs["detection_success"] = "${{ steps.detection_conclusion.outputs.success }}"
@@ -236,18 +250,21 @@ func (c *Compiler) buildMainJob(data *WorkflowData, activationJobCreated bool) (
 	}
 
 	job := &Job{
-		Name:        string(constants.AgentJobName),
-		If:          jobCondition,
-		RunsOn:      c.indentYAMLLines(data.RunsOn, "    "),
-		Environment: c.indentYAMLLines(data.Environment, "    "),
-		Container:   c.indentYAMLLines(data.Container, "    "),
-		Services:    c.indentYAMLLines(data.Services, "    "),
-		Permissions: c.indentYAMLLines(permissions, "    "),
-		Concurrency: c.indentYAMLLines(agentConcurrency, "    "),
-		Env:         env,
-		Steps:       steps,
-		Needs:       depends,
-		Outputs:     outputs,
+		Name:              string(constants.AgentJobName),
+		If:                jobCondition,
+		RunsOn:            c.indentYAMLLines(data.RunsOn, "    "),
+		Environment:       c.indentYAMLLines(data.Environment, "    "),
+		Container:         c.indentYAMLLines(data.Container, "    "),
+		Services:          c.indentYAMLLines(data.Services, "    "),
+		Permissions:       c.indentYAMLLines(permissions, "    "),
+		Concurrency:       c.indentYAMLLines(agentConcurrency, "    "),
+		Defaults:          c.indentYAMLLines(buildRunnerOSJobDefaults(data), "    "),
+		Env:               env,
+		Steps:             steps,
+		Needs:             depends,
+		Outputs:           outputs,
+		TimeoutMinutes:    agentJobTimeoutMinutes(data), // Only set when timeouts.agent gives the agent step its own timeout
+		TimeoutExpression: agentJobTimeoutExpression(data),
 	}
 
 	return job, nil
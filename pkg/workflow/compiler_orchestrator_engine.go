@@ -8,6 +8,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/messages"
 	"github.com/github/gh-aw/pkg/parser"
 )
 
@@ -33,16 +34,30 @@ type engineSetupResult struct {
 func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, cleanPath string, content []byte, markdownDir string) (*engineSetupResult, error) {
 	orchestratorEngineLog.Printf("Setting up engine and processing imports")
 
+	// Reject ${{ vars.* }} expressions in fields that are resolved at compile time
+	if err := validateFrontmatterVarsUsage(result.Frontmatter); err != nil {
+		return nil, err
+	}
+
 	// Extract AI engine setting from frontmatter
 	engineSetting, engineConfig := c.ExtractEngineConfig(result.Frontmatter)
 
 	// Extract network permissions from frontmatter
 	networkPermissions := c.extractNetworkPermissions(result.Frontmatter)
 
-	// Default to 'defaults' ecosystem if no network permissions specified
+	// Default to 'defaults' ecosystem if no network permissions specified.
+	// A sandboxed engine container starts with no default network: only the engine's own
+	// endpoints are reachable unless the workflow allows more.
 	if networkPermissions == nil {
-		networkPermissions = &NetworkPermissions{
-			Allowed: []string{"defaults"},
+		if engineConfig != nil && engineConfig.Container != "" {
+			orchestratorEngineLog.Print("Engine container configured, defaulting to no network access beyond the engine")
+			networkPermissions = &NetworkPermissions{
+				Allowed: []string{},
+			}
+		} else {
+			networkPermissions = &NetworkPermissions{
+				Allowed: []string{"defaults"},
+			}
 		}
 	}
 
@@ -54,7 +69,7 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 	initialStrictMode := c.strictMode
 
 	// Check strict mode in frontmatter
-	// Priority: CLI flag > frontmatter > schema default (true)
+	// Priority: CLI flag > frontmatter > .aw/config.yml > schema default (true)
 	if !c.strictMode {
 		// CLI flag not set, check frontmatter
 		if strictValue, exists := result.Frontmatter["strict"]; exists {
@@ -63,11 +78,18 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 				c.strictMode = strictBool
 			}
 		} else {
-			// Neither CLI nor frontmatter set - use schema default (true)
-			c.strictMode = true
+			// Neither CLI nor frontmatter set - use repo config, then schema default (true)
+			c.strictMode = c.defaultStrictMode()
 		}
 	}
 
+	// Resolve per-category strict levels from strict-rules (frontmatter > .aw/config.yml)
+	if err := c.resolveStrictRules(result.Frontmatter, cleanPath, initialStrictMode); err != nil {
+		orchestratorEngineLog.Printf("Strict rules resolution failed: %v", err)
+		c.strictMode = initialStrictMode
+		return nil, err
+	}
+
 	// Perform strict mode validations
 	orchestratorEngineLog.Printf("Performing strict mode validation (strict=%v)", c.strictMode)
 	if err := c.validateStrictMode(result.Frontmatter, networkPermissions); err != nil {
@@ -93,7 +115,7 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 	if c.engineOverride != "" {
 		originalEngineSetting := engineSetting
 		if originalEngineSetting != "" && originalEngineSetting != c.engineOverride {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Command line --engine %s overrides markdown file engine: %s", c.engineOverride, originalEngineSetting)))
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(messages.Format(messages.EngineOverridden, messages.Args{"override": c.engineOverride, "engine": originalEngineSetting})))
 			c.IncrementWarningCount()
 		}
 		engineSetting = c.engineOverride
@@ -153,6 +175,7 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 			return nil, fmt.Errorf("failed to merge network permissions: %w", err)
 		}
 	}
+	extractNetworkVarsExpressions(networkPermissions)
 
 	// Validate permissions from imports against top-level permissions
 	// Extract top-level permissions first
@@ -176,6 +199,24 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 	// Combine imported engines with included engines
 	allEngines := append(importsResult.MergedEngines, includedEngines...)
 
+	// Deep-merge engine configurations that target the same engine (main workflow > earlier imports > later imports)
+	if c.engineOverride == "" {
+		mergedEngineJSON, err := c.MergeEngines(result.Frontmatter["engine"], allEngines)
+		if err != nil {
+			orchestratorEngineLog.Printf("Engine merge failed: %v", err)
+			return nil, err
+		}
+		if mergedEngineJSON != "" {
+			mergedConfig, err := c.extractEngineConfigFromJSON(mergedEngineJSON)
+			if err != nil {
+				return nil, fmt.Errorf("failed to extract merged engine config: %w", err)
+			}
+			engineConfig = mergedConfig
+			engineSetting = mergedConfig.ID
+			allEngines = nil
+		}
+	}
+
 	// Validate that only one engine field exists across all files
 	orchestratorEngineLog.Printf("Validating single engine specification")
 	finalEngineSetting, err := c.validateSingleEngineSpecification(engineSetting, allEngines)
@@ -200,9 +241,14 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 
 	// Apply the default AI engine setting if not specified
 	if engineSetting == "" {
-		defaultEngine := c.engineRegistry.GetDefaultEngine()
-		engineSetting = defaultEngine.GetID()
-		log.Printf("No 'engine:' setting found, defaulting to: %s", engineSetting)
+		if c.repoConfig != nil && c.repoConfig.Engine != "" {
+			engineSetting = c.repoConfig.Engine
+			log.Printf("No 'engine:' setting found, using %s default: %s", RepoConfigPath, engineSetting)
+		} else {
+			defaultEngine := c.engineRegistry.GetDefaultEngine()
+			engineSetting = defaultEngine.GetID()
+			log.Printf("No 'engine:' setting found, defaulting to: %s", engineSetting)
+		}
 		// Create a default EngineConfig with the default engine ID if not already set
 		if engineConfig == nil {
 			engineConfig = &EngineConfig{ID: engineSetting}
@@ -225,10 +271,17 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 		return nil, err
 	}
 
+	// Validate engine.install options against the resolved engine
+	if err := validateEngineInstallOptions(engineConfig, engineSetting); err != nil {
+		orchestratorEngineLog.Printf("Engine install options validation failed: %v", err)
+		return nil, err
+	}
+
 	log.Printf("AI engine: %s (%s)", agenticEngine.GetDisplayName(), engineSetting)
-	if agenticEngine.IsExperimental() && c.verbose {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental engine: "+agenticEngine.GetDisplayName()))
-		c.IncrementWarningCount()
+	if agenticEngine.IsExperimental() && (c.verbose || c.strictLevel(StrictRuleExperimental) == StrictLevelError) {
+		if err := c.reportExperimentalFeature("Using experimental engine: " + agenticEngine.GetDisplayName()); err != nil {
+			return nil, err
+		}
 	}
 
 	// Enable firewall by default for copilot engine when network restrictions are present
@@ -249,8 +302,8 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 				c.strictMode = strictBool
 			}
 		} else {
-			// Neither CLI nor frontmatter set - use schema default (true)
-			c.strictMode = true
+			// Neither CLI nor frontmatter set - use repo config, then schema default (true)
+			c.strictMode = c.defaultStrictMode()
 		}
 	}
 
@@ -262,6 +315,13 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 		return nil, err
 	}
 
+	// Validate that bash argument patterns are only used with an engine that enforces them
+	if err := c.validateBashArgumentPatternSupport(engineSetting, extractToolsFromFrontmatter(result.Frontmatter)); err != nil {
+		orchestratorEngineLog.Printf("Bash argument pattern validation failed: %v", err)
+		c.strictMode = initialStrictModeForFirewall
+		return nil, err
+	}
+
 	// Check if the engine supports network restrictions when they are defined
 	if err := c.checkNetworkSupport(agenticEngine, networkPermissions); err != nil {
 		orchestratorEngineLog.Printf("Network support check failed: %v", err)
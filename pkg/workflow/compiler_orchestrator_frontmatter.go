@@ -61,12 +61,38 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 		return nil, errors.New("no frontmatter found")
 	}
 
+	// Check gh-aw-version and min-version before schema validation, so that the warning about
+	// a workflow created by a newer gh-aw precedes any unknown field errors
+	if err := c.validateWorkflowVersions(result.Frontmatter, cleanPath); err != nil {
+		orchestratorFrontmatterLog.Printf("Workflow version validation failed: %v", err)
+		return nil, err
+	}
+
 	// Preprocess schedule fields to convert human-friendly format to cron expressions
 	if err := c.preprocessScheduleFields(result.Frontmatter, cleanPath, string(content)); err != nil {
 		orchestratorFrontmatterLog.Printf("Schedule preprocessing failed: %v", err)
 		return nil, err
 	}
 
+	// Expand the permission profile into permissions, tools, and safe outputs so that the
+	// expanded frontmatter goes through schema validation
+	if err := c.applyPermissionProfile(result.Frontmatter, cleanPath); err != nil {
+		orchestratorFrontmatterLog.Printf("Profile expansion failed: %v", err)
+		return nil, fmt.Errorf("%s: %w", cleanPath, err)
+	}
+
+	// Replace the item triggers with a workflow_run trigger on the upstream workflow (needs:)
+	if err := c.applyWorkflowNeeds(result.Frontmatter, cleanPath); err != nil {
+		orchestratorFrontmatterLog.Printf("Workflow needs expansion failed: %v", err)
+		return nil, fmt.Errorf("%s: %w", cleanPath, err)
+	}
+
+	// Let the continuation job re-dispatch the workflow with the checkpoint of the current run
+	addContinuationInputs(result.Frontmatter)
+
+	// Let manual runs preview safe outputs with the dry_run dispatch input
+	addDryRunInput(result.Frontmatter)
+
 	// Create a copy of frontmatter without internal markers for schema validation
 	// Keep the original frontmatter with markers for YAML generation
 	frontmatterForValidation := c.copyFrontmatterWithoutInternalMarkers(result.Frontmatter)
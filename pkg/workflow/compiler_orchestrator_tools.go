@@ -8,6 +8,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/messages"
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/goccy/go-yaml"
 )
@@ -30,11 +31,13 @@ type toolsProcessingResult struct {
 	workflowName          string
 	frontmatterName       string
 	needsTextOutput       bool
+	needsPRContext        bool
 	trackerID             string
 	safeOutputs           *SafeOutputsConfig
 	secretMasking         *SecretMaskingConfig
 	parsedFrontmatter     *FrontmatterConfig
-	hasExplicitGitHubTool bool // true if tools.github was explicitly configured in frontmatter
+	hasExplicitGitHubTool bool          // true if tools.github was explicitly configured in frontmatter
+	memoryConfig          *MemoryConfig // runtime memory key-value store
 }
 
 // processToolsAndMarkdown processes tools configuration, runtimes, and markdown content.
@@ -133,6 +136,13 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 	}
 	orchestratorToolsLog.Printf("hasExplicitGitHubTool: %v", hasExplicitGitHubTool)
 
+	// Map neutral tool names (shell, file-edit, web-search: true, ...) to canonical tool keys
+	neutralTools := collectNeutralTools(tools)
+	tools, err = normalizeToolAliases(tools)
+	if err != nil {
+		return nil, err
+	}
+
 	// Extract and validate tools timeout settings
 	toolsTimeout, err := c.extractToolsTimeout(tools)
 	if err != nil {
@@ -215,6 +225,16 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 	// Add MCP fetch server if needed (when web-fetch is requested but engine doesn't support it)
 	tools, _ = AddMCPFetchServerIfNeeded(tools, agenticEngine)
 
+	// Expose the runtime memory key-value store through its MCP server
+	memoryConfig, err := extractMemoryConfig(result.Frontmatter)
+	if err != nil {
+		return nil, err
+	}
+	tools, err = addMemoryMCPServer(tools, memoryConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate MCP configurations
 	orchestratorToolsLog.Printf("Validating MCP configurations")
 	if err := ValidateMCPConfigs(tools); err != nil {
@@ -224,10 +244,11 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 
 	if !agenticEngine.SupportsToolsAllowlist() {
 		// For engines that don't support tool allowlists (like custom engine), ignore tools section and provide warnings
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Using experimental %s support (engine: %s)", agenticEngine.GetDisplayName(), agenticEngine.GetID())))
-		c.IncrementWarningCount()
+		if err := c.reportExperimentalFeature(fmt.Sprintf("Using experimental %s support (engine: %s)", agenticEngine.GetDisplayName(), agenticEngine.GetID())); err != nil {
+			return nil, err
+		}
 		if _, hasTools := result.Frontmatter["tools"]; hasTools {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("'tools' section ignored when using engine: %s (%s doesn't support MCP tool allow-listing)", agenticEngine.GetID(), agenticEngine.GetDisplayName())))
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(messages.Format(messages.ToolsIgnoredForEngine, messages.Args{"engine": agenticEngine.GetID(), "engine_name": agenticEngine.GetDisplayName()})))
 			c.IncrementWarningCount()
 		}
 		tools = map[string]any{}
@@ -246,8 +267,21 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		return nil, err
 	}
 
+	// Validate max-tool-calls support for the current engine
+	if err := c.validateMaxToolCallsSupport(result.Frontmatter, agenticEngine); err != nil {
+		return nil, err
+	}
+
+	// Validate that the engine has an equivalent for each neutral tool
+	if err := validateNeutralToolSupport(neutralTools, tools, agenticEngine); err != nil {
+		return nil, err
+	}
+
 	// Validate web-search support for the current engine (warning only)
 	c.validateWebSearchSupport(tools, agenticEngine)
+	if err := validateWebSearchDomains(tools); err != nil {
+		return nil, err
+	}
 
 	// Process @include directives in markdown content
 	markdownContent, includedMarkdownFiles, err := parser.ExpandIncludesWithManifest(result.Markdown, markdownDir, false)
@@ -324,6 +358,8 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 	orchestratorToolsLog.Printf("Text output needed: explicit=%v, context=%v, final=%v",
 		explicitUsage, hasContext, needsTextOutput)
 
+	needsPRContext := c.detectPullRequestContextUsage(markdownContent)
+
 	// Extract and validate tracker-id
 	trackerID, err := c.extractTrackerID(result.Frontmatter)
 	if err != nil {
@@ -353,11 +389,13 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		workflowName:          workflowName,
 		frontmatterName:       frontmatterName,
 		needsTextOutput:       needsTextOutput,
+		needsPRContext:        needsPRContext,
 		trackerID:             trackerID,
 		safeOutputs:           safeOutputs,
 		secretMasking:         secretMasking,
 		parsedFrontmatter:     parsedFrontmatter,
 		hasExplicitGitHubTool: hasExplicitGitHubTool,
+		memoryConfig:          memoryConfig,
 	}, nil
 }
 
@@ -375,6 +413,16 @@ func (c *Compiler) detectTextOutputUsage(markdownContent string) bool {
 	return hasUsage
 }
 
+// detectPullRequestContextUsage checks if the markdown content references the pull request
+// context resolved in the activation job, either as ${{ steps.pr-context.outputs.* }} or as
+// ${{ needs.activation.outputs.pr_* }}
+func (c *Compiler) detectPullRequestContextUsage(markdownContent string) bool {
+	hasUsage := strings.Contains(markdownContent, "steps.pr-context.outputs.") ||
+		strings.Contains(markdownContent, "needs.activation.outputs.pr_")
+	detectionLog.Printf("Detected usage of pull request context outputs: %v", hasUsage)
+	return hasUsage
+}
+
 // hasContentContext checks if the workflow is triggered by events that have text content
 // (issues, discussions, pull requests, or comments). These events can provide sanitized
 // text/title/body outputs via the sanitized step, even if not explicitly referenced.
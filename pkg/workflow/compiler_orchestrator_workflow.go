@@ -92,6 +92,13 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	// Extract YAML configuration sections from frontmatter
 	c.extractYAMLSections(result.Frontmatter, workflowData)
 
+	// Parse per-workflow artifact retention and naming
+	artifacts, err := parseArtifactsConfig(result.Frontmatter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cleanPath, err)
+	}
+	workflowData.Artifacts = artifacts
+
 	// Merge features from imports
 	if len(engineSetup.importsResult.MergedFeatures) > 0 {
 		mergedFeatures, err := c.MergeFeatures(workflowData.Features, engineSetup.importsResult.MergedFeatures)
@@ -126,6 +133,11 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	// Note: Git commands are automatically injected when safe-outputs needs them (see compiler_safe_outputs.go)
 	// No validation needed here - the compiler handles adding git to bash allowlist
 
+	// Validate if: conditions before filters combine them with generated conditions
+	if err := validateIfConditions(workflowData); err != nil {
+		return nil, fmt.Errorf("%s: %w", cleanPath, err)
+	}
+
 	// Process on section configuration and apply filters
 	if err := c.processOnSectionAndFilters(result.Frontmatter, workflowData, cleanPath); err != nil {
 		return nil, err
@@ -170,6 +182,7 @@ func (c *Compiler) buildInitialWorkflowData(
 		ImportInputs:          importsResult.ImportInputs,
 		Tools:                 toolsResult.tools,
 		ParsedTools:           NewTools(toolsResult.tools),
+		MemoryConfig:          toolsResult.memoryConfig,
 		Runtimes:              toolsResult.runtimes,
 		PluginInfo:            toolsResult.pluginInfo,
 		APMDependencies:       toolsResult.apmDependencies,
@@ -182,11 +195,13 @@ func (c *Compiler) buildInitialWorkflowData(
 		NetworkPermissions:    engineSetup.networkPermissions,
 		SandboxConfig:         applySandboxDefaults(engineSetup.sandboxConfig, engineSetup.engineConfig),
 		NeedsTextOutput:       toolsResult.needsTextOutput,
+		NeedsPRContext:        toolsResult.needsPRContext,
 		ToolsTimeout:          toolsResult.toolsTimeout,
 		ToolsStartupTimeout:   toolsResult.toolsStartupTimeout,
 		TrialMode:             c.trialMode,
 		TrialLogicalRepo:      c.trialLogicalRepoSlug,
-		StrictMode:            c.strictMode,
+		StrictMode:            c.actionPinningStrict(),
+		NoActionPinWarnings:   c.actionPinWarningsDisabled(),
 		SecretMasking:         toolsResult.secretMasking,
 		ParsedFrontmatter:     toolsResult.parsedFrontmatter,
 		RawFrontmatter:        result.Frontmatter,
@@ -222,6 +237,7 @@ func (c *Compiler) extractYAMLSections(frontmatter map[string]any, workflowData
 
 	workflowData.On = c.extractTopLevelYAMLSection(frontmatter, "on")
 	workflowData.HasDispatchItemNumber = extractDispatchItemNumber(frontmatter)
+	workflowData.HasReplayInputs = hasDispatchInput(frontmatter, ReplayPayloadInputName)
 	workflowData.Permissions = c.extractPermissions(frontmatter)
 	workflowData.Network = c.extractTopLevelYAMLSection(frontmatter, "network")
 	workflowData.Concurrency = c.extractTopLevelYAMLSection(frontmatter, "concurrency")
@@ -234,6 +250,8 @@ func (c *Compiler) extractYAMLSections(frontmatter map[string]any, workflowData
 	workflowData.TimeoutMinutes = c.extractTopLevelYAMLSection(frontmatter, "timeout-minutes")
 
 	workflowData.RunsOn = c.extractTopLevelYAMLSection(frontmatter, "runs-on")
+	workflowData.SelfHostedRunner = isSelfHostedRunsOn(frontmatter["runs-on"])
+	workflowData.RunnerOS = detectRunnerOS(frontmatter["runs-on"])
 	workflowData.Environment = c.extractTopLevelYAMLSection(frontmatter, "environment")
 	workflowData.Container = c.extractTopLevelYAMLSection(frontmatter, "container")
 	workflowData.Cache = c.extractTopLevelYAMLSection(frontmatter, "cache")
@@ -244,6 +262,12 @@ func (c *Compiler) extractYAMLSections(frontmatter map[string]any, workflowData
 // trigger shorthand (e.g. "on: pull_request labeled my-label"). Reading the
 // structured map avoids re-parsing the rendered YAML string later.
 func extractDispatchItemNumber(frontmatter map[string]any) bool {
+	return hasDispatchInput(frontmatter, "item_number")
+}
+
+// hasDispatchInput reports whether the frontmatter's on.workflow_dispatch trigger
+// declares the named input.
+func hasDispatchInput(frontmatter map[string]any, name string) bool {
 	onVal, ok := frontmatter["on"]
 	if !ok {
 		return false
@@ -268,7 +292,7 @@ func extractDispatchItemNumber(frontmatter map[string]any) bool {
 	if !ok {
 		return false
 	}
-	_, ok = inputsMap["item_number"]
+	_, ok = inputsMap[name]
 	return ok
 }
 
@@ -288,9 +312,11 @@ func (c *Compiler) processAndMergeSteps(frontmatter map[string]any, workflowData
 			typedCopilotSteps, err := SliceToSteps(copilotSetupSteps)
 			if err != nil {
 				orchestratorWorkflowLog.Printf("Failed to convert copilot-setup steps to typed steps: %v", err)
+				copilotSetupSteps = hardenCheckoutStepValues(copilotSetupSteps)
 			} else {
-				// Apply action pinning to copilot-setup steps
+				// Apply action pinning and checkout hardening to copilot-setup steps
 				typedCopilotSteps = ApplyActionPinsToTypedSteps(typedCopilotSteps, workflowData)
+				typedCopilotSteps = HardenCheckoutSteps(typedCopilotSteps)
 				// Convert back to []any for YAML marshaling
 				copilotSetupSteps = StepsToSlice(typedCopilotSteps)
 			}
@@ -305,9 +331,11 @@ func (c *Compiler) processAndMergeSteps(frontmatter map[string]any, workflowData
 			typedOtherSteps, err := SliceToSteps(otherImportedSteps)
 			if err != nil {
 				orchestratorWorkflowLog.Printf("Failed to convert other imported steps to typed steps: %v", err)
+				otherImportedSteps = hardenCheckoutStepValues(otherImportedSteps)
 			} else {
-				// Apply action pinning to other imported steps
+				// Apply action pinning and checkout hardening to other imported steps
 				typedOtherSteps = ApplyActionPinsToTypedSteps(typedOtherSteps, workflowData)
+				typedOtherSteps = HardenCheckoutSteps(typedOtherSteps)
 				// Convert back to []any for YAML marshaling
 				otherImportedSteps = StepsToSlice(typedOtherSteps)
 			}
@@ -318,20 +346,25 @@ func (c *Compiler) processAndMergeSteps(frontmatter map[string]any, workflowData
 	var mainSteps []any
 	if workflowData.CustomSteps != "" {
 		var mainStepsWrapper map[string]any
-		if err := yaml.Unmarshal([]byte(workflowData.CustomSteps), &mainStepsWrapper); err == nil {
-			if mainStepsVal, hasSteps := mainStepsWrapper["steps"]; hasSteps {
-				if steps, ok := mainStepsVal.([]any); ok {
-					mainSteps = steps
-					// Convert to typed steps for action pinning
-					typedMainSteps, err := SliceToSteps(mainSteps)
-					if err != nil {
-						orchestratorWorkflowLog.Printf("Failed to convert main steps to typed steps: %v", err)
-					} else {
-						// Apply action pinning to main steps
-						typedMainSteps = ApplyActionPinsToTypedSteps(typedMainSteps, workflowData)
-						// Convert back to []any for YAML marshaling
-						mainSteps = StepsToSlice(typedMainSteps)
-					}
+		if err := yaml.Unmarshal([]byte(workflowData.CustomSteps), &mainStepsWrapper); err != nil {
+			// Fall back to the parsed frontmatter so the steps are still pinned and hardened
+			orchestratorWorkflowLog.Printf("Failed to parse main steps, using frontmatter steps: %v", err)
+			mainStepsWrapper = map[string]any{"steps": frontmatter["steps"]}
+		}
+		if mainStepsVal, hasSteps := mainStepsWrapper["steps"]; hasSteps {
+			if steps, ok := mainStepsVal.([]any); ok {
+				mainSteps = steps
+				// Convert to typed steps for action pinning
+				typedMainSteps, err := SliceToSteps(mainSteps)
+				if err != nil {
+					orchestratorWorkflowLog.Printf("Failed to convert main steps to typed steps: %v", err)
+					mainSteps = hardenCheckoutStepValues(mainSteps)
+				} else {
+					// Apply action pinning and checkout hardening to main steps
+					typedMainSteps = ApplyActionPinsToTypedSteps(typedMainSteps, workflowData)
+					typedMainSteps = HardenCheckoutSteps(typedMainSteps)
+					// Convert back to []any for YAML marshaling
+					mainSteps = StepsToSlice(typedMainSteps)
 				}
 			}
 		}
@@ -366,27 +399,32 @@ func (c *Compiler) processAndMergePostSteps(frontmatter map[string]any, workflow
 	// Apply action pinning to post-steps if any
 	if workflowData.PostSteps != "" {
 		var postStepsWrapper map[string]any
-		if err := yaml.Unmarshal([]byte(workflowData.PostSteps), &postStepsWrapper); err == nil {
-			if postStepsVal, hasPostSteps := postStepsWrapper["post-steps"]; hasPostSteps {
-				if postSteps, ok := postStepsVal.([]any); ok {
-					// Convert to typed steps for action pinning
-					typedPostSteps, err := SliceToSteps(postSteps)
-					if err != nil {
-						orchestratorWorkflowLog.Printf("Failed to convert post-steps to typed steps: %v", err)
-					} else {
-						// Apply action pinning to post steps using type-safe version
-						typedPostSteps = ApplyActionPinsToTypedSteps(typedPostSteps, workflowData)
-						// Convert back to []any for YAML marshaling
-						postSteps = StepsToSlice(typedPostSteps)
-					}
+		if err := yaml.Unmarshal([]byte(workflowData.PostSteps), &postStepsWrapper); err != nil {
+			// Fall back to the parsed frontmatter so the post-steps are still pinned and hardened
+			orchestratorWorkflowLog.Printf("Failed to parse post-steps, using frontmatter post-steps: %v", err)
+			postStepsWrapper = map[string]any{"post-steps": frontmatter["post-steps"]}
+		}
+		if postStepsVal, hasPostSteps := postStepsWrapper["post-steps"]; hasPostSteps {
+			if postSteps, ok := postStepsVal.([]any); ok {
+				// Convert to typed steps for action pinning
+				typedPostSteps, err := SliceToSteps(postSteps)
+				if err != nil {
+					orchestratorWorkflowLog.Printf("Failed to convert post-steps to typed steps: %v", err)
+					postSteps = hardenCheckoutStepValues(postSteps)
+				} else {
+					// Apply action pinning and checkout hardening to post steps using type-safe version
+					typedPostSteps = ApplyActionPinsToTypedSteps(typedPostSteps, workflowData)
+					typedPostSteps = HardenCheckoutSteps(typedPostSteps)
+					// Convert back to []any for YAML marshaling
+					postSteps = StepsToSlice(typedPostSteps)
+				}
 
-					// Convert back to YAML with "post-steps:" wrapper
-					stepsWrapper := map[string]any{"post-steps": postSteps}
-					stepsYAML, err := yaml.Marshal(stepsWrapper)
-					if err == nil {
-						// Remove quotes from uses values with version comments
-						workflowData.PostSteps = unquoteUsesWithComments(string(stepsYAML))
-					}
+				// Convert back to YAML with "post-steps:" wrapper
+				stepsWrapper := map[string]any{"post-steps": postSteps}
+				stepsYAML, err := yaml.Marshal(stepsWrapper)
+				if err == nil {
+					// Remove quotes from uses values with version comments
+					workflowData.PostSteps = unquoteUsesWithComments(string(stepsYAML))
 				}
 			}
 		}
@@ -512,6 +550,11 @@ func (c *Compiler) extractAdditionalConfigurations(
 	}
 	workflowData.RepoMemoryConfig = repoMemoryConfig
 
+	// Persist the runtime memory key-value store with its storage backend
+	if err := applyMemoryBackend(workflowData); err != nil {
+		return err
+	}
+
 	// Extract and process safe-inputs and safe-outputs
 	workflowData.Command, workflowData.CommandEvents = c.extractCommandConfig(frontmatter)
 	workflowData.Jobs = c.extractJobsFromFrontmatter(frontmatter)
@@ -524,10 +567,79 @@ func (c *Compiler) extractAdditionalConfigurations(
 	workflowData.Roles = c.extractRoles(frontmatter)
 	workflowData.Bots = c.extractBots(frontmatter)
 	workflowData.RateLimit = c.extractRateLimitConfig(frontmatter)
+	guards, err := c.extractGuardsConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Guards = guards
+	if err := c.applyGuardsRateLimit(workflowData, frontmatter); err != nil {
+		return err
+	}
+	limits, err := c.extractLimitsConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Limits = limits
+	c.validateLimitsSupport(limits, workflowData.AI)
+	retries, err := c.extractRetriesConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Retries = retries
+	timeouts, err := c.extractTimeoutsConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Timeouts = timeouts
+	if err := validateComputedFrontmatterValues(frontmatter); err != nil {
+		return err
+	}
+	workflowData.WarmCache, _ = frontmatter["warm-cache"].(bool)
+	workflowData.DryRunInput = hasDryRunInput(frontmatter)
+	continuation, err := extractContinuationConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Continuation = continuation
+	notifications, err := extractNotificationsConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Notifications = notifications
+	resourceTelemetry, err := extractResourceTelemetry(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.ResourceTelemetry = resourceTelemetry
+	expressions, err := extractExpressionsConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Expressions = expressions
+	if engine, err := c.getAgenticEngine(workflowData.AI); err == nil {
+		contextConfig, err := c.extractContextConfig(frontmatter, engine.GetID())
+		if err != nil {
+			return err
+		}
+		workflowData.Context = contextConfig
+	}
+	githubHost, err := c.extractGitHubHostConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.GitHubHost = githubHost
+	addGitHubHostDomains(workflowData.NetworkPermissions, githubHost)
 	workflowData.SkipRoles = c.mergeSkipRoles(c.extractSkipRoles(frontmatter), importsResult.MergedSkipRoles)
 	workflowData.SkipBots = c.mergeSkipBots(c.extractSkipBots(frontmatter), importsResult.MergedSkipBots)
+	workflowData.ScheduleChecks = c.scheduleChecks
+	workflowData.WorkflowNeeds = c.workflowNeeds
 	workflowData.ActivationGitHubToken = c.extractActivationGitHubToken(frontmatter)
 	workflowData.ActivationGitHubApp = c.extractActivationGitHubApp(frontmatter)
+	auth, err := c.extractAuthConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Auth = auth
 
 	// Use the already extracted output configuration
 	workflowData.SafeOutputs = safeOutputs
@@ -642,8 +754,14 @@ func (c *Compiler) processOnSectionAndFilters(
 	// Apply pull request fork filter if specified
 	c.applyPullRequestForkFilter(workflowData, frontmatter)
 
+	// Record pull_request_target hardening options
+	c.applyPullRequestTargetHardening(workflowData, frontmatter)
+
 	// Apply label filter if specified
 	c.applyLabelFilter(workflowData, frontmatter)
 
+	// Apply workflow_run conclusion filter if specified
+	c.applyWorkflowRunConclusionFilter(workflowData, frontmatter)
+
 	return nil
 }
@@ -55,6 +55,14 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		perms.Set(PermissionActions, PermissionRead)
 	}
 
+	// Add actions: read permission if the workflow runs after another one (needed to download the upstream activation artifact)
+	if data.WorkflowNeeds != nil {
+		if perms == nil {
+			perms = NewPermissions()
+		}
+		perms.Set(PermissionActions, PermissionRead)
+	}
+
 	// Set permissions if any were configured
 	if perms != nil {
 		permissions = perms.RenderToYAML()
@@ -70,6 +78,11 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		steps = c.generateRateLimitCheck(data, steps)
 	}
 
+	// Add spam and abuse guards check if configured
+	if data.Guards.hasGuardChecks() {
+		steps = c.generateGuardsCheck(data, steps)
+	}
+
 	// Add stop-time check if configured
 	if data.StopTime != "" {
 		// Extract workflow name for the stop-time check
@@ -155,6 +168,16 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		steps = append(steps, generateGitHubScriptWithRequire("check_skip_bots.cjs"))
 	}
 
+	// Add schedule check if schedules use jitter or a timezone with daylight saving time
+	if len(data.ScheduleChecks) > 0 {
+		steps = c.generateScheduleCheckStep(data, steps)
+	}
+
+	// Add upstream workflow check if the workflow runs after another one
+	if data.WorkflowNeeds != nil {
+		steps = c.generateUpstreamWorkflowCheck(data, steps)
+	}
+
 	// Add command position check if this is a command workflow
 	if len(data.Command) > 0 {
 		steps = append(steps, "      - name: Check command position\n")
@@ -251,6 +274,26 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		conditions = append(conditions, rateLimitCheck)
 	}
 
+	if data.Guards.hasGuardChecks() {
+		// Add guards check condition
+		guardsCheckOk := BuildComparison(
+			BuildPropertyAccess(fmt.Sprintf("steps.%s.outputs.%s", constants.CheckGuardsStepID, constants.GuardsOkOutput)),
+			"==",
+			BuildStringLiteral("true"),
+		)
+		conditions = append(conditions, guardsCheckOk)
+	}
+
+	if len(data.ScheduleChecks) > 0 {
+		// Add schedule check condition
+		scheduleCheckOk := BuildComparison(
+			BuildPropertyAccess(fmt.Sprintf("steps.%s.outputs.%s", constants.CheckScheduleStepID, constants.ScheduleOkOutput)),
+			"==",
+			BuildStringLiteral("true"),
+		)
+		conditions = append(conditions, scheduleCheckOk)
+	}
+
 	if len(data.Command) > 0 {
 		// Add command position check condition
 		commandPositionCheck := BuildComparison(
@@ -261,6 +304,16 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		conditions = append(conditions, commandPositionCheck)
 	}
 
+	if data.WorkflowNeeds != nil {
+		// Add upstream workflow check condition
+		upstreamCheckOk := BuildComparison(
+			BuildPropertyAccess(fmt.Sprintf("steps.%s.outputs.%s", constants.CheckUpstreamWorkflowStepID, constants.UpstreamOkOutput)),
+			"==",
+			BuildStringLiteral("true"),
+		)
+		conditions = append(conditions, upstreamCheckOk)
+	}
+
 	// Build the final expression
 	if len(conditions) == 0 {
 		// This should never happen - it means pre-activation job was created without any checks
@@ -289,10 +342,17 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 	// For non-command workflows, emit an empty string so the output key is defined.
 	if len(data.Command) > 0 {
 		outputs[constants.MatchedCommandOutput] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", constants.CheckCommandPositionStepID, constants.MatchedCommandOutput)
+		outputs[constants.CommandArgsOutput] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", constants.CheckCommandPositionStepID, constants.CommandArgsOutput)
 	} else {
 		outputs[constants.MatchedCommandOutput] = "''"
 	}
 
+	// Expose the event of the upstream run for the prompt of workflows that declare needs
+	if data.WorkflowNeeds != nil {
+		outputs[constants.UpstreamEventOutput] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", constants.CheckUpstreamWorkflowStepID, constants.UpstreamEventOutput)
+		outputs[constants.UpstreamPayloadOutput] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", constants.CheckUpstreamWorkflowStepID, constants.UpstreamPayloadOutput)
+	}
+
 	// Merge custom outputs from jobs.pre-activation if present
 	if len(customOutputs) > 0 {
 		compilerActivationJobsLog.Printf("Adding %d custom outputs to pre-activation job", len(customOutputs))
@@ -310,7 +370,7 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 	job := &Job{
 		Name:        string(constants.PreActivationJobName),
 		If:          jobIfCondition,
-		RunsOn:      c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		RunsOn:      c.formatAuxiliaryJobRunsOn(data, constants.DefaultActivationJobRunnerImage),
 		Permissions: permissions,
 		Steps:       steps,
 		Outputs:     outputs,
@@ -149,8 +149,10 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddTemplatableBool("group", c.Group).
 			AddTemplatableBool("close_older_issues", c.CloseOlderIssues).
+			AddIfNotEmpty("dedupe_by", createIssueDedupeBy(c)).
+			AddIfPositive("dedupe_window", createIssueDedupeWindowHours(c)).
 			AddTemplatableBool("footer", getEffectiveFooterForTemplatable(c.Footer, cfg.Footer)).
-			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddIfNotEmpty("github-token", createIssueGitHubToken(c)).
 			Build()
 	},
 	"add_comment": func(cfg *SafeOutputsConfig) map[string]any {
@@ -176,6 +178,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		return newHandlerConfigBuilder().
 			AddTemplatableInt("max", c.Max).
 			AddIfNotEmpty("category", c.Category).
+			AddStringSlice("allowed_categories", c.AllowedCategories).
 			AddIfNotEmpty("title_prefix", c.TitlePrefix).
 			AddStringSlice("labels", c.Labels).
 			AddStringSlice("allowed_labels", c.AllowedLabels).
@@ -218,6 +221,23 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddStringSlice("allowed_repos", c.AllowedRepos).
 			Build()
 	},
+	"answer_discussion": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.AnswerDiscussion == nil {
+			return nil
+		}
+		c := cfg.AnswerDiscussion
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddIfNotEmpty("target", c.Target).
+			AddStringSlice("allowed_categories", c.AllowedCategories).
+			AddTemplatableBool("mark_as_answer", c.MarkAsAnswer).
+			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
+			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddTemplatableBool("footer", getEffectiveFooterForTemplatable(nil, cfg.Footer)).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddIfTrue("staged", c.Staged).
+			Build()
+	},
 	"add_labels": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.AddLabels == nil {
 			return nil
@@ -270,6 +290,22 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddIfNotEmpty("github-token", c.GitHubToken).
 			Build()
 	},
+	"request_review": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.RequestReview == nil {
+			return nil
+		}
+		c := cfg.RequestReview
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddStringSlice("allowed_users", c.AllowedUsers).
+			AddStringSlice("allowed_teams", c.AllowedTeams).
+			AddIfNotEmpty("target", c.Target).
+			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
+			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddIfTrue("staged", c.Staged).
+			Build()
+	},
 	"assign_milestone": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.AssignMilestone == nil {
 			return nil
@@ -343,6 +379,8 @@ var handlerRegistry = map[string]handlerBuilder{
 		// Body uses boolean value mode - add the actual boolean value
 		builder.AddBoolPtrOrDefault("allow_body", c.Body, true)
 		return builder.
+			AddStringSlice("allowed_fields", c.AllowedFields()).
+			AddStringSlice("allowed_labels", c.AllowedLabels).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddStringSlice("allowed_repos", c.AllowedRepos).
 			AddIfNotEmpty("github-token", c.GitHubToken).
@@ -655,6 +693,22 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddTemplatableBool("unassign_first", c.UnassignFirst).
 			Build()
 	},
+	"assign": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.Assign == nil {
+			return nil
+		}
+		c := cfg.Assign
+		return newHandlerConfigBuilder().
+			AddTemplatableInt("max", c.Max).
+			AddStringSlice("allowed_users", c.AllowedUsers).
+			AddStringSlice("allowed_teams", c.AllowedTeams).
+			AddIfNotEmpty("target", c.Target).
+			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
+			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddIfTrue("staged", c.Staged).
+			Build()
+	},
 	"unassign_from_user": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.UnassignFromUser == nil {
 			return nil
@@ -712,6 +766,9 @@ func (c *Compiler) addHandlerManagerConfigEnvVar(steps *[]string, data *Workflow
 
 	compilerSafeOutputsConfigLog.Print("Building handler manager configuration for safe-outputs")
 	config := make(map[string]map[string]any)
+	conditions := safeOutputConditions(data.SafeOutputs)
+	itemConditions := safeOutputItemConditions(data.SafeOutputs)
+	baseConfigs := safeOutputBaseConfigs(data.SafeOutputs)
 
 	// Build configuration for each handler using the registry
 	for handlerName, builder := range handlerRegistry {
@@ -721,6 +778,17 @@ func (c *Compiler) addHandlerManagerConfigEnvVar(steps *[]string, data *Workflow
 		// 2. For auto-enabled handlers, include even with empty config
 		if handlerConfig != nil {
 			compilerSafeOutputsConfigLog.Printf("Adding %s handler configuration", handlerName)
+			// The if: condition is evaluated by GitHub Actions when the env var is rendered;
+			// the handler manager skips handlers whose condition evaluated to false
+			if condition := conditions[handlerName]; condition != "" {
+				handlerConfig["if"] = "${{ " + condition + " }}"
+			}
+			// Post-run conditions are evaluated per item by the handler manager, which needs the
+			// declared fields to order enum values
+			if condition := itemConditions[handlerName]; condition != "" {
+				handlerConfig["item_condition"] = condition
+				handlerConfig["item_fields"] = baseConfigs[handlerName].Fields
+			}
 			config[handlerName] = handlerConfig
 		}
 	}
@@ -30,6 +30,10 @@ func (c *Compiler) hasCustomTokenSafeOutputs(safeOutputs *SafeOutputsConfig) boo
 	// here — that token is used as the step-level github-script token and does
 	// not require @actions/github/getOctokit(). Only per-handler tokens trigger
 	// the npm install.
+	if safeOutputs.CreateIssues != nil && safeOutputs.CreateIssues.GitHubApp != nil {
+		consolidatedSafeOutputsLog.Print("Custom token required: create-issue github-app configured")
+		return true
+	}
 	for _, base := range c.collectBaseSafeOutputConfigs(safeOutputs) {
 		if base != nil && base.GitHubToken != "" {
 			consolidatedSafeOutputsLog.Print("Custom token required: per-handler github-token configured")
@@ -58,6 +62,9 @@ func (c *Compiler) collectBaseSafeOutputConfigs(so *SafeOutputsConfig) []*BaseSa
 	if so.CloseDiscussions != nil {
 		configs = append(configs, &so.CloseDiscussions.BaseSafeOutputConfig)
 	}
+	if so.AnswerDiscussion != nil {
+		configs = append(configs, &so.AnswerDiscussion.BaseSafeOutputConfig)
+	}
 	if so.CloseIssues != nil {
 		configs = append(configs, &so.CloseIssues.BaseSafeOutputConfig)
 	}
@@ -100,6 +107,9 @@ func (c *Compiler) collectBaseSafeOutputConfigs(so *SafeOutputsConfig) []*BaseSa
 	if so.AddReviewer != nil {
 		configs = append(configs, &so.AddReviewer.BaseSafeOutputConfig)
 	}
+	if so.RequestReview != nil {
+		configs = append(configs, &so.RequestReview.BaseSafeOutputConfig)
+	}
 	if so.AssignMilestone != nil {
 		configs = append(configs, &so.AssignMilestone.BaseSafeOutputConfig)
 	}
@@ -109,6 +119,9 @@ func (c *Compiler) collectBaseSafeOutputConfigs(so *SafeOutputsConfig) []*BaseSa
 	if so.AssignToUser != nil {
 		configs = append(configs, &so.AssignToUser.BaseSafeOutputConfig)
 	}
+	if so.Assign != nil {
+		configs = append(configs, &so.Assign.BaseSafeOutputConfig)
+	}
 	if so.UnassignFromUser != nil {
 		configs = append(configs, &so.UnassignFromUser.BaseSafeOutputConfig)
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
 )
 
 var consolidatedSafeOutputsJobLog = logger.New("workflow:compiler_safe_outputs_job")
@@ -84,7 +85,7 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to convert safe-outputs step at index %d to typed step: %w", i, err)
 			}
-			pinnedStep := ApplyActionPinToTypedStep(typedStep, data)
+			pinnedStep := HardenCheckoutStep(ApplyActionPinToTypedStep(typedStep, data))
 			stepYAML, err := c.convertStepToYAML(pinnedStep.ToMap())
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to convert safe-outputs step at index %d to YAML: %w", i, err)
@@ -114,6 +115,7 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		data.SafeOutputs.CreateDiscussions != nil ||
 		data.SafeOutputs.CloseIssues != nil ||
 		data.SafeOutputs.CloseDiscussions != nil ||
+		data.SafeOutputs.AnswerDiscussion != nil ||
 		data.SafeOutputs.AddLabels != nil ||
 		data.SafeOutputs.RemoveLabels != nil ||
 		data.SafeOutputs.UpdateIssues != nil ||
@@ -130,6 +132,8 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		data.SafeOutputs.ClosePullRequests != nil ||
 		data.SafeOutputs.MarkPullRequestAsReadyForReview != nil ||
 		data.SafeOutputs.HideComment != nil ||
+		data.SafeOutputs.RequestReview != nil ||
+		data.SafeOutputs.Assign != nil ||
 		data.SafeOutputs.SetIssueType != nil ||
 		data.SafeOutputs.DispatchWorkflow != nil ||
 		data.SafeOutputs.CreateCodeScanningAlerts != nil ||
@@ -143,8 +147,14 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	// 1. Handler Manager step (processes create_issue, update_issue, add_comment, etc.)
 	// This processes all safe output types that are handled by the unified handler
 	// Critical for workflows that create projects and then add issues/PRs to those projects
+	// Mint the token scoped to the create-issue target repository before any step that uses it
+	if data.SafeOutputs.CreateIssues != nil && data.SafeOutputs.CreateIssues.GitHubApp != nil {
+		steps = append(steps, buildCreateIssueAppTokenSteps(data.SafeOutputs.CreateIssues)...)
+	}
+
 	if hasHandlerManagerTypes {
 		consolidatedSafeOutputsJobLog.Print("Using handler manager for safe outputs")
+		steps = append(steps, buildSanitizeScriptStep(data.SafeOutputs)...)
 		handlerManagerSteps := c.buildHandlerManagerStep(data)
 		steps = append(steps, handlerManagerSteps...)
 		safeOutputStepNames = append(safeOutputStepNames, "process_safe_outputs")
@@ -171,10 +181,19 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 			steps = append(steps, "        env:\n")
 			steps = append(steps, "          GH_AW_ISSUES_TO_ASSIGN_COPILOT: ${{ steps.process_safe_outputs.outputs.issues_to_assign_copilot }}\n")
 			steps = append(steps, "        with:\n")
+			// The create-issue app token only grants issues access, so assignment keeps the agent token
 			c.addSafeOutputAgentGitHubTokenForConfig(&steps, data, data.SafeOutputs.CreateIssues.GitHubToken)
 			steps = append(steps, "          script: |\n")
 			steps = append(steps, generateGitHubScriptWithRequire("assign_copilot_to_created_issues.cjs"))
 		}
+
+		// Upload the SARIF file written by the create_code_scanning_alert handler. upload-sarif
+		// only targets the current repository, so cross-repository alerts are not uploaded.
+		if data.SafeOutputs.CreateCodeScanningAlerts != nil && data.SafeOutputs.CreateCodeScanningAlerts.TargetRepoSlug == "" {
+			consolidatedSafeOutputsJobLog.Print("Adding SARIF upload step for code scanning alerts")
+			steps = append(steps, buildCodeScanningSarifUploadSteps(data.SafeOutputs.CreateCodeScanningAlerts, data.WorkflowID)...)
+			outputs["code_scanning_sarif_id"] = fmt.Sprintf("${{ steps.%s.outputs.sarif-id }}", codeScanningSarifUploadStepID)
+		}
 	}
 
 	// 3. Assign To Agent step (runs after handler managers)
@@ -245,6 +264,10 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	// Add GitHub App token minting step at the beginning if app is configured
 	if data.SafeOutputs.GitHubApp != nil {
 		appTokenSteps := c.buildGitHubAppTokenMintStep(data.SafeOutputs.GitHubApp, permissions)
+		// Team assignments list team members, an organization permission not covered by the job permissions
+		if assignNeedsTeamMembers(data.SafeOutputs) {
+			appTokenSteps = append(appTokenSteps, "          permission-members: read\n")
+		}
 		// Calculate insertion index: after setup action (if present) and artifact downloads, but before checkout and safe output steps
 		insertIndex := 0
 
@@ -285,7 +308,10 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 
 	// Add GitHub App token invalidation step at the end if app is configured
 	if data.SafeOutputs.GitHubApp != nil {
-		steps = append(steps, c.buildGitHubAppTokenInvalidationStep()...)
+		steps = append(steps, c.buildGitHubAppTokenInvalidationStep("safe-outputs-app-token", "Invalidate GitHub App token")...)
+	}
+	if data.SafeOutputs.CreateIssues != nil && data.SafeOutputs.CreateIssues.GitHubApp != nil {
+		steps = append(steps, c.buildCreateIssueAppTokenInvalidationStep()...)
 	}
 
 	// Upload the safe output items manifest as an artifact (non-staged mode only).
@@ -341,10 +367,11 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	job := &Job{
 		Name:           "safe_outputs",
 		If:             jobCondition.Render(),
-		RunsOn:         c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		RunsOn:         c.formatAuxiliaryJobRunsOn(data, constants.DefaultActivationJobRunnerImage),
 		Permissions:    permissions.RenderToYAML(),
-		TimeoutMinutes: 15, // Slightly longer timeout for consolidated job with multiple steps
+		TimeoutMinutes: safeOutputsJobTimeoutMinutes, // Slightly longer timeout for consolidated job with multiple steps
 		Concurrency:    concurrency,
+		Environment:    safeOutputsEnvironment(data),
 		Env:            jobEnv,
 		Steps:          steps,
 		Outputs:        outputs,
@@ -356,6 +383,15 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	return job, safeOutputStepNames, nil
 }
 
+// safeOutputsEnvironment returns the environment line for jobs that apply agent output.
+// Only these write jobs run in the configured environment; the read-only agent job stays unattended.
+func safeOutputsEnvironment(data *WorkflowData) string {
+	if data.SafeOutputs == nil || data.SafeOutputs.Environment == "" {
+		return ""
+	}
+	return "environment: " + stringutil.StripANSI(data.SafeOutputs.Environment)
+}
+
 // buildJobLevelSafeOutputEnvVars builds environment variables that should be set at the job level
 // for the consolidated safe_outputs job. These are variables that are common to all safe output steps.
 func (c *Compiler) buildJobLevelSafeOutputEnvVars(data *WorkflowData, workflowID string) map[string]string {
@@ -404,6 +440,8 @@ func (c *Compiler) buildJobLevelSafeOutputEnvVars(data *WorkflowData, workflowID
 	// Add safe output job environment variables (staged/target repo)
 	if data.SafeOutputs != nil && (c.trialMode || data.SafeOutputs.Staged) {
 		envVars["GH_AW_SAFE_OUTPUTS_STAGED"] = "\"true\""
+	} else if data.SafeOutputs != nil && data.DryRunInput {
+		envVars["GH_AW_SAFE_OUTPUTS_STAGED"] = dryRunStagedExpression
 	}
 
 	// Set GH_AW_TARGET_REPO_SLUG - prefer trial target repo (applies to all steps)
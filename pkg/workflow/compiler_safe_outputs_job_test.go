@@ -3,10 +3,14 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -214,6 +218,89 @@ func TestBuildConsolidatedSafeOutputsJobConcurrencyGroup(t *testing.T) {
 	}
 }
 
+// TestBuildConsolidatedSafeOutputsJobEnvironment tests that the environment field
+// is applied to the safe_outputs job
+func TestBuildConsolidatedSafeOutputsJobEnvironment(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		expected    string
+	}{
+		{
+			name:        "no environment",
+			environment: "",
+			expected:    "",
+		},
+		{
+			name:        "approval environment",
+			environment: "production-approvals",
+			expected:    "environment: production-approvals",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			compiler.jobManager = NewJobManager()
+
+			workflowData := &WorkflowData{
+				Name: "Test Workflow",
+				SafeOutputs: &SafeOutputsConfig{
+					CreateIssues: &CreateIssuesConfig{TitlePrefix: "[Test] "},
+					Environment:  tt.environment,
+				},
+			}
+
+			job, _, err := compiler.buildConsolidatedSafeOutputsJob(workflowData, string(constants.AgentJobName), "test-workflow.md")
+			require.NoError(t, err, "Should build job without error")
+			require.NotNil(t, job, "Job should not be nil")
+			assert.Equal(t, tt.expected, job.Environment, "Job environment should match")
+		})
+	}
+}
+
+// TestSafeOutputsEnvironmentCompiled tests that only write jobs run in the safe-outputs environment
+func TestSafeOutputsEnvironmentCompiled(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-outputs-environment-*")
+	workflowsDir := filepath.Join(tmpDir, constants.GetWorkflowDir())
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows directory")
+
+	content := `---
+on: workflow_dispatch
+engine: claude
+safe-outputs:
+  environment: production-approvals
+  create-issue:
+  jobs:
+    notify:
+      steps:
+        - run: echo notify
+---
+
+# Safe outputs environment test
+`
+	workflowFile := filepath.Join(workflowsDir, "environment.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "Workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "environment.lock.yml"))
+	require.NoError(t, err, "Lock file should be created")
+
+	var lock struct {
+		Jobs map[string]struct {
+			Environment string `yaml:"environment"`
+		} `yaml:"jobs"`
+	}
+	require.NoError(t, yaml.Unmarshal(lockContent, &lock), "Lock file should be valid YAML")
+
+	assert.Equal(t, "production-approvals", lock.Jobs["safe_outputs"].Environment, "safe_outputs job should run in the environment")
+	assert.Equal(t, "production-approvals", lock.Jobs["notify"].Environment, "Custom safe-jobs should run in the environment")
+	assert.Empty(t, lock.Jobs["agent"].Environment, "Agent job should stay unattended")
+	assert.Empty(t, lock.Jobs["activation"].Environment, "Activation job should stay unattended")
+}
+
 func TestBuildJobLevelSafeOutputEnvVars(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -99,7 +99,7 @@ func (c *Compiler) buildAssignToAgentStepConfig(data *WorkflowData, mainJobName
 		customEnvVars = append(customEnvVars, "          GH_AW_TEMPORARY_ID_MAP: ${{ steps.process_safe_outputs.outputs.temporary_id_map }}\n")
 	}
 
-	condition := BuildSafeOutputType("assign_to_agent")
+	condition := withSafeOutputCondition(BuildSafeOutputType("assign_to_agent"), cfg.If)
 
 	return SafeOutputStepConfig{
 		StepName:                   "Assign to agent",
@@ -122,7 +122,7 @@ func (c *Compiler) buildCreateAgentSessionStepConfig(data *WorkflowData, mainJob
 	customEnvVars = append(customEnvVars, c.buildStepLevelSafeOutputEnvVars(data, cfg.TargetRepoSlug)...)
 	customEnvVars = append(customEnvVars, buildAllowedReposEnvVar("GH_AW_ALLOWED_REPOS", cfg.AllowedRepos)...)
 
-	condition := BuildSafeOutputType("create_agent_session")
+	condition := withSafeOutputCondition(BuildSafeOutputType("create_agent_session"), cfg.If)
 
 	return SafeOutputStepConfig{
 		StepName:                "Create Agent Session",
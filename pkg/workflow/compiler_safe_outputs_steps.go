@@ -2,7 +2,6 @@ package workflow
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -289,6 +288,9 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) []string {
 	steps = append(steps, "      - name: Process Safe Outputs\n")
 	steps = append(steps, "        id: process_safe_outputs\n")
 	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/github-script")))
+	if data.Timeouts != nil && data.Timeouts.SafeOutputs > 0 {
+		steps = append(steps, fmt.Sprintf("        timeout-minutes: %d\n", data.Timeouts.SafeOutputs))
+	}
 
 	// Environment variables
 	steps = append(steps, "        env:\n")
@@ -297,15 +299,11 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) []string {
 	// Add allowed domains configuration for URL sanitization in safe output handlers.
 	// Without this, sanitizeContent() in safe_output_handler_manager.cjs only allows
 	// default GitHub domains, causing user-configured allowed domains to be redacted.
-	var domainsStr string
-	if data.SafeOutputs != nil && len(data.SafeOutputs.AllowedDomains) > 0 {
-		domainsStr = strings.Join(data.SafeOutputs.AllowedDomains, ",")
-	} else {
-		domainsStr = c.computeAllowedDomainsForSanitization(data)
-	}
-	if domainsStr != "" {
+	if domainsStr := c.computeSanitizerAllowedDomains(data); domainsStr != "" {
 		steps = append(steps, fmt.Sprintf("          GH_AW_ALLOWED_DOMAINS: %q\n", domainsStr))
 	}
+	// Pass safe-outputs.sanitize options so handlers sanitize with the same rules as ingestion
+	steps = append(steps, buildSanitizeEnvLines(data.SafeOutputs)...)
 	// Pass GitHub server/API URLs so buildAllowedDomains() can add GHES domains dynamically
 	steps = append(steps, "          GITHUB_SERVER_URL: ${{ github.server_url }}\n")
 	steps = append(steps, "          GITHUB_API_URL: ${{ github.api_url }}\n")
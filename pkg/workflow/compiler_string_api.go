@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/github/gh-aw/pkg/parser"
-	"github.com/github/gh-aw/pkg/stringutil"
 )
 
 // CompileToYAML compiles workflow data and returns the YAML as a string
@@ -32,7 +31,7 @@ func (c *Compiler) CompileToYAML(workflowData *WorkflowData, markdownPath string
 		c.artifactManager.Reset()
 	}
 
-	lockFile := stringutil.MarkdownToLockFile(markdownPath)
+	lockFile := c.LockFilePath(markdownPath)
 
 	if err := c.validateWorkflowData(workflowData, markdownPath); err != nil {
 		return "", err
@@ -81,6 +80,20 @@ func (c *Compiler) ParseWorkflowString(content string, virtualPath string) (*Wor
 		return nil, err
 	}
 
+	// Expand the permission profile into permissions, tools, and safe outputs
+	if err := c.applyPermissionProfile(result.Frontmatter, cleanPath); err != nil {
+		return nil, err
+	}
+
+	// Replace the item triggers with a workflow_run trigger on the upstream workflow
+	if err := c.applyWorkflowNeeds(result.Frontmatter, cleanPath); err != nil {
+		return nil, err
+	}
+
+	// Add the continuation and dry_run dispatch inputs
+	addContinuationInputs(result.Frontmatter)
+	addDryRunInput(result.Frontmatter)
+
 	frontmatterForValidation := c.copyFrontmatterWithoutInternalMarkers(result.Frontmatter)
 
 	// Check if shared workflow (no 'on' field)
@@ -161,8 +174,25 @@ func (c *Compiler) ParseWorkflowString(content string, virtualPath string) (*Wor
 	// Process and merge custom steps
 	c.processAndMergeSteps(parseResult.frontmatterResult.Frontmatter, workflowData, engineSetup.importsResult)
 
-	// Apply defaults
-	if err := c.applyDefaults(workflowData, cleanPath); err != nil {
+	// Process and merge post-steps and services
+	c.processAndMergePostSteps(parseResult.frontmatterResult.Frontmatter, workflowData)
+	c.processAndMergeServices(parseResult.frontmatterResult.Frontmatter, workflowData, engineSetup.importsResult)
+
+	// Extract additional configurations (cache, safe-inputs, safe-outputs, etc.)
+	if err := c.extractAdditionalConfigurations(
+		parseResult.frontmatterResult.Frontmatter,
+		toolsResult.tools,
+		parseResult.markdownDir,
+		workflowData,
+		engineSetup.importsResult,
+		parseResult.frontmatterResult.Markdown,
+		toolsResult.safeOutputs,
+	); err != nil {
+		return nil, err
+	}
+
+	// Process on section configuration, apply defaults, and apply filters
+	if err := c.processOnSectionAndFilters(parseResult.frontmatterResult.Frontmatter, workflowData, cleanPath); err != nil {
 		return nil, err
 	}
 
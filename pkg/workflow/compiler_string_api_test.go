@@ -295,3 +295,35 @@ Handle issue ${{ github.event.issue.number }} in repo ${{ github.repository }}.
 	assert.Contains(t, yamlOutput, "Handle issue", "compiled YAML should contain the prompt text")
 	assert.NotContains(t, yamlOutput, "{{#runtime-import", "compiled YAML from string API should not contain runtime-import macros")
 }
+
+func TestCompileToYAML_SafeOutputsAndRoles(t *testing.T) {
+	// The string API must run the same configuration extraction as file-based compilation
+	markdown := `---
+on:
+  issues:
+    types: [opened]
+engine: copilot
+safe-outputs:
+  add-comment:
+---
+
+# Mission
+
+Comment on the issue.
+`
+
+	compiler := NewCompiler(
+		WithNoEmit(true),
+		WithSkipValidation(true),
+	)
+
+	wd, err := compiler.ParseWorkflowString(markdown, "workflow.md")
+	require.NoError(t, err)
+	require.NotNil(t, wd.SafeOutputs, "safe outputs should be extracted")
+	assert.NotNil(t, wd.SafeOutputs.AddComments, "add-comment should be configured")
+
+	yaml, err := compiler.CompileToYAML(wd, "workflow.md")
+	require.NoError(t, err)
+	assert.Contains(t, yaml, "safe_outputs:", "compiled YAML should include the safe outputs job")
+	assert.Contains(t, yaml, "GH_AW_REQUIRED_ROLES: admin,maintainer,write", "role check should use the default roles")
+}
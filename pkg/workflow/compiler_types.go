@@ -22,6 +22,21 @@ func WithEngineOverride(engine string) CompilerOption {
 	return func(c *Compiler) { c.engineOverride = engine }
 }
 
+// WithGitHubHost sets the GitHub Enterprise host override (takes precedence over github-host: in frontmatter)
+func WithGitHubHost(host string) CompilerOption {
+	return func(c *Compiler) { c.githubHost = host }
+}
+
+// WithRepoConfig sets the repository-level defaults loaded from .aw/config.yml
+func WithRepoConfig(config *RepoConfig) CompilerOption {
+	return func(c *Compiler) { c.repoConfig = config }
+}
+
+// WithOrgPolicy sets the organization policy every compiled workflow must satisfy
+func WithOrgPolicy(policy *OrgPolicy) CompilerOption {
+	return func(c *Compiler) { c.orgPolicy = policy }
+}
+
 // WithCustomOutput sets a custom output path for the compiled workflow
 func WithCustomOutput(path string) CompilerOption {
 	return func(c *Compiler) { c.customOutput = path }
@@ -82,38 +97,53 @@ type Compiler struct {
 	verbose                 bool
 	quiet                   bool // If true, suppress success messages (for interactive mode)
 	engineOverride          string
-	customOutput            string              // If set, output will be written to this path instead of default location
-	version                 string              // Version of the extension
-	skipValidation          bool                // If true, skip schema validation
-	noEmit                  bool                // If true, validate without generating lock files
-	strictMode              bool                // If true, enforce strict validation requirements
-	trialMode               bool                // If true, suppress safe outputs for trial mode execution
-	trialLogicalRepoSlug    string              // If set in trial mode, the logical repository to checkout
-	refreshStopTime         bool                // If true, regenerate stop-after times instead of preserving existing ones
-	forceRefreshActionPins  bool                // If true, clear action cache and resolve all actions from GitHub API
-	failFast                bool                // If true, stop at first validation error instead of collecting all errors
-	actionCacheCleared      bool                // Tracks if action cache has already been cleared (for forceRefreshActionPins)
-	markdownPath            string              // Path to the markdown file being compiled (for context in dynamic tool generation)
-	actionMode              ActionMode          // Mode for generating JavaScript steps (inline vs custom actions)
-	actionTag               string              // Override action SHA or tag for actions/setup (when set, overrides actionMode to release)
-	jobManager              *JobManager         // Manages jobs and dependencies
-	engineRegistry          *EngineRegistry     // Registry of available agentic engines
-	fileTracker             FileTracker         // Optional file tracker for tracking created files
-	warningCount            int                 // Number of warnings encountered during compilation
-	stepOrderTracker        *StepOrderTracker   // Tracks step ordering for validation
-	actionCache             *ActionCache        // Shared cache for action pin resolutions across all workflows
-	actionResolver          *ActionResolver     // Shared resolver for action pins across all workflows
-	actionPinWarnings       map[string]bool     // Shared cache of already-warned action pin failures (key: "repo@version")
-	importCache             *parser.ImportCache // Shared cache for imported workflow files
-	workflowIdentifier      string              // Identifier for the current workflow being compiled (for schedule scattering)
-	scheduleWarnings        []string            // Accumulated schedule warnings for this compiler instance
-	repositorySlug          string              // Repository slug (owner/repo) used as seed for scattering
-	artifactManager         *ArtifactManager    // Tracks artifact uploads/downloads for validation
-	scheduleFriendlyFormats map[int]string      // Maps schedule item index to friendly format string for current workflow
-	gitRoot                 string              // Git repository root directory (if set, used for action cache path)
-	contentOverride         string              // If set, use this content instead of reading from disk (for Wasm/in-memory compilation)
-	skipHeader              bool                // If true, skip ASCII art header in generated YAML (for Wasm/editor mode)
-	inlinePrompt            bool                // If true, inline markdown content in YAML instead of using runtime-import macros (for Wasm builds)
+	githubHost              string                 // If set, overrides github-host: in frontmatter
+	customOutput            string                 // If set, output will be written to this path instead of default location
+	outputDir               string                 // If set, lock files are written to this directory instead of next to the markdown source
+	lockFileNamePattern     string                 // If set, maps the markdown file name to the lock file name (see LockFileNamePlaceholder)
+	version                 string                 // Version of the extension
+	skipValidation          bool                   // If true, skip schema validation
+	noEmit                  bool                   // If true, validate without generating lock files
+	strictMode              bool                   // If true, enforce strict validation requirements
+	trialMode               bool                   // If true, suppress safe outputs for trial mode execution
+	trialLogicalRepoSlug    string                 // If set in trial mode, the logical repository to checkout
+	refreshStopTime         bool                   // If true, regenerate stop-after times instead of preserving existing ones
+	forceRefreshActionPins  bool                   // If true, clear action cache and resolve all actions from GitHub API
+	failFast                bool                   // If true, stop at first validation error instead of collecting all errors
+	actionCacheCleared      bool                   // Tracks if action cache has already been cleared (for forceRefreshActionPins)
+	markdownPath            string                 // Path to the markdown file being compiled (for context in dynamic tool generation)
+	actionMode              ActionMode             // Mode for generating JavaScript steps (inline vs custom actions)
+	actionTag               string                 // Override action SHA or tag for actions/setup (when set, overrides actionMode to release)
+	jobManager              *JobManager            // Manages jobs and dependencies
+	engineRegistry          *EngineRegistry        // Registry of available agentic engines
+	fileTracker             FileTracker            // Optional file tracker for tracking created files
+	warningCount            int                    // Number of warnings encountered during compilation
+	stepOrderTracker        *StepOrderTracker      // Tracks step ordering for validation
+	actionCache             *ActionCache           // Shared cache for action pin resolutions across all workflows
+	actionResolver          *ActionResolver        // Shared resolver for action pins across all workflows
+	actionPinWarnings       map[string]bool        // Shared cache of already-warned action pin failures (key: "repo@version")
+	importCache             *parser.ImportCache    // Shared cache for imported workflow files
+	workflowIdentifier      string                 // Identifier for the current workflow being compiled (for schedule scattering)
+	scheduleWarnings        []string               // Accumulated schedule warnings for this compiler instance
+	profileExpansions       []ProfileExpansion     // Permission profile expansions recorded for compile --explain-profile
+	strictRules             map[string]StrictLevel // strict-rules overrides for the current workflow
+	strictReports           []StrictReport         // Strict rule levels and findings recorded for compile --explain-strict
+	repositorySlug          string                 // Repository slug (owner/repo) used as seed for scattering
+	artifactManager         *ArtifactManager       // Tracks artifact uploads/downloads for validation
+	scheduleFriendlyFormats map[int]string         // Maps schedule item index to friendly format string for current workflow
+	scheduleChecks          ScheduleChecks         // Runtime schedule checks (timezone guard, jitter) keyed by compiled cron for current workflow
+	workflowNeeds           *WorkflowNeedsConfig   // Upstream agentic workflow resolved from needs: for current workflow
+	gitRoot                 string                 // Git repository root directory (if set, used for action cache path)
+	contentOverride         string                 // If set, use this content instead of reading from disk (for Wasm/in-memory compilation)
+	skipHeader              bool                   // If true, skip ASCII art header in generated YAML (for Wasm/editor mode)
+	inlinePrompt            bool                   // If true, inline markdown content in YAML instead of using runtime-import macros (for Wasm builds)
+	repoConfig              *RepoConfig            // Repository-level defaults from .aw/config.yml (nil when not loaded)
+	orgPolicy               *OrgPolicy             // Organization policy every workflow must satisfy (nil when none applies)
+	splitScripts            bool                   // If true, factor generated helper files out into .github/aw/scripts/
+	splitScriptFiles        map[string]string      // Split files for the current workflow (versioned file name -> content)
+	splitScriptsStaged      bool                   // True while generating a job that staged the split files
+	writeExpressionMap      bool                   // If true, write the expression mapping table next to each lock file
+	expressionMap           *ExpressionMap         // Expression mapping table of the current workflow (set when writeExpressionMap is on)
 }
 
 // NewCompiler creates a new workflow compiler with functional options.
@@ -352,20 +382,21 @@ type SkipIfNoMatchConfig struct {
 // WorkflowData holds all the data needed to generate a GitHub Actions workflow
 type WorkflowData struct {
 	Name                  string
-	WorkflowID            string         // workflow identifier derived from markdown filename (basename without extension)
-	TrialMode             bool           // whether the workflow is running in trial mode
-	TrialLogicalRepo      string         // target repository slug for trial mode (owner/repo)
-	FrontmatterName       string         // name field from frontmatter (for code scanning alert driver default)
-	FrontmatterYAML       string         // raw frontmatter YAML content (rendered as comment in lock file for reference)
-	Description           string         // optional description rendered as comment in lock file
-	Source                string         // optional source field (owner/repo@ref/path) rendered as comment in lock file
-	TrackerID             string         // optional tracker identifier for created assets (min 8 chars, alphanumeric + hyphens/underscores)
-	ImportedFiles         []string       // list of files imported via imports field (rendered as comment in lock file)
-	ImportedMarkdown      string         // Only imports WITH inputs (for compile-time substitution)
-	ImportPaths           []string       // Import file paths for runtime-import macro generation (imports without inputs)
-	MainWorkflowMarkdown  string         // main workflow markdown without imports (for runtime-import)
-	IncludedFiles         []string       // list of files included via @include directives (rendered as comment in lock file)
-	ImportInputs          map[string]any // input values from imports with inputs (for github.aw.inputs.* substitution)
+	WorkflowID            string              // workflow identifier derived from markdown filename (basename without extension)
+	TrialMode             bool                // whether the workflow is running in trial mode
+	TrialLogicalRepo      string              // target repository slug for trial mode (owner/repo)
+	FrontmatterName       string              // name field from frontmatter (for code scanning alert driver default)
+	FrontmatterYAML       string              // raw frontmatter YAML content (rendered as comment in lock file for reference)
+	Description           string              // optional description rendered as comment in lock file
+	Source                string              // optional source field (owner/repo@ref/path) rendered as comment in lock file
+	TrackerID             string              // optional tracker identifier for created assets (min 8 chars, alphanumeric + hyphens/underscores)
+	ImportedFiles         []string            // list of files imported via imports field (rendered as comment in lock file)
+	ImportedMarkdown      string              // Only imports WITH inputs (for compile-time substitution)
+	ImportPaths           []string            // Import file paths for runtime-import macro generation (imports without inputs)
+	MainWorkflowMarkdown  string              // main workflow markdown without imports (for runtime-import)
+	IncludedFiles         []string            // list of files included via @include directives (rendered as comment in lock file)
+	Provenance            *ProvenanceManifest // compile-time provenance embedded in the lock file and uploaded as an artifact
+	ImportInputs          map[string]any      // input values from imports with inputs (for github.aw.inputs.* substitution)
 	On                    string
 	Permissions           string
 	Network               string // top-level network permissions configuration
@@ -377,9 +408,11 @@ type WorkflowData struct {
 	CustomSteps           string
 	PostSteps             string // steps to run after AI execution
 	RunsOn                string
-	Environment           string // environment setting for the main job
-	Container             string // container setting for the main job
-	Services              string // services setting for the main job
+	SelfHostedRunner      bool     // true when runs-on targets self-hosted runners (self-hosted label or runner group)
+	RunnerOS              RunnerOS // operating system of the agent job runner, derived from runs-on
+	Environment           string   // environment setting for the main job
+	Container             string   // container setting for the main job
+	Services              string   // services setting for the main job
 	Tools                 map[string]any
 	ParsedTools           *Tools // Structured tools configuration (NEW: parsed from Tools map)
 	MarkdownContent       string
@@ -393,6 +426,7 @@ type WorkflowData struct {
 	SkipIfNoMatch         *SkipIfNoMatchConfig // skip-if-no-match configuration with query and min threshold
 	SkipRoles             []string             // roles to skip workflow for (e.g., [admin, maintainer, write])
 	SkipBots              []string             // users to skip workflow for (e.g., [user1, user2])
+	ScheduleChecks        ScheduleChecks       // runtime schedule checks keyed by compiled cron (from on.schedule timezone/jitter)
 	ManualApproval        string               // environment name for manual approval from on: section
 	Command               []string             // for /command trigger support - multiple command names
 	CommandEvents         []string             // events where command should be active (nil = all events)
@@ -401,10 +435,13 @@ type WorkflowData struct {
 	StatusComment         *bool                // whether to post status comments (default: true when ai-reaction is set, false otherwise)
 	ActivationGitHubToken string               // custom github token from on.github-token for reactions/comments
 	ActivationGitHubApp   *GitHubAppConfig     // github app config from on.github-app for minting activation tokens
+	Auth                  *AuthConfig          // workflow-wide GitHub App from auth: used where no github-token or github-app is configured
 	LockForAgent          bool                 // whether to lock the issue during agent workflow execution
 	Jobs                  map[string]any       // custom job configurations with dependencies
 	Cache                 string               // cache configuration
+	Artifacts             *ArtifactsConfig     // per-workflow artifact retention and naming (from artifacts frontmatter field)
 	NeedsTextOutput       bool                 // whether the workflow uses ${{ needs.task.outputs.text }}
+	NeedsPRContext        bool                 // whether the workflow uses ${{ steps.pr-context.outputs.* }} for a triggering pull request
 	NetworkPermissions    *NetworkPermissions  // parsed network permissions
 	SandboxConfig         *SandboxConfig       // parsed sandbox configuration (AWF or SRT)
 	SafeOutputs           *SafeOutputsConfig   // output configuration for automatic output routes
@@ -412,8 +449,22 @@ type WorkflowData struct {
 	Roles                 []string             // permission levels required to trigger workflow
 	Bots                  []string             // allow list of bot identifiers that can trigger workflow
 	RateLimit             *RateLimitConfig     // rate limiting configuration for workflow triggers
+	Guards                *GuardsConfig        // spam and abuse guards for issue and comment triggers
+	Limits                *LimitsConfig        // per-run token and cost budget for the agent
+	Retries               *RetriesConfig       // retry policy for the agent execution step
+	Timeouts              *TimeoutsConfig      // per-phase timeouts (agent step, safe outputs step, activation job)
+	DryRunInput           bool                 // whether workflow_dispatch has a dry_run input that runs the safe output jobs in staged mode
+	WarmCache             bool                 // whether the nightly warm cache workflow pre-populates the caches of this workflow (warm-cache: true)
+	Context               *ContextConfig       // runtime prompt truncation strategy
+	GitHubHost            *GitHubHostConfig    // GitHub Enterprise host the workflow targets (nil for github.com)
 	CacheMemoryConfig     *CacheMemoryConfig   // parsed cache-memory configuration
 	RepoMemoryConfig      *RepoMemoryConfig    // parsed repo-memory configuration
+	MemoryConfig          *MemoryConfig        // runtime memory key-value store (from memory frontmatter field)
+	Continuation          *ContinuationConfig  // automatic continuation of long-running tasks (from continuation frontmatter field)
+	Notifications         *NotificationsConfig // run summary webhook (from notifications frontmatter field)
+	ResourceTelemetry     *ResourceTelemetry   // runner resource sampling for the agent job (from resource-telemetry frontmatter field)
+	Expressions           *ExpressionsConfig   // markdown expression translation settings (from expressions frontmatter field)
+	WorkflowNeeds         *WorkflowNeedsConfig // upstream agentic workflow this workflow runs after (from needs frontmatter field)
 	Runtimes              map[string]any       // runtime version overrides from frontmatter
 	PluginInfo            *PluginInfo          // Consolidated plugin information (plugins, custom token, MCP configs)
 	APMDependencies       *APMDependenciesInfo // APM (Agent Package Manager) dependency packages to install
@@ -423,6 +474,7 @@ type WorkflowData struct {
 	ActionCache           *ActionCache         // cache for action pin resolutions
 	ActionResolver        *ActionResolver      // resolver for action pins
 	StrictMode            bool                 // strict mode for action pinning
+	NoActionPinWarnings   bool                 // pinning: off resolves semver-compatible pins without warnings
 	SecretMasking         *SecretMaskingConfig // secret masking configuration
 	ParsedFrontmatter     *FrontmatterConfig   // cached parsed frontmatter configuration (for performance optimization)
 	RawFrontmatter        map[string]any       // raw parsed frontmatter map (for passing to hash functions without re-parsing)
@@ -432,13 +484,18 @@ type WorkflowData struct {
 	InlinedImports        bool                 // if true, inline all imports at compile time (from inlined-imports frontmatter field)
 	CheckoutConfigs       []*CheckoutConfig    // user-configured checkout settings from frontmatter
 	HasDispatchItemNumber bool                 // true when workflow_dispatch has item_number input (generated by label trigger shorthand)
+	HasReplayInputs       bool                 // true when workflow_dispatch has the replay inputs added by on.replay
+	HasPullRequestTarget  bool                 // true when the workflow triggers on pull_request_target
+	PRTargetCheckoutHead  bool                 // true when on.pull_request_target.checkout-head opts into the quarantined head checkout
 }
 
 // BaseSafeOutputConfig holds common configuration fields for all safe output types
 type BaseSafeOutputConfig struct {
-	Max         *string `yaml:"max,omitempty"`          // Maximum number of items to create (supports integer or GitHub Actions expression)
-	GitHubToken string  `yaml:"github-token,omitempty"` // GitHub token for this specific output type
-	Staged      bool    `yaml:"staged,omitempty"`       // If true, emit step summary messages instead of making GitHub API calls for this specific output type
+	Max         *string                     `yaml:"max,omitempty"`          // Maximum number of items to create (supports integer or GitHub Actions expression)
+	GitHubToken string                      `yaml:"github-token,omitempty"` // GitHub token for this specific output type
+	Staged      bool                        `yaml:"staged,omitempty"`       // If true, emit step summary messages instead of making GitHub API calls for this specific output type
+	If          string                      `yaml:"if,omitempty"`           // GitHub Actions expression, or a post-run condition on item fields; items of this type are skipped when it evaluates to false
+	Fields      map[string]*SafeOutputField `yaml:"fields,omitempty"`       // Typed fields the agent sets on items, referenced by post-run if: conditions as item.<name>
 }
 
 // SafeOutputsConfig holds configuration for automatic output routes
@@ -447,6 +504,7 @@ type SafeOutputsConfig struct {
 	CreateDiscussions               *CreateDiscussionsConfig               `yaml:"create-discussion,omitempty"`
 	UpdateDiscussions               *UpdateDiscussionsConfig               `yaml:"update-discussion,omitempty"`
 	CloseDiscussions                *CloseDiscussionsConfig                `yaml:"close-discussion,omitempty"`
+	AnswerDiscussion                *AnswerDiscussionConfig                `yaml:"answer-discussion,omitempty"`
 	CloseIssues                     *CloseIssuesConfig                     `yaml:"close-issue,omitempty"`
 	ClosePullRequests               *ClosePullRequestsConfig               `yaml:"close-pull-request,omitempty"`
 	MarkPullRequestAsReadyForReview *MarkPullRequestAsReadyForReviewConfig `yaml:"mark-pull-request-as-ready-for-review,omitempty"`
@@ -461,10 +519,12 @@ type SafeOutputsConfig struct {
 	AddLabels                       *AddLabelsConfig                       `yaml:"add-labels,omitempty"`
 	RemoveLabels                    *RemoveLabelsConfig                    `yaml:"remove-labels,omitempty"`
 	AddReviewer                     *AddReviewerConfig                     `yaml:"add-reviewer,omitempty"`
+	RequestReview                   *RequestReviewConfig                   `yaml:"request-review,omitempty"` // Request reviews from users and teams
 	AssignMilestone                 *AssignMilestoneConfig                 `yaml:"assign-milestone,omitempty"`
 	AssignToAgent                   *AssignToAgentConfig                   `yaml:"assign-to-agent,omitempty"`
 	AssignToUser                    *AssignToUserConfig                    `yaml:"assign-to-user,omitempty"`     // Assign users to issues
 	UnassignFromUser                *UnassignFromUserConfig                `yaml:"unassign-from-user,omitempty"` // Remove assignees from issues
+	Assign                          *AssignConfig                          `yaml:"assign,omitempty"`             // Assign users and team members to issues and PRs
 	UpdateIssues                    *UpdateIssuesConfig                    `yaml:"update-issue,omitempty"`
 	UpdatePullRequests              *UpdatePullRequestsConfig              `yaml:"update-pull-request,omitempty"` // Update GitHub pull request title/body
 	PushToPullRequestBranch         *PushToPullRequestBranchConfig         `yaml:"push-to-pull-request-branch,omitempty"`
@@ -493,12 +553,15 @@ type SafeOutputsConfig struct {
 	RunsOn                          string                                 `yaml:"runs-on,omitempty"`                   // Runner configuration for safe-outputs jobs
 	Messages                        *SafeOutputMessagesConfig              `yaml:"messages,omitempty"`                  // Custom message templates for footer and notifications
 	Mentions                        *MentionsConfig                        `yaml:"mentions,omitempty"`                  // Configuration for @mention filtering in safe outputs
+	Sanitize                        *SanitizeConfig                        `yaml:"sanitize,omitempty"`                  // Sanitizer options: mention policy, allowed HTML, max length, link domains
 	Footer                          *bool                                  `yaml:"footer,omitempty"`                    // Global footer control - when false, omits visible footer from all safe outputs (XML markers still included)
 	GroupReports                    bool                                   `yaml:"group-reports,omitempty"`             // If true, create parent "Failed runs" issue for agent failures (default: false)
+	SummaryComment                  bool                                   `yaml:"summary-comment,omitempty"`           // If true, post a sticky run summary comment on the triggering issue/PR (default: false)
 	MaxBotMentions                  *string                                `yaml:"max-bot-mentions,omitempty"`          // Maximum bot trigger references (e.g. 'fixes #123') allowed before filtering. Default: 10. Supports integer or GitHub Actions expression.
 	Steps                           []any                                  `yaml:"steps,omitempty"`                     // User-provided steps injected after setup/checkout and before safe-output code
 	IDToken                         *string                                `yaml:"id-token,omitempty"`                  // Override id-token permission: "write" to force-add, "none" to disable auto-detection
 	ConcurrencyGroup                string                                 `yaml:"concurrency-group,omitempty"`         // Concurrency group for the safe-outputs job (cancel-in-progress is always false)
+	Environment                     string                                 `yaml:"environment,omitempty"`               // GitHub Environment for jobs that apply agent output (e.g. one that requires manual approval)
 	AutoInjectedCreateIssue         bool                                   `yaml:"-"`                                   // Internal: true when create-issues was automatically injected by the compiler (not user-configured)
 }
 
@@ -546,5 +609,6 @@ type MentionsConfig struct {
 
 // SecretMaskingConfig holds configuration for secret redaction behavior
 type SecretMaskingConfig struct {
-	Steps []map[string]any `yaml:"steps,omitempty"` // Additional secret redaction steps to inject after built-in redaction
+	Steps    []map[string]any `yaml:"steps,omitempty"`    // Additional secret redaction steps to inject after built-in redaction
+	Patterns []string         `yaml:"patterns,omitempty"` // Additional regular expressions redacted alongside the built-in credential patterns
 }
@@ -94,7 +94,7 @@ func (c *Compiler) buildUnlockJob(data *WorkflowData, threatDetectionEnabled boo
 		Name:           "unlock",
 		Needs:          needs,
 		If:             alwaysFunc.Render(),
-		RunsOn:         c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		RunsOn:         c.formatAuxiliaryJobRunsOn(data, constants.DefaultActivationJobRunnerImage),
 		Permissions:    permissions,
 		Steps:          steps,
 		TimeoutMinutes: 5, // Short timeout - unlock is a quick operation
@@ -47,7 +47,7 @@ func (c *Compiler) buildJobsAndValidate(data *WorkflowData, markdownPath string)
 }
 
 // generateWorkflowHeader generates the YAML header section including comments
-// for description, source, imports/includes, frontmatter-hash, stop-time, and manual-approval.
+// for description, source, imports/includes, frontmatter-hash, provenance manifest, stop-time, and manual-approval.
 // All ANSI escape codes are stripped from the output.
 func (c *Compiler) generateWorkflowHeader(yaml *strings.Builder, data *WorkflowData, frontmatterHash string) {
 	// Skip the ASCII art banner in wasm/editor mode — it takes up too much space
@@ -128,6 +128,14 @@ func (c *Compiler) generateWorkflowHeader(yaml *strings.Builder, data *WorkflowD
 		}
 	}
 
+	// Add the provenance manifest (source hashes, imports, compiler and engine versions) as JSON
+	if data.Provenance != nil {
+		if manifestJSON, err := data.Provenance.ToJSON(); err == nil {
+			yaml.WriteString("#\n")
+			fmt.Fprintf(yaml, "# gh-aw-manifest: %s\n", manifestJSON)
+		}
+	}
+
 	// Add stop-time comment if configured
 	if data.StopTime != "" {
 		yaml.WriteString("#\n")
@@ -185,11 +193,6 @@ func (c *Compiler) generateWorkflowBody(yaml *strings.Builder, data *WorkflowDat
 func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string, error) {
 	compilerYamlLog.Printf("Generating YAML for workflow: %s", data.Name)
 
-	// Build all jobs and validate dependencies
-	if err := c.buildJobsAndValidate(data, markdownPath); err != nil {
-		return "", fmt.Errorf("failed to build and validate jobs: %w", err)
-	}
-
 	// Compute frontmatter hash before generating YAML
 	var frontmatterHash string
 	if markdownPath != "" {
@@ -205,6 +208,14 @@ func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string
 		}
 	}
 
+	// Collect the provenance manifest before building jobs, since the activation job uploads it
+	data.Provenance = c.buildProvenanceManifest(data, markdownPath, frontmatterHash)
+
+	// Build all jobs and validate dependencies
+	if err := c.buildJobsAndValidate(data, markdownPath); err != nil {
+		return "", fmt.Errorf("failed to build and validate jobs: %w", err)
+	}
+
 	// Pre-allocate builder capacity based on estimated workflow size
 	// Average workflow generates ~200KB, allocate 256KB to minimize reallocations
 	var yaml strings.Builder
@@ -225,6 +236,9 @@ func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string
 		yamlContent = c.replaceIssueNumberReferences(yamlContent)
 	}
 
+	// Apply the workflow's artifact prefix and retention to every artifact step
+	yamlContent = applyArtifactsConfig(yamlContent, data.Artifacts)
+
 	compilerYamlLog.Printf("Successfully generated YAML for workflow: %s (%d bytes)", data.Name, len(yamlContent))
 	return yamlContent, nil
 }
@@ -287,7 +301,7 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, pre
 			compilerYamlLog.Printf("Substituting %d import input values", len(data.ImportInputs))
 			cleaned = SubstituteImportInputs(cleaned, data.ImportInputs)
 		}
-		chunks, exprMaps := processMarkdownBody(cleaned)
+		chunks, exprMaps := processMarkdownBody(cleaned, data.Expressions)
 		userPromptChunks = append(userPromptChunks, chunks...)
 		expressionMappings = exprMaps
 		compilerYamlLog.Printf("Inlined imported markdown with inputs in %d chunks", len(chunks))
@@ -314,7 +328,7 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, pre
 				if extractErr != nil {
 					importedBody = string(rawContent)
 				}
-				chunks, exprMaps := processMarkdownBody(importedBody)
+				chunks, exprMaps := processMarkdownBody(importedBody, data.Expressions)
 				userPromptChunks = append(userPromptChunks, chunks...)
 				expressionMappings = append(expressionMappings, exprMaps...)
 				compilerYamlLog.Printf("Inlined import without inputs: %s", importPath)
@@ -340,7 +354,7 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, pre
 		compilerYamlLog.Printf("Extracting expressions from main workflow markdown (%d bytes)", len(data.MainWorkflowMarkdown))
 
 		// Create a new extractor for main workflow markdown
-		mainExtractor := NewExpressionExtractor()
+		mainExtractor := NewExpressionExtractorWithConfig(data.Expressions)
 		mainExprMappings, err := mainExtractor.ExtractExpressions(data.MainWorkflowMarkdown)
 		if err == nil && len(mainExprMappings) > 0 {
 			compilerYamlLog.Printf("Extracted %d expressions from main workflow markdown", len(mainExprMappings))
@@ -367,7 +381,7 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, pre
 			inlinedMarkdown = wrapExpressionsInTemplateConditionals(inlinedMarkdown)
 
 			// Extract expressions and replace with env var references
-			inlineExtractor := NewExpressionExtractor()
+			inlineExtractor := NewExpressionExtractorWithConfig(data.Expressions)
 			inlineExprMappings, err := inlineExtractor.ExtractExpressions(inlinedMarkdown)
 			if err == nil && len(inlineExprMappings) > 0 {
 				inlinedMarkdown = inlineExtractor.ReplaceExpressionsWithEnvVars(inlinedMarkdown)
@@ -428,6 +442,12 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, pre
 	// mode ones) have been collected so that every entity number reference gets the fallback.
 	applyWorkflowDispatchFallbacks(expressionMappings, data.HasDispatchItemNumber)
 
+	// Read event values from the upstream run when the workflow runs after another one
+	applyUpstreamPayloadFallbacks(expressionMappings, data.WorkflowNeeds)
+
+	// Read event values from the replayed payload when the run was dispatched by gh aw replay
+	applyReplayPayloadFallbacks(expressionMappings, data.HasReplayInputs)
+
 	// Generate a single unified prompt creation step WITHOUT known needs expressions
 	// Known needs expressions are added later for the substitution step only
 	// This returns the combined expression mappings for use in the substitution step
@@ -465,6 +485,9 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, pre
 		}
 	}
 
+	// Keep the final mapping table for compile --expression-map
+	c.recordExpressionMap(data.Expressions, allExpressionMappings)
+
 	// Add combined interpolation and template rendering step
 	// This step processes runtime-import macros, so it must run BEFORE placeholder substitution
 	c.generateInterpolationAndTemplateStep(yaml, expressionMappings, data)
@@ -481,8 +504,14 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, pre
 	yaml.WriteString("      - name: Validate prompt placeholders\n")
 	yaml.WriteString("        env:\n")
 	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	if data.Expressions.hasCustomPrefix() {
+		fmt.Fprintf(yaml, "          GH_AW_EXPRESSION_PREFIX: %s\n", data.Expressions.envPrefix())
+	}
 	yaml.WriteString("        run: bash /opt/gh-aw/actions/validate_prompt_placeholders.sh\n")
 
+	// Truncate the rendered prompt to the configured context budget
+	generateContextTruncationStep(yaml, data)
+
 	// Print prompt (merged into prompt generation)
 	yaml.WriteString("      - name: Print prompt\n")
 	yaml.WriteString("        env:\n")
@@ -644,6 +673,12 @@ func (c *Compiler) generateOutputCollectionStep(yaml *strings.Builder, data *Wor
 	fmt.Fprintf(yaml, "          name: %s\n", constants.SafeOutputArtifactName)
 	yaml.WriteString("          path: ${{ env.GH_AW_SAFE_OUTPUTS }}\n")
 	yaml.WriteString("          if-no-files-found: warn\n")
+	c.writeArtifactRetention(yaml)
+
+	// Write the custom sanitizer after the agent ran so the agent cannot replace it
+	for _, line := range buildSanitizeScriptStep(data.SafeOutputs) {
+		yaml.WriteString(line)
+	}
 
 	yaml.WriteString("      - name: Ingest agent output\n")
 	yaml.WriteString("        id: collect_output\n")
@@ -658,15 +693,7 @@ func (c *Compiler) generateOutputCollectionStep(yaml *strings.Builder, data *Wor
 
 	// Add allowed domains configuration for sanitization
 	// Use manually configured domains if available, otherwise compute from network configuration
-	var domainsStr string
-	if data.SafeOutputs != nil && len(data.SafeOutputs.AllowedDomains) > 0 {
-		// Use manually configured allowed domains
-		domainsStr = strings.Join(data.SafeOutputs.AllowedDomains, ",")
-	} else {
-		// Fall back to computing from network configuration (same as firewall)
-		domainsStr = c.computeAllowedDomainsForSanitization(data)
-	}
-	if domainsStr != "" {
+	if domainsStr := c.computeSanitizerAllowedDomains(data); domainsStr != "" {
 		fmt.Fprintf(yaml, "          GH_AW_ALLOWED_DOMAINS: %q\n", domainsStr)
 	}
 
@@ -676,6 +703,11 @@ func (c *Compiler) generateOutputCollectionStep(yaml *strings.Builder, data *Wor
 		fmt.Fprintf(yaml, "          GH_AW_ALLOWED_GITHUB_REFS: %q\n", refsStr)
 	}
 
+	// Add sanitizer options from safe-outputs.sanitize
+	for _, line := range buildSanitizeEnvLines(data.SafeOutputs) {
+		yaml.WriteString(line)
+	}
+
 	// Add GitHub server URL and API URL for dynamic domain extraction
 	// This allows the sanitization code to permit GitHub domains that vary by deployment
 	yaml.WriteString("          GITHUB_SERVER_URL: ${{ github.server_url }}\n")
@@ -706,16 +738,17 @@ func (c *Compiler) generateOutputCollectionStep(yaml *strings.Builder, data *Wor
 	fmt.Fprintf(yaml, "          name: %s\n", constants.AgentOutputArtifactName)
 	yaml.WriteString("          path: ${{ env.GH_AW_AGENT_OUTPUT }}\n")
 	yaml.WriteString("          if-no-files-found: warn\n")
+	c.writeArtifactRetention(yaml)
 
 }
 
 // processMarkdownBody applies the standard post-processing pipeline to a markdown body:
 // XML comment removal, expression wrapping, expression extraction/substitution, and chunking.
 // It returns the prompt chunks and expression mappings extracted from the content.
-func processMarkdownBody(body string) ([]string, []*ExpressionMapping) {
+func processMarkdownBody(body string, expressions *ExpressionsConfig) ([]string, []*ExpressionMapping) {
 	body = removeXMLComments(body)
 	body = wrapExpressionsInTemplateConditionals(body)
-	extractor := NewExpressionExtractor()
+	extractor := NewExpressionExtractorWithConfig(expressions)
 	exprMappings, err := extractor.ExtractExpressions(body)
 	if err == nil && len(exprMappings) > 0 {
 		body = extractor.ReplaceExpressionsWithEnvVars(body)
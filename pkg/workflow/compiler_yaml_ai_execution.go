@@ -3,6 +3,8 @@ package workflow
 import (
 	"fmt"
 	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
 )
 
 // generateEngineExecutionSteps generates the GitHub Actions steps for executing the AI engine
@@ -10,6 +12,15 @@ func (c *Compiler) generateEngineExecutionSteps(yaml *strings.Builder, data *Wor
 
 	steps := engine.GetExecutionSteps(data, logFile)
 
+	// The engine execution step is the last step; record its PID for the budget monitor
+	// and wrap it in the retry policy when configured
+	if data.Limits != nil && len(steps) > 0 {
+		steps[len(steps)-1] = recordAgentPID(steps[len(steps)-1])
+	}
+	if data.Retries != nil && len(steps) > 0 {
+		steps[len(steps)-1] = wrapExecutionStepWithRetries(steps[len(steps)-1], data.Retries, logFile)
+	}
+
 	for _, step := range steps {
 		for _, line := range step {
 			yaml.WriteString(line + "\n")
@@ -17,8 +28,12 @@ func (c *Compiler) generateEngineExecutionSteps(yaml *strings.Builder, data *Wor
 	}
 }
 
+// transcriptPath is where the log parser writes the engine-independent transcript
+// read by `gh aw logs` and `gh aw audit`
+const transcriptPath = "/tmp/gh-aw/" + constants.TranscriptFilename
+
 // generateLogParsing generates a step that parses the agent's logs and adds them to the step summary
-func (c *Compiler) generateLogParsing(yaml *strings.Builder, engine CodingAgentEngine) {
+func (c *Compiler) generateLogParsing(yaml *strings.Builder, data *WorkflowData, engine CodingAgentEngine) {
 	parserScriptName := engine.GetLogParserScriptId()
 	if parserScriptName == "" {
 		// Skip log parsing if engine doesn't provide a parser
@@ -39,10 +54,16 @@ func (c *Compiler) generateLogParsing(yaml *strings.Builder, engine CodingAgentE
 	logFileForParsing := engine.GetLogFileForParsing()
 
 	yaml.WriteString("      - name: Parse agent logs for step summary\n")
+	// The summary comment and webhook notification read the final agent message and token usage from this step's outputs
+	if (data.SafeOutputs != nil && data.SafeOutputs.SummaryComment) || data.Notifications != nil {
+		fmt.Fprintf(yaml, "        id: %s\n", parseAgentLogsStepID)
+	}
 	yaml.WriteString("        if: always()\n")
 	fmt.Fprintf(yaml, "        uses: %s\n", GetActionPin("actions/github-script"))
 	yaml.WriteString("        env:\n")
 	fmt.Fprintf(yaml, "          GH_AW_AGENT_OUTPUT: %s\n", logFileForParsing)
+	fmt.Fprintf(yaml, "          GH_AW_ENGINE_ID: %s\n", engine.GetID())
+	fmt.Fprintf(yaml, "          GH_AW_TRANSCRIPT: %s\n", transcriptPath)
 	yaml.WriteString("        with:\n")
 	yaml.WriteString("          script: |\n")
 
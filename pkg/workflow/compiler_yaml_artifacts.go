@@ -46,6 +46,16 @@ func (c *Compiler) generateUnifiedArtifactUpload(yaml *strings.Builder, paths []
 	}
 
 	yaml.WriteString("          if-no-files-found: ignore\n")
+	c.writeArtifactRetention(yaml)
 
 	compilerYamlArtifactsLog.Printf("Generated unified artifact upload step with %d paths", len(paths))
 }
+
+// writeArtifactRetention adds retention-days to an upload-artifact step when the
+// repository configuration sets artifact-retention-days.
+func (c *Compiler) writeArtifactRetention(yaml *strings.Builder) {
+	if c.repoConfig == nil || c.repoConfig.ArtifactRetentionDays == 0 {
+		return
+	}
+	fmt.Fprintf(yaml, "          retention-days: %d\n", c.repoConfig.ArtifactRetentionDays)
+}
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"fmt"
+	"maps"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -332,8 +333,8 @@ func (c *Compiler) renderStepFromMap(yaml *strings.Builder, step map[string]any,
 			case map[string]any:
 				// For complex fields like "with" or "env"
 				fmt.Fprintf(yaml, "%s:\n", field)
-				for key, val := range v {
-					fmt.Fprintf(yaml, "%s    %s: %v\n", indent, key, val)
+				for _, key := range slices.Sorted(maps.Keys(v)) {
+					fmt.Fprintf(yaml, "%s    %s: %v\n", indent, key, v[key])
 				}
 			default:
 				fmt.Fprintf(yaml, "%s: %v\n", field, v)
@@ -341,8 +342,9 @@ func (c *Compiler) renderStepFromMap(yaml *strings.Builder, step map[string]any,
 		}
 	}
 
-	// Add any remaining fields not in the predefined order
-	for field, value := range step {
+	// Add any remaining fields not in the predefined order, sorted for stable output
+	for _, field := range slices.Sorted(maps.Keys(step)) {
+		value := step[field]
 		// Skip fields we've already processed
 		skip := slices.Contains(fieldOrder, field)
 		if skip {
@@ -368,8 +370,8 @@ func (c *Compiler) renderStepFromMap(yaml *strings.Builder, step map[string]any,
 			}
 		case map[string]any:
 			fmt.Fprintf(yaml, "%s:\n", field)
-			for key, val := range v {
-				fmt.Fprintf(yaml, "%s    %s: %v\n", indent, key, val)
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				fmt.Fprintf(yaml, "%s    %s: %v\n", indent, key, v[key])
 			}
 		default:
 			fmt.Fprintf(yaml, "%s: %v\n", field, v)
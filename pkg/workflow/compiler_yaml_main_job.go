@@ -47,6 +47,10 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 			yaml.WriteString(line)
 		}
 
+		// Stage split helper files before any step can switch the workspace to another ref
+		c.generateSplitScriptsStagingStep(yaml)
+		defer func() { c.splitScriptsStaged = false }()
+
 		// Add CLI build steps in dev mode (after automatic checkout, before other steps)
 		// This builds the gh-aw CLI and Docker image for use by the agentic-workflows MCP server
 		// Only generate build steps if agentic-workflows tool is enabled
@@ -170,6 +174,9 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	yaml.WriteString("      - name: Create gh-aw temp directory\n")
 	yaml.WriteString("        run: bash /opt/gh-aw/actions/create_gh_aw_tmp_dir.sh\n")
 
+	// Start the resource sampler early so runtime setup and custom steps are included in the samples
+	generateResourceSamplerStartStep(yaml, data)
+
 	// Add custom steps if present
 	if data.CustomSteps != "" {
 		if customStepsContainCheckout && len(runtimeSetupSteps) > 0 {
@@ -196,14 +203,29 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	compilerYamlLog.Printf("Generating repo-memory steps for workflow")
 	generateRepoMemorySteps(yaml, data)
 
-	// Configure git credentials for agentic workflows
-	gitConfigSteps := c.generateGitConfigurationSteps()
-	for _, line := range gitConfigSteps {
-		yaml.WriteString(line)
+	// Add memory gist restore step if the runtime memory is stored in a gist
+	generateMemoryGistSteps(yaml, data)
+
+	// Add continuation checkpoint restore step for runs dispatched by the continuation job
+	generateContinuationRestoreStep(yaml, data)
+
+	// Git configuration and the PR branch checkout need a repository in the workspace,
+	// which is absent when the checkout was dropped because nothing needs the working tree
+	hasWorkingTree := needsCheckout || customStepsContainCheckout
+
+	if hasWorkingTree {
+		// Configure git credentials for agentic workflows
+		gitConfigSteps := c.generateGitConfigurationSteps()
+		for _, line := range gitConfigSteps {
+			yaml.WriteString(line)
+		}
+
+		// Add step to checkout PR branch if the event is pull_request
+		c.generatePRReadyForReviewCheckout(yaml, data)
 	}
 
-	// Add step to checkout PR branch if the event is pull_request
-	c.generatePRReadyForReviewCheckout(yaml, data)
+	// Add the quarantined pull request head checkout if opted into for pull_request_target
+	c.generatePullRequestTargetHeadCheckout(yaml, data)
 
 	// Add Node.js setup if the engine requires it and it's not already set up in custom steps
 	engine, err := c.getAgenticEngine(data.AI)
@@ -274,9 +296,13 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 		yaml.WriteString(line)
 	}
 
+	// Start the budget monitor right before the agent so it can stop it when limits are exceeded
+	c.generateBudgetMonitorStep(yaml, data, engine)
+
 	// Add AI execution step using the agentic engine
 	compilerYamlLog.Printf("Generating engine execution steps for %s", engine.GetID())
 	c.generateEngineExecutionSteps(yaml, data, engine, logFileFull)
+	c.generateBudgetMonitorStopStep(yaml, data)
 
 	// Add inference access error detection step for Copilot engine
 	// This step detects when the Copilot CLI fails due to the token lacking inference access
@@ -295,9 +321,11 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	// Regenerate git credentials after agent execution
 	// This allows safe-outputs operations (like create_pull_request) to work properly
 	// We regenerate the credentials rather than restoring from backup
-	gitConfigStepsAfterAgent := c.generateGitConfigurationSteps()
-	for _, line := range gitConfigStepsAfterAgent {
-		yaml.WriteString(line)
+	if hasWorkingTree {
+		gitConfigStepsAfterAgent := c.generateGitConfigurationSteps()
+		for _, line := range gitConfigStepsAfterAgent {
+			yaml.WriteString(line)
+		}
 	}
 
 	// Collect firewall logs BEFORE secret redaction so secrets in logs can be redacted
@@ -345,7 +373,7 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	}
 
 	// parse agent logs for GITHUB_STEP_SUMMARY
-	c.generateLogParsing(yaml, engine)
+	c.generateLogParsing(yaml, data, engine)
 
 	// parse safe-inputs logs for GITHUB_STEP_SUMMARY (if safe-inputs is enabled)
 	if IsSafeInputsEnabled(data.SafeInputs, data) {
@@ -392,6 +420,16 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	// Collect agent stdio logs path for unified upload
 	artifactPaths = append(artifactPaths, logFileFull)
 
+	// Collect the budget monitor report (only written when a limit was exceeded)
+	if data.Limits != nil {
+		artifactPaths = append(artifactPaths, budgetReportPath)
+	}
+
+	// Collect the canonical transcript written by the log parser
+	if engine.GetLogParserScriptId() != "" {
+		artifactPaths = append(artifactPaths, transcriptPath)
+	}
+
 	// Collect agent-generated files path for unified upload
 	// This directory is used by workflows that instruct the agent to write files
 	// (e.g., smoke-claude status summaries)
@@ -416,6 +454,12 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	// This ensures artifacts are uploaded after the agent has finished modifying the cache
 	generateCacheMemoryArtifactUpload(yaml, data)
 
+	// Add memory artifact upload for the update_memory_gist job
+	generateMemoryGistArtifactUpload(yaml, data)
+
+	// Add continuation checkpoint upload for the next run of a continuation chain
+	generateContinuationCheckpointUpload(yaml, data)
+
 	// Add safe-outputs assets artifact upload (after agent execution)
 	// This creates a separate artifact for assets that will be downloaded by upload_assets job
 	generateSafeOutputsAssetsArtifactUpload(yaml, data)
@@ -431,6 +475,12 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	// Add post-steps (if any) after AI execution
 	c.generatePostSteps(yaml, data)
 
+	// Stop the resource sampler and collect its samples for unified upload
+	if data.ResourceTelemetry != nil {
+		generateResourceSamplerStopStep(yaml, data)
+		artifactPaths = append(artifactPaths, resourceSamplesPath)
+	}
+
 	// Generate single unified artifact upload with all collected paths
 	c.generateUnifiedArtifactUpload(yaml, artifactPaths)
 
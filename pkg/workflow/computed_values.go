@@ -0,0 +1,254 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var computedValuesLog = logger.New("workflow:computed_values")
+
+// Computed frontmatter values are ${{ }} expressions in fields that normally take a number,
+// for example:
+//
+//	timeout-minutes: ${{ inputs.deep_scan && 60 || 15 }}
+//
+// They are compiled unchanged into the lock file, where GitHub Actions evaluates them, so one
+// workflow can adjust its resource usage to its dispatch inputs. The compiler only accepts a
+// sandboxed subset of the expression language: literals, operators, a few pure functions, and
+// the inputs and vars contexts. Every combination of the boolean and choice inputs the
+// expression references is evaluated to make sure it yields a usable value.
+
+// computedFrontmatterFields lists the top-level numeric fields that accept computed values
+var computedFrontmatterFields = []string{"timeout-minutes"}
+
+// computedValueFunctions lists the (lower-case) functions computed values may call
+var computedValueFunctions = []string{"contains", "startswith", "endswith", "format", "fromjson"}
+
+// maxComputedValueCombinations bounds the number of input combinations evaluated per value
+const maxComputedValueCombinations = 256
+
+// ComputedInt is an integer frontmatter field that may also be a computed ${{ }} expression.
+// Expressions stay in the raw frontmatter and leave the typed value at zero.
+type ComputedInt int
+
+// UnmarshalJSON accepts a number or a string (a computed expression)
+func (v *ComputedInt) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*v = 0
+		return nil
+	}
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*v = ComputedInt(n)
+	return nil
+}
+
+// isComputedExpression reports whether a frontmatter value is a single ${{ }} expression
+func isComputedExpression(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.HasPrefix(value, "${{") && strings.HasSuffix(value, "}}") &&
+		strings.Count(value, "${{") == 1 && len(value) > len("${{}}")
+}
+
+// validateComputedFrontmatterValues validates the computed expressions of computedFrontmatterFields
+func validateComputedFrontmatterValues(frontmatter map[string]any) error {
+	for _, field := range computedFrontmatterFields {
+		expression, ok := frontmatter[field].(string)
+		if !ok {
+			continue
+		}
+		if err := validateComputedValue(field, expression, declaredWorkflowInputs(frontmatter)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateComputedValue checks that a computed expression only uses the sandboxed subset of the
+// expression language and evaluates to at least 1 for every combination of the inputs it uses
+func validateComputedValue(field string, expression string, inputs map[string]map[string]any) error {
+	computedValuesLog.Printf("Validating computed value for %s: %s", field, expression)
+	example := fmt.Sprintf("Example:\n%s: ${{ inputs.deep_scan && 60 || 15 }}", field)
+
+	if !isComputedExpression(expression) {
+		return fmt.Errorf("%s must be a number or a single ${{ }} expression, got '%s'. %s", field, expression, example)
+	}
+	tokens, err := tokenizeEvalExpression(stripExpressionWrapper(expression))
+	if err != nil {
+		return fmt.Errorf("%s: invalid expression '%s': %w", field, expression, err)
+	}
+
+	referenced, err := computedValueReferences(tokens, inputs)
+	if err != nil {
+		return fmt.Errorf("%s: %w. %s", field, err, example)
+	}
+
+	for _, values := range computedValueInputCombinations(referenced, inputs) {
+		contexts := map[string]any{
+			"inputs": values,
+			"github": map[string]any{"event": map[string]any{"inputs": values}},
+			"vars":   map[string]any{},
+		}
+		result, err := EvaluateExpression(expression, contexts)
+		if err != nil {
+			return fmt.Errorf("%s: invalid expression '%s': %w", field, expression, err)
+		}
+		if minutes := expressionNumber(result); math.IsNaN(minutes) || math.IsInf(minutes, 0) || minutes < 1 {
+			return fmt.Errorf("%s: expression '%s' evaluates to %s with inputs %s; it must evaluate to a number of at least 1",
+				field, expression, FormatExpressionValue(result), formatComputedValueInputs(values))
+		}
+	}
+	return nil
+}
+
+// computedValueReferences checks the identifiers of an expression against the sandbox and
+// returns the names of the inputs it references
+func computedValueReferences(tokens []evalToken, inputs map[string]map[string]any) ([]string, error) {
+	for _, tok := range tokens {
+		if tok.kind == evalTokenLeftBracket || tok.kind == evalTokenStar {
+			return nil, fmt.Errorf("'%s' is not allowed in computed values; use property access such as inputs.name", tok.text)
+		}
+	}
+
+	var referenced []string
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok.kind != evalTokenIdent {
+			continue
+		}
+
+		if i > 0 && tokens[i-1].kind == evalTokenDot {
+			continue // Property name
+		}
+		if tokens[i+1].kind == evalTokenLeftParen {
+			if !slices.Contains(computedValueFunctions, strings.ToLower(tok.text)) {
+				return nil, fmt.Errorf("function '%s' is not allowed in computed values. Allowed functions: contains, startsWith, endsWith, format, fromJSON", tok.text)
+			}
+			continue
+		}
+		switch tok.text {
+		case "true", "false", "null":
+			continue
+		}
+
+		// Context access: collect the dotted property path
+		var path []string
+		for j := i; j < len(tokens) && tokens[j].kind == evalTokenIdent; j += 2 {
+			path = append(path, tokens[j].text)
+			if j+1 >= len(tokens) || tokens[j+1].kind != evalTokenDot {
+				break
+			}
+		}
+
+		var inputName string
+		switch {
+		case path[0] == "inputs" && len(path) == 2:
+			inputName = path[1]
+		case len(path) == 4 && path[0] == "github" && path[1] == "event" && path[2] == "inputs":
+			inputName = path[3]
+		case path[0] == "vars" && len(path) == 2:
+			continue
+		default:
+			return nil, fmt.Errorf("'%s' is not allowed in computed values; only inputs.<name>, github.event.inputs.<name> and vars.<name> can be used", strings.Join(path, "."))
+		}
+		if _, declared := inputs[inputName]; !declared {
+			return nil, fmt.Errorf("input '%s' is not declared under on.workflow_dispatch.inputs or on.workflow_call.inputs", inputName)
+		}
+		if !slices.Contains(referenced, inputName) {
+			referenced = append(referenced, inputName)
+		}
+	}
+	sort.Strings(referenced)
+	return referenced, nil
+}
+
+// computedValueInputCombinations returns the input values to evaluate a computed value with:
+// every combination of the referenced boolean and choice inputs, and the default of the others.
+// Beyond maxComputedValueCombinations only the defaults are evaluated.
+func computedValueInputCombinations(referenced []string, inputs map[string]map[string]any) []map[string]any {
+	defaults := make(map[string]any)
+	for _, name := range referenced {
+		// Expression numbers are float64, while YAML defaults may be integers
+		value := inputs[name]["default"]
+		if _, isFloat := value.(float64); !isFloat {
+			if n, ok := parseIntValue(value); ok {
+				value = float64(n)
+			}
+		}
+		defaults[name] = value
+	}
+
+	combinations := []map[string]any{defaults}
+	for _, name := range referenced {
+		var candidates []any
+		switch inputs[name]["type"] {
+		case "boolean":
+			candidates = []any{false, true}
+		case "choice":
+			options, _ := inputs[name]["options"].([]any)
+			candidates = options
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		if len(combinations)*len(candidates) > maxComputedValueCombinations {
+			computedValuesLog.Printf("Too many input combinations, evaluating defaults only")
+			return []map[string]any{defaults}
+		}
+
+		var expanded []map[string]any
+		for _, combination := range combinations {
+			for _, candidate := range candidates {
+				values := make(map[string]any, len(combination))
+				for k, v := range combination {
+					values[k] = v
+				}
+				values[name] = candidate
+				expanded = append(expanded, values)
+			}
+		}
+		combinations = expanded
+	}
+	return combinations
+}
+
+// formatComputedValueInputs formats input values for error messages, e.g. {deep_scan: true}
+func formatComputedValueInputs(values map[string]any) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s: %s", name, FormatExpressionValue(values[name])))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// declaredWorkflowInputs returns the input definitions of on.workflow_dispatch and on.workflow_call
+func declaredWorkflowInputs(frontmatter map[string]any) map[string]map[string]any {
+	inputs := make(map[string]map[string]any)
+	onMap, _ := frontmatter["on"].(map[string]any)
+	for _, trigger := range []string{"workflow_dispatch", "workflow_call"} {
+		triggerMap, _ := onMap[trigger].(map[string]any)
+		inputsMap, _ := triggerMap["inputs"].(map[string]any)
+		for name, definition := range inputsMap {
+			definitionMap, _ := definition.(map[string]any)
+			if definitionMap == nil {
+				definitionMap = map[string]any{}
+			}
+			inputs[name] = definitionMap
+		}
+	}
+	return inputs
+}
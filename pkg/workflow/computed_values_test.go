@@ -0,0 +1,102 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateComputedValue(t *testing.T) {
+	inputs := map[string]map[string]any{
+		"deep_scan": {"type": "boolean", "default": false},
+		"depth":     {"type": "choice", "options": []any{"quick", "full"}, "default": "quick"},
+		"minutes":   {"type": "number", "default": 30},
+	}
+
+	for _, expression := range []string{
+		"${{ inputs.deep_scan && 60 || 15 }}",
+		"${{ github.event.inputs.deep_scan && 60 || 15 }}",
+		"${{ inputs.depth == 'full' && 90 || 20 }}",
+		"${{ inputs.minutes }}",
+		"${{ fromJSON(vars.SCAN_TIMEOUT || '20') }}",
+	} {
+		assert.NoError(t, validateComputedValue("timeout-minutes", expression, inputs), "%s should be accepted", expression)
+	}
+
+	for expression, errorSubstring := range map[string]string{
+		"60":                                     "single ${{ }} expression",
+		"${{ inputs.deep_scan && 0 }}":           "evaluates to false with inputs {deep_scan: false}",
+		"${{ inputs.depth == 'full' && 90 }}":    "with inputs {depth: quick}",
+		"${{ secrets.TOKEN || 15 }}":             "'secrets.TOKEN' is not allowed",
+		"${{ github.run_number }}":               "'github.run_number' is not allowed",
+		"${{ inputs.missing || 15 }}":            "input 'missing' is not declared",
+		"${{ toJSON(inputs) || 15 }}":            "function 'toJSON' is not allowed",
+		"${{ inputs['deep_scan'] && 60 || 15 }}": "'[' is not allowed",
+		"${{ inputs.deep_scan && }}":             "invalid expression",
+	} {
+		err := validateComputedValue("timeout-minutes", expression, inputs)
+		require.Error(t, err, "%s should be rejected", expression)
+		assert.Contains(t, err.Error(), errorSubstring, "error for %s", expression)
+	}
+}
+
+func TestParseFrontmatterConfigComputedTimeout(t *testing.T) {
+	config, err := ParseFrontmatterConfig(map[string]any{
+		"name":            "scan",
+		"timeout-minutes": "${{ inputs.deep_scan && 60 || 15 }}",
+	})
+	require.NoError(t, err, "computed timeout should not break typed parsing")
+	assert.Equal(t, "scan", config.Name, "other fields should be parsed")
+	assert.Zero(t, config.TimeoutMinutes, "computed timeout has no typed value")
+}
+
+func TestComputedTimeoutMinutesCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "computed-timeout-test")
+	workflowPath := filepath.Join(tmpDir, "scan.md")
+	content := `---
+on:
+  workflow_dispatch:
+    inputs:
+      deep_scan:
+        type: boolean
+        default: false
+timeout-minutes: ${{ inputs.deep_scan && 60 || 15 }}
+timeouts:
+  agent: 10
+engine: copilot
+permissions:
+  contents: read
+---
+
+Scan the repository.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "    timeout-minutes: ${{ inputs.deep_scan && 60 || 15 }}\n", "agent job should use the computed timeout")
+	assert.Contains(t, lock, "        timeout-minutes: 10\n", "agent step should keep timeouts.agent")
+
+	invalid := filepath.Join(tmpDir, "invalid.md")
+	require.NoError(t, os.WriteFile(invalid, []byte(`---
+on: workflow_dispatch
+timeout-minutes: ${{ secrets.TIMEOUT }}
+engine: copilot
+---
+
+Scan.
+`), 0644), "should write workflow")
+	err = NewCompiler().CompileWorkflow(invalid)
+	require.Error(t, err, "computed values may not read secrets")
+	assert.Contains(t, err.Error(), "'secrets.TIMEOUT' is not allowed", "error should name the reference")
+}
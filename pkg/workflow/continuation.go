@@ -0,0 +1,306 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var continuationLog = logger.New("workflow:continuation")
+
+const (
+	// ContinuationRunIDInputName is the workflow_dispatch input holding the run whose checkpoint is resumed
+	ContinuationRunIDInputName = "continuation_run_id"
+	// ContinuationIterationInputName is the workflow_dispatch input holding the iteration number of the run
+	ContinuationIterationInputName = "continuation_iteration"
+
+	// continuationDir is the directory the agent keeps its checkpoint in
+	continuationDir = "/tmp/gh-aw/continuation"
+	// continuationDoneFile is the marker the agent creates in continuationDir once the task is complete
+	continuationDoneFile = "DONE"
+	// continuationArtifactName is the artifact carrying the checkpoint to the next run
+	continuationArtifactName = "continuation-checkpoint"
+	// continuationJobName is the job that dispatches the next run
+	continuationJobName = "continuation"
+
+	defaultContinuationMaxIterations = 3
+	maxContinuationMaxIterations     = 10
+)
+
+// ContinuationConfig represents automatic continuation of long-running tasks (continuation:)
+//
+// Example:
+//
+//	continuation:
+//	  max-iterations: 5
+type ContinuationConfig struct {
+	MaxIterations int `json:"max-iterations"` // Maximum number of runs in a continuation chain, including the first
+}
+
+// extractContinuationConfig extracts the continuation configuration from frontmatter
+func extractContinuationConfig(frontmatter map[string]any) (*ContinuationConfig, error) {
+	value, exists := frontmatter["continuation"]
+	if !exists || value == nil {
+		return nil, nil
+	}
+
+	config := &ContinuationConfig{MaxIterations: defaultContinuationMaxIterations}
+
+	if enabled, ok := value.(bool); ok {
+		if !enabled {
+			return nil, nil
+		}
+		return config, nil
+	}
+
+	continuationMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("continuation must be a boolean or an object, got %T. Example:\ncontinuation:\n  max-iterations: 5", value)
+	}
+
+	if maxIterations, exists := continuationMap["max-iterations"]; exists {
+		iterations, ok := parseIntValue(maxIterations)
+		if !ok {
+			return nil, fmt.Errorf("continuation.max-iterations must be an integer, got %v", maxIterations)
+		}
+		if err := validateIntRange(iterations, 2, maxContinuationMaxIterations, "continuation.max-iterations"); err != nil {
+			return nil, err
+		}
+		config.MaxIterations = iterations
+	}
+
+	continuationLog.Printf("Extracted continuation config: max_iterations=%d", config.MaxIterations)
+	return config, nil
+}
+
+// addContinuationInputs adds the continuation inputs to on.workflow_dispatch of workflows
+// with continuation enabled, adding the workflow_dispatch trigger when it is missing.
+// The continuation job re-dispatches the workflow with these inputs; inputs the workflow
+// declares itself are left unchanged.
+func addContinuationInputs(frontmatter map[string]any) {
+	switch value := frontmatter["continuation"].(type) {
+	case bool:
+		if !value {
+			return
+		}
+	case map[string]any:
+	default:
+		return
+	}
+
+	var onMap map[string]any
+	switch on := frontmatter["on"].(type) {
+	case map[string]any:
+		onMap = on
+	case string:
+		onMap = map[string]any{on: map[string]any{}}
+	case []any:
+		onMap = make(map[string]any, len(on))
+		for _, event := range on {
+			if name, ok := event.(string); ok {
+				onMap[name] = map[string]any{}
+			}
+		}
+	default:
+		return
+	}
+
+	var dispatch map[string]any
+	switch existing := onMap["workflow_dispatch"].(type) {
+	case nil:
+		dispatch = map[string]any{}
+	case map[string]any:
+		dispatch = existing
+	default:
+		return
+	}
+
+	inputs, ok := dispatch["inputs"].(map[string]any)
+	if !ok {
+		if dispatch["inputs"] != nil {
+			return
+		}
+		inputs = map[string]any{}
+	}
+
+	if _, exists := inputs[ContinuationRunIDInputName]; !exists {
+		inputs[ContinuationRunIDInputName] = map[string]any{
+			"description": "Run whose checkpoint to resume (set by automatic continuation)",
+			"required":    false,
+			"type":        "string",
+		}
+	}
+	if _, exists := inputs[ContinuationIterationInputName]; !exists {
+		inputs[ContinuationIterationInputName] = map[string]any{
+			"description": "Iteration number of the continuation chain (set by automatic continuation)",
+			"required":    false,
+			"type":        "string",
+		}
+	}
+
+	dispatch["inputs"] = inputs
+	onMap["workflow_dispatch"] = dispatch
+	frontmatter["on"] = onMap
+	continuationLog.Print("Added continuation inputs to workflow_dispatch")
+}
+
+// validateContinuationPermissions checks that the agent job can download the checkpoint of the previous run
+func validateContinuationPermissions(workflowData *WorkflowData) error {
+	if workflowData.Continuation == nil {
+		return nil
+	}
+
+	permissions := NewPermissionsParser(workflowData.Permissions).ToPermissions()
+	if level, exists := permissions.Get(PermissionActions); exists && level != PermissionNone {
+		return nil
+	}
+
+	message := "ERROR: Missing required permission for continuation:\n"
+	message += "  - actions: read\n\n"
+	message += "Continuation runs download the checkpoint artifact of the previous run, which requires actions: read permission.\n\n"
+	message += "Suggested fix: Add the following to your workflow frontmatter:\n"
+	message += "permissions:\n"
+	message += "  actions: read"
+	return errors.New(message)
+}
+
+// buildContinuationPromptSection tells the agent how to checkpoint its progress and signal completion
+func buildContinuationPromptSection(config *ContinuationConfig) *PromptSection {
+	if config == nil {
+		return nil
+	}
+
+	var content strings.Builder
+	content.WriteString("<continuation>\n")
+	fmt.Fprintf(&content, "This task may take longer than a single run. When a run ends before the task is complete, the workflow is automatically dispatched again (up to %d runs in total) and the next run resumes from your checkpoint.\n\n", config.MaxIterations)
+	fmt.Fprintf(&content, "- Keep your progress in %s/ and update it as you work, so a run that times out can be resumed. Record everything the next run needs, including the issue or pull request you are working on, because continuation runs are started by workflow_dispatch and do not have the original event.\n", continuationDir)
+	fmt.Fprintf(&content, "- When the whole task is complete, create the file %s/%s. Without it, a non-empty checkpoint starts another run.\n", continuationDir, continuationDoneFile)
+	fmt.Fprintf(&content, "- If %s/ already contains files when you start, read them first and continue from there instead of starting over.\n", continuationDir)
+	content.WriteString("</continuation>")
+
+	return &PromptSection{
+		Content: content.String(),
+		IsFile:  false,
+	}
+}
+
+// generateContinuationRestoreStep downloads the checkpoint of the previous run in a continuation chain
+func generateContinuationRestoreStep(builder *strings.Builder, data *WorkflowData) {
+	if data.Continuation == nil {
+		return
+	}
+
+	continuationLog.Print("Generating continuation checkpoint restore step")
+
+	builder.WriteString("      - name: Restore continuation checkpoint\n")
+	fmt.Fprintf(builder, "        if: inputs.%s != ''\n", ContinuationRunIDInputName)
+	fmt.Fprintf(builder, "        uses: %s\n", GetActionPin("actions/download-artifact"))
+	builder.WriteString("        with:\n")
+	fmt.Fprintf(builder, "          name: %s\n", continuationArtifactName)
+	fmt.Fprintf(builder, "          path: %s\n", continuationDir)
+	fmt.Fprintf(builder, "          run-id: ${{ inputs.%s }}\n", ContinuationRunIDInputName)
+	builder.WriteString("          github-token: ${{ github.token }}\n")
+}
+
+// generateContinuationCheckpointUpload decides whether another run is needed and uploads the checkpoint for it.
+// The agent is done when it created the done marker; otherwise a non-empty checkpoint means the task continues.
+func generateContinuationCheckpointUpload(builder *strings.Builder, data *WorkflowData) {
+	if data.Continuation == nil {
+		return
+	}
+
+	builder.WriteString("      - name: Check continuation checkpoint\n")
+	builder.WriteString("        id: continuation\n")
+	builder.WriteString("        if: always()\n")
+	builder.WriteString("        run: |\n")
+	fmt.Fprintf(builder, "          if [ -f %s/%s ]; then\n", continuationDir, continuationDoneFile)
+	builder.WriteString("            echo \"Agent marked the task as complete\"\n")
+	builder.WriteString("            echo \"status=done\" >> \"$GITHUB_OUTPUT\"\n")
+	fmt.Fprintf(builder, "          elif [ -d %s ] && [ -n \"$(ls -A %s)\" ]; then\n", continuationDir, continuationDir)
+	builder.WriteString("            echo \"Agent left a checkpoint, the task continues in another run\"\n")
+	builder.WriteString("            echo \"status=continue\" >> \"$GITHUB_OUTPUT\"\n")
+	builder.WriteString("          else\n")
+	builder.WriteString("            echo \"No checkpoint found\"\n")
+	builder.WriteString("            echo \"status=none\" >> \"$GITHUB_OUTPUT\"\n")
+	builder.WriteString("          fi\n")
+
+	builder.WriteString("      - name: Upload continuation checkpoint\n")
+	builder.WriteString("        if: always() && steps.continuation.outputs.status == 'continue'\n")
+	fmt.Fprintf(builder, "        uses: %s\n", GetActionPin("actions/upload-artifact"))
+	builder.WriteString("        with:\n")
+	fmt.Fprintf(builder, "          name: %s\n", continuationArtifactName)
+	fmt.Fprintf(builder, "          path: %s\n", continuationDir)
+	builder.WriteString("          include-hidden-files: true\n")
+	builder.WriteString("          if-no-files-found: ignore\n")
+}
+
+// addContinuationClaudeTools grants Claude access to the checkpoint directory
+func addContinuationClaudeTools(allowedTools string) string {
+	var tools []string
+	if allowedTools != "" {
+		tools = strings.Split(allowedTools, ",")
+	}
+	for _, tool := range []string{"Read", "Write", "Edit"} {
+		pattern := fmt.Sprintf("%s(%s/*)", tool, continuationDir)
+		if !slices.Contains(tools, pattern) {
+			tools = append(tools, pattern)
+		}
+	}
+	slices.Sort(tools)
+	return strings.Join(tools, ",")
+}
+
+// buildContinuationJob builds the job that dispatches the next run of a continuation chain.
+// It runs after the agent job when the agent left a checkpoint without marking the task as
+// complete, and stops with a warning once max-iterations runs have been made.
+func (c *Compiler) buildContinuationJob(data *WorkflowData, lockFilename string, threatDetectionEnabled bool) (*Job, error) {
+	if data.Continuation == nil {
+		return nil, nil
+	}
+
+	continuationLog.Printf("Building continuation job (max_iterations=%d, threatDetectionEnabled=%v)", data.Continuation.MaxIterations, threatDetectionEnabled)
+
+	var step strings.Builder
+	step.WriteString("      - name: Dispatch next iteration\n")
+	step.WriteString("        env:\n")
+	step.WriteString("          GH_TOKEN: ${{ github.token }}\n")
+	fmt.Fprintf(&step, "          GH_AW_CONTINUATION_ITERATION: ${{ inputs.%s || '1' }}\n", ContinuationIterationInputName)
+	fmt.Fprintf(&step, "          GH_AW_CONTINUATION_MAX_ITERATIONS: \"%s\"\n", strconv.Itoa(data.Continuation.MaxIterations))
+	step.WriteString("          GH_AW_CONTINUATION_REF: ${{ github.head_ref || github.ref_name }}\n")
+	fmt.Fprintf(&step, "          GH_AW_WORKFLOW_FILE: \"%s\"\n", lockFilename)
+	step.WriteString("        run: |\n")
+	step.WriteString("          if [ \"$GH_AW_CONTINUATION_ITERATION\" -ge \"$GH_AW_CONTINUATION_MAX_ITERATIONS\" ]; then\n")
+	step.WriteString("            echo \"::warning::Reached continuation max-iterations ($GH_AW_CONTINUATION_MAX_ITERATIONS) before the agent marked the task as complete\"\n")
+	step.WriteString("            exit 0\n")
+	step.WriteString("          fi\n")
+	step.WriteString("          NEXT_ITERATION=$((GH_AW_CONTINUATION_ITERATION + 1))\n")
+	step.WriteString("          gh workflow run \"$GH_AW_WORKFLOW_FILE\" --repo \"$GITHUB_REPOSITORY\" --ref \"$GH_AW_CONTINUATION_REF\" \\\n")
+	fmt.Fprintf(&step, "            -f %s=\"$GITHUB_RUN_ID\" -f %s=\"$NEXT_ITERATION\"\n", ContinuationRunIDInputName, ContinuationIterationInputName)
+	step.WriteString("          echo \"Dispatched continuation iteration $NEXT_ITERATION of $GH_AW_CONTINUATION_MAX_ITERATIONS\" >> \"$GITHUB_STEP_SUMMARY\"\n")
+
+	jobCondition := fmt.Sprintf("always() && needs.%s.outputs.continuation == 'continue'", constants.AgentJobName)
+	if threatDetectionEnabled {
+		// Don't carry a checkpoint that failed threat detection into the next run
+		jobCondition += fmt.Sprintf(" && needs.%s.outputs.detection_success == 'true'", constants.AgentJobName)
+	}
+
+	perms := NewPermissions()
+	perms.Set(PermissionActions, PermissionWrite)
+
+	job := &Job{
+		Name:           continuationJobName,
+		Needs:          []string{string(constants.AgentJobName)},
+		If:             jobCondition,
+		RunsOn:         c.formatAuxiliaryJobRunsOn(data, constants.DefaultActivationJobRunnerImage),
+		Permissions:    perms.RenderToYAML(),
+		Steps:          []string{step.String()},
+		TimeoutMinutes: 5,
+	}
+
+	return job, nil
+}
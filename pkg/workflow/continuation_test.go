@@ -0,0 +1,152 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractContinuationConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *ContinuationConfig
+		wantErr     string
+	}{
+		{
+			name:        "no continuation",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "disabled",
+			frontmatter: map[string]any{"continuation": false},
+		},
+		{
+			name:        "enabled with defaults",
+			frontmatter: map[string]any{"continuation": true},
+			expected:    &ContinuationConfig{MaxIterations: 3},
+		},
+		{
+			name:        "max-iterations",
+			frontmatter: map[string]any{"continuation": map[string]any{"max-iterations": 5}},
+			expected:    &ContinuationConfig{MaxIterations: 5},
+		},
+		{
+			name:        "invalid type",
+			frontmatter: map[string]any{"continuation": "yes"},
+			wantErr:     "continuation must be a boolean or an object",
+		},
+		{
+			name:        "max-iterations out of range",
+			frontmatter: map[string]any{"continuation": map[string]any{"max-iterations": 50}},
+			wantErr:     "continuation.max-iterations must be between 2 and 10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := extractContinuationConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "should parse continuation config")
+			assert.Equal(t, tt.expected, config, "continuation config")
+		})
+	}
+}
+
+func TestAddContinuationInputs(t *testing.T) {
+	t.Run("adds workflow_dispatch to string trigger", func(t *testing.T) {
+		frontmatter := map[string]any{"on": "issues", "continuation": true}
+		addContinuationInputs(frontmatter)
+
+		assert.True(t, hasDispatchInput(frontmatter, ContinuationRunIDInputName), "run id input should be added")
+		assert.True(t, hasDispatchInput(frontmatter, ContinuationIterationInputName), "iteration input should be added")
+		assert.Contains(t, frontmatter["on"], "issues", "original trigger should be kept")
+	})
+
+	t.Run("keeps existing inputs", func(t *testing.T) {
+		existing := map[string]any{"description": "Custom", "type": "string"}
+		frontmatter := map[string]any{
+			"on": map[string]any{"workflow_dispatch": map[string]any{"inputs": map[string]any{
+				ContinuationRunIDInputName: existing,
+				"topic":                    map[string]any{"type": "string"},
+			}}},
+			"continuation": map[string]any{"max-iterations": 4},
+		}
+		addContinuationInputs(frontmatter)
+
+		inputs := frontmatter["on"].(map[string]any)["workflow_dispatch"].(map[string]any)["inputs"].(map[string]any)
+		assert.Equal(t, existing, inputs[ContinuationRunIDInputName], "declared input should not be replaced")
+		assert.Contains(t, inputs, ContinuationIterationInputName, "iteration input should be added")
+		assert.Contains(t, inputs, "topic", "other inputs should be kept")
+	})
+
+	t.Run("continuation disabled", func(t *testing.T) {
+		frontmatter := map[string]any{"on": "issues", "continuation": false}
+		addContinuationInputs(frontmatter)
+		assert.Equal(t, "issues", frontmatter["on"], "trigger should be unchanged")
+	})
+}
+
+func TestAddContinuationClaudeTools(t *testing.T) {
+	assert.Equal(t, "Edit(/tmp/gh-aw/continuation/*),Read,Read(/tmp/gh-aw/continuation/*),Write,Write(/tmp/gh-aw/continuation/*)", addContinuationClaudeTools("Read,Write"))
+	assert.Equal(t, "Edit(/tmp/gh-aw/continuation/*),Read(/tmp/gh-aw/continuation/*),Write(/tmp/gh-aw/continuation/*)", addContinuationClaudeTools(""))
+}
+
+func TestContinuationCompilation(t *testing.T) {
+	compile := func(t *testing.T, permissions string) (string, error) {
+		tmpDir := testutil.TempDir(t, "continuation-test")
+		workflowPath := filepath.Join(tmpDir, "long-task.md")
+		content := "---\non: issues\nengine: claude\npermissions:\n  contents: read\n" + permissions + "continuation:\n  max-iterations: 4\n---\n\nMigrate every module to the new API.\n"
+		require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+		if err := NewCompiler().CompileWorkflow(workflowPath); err != nil {
+			return "", err
+		}
+
+		lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+		require.NoError(t, err, "should read lock file")
+		return string(lockContent), nil
+	}
+
+	t.Run("generates checkpoint steps and continuation job", func(t *testing.T) {
+		lock, err := compile(t, "  actions: read\n")
+		require.NoError(t, err, "workflow should compile")
+
+		for _, expected := range []string{
+			"workflow_dispatch:",
+			"continuation_run_id:",
+			"continuation_iteration:",
+			"<continuation>",
+			"up to 4 runs in total",
+			"- name: Restore continuation checkpoint",
+			"run-id: ${{ inputs.continuation_run_id }}",
+			"- name: Check continuation checkpoint",
+			"- name: Upload continuation checkpoint",
+			"name: continuation-checkpoint",
+			"continuation: ${{ steps.continuation.outputs.status }}",
+			"if: always() && needs.agent.outputs.continuation == 'continue'",
+			"actions: write",
+			"GH_AW_CONTINUATION_MAX_ITERATIONS: \"4\"",
+			"GH_AW_WORKFLOW_FILE: \"long-task.lock.yml\"",
+			"Write(/tmp/gh-aw/continuation/*)",
+		} {
+			assert.Contains(t, lock, expected, "lock file should contain %q", expected)
+		}
+	})
+
+	t.Run("requires actions read permission", func(t *testing.T) {
+		_, err := compile(t, "")
+		require.Error(t, err, "workflow without actions: read should fail")
+		assert.Contains(t, err.Error(), "actions: read", "error should suggest the missing permission")
+	})
+}
@@ -26,7 +26,6 @@ import (
 	"maps"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
@@ -57,7 +56,7 @@ func (e *CopilotEngine) GetExecutionSteps(workflowData *WorkflowData, logFile st
 		// Plugins are installed to ~/.copilot/plugins/ via copilot plugin install command
 		// The CLI also reads plugin-index.json from ~/.copilot/ to discover installed plugins
 		if workflowData.PluginInfo != nil && len(workflowData.PluginInfo.Plugins) > 0 {
-			copilotArgs = append(copilotArgs, "--add-dir", "/home/runner/.copilot/")
+			copilotArgs = append(copilotArgs, "--add-dir", runnerHomeDir(workflowData)+"/.copilot/")
 			copilotExecLog.Printf("Added Copilot config directory to --add-dir for plugin discovery (%d plugins)", len(workflowData.PluginInfo.Plugins))
 		}
 
@@ -225,7 +224,7 @@ COPILOT_CLI_INSTRUCTION="$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"
 	}
 
 	env := map[string]string{
-		"XDG_CONFIG_HOME":           "/home/runner",
+		"XDG_CONFIG_HOME":           runnerHomeDir(workflowData),
 		"COPILOT_AGENT_RUNNER_TYPE": "STANDALONE",
 		"COPILOT_GITHUB_TOKEN":      copilotGitHubToken,
 		// Override GITHUB_STEP_SUMMARY with a path that exists inside the sandbox.
@@ -255,7 +254,7 @@ COPILOT_CLI_INSTRUCTION="$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"
 
 	// Add GH_AW_MCP_CONFIG for MCP server configuration only if there are MCP servers
 	if HasMCPServers(workflowData) {
-		env["GH_AW_MCP_CONFIG"] = "/home/runner/.copilot/mcp-config.json"
+		env["GH_AW_MCP_CONFIG"] = runnerHomeDir(workflowData) + "/.copilot/mcp-config.json"
 	}
 
 	if hasGitHubTool(workflowData.ParsedTools) {
@@ -273,6 +272,9 @@ COPILOT_CLI_INSTRUCTION="$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"
 	// Add GH_AW_SAFE_OUTPUTS if output is needed
 	applySafeOutputEnvToMap(env, workflowData)
 
+	// Add network.allowed entries resolved from repository or organization variables
+	applyAllowedDomainsVarsEnvToMap(env, workflowData)
+
 	// Add GH_AW_STARTUP_TIMEOUT environment variable (in seconds) if startup-timeout is specified
 	if workflowData.ToolsStartupTimeout > 0 {
 		env["GH_AW_STARTUP_TIMEOUT"] = strconv.Itoa(workflowData.ToolsStartupTimeout)
@@ -350,13 +352,7 @@ COPILOT_CLI_INSTRUCTION="$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"
 	}
 
 	// Add timeout at step level (GitHub Actions standard)
-	if workflowData.TimeoutMinutes != "" {
-		// Strip timeout-minutes prefix
-		timeoutValue := strings.TrimPrefix(workflowData.TimeoutMinutes, "timeout-minutes: ")
-		stepLines = append(stepLines, "        timeout-minutes: "+timeoutValue)
-	} else {
-		stepLines = append(stepLines, fmt.Sprintf("        timeout-minutes: %d", int(constants.DefaultAgenticWorkflowTimeout/time.Minute))) // Default timeout for agentic workflows
-	}
+	stepLines = append(stepLines, agentStepTimeoutLine(workflowData))
 
 	// Filter environment variables to only include allowed secrets
 	// This is a security measure to prevent exposing unnecessary secrets to the AWF container
@@ -88,10 +88,16 @@ func (e *CopilotEngine) GetInstallationSteps(workflowData *WorkflowData) []GitHu
 
 	// Generate install steps based on installation scope
 	var npmSteps []GitHubActionStep
-	if installGlobally {
+	if !isLinuxRunner(workflowData) {
+		// The binary installer targets Linux runner layouts; install from npm elsewhere
+		copilotInstallLog.Printf("Using npm installation for Copilot on %s runner", getRunnerOS(workflowData))
+		npmSteps = BuildStandardNpmEngineInstallSteps(config.NpmPackage, config.Version, config.InstallStepName, config.CliName, workflowData)
+		npmSteps = applyNpmEngineInstallOptions(npmSteps, config.NpmPackage, copilotVersion, config.CliName, workflowData)
+	} else if installGlobally {
 		// Use the new installer script for global installation
 		copilotInstallLog.Print("Using new installer script for Copilot installation")
 		npmSteps = GenerateCopilotInstallerSteps(copilotVersion, config.InstallStepName)
+		npmSteps = applyCopilotInstallOptions(npmSteps, copilotVersion, workflowData)
 	}
 
 	// Add Node.js setup step first (before sandbox installation)
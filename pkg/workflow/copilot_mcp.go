@@ -13,7 +13,8 @@ func (e *CopilotEngine) RenderMCPConfig(yaml *strings.Builder, tools map[string]
 	copilotMCPLog.Printf("Rendering MCP config for Copilot engine: mcpTools=%d", len(mcpTools))
 
 	// Create the directory first
-	yaml.WriteString("          mkdir -p /home/runner/.copilot\n")
+	copilotDir := runnerHomeDir(workflowData) + "/.copilot"
+	yaml.WriteString("          mkdir -p " + copilotDir + "\n")
 
 	// Create unified renderer with Copilot-specific options
 	// Copilot uses JSON format with type and tools fields, and inline args
@@ -33,7 +34,7 @@ func (e *CopilotEngine) RenderMCPConfig(yaml *strings.Builder, tools map[string]
 
 	// Use shared JSON MCP config renderer with unified renderer methods
 	options := JSONMCPConfigOptions{
-		ConfigPath:    "/home/runner/.copilot/mcp-config.json",
+		ConfigPath:    copilotDir + "/mcp-config.json",
 		GatewayConfig: gatewayConfig,
 		Renderers: MCPToolRenderers{
 			RenderGitHub: func(yaml *strings.Builder, githubTool any, isLast bool, workflowData *WorkflowData) {
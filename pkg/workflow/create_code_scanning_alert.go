@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"fmt"
+
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -10,6 +12,7 @@ var createCodeScanningAlertLog = logger.New("workflow:create_code_scanning_alert
 type CreateCodeScanningAlertsConfig struct {
 	BaseSafeOutputConfig `yaml:",inline"`
 	Driver               string   `yaml:"driver,omitempty"`        // Driver name for SARIF tool.driver.name field (default: "GitHub Agentic Workflows Security Scanner")
+	Category             string   `yaml:"category,omitempty"`      // Code scanning analysis category (default: "gh-aw/<workflow-id>")
 	TargetRepoSlug       string   `yaml:"target-repo,omitempty"`   // Target repository in format "owner/repo" for cross-repository code scanning alert creation
 	AllowedRepos         []string `yaml:"allowed-repos,omitempty"` // List of additional repositories in format "owner/repo" that code scanning alerts can be created in
 }
@@ -32,6 +35,13 @@ func (c *Compiler) parseCodeScanningAlertsConfig(outputMap map[string]any) *Crea
 			}
 		}
 
+		// Parse category
+		if category, exists := configMap["category"]; exists {
+			if categoryStr, ok := category.(string); ok {
+				securityReportsConfig.Category = categoryStr
+			}
+		}
+
 		// Parse target-repo
 		securityReportsConfig.TargetRepoSlug = parseTargetRepoFromConfig(configMap)
 
@@ -48,3 +58,30 @@ func (c *Compiler) parseCodeScanningAlertsConfig(outputMap map[string]any) *Crea
 
 	return securityReportsConfig
 }
+
+// codeScanningSarifUploadStepID is the id of the step uploading the generated SARIF file
+const codeScanningSarifUploadStepID = "upload_code_scanning_sarif"
+
+// buildCodeScanningSarifUploadSteps generates the step uploading the SARIF file written by the
+// create_code_scanning_alert handler to Code Scanning. Each workflow uploads under its own
+// category so the findings of different workflows don't replace each other.
+func buildCodeScanningSarifUploadSteps(config *CreateCodeScanningAlertsConfig, workflowID string) []string {
+	category := config.Category
+	if category == "" {
+		category = "gh-aw/" + workflowID
+	}
+	createCodeScanningAlertLog.Printf("Building SARIF upload step: category=%s", category)
+
+	var steps []string
+	steps = append(steps, "      - name: Upload SARIF to code scanning\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", codeScanningSarifUploadStepID))
+	steps = append(steps, "        if: steps.process_safe_outputs.outputs.sarif_file != ''\n")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("github/codeql-action/upload-sarif")))
+	steps = append(steps, "        with:\n")
+	steps = append(steps, "          sarif_file: ${{ steps.process_safe_outputs.outputs.sarif_file }}\n")
+	steps = append(steps, fmt.Sprintf("          category: %s\n", category))
+	if config.GitHubToken != "" {
+		steps = append(steps, fmt.Sprintf("          token: %s\n", config.GitHubToken))
+	}
+	return steps
+}
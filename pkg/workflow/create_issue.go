@@ -11,16 +11,18 @@ var createIssueLog = logger.New("workflow:create_issue")
 // CreateIssuesConfig holds configuration for creating GitHub issues from agent output
 type CreateIssuesConfig struct {
 	BaseSafeOutputConfig `yaml:",inline"`
-	TitlePrefix          string   `yaml:"title-prefix,omitempty"`
-	Labels               []string `yaml:"labels,omitempty"`
-	AllowedLabels        []string `yaml:"allowed-labels,omitempty"`     // Optional list of allowed labels. If omitted, any labels are allowed (including creating new ones).
-	Assignees            []string `yaml:"assignees,omitempty"`          // List of users/bots to assign the issue to
-	TargetRepoSlug       string   `yaml:"target-repo,omitempty"`        // Target repository in format "owner/repo" for cross-repository issues
-	AllowedRepos         []string `yaml:"allowed-repos,omitempty"`      // List of additional repositories that issues can be created in
-	CloseOlderIssues     *string  `yaml:"close-older-issues,omitempty"` // When true, close older issues with same title prefix or labels as "not planned"
-	Expires              int      `yaml:"expires,omitempty"`            // Hours until the issue expires and should be automatically closed
-	Group                *string  `yaml:"group,omitempty"`              // If true, group issues as sub-issues under a parent issue (workflow ID is used as group identifier)
-	Footer               *string  `yaml:"footer,omitempty"`             // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
+	TitlePrefix          string                   `yaml:"title-prefix,omitempty"`
+	Labels               []string                 `yaml:"labels,omitempty"`
+	AllowedLabels        []string                 `yaml:"allowed-labels,omitempty"`     // Optional list of allowed labels. If omitted, any labels are allowed (including creating new ones).
+	Assignees            []string                 `yaml:"assignees,omitempty"`          // List of users/bots to assign the issue to
+	TargetRepoSlug       string                   `yaml:"target-repo,omitempty"`        // Target repository in format "owner/repo" for cross-repository issues
+	GitHubApp            *GitHubAppConfig         `yaml:"github-app,omitempty"`         // GitHub App used to mint a token scoped to target-repo
+	AllowedRepos         []string                 `yaml:"allowed-repos,omitempty"`      // List of additional repositories that issues can be created in
+	CloseOlderIssues     *string                  `yaml:"close-older-issues,omitempty"` // When true, close older issues with same title prefix or labels as "not planned"
+	Expires              int                      `yaml:"expires,omitempty"`            // Hours until the issue expires and should be automatically closed
+	Group                *string                  `yaml:"group,omitempty"`              // If true, group issues as sub-issues under a parent issue (workflow ID is used as group identifier)
+	Footer               *string                  `yaml:"footer,omitempty"`             // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
+	Dedupe               *CreateIssueDedupeConfig `yaml:"dedupe,omitempty"`             // Comment on an existing open issue with the same fingerprint instead of opening a duplicate
 }
 
 // parseIssuesConfig handles create-issue configuration
@@ -76,6 +78,9 @@ func (c *Compiler) parseIssuesConfig(outputMap map[string]any) *CreateIssuesConf
 		config.Max = defaultIntStr(1)
 	}
 
+	// Fill in the dedupe fingerprint and window when dedupe is configured
+	applyCreateIssueDedupeDefaults(config.Dedupe)
+
 	// Validate target-repo (wildcard "*" is not allowed)
 	if validateTargetRepoSlug(config.TargetRepoSlug, createIssueLog) {
 		return nil // Invalid configuration, return nil to cause validation error
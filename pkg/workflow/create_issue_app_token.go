@@ -0,0 +1,77 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var createIssueAppTokenLog = logger.New("workflow:create_issue_app_token")
+
+// createIssueAppTokenStepID is the id of the step minting the cross-repository create-issue token
+const createIssueAppTokenStepID = "create-issue-app-token"
+
+// literalRepoSlugPattern matches a literal "owner/repo" slug (no expressions)
+var literalRepoSlugPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9_.-]+$`)
+
+// validateCreateIssueGitHubApp validates create-issue.github-app. The minted token is scoped to
+// target-repo only, so target-repo must be a literal slug and the issue cannot be redirected to
+// other repositories.
+func validateCreateIssueGitHubApp(config *SafeOutputsConfig) error {
+	if config == nil || config.CreateIssues == nil || config.CreateIssues.GitHubApp == nil {
+		return nil
+	}
+	createIssue := config.CreateIssues
+	createIssueAppTokenLog.Printf("Validating create-issue github-app: target-repo=%s", createIssue.TargetRepoSlug)
+
+	if createIssue.GitHubApp.AppID == "" || createIssue.GitHubApp.PrivateKey == "" {
+		return errors.New("safe-outputs.create-issue.github-app requires both app-id and private-key")
+	}
+	if !literalRepoSlugPattern.MatchString(createIssue.TargetRepoSlug) {
+		return fmt.Errorf("safe-outputs.create-issue.github-app requires target-repo to be a literal 'owner/repo' (found %q); the token is minted for that repository only", createIssue.TargetRepoSlug)
+	}
+	if createIssue.GitHubToken != "" {
+		return errors.New("safe-outputs.create-issue: github-token and github-app are mutually exclusive")
+	}
+	if len(createIssue.AllowedRepos) > 0 {
+		return errors.New("safe-outputs.create-issue: allowed-repos cannot be used with github-app because the token is scoped to target-repo only")
+	}
+	return nil
+}
+
+// createIssueGitHubToken returns the per-handler token for create-issue: the token minted for
+// target-repo when github-app is configured, otherwise the configured github-token
+func createIssueGitHubToken(config *CreateIssuesConfig) string {
+	if config.GitHubApp != nil {
+		return fmt.Sprintf("${{ steps.%s.outputs.token }}", createIssueAppTokenStepID)
+	}
+	return config.GitHubToken
+}
+
+// buildCreateIssueAppTokenSteps generates the step exchanging the GitHub App credentials for an
+// installation token limited to target-repo and the issues permission
+func buildCreateIssueAppTokenSteps(config *CreateIssuesConfig) []string {
+	owner, repo, _ := strings.Cut(config.TargetRepoSlug, "/")
+	createIssueAppTokenLog.Printf("Building create-issue token exchange step: owner=%s, repo=%s", owner, repo)
+
+	var steps []string
+	steps = append(steps, "      - name: Generate GitHub App token for cross-repository issues\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", createIssueAppTokenStepID))
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/create-github-app-token")))
+	steps = append(steps, "        with:\n")
+	steps = append(steps, fmt.Sprintf("          app-id: %s\n", config.GitHubApp.AppID))
+	steps = append(steps, fmt.Sprintf("          private-key: %s\n", config.GitHubApp.PrivateKey))
+	steps = append(steps, fmt.Sprintf("          owner: %s\n", owner))
+	steps = append(steps, fmt.Sprintf("          repositories: %s\n", repo))
+	steps = append(steps, "          github-api-url: ${{ github.api_url }}\n")
+	steps = append(steps, "          permission-issues: write\n")
+	return steps
+}
+
+// buildCreateIssueAppTokenInvalidationStep revokes the cross-repository create-issue token
+func (c *Compiler) buildCreateIssueAppTokenInvalidationStep() []string {
+	return c.buildGitHubAppTokenInvalidationStep(createIssueAppTokenStepID, "Invalidate cross-repository issues token")
+}
@@ -0,0 +1,134 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileCreateIssueWithGitHubApp(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "create-issue-app-token-test")
+	workflowPath := filepath.Join(tmpDir, "tracker.md")
+	content := `---
+on: issues
+engine: copilot
+permissions:
+  contents: read
+safe-outputs:
+  create-issue:
+    target-repo: my-org/tracker
+    github-app:
+      app-id: ${{ vars.APP_ID }}
+      private-key: ${{ secrets.APP_PRIVATE_KEY }}
+---
+
+File a tracking issue.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	mintIndex := strings.Index(lock, "id: create-issue-app-token")
+	require.NotEqual(t, -1, mintIndex, "token exchange step should be generated")
+	assert.Less(t, mintIndex, strings.Index(lock, "id: process_safe_outputs"), "token should be minted before the handlers run")
+	mintStep := lock[mintIndex:strings.Index(lock, "id: process_safe_outputs")]
+	assert.Contains(t, mintStep, "owner: my-org", "token should be minted for the target owner")
+	assert.Contains(t, mintStep, "repositories: tracker", "token should be limited to the target repository")
+	assert.Contains(t, mintStep, "permission-issues: write", "token should only grant issue access")
+	assert.NotContains(t, mintStep, "permission-contents", "token should not grant contents access")
+
+	assert.Contains(t, lock, `\"github-token\":\"${{ steps.create-issue-app-token.outputs.token }}\"`, "create-issue handler should use the minted token")
+	assert.Contains(t, lock, "Invalidate cross-repository issues token", "token should be revoked after the job")
+}
+
+func TestCompileCreateIssueWithGitHubAppAssignsCopilotWithAgentToken(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "create-issue-app-token-copilot-test")
+	workflowPath := filepath.Join(tmpDir, "tracker.md")
+	content := `---
+on: issues
+engine: copilot
+permissions:
+  contents: read
+safe-outputs:
+  create-issue:
+    target-repo: my-org/tracker
+    assignees: copilot
+    github-app:
+      app-id: ${{ vars.APP_ID }}
+      private-key: ${{ secrets.APP_PRIVATE_KEY }}
+---
+
+File a tracking issue for Copilot.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assignIndex := strings.Index(lock, "name: Assign Copilot to created issues")
+	require.NotEqual(t, -1, assignIndex, "copilot assignment step should be generated")
+	assignStep := lock[assignIndex:]
+	assignStep = assignStep[:strings.Index(assignStep, "script: |")]
+	assert.Contains(t, assignStep, "secrets.GH_AW_AGENT_TOKEN", "copilot assignment should use the agent token")
+	assert.NotContains(t, assignStep, "create-issue-app-token", "copilot assignment should not use the issues-only app token")
+}
+
+func TestBuildCreateIssueAppTokenInvalidationStep(t *testing.T) {
+	step := strings.Join(NewCompiler().buildCreateIssueAppTokenInvalidationStep(), "")
+
+	assert.Contains(t, step, "name: Invalidate cross-repository issues token", "step should be named for the create-issue token")
+	assert.Contains(t, step, "if: always() && steps.create-issue-app-token.outputs.token != ''", "step should only run when the token was minted")
+	assert.Contains(t, step, "TOKEN: ${{ steps.create-issue-app-token.outputs.token }}", "step should revoke the create-issue token")
+	assert.NotContains(t, step, "safe-outputs-app-token", "step should not revoke the safe outputs app token")
+}
+
+func TestValidateCreateIssueGitHubApp(t *testing.T) {
+	app := &GitHubAppConfig{AppID: "${{ vars.APP_ID }}", PrivateKey: "${{ secrets.APP_PRIVATE_KEY }}"}
+
+	tests := []struct {
+		name    string
+		config  *CreateIssuesConfig
+		wantErr string
+	}{
+		{name: "valid", config: &CreateIssuesConfig{TargetRepoSlug: "my-org/tracker", GitHubApp: app}},
+		{name: "no app", config: &CreateIssuesConfig{}},
+		{name: "missing target repo", config: &CreateIssuesConfig{GitHubApp: app}, wantErr: "requires target-repo"},
+		{name: "expression target repo", config: &CreateIssuesConfig{TargetRepoSlug: "${{ inputs.repo }}", GitHubApp: app}, wantErr: "literal 'owner/repo'"},
+		{name: "missing private key", config: &CreateIssuesConfig{TargetRepoSlug: "my-org/tracker", GitHubApp: &GitHubAppConfig{AppID: "1"}}, wantErr: "app-id and private-key"},
+		{
+			name: "with github-token",
+			config: &CreateIssuesConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{GitHubToken: "${{ secrets.PAT }}"},
+				TargetRepoSlug:       "my-org/tracker",
+				GitHubApp:            app,
+			},
+			wantErr: "mutually exclusive",
+		},
+		{name: "with allowed repos", config: &CreateIssuesConfig{TargetRepoSlug: "my-org/tracker", AllowedRepos: []string{"my-org/other"}, GitHubApp: app}, wantErr: "allowed-repos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCreateIssueGitHubApp(&SafeOutputsConfig{CreateIssues: tt.config})
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "configuration should be valid")
+				return
+			}
+			require.Error(t, err, "configuration should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var createIssueDedupeLog = logger.New("workflow:create_issue_dedupe")
+
+const (
+	// issueDedupeByTitleHash fingerprints issues by a hash of their normalized title
+	issueDedupeByTitleHash = "title-hash"
+	// defaultIssueDedupeWindow is how far back the safe outputs job looks for a duplicate issue
+	defaultIssueDedupeWindow = "30d"
+)
+
+// CreateIssueDedupeConfig holds the create-issue deduplication configuration (create-issue.dedupe).
+// When an open issue created by the same workflow with the same fingerprint exists within the
+// window, the safe outputs job comments on it instead of opening a duplicate.
+//
+// Example:
+//
+//	safe-outputs:
+//	  create-issue:
+//	    dedupe:
+//	      by: title-hash
+//	      window: 30d
+type CreateIssueDedupeConfig struct {
+	By     string `yaml:"by,omitempty"`     // Fingerprint used to match issues (title-hash)
+	Window string `yaml:"window,omitempty"` // How far back to look for an existing issue, e.g. "30d" or "2w"
+}
+
+// applyCreateIssueDedupeDefaults fills in the fingerprint and window left out of create-issue.dedupe
+func applyCreateIssueDedupeDefaults(dedupe *CreateIssueDedupeConfig) {
+	if dedupe == nil {
+		return
+	}
+	if dedupe.By == "" {
+		dedupe.By = issueDedupeByTitleHash
+	}
+	if dedupe.Window == "" {
+		dedupe.Window = defaultIssueDedupeWindow
+	}
+}
+
+// validateCreateIssueDedupe validates create-issue.dedupe
+func validateCreateIssueDedupe(config *SafeOutputsConfig) error {
+	if config == nil || config.CreateIssues == nil || config.CreateIssues.Dedupe == nil {
+		return nil
+	}
+	dedupe := config.CreateIssues.Dedupe
+	createIssueDedupeLog.Printf("Validating create-issue dedupe: by=%s, window=%s", dedupe.By, dedupe.Window)
+
+	if dedupe.By != issueDedupeByTitleHash {
+		return fmt.Errorf("safe-outputs.create-issue.dedupe.by must be %q, got %q", issueDedupeByTitleHash, dedupe.By)
+	}
+	if parseRelativeTimeSpec(dedupe.Window) == 0 {
+		return fmt.Errorf("safe-outputs.create-issue.dedupe.window must be a duration such as 12h, 30d, 2w or 1m, got %q", dedupe.Window)
+	}
+	return nil
+}
+
+// createIssueDedupeBy returns the fingerprint passed to the create_issue handler, or "" when
+// deduplication is disabled
+func createIssueDedupeBy(config *CreateIssuesConfig) string {
+	if config.Dedupe == nil {
+		return ""
+	}
+	return config.Dedupe.By
+}
+
+// createIssueDedupeWindowHours returns the deduplication window in hours
+func createIssueDedupeWindowHours(config *CreateIssuesConfig) int {
+	if config.Dedupe == nil {
+		return 0
+	}
+	return parseRelativeTimeSpec(config.Dedupe.Window)
+}
@@ -0,0 +1,106 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIssuesConfigDedupe(t *testing.T) {
+	tests := []struct {
+		name     string
+		dedupe   any
+		expected *CreateIssueDedupeConfig
+	}{
+		{
+			name:     "defaults",
+			dedupe:   map[string]any{},
+			expected: &CreateIssueDedupeConfig{By: "title-hash", Window: "30d"},
+		},
+		{
+			name:     "custom window",
+			dedupe:   map[string]any{"by": "title-hash", "window": "2w"},
+			expected: &CreateIssueDedupeConfig{By: "title-hash", Window: "2w"},
+		},
+		{
+			name: "not configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createIssue := map[string]any{}
+			if tt.dedupe != nil {
+				createIssue["dedupe"] = tt.dedupe
+			}
+			config := NewCompiler().parseIssuesConfig(map[string]any{"create-issue": createIssue})
+			require.NotNil(t, config, "create-issue config should parse")
+			assert.Equal(t, tt.expected, config.Dedupe, "dedupe config")
+		})
+	}
+}
+
+func TestValidateCreateIssueDedupe(t *testing.T) {
+	tests := []struct {
+		name    string
+		dedupe  *CreateIssueDedupeConfig
+		wantErr string
+	}{
+		{name: "valid", dedupe: &CreateIssueDedupeConfig{By: "title-hash", Window: "12h"}},
+		{name: "not configured"},
+		{name: "unknown fingerprint", dedupe: &CreateIssueDedupeConfig{By: "body-hash", Window: "30d"}, wantErr: `dedupe.by must be "title-hash"`},
+		{name: "invalid window", dedupe: &CreateIssueDedupeConfig{By: "title-hash", Window: "1h"}, wantErr: `dedupe.window must be a duration`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCreateIssueDedupe(&SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{Dedupe: tt.dedupe}})
+			if tt.wantErr == "" {
+				require.NoError(t, err, "dedupe config should be valid")
+				return
+			}
+			require.Error(t, err, "dedupe config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error message")
+		})
+	}
+}
+
+func TestCreateIssueDedupeHandlerConfig(t *testing.T) {
+	config := handlerRegistry["create_issue"](&SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{Dedupe: &CreateIssueDedupeConfig{By: "title-hash", Window: "30d"}}})
+	assert.Equal(t, "title-hash", config["dedupe_by"], "dedupe_by should be passed to the handler")
+	assert.Equal(t, 720, config["dedupe_window"], "dedupe_window should be in hours")
+
+	config = handlerRegistry["create_issue"](&SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{}})
+	assert.NotContains(t, config, "dedupe_by", "dedupe_by should be omitted when dedupe is not configured")
+	assert.NotContains(t, config, "dedupe_window", "dedupe_window should be omitted when dedupe is not configured")
+}
+
+func TestCreateIssueDedupeCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "create-issue-dedupe-*")
+	workflowPath := filepath.Join(tmpDir, "dedupe.md")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+    dedupe:
+      window: 2w
+---
+
+Report flaky tests.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "dedupe.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	assert.Contains(t, string(lockContent), `\"dedupe_by\":\"title-hash\",\"dedupe_window\":336`, "handler config should include dedupe")
+}
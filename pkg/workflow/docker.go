@@ -94,8 +94,11 @@ func collectDockerImages(tools map[string]any, workflowData *WorkflowData, actio
 			dockerLog.Printf("Added AWF squid (proxy) container: %s", squidImage)
 		}
 
-		// Add default agent container
+		// Add agent container (the digest-pinned engine container replaces the default image)
 		agentImage := constants.DefaultFirewallRegistry + "/agent:" + awfImageTag
+		if workflowData.EngineConfig != nil && workflowData.EngineConfig.Container != "" {
+			agentImage = workflowData.EngineConfig.Container
+		}
 		if !imageSet[agentImage] {
 			images = append(images, agentImage)
 			imageSet[agentImage] = true
@@ -110,6 +110,21 @@ var GeminiDefaultDomains = []string{
 	"registry.npmjs.org",
 }
 
+// GitHubModelsDefaultDomains are the default domains required for GitHub Models inference
+var GitHubModelsDefaultDomains = []string{
+	"api.github.com",
+	"github.com",
+	"host.docker.internal",
+	"models.github.ai",
+}
+
+// OllamaDefaultDomains are the default domains required by the ollama engine.
+// The model server of a self-hosted runner is reached through host.docker.internal from the
+// firewall sandbox; endpoints on other hosts are added from engine.endpoint.
+var OllamaDefaultDomains = []string{
+	"host.docker.internal",
+}
+
 // PlaywrightDomains are the domains required for Playwright browser downloads
 // These domains are needed when Playwright MCP server initializes in the Docker container
 var PlaywrightDomains = []string{
@@ -490,6 +505,11 @@ func mergeDomainsWithNetworkToolsAndRuntimes(defaultDomains []string, network *N
 		}
 	}
 
+	// Add web-search domains so the agent can browse the sites it is allowed to search
+	for _, domain := range webSearchAllowedDomains(tools) {
+		domainMap[domain] = true
+	}
+
 	// Add runtime ecosystem domains (if runtimes are specified)
 	if runtimes != nil {
 		runtimeDomains := getDomainsFromRuntimes(runtimes)
@@ -512,10 +532,12 @@ func mergeDomainsWithNetworkToolsAndRuntimes(defaultDomains []string, network *N
 // engineDefaultDomains maps each engine to its default required domains.
 // Add new engines here to avoid adding new engine-specific domain functions.
 var engineDefaultDomains = map[constants.EngineName][]string{
-	constants.CopilotEngine: CopilotDefaultDomains,
-	constants.ClaudeEngine:  ClaudeDefaultDomains,
-	constants.CodexEngine:   CodexDefaultDomains,
-	constants.GeminiEngine:  GeminiDefaultDomains,
+	constants.CopilotEngine:      CopilotDefaultDomains,
+	constants.ClaudeEngine:       ClaudeDefaultDomains,
+	constants.CodexEngine:        CodexDefaultDomains,
+	constants.GeminiEngine:       GeminiDefaultDomains,
+	constants.GitHubModelsEngine: GitHubModelsDefaultDomains,
+	constants.OllamaEngine:       OllamaDefaultDomains,
 }
 
 // GetAllowedDomainsForEngine merges the engine's default domains with NetworkPermissions,
@@ -614,6 +636,21 @@ func formatBlockedDomains(network *NetworkPermissions) string {
 // computeAllowedDomainsForSanitization computes the allowed domains for sanitization
 // based on the engine and network configuration, matching what's provided to the firewall
 func (c *Compiler) computeAllowedDomainsForSanitization(data *WorkflowData) string {
+	domains := c.computeLiteralAllowedDomainsForSanitization(data)
+
+	// Variable-backed network.allowed entries are expanded by GitHub Actions in the env value
+	if data.NetworkPermissions != nil && len(data.NetworkPermissions.AllowedVars) > 0 {
+		entries := append([]string{}, data.NetworkPermissions.AllowedVars...)
+		if domains != "" {
+			entries = append([]string{domains}, entries...)
+		}
+		return strings.Join(entries, ",")
+	}
+	return domains
+}
+
+// computeLiteralAllowedDomainsForSanitization computes the allowed domains known at compile time
+func (c *Compiler) computeLiteralAllowedDomainsForSanitization(data *WorkflowData) string {
 	// Determine which engine is being used
 	var engineID string
 	if data.EngineConfig != nil {
@@ -633,6 +670,10 @@ func (c *Compiler) computeAllowedDomainsForSanitization(data *WorkflowData) stri
 		return GetClaudeAllowedDomainsWithToolsAndRuntimes(data.NetworkPermissions, data.Tools, data.Runtimes)
 	case "gemini":
 		return GetGeminiAllowedDomainsWithToolsAndRuntimes(data.NetworkPermissions, data.Tools, data.Runtimes)
+	case string(constants.GitHubModelsEngine):
+		return GetAllowedDomainsForEngine(constants.GitHubModelsEngine, data.NetworkPermissions, data.Tools, data.Runtimes)
+	case string(constants.OllamaEngine):
+		return GetOllamaAllowedDomains(data)
 	default:
 		// For other engines, use network permissions only
 		domains := GetAllowedDomains(data.NetworkPermissions)
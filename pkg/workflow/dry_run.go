@@ -0,0 +1,86 @@
+package workflow
+
+import (
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var dryRunLog = logger.New("workflow:dry_run")
+
+// DryRunInputName is the workflow_dispatch input that runs the safe output jobs in staged mode
+const DryRunInputName = "dry_run"
+
+// dryRunStagedExpression evaluates to "true" when a manual run was dispatched with dry_run set.
+// Both boolean and string inputs are accepted so user-defined dry_run inputs keep working.
+const dryRunStagedExpression = "${{ (inputs.dry_run == true || inputs.dry_run == 'true') && 'true' || '' }}"
+
+// addDryRunInput adds the dry_run input to on.workflow_dispatch of workflows with safe outputs.
+// Dispatching with dry_run: true runs the agent normally while the safe output jobs only
+// preview what they would do (staged mode). Workflows that are always staged, that cannot be
+// dispatched manually or that declare their own dry_run input are left unchanged.
+func addDryRunInput(frontmatter map[string]any) {
+	safeOutputs, ok := frontmatter["safe-outputs"].(map[string]any)
+	if !ok {
+		return
+	}
+	if staged, _ := safeOutputs["staged"].(bool); staged {
+		return
+	}
+
+	onMap, ok := frontmatter["on"].(map[string]any)
+	if !ok {
+		return
+	}
+	dispatchValue, hasDispatch := onMap["workflow_dispatch"]
+	if !hasDispatch {
+		return
+	}
+	var dispatch map[string]any
+	switch existing := dispatchValue.(type) {
+	case nil:
+		dispatch = map[string]any{}
+	case map[string]any:
+		dispatch = existing
+	default:
+		return
+	}
+
+	inputs, ok := dispatch["inputs"].(map[string]any)
+	if !ok {
+		if dispatch["inputs"] != nil {
+			return
+		}
+		inputs = map[string]any{}
+	}
+	if _, exists := inputs[DryRunInputName]; exists {
+		dryRunLog.Print("Workflow declares its own dry_run input")
+		return
+	}
+
+	inputs[DryRunInputName] = map[string]any{
+		"description": "Preview safe outputs without writing to GitHub",
+		"required":    false,
+		"type":        "boolean",
+		"default":     false,
+	}
+	dispatch["inputs"] = inputs
+	onMap["workflow_dispatch"] = dispatch
+	dryRunLog.Print("Added dry_run input to workflow_dispatch")
+}
+
+// hasDryRunInput reports whether the workflow can be dispatched with a dry_run input
+func hasDryRunInput(frontmatter map[string]any) bool {
+	onMap, ok := frontmatter["on"].(map[string]any)
+	if !ok {
+		return false
+	}
+	dispatch, ok := onMap["workflow_dispatch"].(map[string]any)
+	if !ok {
+		return false
+	}
+	inputs, ok := dispatch["inputs"].(map[string]any)
+	if !ok {
+		return false
+	}
+	_, exists := inputs[DryRunInputName]
+	return exists
+}
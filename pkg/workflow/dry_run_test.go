@@ -0,0 +1,127 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddDryRunInput(t *testing.T) {
+	dryRunInput := map[string]any{
+		"description": "Preview safe outputs without writing to GitHub",
+		"required":    false,
+		"type":        "boolean",
+		"default":     false,
+	}
+
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		wantInputs  map[string]any
+	}{
+		{
+			name: "dispatch without inputs",
+			frontmatter: map[string]any{
+				"on":           map[string]any{"workflow_dispatch": nil},
+				"safe-outputs": map[string]any{"add-comment": nil},
+			},
+			wantInputs: map[string]any{DryRunInputName: dryRunInput},
+		},
+		{
+			name: "dispatch with inputs",
+			frontmatter: map[string]any{
+				"on":           map[string]any{"workflow_dispatch": map[string]any{"inputs": map[string]any{"topic": map[string]any{"type": "string"}}}},
+				"safe-outputs": map[string]any{"create-issue": nil},
+			},
+			wantInputs: map[string]any{"topic": map[string]any{"type": "string"}, DryRunInputName: dryRunInput},
+		},
+		{
+			name: "user-defined dry_run input is kept",
+			frontmatter: map[string]any{
+				"on":           map[string]any{"workflow_dispatch": map[string]any{"inputs": map[string]any{DryRunInputName: map[string]any{"type": "string"}}}},
+				"safe-outputs": map[string]any{"create-issue": nil},
+			},
+			wantInputs: map[string]any{DryRunInputName: map[string]any{"type": "string"}},
+		},
+		{
+			name: "no safe outputs",
+			frontmatter: map[string]any{
+				"on": map[string]any{"workflow_dispatch": nil},
+			},
+		},
+		{
+			name: "always staged",
+			frontmatter: map[string]any{
+				"on":           map[string]any{"workflow_dispatch": nil},
+				"safe-outputs": map[string]any{"staged": true, "create-issue": nil},
+			},
+		},
+		{
+			name: "no workflow_dispatch",
+			frontmatter: map[string]any{
+				"on":           map[string]any{"issues": map[string]any{"types": []any{"opened"}}},
+				"safe-outputs": map[string]any{"add-comment": nil},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addDryRunInput(tt.frontmatter)
+			onMap := tt.frontmatter["on"].(map[string]any)
+			if tt.wantInputs == nil {
+				if dispatch, ok := onMap["workflow_dispatch"].(map[string]any); ok {
+					assert.NotContains(t, dispatch, "inputs", "no inputs should be added")
+				}
+				assert.False(t, hasDryRunInput(tt.frontmatter), "workflow should not have a dry_run input")
+				return
+			}
+			dispatch, ok := onMap["workflow_dispatch"].(map[string]any)
+			require.True(t, ok, "workflow_dispatch should be an object")
+			assert.Equal(t, tt.wantInputs, dispatch["inputs"], "workflow_dispatch inputs")
+			assert.True(t, hasDryRunInput(tt.frontmatter), "workflow should have a dry_run input")
+		})
+	}
+}
+
+func TestDryRunInputCompiledWorkflow(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "dry-run-test")
+	workflowFile := filepath.Join(tmpDir, "dry-run.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on:
+  issues:
+    types: [opened]
+  workflow_dispatch:
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+---
+
+# Dry run
+`), 0644), "should write workflow")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "should compile workflow")
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "dry-run.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+
+	var lock map[string]any
+	require.NoError(t, yaml.Unmarshal(lockContent, &lock), "lock file should be valid YAML")
+	on := lock["on"].(map[string]any)
+	inputs := on["workflow_dispatch"].(map[string]any)["inputs"].(map[string]any)
+	dryRun := inputs[DryRunInputName].(map[string]any)
+	assert.Equal(t, "boolean", dryRun["type"], "dry_run should be a boolean input")
+	assert.Equal(t, false, dryRun["default"], "dry_run should default to false")
+
+	jobs := lock["jobs"].(map[string]any)
+	safeOutputsEnv := jobs["safe_outputs"].(map[string]any)["env"].(map[string]any)
+	assert.Equal(t, dryRunStagedExpression, safeOutputsEnv["GH_AW_SAFE_OUTPUTS_STAGED"], "safe outputs should be staged on dry runs")
+}
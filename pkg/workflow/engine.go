@@ -18,14 +18,18 @@ type EngineConfig struct {
 	Model            string
 	MaxTurns         string
 	MaxContinuations int    // Maximum number of continuations for autopilot mode (copilot engine only; > 1 enables --autopilot)
+	MaxToolCalls     int    // Maximum number of tool calls before the agent is stopped (claude engine only)
 	Concurrency      string // Agent job-level concurrency configuration (YAML format)
 	UserAgent        string
 	Command          string // Custom executable path (when set, skip installation steps)
 	Env              map[string]string
 	Config           string
 	Args             []string
-	Firewall         *FirewallConfig // AWF firewall configuration
-	Agent            string          // Agent identifier for copilot --agent flag (copilot engine only)
+	Firewall         *FirewallConfig       // AWF firewall configuration
+	Agent            string                // Agent identifier for copilot --agent flag (copilot engine only)
+	Install          *EngineInstallOptions // CLI installation options (integrity pinning, mirror, cache)
+	Container        string                // Digest-pinned image the agent CLI runs in (replaces the default AWF agent image)
+	Endpoint         string                // Model server URL (ollama engine only)
 }
 
 // NetworkPermissions represents network access permissions for workflow execution
@@ -62,6 +66,7 @@ type NetworkPermissions struct {
 	Allowed           []string        `yaml:"allowed,omitempty"`  // List of allowed domains or ecosystem identifiers (e.g., "defaults", "github", "python")
 	Blocked           []string        `yaml:"blocked,omitempty"`  // List of blocked domains (takes precedence over allowed)
 	Firewall          *FirewallConfig `yaml:"firewall,omitempty"` // AWF firewall configuration (see firewall.go)
+	AllowedVars       []string        `yaml:"-"`                  // ${{ vars.NAME }} entries of allowed, resolved to comma-separated domains at runtime
 	ExplicitlyDefined bool            `yaml:"-"`                  // Internal flag: true if network field was explicitly set in frontmatter
 }
 
@@ -112,6 +117,9 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 					config.MaxTurns = strconv.Itoa(maxTurnsInt)
 				} else if maxTurnsUint64, ok := maxTurns.(uint64); ok {
 					config.MaxTurns = strconv.FormatUint(maxTurnsUint64, 10)
+				} else if maxTurnsFloat, ok := maxTurns.(float64); ok {
+					// Engine configurations from imports are decoded from JSON
+					config.MaxTurns = strconv.Itoa(int(maxTurnsFloat))
 				} else if maxTurnsStr, ok := maxTurns.(string); ok {
 					config.MaxTurns = maxTurnsStr
 				}
@@ -123,6 +131,8 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 					config.MaxContinuations = maxContInt
 				} else if maxContUint64, ok := maxCont.(uint64); ok {
 					config.MaxContinuations = int(maxContUint64)
+				} else if maxContFloat, ok := maxCont.(float64); ok {
+					config.MaxContinuations = int(maxContFloat)
 				} else if maxContStr, ok := maxCont.(string); ok {
 					if parsed, err := strconv.Atoi(maxContStr); err == nil {
 						config.MaxContinuations = parsed
@@ -130,6 +140,21 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 				}
 			}
 
+			// Extract optional 'max-tool-calls' field
+			if maxToolCalls, hasMaxToolCalls := engineObj["max-tool-calls"]; hasMaxToolCalls {
+				if maxToolCallsInt, ok := maxToolCalls.(int); ok {
+					config.MaxToolCalls = maxToolCallsInt
+				} else if maxToolCallsUint64, ok := maxToolCalls.(uint64); ok {
+					config.MaxToolCalls = int(maxToolCallsUint64)
+				} else if maxToolCallsFloat, ok := maxToolCalls.(float64); ok {
+					config.MaxToolCalls = int(maxToolCallsFloat)
+				} else if maxToolCallsStr, ok := maxToolCalls.(string); ok {
+					if parsed, err := strconv.Atoi(maxToolCallsStr); err == nil {
+						config.MaxToolCalls = parsed
+					}
+				}
+			}
+
 			// Extract optional 'concurrency' field (string or object format)
 			if concurrency, hasConcurrency := engineObj["concurrency"]; hasConcurrency {
 				if concurrencyStr, ok := concurrency.(string); ok {
@@ -211,6 +236,27 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 				}
 			}
 
+			// Extract optional 'install' field (object format)
+			if install, hasInstall := engineObj["install"]; hasInstall {
+				config.Install = parseEngineInstallOptions(install)
+			}
+
+			// Extract optional 'container' field (string - digest-pinned image reference)
+			if container, hasContainer := engineObj["container"]; hasContainer {
+				if containerStr, ok := container.(string); ok {
+					config.Container = containerStr
+					engineLog.Printf("Extracted engine container image: %s", containerStr)
+				}
+			}
+
+			// Extract optional 'endpoint' field (string - model server URL)
+			if endpoint, hasEndpoint := engineObj["endpoint"]; hasEndpoint {
+				if endpointStr, ok := endpoint.(string); ok {
+					config.Endpoint = endpointStr
+					engineLog.Printf("Extracted engine endpoint: %s", endpointStr)
+				}
+			}
+
 			// Extract optional 'firewall' field (object format)
 			if firewall, hasFirewall := engineObj["firewall"]; hasFirewall {
 				if firewallObj, ok := firewall.(map[string]any); ok {
@@ -0,0 +1,121 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEngineContainerImage = "ghcr.io/org/agent-sandbox@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestParseEngineContainer(t *testing.T) {
+	compiler := NewCompiler()
+	_, config := compiler.ExtractEngineConfig(map[string]any{
+		"engine": map[string]any{
+			"id":        "copilot",
+			"container": testEngineContainerImage,
+		},
+	})
+
+	require.NotNil(t, config, "Engine config should be parsed")
+	assert.Equal(t, testEngineContainerImage, config.Container, "Container should be parsed")
+}
+
+func TestValidateEngineContainer(t *testing.T) {
+	tests := []struct {
+		name           string
+		workflowData   *WorkflowData
+		errorSubstring string
+	}{
+		{
+			name:         "no engine container",
+			workflowData: &WorkflowData{EngineConfig: &EngineConfig{ID: "copilot"}},
+		},
+		{
+			name: "digest-pinned image with sandbox",
+			workflowData: &WorkflowData{
+				EngineConfig:       &EngineConfig{ID: "copilot", Container: testEngineContainerImage},
+				NetworkPermissions: &NetworkPermissions{Firewall: &FirewallConfig{Enabled: true}},
+			},
+		},
+		{
+			name: "tag instead of digest",
+			workflowData: &WorkflowData{
+				EngineConfig:       &EngineConfig{ID: "copilot", Container: "ghcr.io/org/agent-sandbox:v1"},
+				NetworkPermissions: &NetworkPermissions{Firewall: &FirewallConfig{Enabled: true}},
+			},
+			errorSubstring: "engine.container must be pinned to a digest",
+		},
+		{
+			name: "short digest",
+			workflowData: &WorkflowData{
+				EngineConfig:       &EngineConfig{ID: "copilot", Container: "ghcr.io/org/agent-sandbox@sha256:abc"},
+				NetworkPermissions: &NetworkPermissions{Firewall: &FirewallConfig{Enabled: true}},
+			},
+			errorSubstring: "engine.container must be pinned to a digest",
+		},
+		{
+			name: "combined with top-level container",
+			workflowData: &WorkflowData{
+				EngineConfig:       &EngineConfig{ID: "copilot", Container: testEngineContainerImage},
+				NetworkPermissions: &NetworkPermissions{Firewall: &FirewallConfig{Enabled: true}},
+				Container:          "container:\n  image: node:20",
+			},
+			errorSubstring: "cannot be combined with the top-level 'container:' field",
+		},
+		{
+			name: "sandbox disabled",
+			workflowData: &WorkflowData{
+				EngineConfig:  &EngineConfig{ID: "copilot", Container: testEngineContainerImage},
+				SandboxConfig: &SandboxConfig{Agent: &AgentSandboxConfig{Disabled: true}},
+			},
+			errorSubstring: "engine.container requires the agent sandbox",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEngineContainer(tt.workflowData)
+			if tt.errorSubstring == "" {
+				assert.NoError(t, err, "engine container should be valid")
+				return
+			}
+			require.Error(t, err, "engine container should be rejected")
+			assert.Contains(t, err.Error(), tt.errorSubstring, "error should explain the problem")
+		})
+	}
+}
+
+func TestEngineContainerCompiles(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "engine-container-test")
+	workflowContent := `---
+on: workflow_dispatch
+engine:
+  id: copilot
+  container: ` + testEngineContainerImage + `
+---
+
+Run in the sandbox.
+`
+	workflowFile := filepath.Join(tmpDir, "engine-container.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "should write workflow file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "workflow with engine container should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "--agent-image "+testEngineContainerImage, "AWF should run the agent in the engine container")
+	assert.NotContains(t, lock, "gh-aw-firewall/agent:", "default AWF agent image should not be pulled")
+	assert.Contains(t, lock, "api.githubcopilot.com", "engine endpoints should stay reachable")
+	assert.NotContains(t, lock, "ocsp.digicert.com", "default ecosystem domains should not be allowed without network config")
+}
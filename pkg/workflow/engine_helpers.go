@@ -102,6 +102,13 @@ func GetBaseInstallationSteps(config EngineInstallConfig, workflowData *Workflow
 		config.CliName,
 		workflowData,
 	)
+
+	// Apply engine.install options (integrity pinning, mirror, cache) using the resolved version
+	version := config.Version
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.Version != "" {
+		version = workflowData.EngineConfig.Version
+	}
+	npmSteps = applyNpmEngineInstallOptions(npmSteps, config.NpmPackage, version, config.CliName, workflowData)
 	steps = append(steps, npmSteps...)
 
 	return steps
@@ -0,0 +1,161 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var engineInstallLog = logger.New("workflow:engine_install")
+
+// copilotCLICacheDir is the directory where the verified Copilot CLI tarball is cached
+const copilotCLICacheDir = "/home/runner/.cache/gh-aw/copilot-cli"
+
+// EngineInstallOptions configures how the engine CLI is installed (engine.install).
+//
+// Example:
+//
+//	engine:
+//	  id: claude
+//	  version: 2.0.14
+//	  install:
+//	    integrity: sha512-...
+//	    mirror: https://npm.internal.example.com/
+//	    cache: true
+type EngineInstallOptions struct {
+	Integrity string // Expected npm package integrity (SRI) for npm-installed CLIs
+	Mirror    string // npm registry URL, or base URL mirroring the Copilot CLI releases
+	Cache     bool   // Cache downloaded CLI files with actions/cache across runs
+}
+
+// parseEngineInstallOptions parses the engine.install object from frontmatter
+func parseEngineInstallOptions(value any) *EngineInstallOptions {
+	installMap, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	options := &EngineInstallOptions{}
+	if integrity, ok := installMap["integrity"].(string); ok {
+		options.Integrity = integrity
+	}
+	if mirror, ok := installMap["mirror"].(string); ok {
+		options.Mirror = mirror
+	}
+	if cache, ok := installMap["cache"].(bool); ok {
+		options.Cache = cache
+	}
+
+	engineInstallLog.Printf("Parsed engine install options: integrity=%v, mirror=%s, cache=%v", options.Integrity != "", options.Mirror, options.Cache)
+	return options
+}
+
+// getEngineInstallOptions returns engine.install from workflowData, or nil if not set.
+// warm-cache: true turns on caching so the agent job restores the CLI warmed by the
+// warm cache workflow.
+func getEngineInstallOptions(workflowData *WorkflowData) *EngineInstallOptions {
+	if workflowData == nil {
+		return nil
+	}
+	var options *EngineInstallOptions
+	if workflowData.EngineConfig != nil {
+		options = workflowData.EngineConfig.Install
+	}
+	if workflowData.WarmCache && (options == nil || !options.Cache) {
+		warmOptions := EngineInstallOptions{Cache: true}
+		if options != nil {
+			warmOptions = *options
+			warmOptions.Cache = true
+		}
+		return &warmOptions
+	}
+	return options
+}
+
+// isPinnedEngineVersion reports whether version refers to a fixed release
+func isPinnedEngineVersion(version string) bool {
+	return version != "" && version != "latest"
+}
+
+// applyNpmEngineInstallOptions applies engine.install to the steps produced by GenerateNpmInstallSteps.
+// The last step is the npm install step; a cache step is inserted right before it when caching is enabled.
+func applyNpmEngineInstallOptions(npmSteps []GitHubActionStep, packageName, version, cliName string, workflowData *WorkflowData) []GitHubActionStep {
+	options := getEngineInstallOptions(workflowData)
+	if options == nil || len(npmSteps) == 0 {
+		return npmSteps
+	}
+
+	engineInstallLog.Printf("Applying install options to %s@%s", packageName, version)
+
+	installStep := npmSteps[len(npmSteps)-1]
+	stepName := strings.TrimPrefix(installStep[0], "      - name: ")
+
+	var install GitHubActionStep
+	if options.Integrity != "" {
+		install = GitHubActionStep{
+			"      - name: " + stepName,
+			fmt.Sprintf("        run: /opt/gh-aw/actions/install_npm_cli.sh %s %s", packageName, version),
+			"        env:",
+			"          GH_AW_NPM_INTEGRITY: " + options.Integrity,
+		}
+		if options.Mirror != "" {
+			install = append(install, "          GH_AW_NPM_REGISTRY: "+options.Mirror)
+		}
+	} else {
+		registryFlag := ""
+		if options.Mirror != "" {
+			registryFlag = fmt.Sprintf("--registry '%s' ", strings.ReplaceAll(options.Mirror, "'", `'\''`))
+		}
+		install = GitHubActionStep{
+			"      - name: " + stepName,
+			fmt.Sprintf("        run: npm install -g %s%s@%s", registryFlag, packageName, version),
+		}
+	}
+
+	steps := append([]GitHubActionStep{}, npmSteps[:len(npmSteps)-1]...)
+	if options.Cache {
+		steps = append(steps, generateEngineInstallCacheStep(cliName, version, "~/.npm"))
+	}
+	return append(steps, install)
+}
+
+// applyCopilotInstallOptions applies engine.install to the steps produced by GenerateCopilotInstallerSteps.
+// The Copilot CLI is always verified against the SHA256SUMS.txt of the official GitHub release,
+// even when the tarball comes from a mirror; engine.install only redirects the tarball download
+// to a mirror and caches the verified tarball.
+func applyCopilotInstallOptions(installerSteps []GitHubActionStep, version string, workflowData *WorkflowData) []GitHubActionStep {
+	options := getEngineInstallOptions(workflowData)
+	if options == nil || len(installerSteps) == 0 || (options.Mirror == "" && !options.Cache) {
+		return installerSteps
+	}
+
+	engineInstallLog.Printf("Applying install options to Copilot CLI %s", version)
+
+	install := append(GitHubActionStep{}, installerSteps[0]...)
+	install = append(install, "        env:")
+	if options.Mirror != "" {
+		install = append(install, "          GH_AW_COPILOT_MIRROR_URL: "+options.Mirror)
+	}
+	if options.Cache {
+		install = append(install, "          GH_AW_COPILOT_CACHE_DIR: "+copilotCLICacheDir)
+	}
+
+	var steps []GitHubActionStep
+	if options.Cache {
+		steps = append(steps, generateEngineInstallCacheStep("copilot", version, copilotCLICacheDir))
+	}
+	steps = append(steps, install)
+	return append(steps, installerSteps[1:]...)
+}
+
+// generateEngineInstallCacheStep creates an actions/cache step for engine CLI downloads
+func generateEngineInstallCacheStep(cliName, version, path string) GitHubActionStep {
+	return GitHubActionStep{
+		fmt.Sprintf("      - name: Cache %s CLI", cliName),
+		"        uses: " + GetActionPin("actions/cache"),
+		"        with:",
+		"          path: " + path,
+		fmt.Sprintf("          key: gh-aw-%s-cli-%s-${{ runner.os }}-${{ runner.arch }}", cliName, version),
+	}
+}
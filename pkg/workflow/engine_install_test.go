@@ -0,0 +1,77 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEngineInstallOptions(t *testing.T) {
+	compiler := NewCompiler()
+	_, config := compiler.ExtractEngineConfig(map[string]any{
+		"engine": map[string]any{
+			"id":      "claude",
+			"version": "2.0.14",
+			"install": map[string]any{
+				"integrity": "sha512-abc==",
+				"mirror":    "https://npm.example.com/",
+				"cache":     true,
+			},
+		},
+	})
+
+	require.NotNil(t, config.Install, "Install options should be parsed")
+	assert.Equal(t, "sha512-abc==", config.Install.Integrity, "Integrity should be parsed")
+	assert.Equal(t, "https://npm.example.com/", config.Install.Mirror, "Mirror should be parsed")
+	assert.True(t, config.Install.Cache, "Cache should be parsed")
+}
+
+func TestApplyNpmEngineInstallOptions(t *testing.T) {
+	npmSteps := GenerateNpmInstallSteps("@anthropic-ai/claude-code", "2.0.14", "Install Claude Code CLI", "claude", true)
+
+	t.Run("no options leaves steps unchanged", func(t *testing.T) {
+		steps := applyNpmEngineInstallOptions(npmSteps, "@anthropic-ai/claude-code", "2.0.14", "claude", &WorkflowData{EngineConfig: &EngineConfig{ID: "claude"}})
+		assert.Equal(t, npmSteps, steps, "Steps should be unchanged without install options")
+	})
+
+	t.Run("mirror adds registry flag", func(t *testing.T) {
+		data := &WorkflowData{EngineConfig: &EngineConfig{ID: "claude", Install: &EngineInstallOptions{Mirror: "https://npm.example.com/"}}}
+		steps := applyNpmEngineInstallOptions(npmSteps, "@anthropic-ai/claude-code", "2.0.14", "claude", data)
+		require.Len(t, steps, 2, "Should keep node setup and install steps")
+		assert.Contains(t, strings.Join(steps[1], "\n"), "npm install -g --registry 'https://npm.example.com/' @anthropic-ai/claude-code@2.0.14", "Install should use the mirror registry")
+	})
+
+	t.Run("integrity and cache use verified installer", func(t *testing.T) {
+		data := &WorkflowData{EngineConfig: &EngineConfig{ID: "claude", Version: "2.0.14", Install: &EngineInstallOptions{Integrity: "sha512-abc==", Cache: true}}}
+		steps := applyNpmEngineInstallOptions(npmSteps, "@anthropic-ai/claude-code", "2.0.14", "claude", data)
+		require.Len(t, steps, 3, "Should add a cache step before the install step")
+		assert.Equal(t, npmSteps[0], steps[0], "Node setup should stay first")
+
+		cache := strings.Join(steps[1], "\n")
+		assert.Contains(t, cache, "actions/cache", "Cache step should use actions/cache")
+		assert.Contains(t, cache, "key: gh-aw-claude-cli-2.0.14-", "Cache key should include the pinned version")
+
+		install := strings.Join(steps[2], "\n")
+		assert.Contains(t, install, "- name: Install Claude Code CLI", "Install step name should be preserved")
+		assert.Contains(t, install, "install_npm_cli.sh @anthropic-ai/claude-code 2.0.14", "Install should use the verifying script")
+		assert.Contains(t, install, "GH_AW_NPM_INTEGRITY: sha512-abc==", "Install should pass the pinned integrity")
+	})
+}
+
+func TestApplyCopilotInstallOptions(t *testing.T) {
+	installerSteps := GenerateCopilotInstallerSteps("0.0.400", "Install GitHub Copilot CLI")
+	data := &WorkflowData{EngineConfig: &EngineConfig{ID: "copilot", Version: "0.0.400", Install: &EngineInstallOptions{Mirror: "https://mirror.example.com/copilot-cli", Cache: true}}}
+
+	steps := applyCopilotInstallOptions(installerSteps, "0.0.400", data)
+	require.Len(t, steps, 2, "Should add a cache step before the installer")
+	assert.Contains(t, strings.Join(steps[0], "\n"), "path: "+copilotCLICacheDir, "Cache step should cache the Copilot CLI download directory")
+
+	install := strings.Join(steps[1], "\n")
+	assert.Contains(t, install, "install_copilot_cli.sh 0.0.400", "Installer should be unchanged")
+	assert.Contains(t, install, "GH_AW_COPILOT_MIRROR_URL: https://mirror.example.com/copilot-cli", "Installer should receive the mirror")
+	assert.Contains(t, install, "GH_AW_COPILOT_CACHE_DIR: "+copilotCLICacheDir, "Installer should receive the cache directory")
+}
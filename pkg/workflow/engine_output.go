@@ -83,6 +83,7 @@ func (c *Compiler) generateEngineOutputCollection(yaml *strings.Builder, engine
 	}
 
 	yaml.WriteString("          if-no-files-found: ignore\n")
+	c.writeArtifactRetention(yaml)
 
 	// Add cleanup step to remove output files after upload
 	// Only clean files under the workspace, ignore files in /tmp/gh-aw/
@@ -10,6 +10,8 @@
 //
 //   - validateEngine() - Validates that a given engine ID is supported
 //   - validateSingleEngineSpecification() - Validates that only one engine field exists across all files
+//   - validateEngineInstallOptions() - Validates engine.install integrity, mirror, and cache settings
+//   - validateEngineContainer() - Validates that engine.container is digest-pinned and runs under AWF
 //
 // # Validation Pattern: Engine Registry
 //
@@ -35,7 +37,9 @@ package workflow
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
@@ -44,6 +48,9 @@ import (
 
 var engineValidationLog = newValidationLogger("engine")
 
+// digestPinnedImagePattern matches container image references pinned to a sha256 digest
+var digestPinnedImagePattern = regexp.MustCompile(`^[^@\s]+@sha256:[a-f0-9]{64}$`)
+
 // validateEngine validates that the given engine ID is supported
 func (c *Compiler) validateEngine(engineID string) error {
 	if engineID == "" {
@@ -191,3 +198,61 @@ func (c *Compiler) validatePluginSupport(pluginInfo *PluginInfo, agenticEngine C
 	engineValidationLog.Printf("Engine %s supports plugins: %d plugins to install", agenticEngine.GetID(), len(pluginInfo.Plugins))
 	return nil
 }
+
+// validateEngineInstallOptions validates the engine.install configuration.
+// Integrity pinning and caching only make sense for a fixed CLI version, and integrity
+// pinning applies to npm-installed CLIs (the Copilot CLI is always verified against the
+// checksums published with its release).
+func validateEngineInstallOptions(engineConfig *EngineConfig, engineID string) error {
+	if engineConfig == nil || engineConfig.Install == nil {
+		return nil
+	}
+	options := engineConfig.Install
+	engineValidationLog.Printf("Validating engine install options for engine: %s", engineID)
+
+	if options.Integrity != "" {
+		if engineID == "copilot" {
+			return errors.New("engine.install.integrity is not supported for the copilot engine. The Copilot CLI is always verified against the SHA256SUMS.txt published with its release; pin engine.version to control which release is installed")
+		}
+		algorithm, _, found := strings.Cut(options.Integrity, "-")
+		if !found || (algorithm != "sha512" && algorithm != "sha384" && algorithm != "sha256") {
+			return fmt.Errorf("engine.install.integrity must be a Subresource Integrity value such as 'sha512-...', got '%s'. Use 'npm view <package>@<version> dist.integrity' to look it up", options.Integrity)
+		}
+	}
+
+	if (options.Integrity != "" || options.Cache) && !isPinnedEngineVersion(engineConfig.Version) {
+		return errors.New("engine.install.integrity and engine.install.cache require a pinned engine.version (not 'latest'). Example:\nengine:\n  id: " + engineID + "\n  version: 1.2.3\n  install:\n    cache: true")
+	}
+
+	if options.Mirror != "" && !strings.HasPrefix(options.Mirror, "https://") {
+		return fmt.Errorf("engine.install.mirror must be an https:// URL, got '%s'", options.Mirror)
+	}
+
+	return nil
+}
+
+// validateEngineContainer validates the engine.container image.
+// The image replaces the AWF agent container, so it must be pinned to an immutable digest
+// and the agent must run inside the AWF sandbox. A job-level container: cannot be combined
+// with it because the agent would then run in that container instead.
+func validateEngineContainer(workflowData *WorkflowData) error {
+	if workflowData.EngineConfig == nil || workflowData.EngineConfig.Container == "" {
+		return nil
+	}
+	image := workflowData.EngineConfig.Container
+	engineValidationLog.Printf("Validating engine container image: %s", image)
+
+	if !digestPinnedImagePattern.MatchString(image) {
+		return fmt.Errorf("engine.container must be pinned to a digest (image@sha256:<64 hex characters>), got '%s'. Use 'docker buildx imagetools inspect <image>:<tag>' to look up the digest", image)
+	}
+
+	if workflowData.Container != "" {
+		return errors.New("engine.container cannot be combined with the top-level 'container:' field. Remove 'container:' to run the agent in the engine container")
+	}
+
+	if !isFirewallEnabled(workflowData) {
+		return errors.New("engine.container requires the agent sandbox (AWF). Remove 'sandbox.agent: false' or the 'network.firewall' setting that disables it")
+	}
+
+	return nil
+}
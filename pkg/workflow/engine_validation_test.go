@@ -495,3 +495,74 @@ func TestValidatePluginSupportErrorMessage(t *testing.T) {
 		t.Errorf("Error message should provide actionable fixes, got: %s", errorMsg)
 	}
 }
+
+// TestValidateEngineInstallOptions tests the validateEngineInstallOptions function
+func TestValidateEngineInstallOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		engineID    string
+		config      *EngineConfig
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:     "no install options",
+			engineID: "claude",
+			config:   &EngineConfig{ID: "claude"},
+		},
+		{
+			name:     "pinned version with integrity and cache",
+			engineID: "claude",
+			config:   &EngineConfig{ID: "claude", Version: "2.0.14", Install: &EngineInstallOptions{Integrity: "sha512-abc==", Cache: true}},
+		},
+		{
+			name:     "mirror with latest version",
+			engineID: "codex",
+			config:   &EngineConfig{ID: "codex", Install: &EngineInstallOptions{Mirror: "https://npm.example.com/"}},
+		},
+		{
+			name:        "cache without pinned version",
+			engineID:    "copilot",
+			config:      &EngineConfig{ID: "copilot", Version: "latest", Install: &EngineInstallOptions{Cache: true}},
+			expectError: true,
+			errorMsg:    "require a pinned engine.version",
+		},
+		{
+			name:        "integrity with copilot",
+			engineID:    "copilot",
+			config:      &EngineConfig{ID: "copilot", Version: "0.0.400", Install: &EngineInstallOptions{Integrity: "sha512-abc=="}},
+			expectError: true,
+			errorMsg:    "not supported for the copilot engine",
+		},
+		{
+			name:        "invalid integrity format",
+			engineID:    "claude",
+			config:      &EngineConfig{ID: "claude", Version: "2.0.14", Install: &EngineInstallOptions{Integrity: "abc123"}},
+			expectError: true,
+			errorMsg:    "Subresource Integrity",
+		},
+		{
+			name:        "insecure mirror",
+			engineID:    "gemini",
+			config:      &EngineConfig{ID: "gemini", Install: &EngineInstallOptions{Mirror: "http://npm.example.com/"}},
+			expectError: true,
+			errorMsg:    "https:// URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEngineInstallOptions(tt.config, tt.engineID)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("Expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error containing %q, got %q", tt.errorMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
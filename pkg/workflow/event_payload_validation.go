@@ -0,0 +1,249 @@
+// This file provides event payload validation for github.event expressions.
+//
+// # Event Payload Validation
+//
+// Prompts reference the triggering webhook payload through expressions such as
+// ${{ github.event.issue.title }}. This file checks those references against the
+// payload schemas bundled in schemas/github-event-payloads.json: a github.event path
+// must exist in the payload of at least one of the workflow's trigger events.
+//
+// This catches placeholders that can never resolve, for example
+// ${{ github.event.pull_request.title }} in a workflow that only runs on issues,
+// or a misspelled property of a known payload object.
+//
+// Validation is skipped when any trigger has no bundled schema (workflow_call
+// for example, whose payload is the caller's), since the path may be valid there.
+//
+// # Validation Functions
+//
+//   - validateEventPayloadExpressions() - Checks github.event paths in the prompt against the triggers
+
+package workflow
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-yaml"
+)
+
+var eventPayloadValidationLog = newValidationLogger("event_payload")
+
+//go:embed schemas/github-event-payloads.json
+var githubEventPayloadsJSON []byte
+
+// payloadSchema is the subset of JSON Schema used by the bundled event payload schemas
+type payloadSchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Properties           map[string]*payloadSchema `json:"properties,omitempty"`
+	Items                *payloadSchema            `json:"items,omitempty"`
+	AdditionalProperties bool                      `json:"additionalProperties,omitempty"`
+}
+
+// eventPayloadSchemas holds the bundled webhook payload schemas
+type eventPayloadSchemas struct {
+	Common      *payloadSchema            `json:"common"`
+	Events      map[string]*payloadSchema `json:"events"`
+	Definitions map[string]*payloadSchema `json:"definitions"`
+}
+
+var (
+	cachedEventPayloadSchemas *eventPayloadSchemas
+	eventPayloadSchemasOnce   sync.Once
+)
+
+// githubEventPathRegex matches github.event property paths inside an expression
+var githubEventPathRegex = regexp.MustCompile(`\bgithub\.event((?:\.[A-Za-z0-9_-]+|\[\d+\])+)`)
+
+// payloadPathSegmentRegex splits a property path into names and [N] indices
+var payloadPathSegmentRegex = regexp.MustCompile(`[A-Za-z0-9_-]+|\[\d+\]`)
+
+// getEventPayloadSchemas returns the bundled payload schemas, parsed once
+func getEventPayloadSchemas() *eventPayloadSchemas {
+	eventPayloadSchemasOnce.Do(func() {
+		var schemas eventPayloadSchemas
+		if err := json.Unmarshal(githubEventPayloadsJSON, &schemas); err != nil {
+			// The file is embedded, so this only happens if it is edited incorrectly
+			panic(fmt.Sprintf("failed to parse embedded event payload schemas: %v", err))
+		}
+		cachedEventPayloadSchemas = &schemas
+	})
+	return cachedEventPayloadSchemas
+}
+
+// resolve follows a $ref to its definition
+func (s *eventPayloadSchemas) resolve(node *payloadSchema) *payloadSchema {
+	for node != nil && node.Ref != "" {
+		node = s.Definitions[strings.TrimPrefix(node.Ref, "#/definitions/")]
+	}
+	return node
+}
+
+// hasPath reports whether the payload of event contains the property path (segments after github.event)
+func (s *eventPayloadSchemas) hasPath(event string, segments []string) bool {
+	root, ok := s.Events[event]
+	if !ok || len(segments) == 0 {
+		return ok
+	}
+
+	node, ok := s.resolve(root).Properties[segments[0]]
+	if !ok {
+		node, ok = s.Common.Properties[segments[0]]
+	}
+	if !ok {
+		return false
+	}
+
+	for _, segment := range segments[1:] {
+		node = s.resolve(node)
+		if node == nil {
+			return false
+		}
+		if strings.HasPrefix(segment, "[") {
+			if node.Items == nil {
+				return false
+			}
+			node = node.Items
+			continue
+		}
+		if child, ok := node.Properties[segment]; ok {
+			node = child
+			continue
+		}
+		// Open objects accept any property below them
+		return node.AdditionalProperties
+	}
+	return true
+}
+
+// extractTriggerEvents returns the sorted event names of a compiled on: section
+func extractTriggerEvents(on string) []string {
+	if on == "" {
+		return nil
+	}
+	var parsed map[string]any
+	if err := yaml.Unmarshal([]byte(on), &parsed); err != nil {
+		eventPayloadValidationLog.Printf("Could not parse On field as YAML: %v", err)
+		return nil
+	}
+
+	var events []string
+	switch onValue := parsed["on"].(type) {
+	case string:
+		events = append(events, onValue)
+	case []any:
+		for _, event := range onValue {
+			if name, ok := event.(string); ok {
+				events = append(events, name)
+			}
+		}
+	case map[string]any:
+		for name := range onValue {
+			events = append(events, name)
+		}
+	}
+	sort.Strings(events)
+	return events
+}
+
+// validateEventPayloadExpressions checks that every github.event path referenced by the prompt
+// exists in the payload of at least one trigger event. Unresolvable paths are errors in strict
+// mode and warnings otherwise.
+func (c *Compiler) validateEventPayloadExpressions(workflowData *WorkflowData, markdownPath string) error {
+	events := extractTriggerEvents(workflowData.On)
+	if workflowData.WorkflowNeeds != nil {
+		// Prompt expressions read the event of the upstream run instead of the workflow_run event
+		events = slices.DeleteFunc(events, func(event string) bool { return event == "workflow_run" })
+		for event := range workflowData.WorkflowNeeds.Events {
+			events = append(events, event)
+		}
+		sort.Strings(events)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	schemas := getEventPayloadSchemas()
+	for _, event := range events {
+		if _, ok := schemas.Events[event]; !ok {
+			eventPayloadValidationLog.Printf("No payload schema for trigger %q, skipping validation", event)
+			return nil
+		}
+	}
+	eventPayloadValidationLog.Printf("Validating github.event expressions against triggers: %v", events)
+
+	var unresolved []string
+	for _, match := range expressionRegex.FindAllStringSubmatch(workflowData.MarkdownContent, -1) {
+		var missing []string
+		for _, pathMatch := range githubEventPathRegex.FindAllStringSubmatch(match[1], -1) {
+			segments := payloadPathSegmentRegex.FindAllString(pathMatch[1], -1)
+			if !slices.ContainsFunc(events, func(event string) bool { return schemas.hasPath(event, segments) }) {
+				missing = append(missing, "github.event"+pathMatch[1])
+			}
+		}
+		if len(missing) == 0 || hasExpressionFallback(match[1], missing) {
+			continue
+		}
+		for _, path := range missing {
+			if !slices.Contains(unresolved, path) {
+				unresolved = append(unresolved, path)
+			}
+		}
+	}
+	if len(unresolved) == 0 {
+		return nil
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "%d github.event expressions are not present in the payload of the workflow triggers (%s):\n", len(unresolved), strings.Join(events, ", "))
+	for _, path := range unresolved {
+		message.WriteString("  - ")
+		message.WriteString(path)
+		if owners := payloadEventsWithPath(schemas, path); len(owners) > 0 {
+			fmt.Fprintf(&message, " (only available on: %s)", strings.Join(owners, ", "))
+		}
+		message.WriteString("\n")
+	}
+	message.WriteString("These placeholders would render empty. Check the spelling or add the matching trigger.")
+
+	if c.strictMode {
+		return formatCompilerError(markdownPath, "error", message.String(), nil)
+	}
+	fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", message.String()))
+	c.IncrementWarningCount()
+	return nil
+}
+
+// hasExpressionFallback reports whether an || chain has an operand that does not reference a
+// missing path, such as issue.number || pull_request.number or pull_request.number || inputs.number
+func hasExpressionFallback(expression string, missing []string) bool {
+	operands := strings.Split(expression, "||")
+	if len(operands) < 2 {
+		return false
+	}
+	return slices.ContainsFunc(operands, func(operand string) bool {
+		return !slices.ContainsFunc(missing, func(path string) bool {
+			return strings.Contains(operand, path)
+		})
+	})
+}
+
+// payloadEventsWithPath returns the events whose payload contains the github.event path
+func payloadEventsWithPath(schemas *eventPayloadSchemas, path string) []string {
+	segments := payloadPathSegmentRegex.FindAllString(strings.TrimPrefix(path, "github.event"), -1)
+	var events []string
+	for event := range schemas.Events {
+		if schemas.hasPath(event, segments) {
+			events = append(events, event)
+		}
+	}
+	sort.Strings(events)
+	return events
+}
@@ -0,0 +1,107 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventPayloadSchemasHasPath(t *testing.T) {
+	tests := []struct {
+		event    string
+		path     string
+		expected bool
+	}{
+		{event: "issues", path: "issue.title", expected: true},
+		{event: "issues", path: "issue.user.login", expected: true},
+		{event: "issues", path: "isue.title", expected: false},
+		{event: "issues", path: "issue.titel", expected: false},
+		{event: "issues", path: "pull_request.title", expected: false},
+		{event: "issue_comment", path: "comment.body", expected: true},
+		{event: "pull_request", path: "pull_request.head.sha", expected: true},
+		{event: "pull_request_target", path: "pull_request.base.ref", expected: true},
+		{event: "release", path: "release.assets[0].id", expected: true},
+		{event: "release", path: "release.assets.id", expected: false},
+		{event: "schedule", path: "sender.id", expected: true},
+		{event: "push", path: "repository.custom_properties.team", expected: true},
+		{event: "workflow_dispatch", path: "inputs.topic", expected: true},
+		{event: "unknown_event", path: "issue.title", expected: false},
+	}
+
+	schemas := getEventPayloadSchemas()
+	for _, tt := range tests {
+		t.Run(tt.event+"/"+tt.path, func(t *testing.T) {
+			segments := payloadPathSegmentRegex.FindAllString("."+tt.path, -1)
+			assert.Equal(t, tt.expected, schemas.hasPath(tt.event, segments), "path lookup should match")
+		})
+	}
+}
+
+func TestEventPayloadSchemasCoverAllowedExpressions(t *testing.T) {
+	// These allowed expressions are not part of any webhook payload GitHub Actions receives
+	notInPayloads := []string{"github.event.page.id", "github.event.review_comment.id"}
+
+	schemas := getEventPayloadSchemas()
+	for _, expression := range constants.AllowedExpressions {
+		if !strings.HasPrefix(expression, "github.event.") || slices.Contains(notInPayloads, expression) {
+			continue
+		}
+		assert.NotEmpty(t, payloadEventsWithPath(schemas, expression), "allowed expression %s should exist in a bundled payload schema", expression)
+	}
+}
+
+func TestExtractTriggerEvents(t *testing.T) {
+	assert.Equal(t, []string{"issues", "workflow_dispatch"}, extractTriggerEvents("on:\n  workflow_dispatch:\n  issues:\n    types: [opened]\n"), "map triggers should be extracted")
+	assert.Equal(t, []string{"push"}, extractTriggerEvents("on: push\n"), "string triggers should be extracted")
+	assert.Equal(t, []string{"pull_request", "push"}, extractTriggerEvents("on: [push, pull_request]\n"), "list triggers should be extracted")
+	assert.Empty(t, extractTriggerEvents(""), "empty on should yield no events")
+}
+
+func TestValidateEventPayloadExpressions(t *testing.T) {
+	tests := []struct {
+		name       string
+		on         string
+		body       string
+		strict     bool
+		wantErr    string
+		wantWarned bool
+	}{
+		{name: "matching trigger", on: "issues:\n    types: [opened]", body: "Triage ${{ github.event.issue.title }}"},
+		{name: "any trigger matches", on: "issues:\n    types: [opened]\n  pull_request:\n    types: [opened]", body: "Review ${{ github.event.pull_request.title }}"},
+		{name: "fallback chain", on: "issue_comment:\n    types: [created]", body: "Item ${{ github.event.issue.number || github.event.pull_request.number }}"},
+		{name: "unknown trigger skips validation", on: "workflow_call:", body: "Triage ${{ github.event.issue.title }}"},
+		{name: "missing trigger warns", on: "issues:\n    types: [opened]", body: "Review ${{ github.event.pull_request.title }}", wantWarned: true},
+		{name: "missing trigger fails in strict mode", on: "schedule:\n    - cron: \"0 9 * * 1\"", body: "Commit ${{ github.event.head_commit.id }}", strict: true, wantErr: "github.event.head_commit.id (only available on: push)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "event-payload-test")
+			workflowPath := filepath.Join(tmpDir, "workflow.md")
+			content := "---\non:\n  " + tt.on + "\nengine: copilot\nstrict: false\npermissions:\n  contents: read\n  issues: read\n  pull-requests: read\n---\n\n" + tt.body + "\n"
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+			compiler := NewCompiler()
+			compiler.SetStrictMode(tt.strict)
+			err := compiler.CompileWorkflow(workflowPath)
+			if tt.wantErr != "" {
+				require.Error(t, err, "workflow should fail to compile")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should list the unresolved path")
+				return
+			}
+			require.NoError(t, err, "workflow should compile")
+			if tt.wantWarned {
+				assert.Positive(t, compiler.GetWarningCount(), "unresolved path should be reported as a warning")
+			}
+		})
+	}
+}
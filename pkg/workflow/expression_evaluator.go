@@ -0,0 +1,668 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var expressionEvaluatorLog = logger.New("workflow:expression_evaluator")
+
+// EvaluateExpression evaluates a GitHub Actions expression, with or without the ${{ }} wrapper,
+// against the given contexts (e.g. "github", "inputs", "needs"). It implements the literals,
+// operators, property dereferences and built-in functions of the Actions expression language,
+// so conditions and prompt templates can be checked offline. Status functions behave as in a
+// run where every previous step succeeded, and hashFiles returns an empty string.
+func EvaluateExpression(expression string, contexts map[string]any) (any, error) {
+	expr := stripExpressionWrapper(expression)
+	expressionEvaluatorLog.Printf("Evaluating expression: %s", expr)
+
+	tokens, err := tokenizeEvalExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	e := &expressionEvaluator{tokens: tokens, contexts: contexts}
+	value, err := e.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := e.peek(); tok.kind != evalTokenEOF {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos)
+	}
+	return value, nil
+}
+
+// IsExpressionTruthy reports whether an evaluated value is truthy under Actions rules:
+// false, 0, NaN, empty strings and null are falsy, everything else is truthy
+func IsExpressionTruthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	default:
+		return true
+	}
+}
+
+// FormatExpressionValue converts an evaluated value to the string Actions substitutes into
+// YAML: null becomes an empty string and objects and arrays are rendered as JSON
+func FormatExpressionValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return formatExpressionNumber(v)
+	case string:
+		return v
+	default:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+func formatExpressionNumber(v float64) string {
+	if math.IsNaN(v) {
+		return "NaN"
+	}
+	if math.IsInf(v, 0) {
+		if v > 0 {
+			return "Infinity"
+		}
+		return "-Infinity"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+type evalTokenKind int
+
+const (
+	evalTokenEOF evalTokenKind = iota
+	evalTokenNumber
+	evalTokenString
+	evalTokenIdent
+	evalTokenOperator
+	evalTokenDot
+	evalTokenComma
+	evalTokenLeftParen
+	evalTokenRightParen
+	evalTokenLeftBracket
+	evalTokenRightBracket
+	evalTokenStar
+)
+
+type evalToken struct {
+	kind   evalTokenKind
+	text   string
+	number float64
+	pos    int
+}
+
+// tokenizeEvalExpression splits an expression into tokens
+func tokenizeEvalExpression(expr string) ([]evalToken, error) {
+	var tokens []evalToken
+	i := 0
+	for i < len(expr) {
+		ch := expr[i]
+		switch {
+		case unicode.IsSpace(rune(ch)):
+			i++
+		case ch == '\'':
+			var sb strings.Builder
+			start := i
+			i++
+			closed := false
+			for i < len(expr) {
+				if expr[i] == '\'' {
+					if i+1 < len(expr) && expr[i+1] == '\'' {
+						sb.WriteByte('\'')
+						i += 2
+						continue
+					}
+					i++
+					closed = true
+					break
+				}
+				sb.WriteByte(expr[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string starting at position %d", start)
+			}
+			tokens = append(tokens, evalToken{kind: evalTokenString, text: sb.String(), pos: start})
+		case ch >= '0' && ch <= '9' || (ch == '.' && i+1 < len(expr) && expr[i+1] >= '0' && expr[i+1] <= '9'):
+			start := i
+			for i < len(expr) && (isEvalIdentChar(expr[i]) || expr[i] == '.' ||
+				((expr[i] == '+' || expr[i] == '-') && (expr[i-1] == 'e' || expr[i-1] == 'E') && !strings.HasPrefix(expr[start:], "0x"))) {
+				i++
+			}
+			text := expr[start:i]
+			number, err := parseExpressionNumber(text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number '%s' at position %d", text, start)
+			}
+			tokens = append(tokens, evalToken{kind: evalTokenNumber, text: text, number: number, pos: start})
+		case isEvalIdentStart(ch):
+			start := i
+			for i < len(expr) && (isEvalIdentChar(expr[i]) || expr[i] == '-') {
+				i++
+			}
+			tokens = append(tokens, evalToken{kind: evalTokenIdent, text: expr[start:i], pos: start})
+		case strings.HasPrefix(expr[i:], "=="), strings.HasPrefix(expr[i:], "!="),
+			strings.HasPrefix(expr[i:], "<="), strings.HasPrefix(expr[i:], ">="),
+			strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, evalToken{kind: evalTokenOperator, text: expr[i : i+2], pos: i})
+			i += 2
+		case ch == '<' || ch == '>' || ch == '!':
+			tokens = append(tokens, evalToken{kind: evalTokenOperator, text: string(ch), pos: i})
+			i++
+		case ch == '.':
+			tokens = append(tokens, evalToken{kind: evalTokenDot, text: ".", pos: i})
+			i++
+		case ch == ',':
+			tokens = append(tokens, evalToken{kind: evalTokenComma, text: ",", pos: i})
+			i++
+		case ch == '(':
+			tokens = append(tokens, evalToken{kind: evalTokenLeftParen, text: "(", pos: i})
+			i++
+		case ch == ')':
+			tokens = append(tokens, evalToken{kind: evalTokenRightParen, text: ")", pos: i})
+			i++
+		case ch == '[':
+			tokens = append(tokens, evalToken{kind: evalTokenLeftBracket, text: "[", pos: i})
+			i++
+		case ch == ']':
+			tokens = append(tokens, evalToken{kind: evalTokenRightBracket, text: "]", pos: i})
+			i++
+		case ch == '*':
+			tokens = append(tokens, evalToken{kind: evalTokenStar, text: "*", pos: i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' at position %d", ch, i)
+		}
+	}
+	tokens = append(tokens, evalToken{kind: evalTokenEOF, pos: len(expr)})
+	return tokens, nil
+}
+
+func isEvalIdentStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isEvalIdentChar(ch byte) bool {
+	return isEvalIdentStart(ch) || (ch >= '0' && ch <= '9')
+}
+
+// parseExpressionNumber parses decimal, hexadecimal and exponent number literals
+func parseExpressionNumber(text string) (float64, error) {
+	if strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X") {
+		value, err := strconv.ParseInt(text[2:], 16, 64)
+		return float64(value), err
+	}
+	return strconv.ParseFloat(text, 64)
+}
+
+// expressionEvaluator is a recursive descent evaluator over a token stream
+type expressionEvaluator struct {
+	tokens   []evalToken
+	pos      int
+	contexts map[string]any
+}
+
+func (e *expressionEvaluator) peek() evalToken {
+	return e.tokens[e.pos]
+}
+
+func (e *expressionEvaluator) next() evalToken {
+	tok := e.tokens[e.pos]
+	if tok.kind != evalTokenEOF {
+		e.pos++
+	}
+	return tok
+}
+
+func (e *expressionEvaluator) isOperator(ops ...string) (string, bool) {
+	tok := e.peek()
+	if tok.kind != evalTokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (e *expressionEvaluator) parseOr() (any, error) {
+	left, err := e.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := e.isOperator("||"); !ok {
+			return left, nil
+		}
+		e.next()
+		right, err := e.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if !IsExpressionTruthy(left) {
+			left = right
+		}
+	}
+}
+
+func (e *expressionEvaluator) parseAnd() (any, error) {
+	left, err := e.parseEquality()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := e.isOperator("&&"); !ok {
+			return left, nil
+		}
+		e.next()
+		right, err := e.parseEquality()
+		if err != nil {
+			return nil, err
+		}
+		if IsExpressionTruthy(left) {
+			left = right
+		}
+	}
+}
+
+func (e *expressionEvaluator) parseEquality() (any, error) {
+	left, err := e.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := e.isOperator("==", "!=")
+		if !ok {
+			return left, nil
+		}
+		e.next()
+		right, err := e.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		equal := expressionValuesEqual(left, right)
+		left = equal == (op == "==")
+	}
+}
+
+func (e *expressionEvaluator) parseComparison() (any, error) {
+	left, err := e.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := e.isOperator("<", "<=", ">", ">=")
+		if !ok {
+			return left, nil
+		}
+		e.next()
+		right, err := e.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = compareExpressionValues(left, right, op)
+	}
+}
+
+func (e *expressionEvaluator) parseUnary() (any, error) {
+	if _, ok := e.isOperator("!"); ok {
+		e.next()
+		value, err := e.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return !IsExpressionTruthy(value), nil
+	}
+	return e.parsePostfix()
+}
+
+func (e *expressionEvaluator) parsePostfix() (any, error) {
+	value, err := e.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch e.peek().kind {
+		case evalTokenDot:
+			e.next()
+			tok := e.next()
+			switch tok.kind {
+			case evalTokenIdent:
+				value = dereferenceExpressionValue(value, tok.text)
+			case evalTokenStar:
+				value = filterExpressionValue(value)
+			default:
+				return nil, fmt.Errorf("expected property name after '.' at position %d", tok.pos)
+			}
+		case evalTokenLeftBracket:
+			e.next()
+			if e.peek().kind == evalTokenStar {
+				e.next()
+				value = filterExpressionValue(value)
+			} else {
+				index, err := e.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				value = dereferenceExpressionValue(value, index)
+			}
+			if tok := e.next(); tok.kind != evalTokenRightBracket {
+				return nil, fmt.Errorf("expected ']' at position %d", tok.pos)
+			}
+		default:
+			return value, nil
+		}
+	}
+}
+
+func (e *expressionEvaluator) parsePrimary() (any, error) {
+	tok := e.next()
+	switch tok.kind {
+	case evalTokenNumber:
+		return tok.number, nil
+	case evalTokenString:
+		return tok.text, nil
+	case evalTokenLeftParen:
+		value, err := e.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := e.next(); closing.kind != evalTokenRightParen {
+			return nil, fmt.Errorf("expected ')' at position %d", closing.pos)
+		}
+		return value, nil
+	case evalTokenIdent:
+		switch tok.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		case "NaN":
+			return math.NaN(), nil
+		case "Infinity":
+			return math.Inf(1), nil
+		}
+		if e.peek().kind == evalTokenLeftParen {
+			return e.parseFunctionCall(tok)
+		}
+		if value, ok := lookupExpressionKey(e.contexts, tok.text); ok {
+			return value, nil
+		}
+		return nil, nil
+	case evalTokenEOF:
+		return nil, errors.New("unexpected end of expression")
+	default:
+		return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos)
+	}
+}
+
+func (e *expressionEvaluator) parseFunctionCall(name evalToken) (any, error) {
+	e.next() // consume (
+	var args []any
+	if e.peek().kind != evalTokenRightParen {
+		for {
+			arg, err := e.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if e.peek().kind != evalTokenComma {
+				break
+			}
+			e.next()
+		}
+	}
+	if tok := e.next(); tok.kind != evalTokenRightParen {
+		return nil, fmt.Errorf("expected ')' at position %d", tok.pos)
+	}
+	return callExpressionFunction(name.text, args)
+}
+
+// callExpressionFunction implements the built-in expression functions
+func callExpressionFunction(name string, args []any) (any, error) {
+	requireArgs := func(min, max int) error {
+		if len(args) < min || (max >= 0 && len(args) > max) {
+			return fmt.Errorf("%s() called with %d argument(s)", name, len(args))
+		}
+		return nil
+	}
+
+	switch strings.ToLower(name) {
+	case "success", "always":
+		return true, requireArgs(0, 0)
+	case "failure", "cancelled":
+		return false, requireArgs(0, 0)
+	case "contains":
+		if err := requireArgs(2, 2); err != nil {
+			return nil, err
+		}
+		if items, ok := args[0].([]any); ok {
+			for _, item := range items {
+				if expressionValuesEqual(item, args[1]) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+		return strings.Contains(strings.ToLower(FormatExpressionValue(args[0])), strings.ToLower(FormatExpressionValue(args[1]))), nil
+	case "startswith":
+		if err := requireArgs(2, 2); err != nil {
+			return nil, err
+		}
+		return strings.HasPrefix(strings.ToLower(FormatExpressionValue(args[0])), strings.ToLower(FormatExpressionValue(args[1]))), nil
+	case "endswith":
+		if err := requireArgs(2, 2); err != nil {
+			return nil, err
+		}
+		return strings.HasSuffix(strings.ToLower(FormatExpressionValue(args[0])), strings.ToLower(FormatExpressionValue(args[1]))), nil
+	case "format":
+		if err := requireArgs(1, -1); err != nil {
+			return nil, err
+		}
+		result := FormatExpressionValue(args[0])
+		for i, arg := range args[1:] {
+			result = strings.ReplaceAll(result, "{"+strconv.Itoa(i)+"}", FormatExpressionValue(arg))
+		}
+		result = strings.ReplaceAll(strings.ReplaceAll(result, "{{", "{"), "}}", "}")
+		return result, nil
+	case "join":
+		if err := requireArgs(1, 2); err != nil {
+			return nil, err
+		}
+		separator := ","
+		if len(args) == 2 {
+			separator = FormatExpressionValue(args[1])
+		}
+		items, ok := args[0].([]any)
+		if !ok {
+			return FormatExpressionValue(args[0]), nil
+		}
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			parts = append(parts, FormatExpressionValue(item))
+		}
+		return strings.Join(parts, separator), nil
+	case "tojson":
+		if err := requireArgs(1, 1); err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(args[0], "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("toJSON(): %w", err)
+		}
+		return string(data), nil
+	case "fromjson":
+		if err := requireArgs(1, 1); err != nil {
+			return nil, err
+		}
+		var value any
+		if err := json.Unmarshal([]byte(FormatExpressionValue(args[0])), &value); err != nil {
+			return nil, fmt.Errorf("fromJSON(): %w", err)
+		}
+		return value, nil
+	case "hashfiles":
+		return "", nil
+	default:
+		return nil, fmt.Errorf("unknown function '%s'", name)
+	}
+}
+
+// lookupExpressionKey finds a key in an object, ignoring case like Actions does
+func lookupExpressionKey(object map[string]any, key string) (any, bool) {
+	if value, ok := object[key]; ok {
+		return value, true
+	}
+	for k, value := range object {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// dereferenceExpressionValue returns value[key], or null when it does not exist
+func dereferenceExpressionValue(value any, key any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result, _ := lookupExpressionKey(v, FormatExpressionValue(key))
+		return result
+	case []any:
+		if index, ok := key.(float64); ok && index >= 0 && int(index) < len(v) && index == math.Trunc(index) {
+			return v[int(index)]
+		}
+		if name, ok := key.(string); ok {
+			// Property access on a filtered array applies to every element
+			var results []any
+			for _, item := range v {
+				if result := dereferenceExpressionValue(item, name); result != nil {
+					results = append(results, result)
+				}
+			}
+			return results
+		}
+	}
+	return nil
+}
+
+// filterExpressionValue implements the .* object filter
+func filterExpressionValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		results := make([]any, 0, len(v))
+		for _, item := range v {
+			results = append(results, item)
+		}
+		return results
+	case []any:
+		return v
+	}
+	return []any{}
+}
+
+// expressionNumber coerces a value to a number the way Actions does for comparisons
+func expressionNumber(value any) float64 {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case float64:
+		return v
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if trimmed == "" {
+			return 0
+		}
+		if number, err := parseExpressionNumber(trimmed); err == nil {
+			return number
+		}
+		return math.NaN()
+	}
+	return math.NaN()
+}
+
+// expressionValuesEqual implements == with Actions' loose type coercion and
+// case-insensitive string comparison
+func expressionValuesEqual(left, right any) bool {
+	switch l := left.(type) {
+	case string:
+		if r, ok := right.(string); ok {
+			return strings.EqualFold(l, r)
+		}
+	case nil:
+		if right == nil {
+			return true
+		}
+	case bool:
+		if r, ok := right.(bool); ok {
+			return l == r
+		}
+	case map[string]any, []any:
+		// Objects and arrays are only equal to themselves
+		return fmt.Sprintf("%p", left) == fmt.Sprintf("%p", right)
+	}
+	switch right.(type) {
+	case map[string]any, []any:
+		return false
+	}
+	return expressionNumber(left) == expressionNumber(right)
+}
+
+// compareExpressionValues implements <, <=, > and >=
+func compareExpressionValues(left, right any, op string) bool {
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			cmp := strings.Compare(strings.ToLower(l), strings.ToLower(r))
+			switch op {
+			case "<":
+				return cmp < 0
+			case "<=":
+				return cmp <= 0
+			case ">":
+				return cmp > 0
+			default:
+				return cmp >= 0
+			}
+		}
+	}
+	l, r := expressionNumber(left), expressionNumber(right)
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	default:
+		return l >= r
+	}
+}
@@ -0,0 +1,91 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateExpression(t *testing.T) {
+	contexts := map[string]any{
+		"github": map[string]any{
+			"event_name": "issues",
+			"actor":      "Octocat",
+			"event": map[string]any{
+				"action": "opened",
+				"issue": map[string]any{
+					"number": float64(42),
+					"title":  "Crash on startup",
+					"labels": []any{
+						map[string]any{"name": "bug"},
+						map[string]any{"name": "priority"},
+					},
+				},
+			},
+		},
+		"needs": map[string]any{
+			"pre_activation": map[string]any{"outputs": map[string]any{"activated": "true"}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		want       any
+	}{
+		{name: "property access", expression: "github.event.issue.number", want: float64(42)},
+		{name: "wrapped expression", expression: "${{ github.event_name }}", want: "issues"},
+		{name: "index access", expression: "github.event['action']", want: "opened"},
+		{name: "missing property", expression: "github.event.pull_request.number", want: nil},
+		{name: "case-insensitive equality", expression: "github.actor == 'octocat'", want: true},
+		{name: "loose number equality", expression: "github.event.issue.number == '42'", want: true},
+		{name: "and with output", expression: "needs.pre_activation.outputs.activated == 'true' && github.event_name == 'issues'", want: true},
+		{name: "or returns operand", expression: "github.event.missing || 'fallback'", want: "fallback"},
+		{name: "negation", expression: "!(github.event_name == 'push')", want: true},
+		{name: "comparison", expression: "github.event.issue.number >= 10", want: true},
+		{name: "contains on filter", expression: "contains(github.event.issue.labels.*.name, 'bug')", want: true},
+		{name: "startsWith ignores case", expression: "startsWith(github.event.issue.title, 'crash')", want: true},
+		{name: "format", expression: "format('#{0}: {1}', github.event.issue.number, github.event.issue.title)", want: "#42: Crash on startup"},
+		{name: "join", expression: "join(github.event.issue.labels.*.name, ', ')", want: "bug, priority"},
+		{name: "status function", expression: "always() && success()", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateExpression(tt.expression, contexts)
+			require.NoError(t, err, "expression should evaluate")
+			assert.Equal(t, tt.want, got, "expression result should match")
+		})
+	}
+}
+
+func TestEvaluateExpressionErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{name: "unknown function", expression: "doSomething()"},
+		{name: "unterminated string", expression: "github.actor == 'oops"},
+		{name: "dangling operator", expression: "github.actor =="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EvaluateExpression(tt.expression, map[string]any{})
+			assert.Error(t, err, "invalid expression should fail")
+		})
+	}
+}
+
+func TestFormatExpressionValue(t *testing.T) {
+	assert.Empty(t, FormatExpressionValue(nil), "null should format as empty")
+	assert.Equal(t, "42", FormatExpressionValue(float64(42)), "integers should not have a fraction")
+	assert.Equal(t, "true", FormatExpressionValue(true), "booleans should format as words")
+	assert.JSONEq(t, `{"a":1}`, FormatExpressionValue(map[string]any{"a": float64(1)}), "objects should format as JSON")
+	assert.False(t, IsExpressionTruthy(""), "empty string should be falsy")
+	assert.False(t, IsExpressionTruthy(float64(0)), "zero should be falsy")
+	assert.True(t, IsExpressionTruthy("false"), "non-empty strings should be truthy")
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -23,7 +24,7 @@ var (
 // ExpressionMapping represents a mapping between a GitHub expression and its environment variable
 type ExpressionMapping struct {
 	Original string // The original ${{ ... }} expression
-	EnvVar   string // The GH_AW_ prefixed environment variable name
+	EnvVar   string // The prefixed environment variable name (GH_AW_ unless expressions.env-prefix is set)
 	Content  string // The expression content without ${{ }}
 }
 
@@ -32,14 +33,32 @@ type ExpressionMapping struct {
 type ExpressionExtractor struct {
 	mappings map[string]*ExpressionMapping // key is the original expression
 	counter  int
+	prefix   string          // prefix of generated environment variable names
+	inline   map[string]bool // expression contents left in place instead of mapped
 }
 
-// NewExpressionExtractor creates a new ExpressionExtractor
+// NewExpressionExtractor creates a new ExpressionExtractor using the default GH_AW_ prefix
 func NewExpressionExtractor() *ExpressionExtractor {
-	return &ExpressionExtractor{
+	return NewExpressionExtractorWithConfig(nil)
+}
+
+// NewExpressionExtractorWithConfig creates an ExpressionExtractor that applies the
+// workflow's expressions settings: generated names use config.EnvPrefix and the
+// expressions listed in config.Inline are not extracted, so they stay in the markdown.
+// A nil config behaves like NewExpressionExtractor.
+func NewExpressionExtractorWithConfig(config *ExpressionsConfig) *ExpressionExtractor {
+	extractor := &ExpressionExtractor{
 		mappings: make(map[string]*ExpressionMapping),
 		counter:  0,
+		prefix:   config.envPrefix(),
+		inline:   make(map[string]bool),
+	}
+	if config != nil {
+		for _, expr := range config.Inline {
+			extractor.inline[expr] = true
+		}
 	}
+	return extractor
 }
 
 // ExtractExpressions extracts all ${{ ... }} expressions from the markdown content
@@ -64,6 +83,7 @@ func (e *ExpressionExtractor) ExtractExpressions(markdown string) ([]*Expression
 
 		// Apply activation output transformation for backward compatibility
 		// This transforms needs.activation.outputs.{text|title|body} to steps.sanitized.outputs.{text|title|body}
+		// and needs.activation.outputs.{slash_command|slash_command_args} to the pre_activation outputs
 		// Users should now use steps.sanitized.outputs.* directly, but we keep this transformation
 		// for backward compatibility with existing workflows.
 		transformedContent := transformActivationOutputs(content)
@@ -72,6 +92,11 @@ func (e *ExpressionExtractor) ExtractExpressions(markdown string) ([]*Expression
 			content = transformedContent
 		}
 
+		// Leave expressions configured in expressions.inline in place
+		if e.inline[content] {
+			continue
+		}
+
 		// Skip if we've already seen this expression
 		if _, exists := e.mappings[originalExpr]; exists {
 			continue
@@ -106,8 +131,31 @@ func (e *ExpressionExtractor) ExtractExpressions(markdown string) ([]*Expression
 	return result, nil
 }
 
-// transformActivationOutputs transforms needs.activation.outputs.* expressions to steps.sanitized.outputs.*
-// for backward compatibility with existing workflows.
+// activationOutputTransforms maps needs.activation.outputs.* expressions to the values they
+// are computed from. The prompt is rendered inside the activation job, which cannot read its
+// own outputs, so these expressions are rewritten to the underlying step or job outputs.
+var activationOutputTransforms = append([]activationOutputTransform{
+	{"text", "steps.sanitized.outputs.text"},
+	{"title", "steps.sanitized.outputs.title"},
+	{"body", "steps.sanitized.outputs.body"},
+	{"slash_command", "needs.pre_activation.outputs." + constants.MatchedCommandOutput},
+	{"slash_command_args", "needs.pre_activation.outputs." + constants.CommandArgsOutput},
+}, pullRequestContextTransforms()...)
+
+// activationOutputTransform rewrites needs.activation.outputs.<output> to replacement
+type activationOutputTransform struct{ output, replacement string }
+
+// pullRequestContextTransforms maps needs.activation.outputs.pr_* to the pr-context step outputs
+func pullRequestContextTransforms() []activationOutputTransform {
+	transforms := make([]activationOutputTransform, 0, len(pullRequestContextOutputs))
+	for _, name := range pullRequestContextOutputs {
+		transforms = append(transforms, activationOutputTransform{"pr_" + name, "steps.pr-context.outputs." + name})
+	}
+	return transforms
+}
+
+// transformActivationOutputs transforms needs.activation.outputs.* expressions to the outputs
+// they are computed from, for backward compatibility with existing workflows.
 //
 // NEW WORKFLOWS should use steps.sanitized.outputs.* directly in their markdown.
 //
@@ -116,6 +164,9 @@ func (e *ExpressionExtractor) ExtractExpressions(markdown string) ([]*Expression
 //	needs.activation.outputs.text -> steps.sanitized.outputs.text
 //	needs.activation.outputs.title -> steps.sanitized.outputs.title
 //	needs.activation.outputs.body -> steps.sanitized.outputs.body
+//	needs.activation.outputs.slash_command -> needs.pre_activation.outputs.matched_command
+//	needs.activation.outputs.slash_command_args -> needs.pre_activation.outputs.command_args
+//	needs.activation.outputs.pr_<name> -> steps.pr-context.outputs.<name>
 //
 // Other activation outputs (e.g., comment_id, comment_repo) are not transformed.
 //
@@ -125,14 +176,10 @@ func (e *ExpressionExtractor) ExtractExpressions(markdown string) ([]*Expression
 // Returns:
 //   - The transformed expression, or the original if no transformation applies
 func transformActivationOutputs(expr string) string {
-	// Define the activation outputs that should be transformed
-	// These are the outputs generated by the sanitized step (formerly compute-text)
-	activationOutputs := []string{"text", "title", "body"}
-
-	for _, output := range activationOutputs {
+	for _, transform := range activationOutputTransforms {
 		// Build the old and new expressions
-		oldExpr := "needs.activation.outputs." + output
-		newExpr := "steps.sanitized.outputs." + output
+		oldExpr := "needs.activation.outputs." + transform.output
+		newExpr := transform.replacement
 
 		// Use word boundary replacement to avoid partial matches
 		// We need to ensure we're replacing complete tokens, not substrings
@@ -187,12 +234,13 @@ var simpleIdentifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-
 // For simple JavaScript property access chains (e.g., "github.event.issue.number"),
 // it generates a pretty name like "GH_AW_GITHUB_EVENT_ISSUE_NUMBER".
 // For complex expressions, it falls back to a hash-based name.
+// The GH_AW_ prefix is replaced by the extractor's configured prefix.
 func (e *ExpressionExtractor) generateEnvVarName(content string) string {
 	// Check if the expression is a simple JavaScript property access chain
 	if simpleIdentifierRegex.MatchString(content) {
 		// Convert dots to underscores and uppercase
 		prettyName := strings.ToUpper(strings.ReplaceAll(content, ".", "_"))
-		return "GH_AW_" + prettyName
+		return e.prefix + prettyName
 	}
 
 	// Fall back to hash-based name for complex expressions
@@ -204,7 +252,7 @@ func (e *ExpressionExtractor) generateEnvVarName(content string) string {
 	shortHash := hashStr[:8]
 
 	// Create environment variable name
-	return "GH_AW_EXPR_" + strings.ToUpper(shortHash)
+	return e.prefix + "EXPR_" + strings.ToUpper(shortHash)
 }
 
 // ReplaceExpressionsWithEnvVars replaces all ${{ ... }} expressions in the markdown
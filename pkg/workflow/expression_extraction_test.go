@@ -261,6 +261,16 @@ func TestTransformActivationOutputs(t *testing.T) {
 			input:    "needs.activation.outputs.body",
 			expected: "steps.sanitized.outputs.body",
 		},
+		{
+			name:     "transform slash_command output",
+			input:    "needs.activation.outputs.slash_command",
+			expected: "needs.pre_activation.outputs.matched_command",
+		},
+		{
+			name:     "transform slash_command_args output",
+			input:    "needs.activation.outputs.slash_command_args",
+			expected: "needs.pre_activation.outputs.command_args",
+		},
 		{
 			name:     "no transformation for other outputs",
 			input:    "needs.activation.outputs.comment_id",
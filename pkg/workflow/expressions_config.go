@@ -0,0 +1,221 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var expressionsConfigLog = logger.New("workflow:expressions_config")
+
+const (
+	// DefaultExpressionEnvPrefix is the prefix of the environment variables that carry
+	// markdown expressions into the prompt when no expressions.env-prefix is configured
+	DefaultExpressionEnvPrefix = "GH_AW_"
+
+	// ExpressionMapFileSuffix is appended to the workflow name for the mapping table
+	// written by compile --expression-map, e.g. issue-triage.expressions.json
+	ExpressionMapFileSuffix = ".expressions.json"
+)
+
+// expressionEnvPrefixRegex matches valid prefixes: uppercase letters, digits and underscores,
+// starting with a letter and ending with an underscore
+var expressionEnvPrefixRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]*_$`)
+
+// reservedExpressionEnvPrefixes are prefixes owned by the Actions runner
+var reservedExpressionEnvPrefixes = []string{"GITHUB_", "RUNNER_", "ACTIONS_"}
+
+// InlineableExpressions lists the expressions that may be rendered in place instead of
+// through an environment variable. They describe the repository and the run, so they
+// cannot carry attacker-controlled text into the prompt.
+var InlineableExpressions = []string{
+	"github.actor",
+	"github.job",
+	"github.owner",
+	"github.repository",
+	"github.repository_owner",
+	"github.run_id",
+	"github.run_number",
+	"github.server_url",
+	"github.workflow",
+	"github.workspace",
+}
+
+// ExpressionsConfig controls how markdown expressions are translated into environment
+// variable placeholders (expressions:)
+//
+// Example:
+//
+//	expressions:
+//	  env-prefix: TRIAGE_
+//	  inline: [github.repository, github.run_id]
+type ExpressionsConfig struct {
+	EnvPrefix string   `json:"env-prefix,omitempty"` // Prefix of generated environment variable names (default: GH_AW_)
+	Inline    []string `json:"inline,omitempty"`     // Expressions rendered in place instead of through an environment variable
+}
+
+// envPrefix returns the configured prefix, or the default when none is set
+func (e *ExpressionsConfig) envPrefix() string {
+	if e == nil || e.EnvPrefix == "" {
+		return DefaultExpressionEnvPrefix
+	}
+	return e.EnvPrefix
+}
+
+// hasCustomPrefix reports whether the generated names differ from the default GH_AW_ names
+func (e *ExpressionsConfig) hasCustomPrefix() bool {
+	return e.envPrefix() != DefaultExpressionEnvPrefix
+}
+
+// extractExpressionsConfig extracts the expressions configuration from frontmatter
+func extractExpressionsConfig(frontmatter map[string]any) (*ExpressionsConfig, error) {
+	value, exists := frontmatter["expressions"]
+	if !exists || value == nil {
+		return nil, nil
+	}
+
+	expressionsMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expressions must be an object, got %T. Example:\nexpressions:\n  env-prefix: TRIAGE_\n  inline: [github.repository]", value)
+	}
+
+	config := &ExpressionsConfig{}
+
+	if prefixValue, exists := expressionsMap["env-prefix"]; exists {
+		prefix, ok := prefixValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("expressions.env-prefix must be a string, got %T", prefixValue)
+		}
+		if err := validateExpressionEnvPrefix(prefix); err != nil {
+			return nil, err
+		}
+		config.EnvPrefix = prefix
+	}
+
+	if inlineValue, exists := expressionsMap["inline"]; exists {
+		inline, ok := inlineValue.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expressions.inline must be an array of strings, got %T", inlineValue)
+		}
+		for _, item := range inline {
+			expr, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expressions.inline must be an array of strings, got %T item", item)
+			}
+			if !slices.Contains(InlineableExpressions, expr) {
+				return nil, fmt.Errorf("expressions.inline: %q cannot be rendered inline. Only run and repository metadata is allowed: %s", expr, strings.Join(InlineableExpressions, ", "))
+			}
+			if !slices.Contains(config.Inline, expr) {
+				config.Inline = append(config.Inline, expr)
+			}
+		}
+		sort.Strings(config.Inline)
+	}
+
+	expressionsConfigLog.Printf("Extracted expressions config: env_prefix=%s, inline=%v", config.envPrefix(), config.Inline)
+	return config, nil
+}
+
+// validateExpressionEnvPrefix checks that prefix yields valid, non-reserved environment variable names
+func validateExpressionEnvPrefix(prefix string) error {
+	if !expressionEnvPrefixRegex.MatchString(prefix) {
+		return fmt.Errorf("expressions.env-prefix %q is invalid: use uppercase letters, digits and underscores, starting with a letter and ending with '_' (e.g. TRIAGE_)", prefix)
+	}
+	for _, reserved := range reservedExpressionEnvPrefixes {
+		if strings.HasPrefix(prefix, reserved) {
+			return fmt.Errorf("expressions.env-prefix %q is invalid: the %s prefix is reserved by GitHub Actions", prefix, reserved)
+		}
+	}
+	return nil
+}
+
+// ExpressionMapEntry is one row of the expression mapping table
+type ExpressionMapEntry struct {
+	EnvVar      string `json:"env_var"`
+	Placeholder string `json:"placeholder"`
+	Expression  string `json:"expression"`
+}
+
+// ExpressionMap is the mapping table written by compile --expression-map. It lists every
+// expression the activation job passes into the prompt and the placeholder it replaces.
+type ExpressionMap struct {
+	EnvPrefix string               `json:"env_prefix"`
+	Inline    []string             `json:"inline,omitempty"`
+	Mappings  []ExpressionMapEntry `json:"mappings"`
+}
+
+// WithExpressionMap configures whether the expression mapping table is written next to
+// each lock file as <workflow>.expressions.json
+func WithExpressionMap(enabled bool) CompilerOption {
+	return func(c *Compiler) { c.writeExpressionMap = enabled }
+}
+
+// recordExpressionMap keeps the final expression mappings of the current compilation
+// for writeExpressionMapFile
+func (c *Compiler) recordExpressionMap(config *ExpressionsConfig, mappings []*ExpressionMapping) {
+	if !c.writeExpressionMap {
+		return
+	}
+
+	expressionMap := &ExpressionMap{EnvPrefix: config.envPrefix(), Mappings: []ExpressionMapEntry{}}
+	if config != nil {
+		expressionMap.Inline = config.Inline
+	}
+	seen := make(map[string]bool)
+	for _, mapping := range mappings {
+		if seen[mapping.EnvVar] {
+			continue
+		}
+		seen[mapping.EnvVar] = true
+		expressionMap.Mappings = append(expressionMap.Mappings, ExpressionMapEntry{
+			EnvVar:      mapping.EnvVar,
+			Placeholder: fmt.Sprintf("__%s__", mapping.EnvVar),
+			Expression:  mapping.Content,
+		})
+	}
+	sort.Slice(expressionMap.Mappings, func(i, j int) bool {
+		return expressionMap.Mappings[i].EnvVar < expressionMap.Mappings[j].EnvVar
+	})
+	c.expressionMap = expressionMap
+}
+
+// ExpressionMapFilePath returns the path of the expression mapping table for a lock file
+func ExpressionMapFilePath(lockFile string) string {
+	base := lockFile
+	for _, suffix := range []string{".lock.yml", ".yml", ".yaml"} {
+		if trimmed, ok := strings.CutSuffix(lockFile, suffix); ok {
+			base = trimmed
+			break
+		}
+	}
+	return base + ExpressionMapFileSuffix
+}
+
+// writeExpressionMapFile writes the mapping table recorded during the compilation
+func (c *Compiler) writeExpressionMapFile(lockFile string) error {
+	if !c.writeExpressionMap || c.expressionMap == nil {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(c.expressionMap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal expression map: %w", err)
+	}
+
+	path := ExpressionMapFilePath(lockFile)
+	if err := os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write expression map: %w", err)
+	}
+	if c.fileTracker != nil {
+		c.fileTracker.TrackCreated(path)
+	}
+
+	expressionsConfigLog.Printf("Wrote %d expression mappings to %s", len(c.expressionMap.Mappings), path)
+	return nil
+}
@@ -0,0 +1,147 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractExpressionsConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *ExpressionsConfig
+		wantErr     string
+	}{
+		{
+			name:        "not configured",
+			frontmatter: map[string]any{},
+		},
+		{
+			name: "prefix and inline",
+			frontmatter: map[string]any{"expressions": map[string]any{
+				"env-prefix": "TRIAGE_",
+				"inline":     []any{"github.run_id", "github.repository", "github.run_id"},
+			}},
+			expected: &ExpressionsConfig{EnvPrefix: "TRIAGE_", Inline: []string{"github.repository", "github.run_id"}},
+		},
+		{
+			name:        "invalid type",
+			frontmatter: map[string]any{"expressions": "TRIAGE_"},
+			wantErr:     "expressions must be an object",
+		},
+		{
+			name:        "lowercase prefix",
+			frontmatter: map[string]any{"expressions": map[string]any{"env-prefix": "triage_"}},
+			wantErr:     "expressions.env-prefix \"triage_\" is invalid",
+		},
+		{
+			name:        "prefix without trailing underscore",
+			frontmatter: map[string]any{"expressions": map[string]any{"env-prefix": "TRIAGE"}},
+			wantErr:     "ending with '_'",
+		},
+		{
+			name:        "reserved prefix",
+			frontmatter: map[string]any{"expressions": map[string]any{"env-prefix": "GITHUB_AW_"}},
+			wantErr:     "the GITHUB_ prefix is reserved",
+		},
+		{
+			name:        "untrusted inline expression",
+			frontmatter: map[string]any{"expressions": map[string]any{"inline": []any{"github.event.issue.title"}}},
+			wantErr:     "\"github.event.issue.title\" cannot be rendered inline",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := extractExpressionsConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "should parse expressions config")
+			assert.Equal(t, tt.expected, config, "expressions config")
+		})
+	}
+}
+
+func TestExpressionExtractorWithConfig(t *testing.T) {
+	markdown := "Issue ${{ github.event.issue.number }} in ${{ github.repository }}: ${{ github.event.issue.title || 'untitled' }}"
+
+	t.Run("default prefix", func(t *testing.T) {
+		extractor := NewExpressionExtractorWithConfig(nil)
+		mappings, err := extractor.ExtractExpressions(markdown)
+		require.NoError(t, err, "should extract expressions")
+		require.Len(t, mappings, 3, "every expression should be mapped")
+		assert.Equal(t, "GH_AW_GITHUB_EVENT_ISSUE_NUMBER", mappings[0].EnvVar, "simple expression name")
+	})
+
+	t.Run("custom prefix and inline expressions", func(t *testing.T) {
+		extractor := NewExpressionExtractorWithConfig(&ExpressionsConfig{EnvPrefix: "TRIAGE_", Inline: []string{"github.repository"}})
+		mappings, err := extractor.ExtractExpressions(markdown)
+		require.NoError(t, err, "should extract expressions")
+		require.Len(t, mappings, 2, "inline expression should not be mapped")
+
+		for _, mapping := range mappings {
+			assert.Regexp(t, `^TRIAGE_(GITHUB_EVENT_ISSUE_NUMBER|EXPR_[0-9A-F]{8})$`, mapping.EnvVar, "names should use the prefix")
+		}
+
+		rendered := extractor.ReplaceExpressionsWithEnvVars(markdown)
+		assert.Contains(t, rendered, "Issue __TRIAGE_GITHUB_EVENT_ISSUE_NUMBER__", "mapped expression should become a placeholder")
+		assert.Contains(t, rendered, "in ${{ github.repository }}", "inline expression should stay in place")
+	})
+}
+
+func TestExpressionMapFilePath(t *testing.T) {
+	assert.Equal(t, filepath.Join("wf", "triage.expressions.json"), ExpressionMapFilePath(filepath.Join("wf", "triage.lock.yml")))
+	assert.Equal(t, "aw-triage.expressions.json", ExpressionMapFilePath("aw-triage.yml"))
+}
+
+func TestExpressionsCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "expressions-test")
+	workflowPath := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on: issues
+engine: claude
+permissions:
+  contents: read
+expressions:
+  env-prefix: TRIAGE_
+  inline: [github.repository]
+---
+
+Triage issue #${{ github.event.issue.number }} in ${{ github.repository }}.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+	require.NoError(t, NewCompiler(WithExpressionMap(true)).CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+	assert.Contains(t, lock, "TRIAGE_GITHUB_EVENT_ISSUE_NUMBER: ${{ github.event.issue.number }}", "markdown expression should use the prefix")
+	assert.Contains(t, lock, "GH_AW_EXPRESSION_PREFIX: TRIAGE_", "runtime imports should know the prefix")
+	assert.Contains(t, lock, "TRIAGE_NEEDS_PRE_ACTIVATION_OUTPUTS_ACTIVATED", "known needs expressions should use the prefix")
+	assert.NotContains(t, lock, "TRIAGE_GITHUB_REPOSITORY", "inline expression should not be mapped")
+
+	mapContent, err := os.ReadFile(filepath.Join(tmpDir, "triage.expressions.json"))
+	require.NoError(t, err, "expression map should be written")
+	var expressionMap ExpressionMap
+	require.NoError(t, json.Unmarshal(mapContent, &expressionMap), "expression map should be valid JSON")
+	assert.Equal(t, "TRIAGE_", expressionMap.EnvPrefix, "map should record the prefix")
+	assert.Equal(t, []string{"github.repository"}, expressionMap.Inline, "map should record inline expressions")
+	assert.Contains(t, expressionMap.Mappings, ExpressionMapEntry{
+		EnvVar:      "TRIAGE_GITHUB_EVENT_ISSUE_NUMBER",
+		Placeholder: "__TRIAGE_GITHUB_EVENT_ISSUE_NUMBER__",
+		Expression:  "github.event.issue.number",
+	}, "map should list the markdown expression")
+}
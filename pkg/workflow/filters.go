@@ -8,7 +8,7 @@ import (
 
 var filtersLog = logger.New("workflow:filters")
 
-// applyPullRequestDraftFilter applies draft filter conditions for pull_request triggers
+// applyPullRequestDraftFilter applies draft filter conditions for pull_request and pull_request_target triggers
 func (c *Compiler) applyPullRequestDraftFilter(data *WorkflowData, frontmatter map[string]any) {
 	filtersLog.Print("Applying pull request draft filter")
 
@@ -33,74 +33,61 @@ func (c *Compiler) applyPullRequestDraftFilter(data *WorkflowData, frontmatter m
 		return
 	}
 
-	// Check if there's a pull_request section
-	prValue, hasPR := onMap["pull_request"]
-	if !hasPR {
-		return
+	// Draft filtering applies to both pull_request and pull_request_target triggers
+	for _, eventName := range []string{"pull_request", "pull_request_target"} {
+		draftCondition := buildDraftCondition(onMap[eventName], eventName)
+		if draftCondition == nil {
+			continue
+		}
+
+		// Build condition tree and render
+		existingCondition := data.If
+		conditionTree := BuildConditionTree(existingCondition, draftCondition.Render())
+		data.If = conditionTree.Render()
 	}
+}
 
-	// Check if pull_request is an object with draft settings
+// buildDraftCondition builds the draft filter condition for a single pull request event section.
+// Returns nil when the section has no boolean draft setting.
+func buildDraftCondition(prValue any, eventName string) ConditionNode {
+	// Check if the section is an object with draft settings
 	prMap, isPRMap := prValue.(map[string]any)
 	if !isPRMap {
-		return
+		return nil
 	}
 
 	// Check if draft is specified
 	draftValue, hasDraft := prMap["draft"]
 	if !hasDraft {
-		return
+		return nil
 	}
 
 	// Check if draft is a boolean
 	draftBool, isDraftBool := draftValue.(bool)
 	if !isDraftBool {
 		// If draft is not a boolean, don't add filter
-		return
+		return nil
 	}
 
-	filtersLog.Printf("Found draft filter configuration: draft=%v", draftBool)
+	filtersLog.Printf("Found draft filter configuration for %s: draft=%v", eventName, draftBool)
 
-	// Generate conditional logic based on draft value using expression nodes
-	var draftCondition ConditionNode
-	if draftBool {
-		// draft: true - include only draft PRs
-		// The condition should be true for non-pull_request events or for draft pull_requests
-		notPullRequestEvent := BuildNotEquals(
-			BuildPropertyAccess("github.event_name"),
-			BuildStringLiteral("pull_request"),
-		)
-		isDraftPR := BuildEquals(
-			BuildPropertyAccess("github.event.pull_request.draft"),
-			BuildBooleanLiteral(true),
-		)
-		draftCondition = &OrNode{
-			Left:  notPullRequestEvent,
-			Right: isDraftPR,
-		}
-	} else {
-		// draft: false - exclude draft PRs
-		// The condition should be true for non-pull_request events or for non-draft pull_requests
-		notPullRequestEvent := BuildNotEquals(
-			BuildPropertyAccess("github.event_name"),
-			BuildStringLiteral("pull_request"),
-		)
-		isNotDraftPR := BuildEquals(
-			BuildPropertyAccess("github.event.pull_request.draft"),
-			BuildBooleanLiteral(false),
-		)
-		draftCondition = &OrNode{
-			Left:  notPullRequestEvent,
-			Right: isNotDraftPR,
-		}
+	// The condition should be true for other events or for pull requests matching the draft value:
+	// draft: true includes only draft PRs, draft: false excludes draft PRs
+	notPullRequestEvent := BuildNotEquals(
+		BuildPropertyAccess("github.event_name"),
+		BuildStringLiteral(eventName),
+	)
+	matchesDraft := BuildEquals(
+		BuildPropertyAccess("github.event.pull_request.draft"),
+		BuildBooleanLiteral(draftBool),
+	)
+	return &OrNode{
+		Left:  notPullRequestEvent,
+		Right: matchesDraft,
 	}
-
-	// Build condition tree and render
-	existingCondition := data.If
-	conditionTree := BuildConditionTree(existingCondition, draftCondition.Render())
-	data.If = conditionTree.Render()
 }
 
-// applyPullRequestForkFilter applies fork filter conditions for pull_request triggers
+// applyPullRequestForkFilter applies fork filter conditions for pull_request and pull_request_target triggers
 // Supports "forks: []string" with glob patterns
 // Default behavior: When forks field is not specified, only same-repo PRs are allowed (forks are disallowed by default)
 func (c *Compiler) applyPullRequestForkFilter(data *WorkflowData, frontmatter map[string]any) {
@@ -127,16 +114,32 @@ func (c *Compiler) applyPullRequestForkFilter(data *WorkflowData, frontmatter ma
 		return
 	}
 
-	// Check if there's a pull_request section
-	prValue, hasPR := onMap["pull_request"]
+	for _, eventName := range []string{"pull_request", "pull_request_target"} {
+		forkCondition := buildForkCondition(onMap, eventName)
+		if forkCondition == nil {
+			continue
+		}
+
+		// Build condition tree and render
+		existingCondition := data.If
+		conditionTree := BuildConditionTree(existingCondition, forkCondition.Render())
+		data.If = conditionTree.Render()
+	}
+}
+
+// buildForkCondition builds the fork filter condition for a single pull request event section.
+// The default same-repo restriction only applies to pull_request; pull_request_target is
+// filtered only when forks is specified explicitly.
+func buildForkCondition(onMap map[string]any, eventName string) ConditionNode {
+	prValue, hasPR := onMap[eventName]
 	if !hasPR {
-		return
+		return nil
 	}
 
-	// Check if pull_request is an object with fork settings
+	// Check if the section is an object with fork settings
 	prMap, isPRMap := prValue.(map[string]any)
 	if !isPRMap {
-		return
+		return nil
 	}
 
 	// Check for "forks" field (string or array)
@@ -145,11 +148,14 @@ func (c *Compiler) applyPullRequestForkFilter(data *WorkflowData, frontmatter ma
 	// Default behavior: If forks field is not specified, only allow same-repo PRs (disallow all forks by default)
 	var allowedForks []string
 	if !hasForks {
+		if eventName != "pull_request" {
+			return nil
+		}
 		filtersLog.Print("No forks field specified - applying default fork filter (disallow all forks)")
 		// Empty allowedForks array means only same-repo PRs are allowed
 		allowedForks = []string{}
 	} else {
-		filtersLog.Print("Found forks filter configuration")
+		filtersLog.Printf("Found forks filter configuration for %s", eventName)
 
 		// Convert forks value to []string, handling both string and array formats
 		// Handle string format (e.g., forks: "*" or forks: "org/*")
@@ -164,32 +170,27 @@ func (c *Compiler) applyPullRequestForkFilter(data *WorkflowData, frontmatter ma
 			}
 		} else {
 			// Invalid forks format, skip
-			return
+			return nil
 		}
 	}
 
 	// If "*" wildcard is present, skip fork filtering (allow all forks)
 	if slices.Contains(allowedForks, "*") {
 		filtersLog.Print("Wildcard fork pattern detected, allowing all forks")
-		return // No fork filtering needed
+		return nil // No fork filtering needed
 	}
 
 	// Build condition for allowed forks with glob support
 	notPullRequestEvent := BuildNotEquals(
 		BuildPropertyAccess("github.event_name"),
-		BuildStringLiteral("pull_request"),
+		BuildStringLiteral(eventName),
 	)
 	allowedForksCondition := BuildFromAllowedForks(allowedForks)
 
-	forkCondition := &OrNode{
+	return &OrNode{
 		Left:  notPullRequestEvent,
 		Right: allowedForksCondition,
 	}
-
-	// Build condition tree and render
-	existingCondition := data.If
-	conditionTree := BuildConditionTree(existingCondition, forkCondition.Render())
-	data.If = conditionTree.Render()
 }
 
 // applyLabelFilter applies label name filter conditions for labeled/unlabeled triggers
@@ -218,7 +219,7 @@ func (c *Compiler) applyLabelFilter(data *WorkflowData, frontmatter map[string]a
 		return
 	}
 
-	// Check both issues, pull_request, and discussion sections for labeled/unlabeled with names
+	// Check issues, pull_request, pull_request_target, and discussion sections for labeled/unlabeled with names
 	eventSections := []struct {
 		eventName    string
 		eventValue   any
@@ -226,6 +227,7 @@ func (c *Compiler) applyLabelFilter(data *WorkflowData, frontmatter map[string]a
 	}{
 		{"issues", onMap["issues"], "issues"},
 		{"pull_request", onMap["pull_request"], "pull_request"},
+		{"pull_request_target", onMap["pull_request_target"], "pull_request_target"},
 		{"discussion", onMap["discussion"], "discussion"},
 	}
 
@@ -431,3 +433,93 @@ func (c *Compiler) applyLabelFilter(data *WorkflowData, frontmatter map[string]a
 		data.If = conditionTree.Render()
 	}
 }
+
+// applyWorkflowRunConclusionFilter applies conclusion filter conditions for workflow_run triggers.
+// GitHub Actions can only filter workflow_run events by workflow, type and branch, so
+// "conclusions: []string" is enforced by the activation job condition instead.
+func (c *Compiler) applyWorkflowRunConclusionFilter(data *WorkflowData, frontmatter map[string]any) {
+	filtersLog.Print("Applying workflow_run conclusion filter")
+
+	// Use cached On field from ParsedFrontmatter if available, otherwise fall back to map access
+	var onValue any
+	var hasOn bool
+	if data.ParsedFrontmatter != nil && data.ParsedFrontmatter.On != nil {
+		onValue = data.ParsedFrontmatter.On
+		hasOn = true
+	} else {
+		onValue, hasOn = frontmatter["on"]
+	}
+
+	// Check if there's an "on" section in the frontmatter
+	if !hasOn {
+		return
+	}
+
+	// Check if "on" is an object (not a string)
+	onMap, isOnMap := onValue.(map[string]any)
+	if !isOnMap {
+		return
+	}
+
+	conclusionCondition := buildWorkflowRunConclusionCondition(onMap["workflow_run"])
+	if conclusionCondition == nil {
+		return
+	}
+
+	// Build condition tree and render
+	existingCondition := data.If
+	conditionTree := BuildConditionTree(existingCondition, conclusionCondition.Render())
+	data.If = conditionTree.Render()
+}
+
+// buildWorkflowRunConclusionCondition builds the conclusion filter condition for the workflow_run
+// event section. Returns nil when the section has no conclusions.
+func buildWorkflowRunConclusionCondition(workflowRunValue any) ConditionNode {
+	workflowRunMap, isMap := workflowRunValue.(map[string]any)
+	if !isMap {
+		return nil
+	}
+
+	// Convert conclusions to []string, handling both string and array formats
+	var conclusions []string
+	switch conclusionsValue := workflowRunMap["conclusions"].(type) {
+	case string:
+		conclusions = []string{conclusionsValue}
+	case []any:
+		for _, conclusion := range conclusionsValue {
+			if conclusionStr, ok := conclusion.(string); ok {
+				conclusions = append(conclusions, conclusionStr)
+			}
+		}
+	case []string:
+		conclusions = conclusionsValue
+	}
+	if len(conclusions) == 0 {
+		return nil
+	}
+
+	filtersLog.Printf("Found workflow_run conclusions filter: %v", conclusions)
+
+	// (event_name != 'workflow_run') OR (workflow_run.conclusion matches one of the conclusions)
+	var conclusionMatches []ConditionNode
+	for _, conclusion := range conclusions {
+		conclusionMatches = append(conclusionMatches, BuildEquals(
+			BuildPropertyAccess("github.event.workflow_run.conclusion"),
+			BuildStringLiteral(conclusion),
+		))
+	}
+	var conclusionMatch ConditionNode
+	if len(conclusionMatches) == 1 {
+		conclusionMatch = conclusionMatches[0]
+	} else {
+		conclusionMatch = &DisjunctionNode{Terms: conclusionMatches}
+	}
+
+	return &OrNode{
+		Left: BuildNotEquals(
+			BuildPropertyAccess("github.event_name"),
+			BuildStringLiteral("workflow_run"),
+		),
+		Right: conclusionMatch,
+	}
+}
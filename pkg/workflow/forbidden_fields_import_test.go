@@ -22,12 +22,16 @@ func TestForbiddenFieldsImportRejection(t *testing.T) {
 		"command":         `command: /help`,
 		"concurrency":     `concurrency: production`,
 		"container":       `container: node:lts`,
+		"continuation":    `continuation: true`,
 		"env":             `env: {NODE_ENV: production}`,
 		"environment":     `environment: staging`,
+		"expressions":     `expressions: {env-prefix: TRIAGE_}`,
 		"features":        `features: {test: true}`,
 		"github-token":    `github-token: ${{ secrets.TOKEN }}`,
 		"if":              `if: success()`,
 		"name":            `name: Test Workflow`,
+		"needs":           `needs: triage`,
+		"notifications":   `notifications: {webhook: "https://hooks.example.com/gh-aw"}`,
 		"roles":           `roles: ["admin"]`,
 		"run-name":        `run-name: Test Run`,
 		"runs-on":         `runs-on: ubuntu-latest`,
@@ -36,6 +40,7 @@ func TestForbiddenFieldsImportRejection(t *testing.T) {
 		"timeout-minutes": `timeout-minutes: 30`,
 		"timeout_minutes": `timeout_minutes: 30`,
 		"tracker-id":      `tracker-id: "12345"`,
+		"warm-cache":      `warm-cache: true`,
 	}
 
 	for _, field := range constants.SharedWorkflowForbiddenFields {
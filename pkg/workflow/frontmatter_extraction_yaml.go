@@ -100,7 +100,7 @@ func (c *Compiler) extractTopLevelYAMLSection(frontmatter map[string]any, key st
 	return yamlStr
 }
 
-// commentOutProcessedFieldsInOnSection comments out draft, fork, forks, names, manual-approval, stop-after, skip-if-match, skip-if-no-match, skip-roles, reaction, and lock-for-agent fields in the on section
+// commentOutProcessedFieldsInOnSection comments out draft, fork, forks, names, conclusions, checkout-head, manual-approval, stop-after, skip-if-match, skip-if-no-match, skip-roles, reaction, and lock-for-agent fields in the on section
 // These fields are processed separately and should be commented for documentation
 // Exception: names fields in sections with __gh_aw_native_label_filter__ marker in frontmatter are NOT commented out
 func (c *Compiler) commentOutProcessedFieldsInOnSection(yamlStr string, frontmatter map[string]any) string {
@@ -110,7 +110,7 @@ func (c *Compiler) commentOutProcessedFieldsInOnSection(yamlStr string, frontmat
 	nativeLabelFilterSections := make(map[string]bool)
 	if onValue, exists := frontmatter["on"]; exists {
 		if onMap, ok := onValue.(map[string]any); ok {
-			for _, sectionKey := range []string{"issues", "pull_request", "discussion", "issue_comment"} {
+			for _, sectionKey := range []string{"issues", "pull_request", "pull_request_target", "discussion", "issue_comment"} {
 				if sectionValue, hasSec := onMap[sectionKey]; hasSec {
 					if sectionMap, ok := sectionValue.(map[string]any); ok {
 						if marker, hasMarker := sectionMap["__gh_aw_native_label_filter__"]; hasMarker {
@@ -139,16 +139,23 @@ func (c *Compiler) commentOutProcessedFieldsInOnSection(yamlStr string, frontmat
 	inRolesArray := false
 	inBotsArray := false
 	inGitHubApp := false
-	currentSection := "" // Track which section we're in ("issues", "pull_request", "discussion", or "issue_comment")
+	inWorkflowRun := false
+	inConclusionsArray := false
+	currentSection := "" // Track which section we're in ("issues", "pull_request", "pull_request_target", "discussion", or "issue_comment")
 
 	for _, line := range lines {
-		// Check if we're entering a pull_request, issues, discussion, or issue_comment section
-		if strings.Contains(line, "pull_request:") {
+		// Check if we're entering a pull_request, pull_request_target, issues, discussion, or issue_comment section
+		// pull_request_target shares the draft, forks, and names handling of pull_request
+		if strings.Contains(line, "pull_request:") || strings.Contains(line, "pull_request_target:") {
 			inPullRequest = true
 			inIssues = false
 			inDiscussion = false
 			inIssueComment = false
-			currentSection = "pull_request"
+			if strings.Contains(line, "pull_request_target:") {
+				currentSection = "pull_request_target"
+			} else {
+				currentSection = "pull_request"
+			}
 			result = append(result, line)
 			continue
 		}
@@ -195,6 +202,19 @@ func (c *Compiler) commentOutProcessedFieldsInOnSection(yamlStr string, frontmat
 
 		trimmedLine := strings.TrimSpace(line)
 
+		// Track the workflow_run section and its conclusions array
+		if trimmedLine == "workflow_run:" {
+			inWorkflowRun = true
+		} else if inWorkflowRun && trimmedLine != "" && !strings.HasPrefix(line, "    ") && !strings.HasPrefix(line, "\t") {
+			inWorkflowRun = false
+			inConclusionsArray = false
+		}
+		if inWorkflowRun && strings.HasPrefix(trimmedLine, "conclusions:") {
+			inConclusionsArray = true
+		} else if inConclusionsArray && trimmedLine != "" && !strings.HasPrefix(trimmedLine, "-") {
+			inConclusionsArray = false
+		}
+
 		// Skip marker lines in the YAML output
 		if (inPullRequest || inIssues || inDiscussion || inIssueComment) && strings.Contains(trimmedLine, "__gh_aw_native_label_filter__:") {
 			// Don't include the marker line in the output
@@ -432,6 +452,15 @@ func (c *Compiler) commentOutProcessedFieldsInOnSection(yamlStr string, frontmat
 		} else if inForksArray && strings.HasPrefix(trimmedLine, "-") {
 			shouldComment = true
 			commentReason = " # Fork filtering applied via job conditions"
+		} else if inWorkflowRun && strings.HasPrefix(trimmedLine, "conclusions:") {
+			shouldComment = true
+			commentReason = " # Conclusion filtering applied via job conditions"
+		} else if inConclusionsArray && strings.HasPrefix(trimmedLine, "-") {
+			shouldComment = true
+			commentReason = " # Conclusion filtering applied via job conditions"
+		} else if currentSection == "pull_request_target" && strings.HasPrefix(trimmedLine, "checkout-head:") {
+			shouldComment = true
+			commentReason = " # Head checkout applied via quarantined checkout step"
 		} else if (inPullRequest || inIssues || inDiscussion || inIssueComment) && strings.HasPrefix(trimmedLine, "lock-for-agent:") {
 			shouldComment = true
 			commentReason = " # Lock-for-agent processed as issue locking in activation job"
@@ -106,16 +106,16 @@ type RateLimitConfig struct {
 // This provides compile-time type safety and clearer error messages compared to map[string]any
 type FrontmatterConfig struct {
 	// Core workflow fields
-	Name           string   `json:"name,omitempty"`
-	Description    string   `json:"description,omitempty"`
-	Engine         string   `json:"engine,omitempty"`
-	Source         string   `json:"source,omitempty"`
-	TrackerID      string   `json:"tracker-id,omitempty"`
-	Version        string   `json:"version,omitempty"`
-	TimeoutMinutes int      `json:"timeout-minutes,omitempty"`
-	Strict         *bool    `json:"strict,omitempty"`  // Pointer to distinguish unset from false
-	Private        *bool    `json:"private,omitempty"` // If true, workflow cannot be added to other repositories
-	Labels         []string `json:"labels,omitempty"`
+	Name           string      `json:"name,omitempty"`
+	Description    string      `json:"description,omitempty"`
+	Engine         string      `json:"engine,omitempty"`
+	Source         string      `json:"source,omitempty"`
+	TrackerID      string      `json:"tracker-id,omitempty"`
+	Version        string      `json:"version,omitempty"`
+	TimeoutMinutes ComputedInt `json:"timeout-minutes,omitempty"` // Zero when timeout-minutes is a computed expression
+	Strict         *bool       `json:"strict,omitempty"`          // Pointer to distinguish unset from false
+	Private        *bool       `json:"private,omitempty"`         // If true, workflow cannot be added to other repositories
+	Labels         []string    `json:"labels,omitempty"`
 
 	// Configuration sections - using strongly-typed structs
 	Tools            *ToolsConfig       `json:"tools,omitempty"`
@@ -505,7 +505,7 @@ func (fc *FrontmatterConfig) ToMap() map[string]any {
 		result["version"] = fc.Version
 	}
 	if fc.TimeoutMinutes != 0 {
-		result["timeout-minutes"] = fc.TimeoutMinutes
+		result["timeout-minutes"] = int(fc.TimeoutMinutes)
 	}
 	if fc.Strict != nil {
 		result["strict"] = *fc.Strict
@@ -0,0 +1,188 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var frontmatterVarsLog = logger.New("workflow:frontmatter_vars")
+
+// allowedDomainsVarsEnvPrefix prefixes the environment variables that carry network.allowed
+// entries coming from repository or organization variables; the AWF --allow-domains argument
+// appends each of them at runtime
+const allowedDomainsVarsEnvPrefix = "GH_AW_ALLOWED_DOMAINS_VAR_"
+
+var (
+	// varsReferencePattern matches an expression that reads a repository or organization variable
+	varsReferencePattern = regexp.MustCompile(`\$\{\{[^}]*\bvars\.`)
+	// varsExpressionPattern matches a value that is exactly one ${{ vars.NAME }} expression
+	varsExpressionPattern = regexp.MustCompile(`^\$\{\{\s*vars\.[A-Za-z_][A-Za-z0-9_]*\s*\}\}$`)
+)
+
+// compileTimeFrontmatterFields lists the frontmatter fields the compiler resolves while generating
+// the lock file. A ${{ vars.* }} expression in these fields would be compiled as a literal string,
+// so it is rejected. Entries match the field and everything nested below it.
+var compileTimeFrontmatterFields = []string{
+	"on",
+	"permissions",
+	"imports",
+	"strict",
+	"strict-rules",
+	"features",
+	"runtimes",
+	"engine.id",
+	"network.blocked",
+	"network.firewall",
+	"tools.github.toolsets",
+	"tools.github.allowed",
+	"tools.bash",
+	"tools.edit",
+}
+
+// runtimeFrontmatterFields are fields nested under compileTimeFrontmatterFields that are
+// evaluated when the workflow runs, such as the GitHub App used by the activation job
+var runtimeFrontmatterFields = []string{
+	"on.github-app",
+	"on.github-token",
+}
+
+// isCompileTimeFrontmatterField reports whether a field (without list indexes) is resolved at compile time
+func isCompileTimeFrontmatterField(fieldPath string) bool {
+	matches := func(fields []string) bool {
+		for _, field := range fields {
+			if fieldPath == field || strings.HasPrefix(fieldPath, field+".") {
+				return true
+			}
+		}
+		return false
+	}
+	return matches(compileTimeFrontmatterFields) && !matches(runtimeFrontmatterFields)
+}
+
+// validateFrontmatterVarsUsage checks where ${{ vars.* }} expressions appear in the frontmatter.
+// Variables let organization admins tune agent behavior (such as the model or extra allowed
+// domains) without editing workflows, but only in fields that are evaluated when the workflow runs.
+func validateFrontmatterVarsUsage(frontmatter map[string]any) error {
+	var errs []string
+	walkFrontmatterStrings(frontmatter, "", func(path, value string) {
+		if !varsReferencePattern.MatchString(value) {
+			return
+		}
+		fieldPath := stripIndexes(path)
+		if isCompileTimeFrontmatterField(fieldPath) {
+			errs = append(errs, fmt.Sprintf("'%s' is resolved at compile time and cannot use ${{ vars.* }} (found %q)", path, value))
+			return
+		}
+		if fieldPath == "network.allowed" && !varsExpressionPattern.MatchString(strings.TrimSpace(value)) {
+			errs = append(errs, fmt.Sprintf("'%s' must be a domain or exactly one ${{ vars.NAME }} expression holding a comma-separated domain list (found %q)", path, value))
+		}
+	})
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Strings(errs)
+	frontmatterVarsLog.Printf("Found %d invalid vars references", len(errs))
+	return fmt.Errorf("invalid ${{ vars.* }} usage in frontmatter:\n  - %s", strings.Join(errs, "\n  - "))
+}
+
+// walkFrontmatterStrings calls fn for every string value with its dotted path (e.g. "network.allowed[1]")
+func walkFrontmatterStrings(value any, path string, fn func(path, value string)) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			walkFrontmatterStrings(child, childPath, fn)
+		}
+	case []any:
+		for i, child := range v {
+			walkFrontmatterStrings(child, fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	case string:
+		fn(path, v)
+	}
+}
+
+// stripIndexes removes list indexes from a frontmatter path ("network.allowed[1]" -> "network.allowed")
+func stripIndexes(path string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range path {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// extractNetworkVarsExpressions moves the ${{ vars.NAME }} entries of network.allowed (including
+// entries merged from imports) to AllowedVars so that compile-time domain handling only sees
+// literal domains and ecosystem identifiers
+func extractNetworkVarsExpressions(network *NetworkPermissions) {
+	if network == nil {
+		return
+	}
+	literals := make([]string, 0, len(network.Allowed))
+	for _, entry := range network.Allowed {
+		if varsExpressionPattern.MatchString(strings.TrimSpace(entry)) {
+			network.AllowedVars = append(network.AllowedVars, strings.TrimSpace(entry))
+			continue
+		}
+		literals = append(literals, entry)
+	}
+	if len(network.AllowedVars) > 0 {
+		frontmatterVarsLog.Printf("Resolving %d network.allowed entries from variables at runtime", len(network.AllowedVars))
+		network.Allowed = literals
+	}
+}
+
+// allowedDomainsVarsEnvNames returns the environment variable names holding the variable-backed
+// network.allowed entries, in the order they were declared
+func allowedDomainsVarsEnvNames(network *NetworkPermissions) []string {
+	if network == nil {
+		return nil
+	}
+	names := make([]string, 0, len(network.AllowedVars))
+	for i := range network.AllowedVars {
+		names = append(names, fmt.Sprintf("%s%d", allowedDomainsVarsEnvPrefix, i+1))
+	}
+	return names
+}
+
+// applyAllowedDomainsVarsEnvToMap adds the variable-backed network.allowed entries to the
+// agent execution environment
+func applyAllowedDomainsVarsEnvToMap(env map[string]string, data *WorkflowData) {
+	if data.NetworkPermissions == nil {
+		return
+	}
+	for i, name := range allowedDomainsVarsEnvNames(data.NetworkPermissions) {
+		env[name] = data.NetworkPermissions.AllowedVars[i]
+	}
+}
+
+// appendAllowedDomainsVars extends a double-quoted --allow-domains argument with the
+// variable-backed entries. Each variable is expanded by the shell and skipped when empty.
+func appendAllowedDomainsVars(quotedDomains string, network *NetworkPermissions) string {
+	names := allowedDomainsVarsEnvNames(network)
+	if len(names) == 0 {
+		return quotedDomains
+	}
+	var b strings.Builder
+	b.WriteString(strings.TrimSuffix(quotedDomains, "\""))
+	for _, name := range names {
+		fmt.Fprintf(&b, "${%s:+,${%s}}", name, name)
+	}
+	b.WriteString("\"")
+	return b.String()
+}
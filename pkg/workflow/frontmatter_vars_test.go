@@ -0,0 +1,105 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFrontmatterVarsUsage(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		wantErr     string
+	}{
+		{
+			name: "runtime fields",
+			frontmatter: map[string]any{
+				"engine":  map[string]any{"id": "copilot", "model": "${{ vars.AGENT_MODEL }}"},
+				"network": map[string]any{"allowed": []any{"defaults", "${{ vars.EXTRA_DOMAINS }}"}},
+				"env":     map[string]any{"TARGET": "${{ vars.TARGET || github.repository }}"},
+				"on":      map[string]any{"issues": nil, "github-app": map[string]any{"app-id": "${{ vars.APP_ID }}"}},
+			},
+		},
+		{
+			name:        "engine id",
+			frontmatter: map[string]any{"engine": map[string]any{"id": "${{ vars.ENGINE }}"}},
+			wantErr:     "'engine.id' is resolved at compile time",
+		},
+		{
+			name:        "nested compile-time field",
+			frontmatter: map[string]any{"on": map[string]any{"schedule": []any{map[string]any{"cron": "${{ vars.CRON }}"}}}},
+			wantErr:     "'on.schedule[0].cron' is resolved at compile time",
+		},
+		{
+			name:        "embedded in a domain",
+			frontmatter: map[string]any{"network": map[string]any{"allowed": []any{"api.${{ vars.HOST }}.com"}}},
+			wantErr:     "'network.allowed[0]' must be a domain or exactly one ${{ vars.NAME }} expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFrontmatterVarsUsage(tt.frontmatter)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "vars should be accepted")
+				return
+			}
+			require.Error(t, err, "vars should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should name the field")
+		})
+	}
+}
+
+func TestExtractNetworkVarsExpressions(t *testing.T) {
+	network := &NetworkPermissions{Allowed: []string{"defaults", "${{ vars.A }}", "example.com", " ${{ vars.B }} "}}
+	extractNetworkVarsExpressions(network)
+
+	assert.Equal(t, []string{"defaults", "example.com"}, network.Allowed, "literal entries should stay in allowed")
+	assert.Equal(t, []string{"${{ vars.A }}", "${{ vars.B }}"}, network.AllowedVars, "vars entries should move to AllowedVars")
+	assert.Equal(t,
+		`"example.com${GH_AW_ALLOWED_DOMAINS_VAR_1:+,${GH_AW_ALLOWED_DOMAINS_VAR_1}}${GH_AW_ALLOWED_DOMAINS_VAR_2:+,${GH_AW_ALLOWED_DOMAINS_VAR_2}}"`,
+		appendAllowedDomainsVars(`"example.com"`, network),
+		"each variable should be appended when set")
+	assert.Equal(t, `"example.com"`, appendAllowedDomainsVars(`"example.com"`, &NetworkPermissions{}), "argument should be unchanged without vars")
+}
+
+func TestCompileWorkflowWithVarsAllowedDomains(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "frontmatter-vars-test")
+	workflowPath := filepath.Join(tmpDir, "vars.md")
+	content := `---
+on: issues
+engine:
+  id: copilot
+  model: ${{ vars.AGENT_MODEL }}
+network:
+  allowed:
+    - defaults
+    - ${{ vars.EXTRA_DOMAINS }}
+permissions:
+  contents: read
+safe-outputs:
+  add-comment:
+---
+
+Triage the issue.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "GH_AW_ALLOWED_DOMAINS_VAR_1: ${{ vars.EXTRA_DOMAINS }}", "variable should be passed to the agent step")
+	assert.Contains(t, lock, `${GH_AW_ALLOWED_DOMAINS_VAR_1:+,${GH_AW_ALLOWED_DOMAINS_VAR_1}}"`, "firewall should append the variable domains")
+	assert.Contains(t, lock, `,${{ vars.EXTRA_DOMAINS }}"`, "sanitizer should allow the variable domains")
+	assert.Contains(t, lock, "COPILOT_MODEL: ${{ vars.AGENT_MODEL }}", "model should be read from the variable")
+}
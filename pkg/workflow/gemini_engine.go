@@ -18,16 +18,18 @@ type GeminiEngine struct {
 func NewGeminiEngine() *GeminiEngine {
 	return &GeminiEngine{
 		BaseEngine: BaseEngine{
-			id:                     "gemini",
-			displayName:            "Google Gemini CLI",
-			description:            "Google Gemini CLI with headless mode and LLM gateway support",
-			experimental:           false,
-			supportsToolsAllowlist: true,
-			supportsMaxTurns:       false,
-			supportsWebFetch:       false,
-			supportsWebSearch:      false,
-			supportsPlugins:        false,
-			llmGatewayPort:         constants.GeminiLLMGatewayPort,
+			id:                      "gemini",
+			displayName:             "Google Gemini CLI",
+			description:             "Google Gemini CLI with headless mode and LLM gateway support",
+			experimental:            false,
+			supportsToolsAllowlist:  true,
+			supportsMaxTurns:        false,
+			supportsWebFetch:        false,
+			supportsWebSearch:       false,
+			supportsBashAllowlist:   true,
+			supportsWorkspaceAccess: true,
+			supportsPlugins:         false,
+			llmGatewayPort:          constants.GeminiLLMGatewayPort,
 		},
 	}
 }
@@ -132,6 +134,7 @@ func (e *GeminiEngine) GetInstallationSteps(workflowData *WorkflowData) []GitHub
 		config.CliName,
 		true, // Include Node.js setup
 	)
+	npmSteps = applyNpmEngineInstallOptions(npmSteps, config.NpmPackage, geminiVersion, config.CliName, workflowData)
 
 	if len(npmSteps) > 0 {
 		steps = append(steps, npmSteps[0]) // Setup Node.js step
@@ -277,6 +280,9 @@ touch %s
 	// Add safe outputs env
 	applySafeOutputEnvToMap(env, workflowData)
 
+	// Add network.allowed entries resolved from repository or organization variables
+	applyAllowedDomainsVarsEnvToMap(env, workflowData)
+
 	// Set the model environment variable only when explicitly configured.
 	// When model is configured, use the native GEMINI_MODEL env var - the Gemini CLI reads it
 	// directly, avoiding the need to embed the value in the shell command (which would fail
@@ -0,0 +1,199 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var githubHostLog = logger.New("workflow:github_host")
+
+// githubHostnamePattern matches a DNS hostname with an optional port
+var githubHostnamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*(:[0-9]+)?$`)
+
+// ghesVersionPattern matches a GitHub Enterprise Server release (e.g. 3.16 or 3.16.2)
+var ghesVersionPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+(\.[0-9]+)?$`)
+
+// GitHubHostConfig represents the GitHub Enterprise host a workflow targets (github-host:)
+//
+// Examples:
+//
+//	github-host: github.example.com
+//
+//	github-host:
+//	  host: github.example.com
+//	  min-version: "3.16"
+type GitHubHostConfig struct {
+	Host       string `json:"host"`                  // Normalized hostname (no scheme or path)
+	MinVersion string `json:"min-version,omitempty"` // Minimum GHES version verified at runtime (GHES only)
+}
+
+// IsGHEC reports whether the host is a GHE.com (data residency) tenant rather than a GitHub Enterprise Server instance
+func (g *GitHubHostConfig) IsGHEC() bool {
+	return strings.HasSuffix(g.Host, ".ghe.com")
+}
+
+// ServerURL returns the web URL of the host
+func (g *GitHubHostConfig) ServerURL() string {
+	return "https://" + g.Host
+}
+
+// Domains returns the domains the agent needs to reach the host
+func (g *GitHubHostConfig) Domains() []string {
+	hostname, _, _ := strings.Cut(g.Host, ":")
+	if g.IsGHEC() {
+		return []string{hostname, "api." + hostname}
+	}
+	return []string{hostname}
+}
+
+// getGitHubHostURL returns the GitHub Enterprise URL for the GitHub MCP server, or an empty string for github.com
+func getGitHubHostURL(workflowData *WorkflowData) string {
+	if workflowData == nil || workflowData.GitHubHost == nil {
+		return ""
+	}
+	return workflowData.GitHubHost.ServerURL()
+}
+
+// normalizeGitHubHost converts a hostname or URL into a bare hostname.
+// Returns an empty string for github.com, which is the default target.
+func normalizeGitHubHost(value string) (string, error) {
+	host := strings.ToLower(strings.TrimSpace(value))
+	if strings.HasPrefix(host, "http://") {
+		return "", fmt.Errorf("github-host must use https, got %q", value)
+	}
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimSuffix(host, "/")
+	host = strings.TrimSuffix(host, "/api/v3")
+
+	if !githubHostnamePattern.MatchString(host) {
+		return "", fmt.Errorf("github-host must be a hostname or https URL (e.g. github.example.com), got %q", value)
+	}
+	if host == "github.com" || host == "api.github.com" {
+		return "", nil
+	}
+	return host, nil
+}
+
+// extractGitHubHostConfig extracts the GitHub Enterprise host from frontmatter.
+// The --github-host compiler option takes precedence over the frontmatter value.
+func (c *Compiler) extractGitHubHostConfig(frontmatter map[string]any) (*GitHubHostConfig, error) {
+	var hostValue string
+	var minVersion string
+
+	switch value := frontmatter["github-host"].(type) {
+	case nil:
+	case string:
+		hostValue = value
+	case map[string]any:
+		host, ok := value["host"].(string)
+		if !ok {
+			return nil, fmt.Errorf("github-host.host must be a string, got %T", value["host"])
+		}
+		hostValue = host
+		if v, exists := value["min-version"]; exists {
+			versionStr, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("github-host.min-version must be a string (e.g. \"3.16\"), got %T", v)
+			}
+			minVersion = versionStr
+		}
+	default:
+		return nil, fmt.Errorf("github-host must be a hostname or an object with host and min-version, got %T. Example:\ngithub-host: github.example.com", value)
+	}
+
+	if c.githubHost != "" {
+		if hostValue != "" && !strings.EqualFold(hostValue, c.githubHost) {
+			githubHostLog.Printf("Command line --github-host %s overrides frontmatter github-host: %s", c.githubHost, hostValue)
+		}
+		hostValue = c.githubHost
+	}
+	if hostValue == "" {
+		return nil, nil
+	}
+
+	host, err := normalizeGitHubHost(hostValue)
+	if err != nil {
+		return nil, err
+	}
+	if host == "" {
+		githubHostLog.Print("github-host targets github.com, using default endpoints")
+		return nil, nil
+	}
+
+	config := &GitHubHostConfig{Host: host}
+	if !config.IsGHEC() {
+		config.MinVersion, err = resolveMinimumGHESVersion(minVersion)
+		if err != nil {
+			return nil, err
+		}
+	} else if minVersion != "" {
+		return nil, fmt.Errorf("github-host.min-version only applies to GitHub Enterprise Server, but %s is a GHE.com host", host)
+	}
+
+	githubHostLog.Printf("Extracted github-host: host=%s, min-version=%s", config.Host, config.MinVersion)
+	return config, nil
+}
+
+// resolveMinimumGHESVersion validates the requested minimum GHES version against the oldest supported release
+func resolveMinimumGHESVersion(minVersion string) (string, error) {
+	supported := string(constants.MinimumGHESVersion)
+	if minVersion == "" {
+		return supported, nil
+	}
+	if !ghesVersionPattern.MatchString(minVersion) {
+		return "", fmt.Errorf("github-host.min-version must be a GitHub Enterprise Server release such as \"3.16\", got %q", minVersion)
+	}
+	if compareVersions(minVersion, supported) < 0 {
+		return "", fmt.Errorf("github-host.min-version %s is not supported: gh-aw requires GitHub Enterprise Server %s or later", minVersion, supported)
+	}
+	return minVersion, nil
+}
+
+// validateGitHubHost validates that the workflow configuration is compatible with the GitHub Enterprise host
+func validateGitHubHost(workflowData *WorkflowData) error {
+	if workflowData.GitHubHost == nil {
+		return nil
+	}
+	if githubTool, ok := workflowData.Tools["github"]; ok && githubTool != false && getGitHubType(githubTool) == "remote" {
+		return fmt.Errorf("tools.github.mode: remote is not available for github-host %s. The hosted GitHub MCP server only serves github.com; use the local mode instead", workflowData.GitHubHost.Host)
+	}
+	return nil
+}
+
+// addGitHubHostDomains adds the GitHub Enterprise host to the network allow-list
+func addGitHubHostDomains(network *NetworkPermissions, githubHost *GitHubHostConfig) {
+	if network == nil || githubHost == nil {
+		return
+	}
+	for _, domain := range githubHost.Domains() {
+		if !slices.Contains(network.Allowed, domain) {
+			network.Allowed = append(network.Allowed, domain)
+		}
+	}
+}
+
+// generateGitHubHostValidationStep generates the activation step that verifies the workflow runs
+// on the configured GitHub Enterprise host with a supported server version
+func (c *Compiler) generateGitHubHostValidationStep(data *WorkflowData) []string {
+	if data.GitHubHost == nil {
+		return nil
+	}
+
+	var steps []string
+	steps = append(steps, "      - name: Validate GitHub Enterprise host\n")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/github-script")))
+	steps = append(steps, "        env:\n")
+	steps = append(steps, fmt.Sprintf("          GH_AW_GITHUB_HOST: %q\n", data.GitHubHost.Host))
+	if data.GitHubHost.MinVersion != "" {
+		steps = append(steps, fmt.Sprintf("          GH_AW_GHES_MIN_VERSION: %q\n", data.GitHubHost.MinVersion))
+	}
+	steps = append(steps, "        with:\n")
+	steps = append(steps, "          script: |\n")
+	steps = append(steps, generateGitHubScriptWithRequire("validate_github_host.cjs"))
+	return steps
+}
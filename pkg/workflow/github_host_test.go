@@ -0,0 +1,201 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeGitHubHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  string
+	}{
+		{name: "hostname", value: "github.example.com", expected: "github.example.com"},
+		{name: "https URL", value: "https://GitHub.Example.com/", expected: "github.example.com"},
+		{name: "API URL", value: "https://github.example.com/api/v3", expected: "github.example.com"},
+		{name: "hostname with port", value: "github.example.com:8443", expected: "github.example.com:8443"},
+		{name: "ghe.com tenant", value: "octocorp.ghe.com", expected: "octocorp.ghe.com"},
+		{name: "github.com is the default", value: "github.com", expected: ""},
+		{name: "http is rejected", value: "http://github.example.com", wantErr: "must use https"},
+		{name: "path is rejected", value: "github.example.com/org", wantErr: "must be a hostname or https URL"},
+		{name: "empty is rejected", value: " ", wantErr: "must be a hostname or https URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, err := normalizeGitHubHost(tt.value)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected normalization error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error should describe the invalid host")
+				return
+			}
+			require.NoError(t, err, "Normalization should succeed")
+			assert.Equal(t, tt.expected, host, "Normalized host should match")
+		})
+	}
+}
+
+func TestExtractGitHubHostConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		override    string
+		expected    *GitHubHostConfig
+		wantErr     string
+	}{
+		{
+			name:        "not configured",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "string host uses the minimum supported version",
+			frontmatter: map[string]any{"github-host": "https://github.example.com"},
+			expected:    &GitHubHostConfig{Host: "github.example.com", MinVersion: string(constants.MinimumGHESVersion)},
+		},
+		{
+			name:        "object with min-version",
+			frontmatter: map[string]any{"github-host": map[string]any{"host": "github.example.com", "min-version": "3.16"}},
+			expected:    &GitHubHostConfig{Host: "github.example.com", MinVersion: "3.16"},
+		},
+		{
+			name:        "ghe.com host has no version check",
+			frontmatter: map[string]any{"github-host": "octocorp.ghe.com"},
+			expected:    &GitHubHostConfig{Host: "octocorp.ghe.com"},
+		},
+		{
+			name:        "github.com is the default target",
+			frontmatter: map[string]any{"github-host": "github.com"},
+		},
+		{
+			name:        "command line override takes precedence",
+			frontmatter: map[string]any{"github-host": "github.example.com"},
+			override:    "ghes.internal.example",
+			expected:    &GitHubHostConfig{Host: "ghes.internal.example", MinVersion: string(constants.MinimumGHESVersion)},
+		},
+		{
+			name:        "command line override without frontmatter",
+			frontmatter: map[string]any{},
+			override:    "github.example.com",
+			expected:    &GitHubHostConfig{Host: "github.example.com", MinVersion: string(constants.MinimumGHESVersion)},
+		},
+		{
+			name:        "min-version below the supported release",
+			frontmatter: map[string]any{"github-host": map[string]any{"host": "github.example.com", "min-version": "3.9"}},
+			wantErr:     "requires GitHub Enterprise Server " + string(constants.MinimumGHESVersion) + " or later",
+		},
+		{
+			name:        "invalid min-version",
+			frontmatter: map[string]any{"github-host": map[string]any{"host": "github.example.com", "min-version": "latest"}},
+			wantErr:     "must be a GitHub Enterprise Server release",
+		},
+		{
+			name:        "min-version on a ghe.com host",
+			frontmatter: map[string]any{"github-host": map[string]any{"host": "octocorp.ghe.com", "min-version": "3.16"}},
+			wantErr:     "only applies to GitHub Enterprise Server",
+		},
+		{
+			name:        "invalid type",
+			frontmatter: map[string]any{"github-host": 42},
+			wantErr:     "github-host must be a hostname or an object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler(WithGitHubHost(tt.override))
+			config, err := compiler.extractGitHubHostConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected extraction error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error should describe the invalid github-host")
+				return
+			}
+			require.NoError(t, err, "Extraction should succeed")
+			assert.Equal(t, tt.expected, config, "GitHub host config should match")
+		})
+	}
+}
+
+func TestGitHubHostConfigDomains(t *testing.T) {
+	assert.Equal(t, []string{"github.example.com"}, (&GitHubHostConfig{Host: "github.example.com:8443"}).Domains(), "GHES serves the API from the same host")
+	assert.Equal(t, []string{"octocorp.ghe.com", "api.octocorp.ghe.com"}, (&GitHubHostConfig{Host: "octocorp.ghe.com"}).Domains(), "GHE.com serves the API from the api subdomain")
+}
+
+func TestValidateGitHubHost(t *testing.T) {
+	host := &GitHubHostConfig{Host: "github.example.com"}
+
+	assert.NoError(t, validateGitHubHost(&WorkflowData{}), "github.com workflows are always valid")
+	assert.NoError(t, validateGitHubHost(&WorkflowData{GitHubHost: host, Tools: map[string]any{"github": map[string]any{}}}), "Local GitHub MCP server is supported")
+
+	err := validateGitHubHost(&WorkflowData{GitHubHost: host, Tools: map[string]any{"github": map[string]any{"mode": "remote"}}})
+	require.Error(t, err, "Remote GitHub MCP server should be rejected")
+	assert.Contains(t, err.Error(), "mode: remote is not available", "Error should explain the remote mode limitation")
+}
+
+func TestGitHubHostCompiledWorkflow(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		override    string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "github.com",
+			frontmatter: "tools:\n  github:\n",
+			notContains: []string{"GITHUB_HOST", "validate_github_host.cjs"},
+		},
+		{
+			name:        "frontmatter host",
+			frontmatter: "github-host: github.example.com\ntools:\n  github:\n",
+			contains: []string{
+				`"GITHUB_HOST": "https://github.example.com"`,
+				"Validate GitHub Enterprise host",
+				`GH_AW_GITHUB_HOST: "github.example.com"`,
+				`GH_AW_GHES_MIN_VERSION: "` + string(constants.MinimumGHESVersion) + `"`,
+				"validate_github_host.cjs",
+				"github.example.com",
+			},
+		},
+		{
+			name:        "command line host",
+			frontmatter: "tools:\n  github:\n",
+			override:    "github.example.com",
+			contains:    []string{`"GITHUB_HOST": "https://github.example.com"`, "validate_github_host.cjs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "github-host-*")
+			workflowsDir := filepath.Join(tmpDir, constants.GetWorkflowDir())
+			require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows directory")
+
+			content := "---\non: workflow_dispatch\nengine: copilot\n" + tt.frontmatter + "---\n\n# GitHub host test\n"
+			workflowFile := filepath.Join(workflowsDir, "github-host.md")
+			require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+			compiler := NewCompiler(WithGitHubHost(tt.override))
+			require.NoError(t, compiler.CompileWorkflow(workflowFile), "Workflow should compile")
+
+			lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "github-host.lock.yml"))
+			require.NoError(t, err, "Lock file should be created")
+
+			for _, expected := range tt.contains {
+				assert.Contains(t, string(lockContent), expected, "Lock file should contain %q", expected)
+			}
+			for _, unexpected := range tt.notContains {
+				assert.NotContains(t, string(lockContent), unexpected, "Lock file should not contain %q", unexpected)
+			}
+		})
+	}
+}
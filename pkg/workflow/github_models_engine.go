@@ -0,0 +1,200 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var githubModelsLog = logger.New("workflow:github_models_engine")
+
+// githubModelsAgentScript is the agent loop that calls the GitHub Models API and runs
+// the requested MCP tool calls (actions/setup/js/github_models_agent.cjs)
+const githubModelsAgentScript = SetupActionDestination + "/github_models_agent.cjs"
+
+// githubModelsMCPConfigPath is where convert_gateway_config_github_models.sh writes the
+// MCP server configuration read by the agent script
+const githubModelsMCPConfigPath = "/tmp/gh-aw/mcp-config/github-models-mcp.json"
+
+// GitHubModelsEngine represents the GitHub Models agentic engine.
+// It runs inference through the GitHub Models API authenticated with the workflow's
+// GITHUB_TOKEN (models: read), so no external API key or secret is needed.
+type GitHubModelsEngine struct {
+	BaseEngine
+}
+
+func NewGitHubModelsEngine() *GitHubModelsEngine {
+	return &GitHubModelsEngine{
+		BaseEngine: BaseEngine{
+			id:                     string(constants.GitHubModelsEngine),
+			displayName:            "GitHub Models",
+			description:            "GitHub Models inference with the built-in GITHUB_TOKEN, using MCP tools only",
+			experimental:           true,
+			supportsToolsAllowlist: true,
+			supportsMaxTurns:       true,
+			supportsWebFetch:       false,
+			supportsWebSearch:      false,
+			supportsBashAllowlist:  false,
+			supportsPlugins:        false,
+		},
+	}
+}
+
+// GetModelEnvVarName returns the environment variable the agent script reads for model selection
+func (e *GitHubModelsEngine) GetModelEnvVarName() string {
+	return constants.GitHubModelsModelEnvVar
+}
+
+// GetRequiredSecretNames returns the list of secrets required by the GitHub Models engine.
+// Inference uses the workflow token, so only MCP-related secrets are needed.
+func (e *GitHubModelsEngine) GetRequiredSecretNames(workflowData *WorkflowData) []string {
+	githubModelsLog.Print("Collecting required secrets for GitHub Models engine")
+	var secrets []string
+
+	// Add MCP gateway API key if MCP servers are present (gateway is always started with MCP servers)
+	if HasMCPServers(workflowData) {
+		secrets = append(secrets, "MCP_GATEWAY_API_KEY")
+	}
+
+	// Add GitHub token for GitHub MCP server if present
+	if hasGitHubTool(workflowData.ParsedTools) {
+		secrets = append(secrets, "GITHUB_MCP_SERVER_TOKEN")
+	}
+
+	// Add HTTP MCP header secret names
+	for varName := range collectHTTPMCPHeaderSecrets(workflowData.Tools) {
+		secrets = append(secrets, varName)
+	}
+
+	// Add safe-inputs secret names
+	if IsSafeInputsEnabled(workflowData.SafeInputs, workflowData) {
+		for varName := range collectSafeInputsSecrets(workflowData.SafeInputs) {
+			secrets = append(secrets, varName)
+		}
+	}
+
+	return secrets
+}
+
+// GetInstallationSteps returns the Node.js setup for the agent script and, when the
+// firewall is enabled, the AWF installation. There is no CLI to install.
+func (e *GitHubModelsEngine) GetInstallationSteps(workflowData *WorkflowData) []GitHubActionStep {
+	githubModelsLog.Printf("Generating installation steps for GitHub Models engine: workflow=%s", workflowData.Name)
+
+	// Skip installation if custom command is specified
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.Command != "" {
+		githubModelsLog.Printf("Skipping installation steps: custom command specified (%s)", workflowData.EngineConfig.Command)
+		return []GitHubActionStep{}
+	}
+
+	steps := []GitHubActionStep{GenerateNodeJsSetupStep()}
+
+	// Add AWF installation if firewall is enabled
+	if isFirewallEnabled(workflowData) {
+		firewallConfig := getFirewallConfig(workflowData)
+		agentConfig := getAgentConfig(workflowData)
+		var awfVersion string
+		if firewallConfig != nil {
+			awfVersion = firewallConfig.Version
+		}
+
+		awfInstall := generateAWFInstallationStep(awfVersion, agentConfig)
+		if len(awfInstall) > 0 {
+			steps = append(steps, awfInstall)
+		}
+	}
+
+	return steps
+}
+
+// GetExecutionSteps returns the GitHub Actions steps for executing the GitHub Models agent
+func (e *GitHubModelsEngine) GetExecutionSteps(workflowData *WorkflowData, logFile string) []GitHubActionStep {
+	githubModelsLog.Printf("Generating execution steps for GitHub Models engine: workflow=%s, firewall=%v", workflowData.Name, isFirewallEnabled(workflowData))
+
+	agentCommand := "node " + githubModelsAgentScript
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.Command != "" {
+		agentCommand = workflowData.EngineConfig.Command
+	}
+
+	// Build the full command with AWF wrapping if enabled
+	var command string
+	if isFirewallEnabled(workflowData) {
+		allowedDomains := GetAllowedDomainsForEngine(
+			constants.GitHubModelsEngine,
+			workflowData.NetworkPermissions,
+			workflowData.Tools,
+			workflowData.Runtimes,
+		)
+
+		command = BuildAWFCommand(AWFCommandConfig{
+			EngineName:     e.GetID(),
+			EngineCommand:  agentCommand,
+			LogFile:        logFile,
+			WorkflowData:   workflowData,
+			UsesTTY:        false,
+			AllowedDomains: allowedDomains,
+			// Create the agent step summary file before AWF starts so it is accessible
+			// inside the sandbox.
+			PathSetup: "touch " + AgentStepSummaryPath,
+		})
+	} else {
+		command = fmt.Sprintf(`set -o pipefail
+touch %s
+%s 2>&1 | tee -a %s`, AgentStepSummaryPath, agentCommand, logFile)
+	}
+
+	// Build environment variables
+	env := map[string]string{
+		// The workflow token authenticates with the GitHub Models API (requires models: read)
+		"GH_AW_GITHUB_MODELS_TOKEN": "${{ github.token }}",
+		"GH_AW_PROMPT":              "/tmp/gh-aw/aw-prompts/prompt.txt",
+		"GITHUB_WORKSPACE":          "${{ github.workspace }}",
+		// Override GITHUB_STEP_SUMMARY with a path that exists inside the sandbox.
+		"GITHUB_STEP_SUMMARY": AgentStepSummaryPath,
+	}
+
+	if HasMCPServers(workflowData) {
+		env["GH_AW_MCP_CONFIG"] = githubModelsMCPConfigPath
+	}
+
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.MaxTurns != "" {
+		env["GH_AW_MAX_TURNS"] = workflowData.EngineConfig.MaxTurns
+	}
+
+	// Add safe outputs env
+	applySafeOutputEnvToMap(env, workflowData)
+
+	// Add network.allowed entries resolved from repository or organization variables
+	applyAllowedDomainsVarsEnvToMap(env, workflowData)
+
+	// Set the model only when explicitly configured; the agent script has its own default
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.Model != "" {
+		githubModelsLog.Printf("Setting %s env var for model: %s", constants.GitHubModelsModelEnvVar, workflowData.EngineConfig.Model)
+		env[constants.GitHubModelsModelEnvVar] = workflowData.EngineConfig.Model
+	}
+
+	// Add custom environment variables from engine config
+	if workflowData.EngineConfig != nil && len(workflowData.EngineConfig.Env) > 0 {
+		maps.Copy(env, workflowData.EngineConfig.Env)
+	}
+
+	// Add custom environment variables from agent config
+	agentConfig := getAgentConfig(workflowData)
+	if agentConfig != nil && len(agentConfig.Env) > 0 {
+		maps.Copy(env, agentConfig.Env)
+		githubModelsLog.Printf("Added %d custom env vars from agent config", len(agentConfig.Env))
+	}
+
+	stepLines := []string{
+		"      - name: Execute GitHub Models agent",
+		"        id: agentic_execution",
+	}
+
+	// Filter environment variables for security
+	filteredEnv := FilterEnvForSecrets(env, e.GetRequiredSecretNames(workflowData))
+	stepLines = FormatStepWithCommandAndEnv(stepLines, command, filteredEnv)
+
+	return []GitHubActionStep{GitHubActionStep(stepLines)}
+}
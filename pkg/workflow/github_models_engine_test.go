@@ -0,0 +1,202 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubModelsEngine(t *testing.T) {
+	engine := NewGitHubModelsEngine()
+
+	t.Run("engine identity", func(t *testing.T) {
+		assert.Equal(t, "github-models", engine.GetID(), "Engine ID should be 'github-models'")
+		assert.Equal(t, "GitHub Models", engine.GetDisplayName(), "Display name should be 'GitHub Models'")
+		assert.True(t, engine.IsExperimental(), "GitHub Models engine should be experimental")
+	})
+
+	t.Run("capabilities", func(t *testing.T) {
+		assert.True(t, engine.SupportsToolsAllowlist(), "Should support tools allowlist")
+		assert.True(t, engine.SupportsMaxTurns(), "Should support max turns")
+		assert.False(t, engine.SupportsWebFetch(), "Should not support built-in web fetch")
+		assert.False(t, engine.SupportsWebSearch(), "Should not support built-in web search")
+		assert.False(t, engine.SupportsBashAllowlist(), "Should not support bash allowlist")
+	})
+
+	t.Run("registered", func(t *testing.T) {
+		registered, err := NewEngineRegistry().GetEngine("github-models")
+		require.NoError(t, err, "github-models should be registered")
+		assert.Equal(t, "github-models", registered.GetID())
+	})
+
+	t.Run("no API key secret", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name:        "test",
+			ParsedTools: &ToolsConfig{},
+			Tools:       map[string]any{},
+		}
+		assert.Empty(t, engine.GetRequiredSecretNames(workflowData), "Should not require any secret without MCP servers")
+		assert.Empty(t, engine.GetSecretValidationStep(workflowData), "Should not validate secrets")
+	})
+
+	t.Run("required secrets with MCP servers", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name: "test",
+			ParsedTools: &ToolsConfig{
+				GitHub: &GitHubToolConfig{},
+			},
+			Tools: map[string]any{
+				"github": map[string]any{},
+			},
+		}
+		secrets := engine.GetRequiredSecretNames(workflowData)
+		assert.Contains(t, secrets, "MCP_GATEWAY_API_KEY", "Should require MCP_GATEWAY_API_KEY when MCP servers present")
+		assert.Contains(t, secrets, "GITHUB_MCP_SERVER_TOKEN", "Should require GITHUB_MCP_SERVER_TOKEN for GitHub tool")
+	})
+}
+
+func TestGitHubModelsEngineInstallation(t *testing.T) {
+	engine := NewGitHubModelsEngine()
+
+	t.Run("installs Node.js only", func(t *testing.T) {
+		steps := engine.GetInstallationSteps(&WorkflowData{Name: "test-workflow"})
+		require.Len(t, steps, 1, "Should only set up Node.js")
+		assert.Contains(t, strings.Join(steps[0], "\n"), "Setup Node.js", "Should set up Node.js for the agent script")
+	})
+
+	t.Run("with firewall", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name: "test-workflow",
+			NetworkPermissions: &NetworkPermissions{
+				Firewall: &FirewallConfig{Enabled: true},
+			},
+		}
+		steps := engine.GetInstallationSteps(workflowData)
+		require.Len(t, steps, 2, "Should set up Node.js and install AWF")
+		assert.Contains(t, strings.Join(steps[1], "\n"), "awf", "Should install AWF")
+	})
+
+	t.Run("with custom command", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name:         "test-workflow",
+			EngineConfig: &EngineConfig{Command: "/custom/agent"},
+		}
+		assert.Empty(t, engine.GetInstallationSteps(workflowData), "Should skip installation with custom command")
+	})
+}
+
+func TestGitHubModelsEngineExecution(t *testing.T) {
+	engine := NewGitHubModelsEngine()
+
+	t.Run("basic execution", func(t *testing.T) {
+		steps := engine.GetExecutionSteps(&WorkflowData{Name: "test-workflow"}, "/tmp/test.log")
+		require.Len(t, steps, 1, "Should generate one execution step")
+
+		stepContent := strings.Join(steps[0], "\n")
+		assert.Contains(t, stepContent, "name: Execute GitHub Models agent", "Should have correct step name")
+		assert.Contains(t, stepContent, "id: agentic_execution", "Should have agentic_execution ID")
+		assert.Contains(t, stepContent, "node /opt/gh-aw/actions/github_models_agent.cjs", "Should run the agent script")
+		assert.Contains(t, stepContent, "/tmp/test.log", "Should include log file")
+		assert.Contains(t, stepContent, "GH_AW_GITHUB_MODELS_TOKEN: ${{ github.token }}", "Should authenticate with the workflow token")
+		assert.NotContains(t, stepContent, "secrets.", "Should not reference any secret")
+		assert.NotContains(t, stepContent, "GH_AW_GITHUB_MODELS_MODEL", "Should let the agent script pick the default model")
+	})
+
+	t.Run("with model and max-turns", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name: "test-workflow",
+			EngineConfig: &EngineConfig{
+				Model:    "openai/gpt-4.1-mini",
+				MaxTurns: "12",
+			},
+		}
+		stepContent := strings.Join(engine.GetExecutionSteps(workflowData, "/tmp/test.log")[0], "\n")
+		assert.Contains(t, stepContent, "GH_AW_GITHUB_MODELS_MODEL: openai/gpt-4.1-mini", "Should set the model env var")
+		assert.Contains(t, stepContent, "GH_AW_MAX_TURNS: 12", "Should pass max-turns to the agent script")
+	})
+
+	t.Run("with MCP servers", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name: "test-workflow",
+			ParsedTools: &ToolsConfig{
+				GitHub: &GitHubToolConfig{},
+			},
+			Tools: map[string]any{
+				"github": map[string]any{},
+			},
+		}
+		stepContent := strings.Join(engine.GetExecutionSteps(workflowData, "/tmp/test.log")[0], "\n")
+		assert.Contains(t, stepContent, "GH_AW_MCP_CONFIG: /tmp/gh-aw/mcp-config/github-models-mcp.json", "Should point the agent at the converted MCP config")
+	})
+
+	t.Run("firewall enabled", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name: "test-workflow",
+			NetworkPermissions: &NetworkPermissions{
+				Allowed:  []string{"defaults"},
+				Firewall: &FirewallConfig{Enabled: true},
+			},
+		}
+		stepContent := strings.Join(engine.GetExecutionSteps(workflowData, "/tmp/test.log")[0], "\n")
+		assert.Contains(t, stepContent, "awf", "Should use AWF when firewall is enabled")
+		assert.Contains(t, stepContent, "models.github.ai", "Should allow the GitHub Models endpoint")
+	})
+}
+
+func TestGitHubModelsEngineParseLogMetrics(t *testing.T) {
+	engine := NewGitHubModelsEngine()
+
+	logContent := strings.Join([]string{
+		`{"type":"init","model":"openai/gpt-4.1","tools":["github__issue_read","safeoutputs__add_comment"]}`,
+		`Some stderr output`,
+		`{"type":"tool_use","tool_name":"github__issue_read","tool_id":"call_1","parameters":{}}`,
+		`{"type":"tool_result","tool_id":"call_1","status":"success","output":"{}"}`,
+		`{"type":"tool_use","tool_name":"github__issue_read","tool_id":"call_2","parameters":{}}`,
+		`{"type":"tool_use","tool_name":"safeoutputs__add_comment","tool_id":"call_3","parameters":{}}`,
+		`{"type":"message","role":"assistant","content":"Done"}`,
+		`{"type":"result","status":"success","stats":{"turns":3,"input_tokens":1200,"output_tokens":300,"tool_calls":3}}`,
+	}, "\n")
+
+	metrics := engine.ParseLogMetrics(logContent, false)
+	assert.Equal(t, 3, metrics.Turns, "Should read turns from the result event")
+	assert.Equal(t, 1500, metrics.TokenUsage, "Should sum input and output tokens")
+	require.Len(t, metrics.ToolCalls, 2, "Should count calls per tool")
+	assert.Equal(t, ToolCallInfo{Name: "github::issue_read", CallCount: 2}, metrics.ToolCalls[0])
+	assert.Equal(t, ToolCallInfo{Name: "safeoutputs::add_comment", CallCount: 1}, metrics.ToolCalls[1])
+	assert.Equal(t, [][]string{{"github::issue_read", "github::issue_read", "safeoutputs::add_comment"}}, metrics.ToolSequences)
+}
+
+func TestGitHubModelsEngineModelsPermission(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions string
+	}{
+		{name: "explicit permissions", permissions: "permissions:\n  contents: read\n  issues: read"},
+		{name: "no permissions", permissions: ""},
+		{name: "empty permissions", permissions: "permissions: {}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "github-models-permissions")
+			content := "---\non: workflow_dispatch\n" + tt.permissions + "\nengine: github-models\n---\n\n# Test\n\nSummarize the repository.\n"
+			workflowPath := filepath.Join(tmpDir, "test.md")
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644))
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(workflowPath), "Workflow should compile")
+
+			lockContent, err := os.ReadFile(filepath.Join(tmpDir, "test.lock.yml"))
+			require.NoError(t, err)
+			agentJob := string(lockContent)[strings.Index(string(lockContent), "\n  agent:\n"):]
+			assert.Contains(t, agentJob, "      models: read", "Agent job should get models: read")
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package workflow
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var githubModelsLogsLog = logger.New("workflow:github_models_logs")
+
+// githubModelsEvent represents one JSONL event written by github_models_agent.cjs
+type githubModelsEvent struct {
+	Type     string `json:"type"`
+	ToolName string `json:"tool_name"`
+	Stats    struct {
+		Turns        int `json:"turns"`
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"stats"`
+}
+
+// ParseLogMetrics parses the GitHub Models agent log and extracts metrics.
+// The agent writes one JSON event per line; tool_use events are counted per tool and the
+// final result event carries the turn count and token usage.
+func (e *GitHubModelsEngine) ParseLogMetrics(logContent string, verbose bool) LogMetrics {
+	githubModelsLogsLog.Printf("Parsing GitHub Models log metrics: log_size=%d bytes, verbose=%v", len(logContent), verbose)
+
+	metrics := LogMetrics{
+		ToolCalls: []ToolCallInfo{},
+	}
+
+	toolCallCounts := make(map[string]int)
+	var toolOrder []string
+	var sequence []string
+	for line := range strings.SplitSeq(logContent, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		var event githubModelsEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			continue
+		}
+
+		switch event.Type {
+		case "tool_use":
+			// Function names are server__tool; report them as server::tool like other engines
+			name := strings.Replace(event.ToolName, "__", "::", 1)
+			if _, seen := toolCallCounts[name]; !seen {
+				toolOrder = append(toolOrder, name)
+			}
+			toolCallCounts[name]++
+			sequence = append(sequence, name)
+		case "result":
+			metrics.Turns = event.Stats.Turns
+			metrics.TokenUsage = event.Stats.InputTokens + event.Stats.OutputTokens
+		}
+	}
+
+	for _, name := range toolOrder {
+		metrics.ToolCalls = append(metrics.ToolCalls, ToolCallInfo{
+			Name:      name,
+			CallCount: toolCallCounts[name],
+		})
+	}
+	if len(sequence) > 0 {
+		metrics.ToolSequences = [][]string{sequence}
+	}
+
+	githubModelsLogsLog.Printf("Parsed metrics: turns=%d, token_usage=%d, tool_calls=%d",
+		metrics.Turns, metrics.TokenUsage, len(metrics.ToolCalls))
+
+	return metrics
+}
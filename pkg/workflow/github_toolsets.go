@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
@@ -60,7 +62,7 @@ func ParseGitHubToolsets(toolsetsStr string) []string {
 		case "all":
 			// Add all toolsets from the toolset permissions map
 			toolsetsLog.Printf("Expanding 'all' to %d toolsets from permissions map", len(toolsetPermissionsMap))
-			for t := range toolsetPermissionsMap {
+			for _, t := range slices.Sorted(maps.Keys(toolsetPermissionsMap)) {
 				if !seenToolsets[t] {
 					expanded = append(expanded, t)
 					seenToolsets[t] = true
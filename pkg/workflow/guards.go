@@ -0,0 +1,170 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var guardsLog = logger.New("workflow:guards")
+
+// GuardsConfig represents the spam and abuse guards for issue, pull request, discussion and
+// comment triggers (guards:). The checks run in the pre-activation job, so a rejected event
+// never starts the agent.
+//
+// Example:
+//
+//	guards:
+//	  min-account-age: 30
+//	  associations: [owner, member, collaborator]
+//	  blocked-users: [spam-account]
+//	  max-runs-per-hour: 3
+type GuardsConfig struct {
+	MinAccountAge  int      `json:"min-account-age,omitempty"`   // Minimum age of the actor's GitHub account in days
+	Associations   []string `json:"associations,omitempty"`      // Author associations allowed to trigger the workflow
+	BlockedUsers   []string `json:"blocked-users,omitempty"`     // Users that may never trigger the workflow
+	MaxRunsPerHour int      `json:"max-runs-per-hour,omitempty"` // Maximum runs per user per hour (applied as rate-limit)
+}
+
+// guardedEvents lists the triggers the guards apply to; other events are not checked
+var guardedEvents = []string{
+	"discussion",
+	"discussion_comment",
+	"issue_comment",
+	"issues",
+	"pull_request",
+	"pull_request_review",
+	"pull_request_review_comment",
+	"pull_request_target",
+}
+
+// guardAssociations lists the accepted values of guards.associations (GitHub author
+// associations in lower case with dashes, e.g. FIRST_TIME_CONTRIBUTOR is first-time-contributor)
+var guardAssociations = []string{
+	"collaborator",
+	"contributor",
+	"first-time-contributor",
+	"first-timer",
+	"member",
+	"none",
+	"owner",
+}
+
+// maxGuardRunsPerHour matches the maximum of rate-limit.max
+const maxGuardRunsPerHour = 10
+
+// extractGuardsConfig extracts and validates the guards configuration from frontmatter
+func (c *Compiler) extractGuardsConfig(frontmatter map[string]any) (*GuardsConfig, error) {
+	guardsValue, exists := frontmatter["guards"]
+	if !exists || guardsValue == nil {
+		return nil, nil
+	}
+
+	example := "Example:\nguards:\n  min-account-age: 30\n  associations: [owner, member, collaborator]\n  blocked-users: [spam-account]\n  max-runs-per-hour: 3"
+	guardsMap, ok := guardsValue.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("guards must be an object, got %T. %s", guardsValue, example)
+	}
+
+	config := &GuardsConfig{}
+	if value, exists := guardsMap["min-account-age"]; exists {
+		days, ok := parseIntValue(value)
+		if !ok || days <= 0 {
+			return nil, fmt.Errorf("guards.min-account-age must be a positive number of days, got %v. %s", value, example)
+		}
+		config.MinAccountAge = days
+	}
+	if value, exists := guardsMap["associations"]; exists {
+		config.Associations = extractStringSliceField(value, "guards.associations")
+		for _, association := range config.Associations {
+			if !slices.Contains(guardAssociations, association) {
+				return nil, fmt.Errorf("guards.associations: unknown association '%s'. Valid associations: %s", association, strings.Join(guardAssociations, ", "))
+			}
+		}
+		sort.Strings(config.Associations)
+	}
+	if value, exists := guardsMap["blocked-users"]; exists {
+		config.BlockedUsers = extractStringSliceField(value, "guards.blocked-users")
+		sort.Strings(config.BlockedUsers)
+	}
+	if value, exists := guardsMap["max-runs-per-hour"]; exists {
+		runs, ok := parseIntValue(value)
+		if !ok || runs <= 0 || runs > maxGuardRunsPerHour {
+			return nil, fmt.Errorf("guards.max-runs-per-hour must be an integer between 1 and %d, got %v. %s", maxGuardRunsPerHour, value, example)
+		}
+		config.MaxRunsPerHour = runs
+	}
+
+	if config.MinAccountAge == 0 && len(config.Associations) == 0 && len(config.BlockedUsers) == 0 && config.MaxRunsPerHour == 0 {
+		return nil, nil
+	}
+
+	guardsLog.Printf("Extracted guards: min_account_age=%d, associations=%v, blocked_users=%d, max_runs_per_hour=%d",
+		config.MinAccountAge, config.Associations, len(config.BlockedUsers), config.MaxRunsPerHour)
+	return config, nil
+}
+
+// hasGuardChecks reports whether the guards need the check step (max-runs-per-hour is
+// enforced by the rate limit check instead)
+func (g *GuardsConfig) hasGuardChecks() bool {
+	return g != nil && (g.MinAccountAge > 0 || len(g.Associations) > 0 || len(g.BlockedUsers) > 0)
+}
+
+// applyGuardsRateLimit turns guards.max-runs-per-hour into a rate limit on the guarded
+// events the workflow is triggered by
+func (c *Compiler) applyGuardsRateLimit(data *WorkflowData, frontmatter map[string]any) error {
+	if data.Guards == nil || data.Guards.MaxRunsPerHour == 0 {
+		return nil
+	}
+	if data.RateLimit != nil {
+		return errors.New("guards.max-runs-per-hour cannot be combined with rate-limit; set rate-limit.max and rate-limit.window instead")
+	}
+
+	var events []string
+	for _, event := range c.inferEventsFromTriggers(frontmatter) {
+		if slices.Contains(guardedEvents, event) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		// Command triggers expand to comment events after this point
+		events = slices.Clone(guardedEvents)
+		events = slices.DeleteFunc(events, func(event string) bool { return event == "pull_request_target" })
+	}
+
+	data.RateLimit = &RateLimitConfig{
+		Max:          data.Guards.MaxRunsPerHour,
+		Window:       constants.DefaultRateLimitWindow,
+		Events:       events,
+		IgnoredRoles: []string{"admin", "maintain", "write"},
+	}
+	guardsLog.Printf("Applied guards.max-runs-per-hour as rate limit: max=%d, events=%v", data.Guards.MaxRunsPerHour, events)
+	return nil
+}
+
+// generateGuardsCheck generates the pre-activation step that enforces the guards
+func (c *Compiler) generateGuardsCheck(data *WorkflowData, steps []string) []string {
+	steps = append(steps, "      - name: Check spam and abuse guards\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckGuardsStepID))
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/github-script")))
+	steps = append(steps, "        env:\n")
+	if data.Guards.MinAccountAge > 0 {
+		steps = append(steps, fmt.Sprintf("          GH_AW_GUARD_MIN_ACCOUNT_AGE: \"%d\"\n", data.Guards.MinAccountAge))
+	}
+	if len(data.Guards.Associations) > 0 {
+		steps = append(steps, fmt.Sprintf("          GH_AW_GUARD_ASSOCIATIONS: %q\n", strings.Join(data.Guards.Associations, ",")))
+	}
+	if len(data.Guards.BlockedUsers) > 0 {
+		steps = append(steps, fmt.Sprintf("          GH_AW_GUARD_BLOCKED_USERS: %q\n", strings.Join(data.Guards.BlockedUsers, ",")))
+	}
+	steps = append(steps, "        with:\n")
+	steps = append(steps, "          github-token: ${{ secrets.GITHUB_TOKEN }}\n")
+	steps = append(steps, "          script: |\n")
+	steps = append(steps, generateGitHubScriptWithRequire("check_guards.cjs"))
+	return steps
+}
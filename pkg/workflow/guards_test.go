@@ -0,0 +1,111 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractGuardsConfig(t *testing.T) {
+	compiler := NewCompiler()
+
+	config, err := compiler.extractGuardsConfig(map[string]any{
+		"guards": map[string]any{
+			"min-account-age":   30,
+			"associations":      []any{"owner", "member", "collaborator"},
+			"blocked-users":     []any{"spam-b", "spam-a"},
+			"max-runs-per-hour": 3,
+		},
+	})
+	require.NoError(t, err, "valid guards should be accepted")
+	assert.Equal(t, &GuardsConfig{
+		MinAccountAge:  30,
+		Associations:   []string{"collaborator", "member", "owner"},
+		BlockedUsers:   []string{"spam-a", "spam-b"},
+		MaxRunsPerHour: 3,
+	}, config, "guards should be extracted with sorted lists")
+	assert.True(t, config.hasGuardChecks(), "guards should need the check step")
+
+	config, err = compiler.extractGuardsConfig(map[string]any{})
+	require.NoError(t, err, "missing guards should be accepted")
+	assert.Nil(t, config, "missing guards should return nil")
+	assert.False(t, config.hasGuardChecks(), "nil guards should not need the check step")
+
+	config, err = compiler.extractGuardsConfig(map[string]any{"guards": map[string]any{"max-runs-per-hour": 2}})
+	require.NoError(t, err, "max-runs-per-hour alone should be accepted")
+	assert.False(t, config.hasGuardChecks(), "max-runs-per-hour alone is enforced by the rate limit check")
+
+	for name, guards := range map[string]any{
+		"guards must be an object":                       "strict",
+		"min-account-age must be a positive number":      map[string]any{"min-account-age": 0},
+		"unknown association 'maintainer'":               map[string]any{"associations": []any{"maintainer"}},
+		"max-runs-per-hour must be an integer between 1": map[string]any{"max-runs-per-hour": 11},
+	} {
+		_, err := compiler.extractGuardsConfig(map[string]any{"guards": guards})
+		require.Error(t, err, "invalid guards should be rejected: %v", guards)
+		assert.Contains(t, err.Error(), name, "error should explain the problem")
+	}
+}
+
+func TestApplyGuardsRateLimit(t *testing.T) {
+	compiler := NewCompiler()
+	frontmatter := map[string]any{
+		"on": map[string]any{
+			"issue_comment":     map[string]any{"types": []any{"created"}},
+			"workflow_dispatch": nil,
+		},
+	}
+
+	data := &WorkflowData{Guards: &GuardsConfig{MaxRunsPerHour: 3}}
+	require.NoError(t, compiler.applyGuardsRateLimit(data, frontmatter), "rate limit should be applied")
+	require.NotNil(t, data.RateLimit, "rate limit should be set")
+	assert.Equal(t, 3, data.RateLimit.Max, "max should come from the guard")
+	assert.Equal(t, 60, data.RateLimit.Window, "window should be one hour")
+	assert.Equal(t, []string{"issue_comment"}, data.RateLimit.Events, "only guarded events should be limited")
+
+	data = &WorkflowData{Guards: &GuardsConfig{MaxRunsPerHour: 3}, RateLimit: &RateLimitConfig{Max: 5}}
+	err := compiler.applyGuardsRateLimit(data, frontmatter)
+	require.Error(t, err, "guards and rate-limit should not be combined")
+	assert.Contains(t, err.Error(), "cannot be combined with rate-limit", "error should explain the conflict")
+}
+
+func TestGuardsCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "guards-test")
+	workflowPath := filepath.Join(tmpDir, "reply.md")
+	content := `---
+on:
+  issue_comment:
+    types: [created]
+  roles: all
+guards:
+  min-account-age: 30
+  associations: [owner, member, collaborator]
+  blocked-users: [spam-account]
+engine: copilot
+permissions:
+  contents: read
+---
+
+Reply to the comment.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "id: check_guards", "pre-activation job should check the guards")
+	assert.Contains(t, lock, `GH_AW_GUARD_MIN_ACCOUNT_AGE: "30"`, "minimum account age should be passed")
+	assert.Contains(t, lock, `GH_AW_GUARD_ASSOCIATIONS: "collaborator,member,owner"`, "associations should be passed")
+	assert.Contains(t, lock, `GH_AW_GUARD_BLOCKED_USERS: "spam-account"`, "blocked users should be passed")
+	assert.Contains(t, lock, "activated: ${{ steps.check_guards.outputs.guards_ok == 'true' }}", "activation should depend on the guards")
+	assert.Contains(t, lock, "require('/opt/gh-aw/actions/check_guards.cjs')", "guards script should be loaded")
+}
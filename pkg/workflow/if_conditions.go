@@ -0,0 +1,121 @@
+package workflow
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var ifConditionsLog = logger.New("workflow:if_conditions")
+
+// validateIfConditions validates the syntax of the workflow-level if: condition and of every
+// per-safe-output if: condition, so that mistakes surface at compile time instead of as a
+// workflow that never runs
+func validateIfConditions(data *WorkflowData) error {
+	if data.If != "" {
+		if err := validateIfConditionSyntax("if", data.If); err != nil {
+			return err
+		}
+	}
+
+	baseConfigs := safeOutputBaseConfigs(data.SafeOutputs)
+	toolNames := make([]string, 0, len(baseConfigs))
+	for toolName := range baseConfigs {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+	for _, toolName := range toolNames {
+		base := baseConfigs[toolName]
+		if err := validateSafeOutputFields(toolName, base.Fields); err != nil {
+			return err
+		}
+		if base.If == "" {
+			continue
+		}
+		field := fmt.Sprintf("safe-outputs.%s.if", strings.ReplaceAll(toolName, "_", "-"))
+		if err := validateIfConditionSyntax(field, base.If); err != nil {
+			return err
+		}
+		if isItemCondition(base.If) {
+			if err := validateItemCondition(toolName, base.If, base.Fields); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateIfConditionSyntax checks that condition is a single well-formed GitHub Actions
+// expression, with or without the ${{ }} wrapper
+func validateIfConditionSyntax(field, condition string) error {
+	expr := stripExpressionWrapper(condition)
+	ifConditionsLog.Printf("Validating %s: %s", field, expr)
+
+	if strings.Contains(expr, "${{") || strings.Contains(expr, "}}") {
+		return NewValidationError(
+			field,
+			condition,
+			"the condition must be a single expression without nested '${{ }}'",
+			"Write the condition as one expression, for example: github.event.issue.user.type != 'Bot'",
+		)
+	}
+
+	if _, err := ParseExpression(expr); err != nil {
+		return NewValidationError(
+			field,
+			condition,
+			"invalid expression syntax: "+err.Error(),
+			"Check that parentheses and quotes are balanced and that every '&&' and '||' has an operand on both sides.",
+		)
+	}
+	return nil
+}
+
+// safeOutputConditions returns the GitHub Actions if: condition of every enabled safe output that
+// has one, without the ${{ }} wrapper, keyed by safe output tool name (e.g. "create_issue").
+// Post-run conditions on item fields are returned by safeOutputItemConditions instead.
+func safeOutputConditions(safeOutputs *SafeOutputsConfig) map[string]string {
+	conditions := make(map[string]string)
+	for toolName, base := range safeOutputBaseConfigs(safeOutputs) {
+		if base.If != "" && !isItemCondition(base.If) {
+			conditions[toolName] = stripExpressionWrapper(base.If)
+		}
+	}
+	return conditions
+}
+
+// safeOutputBaseConfigs returns the common configuration of every enabled safe output, keyed by
+// safe output tool name
+func safeOutputBaseConfigs(safeOutputs *SafeOutputsConfig) map[string]*BaseSafeOutputConfig {
+	configs := make(map[string]*BaseSafeOutputConfig)
+	if safeOutputs == nil {
+		return configs
+	}
+
+	val := reflect.ValueOf(safeOutputs).Elem()
+	for fieldName, toolName := range safeOutputFieldMapping {
+		field := val.FieldByName(fieldName)
+		if !field.IsValid() || field.IsNil() {
+			continue
+		}
+		baseField := field.Elem().FieldByName("BaseSafeOutputConfig")
+		if !baseField.IsValid() || !baseField.CanAddr() {
+			continue
+		}
+		if base, ok := baseField.Addr().Interface().(*BaseSafeOutputConfig); ok {
+			configs[toolName] = base
+		}
+	}
+	return configs
+}
+
+// withSafeOutputCondition combines a step condition with a safe output's if: condition
+func withSafeOutputCondition(condition ConditionNode, ifCondition string) ConditionNode {
+	if ifCondition == "" {
+		return condition
+	}
+	return BuildAnd(condition, &ExpressionNode{Expression: stripExpressionWrapper(ifCondition)})
+}
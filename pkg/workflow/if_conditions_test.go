@@ -0,0 +1,83 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIfConditionSyntax(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		errorMsg  string
+	}{
+		{name: "comparison", condition: "github.event.issue.user.type != 'Bot'"},
+		{name: "wrapped expression", condition: "${{ github.event_name == 'issues' && github.actor != 'dependabot[bot]' }}"},
+		{name: "function call", condition: "contains(github.event.issue.labels.*.name, 'triage') || !github.event.issue.draft"},
+		{name: "missing operand", condition: "github.actor != 'bot' &&", errorMsg: "invalid expression syntax"},
+		{name: "unbalanced parentheses", condition: "(github.actor != 'bot'", errorMsg: "invalid expression syntax"},
+		{name: "nested expression", condition: "${{ github.actor }} == 'bot'", errorMsg: "nested"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateIfConditionSyntax("safe-outputs.create-issue.if", tt.condition)
+			if tt.errorMsg == "" {
+				assert.NoError(t, err, "condition should be valid")
+				return
+			}
+			require.Error(t, err, "condition should be rejected")
+			assert.Contains(t, err.Error(), tt.errorMsg, "error should explain the problem")
+			assert.Contains(t, err.Error(), "safe-outputs.create-issue.if", "error should name the field")
+		})
+	}
+}
+
+func TestIfConditionsCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "if-conditions-test")
+	workflowPath := filepath.Join(tmpDir, "triage.md")
+
+	compile := func(t *testing.T, ifCondition string) (string, error) {
+		t.Helper()
+		content := `---
+on:
+  issues:
+    types: [opened]
+if: github.event.issue.user.type != 'Bot'
+permissions:
+  contents: read
+safe-outputs:
+  create-issue:
+    if: ` + ifCondition + `
+  add-comment:
+---
+
+Triage the issue.
+`
+		require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+		if err := NewCompiler().CompileWorkflow(workflowPath); err != nil {
+			return "", err
+		}
+		lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+		require.NoError(t, err, "should read lock file")
+		return string(lockContent), nil
+	}
+
+	lock, err := compile(t, `"${{ github.event.issue.author_association == 'MEMBER' }}"`)
+	require.NoError(t, err, "workflow should compile")
+	assert.Contains(t, lock, "github.event.issue.user.type != 'Bot'", "workflow condition should gate the activation job")
+	assert.Contains(t, lock, `\"create_issue\":{\"if\":\"${{ github.event.issue.author_association == 'MEMBER' }}\"`, "create-issue condition should be rendered into the handler config")
+	assert.NotContains(t, lock, `\"add_comment\":{\"if\"`, "add-comment should have no condition")
+
+	_, err = compile(t, `"github.actor != 'bot' &&"`)
+	require.Error(t, err, "invalid condition should fail compilation")
+	assert.Contains(t, err.Error(), "safe-outputs.create-issue.if", "error should point at the safe output condition")
+}
@@ -8,9 +8,10 @@
 //
 // # Checkout Credential Validation
 //
-// When actions/checkout is used without 'persist-credentials: false', the GitHub
-// token is stored in .git/config and accessible to the agent, which is a security
-// concern. This file detects this pattern and:
+// Checkout steps that do not set persist-credentials are hardened automatically with
+// 'persist-credentials: false' (see checkout_hardening.go). When a checkout step sets
+// persist-credentials to anything else, the GitHub token is stored in .git/config and
+// accessible to the agent, which is a security concern. This file detects this pattern and:
 //   - In strict mode: raises a compilation error
 //   - In non-strict mode: emits a warning
 //
@@ -45,8 +46,9 @@ func (c *Compiler) validateImportedStepsNoAgenticSecrets(engineConfig *EngineCon
 }
 
 // validateCheckoutPersistCredentials checks that actions/checkout steps in the agent job
-// include 'persist-credentials: false'. Without this setting, the git token is stored in
-// .git/config and accessible to the agent.
+// do not opt out of 'persist-credentials: false'. Steps without the setting are hardened
+// automatically; an explicit value other than false stores the git token in .git/config
+// where it is accessible to the agent.
 //
 // This validates steps from:
 //   - The main frontmatter 'steps' section (agent job steps)
@@ -64,7 +66,7 @@ func (c *Compiler) validateCheckoutPersistCredentials(frontmatter map[string]any
 		if steps, ok := stepsValue.([]any); ok {
 			for _, step := range steps {
 				if stepMap, ok := step.(map[string]any); ok {
-					if checkoutMissingPersistCredentialsFalse(stepMap) {
+					if checkoutPersistsCredentials(stepMap) {
 						offendingStepNames = append(offendingStepNames, stepDisplayName(stepMap))
 					}
 				}
@@ -78,7 +80,7 @@ func (c *Compiler) validateCheckoutPersistCredentials(frontmatter map[string]any
 		if err := yaml.Unmarshal([]byte(mergedSteps), &importedSteps); err == nil {
 			for _, step := range importedSteps {
 				if stepMap, ok := step.(map[string]any); ok {
-					if checkoutMissingPersistCredentialsFalse(stepMap) {
+					if checkoutPersistsCredentials(stepMap) {
 						offendingStepNames = append(offendingStepNames, stepDisplayName(stepMap))
 					}
 				}
@@ -93,8 +95,8 @@ func (c *Compiler) validateCheckoutPersistCredentials(frontmatter map[string]any
 
 	msg := fmt.Sprintf(
 		"actions/checkout step(s) without 'persist-credentials: false' detected in the agent job: %s. "+
-			"Without this setting the git token is stored in .git/config and leaked to the agent. "+
-			"Add 'persist-credentials: false' to the 'with:' block of each checkout step. "+
+			"With persist-credentials enabled the git token is stored in .git/config and leaked to the agent. "+
+			"Set 'persist-credentials: false' in the 'with:' block of each checkout step, or remove the setting to have it added automatically. "+
 			"See: https://github.github.com/gh-aw/reference/steps/",
 		strings.Join(offendingStepNames, ", "),
 	)
@@ -111,9 +113,11 @@ func (c *Compiler) validateCheckoutPersistCredentials(frontmatter map[string]any
 	return nil
 }
 
-// checkoutMissingPersistCredentialsFalse returns true when the step uses actions/checkout
-// but does not have 'persist-credentials: false' in its 'with' block.
-func checkoutMissingPersistCredentialsFalse(step map[string]any) bool {
+// checkoutPersistsCredentials returns true when the step uses actions/checkout and sets
+// persist-credentials to a value other than false in its 'with' block. Checkouts that do
+// not set persist-credentials are hardened by HardenCheckoutStep (or hardenCheckoutStepValues
+// when the steps cannot be typed) on every step merge path and are not reported.
+func checkoutPersistsCredentials(step map[string]any) bool {
 	usesValue, exists := step["uses"]
 	if !exists {
 		return false
@@ -124,19 +128,16 @@ func checkoutMissingPersistCredentialsFalse(step map[string]any) bool {
 		return false
 	}
 
-	// Strip action pin comments (e.g., "actions/checkout@abc123 # v4") before matching
-	usesStr = strings.TrimSpace(strings.SplitN(usesStr, " #", 2)[0])
-
-	// Match "actions/checkout" or "actions/checkout@<ref>"
-	if usesStr != "actions/checkout" && !strings.HasPrefix(usesStr, "actions/checkout@") {
+	// Match "actions/checkout" or "actions/checkout@<ref>", ignoring action pin comments
+	if !isCheckoutAction(usesStr) {
 		return false
 	}
 
-	// Check whether persist-credentials is explicitly set to false
+	// Check whether persist-credentials is explicitly set to something other than false
 	withValue, hasWithBlock := step["with"]
 	if !hasWithBlock {
-		// No with block: persist-credentials defaults to true → insecure
-		return true
+		// No with block: hardened with persist-credentials: false at compile time
+		return false
 	}
 
 	withMap, ok := withValue.(map[string]any)
@@ -147,8 +148,8 @@ func checkoutMissingPersistCredentialsFalse(step map[string]any) bool {
 
 	persistCredentials, hasPersistCredentials := withMap["persist-credentials"]
 	if !hasPersistCredentials {
-		// Not set: defaults to true → insecure
-		return true
+		// Not set: hardened with persist-credentials: false at compile time
+		return false
 	}
 
 	// Bool false is the safe value
@@ -10,12 +10,12 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// TestCheckoutMissingPersistCredentialsFalse tests the checkoutMissingPersistCredentialsFalse helper
-func TestCheckoutMissingPersistCredentialsFalse(t *testing.T) {
+// TestCheckoutPersistsCredentials tests the checkoutPersistsCredentials helper
+func TestCheckoutPersistsCredentials(t *testing.T) {
 	tests := []struct {
 		name     string
 		step     map[string]any
-		expected bool // true = insecure (missing persist-credentials: false)
+		expected bool // true = insecure (persist-credentials set to a value other than false)
 	}{
 		{
 			name: "non-checkout step is safe",
@@ -26,11 +26,11 @@ func TestCheckoutMissingPersistCredentialsFalse(t *testing.T) {
 			expected: false,
 		},
 		{
-			name: "checkout without with block is insecure",
+			name: "checkout without with block is hardened automatically",
 			step: map[string]any{
 				"uses": "actions/checkout@v4",
 			},
-			expected: true,
+			expected: false,
 		},
 		{
 			name: "checkout with persist-credentials false is safe",
@@ -73,21 +73,21 @@ func TestCheckoutMissingPersistCredentialsFalse(t *testing.T) {
 			expected: true,
 		},
 		{
-			name: "checkout without persist-credentials key in with block is insecure",
+			name: "checkout without persist-credentials key in with block is hardened automatically",
 			step: map[string]any{
 				"uses": "actions/checkout@v4",
 				"with": map[string]any{
 					"fetch-depth": 1,
 				},
 			},
-			expected: true,
+			expected: false,
 		},
 		{
-			name: "checkout without version tag is insecure",
+			name: "checkout without version tag with persist-credentials true is insecure",
 			step: map[string]any{
 				"uses": "actions/checkout",
 				"with": map[string]any{
-					"fetch-depth": 0,
+					"persist-credentials": true,
 				},
 			},
 			expected: true,
@@ -121,9 +121,12 @@ func TestCheckoutMissingPersistCredentialsFalse(t *testing.T) {
 			expected: false,
 		},
 		{
-			name: "checkout with SHA pin without persist-credentials is insecure",
+			name: "checkout with SHA pin and persist-credentials true is insecure",
 			step: map[string]any{
 				"uses": "actions/checkout@11bd71901bbe5b1630ceea73d27597364c9af683",
+				"with": map[string]any{
+					"persist-credentials": true,
+				},
 			},
 			expected: true,
 		},
@@ -138,8 +141,8 @@ func TestCheckoutMissingPersistCredentialsFalse(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := checkoutMissingPersistCredentialsFalse(tt.step)
-			assert.Equal(t, tt.expected, result, "checkoutMissingPersistCredentialsFalse returned unexpected result")
+			result := checkoutPersistsCredentials(tt.step)
+			assert.Equal(t, tt.expected, result, "checkoutPersistsCredentials returned unexpected result")
 		})
 	}
 }
@@ -188,12 +191,15 @@ func TestValidateCheckoutPersistCredentials_FrontmatterSteps(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name: "checkout without persist-credentials false in strict mode - error",
+			name: "checkout with persist-credentials true in strict mode - error",
 			frontmatter: map[string]any{
 				"steps": []any{
 					map[string]any{
 						"name": "Checkout",
 						"uses": "actions/checkout@v4",
+						"with": map[string]any{
+							"persist-credentials": true,
+						},
 					},
 				},
 			},
@@ -202,18 +208,34 @@ func TestValidateCheckoutPersistCredentials_FrontmatterSteps(t *testing.T) {
 			errorMsg:    "strict mode: actions/checkout step(s) without 'persist-credentials: false'",
 		},
 		{
-			name: "checkout without persist-credentials false in non-strict mode - warning only",
+			name: "checkout with persist-credentials true in non-strict mode - warning only",
 			frontmatter: map[string]any{
 				"steps": []any{
 					map[string]any{
 						"name": "Checkout",
 						"uses": "actions/checkout@v4",
+						"with": map[string]any{
+							"persist-credentials": true,
+						},
 					},
 				},
 			},
 			strictMode:  false,
 			expectError: false, // warning only in non-strict mode
 		},
+		{
+			name: "checkout without persist-credentials is hardened - no error",
+			frontmatter: map[string]any{
+				"steps": []any{
+					map[string]any{
+						"name": "Checkout",
+						"uses": "actions/checkout@v4",
+					},
+				},
+			},
+			strictMode:  true,
+			expectError: false,
+		},
 		{
 			name: "non-checkout step - no error",
 			frontmatter: map[string]any{
@@ -234,6 +256,9 @@ func TestValidateCheckoutPersistCredentials_FrontmatterSteps(t *testing.T) {
 					map[string]any{
 						"name": "My Checkout Step",
 						"uses": "actions/checkout@v4",
+						"with": map[string]any{
+							"persist-credentials": true,
+						},
 					},
 				},
 			},
@@ -286,18 +311,22 @@ func TestValidateCheckoutPersistCredentials_MergedSteps(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name: "imported checkout without persist-credentials in strict mode - error",
+			name: "imported checkout with persist-credentials true in strict mode - error",
 			mergedSteps: `- name: Imported Checkout
   uses: actions/checkout@v4
+  with:
+    persist-credentials: true
 `,
 			strictMode:  true,
 			expectError: true,
 			errorMsg:    "strict mode: actions/checkout step(s) without 'persist-credentials: false'",
 		},
 		{
-			name: "imported checkout without persist-credentials in non-strict mode - warning only",
+			name: "imported checkout with persist-credentials true in non-strict mode - warning only",
 			mergedSteps: `- name: Imported Checkout
   uses: actions/checkout@v4
+  with:
+    persist-credentials: true
 `,
 			strictMode:  false,
 			expectError: false,
@@ -316,6 +345,8 @@ func TestValidateCheckoutPersistCredentials_MergedSteps(t *testing.T) {
 			name: "error message includes imported step name",
 			mergedSteps: `- name: My Imported Checkout
   uses: actions/checkout@v4
+  with:
+    persist-credentials: true
 `,
 			strictMode:  true,
 			expectError: true,
@@ -350,6 +381,9 @@ func TestValidateCheckoutPersistCredentials_WarningEmitted(t *testing.T) {
 			map[string]any{
 				"name": "Checkout",
 				"uses": "actions/checkout@v4",
+				"with": map[string]any{
+					"persist-credentials": true,
+				},
 			},
 		},
 	}
@@ -382,6 +416,8 @@ func TestValidateCheckoutPersistCredentials_BothSourcesChecked(t *testing.T) {
 	// Imported steps have insecure checkout
 	mergedSteps := `- name: Insecure Imported Checkout
   uses: actions/checkout@v4
+  with:
+    persist-credentials: true
 `
 
 	err := compiler.validateCheckoutPersistCredentials(frontmatter, mergedSteps)
@@ -400,10 +436,16 @@ func TestValidateCheckoutPersistCredentials_MultipleOffenders(t *testing.T) {
 			map[string]any{
 				"name": "First Checkout",
 				"uses": "actions/checkout@v4",
+				"with": map[string]any{
+					"persist-credentials": true,
+				},
 			},
 			map[string]any{
 				"name": "Second Checkout",
 				"uses": "actions/checkout@v4",
+				"with": map[string]any{
+					"persist-credentials": true,
+				},
 			},
 		},
 	}
@@ -451,14 +493,17 @@ func TestStepDisplayName(t *testing.T) {
 	}
 }
 
-// TestCheckoutMissingPersistCredentialsFalse_ActionPin tests that action pin comments are handled
-func TestCheckoutMissingPersistCredentialsFalse_ActionPin(t *testing.T) {
+// TestCheckoutPersistsCredentials_ActionPin tests that action pin comments are handled
+func TestCheckoutPersistsCredentials_ActionPin(t *testing.T) {
 	// Action pin with SHA and comment should still be recognized as checkout
 	step := map[string]any{
 		"uses": "actions/checkout@11bd71901bbe5b1630ceea73d27597364c9af683 # v4.2.2",
+		"with": map[string]any{
+			"persist-credentials": true,
+		},
 	}
-	result := checkoutMissingPersistCredentialsFalse(step)
-	assert.True(t, result, "Checkout with action pin comment but no persist-credentials should be flagged")
+	result := checkoutPersistsCredentials(step)
+	assert.True(t, result, "Checkout with action pin comment and persist-credentials: true should be flagged")
 
 	// Action pin with SHA, comment, and persist-credentials: false should be safe
 	stepSafe := map[string]any{
@@ -467,7 +512,7 @@ func TestCheckoutMissingPersistCredentialsFalse_ActionPin(t *testing.T) {
 			"persist-credentials": false,
 		},
 	}
-	resultSafe := checkoutMissingPersistCredentialsFalse(stepSafe)
+	resultSafe := checkoutPersistsCredentials(stepSafe)
 	assert.False(t, resultSafe, "Checkout with action pin comment and persist-credentials: false should be safe")
 }
 
@@ -480,6 +525,9 @@ func TestValidateCheckoutPersistCredentials_GitLeakErrorMessage(t *testing.T) {
 		"steps": []any{
 			map[string]any{
 				"uses": "actions/checkout@v4",
+				"with": map[string]any{
+					"persist-credentials": true,
+				},
 			},
 		},
 	}
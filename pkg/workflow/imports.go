@@ -57,6 +57,96 @@ func (c *Compiler) MergeTools(topTools map[string]any, includedToolsJSON string)
 	return result, nil
 }
 
+// MergeEngines deep-merges engine configurations from the main workflow and its imports when
+// they all target the same engine ID. The main workflow takes precedence over imports, and earlier
+// imports take precedence over later ones. Nested objects (env, install, ...) are merged key by key;
+// scalars and arrays from the higher-precedence source replace lower-precedence values.
+//
+// Returns the merged engine configuration as JSON, or "" when there is nothing to merge (fewer than
+// two specifications) or when the specifications name different engines, which is left to
+// validateSingleEngineSpecification to report.
+func (c *Compiler) MergeEngines(mainEngine any, importedEnginesJSON []string) (string, error) {
+	var specs []map[string]any
+	if mainEngine != nil {
+		if spec := normalizeEngineSpecification(mainEngine); spec != nil {
+			specs = append(specs, spec)
+		}
+	}
+	for _, engineJSON := range importedEnginesJSON {
+		if engineJSON == "" {
+			continue
+		}
+		var engineData any
+		if err := json.Unmarshal([]byte(engineJSON), &engineData); err != nil {
+			return "", fmt.Errorf("failed to parse imported engine configuration: %w", err)
+		}
+		if spec := normalizeEngineSpecification(engineData); spec != nil {
+			specs = append(specs, spec)
+		}
+	}
+
+	if len(specs) < 2 {
+		return "", nil
+	}
+
+	// Only configurations of the same engine can be merged
+	var engineID string
+	for _, spec := range specs {
+		id, _ := spec["id"].(string)
+		if id == "" {
+			continue
+		}
+		if engineID != "" && id != engineID {
+			importsLog.Printf("Engine specifications target different engines (%s, %s), not merging", engineID, id)
+			return "", nil
+		}
+		engineID = id
+	}
+
+	// Apply from lowest to highest precedence: last import first, main workflow last
+	merged := make(map[string]any)
+	for i := len(specs) - 1; i >= 0; i-- {
+		deepMergeEngineConfig(merged, specs[i])
+	}
+
+	importsLog.Printf("Merged %d engine specifications for engine %s", len(specs), engineID)
+	mergedJSON, err := json.Marshal(merged)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal merged engine configuration: %w", err)
+	}
+	return string(mergedJSON), nil
+}
+
+// normalizeEngineSpecification converts the string form (engine: claude) into the object form
+func normalizeEngineSpecification(engine any) map[string]any {
+	switch v := engine.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return map[string]any{"id": v}
+	case map[string]any:
+		return v
+	}
+	return nil
+}
+
+// deepMergeEngineConfig merges src into dst, with src taking precedence
+func deepMergeEngineConfig(dst, src map[string]any) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]any)
+		dstMap, dstIsMap := dst[key].(map[string]any)
+		if srcIsMap && dstIsMap {
+			merged := make(map[string]any, len(dstMap))
+			maps.Copy(merged, dstMap)
+			deepMergeEngineConfig(merged, srcMap)
+			dst[key] = merged
+			continue
+		}
+		dst[key] = value
+	}
+}
+
 // MergeMCPServers merges mcp-servers from imports with top-level mcp-servers
 // Takes object maps and merges them directly
 func (c *Compiler) MergeMCPServers(topMCPServers map[string]any, importedMCPServersJSON string) (map[string]any, error) {
@@ -431,6 +521,8 @@ func hasSafeOutputType(config *SafeOutputsConfig, key string) bool {
 		return config.CreateDiscussions != nil
 	case "close-discussion":
 		return config.CloseDiscussions != nil
+	case "answer-discussion":
+		return config.AnswerDiscussion != nil
 	case "close-issue":
 		return config.CloseIssues != nil
 	case "close-pull-request":
@@ -455,6 +547,8 @@ func hasSafeOutputType(config *SafeOutputsConfig, key string) bool {
 		return config.RemoveLabels != nil
 	case "add-reviewer":
 		return config.AddReviewer != nil
+	case "request-review":
+		return config.RequestReview != nil
 	case "assign-milestone":
 		return config.AssignMilestone != nil
 	case "assign-to-agent":
@@ -483,6 +577,8 @@ func hasSafeOutputType(config *SafeOutputsConfig, key string) bool {
 		return config.AutofixCodeScanningAlert != nil
 	case "assign-to-user":
 		return config.AssignToUser != nil
+	case "assign":
+		return config.Assign != nil
 	case "unassign-from-user":
 		return config.UnassignFromUser != nil
 	case "create-project":
@@ -536,6 +632,9 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	if result.CloseDiscussions == nil && importedConfig.CloseDiscussions != nil {
 		result.CloseDiscussions = importedConfig.CloseDiscussions
 	}
+	if result.AnswerDiscussion == nil && importedConfig.AnswerDiscussion != nil {
+		result.AnswerDiscussion = importedConfig.AnswerDiscussion
+	}
 	if result.CloseIssues == nil && importedConfig.CloseIssues != nil {
 		result.CloseIssues = importedConfig.CloseIssues
 	}
@@ -578,6 +677,9 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	if result.AddReviewer == nil && importedConfig.AddReviewer != nil {
 		result.AddReviewer = importedConfig.AddReviewer
 	}
+	if result.RequestReview == nil && importedConfig.RequestReview != nil {
+		result.RequestReview = importedConfig.RequestReview
+	}
 	if result.AssignMilestone == nil && importedConfig.AssignMilestone != nil {
 		result.AssignMilestone = importedConfig.AssignMilestone
 	}
@@ -587,6 +689,9 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	if result.AssignToUser == nil && importedConfig.AssignToUser != nil {
 		result.AssignToUser = importedConfig.AssignToUser
 	}
+	if result.Assign == nil && importedConfig.Assign != nil {
+		result.Assign = importedConfig.Assign
+	}
 	if result.UpdateIssues == nil && importedConfig.UpdateIssues != nil {
 		result.UpdateIssues = importedConfig.UpdateIssues
 	}
@@ -665,6 +770,9 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	if result.RunsOn == "" && importedConfig.RunsOn != "" {
 		result.RunsOn = importedConfig.RunsOn
 	}
+	if result.Environment == "" && importedConfig.Environment != "" {
+		result.Environment = importedConfig.Environment
+	}
 
 	// Merge Messages configuration at field level (main workflow entries override imported entries)
 	if importedConfig.Messages != nil {
@@ -687,12 +795,18 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	if !result.GroupReports && importedConfig.GroupReports {
 		result.GroupReports = true
 	}
+	if !result.SummaryComment && importedConfig.SummaryComment {
+		result.SummaryComment = true
+	}
 	if result.MaxBotMentions == nil && importedConfig.MaxBotMentions != nil {
 		result.MaxBotMentions = importedConfig.MaxBotMentions
 	}
 	if result.Mentions == nil && importedConfig.Mentions != nil {
 		result.Mentions = importedConfig.Mentions
 	}
+	if result.Sanitize == nil && importedConfig.Sanitize != nil {
+		result.Sanitize = importedConfig.Sanitize
+	}
 
 	// Merge steps: concatenate imported steps after main workflow's steps
 	if len(importedConfig.Steps) > 0 {
@@ -0,0 +1,134 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeEngines(t *testing.T) {
+	tests := []struct {
+		name     string
+		main     any
+		imported []string
+		expected map[string]any
+	}{
+		{
+			name:     "single imported engine is not merged",
+			imported: []string{`{"id": "claude", "max-turns": 10}`},
+		},
+		{
+			name: "main engine without imports is not merged",
+			main: "claude",
+		},
+		{
+			name:     "main string engine inherits imported settings",
+			main:     "claude",
+			imported: []string{`{"id": "claude", "max-turns": 10}`},
+			expected: map[string]any{"id": "claude", "max-turns": float64(10)},
+		},
+		{
+			name: "main takes precedence and nested objects are deep-merged",
+			main: map[string]any{
+				"id":    "claude",
+				"model": "claude-opus-4",
+				"env":   map[string]any{"OVERRIDE": "main"},
+			},
+			imported: []string{`{"id": "claude", "model": "claude-sonnet-4", "env": {"OVERRIDE": "import", "SHARED": "yes"}}`},
+			expected: map[string]any{
+				"id":    "claude",
+				"model": "claude-opus-4",
+				"env":   map[string]any{"OVERRIDE": "main", "SHARED": "yes"},
+			},
+		},
+		{
+			name:     "earlier imports take precedence over later imports",
+			imported: []string{`{"id": "copilot", "model": "gpt-5"}`, `{"id": "copilot", "model": "gpt-4.1", "max-turns": 5}`},
+			expected: map[string]any{"id": "copilot", "model": "gpt-5", "max-turns": float64(5)},
+		},
+		{
+			name:     "different engines are left to conflict validation",
+			main:     "copilot",
+			imported: []string{`"claude"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			mergedJSON, err := compiler.MergeEngines(tt.main, tt.imported)
+			require.NoError(t, err, "MergeEngines should not error")
+
+			if tt.expected == nil {
+				assert.Empty(t, mergedJSON, "Nothing should be merged")
+				return
+			}
+
+			var merged map[string]any
+			require.NoError(t, json.Unmarshal([]byte(mergedJSON), &merged), "Merged engine should be valid JSON")
+			assert.Equal(t, tt.expected, merged, "Merged engine configuration should follow precedence rules")
+		})
+	}
+}
+
+func TestMergeEnginesInvalidJSON(t *testing.T) {
+	compiler := NewCompiler()
+	_, err := compiler.MergeEngines("claude", []string{`{invalid`})
+	require.Error(t, err, "Invalid imported engine JSON should fail")
+	assert.Contains(t, err.Error(), "failed to parse imported engine configuration", "Error should explain the failure")
+}
+
+func TestImportedEngineConfigMergedWithMainEngine(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-*")
+	workflowsDir := filepath.Join(tmpDir, constants.GetWorkflowDir())
+	sharedDir := filepath.Join(workflowsDir, "shared")
+	require.NoError(t, os.MkdirAll(sharedDir, 0755), "Failed to create shared directory")
+
+	sharedEngine := `---
+engine:
+  id: claude
+  max-turns: 20
+  env:
+    SHARED_VAR: shared
+    OVERRIDE_VAR: from-import
+---
+`
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "engine.md"), []byte(sharedEngine), 0644), "Failed to write shared engine")
+
+	mainContent := `---
+on: workflow_dispatch
+imports:
+  - shared/engine.md
+engine:
+  id: claude
+  model: claude-opus-4
+  env:
+    OVERRIDE_VAR: from-main
+---
+
+# Main Workflow
+`
+	mainFile := filepath.Join(workflowsDir, "main.md")
+	require.NoError(t, os.WriteFile(mainFile, []byte(mainContent), 0644), "Failed to write main workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(mainFile), "Same-engine configurations should merge instead of conflicting")
+
+	lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "main.lock.yml"))
+	require.NoError(t, err, "Lock file should be created")
+	lockStr := string(lockContent)
+
+	assert.Contains(t, lockStr, "--max-turns 20", "max-turns should be inherited from the import")
+	assert.Contains(t, lockStr, "ANTHROPIC_MODEL: claude-opus-4", "model should come from the main workflow")
+	assert.Contains(t, lockStr, "SHARED_VAR: shared", "env from the import should be merged")
+	assert.Contains(t, lockStr, "OVERRIDE_VAR: from-main", "main workflow env should take precedence")
+	assert.NotContains(t, lockStr, "from-import", "Overridden import values should not appear")
+}
@@ -22,7 +22,9 @@ type Job struct {
 	HasWorkflowRunSafetyChecks bool // If true, the job's if condition includes workflow_run safety checks
 	Permissions                string
 	TimeoutMinutes             int
+	TimeoutExpression          string            // Computed timeout-minutes (${{ }} expression), used instead of TimeoutMinutes
 	Concurrency                string            // Job-level concurrency configuration
+	Defaults                   string            // Job-level defaults configuration (e.g. default run shell)
 	Environment                string            // Job environment configuration
 	Strategy                   string            // Job strategy configuration (matrix strategy)
 	Container                  string            // Job container configuration
@@ -318,8 +320,15 @@ func (jm *JobManager) renderJob(job *Job) string {
 		fmt.Fprintf(&yaml, "    %s\n", job.Concurrency)
 	}
 
+	// Add defaults section
+	if job.Defaults != "" {
+		fmt.Fprintf(&yaml, "    %s\n", job.Defaults)
+	}
+
 	// Add timeout-minutes if specified
-	if job.TimeoutMinutes > 0 {
+	if job.TimeoutExpression != "" {
+		fmt.Fprintf(&yaml, "    timeout-minutes: %s\n", job.TimeoutExpression)
+	} else if job.TimeoutMinutes > 0 {
 		fmt.Fprintf(&yaml, "    timeout-minutes: %d\n", job.TimeoutMinutes)
 	}
 
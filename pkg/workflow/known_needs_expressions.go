@@ -19,7 +19,7 @@ var knownNeedsLog = logger.New("workflow:known_needs")
 // IMPORTANT: The prompt is generated in the ACTIVATION job, so it can only access outputs
 // from jobs that the activation job depends on (i.e., jobs that run BEFORE activation).
 // This typically includes:
-// - needs.pre_activation.outputs.* (activated, matched_command) - only when pre_activation job exists
+// - needs.pre_activation.outputs.* (activated, matched_command, command_args) - only when pre_activation job exists
 // - needs.<custom-job>.outputs.* for custom jobs that run before activation
 //
 // The function does NOT generate mappings for jobs that run AFTER activation:
@@ -35,6 +35,8 @@ func generateKnownNeedsExpressions(data *WorkflowData, preActivationJobCreated b
 	knownNeedsLog.Print("Generating known needs.* expressions for activation job")
 
 	var mappings []*ExpressionMapping
+	// Use the same names as the placeholders of runtime-imported markdown
+	envPrefix := data.Expressions.envPrefix()
 
 	// Pre-activation job outputs (activation depends on pre_activation only when it exists)
 	// Only generate these mappings when the pre_activation job was actually created;
@@ -42,7 +44,7 @@ func generateKnownNeedsExpressions(data *WorkflowData, preActivationJobCreated b
 	if preActivationJobCreated {
 		// Always include the "activated" output
 		activatedExpr := fmt.Sprintf("needs.%s.outputs.%s", constants.PreActivationJobName, constants.ActivatedOutput)
-		activatedEnvVar := fmt.Sprintf("GH_AW_NEEDS_%s_OUTPUTS_%s",
+		activatedEnvVar := fmt.Sprintf("%sNEEDS_%s_OUTPUTS_%s", envPrefix,
 			normalizeJobNameForEnvVar(string(constants.PreActivationJobName)),
 			normalizeOutputNameForEnvVar(constants.ActivatedOutput))
 		mappings = append(mappings, &ExpressionMapping{
@@ -51,11 +53,11 @@ func generateKnownNeedsExpressions(data *WorkflowData, preActivationJobCreated b
 			Content:  activatedExpr,
 		})
 
-		// Only include "matched_command" when the workflow has a command trigger,
-		// since matched_command is only declared in the pre_activation job outputs for command workflows.
+		// Only include "matched_command" and "command_args" when the workflow has a command trigger,
+		// since they are only declared in the pre_activation job outputs for command workflows.
 		if len(data.Command) > 0 {
 			matchedCmdExpr := fmt.Sprintf("needs.%s.outputs.%s", constants.PreActivationJobName, constants.MatchedCommandOutput)
-			matchedCmdEnvVar := fmt.Sprintf("GH_AW_NEEDS_%s_OUTPUTS_%s",
+			matchedCmdEnvVar := fmt.Sprintf("%sNEEDS_%s_OUTPUTS_%s", envPrefix,
 				normalizeJobNameForEnvVar(string(constants.PreActivationJobName)),
 				normalizeOutputNameForEnvVar(constants.MatchedCommandOutput))
 			mappings = append(mappings, &ExpressionMapping{
@@ -63,6 +65,16 @@ func generateKnownNeedsExpressions(data *WorkflowData, preActivationJobCreated b
 				EnvVar:   matchedCmdEnvVar,
 				Content:  matchedCmdExpr,
 			})
+
+			commandArgsExpr := fmt.Sprintf("needs.%s.outputs.%s", constants.PreActivationJobName, constants.CommandArgsOutput)
+			commandArgsEnvVar := fmt.Sprintf("%sNEEDS_%s_OUTPUTS_%s", envPrefix,
+				normalizeJobNameForEnvVar(string(constants.PreActivationJobName)),
+				normalizeOutputNameForEnvVar(constants.CommandArgsOutput))
+			mappings = append(mappings, &ExpressionMapping{
+				Original: fmt.Sprintf("${{ %s }}", commandArgsExpr),
+				EnvVar:   commandArgsEnvVar,
+				Content:  commandArgsExpr,
+			})
 		}
 	}
 
@@ -94,7 +106,7 @@ func generateKnownNeedsExpressions(data *WorkflowData, preActivationJobCreated b
 			}
 			for _, output := range commonCustomOutputs {
 				expr := fmt.Sprintf("needs.%s.outputs.%s", jobName, output)
-				envVar := fmt.Sprintf("GH_AW_NEEDS_%s_OUTPUTS_%s",
+				envVar := fmt.Sprintf("%sNEEDS_%s_OUTPUTS_%s", envPrefix,
 					normalizeJobNameForEnvVar(jobName),
 					normalizeOutputNameForEnvVar(output))
 				mappings = append(mappings, &ExpressionMapping{
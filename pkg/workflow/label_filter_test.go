@@ -20,10 +20,11 @@ func TestLabelFilter(t *testing.T) {
 	compiler := NewCompiler()
 
 	tests := []struct {
-		name         string
-		frontmatter  string
-		expectedIf   string // Expected if condition in the generated lock file
-		shouldHaveIf bool   // Whether an if condition should be present
+		name          string
+		frontmatter   string
+		expectedIf    string // Expected if condition in the generated lock file
+		shouldHaveIf  bool   // Whether an if condition should be present
+		expectedError string // Expected compilation error, if any
 	}{
 		{
 			name: "issues with labeled and single label name",
@@ -148,8 +149,50 @@ tools:
   github:
     allowed: [issue_read]
 ---`,
-			expectedIf:   "",
-			shouldHaveIf: false,
+			expectedError: "issues event specifies 'names' but its 'types' do not include 'labeled' or 'unlabeled'",
+		},
+		{
+			name: "pull_request_target with labeled and label names",
+			frontmatter: `---
+on:
+  pull_request_target:
+    types: [labeled]
+    names: [safe-to-test]
+
+permissions:
+  contents: read
+  issues: read
+  pull-requests: read
+
+strict: false
+tools:
+  github:
+    allowed: [issue_read]
+---`,
+			expectedIf:   "github.event_name != 'pull_request_target'",
+			shouldHaveIf: true,
+		},
+		{
+			name: "discussion with labeled and label names",
+			frontmatter: `---
+on:
+  discussion:
+    types: [labeled]
+    names: [needs-triage]
+
+permissions:
+  contents: read
+  discussions: read
+  issues: read
+  pull-requests: read
+
+strict: false
+tools:
+  github:
+    allowed: [issue_read]
+---`,
+			expectedIf:   "github.event.label.name == 'needs-triage'",
+			shouldHaveIf: true,
 		},
 		{
 			name: "issues with labeled but no names field",
@@ -184,6 +227,13 @@ tools:
 
 			// Compile the workflow
 			err := compiler.CompileWorkflow(testFile)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected compilation error containing %q, got: %v", tt.expectedError, err)
+				}
+				os.Remove(testFile)
+				return
+			}
 			if err != nil {
 				t.Fatalf("Failed to compile workflow: %v", err)
 			}
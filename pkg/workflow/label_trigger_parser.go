@@ -2,6 +2,8 @@ package workflow
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
@@ -148,3 +150,99 @@ func getItemTypeName(entityType string) string {
 		return "item" // Fallback (though this shouldn't happen with our parser)
 	}
 }
+
+// labelRoutingEvents lists the events that accept the labeled:/unlabeled: routing sugar
+var labelRoutingEvents = []string{"issues", "pull_request", "pull_request_target", "discussion"}
+
+// expandLabelRoutingSugar rewrites the label routing sugar on labeled events, e.g.
+//
+//	issues:
+//	  labeled: [bug, needs-triage]
+//
+// into the equivalent types: [labeled] plus names: [bug, needs-triage] form, so the
+// activation condition only lets the listed labels through. It modifies onMap in place.
+func expandLabelRoutingSugar(onMap map[string]any) error {
+	for _, eventName := range labelRoutingEvents {
+		eventMap, ok := onMap[eventName].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		var actions []string
+		var labelNames []string
+		for _, action := range []string{"labeled", "unlabeled"} {
+			value, exists := eventMap[action]
+			if !exists {
+				continue
+			}
+			names, err := parseLabelRoutingNames(value)
+			if err != nil {
+				return fmt.Errorf("on.%s.%s: %w", eventName, action, err)
+			}
+			if labelNames != nil && !slices.Equal(labelNames, names) {
+				return fmt.Errorf("on.%s: labeled and unlabeled must list the same labels because the label filter applies to both; use separate workflows for different labels", eventName)
+			}
+			labelNames = names
+			actions = append(actions, action)
+			delete(eventMap, action)
+		}
+		if len(actions) == 0 {
+			continue
+		}
+
+		var types []any
+		switch typesVal := eventMap["types"].(type) {
+		case []any:
+			types = typesVal
+		case string:
+			types = []any{typesVal}
+		}
+		for _, action := range actions {
+			if !slices.Contains(types, any(action)) {
+				types = append(types, action)
+			}
+		}
+		eventMap["types"] = types
+
+		existingNames, _ := parseLabelRoutingNames(eventMap["names"])
+		namesAny := make([]any, 0, len(existingNames)+len(labelNames))
+		for _, name := range existingNames {
+			namesAny = append(namesAny, name)
+		}
+		for _, name := range labelNames {
+			if !slices.Contains(existingNames, name) {
+				namesAny = append(namesAny, name)
+			}
+		}
+		eventMap["names"] = namesAny
+
+		labelTriggerParserLog.Printf("Expanded label routing for %s: actions=%v, labels=%v", eventName, actions, labelNames)
+	}
+	return nil
+}
+
+// parseLabelRoutingNames returns the label names of a labeled:/unlabeled: value, which may be
+// a single label or a list of labels
+func parseLabelRoutingNames(value any) ([]string, error) {
+	var names []string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		names = []string{strings.TrimSpace(v)}
+	case []any:
+		for _, item := range v {
+			name, ok := item.(string)
+			if !ok {
+				return nil, errors.New("label names must be strings")
+			}
+			names = append(names, strings.TrimSpace(name))
+		}
+	default:
+		return nil, errors.New("expected a label name or a list of label names")
+	}
+	if len(names) == 0 || slices.Contains(names, "") {
+		return nil, errors.New("label names must not be empty")
+	}
+	return names, nil
+}
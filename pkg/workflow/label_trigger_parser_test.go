@@ -3,6 +3,7 @@
 package workflow
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -438,6 +439,69 @@ func TestExpandLabelTriggerShorthand(t *testing.T) {
 	}
 }
 
+func TestExpandLabelRoutingSugar(t *testing.T) {
+	tests := []struct {
+		name            string
+		on              map[string]any
+		want            map[string]any
+		wantErrContains string
+	}{
+		{
+			name: "labeled list on issues",
+			on:   map[string]any{"issues": map[string]any{"labeled": []any{"bug", "needs-triage"}}},
+			want: map[string]any{"issues": map[string]any{"types": []any{"labeled"}, "names": []any{"bug", "needs-triage"}}},
+		},
+		{
+			name: "single label keeps existing types",
+			on:   map[string]any{"pull_request": map[string]any{"types": []any{"opened"}, "labeled": "ready"}},
+			want: map[string]any{"pull_request": map[string]any{"types": []any{"opened", "labeled"}, "names": []any{"ready"}}},
+		},
+		{
+			name: "labeled and unlabeled with the same labels",
+			on:   map[string]any{"discussion": map[string]any{"labeled": []any{"q"}, "unlabeled": []any{"q"}}},
+			want: map[string]any{"discussion": map[string]any{"types": []any{"labeled", "unlabeled"}, "names": []any{"q"}}},
+		},
+		{
+			name: "merges with existing names without duplicates",
+			on:   map[string]any{"issues": map[string]any{"types": []any{"labeled"}, "names": []any{"bug"}, "labeled": []any{"bug", "p1"}}},
+			want: map[string]any{"issues": map[string]any{"types": []any{"labeled"}, "names": []any{"bug", "p1"}}},
+		},
+		{
+			name: "events without sugar are untouched",
+			on:   map[string]any{"issues": map[string]any{"types": []any{"opened"}}, "push": nil},
+			want: map[string]any{"issues": map[string]any{"types": []any{"opened"}}, "push": nil},
+		},
+		{
+			name:            "different labels for labeled and unlabeled",
+			on:              map[string]any{"issues": map[string]any{"labeled": []any{"a"}, "unlabeled": []any{"b"}}},
+			wantErrContains: "must list the same labels",
+		},
+		{
+			name:            "empty label name",
+			on:              map[string]any{"issues": map[string]any{"labeled": []any{""}}},
+			wantErrContains: "on.issues.labeled: label names must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := expandLabelRoutingSugar(tt.on)
+			if tt.wantErrContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrContains) {
+					t.Errorf("expandLabelRoutingSugar() error = %v, want error containing %q", err, tt.wantErrContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expandLabelRoutingSugar() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.on, tt.want) {
+				t.Errorf("expandLabelRoutingSugar() = %v, want %v", tt.on, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetItemTypeName(t *testing.T) {
 	tests := []struct {
 		entityType string
@@ -0,0 +1,156 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var limitsLog = logger.New("workflow:limits")
+
+// budgetReportPath is where the budget monitor records why it stopped the agent
+const budgetReportPath = "/tmp/gh-aw/budget-exceeded.json"
+
+// agentPIDPath is where the agent execution step records the PID of the shell running the
+// engine command; the budget monitor only signals the children of that shell
+const agentPIDPath = "/tmp/gh-aw/agent.pid"
+
+// budgetMonitorPIDPath is where the start step records the PID of the background monitor
+const budgetMonitorPIDPath = "/tmp/gh-aw/budget-monitor.pid"
+
+// LimitsConfig represents per-run budget limits for the agent (limits:)
+//
+// Example:
+//
+//	limits:
+//	  max-tokens: 500000
+//	  max-cost-usd: 5
+type LimitsConfig struct {
+	MaxTokens  int     `json:"max-tokens,omitempty"`   // Maximum total tokens (input + output) consumed by the agent
+	MaxCostUSD float64 `json:"max-cost-usd,omitempty"` // Maximum cost in USD as reported by the engine
+}
+
+// extractLimitsConfig extracts the limits configuration from frontmatter
+func (c *Compiler) extractLimitsConfig(frontmatter map[string]any) (*LimitsConfig, error) {
+	limitsValue, exists := frontmatter["limits"]
+	if !exists || limitsValue == nil {
+		return nil, nil
+	}
+
+	limitsMap, ok := limitsValue.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("limits must be an object, got %T. Example:\nlimits:\n  max-tokens: 500000\n  max-cost-usd: 5", limitsValue)
+	}
+
+	config := &LimitsConfig{}
+	if maxTokens, exists := limitsMap["max-tokens"]; exists {
+		value, ok := parseIntValue(maxTokens)
+		if !ok || value <= 0 {
+			return nil, fmt.Errorf("limits.max-tokens must be a positive integer, got %v", maxTokens)
+		}
+		config.MaxTokens = value
+	}
+	if maxCost, exists := limitsMap["max-cost-usd"]; exists {
+		value, ok := parseLimitFloat(maxCost)
+		if !ok || value <= 0 {
+			return nil, fmt.Errorf("limits.max-cost-usd must be a positive number, got %v", maxCost)
+		}
+		config.MaxCostUSD = value
+	}
+
+	if config.MaxTokens == 0 && config.MaxCostUSD == 0 {
+		return nil, nil
+	}
+
+	limitsLog.Printf("Extracted limits: max_tokens=%d, max_cost_usd=%.2f", config.MaxTokens, config.MaxCostUSD)
+	return config, nil
+}
+
+// parseLimitFloat converts a YAML number into a float64
+func parseLimitFloat(value any) (float64, bool) {
+	if f, ok := value.(float64); ok {
+		return f, true
+	}
+	if i, ok := parseIntValue(value); ok {
+		return float64(i), true
+	}
+	return 0, false
+}
+
+// validateLimitsSupport warns when max-cost-usd is used with an engine that does not report cost
+func (c *Compiler) validateLimitsSupport(limits *LimitsConfig, engineSetting string) {
+	if limits == nil || limits.MaxCostUSD == 0 {
+		return
+	}
+	engine, err := c.getAgenticEngine(engineSetting)
+	if err != nil {
+		return
+	}
+	if engine.GetID() != "claude" {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("limits.max-cost-usd is only enforced for engines that report cost in their logs (claude); engine '%s' is limited by max-tokens only", engine.GetID())))
+		c.IncrementWarningCount()
+	}
+}
+
+// generateBudgetMonitorStep generates the step that starts the background budget monitor.
+// The monitor watches the agent logs and stops the agent process once a limit is exceeded,
+// writing the reason to budgetReportPath for the log parser.
+func (c *Compiler) generateBudgetMonitorStep(yaml *strings.Builder, data *WorkflowData, engine CodingAgentEngine) {
+	if data.Limits == nil {
+		return
+	}
+
+	limitsLog.Printf("Generating budget monitor step for engine %s", engine.GetID())
+
+	yaml.WriteString("      - name: Start budget monitor\n")
+	yaml.WriteString("        env:\n")
+	fmt.Fprintf(yaml, "          GH_AW_ENGINE_ID: %s\n", engine.GetID())
+	fmt.Fprintf(yaml, "          GH_AW_AGENT_OUTPUT: %s\n", engine.GetLogFileForParsing())
+	fmt.Fprintf(yaml, "          GH_AW_BUDGET_REPORT: %s\n", budgetReportPath)
+	fmt.Fprintf(yaml, "          GH_AW_AGENT_PID_FILE: %s\n", agentPIDPath)
+	if data.Limits.MaxTokens > 0 {
+		fmt.Fprintf(yaml, "          GH_AW_MAX_TOKENS: %d\n", data.Limits.MaxTokens)
+	}
+	if data.Limits.MaxCostUSD > 0 {
+		fmt.Fprintf(yaml, "          GH_AW_MAX_COST_USD: %s\n", strconv.FormatFloat(data.Limits.MaxCostUSD, 'f', -1, 64))
+	}
+	yaml.WriteString("        run: |\n")
+	yaml.WriteString("          nohup node /opt/gh-aw/actions/budget_monitor.cjs > /tmp/gh-aw/budget-monitor.log 2>&1 &\n")
+	fmt.Fprintf(yaml, "          echo \"$!\" > %s\n", budgetMonitorPIDPath)
+	yaml.WriteString("          echo \"Budget monitor started (pid $!)\"\n")
+}
+
+// generateBudgetMonitorStopStep generates the step that stops the background budget monitor
+// once the agent execution step ended, whatever its outcome
+func (c *Compiler) generateBudgetMonitorStopStep(yaml *strings.Builder, data *WorkflowData) {
+	if data.Limits == nil {
+		return
+	}
+
+	yaml.WriteString("      - name: Stop budget monitor\n")
+	yaml.WriteString("        if: always()\n")
+	yaml.WriteString("        run: |\n")
+	fmt.Fprintf(yaml, "          if [ -f %s ]; then\n", budgetMonitorPIDPath)
+	fmt.Fprintf(yaml, "            kill \"$(cat %s)\" 2>/dev/null || true\n", budgetMonitorPIDPath)
+	fmt.Fprintf(yaml, "            rm -f %s\n", budgetMonitorPIDPath)
+	yaml.WriteString("          fi\n")
+}
+
+// recordAgentPID makes the agent execution step write the PID of the shell running the engine
+// command to agentPIDPath. $BASHPID is used so that the retry loop, which runs each attempt in a
+// subshell, records the shell of the current attempt.
+func recordAgentPID(step GitHubActionStep) GitHubActionStep {
+	runIndex := slices.Index(step, "        run: |")
+	if runIndex == -1 {
+		return step
+	}
+	lines := append([]string{}, step[:runIndex+1]...)
+	lines = append(lines, fmt.Sprintf("          echo \"$BASHPID\" > %s", agentPIDPath))
+	lines = append(lines, step[runIndex+1:]...)
+	return GitHubActionStep(lines)
+}
@@ -0,0 +1,141 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractLimitsConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *LimitsConfig
+		wantErr     string
+	}{
+		{
+			name:        "no limits",
+			frontmatter: map[string]any{},
+		},
+		{
+			name: "token and cost limits",
+			frontmatter: map[string]any{
+				"limits": map[string]any{"max-tokens": 500000, "max-cost-usd": 5},
+			},
+			expected: &LimitsConfig{MaxTokens: 500000, MaxCostUSD: 5},
+		},
+		{
+			name: "fractional cost limit",
+			frontmatter: map[string]any{
+				"limits": map[string]any{"max-cost-usd": 2.5},
+			},
+			expected: &LimitsConfig{MaxCostUSD: 2.5},
+		},
+		{
+			name:        "empty limits object",
+			frontmatter: map[string]any{"limits": map[string]any{}},
+		},
+		{
+			name:        "limits must be an object",
+			frontmatter: map[string]any{"limits": 1000},
+			wantErr:     "limits must be an object",
+		},
+		{
+			name: "non-positive max-tokens",
+			frontmatter: map[string]any{
+				"limits": map[string]any{"max-tokens": 0},
+			},
+			wantErr: "limits.max-tokens must be a positive integer",
+		},
+		{
+			name: "invalid max-cost-usd",
+			frontmatter: map[string]any{
+				"limits": map[string]any{"max-cost-usd": "five"},
+			},
+			wantErr: "limits.max-cost-usd must be a positive number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			config, err := compiler.extractLimitsConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected extraction error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error should describe the invalid limit")
+				return
+			}
+			require.NoError(t, err, "Extraction should succeed")
+			assert.Equal(t, tt.expected, config, "Limits config should match")
+		})
+	}
+}
+
+func TestGenerateBudgetMonitorStep(t *testing.T) {
+	compiler := NewCompiler()
+
+	t.Run("no step without limits", func(t *testing.T) {
+		var yaml strings.Builder
+		compiler.generateBudgetMonitorStep(&yaml, &WorkflowData{}, NewClaudeEngine())
+		assert.Empty(t, yaml.String(), "No step should be generated without limits")
+	})
+
+	t.Run("step passes limits to the monitor", func(t *testing.T) {
+		var yaml strings.Builder
+		data := &WorkflowData{Limits: &LimitsConfig{MaxTokens: 500000, MaxCostUSD: 2.5}}
+		compiler.generateBudgetMonitorStep(&yaml, data, NewClaudeEngine())
+
+		step := yaml.String()
+		assert.Contains(t, step, "- name: Start budget monitor", "Step should be named")
+		assert.Contains(t, step, "GH_AW_ENGINE_ID: claude", "Step should pass the engine")
+		assert.Contains(t, step, "GH_AW_MAX_TOKENS: 500000", "Step should pass the token limit")
+		assert.Contains(t, step, "GH_AW_MAX_COST_USD: 2.5", "Step should pass the cost limit")
+		assert.Contains(t, step, "GH_AW_BUDGET_REPORT: "+budgetReportPath, "Step should pass the report path")
+		assert.Contains(t, step, "nohup node /opt/gh-aw/actions/budget_monitor.cjs", "Step should start the monitor in the background")
+		assert.Contains(t, step, "GH_AW_AGENT_PID_FILE: "+agentPIDPath, "Step should pass the agent PID file")
+		assert.Contains(t, step, "echo \"$!\" > "+budgetMonitorPIDPath, "Step should record the monitor PID")
+	})
+
+	t.Run("cost limit is omitted when unset", func(t *testing.T) {
+		var yaml strings.Builder
+		data := &WorkflowData{Limits: &LimitsConfig{MaxTokens: 1000}}
+		compiler.generateBudgetMonitorStep(&yaml, data, NewCodexEngine())
+
+		assert.NotContains(t, yaml.String(), "GH_AW_MAX_COST_USD", "Unset cost limit should not be passed")
+	})
+}
+
+func TestGenerateBudgetMonitorStopStep(t *testing.T) {
+	compiler := NewCompiler()
+
+	var yaml strings.Builder
+	compiler.generateBudgetMonitorStopStep(&yaml, &WorkflowData{})
+	assert.Empty(t, yaml.String(), "No step should be generated without limits")
+
+	compiler.generateBudgetMonitorStopStep(&yaml, &WorkflowData{Limits: &LimitsConfig{MaxTokens: 1000}})
+	step := yaml.String()
+	assert.Contains(t, step, "- name: Stop budget monitor", "Step should be named")
+	assert.Contains(t, step, "if: always()", "Monitor should be stopped whatever the agent outcome")
+	assert.Contains(t, step, `kill "$(cat `+budgetMonitorPIDPath+`)"`, "Step should stop the monitor by its PID")
+}
+
+func TestRecordAgentPID(t *testing.T) {
+	step := GitHubActionStep{
+		"      - name: Execute Claude Code CLI",
+		"        run: |",
+		"          claude --print 2>&1 | tee /tmp/gh-aw/agent-stdio.log",
+		"        env:",
+	}
+
+	recorded := recordAgentPID(step)
+	require.Len(t, recorded, 5, "One line should be added")
+	assert.Equal(t, `          echo "$BASHPID" > `+agentPIDPath, recorded[2], "PID should be recorded before the engine command")
+
+	wrapped := wrapExecutionStepWithRetries(recorded, &RetriesConfig{Max: 1, Backoff: "fixed", On: []string{retryCategoryNetwork}}, "/tmp/gh-aw/agent-stdio.log")
+	script := strings.Join(wrapped, "\n")
+	assert.Less(t, strings.Index(script, "          ("), strings.Index(script, "$BASHPID"), "PID of each retry attempt should be recorded inside its subshell")
+}
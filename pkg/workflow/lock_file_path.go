@@ -0,0 +1,99 @@
+package workflow
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var lockFilePathLog = logger.New("workflow:lock_file_path")
+
+// LockFileNamePlaceholder is replaced by the markdown file name without its .md extension
+// in a lock file name pattern.
+const LockFileNamePlaceholder = "{name}"
+
+// DefaultLockFileNamePattern is the lock file name pattern of the .github/workflows layout.
+const DefaultLockFileNamePattern = LockFileNamePlaceholder + ".lock.yml"
+
+// WithOutputDir sets the directory lock files are written to instead of next to their markdown source
+func WithOutputDir(dir string) CompilerOption {
+	return func(c *Compiler) { c.outputDir = dir }
+}
+
+// WithLockFileNamePattern sets the pattern mapping a markdown file name to its lock file name
+func WithLockFileNamePattern(pattern string) CompilerOption {
+	return func(c *Compiler) { c.lockFileNamePattern = pattern }
+}
+
+// SetOutputDir sets the directory lock files are written to (empty writes next to the markdown source)
+func (c *Compiler) SetOutputDir(dir string) {
+	c.outputDir = dir
+}
+
+// SetLockFileNamePattern sets the pattern mapping a markdown file name to its lock file name
+// (empty uses the repository configuration, then DefaultLockFileNamePattern)
+func (c *Compiler) SetLockFileNamePattern(pattern string) {
+	c.lockFileNamePattern = pattern
+}
+
+// ValidateLockFileNamePattern checks that a lock file name pattern yields a distinct
+// YAML file name per workflow in the output directory.
+func ValidateLockFileNamePattern(pattern string) error {
+	if strings.Count(pattern, LockFileNamePlaceholder) != 1 {
+		return fmt.Errorf("lock file name pattern '%s' must contain %s exactly once", pattern, LockFileNamePlaceholder)
+	}
+	if strings.ContainsAny(pattern, `/\`) {
+		return fmt.Errorf("lock file name pattern '%s' must be a file name, not a path", pattern)
+	}
+	if strings.ContainsAny(strings.ReplaceAll(pattern, LockFileNamePlaceholder, ""), "*?[") {
+		return fmt.Errorf("lock file name pattern '%s' must not contain glob characters", pattern)
+	}
+	if ext := filepath.Ext(pattern); ext != ".yml" && ext != ".yaml" {
+		return fmt.Errorf("lock file name pattern '%s' must end with .yml or .yaml", pattern)
+	}
+	return nil
+}
+
+// effectiveLockFileNamePattern returns the CLI pattern, then the repository configuration
+// pattern, then DefaultLockFileNamePattern
+func (c *Compiler) effectiveLockFileNamePattern() string {
+	if c.lockFileNamePattern != "" {
+		return c.lockFileNamePattern
+	}
+	if c.repoConfig != nil && c.repoConfig.LockFileName != "" {
+		return c.repoConfig.LockFileName
+	}
+	return DefaultLockFileNamePattern
+}
+
+// LockFilePath returns the path of the lock file compiled from a markdown workflow, applying
+// the configured output directory and lock file name pattern. Without either it matches
+// stringutil.MarkdownToLockFile.
+func (c *Compiler) LockFilePath(markdownPath string) string {
+	pattern := c.effectiveLockFileNamePattern()
+	if c.outputDir == "" && pattern == DefaultLockFileNamePattern {
+		return filepath.Clean(stringutil.MarkdownToLockFile(markdownPath))
+	}
+
+	dir := filepath.Dir(markdownPath)
+	if c.outputDir != "" {
+		dir = c.outputDir
+	}
+	name := strings.TrimSuffix(filepath.Base(markdownPath), ".md")
+	lockFile := filepath.Join(dir, strings.Replace(pattern, LockFileNamePlaceholder, name, 1))
+	lockFilePathLog.Printf("Lock file path: %s -> %s", markdownPath, lockFile)
+	return lockFile
+}
+
+// LockFileGlob returns the glob matching every lock file the compiler writes for the
+// markdown workflows in workflowsDir
+func (c *Compiler) LockFileGlob(workflowsDir string) string {
+	dir := workflowsDir
+	if c.outputDir != "" {
+		dir = c.outputDir
+	}
+	return filepath.Join(dir, strings.Replace(c.effectiveLockFileNamePattern(), LockFileNamePlaceholder, "*", 1))
+}
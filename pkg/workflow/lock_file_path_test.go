@@ -0,0 +1,107 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockFilePath(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []CompilerOption
+		repoConfig *RepoConfig
+		expected   string
+		glob       string
+	}{
+		{
+			name:     "default layout",
+			expected: ".github/workflows/triage.lock.yml",
+			glob:     ".github/workflows/*.lock.yml",
+		},
+		{
+			name:     "output dir",
+			opts:     []CompilerOption{WithOutputDir("out/workflows")},
+			expected: "out/workflows/triage.lock.yml",
+			glob:     "out/workflows/*.lock.yml",
+		},
+		{
+			name:     "lock file name pattern",
+			opts:     []CompilerOption{WithLockFileNamePattern("aw-{name}.yml")},
+			expected: ".github/workflows/aw-triage.yml",
+			glob:     ".github/workflows/aw-*.yml",
+		},
+		{
+			name:       "repository config pattern",
+			repoConfig: &RepoConfig{LockFileName: "{name}.generated.yml"},
+			expected:   ".github/workflows/triage.generated.yml",
+			glob:       ".github/workflows/*.generated.yml",
+		},
+		{
+			name:       "flag pattern overrides repository config",
+			opts:       []CompilerOption{WithLockFileNamePattern("{name}.yaml")},
+			repoConfig: &RepoConfig{LockFileName: "{name}.generated.yml"},
+			expected:   ".github/workflows/triage.yaml",
+			glob:       ".github/workflows/*.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler(append(tt.opts, WithRepoConfig(tt.repoConfig))...)
+			assert.Equal(t, tt.expected, compiler.LockFilePath(".github/workflows/triage.md"), "lock file path")
+			assert.Equal(t, tt.glob, compiler.LockFileGlob(".github/workflows"), "lock file glob")
+		})
+	}
+}
+
+func TestValidateLockFileNamePattern(t *testing.T) {
+	assert.NoError(t, ValidateLockFileNamePattern(DefaultLockFileNamePattern), "default pattern")
+	assert.NoError(t, ValidateLockFileNamePattern("aw-{name}.yaml"), "custom pattern")
+
+	for pattern, errorSubstring := range map[string]string{
+		"workflow.yml":         "exactly once",
+		"{name}-{name}.yml":    "exactly once",
+		"out/{name}.lock.yml":  "not a path",
+		"{name}*.yml":          "glob characters",
+		"{name}.lock":          "must end with .yml or .yaml",
+		"{name}.compiled.json": "must end with .yml or .yaml",
+	} {
+		err := ValidateLockFileNamePattern(pattern)
+		require.Error(t, err, "pattern %q should be rejected", pattern)
+		assert.Contains(t, err.Error(), errorSubstring, "error for %q", pattern)
+	}
+}
+
+func TestCompileWithOutputDirAndLockFileName(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "lock-file-path-test")
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "should create workflows dir")
+
+	workflowFile := filepath.Join(workflowsDir, "triage.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Triage
+`), 0644), "should write workflow")
+
+	outputDir := filepath.Join(tmpDir, "generated", "workflows")
+	compiler := NewCompiler(WithOutputDir(outputDir), WithLockFileNamePattern("aw-{name}.yml"))
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "should compile workflow")
+
+	lockContent, err := os.ReadFile(filepath.Join(outputDir, "aw-triage.yml"))
+	require.NoError(t, err, "lock file should be written to the output directory")
+	assert.NoFileExists(t, filepath.Join(workflowsDir, "triage.lock.yml"), "no lock file should be written next to the markdown")
+	assert.Contains(t, string(lockContent), `GH_AW_WORKFLOW_FILE: "aw-triage.yml"`, "timestamp check should use the custom lock file name")
+	assert.Contains(t, string(lockContent), `GH_AW_WORKFLOW_MD_FILE: "triage.md"`, "timestamp check should get the markdown file name")
+}
@@ -0,0 +1,85 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// lockGoldenCompileRuns is how many times each fixture is compiled with a fresh compiler.
+// Go randomizes map iteration per range loop, so repeated runs surface ordering that
+// depends on map iteration.
+const lockGoldenCompileRuns = 5
+
+// TestLockGolden_CompileFixtures compiles each workflow fixture through the native
+// file-based path several times, requires every run to be byte-for-byte identical,
+// and compares the output against golden files.
+//
+// To update golden files:
+//
+//	go test -v ./pkg/workflow -run='^TestLockGolden_' -update
+//
+// Or use the Makefile target:
+//
+//	make update-lock-golden
+func TestLockGolden_CompileFixtures(t *testing.T) {
+	fixturesDir, err := filepath.Abs(filepath.Join("testdata", "lock_golden", "fixtures"))
+	require.NoError(t, err)
+	goldenDir, err := filepath.Abs(filepath.Join("testdata", "lock_golden", "TestLockGolden_CompileFixtures"))
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(fixturesDir)
+	require.NoError(t, err, "failed to read fixtures directory")
+
+	var fixtures []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			fixtures = append(fixtures, entry.Name())
+		}
+	}
+	require.NotEmpty(t, fixtures, "no .md fixtures found in %s", fixturesDir)
+
+	for _, fixture := range fixtures {
+		testName := strings.TrimSuffix(fixture, ".md")
+		t.Run(testName, func(t *testing.T) {
+			fixturePath := filepath.Join(fixturesDir, fixture)
+
+			var first string
+			for run := range lockGoldenCompileRuns {
+				compiler := NewCompiler(
+					WithNoEmit(true),
+					WithSkipValidation(true),
+					WithWorkflowIdentifier(testName),
+				)
+
+				wd, err := compiler.ParseWorkflowFile(fixturePath)
+				require.NoError(t, err, "failed to parse fixture %s", fixture)
+
+				yamlOutput, err := compiler.CompileToYAML(wd, fixturePath)
+				require.NoError(t, err, "failed to compile fixture %s", fixture)
+				require.NotEmpty(t, yamlOutput, "empty YAML output for %s", fixture)
+
+				if run == 0 {
+					first = yamlOutput
+					continue
+				}
+				require.Equal(t, first, yamlOutput, "compilation %d of %s differs from the first compilation", run+1, fixture) //nolint:testifylint // stability requires exact string comparison
+			}
+
+			goldenPath := filepath.Join(goldenDir, testName+".golden")
+			if isUpdateMode() {
+				require.NoError(t, os.MkdirAll(goldenDir, 0o755))
+				require.NoError(t, os.WriteFile(goldenPath, []byte(first), 0o644))
+				return
+			}
+			expected, err := os.ReadFile(goldenPath)
+			require.NoError(t, err, "golden file not found for %s (run with -update to create)", fixture)
+			require.Equal(t, string(expected), first, "output differs from golden for %s", fixture) //nolint:testifylint // golden test requires exact string comparison, not semantic YAML equality
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 )
 
 var markdownSecurityLog = logger.New("workflow:markdown_security_scanner")
@@ -114,18 +115,22 @@ func ScanMarkdownSecurity(content string) []SecurityFinding {
 	return findings
 }
 
-// stripFrontmatter removes YAML frontmatter (--- delimited) from content.
+// stripFrontmatter removes YAML (--- delimited) or TOML (+++ delimited) frontmatter from content.
 // Returns the markdown body and the number of lines consumed by frontmatter
-// (including the closing --- delimiter) so callers can adjust line numbers.
+// (including the closing delimiter) so callers can adjust line numbers.
 func stripFrontmatter(content string) (string, int) {
 	lines := strings.Split(content, "\n")
-	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+	delimiter := ""
+	if len(lines) > 0 {
+		delimiter = parser.FrontmatterDelimiter(lines[0])
+	}
+	if delimiter == "" {
 		return content, 0
 	}
 
-	// Find closing ---
+	// Find the closing delimiter
 	for i := 1; i < len(lines); i++ {
-		if strings.TrimSpace(lines[i]) == "---" {
+		if strings.TrimSpace(lines[i]) == delimiter {
 			// Return everything after the closing ---
 			remaining := strings.Join(lines[i+1:], "\n")
 			return remaining, i + 1 // i+1 lines consumed (0-indexed i, plus the closing ---)
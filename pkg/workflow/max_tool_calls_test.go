@@ -0,0 +1,77 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxToolCallsCompilation(t *testing.T) {
+	tests := []struct {
+		name        string
+		engine      string
+		wantErr     string
+		wantLimited bool
+	}{
+		{
+			name:        "claude with max-tool-calls",
+			engine:      "engine:\n  id: claude\n  max-tool-calls: 25\n",
+			wantLimited: true,
+		},
+		{
+			name:   "claude without max-tool-calls",
+			engine: "engine: claude\n",
+		},
+		{
+			name:    "copilot does not support max-tool-calls",
+			engine:  "engine:\n  id: copilot\n  max-tool-calls: 25\n",
+			wantErr: "max-tool-calls not supported: engine 'copilot'",
+		},
+		{
+			name:    "copilot does not support max-turns",
+			engine:  "engine:\n  id: copilot\n  max-turns: 5\n",
+			wantErr: "max-turns not supported: engine 'copilot'",
+		},
+		{
+			name:    "max-tool-calls must be positive",
+			engine:  "engine:\n  id: claude\n  max-tool-calls: 0\n",
+			wantErr: "max-tool-calls",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "max-tool-calls-test")
+			workflowPath := filepath.Join(tmpDir, "limits.md")
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\n" + tt.engine + "---\n\nSummarize the repository.\n"
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+			err := NewCompiler().CompileWorkflow(workflowPath)
+			if tt.wantErr != "" {
+				require.Error(t, err, "compilation should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should name the unsupported limit")
+				return
+			}
+			require.NoError(t, err, "workflow should compile")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+			require.NoError(t, err, "should read lock file")
+			lock := string(lockContent)
+			if !tt.wantLimited {
+				assert.NotContains(t, lock, claudeHooksSettingsPath, "should not configure the tool call limit")
+				return
+			}
+			assert.Contains(t, lock, "--settings "+claudeHooksSettingsPath, "claude should load the hook settings")
+			assert.Contains(t, lock, "GH_AW_MAX_TOOL_CALLS: 25", "hook should receive the limit")
+			assert.Contains(t, lock, "cp /opt/gh-aw/actions/limit_tool_calls.sh", "hook script should be installed")
+			assert.Contains(t, lock, `"PreToolUse"`, "settings should register the hook")
+		})
+	}
+}
@@ -406,9 +406,9 @@ func TestIsMCPType(t *testing.T) {
 			expected: false,
 		},
 		{
-			name:     "docker type (not valid)",
+			name:     "docker type",
 			typeStr:  "docker",
-			expected: false,
+			expected: true,
 		},
 		{
 			name:     "websocket type (not valid)",
@@ -101,6 +101,7 @@ func renderSharedMCPConfig(yaml *strings.Builder, toolName string, toolConfig ma
 	var headerSecrets map[string]string
 	if mcpConfig.Type == "http" && renderer.RequiresCopilotFields {
 		headerSecrets = ExtractSecretsFromMap(mcpConfig.Headers)
+		maps.Copy(headerSecrets, extractMCPOAuthTokenVars(toolName, &mcpConfig.BaseMCPServerConfig))
 	}
 
 	// Determine properties based on type
@@ -551,6 +552,12 @@ func collectHTTPMCPHeaderSecrets(tools map[string]any) map[string]string {
 func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServerConfig, error) {
 	mcpCustomLog.Printf("Extracting MCP config for tool: %s", toolName)
 
+	// Docker servers are containerized stdio servers with generated runtime arguments
+	toolConfig, err := parser.ExpandDockerMCPConfig(toolName, toolConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	config := MapToolConfig(toolConfig)
 	result := &parser.MCPServerConfig{
 		BaseMCPServerConfig: types.BaseMCPServerConfig{
@@ -571,10 +578,12 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 		"entrypoint":     true,
 		"entrypointArgs": true,
 		"mounts":         true,
+		"cache":          true,
 		"env":            true,
 		"proxy-args":     true,
 		"url":            true,
 		"headers":        true,
+		"oauth":          true,
 		"registry":       true,
 		"allowed":        true,
 		"toolsets":       true, // Added for MCPServerConfig struct
@@ -692,6 +701,14 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 		if headers, hasHeaders := config.GetStringMap("headers"); hasHeaders {
 			result.Headers = headers
 		}
+		if oauthValue, hasOAuth := toolConfig["oauth"]; hasOAuth {
+			oauth, err := parseMCPOAuthConfig(toolName, oauthValue)
+			if err != nil {
+				return nil, err
+			}
+			result.OAuth = oauth
+			applyMCPOAuthHeader(&result.BaseMCPServerConfig, toolName)
+		}
 	default:
 		mcpCustomLog.Printf("Unsupported MCP type '%s' for tool '%s'", result.Type, toolName)
 		return nil, fmt.Errorf(
@@ -727,6 +744,17 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 		}
 	}
 
+	// Run npx and uvx servers with cache: true from their pre-installed packages
+	if result.Type == "stdio" && result.Entrypoint != "" {
+		cachedPackage, err := getMCPCachedPackage(toolName, toolConfig)
+		if err != nil {
+			return nil, err
+		}
+		if cachedPackage != nil {
+			applyMCPPackageCache(cachedPackage, &result.BaseMCPServerConfig)
+		}
+	}
+
 	// Combine container and version fields into a single container image string
 	// Per MCP Gateway Specification, the container field should include the full image reference
 	// including the tag (e.g., "mcp/ast-grep:latest" instead of separate container + version fields)
@@ -743,8 +771,8 @@ func hasMCPConfig(toolConfig map[string]any) (bool, string) {
 	// Check for direct type field
 	if mcpType, hasType := toolConfig["type"]; hasType {
 		if typeStr, ok := mcpType.(string); ok && parser.IsMCPType(typeStr) {
-			// Normalize "local" to "stdio" for consistency
-			if typeStr == "local" {
+			// Normalize "local" and "docker" to "stdio" for consistency
+			if typeStr == "local" || typeStr == "docker" {
 				return true, "stdio"
 			}
 			return true, typeStr
@@ -25,6 +25,12 @@
 // ## stdio type
 //   - Requires either 'command' or 'container' (but not both)
 //   - Optional: version, args, entrypointArgs, env, proxy-args, registry
+//   - Optional: cache, for npx and uvx commands with a pinned package version
+//
+// ## docker type
+//   - Requires 'image' pinned to a digest (image@sha256:...)
+//   - Optional: resources (cpus, memory), read-only-rootfs (default true), args, entrypoint, env, mounts
+//   - Cannot use 'command', 'container' or 'version'
 //
 // ## http type
 //   - Requires 'url' field
@@ -123,28 +129,33 @@ func getRawMCPConfig(toolConfig map[string]any) (map[string]any, error) {
 
 	// List of all known tool config fields (not just MCP)
 	knownToolFields := map[string]bool{
-		"type":           true,
-		"url":            true,
-		"command":        true,
-		"container":      true,
-		"env":            true,
-		"headers":        true,
-		"version":        true,
-		"args":           true,
-		"entrypoint":     true,
-		"entrypointArgs": true,
-		"mounts":         true,
-		"proxy-args":     true,
-		"registry":       true,
-		"allowed":        true,
-		"mode":           true, // for github tool
-		"github-token":   true, // for github tool
-		"read-only":      true, // for github tool
-		"toolsets":       true, // for github tool
-		"id":             true, // for cache-memory (array notation)
-		"key":            true, // for cache-memory
-		"description":    true, // for cache-memory
-		"retention-days": true, // for cache-memory
+		"type":             true,
+		"url":              true,
+		"command":          true,
+		"container":        true,
+		"env":              true,
+		"headers":          true,
+		"oauth":            true,
+		"image":            true, // for docker MCP servers
+		"resources":        true, // for docker MCP servers
+		"read-only-rootfs": true, // for docker MCP servers
+		"version":          true,
+		"args":             true,
+		"entrypoint":       true,
+		"entrypointArgs":   true,
+		"mounts":           true,
+		"cache":            true, // for npx and uvx MCP servers
+		"proxy-args":       true,
+		"registry":         true,
+		"allowed":          true,
+		"mode":             true, // for github tool
+		"github-token":     true, // for github tool
+		"read-only":        true, // for github tool
+		"toolsets":         true, // for github tool
+		"id":               true, // for cache-memory (array notation)
+		"key":              true, // for cache-memory
+		"description":      true, // for cache-memory
+		"retention-days":   true, // for cache-memory
 	}
 
 	// Check new format: direct fields in tool config
@@ -239,11 +250,16 @@ func validateMCPRequirements(toolName string, mcpConfig map[string]any, toolConf
 
 	// Validate type is one of the supported types
 	if !parser.IsMCPType(typeStr) {
-		return fmt.Errorf("tool '%s' mcp configuration 'type' must be one of: stdio, http (per MCP Gateway Specification). Note: 'local' is accepted for backward compatibility and treated as 'stdio'. Got: %s.\n\nExample:\ntools:\n  %s:\n    type: \"stdio\"\n    command: \"node server.js\"\n\nSee: %s", toolName, typeStr, toolName, constants.DocsToolsURL)
+		return fmt.Errorf("tool '%s' mcp configuration 'type' must be one of: stdio, http (per MCP Gateway Specification), or docker. Note: 'local' is accepted for backward compatibility and treated as 'stdio'. Got: %s.\n\nExample:\ntools:\n  %s:\n    type: \"stdio\"\n    command: \"node server.js\"\n\nSee: %s", toolName, typeStr, toolName, constants.DocsToolsURL)
 	}
 
 	// Validate type-specific requirements
 	switch typeStr {
+	case "docker":
+		// Docker servers are validated while expanding them into containerized stdio servers
+		_, err := parser.ExpandDockerMCPConfig(toolName, toolConfig)
+		return err
+
 	case "http":
 		// HTTP type requires 'url' property
 		url, hasURL := mcpConfig["url"]
@@ -287,6 +303,11 @@ func validateMCPRequirements(toolName string, mcpConfig map[string]any, toolConf
 				return err
 			}
 		}
+
+		// Validate that cached servers use npx or uvx with a pinned package version
+		if _, err := getMCPCachedPackage(toolName, toolConfig); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -0,0 +1,72 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileWorkflowWithDockerMCPServer(t *testing.T) {
+	const image = "ghcr.io/org/scanner@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		name     string
+		server   string
+		wantErr  string
+		contains []string
+	}{
+		{
+			name: "digest pinned image with limits",
+			server: `    type: docker
+    image: "` + image + `"
+    resources:
+      cpus: 2
+      memory: 1g
+    entrypointArgs: ["serve"]`,
+			contains: []string{
+				`"container": "` + image + `"`,
+				`"--cpus",`,
+				`"--memory",`,
+				`"1g",`,
+				`"--read-only",`,
+				"download_docker_images.sh",
+			},
+		},
+		{
+			name: "tag without digest",
+			server: `    type: docker
+    image: "ghcr.io/org/scanner:latest"`,
+			wantErr: "must be pinned to a digest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "mcp-docker-test")
+			workflowPath := filepath.Join(tmpDir, "docker.md")
+			content := "---\non: issues\nengine: copilot\npermissions:\n  contents: read\nmcp-servers:\n  scanner:\n" + tt.server + "\n---\n\nScan the repository.\n"
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+			err := NewCompiler().CompileWorkflow(workflowPath)
+			if tt.wantErr != "" {
+				require.Error(t, err, "compilation should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "workflow should compile")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+			require.NoError(t, err, "should read lock file")
+			for _, want := range tt.contains {
+				assert.Contains(t, string(lockContent), want, "lock file should contain %q", want)
+			}
+		})
+	}
+}
@@ -168,6 +168,7 @@ func collectMCPEnvironmentVariables(tools map[string]any, mcpTools []string, wor
 				headerSecrets := ExtractSecretsFromMap(mcpConfig.Headers)
 				mcpEnvironmentLog.Printf("Extracted %d secrets from HTTP MCP server '%s'", len(headerSecrets), toolName)
 				maps.Copy(envVars, headerSecrets)
+				maps.Copy(envVars, extractMCPOAuthTokenVars(toolName, &mcpConfig.BaseMCPServerConfig))
 			}
 
 			// Also extract secrets and env expressions from env section if present
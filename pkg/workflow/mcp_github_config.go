@@ -389,11 +389,7 @@ func (c *Compiler) generateGitHubMCPAppTokenInvalidationStep(yaml *strings.Build
 	githubConfigLog.Print("Generating GitHub App token invalidation step for GitHub MCP server")
 
 	// Generate the token invalidation step using the existing helper from safe_outputs_app.go
-	steps := c.buildGitHubAppTokenInvalidationStep()
-
-	// Modify the step references to use github-mcp-app-token instead of safe-outputs-app-token
-	for _, step := range steps {
-		modifiedStep := strings.ReplaceAll(step, "steps.safe-outputs-app-token.outputs.token", "steps.github-mcp-app-token.outputs.token")
-		yaml.WriteString(modifiedStep)
+	for _, step := range c.buildGitHubAppTokenInvalidationStep("github-mcp-app-token", "Invalidate GitHub App token") {
+		yaml.WriteString(step)
 	}
 }
@@ -0,0 +1,211 @@
+package workflow
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/types"
+)
+
+var mcpOAuthLog = logger.New("workflow:mcp_oauth")
+
+// mcpOAuthEnvNamePattern matches characters that are not valid in environment variable names
+var mcpOAuthEnvNamePattern = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// parseMCPOAuthConfig parses the oauth: section of an HTTP MCP server configuration
+func parseMCPOAuthConfig(toolName string, value any) (*types.MCPOAuthConfig, error) {
+	oauthMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("oauth configuration for MCP server '%s' must be an object, got %T", toolName, value)
+	}
+	config := MapToolConfig(oauthMap)
+
+	knownFields := map[string]bool{
+		"token-url":                true,
+		"device-authorization-url": true,
+		"client-id":                true,
+		"client-secret":            true,
+		"refresh-token":            true,
+		"scopes":                   true,
+		"audience":                 true,
+	}
+	for key := range oauthMap {
+		if !knownFields[key] {
+			validFields := make([]string, 0, len(knownFields))
+			for field := range knownFields {
+				validFields = append(validFields, field)
+			}
+			sort.Strings(validFields)
+			return nil, fmt.Errorf("unknown property '%s' in oauth configuration for MCP server '%s'. Valid properties are: %s",
+				key, toolName, strings.Join(validFields, ", "))
+		}
+	}
+
+	oauth := &types.MCPOAuthConfig{}
+	oauth.TokenURL, _ = config.GetString("token-url")
+	oauth.DeviceAuthorizationURL, _ = config.GetString("device-authorization-url")
+	oauth.ClientID, _ = config.GetString("client-id")
+	oauth.ClientSecret, _ = config.GetString("client-secret")
+	oauth.RefreshToken, _ = config.GetString("refresh-token")
+	oauth.Audience, _ = config.GetString("audience")
+	if scopes, hasScopes := config.GetStringArray("scopes"); hasScopes {
+		oauth.Scopes = scopes
+	}
+
+	if oauth.TokenURL == "" {
+		return nil, fmt.Errorf(
+			"oauth configuration for MCP server '%s' is missing required 'token-url' field. "+
+				"Example:\n"+
+				"mcp-servers:\n"+
+				"  %s:\n"+
+				"    url: \"https://mcp.example.com/mcp\"\n"+
+				"    oauth:\n"+
+				"      token-url: \"https://auth.example.com/oauth/token\"\n"+
+				"      client-id: \"my-client\"\n"+
+				"      refresh-token: \"${{ secrets.MCP_REFRESH_TOKEN }}\"",
+			toolName, toolName)
+	}
+	for field, value := range map[string]string{"token-url": oauth.TokenURL, "device-authorization-url": oauth.DeviceAuthorizationURL} {
+		if value == "" {
+			continue
+		}
+		if parsed, err := url.Parse(value); err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return nil, fmt.Errorf("oauth %s for MCP server '%s' must be an https URL, got '%s'", field, toolName, value)
+		}
+	}
+	// A literal credential would be committed to the repository in the workflow and lock files
+	if oauth.ClientSecret != "" && !isSecretsExpression(oauth.ClientSecret) {
+		return nil, fmt.Errorf("oauth client-secret for MCP server '%s' must be an expression such as ${{ secrets.MCP_CLIENT_SECRET }}", toolName)
+	}
+	if oauth.RefreshToken != "" && !isSecretsExpression(oauth.RefreshToken) {
+		return nil, fmt.Errorf("oauth refresh-token for MCP server '%s' must be an expression such as ${{ secrets.MCP_REFRESH_TOKEN }}", toolName)
+	}
+	if oauth.ClientSecret == "" && oauth.RefreshToken == "" {
+		return nil, fmt.Errorf("oauth configuration for MCP server '%s' needs a 'refresh-token' or a 'client-secret'. "+
+			"Run 'gh aw mcp oauth' to obtain a refresh token with the device flow", toolName)
+	}
+
+	mcpOAuthLog.Printf("Parsed oauth configuration for MCP server %s: token_url=%s", toolName, oauth.TokenURL)
+	return oauth, nil
+}
+
+// isSecretsExpression reports whether value is a ${{ secrets.* }} expression
+func isSecretsExpression(value string) bool {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "${{") || !strings.HasSuffix(value, "}}") {
+		return false
+	}
+	expression := strings.TrimSpace(value[3 : len(value)-2])
+	return strings.HasPrefix(expression, "secrets.")
+}
+
+// mcpOAuthStepID returns the id of the step that acquires the OAuth access token for an MCP server
+func mcpOAuthStepID(toolName string) string {
+	return "mcp-oauth-" + toolName
+}
+
+// mcpOAuthTokenEnvVar returns the environment variable that passes an MCP server's OAuth
+// access token to the MCP gateway
+func mcpOAuthTokenEnvVar(toolName string) string {
+	name := mcpOAuthEnvNamePattern.ReplaceAllString(strings.ToUpper(toolName), "_")
+	return "GH_AW_MCP_OAUTH_" + name + "_TOKEN"
+}
+
+// mcpOAuthTokenExpression returns the expression reading an MCP server's acquired access token
+func mcpOAuthTokenExpression(toolName string) string {
+	return fmt.Sprintf("${{ steps.%s.outputs.access_token }}", mcpOAuthStepID(toolName))
+}
+
+// applyMCPOAuthHeader sends the acquired access token as a bearer token unless the
+// configuration already sets an Authorization header
+func applyMCPOAuthHeader(config *types.BaseMCPServerConfig, toolName string) {
+	if config.OAuth == nil {
+		return
+	}
+	for header := range config.Headers {
+		if strings.EqualFold(header, "Authorization") {
+			mcpOAuthLog.Printf("MCP server %s sets its own Authorization header", toolName)
+			return
+		}
+	}
+	if config.Headers == nil {
+		config.Headers = make(map[string]string)
+	}
+	config.Headers["Authorization"] = "Bearer " + mcpOAuthTokenExpression(toolName)
+}
+
+// extractMCPOAuthTokenVars returns the environment variable carrying the acquired access token
+// of an OAuth MCP server, keyed like ExtractSecretsFromMap so header values can be rewritten
+// with ReplaceSecretsWithEnvVars
+func extractMCPOAuthTokenVars(toolName string, config *types.BaseMCPServerConfig) map[string]string {
+	if config.OAuth == nil {
+		return nil
+	}
+	return map[string]string{mcpOAuthTokenEnvVar(toolName): mcpOAuthTokenExpression(toolName)}
+}
+
+// generateMCPOAuthTokenSteps writes one step per OAuth MCP server that exchanges the configured
+// credentials for an access token before the MCP gateway starts
+func generateMCPOAuthTokenSteps(yaml *strings.Builder, tools map[string]any) {
+	toolNames := make([]string, 0, len(tools))
+	for toolName := range tools {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+
+	for _, toolName := range toolNames {
+		toolConfig, ok := tools[toolName].(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, mcpType := hasMCPConfig(toolConfig); !hasMcp || mcpType != "http" {
+			continue
+		}
+		mcpConfig, err := getMCPConfig(toolConfig, toolName)
+		if err != nil || mcpConfig.OAuth == nil {
+			continue
+		}
+		mcpOAuthLog.Printf("Generating OAuth token step for MCP server %s", toolName)
+
+		oauth := mcpConfig.OAuth
+		env := map[string]string{
+			"GH_AW_OAUTH_SERVER":    toolName,
+			"GH_AW_OAUTH_TOKEN_URL": oauth.TokenURL,
+		}
+		optional := map[string]string{
+			"GH_AW_OAUTH_CLIENT_ID":     oauth.ClientID,
+			"GH_AW_OAUTH_CLIENT_SECRET": oauth.ClientSecret,
+			"GH_AW_OAUTH_REFRESH_TOKEN": oauth.RefreshToken,
+			"GH_AW_OAUTH_SCOPES":        strings.Join(oauth.Scopes, " "),
+			"GH_AW_OAUTH_AUDIENCE":      oauth.Audience,
+		}
+		for name, value := range optional {
+			if value != "" {
+				env[name] = value
+			}
+		}
+		envNames := make([]string, 0, len(env))
+		for name := range env {
+			envNames = append(envNames, name)
+		}
+		sort.Strings(envNames)
+
+		fmt.Fprintf(yaml, "      - name: Acquire OAuth token for %s MCP server\n", toolName)
+		fmt.Fprintf(yaml, "        id: %s\n", mcpOAuthStepID(toolName))
+		yaml.WriteString("        uses: " + GetActionPin("actions/github-script") + "\n")
+		yaml.WriteString("        env:\n")
+		for _, name := range envNames {
+			fmt.Fprintf(yaml, "          %s: %q\n", name, env[name])
+		}
+		yaml.WriteString("        with:\n")
+		yaml.WriteString("          script: |\n")
+		yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
+		yaml.WriteString("            setupGlobals(core, github, context, exec, io);\n")
+		yaml.WriteString("            const { main } = require('" + SetupActionDestination + "/acquire_mcp_oauth_token.cjs');\n")
+		yaml.WriteString("            await main();\n")
+	}
+}
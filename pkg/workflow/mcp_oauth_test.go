@@ -0,0 +1,181 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMCPConfigOAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     map[string]any
+		wantErr    string
+		wantHeader string
+	}{
+		{
+			name: "refresh token adds bearer header",
+			config: map[string]any{
+				"url": "https://mcp.example.com/mcp",
+				"oauth": map[string]any{
+					"token-url":     "https://auth.example.com/token",
+					"client-id":     "client",
+					"refresh-token": "${{ secrets.MCP_REFRESH_TOKEN }}",
+					"scopes":        []any{"read", "write"},
+				},
+			},
+			wantHeader: "Bearer ${{ steps.mcp-oauth-remote.outputs.access_token }}",
+		},
+		{
+			name: "explicit authorization header is kept",
+			config: map[string]any{
+				"url":     "https://mcp.example.com/mcp",
+				"headers": map[string]any{"Authorization": "Token ${{ steps.mcp-oauth-remote.outputs.access_token }}"},
+				"oauth": map[string]any{
+					"token-url":     "https://auth.example.com/token",
+					"client-secret": "${{ secrets.MCP_CLIENT_SECRET }}",
+				},
+			},
+			wantHeader: "Token ${{ steps.mcp-oauth-remote.outputs.access_token }}",
+		},
+		{
+			name: "missing token url",
+			config: map[string]any{
+				"url":   "https://mcp.example.com/mcp",
+				"oauth": map[string]any{"refresh-token": "${{ secrets.MCP_REFRESH_TOKEN }}"},
+			},
+			wantErr: "missing required 'token-url'",
+		},
+		{
+			name: "non-https token url",
+			config: map[string]any{
+				"url": "https://mcp.example.com/mcp",
+				"oauth": map[string]any{
+					"token-url":     "http://auth.example.com/token",
+					"refresh-token": "${{ secrets.MCP_REFRESH_TOKEN }}",
+				},
+			},
+			wantErr: "must be an https URL",
+		},
+		{
+			name: "missing credentials",
+			config: map[string]any{
+				"url":   "https://mcp.example.com/mcp",
+				"oauth": map[string]any{"token-url": "https://auth.example.com/token", "client-id": "client"},
+			},
+			wantErr: "needs a 'refresh-token' or a 'client-secret'",
+		},
+		{
+			name: "literal refresh token",
+			config: map[string]any{
+				"url": "https://mcp.example.com/mcp",
+				"oauth": map[string]any{
+					"token-url":     "https://auth.example.com/token",
+					"refresh-token": "rt-0123456789",
+				},
+			},
+			wantErr: "oauth refresh-token for MCP server 'remote' must be an expression such as ${{ secrets.MCP_REFRESH_TOKEN }}",
+		},
+		{
+			name: "literal client secret",
+			config: map[string]any{
+				"url": "https://mcp.example.com/mcp",
+				"oauth": map[string]any{
+					"token-url":     "https://auth.example.com/token",
+					"client-id":     "client",
+					"client-secret": "s3cr3t",
+				},
+			},
+			wantErr: "oauth client-secret for MCP server 'remote' must be an expression",
+		},
+		{
+			name: "non-secret expression",
+			config: map[string]any{
+				"url": "https://mcp.example.com/mcp",
+				"oauth": map[string]any{
+					"token-url":     "https://auth.example.com/token",
+					"client-secret": "${{ vars.MCP_CLIENT_SECRET }}",
+				},
+			},
+			wantErr: "oauth client-secret for MCP server 'remote' must be an expression",
+		},
+		{
+			name: "unknown oauth property",
+			config: map[string]any{
+				"url": "https://mcp.example.com/mcp",
+				"oauth": map[string]any{
+					"token-url":     "https://auth.example.com/token",
+					"refresh-token": "${{ secrets.MCP_REFRESH_TOKEN }}",
+					"grant":         "password",
+				},
+			},
+			wantErr: "unknown property 'grant'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := getMCPConfig(tt.config, "remote")
+			if tt.wantErr != "" {
+				require.Error(t, err, "getMCPConfig should reject the oauth configuration")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "getMCPConfig should accept the oauth configuration")
+			require.NotNil(t, config.OAuth, "oauth configuration should be parsed")
+			assert.Equal(t, tt.wantHeader, config.Headers["Authorization"], "Authorization header should carry the access token")
+		})
+	}
+}
+
+func TestMCPOAuthTokenEnvVar(t *testing.T) {
+	assert.Equal(t, "GH_AW_MCP_OAUTH_NOTION_TOKEN", mcpOAuthTokenEnvVar("notion"), "simple server name")
+	assert.Equal(t, "GH_AW_MCP_OAUTH_MY_REMOTE_MCP_TOKEN", mcpOAuthTokenEnvVar("my-remote.mcp"), "invalid characters should be replaced")
+}
+
+func TestCompileWorkflowWithOAuthMCPServer(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-oauth-test")
+	workflowPath := filepath.Join(tmpDir, "oauth.md")
+	content := `---
+on: issues
+engine: copilot
+permissions:
+  contents: read
+mcp-servers:
+  notion:
+    url: "https://mcp.notion.com/mcp"
+    oauth:
+      token-url: "https://api.notion.com/v1/oauth/token"
+      client-id: "notion-client"
+      refresh-token: "${{ secrets.NOTION_REFRESH_TOKEN }}"
+    allowed: ["*"]
+---
+
+Summarize the issue.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "workflow should compile")
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	stepIndex := strings.Index(lock, "- name: Acquire OAuth token for notion MCP server")
+	gatewayIndex := strings.Index(lock, "- name: Start MCP Gateway")
+	require.NotEqual(t, -1, stepIndex, "token step should be generated")
+	assert.Less(t, stepIndex, gatewayIndex, "token step should run before the gateway starts")
+	assert.Contains(t, lock, "id: mcp-oauth-notion", "token step should have an id")
+	assert.Contains(t, lock, `GH_AW_OAUTH_REFRESH_TOKEN: "${{ secrets.NOTION_REFRESH_TOKEN }}"`, "refresh token should be passed via env")
+	assert.Contains(t, lock, "require('/opt/gh-aw/actions/acquire_mcp_oauth_token.cjs')", "token step should run the acquisition script")
+	assert.Contains(t, lock, "GH_AW_MCP_OAUTH_NOTION_TOKEN: ${{ steps.mcp-oauth-notion.outputs.access_token }}", "gateway step should receive the token via env")
+	assert.Contains(t, lock, `"Authorization": "Bearer \${GH_AW_MCP_OAUTH_NOTION_TOKEN}"`, "gateway config should reference the token env var")
+}
@@ -0,0 +1,237 @@
+// This file provides package caching for MCP servers launched with npx or uvx.
+//
+// # MCP Package Cache
+//
+// npx and uvx download the server package and its dependencies every time the
+// server starts. A stdio MCP server that pins its package version can set
+// cache: true to have the agent job pre-install the package into a directory
+// that is saved in the Actions cache:
+//
+//	mcp-servers:
+//	  memory:
+//	    command: npx
+//	    args: ["-y", "@modelcontextprotocol/server-memory@2025.8.4"]
+//	    cache: true
+//
+// The cache key is derived from the pinned packages and their container images,
+// so changing a version installs it again. On a cache miss, a step runs the
+// install in the same container image the server runs in. The server container
+// then mounts the directory: npx runs the package installed under its prefix,
+// and uvx runs offline from the populated uv cache.
+
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/types"
+)
+
+var mcpPackageCacheLog = logger.New("workflow:mcp_package_cache")
+
+const (
+	// mcpPackageCacheDir is the directory on the runner that holds the pre-installed packages
+	mcpPackageCacheDir = "/tmp/gh-aw/mcp-package-cache"
+	// mcpPackageCacheMountPath is where the MCP server containers mount mcpPackageCacheDir
+	mcpPackageCacheMountPath = "/mcp-cache"
+)
+
+var (
+	// npmPinnedVersionPattern matches exact npm versions such as 1.2.3 or 1.2.3-beta.1
+	npmPinnedVersionPattern = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?$`)
+	// pythonPinnedVersionPattern matches exact Python versions such as 2025.4.7 or 1.0
+	pythonPinnedVersionPattern = regexp.MustCompile(`^\d+(\.\d+)*([a-z]+\d*)?$`)
+)
+
+// mcpCachedPackage is a pinned package of an MCP server with cache: true
+type mcpCachedPackage struct {
+	Server   string // MCP server name
+	Launcher string // npx or uvx
+	Image    string // Container image the server runs in
+	Spec     string // Pinned package specification, e.g. name@1.2.3 or name==1.2.3
+}
+
+// installDir returns the npm prefix the package is installed into, inside the container
+func (p mcpCachedPackage) installDir() string {
+	return mcpPackageCacheMountPath + "/npx/" + p.Server
+}
+
+// getMCPCachedPackage returns the pinned package of an MCP server that sets cache: true.
+// It returns nil when caching is not enabled and an error when the server cannot be cached.
+func getMCPCachedPackage(toolName string, toolConfig map[string]any) (*mcpCachedPackage, error) {
+	cacheValue, hasCache := toolConfig["cache"]
+	if !hasCache {
+		return nil, nil
+	}
+	enabled, ok := cacheValue.(bool)
+	if !ok {
+		return nil, fmt.Errorf("tool '%s' mcp configuration 'cache' must be a boolean, got %s", toolName, getTypeString(cacheValue))
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	command, _ := toolConfig["command"].(string)
+	container := getWellKnownContainer(command)
+	if container == nil {
+		return nil, fmt.Errorf("tool '%s' mcp configuration 'cache' is only supported for servers launched with 'npx' or 'uvx', got command '%s'", toolName, command)
+	}
+
+	var args []string
+	if rawArgs, ok := toolConfig["args"].([]any); ok {
+		for _, arg := range rawArgs {
+			if s, ok := arg.(string); ok {
+				args = append(args, s)
+			}
+		}
+	}
+
+	spec := findLauncherPackage(command, args)
+	if !isPinnedPackageSpec(command, spec) {
+		example := "@modelcontextprotocol/server-memory@2025.8.4"
+		if command == "uvx" {
+			example = "mcp-server-fetch==2025.4.7"
+		}
+		return nil, fmt.Errorf("tool '%s' mcp configuration 'cache' requires a pinned package version, got '%s'. Pin an exact version in args, for example '%s'", toolName, spec, example)
+	}
+
+	return &mcpCachedPackage{Server: toolName, Launcher: command, Image: container.Image, Spec: spec}, nil
+}
+
+// findLauncherPackage returns the package an npx or uvx command line runs: the value of
+// --package/-p (npx) or --from (uvx) when present, and the first non-flag argument otherwise
+func findLauncherPackage(launcher string, args []string) string {
+	packageFlags := []string{"--package", "-p"}
+	if launcher == "uvx" {
+		packageFlags = []string{"--from"}
+	}
+	for i, arg := range args {
+		for _, flag := range packageFlags {
+			if arg == flag && i+1 < len(args) {
+				return args[i+1]
+			}
+			if value, ok := strings.CutPrefix(arg, flag+"="); ok {
+				return value
+			}
+		}
+	}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+	}
+	return ""
+}
+
+// isPinnedPackageSpec reports whether a package specification names an exact version:
+// name@1.2.3 for npx, and name==1.2.3 or name@1.2.3 for uvx
+func isPinnedPackageSpec(launcher, spec string) bool {
+	// The specification is passed to the pre-install step as a single quoted shell word
+	if strings.ContainsAny(spec, "' \t\n") {
+		return false
+	}
+	if launcher == "uvx" {
+		if _, version, ok := strings.Cut(spec, "=="); ok {
+			return pythonPinnedVersionPattern.MatchString(version)
+		}
+	}
+	// Scoped npm packages start with @, so the version follows the last @
+	at := strings.LastIndex(spec, "@")
+	if at <= 0 {
+		return false
+	}
+	version := spec[at+1:]
+	if launcher == "uvx" {
+		return pythonPinnedVersionPattern.MatchString(version)
+	}
+	return npmPinnedVersionPattern.MatchString(version)
+}
+
+// collectMCPCachedPackages returns the pinned packages of every MCP server with cache: true,
+// sorted by server name
+func collectMCPCachedPackages(tools map[string]any) []mcpCachedPackage {
+	var packages []mcpCachedPackage
+	for toolName, toolValue := range tools {
+		toolConfig, ok := toolValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		// Configurations were validated by ValidateMCPConfigs
+		pkg, err := getMCPCachedPackage(toolName, toolConfig)
+		if err != nil || pkg == nil {
+			continue
+		}
+		packages = append(packages, *pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Server < packages[j].Server })
+	mcpPackageCacheLog.Printf("Collected %d cached MCP server packages", len(packages))
+	return packages
+}
+
+// applyMCPPackageCache makes a containerized npx or uvx server run from the pre-installed
+// packages: it mounts the cache directory and points the launcher at it
+func applyMCPPackageCache(pkg *mcpCachedPackage, config *types.BaseMCPServerConfig) {
+	config.Mounts = append(config.Mounts, mcpPackageCacheDir+":"+mcpPackageCacheMountPath+":rw")
+	switch pkg.Launcher {
+	case "npx":
+		// Run the package installed under the prefix, falling back to the cached tarballs.
+		// The entrypoint arguments repeat the npx command, which receives the flag.
+		args := config.EntrypointArgs
+		if len(args) > 0 && args[0] == pkg.Launcher {
+			args = args[1:]
+		}
+		config.EntrypointArgs = append([]string{pkg.Launcher, "--prefix", pkg.installDir()}, args...)
+		config.Env["NPM_CONFIG_CACHE"] = mcpPackageCacheMountPath + "/npm"
+		config.Env["NPM_CONFIG_PREFER_OFFLINE"] = "true"
+	case "uvx":
+		// The pre-install step resolved the same pinned package into the uv cache
+		config.Env["UV_CACHE_DIR"] = mcpPackageCacheMountPath + "/uv"
+		config.Env["UV_OFFLINE"] = "1"
+	}
+}
+
+// mcpPackageCacheKey returns the Actions cache key of the pre-installed packages
+func mcpPackageCacheKey(packages []mcpCachedPackage) string {
+	var lines []string
+	for _, pkg := range packages {
+		lines = append(lines, strings.Join([]string{pkg.Server, pkg.Launcher, pkg.Image, pkg.Spec}, " "))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return fmt.Sprintf("gh-aw-mcp-packages-%s-${{ runner.os }}-${{ runner.arch }}", hex.EncodeToString(sum[:])[:12])
+}
+
+// generateMCPPackageCacheSteps generates the steps that restore the pre-installed MCP server
+// packages and install them on a cache miss. The cache is saved when the job completes.
+func generateMCPPackageCacheSteps(yaml *strings.Builder, packages []mcpCachedPackage) {
+	if len(packages) == 0 {
+		return
+	}
+
+	yaml.WriteString("      - name: Restore MCP server packages\n")
+	yaml.WriteString("        id: mcp-package-cache\n")
+	yaml.WriteString("        uses: " + GetActionPin("actions/cache") + "\n")
+	yaml.WriteString("        with:\n")
+	yaml.WriteString("          path: " + mcpPackageCacheDir + "\n")
+	yaml.WriteString("          key: " + mcpPackageCacheKey(packages) + "\n")
+	yaml.WriteString("      - name: Pre-install MCP server packages\n")
+	yaml.WriteString("        if: steps.mcp-package-cache.outputs.cache-hit != 'true'\n")
+	yaml.WriteString("        run: |\n")
+	yaml.WriteString("          mkdir -p " + mcpPackageCacheDir + "\n")
+	for _, pkg := range packages {
+		volume := mcpPackageCacheDir + ":" + mcpPackageCacheMountPath
+		switch pkg.Launcher {
+		case "npx":
+			fmt.Fprintf(yaml, "          docker run --rm -v %s -e NPM_CONFIG_CACHE=%s/npm --entrypoint npm %s install --prefix %s --no-audit --no-fund '%s'\n",
+				volume, mcpPackageCacheMountPath, pkg.Image, pkg.installDir(), pkg.Spec)
+		case "uvx":
+			fmt.Fprintf(yaml, "          docker run --rm -v %s -e UV_CACHE_DIR=%s/uv --entrypoint uvx %s --from '%s' python --version\n",
+				volume, mcpPackageCacheMountPath, pkg.Image, pkg.Spec)
+		}
+	}
+}
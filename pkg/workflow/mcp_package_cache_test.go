@@ -0,0 +1,105 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMCPCachedPackage(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         map[string]any
+		expected       *mcpCachedPackage
+		errorSubstring string
+	}{
+		{
+			name:   "cache not set",
+			config: map[string]any{"command": "npx", "args": []any{"-y", "@modelcontextprotocol/server-memory"}},
+		},
+		{
+			name:     "pinned scoped npm package",
+			config:   map[string]any{"command": "npx", "args": []any{"-y", "@modelcontextprotocol/server-memory@2025.8.4"}, "cache": true},
+			expected: &mcpCachedPackage{Server: "memory", Launcher: "npx", Image: "node:lts-alpine", Spec: "@modelcontextprotocol/server-memory@2025.8.4"},
+		},
+		{
+			name:     "npm package flag",
+			config:   map[string]any{"command": "npx", "args": []any{"--package", "mcp-tools@1.2.3-beta.1", "mcp-memory"}, "cache": true},
+			expected: &mcpCachedPackage{Server: "memory", Launcher: "npx", Image: "node:lts-alpine", Spec: "mcp-tools@1.2.3-beta.1"},
+		},
+		{
+			name:     "pinned python package with from",
+			config:   map[string]any{"command": "uvx", "args": []any{"--from", "mcp-server-fetch==2025.4.7", "mcp-server-fetch"}, "cache": true},
+			expected: &mcpCachedPackage{Server: "memory", Launcher: "uvx", Image: "python:alpine", Spec: "mcp-server-fetch==2025.4.7"},
+		},
+		{
+			name:           "npm tag is not pinned",
+			config:         map[string]any{"command": "npx", "args": []any{"-y", "@modelcontextprotocol/server-memory@latest"}, "cache": true},
+			errorSubstring: "requires a pinned package version, got '@modelcontextprotocol/server-memory@latest'",
+		},
+		{
+			name:           "python range is not pinned",
+			config:         map[string]any{"command": "uvx", "args": []any{"mcp-server-fetch>=2025"}, "cache": true},
+			errorSubstring: "example 'mcp-server-fetch==2025.4.7'",
+		},
+		{
+			name:           "other commands cannot be cached",
+			config:         map[string]any{"command": "node", "args": []any{"server.js"}, "cache": true},
+			errorSubstring: "only supported for servers launched with 'npx' or 'uvx'",
+		},
+		{
+			name:           "cache must be a boolean",
+			config:         map[string]any{"command": "npx", "args": []any{"pkg@1.0.0"}, "cache": "yes"},
+			errorSubstring: "'cache' must be a boolean, got string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg, err := getMCPCachedPackage("memory", tt.config)
+			if tt.errorSubstring != "" {
+				require.Error(t, err, "configuration should be rejected")
+				assert.Contains(t, err.Error(), tt.errorSubstring, "error message")
+				return
+			}
+			require.NoError(t, err, "configuration should be accepted")
+			assert.Equal(t, tt.expected, pkg, "cached package")
+		})
+	}
+}
+
+func TestCompileWorkflowWithMCPPackageCache(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-package-cache-test")
+	workflowFile := filepath.Join(tmpDir, "cached.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+mcp-servers:
+  memory:
+    command: npx
+    args: ["-y", "@modelcontextprotocol/server-memory@2025.8.4"]
+    cache: true
+---
+
+Remember things.
+`), 0644), "should write workflow")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "workflow should compile")
+	lockBytes, err := os.ReadFile(filepath.Join(tmpDir, "cached.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	lockContent := string(lockBytes)
+
+	assert.Contains(t, lockContent, "- name: Restore MCP server packages", "cache restore step")
+	assert.Contains(t, lockContent, "if: steps.mcp-package-cache.outputs.cache-hit != 'true'", "install only on cache miss")
+	assert.Contains(t, lockContent, "--entrypoint npm node:lts-alpine install --prefix /mcp-cache/npx/memory --no-audit --no-fund '@modelcontextprotocol/server-memory@2025.8.4'", "pre-install command")
+	assert.Contains(t, lockContent, `"/tmp/gh-aw/mcp-package-cache:/mcp-cache:rw"`, "server should mount the cache")
+	assert.Contains(t, lockContent, `"NPM_CONFIG_PREFER_OFFLINE": "true"`, "server should prefer the cached packages")
+}
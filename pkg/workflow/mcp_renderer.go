@@ -189,6 +189,7 @@ func (r *MCPConfigRendererUnified) RenderGitHubMCP(yaml *strings.Builder, github
 			AllowedTools:       getGitHubAllowedTools(githubTool),
 			EffectiveToken:     "", // Token passed via env
 			GuardPolicies:      getGitHubGuardPolicies(githubTool),
+			GitHubHost:         getGitHubHostURL(workflowData),
 		})
 	}
 
@@ -552,6 +553,10 @@ func (r *MCPConfigRendererUnified) renderGitHubTOML(yaml *strings.Builder, githu
 
 		envVars["GITHUB_TOOLSETS"] = toolsets
 
+		if githubHost := getGitHubHostURL(workflowData); githubHost != "" {
+			envVars["GITHUB_HOST"] = githubHost
+		}
+
 		// Write environment variables in sorted order for deterministic output
 		envKeys := make([]string, 0, len(envVars))
 		for key := range envVars {
@@ -667,6 +672,8 @@ type GitHubMCPDockerOptions struct {
 	Mounts []string
 	// GuardPolicies specifies access control policies for the MCP gateway (e.g., allow-only repos/integrity)
 	GuardPolicies map[string]any
+	// GitHubHost is the GitHub Enterprise URL the server connects to (empty for github.com)
+	GitHubHost string
 }
 
 // RenderGitHubMCPDockerConfig renders the GitHub MCP server configuration for Docker (local mode).
@@ -746,6 +753,11 @@ func RenderGitHubMCPDockerConfig(yaml *strings.Builder, options GitHubMCPDockerO
 	// Toolsets (always configured, defaults to "default")
 	envVars["GITHUB_TOOLSETS"] = options.Toolsets
 
+	// GitHub Enterprise host (github-host:)
+	if options.GitHubHost != "" {
+		envVars["GITHUB_HOST"] = options.GitHubHost
+	}
+
 	// Write environment variables in sorted order for deterministic output
 	envKeys := make([]string, 0, len(envVars))
 	for key := range envVars {
@@ -135,8 +135,14 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 
 	// Collect all Docker images that will be used and generate download step
 	dockerImages := collectDockerImages(tools, workflowData, c.actionMode)
+	if workflowData.WarmCache {
+		generateRestoreWarmCacheImagesSteps(yaml, dockerImages)
+	}
 	generateDownloadDockerImagesStep(yaml, dockerImages)
 
+	// Pre-install the pinned packages of npx and uvx servers with cache: true
+	generateMCPPackageCacheSteps(yaml, collectMCPCachedPackages(tools))
+
 	// If no MCP tools, no configuration needed
 	if len(mcpTools) == 0 {
 		mcpSetupGeneratorLog.Print("No MCP tools configured, skipping MCP setup")
@@ -214,13 +220,15 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 			// Fall back to empty array on error
 			filteredToolsJSON = "[]"
 		}
-		toolsDelimiter := GenerateHeredocDelimiter("SAFE_OUTPUTS_TOOLS")
-		yaml.WriteString("          cat > /opt/gh-aw/safeoutputs/tools.json << '" + toolsDelimiter + "'\n")
-		// Write each line of the indented JSON with proper YAML indentation
-		for line := range strings.SplitSeq(filteredToolsJSON, "\n") {
-			yaml.WriteString("          " + line + "\n")
+		if !c.writeSplitScriptFile(yaml, "/opt/gh-aw/safeoutputs/tools.json", filteredToolsJSON) {
+			toolsDelimiter := GenerateHeredocDelimiter("SAFE_OUTPUTS_TOOLS")
+			yaml.WriteString("          cat > /opt/gh-aw/safeoutputs/tools.json << '" + toolsDelimiter + "'\n")
+			// Write each line of the indented JSON with proper YAML indentation
+			for line := range strings.SplitSeq(filteredToolsJSON, "\n") {
+				yaml.WriteString("          " + line + "\n")
+			}
+			yaml.WriteString("          " + toolsDelimiter + "\n")
 		}
-		yaml.WriteString("          " + toolsDelimiter + "\n")
 
 		// Generate and write the validation configuration from Go source of truth
 		// Only include validation for activated safe output types to keep validation.json small
@@ -233,20 +241,22 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 				}
 			}
 		}
-		validationConfigJSON, err := GetValidationConfigJSON(enabledTypes)
+		validationConfigJSON, err := GetValidationConfigJSONWithFields(enabledTypes, safeOutputFieldValidations(workflowData.SafeOutputs))
 		if err != nil {
 			// Log error prominently - validation config is critical for safe output processing
 			// The error will be caught at compile time if this ever fails
 			mcpSetupGeneratorLog.Printf("CRITICAL: Error generating validation config JSON: %v - validation will not work correctly", err)
 			validationConfigJSON = "{}"
 		}
-		validationDelimiter := GenerateHeredocDelimiter("SAFE_OUTPUTS_VALIDATION")
-		yaml.WriteString("          cat > /opt/gh-aw/safeoutputs/validation.json << '" + validationDelimiter + "'\n")
-		// Write each line of the indented JSON with proper YAML indentation
-		for line := range strings.SplitSeq(validationConfigJSON, "\n") {
-			yaml.WriteString("          " + line + "\n")
+		if !c.writeSplitScriptFile(yaml, "/opt/gh-aw/safeoutputs/validation.json", validationConfigJSON) {
+			validationDelimiter := GenerateHeredocDelimiter("SAFE_OUTPUTS_VALIDATION")
+			yaml.WriteString("          cat > /opt/gh-aw/safeoutputs/validation.json << '" + validationDelimiter + "'\n")
+			// Write each line of the indented JSON with proper YAML indentation
+			for line := range strings.SplitSeq(validationConfigJSON, "\n") {
+				yaml.WriteString("          " + line + "\n")
+			}
+			yaml.WriteString("          " + validationDelimiter + "\n")
 		}
-		yaml.WriteString("          " + validationDelimiter + "\n")
 
 		// Note: The MCP server entry point (mcp-server.cjs) is now copied by actions/setup
 		// from safe-outputs-mcp-server.cjs - no need to generate it here
@@ -309,21 +319,25 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 
 		// Generate the tools.json configuration file
 		toolsJSON := generateSafeInputsToolsConfig(workflowData.SafeInputs)
-		toolsDelimiter := GenerateHeredocDelimiter("SAFE_INPUTS_TOOLS")
-		yaml.WriteString("          cat > /opt/gh-aw/safe-inputs/tools.json << '" + toolsDelimiter + "'\n")
-		for line := range strings.SplitSeq(toolsJSON, "\n") {
-			yaml.WriteString("          " + line + "\n")
+		if !c.writeSplitScriptFile(yaml, "/opt/gh-aw/safe-inputs/tools.json", toolsJSON) {
+			toolsDelimiter := GenerateHeredocDelimiter("SAFE_INPUTS_TOOLS")
+			yaml.WriteString("          cat > /opt/gh-aw/safe-inputs/tools.json << '" + toolsDelimiter + "'\n")
+			for line := range strings.SplitSeq(toolsJSON, "\n") {
+				yaml.WriteString("          " + line + "\n")
+			}
+			yaml.WriteString("          " + toolsDelimiter + "\n")
 		}
-		yaml.WriteString("          " + toolsDelimiter + "\n")
 
 		// Generate the MCP server entry point
 		safeInputsMCPServer := generateSafeInputsMCPServerScript(workflowData.SafeInputs)
-		serverDelimiter := GenerateHeredocDelimiter("SAFE_INPUTS_SERVER")
-		yaml.WriteString("          cat > /opt/gh-aw/safe-inputs/mcp-server.cjs << '" + serverDelimiter + "'\n")
-		for _, line := range FormatJavaScriptForYAML(safeInputsMCPServer) {
-			yaml.WriteString(line)
+		if !c.writeSplitScriptFile(yaml, "/opt/gh-aw/safe-inputs/mcp-server.cjs", safeInputsMCPServer) {
+			serverDelimiter := GenerateHeredocDelimiter("SAFE_INPUTS_SERVER")
+			yaml.WriteString("          cat > /opt/gh-aw/safe-inputs/mcp-server.cjs << '" + serverDelimiter + "'\n")
+			for _, line := range FormatJavaScriptForYAML(safeInputsMCPServer) {
+				yaml.WriteString(line)
+			}
+			yaml.WriteString("          " + serverDelimiter + "\n")
 		}
-		yaml.WriteString("          " + serverDelimiter + "\n")
 		yaml.WriteString("          chmod +x /opt/gh-aw/safe-inputs/mcp-server.cjs\n")
 		yaml.WriteString("          \n")
 
@@ -340,41 +354,49 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 			if toolConfig.Script != "" {
 				// JavaScript tool
 				toolScript := generateSafeInputJavaScriptToolScript(toolConfig)
-				jsDelimiter := GenerateHeredocDelimiter("SAFE_INPUTS_JS_" + strings.ToUpper(toolName))
-				fmt.Fprintf(yaml, "          cat > /opt/gh-aw/safe-inputs/%s.cjs << '%s'\n", toolName, jsDelimiter)
-				for _, line := range FormatJavaScriptForYAML(toolScript) {
-					yaml.WriteString(line)
+				if !c.writeSplitScriptFile(yaml, "/opt/gh-aw/safe-inputs/"+toolName+".cjs", toolScript) {
+					jsDelimiter := GenerateContentHeredocDelimiter("SAFE_INPUTS_JS_"+strings.ToUpper(toolName), toolScript)
+					fmt.Fprintf(yaml, "          cat > /opt/gh-aw/safe-inputs/%s.cjs << '%s'\n", toolName, jsDelimiter)
+					for _, line := range FormatJavaScriptForYAML(toolScript) {
+						yaml.WriteString(line)
+					}
+					fmt.Fprintf(yaml, "          %s\n", jsDelimiter)
 				}
-				fmt.Fprintf(yaml, "          %s\n", jsDelimiter)
 			} else if toolConfig.Run != "" {
 				// Shell script tool
 				toolScript := generateSafeInputShellToolScript(toolConfig)
-				shDelimiter := GenerateHeredocDelimiter("SAFE_INPUTS_SH_" + strings.ToUpper(toolName))
-				fmt.Fprintf(yaml, "          cat > /opt/gh-aw/safe-inputs/%s.sh << '%s'\n", toolName, shDelimiter)
-				for line := range strings.SplitSeq(toolScript, "\n") {
-					yaml.WriteString("          " + line + "\n")
+				if !c.writeSplitScriptFile(yaml, "/opt/gh-aw/safe-inputs/"+toolName+".sh", toolScript) {
+					shDelimiter := GenerateContentHeredocDelimiter("SAFE_INPUTS_SH_"+strings.ToUpper(toolName), toolScript)
+					fmt.Fprintf(yaml, "          cat > /opt/gh-aw/safe-inputs/%s.sh << '%s'\n", toolName, shDelimiter)
+					for line := range strings.SplitSeq(toolScript, "\n") {
+						yaml.WriteString("          " + line + "\n")
+					}
+					fmt.Fprintf(yaml, "          %s\n", shDelimiter)
 				}
-				fmt.Fprintf(yaml, "          %s\n", shDelimiter)
 				fmt.Fprintf(yaml, "          chmod +x /opt/gh-aw/safe-inputs/%s.sh\n", toolName)
 			} else if toolConfig.Py != "" {
 				// Python script tool
 				toolScript := generateSafeInputPythonToolScript(toolConfig)
-				pyDelimiter := GenerateHeredocDelimiter("SAFE_INPUTS_PY_" + strings.ToUpper(toolName))
-				fmt.Fprintf(yaml, "          cat > /opt/gh-aw/safe-inputs/%s.py << '%s'\n", toolName, pyDelimiter)
-				for line := range strings.SplitSeq(toolScript, "\n") {
-					yaml.WriteString("          " + line + "\n")
+				if !c.writeSplitScriptFile(yaml, "/opt/gh-aw/safe-inputs/"+toolName+".py", toolScript) {
+					pyDelimiter := GenerateContentHeredocDelimiter("SAFE_INPUTS_PY_"+strings.ToUpper(toolName), toolScript)
+					fmt.Fprintf(yaml, "          cat > /opt/gh-aw/safe-inputs/%s.py << '%s'\n", toolName, pyDelimiter)
+					for line := range strings.SplitSeq(toolScript, "\n") {
+						yaml.WriteString("          " + line + "\n")
+					}
+					fmt.Fprintf(yaml, "          %s\n", pyDelimiter)
 				}
-				fmt.Fprintf(yaml, "          %s\n", pyDelimiter)
 				fmt.Fprintf(yaml, "          chmod +x /opt/gh-aw/safe-inputs/%s.py\n", toolName)
 			} else if toolConfig.Go != "" {
 				// Go script tool
 				toolScript := generateSafeInputGoToolScript(toolConfig)
-				goDelimiter := GenerateHeredocDelimiter("SAFE_INPUTS_GO_" + strings.ToUpper(toolName))
-				fmt.Fprintf(yaml, "          cat > /opt/gh-aw/safe-inputs/%s.go << '%s'\n", toolName, goDelimiter)
-				for line := range strings.SplitSeq(toolScript, "\n") {
-					yaml.WriteString("          " + line + "\n")
+				if !c.writeSplitScriptFile(yaml, "/opt/gh-aw/safe-inputs/"+toolName+".go", toolScript) {
+					goDelimiter := GenerateContentHeredocDelimiter("SAFE_INPUTS_GO_"+strings.ToUpper(toolName), toolScript)
+					fmt.Fprintf(yaml, "          cat > /opt/gh-aw/safe-inputs/%s.go << '%s'\n", toolName, goDelimiter)
+					for line := range strings.SplitSeq(toolScript, "\n") {
+						yaml.WriteString("          " + line + "\n")
+					}
+					fmt.Fprintf(yaml, "          %s\n", goDelimiter)
 				}
-				fmt.Fprintf(yaml, "          %s\n", goDelimiter)
 			}
 		}
 		yaml.WriteString("          \n")
@@ -434,6 +456,9 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 		yaml.WriteString("          \n")
 	}
 
+	// Acquire OAuth access tokens for HTTP MCP servers before the gateway starts
+	generateMCPOAuthTokenSteps(yaml, tools)
+
 	// The MCP gateway is always enabled, even when agent sandbox is disabled
 	// Use the engine's RenderMCPConfig method
 	yaml.WriteString("      - name: Start MCP Gateway\n")
@@ -0,0 +1,330 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var memoryLog = logger.New("workflow:memory")
+
+// Storage backends for the runtime memory key-value store
+const (
+	memoryBackendCache  = "cache"
+	memoryBackendBranch = "branch"
+	memoryBackendGist   = "gist"
+)
+
+// validMemoryBackends lists the supported values for memory.backend
+var validMemoryBackends = []string{memoryBackendCache, memoryBackendBranch, memoryBackendGist}
+
+const (
+	// memoryStoreID is the cache-memory/repo-memory ID used for the key-value store
+	memoryStoreID = "kv"
+	// memoryStoreFile is the file holding the key-value store inside the memory directory
+	memoryStoreFile = "memory.json"
+	// memoryMCPServerName is the tool name of the MCP server exposing the key-value store
+	memoryMCPServerName = "memory"
+	// memoryGistDir is the directory holding the key-value store for the gist backend
+	memoryGistDir = "/tmp/gh-aw/memory-kv"
+	// memoryContainerDir is the mount point of the memory directory inside the MCP server container
+	memoryContainerDir = "/tmp/gh-aw/memory"
+
+	defaultMemoryMaxKeys      = 100
+	defaultMemoryMaxValueSize = 1024
+	maxMemoryMaxKeys          = 1000
+	maxMemoryMaxValueSize     = 65536
+)
+
+// memoryMCPTools lists the tools provided by memory_mcp_server.cjs
+var memoryMCPTools = []string{"memory_get", "memory_set", "memory_delete", "memory_list"}
+
+// MemoryConfig represents the runtime memory key-value store (memory:)
+//
+// Example:
+//
+//	memory:
+//	  backend: gist
+//	  gist-id: 0123456789abcdef
+//	  github-token: ${{ secrets.GIST_TOKEN }}
+//	  max-keys: 200
+type MemoryConfig struct {
+	Backend      string `json:"backend"`                // Storage backend: cache (default), branch or gist
+	GistID       string `json:"gist-id,omitempty"`      // Gist holding the store (gist backend only)
+	GitHubToken  string `json:"github-token,omitempty"` // Token with gist scope (gist backend only)
+	Description  string `json:"description,omitempty"`  // Optional description of what the agent should remember
+	MaxKeys      int    `json:"max-keys"`               // Maximum number of keys in the store
+	MaxValueSize int    `json:"max-value-size"`         // Maximum size of a single value in bytes
+}
+
+// extractMemoryConfig extracts the runtime memory configuration from frontmatter
+func extractMemoryConfig(frontmatter map[string]any) (*MemoryConfig, error) {
+	memoryValue, exists := frontmatter["memory"]
+	if !exists || memoryValue == nil {
+		return nil, nil
+	}
+
+	config := &MemoryConfig{
+		Backend:      memoryBackendCache,
+		MaxKeys:      defaultMemoryMaxKeys,
+		MaxValueSize: defaultMemoryMaxValueSize,
+	}
+
+	if enabled, ok := memoryValue.(bool); ok {
+		if !enabled {
+			return nil, nil
+		}
+		return config, nil
+	}
+
+	memoryMap, ok := memoryValue.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("memory must be a boolean or an object, got %T. Example:\nmemory:\n  backend: cache\n  max-keys: 100", memoryValue)
+	}
+
+	if backend, exists := memoryMap["backend"]; exists {
+		backendStr, ok := backend.(string)
+		if !ok || !slices.Contains(validMemoryBackends, backendStr) {
+			return nil, fmt.Errorf("memory.backend must be one of %s, got %v", strings.Join(validMemoryBackends, ", "), backend)
+		}
+		config.Backend = backendStr
+	}
+
+	if gistID, ok := memoryMap["gist-id"].(string); ok {
+		config.GistID = gistID
+	}
+	if token, ok := memoryMap["github-token"].(string); ok {
+		config.GitHubToken = token
+	}
+	if description, ok := memoryMap["description"].(string); ok {
+		config.Description = description
+	}
+
+	if maxKeys, exists := memoryMap["max-keys"]; exists {
+		value, ok := parseIntValue(maxKeys)
+		if !ok {
+			return nil, fmt.Errorf("memory.max-keys must be an integer, got %v", maxKeys)
+		}
+		if err := validateIntRange(value, 1, maxMemoryMaxKeys, "memory.max-keys"); err != nil {
+			return nil, err
+		}
+		config.MaxKeys = value
+	}
+
+	if maxValueSize, exists := memoryMap["max-value-size"]; exists {
+		value, ok := parseIntValue(maxValueSize)
+		if !ok {
+			return nil, fmt.Errorf("memory.max-value-size must be an integer, got %v", maxValueSize)
+		}
+		if err := validateIntRange(value, 1, maxMemoryMaxValueSize, "memory.max-value-size"); err != nil {
+			return nil, err
+		}
+		config.MaxValueSize = value
+	}
+
+	if config.Backend == memoryBackendGist {
+		// GITHUB_TOKEN cannot read or write gists, so a token with gist scope is required
+		if config.GistID == "" || config.GitHubToken == "" {
+			return nil, fmt.Errorf("memory.backend: gist requires gist-id and github-token (a token with the gist scope). Example:\nmemory:\n  backend: gist\n  gist-id: 0123456789abcdef\n  github-token: ${{ secrets.GIST_TOKEN }}")
+		}
+	} else if config.GistID != "" {
+		return nil, fmt.Errorf("memory.gist-id is only supported with backend: gist, got backend: %s", config.Backend)
+	}
+
+	memoryLog.Printf("Extracted memory config: backend=%s, max_keys=%d, max_value_size=%d", config.Backend, config.MaxKeys, config.MaxValueSize)
+	return config, nil
+}
+
+// memoryStoreDir returns the host directory holding the key-value store for the configured backend
+func memoryStoreDir(config *MemoryConfig) string {
+	switch config.Backend {
+	case memoryBackendBranch:
+		return "/tmp/gh-aw/repo-memory/" + memoryStoreID
+	case memoryBackendGist:
+		return memoryGistDir
+	default:
+		return "/tmp/gh-aw/cache-memory-" + memoryStoreID
+	}
+}
+
+// addMemoryMCPServer adds the memory MCP server to the tools configuration.
+// The server runs memory_mcp_server.cjs from the setup action in a Node.js container
+// with only the key-value store directory mounted writable.
+func addMemoryMCPServer(tools map[string]any, config *MemoryConfig) (map[string]any, error) {
+	if config == nil {
+		return tools, nil
+	}
+	if _, exists := tools[memoryMCPServerName]; exists {
+		return nil, fmt.Errorf("memory: cannot be combined with a tool or MCP server named '%s'", memoryMCPServerName)
+	}
+
+	memoryLog.Printf("Adding memory MCP server: backend=%s", config.Backend)
+
+	updatedTools := make(map[string]any)
+	maps.Copy(updatedTools, tools)
+
+	allowed := make([]any, 0, len(memoryMCPTools))
+	for _, tool := range memoryMCPTools {
+		allowed = append(allowed, tool)
+	}
+
+	updatedTools[memoryMCPServerName] = map[string]any{
+		"container":      constants.DefaultNodeAlpineLTSImage,
+		"entrypoint":     "node",
+		"entrypointArgs": []any{SetupActionDestination + "/memory_mcp_server.cjs", memoryContainerDir},
+		"mounts": []any{
+			SetupActionDestination + ":" + SetupActionDestination + ":ro",
+			memoryStoreDir(config) + ":" + memoryContainerDir + ":rw",
+		},
+		"env": map[string]any{
+			"GH_AW_MEMORY_MAX_KEYS":       strconv.Itoa(config.MaxKeys),
+			"GH_AW_MEMORY_MAX_VALUE_SIZE": strconv.Itoa(config.MaxValueSize),
+		},
+		"allowed": allowed,
+	}
+	return updatedTools, nil
+}
+
+// applyMemoryBackend registers the key-value store with the cache-memory or repo-memory
+// configuration so the existing restore, validation and save steps persist it between runs.
+// The gist backend has its own steps (see generateMemoryGistSteps).
+func applyMemoryBackend(data *WorkflowData) error {
+	config := data.MemoryConfig
+	if config == nil {
+		return nil
+	}
+
+	description := "Key-value store of the memory tools. Use memory_get, memory_set, memory_delete and memory_list instead of editing it directly."
+	if config.Description != "" {
+		description = config.Description + ". " + description
+	}
+
+	switch config.Backend {
+	case memoryBackendCache:
+		if data.CacheMemoryConfig == nil {
+			data.CacheMemoryConfig = &CacheMemoryConfig{}
+		}
+		data.CacheMemoryConfig.Caches = append(data.CacheMemoryConfig.Caches, CacheMemoryEntry{
+			ID:                memoryStoreID,
+			Key:               generateDefaultCacheKey(memoryStoreID),
+			Description:       description,
+			Scope:             "workflow",
+			AllowedExtensions: []string{".json"},
+		})
+		return validateNoDuplicateCacheIDs(data.CacheMemoryConfig.Caches)
+	case memoryBackendBranch:
+		if data.RepoMemoryConfig == nil {
+			data.RepoMemoryConfig = &RepoMemoryConfig{BranchPrefix: "memory"}
+		}
+		data.RepoMemoryConfig.Memories = append(data.RepoMemoryConfig.Memories, RepoMemoryEntry{
+			ID:                memoryStoreID,
+			BranchName:        generateDefaultBranchName(data.WorkflowID+"-"+memoryStoreID, data.RepoMemoryConfig.BranchPrefix),
+			FileGlob:          []string{memoryStoreFile},
+			MaxFileSize:       maxRepoMemoryPatchSize,
+			MaxFileCount:      1,
+			MaxPatchSize:      maxRepoMemoryPatchSize,
+			Description:       description,
+			CreateOrphan:      true,
+			AllowedExtensions: []string{".json"},
+		})
+		return validateNoDuplicateMemoryIDs(data.RepoMemoryConfig.Memories)
+	}
+	return nil
+}
+
+// generateMemoryGistSteps generates the step restoring the key-value store from the gist
+func generateMemoryGistSteps(builder *strings.Builder, data *WorkflowData) {
+	if data.MemoryConfig == nil || data.MemoryConfig.Backend != memoryBackendGist {
+		return
+	}
+
+	memoryLog.Print("Generating memory gist restore step")
+
+	builder.WriteString("      - name: Restore memory from gist\n")
+	builder.WriteString("        env:\n")
+	fmt.Fprintf(builder, "          GH_TOKEN: %s\n", data.MemoryConfig.GitHubToken)
+	fmt.Fprintf(builder, "          GIST_ID: %s\n", data.MemoryConfig.GistID)
+	builder.WriteString("        run: |\n")
+	fmt.Fprintf(builder, "          mkdir -p %s\n", memoryGistDir)
+	fmt.Fprintf(builder, "          gh api \"gists/$GIST_ID\" --jq '.files[\"%s\"].content // \"{}\"' > %s/%s\n", memoryStoreFile, memoryGistDir, memoryStoreFile)
+}
+
+// generateMemoryGistArtifactUpload uploads the key-value store for the update_memory_gist job
+func generateMemoryGistArtifactUpload(builder *strings.Builder, data *WorkflowData) {
+	if data.MemoryConfig == nil || data.MemoryConfig.Backend != memoryBackendGist {
+		return
+	}
+
+	builder.WriteString("      - name: Upload memory data as artifact\n")
+	fmt.Fprintf(builder, "        uses: %s\n", GetActionPin("actions/upload-artifact"))
+	builder.WriteString("        if: always()\n")
+	builder.WriteString("        with:\n")
+	builder.WriteString("          name: memory-kv\n")
+	fmt.Fprintf(builder, "          path: %s/%s\n", memoryGistDir, memoryStoreFile)
+	builder.WriteString("          if-no-files-found: ignore\n")
+}
+
+// buildUpdateMemoryGistJob builds a job that writes the key-value store back to the gist.
+// Like push_repo_memory, it runs after the agent job and only after detection passed when
+// threat detection is enabled.
+func (c *Compiler) buildUpdateMemoryGistJob(data *WorkflowData, threatDetectionEnabled bool) (*Job, error) {
+	if data.MemoryConfig == nil || data.MemoryConfig.Backend != memoryBackendGist {
+		return nil, nil
+	}
+
+	memoryLog.Printf("Building update_memory_gist job (threatDetectionEnabled=%v)", threatDetectionEnabled)
+
+	var steps []string
+
+	var downloadStep strings.Builder
+	downloadStep.WriteString("      - name: Download memory artifact\n")
+	downloadStep.WriteString("        id: download_memory\n")
+	fmt.Fprintf(&downloadStep, "        uses: %s\n", GetActionPin("actions/download-artifact"))
+	downloadStep.WriteString("        continue-on-error: true\n")
+	downloadStep.WriteString("        with:\n")
+	downloadStep.WriteString("          name: memory-kv\n")
+	fmt.Fprintf(&downloadStep, "          path: %s\n", memoryGistDir)
+	steps = append(steps, downloadStep.String())
+
+	var saveStep strings.Builder
+	saveStep.WriteString("      - name: Save memory to gist\n")
+	saveStep.WriteString("        if: steps.download_memory.outcome == 'success'\n")
+	saveStep.WriteString("        env:\n")
+	fmt.Fprintf(&saveStep, "          GH_TOKEN: %s\n", data.MemoryConfig.GitHubToken)
+	fmt.Fprintf(&saveStep, "          GIST_ID: %s\n", data.MemoryConfig.GistID)
+	saveStep.WriteString("        run: |\n")
+	fmt.Fprintf(&saveStep, "          if [ ! -f %s/%s ]; then\n", memoryGistDir, memoryStoreFile)
+	saveStep.WriteString("            echo \"No memory to save\"\n")
+	saveStep.WriteString("            exit 0\n")
+	saveStep.WriteString("          fi\n")
+	fmt.Fprintf(&saveStep, "          jq empty %s/%s\n", memoryGistDir, memoryStoreFile)
+	fmt.Fprintf(&saveStep, "          jq -n --rawfile content %s/%s '{files: {\"%s\": {content: $content}}}' \\\n", memoryGistDir, memoryStoreFile, memoryStoreFile)
+	saveStep.WriteString("            | gh api --method PATCH \"gists/$GIST_ID\" --input - > /dev/null\n")
+	steps = append(steps, saveStep.String())
+
+	jobCondition := "always()"
+	if threatDetectionEnabled {
+		jobCondition = fmt.Sprintf("always() && needs.%s.outputs.detection_success == 'true'", constants.AgentJobName)
+	}
+
+	// Serialize gist updates so concurrent runs don't overwrite each other mid-update
+	concurrency := c.indentYAMLLines("concurrency:\n  group: \"update-memory-gist-"+data.MemoryConfig.GistID+"\"\n  cancel-in-progress: false", "    ")
+
+	job := &Job{
+		Name:        "update_memory_gist",
+		DisplayName: "", // No display name - job ID is sufficient
+		RunsOn:      c.formatSelfHostedRunsOn(data, "ubuntu-latest"),
+		If:          jobCondition,
+		Permissions: NewPermissionsEmpty().RenderToYAML(),
+		Concurrency: concurrency,
+		Needs:       []string{string(constants.AgentJobName)},
+		Steps:       steps,
+	}
+
+	return job, nil
+}
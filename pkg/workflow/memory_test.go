@@ -0,0 +1,158 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractMemoryConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *MemoryConfig
+		wantErr     string
+	}{
+		{
+			name:        "no memory",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "disabled",
+			frontmatter: map[string]any{"memory": false},
+		},
+		{
+			name:        "enabled with defaults",
+			frontmatter: map[string]any{"memory": true},
+			expected:    &MemoryConfig{Backend: "cache", MaxKeys: 100, MaxValueSize: 1024},
+		},
+		{
+			name:        "branch backend with limits",
+			frontmatter: map[string]any{"memory": map[string]any{"backend": "branch", "max-keys": 200, "max-value-size": 4096, "description": "Seen issues"}},
+			expected:    &MemoryConfig{Backend: "branch", Description: "Seen issues", MaxKeys: 200, MaxValueSize: 4096},
+		},
+		{
+			name:        "gist backend",
+			frontmatter: map[string]any{"memory": map[string]any{"backend": "gist", "gist-id": "abc123", "github-token": "${{ secrets.GIST_TOKEN }}"}},
+			expected:    &MemoryConfig{Backend: "gist", GistID: "abc123", GitHubToken: "${{ secrets.GIST_TOKEN }}", MaxKeys: 100, MaxValueSize: 1024},
+		},
+		{
+			name:        "invalid type",
+			frontmatter: map[string]any{"memory": "cache"},
+			wantErr:     "memory must be a boolean or an object",
+		},
+		{
+			name:        "unknown backend",
+			frontmatter: map[string]any{"memory": map[string]any{"backend": "s3"}},
+			wantErr:     "memory.backend must be one of cache, branch, gist",
+		},
+		{
+			name:        "max-keys out of range",
+			frontmatter: map[string]any{"memory": map[string]any{"max-keys": 5000}},
+			wantErr:     "memory.max-keys must be between 1 and 1000",
+		},
+		{
+			name:        "gist backend requires token",
+			frontmatter: map[string]any{"memory": map[string]any{"backend": "gist", "gist-id": "abc123"}},
+			wantErr:     "requires gist-id and github-token",
+		},
+		{
+			name:        "gist-id requires gist backend",
+			frontmatter: map[string]any{"memory": map[string]any{"gist-id": "abc123"}},
+			wantErr:     "memory.gist-id is only supported with backend: gist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := extractMemoryConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "should parse memory config")
+			assert.Equal(t, tt.expected, config, "memory config")
+		})
+	}
+}
+
+func TestAddMemoryMCPServerConflict(t *testing.T) {
+	tools := map[string]any{"memory": map[string]any{"container": "example/memory"}}
+	_, err := addMemoryMCPServer(tools, &MemoryConfig{Backend: "cache"})
+	require.Error(t, err, "should reject an existing memory tool")
+	assert.Contains(t, err.Error(), "cannot be combined with a tool or MCP server named 'memory'", "error message")
+}
+
+func TestMemoryCompilation(t *testing.T) {
+	tests := []struct {
+		name        string
+		memory      string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:   "cache backend",
+			memory: "memory: true\n",
+			contains: []string{
+				"path: /tmp/gh-aw/cache-memory-kv",
+				"/tmp/gh-aw/cache-memory-kv:/tmp/gh-aw/memory:rw",
+				"/opt/gh-aw/actions/memory_mcp_server.cjs",
+				"GH_AW_MEMORY_MAX_KEYS",
+			},
+			notContains: []string{"update_memory_gist:", "push_repo_memory:"},
+		},
+		{
+			name:   "branch backend",
+			memory: "memory:\n  backend: branch\n",
+			contains: []string{
+				"push_repo_memory:",
+				"BRANCH_NAME: memory/memory-agent-kv",
+				"/tmp/gh-aw/repo-memory/kv:/tmp/gh-aw/memory:rw",
+			},
+			notContains: []string{"cache-memory-kv"},
+		},
+		{
+			name:   "gist backend",
+			memory: "memory:\n  backend: gist\n  gist-id: abc123\n  github-token: ${{ secrets.GIST_TOKEN }}\n",
+			contains: []string{
+				"- name: Restore memory from gist",
+				"GIST_ID: abc123",
+				"name: memory-kv",
+				"update_memory_gist:",
+				"gh api --method PATCH \"gists/$GIST_ID\"",
+				"/tmp/gh-aw/memory-kv:/tmp/gh-aw/memory:rw",
+			},
+			notContains: []string{"cache-memory-kv", "push_repo_memory:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "memory-test")
+			workflowPath := filepath.Join(tmpDir, "memory-agent.md")
+			content := "---\non: workflow_dispatch\nengine: claude\npermissions:\n  contents: read\n" + tt.memory + "---\n\nRemember which issues you have already triaged.\n"
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+			require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+			require.NoError(t, err, "should read lock file")
+			lock := string(lockContent)
+
+			assert.Contains(t, lock, "mcp__memory__memory_set", "memory tools should be allowed")
+			for _, expected := range tt.contains {
+				assert.Contains(t, lock, expected, "lock file should contain %q", expected)
+			}
+			for _, unexpected := range tt.notContains {
+				assert.NotContains(t, lock, unexpected, "lock file should not contain %q", unexpected)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var notificationsLog = logger.New("workflow:notifications")
+
+const (
+	// notifyWebhookJobName is the job that posts the run summary to the notifications webhook
+	notifyWebhookJobName = "notify_webhook"
+
+	// defaultWebhookSecret signs the webhook payload when notifications.secret is not set
+	defaultWebhookSecret = "${{ secrets.GH_AW_WEBHOOK_SECRET }}"
+)
+
+// notificationEventResults maps notification events to the agent job result that triggers them
+var notificationEventResults = map[string]string{
+	"completed": "success",
+	"failed":    "failure",
+	"cancelled": "cancelled",
+}
+
+// defaultNotificationEvents are the events notified when notifications.events is not set
+var defaultNotificationEvents = []string{"completed", "failed"}
+
+// NotificationsConfig represents run notifications sent to an external endpoint (notifications:)
+//
+// Example:
+//
+//	notifications:
+//	  webhook: https://agents.example.com/hooks/gh-aw
+//	  events: [completed, failed]
+type NotificationsConfig struct {
+	Webhook string   `json:"webhook"`          // HTTPS URL (or expression) receiving the run summary
+	Events  []string `json:"events,omitempty"` // Agent job outcomes to notify: completed, failed, cancelled
+	Secret  string   `json:"secret,omitempty"` // Expression resolving to the HMAC-SHA256 signing secret
+}
+
+// extractNotificationsConfig extracts the notifications configuration from frontmatter
+func extractNotificationsConfig(frontmatter map[string]any) (*NotificationsConfig, error) {
+	value, exists := frontmatter["notifications"]
+	if !exists || value == nil {
+		return nil, nil
+	}
+
+	notificationsMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("notifications must be an object, got %T. Example:\nnotifications:\n  webhook: https://agents.example.com/hooks/gh-aw\n  events: [completed, failed]", value)
+	}
+
+	webhook, _ := notificationsMap["webhook"].(string)
+	webhook = strings.TrimSpace(webhook)
+	if webhook == "" {
+		return nil, errors.New("notifications.webhook is required and must be a string")
+	}
+	if !strings.HasPrefix(webhook, "https://") && !strings.HasPrefix(webhook, "${{") {
+		return nil, fmt.Errorf("notifications.webhook must be an https:// URL or a ${{ }} expression, got %q", webhook)
+	}
+
+	config := &NotificationsConfig{
+		Webhook: webhook,
+		Events:  defaultNotificationEvents,
+		Secret:  defaultWebhookSecret,
+	}
+
+	if eventsValue, exists := notificationsMap["events"]; exists {
+		eventsList, ok := eventsValue.([]any)
+		if !ok || len(eventsList) == 0 {
+			return nil, errors.New("notifications.events must be a non-empty array of: completed, failed, cancelled")
+		}
+		var events []string
+		for _, item := range eventsList {
+			event, _ := item.(string)
+			if _, known := notificationEventResults[event]; !known {
+				return nil, fmt.Errorf("notifications.events contains unknown event %v. Valid events: completed, failed, cancelled", item)
+			}
+			if !slices.Contains(events, event) {
+				events = append(events, event)
+			}
+		}
+		config.Events = events
+	}
+
+	if secretValue, exists := notificationsMap["secret"]; exists {
+		secret, _ := secretValue.(string)
+		secret = strings.TrimSpace(secret)
+		// A literal secret would be committed to the repository in the workflow and lock files
+		if !strings.HasPrefix(secret, "${{") {
+			return nil, errors.New("notifications.secret must be an expression such as ${{ secrets.WEBHOOK_SECRET }}")
+		}
+		config.Secret = secret
+	}
+
+	notificationsLog.Printf("Extracted notifications config: events=%v", config.Events)
+	return config, nil
+}
+
+// buildNotificationsAgentOutputs returns the agent job outputs consumed by the webhook notification
+func buildNotificationsAgentOutputs(data *WorkflowData) map[string]string {
+	if data.Notifications == nil {
+		return nil
+	}
+	return map[string]string{
+		"token_usage": fmt.Sprintf("${{ steps.%s.outputs.token_usage }}", parseAgentLogsStepID),
+	}
+}
+
+// buildNotifyWebhookJob builds the job that posts a signed JSON summary of the run (conclusion,
+// duration, token usage and safe outputs) to the notifications webhook. It runs after the agent
+// job and, when present, the conclusion job, for the agent outcomes listed in notifications.events.
+func (c *Compiler) buildNotifyWebhookJob(data *WorkflowData, extraNeeds []string) (*Job, error) {
+	if data.Notifications == nil {
+		return nil, nil
+	}
+
+	notificationsLog.Printf("Building notify webhook job (events=%v)", data.Notifications.Events)
+
+	var steps []string
+
+	setupActionRef := c.resolveActionReference("./actions/setup", data)
+	if setupActionRef == "" && !c.actionMode.IsScript() {
+		return nil, errors.New("setup action reference is required but could not be resolved")
+	}
+
+	// For dev mode (local action path), checkout the actions folder first
+	steps = append(steps, c.generateCheckoutActionsFolder(data)...)
+	steps = append(steps, c.generateSetupStep(setupActionRef, SetupActionDestination, false)...)
+
+	// The agent output artifact only exists when safe outputs are configured
+	if data.SafeOutputs != nil {
+		steps = append(steps, buildAgentOutputDownloadSteps()...)
+	}
+
+	steps = append(steps, "      - name: Post run summary to webhook\n")
+	steps = append(steps, "        id: notify_webhook\n")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/github-script")))
+	steps = append(steps, "        env:\n")
+	if data.SafeOutputs != nil {
+		steps = append(steps, "          GH_AW_AGENT_OUTPUT: ${{ env.GH_AW_AGENT_OUTPUT }}\n")
+	}
+	steps = append(steps, fmt.Sprintf("          GH_AW_WEBHOOK_URL: %q\n", data.Notifications.Webhook))
+	steps = append(steps, fmt.Sprintf("          GH_AW_WEBHOOK_SECRET: %s\n", data.Notifications.Secret))
+	steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_NAME: %q\n", data.Name))
+	steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_ID: %q\n", data.WorkflowID))
+	steps = append(steps, "          GH_AW_RUN_URL: ${{ github.server_url }}/${{ github.repository }}/actions/runs/${{ github.run_id }}\n")
+	steps = append(steps, fmt.Sprintf("          GH_AW_AGENT_CONCLUSION: ${{ needs.%s.result }}\n", constants.AgentJobName))
+	steps = append(steps, fmt.Sprintf("          GH_AW_TOKEN_USAGE: ${{ needs.%s.outputs.token_usage }}\n", constants.AgentJobName))
+	steps = append(steps, "        with:\n")
+	steps = append(steps, "          script: |\n")
+	steps = append(steps, generateGitHubScriptWithRequire("notify_webhook.cjs"))
+
+	// Only run for the agent outcomes that should be notified
+	var resultChecks []ConditionNode
+	for _, event := range data.Notifications.Events {
+		resultChecks = append(resultChecks, BuildEquals(
+			BuildPropertyAccess(fmt.Sprintf("needs.%s.result", constants.AgentJobName)),
+			BuildStringLiteral(notificationEventResults[event]),
+		))
+	}
+	var resultCondition ConditionNode = resultChecks[0]
+	if len(resultChecks) > 1 {
+		resultCondition = BuildDisjunction(false, resultChecks...)
+	}
+	jobCondition := BuildAnd(BuildFunctionCall("always"), resultCondition)
+
+	// actions: read to look up the run start time for the duration
+	var perms *Permissions
+	if (c.actionMode.IsDev() || c.actionMode.IsScript()) && len(c.generateCheckoutActionsFolder(data)) > 0 {
+		perms = NewPermissionsContentsRead()
+	} else {
+		perms = NewPermissions()
+	}
+	perms.Set(PermissionActions, PermissionRead)
+
+	needs := append([]string{string(constants.AgentJobName)}, extraNeeds...)
+
+	job := &Job{
+		Name:           notifyWebhookJobName,
+		Needs:          needs,
+		If:             jobCondition.Render(),
+		RunsOn:         c.formatAuxiliaryJobRunsOn(data, constants.DefaultActivationJobRunnerImage),
+		Permissions:    perms.RenderToYAML(),
+		Steps:          steps,
+		TimeoutMinutes: 5,
+	}
+
+	return job, nil
+}
@@ -0,0 +1,134 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractNotificationsConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *NotificationsConfig
+		wantErr     string
+	}{
+		{
+			name:        "no notifications",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "webhook with defaults",
+			frontmatter: map[string]any{"notifications": map[string]any{"webhook": "https://hooks.example.com/gh-aw"}},
+			expected: &NotificationsConfig{
+				Webhook: "https://hooks.example.com/gh-aw",
+				Events:  []string{"completed", "failed"},
+				Secret:  "${{ secrets.GH_AW_WEBHOOK_SECRET }}",
+			},
+		},
+		{
+			name: "custom events and secret",
+			frontmatter: map[string]any{"notifications": map[string]any{
+				"webhook": "${{ vars.AGENT_WEBHOOK_URL }}",
+				"events":  []any{"cancelled", "failed", "failed"},
+				"secret":  "${{ secrets.AGENT_WEBHOOK_SECRET }}",
+			}},
+			expected: &NotificationsConfig{
+				Webhook: "${{ vars.AGENT_WEBHOOK_URL }}",
+				Events:  []string{"cancelled", "failed"},
+				Secret:  "${{ secrets.AGENT_WEBHOOK_SECRET }}",
+			},
+		},
+		{
+			name:        "invalid type",
+			frontmatter: map[string]any{"notifications": "https://hooks.example.com"},
+			wantErr:     "notifications must be an object",
+		},
+		{
+			name:        "missing webhook",
+			frontmatter: map[string]any{"notifications": map[string]any{"events": []any{"failed"}}},
+			wantErr:     "notifications.webhook is required",
+		},
+		{
+			name:        "plain http webhook",
+			frontmatter: map[string]any{"notifications": map[string]any{"webhook": "http://hooks.example.com"}},
+			wantErr:     "notifications.webhook must be an https:// URL",
+		},
+		{
+			name:        "unknown event",
+			frontmatter: map[string]any{"notifications": map[string]any{"webhook": "https://hooks.example.com", "events": []any{"started"}}},
+			wantErr:     "unknown event started",
+		},
+		{
+			name:        "literal secret",
+			frontmatter: map[string]any{"notifications": map[string]any{"webhook": "https://hooks.example.com", "secret": "hunter2"}},
+			wantErr:     "notifications.secret must be an expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := extractNotificationsConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "should parse notifications config")
+			assert.Equal(t, tt.expected, config, "notifications config")
+		})
+	}
+}
+
+func TestNotificationsCompilation(t *testing.T) {
+	compile := func(t *testing.T, frontmatter string) string {
+		tmpDir := testutil.TempDir(t, "notifications-test")
+		workflowPath := filepath.Join(tmpDir, "triage.md")
+		content := "---\non: issues\nengine: claude\npermissions:\n  contents: read\n" + frontmatter + "---\n\nTriage the issue.\n"
+		require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+		require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+		lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+		require.NoError(t, err, "should read lock file")
+		return string(lockContent)
+	}
+
+	t.Run("generates notify webhook job after the conclusion job", func(t *testing.T) {
+		lock := compile(t, "notifications:\n  webhook: https://hooks.example.com/gh-aw\nsafe-outputs:\n  add-comment:\n")
+
+		for _, expected := range []string{
+			"  notify_webhook:\n",
+			"if: (always()) && (needs.agent.result == 'success' || needs.agent.result == 'failure')",
+			"- conclusion",
+			"actions: read",
+			"id: parse-agent-logs",
+			"token_usage: ${{ steps.parse-agent-logs.outputs.token_usage }}",
+			"- name: Download agent output artifact",
+			"GH_AW_WEBHOOK_URL: \"https://hooks.example.com/gh-aw\"",
+			"GH_AW_WEBHOOK_SECRET: ${{ secrets.GH_AW_WEBHOOK_SECRET }}",
+			"GH_AW_TOKEN_USAGE: ${{ needs.agent.outputs.token_usage }}",
+			"require('/opt/gh-aw/actions/notify_webhook.cjs')",
+		} {
+			assert.Contains(t, lock, expected, "lock file should contain %q", expected)
+		}
+	})
+
+	t.Run("single event without safe outputs", func(t *testing.T) {
+		lock := compile(t, "notifications:\n  webhook: https://hooks.example.com/gh-aw\n  events: [failed]\n")
+
+		assert.Contains(t, lock, "if: (always()) && (needs.agent.result == 'failure')", "job should only run for failures")
+		assert.NotContains(t, lock, "- name: Download agent output artifact", "agent output is only available with safe outputs")
+	})
+
+	t.Run("no notifications", func(t *testing.T) {
+		lock := compile(t, "")
+		assert.NotContains(t, lock, "notify_webhook", "notify webhook job should not be generated")
+	})
+}
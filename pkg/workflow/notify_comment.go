@@ -50,6 +50,9 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 	if data.SafeOutputs.GitHubApp != nil {
 		// Compute permissions based on configured safe outputs (principle of least privilege)
 		permissions := ComputePermissionsForSafeOutputs(data.SafeOutputs)
+		if data.SafeOutputs.SummaryComment {
+			permissions.Merge(NewPermissionsContentsReadIssuesWritePRWrite())
+		}
 		steps = append(steps, c.buildGitHubAppTokenMintStep(data.SafeOutputs.GitHubApp, permissions)...)
 	}
 
@@ -326,6 +329,9 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 		steps = append(steps, scriptSteps...)
 	}
 
+	// Add the sticky run summary comment step if summary-comment is enabled
+	steps = append(steps, c.buildSummaryCommentStep(data, mainJobName)...)
+
 	// Note: Unlock step has been moved to a dedicated unlock job
 	// that always runs, even if this conclusion job doesn't run.
 	// See buildUnlockJob() in compiler_unlock_job.go
@@ -333,7 +339,7 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 	// Add GitHub App token invalidation step if app is configured
 	if data.SafeOutputs.GitHubApp != nil {
 		notifyCommentLog.Print("Adding GitHub App token invalidation step to conclusion job")
-		steps = append(steps, c.buildGitHubAppTokenInvalidationStep()...)
+		steps = append(steps, c.buildGitHubAppTokenInvalidationStep("safe-outputs-app-token", "Invalidate GitHub App token")...)
 	}
 
 	// Build the condition for this job:
@@ -393,6 +399,10 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 
 	// Compute permissions based on configured safe outputs (principle of least privilege)
 	permissions := ComputePermissionsForSafeOutputs(data.SafeOutputs)
+	if data.SafeOutputs.SummaryComment {
+		// The summary comment is posted on the triggering issue or pull request
+		permissions.Merge(NewPermissionsContentsReadIssuesWritePRWrite())
+	}
 
 	// Build concurrency config for the conclusion job using the workflow ID.
 	// This prevents concurrent agents on the same workflow from interfering with each other.
@@ -406,7 +416,7 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 	job := &Job{
 		Name:        "conclusion",
 		If:          condition.Render(),
-		RunsOn:      c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		RunsOn:      c.formatAuxiliaryJobRunsOn(data, constants.DefaultActivationJobRunnerImage),
 		Permissions: permissions.RenderToYAML(),
 		Concurrency: concurrency,
 		Steps:       steps,
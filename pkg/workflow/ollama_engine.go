@@ -0,0 +1,270 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var ollamaLog = logger.New("workflow:ollama_engine")
+
+// ollamaAgentScript is the agent loop that calls the Ollama chat completions API and runs
+// the requested MCP tool calls (actions/setup/js/ollama_agent.cjs)
+const ollamaAgentScript = SetupActionDestination + "/ollama_agent.cjs"
+
+// DefaultOllamaEndpoint is the Ollama server of a self-hosted runner
+const DefaultOllamaEndpoint = "http://localhost:11434"
+
+// DefaultOllamaModel is the model used when engine.model is not set
+const DefaultOllamaModel = "llama3.1"
+
+// ollamaHealthCheckAttempts is how many times the health check polls the server, two seconds apart
+const ollamaHealthCheckAttempts = 30
+
+// OllamaEngine represents the Ollama agentic engine for self-hosted models.
+// It runs the same MCP tool loop as the github-models engine against the OpenAI-compatible
+// API of an Ollama server, so no external API or secret is needed (air-gapped runners).
+// MCP configuration rendering and log parsing are shared with GitHubModelsEngine.
+type OllamaEngine struct {
+	GitHubModelsEngine
+}
+
+func NewOllamaEngine() *OllamaEngine {
+	return &OllamaEngine{
+		GitHubModelsEngine: GitHubModelsEngine{
+			BaseEngine: BaseEngine{
+				id:                     string(constants.OllamaEngine),
+				displayName:            "Ollama",
+				description:            "Self-hosted models served by Ollama, using MCP tools only",
+				experimental:           true,
+				supportsToolsAllowlist: true,
+				supportsMaxTurns:       true,
+				supportsWebFetch:       false,
+				supportsWebSearch:      false,
+				supportsBashAllowlist:  false,
+				supportsPlugins:        false,
+			},
+		},
+	}
+}
+
+// GetModelEnvVarName returns the environment variable the agent script reads for model selection
+func (e *OllamaEngine) GetModelEnvVarName() string {
+	return constants.OllamaModelEnvVar
+}
+
+// GetInstallationSteps returns the Node.js setup for the agent script, the Ollama server
+// health check, the model pull and, when the firewall is enabled, the AWF installation
+func (e *OllamaEngine) GetInstallationSteps(workflowData *WorkflowData) []GitHubActionStep {
+	ollamaLog.Printf("Generating installation steps for Ollama engine: workflow=%s", workflowData.Name)
+
+	endpoint := getOllamaEndpoint(workflowData)
+	model := getOllamaModel(workflowData)
+
+	var steps []GitHubActionStep
+	// A custom command brings its own runtime, but still needs a healthy server with the model
+	if workflowData.EngineConfig == nil || workflowData.EngineConfig.Command == "" {
+		steps = append(steps, GenerateNodeJsSetupStep())
+	}
+	steps = append(steps, generateOllamaHealthCheckStep(endpoint), generateOllamaModelPullStep(endpoint, model))
+
+	// Add AWF installation if firewall is enabled
+	if isFirewallEnabled(workflowData) {
+		firewallConfig := getFirewallConfig(workflowData)
+		agentConfig := getAgentConfig(workflowData)
+		var awfVersion string
+		if firewallConfig != nil {
+			awfVersion = firewallConfig.Version
+		}
+
+		awfInstall := generateAWFInstallationStep(awfVersion, agentConfig)
+		if len(awfInstall) > 0 {
+			steps = append(steps, awfInstall)
+		}
+	}
+
+	return steps
+}
+
+// generateOllamaHealthCheckStep waits for the Ollama server to answer before the model is pulled
+func generateOllamaHealthCheckStep(endpoint string) GitHubActionStep {
+	return GitHubActionStep{
+		"      - name: Wait for Ollama server",
+		"        run: |",
+		fmt.Sprintf("          for attempt in $(seq 1 %d); do", ollamaHealthCheckAttempts),
+		`            if curl -sf "${OLLAMA_ENDPOINT}/api/version" > /dev/null; then`,
+		`              echo "Ollama server is ready at ${OLLAMA_ENDPOINT}"`,
+		"              exit 0",
+		"            fi",
+		fmt.Sprintf(`            echo "Waiting for Ollama server at ${OLLAMA_ENDPOINT} ($attempt/%d)..."`, ollamaHealthCheckAttempts),
+		"            sleep 2",
+		"          done",
+		`          echo "::error::Ollama server is not reachable at ${OLLAMA_ENDPOINT}. Start it on the runner (ollama serve) or set engine.endpoint."`,
+		"          exit 1",
+		"        env:",
+		"          OLLAMA_ENDPOINT: " + endpoint,
+	}
+}
+
+// generateOllamaModelPullStep pulls the model unless the server already has it, so runners
+// without internet access work as long as the model was loaded in advance
+func generateOllamaModelPullStep(endpoint, model string) GitHubActionStep {
+	return GitHubActionStep{
+		"      - name: Pull Ollama model",
+		"        run: |",
+		`          if curl -sf "${OLLAMA_ENDPOINT}/api/tags" | grep -qF "\"name\":\"${OLLAMA_MODEL_TAG}\""; then`,
+		`            echo "Model ${OLLAMA_MODEL_TAG} is already available"`,
+		"          else",
+		`            echo "Pulling model ${OLLAMA_MODEL_TAG}..."`,
+		`            curl -sSf "${OLLAMA_ENDPOINT}/api/pull" -d "{\"model\":\"${OLLAMA_MODEL_TAG}\",\"stream\":false}"`,
+		"            echo",
+		"          fi",
+		"        env:",
+		"          OLLAMA_ENDPOINT: " + endpoint,
+		"          OLLAMA_MODEL_TAG: " + ollamaModelTag(model),
+	}
+}
+
+// GetExecutionSteps returns the GitHub Actions steps for executing the Ollama agent
+func (e *OllamaEngine) GetExecutionSteps(workflowData *WorkflowData, logFile string) []GitHubActionStep {
+	firewallEnabled := isFirewallEnabled(workflowData)
+	ollamaLog.Printf("Generating execution steps for Ollama engine: workflow=%s, firewall=%v", workflowData.Name, firewallEnabled)
+
+	agentCommand := "node " + ollamaAgentScript
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.Command != "" {
+		agentCommand = workflowData.EngineConfig.Command
+	}
+
+	// Build the full command with AWF wrapping if enabled
+	var command string
+	if firewallEnabled {
+		command = BuildAWFCommand(AWFCommandConfig{
+			EngineName:     e.GetID(),
+			EngineCommand:  agentCommand,
+			LogFile:        logFile,
+			WorkflowData:   workflowData,
+			UsesTTY:        false,
+			AllowedDomains: GetOllamaAllowedDomains(workflowData),
+			// Create the agent step summary file before AWF starts so it is accessible
+			// inside the sandbox.
+			PathSetup: "touch " + AgentStepSummaryPath,
+		})
+	} else {
+		command = fmt.Sprintf(`set -o pipefail
+touch %s
+%s 2>&1 | tee -a %s`, AgentStepSummaryPath, agentCommand, logFile)
+	}
+
+	endpoint := getOllamaEndpoint(workflowData)
+	if firewallEnabled {
+		// Inside the sandbox, localhost is the agent container rather than the runner
+		endpoint = ollamaSandboxEndpoint(endpoint)
+	}
+
+	// Build environment variables
+	env := map[string]string{
+		"GH_AW_OLLAMA_ENDPOINT":     endpoint,
+		constants.OllamaModelEnvVar: getOllamaModel(workflowData),
+		"GH_AW_PROMPT":              "/tmp/gh-aw/aw-prompts/prompt.txt",
+		"GITHUB_WORKSPACE":          "${{ github.workspace }}",
+		// Override GITHUB_STEP_SUMMARY with a path that exists inside the sandbox.
+		"GITHUB_STEP_SUMMARY": AgentStepSummaryPath,
+	}
+
+	if HasMCPServers(workflowData) {
+		env["GH_AW_MCP_CONFIG"] = githubModelsMCPConfigPath
+	}
+
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.MaxTurns != "" {
+		env["GH_AW_MAX_TURNS"] = workflowData.EngineConfig.MaxTurns
+	}
+
+	// Add safe outputs env
+	applySafeOutputEnvToMap(env, workflowData)
+
+	// Add network.allowed entries resolved from repository or organization variables
+	applyAllowedDomainsVarsEnvToMap(env, workflowData)
+
+	// Add custom environment variables from engine config
+	if workflowData.EngineConfig != nil && len(workflowData.EngineConfig.Env) > 0 {
+		maps.Copy(env, workflowData.EngineConfig.Env)
+	}
+
+	// Add custom environment variables from agent config
+	agentConfig := getAgentConfig(workflowData)
+	if agentConfig != nil && len(agentConfig.Env) > 0 {
+		maps.Copy(env, agentConfig.Env)
+		ollamaLog.Printf("Added %d custom env vars from agent config", len(agentConfig.Env))
+	}
+
+	stepLines := []string{
+		"      - name: Execute Ollama agent",
+		"        id: agentic_execution",
+	}
+
+	// Filter environment variables for security
+	filteredEnv := FilterEnvForSecrets(env, e.GetRequiredSecretNames(workflowData))
+	stepLines = FormatStepWithCommandAndEnv(stepLines, command, filteredEnv)
+
+	return []GitHubActionStep{GitHubActionStep(stepLines)}
+}
+
+// GetOllamaAllowedDomains returns the firewall allow-list for the ollama engine: the default
+// domains, the host of engine.endpoint, and the network, tool and runtime domains
+func GetOllamaAllowedDomains(workflowData *WorkflowData) string {
+	domains := slices.Clone(OllamaDefaultDomains)
+	if parsed, err := url.Parse(getOllamaEndpoint(workflowData)); err == nil && parsed.Hostname() != "" && !isLoopbackHost(parsed.Hostname()) {
+		domains = append(domains, parsed.Hostname())
+	}
+	return mergeDomainsWithNetworkToolsAndRuntimes(domains, workflowData.NetworkPermissions, workflowData.Tools, workflowData.Runtimes)
+}
+
+// getOllamaEndpoint returns the configured model server URL without a trailing slash
+func getOllamaEndpoint(workflowData *WorkflowData) string {
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.Endpoint != "" {
+		return strings.TrimRight(workflowData.EngineConfig.Endpoint, "/")
+	}
+	return DefaultOllamaEndpoint
+}
+
+// getOllamaModel returns the configured model, or DefaultOllamaModel
+func getOllamaModel(workflowData *WorkflowData) string {
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.Model != "" {
+		return workflowData.EngineConfig.Model
+	}
+	return DefaultOllamaModel
+}
+
+// ollamaModelTag returns the model name as listed by the server, which adds ":latest"
+// to models pulled without a tag
+func ollamaModelTag(model string) string {
+	if strings.Contains(model, ":") {
+		return model
+	}
+	return model + ":latest"
+}
+
+// ollamaSandboxEndpoint rewrites a loopback endpoint to host.docker.internal, the runner as
+// seen from the firewall sandbox
+func ollamaSandboxEndpoint(endpoint string) string {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || !isLoopbackHost(parsed.Hostname()) {
+		return endpoint
+	}
+	host := "host.docker.internal"
+	if port := parsed.Port(); port != "" {
+		host += ":" + port
+	}
+	parsed.Host = host
+	return parsed.String()
+}
+
+// isLoopbackHost reports whether the host names the local machine
+func isLoopbackHost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
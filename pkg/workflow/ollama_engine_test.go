@@ -0,0 +1,174 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOllamaEngine(t *testing.T) {
+	engine := NewOllamaEngine()
+
+	t.Run("engine identity", func(t *testing.T) {
+		assert.Equal(t, "ollama", engine.GetID(), "Engine ID should be 'ollama'")
+		assert.Equal(t, "Ollama", engine.GetDisplayName(), "Display name should be 'Ollama'")
+		assert.True(t, engine.IsExperimental(), "Ollama engine should be experimental")
+		assert.Equal(t, "GH_AW_OLLAMA_MODEL", engine.GetModelEnvVarName())
+	})
+
+	t.Run("registered", func(t *testing.T) {
+		registered, err := NewEngineRegistry().GetEngine("ollama")
+		require.NoError(t, err, "ollama should be registered")
+		assert.Equal(t, "ollama", registered.GetID())
+	})
+
+	t.Run("no secrets without MCP servers", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name:        "test",
+			ParsedTools: &ToolsConfig{},
+			Tools:       map[string]any{},
+		}
+		assert.Empty(t, engine.GetRequiredSecretNames(workflowData), "Should not require any secret without MCP servers")
+	})
+}
+
+func TestOllamaEngineInstallation(t *testing.T) {
+	engine := NewOllamaEngine()
+
+	t.Run("health check and model pull", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name:         "test-workflow",
+			EngineConfig: &EngineConfig{ID: "ollama", Model: "llama3.1:70b", Endpoint: "http://ollama.internal:11434/"},
+		}
+		steps := engine.GetInstallationSteps(workflowData)
+		require.Len(t, steps, 3, "Should set up Node.js, check the server and pull the model")
+		assert.Contains(t, strings.Join(steps[0], "\n"), "Setup Node.js")
+
+		healthCheck := strings.Join(steps[1], "\n")
+		assert.Contains(t, healthCheck, "name: Wait for Ollama server")
+		assert.Contains(t, healthCheck, `curl -sf "${OLLAMA_ENDPOINT}/api/version"`)
+		assert.Contains(t, healthCheck, "OLLAMA_ENDPOINT: http://ollama.internal:11434", "Should trim the trailing slash")
+
+		pull := strings.Join(steps[2], "\n")
+		assert.Contains(t, pull, "name: Pull Ollama model")
+		assert.Contains(t, pull, "/api/tags", "Should skip the pull when the model is already available")
+		assert.Contains(t, pull, "/api/pull")
+		assert.Contains(t, pull, "OLLAMA_MODEL_TAG: llama3.1:70b")
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		steps := engine.GetInstallationSteps(&WorkflowData{Name: "test-workflow"})
+		require.Len(t, steps, 3)
+		assert.Contains(t, strings.Join(steps[1], "\n"), "OLLAMA_ENDPOINT: http://localhost:11434")
+		assert.Contains(t, strings.Join(steps[2], "\n"), "OLLAMA_MODEL_TAG: llama3.1:latest", "Untagged models should be checked as :latest")
+	})
+
+	t.Run("with custom command", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name:         "test-workflow",
+			EngineConfig: &EngineConfig{Command: "/custom/agent"},
+		}
+		steps := engine.GetInstallationSteps(workflowData)
+		require.Len(t, steps, 2, "Should still check the server and pull the model")
+		assert.NotContains(t, strings.Join(steps[0], "\n"), "Setup Node.js")
+	})
+
+	t.Run("with firewall", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name: "test-workflow",
+			NetworkPermissions: &NetworkPermissions{
+				Firewall: &FirewallConfig{Enabled: true},
+			},
+		}
+		steps := engine.GetInstallationSteps(workflowData)
+		require.Len(t, steps, 4, "Should also install AWF")
+		assert.Contains(t, strings.Join(steps[3], "\n"), "awf")
+	})
+}
+
+func TestOllamaEngineExecution(t *testing.T) {
+	engine := NewOllamaEngine()
+
+	t.Run("basic execution", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name:         "test-workflow",
+			EngineConfig: &EngineConfig{ID: "ollama", Model: "llama3.1:70b", MaxTurns: "8"},
+		}
+		steps := engine.GetExecutionSteps(workflowData, "/tmp/test.log")
+		require.Len(t, steps, 1, "Should generate one execution step")
+
+		stepContent := strings.Join(steps[0], "\n")
+		assert.Contains(t, stepContent, "name: Execute Ollama agent")
+		assert.Contains(t, stepContent, "id: agentic_execution")
+		assert.Contains(t, stepContent, "node /opt/gh-aw/actions/ollama_agent.cjs", "Should run the agent script")
+		assert.Contains(t, stepContent, "GH_AW_OLLAMA_ENDPOINT: http://localhost:11434")
+		assert.Contains(t, stepContent, "GH_AW_OLLAMA_MODEL: llama3.1:70b")
+		assert.Contains(t, stepContent, "GH_AW_MAX_TURNS: 8")
+		assert.NotContains(t, stepContent, "secrets.", "Should not reference any secret")
+	})
+
+	t.Run("firewall rewrites loopback endpoint", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name:         "test-workflow",
+			EngineConfig: &EngineConfig{ID: "ollama", Endpoint: "http://127.0.0.1:11434"},
+			NetworkPermissions: &NetworkPermissions{
+				Firewall: &FirewallConfig{Enabled: true},
+			},
+		}
+		stepContent := strings.Join(engine.GetExecutionSteps(workflowData, "/tmp/test.log")[0], "\n")
+		assert.Contains(t, stepContent, "awf", "Should use AWF when firewall is enabled")
+		assert.Contains(t, stepContent, "GH_AW_OLLAMA_ENDPOINT: http://host.docker.internal:11434", "The runner is host.docker.internal inside the sandbox")
+	})
+}
+
+func TestGetOllamaAllowedDomains(t *testing.T) {
+	t.Run("local endpoint", func(t *testing.T) {
+		domains := GetOllamaAllowedDomains(&WorkflowData{})
+		assert.Equal(t, "host.docker.internal", domains, "Only the runner should be reachable by default")
+	})
+
+	t.Run("remote endpoint", func(t *testing.T) {
+		domains := GetOllamaAllowedDomains(&WorkflowData{EngineConfig: &EngineConfig{Endpoint: "http://ollama.internal:11434"}})
+		assert.Equal(t, "host.docker.internal,ollama.internal", domains)
+	})
+}
+
+func TestOllamaEngineCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "ollama-engine-test")
+
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine:
+  id: ollama
+  model: llama3.1:70b
+  endpoint: http://localhost:11434
+---
+
+# Summarize
+
+Summarize the repository.
+`
+	workflowPath := filepath.Join(tmpDir, "ollama.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "ollama workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "ollama.lock.yml"))
+	require.NoError(t, err)
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "name: Wait for Ollama server")
+	assert.Contains(t, lock, "name: Pull Ollama model")
+	assert.Contains(t, lock, "name: Execute Ollama agent")
+	assert.Less(t, strings.Index(lock, "name: Pull Ollama model"), strings.Index(lock, "name: Execute Ollama agent"), "The model should be pulled before the agent runs")
+}
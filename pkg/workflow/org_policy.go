@@ -0,0 +1,196 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/messages"
+	"github.com/goccy/go-yaml"
+)
+
+var orgPolicyLog = logger.New("workflow:org_policy")
+
+// OrgPolicyRepo is the repository of an organization that holds its policy file
+const OrgPolicyRepo = ".github"
+
+// OrgPolicyPath is the location of the policy file in OrgPolicyRepo
+const OrgPolicyPath = "aw-policy.yml"
+
+// OrgPolicy holds the organization-wide constraints every workflow must satisfy.
+// Unlike RepoConfig values, policy entries are not defaults: a workflow that
+// violates any of them fails to compile.
+type OrgPolicy struct {
+	AllowedEngines             []string          `yaml:"allowed-engines,omitempty"`              // Engines workflows may use (empty allows every engine)
+	MaxPermissions             map[string]string `yaml:"max-permissions,omitempty"`              // Highest level (none, read, write) of each listed permission scope
+	RequireNetworkRestrictions bool              `yaml:"require-network-restrictions,omitempty"` // Require the agent firewall and refuse the "*" network wildcard
+	BannedTools                []string          `yaml:"banned-tools,omitempty"`                 // Tools and MCP servers workflows must not configure
+	PromptLint                 *PromptLintPolicy `yaml:"prompt-lint,omitempty"`                  // Phrases and sections checked in the workflow prompt
+
+	Source string `yaml:"-"` // Where the policy was loaded from, shown in violation messages
+}
+
+// ParseOrgPolicy parses and validates a policy document. source names its origin
+// (a file path or owner/repo/path) for error and violation messages.
+func ParseOrgPolicy(data []byte, source string) (*OrgPolicy, error) {
+	policy := &OrgPolicy{}
+	if err := yaml.UnmarshalWithOptions(data, policy, yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", source, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", source, err)
+	}
+	policy.Source = source
+
+	orgPolicyLog.Printf("Loaded policy %s: engines=%v, maxPermissions=%d, requireNetworkRestrictions=%v, bannedTools=%v, promptLint=%v",
+		source, policy.AllowedEngines, len(policy.MaxPermissions), policy.RequireNetworkRestrictions, policy.BannedTools, policy.PromptLint != nil)
+	return policy, nil
+}
+
+// LoadOrgPolicyFile reads a policy document from a local file
+func LoadOrgPolicyFile(path string) (*OrgPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy %s: %w", path, err)
+	}
+	return ParseOrgPolicy(data, path)
+}
+
+// Validate checks that every engine, permission scope and level of the policy is known
+// and that the prompt-lint rules are well-formed.
+func (p *OrgPolicy) Validate() error {
+	for _, engine := range p.AllowedEngines {
+		if !GetGlobalEngineRegistry().IsValidEngine(engine) {
+			return fmt.Errorf("allowed-engines: unknown engine '%s'. Supported engines: %s",
+				engine, strings.Join(GetGlobalEngineRegistry().GetSupportedEngines(), ", "))
+		}
+	}
+
+	for scope, level := range p.MaxPermissions {
+		if !slices.Contains(GetAllPermissionScopes(), PermissionScope(scope)) {
+			return fmt.Errorf("max-permissions: unknown permission scope '%s'", scope)
+		}
+		if permissionLevelRank(PermissionLevel(level)) < 0 {
+			return fmt.Errorf("max-permissions: %s must be none, read or write, got '%s'", scope, level)
+		}
+	}
+
+	if p.PromptLint != nil {
+		return p.PromptLint.Validate()
+	}
+
+	return nil
+}
+
+// permissionLevelRank orders permission levels from none to write; unknown levels return -1
+func permissionLevelRank(level PermissionLevel) int {
+	switch level {
+	case PermissionNone:
+		return 0
+	case PermissionRead:
+		return 1
+	case PermissionWrite:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// Violations returns a description of every policy entry the workflow does not satisfy.
+// Permissions are checked on the top-level workflow permissions, which the agent job runs with,
+// and on the permissions of every custom job.
+func (p *OrgPolicy) Violations(workflowData *WorkflowData) []string {
+	var violations []string
+
+	engineID := workflowData.AI
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.ID != "" {
+		engineID = workflowData.EngineConfig.ID
+	}
+	if len(p.AllowedEngines) > 0 && !slices.Contains(p.AllowedEngines, engineID) {
+		violations = append(violations, fmt.Sprintf("engine '%s' is not allowed (allowed engines: %s)", engineID, strings.Join(p.AllowedEngines, ", ")))
+	}
+
+	if len(p.MaxPermissions) > 0 {
+		violations = append(violations, p.permissionViolations("", NewPermissionsParser(workflowData.Permissions).ToPermissions())...)
+
+		jobNames := make([]string, 0, len(workflowData.Jobs))
+		for name := range workflowData.Jobs {
+			jobNames = append(jobNames, name)
+		}
+		sort.Strings(jobNames)
+		for _, name := range jobNames {
+			jobConfig, ok := workflowData.Jobs[name].(map[string]any)
+			if !ok {
+				continue
+			}
+			permissionsValue, hasPermissions := jobConfig["permissions"]
+			if !hasPermissions {
+				continue
+			}
+			permissions := NewPermissionsParserFromValue(permissionsValue).ToPermissions()
+			violations = append(violations, p.permissionViolations(fmt.Sprintf("jobs.%s: ", name), permissions)...)
+		}
+	}
+
+	if p.RequireNetworkRestrictions {
+		if !isFirewallEnabled(workflowData) {
+			violations = append(violations, "network access must be restricted: the agent firewall is disabled")
+		}
+		if workflowData.NetworkPermissions != nil && slices.Contains(workflowData.NetworkPermissions.Allowed, "*") {
+			violations = append(violations, "network access must be restricted: network.allowed must not contain '*'")
+		}
+	}
+
+	for _, tool := range p.BannedTools {
+		if _, configured := workflowData.Tools[tool]; configured {
+			violations = append(violations, fmt.Sprintf("tool '%s' is banned", tool))
+		}
+	}
+
+	if p.PromptLint != nil {
+		violations = append(violations, p.PromptLint.Violations(workflowData.MarkdownContent)...)
+	}
+
+	return violations
+}
+
+// permissionViolations checks permissions against max-permissions; prefix names the job they belong to
+func (p *OrgPolicy) permissionViolations(prefix string, permissions *Permissions) []string {
+	scopes := make([]string, 0, len(p.MaxPermissions))
+	for scope := range p.MaxPermissions {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+
+	var violations []string
+	for _, scope := range scopes {
+		maxLevel := PermissionLevel(p.MaxPermissions[scope])
+		level, exists := permissions.Get(PermissionScope(scope))
+		if exists && permissionLevelRank(level) > permissionLevelRank(maxLevel) {
+			violations = append(violations, fmt.Sprintf("%spermission %s: %s exceeds the maximum '%s'", prefix, scope, level, maxLevel))
+		}
+	}
+	return violations
+}
+
+// validateOrgPolicy fails compilation when the workflow violates the organization policy
+func (c *Compiler) validateOrgPolicy(workflowData *WorkflowData) error {
+	if c.orgPolicy == nil {
+		return nil
+	}
+
+	violations := c.orgPolicy.Violations(workflowData)
+	if len(violations) == 0 {
+		orgPolicyLog.Print("Workflow satisfies the organization policy")
+		return nil
+	}
+
+	orgPolicyLog.Printf("Workflow violates the organization policy: %d violation(s)", len(violations))
+	return messages.NewError(messages.OrgPolicyViolation, messages.Args{
+		"source":     c.orgPolicy.Source,
+		"violations": "  - " + strings.Join(violations, "\n  - "),
+	})
+}
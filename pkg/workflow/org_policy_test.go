@@ -0,0 +1,118 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/messages"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOrgPolicy(t *testing.T) {
+	policy, err := ParseOrgPolicy([]byte(`allowed-engines: [copilot, claude]
+max-permissions:
+  contents: read
+  actions: none
+require-network-restrictions: true
+banned-tools: [playwright]
+`), "octo-org/.github/aw-policy.yml")
+	require.NoError(t, err, "valid policy should parse")
+	assert.Equal(t, []string{"copilot", "claude"}, policy.AllowedEngines, "allowed engines")
+	assert.Equal(t, map[string]string{"contents": "read", "actions": "none"}, policy.MaxPermissions, "max permissions")
+	assert.True(t, policy.RequireNetworkRestrictions, "network restrictions")
+	assert.Equal(t, []string{"playwright"}, policy.BannedTools, "banned tools")
+	assert.Equal(t, "octo-org/.github/aw-policy.yml", policy.Source, "source")
+
+	for content, errorSubstring := range map[string]string{
+		"allowed-engines: [gpt]\n":                    "unknown engine 'gpt'",
+		"max-permissions:\n  code: read\n":            "unknown permission scope 'code'",
+		"max-permissions:\n  contents: admin\n":       "must be none, read or write",
+		"banned-tools: [bash]\nunknown-key: 1\n":      "unknown-key",
+		"prompt-lint:\n  forbidden-patterns: ['(']\n": "invalid regular expression '('",
+	} {
+		_, err := ParseOrgPolicy([]byte(content), "aw-policy.yml")
+		require.Error(t, err, "policy %q should be rejected", content)
+		assert.Contains(t, err.Error(), errorSubstring, "error for %q", content)
+	}
+}
+
+func TestOrgPolicyViolations(t *testing.T) {
+	workflowData := &WorkflowData{
+		AI:                 "claude",
+		Permissions:        "permissions:\n  contents: read\n  issues: write\n",
+		NetworkPermissions: &NetworkPermissions{Allowed: []string{"*"}},
+		Tools:              map[string]any{"bash": true, "playwright": nil},
+	}
+
+	assert.Empty(t, (&OrgPolicy{}).Violations(workflowData), "empty policy allows everything")
+
+	policy := &OrgPolicy{
+		AllowedEngines:             []string{"copilot"},
+		MaxPermissions:             map[string]string{"contents": "read", "issues": "read"},
+		RequireNetworkRestrictions: true,
+		BannedTools:                []string{"playwright", "web-fetch"},
+	}
+	assert.Equal(t, []string{
+		"engine 'claude' is not allowed (allowed engines: copilot)",
+		"permission issues: write exceeds the maximum 'read'",
+		"network access must be restricted: the agent firewall is disabled",
+		"network access must be restricted: network.allowed must not contain '*'",
+		"tool 'playwright' is banned",
+	}, policy.Violations(workflowData), "violations")
+}
+
+func TestOrgPolicyViolationsInCustomJobs(t *testing.T) {
+	workflowData := &WorkflowData{
+		AI:          "copilot",
+		Permissions: "permissions:\n  contents: read\n",
+		Jobs: map[string]any{
+			"release": map[string]any{"permissions": map[string]any{"contents": "write"}},
+			"publish": map[string]any{"permissions": "write-all"},
+			"lint":    map[string]any{"permissions": map[string]any{"contents": "read"}},
+			"report":  map[string]any{"runs-on": "ubuntu-latest"},
+		},
+	}
+
+	policy := &OrgPolicy{MaxPermissions: map[string]string{"contents": "read"}}
+	assert.Equal(t, []string{
+		"jobs.publish: permission contents: write exceeds the maximum 'read'",
+		"jobs.release: permission contents: write exceeds the maximum 'read'",
+	}, policy.Violations(workflowData), "custom jobs should not exceed the maximum permissions")
+}
+
+func TestCompileWorkflowWithOrgPolicy(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "org-policy-test")
+	workflowFile := filepath.Join(tmpDir, "policy.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+tools:
+  playwright:
+---
+
+# Policy
+`), 0644), "should write workflow")
+
+	allowed := &OrgPolicy{AllowedEngines: []string{"copilot"}, RequireNetworkRestrictions: true, Source: "aw-policy.yml"}
+	require.NoError(t, NewCompiler(WithOrgPolicy(allowed)).CompileWorkflow(workflowFile), "workflow satisfying the policy should compile")
+
+	banned := &OrgPolicy{AllowedEngines: []string{"claude"}, BannedTools: []string{"playwright"}, Source: "aw-policy.yml"}
+	err := NewCompiler(WithOrgPolicy(banned)).CompileWorkflow(workflowFile)
+	require.Error(t, err, "workflow violating the policy should fail")
+	assert.Contains(t, err.Error(), "workflow violates the organization policy aw-policy.yml", "error should name the policy")
+	assert.Contains(t, err.Error(), "engine 'copilot' is not allowed", "error should list the engine violation")
+	assert.Contains(t, err.Error(), "tool 'playwright' is banned", "error should list the tool violation")
+	assert.Contains(t, err.Error(), string(messages.OrgPolicyViolation), "error should carry the diagnostic code")
+
+	promptLint := &OrgPolicy{PromptLint: &PromptLintPolicy{RequiredSections: []string{"Output format"}}, Source: "aw-policy.yml"}
+	err = NewCompiler(WithOrgPolicy(promptLint)).CompileWorkflow(workflowFile)
+	require.Error(t, err, "workflow missing a required section should fail")
+	assert.Contains(t, err.Error(), "prompt is missing required section 'Output format'", "error should list the prompt-lint violation")
+}
@@ -0,0 +1,183 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var permissionProfilesLog = logger.New("workflow:permission_profiles")
+
+// permissionProfileSections lists the frontmatter sections a profile expands into, in display order
+var permissionProfileSections = []string{"permissions", "tools", "safe-outputs"}
+
+// PermissionProfile is a named preset of permissions, tools, and safe outputs selected with
+// the `profile:` frontmatter field. Frontmatter values override the profile defaults per field.
+type PermissionProfile struct {
+	Name        string
+	Description string
+	Sections    func() map[string]map[string]any // Returns fresh copies of the section defaults
+}
+
+// ProfileExpansion records how a workflow's profile was expanded, for `compile --explain-profile`
+type ProfileExpansion struct {
+	WorkflowPath string
+	Profile      string
+	Expanded     string   // Expanded sections after frontmatter overrides, rendered as frontmatter YAML
+	Overrides    []string // Fields set in frontmatter that override the profile, e.g. "tools.bash"
+}
+
+// permissionProfiles holds the built-in profiles by name
+var permissionProfiles = map[string]PermissionProfile{
+	"readonly": {
+		Name:        "readonly",
+		Description: "Read repository content, issues, and pull requests; no write operations",
+		Sections: func() map[string]map[string]any {
+			return map[string]map[string]any{
+				"permissions": {
+					"contents":      "read",
+					"issues":        "read",
+					"pull-requests": "read",
+				},
+				"tools": {
+					"github": map[string]any{"read-only": true, "toolsets": []any{"default"}},
+				},
+			}
+		},
+	},
+	"triage": {
+		Name:        "triage",
+		Description: "Read issues and pull requests; comment on and label them through safe outputs",
+		Sections: func() map[string]map[string]any {
+			return map[string]map[string]any{
+				"permissions": {
+					"contents":      "read",
+					"issues":        "read",
+					"pull-requests": "read",
+				},
+				"tools": {
+					"github": map[string]any{"read-only": true, "toolsets": []any{"default", "labels"}},
+				},
+				"safe-outputs": {
+					"add-comment": map[string]any{"max": 1},
+					"add-labels":  map[string]any{"max": 5},
+				},
+			}
+		},
+	},
+	"developer": {
+		Name:        "developer",
+		Description: "Edit files and run commands in the workspace; propose changes as pull requests through safe outputs",
+		Sections: func() map[string]map[string]any {
+			return map[string]map[string]any{
+				"permissions": {
+					"contents":      "read",
+					"issues":        "read",
+					"pull-requests": "read",
+				},
+				"tools": {
+					"github": map[string]any{"toolsets": []any{"default"}},
+					"edit":   nil,
+					"bash":   true,
+				},
+				"safe-outputs": {
+					"create-pull-request": nil,
+					"add-comment":         map[string]any{"max": 1},
+				},
+			}
+		},
+	},
+}
+
+// GetPermissionProfileNames returns the names of the built-in permission profiles, sorted
+func GetPermissionProfileNames() []string {
+	return slices.Sorted(maps.Keys(permissionProfiles))
+}
+
+// GetPermissionProfile returns the built-in permission profile with the given name
+func GetPermissionProfile(name string) (PermissionProfile, bool) {
+	profile, ok := permissionProfiles[name]
+	return profile, ok
+}
+
+// applyPermissionProfile expands the `profile:` field into the permissions, tools, and
+// safe-outputs sections of the frontmatter. Fields already present in the frontmatter take
+// precedence over the profile defaults, so users can override individual permissions, tools,
+// or safe outputs. The profile field itself is removed after expansion.
+func (c *Compiler) applyPermissionProfile(frontmatter map[string]any, markdownPath string) error {
+	value, exists := frontmatter["profile"]
+	if !exists {
+		return nil
+	}
+	name, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("'profile' must be a string, one of: %s", strings.Join(GetPermissionProfileNames(), ", "))
+	}
+	profile, ok := GetPermissionProfile(name)
+	if !ok {
+		return fmt.Errorf("unknown profile '%s': valid profiles are %s", name, strings.Join(GetPermissionProfileNames(), ", "))
+	}
+	permissionProfilesLog.Printf("Applying profile %s to %s", name, markdownPath)
+
+	expansion := ProfileExpansion{
+		WorkflowPath: markdownPath,
+		Profile:      name,
+	}
+	var expanded yaml.MapSlice
+	defaults := profile.Sections()
+	for _, section := range permissionProfileSections {
+		sectionDefaults, hasDefaults := defaults[section]
+		userValue, hasUserValue := frontmatter[section]
+
+		if !hasDefaults {
+			if hasUserValue {
+				expanded = append(expanded, yaml.MapItem{Key: section, Value: userValue})
+			}
+			continue
+		}
+		if !hasUserValue {
+			frontmatter[section] = sectionDefaults
+			expanded = append(expanded, yaml.MapItem{Key: section, Value: sectionDefaults})
+			continue
+		}
+
+		userMap, isMap := userValue.(map[string]any)
+		if !isMap {
+			// Shorthands such as `permissions: read-all` replace the profile section entirely
+			expansion.Overrides = append(expansion.Overrides, section)
+			expanded = append(expanded, yaml.MapItem{Key: section, Value: userValue})
+			continue
+		}
+
+		merged := maps.Clone(sectionDefaults)
+		for _, key := range slices.Sorted(maps.Keys(userMap)) {
+			if _, isDefault := sectionDefaults[key]; isDefault {
+				expansion.Overrides = append(expansion.Overrides, section+"."+key)
+			}
+			merged[key] = userMap[key]
+		}
+		frontmatter[section] = merged
+		expanded = append(expanded, yaml.MapItem{Key: section, Value: merged})
+	}
+	delete(frontmatter, "profile")
+
+	// Render now: later compilation stages normalize these sections in place
+	yamlBytes, err := yaml.MarshalWithOptions(expanded, DefaultMarshalOptions...)
+	if err != nil {
+		return fmt.Errorf("failed to render profile '%s': %w", name, err)
+	}
+	expansion.Expanded = string(yamlBytes)
+
+	permissionProfilesLog.Printf("Profile %s expanded with %d overrides", name, len(expansion.Overrides))
+	c.profileExpansions = append(c.profileExpansions, expansion)
+	return nil
+}
+
+// GetProfileExpansions returns the profile expansions recorded by this compiler instance
+func (c *Compiler) GetProfileExpansions() []ProfileExpansion {
+	return c.profileExpansions
+}
@@ -0,0 +1,159 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPermissionProfile(t *testing.T) {
+	tests := []struct {
+		name              string
+		frontmatter       map[string]any
+		expectedSections  map[string]any
+		expectedOverrides []string
+		errorSubstring    string
+	}{
+		{
+			name:        "no profile leaves frontmatter unchanged",
+			frontmatter: map[string]any{"permissions": map[string]any{"contents": "read"}},
+			expectedSections: map[string]any{
+				"permissions": map[string]any{"contents": "read"},
+			},
+		},
+		{
+			name:        "readonly expands permissions and tools",
+			frontmatter: map[string]any{"profile": "readonly"},
+			expectedSections: map[string]any{
+				"permissions": map[string]any{"contents": "read", "issues": "read", "pull-requests": "read"},
+				"tools": map[string]any{
+					"github": map[string]any{"read-only": true, "toolsets": []any{"default"}},
+				},
+			},
+		},
+		{
+			name: "frontmatter fields override triage defaults",
+			frontmatter: map[string]any{
+				"profile":      "triage",
+				"permissions":  map[string]any{"discussions": "read"},
+				"tools":        map[string]any{"bash": []any{"jq *"}},
+				"safe-outputs": map[string]any{"add-labels": map[string]any{"allowed": []any{"bug"}}},
+			},
+			expectedSections: map[string]any{
+				"permissions": map[string]any{"contents": "read", "issues": "read", "pull-requests": "read", "discussions": "read"},
+				"tools": map[string]any{
+					"github": map[string]any{"read-only": true, "toolsets": []any{"default", "labels"}},
+					"bash":   []any{"jq *"},
+				},
+				"safe-outputs": map[string]any{
+					"add-comment": map[string]any{"max": 1},
+					"add-labels":  map[string]any{"allowed": []any{"bug"}},
+				},
+			},
+			expectedOverrides: []string{"safe-outputs.add-labels"},
+		},
+		{
+			name: "developer tools can be disabled individually",
+			frontmatter: map[string]any{
+				"profile": "developer",
+				"tools":   map[string]any{"bash": false},
+			},
+			expectedSections: map[string]any{
+				"permissions": map[string]any{"contents": "read", "issues": "read", "pull-requests": "read"},
+				"tools": map[string]any{
+					"github": map[string]any{"toolsets": []any{"default"}},
+					"edit":   nil,
+					"bash":   false,
+				},
+				"safe-outputs": map[string]any{
+					"create-pull-request": nil,
+					"add-comment":         map[string]any{"max": 1},
+				},
+			},
+			expectedOverrides: []string{"tools.bash"},
+		},
+		{
+			name: "permission shorthand replaces the profile section",
+			frontmatter: map[string]any{
+				"profile":     "readonly",
+				"permissions": "read-all",
+			},
+			expectedSections: map[string]any{
+				"permissions": "read-all",
+				"tools": map[string]any{
+					"github": map[string]any{"read-only": true, "toolsets": []any{"default"}},
+				},
+			},
+			expectedOverrides: []string{"permissions"},
+		},
+		{
+			name:           "unknown profile",
+			frontmatter:    map[string]any{"profile": "admin"},
+			errorSubstring: "unknown profile 'admin': valid profiles are developer, readonly, triage",
+		},
+		{
+			name:           "non-string profile",
+			frontmatter:    map[string]any{"profile": 3},
+			errorSubstring: "'profile' must be a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			err := compiler.applyPermissionProfile(tt.frontmatter, "test.md")
+			if tt.errorSubstring != "" {
+				require.Error(t, err, "profile expansion should fail")
+				assert.Contains(t, err.Error(), tt.errorSubstring, "error should describe the invalid profile")
+				return
+			}
+			require.NoError(t, err, "profile expansion should succeed")
+
+			assert.NotContains(t, tt.frontmatter, "profile", "profile field should be removed after expansion")
+			assert.Equal(t, tt.expectedSections, tt.frontmatter, "expanded frontmatter")
+
+			expansions := compiler.GetProfileExpansions()
+			if _, hadProfile := tt.expectedSections["tools"]; !hadProfile {
+				assert.Empty(t, expansions, "no expansion should be recorded without a profile")
+				return
+			}
+			require.Len(t, expansions, 1, "one expansion should be recorded")
+			assert.Equal(t, tt.expectedOverrides, expansions[0].Overrides, "recorded overrides")
+			assert.Contains(t, expansions[0].Expanded, "permissions:", "rendered expansion should include permissions")
+		})
+	}
+}
+
+func TestPermissionProfileCompiles(t *testing.T) {
+	for _, name := range GetPermissionProfileNames() {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "permission-profile-test")
+			workflowContent := `---
+on:
+  issues:
+    types: [opened]
+profile: ` + name + `
+engine: copilot
+---
+
+Handle the issue.
+`
+			workflowFile := filepath.Join(tmpDir, "profile.md")
+			require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "should write workflow file")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(workflowFile), "workflow using profile %s should compile", name)
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+			require.NoError(t, err, "should read lock file")
+			assert.Contains(t, string(lockContent), "pull-requests: read", "profile permissions should be applied")
+		})
+	}
+}
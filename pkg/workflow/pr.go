@@ -41,6 +41,11 @@ func (c *Compiler) generatePRReadyForReviewCheckout(yaml *strings.Builder, data
 		BuildPropertyAccess("github.event.pull_request"),
 		BuildPropertyAccess("github.event.issue.pull_request"),
 	)
+	// pull_request_target runs with base repository secrets, so the untrusted head is never
+	// checked out over the workspace; it is only available via the quarantined checkout-head step.
+	if data.HasPullRequestTarget {
+		condition = BuildAnd(buildPullRequestTargetExclusion(), condition)
+	}
 	RenderConditionAsIf(yaml, condition, "          ")
 
 	// Use actions/github-script instead of shell script
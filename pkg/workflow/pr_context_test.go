@@ -0,0 +1,85 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileWorkflowWithPullRequestContext(t *testing.T) {
+	tests := []struct {
+		name        string
+		markdown    string
+		wantStep    bool
+		wantOutputs bool
+	}{
+		{
+			name:        "step output placeholders",
+			markdown:    "Review commit ${{ steps.pr-context.outputs.head_sha }} touching:\n\n${{ steps.pr-context.outputs.changed_files }}\n",
+			wantStep:    true,
+			wantOutputs: true,
+		},
+		{
+			name:        "activation output placeholders",
+			markdown:    "Review commit ${{ needs.activation.outputs.pr_head_sha }}.\n",
+			wantStep:    true,
+			wantOutputs: true,
+		},
+		{
+			name:     "no placeholders",
+			markdown: "Reply to the comment.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "pr-context-test")
+			workflowPath := filepath.Join(tmpDir, "pr-context.md")
+			content := "---\non:\n  issue_comment:\n    types: [created]\nengine: copilot\npermissions:\n  contents: read\n---\n\n" + tt.markdown
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+			require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+			require.NoError(t, err, "should read lock file")
+			lock := string(lockContent)
+
+			activation := lock[strings.Index(lock, "  activation:"):strings.Index(lock, "  agent:")]
+			if !tt.wantStep {
+				assert.NotContains(t, lock, "resolve_pr_context.cjs", "should not resolve the pull request context")
+				assert.NotContains(t, activation, "pull-requests: read", "should not request pull request access")
+				return
+			}
+			assert.Contains(t, activation, "id: pr-context", "activation job should resolve the pull request context")
+			assert.Contains(t, activation, "resolve_pr_context.cjs", "step should run the resolver script")
+			assert.Contains(t, activation, "pull-requests: read", "activation job should be able to read the pull request")
+			if tt.wantOutputs {
+				assert.Contains(t, activation, "pr_head_sha: ${{ steps.pr-context.outputs.head_sha }}", "head SHA should be exposed to downstream jobs")
+				assert.Contains(t, activation, "pr_changed_files: ${{ steps.pr-context.outputs.changed_files }}", "changed files should be exposed to downstream jobs")
+			}
+		})
+	}
+}
+
+func TestActivationPullRequestContextKeepsWritePermission(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "pr-context-test")
+	workflowPath := filepath.Join(tmpDir, "pr-context.md")
+	content := "---\non:\n  issue_comment:\n    types: [created]\n  reaction: eyes\nengine: copilot\npermissions:\n  contents: read\n---\n\nReview ${{ steps.pr-context.outputs.head_sha }}.\n"
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	activation := lock[strings.Index(lock, "  activation:"):strings.Index(lock, "  agent:")]
+	assert.Contains(t, activation, "pull-requests: write", "reaction write access should be kept")
+	assert.NotContains(t, activation, "pull-requests: read", "write access should not be downgraded")
+}
@@ -0,0 +1,166 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var promptBudgetLog = logger.New("workflow:prompt_budget")
+
+// charsPerToken is the rough number of prompt characters per model token used for estimates
+const charsPerToken = 4
+
+// contextBudgetRatio is the share of the context window the default max-chars leaves for the prompt;
+// the rest is kept for tool results and the agent's own output
+const contextBudgetRatio = 0.8
+
+// Estimated sizes of expressions substituted into the prompt at runtime
+const (
+	payloadBodyEstimateChars  = 65536 // GitHub's limit for issue, pull request, and comment bodies
+	payloadTitleEstimateChars = 256   // GitHub's limit for issue and pull request titles
+	payloadValueEstimateChars = 64    // Other expressions (numbers, names, refs)
+	promptFileEstimateChars   = 2048  // Built-in prompt sections loaded from files at runtime
+)
+
+// engineContextWindowTokens maps each engine to the context window of its default model in tokens.
+// Engines without an entry are not checked.
+var engineContextWindowTokens = map[constants.EngineName]int{
+	constants.CopilotEngine:      128000,
+	constants.ClaudeEngine:       200000,
+	constants.CodexEngine:        272000,
+	constants.GeminiEngine:       1048576,
+	constants.GitHubModelsEngine: 128000,
+}
+
+// validContextStrategies lists the supported prompt truncation strategies for context.strategy
+var validContextStrategies = []string{"head", "tail", "summarize"}
+
+// ContextConfig represents the runtime prompt truncation strategy (context:)
+//
+// Example:
+//
+//	context:
+//	  strategy: summarize
+//	  max-chars: 200000
+type ContextConfig struct {
+	Strategy string `json:"strategy"`            // head keeps the beginning, tail keeps the end, summarize keeps both ends
+	MaxChars int    `json:"max-chars,omitempty"` // Maximum prompt size in characters (default: derived from the engine's context window)
+}
+
+// extractContextConfig extracts the prompt truncation strategy from frontmatter
+func (c *Compiler) extractContextConfig(frontmatter map[string]any, engineID string) (*ContextConfig, error) {
+	contextValue, exists := frontmatter["context"]
+	if !exists || contextValue == nil {
+		return nil, nil
+	}
+
+	contextMap, ok := contextValue.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("context must be an object, got %T. Example:\ncontext:\n  strategy: head\n  max-chars: 200000", contextValue)
+	}
+
+	config := &ContextConfig{Strategy: "head"}
+	if strategy, exists := contextMap["strategy"]; exists {
+		strategyStr, ok := strategy.(string)
+		if !ok || !slices.Contains(validContextStrategies, strategyStr) {
+			return nil, fmt.Errorf("context.strategy must be one of %s, got %v", strings.Join(validContextStrategies, ", "), strategy)
+		}
+		config.Strategy = strategyStr
+	}
+
+	if maxChars, exists := contextMap["max-chars"]; exists {
+		value, ok := parseIntValue(maxChars)
+		if !ok || value <= 0 {
+			return nil, fmt.Errorf("context.max-chars must be a positive integer, got %v", maxChars)
+		}
+		config.MaxChars = value
+	} else if window, ok := engineContextWindowTokens[constants.EngineName(engineID)]; ok {
+		config.MaxChars = int(float64(window*charsPerToken) * contextBudgetRatio)
+	} else {
+		return nil, fmt.Errorf("context.max-chars is required for engine '%s' because its context window is unknown", engineID)
+	}
+
+	promptBudgetLog.Printf("Extracted context: strategy=%s, max_chars=%d", config.Strategy, config.MaxChars)
+	return config, nil
+}
+
+// estimatePromptChars estimates the size of the rendered prompt in characters: the workflow
+// markdown (including imports), the built-in prompt sections, and the expressions substituted at runtime
+func (c *Compiler) estimatePromptChars(data *WorkflowData) int {
+	total := len(data.MarkdownContent)
+
+	for _, section := range c.collectPromptSections(data) {
+		if section.IsFile {
+			total += promptFileEstimateChars
+		} else {
+			total += len(section.Content)
+		}
+	}
+
+	mappings, err := NewExpressionExtractor().ExtractExpressions(data.MarkdownContent)
+	if err != nil {
+		return total
+	}
+	for _, mapping := range mappings {
+		total += estimateExpressionChars(mapping.Content) - len(mapping.Original)
+	}
+	return total
+}
+
+// estimateExpressionChars estimates the substituted size of a prompt expression
+func estimateExpressionChars(expression string) int {
+	switch {
+	case strings.HasSuffix(expression, ".body"), strings.HasSuffix(expression, ".text"):
+		return payloadBodyEstimateChars
+	case strings.HasSuffix(expression, ".title"):
+		return payloadTitleEstimateChars
+	default:
+		return payloadValueEstimateChars
+	}
+}
+
+// promptBudgetWarnings returns warnings when the rendered prompt likely exceeds the engine's context window
+func (c *Compiler) promptBudgetWarnings(data *WorkflowData) []string {
+	engine, err := c.getAgenticEngine(data.AI)
+	if err != nil {
+		return nil
+	}
+	engineID := engine.GetID()
+	window, ok := engineContextWindowTokens[constants.EngineName(engineID)]
+	if !ok {
+		return nil
+	}
+
+	var warnings []string
+	if data.Context != nil {
+		if data.Context.MaxChars/charsPerToken > window {
+			warnings = append(warnings, fmt.Sprintf("context.max-chars %d (~%d tokens) exceeds the %d token context window of engine '%s'; the truncated prompt may still not fit", data.Context.MaxChars, data.Context.MaxChars/charsPerToken, window, engineID))
+		}
+		return warnings
+	}
+
+	estimatedTokens := c.estimatePromptChars(data) / charsPerToken
+	promptBudgetLog.Printf("Estimated prompt size: %d tokens (context window: %d)", estimatedTokens, window)
+	if estimatedTokens > window {
+		warnings = append(warnings, fmt.Sprintf("The rendered prompt is estimated at ~%d tokens (markdown, imports, and event payloads), which exceeds the %d token context window of engine '%s'. Reduce the prompt or set context.strategy to truncate it at runtime", estimatedTokens, window, engineID))
+	}
+	return warnings
+}
+
+// generateContextTruncationStep generates the step that truncates the rendered prompt to context.max-chars
+func generateContextTruncationStep(yaml *strings.Builder, data *WorkflowData) {
+	if data.Context == nil {
+		return
+	}
+
+	yaml.WriteString("      - name: Truncate prompt\n")
+	yaml.WriteString("        env:\n")
+	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	fmt.Fprintf(yaml, "          GH_AW_CONTEXT_STRATEGY: %s\n", data.Context.Strategy)
+	fmt.Fprintf(yaml, "          GH_AW_CONTEXT_MAX_CHARS: %d\n", data.Context.MaxChars)
+	yaml.WriteString("        run: node /opt/gh-aw/actions/truncate_prompt.cjs\n")
+}
@@ -0,0 +1,151 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractContextConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		engineID    string
+		expected    *ContextConfig
+		wantErr     string
+	}{
+		{
+			name:        "no context",
+			frontmatter: map[string]any{},
+			engineID:    "claude",
+		},
+		{
+			name:        "explicit configuration",
+			frontmatter: map[string]any{"context": map[string]any{"strategy": "summarize", "max-chars": 50000}},
+			engineID:    "claude",
+			expected:    &ContextConfig{Strategy: "summarize", MaxChars: 50000},
+		},
+		{
+			name:        "max-chars defaults from the engine context window",
+			frontmatter: map[string]any{"context": map[string]any{"strategy": "tail"}},
+			engineID:    "copilot",
+			expected:    &ContextConfig{Strategy: "tail", MaxChars: 409600},
+		},
+		{
+			name:        "strategy defaults to head",
+			frontmatter: map[string]any{"context": map[string]any{"max-chars": 1000}},
+			engineID:    "codex",
+			expected:    &ContextConfig{Strategy: "head", MaxChars: 1000},
+		},
+		{
+			name:        "context must be an object",
+			frontmatter: map[string]any{"context": "head"},
+			engineID:    "claude",
+			wantErr:     "context must be an object",
+		},
+		{
+			name:        "invalid strategy",
+			frontmatter: map[string]any{"context": map[string]any{"strategy": "middle"}},
+			engineID:    "claude",
+			wantErr:     "context.strategy must be one of head, tail, summarize",
+		},
+		{
+			name:        "invalid max-chars",
+			frontmatter: map[string]any{"context": map[string]any{"max-chars": 0}},
+			engineID:    "claude",
+			wantErr:     "context.max-chars must be a positive integer",
+		},
+		{
+			name:        "max-chars required for unknown engines",
+			frontmatter: map[string]any{"context": map[string]any{"strategy": "head"}},
+			engineID:    "custom",
+			wantErr:     "context.max-chars is required for engine 'custom'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			config, err := compiler.extractContextConfig(tt.frontmatter, tt.engineID)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected extraction error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error should describe the invalid context budget")
+				return
+			}
+			require.NoError(t, err, "Extraction should succeed")
+			assert.Equal(t, tt.expected, config, "Context config should match")
+		})
+	}
+}
+
+func TestEstimateExpressionChars(t *testing.T) {
+	assert.Equal(t, payloadBodyEstimateChars, estimateExpressionChars("github.event.issue.body"), "Bodies use GitHub's body limit")
+	assert.Equal(t, payloadBodyEstimateChars, estimateExpressionChars("steps.sanitized.outputs.text"), "Sanitized text uses GitHub's body limit")
+	assert.Equal(t, payloadTitleEstimateChars, estimateExpressionChars("github.event.pull_request.title"), "Titles use GitHub's title limit")
+	assert.Equal(t, payloadValueEstimateChars, estimateExpressionChars("github.event.issue.number"), "Other values are small")
+}
+
+func TestPromptBudgetWarnings(t *testing.T) {
+	compiler := NewCompiler()
+
+	small := &WorkflowData{AI: "copilot", MarkdownContent: "# Triage\n\nTriage issue ${{ github.event.issue.number }}: ${{ github.event.issue.body }}"}
+	assert.Empty(t, compiler.promptBudgetWarnings(small), "Small prompts should not warn")
+
+	large := &WorkflowData{AI: "copilot", MarkdownContent: strings.Repeat("Review every file in detail. ", 20000)}
+	warnings := compiler.promptBudgetWarnings(large)
+	require.Len(t, warnings, 1, "Large prompts should warn")
+	assert.Contains(t, warnings[0], "exceeds the 128000 token context window of engine 'copilot'", "Warning should name the context window")
+	assert.Contains(t, warnings[0], "context.strategy", "Warning should suggest runtime truncation")
+
+	large.Context = &ContextConfig{Strategy: "head", MaxChars: 400000}
+	assert.Empty(t, compiler.promptBudgetWarnings(large), "Truncated prompts within the window should not warn")
+
+	large.Context = &ContextConfig{Strategy: "head", MaxChars: 1000000}
+	warnings = compiler.promptBudgetWarnings(large)
+	require.Len(t, warnings, 1, "A budget above the context window should warn")
+	assert.Contains(t, warnings[0], "context.max-chars 1000000", "Warning should name the configured budget")
+
+	large.AI = "custom"
+	large.Context = nil
+	assert.Empty(t, compiler.promptBudgetWarnings(large), "Engines with unknown context windows are not checked")
+}
+
+func TestContextTruncationStepCompiled(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "prompt-budget-*")
+	workflowsDir := filepath.Join(tmpDir, constants.GetWorkflowDir())
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows directory")
+
+	content := `---
+on: workflow_dispatch
+engine: claude
+context:
+  strategy: summarize
+  max-chars: 120000
+---
+
+# Context budget test
+`
+	workflowFile := filepath.Join(workflowsDir, "context-budget.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "Workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "context-budget.lock.yml"))
+	require.NoError(t, err, "Lock file should be created")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "- name: Truncate prompt", "Truncation step should be generated")
+	assert.Contains(t, lock, "GH_AW_CONTEXT_STRATEGY: summarize", "Strategy should be passed to the script")
+	assert.Contains(t, lock, "GH_AW_CONTEXT_MAX_CHARS: 120000", "Budget should be passed to the script")
+	assert.Less(t, strings.Index(lock, "- name: Validate prompt placeholders"), strings.Index(lock, "- name: Truncate prompt"), "Truncation should run after the prompt is rendered")
+	assert.Less(t, strings.Index(lock, "- name: Truncate prompt"), strings.Index(lock, "- name: Print prompt"), "Printed prompt should be the truncated one")
+}
@@ -0,0 +1,110 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var promptLintLog = logger.New("workflow:prompt_lint")
+
+// markdownHeadingPattern matches ATX headings and captures their text
+var markdownHeadingPattern = regexp.MustCompile(`^ {0,3}#{1,6}\s+(.*?)\s*#*\s*$`)
+
+// PromptLintPolicy holds the prompt-lint rules of an organization policy (prompt-lint:).
+// The rules are checked against the markdown body of each workflow together with its
+// compile-time imports.
+//
+// Example:
+//
+//	prompt-lint:
+//	  forbidden-phrases:
+//	    - ignore previous instructions
+//	  forbidden-patterns:
+//	    - '\b[A-Z][A-Z0-9_]*_(TOKEN|SECRET)\b'
+//	  required-sections:
+//	    - Output format
+type PromptLintPolicy struct {
+	ForbiddenPhrases  []string `yaml:"forbidden-phrases,omitempty"`  // Phrases the prompt must not contain (case and whitespace insensitive)
+	ForbiddenPatterns []string `yaml:"forbidden-patterns,omitempty"` // Regular expressions the prompt must not match
+	RequiredSections  []string `yaml:"required-sections,omitempty"`  // Headings the prompt must contain (case insensitive)
+}
+
+// Validate checks that every phrase and section is non-empty and every pattern compiles
+func (p *PromptLintPolicy) Validate() error {
+	for _, phrase := range p.ForbiddenPhrases {
+		if strings.TrimSpace(phrase) == "" {
+			return fmt.Errorf("prompt-lint.forbidden-phrases: phrases must not be empty")
+		}
+	}
+	for _, pattern := range p.ForbiddenPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("prompt-lint.forbidden-patterns: invalid regular expression '%s': %w", pattern, err)
+		}
+	}
+	for _, section := range p.RequiredSections {
+		if strings.TrimSpace(section) == "" {
+			return fmt.Errorf("prompt-lint.required-sections: sections must not be empty")
+		}
+	}
+	return nil
+}
+
+// Violations returns a description of every prompt-lint rule the markdown does not satisfy
+func (p *PromptLintPolicy) Violations(markdown string) []string {
+	var violations []string
+
+	normalized := normalizePromptText(markdown)
+	for _, phrase := range p.ForbiddenPhrases {
+		if strings.Contains(normalized, normalizePromptText(phrase)) {
+			violations = append(violations, fmt.Sprintf("prompt contains forbidden phrase '%s'", phrase))
+		}
+	}
+
+	for _, pattern := range p.ForbiddenPatterns {
+		// Patterns are validated when the policy is loaded
+		if match := regexp.MustCompile(pattern).FindString(markdown); match != "" {
+			violations = append(violations, fmt.Sprintf("prompt matches forbidden pattern '%s': '%s'", pattern, match))
+		}
+	}
+
+	if len(p.RequiredSections) > 0 {
+		headings := extractMarkdownHeadings(markdown)
+		for _, section := range p.RequiredSections {
+			if !slices.Contains(headings, normalizePromptText(section)) {
+				violations = append(violations, fmt.Sprintf("prompt is missing required section '%s'", section))
+			}
+		}
+	}
+
+	promptLintLog.Printf("Linted prompt: %d bytes, %d violation(s)", len(markdown), len(violations))
+	return violations
+}
+
+// normalizePromptText lowercases text and collapses whitespace so phrases match across line breaks
+func normalizePromptText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// extractMarkdownHeadings returns the normalized text of every heading outside fenced code blocks
+func extractMarkdownHeadings(markdown string) []string {
+	var headings []string
+	inFence := false
+	for line := range strings.SplitSeq(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if match := markdownHeadingPattern.FindStringSubmatch(line); match != nil {
+			headings = append(headings, normalizePromptText(match[1]))
+		}
+	}
+	return headings
+}
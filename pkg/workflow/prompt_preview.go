@@ -0,0 +1,325 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/goccy/go-yaml"
+)
+
+var promptPreviewLog = logger.New("workflow:prompt_preview")
+
+var (
+	// runtimeImportMacroPattern matches {{#runtime-import path}} and {{#runtime-import? path}}
+	runtimeImportMacroPattern = regexp.MustCompile(`\{\{#runtime-import(\?)?[ \t]+([^\}]+?)\}\}`)
+	// runtimeImportRangePattern matches the optional :start-end line range of a runtime import
+	runtimeImportRangePattern = regexp.MustCompile(`^(.+?):(\d+)-(\d+)$`)
+	// blockConditionalPattern matches {{#if}} blocks whose tags are on their own lines
+	blockConditionalPattern = regexp.MustCompile(`(\n?)([ \t]*\{\{#if\s+([^}]*)\}\}[ \t]*\n)([\s\S]*?)([ \t]*\{\{/if\}\}[ \t]*)(\n?)`)
+	// inlineConditionalPattern matches the remaining inline {{#if}} blocks
+	inlineConditionalPattern = regexp.MustCompile(`\{\{#if\s+([^}]*)\}\}([\s\S]*?)\{\{/if\}\}`)
+	// excessiveBlankLinesPattern matches runs of more than one blank line
+	excessiveBlankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// activationJobChain lists the jobs that must all run for the agent to start, in order
+var activationJobChain = []string{"pre_activation", "activation", "agent"}
+
+// ActivationDecision describes whether a compiled workflow would start its agent for an event
+type ActivationDecision struct {
+	Activated bool   `json:"activated"`
+	Reason    string `json:"reason,omitempty"` // why the workflow did not activate
+}
+
+// EvaluateActivation decides offline whether a compiled workflow would run its agent for the
+// event described by contexts. It checks that the event and its activity type are among the
+// workflow's triggers, then evaluates the if: conditions of the pre-activation, activation and
+// agent jobs. The pre-activation job's API checks (team membership, stop-time, skip-if-match)
+// cannot run offline and are assumed to pass unless contexts["needs"] overrides its outputs.
+func EvaluateActivation(lockYAML string, contexts map[string]any) (*ActivationDecision, error) {
+	var lock map[string]any
+	if err := yaml.Unmarshal([]byte(lockYAML), &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse compiled workflow: %w", err)
+	}
+
+	github, _ := contexts["github"].(map[string]any)
+	eventName, _ := github["event_name"].(string)
+	event, _ := github["event"].(map[string]any)
+	action, _ := event["action"].(string)
+
+	if reason := matchWorkflowTrigger(lock["on"], eventName, action); reason != "" {
+		promptPreviewLog.Printf("Trigger does not match: %s", reason)
+		return &ActivationDecision{Reason: reason}, nil
+	}
+
+	jobs, _ := lock["jobs"].(map[string]any)
+	fixtureNeeds, _ := contexts["needs"].(map[string]any)
+	needs := map[string]any{}
+	for name, value := range fixtureNeeds {
+		needs[name] = value
+	}
+
+	for _, jobName := range activationJobChain {
+		job, ok := jobs[jobName].(map[string]any)
+		if !ok {
+			continue
+		}
+		jobContexts := make(map[string]any, len(contexts)+1)
+		for name, value := range contexts {
+			jobContexts[name] = value
+		}
+		jobContexts["needs"] = needs
+
+		if condition, ok := job["if"].(string); ok && strings.TrimSpace(condition) != "" {
+			value, err := EvaluateExpression(strings.TrimSpace(condition), jobContexts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate %s job condition: %w", jobName, err)
+			}
+			if !IsExpressionTruthy(value) {
+				promptPreviewLog.Printf("Job %s condition is false", jobName)
+				return &ActivationDecision{Reason: jobName + " job condition is false"}, nil
+			}
+		}
+
+		// Jobs that ran successfully expose their outputs to the next job in the chain
+		if _, overridden := fixtureNeeds[jobName]; !overridden {
+			outputs := map[string]any{}
+			if jobName == "pre_activation" {
+				outputs["activated"] = "true"
+			}
+			needs[jobName] = map[string]any{"result": "success", "outputs": outputs}
+		}
+	}
+
+	return &ActivationDecision{Activated: true}, nil
+}
+
+// matchWorkflowTrigger returns why an event does not trigger the workflow, or an empty
+// string when the event and its activity type are listed in the on: section
+func matchWorkflowTrigger(on any, eventName, action string) string {
+	if eventName == "" {
+		return "the fixture does not set an event name"
+	}
+
+	var eventConfig any
+	switch v := on.(type) {
+	case string:
+		if v != eventName {
+			return fmt.Sprintf("the workflow is not triggered by %s", eventName)
+		}
+		return ""
+	case []any:
+		for _, item := range v {
+			if item == eventName {
+				return ""
+			}
+		}
+		return fmt.Sprintf("the workflow is not triggered by %s", eventName)
+	case map[string]any:
+		config, ok := v[eventName]
+		if !ok {
+			return fmt.Sprintf("the workflow is not triggered by %s", eventName)
+		}
+		eventConfig = config
+	default:
+		return "the workflow has no triggers"
+	}
+
+	configMap, ok := eventConfig.(map[string]any)
+	if !ok || action == "" {
+		return ""
+	}
+	var types []string
+	switch typesVal := configMap["types"].(type) {
+	case []any:
+		for _, t := range typesVal {
+			if s, ok := t.(string); ok {
+				types = append(types, s)
+			}
+		}
+	case string:
+		types = []string{typesVal}
+	}
+	if len(types) > 0 && !slices.Contains(types, action) {
+		return fmt.Sprintf("%s activity type '%s' is not in types [%s]", eventName, action, strings.Join(types, ", "))
+	}
+	return ""
+}
+
+// RenderPromptPreview renders the user prompt of a workflow the way the activation job does
+// at runtime: runtime imports are inlined, the trigger sections of other events are removed,
+// ${{ }} expressions are evaluated against contexts, and {{#if}} template conditionals are
+// applied. The built-in system sections the compiler adds (safe outputs, tools, and so on)
+// are not included.
+func RenderPromptPreview(data *WorkflowData, markdownPath string, contexts map[string]any) (string, error) {
+	workspaceRoot := resolveWorkspaceRoot(markdownPath)
+
+	var parts []string
+	if data.ImportedMarkdown != "" {
+		imported := removeXMLComments(data.ImportedMarkdown)
+		if len(data.ImportInputs) > 0 {
+			imported = SubstituteImportInputs(imported, data.ImportInputs)
+		}
+		parts = append(parts, imported)
+	}
+	for _, importPath := range data.ImportPaths {
+		parts = append(parts, fmt.Sprintf("{{#runtime-import %s}}", filepath.ToSlash(importPath)))
+	}
+	parts = append(parts, data.MainWorkflowMarkdown)
+
+	content, err := expandRuntimeImports(strings.Join(parts, "\n"), workspaceRoot, nil)
+	if err != nil {
+		return "", err
+	}
+	content = removeXMLComments(content)
+	if len(findTriggerSections(data.MarkdownContent)) > 0 {
+		github, _ := contexts["github"].(map[string]any)
+		eventName, _ := github["event_name"].(string)
+		content = filterTriggerSections(content, eventName)
+	}
+	content = wrapExpressionsInTemplateConditionals(content)
+
+	var evalErr error
+	content = ExpressionPatternDotAll.ReplaceAllStringFunc(content, func(match string) string {
+		value, err := EvaluateExpression(match, contexts)
+		if err != nil {
+			evalErr = errors.Join(evalErr, fmt.Errorf("%s: %w", match, err))
+			return match
+		}
+		return FormatExpressionValue(value)
+	})
+	if evalErr != nil {
+		return "", fmt.Errorf("failed to evaluate prompt expressions: %w", evalErr)
+	}
+
+	content = renderTemplateConditionals(content)
+	promptPreviewLog.Printf("Rendered prompt preview: %d bytes", len(content))
+	return strings.TrimSpace(content) + "\n", nil
+}
+
+// expandRuntimeImports replaces runtime-import macros with the content of the imported files,
+// resolving paths the same way runtime_import.cjs does. URL imports are left in place since
+// they cannot be fetched offline.
+func expandRuntimeImports(content, workspaceRoot string, stack []string) (string, error) {
+	var importErr error
+	result := runtimeImportMacroPattern.ReplaceAllStringFunc(content, func(match string) string {
+		if importErr != nil {
+			return match
+		}
+		groups := runtimeImportMacroPattern.FindStringSubmatch(match)
+		optional := groups[1] == "?"
+		target := strings.TrimSpace(groups[2])
+		if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+			return match
+		}
+		if slices.Contains(stack, target) {
+			importErr = fmt.Errorf("circular runtime import: %s", strings.Join(append(stack, target), " -> "))
+			return match
+		}
+
+		imported, err := readRuntimeImport(target, optional, workspaceRoot)
+		if err == nil && strings.Contains(imported, "{{#runtime-import") {
+			imported, err = expandRuntimeImports(imported, workspaceRoot, append(stack, target))
+		}
+		if err != nil {
+			importErr = fmt.Errorf("runtime import %s: %w", target, err)
+			return match
+		}
+		return imported
+	})
+	return result, importErr
+}
+
+// readRuntimeImport reads a runtime-imported file, applying its optional line range and
+// dropping its frontmatter
+func readRuntimeImport(target string, optional bool, workspaceRoot string) (string, error) {
+	filePath := target
+	startLine, endLine := 0, 0
+	if rangeMatch := runtimeImportRangePattern.FindStringSubmatch(target); rangeMatch != nil {
+		filePath = rangeMatch[1]
+		startLine, _ = strconv.Atoi(rangeMatch[2])
+		endLine, _ = strconv.Atoi(rangeMatch[3])
+	}
+
+	var resolved string
+	switch {
+	case strings.HasPrefix(filePath, ".agents/"):
+		resolved = filepath.Join(workspaceRoot, filePath)
+	case strings.HasPrefix(filePath, ".github/"):
+		resolved = filepath.Join(workspaceRoot, ".github", strings.TrimPrefix(filePath, ".github/"))
+	default:
+		resolved = filepath.Join(workspaceRoot, ".github", "workflows", strings.TrimPrefix(filePath, "./"))
+	}
+	githubDir := filepath.Join(workspaceRoot, ".github")
+	agentsDir := filepath.Join(workspaceRoot, ".agents")
+	if !isWithinDir(resolved, githubDir) && !isWithinDir(resolved, agentsDir) {
+		return "", errors.New("path must be within the .github or .agents folder")
+	}
+
+	data, err := os.ReadFile(resolved)
+	if err != nil {
+		if optional && os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	content := string(data)
+
+	if startLine > 0 {
+		lines := strings.Split(content, "\n")
+		if startLine > len(lines) || endLine > len(lines) || startLine > endLine {
+			return "", fmt.Errorf("invalid line range %d-%d (file has %d lines)", startLine, endLine, len(lines))
+		}
+		content = strings.Join(lines[startLine-1:endLine], "\n")
+	}
+
+	if trimmed := strings.TrimSpace(content); strings.HasPrefix(trimmed, "---") || strings.HasPrefix(trimmed, parser.TOMLFrontmatterDelimiter) {
+		if body, err := parser.ExtractMarkdownContent(content); err == nil {
+			content = body
+		}
+	}
+	return removeXMLComments(content), nil
+}
+
+// isWithinDir reports whether path is dir or lies inside it
+func isWithinDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// renderTemplateConditionals applies {{#if}} blocks whose conditions have already been
+// evaluated, matching renderMarkdownTemplate in interpolate_prompt.cjs
+func renderTemplateConditionals(markdown string) string {
+	result := blockConditionalPattern.ReplaceAllStringFunc(markdown, func(match string) string {
+		groups := blockConditionalPattern.FindStringSubmatch(match)
+		if isTemplateConditionTruthy(groups[3]) {
+			return groups[1] + groups[4]
+		}
+		return ""
+	})
+	result = inlineConditionalPattern.ReplaceAllStringFunc(result, func(match string) string {
+		groups := inlineConditionalPattern.FindStringSubmatch(match)
+		if isTemplateConditionTruthy(groups[1]) {
+			return groups[2]
+		}
+		return ""
+	})
+	return excessiveBlankLinesPattern.ReplaceAllString(result, "\n\n")
+}
+
+// isTemplateConditionTruthy matches isTruthy in is_truthy.cjs
+func isTemplateConditionTruthy(condition string) bool {
+	switch strings.ToLower(strings.TrimSpace(condition)) {
+	case "", "false", "0", "null", "undefined":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateActivation(t *testing.T) {
+	lockYAML := `on:
+  issues:
+    types: [opened, edited]
+jobs:
+  pre_activation:
+    runs-on: ubuntu-latest
+  activation:
+    needs: pre_activation
+    if: needs.pre_activation.outputs.activated == 'true' && github.event.issue.user.login != 'bot'
+  agent:
+    needs: activation
+`
+	issueEvent := func(action, login string) map[string]any {
+		return map[string]any{"github": map[string]any{
+			"event_name": "issues",
+			"event": map[string]any{
+				"action": action,
+				"issue":  map[string]any{"user": map[string]any{"login": login}},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		contexts  map[string]any
+		activated bool
+		reason    string
+	}{
+		{name: "matching event", contexts: issueEvent("opened", "octocat"), activated: true},
+		{name: "other activity type", contexts: issueEvent("closed", "octocat"), reason: "issues activity type 'closed' is not in types [opened, edited]"},
+		{name: "other event", contexts: map[string]any{"github": map[string]any{"event_name": "push"}}, reason: "the workflow is not triggered by push"},
+		{name: "job condition false", contexts: issueEvent("opened", "bot"), reason: "activation job condition is false"},
+		{
+			name: "pre-activation output overridden",
+			contexts: func() map[string]any {
+				contexts := issueEvent("opened", "octocat")
+				contexts["needs"] = map[string]any{"pre_activation": map[string]any{"outputs": map[string]any{"activated": "false"}}}
+				return contexts
+			}(),
+			reason: "activation job condition is false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := EvaluateActivation(lockYAML, tt.contexts)
+			require.NoError(t, err, "activation should evaluate")
+			assert.Equal(t, tt.activated, decision.Activated, "activation decision should match")
+			assert.Equal(t, tt.reason, decision.Reason, "reason should match")
+		})
+	}
+}
+
+func TestRenderPromptPreview(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "prompt-preview-test")
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(filepath.Join(workflowsDir, "shared"), 0755), "should create workflows dir")
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "shared", "guidelines.md"), []byte("---\ndescription: shared\n---\n\nBe concise with ${{ github.actor }}.\n"), 0644), "should write import")
+
+	data := &WorkflowData{
+		MainWorkflowMarkdown: `# Triage
+
+<!-- maintainers only -->
+Issue #${{ github.event.issue.number }}: ${{ github.event.issue.title }}
+
+{{#if github.event.issue.body}}
+Body: ${{ github.event.issue.body }}
+{{/if}}
+
+{{#if github.event.issue.pull_request}}
+This is a pull request.
+{{/if}}
+
+{{#runtime-import shared/guidelines.md}}
+{{#runtime-import? shared/missing.md}}
+`,
+	}
+	contexts := map[string]any{"github": map[string]any{
+		"actor": "octocat",
+		"event": map[string]any{"issue": map[string]any{"number": float64(7), "title": "Broken build", "body": "Logs attached"}},
+	}}
+
+	prompt, err := RenderPromptPreview(data, filepath.Join(workflowsDir, "triage.md"), contexts)
+	require.NoError(t, err, "prompt should render")
+	assert.Equal(t, "# Triage\n\nIssue #7: Broken build\n\nBody: Logs attached\n\nBe concise with octocat.\n", prompt, "rendered prompt should match")
+}
+
+func TestRenderPromptPreviewRejectsImportsOutsideGithub(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "prompt-preview-escape-test")
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "should create workflows dir")
+
+	data := &WorkflowData{MainWorkflowMarkdown: "{{#runtime-import ../../secret.md}}\n"}
+	_, err := RenderPromptPreview(data, filepath.Join(workflowsDir, "triage.md"), map[string]any{})
+	require.Error(t, err, "imports outside .github should be rejected")
+	assert.Contains(t, err.Error(), "within the .github or .agents folder", "error should explain the restriction")
+}
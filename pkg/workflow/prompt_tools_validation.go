@@ -0,0 +1,172 @@
+// This file provides reachability analysis of prompt instructions against configured tools.
+//
+// # Prompt Tools Validation
+//
+// The agent can only act on GitHub through safe outputs and can only reach the web through
+// the web-search and web-fetch tools. A prompt that says "open a pull request" in a workflow
+// without create-pull-request makes the agent either give up or report success for work that
+// never happens, and the run still succeeds.
+//
+// This file matches common instruction phrases in the prompt against the workflow
+// configuration and warns about instructions no configured tool allows. The phrases are
+// heuristics, so findings are always warnings, also in strict mode. Fenced code blocks,
+// HTML comments, negated sentences ("do not open a pull request") and descriptions of what
+// others do ("the coding agent will open a pull request") are ignored.
+//
+// # Validation Functions
+//
+//   - validatePromptToolReachability() - Warns about prompt instructions without a matching tool
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var promptToolsValidationLog = newValidationLogger("prompt_tools")
+
+// promptToolRule maps instruction phrases to the tool that carries them out
+type promptToolRule struct {
+	pattern    *regexp.Regexp
+	tool       string                        // configuration that enables the action, e.g. safe-outputs.create-issue
+	configured func(data *WorkflowData) bool // reports whether the workflow enables the action
+}
+
+// safeOutputConfigured returns a check for a safe output type
+func safeOutputConfigured(enabled func(*SafeOutputsConfig) bool) func(*WorkflowData) bool {
+	return func(data *WorkflowData) bool {
+		return data.SafeOutputs != nil && enabled(data.SafeOutputs)
+	}
+}
+
+// toolConfigured returns a check for a tool under tools:
+func toolConfigured(name string) func(*WorkflowData) bool {
+	return func(data *WorkflowData) bool {
+		value, ok := data.Tools[name]
+		return ok && value != false
+	}
+}
+
+var promptToolRules = []promptToolRule{
+	{
+		pattern: regexp.MustCompile(`(?i)\b(?:open|create|submit|raise|file)\s+(?:a|an)\s+(?:new\s+|draft\s+)?(?:pull\s+request|PR)\b`),
+		tool:    "safe-outputs.create-pull-request",
+		// The Copilot coding agent opens the pull request when the work is handed off to it
+		configured: safeOutputConfigured(func(s *SafeOutputsConfig) bool {
+			return s.CreatePullRequests != nil || s.AssignToAgent != nil || s.CreateAgentSessions != nil
+		}),
+	},
+	{
+		pattern:    regexp.MustCompile(`(?i)\bpush\s+(?:the\s+|your\s+)?(?:changes|commits?|fix(?:es)?)\s+to\s+(?:the\s+)?(?:pull\s+request|PR)\b`),
+		tool:       "safe-outputs.push-to-pull-request-branch",
+		configured: safeOutputConfigured(func(s *SafeOutputsConfig) bool { return s.PushToPullRequestBranch != nil }),
+	},
+	{
+		pattern:    regexp.MustCompile(`(?i)\b(?:open|create|file|raise)\s+(?:a|an)\s+(?:new\s+)?issue\b`),
+		tool:       "safe-outputs.create-issue",
+		configured: safeOutputConfigured(func(s *SafeOutputsConfig) bool { return s.CreateIssues != nil }),
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)\bclose\s+(?:the|this)\s+issue\b`),
+		tool:    "safe-outputs.close-issue",
+		configured: safeOutputConfigured(func(s *SafeOutputsConfig) bool {
+			return s.CloseIssues != nil || (s.UpdateIssues != nil && s.UpdateIssues.Status != nil)
+		}),
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)\b(?:add|post|leave|write)\s+(?:a|an)\s+(?:short\s+|brief\s+)?comment\b|\bcomment\s+on\s+(?:the|this)\s+(?:issue|pull\s+request|PR|discussion)\b`),
+		tool:    "safe-outputs.add-comment",
+		// Closing an issue, discussion or pull request and marking a pull request ready post a comment too
+		configured: safeOutputConfigured(func(s *SafeOutputsConfig) bool {
+			return s.AddComments != nil || s.CloseIssues != nil || s.CloseDiscussions != nil ||
+				s.ClosePullRequests != nil || s.MarkPullRequestAsReadyForReview != nil
+		}),
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)\b(?:add|apply)\s+(?:the\s+)?(?:appropriate\s+|relevant\s+)?labels?\b|\blabel\s+(?:the|this)\s+(?:issue|pull\s+request|PR)\b`),
+		tool:    "safe-outputs.add-labels",
+		configured: safeOutputConfigured(func(s *SafeOutputsConfig) bool {
+			return s.AddLabels != nil || (s.UpdateIssues != nil && s.UpdateIssues.Labels != nil)
+		}),
+	},
+	{
+		pattern:    regexp.MustCompile(`(?i)\b(?:create|start|open)\s+(?:a|an)\s+(?:new\s+)?discussion\b`),
+		tool:       "safe-outputs.create-discussion",
+		configured: safeOutputConfigured(func(s *SafeOutputsConfig) bool { return s.CreateDiscussions != nil }),
+	},
+	{
+		pattern:    regexp.MustCompile(`(?i)\bsearch\s+(?:the\s+)?(?:web|internet)\b|\bweb\s+search\b`),
+		tool:       "tools.web-search",
+		configured: toolConfigured("web-search"),
+	},
+	{
+		pattern:    regexp.MustCompile(`(?i)\bfetch\s+(?:the\s+)?(?:url|web\s*page|website)s?\b`),
+		tool:       "tools.web-fetch",
+		configured: toolConfigured("web-fetch"),
+	},
+}
+
+var (
+	// promptFencedCodeRegex matches fenced code blocks, whose text is not an instruction
+	promptFencedCodeRegex = regexp.MustCompile("(?ms)^\\s*(```|~~~).*?^\\s*(```|~~~)\\s*$")
+	// promptHTMLCommentRegex matches HTML comments
+	promptHTMLCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// promptSentenceSplitRegex splits the prompt into sentences and list items
+	promptSentenceSplitRegex = regexp.MustCompile(`[.!?;]\s+|\n\s*\n|\n\s*[-*+]\s+|\n\s*\d+\.\s+`)
+	// promptNonInstructionRegex matches words that turn an instruction into a prohibition or
+	// into a description of what someone else does ("the coding agent will open a pull request")
+	promptNonInstructionRegex = regexp.MustCompile(`(?i)\b(?:not|never|don't|dont|cannot|can't|without|avoid|instead\s+of|no\s+need\s+to|will|would)\b`)
+)
+
+// promptToolFinding is an instruction of the prompt that no configured tool allows
+type promptToolFinding struct {
+	phrase string
+	tool   string
+}
+
+// findUnreachablePromptInstructions returns the prompt instructions whose tool is not configured.
+// Each tool is reported once, with the first phrase that asks for it.
+func findUnreachablePromptInstructions(data *WorkflowData) []promptToolFinding {
+	prompt := promptFencedCodeRegex.ReplaceAllString(data.MarkdownContent, "\n")
+	prompt = promptHTMLCommentRegex.ReplaceAllString(prompt, "")
+
+	var findings []promptToolFinding
+	for _, rule := range promptToolRules {
+		if rule.configured(data) {
+			continue
+		}
+		for _, sentence := range promptSentenceSplitRegex.Split(prompt, -1) {
+			loc := rule.pattern.FindStringIndex(sentence)
+			if loc == nil || promptNonInstructionRegex.MatchString(sentence[:loc[0]]) {
+				continue
+			}
+			phrase := strings.Join(strings.Fields(sentence[loc[0]:loc[1]]), " ")
+			promptToolsValidationLog.Printf("Prompt instruction %q needs %s", phrase, rule.tool)
+			findings = append(findings, promptToolFinding{phrase: phrase, tool: rule.tool})
+			break
+		}
+	}
+	return findings
+}
+
+// validatePromptToolReachability warns when the prompt instructs the agent to do something
+// that no configured tool allows
+func (c *Compiler) validatePromptToolReachability(workflowData *WorkflowData, markdownPath string) {
+	findings := findUnreachablePromptInstructions(workflowData)
+	if len(findings) == 0 {
+		return
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "The prompt asks for %d action(s) that no configured tool allows:\n", len(findings))
+	for _, finding := range findings {
+		fmt.Fprintf(&message, "  - %q needs %s\n", finding.phrase, finding.tool)
+	}
+	message.WriteString("The agent cannot carry these out. Configure the tools or reword the instructions.")
+
+	fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", message.String()))
+	c.IncrementWarningCount()
+}
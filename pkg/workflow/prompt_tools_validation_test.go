@@ -0,0 +1,84 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindUnreachablePromptInstructions(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     *WorkflowData
+		expected []promptToolFinding
+	}{
+		{
+			name: "pull request without create-pull-request",
+			data: &WorkflowData{
+				MarkdownContent: "# Fixer\n\nFix the bug and open a pull request with the change.\n",
+				SafeOutputs:     &SafeOutputsConfig{AddComments: &AddCommentsConfig{}},
+			},
+			expected: []promptToolFinding{{phrase: "open a pull request", tool: "safe-outputs.create-pull-request"}},
+		},
+		{
+			name: "pull request with create-pull-request",
+			data: &WorkflowData{
+				MarkdownContent: "Fix the bug and open a pull request with the change.",
+				SafeOutputs:     &SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{}},
+			},
+		},
+		{
+			name: "pull request handed off to the coding agent",
+			data: &WorkflowData{
+				MarkdownContent: "Assign the issue to Copilot so it can create a PR.",
+				SafeOutputs:     &SafeOutputsConfig{AssignToAgent: &AssignToAgentConfig{}},
+			},
+		},
+		{
+			name: "web search without the tool and multiple findings",
+			data: &WorkflowData{
+				MarkdownContent: "1. Search the web for recent advisories\n2. Add a comment to the issue summarizing them\n",
+				Tools:           map[string]any{"github": nil},
+			},
+			expected: []promptToolFinding{
+				{phrase: "Add a comment", tool: "safe-outputs.add-comment"},
+				{phrase: "Search the web", tool: "tools.web-search"},
+			},
+		},
+		{
+			name: "web search with the tool",
+			data: &WorkflowData{
+				MarkdownContent: "Search the web for recent advisories.",
+				Tools:           map[string]any{"web-search": nil},
+			},
+		},
+		{
+			name: "close issue through update-issue status",
+			data: &WorkflowData{
+				MarkdownContent: "When all sub-issues are done, close the issue.",
+				SafeOutputs:     &SafeOutputsConfig{UpdateIssues: &UpdateIssuesConfig{Status: boolPtr(true)}},
+			},
+		},
+		{
+			name: "negated and descriptive sentences",
+			data: &WorkflowData{
+				MarkdownContent: "Do not open a pull request. The maintainers will create an issue if needed. Never add labels.",
+			},
+		},
+		{
+			name: "code blocks and comments are not instructions",
+			data: &WorkflowData{
+				MarkdownContent: "Report the findings.\n\n```bash\n# open a pull request\ngh pr create\n```\n<!-- add labels later -->\n",
+				SafeOutputs:     &SafeOutputsConfig{NoOp: &NoOpConfig{}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, findUnreachablePromptInstructions(tt.data), "findings should match")
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var provenanceLog = logger.New("workflow:provenance_manifest")
+
+// provenanceManifestFile is where the activation job writes the manifest before uploading it
+const provenanceManifestFile = "/tmp/gh-aw/provenance.json"
+
+// ProvenanceManifest records the inputs a lock file was compiled from, so the prompt
+// version behind a run's output can be reproduced. The compiler embeds it in the lock
+// file header; at runtime the activation job adds the content hashes of the checked-out
+// sources and the rendered prompt and uploads it as the provenance artifact.
+//
+// Content hashes are deliberately not computed at compile time: the markdown body and
+// imports are loaded at runtime, and editing them must not change the lock file.
+type ProvenanceManifest struct {
+	SchemaVersion   LockSchemaVersion  `json:"schema_version"`
+	Path            string             `json:"path,omitempty"`   // markdown source path, relative to the repository root when possible
+	Source          string             `json:"source,omitempty"` // source field (owner/repo/path@ref) of installed workflows
+	FrontmatterHash string             `json:"frontmatter_hash,omitempty"`
+	CompilerVersion string             `json:"compiler_version,omitempty"`
+	Engine          ProvenanceEngine   `json:"engine"`
+	Imports         []ProvenanceSource `json:"imports,omitempty"`
+	Includes        []ProvenanceSource `json:"includes,omitempty"`
+
+	// Recorded at runtime by write_provenance_manifest.cjs
+	SourceHash string `json:"source_hash,omitempty"`
+	PromptHash string `json:"prompt_hash,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+	RunID      int64  `json:"run_id,omitempty"`
+}
+
+// ProvenanceEngine records the engine and the version constraint used to install it
+type ProvenanceEngine struct {
+	ID      string `json:"id"`
+	Version string `json:"version,omitempty"` // configured engine.version, or the compiler's default
+	Model   string `json:"model,omitempty"`
+}
+
+// ProvenanceSource is an imported or included file. Local files are recorded relative to
+// the repository root; remote imports keep their workflowspec, which pins the ref.
+type ProvenanceSource struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256,omitempty"` // recorded at runtime for files present in the checkout
+}
+
+// buildProvenanceManifest collects the compile-time provenance of a workflow
+func (c *Compiler) buildProvenanceManifest(data *WorkflowData, markdownPath string, frontmatterHash string) *ProvenanceManifest {
+	manifest := &ProvenanceManifest{
+		SchemaVersion:   LockSchemaV1,
+		Source:          data.Source,
+		FrontmatterHash: frontmatterHash,
+	}
+
+	// Like the lock metadata, the compiler version is only recorded for release builds
+	// so that development builds do not churn lock files
+	if IsRelease() {
+		manifest.CompilerVersion = GetVersion()
+	}
+
+	if markdownPath != "" {
+		manifest.Path = provenancePath(markdownPath, filepath.Base(markdownPath))
+	}
+
+	if engine, err := c.getAgenticEngine(data.AI); err == nil {
+		manifest.Engine.ID = engine.GetID()
+		manifest.Engine.Version = getInstallationVersion(data, engine)
+	}
+	if data.EngineConfig != nil {
+		if data.EngineConfig.ID != "" {
+			manifest.Engine.ID = data.EngineConfig.ID
+		}
+		manifest.Engine.Model = data.EngineConfig.Model
+	}
+
+	// Imports and includes are relative to the markdown file's directory
+	baseDir := filepath.Dir(markdownPath)
+	for _, importPath := range data.ImportedFiles {
+		filePath, _, _ := strings.Cut(importPath, "#")
+		if parser.IsWorkflowSpec(filePath) {
+			manifest.Imports = append(manifest.Imports, ProvenanceSource{Path: filePath})
+			continue
+		}
+		manifest.Imports = append(manifest.Imports, ProvenanceSource{Path: provenancePath(filepath.Join(baseDir, filePath), filePath)})
+	}
+	for _, includePath := range data.IncludedFiles {
+		fullPath := includePath
+		if !filepath.IsAbs(fullPath) {
+			fullPath = filepath.Join(baseDir, includePath)
+		}
+		manifest.Includes = append(manifest.Includes, ProvenanceSource{Path: provenancePath(fullPath, includePath)})
+	}
+
+	provenanceLog.Printf("Built provenance manifest: path=%s, imports=%d, includes=%d", manifest.Path, len(manifest.Imports), len(manifest.Includes))
+	return manifest
+}
+
+// provenancePath returns a path relative to the repository root, detected from the
+// .github directory, or fallback when the path is outside .github
+func provenancePath(path string, fallback string) string {
+	slashPath := filepath.ToSlash(path)
+	if strings.HasPrefix(slashPath, ".github/") {
+		return slashPath
+	}
+	if idx := strings.LastIndex(slashPath, "/.github/"); idx >= 0 {
+		return slashPath[idx+1:]
+	}
+	return filepath.ToSlash(fallback)
+}
+
+// ToJSON converts the manifest to a compact JSON string for embedding in comments
+func (m *ProvenanceManifest) ToJSON() (string, error) {
+	bytes, err := json.Marshal(m)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize provenance manifest: %w", err)
+	}
+	return string(bytes), nil
+}
+
+// manifestPattern matches the provenance manifest line of a lock file header
+var manifestPattern = regexp.MustCompile(`#\s*gh-aw-manifest:\s*(\{.+\})`)
+
+// ExtractProvenanceManifestFromLockFile extracts the provenance manifest from a lock file's
+// comment header. Returns nil without error for lock files compiled before manifests existed.
+func ExtractProvenanceManifestFromLockFile(content string) (*ProvenanceManifest, error) {
+	matches := manifestPattern.FindStringSubmatch(content)
+	if len(matches) < 2 {
+		return nil, nil
+	}
+	var manifest ProvenanceManifest
+	if err := json.Unmarshal([]byte(matches[1]), &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse provenance manifest JSON: %w", err)
+	}
+	return &manifest, nil
+}
+
+// generateProvenanceManifestSteps completes the provenance manifest with the runtime content hashes
+// and uploads it together with the rendered prompt, so an output can be traced back to the exact
+// prompt that produced it
+func (c *Compiler) generateProvenanceManifestSteps(data *WorkflowData) []string {
+	if data.Provenance == nil {
+		return nil
+	}
+	manifestJSON, err := data.Provenance.ToJSON()
+	if err != nil {
+		provenanceLog.Printf("Skipping provenance manifest upload: %v", err)
+		return nil
+	}
+
+	var yaml strings.Builder
+	yaml.WriteString("      - name: Write provenance manifest\n")
+	yaml.WriteString("        env:\n")
+	fmt.Fprintf(&yaml, "          GH_AW_PROVENANCE_MANIFEST: '%s'\n", strings.ReplaceAll(manifestJSON, "'", "''"))
+	fmt.Fprintf(&yaml, "        uses: %s\n", GetActionPin("actions/github-script"))
+	yaml.WriteString("        with:\n")
+	yaml.WriteString("          script: |\n")
+	yaml.WriteString("            const { main } = require('/opt/gh-aw/actions/write_provenance_manifest.cjs');\n")
+	yaml.WriteString("            await main(core, context);\n")
+	yaml.WriteString("      - name: Upload provenance manifest\n")
+	yaml.WriteString("        if: success()\n")
+	fmt.Fprintf(&yaml, "        uses: %s\n", GetActionPin("actions/upload-artifact"))
+	yaml.WriteString("        with:\n")
+	fmt.Fprintf(&yaml, "          name: %s\n", constants.ProvenanceArtifactName)
+	yaml.WriteString("          path: |\n")
+	fmt.Fprintf(&yaml, "            %s\n", provenanceManifestFile)
+	yaml.WriteString("            /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	yaml.WriteString("          if-no-files-found: warn\n")
+	c.writeArtifactRetention(&yaml)
+	return []string{yaml.String()}
+}
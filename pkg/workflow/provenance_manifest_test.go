@@ -0,0 +1,84 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvenanceManifest(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "provenance-manifest-test")
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(filepath.Join(workflowsDir, "shared"), 0755), "should create workflows dir")
+
+	sharedContent := "---\ntools:\n  bash: true\n---\nShared instructions\n"
+	includeContent := "Included instructions\n"
+	workflowContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine:
+  id: claude
+  version: 2.0.0
+  model: claude-sonnet-4
+imports:
+  - shared/tools.md
+---
+
+# Provenance
+
+{{#import shared/notes.md}}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "shared", "tools.md"), []byte(sharedContent), 0644), "should write import")
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "shared", "notes.md"), []byte(includeContent), 0644), "should write include")
+	workflowFile := filepath.Join(workflowsDir, "provenance.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "should write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "should compile workflow")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+	require.NoError(t, err, "should read lock file")
+	lockStr := string(lockContent)
+
+	manifest, err := ExtractProvenanceManifestFromLockFile(lockStr)
+	require.NoError(t, err, "should parse manifest")
+	require.NotNil(t, manifest, "lock file should embed a manifest")
+
+	metadata, _, err := ExtractMetadataFromLockFile(lockStr)
+	require.NoError(t, err, "should parse metadata")
+
+	assert.Equal(t, LockSchemaV1, manifest.SchemaVersion, "schema version")
+	assert.Equal(t, ".github/workflows/provenance.md", manifest.Path, "path should be relative to the repository root")
+	assert.Empty(t, manifest.SourceHash, "content hashes are recorded at runtime, not in the lock file")
+	assert.Equal(t, metadata.FrontmatterHash, manifest.FrontmatterHash, "frontmatter hash should match the lock metadata")
+	assert.Equal(t, ProvenanceEngine{ID: "claude", Version: "2.0.0", Model: "claude-sonnet-4"}, manifest.Engine, "engine")
+	assert.Equal(t, []ProvenanceSource{{Path: ".github/workflows/shared/tools.md"}}, manifest.Imports, "imports")
+	assert.Equal(t, []ProvenanceSource{{Path: ".github/workflows/shared/notes.md"}}, manifest.Includes, "includes")
+
+	assert.Contains(t, lockStr, "- name: Upload provenance manifest", "activation job should upload the manifest")
+	assert.Contains(t, lockStr, "name: provenance\n", "manifest should be uploaded as the provenance artifact")
+	assert.Contains(t, lockStr, "require('/opt/gh-aw/actions/write_provenance_manifest.cjs')", "manifest should be completed with runtime hashes before upload")
+}
+
+func TestExtractProvenanceManifestFromLockFile(t *testing.T) {
+	manifest, err := ExtractProvenanceManifestFromLockFile("# gh-aw-metadata: {\"schema_version\":\"v1\"}\nname: test\n")
+	require.NoError(t, err, "lock files without manifest should not fail")
+	assert.Nil(t, manifest, "lock files without manifest should return nil")
+
+	_, err = ExtractProvenanceManifestFromLockFile("# gh-aw-manifest: {not json}\n")
+	require.Error(t, err, "malformed manifest should fail")
+}
+
+func TestProvenancePath(t *testing.T) {
+	assert.Equal(t, ".github/workflows/a.md", provenancePath("/home/user/repo/.github/workflows/a.md", "a.md"), "absolute path")
+	assert.Equal(t, ".github/workflows/a.md", provenancePath(".github/workflows/a.md", "a.md"), "relative path")
+	assert.Equal(t, "shared/a.md", provenancePath("/tmp/shared/a.md", "shared/a.md"), "path outside .github")
+}
@@ -10,12 +10,33 @@ import (
 
 var publishAssetsLog = logger.New("workflow:publish_assets")
 
+// Upload asset targets
+const (
+	uploadAssetTargetBranch   = "branch"   // Push assets to an orphaned git branch (default)
+	uploadAssetTargetArtifact = "artifact" // Publish assets as a workflow artifact
+)
+
+// defaultUploadAssetArtifactName is the artifact name used when target is "artifact"
+const defaultUploadAssetArtifactName = "assets"
+
+// publishedAssetsDir is where verified assets are staged before the artifact upload
+const publishedAssetsDir = "/tmp/gh-aw/safeoutputs/published-assets/"
+
 // UploadAssetsConfig holds configuration for publishing assets to an orphaned git branch
+// or, with target: artifact, as a workflow artifact
 type UploadAssetsConfig struct {
 	BaseSafeOutputConfig `yaml:",inline"`
-	BranchName           string   `yaml:"branch,omitempty"`       // Branch name (default: "assets/${{ github.workflow }}")
-	MaxSizeKB            int      `yaml:"max-size,omitempty"`     // Maximum file size in KB (default: 10240 = 10MB)
-	AllowedExts          []string `yaml:"allowed-exts,omitempty"` // Allowed file extensions (default: common non-executable types)
+	Target               string   `yaml:"target,omitempty"`         // Where assets are published: "branch" (default) or "artifact"
+	BranchName           string   `yaml:"branch,omitempty"`         // Branch name (default: "assets/${{ github.workflow }}")
+	ArtifactName         string   `yaml:"artifact-name,omitempty"`  // Artifact name when target is "artifact" (default: "assets")
+	RetentionDays        int      `yaml:"retention-days,omitempty"` // Artifact retention in days when target is "artifact" (default: repository setting)
+	MaxSizeKB            int      `yaml:"max-size,omitempty"`       // Maximum file size in KB (default: 10240 = 10MB)
+	AllowedExts          []string `yaml:"allowed-exts,omitempty"`   // Allowed file extensions (default: common non-executable types)
+}
+
+// IsArtifactTarget reports whether assets are published as a workflow artifact instead of a branch
+func (c *UploadAssetsConfig) IsArtifactTarget() bool {
+	return c != nil && c.Target == uploadAssetTargetArtifact
 }
 
 // parseUploadAssetConfig handles upload-asset configuration
@@ -23,8 +44,10 @@ func (c *Compiler) parseUploadAssetConfig(outputMap map[string]any) *UploadAsset
 	if configData, exists := outputMap["upload-asset"]; exists {
 		publishAssetsLog.Print("Parsing upload-asset configuration")
 		config := &UploadAssetsConfig{
-			BranchName: "assets/${{ github.workflow }}", // Default branch name
-			MaxSizeKB:  10240,                           // Default 10MB
+			Target:       uploadAssetTargetBranch,
+			BranchName:   "assets/${{ github.workflow }}", // Default branch name
+			ArtifactName: defaultUploadAssetArtifactName,
+			MaxSizeKB:    10240, // Default 10MB
 			AllowedExts: []string{
 				// Default set of extensions as specified in problem statement
 				".png",
@@ -34,6 +57,13 @@ func (c *Compiler) parseUploadAssetConfig(outputMap map[string]any) *UploadAsset
 		}
 
 		if configMap, ok := configData.(map[string]any); ok {
+			// Parse target
+			if target, exists := configMap["target"]; exists {
+				if targetStr, ok := target.(string); ok && targetStr != "" {
+					config.Target = targetStr
+				}
+			}
+
 			// Parse branch
 			if branchName, exists := configMap["branch"]; exists {
 				if branchNameStr, ok := branchName.(string); ok {
@@ -41,6 +71,20 @@ func (c *Compiler) parseUploadAssetConfig(outputMap map[string]any) *UploadAsset
 				}
 			}
 
+			// Parse artifact-name
+			if artifactName, exists := configMap["artifact-name"]; exists {
+				if artifactNameStr, ok := artifactName.(string); ok && artifactNameStr != "" {
+					config.ArtifactName = artifactNameStr
+				}
+			}
+
+			// Parse retention-days
+			if retentionDays, exists := configMap["retention-days"]; exists {
+				if retentionDaysInt, ok := parseIntValue(retentionDays); ok && retentionDaysInt > 0 {
+					config.RetentionDays = retentionDaysInt
+				}
+			}
+
 			// Parse max-size
 			if maxSize, exists := configMap["max-size"]; exists {
 				if maxSizeInt, ok := parseIntValue(maxSize); ok && maxSizeInt > 0 {
@@ -65,7 +109,7 @@ func (c *Compiler) parseUploadAssetConfig(outputMap map[string]any) *UploadAsset
 
 			// Parse common base fields with default max of 0 (no limit)
 			c.parseBaseSafeOutputConfig(configMap, &config.BaseSafeOutputConfig, 0)
-			publishAssetsLog.Printf("Parsed upload-asset config: branch=%s, max_size_kb=%d, allowed_exts=%d", config.BranchName, config.MaxSizeKB, len(config.AllowedExts))
+			publishAssetsLog.Printf("Parsed upload-asset config: target=%s, branch=%s, max_size_kb=%d, allowed_exts=%d", config.Target, config.BranchName, config.MaxSizeKB, len(config.AllowedExts))
 		} else if configData == nil {
 			// Handle null case: create config with defaults
 			publishAssetsLog.Print("Using default upload-asset configuration")
@@ -78,6 +122,19 @@ func (c *Compiler) parseUploadAssetConfig(outputMap map[string]any) *UploadAsset
 	return nil
 }
 
+// validateUploadAssetConfig validates the safe-outputs.upload-asset block
+func validateUploadAssetConfig(config *SafeOutputsConfig) error {
+	if config == nil || config.UploadAssets == nil {
+		return nil
+	}
+	switch target := config.UploadAssets.Target; target {
+	case uploadAssetTargetBranch, uploadAssetTargetArtifact:
+		return nil
+	default:
+		return fmt.Errorf("safe-outputs.upload-asset.target: must be '%s' or '%s', got '%s'", uploadAssetTargetBranch, uploadAssetTargetArtifact, target)
+	}
+}
+
 // buildUploadAssetsJob creates the publish_assets job
 func (c *Compiler) buildUploadAssetsJob(data *WorkflowData, mainJobName string, threatDetectionEnabled bool) (*Job, error) {
 	publishAssetsLog.Printf("Building upload_assets job: workflow=%s, main_job=%s, threat_detection=%v", data.Name, mainJobName, threatDetectionEnabled)
@@ -86,6 +143,9 @@ func (c *Compiler) buildUploadAssetsJob(data *WorkflowData, mainJobName string,
 		return nil, errors.New("safe-outputs.upload-asset configuration is required")
 	}
 
+	uploadConfig := data.SafeOutputs.UploadAssets
+	artifactTarget := uploadConfig.IsArtifactTarget()
+
 	var preSteps []string
 
 	// Permission checks are now handled by the separate check_membership job
@@ -101,11 +161,11 @@ func (c *Compiler) buildUploadAssetsJob(data *WorkflowData, mainJobName string,
 		preSteps = append(preSteps, c.generateSetupStep(setupActionRef, SetupActionDestination, false)...)
 	}
 
-	// Step 1: Checkout repository
-	preSteps = buildCheckoutRepository(preSteps, c, "", "")
-
-	// Step 2: Configure Git credentials
-	preSteps = append(preSteps, c.generateGitConfigurationSteps()...)
+	// Steps 1-2: Checkout repository and configure Git credentials (branch target only)
+	if !artifactTarget {
+		preSteps = buildCheckoutRepository(preSteps, c, "", "")
+		preSteps = append(preSteps, c.generateGitConfigurationSteps()...)
+	}
 
 	// Step 3: Download assets artifact if it exists
 	preSteps = append(preSteps, "      - name: Download assets\n")
@@ -124,9 +184,13 @@ func (c *Compiler) buildUploadAssetsJob(data *WorkflowData, mainJobName string,
 
 	// Build custom environment variables specific to upload-assets
 	var customEnvVars []string
-	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_BRANCH: %q\n", data.SafeOutputs.UploadAssets.BranchName))
-	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_MAX_SIZE_KB: %d\n", data.SafeOutputs.UploadAssets.MaxSizeKB))
-	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_ALLOWED_EXTS: %q\n", strings.Join(data.SafeOutputs.UploadAssets.AllowedExts, ",")))
+	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_BRANCH: %q\n", uploadConfig.BranchName))
+	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_MAX_SIZE_KB: %d\n", uploadConfig.MaxSizeKB))
+	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_ALLOWED_EXTS: %q\n", strings.Join(uploadConfig.AllowedExts, ",")))
+	if artifactTarget {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_TARGET: %q\n", uploadAssetTargetArtifact))
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_PUBLISH_DIR: %q\n", publishedAssetsDir))
+	}
 
 	// Add standard environment variables (metadata + staged/target repo)
 	customEnvVars = append(customEnvVars, c.buildStandardSafeOutputEnvVars(data, "")...) // No target repo for upload assets
@@ -137,8 +201,34 @@ func (c *Compiler) buildUploadAssetsJob(data *WorkflowData, mainJobName string,
 		"branch_name":     "${{ steps.upload_assets.outputs.branch_name }}",
 	}
 
+	// Artifact target: upload the verified assets after the script stages them
+	var postSteps []string
+	permissions := NewPermissionsContentsWrite()
+	stepName := "Push assets"
+	if artifactTarget {
+		permissions = NewPermissionsContentsRead()
+		stepName = "Publish assets"
+		postSteps = append(postSteps, "      - name: Upload published assets\n")
+		postSteps = append(postSteps, "        id: upload_published_assets\n")
+		postSteps = append(postSteps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/upload-artifact")))
+		postSteps = append(postSteps, "        with:\n")
+		postSteps = append(postSteps, fmt.Sprintf("          name: %s\n", uploadConfig.ArtifactName))
+		postSteps = append(postSteps, fmt.Sprintf("          path: %s\n", publishedAssetsDir))
+		if uploadConfig.RetentionDays > 0 {
+			postSteps = append(postSteps, fmt.Sprintf("          retention-days: %d\n", uploadConfig.RetentionDays))
+		}
+		postSteps = append(postSteps, "          if-no-files-found: ignore\n")
+		// The download URL is only known once the upload API has created the artifact
+		postSteps = append(postSteps, "      - name: Record published assets URL\n")
+		postSteps = append(postSteps, "        if: steps.upload_published_assets.outputs.artifact-url != ''\n")
+		postSteps = append(postSteps, "        env:\n")
+		postSteps = append(postSteps, "          ARTIFACT_URL: ${{ steps.upload_published_assets.outputs.artifact-url }}\n")
+		postSteps = append(postSteps, "        run: echo \"Assets artifact: $ARTIFACT_URL\" >> \"$GITHUB_STEP_SUMMARY\"\n")
+		outputs["artifact_url"] = "${{ steps.upload_published_assets.outputs.artifact-url }}"
+	}
+
 	// Build the job condition using expression tree
-	jobCondition := BuildSafeOutputType("upload_asset")
+	jobCondition := withSafeOutputCondition(BuildSafeOutputType("upload_asset"), uploadConfig.If)
 
 	// Build job dependencies — detection is now inline in the agent job
 	needs := []string{mainJobName}
@@ -146,17 +236,18 @@ func (c *Compiler) buildUploadAssetsJob(data *WorkflowData, mainJobName string,
 	// Use the shared builder function to create the job
 	return c.buildSafeOutputJob(data, SafeOutputJobConfig{
 		JobName:       "upload_assets",
-		StepName:      "Push assets",
+		StepName:      stepName,
 		StepID:        "upload_assets",
 		ScriptName:    "upload_assets",
 		MainJobName:   mainJobName,
 		CustomEnvVars: customEnvVars,
 		Script:        getUploadAssetsScript(),
-		Permissions:   NewPermissionsContentsWrite(),
+		Permissions:   permissions,
 		Outputs:       outputs,
 		Condition:     jobCondition,
 		PreSteps:      preSteps,
-		Token:         data.SafeOutputs.UploadAssets.GitHubToken,
+		PostSteps:     postSteps,
+		Token:         uploadConfig.GitHubToken,
 		Needs:         needs,
 	})
 }
@@ -46,6 +46,24 @@ func TestParseUploadAssetConfig(t *testing.T) {
 				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("5")},
 			},
 		},
+		{
+			name: "upload-asset config with artifact target",
+			input: map[string]any{
+				"upload-asset": map[string]any{
+					"target":         "artifact",
+					"artifact-name":  "charts",
+					"retention-days": 7,
+				},
+			},
+			expected: &UploadAssetsConfig{
+				Target:        "artifact",
+				BranchName:    "assets/${{ github.workflow }}",
+				ArtifactName:  "charts",
+				RetentionDays: 7,
+				MaxSizeKB:     10240,
+				AllowedExts:   []string{".png", ".jpg", ".jpeg"},
+			},
+		},
 		{
 			name:     "no upload-asset config",
 			input:    map[string]any{},
@@ -73,6 +91,18 @@ func TestParseUploadAssetConfig(t *testing.T) {
 				t.Errorf("BranchName: expected %s, got %s", tt.expected.BranchName, result.BranchName)
 			}
 
+			if tt.expected.Target != "" && result.Target != tt.expected.Target {
+				t.Errorf("Target: expected %s, got %s", tt.expected.Target, result.Target)
+			}
+
+			if tt.expected.ArtifactName != "" && result.ArtifactName != tt.expected.ArtifactName {
+				t.Errorf("ArtifactName: expected %s, got %s", tt.expected.ArtifactName, result.ArtifactName)
+			}
+
+			if result.RetentionDays != tt.expected.RetentionDays {
+				t.Errorf("RetentionDays: expected %d, got %d", tt.expected.RetentionDays, result.RetentionDays)
+			}
+
 			if result.MaxSizeKB != tt.expected.MaxSizeKB {
 				t.Errorf("MaxSizeKB: expected %d, got %d", tt.expected.MaxSizeKB, result.MaxSizeKB)
 			}
@@ -172,3 +202,63 @@ func TestUploadAssetsJobUsesFileInput(t *testing.T) {
 		t.Error("Expected GH_AW_ASSETS_ALLOWED_EXTS environment variable")
 	}
 }
+
+func TestUploadAssetsJobArtifactTarget(t *testing.T) {
+	c := NewCompiler()
+	data := &WorkflowData{
+		Name: "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			UploadAssets: &UploadAssetsConfig{
+				Target:        "artifact",
+				BranchName:    "assets/test",
+				ArtifactName:  "charts",
+				RetentionDays: 7,
+				MaxSizeKB:     10240,
+				AllowedExts:   []string{".png"},
+			},
+		},
+	}
+
+	job, err := c.buildUploadAssetsJob(data, "agent", false)
+	if err != nil {
+		t.Fatalf("Failed to build upload assets job: %v", err)
+	}
+
+	stepsStr := strings.Join(job.Steps, "")
+
+	if strings.Contains(stepsStr, "git config") || strings.Contains(stepsStr, "Checkout repository") {
+		t.Error("Artifact target should not check out the repository or configure git")
+	}
+
+	if !strings.Contains(stepsStr, `GH_AW_ASSETS_TARGET: "artifact"`) {
+		t.Error("Expected GH_AW_ASSETS_TARGET environment variable")
+	}
+
+	for _, expected := range []string{"Upload published assets", "id: upload_published_assets", "name: charts", "path: " + publishedAssetsDir, "retention-days: 7", "ARTIFACT_URL: ${{ steps.upload_published_assets.outputs.artifact-url }}"} {
+		if !strings.Contains(stepsStr, expected) {
+			t.Errorf("Expected artifact upload step to contain %q", expected)
+		}
+	}
+
+	if strings.Contains(job.Permissions, "contents: write") {
+		t.Errorf("Artifact target should only need contents: read, got %s", job.Permissions)
+	}
+
+	if job.Outputs["artifact_url"] != "${{ steps.upload_published_assets.outputs.artifact-url }}" {
+		t.Errorf("Expected artifact_url output from the upload step, got %q", job.Outputs["artifact_url"])
+	}
+}
+
+func TestValidateUploadAssetConfig(t *testing.T) {
+	for _, target := range []string{uploadAssetTargetBranch, uploadAssetTargetArtifact} {
+		config := &SafeOutputsConfig{UploadAssets: &UploadAssetsConfig{Target: target}}
+		if err := validateUploadAssetConfig(config); err != nil {
+			t.Errorf("Target %q should be valid, got %v", target, err)
+		}
+	}
+
+	err := validateUploadAssetConfig(&SafeOutputsConfig{UploadAssets: &UploadAssetsConfig{Target: "release"}})
+	if err == nil || !strings.Contains(err.Error(), "must be 'branch' or 'artifact', got 'release'") {
+		t.Errorf("Expected unknown target to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestTargetHardening(t *testing.T) {
+	tests := []struct {
+		name           string
+		frontmatter    string
+		contains       []string
+		notContains    []string
+		errorSubstring string
+	}{
+		{
+			name: "head is not checked out by default",
+			frontmatter: `on:
+  pull_request_target:
+    types: [opened]
+permissions:
+  contents: read
+  pull-requests: read`,
+			contains: []string{
+				"(github.event_name != 'pull_request_target') && ((github.event.pull_request) || (github.event.issue.pull_request))",
+			},
+			notContains: []string{
+				"Checkout pull request head (untrusted)",
+				"path: " + PullRequestTargetHeadPath,
+			},
+		},
+		{
+			name: "checkout-head checks out into the quarantined path",
+			frontmatter: `on:
+  pull_request_target:
+    types: [opened]
+    checkout-head: true
+permissions:
+  contents: read
+  pull-requests: read`,
+			contains: []string{
+				"# checkout-head: true # Head checkout applied via quarantined checkout step",
+				"Checkout pull request head (untrusted)",
+				"ref: ${{ github.event.pull_request.head.sha }}",
+				"path: " + PullRequestTargetHeadPath,
+				`<untrusted-pr-head path="` + PullRequestTargetHeadPath + `">`,
+			},
+		},
+		{
+			name: "pull_request workflows keep the PR branch checkout",
+			frontmatter: `on:
+  pull_request:
+    types: [opened]
+permissions:
+  contents: read
+  pull-requests: read`,
+			contains: []string{
+				"(github.event.pull_request) || (github.event.issue.pull_request)",
+			},
+			notContains: []string{
+				"github.event_name != 'pull_request_target'",
+			},
+		},
+		{
+			name: "checkout-head with write permissions in a custom job is rejected",
+			frontmatter: `on:
+  pull_request_target:
+    types: [opened]
+    checkout-head: true
+permissions:
+  contents: read
+jobs:
+  label:
+    runs-on: ubuntu-latest
+    permissions:
+      pull-requests: write
+    steps:
+      - run: echo labeling`,
+			errorSubstring: "checkout-head cannot be combined with write permissions (found: jobs.label: pull-requests)",
+		},
+		{
+			name: "checkout-head with top-level write permissions is rejected",
+			frontmatter: `on:
+  pull_request_target:
+    types: [opened]
+    checkout-head: true
+strict: false
+permissions:
+  contents: write`,
+			errorSubstring: "checkout-head cannot be combined with write permissions (found: contents)",
+		},
+		{
+			name: "checkout of the head ref is rejected",
+			frontmatter: `on:
+  pull_request_target:
+    types: [opened]
+permissions:
+  contents: read
+checkout:
+  ref: ${{ github.event.pull_request.head.sha }}`,
+			errorSubstring: "must not check out the pull request head",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "pull-request-target-test")
+			workflowPath := filepath.Join(tmpDir, "test.md")
+			content := "---\n" + tt.frontmatter + "\n---\n\nReview the pull request.\n"
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+			compiler := NewCompiler()
+			err := compiler.CompileWorkflow(workflowPath)
+			if tt.errorSubstring != "" {
+				require.Error(t, err, "workflow should be rejected")
+				assert.Contains(t, err.Error(), tt.errorSubstring, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "workflow should compile")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+			require.NoError(t, err, "should read lock file")
+			lock := string(lockContent)
+			for _, expected := range tt.contains {
+				assert.Contains(t, lock, expected, "lock file should contain %q", expected)
+			}
+			for _, unexpected := range tt.notContains {
+				assert.NotContains(t, lock, unexpected, "lock file should not contain %q", unexpected)
+			}
+		})
+	}
+}
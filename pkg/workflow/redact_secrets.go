@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"sort"
@@ -60,9 +61,14 @@ func (c *Compiler) generateSecretRedactionStep(yaml *strings.Builder, yamlConten
 	// This is important for validation to ensure the step ordering is correct
 	c.stepOrderTracker.RecordSecretRedaction("Redact secrets in logs")
 
-	// If no secrets found, we still generate the step but it will be a no-op at runtime
+	var customPatterns []string
+	if data.SecretMasking != nil {
+		customPatterns = data.SecretMasking.Patterns
+	}
+
+	// If no secrets or custom patterns are found, we still generate the step but it will be a no-op at runtime
 	// This ensures consistent step ordering and validation
-	if len(secretReferences) == 0 {
+	if len(secretReferences) == 0 && len(customPatterns) == 0 {
 		secretMaskingLog.Print("No secrets found, generating no-op redaction step")
 		// Generate a minimal no-op redaction step for validation purposes
 		yaml.WriteString("      - name: Redact secrets in logs\n")
@@ -94,6 +100,15 @@ func (c *Compiler) generateSecretRedactionStep(yaml *strings.Builder, yamlConten
 		}
 		fmt.Fprintf(yaml, "          GH_AW_SECRET_NAMES: '%s'\n", strings.Join(escapedRefs, ","))
 
+		// Pass custom redaction patterns as a JSON array in a YAML single-quoted scalar,
+		// where the only escape is doubling single quotes
+		if len(customPatterns) > 0 {
+			patternsJSON, err := json.Marshal(customPatterns)
+			if err == nil {
+				fmt.Fprintf(yaml, "          GH_AW_SECRET_PATTERNS: '%s'\n", strings.ReplaceAll(string(patternsJSON), "'", "''"))
+			}
+		}
+
 		// Pass the actual secret values as environment variables so they can be redacted
 		// Each secret will be available as an environment variable
 		for _, secretName := range secretReferences {
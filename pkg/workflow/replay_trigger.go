@@ -0,0 +1,110 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var replayTriggerLog = logger.New("workflow:replay_trigger")
+
+const (
+	// ReplayEventInputName is the workflow_dispatch input carrying the event name of a replayed run
+	ReplayEventInputName = "aw_replay_event"
+	// ReplayPayloadInputName is the workflow_dispatch input carrying the JSON event payload of a replayed run
+	ReplayPayloadInputName = "aw_replay_payload"
+)
+
+// replayablePathPattern matches simple github.event property paths that can be read from a
+// replayed payload
+var replayablePathPattern = regexp.MustCompile(`^github\.event((?:\.[A-Za-z_][A-Za-z0-9_]*)+)$`)
+
+// expandReplayTrigger expands the on.replay: true sugar. It adds the workflow_dispatch inputs
+// `gh aw replay` uses to re-dispatch a previous run with its recorded event payload, and
+// removes the replay key so it is not rendered into the compiled workflow.
+func expandReplayTrigger(onMap map[string]any) error {
+	value, exists := onMap["replay"]
+	if !exists {
+		return nil
+	}
+	delete(onMap, "replay")
+
+	enabled, ok := value.(bool)
+	if !ok {
+		return fmt.Errorf("on.replay: must be a boolean, got %T", value)
+	}
+	if !enabled {
+		return nil
+	}
+
+	var dispatch map[string]any
+	switch existing := onMap["workflow_dispatch"].(type) {
+	case nil:
+		dispatch = map[string]any{}
+	case map[string]any:
+		dispatch = existing
+	default:
+		return fmt.Errorf("on.replay: workflow_dispatch must be an object, got %T", existing)
+	}
+
+	inputs, ok := dispatch["inputs"].(map[string]any)
+	if !ok {
+		if dispatch["inputs"] != nil {
+			return fmt.Errorf("on.replay: workflow_dispatch.inputs must be an object, got %T", dispatch["inputs"])
+		}
+		inputs = map[string]any{}
+	}
+	for _, name := range []string{ReplayEventInputName, ReplayPayloadInputName} {
+		if _, exists := inputs[name]; exists {
+			return fmt.Errorf("on.replay: workflow_dispatch input '%s' is reserved for replayed runs", name)
+		}
+	}
+
+	inputs[ReplayEventInputName] = map[string]any{
+		"description": "Event name of a replayed run (set by gh aw replay)",
+		"required":    false,
+		"type":        "string",
+	}
+	inputs[ReplayPayloadInputName] = map[string]any{
+		"description": "JSON event payload of a replayed run (set by gh aw replay)",
+		"required":    false,
+		"type":        "string",
+	}
+	dispatch["inputs"] = inputs
+	onMap["workflow_dispatch"] = dispatch
+
+	replayTriggerLog.Print("Added replay inputs to workflow_dispatch")
+	return nil
+}
+
+// applyReplayPayloadFallbacks makes prompt expressions read the event from the replay
+// inputs when a run was dispatched by `gh aw replay`. Other expressions are left unchanged.
+//
+// The EnvVar field is intentionally left unchanged, as in applyWorkflowDispatchFallbacks.
+func applyReplayPayloadFallbacks(mappings []*ExpressionMapping, hasReplay bool) {
+	if !hasReplay {
+		return
+	}
+	for _, mapping := range mappings {
+		if replayed, ok := replayFallbackExpression(mapping.Content); ok {
+			replayTriggerLog.Printf("Applying replay fallback: %s -> %s", mapping.Content, replayed)
+			mapping.Content = replayed
+		}
+	}
+}
+
+// replayFallbackExpression rewrites an expression to prefer the replayed event. Simple
+// github.event.* paths read from the replayed payload, falling back to the actual event,
+// and github.event_name reads the replayed event name.
+func replayFallbackExpression(content string) (string, bool) {
+	content = strings.TrimSpace(content)
+	if content == "github.event_name" {
+		return fmt.Sprintf("inputs.%s || github.event_name", ReplayEventInputName), true
+	}
+	if match := replayablePathPattern.FindStringSubmatch(content); match != nil {
+		return fmt.Sprintf("fromJSON(inputs.%s || toJSON(github.event))%s", ReplayPayloadInputName, match[1]), true
+	}
+	return content, false
+}
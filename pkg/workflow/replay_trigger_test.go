@@ -0,0 +1,117 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandReplayTrigger(t *testing.T) {
+	tests := []struct {
+		name      string
+		onMap     map[string]any
+		wantErr   string
+		wantReps  bool
+		keepInput string
+	}{
+		{name: "absent", onMap: map[string]any{"issues": nil}},
+		{name: "disabled", onMap: map[string]any{"issues": nil, "replay": false}},
+		{name: "creates workflow_dispatch", onMap: map[string]any{"issues": nil, "replay": true}, wantReps: true},
+		{
+			name: "keeps existing inputs",
+			onMap: map[string]any{"replay": true, "workflow_dispatch": map[string]any{
+				"inputs": map[string]any{"topic": map[string]any{"type": "string"}},
+			}},
+			wantReps:  true,
+			keepInput: "topic",
+		},
+		{name: "not a boolean", onMap: map[string]any{"replay": "yes"}, wantErr: "on.replay: must be a boolean"},
+		{
+			name: "reserved input",
+			onMap: map[string]any{"replay": true, "workflow_dispatch": map[string]any{
+				"inputs": map[string]any{"aw_replay_payload": map[string]any{"type": "string"}},
+			}},
+			wantErr: "reserved for replayed runs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := expandReplayTrigger(tt.onMap)
+			if tt.wantErr != "" {
+				require.Error(t, err, "invalid replay config should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "valid replay config should expand")
+			assert.NotContains(t, tt.onMap, "replay", "replay key should be removed")
+
+			frontmatter := map[string]any{"on": tt.onMap}
+			assert.Equal(t, tt.wantReps, hasDispatchInput(frontmatter, ReplayEventInputName), "event input presence should match")
+			assert.Equal(t, tt.wantReps, hasDispatchInput(frontmatter, ReplayPayloadInputName), "payload input presence should match")
+			if tt.keepInput != "" {
+				assert.True(t, hasDispatchInput(frontmatter, tt.keepInput), "existing inputs should be kept")
+			}
+		})
+	}
+}
+
+func TestReplayFallbackExpression(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+		ok      bool
+	}{
+		{content: "github.event.issue.number", want: "fromJSON(inputs.aw_replay_payload || toJSON(github.event)).issue.number", ok: true},
+		{content: "github.event_name", want: "inputs.aw_replay_event || github.event_name", ok: true},
+		{content: "github.event.issue.title || 'none'", want: "github.event.issue.title || 'none'"},
+		{content: "github.repository", want: "github.repository"},
+		{content: "github.event", want: "github.event"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			got, ok := replayFallbackExpression(tt.content)
+			assert.Equal(t, tt.ok, ok, "rewrite decision should match")
+			assert.Equal(t, tt.want, got, "rewritten expression should match")
+		})
+	}
+}
+
+func TestReplayTriggerCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "replay-trigger-test")
+	workflowPath := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+  replay: true
+engine: copilot
+permissions:
+  contents: read
+---
+
+# Triage
+
+Triage issue #${{ github.event.issue.number }}.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "  workflow_dispatch:\n    inputs:\n      aw_replay_event:\n", "replay inputs should be added to workflow_dispatch")
+	assert.Contains(t, lock, "      aw_replay_payload:\n", "payload input should be added")
+	assert.NotContains(t, lock, "replay: true", "replay key should not be rendered")
+	assert.Contains(t, lock, "GH_AW_GITHUB_EVENT_ISSUE_NUMBER: ${{ fromJSON(inputs.aw_replay_payload || toJSON(github.event)).issue.number }}", "prompt expressions should read the replayed payload")
+	assert.Contains(t, lock, "/tmp/gh-aw/event.json\n", "event payload should be uploaded with the activation artifact")
+}
@@ -0,0 +1,196 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var repoConfigLog = logger.New("workflow:repo_config")
+
+// RepoConfigPath is the location of the repository-level configuration file,
+// relative to the git root.
+const RepoConfigPath = ".aw/config.yml"
+
+// RepoConfigLinters lists the lock file scanners that can be enabled by default
+// through the repository configuration.
+var RepoConfigLinters = []string{"actionlint", "zizmor", "poutine"}
+
+// RepoConfigRemoteImportPolicies lists the trust policies that can be enabled for imports
+// from other repositories: require-pinned rejects refs other than full commit SHAs, and lock
+// verifies remote content against the hashes recorded in .aw/imports.lock.
+var RepoConfigRemoteImportPolicies = []string{"require-pinned", "lock"}
+
+// maxArtifactRetentionDays is the upper bound GitHub Actions accepts for retention-days.
+const maxArtifactRetentionDays = 90
+
+// RepoConfig holds repository-wide defaults read from .aw/config.yml.
+// Values only apply when the workflow frontmatter (or a CLI flag) does not set them.
+type RepoConfig struct {
+	Engine                string            `yaml:"engine,omitempty"`                  // Default engine when a workflow has no engine: field
+	Strict                *bool             `yaml:"strict,omitempty"`                  // Default strict mode when a workflow has no strict: field
+	StrictRules           map[string]string `yaml:"strict-rules,omitempty"`            // Default strict rule levels (off, warn, error) by category; frontmatter strict-rules entries take precedence
+	Catalogs              []string          `yaml:"catalogs,omitempty"`                // Repositories (owner/repo) searched by `add <workflow-name>`
+	Lint                  []string          `yaml:"lint,omitempty"`                    // Lock file scanners run by compile by default
+	ArtifactRetentionDays int               `yaml:"artifact-retention-days,omitempty"` // Default retention-days for uploaded artifacts
+	Messages              string            `yaml:"messages,omitempty"`                // Message catalog file (relative to the git root) overriding diagnostic texts
+	LockFileName          string            `yaml:"lock-file-name,omitempty"`          // Lock file name pattern, e.g. {name}.lock.yml (see LockFileNamePlaceholder)
+	ActionPins            map[string]string `yaml:"action-pins,omitempty"`             // Actions replaced by digest-pinned mirrors, e.g. actions/checkout: my-org/checkout@<sha>
+	Health                *HealthThresholds `yaml:"health,omitempty"`                  // Thresholds used by `status --health` to flag workflows needing attention
+	RemoteImports         []string          `yaml:"remote-imports,omitempty"`          // Trust policies for imports from other repositories (see RepoConfigRemoteImportPolicies)
+}
+
+// HealthThresholds configures when `status --health` reports a workflow as needing attention.
+// A zero value uses the status command's default for that metric.
+type HealthThresholds struct {
+	FailureRate         float64 `yaml:"failure-rate,omitempty"`         // Maximum percentage of failed runs
+	TimeoutRate         float64 `yaml:"timeout-rate,omitempty"`         // Maximum percentage of timed out runs
+	AvgCost             float64 `yaml:"avg-cost,omitempty"`             // Maximum average cost per run in USD
+	SanitizerRejections float64 `yaml:"sanitizer-rejections,omitempty"` // Maximum average number of safe output items rejected by the sanitizer per run
+}
+
+// HealthThresholdKeys lists the keys of the health: section, in display order
+var HealthThresholdKeys = []string{"failure-rate", "timeout-rate", "avg-cost", "sanitizer-rejections"}
+
+// LoadRepoConfig reads .aw/config.yml from the given git root.
+// A missing file is not an error and yields an empty configuration.
+func LoadRepoConfig(gitRoot string) (*RepoConfig, error) {
+	path := filepath.Join(gitRoot, RepoConfigPath)
+	repoConfigLog.Printf("Loading repository config: %s", path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			repoConfigLog.Print("No repository config found")
+			return &RepoConfig{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", RepoConfigPath, err)
+	}
+
+	config := &RepoConfig{}
+	if err := yaml.UnmarshalWithOptions(data, config, yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", RepoConfigPath, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", RepoConfigPath, err)
+	}
+
+	repoConfigLog.Printf("Loaded repository config: engine=%q, catalogs=%d, lint=%v, artifactRetentionDays=%d",
+		config.Engine, len(config.Catalogs), config.Lint, config.ArtifactRetentionDays)
+	return config, nil
+}
+
+// Save writes the configuration to .aw/config.yml under the given git root.
+func (r *RepoConfig) Save(gitRoot string) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", RepoConfigPath, err)
+	}
+
+	path := filepath.Join(gitRoot, RepoConfigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", RepoConfigPath, err)
+	}
+
+	repoConfigLog.Printf("Saved repository config: %s", path)
+	return nil
+}
+
+// Validate checks that every configured value is usable by the compiler.
+func (r *RepoConfig) Validate() error {
+	if r.Engine != "" && !GetGlobalEngineRegistry().IsValidEngine(r.Engine) {
+		return fmt.Errorf("engine: unknown engine '%s'. Supported engines: %s",
+			r.Engine, strings.Join(GetGlobalEngineRegistry().GetSupportedEngines(), ", "))
+	}
+
+	if err := ValidateStrictRules(r.StrictRules); err != nil {
+		return err
+	}
+
+	for _, catalog := range r.Catalogs {
+		owner, repo, ok := strings.Cut(catalog, "/")
+		if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+			return fmt.Errorf("catalogs: '%s' must be in owner/repo format", catalog)
+		}
+	}
+
+	for _, linter := range r.Lint {
+		if !slices.Contains(RepoConfigLinters, linter) {
+			return fmt.Errorf("lint: unknown linter '%s'. Supported linters: %s", linter, strings.Join(RepoConfigLinters, ", "))
+		}
+	}
+
+	if r.ArtifactRetentionDays < 0 || r.ArtifactRetentionDays > maxArtifactRetentionDays {
+		return fmt.Errorf("artifact-retention-days: must be between 1 and %d, got %d", maxArtifactRetentionDays, r.ArtifactRetentionDays)
+	}
+
+	if r.Messages != "" {
+		if filepath.IsAbs(r.Messages) || strings.HasPrefix(filepath.Clean(r.Messages), "..") {
+			return fmt.Errorf("messages: '%s' must be a path inside the repository", r.Messages)
+		}
+		if ext := filepath.Ext(r.Messages); ext != ".yml" && ext != ".yaml" {
+			return fmt.Errorf("messages: '%s' must be a .yml or .yaml file", r.Messages)
+		}
+	}
+
+	if r.LockFileName != "" {
+		if err := ValidateLockFileNamePattern(r.LockFileName); err != nil {
+			return fmt.Errorf("lock-file-name: %w", err)
+		}
+	}
+
+	if err := ValidateActionPinMirrors(r.ActionPins); err != nil {
+		return err
+	}
+
+	for _, policy := range r.RemoteImports {
+		if !slices.Contains(RepoConfigRemoteImportPolicies, policy) {
+			return fmt.Errorf("remote-imports: unknown policy '%s'. Supported policies: %s", policy, strings.Join(RepoConfigRemoteImportPolicies, ", "))
+		}
+	}
+
+	if h := r.Health; h != nil {
+		for i, value := range []float64{h.FailureRate, h.TimeoutRate, h.AvgCost, h.SanitizerRejections} {
+			if value < 0 {
+				return fmt.Errorf("health.%s: must not be negative, got %g", HealthThresholdKeys[i], value)
+			}
+		}
+		if h.FailureRate > 100 || h.TimeoutRate > 100 {
+			return fmt.Errorf("health: failure-rate and timeout-rate are percentages between 0 and 100, got %g and %g", h.FailureRate, h.TimeoutRate)
+		}
+	}
+
+	return nil
+}
+
+// LintEnabled reports whether the named scanner is enabled by default.
+func (r *RepoConfig) LintEnabled(linter string) bool {
+	return r != nil && slices.Contains(r.Lint, linter)
+}
+
+// RemoteImportPolicyEnabled reports whether the named remote import trust policy is enabled.
+func (r *RepoConfig) RemoteImportPolicyEnabled(policy string) bool {
+	return r != nil && slices.Contains(r.RemoteImports, policy)
+}
+
+// defaultStrictMode returns the strict mode to use when neither the CLI nor the
+// frontmatter sets it: the repository configuration value, or true.
+func (c *Compiler) defaultStrictMode() bool {
+	if c.repoConfig != nil && c.repoConfig.Strict != nil {
+		return *c.repoConfig.Strict
+	}
+	return true
+}
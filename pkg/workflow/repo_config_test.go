@@ -0,0 +1,252 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRepoConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		expected       *RepoConfig
+		errorSubstring string
+	}{
+		{
+			name:     "missing file yields empty config",
+			expected: &RepoConfig{},
+		},
+		{
+			name: "all keys",
+			content: `engine: claude
+strict: false
+catalogs: [githubnext/agentics]
+lint: [actionlint, zizmor]
+artifact-retention-days: 14
+`,
+			expected: &RepoConfig{
+				Engine:                "claude",
+				Strict:                boolPtr(false),
+				Catalogs:              []string{"githubnext/agentics"},
+				Lint:                  []string{"actionlint", "zizmor"},
+				ArtifactRetentionDays: 14,
+			},
+		},
+		{
+			name:           "unknown key",
+			content:        "engines: claude\n",
+			errorSubstring: "invalid .aw/config.yml",
+		},
+		{
+			name:           "unknown engine",
+			content:        "engine: gpt\n",
+			errorSubstring: "unknown engine 'gpt'",
+		},
+		{
+			name:           "catalog without repo",
+			content:        "catalogs: [githubnext]\n",
+			errorSubstring: "must be in owner/repo format",
+		},
+		{
+			name:           "unknown linter",
+			content:        "lint: [eslint]\n",
+			errorSubstring: "unknown linter 'eslint'",
+		},
+		{
+			name:           "retention too long",
+			content:        "artifact-retention-days: 400\n",
+			errorSubstring: "must be between 1 and 90",
+		},
+		{
+			name:     "lock file name",
+			content:  "lock-file-name: \"aw-{name}.yml\"\n",
+			expected: &RepoConfig{LockFileName: "aw-{name}.yml"},
+		},
+		{
+			name:           "lock file name without placeholder",
+			content:        "lock-file-name: workflow.yml\n",
+			errorSubstring: "must contain {name} exactly once",
+		},
+		{
+			name:     "action pin mirror",
+			content:  "action-pins:\n  actions/checkout: my-org/checkout@08c6903cd8c0fde910a37f88322edcfb5dd907a8\n",
+			expected: &RepoConfig{ActionPins: map[string]string{"actions/checkout": "my-org/checkout@08c6903cd8c0fde910a37f88322edcfb5dd907a8"}},
+		},
+		{
+			name:           "action pin mirror without digest",
+			content:        "action-pins:\n  actions/checkout: my-org/checkout@v5\n",
+			errorSubstring: "must be pinned to a full 40-character commit SHA",
+		},
+		{
+			name:           "action pin mirror with host",
+			content:        "action-pins:\n  actions/checkout: ghe.example.com/actions/checkout@08c6903cd8c0fde910a37f88322edcfb5dd907a8\n",
+			errorSubstring: "must not include a host",
+		},
+		{
+			name:     "remote import policies",
+			content:  "remote-imports: [require-pinned, lock]\n",
+			expected: &RepoConfig{RemoteImports: []string{"require-pinned", "lock"}},
+		},
+		{
+			name:           "unknown remote import policy",
+			content:        "remote-imports: [signed]\n",
+			errorSubstring: "unknown policy 'signed'",
+		},
+		{
+			name:     "health thresholds",
+			content:  "health:\n  failure-rate: 10\n  avg-cost: 0.5\n",
+			expected: &RepoConfig{Health: &HealthThresholds{FailureRate: 10, AvgCost: 0.5}},
+		},
+		{
+			name:           "health failure rate above 100",
+			content:        "health:\n  failure-rate: 150\n",
+			errorSubstring: "percentages between 0 and 100",
+		},
+		{
+			name:           "negative health threshold",
+			content:        "health:\n  avg-cost: -1\n",
+			errorSubstring: "health.avg-cost: must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitRoot := testutil.TempDir(t, "repo-config-test")
+			if tt.content != "" {
+				require.NoError(t, os.MkdirAll(filepath.Join(gitRoot, ".aw"), 0755), "should create .aw directory")
+				require.NoError(t, os.WriteFile(filepath.Join(gitRoot, RepoConfigPath), []byte(tt.content), 0644), "should write config")
+			}
+
+			config, err := LoadRepoConfig(gitRoot)
+			if tt.errorSubstring != "" {
+				require.Error(t, err, "config should be rejected")
+				assert.Contains(t, err.Error(), tt.errorSubstring, "error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "config should load")
+			assert.Equal(t, tt.expected, config, "config should match")
+		})
+	}
+}
+
+func TestRepoConfigSaveRoundTrip(t *testing.T) {
+	gitRoot := testutil.TempDir(t, "repo-config-save-test")
+	config := &RepoConfig{Engine: "codex", Lint: []string{"poutine"}, ArtifactRetentionDays: 5}
+
+	require.NoError(t, config.Save(gitRoot), "config should save")
+
+	loaded, err := LoadRepoConfig(gitRoot)
+	require.NoError(t, err, "saved config should load")
+	assert.Equal(t, config, loaded, "saved config should round-trip")
+
+	invalid := &RepoConfig{Lint: []string{"eslint"}}
+	require.Error(t, invalid.Save(gitRoot), "invalid config should not be saved")
+}
+
+func TestCompileWithRepoConfig(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "repo-config-compile-test")
+	workflowPath := filepath.Join(tmpDir, "test.md")
+
+	compile := func(t *testing.T, content string, config *RepoConfig) (string, error) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+		compiler := NewCompiler(WithRepoConfig(config))
+		if err := compiler.CompileWorkflow(workflowPath); err != nil {
+			return "", err
+		}
+		lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+		require.NoError(t, err, "should read lock file")
+		return string(lockContent), nil
+	}
+
+	noEngine := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+---
+
+Triage the issue.
+`
+
+	t.Run("engine default applies when frontmatter has none", func(t *testing.T) {
+		lock, err := compile(t, noEngine, &RepoConfig{Engine: "claude"})
+		require.NoError(t, err, "workflow should compile")
+		assert.Contains(t, lock, `GH_AW_INFO_ENGINE_ID: "claude"`, "repo default engine should be used")
+	})
+
+	t.Run("frontmatter engine wins over repo default", func(t *testing.T) {
+		content := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: codex
+---
+
+Triage the issue.
+`
+		lock, err := compile(t, content, &RepoConfig{Engine: "claude"})
+		require.NoError(t, err, "workflow should compile")
+		assert.Contains(t, lock, `GH_AW_INFO_ENGINE_ID: "codex"`, "frontmatter engine should be used")
+	})
+
+	t.Run("artifact retention applies to agent uploads", func(t *testing.T) {
+		lock, err := compile(t, noEngine, &RepoConfig{ArtifactRetentionDays: 7})
+		require.NoError(t, err, "workflow should compile")
+		assert.Contains(t, lock, "retention-days: 7", "agent artifacts should use the repo retention")
+
+		lock, err = compile(t, noEngine, nil)
+		require.NoError(t, err, "workflow should compile")
+		assert.NotContains(t, lock, "retention-days: 7", "retention should not be set without repo config")
+	})
+
+	wildcardNetwork := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+network:
+  allowed: ["*"]
+---
+
+Triage the issue.
+`
+
+	t.Run("strict default can be disabled", func(t *testing.T) {
+		_, err := compile(t, wildcardNetwork, nil)
+		require.Error(t, err, "wildcard network should be rejected in strict mode by default")
+
+		_, err = compile(t, wildcardNetwork, &RepoConfig{Strict: boolPtr(false)})
+		require.NoError(t, err, "repo config should disable strict mode by default")
+	})
+
+	t.Run("frontmatter strict wins over repo default", func(t *testing.T) {
+		content := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+network:
+  allowed: ["*"]
+strict: true
+---
+
+Triage the issue.
+`
+		_, err := compile(t, content, &RepoConfig{Strict: boolPtr(false)})
+		require.Error(t, err, "frontmatter strict: true should still apply")
+	})
+}
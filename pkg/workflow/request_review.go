@@ -0,0 +1,50 @@
+package workflow
+
+import (
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var requestReviewLog = logger.New("workflow:request_review")
+
+// RequestReviewConfig holds configuration for requesting pull request reviews from users and teams
+type RequestReviewConfig struct {
+	BaseSafeOutputConfig   `yaml:",inline"`
+	SafeOutputTargetConfig `yaml:",inline"`
+	AllowedUsers           []string `yaml:"allowed-users,omitempty"` // Users that can be requested as reviewers. If omitted, any user is allowed.
+	AllowedTeams           []string `yaml:"allowed-teams,omitempty"` // Team slugs that can be requested as reviewers. If omitted, no team can be requested.
+}
+
+// parseRequestReviewConfig handles request-review configuration
+func (c *Compiler) parseRequestReviewConfig(outputMap map[string]any) *RequestReviewConfig {
+	configData, exists := outputMap["request-review"]
+	if !exists {
+		return nil
+	}
+
+	requestReviewLog.Print("Parsing request-review configuration")
+	config := &RequestReviewConfig{}
+
+	configMap, ok := configData.(map[string]any)
+	if !ok {
+		// If configData is nil or not a map, still set the default max
+		config.Max = defaultIntStr(3)
+		return config
+	}
+
+	// Parse target config (target, target-repo, allowed-repos) with validation
+	targetConfig, isInvalid := ParseTargetConfig(configMap)
+	if isInvalid {
+		return nil // Invalid configuration (e.g., wildcard target-repo), return nil to cause validation error
+	}
+	config.SafeOutputTargetConfig = targetConfig
+
+	config.AllowedUsers = ParseStringArrayFromConfig(configMap, "allowed-users", requestReviewLog)
+	config.AllowedTeams = ParseStringArrayFromConfig(configMap, "allowed-teams", requestReviewLog)
+
+	// Parse common base fields with default max of 3
+	c.parseBaseSafeOutputConfig(configMap, &config.BaseSafeOutputConfig, 3)
+
+	requestReviewLog.Printf("Parsed request-review config: max=%v, target=%s, allowed_users=%d, allowed_teams=%d",
+		config.Max, config.Target, len(config.AllowedUsers), len(config.AllowedTeams))
+	return config
+}
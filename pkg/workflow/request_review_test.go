@@ -0,0 +1,52 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRequestReviewConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		outputMap map[string]any
+		expected  *RequestReviewConfig
+	}{
+		{
+			name:      "not configured",
+			outputMap: map[string]any{},
+		},
+		{
+			name:      "null config uses defaults",
+			outputMap: map[string]any{"request-review": nil},
+			expected:  &RequestReviewConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Max: defaultIntStr(3)}},
+		},
+		{
+			name: "all options",
+			outputMap: map[string]any{"request-review": map[string]any{
+				"target":        "*",
+				"allowed-users": []any{"octocat", "mona"},
+				"allowed-teams": []any{"docs-team"},
+				"max":           5,
+				"github-token":  "${{ secrets.REVIEW_TOKEN }}",
+				"target-repo":   "octo-org/docs",
+			}},
+			expected: &RequestReviewConfig{
+				BaseSafeOutputConfig:   BaseSafeOutputConfig{Max: defaultIntStr(5), GitHubToken: "${{ secrets.REVIEW_TOKEN }}"},
+				SafeOutputTargetConfig: SafeOutputTargetConfig{Target: "*", TargetRepoSlug: "octo-org/docs"},
+				AllowedUsers:           []string{"octocat", "mona"},
+				AllowedTeams:           []string{"docs-team"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			config := compiler.parseRequestReviewConfig(tt.outputMap)
+			assert.Equal(t, tt.expected, config, "Request review config should match")
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var resourceTelemetryLog = logger.New("workflow:resource_telemetry")
+
+const (
+	// resourceSamplesPath is where the resource sampler appends one JSON sample per interval.
+	// It is uploaded with the agent artifacts and read by gh aw logs --resources.
+	resourceSamplesPath = "/tmp/gh-aw/resource-usage.jsonl"
+
+	defaultResourceSampleInterval = 5
+	maxResourceSampleInterval     = 300
+)
+
+// ResourceTelemetry represents runner resource sampling for the agent job (resource-telemetry:)
+//
+// Example:
+//
+//	resource-telemetry:
+//	  interval: 10
+type ResourceTelemetry struct {
+	Interval int `json:"interval"` // Seconds between samples
+}
+
+// extractResourceTelemetry extracts the resource-telemetry configuration from frontmatter
+func extractResourceTelemetry(frontmatter map[string]any) (*ResourceTelemetry, error) {
+	value, exists := frontmatter["resource-telemetry"]
+	if !exists || value == nil {
+		return nil, nil
+	}
+
+	config := &ResourceTelemetry{Interval: defaultResourceSampleInterval}
+
+	if enabled, ok := value.(bool); ok {
+		if !enabled {
+			return nil, nil
+		}
+		return config, nil
+	}
+
+	telemetryMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("resource-telemetry must be a boolean or an object, got %T. Example:\nresource-telemetry:\n  interval: 10", value)
+	}
+
+	if interval, exists := telemetryMap["interval"]; exists {
+		seconds, ok := parseIntValue(interval)
+		if !ok {
+			return nil, fmt.Errorf("resource-telemetry.interval must be an integer, got %v", interval)
+		}
+		if err := validateIntRange(seconds, 1, maxResourceSampleInterval, "resource-telemetry.interval"); err != nil {
+			return nil, err
+		}
+		config.Interval = seconds
+	}
+
+	resourceTelemetryLog.Printf("Extracted resource telemetry config: interval=%ds", config.Interval)
+	return config, nil
+}
+
+// generateResourceSamplerStartStep generates the step that starts the background resource sampler.
+// It runs before the workflow's own steps so dependency installs are included in the samples.
+func generateResourceSamplerStartStep(yaml *strings.Builder, data *WorkflowData) {
+	if data.ResourceTelemetry == nil {
+		return
+	}
+
+	resourceTelemetryLog.Printf("Generating resource sampler start step: interval=%ds", data.ResourceTelemetry.Interval)
+
+	yaml.WriteString("      - name: Start resource sampler\n")
+	yaml.WriteString("        id: start-resource-sampler\n")
+	yaml.WriteString("        continue-on-error: true\n")
+	yaml.WriteString("        env:\n")
+	fmt.Fprintf(yaml, "          GH_AW_RESOURCE_SAMPLES: %s\n", resourceSamplesPath)
+	fmt.Fprintf(yaml, "          GH_AW_RESOURCE_SAMPLE_INTERVAL: %d\n", data.ResourceTelemetry.Interval)
+	yaml.WriteString("        run: bash /opt/gh-aw/actions/start_resource_sampler.sh\n")
+}
+
+// generateResourceSamplerStopStep generates the step that stops the resource sampler before the
+// agent artifacts are uploaded, so the last sample is complete when the file is collected.
+func generateResourceSamplerStopStep(yaml *strings.Builder, data *WorkflowData) {
+	if data.ResourceTelemetry == nil {
+		return
+	}
+
+	yaml.WriteString("      - name: Stop resource sampler\n")
+	yaml.WriteString("        if: always()\n")
+	yaml.WriteString("        continue-on-error: true\n")
+	yaml.WriteString("        env:\n")
+	yaml.WriteString("          RESOURCE_SAMPLER_PID: ${{ steps.start-resource-sampler.outputs.sampler-pid }}\n")
+	yaml.WriteString("        run: |\n")
+	yaml.WriteString("          if [ -n \"$RESOURCE_SAMPLER_PID\" ]; then\n")
+	yaml.WriteString("            kill \"$RESOURCE_SAMPLER_PID\" 2>/dev/null || true\n")
+	yaml.WriteString("          fi\n")
+}
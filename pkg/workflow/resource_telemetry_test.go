@@ -0,0 +1,103 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractResourceTelemetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *ResourceTelemetry
+		wantErr     string
+	}{
+		{
+			name:        "not configured",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "disabled",
+			frontmatter: map[string]any{"resource-telemetry": false},
+		},
+		{
+			name:        "enabled with defaults",
+			frontmatter: map[string]any{"resource-telemetry": true},
+			expected:    &ResourceTelemetry{Interval: 5},
+		},
+		{
+			name:        "custom interval",
+			frontmatter: map[string]any{"resource-telemetry": map[string]any{"interval": 30}},
+			expected:    &ResourceTelemetry{Interval: 30},
+		},
+		{
+			name:        "invalid type",
+			frontmatter: map[string]any{"resource-telemetry": "yes"},
+			wantErr:     "resource-telemetry must be a boolean or an object",
+		},
+		{
+			name:        "interval out of range",
+			frontmatter: map[string]any{"resource-telemetry": map[string]any{"interval": 0}},
+			wantErr:     "resource-telemetry.interval must be between 1 and 300",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := extractResourceTelemetry(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "should parse resource-telemetry config")
+			assert.Equal(t, tt.expected, config, "resource-telemetry config")
+		})
+	}
+}
+
+func TestResourceTelemetryCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "resource-telemetry-*")
+
+	compile := func(t *testing.T, name, telemetry string) string {
+		t.Helper()
+		content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\n" + telemetry + "---\n\nBuild the project.\n"
+		workflowPath := filepath.Join(tmpDir, name+".md")
+		require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+		require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+		lockContent, err := os.ReadFile(filepath.Join(tmpDir, name+".lock.yml"))
+		require.NoError(t, err, "should read lock file")
+		return string(lockContent)
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		lock := compile(t, "enabled", "resource-telemetry:\n  interval: 10\n")
+
+		assert.Contains(t, lock, "GH_AW_RESOURCE_SAMPLE_INTERVAL: 10", "sampler interval should be set")
+		assert.Contains(t, lock, "bash /opt/gh-aw/actions/start_resource_sampler.sh", "sampler should be started")
+		assert.Contains(t, lock, "RESOURCE_SAMPLER_PID: ${{ steps.start-resource-sampler.outputs.sampler-pid }}", "sampler should be stopped")
+		assert.Contains(t, lock, "            /tmp/gh-aw/resource-usage.jsonl\n", "samples should be uploaded with the agent artifacts")
+
+		start := strings.Index(lock, "name: Start resource sampler")
+		agent := strings.Index(lock, "name: Execute GitHub Copilot CLI")
+		stop := strings.Index(lock, "name: Stop resource sampler")
+		upload := strings.Index(lock, "name: Upload agent artifacts")
+		require.NotEqual(t, -1, agent, "agent step should exist")
+		assert.True(t, start < agent && agent < stop && stop < upload, "sampler should run around the agent and stop before the upload")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		lock := compile(t, "disabled", "")
+
+		assert.NotContains(t, lock, "start_resource_sampler.sh", "sampler should not be started")
+		assert.NotContains(t, lock, "resource-usage.jsonl", "samples should not be uploaded")
+	})
+}
@@ -0,0 +1,156 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var retriesLog = logger.New("workflow:retries")
+
+// Retryable failure categories recognized by agent_retry.cjs
+const (
+	retryCategoryRateLimit   = "rate-limit"
+	retryCategoryNetwork     = "network"
+	retryCategoryServerError = "server-error"
+)
+
+// validRetryCategories lists the failure categories that may appear in retries.on
+var validRetryCategories = []string{retryCategoryRateLimit, retryCategoryNetwork, retryCategoryServerError}
+
+// validRetryBackoffs lists the supported delay strategies for retries.backoff
+var validRetryBackoffs = []string{"exponential", "linear", "fixed"}
+
+// maxRetries is the upper bound for retries.max
+const maxRetries = 5
+
+// RetriesConfig represents the retry policy for the agent execution step (retries:)
+//
+// Example:
+//
+//	retries:
+//	  max: 2
+//	  backoff: exponential
+//	  on: [rate-limit, network]
+type RetriesConfig struct {
+	Max     int      `json:"max"`               // Maximum number of retries after the first attempt
+	Backoff string   `json:"backoff,omitempty"` // Delay strategy: exponential (default), linear or fixed
+	On      []string `json:"on,omitempty"`      // Retryable failure categories (default: rate-limit, network)
+}
+
+// extractRetriesConfig extracts the retry policy from frontmatter
+func (c *Compiler) extractRetriesConfig(frontmatter map[string]any) (*RetriesConfig, error) {
+	retriesValue, exists := frontmatter["retries"]
+	if !exists || retriesValue == nil {
+		return nil, nil
+	}
+
+	retriesMap, ok := retriesValue.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("retries must be an object, got %T. Example:\nretries:\n  max: 2\n  backoff: exponential\n  on: [rate-limit, network]", retriesValue)
+	}
+
+	config := &RetriesConfig{
+		Backoff: "exponential",
+		On:      []string{retryCategoryRateLimit, retryCategoryNetwork},
+	}
+
+	maxValue, ok := parseIntValue(retriesMap["max"])
+	if !ok || maxValue < 1 || maxValue > maxRetries {
+		return nil, fmt.Errorf("retries.max must be an integer between 1 and %d, got %v", maxRetries, retriesMap["max"])
+	}
+	config.Max = maxValue
+
+	if backoff, exists := retriesMap["backoff"]; exists {
+		backoffStr, ok := backoff.(string)
+		if !ok || !slices.Contains(validRetryBackoffs, backoffStr) {
+			return nil, fmt.Errorf("retries.backoff must be one of %s, got %v", strings.Join(validRetryBackoffs, ", "), backoff)
+		}
+		config.Backoff = backoffStr
+	}
+
+	if on, exists := retriesMap["on"]; exists {
+		onList, ok := on.([]any)
+		if !ok || len(onList) == 0 {
+			return nil, fmt.Errorf("retries.on must be a non-empty array of failure categories (%s)", strings.Join(validRetryCategories, ", "))
+		}
+		config.On = nil
+		for _, item := range onList {
+			category, ok := item.(string)
+			if !ok || !slices.Contains(validRetryCategories, category) {
+				return nil, fmt.Errorf("retries.on contains unknown failure category %v. Valid categories: %s", item, strings.Join(validRetryCategories, ", "))
+			}
+			if !slices.Contains(config.On, category) {
+				config.On = append(config.On, category)
+			}
+		}
+	}
+
+	retriesLog.Printf("Extracted retries: max=%d, backoff=%s, on=%v", config.Max, config.Backoff, config.On)
+	return config, nil
+}
+
+// wrapExecutionStepWithRetries wraps the run script of the agent execution step in a retry loop.
+// After a failed attempt, agent_retry.cjs classifies the failure from the engine log and prints
+// the backoff delay when the category is retryable; otherwise the step fails with the original
+// exit code. The script body is not re-indented so heredocs keep working. Each attempt runs in a
+// subshell that restores errexit (and pipefail for shell: bash steps), which the loop turns off
+// to capture the exit code, so a failing command still ends the attempt.
+// Only the log output written since the start of the failed attempt is classified.
+func wrapExecutionStepWithRetries(step GitHubActionStep, retries *RetriesConfig, logFile string) GitHubActionStep {
+	runIndex := slices.Index(step, "        run: |")
+	if retries == nil || runIndex == -1 {
+		return step
+	}
+
+	// The script body ends at the next step key (e.g. "        env:")
+	end := len(step)
+	for i := runIndex + 1; i < len(step); i++ {
+		if strings.HasPrefix(step[i], "        ") && !strings.HasPrefix(step[i], "          ") {
+			end = i
+			break
+		}
+	}
+
+	retriesLog.Printf("Wrapping agent execution step with retries: max=%d", retries.Max)
+
+	classifyCommand := fmt.Sprintf(
+		"GH_AW_RETRY_ATTEMPT=\"$GH_AW_RETRY_ATTEMPT\" GH_AW_RETRY_LOG_OFFSET=\"$GH_AW_RETRY_LOG_OFFSET\" GH_AW_RETRY_MAX=%d GH_AW_RETRY_BACKOFF=%s GH_AW_RETRY_ON=%s GH_AW_AGENT_OUTPUT=%s GH_AW_BUDGET_REPORT=%s node /opt/gh-aw/actions/agent_retry.cjs",
+		retries.Max, retries.Backoff, strings.Join(retries.On, ","), logFile, budgetReportPath)
+
+	lines := append([]string{}, step[:runIndex+1]...)
+	lines = append(lines,
+		"          GH_AW_RETRY_ATTEMPT=0",
+		"          GH_AW_RETRY_LOG_OFFSET=0",
+		"          while true; do",
+	)
+	// Engines that append to the log keep earlier attempts, so only the output of the current attempt is classified
+	if strings.Contains(strings.Join(step[runIndex+1:end], "\n"), "tee -a "+logFile) {
+		lines = append(lines, fmt.Sprintf("          GH_AW_RETRY_LOG_OFFSET=$({ wc -c < %s; } 2>/dev/null || echo 0)", logFile))
+	}
+	lines = append(lines,
+		"          set +e",
+		"          (",
+		"          set -e",
+	)
+	// GitHub runs shell: bash steps with -eo pipefail and steps without a shell with -e
+	if slices.Contains(step, "        shell: bash") {
+		lines = append(lines, "          set -o pipefail")
+	}
+	lines = append(lines, step[runIndex+1:end]...)
+	lines = append(lines,
+		"          )",
+		"          GH_AW_EXIT_CODE=$?",
+		"          set -e",
+		"          if [ \"$GH_AW_EXIT_CODE\" -eq 0 ]; then break; fi",
+		"          GH_AW_RETRY_DELAY=$("+classifyCommand+") || exit \"$GH_AW_EXIT_CODE\"",
+		"          GH_AW_RETRY_ATTEMPT=$((GH_AW_RETRY_ATTEMPT + 1))",
+		fmt.Sprintf("          echo \"Agent failed with exit code $GH_AW_EXIT_CODE, retrying in ${GH_AW_RETRY_DELAY}s (retry $GH_AW_RETRY_ATTEMPT of %d)\"", retries.Max),
+		"          sleep \"$GH_AW_RETRY_DELAY\"",
+		"          done",
+	)
+	lines = append(lines, step[end:]...)
+	return GitHubActionStep(lines)
+}
@@ -0,0 +1,156 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractRetriesConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *RetriesConfig
+		wantErr     string
+	}{
+		{
+			name:        "no retries",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:        "defaults",
+			frontmatter: map[string]any{"retries": map[string]any{"max": 2}},
+			expected:    &RetriesConfig{Max: 2, Backoff: "exponential", On: []string{"rate-limit", "network"}},
+		},
+		{
+			name: "full configuration",
+			frontmatter: map[string]any{
+				"retries": map[string]any{"max": 3, "backoff": "linear", "on": []any{"server-error", "rate-limit", "server-error"}},
+			},
+			expected: &RetriesConfig{Max: 3, Backoff: "linear", On: []string{"server-error", "rate-limit"}},
+		},
+		{
+			name:        "retries must be an object",
+			frontmatter: map[string]any{"retries": 2},
+			wantErr:     "retries must be an object",
+		},
+		{
+			name:        "max is required",
+			frontmatter: map[string]any{"retries": map[string]any{"backoff": "fixed"}},
+			wantErr:     "retries.max must be an integer between 1 and 5",
+		},
+		{
+			name:        "max out of range",
+			frontmatter: map[string]any{"retries": map[string]any{"max": 10}},
+			wantErr:     "retries.max must be an integer between 1 and 5",
+		},
+		{
+			name:        "invalid backoff",
+			frontmatter: map[string]any{"retries": map[string]any{"max": 1, "backoff": "random"}},
+			wantErr:     "retries.backoff must be one of exponential, linear, fixed",
+		},
+		{
+			name:        "unknown category",
+			frontmatter: map[string]any{"retries": map[string]any{"max": 1, "on": []any{"timeout"}}},
+			wantErr:     "unknown failure category timeout",
+		},
+		{
+			name:        "empty categories",
+			frontmatter: map[string]any{"retries": map[string]any{"max": 1, "on": []any{}}},
+			wantErr:     "retries.on must be a non-empty array",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			config, err := compiler.extractRetriesConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected extraction error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error should describe the invalid retry policy")
+				return
+			}
+			require.NoError(t, err, "Extraction should succeed")
+			assert.Equal(t, tt.expected, config, "Retries config should match")
+		})
+	}
+}
+
+func TestWrapExecutionStepWithRetries(t *testing.T) {
+	logFile := "/tmp/gh-aw/agent-stdio.log"
+	step := GitHubActionStep{
+		"      - name: Execute Agent",
+		"        id: agentic_execution",
+		"        run: |",
+		"          set -o pipefail",
+		"          agent --prompt \"$PROMPT\" 2>&1 | tee -a " + logFile,
+		"        env:",
+		"          PROMPT: hello",
+	}
+
+	t.Run("nil config leaves the step unchanged", func(t *testing.T) {
+		assert.Equal(t, step, wrapExecutionStepWithRetries(step, nil, logFile), "Step should not change")
+	})
+
+	t.Run("step without run block is unchanged", func(t *testing.T) {
+		usesStep := GitHubActionStep{"      - name: Action", "        uses: actions/checkout@v5"}
+		assert.Equal(t, usesStep, wrapExecutionStepWithRetries(usesStep, &RetriesConfig{Max: 1}, logFile), "Step should not change")
+	})
+
+	t.Run("run block is wrapped in a retry loop", func(t *testing.T) {
+		retries := &RetriesConfig{Max: 2, Backoff: "exponential", On: []string{"rate-limit", "network"}}
+		wrapped := strings.Join(wrapExecutionStepWithRetries(step, retries, logFile), "\n")
+
+		assert.Contains(t, wrapped, "        run: |\n          GH_AW_RETRY_ATTEMPT=0\n", "Loop should start the run block")
+		assert.Contains(t, wrapped, "          (\n          set -e\n          set -o pipefail\n          agent", "Original script should run in a subshell with errexit restored")
+		assert.Contains(t, wrapped, "tee -a "+logFile+"\n          )\n          GH_AW_EXIT_CODE=$?", "Exit code should be captured after the subshell")
+		assert.Contains(t, wrapped, "GH_AW_RETRY_LOG_OFFSET=$({ wc -c < "+logFile+"; }", "Appended logs should be classified from the attempt offset")
+		assert.Contains(t, wrapped, "GH_AW_RETRY_MAX=2 GH_AW_RETRY_BACKOFF=exponential GH_AW_RETRY_ON=rate-limit,network", "Policy should be passed to the classifier")
+		assert.Contains(t, wrapped, "node /opt/gh-aw/actions/agent_retry.cjs) || exit \"$GH_AW_EXIT_CODE\"", "Non-retryable failures should keep the exit code")
+		assert.Contains(t, wrapped, "          done\n        env:\n          PROMPT: hello", "Env block should follow the loop")
+	})
+
+	t.Run("pipefail is restored for shell: bash steps", func(t *testing.T) {
+		bashStep := GitHubActionStep{
+			"      - name: Execute Agent",
+			"        shell: bash",
+			"        run: |",
+			"          agent 2>&1 | tee " + logFile,
+		}
+		wrapped := strings.Join(wrapExecutionStepWithRetries(bashStep, &RetriesConfig{Max: 1, Backoff: "fixed", On: []string{"network"}}, logFile), "\n")
+		assert.Contains(t, wrapped, "          (\n          set -e\n          set -o pipefail\n          agent", "Subshell should restore the errexit and pipefail options of shell: bash")
+	})
+
+	t.Run("truncated logs are classified from the start", func(t *testing.T) {
+		truncating := GitHubActionStep{
+			"      - name: Execute Agent",
+			"        run: |",
+			"          agent 2>&1 | tee " + logFile,
+		}
+		wrapped := strings.Join(wrapExecutionStepWithRetries(truncating, &RetriesConfig{Max: 1, Backoff: "fixed", On: []string{"network"}}, logFile), "\n")
+		assert.NotContains(t, wrapped, "wc -c", "Offset should not be recorded when the log is truncated per attempt")
+	})
+}
+
+func TestRetriesAppliedToEngineExecution(t *testing.T) {
+	for _, engine := range []CodingAgentEngine{NewClaudeEngine(), NewCopilotEngine(), NewCodexEngine(), NewGeminiEngine()} {
+		t.Run(engine.GetID(), func(t *testing.T) {
+			compiler := NewCompiler()
+			data := &WorkflowData{
+				Name:         "test-workflow",
+				EngineConfig: &EngineConfig{ID: engine.GetID()},
+				Retries:      &RetriesConfig{Max: 1, Backoff: "fixed", On: []string{"rate-limit"}},
+			}
+
+			var yaml strings.Builder
+			compiler.generateEngineExecutionSteps(&yaml, data, engine, "/tmp/gh-aw/agent-stdio.log")
+
+			assert.Contains(t, yaml.String(), "agent_retry.cjs", "Execution step should be wrapped in the retry loop")
+			assert.Equal(t, 1, strings.Count(yaml.String(), "GH_AW_RETRY_ATTEMPT=0"), "Only the execution step should be wrapped")
+		})
+	}
+}
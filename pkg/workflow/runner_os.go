@@ -0,0 +1,119 @@
+// This file provides runner operating system detection for the agent job.
+//
+// # Runner OS Detection
+//
+// Generated engine installation and launch steps default to Linux conventions
+// (bash, /home/runner, the Copilot CLI binary installer). The runner OS is
+// derived from the runs-on labels so the compiler can emit portable steps for
+// windows-* and macos-* runners:
+//
+//   - Windows: the agent job sets "defaults.run.shell: bash" so generated bash
+//     scripts run under Git Bash instead of PowerShell
+//   - Windows: Node.js resolves /opt and /tmp on the workspace drive while Git
+//     Bash maps them into its install directory, so the agent job first mounts
+//     the workspace drive directories in Git Bash and both see the same files
+//   - macOS and Windows: the Copilot CLI is installed from npm instead of the
+//     Linux-oriented binary installer, and Copilot config lives under the
+//     runner's home directory
+//
+// Runs-on values that do not name an OS (self-hosted labels, runner groups)
+// are treated as Linux.
+
+package workflow
+
+import (
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var runnerOSLog = logger.New("workflow:runner_os")
+
+// RunnerOS identifies the operating system of the runner executing the agent job
+type RunnerOS string
+
+const (
+	// RunnerOSLinux is the default runner OS (ubuntu-*, self-hosted Linux runners)
+	RunnerOSLinux RunnerOS = "linux"
+	// RunnerOSWindows is used for windows-* runners and self-hosted runners labeled windows
+	RunnerOSWindows RunnerOS = "windows"
+	// RunnerOSMacOS is used for macos-* runners and self-hosted runners labeled macos
+	RunnerOSMacOS RunnerOS = "macos"
+)
+
+// runnerHomeDirs maps each runner OS to the home directory of the runner user on
+// GitHub-hosted runners. Windows paths use forward slashes so they work both in
+// Git Bash and in Node.js.
+var runnerHomeDirs = map[RunnerOS]string{
+	RunnerOSLinux:   "/home/runner",
+	RunnerOSMacOS:   "/Users/runner",
+	RunnerOSWindows: "C:/Users/runneradmin",
+}
+
+// detectRunnerOS determines the runner OS from a runs-on value.
+// The first label naming an OS wins; values without an OS label are treated as Linux.
+func detectRunnerOS(runsOn any) RunnerOS {
+	for _, label := range extractRunnerLabels(runsOn) {
+		lower := strings.ToLower(label)
+		switch {
+		case strings.HasPrefix(lower, "windows"):
+			runnerOSLog.Printf("Detected Windows runner from label: %s", label)
+			return RunnerOSWindows
+		case strings.HasPrefix(lower, "macos"):
+			runnerOSLog.Printf("Detected macOS runner from label: %s", label)
+			return RunnerOSMacOS
+		case strings.HasPrefix(lower, "ubuntu"), lower == "linux":
+			return RunnerOSLinux
+		}
+	}
+	return RunnerOSLinux
+}
+
+// getRunnerOS returns the runner OS of the agent job, defaulting to Linux
+func getRunnerOS(workflowData *WorkflowData) RunnerOS {
+	if workflowData == nil || workflowData.RunnerOS == "" {
+		return RunnerOSLinux
+	}
+	return workflowData.RunnerOS
+}
+
+// isLinuxRunner reports whether the agent job runs on a Linux runner
+func isLinuxRunner(workflowData *WorkflowData) bool {
+	return getRunnerOS(workflowData) == RunnerOSLinux
+}
+
+// runnerHomeDir returns the home directory of the runner user for the agent job
+func runnerHomeDir(workflowData *WorkflowData) string {
+	return runnerHomeDirs[getRunnerOS(workflowData)]
+}
+
+// buildRunnerOSJobDefaults returns the job-level defaults section for the agent job.
+// Windows runners default to PowerShell, so generated bash scripts need an explicit shell.
+func buildRunnerOSJobDefaults(workflowData *WorkflowData) string {
+	if getRunnerOS(workflowData) != RunnerOSWindows {
+		return ""
+	}
+	return "defaults:\n  run:\n    shell: bash"
+}
+
+// buildWindowsPathMappingStep returns the step that makes Git Bash resolve /opt and /tmp to
+// the same directories as Node.js on Windows runners. Generated bash steps write under
+// /opt/gh-aw and /tmp/gh-aw, and github-script steps require and read the same paths.
+// Returns nil for other runners.
+func buildWindowsPathMappingStep(workflowData *WorkflowData) []string {
+	if getRunnerOS(workflowData) != RunnerOSWindows {
+		return nil
+	}
+	runnerOSLog.Print("Adding Windows path mapping step")
+	return []string{
+		"      - name: Map /opt and /tmp to the workspace drive\n",
+		"        run: |\n",
+		"          # Node.js resolves /opt and /tmp on the workspace drive; mount the same directories in Git Bash\n",
+		"          drive=\"$(cygpath -m \"$GITHUB_WORKSPACE\" | cut -d: -f1)\"\n",
+		"          sed -i -E '/[[:space:]]\\/(opt|tmp)[[:space:]]/d' /etc/fstab\n",
+		"          for dir in opt tmp; do\n",
+		"            mkdir -p \"$(cygpath -u \"${drive}:/${dir}\")\"\n",
+		"            echo \"${drive}:/${dir} /${dir} ntfs binary,noacl,posix=0 0 0\" >> /etc/fstab\n",
+		"          done\n",
+	}
+}
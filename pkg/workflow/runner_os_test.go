@@ -0,0 +1,245 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectRunnerOS(t *testing.T) {
+	tests := []struct {
+		name     string
+		runsOn   any
+		expected RunnerOS
+	}{
+		{name: "missing runs-on", runsOn: nil, expected: RunnerOSLinux},
+		{name: "ubuntu", runsOn: "ubuntu-latest", expected: RunnerOSLinux},
+		{name: "windows", runsOn: "windows-latest", expected: RunnerOSWindows},
+		{name: "windows versioned", runsOn: "windows-2022", expected: RunnerOSWindows},
+		{name: "macos", runsOn: "macos-latest", expected: RunnerOSMacOS},
+		{name: "macos uppercase", runsOn: "macOS-14", expected: RunnerOSMacOS},
+		{name: "self-hosted windows labels", runsOn: []any{"self-hosted", "Windows", "X64"}, expected: RunnerOSWindows},
+		{name: "self-hosted linux labels", runsOn: []any{"self-hosted", "linux"}, expected: RunnerOSLinux},
+		{name: "runner group with macos label", runsOn: map[string]any{"group": "mac", "labels": []any{"macos-14"}}, expected: RunnerOSMacOS},
+		{name: "runner group without labels", runsOn: map[string]any{"group": "big-runners"}, expected: RunnerOSLinux},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, detectRunnerOS(tt.runsOn), "Runner OS should be detected from runs-on")
+		})
+	}
+}
+
+func TestRunnerHomeDir(t *testing.T) {
+	assert.Equal(t, "/home/runner", runnerHomeDir(nil), "Nil workflow data defaults to Linux")
+	assert.Equal(t, "/home/runner", runnerHomeDir(&WorkflowData{}), "Unset runner OS defaults to Linux")
+	assert.Equal(t, "/Users/runner", runnerHomeDir(&WorkflowData{RunnerOS: RunnerOSMacOS}), "macOS home directory")
+	assert.Equal(t, "C:/Users/runneradmin", runnerHomeDir(&WorkflowData{RunnerOS: RunnerOSWindows}), "Windows home directory")
+}
+
+func TestEngineInstallationStepsPerRunnerOS(t *testing.T) {
+	engines := []struct {
+		name            string
+		engine          CodingAgentEngine
+		linuxInstall    string
+		nonLinuxInstall string
+	}{
+		{name: "copilot", engine: NewCopilotEngine(), linuxInstall: "install_copilot_cli.sh", nonLinuxInstall: "npm install -g @github/copilot@"},
+		{name: "claude", engine: NewClaudeEngine(), linuxInstall: "npm install -g @anthropic-ai/claude-code@", nonLinuxInstall: "npm install -g @anthropic-ai/claude-code@"},
+		{name: "codex", engine: NewCodexEngine(), linuxInstall: "npm install -g @openai/codex@", nonLinuxInstall: "npm install -g @openai/codex@"},
+		{name: "gemini", engine: NewGeminiEngine(), linuxInstall: "npm install -g @google/gemini-cli@", nonLinuxInstall: "npm install -g @google/gemini-cli@"},
+	}
+
+	for _, e := range engines {
+		for _, runnerOS := range []RunnerOS{RunnerOSLinux, RunnerOSWindows, RunnerOSMacOS} {
+			t.Run(e.name+"/"+string(runnerOS), func(t *testing.T) {
+				workflowData := &WorkflowData{
+					Name:          "test-workflow",
+					RunnerOS:      runnerOS,
+					EngineConfig:  &EngineConfig{ID: e.name},
+					SandboxConfig: &SandboxConfig{Agent: &AgentSandboxConfig{Disabled: true}},
+				}
+
+				var rendered strings.Builder
+				for _, step := range e.engine.GetInstallationSteps(workflowData) {
+					rendered.WriteString(strings.Join(step, "\n"))
+					rendered.WriteString("\n")
+				}
+				installSteps := rendered.String()
+
+				if runnerOS == RunnerOSLinux {
+					assert.Contains(t, installSteps, e.linuxInstall, "Linux install step should be generated")
+					return
+				}
+				assert.Contains(t, installSteps, e.nonLinuxInstall, "Portable install step should be generated")
+				assert.NotContains(t, installSteps, "/opt/gh-aw/actions/install_copilot_cli.sh", "Linux binary installer should not be used")
+				assert.NotContains(t, installSteps, "sudo", "Install steps should not require sudo")
+			})
+		}
+	}
+}
+
+func TestAgentJobRunnerOSSteps(t *testing.T) {
+	const pathMappingStep = "name: Map /opt and /tmp to the workspace drive"
+	const windowsDefaults = "defaults:\n      run:\n        shell: bash"
+
+	type runnerOSCase struct {
+		name        string
+		runsOn      string
+		engine      string
+		contains    []string
+		notContains []string
+	}
+	tests := []runnerOSCase{
+		{
+			name:        "copilot on linux",
+			runsOn:      "ubuntu-latest",
+			engine:      "copilot",
+			contains:    []string{"XDG_CONFIG_HOME: /home/runner", "install_copilot_cli.sh"},
+			notContains: []string{"shell: bash\n    env:", "defaults:", pathMappingStep},
+		},
+		{
+			name:        "copilot on macos",
+			runsOn:      "macos-latest",
+			engine:      "copilot",
+			contains:    []string{"XDG_CONFIG_HOME: /Users/runner", "mkdir -p /Users/runner/.copilot", "npm install -g @github/copilot@"},
+			notContains: []string{"defaults:", "/home/runner", pathMappingStep},
+		},
+		{
+			name:        "copilot on windows",
+			runsOn:      "windows-latest",
+			engine:      "copilot",
+			contains:    []string{windowsDefaults, pathMappingStep, "XDG_CONFIG_HOME: C:/Users/runneradmin", "npm install -g @github/copilot@"},
+			notContains: []string{"/home/runner"},
+		},
+	}
+
+	// Every engine gets a portable install and, on Windows, bash as the default shell and the path mapping
+	engineInstalls := map[string]string{
+		"claude": "npm install -g @anthropic-ai/claude-code@",
+		"codex":  "npm install -g @openai/codex@",
+		"gemini": "npm install -g @google/gemini-cli@",
+	}
+	for _, engine := range []string{"claude", "codex", "gemini"} {
+		tests = append(tests,
+			runnerOSCase{
+				name:        engine + " on windows",
+				runsOn:      "windows-latest",
+				engine:      engine,
+				contains:    []string{windowsDefaults, pathMappingStep, engineInstalls[engine]},
+				notContains: []string{"sudo ", "/home/runner"},
+			},
+			runnerOSCase{
+				name:        engine + " on macos",
+				runsOn:      "macos-latest",
+				engine:      engine,
+				contains:    []string{"runs-on: macos-latest", engineInstalls[engine]},
+				notContains: []string{"defaults:", pathMappingStep, "sudo ", "/home/runner"},
+			},
+		)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "runner-os-*")
+			workflowsDir := filepath.Join(tmpDir, constants.GetWorkflowDir())
+			require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows directory")
+
+			content := `---
+on: workflow_dispatch
+runs-on: ` + tt.runsOn + `
+engine: ` + tt.engine + `
+strict: false
+sandbox:
+  agent: false
+---
+
+# Runner OS test
+`
+			workflowFile := filepath.Join(workflowsDir, "runner-os.md")
+			require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(workflowFile), "Workflow should compile")
+
+			lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "runner-os.lock.yml"))
+			require.NoError(t, err, "Lock file should be created")
+			agentJob := extractAgentJobSection(string(lockContent))
+			require.NotEmpty(t, agentJob, "Agent job should be present")
+
+			for _, expected := range tt.contains {
+				assert.Contains(t, agentJob, expected, "Agent job should contain %q", expected)
+			}
+			for _, unexpected := range tt.notContains {
+				assert.NotContains(t, agentJob, unexpected, "Agent job should not contain %q", unexpected)
+			}
+		})
+	}
+}
+
+func TestBuildWindowsPathMappingStep(t *testing.T) {
+	assert.Nil(t, buildWindowsPathMappingStep(&WorkflowData{}), "Linux runners should not map paths")
+	assert.Nil(t, buildWindowsPathMappingStep(&WorkflowData{RunnerOS: RunnerOSMacOS}), "macOS runners should not map paths")
+
+	step := strings.Join(buildWindowsPathMappingStep(&WorkflowData{RunnerOS: RunnerOSWindows}), "")
+	assert.Contains(t, step, `cygpath -m "$GITHUB_WORKSPACE"`, "Mapping should use the workspace drive that Node.js resolves against")
+	assert.Contains(t, step, "for dir in opt tmp; do", "Mapping should cover /opt and /tmp")
+	assert.Contains(t, step, ">> /etc/fstab", "Mapping should mount the directories for later Git Bash processes")
+}
+
+func TestClearMCPConfigStepRunnerHome(t *testing.T) {
+	compiler := NewCompiler()
+
+	linux := strings.Join(compiler.buildClearMCPConfigStep(&WorkflowData{}), "")
+	assert.Contains(t, linux, "rm -f /home/runner/.copilot/mcp-config.json", "Linux should clear the Copilot config under /home/runner")
+
+	windows := strings.Join(compiler.buildClearMCPConfigStep(&WorkflowData{RunnerOS: RunnerOSWindows}), "")
+	assert.Contains(t, windows, "rm -f C:/Users/runneradmin/.copilot/mcp-config.json", "Windows should clear the Copilot config under the runner home")
+	assert.NotContains(t, windows, "/home/runner", "Windows should not use the Linux home directory")
+}
+
+func TestNonLinuxRunnerWarnings(t *testing.T) {
+	compiler := NewCompiler()
+
+	assert.Empty(t, compiler.nonLinuxRunnerWarnings(&WorkflowData{}), "Linux runners should not produce warnings")
+
+	warnings := compiler.nonLinuxRunnerWarnings(&WorkflowData{
+		RunnerOS:      RunnerOSWindows,
+		SandboxConfig: &SandboxConfig{Agent: &AgentSandboxConfig{Disabled: true}},
+	})
+	assert.Empty(t, warnings, "Windows runner without firewall or MCP servers should not produce warnings")
+
+	warnings = compiler.nonLinuxRunnerWarnings(&WorkflowData{
+		RunnerOS:      RunnerOSMacOS,
+		SandboxConfig: &SandboxConfig{Agent: &AgentSandboxConfig{Disabled: true}},
+		Tools:         map[string]any{"github": map[string]any{}},
+	})
+	require.Len(t, warnings, 1, "MCP servers on macOS should produce a warning")
+	assert.Contains(t, warnings[0], "MCP gateway", "Warning should mention the MCP gateway")
+}
+
+// extractAgentJobSection returns the lines of the agent job from a compiled lock file
+func extractAgentJobSection(lockContent string) string {
+	var agentLines []string
+	inAgent := false
+	for line := range strings.SplitSeq(lockContent, "\n") {
+		if strings.HasPrefix(line, "  agent:") {
+			inAgent = true
+		} else if inAgent && strings.HasPrefix(line, "  ") && !strings.HasPrefix(line, "    ") {
+			break
+		}
+		if inAgent {
+			agentLines = append(agentLines, line)
+		}
+	}
+	return strings.Join(agentLines, "\n")
+}
@@ -4,14 +4,18 @@
 //
 // This file validates that the runs-on field in workflow frontmatter does not
 // specify runner types that are incompatible with agentic workflows. Specifically,
-// macOS runners are not supported because agentic workflows rely on containers to
-// provide a secure sandbox, and GitHub-hosted macOS runners do not support container
-// jobs which are required for the Agent Workflow Firewall.
+// macOS runners require the agent sandbox to be disabled (sandbox.agent: false)
+// because agentic workflows rely on containers to provide a secure sandbox, and
+// GitHub-hosted macOS runners do not support container jobs which are required
+// for the Agent Workflow Firewall.
 //
 // # Validation Functions
 //
 //   - validateRunsOn() - Validates the runs-on field for unsupported runner types
 //   - extractRunnerLabels() - Extracts individual runner labels from runs-on value
+//   - isSelfHostedRunsOn() - Detects runs-on values that target self-hosted runners
+//   - selfHostedRunnerWarnings() - Reports engine features that may be missing on self-hosted runners
+//   - nonLinuxRunnerWarnings() - Reports Linux-only features used on Windows or macOS runners
 //
 // # When to Add Validation Here
 //
@@ -32,11 +36,12 @@ var runsOnValidationLog = newValidationLogger("runs_on")
 // macOSRunnerFAQURL is the URL to the FAQ entry explaining why macOS runners are not supported.
 const macOSRunnerFAQURL = "https://github.github.com/gh-aw/reference/faq/#why-are-macos-runners-not-supported"
 
-// validateRunsOn validates that the runs-on field does not specify macOS runners,
-// which are not supported in agentic workflows because they do not support
-// container jobs required for the Agent Workflow Firewall sandbox.
+// validateRunsOn validates that the runs-on field does not specify macOS runners
+// while the agent sandbox is enabled. macOS runners do not support the container
+// jobs required for the Agent Workflow Firewall sandbox, so they can only be used
+// with sandbox.agent: false.
 //
-// Returns an error with a FAQ link if a macOS runner is detected, nil otherwise.
+// Returns an error with a FAQ link if a macOS runner is detected with the sandbox enabled, nil otherwise.
 func validateRunsOn(frontmatter map[string]any, markdownPath string) error {
 	runsOn, exists := frontmatter["runs-on"]
 	if !exists {
@@ -45,6 +50,11 @@ func validateRunsOn(frontmatter map[string]any, markdownPath string) error {
 
 	runsOnValidationLog.Printf("Validating runs-on configuration")
 
+	if isAgentSandboxDisabledInFrontmatter(frontmatter) {
+		runsOnValidationLog.Printf("Agent sandbox disabled, allowing any runner OS")
+		return nil
+	}
+
 	labels := extractRunnerLabels(runsOn)
 	for _, label := range labels {
 		lower := strings.ToLower(label)
@@ -54,7 +64,8 @@ func validateRunsOn(frontmatter map[string]any, markdownPath string) error {
 					"macOS runners are not supported because agentic workflows rely on containers "+
 					"for the secure Agent Workflow Firewall sandbox, and GitHub-hosted macOS runners "+
 					"do not support container jobs.\n\n"+
-					"Use 'ubuntu-latest' (default) or another Linux-based runner instead.\n\n"+
+					"Use 'ubuntu-latest' (default) or another Linux-based runner instead, "+
+					"or disable the agent sandbox with 'sandbox.agent: false' to run without the firewall.\n\n"+
 					"See %s for details.",
 					label, macOSRunnerFAQURL), nil)
 		}
@@ -95,3 +106,65 @@ func extractRunnerLabels(runsOn any) []string {
 
 	return labels
 }
+
+// isSelfHostedRunsOn reports whether a runs-on value targets self-hosted runners.
+// A value is considered self-hosted when it includes the "self-hosted" label or
+// uses the object form with a runner group.
+func isSelfHostedRunsOn(runsOn any) bool {
+	if runsOnMap, ok := runsOn.(map[string]any); ok {
+		if group, ok := runsOnMap["group"].(string); ok && group != "" {
+			return true
+		}
+	}
+	for _, label := range extractRunnerLabels(runsOn) {
+		if strings.EqualFold(label, "self-hosted") {
+			return true
+		}
+	}
+	return false
+}
+
+// selfHostedRunnerWarnings returns warnings for workflow features that need Docker or
+// outbound network access, which self-hosted runners may not provide.
+// Returns nil when the workflow does not target self-hosted runners.
+func (c *Compiler) selfHostedRunnerWarnings(workflowData *WorkflowData) []string {
+	if workflowData == nil || !workflowData.SelfHostedRunner {
+		return nil
+	}
+
+	var warnings []string
+	if isFirewallEnabled(workflowData) {
+		warnings = append(warnings, "self-hosted runner: the agent firewall runs the engine inside Docker containers. "+
+			"Ensure Docker is installed and the runner user can access the Docker daemon.")
+	}
+	if images := collectDockerImages(workflowData.Tools, workflowData, c.actionMode); len(images) > 0 {
+		warnings = append(warnings, fmt.Sprintf("self-hosted runner: the workflow pulls Docker images (%s). "+
+			"Ensure the runner can pull from these image registries.", strings.Join(images, ", ")))
+	}
+
+	runsOnValidationLog.Printf("Generated %d self-hosted runner warnings", len(warnings))
+	return warnings
+}
+
+// nonLinuxRunnerWarnings returns warnings for workflow features that only work on
+// Linux runners when the agent job targets a Windows or macOS runner.
+// Returns nil for Linux runners.
+func (c *Compiler) nonLinuxRunnerWarnings(workflowData *WorkflowData) []string {
+	if isLinuxRunner(workflowData) {
+		return nil
+	}
+
+	runnerOS := getRunnerOS(workflowData)
+	var warnings []string
+	if isFirewallEnabled(workflowData) {
+		warnings = append(warnings, fmt.Sprintf("%s runner: the agent firewall requires a Linux runner with Docker. "+
+			"Use a Linux runner or set 'sandbox.agent: false'.", runnerOS))
+	}
+	if HasMCPServers(workflowData) {
+		warnings = append(warnings, fmt.Sprintf("%s runner: the MCP gateway and containerized MCP servers run in Linux Docker containers. "+
+			"Ensure the runner provides Docker with Linux containers.", runnerOS))
+	}
+
+	runsOnValidationLog.Printf("Generated %d %s runner warnings", len(warnings), runnerOS)
+	return warnings
+}
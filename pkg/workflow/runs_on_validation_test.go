@@ -105,6 +105,25 @@ func TestValidateRunsOn(t *testing.T) {
 			errorInMsg:  "macos-14",
 			description: "Object form with macos labels should be rejected",
 		},
+		{
+			name: "macos allowed with agent sandbox disabled",
+			frontmatter: map[string]any{
+				"runs-on": "macos-latest",
+				"sandbox": map[string]any{"agent": false},
+			},
+			wantErr:     false,
+			description: "macOS runners should be allowed when sandbox.agent is false",
+		},
+		{
+			name: "macos rejected with agent sandbox configured",
+			frontmatter: map[string]any{
+				"runs-on": "macos-latest",
+				"sandbox": map[string]any{"agent": "awf"},
+			},
+			wantErr:     true,
+			errorInMsg:  "sandbox.agent: false",
+			description: "macOS runners should be rejected when the agent sandbox is enabled",
+		},
 		{
 			name:        "error message contains FAQ link",
 			frontmatter: map[string]any{"runs-on": "macos-latest"},
@@ -187,3 +206,42 @@ func TestExtractRunnerLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSelfHostedRunsOn(t *testing.T) {
+	tests := []struct {
+		name     string
+		runsOn   any
+		expected bool
+	}{
+		{name: "github-hosted string", runsOn: "ubuntu-latest", expected: false},
+		{name: "self-hosted string", runsOn: "self-hosted", expected: true},
+		{name: "self-hosted label array", runsOn: []any{"self-hosted", "linux", "x64"}, expected: true},
+		{name: "label array without self-hosted", runsOn: []any{"ubuntu-latest", "linux"}, expected: false},
+		{name: "runner group", runsOn: map[string]any{"group": "my-runners"}, expected: true},
+		{name: "labels object without group", runsOn: map[string]any{"labels": []any{"linux"}}, expected: false},
+		{name: "nil", runsOn: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isSelfHostedRunsOn(tt.runsOn), "Self-hosted detection should match")
+		})
+	}
+}
+
+func TestSelfHostedRunnerWarnings(t *testing.T) {
+	compiler := NewCompiler()
+
+	assert.Empty(t, compiler.selfHostedRunnerWarnings(&WorkflowData{}), "GitHub-hosted runners should not produce warnings")
+
+	warnings := compiler.selfHostedRunnerWarnings(&WorkflowData{
+		SelfHostedRunner: true,
+		Tools: map[string]any{
+			"github": map[string]any{"mode": "local"},
+		},
+		SandboxConfig: &SandboxConfig{Agent: &AgentSandboxConfig{Type: SandboxTypeAWF}},
+	})
+	require.Len(t, warnings, 2, "Should warn about firewall and Docker image pulls")
+	assert.Contains(t, warnings[0], "agent firewall", "First warning should mention the firewall")
+	assert.Contains(t, warnings[1], "ghcr.io/github/github-mcp-server", "Second warning should list Docker images")
+}
@@ -3,6 +3,7 @@ package workflow
 import (
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
@@ -161,7 +162,9 @@ func (c *Compiler) buildSafeJobs(data *WorkflowData, threatDetectionEnabled bool
 
 	safeJobsLog.Printf("Building %d safe-jobs, threatDetectionEnabled=%v", len(data.SafeOutputs.Jobs), threatDetectionEnabled)
 	var safeJobNames []string
-	for jobName, jobConfig := range data.SafeOutputs.Jobs {
+	// Build jobs in name order so the compiled lock file is stable across runs
+	for _, jobName := range slices.Sorted(maps.Keys(data.SafeOutputs.Jobs)) {
+		jobConfig := data.SafeOutputs.Jobs[jobName]
 		// Normalize job name to use underscores for consistency
 		normalizedJobName := stringutil.NormalizeSafeOutputIdentifier(jobName)
 
@@ -200,6 +203,9 @@ func (c *Compiler) buildSafeJobs(data *WorkflowData, threatDetectionEnabled bool
 			job.RunsOn = "runs-on: ubuntu-latest" // Default
 		}
 
+		// Safe-jobs apply agent output, so they run in the safe-outputs environment
+		job.Environment = safeOutputsEnvironment(data)
+
 		// Set if condition - combine safe output type check with user-provided condition
 		// Custom safe jobs should only run if the agent output contains the job name (tool call)
 		// Use normalized job name to match the underscore format in output_types
@@ -239,8 +245,8 @@ func (c *Compiler) buildSafeJobs(data *WorkflowData, threatDetectionEnabled bool
 
 		// Add job-specific environment variables
 		if jobConfig.Env != nil {
-			for key, value := range jobConfig.Env {
-				steps = append(steps, fmt.Sprintf("          echo \"%s=%s\" >> \"$GITHUB_ENV\"\n", key, value))
+			for _, key := range slices.Sorted(maps.Keys(jobConfig.Env)) {
+				steps = append(steps, fmt.Sprintf("          echo \"%s=%s\" >> \"$GITHUB_ENV\"\n", key, jobConfig.Env[key]))
 			}
 		}
 
@@ -254,8 +260,8 @@ func (c *Compiler) buildSafeJobs(data *WorkflowData, threatDetectionEnabled bool
 						return nil, fmt.Errorf("failed to convert step to typed step for safe job %s: %w", jobName, err)
 					}
 
-					// Apply action pinning using type-safe version
-					pinnedStep := ApplyActionPinToTypedStep(typedStep, data)
+					// Apply action pinning and checkout hardening using type-safe version
+					pinnedStep := HardenCheckoutStep(ApplyActionPinToTypedStep(typedStep, data))
 
 					// Convert back to map for YAML generation
 					stepYAML, err := c.convertStepToYAML(pinnedStep.ToMap())
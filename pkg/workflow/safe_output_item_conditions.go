@@ -0,0 +1,243 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputItemConditionsLog = logger.New("workflow:safe_output_item_conditions")
+
+// SafeOutputField declares a typed field the agent can set on items of a safe output type (fields:).
+// A post-run if: condition references it as item.<name>.
+//
+// Example:
+//
+//	safe-outputs:
+//	  create-issue:
+//	    fields:
+//	      severity:
+//	        type: string
+//	        enum: [low, medium, high, critical]
+//	    if: item.severity >= 'high'
+type SafeOutputField struct {
+	Type        string   `yaml:"type,omitempty" json:"type"`                         // string, number or boolean
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"` // Shown to the agent in the tool schema
+	Enum        []string `yaml:"enum,omitempty" json:"enum,omitempty"`               // Allowed string values, lowest first; ordering comparisons follow this order
+	Required    bool     `yaml:"required,omitempty" json:"required,omitempty"`       // Whether the agent must set the field
+}
+
+// safeOutputFieldTypes lists the accepted values of fields.<name>.type
+var safeOutputFieldTypes = []string{"boolean", "number", "string"}
+
+// itemConditionUnsupportedTypes lists safe output types that are processed outside the handler
+// manager or collected before it dispatches items, so post-run conditions cannot skip their items
+var itemConditionUnsupportedTypes = []string{"assign_to_agent", "create_agent_session", "dispatch_workflow", "missing_data", "missing_tool", "noop", "upload_asset"}
+
+// itemConditionOperand matches the operands of a post-run condition: item fields and literals
+const itemConditionOperand = `item\.[A-Za-z_][A-Za-z0-9_]*|'(?:[^']|'')*'|-?[0-9]+(?:\.[0-9]+)?|true|false|null`
+
+var (
+	itemConditionComparisonPattern = regexp.MustCompile(`^(` + itemConditionOperand + `)\s*(?:(==|!=|<=|>=|<|>)\s*(` + itemConditionOperand + `))?$`)
+	itemConditionStringPattern     = regexp.MustCompile(`'(?:[^']|'')*'`)
+	itemFieldReferencePattern      = regexp.MustCompile(`(^|[^\w.])item\.`)
+	safeOutputFieldNamePattern     = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+)
+
+// parseSafeOutputFields parses the fields: map of a safe output. Invalid entries are kept as
+// parsed and reported by validateSafeOutputFields.
+func parseSafeOutputFields(value any) map[string]*SafeOutputField {
+	fieldsMap, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	fields := make(map[string]*SafeOutputField, len(fieldsMap))
+	for name, fieldValue := range fieldsMap {
+		field := &SafeOutputField{}
+		if fieldMap, ok := fieldValue.(map[string]any); ok {
+			field.Type, _ = fieldMap["type"].(string)
+			field.Description, _ = fieldMap["description"].(string)
+			field.Required, _ = fieldMap["required"].(bool)
+			if enumValues, ok := fieldMap["enum"].([]any); ok {
+				for _, enumValue := range enumValues {
+					field.Enum = append(field.Enum, fmt.Sprint(enumValue))
+				}
+			}
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// isItemCondition reports whether an if: condition references item fields, which makes it a
+// post-run condition evaluated per item instead of a GitHub Actions expression
+func isItemCondition(condition string) bool {
+	return itemFieldReferencePattern.MatchString(itemConditionStringPattern.ReplaceAllString(condition, "''"))
+}
+
+// validateSafeOutputFields checks the fields: declarations of a safe output
+func validateSafeOutputFields(toolName string, fields map[string]*SafeOutputField) error {
+	prefix := fmt.Sprintf("safe-outputs.%s.fields", strings.ReplaceAll(toolName, "_", "-"))
+	builtin := ValidationConfig[toolName].Fields
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := fields[name]
+		if !safeOutputFieldNamePattern.MatchString(name) {
+			return NewValidationError(prefix, name, "field names must be lower case letters, digits and underscores", "Rename the field, for example: severity or risk_score")
+		}
+		if _, exists := builtin[name]; exists || name == "type" {
+			return NewValidationError(prefix+"."+name, name, "the field is already defined by the safe output", "Choose a name that is not one of the tool's own parameters")
+		}
+		if !slices.Contains(safeOutputFieldTypes, field.Type) {
+			return NewValidationError(prefix+"."+name+".type", field.Type, "unknown field type", "Use one of: "+strings.Join(safeOutputFieldTypes, ", "))
+		}
+		if len(field.Enum) > 0 && field.Type != "string" {
+			return NewValidationError(prefix+"."+name+".enum", strings.Join(field.Enum, ", "), "enum is only supported for string fields", "Set type: string or remove the enum")
+		}
+	}
+	return nil
+}
+
+// validateItemCondition checks a post-run if: condition: every comparison must compare declared
+// item fields with literals of a matching type
+func validateItemCondition(toolName, condition string, fields map[string]*SafeOutputField) error {
+	field := fmt.Sprintf("safe-outputs.%s.if", strings.ReplaceAll(toolName, "_", "-"))
+	if slices.Contains(itemConditionUnsupportedTypes, toolName) {
+		return NewValidationError(field, condition, "conditions on item fields are not supported for this safe output", "Use a GitHub Actions expression instead, for example: github.event.issue.user.type != 'Bot'")
+	}
+
+	node, err := ParseExpression(stripExpressionWrapper(condition))
+	if err != nil {
+		return NewValidationError(field, condition, "invalid expression syntax: "+err.Error(), "Check that parentheses and quotes are balanced.")
+	}
+
+	return VisitExpressionTree(node, func(expr *ExpressionNode) error {
+		match := itemConditionComparisonPattern.FindStringSubmatch(strings.TrimSpace(expr.Expression))
+		if match == nil {
+			return NewValidationError(field, condition,
+				fmt.Sprintf("'%s' is not a comparison of item fields and literals; conditions on item fields are evaluated after the agent run, where GitHub Actions contexts are not available", expr.Expression),
+				"Compare item fields with literals, for example: item.severity >= 'high' && item.confirmed")
+		}
+		left, operator, right := match[1], match[2], match[3]
+
+		for _, operand := range []string{left, right} {
+			name, isField := strings.CutPrefix(operand, "item.")
+			if isField && fields[name] == nil {
+				return NewValidationError(field, condition,
+					fmt.Sprintf("unknown item field '%s'", name),
+					fmt.Sprintf("Declare it under safe-outputs.%s.fields, for example:\nfields:\n  %s:\n    type: string", strings.ReplaceAll(toolName, "_", "-"), name))
+			}
+		}
+		if operator == "" {
+			return nil
+		}
+
+		// Ordering comparisons need numbers or an enum that defines the order
+		for _, pair := range [][2]string{{left, right}, {right, left}} {
+			name, isField := strings.CutPrefix(pair[0], "item.")
+			if !isField {
+				continue
+			}
+			declared := fields[name]
+			if operator != "==" && operator != "!=" && declared.Type != "number" && len(declared.Enum) == 0 {
+				return NewValidationError(field, condition,
+					fmt.Sprintf("'%s' orders item.%s, which is neither a number nor an enum", expr.Expression, name),
+					"Declare the field with type: number, or with an enum listing its values from lowest to highest")
+			}
+			if literal, isString := strings.CutPrefix(pair[1], "'"); isString && len(declared.Enum) > 0 {
+				value := strings.ReplaceAll(strings.TrimSuffix(literal, "'"), "''", "'")
+				if !slices.ContainsFunc(declared.Enum, func(entry string) bool { return strings.EqualFold(entry, value) }) {
+					return NewValidationError(field, condition,
+						fmt.Sprintf("'%s' is not a value of item.%s", value, name),
+						"Use one of: "+strings.Join(declared.Enum, ", "))
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// safeOutputItemConditions returns the post-run if: condition of every enabled safe output that
+// has one, keyed by safe output tool name (e.g. "create_issue")
+func safeOutputItemConditions(safeOutputs *SafeOutputsConfig) map[string]string {
+	conditions := make(map[string]string)
+	for toolName, base := range safeOutputBaseConfigs(safeOutputs) {
+		if base.If != "" && isItemCondition(base.If) {
+			conditions[toolName] = stripExpressionWrapper(base.If)
+		}
+	}
+	return conditions
+}
+
+// addItemFieldsToTool adds the declared item fields of a safe output to its MCP tool input schema
+func addItemFieldsToTool(tool map[string]any, fields map[string]*SafeOutputField) {
+	if len(fields) == 0 {
+		return
+	}
+	inputSchema, ok := tool["inputSchema"].(map[string]any)
+	if !ok {
+		return
+	}
+	properties, ok := inputSchema["properties"].(map[string]any)
+	if !ok {
+		return
+	}
+
+	required, _ := inputSchema["required"].([]any)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := fields[name]
+		property := map[string]any{"type": field.Type}
+		if field.Description != "" {
+			property["description"] = field.Description
+		}
+		if len(field.Enum) > 0 {
+			property["enum"] = field.Enum
+		}
+		properties[name] = property
+		if field.Required {
+			required = append(required, name)
+		}
+	}
+	if len(required) > 0 {
+		inputSchema["required"] = required
+	}
+	safeOutputItemConditionsLog.Printf("Added %d item fields to tool %v", len(fields), tool["name"])
+}
+
+// safeOutputFieldValidations returns the validation rules for the declared item fields of every
+// enabled safe output, keyed by safe output tool name
+func safeOutputFieldValidations(safeOutputs *SafeOutputsConfig) map[string]map[string]FieldValidation {
+	validations := make(map[string]map[string]FieldValidation)
+	for toolName, base := range safeOutputBaseConfigs(safeOutputs) {
+		if len(base.Fields) == 0 {
+			continue
+		}
+		validations[toolName] = make(map[string]FieldValidation, len(base.Fields))
+		for name, field := range base.Fields {
+			validation := FieldValidation{Required: field.Required, Type: field.Type, Enum: field.Enum}
+			if field.Type == "string" {
+				validation.Sanitize = true
+				validation.MaxLength = 256
+			}
+			validations[toolName][name] = validation
+		}
+	}
+	return validations
+}
@@ -0,0 +1,115 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsItemCondition(t *testing.T) {
+	assert.True(t, isItemCondition("item.severity >= 'high'"), "item field reference should make a post-run condition")
+	assert.True(t, isItemCondition("${{ !item.confirmed }}"), "negated item field should make a post-run condition")
+	assert.False(t, isItemCondition("github.event.issue.user.type != 'Bot'"), "GitHub contexts should stay Actions expressions")
+	assert.False(t, isItemCondition("github.event.item.id == 1"), "nested item property should not count")
+	assert.False(t, isItemCondition("github.actor != 'item.x'"), "item inside a string literal should not count")
+}
+
+func TestValidateItemCondition(t *testing.T) {
+	fields := map[string]*SafeOutputField{
+		"severity":  {Type: "string", Enum: []string{"low", "medium", "high", "critical"}},
+		"score":     {Type: "number"},
+		"area":      {Type: "string"},
+		"confirmed": {Type: "boolean"},
+	}
+
+	tests := []struct {
+		name      string
+		toolName  string
+		condition string
+		errorMsg  string
+	}{
+		{name: "enum ordering", condition: "item.severity >= 'high'"},
+		{name: "combined comparisons", condition: "(item.score > 7.5 || item.area == 'security') && !item.confirmed"},
+		{name: "unknown field", condition: "item.priority == 'p1'", errorMsg: "unknown item field 'priority'"},
+		{name: "github context", condition: "item.confirmed && github.actor != 'bot'", errorMsg: "GitHub Actions contexts are not available"},
+		{name: "ordering a plain string", condition: "item.area > 'a'", errorMsg: "neither a number nor an enum"},
+		{name: "value outside the enum", condition: "item.severity == 'urgent'", errorMsg: "'urgent' is not a value of item.severity"},
+		{name: "standalone step type", toolName: "assign_to_agent", condition: "item.confirmed", errorMsg: "not supported for this safe output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toolName := tt.toolName
+			if toolName == "" {
+				toolName = "create_issue"
+			}
+			err := validateItemCondition(toolName, tt.condition, fields)
+			if tt.errorMsg == "" {
+				assert.NoError(t, err, "condition should be valid")
+				return
+			}
+			require.Error(t, err, "condition should be rejected")
+			assert.Contains(t, err.Error(), tt.errorMsg, "error should explain the problem")
+		})
+	}
+}
+
+func TestValidateSafeOutputFields(t *testing.T) {
+	require.NoError(t, validateSafeOutputFields("create_issue", map[string]*SafeOutputField{
+		"severity": {Type: "string", Enum: []string{"low", "high"}},
+		"score":    {Type: "number", Required: true},
+	}), "valid fields should be accepted")
+
+	for errorMsg, fields := range map[string]map[string]*SafeOutputField{
+		"already defined by the safe output": {"title": {Type: "string"}},
+		"unknown field type":                 {"severity": {Type: "integer"}},
+		"enum is only supported for string":  {"score": {Type: "number", Enum: []string{"1"}}},
+		"lower case letters":                 {"Risk-Score": {Type: "number"}},
+	} {
+		err := validateSafeOutputFields("create_issue", fields)
+		require.Error(t, err, "invalid fields should be rejected: %v", fields)
+		assert.Contains(t, err.Error(), errorMsg, "error should explain the problem")
+	}
+}
+
+func TestItemConditionsCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "item-conditions-test")
+	workflowPath := filepath.Join(tmpDir, "audit.md")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+safe-outputs:
+  create-issue:
+    fields:
+      severity:
+        type: string
+        description: Severity of the finding
+        enum: [low, medium, high, critical]
+        required: true
+    if: item.severity >= 'high'
+  add-comment:
+    if: github.actor != 'bot'
+---
+
+Audit the repository.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, `\"item_condition\":\"item.severity \\u003e= 'high'\"`, "post-run condition should be passed to the handler manager")
+	assert.NotContains(t, lock, "${{ item.severity", "post-run condition must not become an Actions expression")
+	assert.Contains(t, lock, `\"add_comment\":{\"if\":\"${{ github.actor != 'bot' }}\"`, "Actions conditions should still be rendered")
+	assert.Contains(t, lock, `"description": "Severity of the finding"`, "field should be added to the tool schema")
+}
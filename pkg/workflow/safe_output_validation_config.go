@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"encoding/json"
+	"maps"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -96,6 +97,16 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"repo":                {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
 		},
 	},
+	"request_review": {
+		DefaultMax:       3,
+		CustomValidation: "requiresOneOf:reviewers,team_reviewers",
+		Fields: map[string]FieldValidation{
+			"reviewers":           {Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: MaxGitHubUsernameLength},
+			"team_reviewers":      {Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: 128},
+			"pull_request_number": {IssueOrPRNumber: true},
+			"repo":                {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+		},
+	},
 	"assign_milestone": {
 		DefaultMax: 1,
 		Fields: map[string]FieldValidation{
@@ -132,6 +143,16 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"repo":         {Type: "string", MaxLength: 256},                  // Optional: target repository in format "owner/repo"
 		},
 	},
+	"assign": {
+		DefaultMax:       1,
+		CustomValidation: "requiresOneOf:assignees,teams",
+		Fields: map[string]FieldValidation{
+			"item_number": {IssueOrPRNumber: true},
+			"assignees":   {Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: MaxGitHubUsernameLength},
+			"teams":       {Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: 128},
+			"repo":        {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+		},
+	},
 	"update_issue": {
 		DefaultMax:       1,
 		CustomValidation: "requiresOneOf:status,title,body",
@@ -176,7 +197,8 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"body":       {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
 			"start_line": {OptionalPositiveInteger: true},
 			"side":       {Type: "string", Enum: []string{"LEFT", "RIGHT"}},
-			"repo":       {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+			"suggestion": {Type: "string", Sanitize: true, MaxLength: MaxBodyLength}, // Optional: replacement for the commented lines
+			"repo":       {Type: "string", MaxLength: 256},                           // Optional: target repository in format "owner/repo"
 		},
 	},
 	"submit_pull_request_review": {
@@ -219,6 +241,14 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"repo":              {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
 		},
 	},
+	"answer_discussion": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"body":              {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
+			"discussion_number": {OptionalPositiveInteger: true},
+			"repo":              {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
+		},
+	},
 	"close_issue": {
 		DefaultMax: 1,
 		Fields: map[string]FieldValidation{
@@ -381,6 +411,12 @@ var ValidationConfig = map[string]TypeValidationConfig{
 // If enabledTypes is empty or nil, returns all validation configs
 // If enabledTypes is provided, returns only configs for the specified types
 func GetValidationConfigJSON(enabledTypes []string) (string, error) {
+	return GetValidationConfigJSONWithFields(enabledTypes, nil)
+}
+
+// GetValidationConfigJSONWithFields is GetValidationConfigJSON with additional field rules per type,
+// such as the typed item fields declared under safe-outputs.<type>.fields
+func GetValidationConfigJSONWithFields(enabledTypes []string, extraFields map[string]map[string]FieldValidation) (string, error) {
 	safeOutputValidationLog.Printf("Getting validation config JSON for %d types", len(enabledTypes))
 
 	configToMarshal := ValidationConfig
@@ -396,6 +432,21 @@ func GetValidationConfigJSON(enabledTypes []string) (string, error) {
 		safeOutputValidationLog.Print("Returning all validation configs")
 	}
 
+	if len(extraFields) > 0 {
+		merged := make(map[string]TypeValidationConfig, len(configToMarshal))
+		maps.Copy(merged, configToMarshal)
+		for typeName, fields := range extraFields {
+			config, ok := merged[typeName]
+			if !ok {
+				continue
+			}
+			config.Fields = maps.Clone(config.Fields)
+			maps.Copy(config.Fields, fields)
+			merged[typeName] = config
+		}
+		configToMarshal = merged
+	}
+
 	data, err := json.MarshalIndent(configToMarshal, "", "  ")
 	if err != nil {
 		safeOutputValidationLog.Printf("Failed to marshal validation config: %v", err)
@@ -252,6 +252,8 @@ func TestValidationConfigConsistency(t *testing.T) {
 		"requiresOneOf:status,title,body":        true,
 		"requiresOneOf:title,body":               true,
 		"requiresOneOf:issue_number,pull_number": true,
+		"requiresOneOf:reviewers,team_reviewers": true,
+		"requiresOneOf:assignees,teams":          true,
 		"startLineLessOrEqualLine":               true,
 		"parentAndSubDifferent":                  true,
 	}
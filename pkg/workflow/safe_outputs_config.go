@@ -106,6 +106,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.CloseDiscussions = closeDiscussionsConfig
 			}
 
+			// Handle answer-discussion
+			answerDiscussionConfig := c.parseAnswerDiscussionConfig(outputMap)
+			if answerDiscussionConfig != nil {
+				config.AnswerDiscussion = answerDiscussionConfig
+			}
+
 			// Handle close-issue
 			closeIssuesConfig := c.parseCloseIssuesConfig(outputMap)
 			if closeIssuesConfig != nil {
@@ -218,6 +224,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.AddReviewer = addReviewerConfig
 			}
 
+			// Handle request-review
+			requestReviewConfig := c.parseRequestReviewConfig(outputMap)
+			if requestReviewConfig != nil {
+				config.RequestReview = requestReviewConfig
+			}
+
 			// Parse assign-milestone configuration
 			assignMilestoneConfig := c.parseAssignMilestoneConfig(outputMap)
 			if assignMilestoneConfig != nil {
@@ -242,6 +254,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.UnassignFromUser = unassignFromUserConfig
 			}
 
+			// Handle assign
+			assignConfig := c.parseAssignConfig(outputMap)
+			if assignConfig != nil {
+				config.Assign = assignConfig
+			}
+
 			// Handle update-issue
 			updateIssuesConfig := c.parseUpdateIssuesConfig(outputMap)
 			if updateIssuesConfig != nil {
@@ -445,6 +463,11 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.Mentions = parseMentionsConfig(mentions)
 			}
 
+			// Handle sanitize configuration
+			if sanitize, exists := outputMap["sanitize"]; exists {
+				config.Sanitize = parseSanitizeConfig(sanitize)
+			}
+
 			// Handle global footer flag
 			if footer, exists := outputMap["footer"]; exists {
 				if footerBool, ok := footer.(bool); ok {
@@ -461,6 +484,14 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				}
 			}
 
+			// Handle summary-comment flag
+			if summaryComment, exists := outputMap["summary-comment"]; exists {
+				if summaryCommentBool, ok := summaryComment.(bool); ok {
+					config.SummaryComment = summaryCommentBool
+					safeOutputsConfigLog.Printf("Summary comment control: %t", summaryCommentBool)
+				}
+			}
+
 			// Handle max-bot-mentions (templatable integer)
 			if err := preprocessIntFieldAsString(outputMap, "max-bot-mentions", safeOutputsConfigLog); err != nil {
 				safeOutputsConfigLog.Printf("max-bot-mentions: %v", err)
@@ -498,6 +529,14 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				}
 			}
 
+			// Handle environment configuration
+			if environment, exists := outputMap["environment"]; exists {
+				if environmentStr, ok := environment.(string); ok && environmentStr != "" {
+					config.Environment = environmentStr
+					safeOutputsConfigLog.Printf("Configured environment for safe-outputs jobs: %s", environmentStr)
+				}
+			}
+
 			// Handle jobs (safe-jobs must be under safe-outputs)
 			if jobs, exists := outputMap["jobs"]; exists {
 				if jobsMap, ok := jobs.(map[string]any); ok {
@@ -583,6 +622,20 @@ func (c *Compiler) parseBaseSafeOutputConfig(configMap map[string]any, config *B
 			config.Staged = stagedBool
 		}
 	}
+
+	// Parse if condition (evaluated by GitHub Actions when the safe outputs job runs)
+	if ifCond, exists := configMap["if"]; exists {
+		if ifStr, ok := ifCond.(string); ok {
+			config.If = stripExpressionWrapper(c.extractExpressionFromIfString(ifStr))
+			safeOutputsConfigLog.Printf("Parsed if condition: %s", config.If)
+		}
+	}
+
+	// Parse typed item fields (validated together with the if condition)
+	if fields, exists := configMap["fields"]; exists {
+		config.Fields = parseSafeOutputFields(fields)
+		safeOutputsConfigLog.Printf("Parsed %d item fields", len(config.Fields))
+	}
 }
 
 var safeOutputsAppLog = logger.New("workflow:safe_outputs_app")
@@ -822,16 +875,17 @@ func convertPermissionsToAppTokenFields(permissions *Permissions) map[string]str
 	return fields
 }
 
-// buildGitHubAppTokenInvalidationStep generates the step to invalidate the GitHub App token
+// buildGitHubAppTokenInvalidationStep generates the step named name that invalidates the GitHub
+// App token minted by the step with ID tokenStepID
 // This step always runs (even on failure) to ensure tokens are properly cleaned up
 // Only runs if a token was successfully minted
-func (c *Compiler) buildGitHubAppTokenInvalidationStep() []string {
+func (c *Compiler) buildGitHubAppTokenInvalidationStep(tokenStepID, name string) []string {
 	var steps []string
 
-	steps = append(steps, "      - name: Invalidate GitHub App token\n")
-	steps = append(steps, "        if: always() && steps.safe-outputs-app-token.outputs.token != ''\n")
+	steps = append(steps, fmt.Sprintf("      - name: %s\n", name))
+	steps = append(steps, fmt.Sprintf("        if: always() && steps.%s.outputs.token != ''\n", tokenStepID))
 	steps = append(steps, "        env:\n")
-	steps = append(steps, "          TOKEN: ${{ steps.safe-outputs-app-token.outputs.token }}\n")
+	steps = append(steps, fmt.Sprintf("          TOKEN: ${{ steps.%s.outputs.token }}\n", tokenStepID))
 	steps = append(steps, "        run: |\n")
 	steps = append(steps, "          echo \"Revoking GitHub App installation token...\"\n")
 	steps = append(steps, "          # GitHub CLI will auth with the token being revoked.\n")
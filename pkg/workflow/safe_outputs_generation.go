@@ -220,6 +220,14 @@ func generateSafeOutputsConfig(data *WorkflowData) string {
 				data.SafeOutputs.CloseDiscussions.RequiredTitlePrefix,
 			)
 		}
+		if data.SafeOutputs.AnswerDiscussion != nil {
+			safeOutputsConfig["answer_discussion"] = generateTargetConfigWithRepos(
+				data.SafeOutputs.AnswerDiscussion.SafeOutputTargetConfig,
+				data.SafeOutputs.AnswerDiscussion.Max,
+				1, // default max
+				nil,
+			)
+		}
 		if data.SafeOutputs.CloseIssues != nil {
 			additionalFields := make(map[string]any)
 			if len(data.SafeOutputs.CloseIssues.RequiredLabels) > 0 {
@@ -300,6 +308,14 @@ func generateSafeOutputsConfig(data *WorkflowData) string {
 				data.SafeOutputs.AddReviewer.Reviewers,
 			)
 		}
+		if data.SafeOutputs.RequestReview != nil {
+			safeOutputsConfig["request_review"] = generateTargetConfigWithRepos(
+				data.SafeOutputs.RequestReview.SafeOutputTargetConfig,
+				data.SafeOutputs.RequestReview.Max,
+				3, // default max
+				generateAllowedUsersAndTeamsFields(data.SafeOutputs.RequestReview.AllowedUsers, data.SafeOutputs.RequestReview.AllowedTeams),
+			)
+		}
 		if data.SafeOutputs.AssignMilestone != nil {
 			safeOutputsConfig["assign_milestone"] = generateMaxWithAllowedConfig(
 				data.SafeOutputs.AssignMilestone.Max,
@@ -324,6 +340,14 @@ func generateSafeOutputsConfig(data *WorkflowData) string {
 				data.SafeOutputs.AssignToUser.Blocked,
 			)
 		}
+		if data.SafeOutputs.Assign != nil {
+			safeOutputsConfig["assign"] = generateTargetConfigWithRepos(
+				data.SafeOutputs.Assign.SafeOutputTargetConfig,
+				data.SafeOutputs.Assign.Max,
+				1, // default max
+				generateAllowedUsersAndTeamsFields(data.SafeOutputs.Assign.AllowedUsers, data.SafeOutputs.Assign.AllowedTeams),
+			)
+		}
 		if data.SafeOutputs.UnassignFromUser != nil {
 			safeOutputsConfig["unassign_from_user"] = generateMaxWithAllowedAndBlockedConfig(
 				data.SafeOutputs.UnassignFromUser.Max,
@@ -365,10 +389,15 @@ func generateSafeOutputsConfig(data *WorkflowData) string {
 			)
 		}
 		if data.SafeOutputs.UploadAssets != nil {
-			safeOutputsConfig["upload_asset"] = generateMaxConfig(
+			uploadAssetConfig := generateMaxConfig(
 				data.SafeOutputs.UploadAssets.Max,
 				0, // default: unlimited
 			)
+			if data.SafeOutputs.UploadAssets.IsArtifactTarget() {
+				uploadAssetConfig["target"] = uploadAssetTargetArtifact
+				uploadAssetConfig["artifact_name"] = data.SafeOutputs.UploadAssets.ArtifactName
+			}
+			safeOutputsConfig["upload_asset"] = uploadAssetConfig
 		}
 		if data.SafeOutputs.MissingTool != nil {
 			// Generate config for missing_tool with issue creation support
@@ -708,6 +737,18 @@ func generateMaxWithReviewersConfig(max *string, defaultMax int, reviewers []str
 	return config
 }
 
+// generateAllowedUsersAndTeamsFields creates the allow-list fields of request_review and assign
+func generateAllowedUsersAndTeamsFields(allowedUsers []string, allowedTeams []string) map[string]any {
+	fields := make(map[string]any)
+	if len(allowedUsers) > 0 {
+		fields["allowed_users"] = allowedUsers
+	}
+	if len(allowedTeams) > 0 {
+		fields["allowed_teams"] = allowedTeams
+	}
+	return fields
+}
+
 // generateAssignToAgentConfig creates a config with optional max, default_agent, target, and allowed
 func generateAssignToAgentConfig(max *string, defaultMax int, defaultAgent string, target string, allowed []string) map[string]any {
 	if safeOutputsConfigGenLog.Enabled() {
@@ -830,6 +871,7 @@ var safeOutputFieldMapping = map[string]string{
 	"CreateDiscussions":               "create_discussion",
 	"UpdateDiscussions":               "update_discussion",
 	"CloseDiscussions":                "close_discussion",
+	"AnswerDiscussion":                "answer_discussion",
 	"CloseIssues":                     "close_issue",
 	"ClosePullRequests":               "close_pull_request",
 	"AddComments":                     "add_comment",
@@ -842,9 +884,11 @@ var safeOutputFieldMapping = map[string]string{
 	"AddLabels":                       "add_labels",
 	"RemoveLabels":                    "remove_labels",
 	"AddReviewer":                     "add_reviewer",
+	"RequestReview":                   "request_review",
 	"AssignMilestone":                 "assign_milestone",
 	"AssignToAgent":                   "assign_to_agent",
 	"AssignToUser":                    "assign_to_user",
+	"Assign":                          "assign",
 	"UpdateIssues":                    "update_issue",
 	"UpdatePullRequests":              "update_pull_request",
 	"PushToPullRequestBranch":         "push_to_pull_request_branch",
@@ -929,6 +973,28 @@ func (c *Compiler) formatSafeOutputsRunsOn(safeOutputs *SafeOutputsConfig) strin
 	return "runs-on: " + safeOutputs.RunsOn
 }
 
+// formatAuxiliaryJobRunsOn resolves the runner for generated support jobs (activation,
+// safe outputs, conclusion, etc.) using the following priority:
+// 1. safe-outputs.runs-on (explicit override for support jobs)
+// 2. top-level runs-on when it targets self-hosted runners
+// 3. defaultRunsOn (the job's GitHub-hosted default)
+func (c *Compiler) formatAuxiliaryJobRunsOn(data *WorkflowData, defaultRunsOn string) string {
+	if data.SafeOutputs != nil && data.SafeOutputs.RunsOn != "" {
+		return c.formatSafeOutputsRunsOn(data.SafeOutputs)
+	}
+	return c.formatSelfHostedRunsOn(data, defaultRunsOn)
+}
+
+// formatSelfHostedRunsOn returns the top-level runs-on value when it targets self-hosted
+// runners, so that every generated job lands on the same runner pool. Otherwise it
+// returns defaultRunsOn.
+func (c *Compiler) formatSelfHostedRunsOn(data *WorkflowData, defaultRunsOn string) string {
+	if data.SelfHostedRunner && data.RunsOn != "" {
+		return c.indentYAMLLines(data.RunsOn, "    ")
+	}
+	return "runs-on: " + defaultRunsOn
+}
+
 // formatDetectionRunsOn resolves the runner for the detection job using the following priority:
 // 1. safe-outputs.detection.runs-on (detection-specific override)
 // 2. agentRunsOn (the agent job's runner, passed by the caller)
@@ -1112,6 +1178,9 @@ func generateFilteredToolsJSON(data *WorkflowData, markdownPath string) (string,
 	if data.SafeOutputs.CloseDiscussions != nil {
 		enabledTools["close_discussion"] = true
 	}
+	if data.SafeOutputs.AnswerDiscussion != nil {
+		enabledTools["answer_discussion"] = true
+	}
 	if data.SafeOutputs.CloseIssues != nil {
 		enabledTools["close_issue"] = true
 	}
@@ -1154,6 +1223,9 @@ func generateFilteredToolsJSON(data *WorkflowData, markdownPath string) (string,
 	if data.SafeOutputs.AddReviewer != nil {
 		enabledTools["add_reviewer"] = true
 	}
+	if data.SafeOutputs.RequestReview != nil {
+		enabledTools["request_review"] = true
+	}
 	if data.SafeOutputs.AssignMilestone != nil {
 		enabledTools["assign_milestone"] = true
 	}
@@ -1163,6 +1235,9 @@ func generateFilteredToolsJSON(data *WorkflowData, markdownPath string) (string,
 	if data.SafeOutputs.AssignToUser != nil {
 		enabledTools["assign_to_user"] = true
 	}
+	if data.SafeOutputs.Assign != nil {
+		enabledTools["assign"] = true
+	}
 	if data.SafeOutputs.UnassignFromUser != nil {
 		enabledTools["unassign_from_user"] = true
 	}
@@ -1211,6 +1286,7 @@ func generateFilteredToolsJSON(data *WorkflowData, markdownPath string) (string,
 	// Note: dispatch_workflow tools are generated dynamically below, not from the static tools list
 
 	// Filter tools to only include enabled ones and enhance descriptions
+	baseConfigs := safeOutputBaseConfigs(data.SafeOutputs)
 	var filteredTools []map[string]any
 	for _, tool := range allTools {
 		toolName, ok := tool["name"].(string)
@@ -1231,6 +1307,11 @@ func generateFilteredToolsJSON(data *WorkflowData, markdownPath string) (string,
 			// Add repo parameter to inputSchema if allowed-repos has entries
 			addRepoParameterIfNeeded(enhancedTool, toolName, data.SafeOutputs)
 
+			// Add the typed item fields declared for post-run if: conditions
+			if base := baseConfigs[toolName]; base != nil {
+				addItemFieldsToTool(enhancedTool, base.Fields)
+			}
+
 			filteredTools = append(filteredTools, enhancedTool)
 		}
 	}
@@ -1398,6 +1479,11 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 			hasAllowedRepos = len(config.AllowedRepos) > 0
 			targetRepoSlug = config.TargetRepoSlug
 		}
+	case "answer_discussion":
+		if config := safeOutputs.AnswerDiscussion; config != nil {
+			hasAllowedRepos = len(config.AllowedRepos) > 0
+			targetRepoSlug = config.TargetRepoSlug
+		}
 	case "close_pull_request", "update_pull_request":
 		if config := safeOutputs.ClosePullRequests; config != nil && toolName == "close_pull_request" {
 			hasAllowedRepos = len(config.AllowedRepos) > 0
@@ -1407,7 +1493,7 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 			targetRepoSlug = config.TargetRepoSlug
 		}
 	case "add_labels", "remove_labels", "hide_comment", "link_sub_issue", "mark_pull_request_as_ready_for_review",
-		"add_reviewer", "assign_milestone", "assign_to_agent", "assign_to_user", "unassign_from_user",
+		"add_reviewer", "request_review", "assign_milestone", "assign_to_agent", "assign_to_user", "assign", "unassign_from_user",
 		"set_issue_type":
 		// These use SafeOutputTargetConfig - check the appropriate config
 		switch toolName {
@@ -1441,6 +1527,11 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 				hasAllowedRepos = len(config.AllowedRepos) > 0
 				targetRepoSlug = config.TargetRepoSlug
 			}
+		case "request_review":
+			if config := safeOutputs.RequestReview; config != nil {
+				hasAllowedRepos = len(config.AllowedRepos) > 0
+				targetRepoSlug = config.TargetRepoSlug
+			}
 		case "assign_milestone":
 			if config := safeOutputs.AssignMilestone; config != nil {
 				hasAllowedRepos = len(config.AllowedRepos) > 0
@@ -1456,6 +1547,11 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 				hasAllowedRepos = len(config.AllowedRepos) > 0
 				targetRepoSlug = config.TargetRepoSlug
 			}
+		case "assign":
+			if config := safeOutputs.Assign; config != nil {
+				hasAllowedRepos = len(config.AllowedRepos) > 0
+				targetRepoSlug = config.TargetRepoSlug
+			}
 		case "unassign_from_user":
 			if config := safeOutputs.UnassignFromUser; config != nil {
 				hasAllowedRepos = len(config.AllowedRepos) > 0
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"fmt"
+	"maps"
 	"slices"
 	"strconv"
 	"strings"
@@ -118,7 +119,7 @@ func (c *Compiler) buildSafeOutputJob(data *WorkflowData, config SafeOutputJobCo
 	// Add GitHub App token invalidation step if app is configured
 	if data.SafeOutputs != nil && data.SafeOutputs.GitHubApp != nil {
 		safeOutputsJobsLog.Print("Adding GitHub App token invalidation step")
-		steps = append(steps, c.buildGitHubAppTokenInvalidationStep()...)
+		steps = append(steps, c.buildGitHubAppTokenInvalidationStep("safe-outputs-app-token", "Invalidate GitHub App token")...)
 	}
 
 	// Determine job condition
@@ -139,8 +140,9 @@ func (c *Compiler) buildSafeOutputJob(data *WorkflowData, config SafeOutputJobCo
 	job := &Job{
 		Name:           config.JobName,
 		If:             jobCondition.Render(),
-		RunsOn:         c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		RunsOn:         c.formatAuxiliaryJobRunsOn(data, constants.DefaultActivationJobRunnerImage),
 		Permissions:    config.Permissions.RenderToYAML(),
+		Environment:    safeOutputsEnvironment(data),
 		TimeoutMinutes: 10, // 10-minute timeout as required for all safe output jobs
 		Steps:          steps,
 		Outputs:        config.Outputs,
@@ -222,6 +224,10 @@ func ComputePermissionsForSafeOutputs(safeOutputs *SafeOutputsConfig) *Permissio
 		safeOutputsPermissionsLog.Print("Adding permissions for close-discussion")
 		permissions.Merge(NewPermissionsContentsReadDiscussionsWrite())
 	}
+	if safeOutputs.AnswerDiscussion != nil {
+		safeOutputsPermissionsLog.Print("Adding permissions for answer-discussion")
+		permissions.Merge(NewPermissionsContentsReadDiscussionsWrite())
+	}
 	if safeOutputs.AddLabels != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for add-labels")
 		permissions.Merge(NewPermissionsContentsReadIssuesWritePRWrite())
@@ -315,7 +321,8 @@ func ComputePermissionsForSafeOutputs(safeOutputs *SafeOutputsConfig) *Permissio
 	}
 	if safeOutputs.CreateCodeScanningAlerts != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for create-code-scanning-alert")
-		permissions.Merge(NewPermissionsContentsReadSecurityEventsWrite())
+		// upload-sarif reads the workflow run (actions: read) to attribute the analysis
+		permissions.Merge(NewPermissionsContentsReadSecurityEventsWriteActionsRead())
 	}
 	if safeOutputs.AutofixCodeScanningAlert != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for autofix-code-scanning-alert")
@@ -341,9 +348,21 @@ func ComputePermissionsForSafeOutputs(safeOutputs *SafeOutputsConfig) *Permissio
 		safeOutputsPermissionsLog.Print("Adding permissions for add-reviewer")
 		permissions.Merge(NewPermissionsContentsReadPRWrite())
 	}
+	if safeOutputs.RequestReview != nil {
+		safeOutputsPermissionsLog.Print("Adding permissions for request-review")
+		permissions.Merge(NewPermissionsContentsReadPRWrite())
+	}
+	if safeOutputs.Assign != nil {
+		safeOutputsPermissionsLog.Print("Adding permissions for assign")
+		permissions.Merge(NewPermissionsContentsReadIssuesWritePRWrite())
+	}
 	if safeOutputs.UploadAssets != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for upload-asset")
-		permissions.Merge(NewPermissionsContentsWrite())
+		if safeOutputs.UploadAssets.IsArtifactTarget() {
+			permissions.Merge(NewPermissionsContentsRead())
+		} else {
+			permissions.Merge(NewPermissionsContentsWrite())
+		}
 	}
 
 	// NoOp and MissingTool don't require write permissions beyond what's already included
@@ -384,6 +403,8 @@ func SafeOutputsConfigFromKeys(keys []string) *SafeOutputsConfig {
 			config.UpdateDiscussions = &UpdateDiscussionsConfig{}
 		case "close-discussion":
 			config.CloseDiscussions = &CloseDiscussionsConfig{}
+		case "answer-discussion":
+			config.AnswerDiscussion = &AnswerDiscussionConfig{}
 		case "add-comment":
 			config.AddComments = &AddCommentsConfig{}
 		case "close-issue":
@@ -410,12 +431,16 @@ func SafeOutputsConfigFromKeys(keys []string) *SafeOutputsConfig {
 			config.RemoveLabels = &RemoveLabelsConfig{}
 		case "add-reviewer":
 			config.AddReviewer = &AddReviewerConfig{}
+		case "request-review":
+			config.RequestReview = &RequestReviewConfig{}
 		case "assign-milestone":
 			config.AssignMilestone = &AssignMilestoneConfig{}
 		case "assign-to-agent":
 			config.AssignToAgent = &AssignToAgentConfig{}
 		case "assign-to-user":
 			config.AssignToUser = &AssignToUserConfig{}
+		case "assign":
+			config.Assign = &AssignConfig{}
 		case "unassign-from-user":
 			config.UnassignFromUser = &UnassignFromUserConfig{}
 		case "update-issue":
@@ -824,6 +849,9 @@ func (c *Compiler) buildStandardSafeOutputEnvVars(data *WorkflowData, targetRepo
 		data.SafeOutputs.Staged,
 		targetRepoSlug,
 	)...)
+	if !c.trialMode && !data.SafeOutputs.Staged && data.DryRunInput {
+		customEnvVars = append(customEnvVars, "          GH_AW_SAFE_OUTPUTS_STAGED: "+dryRunStagedExpression+"\n")
+	}
 
 	// Add messages config if present
 	if data.SafeOutputs.Messages != nil {
@@ -905,8 +933,8 @@ func buildEngineMetadataEnvVars(engineConfig *EngineConfig) []string {
 // addCustomSafeOutputEnvVars adds custom environment variables to safe output job steps
 func (c *Compiler) addCustomSafeOutputEnvVars(steps *[]string, data *WorkflowData) {
 	if data.SafeOutputs != nil && len(data.SafeOutputs.Env) > 0 {
-		for key, value := range data.SafeOutputs.Env {
-			*steps = append(*steps, fmt.Sprintf("          %s: %s\n", key, value))
+		for _, key := range slices.Sorted(maps.Keys(data.SafeOutputs.Env)) {
+			*steps = append(*steps, fmt.Sprintf("          %s: %s\n", key, data.SafeOutputs.Env[key]))
 		}
 	}
 }
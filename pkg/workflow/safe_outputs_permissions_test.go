@@ -307,7 +307,7 @@ func TestComputePermissionsForSafeOutputs(t *testing.T) {
 			},
 		},
 		{
-			name: "create-code-scanning-alert requires security-events write",
+			name: "create-code-scanning-alert requires security-events write and actions read",
 			safeOutputs: &SafeOutputsConfig{
 				CreateCodeScanningAlerts: &CreateCodeScanningAlertsConfig{
 					BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("1")},
@@ -316,6 +316,7 @@ func TestComputePermissionsForSafeOutputs(t *testing.T) {
 			expected: map[PermissionScope]PermissionLevel{
 				PermissionContents:       PermissionRead,
 				PermissionSecurityEvents: PermissionWrite,
+				PermissionActions:        PermissionRead,
 			},
 		},
 		{
@@ -238,3 +238,48 @@ This is a test workflow.`
 		t.Errorf("Unlock job does not use expected %q.\nUnlock section:\n%s", expectedRunsOn, unlockSection)
 	}
 }
+
+func TestSelfHostedRunsOnAppliedToAllJobs(t *testing.T) {
+	frontmatter := `---
+on: push
+runs-on: [self-hosted, linux]
+safe-outputs:
+  create-issue:
+    title-prefix: "[ai] "
+---
+
+# Test Workflow
+
+This is a test workflow.`
+
+	tmpDir := testutil.TempDir(t, "workflow-self-hosted-test")
+	testFile := filepath.Join(tmpDir, "test.md")
+	if err := os.WriteFile(testFile, []byte(frontmatter), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Failed to compile workflow: %v", err)
+	}
+
+	yamlContent, err := os.ReadFile(filepath.Join(tmpDir, "test.lock.yml"))
+	if err != nil {
+		t.Fatalf("Failed to read lock file: %v", err)
+	}
+	yamlStr := string(yamlContent)
+
+	if strings.Contains(yamlStr, "runs-on: "+constants.DefaultActivationJobRunnerImage) {
+		t.Errorf("Expected no job to use the default %q runner when targeting self-hosted runners.\nYAML content:\n%s", constants.DefaultActivationJobRunnerImage, yamlStr)
+	}
+	for _, jobName := range []string{"activation:", "agent:", "safe_outputs:"} {
+		jobStart := strings.Index(yamlStr, "\n  "+jobName)
+		if jobStart == -1 {
+			t.Fatalf("Expected job %q in compiled YAML", jobName)
+		}
+		jobSection := yamlStr[jobStart:min(jobStart+500, len(yamlStr))]
+		if !strings.Contains(jobSection, "- self-hosted") {
+			t.Errorf("Job %q does not use the self-hosted runner labels.\nJob section:\n%s", jobName, jobSection)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsSanitizeLog = logger.New("workflow:safe_outputs_sanitize")
+
+// Sanitizer mention policies for safe-outputs.sanitize.mentions
+const (
+	// SanitizeMentionsFilter applies the safe-outputs.mentions rules (default)
+	SanitizeMentionsFilter = "filter"
+	// SanitizeMentionsNeutralize escapes every @mention, ignoring allow lists
+	SanitizeMentionsNeutralize = "neutralize"
+)
+
+// safeHTMLTags are the tags allowed-html may choose from: the default list of the sanitizer
+// (DEFAULT_ALLOWED_HTML_TAGS in sanitize_content_core.cjs, kept in sync by a test). allowed-html
+// can only narrow this list; other tags can execute code, load remote content, capture input,
+// or disguise links, so they are never allowed.
+var safeHTMLTags = []string{"abbr", "b", "blockquote", "br", "code", "del", "details", "em", "h1", "h2", "h3", "h4", "h5", "h6", "hr", "i", "ins", "kbd", "li", "mark", "ol", "p", "pre", "s", "span", "strong", "sub", "summary", "sup", "table", "tbody", "td", "th", "thead", "tr", "ul"}
+
+// defaultSanitizeMaxLength is the built-in length limit of sanitized fields (the applyTruncation
+// default in sanitize_content_core.cjs), which safe-outputs.sanitize.max-length can only lower
+const defaultSanitizeMaxLength = 524288
+
+var htmlTagNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// customSanitizerPath is where the safe-outputs.sanitize.script module is written on the runner
+const customSanitizerPath = "/opt/gh-aw/sanitize/custom_sanitizer.cjs"
+
+// SanitizeConfig holds the safe-outputs.sanitize configuration applied to AI-generated
+// issue, comment, and pull request content
+type SanitizeConfig struct {
+	Mentions       string   `yaml:"mentions,omitempty"`        // "filter" (default) or "neutralize"
+	AllowedHTML    []string `yaml:"allowed-html,omitempty"`    // HTML tags that survive sanitization (replaces the default list; empty converts all tags)
+	MaxLength      int      `yaml:"max-length,omitempty"`      // Maximum length in characters of each sanitized field (0 = default)
+	AllowedDomains []string `yaml:"allowed-domains,omitempty"` // Domains links may point to; all other URLs are redacted
+	Script         string   `yaml:"script,omitempty"`          // Repository-relative path of a CommonJS module run after the built-in rules
+	ScriptContent  string   `yaml:"-"`                         // Content of Script, read at compile time and embedded in the lock file
+}
+
+// parseSanitizeConfig parses the safe-outputs.sanitize block
+func parseSanitizeConfig(value any) *SanitizeConfig {
+	sanitizeMap, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	config := &SanitizeConfig{}
+
+	if mentions, ok := sanitizeMap["mentions"].(string); ok {
+		config.Mentions = mentions
+	}
+	if allowedHTML, exists := sanitizeMap["allowed-html"]; exists {
+		// Keep an explicit empty list distinct from "not set": it converts every tag
+		config.AllowedHTML = []string{}
+		if tags, ok := allowedHTML.([]any); ok {
+			for _, tag := range tags {
+				if tagStr, ok := tag.(string); ok {
+					config.AllowedHTML = append(config.AllowedHTML, strings.ToLower(strings.TrimSpace(tagStr)))
+				}
+			}
+		}
+	}
+	switch v := sanitizeMap["max-length"].(type) {
+	case int:
+		config.MaxLength = v
+	case uint64:
+		config.MaxLength = int(v)
+	case float64:
+		config.MaxLength = int(v)
+	}
+	if domains, ok := sanitizeMap["allowed-domains"].([]any); ok {
+		for _, domain := range domains {
+			if domainStr, ok := domain.(string); ok {
+				config.AllowedDomains = append(config.AllowedDomains, domainStr)
+			}
+		}
+	}
+
+	if script, ok := sanitizeMap["script"].(string); ok {
+		config.Script = strings.TrimSpace(script)
+	}
+
+	safeOutputsSanitizeLog.Printf("Parsed sanitize config: mentions=%q, allowedHTML=%d, maxLength=%d, allowedDomains=%d, script=%q",
+		config.Mentions, len(config.AllowedHTML), config.MaxLength, len(config.AllowedDomains), config.Script)
+	return config
+}
+
+// validateSanitizeConfig validates the safe-outputs.sanitize block
+func validateSanitizeConfig(config *SafeOutputsConfig) error {
+	if config == nil || config.Sanitize == nil {
+		return nil
+	}
+	sanitize := config.Sanitize
+
+	if sanitize.Mentions != "" && sanitize.Mentions != SanitizeMentionsFilter && sanitize.Mentions != SanitizeMentionsNeutralize {
+		return fmt.Errorf("safe-outputs.sanitize.mentions: must be '%s' or '%s', got '%s'", SanitizeMentionsFilter, SanitizeMentionsNeutralize, sanitize.Mentions)
+	}
+	if sanitize.Mentions == SanitizeMentionsNeutralize && config.Mentions != nil && config.Mentions.Enabled != nil && *config.Mentions.Enabled {
+		return errors.New("safe-outputs.sanitize.mentions: 'neutralize' conflicts with 'safe-outputs.mentions: true'. Remove one of them")
+	}
+
+	for i, tag := range sanitize.AllowedHTML {
+		if !htmlTagNamePattern.MatchString(tag) {
+			return fmt.Errorf("safe-outputs.sanitize.allowed-html[%d]: '%s' is not a valid HTML tag name", i, tag)
+		}
+		if !slices.Contains(safeHTMLTags, tag) {
+			return fmt.Errorf("safe-outputs.sanitize.allowed-html[%d]: '%s' cannot be allowed. allowed-html can only narrow the default tags: %s", i, tag, strings.Join(safeHTMLTags, ", "))
+		}
+	}
+
+	if sanitize.MaxLength < 0 {
+		return fmt.Errorf("safe-outputs.sanitize.max-length: must be a positive number of characters, got %d", sanitize.MaxLength)
+	}
+	if sanitize.MaxLength > defaultSanitizeMaxLength {
+		return fmt.Errorf("safe-outputs.sanitize.max-length: can only lower the default of %d characters, got %d", defaultSanitizeMaxLength, sanitize.MaxLength)
+	}
+
+	if len(sanitize.AllowedDomains) > 0 && len(config.AllowedDomains) > 0 {
+		return errors.New("safe-outputs.sanitize.allowed-domains cannot be combined with safe-outputs.allowed-domains. Move the domains into one of them")
+	}
+	for i, domain := range sanitize.AllowedDomains {
+		if err := validateDomainPattern(domain); err != nil {
+			return fmt.Errorf("safe-outputs.sanitize.allowed-domains[%d]: %w", i, err)
+		}
+	}
+
+	if sanitize.Script != "" {
+		script := filepath.ToSlash(sanitize.Script)
+		if path.IsAbs(script) || path.Clean(script) != script || strings.HasPrefix(script, "../") {
+			return fmt.Errorf("safe-outputs.sanitize.script: '%s' must be a path relative to the repository root, e.g. .github/aw/sanitize.cjs", sanitize.Script)
+		}
+		if ext := path.Ext(script); ext != ".cjs" && ext != ".js" {
+			return fmt.Errorf("safe-outputs.sanitize.script: '%s' must be a CommonJS module (.cjs or .js)", sanitize.Script)
+		}
+	}
+
+	return nil
+}
+
+// loadSanitizeScript reads the safe-outputs.sanitize.script module so it can be embedded in
+// the lock file, and warns that it runs as trusted code. Embedding at compile time keeps the
+// agent from changing the sanitizer through its workspace.
+func (c *Compiler) loadSanitizeScript(data *WorkflowData, markdownPath string) error {
+	if data.SafeOutputs == nil || data.SafeOutputs.Sanitize == nil || data.SafeOutputs.Sanitize.Script == "" {
+		return nil
+	}
+	sanitize := data.SafeOutputs.Sanitize
+
+	scriptPath := filepath.Join(resolveWorkspaceRoot(markdownPath), filepath.FromSlash(sanitize.Script))
+	content, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return fmt.Errorf("safe-outputs.sanitize.script: cannot read '%s': %w", sanitize.Script, err)
+	}
+	if strings.TrimSpace(string(content)) == "" {
+		return fmt.Errorf("safe-outputs.sanitize.script: '%s' is empty", sanitize.Script)
+	}
+	// The script is written by a run step, where GitHub Actions would evaluate expressions
+	if strings.Contains(string(content), "${{") {
+		return fmt.Errorf("safe-outputs.sanitize.script: '%s' must not contain GitHub Actions expressions (${{ ... }})", sanitize.Script)
+	}
+	sanitize.ScriptContent = string(content)
+	safeOutputsSanitizeLog.Printf("Loaded sanitize script %s (%d bytes)", sanitize.Script, len(content))
+
+	c.IncrementWarningCount()
+	fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", fmt.Sprintf(
+		"safe-outputs.sanitize.script runs '%s' as trusted code on all agent output, with access to the safe outputs job's tokens. "+
+			"Review changes to it like workflow changes and recompile after editing it.", sanitize.Script)))
+	return nil
+}
+
+// computeSanitizerAllowedDomains returns the comma-separated link domain allow-list for the
+// sanitizer. Explicit safe-outputs configuration wins; otherwise the domains are computed
+// from the network configuration (same as the firewall).
+func (c *Compiler) computeSanitizerAllowedDomains(data *WorkflowData) string {
+	if data.SafeOutputs != nil {
+		if data.SafeOutputs.Sanitize != nil && len(data.SafeOutputs.Sanitize.AllowedDomains) > 0 {
+			return strings.Join(data.SafeOutputs.Sanitize.AllowedDomains, ",")
+		}
+		if len(data.SafeOutputs.AllowedDomains) > 0 {
+			return strings.Join(data.SafeOutputs.AllowedDomains, ",")
+		}
+	}
+	return c.computeAllowedDomainsForSanitization(data)
+}
+
+// buildSanitizeEnvLines returns the env entries that pass safe-outputs.sanitize options to
+// the sanitizer in sanitize_content_core.cjs. Each line ends with \n.
+func buildSanitizeEnvLines(config *SafeOutputsConfig) []string {
+	if config == nil || config.Sanitize == nil {
+		return nil
+	}
+	var lines []string
+	if config.Sanitize.Mentions == SanitizeMentionsNeutralize {
+		lines = append(lines, "          GH_AW_SANITIZE_MENTIONS: "+SanitizeMentionsNeutralize+"\n")
+	}
+	if config.Sanitize.AllowedHTML != nil {
+		lines = append(lines, fmt.Sprintf("          GH_AW_SANITIZE_ALLOWED_HTML: %q\n", strings.Join(config.Sanitize.AllowedHTML, ",")))
+	}
+	if config.Sanitize.MaxLength > 0 {
+		lines = append(lines, fmt.Sprintf("          GH_AW_SANITIZE_MAX_LENGTH: %q\n", strconv.Itoa(config.Sanitize.MaxLength)))
+	}
+	if config.Sanitize.ScriptContent != "" {
+		lines = append(lines, "          GH_AW_SANITIZE_SCRIPT: "+customSanitizerPath+"\n")
+	}
+	return lines
+}
+
+// buildSanitizeScriptStep returns the step that writes the safe-outputs.sanitize.script module
+// to customSanitizerPath, or nil when no script is configured. Each line ends with \n.
+func buildSanitizeScriptStep(config *SafeOutputsConfig) []string {
+	if config == nil || config.Sanitize == nil || config.Sanitize.ScriptContent == "" {
+		return nil
+	}
+	script := config.Sanitize.ScriptContent
+	delimiter := GenerateContentHeredocDelimiter("SANITIZE_SCRIPT", script)
+
+	lines := []string{
+		"      - name: Write custom sanitizer\n",
+		"        if: always()\n",
+		"        run: |\n",
+		"          mkdir -p " + path.Dir(customSanitizerPath) + "\n",
+		"          cat > " + customSanitizerPath + " << '" + delimiter + "'\n",
+	}
+	// Write the module verbatim: comments, blank lines, and template literals must survive
+	for line := range strings.SplitSeq(strings.TrimRight(script, "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			lines = append(lines, "\n")
+			continue
+		}
+		lines = append(lines, "          "+line+"\n")
+	}
+	lines = append(lines, "          "+delimiter+"\n")
+	return lines
+}
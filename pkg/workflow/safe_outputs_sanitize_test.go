@@ -0,0 +1,286 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSanitizeConfig(t *testing.T) {
+	config := parseSanitizeConfig(map[string]any{
+		"mentions":        "neutralize",
+		"allowed-html":    []any{"Details", "summary"},
+		"max-length":      uint64(2000),
+		"allowed-domains": []any{"docs.example.com"},
+	})
+	require.NotNil(t, config, "config should be parsed")
+	assert.Equal(t, &SanitizeConfig{
+		Mentions:       SanitizeMentionsNeutralize,
+		AllowedHTML:    []string{"details", "summary"},
+		MaxLength:      2000,
+		AllowedDomains: []string{"docs.example.com"},
+	}, config, "all fields should be parsed")
+
+	empty := parseSanitizeConfig(map[string]any{"allowed-html": []any{}})
+	require.NotNil(t, empty, "config should be parsed")
+	assert.NotNil(t, empty.AllowedHTML, "explicit empty allowed-html should be kept")
+	assert.Empty(t, empty.AllowedHTML, "explicit empty allowed-html should have no tags")
+
+	assert.Nil(t, parseSanitizeConfig("neutralize"), "non-object values should be ignored")
+}
+
+func TestValidateSanitizeConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         *SafeOutputsConfig
+		errorSubstring string
+	}{
+		{
+			name:   "no sanitize block",
+			config: &SafeOutputsConfig{},
+		},
+		{
+			name: "valid config",
+			config: &SafeOutputsConfig{Sanitize: &SanitizeConfig{
+				Mentions:       SanitizeMentionsFilter,
+				AllowedHTML:    []string{"details", "h2"},
+				MaxLength:      100,
+				AllowedDomains: []string{"*.example.com"},
+			}},
+		},
+		{
+			name:           "unknown mention policy",
+			config:         &SafeOutputsConfig{Sanitize: &SanitizeConfig{Mentions: "allow"}},
+			errorSubstring: "must be 'filter' or 'neutralize'",
+		},
+		{
+			name: "neutralize conflicts with mentions: true",
+			config: &SafeOutputsConfig{
+				Mentions: &MentionsConfig{Enabled: boolPtr(true)},
+				Sanitize: &SanitizeConfig{Mentions: SanitizeMentionsNeutralize},
+			},
+			errorSubstring: "conflicts with 'safe-outputs.mentions: true'",
+		},
+		{
+			name:           "unsafe html tag",
+			config:         &SafeOutputsConfig{Sanitize: &SanitizeConfig{AllowedHTML: []string{"details", "script"}}},
+			errorSubstring: "allowed-html[1]: 'script' cannot be allowed",
+		},
+		{
+			name:           "link html tag",
+			config:         &SafeOutputsConfig{Sanitize: &SanitizeConfig{AllowedHTML: []string{"a"}}},
+			errorSubstring: "allowed-html[0]: 'a' cannot be allowed",
+		},
+		{
+			name:           "remote media html tag",
+			config:         &SafeOutputsConfig{Sanitize: &SanitizeConfig{AllowedHTML: []string{"details", "img"}}},
+			errorSubstring: "allowed-html[1]: 'img' cannot be allowed",
+		},
+		{
+			name:           "max-length above the default",
+			config:         &SafeOutputsConfig{Sanitize: &SanitizeConfig{MaxLength: defaultSanitizeMaxLength + 1}},
+			errorSubstring: "can only lower the default of 524288 characters",
+		},
+		{
+			name:           "invalid html tag name",
+			config:         &SafeOutputsConfig{Sanitize: &SanitizeConfig{AllowedHTML: []string{"data-x"}}},
+			errorSubstring: "is not a valid HTML tag name",
+		},
+		{
+			name: "domains in both places",
+			config: &SafeOutputsConfig{
+				AllowedDomains: []string{"example.com"},
+				Sanitize:       &SanitizeConfig{AllowedDomains: []string{"docs.example.com"}},
+			},
+			errorSubstring: "cannot be combined with safe-outputs.allowed-domains",
+		},
+		{
+			name:           "invalid domain",
+			config:         &SafeOutputsConfig{Sanitize: &SanitizeConfig{AllowedDomains: []string{""}}},
+			errorSubstring: "sanitize.allowed-domains[0]",
+		},
+		{
+			name:   "valid script",
+			config: &SafeOutputsConfig{Sanitize: &SanitizeConfig{Script: ".github/aw/sanitize.cjs"}},
+		},
+		{
+			name:           "absolute script path",
+			config:         &SafeOutputsConfig{Sanitize: &SanitizeConfig{Script: "/etc/sanitize.cjs"}},
+			errorSubstring: "must be a path relative to the repository root",
+		},
+		{
+			name:           "script outside the repository",
+			config:         &SafeOutputsConfig{Sanitize: &SanitizeConfig{Script: "../sanitize.cjs"}},
+			errorSubstring: "must be a path relative to the repository root",
+		},
+		{
+			name:           "script is not a JavaScript module",
+			config:         &SafeOutputsConfig{Sanitize: &SanitizeConfig{Script: ".github/aw/sanitize.sh"}},
+			errorSubstring: "must be a CommonJS module",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSanitizeConfig(tt.config)
+			if tt.errorSubstring != "" {
+				require.Error(t, err, "config should be rejected")
+				assert.Contains(t, err.Error(), tt.errorSubstring, "error should explain the problem")
+				return
+			}
+			assert.NoError(t, err, "config should be valid")
+		})
+	}
+}
+
+func TestSanitizeConfigCompilesToEnv(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "sanitize-config-test")
+	workflowPath := filepath.Join(tmpDir, "test.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+safe-outputs:
+  sanitize:
+    mentions: neutralize
+    allowed-html: [details, summary]
+    max-length: 20000
+    allowed-domains: [docs.example.com]
+  add-comment:
+---
+
+Comment on the issue.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	for _, expected := range []string{
+		`GH_AW_ALLOWED_DOMAINS: "docs.example.com"`,
+		"GH_AW_SANITIZE_MENTIONS: neutralize",
+		`GH_AW_SANITIZE_ALLOWED_HTML: "details,summary"`,
+		`GH_AW_SANITIZE_MAX_LENGTH: "20000"`,
+	} {
+		assert.Equal(t, 2, strings.Count(lock, expected), "%q should be set for ingestion and safe output handlers", expected)
+	}
+}
+
+func TestSanitizeScriptCompilesToStep(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "sanitize-script-test")
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, ".github", "aw"), 0755))
+
+	script := "// Redact internal hostnames\nmodule.exports = content =>\n  content.replace(/\\b[a-z0-9-]+\\.corp\\.example\\b/g, \"[internal host]\");\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".github", "aw", "sanitize.cjs"), []byte(script), 0644))
+
+	workflowPath := filepath.Join(workflowsDir, "test.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+safe-outputs:
+  sanitize:
+    script: .github/aw/sanitize.cjs
+  add-comment:
+---
+
+Comment on the issue.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "workflow should compile")
+	assert.Positive(t, compiler.GetWarningCount(), "custom sanitizer should be reported as trusted code")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Equal(t, 2, strings.Count(lock, "GH_AW_SANITIZE_SCRIPT: /opt/gh-aw/sanitize/custom_sanitizer.cjs"), "script should be used for ingestion and safe output handlers")
+	assert.Equal(t, 2, strings.Count(lock, "- name: Write custom sanitizer"), "script should be written in the agent and safe outputs jobs")
+	assert.Contains(t, lock, "          // Redact internal hostnames\n", "script should be embedded verbatim")
+	assert.Contains(t, lock, `content.replace(/\b[a-z0-9-]+\.corp\.example\b/g, "[internal host]");`, "script should be embedded verbatim")
+	assert.Less(t, strings.Index(lock, "- name: Write custom sanitizer"), strings.Index(lock, "- name: Ingest agent output"), "script should be written before ingestion")
+}
+
+func TestSanitizeScriptErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		script         *string
+		errorSubstring string
+	}{
+		{name: "missing script", errorSubstring: "cannot read '.github/aw/sanitize.cjs'"},
+		{name: "empty script", script: strPtr("  \n"), errorSubstring: "is empty"},
+		{name: "expression in script", script: strPtr("module.exports = c => c.replace('${{ secrets.X }}', '');\n"), errorSubstring: "must not contain GitHub Actions expressions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "sanitize-script-error-test")
+			workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+			require.NoError(t, os.MkdirAll(workflowsDir, 0755))
+			if tt.script != nil {
+				require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, ".github", "aw"), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".github", "aw", "sanitize.cjs"), []byte(*tt.script), 0644))
+			}
+
+			workflowPath := filepath.Join(workflowsDir, "test.md")
+			content := "---\non: workflow_dispatch\nsafe-outputs:\n  sanitize:\n    script: .github/aw/sanitize.cjs\n  add-comment:\n---\n\nComment.\n"
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+			err := NewCompiler().CompileWorkflow(workflowPath)
+			require.Error(t, err, "workflow should not compile")
+			assert.Contains(t, err.Error(), tt.errorSubstring, "error should explain the problem")
+		})
+	}
+}
+
+func TestSanitizeDefaultsMatchJavaScript(t *testing.T) {
+	repoRoot, err := findRepoRoot()
+	require.NoError(t, err, "should find repository root")
+	content, err := os.ReadFile(filepath.Join(repoRoot, "actions", "setup", "js", "sanitize_content_core.cjs"))
+	require.NoError(t, err, "should read sanitize_content_core.cjs")
+	source := string(content)
+
+	tagsMatch := regexp.MustCompile(`(?s)const DEFAULT_ALLOWED_HTML_TAGS = \[(.*?)\];`).FindStringSubmatch(source)
+	require.Len(t, tagsMatch, 2, "sanitize_content_core.cjs should define DEFAULT_ALLOWED_HTML_TAGS")
+	var jsTags []string
+	for _, tag := range regexp.MustCompile(`"([^"]+)"`).FindAllStringSubmatch(tagsMatch[1], -1) {
+		jsTags = append(jsTags, tag[1])
+	}
+	assert.Equal(t, jsTags, safeHTMLTags, "safeHTMLTags should match DEFAULT_ALLOWED_HTML_TAGS")
+
+	maxLengthMatch := regexp.MustCompile(`maxLength = maxLength \|\| (\d+);`).FindStringSubmatch(source)
+	require.Len(t, maxLengthMatch, 2, "sanitize_content_core.cjs should define the default max length")
+	assert.Equal(t, strconv.Itoa(defaultSanitizeMaxLength), maxLengthMatch[1], "defaultSanitizeMaxLength should match the sanitizer default")
+}
+
+func TestSanitizeAllowedHTMLOnlyNarrowsDefaults(t *testing.T) {
+	allDefaults := &SafeOutputsConfig{Sanitize: &SanitizeConfig{AllowedHTML: slices.Clone(safeHTMLTags)}}
+	require.NoError(t, validateSanitizeConfig(allDefaults), "the full default list should be accepted")
+
+	for _, tag := range []string{"a", "img", "u", "font", "input", "iframe"} {
+		config := &SafeOutputsConfig{Sanitize: &SanitizeConfig{AllowedHTML: append(slices.Clone(safeHTMLTags), tag)}}
+		err := validateSanitizeConfig(config)
+		require.Error(t, err, "adding '%s' to the default list should be rejected", tag)
+		assert.Contains(t, err.Error(), "can only narrow the default tags", "error should explain that allowed-html narrows the defaults")
+	}
+}
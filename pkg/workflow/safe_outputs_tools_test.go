@@ -310,6 +310,7 @@ func TestGetSafeOutputsToolsJSON(t *testing.T) {
 		"create_discussion",
 		"update_discussion",
 		"close_discussion",
+		"answer_discussion",
 		"close_issue",
 		"close_pull_request",
 		"mark_pull_request_as_ready_for_review",
@@ -323,9 +324,11 @@ func TestGetSafeOutputsToolsJSON(t *testing.T) {
 		"add_labels",
 		"remove_labels",
 		"add_reviewer",
+		"request_review",
 		"assign_milestone",
 		"assign_to_agent",
 		"assign_to_user",
+		"assign",
 		"unassign_from_user",
 		"update_issue",
 		"update_pull_request",
@@ -277,6 +277,9 @@ func validateSafeOutputsTarget(config *SafeOutputsConfig) error {
 	if config.CloseDiscussions != nil {
 		configs = append(configs, targetConfig{"close-discussion", config.CloseDiscussions.Target})
 	}
+	if config.AnswerDiscussion != nil {
+		configs = append(configs, targetConfig{"answer-discussion", config.AnswerDiscussion.Target})
+	}
 	if config.ClosePullRequests != nil {
 		configs = append(configs, targetConfig{"close-pull-request", config.ClosePullRequests.Target})
 	}
@@ -289,6 +292,9 @@ func validateSafeOutputsTarget(config *SafeOutputsConfig) error {
 	if config.AddReviewer != nil {
 		configs = append(configs, targetConfig{"add-reviewer", config.AddReviewer.Target})
 	}
+	if config.RequestReview != nil {
+		configs = append(configs, targetConfig{"request-review", config.RequestReview.Target})
+	}
 	if config.AssignMilestone != nil {
 		configs = append(configs, targetConfig{"assign-milestone", config.AssignMilestone.Target})
 	}
@@ -298,6 +304,9 @@ func validateSafeOutputsTarget(config *SafeOutputsConfig) error {
 	if config.AssignToUser != nil {
 		configs = append(configs, targetConfig{"assign-to-user", config.AssignToUser.Target})
 	}
+	if config.Assign != nil {
+		configs = append(configs, targetConfig{"assign", config.Assign.Target})
+	}
 	if config.LinkSubIssue != nil {
 		configs = append(configs, targetConfig{"link-sub-issue", config.LinkSubIssue.Target})
 	}
@@ -0,0 +1,147 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata" // Embed the timezone database so schedule timezones resolve on any host
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var scheduleChecksLog = logger.New("workflow:schedule_checks")
+
+// maxScheduleJitter bounds on.schedule jitter so scheduled runs stay close to their slot
+const maxScheduleJitter = time.Hour
+
+// scheduleOffsetsYear is the year whose UTC offsets a schedule timezone compiles to. It lies
+// beyond the explicit transitions of the timezone database, so the offsets follow each zone's
+// standing daylight saving rule instead of the year the workflow happens to be compiled in.
+var scheduleOffsetsYear = 2100
+
+// ScheduleCheck describes how the pre-activation job handles a run started by one compiled cron entry.
+// GitHub Actions only supports UTC schedules, so a timezone that observes daylight saving time
+// compiles to one cron entry per UTC offset; the check skips the entry whose offset is not
+// currently in effect.
+type ScheduleCheck struct {
+	Timezone  string `json:"timezone,omitempty"`   // IANA timezone the schedule was written in
+	UTCOffset *int   `json:"utc_offset,omitempty"` // Offset in minutes east of UTC this entry was compiled for (only set when the timezone has several offsets)
+	Jitter    int    `json:"jitter,omitempty"`     // Maximum random delay in seconds before the run proceeds
+}
+
+// ScheduleChecks maps a compiled UTC cron expression to the checks for the schedule items it came from
+type ScheduleChecks map[string][]ScheduleCheck
+
+// expandScheduleItem converts a schedule item's cron expression from its timezone to UTC and
+// records the runtime checks for the resulting cron entries. It returns the UTC cron entries and,
+// in parallel, a friendly description of each conversion (nil when no timezone is set).
+func (c *Compiler) expandScheduleItem(cron string, itemMap map[string]any, itemIndex int) ([]string, []string, error) {
+	timezone, jitter, err := parseScheduleItemOptions(itemMap, itemIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	jitterSeconds := int(jitter / time.Second)
+
+	if timezone == "" {
+		if jitterSeconds > 0 {
+			c.addScheduleCheck(cron, ScheduleCheck{Jitter: jitterSeconds})
+		}
+		return []string{cron}, nil, nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "Local" {
+		return nil, nil, fmt.Errorf("schedule item %d has unknown timezone '%s': use an IANA timezone name such as 'Europe/Paris' or 'America/New_York'", itemIndex, timezone)
+	}
+
+	offsets := parser.TimezoneUTCOffsets(loc, scheduleOffsetsYear)
+	var crons []string
+	offsetLabels := make(map[string][]string)
+	for _, offset := range offsets {
+		converted, err := parser.ConvertCronToUTC(cron, offset)
+		if err != nil {
+			return nil, nil, fmt.Errorf("schedule item %d: %w", itemIndex, err)
+		}
+		for _, utcCron := range converted {
+			if len(offsets) > 1 {
+				// Each entry only matches local time while its offset is in effect
+				c.addScheduleCheck(utcCron, ScheduleCheck{Timezone: timezone, UTCOffset: &offset, Jitter: jitterSeconds})
+			} else if jitterSeconds > 0 {
+				c.addScheduleCheck(utcCron, ScheduleCheck{Jitter: jitterSeconds})
+			}
+			if !slices.Contains(crons, utcCron) {
+				crons = append(crons, utcCron)
+			}
+			offsetLabels[utcCron] = append(offsetLabels[utcCron], parser.FormatUTCOffset(offset))
+		}
+	}
+
+	scheduleChecksLog.Printf("Converted schedule item %d from %s: %s -> %v", itemIndex, timezone, cron, crons)
+	friendly := make([]string, len(crons))
+	for i, utcCron := range crons {
+		friendly[i] = fmt.Sprintf("%s in %s (%s)", cron, timezone, strings.Join(offsetLabels[utcCron], ", "))
+	}
+	return crons, friendly, nil
+}
+
+// parseScheduleItemOptions extracts and removes the timezone and jitter options from a schedule item.
+// GitHub Actions rejects unknown schedule keys, so only cron remains after this call.
+func parseScheduleItemOptions(itemMap map[string]any, itemIndex int) (string, time.Duration, error) {
+	var timezone string
+	if value, exists := itemMap["timezone"]; exists {
+		str, ok := value.(string)
+		if !ok || str == "" {
+			return "", 0, fmt.Errorf("schedule item %d 'timezone' field must be a non-empty string", itemIndex)
+		}
+		timezone = str
+		delete(itemMap, "timezone")
+	}
+
+	var jitter time.Duration
+	if value, exists := itemMap["jitter"]; exists {
+		str, ok := value.(string)
+		if !ok {
+			return "", 0, fmt.Errorf("schedule item %d 'jitter' field must be a duration string such as '15m'", itemIndex)
+		}
+		parsed, err := time.ParseDuration(str)
+		if err != nil || parsed < time.Second || parsed > maxScheduleJitter {
+			return "", 0, fmt.Errorf("schedule item %d has invalid jitter '%s': must be a duration between 1s and %s (e.g. '90s', '15m')", itemIndex, str, maxScheduleJitter)
+		}
+		jitter = parsed
+		delete(itemMap, "jitter")
+	}
+
+	return timezone, jitter, nil
+}
+
+// addScheduleCheck records a runtime check for a compiled cron entry
+func (c *Compiler) addScheduleCheck(cron string, check ScheduleCheck) {
+	if c.scheduleChecks == nil {
+		c.scheduleChecks = make(ScheduleChecks)
+	}
+	c.scheduleChecks[cron] = append(c.scheduleChecks[cron], check)
+}
+
+// generateScheduleCheckStep generates the pre-activation step that applies schedule jitter and
+// skips runs from cron entries compiled for a UTC offset that is not currently in effect
+func (c *Compiler) generateScheduleCheckStep(data *WorkflowData, steps []string) []string {
+	checksJSON, err := json.Marshal(data.ScheduleChecks)
+	if err != nil {
+		scheduleChecksLog.Printf("Failed to marshal schedule checks: %v", err)
+		return steps
+	}
+
+	steps = append(steps, "      - name: Check schedule\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckScheduleStepID))
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/github-script")))
+	steps = append(steps, "        env:\n")
+	steps = append(steps, fmt.Sprintf("          GH_AW_SCHEDULE_CHECKS: %q\n", string(checksJSON)))
+	steps = append(steps, "        with:\n")
+	steps = append(steps, "          script: |\n")
+	steps = append(steps, generateGitHubScriptWithRequire("check_schedule.cjs"))
+	return steps
+}
@@ -0,0 +1,154 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulePreprocessingTimezoneAndJitter(t *testing.T) {
+	tests := []struct {
+		name           string
+		schedule       any
+		expectedCrons  []string
+		expectedChecks ScheduleChecks
+		errorSubstring string
+	}{
+		{
+			name:          "single object with daylight saving timezone and jitter",
+			schedule:      map[string]any{"cron": "0 9 * * 1-5", "timezone": "Europe/Paris", "jitter": "15m"},
+			expectedCrons: []string{"0 8 * * 1-5", "0 7 * * 1-5"},
+			expectedChecks: ScheduleChecks{
+				"0 8 * * 1-5": {{Timezone: "Europe/Paris", UTCOffset: intPtr(60), Jitter: 900}},
+				"0 7 * * 1-5": {{Timezone: "Europe/Paris", UTCOffset: intPtr(120), Jitter: 900}},
+			},
+		},
+		{
+			name:           "timezone without daylight saving needs no checks",
+			schedule:       []any{map[string]any{"cron": "30 9 * * *", "timezone": "Asia/Kolkata"}},
+			expectedCrons:  []string{"0 4 * * *"},
+			expectedChecks: nil,
+		},
+		{
+			name:          "jitter without timezone",
+			schedule:      []any{map[string]any{"cron": "0 2 * * *", "jitter": "90s"}},
+			expectedCrons: []string{"0 2 * * *"},
+			expectedChecks: ScheduleChecks{
+				"0 2 * * *": {{Jitter: 90}},
+			},
+		},
+		{
+			name:           "unknown timezone",
+			schedule:       []any{map[string]any{"cron": "0 9 * * *", "timezone": "Mars/Olympus"}},
+			errorSubstring: "unknown timezone 'Mars/Olympus'",
+		},
+		{
+			name:           "jitter above maximum",
+			schedule:       []any{map[string]any{"cron": "0 9 * * *", "jitter": "2h"}},
+			errorSubstring: "invalid jitter '2h'",
+		},
+		{
+			name:           "jitter that is not a duration",
+			schedule:       []any{map[string]any{"cron": "0 9 * * *", "jitter": "soon"}},
+			errorSubstring: "invalid jitter 'soon'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"on": map[string]any{"schedule": tt.schedule},
+			}
+
+			compiler := NewCompiler()
+			err := compiler.preprocessScheduleFields(frontmatter, "", "")
+			if tt.errorSubstring != "" {
+				require.Error(t, err, "preprocessing should fail")
+				assert.Contains(t, err.Error(), tt.errorSubstring, "error should describe the invalid option")
+				return
+			}
+			require.NoError(t, err, "preprocessing should succeed")
+
+			onMap := frontmatter["on"].(map[string]any)
+			scheduleArray, ok := onMap["schedule"].([]any)
+			require.True(t, ok, "schedule should be normalized to an array")
+
+			var crons []string
+			for _, item := range scheduleArray {
+				itemMap := item.(map[string]any)
+				assert.NotContains(t, itemMap, "timezone", "timezone should be removed from the compiled item")
+				assert.NotContains(t, itemMap, "jitter", "jitter should be removed from the compiled item")
+				crons = append(crons, itemMap["cron"].(string))
+			}
+			assert.Equal(t, tt.expectedCrons, crons, "compiled UTC cron entries")
+			assert.Equal(t, tt.expectedChecks, compiler.scheduleChecks, "recorded schedule checks")
+		})
+	}
+}
+
+func TestScheduleCheckStepInPreActivation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "schedule-checks-test")
+
+	workflowContent := `---
+on:
+  schedule:
+    cron: "0 9 * * 1-5"
+    timezone: Europe/Paris
+    jitter: 15m
+permissions:
+  contents: read
+engine: claude
+---
+
+Scheduled workflow in a local timezone
+`
+
+	workflowFile := filepath.Join(tmpDir, "tz-schedule.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "should write workflow file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, `cron: "0 8 * * 1-5"`, "winter UTC entry should be compiled")
+	assert.Contains(t, lock, `cron: "0 7 * * 1-5"`, "summer UTC entry should be compiled")
+	assert.Contains(t, lock, "# Friendly format: 0 9 * * 1-5 in Europe/Paris (UTC+01:00)", "friendly comment should name the timezone")
+	assert.NotContains(t, lock, "timezone: Europe/Paris", "timezone must not reach the Actions schedule")
+	assert.Contains(t, lock, "id: check_schedule", "pre-activation should include the schedule check step")
+	assert.Contains(t, lock, "GH_AW_SCHEDULE_CHECKS:", "schedule check step should receive its configuration")
+	assert.Contains(t, lock, "steps.check_schedule.outputs.schedule_ok == 'true'", "activation should depend on the schedule check")
+	assert.Contains(t, lock, "pre_activation:", "pre_activation job should be created")
+}
+
+func TestScheduleOffsetsIndependentOfYear(t *testing.T) {
+	expand := func(year int) ([]string, ScheduleChecks) {
+		original := scheduleOffsetsYear
+		scheduleOffsetsYear = year
+		defer func() { scheduleOffsetsYear = original }()
+
+		compiler := NewCompiler()
+		var crons []string
+		for i, timezone := range []string{"Europe/Paris", "America/New_York", "Australia/Sydney", "America/Sao_Paulo", "Asia/Kolkata"} {
+			converted, _, err := compiler.expandScheduleItem("0 9 * * 1-5", map[string]any{"timezone": timezone}, i)
+			require.NoError(t, err, "schedule in %s should expand", timezone)
+			crons = append(crons, converted...)
+		}
+		return crons, compiler.scheduleChecks
+	}
+
+	crons2100, checks2100 := expand(2100)
+	crons2150, checks2150 := expand(2150)
+	assert.Equal(t, crons2100, crons2150, "compiled cron entries should not depend on the reference year")
+	assert.Equal(t, checks2100, checks2150, "schedule checks should not depend on the reference year")
+	assert.Len(t, crons2100, 8, "Paris, New York and Sydney observe two offsets, Sao Paulo and Kolkata one")
+}
@@ -3,6 +3,8 @@ package workflow
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"regexp"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
@@ -12,6 +14,9 @@ import (
 
 var schedulePreprocessingLog = logger.New("workflow:schedule_preprocessing")
 
+// tomlOnKeyPattern matches the on key of TOML frontmatter: on = "...", [on] or [on.schedule]
+var tomlOnKeyPattern = regexp.MustCompile(`^(on\s*=|\[on[\].])`)
+
 // normalizeScheduleString handles the common schedule string parsing, warning emission,
 // fuzzy scattering, and validation logic. It returns the normalized cron expression
 // and the original friendly format, or an error if validation fails.
@@ -103,6 +108,9 @@ func (c *Compiler) normalizeScheduleString(scheduleStr string, itemIndex int) (p
 func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdownPath string, content string) error {
 	schedulePreprocessingLog.Print("Preprocessing schedule fields in frontmatter")
 
+	// Runtime schedule checks are rebuilt for each workflow
+	c.scheduleChecks = nil
+
 	// Check if "on" field exists
 	onValue, exists := frontmatter["on"]
 	if !exists {
@@ -208,6 +216,16 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 		return nil
 	}
 
+	// Expand labeled:/unlabeled: routing sugar into types + names
+	if err := expandLabelRoutingSugar(onMap); err != nil {
+		return err
+	}
+
+	// Expand on.replay: true into the workflow_dispatch inputs used by gh aw replay
+	if err := expandReplayTrigger(onMap); err != nil {
+		return err
+	}
+
 	// Check if schedule field exists in the "on" map
 	scheduleValue, hasSchedule := onMap["schedule"]
 	if !hasSchedule {
@@ -248,10 +266,15 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 		return nil
 	}
 
+	// Handle single object format: schedule: {cron: "0 9 * * 1-5", timezone: Europe/Paris}
+	if scheduleItem, ok := scheduleValue.(map[string]any); ok {
+		scheduleValue = []any{scheduleItem}
+	}
+
 	// Schedule should be an array of schedule items
 	scheduleArray, ok := scheduleValue.([]any)
 	if !ok {
-		return errors.New("schedule field must be a string or an array")
+		return errors.New("schedule field must be a string, an object, or an array")
 	}
 
 	// Initialize friendly formats map for this compilation
@@ -259,8 +282,10 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 		c.scheduleFriendlyFormats = make(map[int]string)
 	}
 
-	// Process each schedule item
+	// Process each schedule item. Items with a timezone can expand into several UTC entries,
+	// so friendly formats are keyed by the index of the compiled entry.
 	schedulePreprocessingLog.Printf("Processing %d schedule items", len(scheduleArray))
+	var compiledSchedule []any
 	for i, item := range scheduleArray {
 		itemMap, ok := item.(map[string]any)
 		if !ok {
@@ -284,14 +309,31 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 			return err
 		}
 
-		// Update the cron field with the parsed cron expression
-		itemMap["cron"] = parsedCron
+		// Convert from the item's timezone to UTC and record jitter
+		utcCrons, timezoneFormats, err := c.expandScheduleItem(parsedCron, itemMap, i)
+		if err != nil {
+			return err
+		}
 
-		// If there was an original friendly format, store it for later use
-		if original != "" {
-			c.scheduleFriendlyFormats[i] = original
+		for j, utcCron := range utcCrons {
+			friendly := original
+			if timezoneFormats != nil {
+				if friendly != "" {
+					friendly += " -> " + timezoneFormats[j]
+				} else {
+					friendly = timezoneFormats[j]
+				}
+			}
+			// If there was an original friendly format, store it for later use
+			if friendly != "" {
+				c.scheduleFriendlyFormats[len(compiledSchedule)] = friendly
+			}
+			compiledItem := maps.Clone(itemMap)
+			compiledItem["cron"] = utcCron
+			compiledSchedule = append(compiledSchedule, compiledItem)
 		}
 	}
+	onMap["schedule"] = compiledSchedule
 
 	// Add workflow_dispatch if not already present
 	if _, hasWorkflowDispatch := onMap["workflow_dispatch"]; !hasWorkflowDispatch {
@@ -308,34 +350,31 @@ func (c *Compiler) createTriggerParseError(filePath, content, triggerStr string,
 
 	lines := strings.Split(content, "\n")
 
-	// Find the line where "on:" appears in the frontmatter
+	// Find the line where "on:" (or "on =" and "[on" in TOML frontmatter) appears in the frontmatter
 	var onLine int
 	var onColumn int
-	inFrontmatter := false
+	delimiter := ""
 
 	for i, line := range lines {
 		lineNum := i + 1
 
 		// Check for frontmatter delimiter
-		if strings.TrimSpace(line) == "---" {
-			if !inFrontmatter {
-				inFrontmatter = true
-			} else {
-				// End of frontmatter
-				break
-			}
+		if delimiter == "" {
+			delimiter = parser.FrontmatterDelimiter(line)
 			continue
 		}
+		if strings.TrimSpace(line) == delimiter {
+			// End of frontmatter
+			break
+		}
 
-		if inFrontmatter {
-			// Look for "on:" field
-			trimmed := strings.TrimSpace(line)
-			if strings.HasPrefix(trimmed, "on:") {
-				onLine = lineNum
-				// Find the column where "on:" starts
-				onColumn = strings.Index(line, "on:") + 1
-				break
-			}
+		// Look for "on:" field
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "on:") || tomlOnKeyPattern.MatchString(trimmed) {
+			onLine = lineNum
+			// Find the column where the on key starts
+			onColumn = strings.Index(line, "on") + 1
+			break
 		}
 	}
 
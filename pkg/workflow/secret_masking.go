@@ -2,6 +2,9 @@ package workflow
 
 import (
 	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -27,9 +30,21 @@ func (c *Compiler) extractSecretMaskingConfig(frontmatter map[string]any) *Secre
 				}
 			}
 
-			// Return nil if no steps were found
-			if len(config.Steps) == 0 {
-				secretMaskingLog.Print("No secret-masking steps found in frontmatter")
+			// Extract patterns array
+			if patterns, exists := secretMaskingMap["patterns"]; exists {
+				if patternsArray, ok := patterns.([]any); ok {
+					for _, pattern := range patternsArray {
+						if patternStr, ok := pattern.(string); ok && patternStr != "" {
+							config.Patterns = append(config.Patterns, patternStr)
+						}
+					}
+					secretMaskingLog.Printf("Extracted %d secret-masking patterns from frontmatter", len(config.Patterns))
+				}
+			}
+
+			// Return nil if no steps or patterns were found
+			if len(config.Steps) == 0 && len(config.Patterns) == 0 {
+				secretMaskingLog.Print("No secret-masking steps or patterns found in frontmatter")
 				return nil
 			}
 
@@ -55,7 +70,8 @@ func (c *Compiler) MergeSecretMasking(topConfig *SecretMaskingConfig, importedSe
 	if topConfig != nil {
 		result.Steps = make([]map[string]any, len(topConfig.Steps))
 		copy(result.Steps, topConfig.Steps)
-		secretMaskingLog.Printf("Starting with %d top-level steps", len(topConfig.Steps))
+		result.Patterns = slices.Clone(topConfig.Patterns)
+		secretMaskingLog.Printf("Starting with %d top-level steps and %d patterns", len(topConfig.Steps), len(topConfig.Patterns))
 	}
 
 	// Split by newlines to handle multiple JSON objects from different imports
@@ -80,13 +96,42 @@ func (c *Compiler) MergeSecretMasking(topConfig *SecretMaskingConfig, importedSe
 			result.Steps = append(result.Steps, importedConfig.Steps...)
 			secretMaskingLog.Printf("Merged %d steps from import", len(importedConfig.Steps))
 		}
+
+		// Append patterns from imported config, skipping duplicates
+		for _, pattern := range importedConfig.Patterns {
+			if pattern != "" && !slices.Contains(result.Patterns, pattern) {
+				result.Patterns = append(result.Patterns, pattern)
+			}
+		}
 	}
 
-	if len(result.Steps) == 0 {
-		secretMaskingLog.Print("No secret-masking steps after merging")
+	if len(result.Steps) == 0 && len(result.Patterns) == 0 {
+		secretMaskingLog.Print("No secret-masking steps or patterns after merging")
 		return nil, nil
 	}
 
-	secretMaskingLog.Printf("Successfully merged secret-masking with %d total steps", len(result.Steps))
+	secretMaskingLog.Printf("Successfully merged secret-masking with %d total steps and %d patterns", len(result.Steps), len(result.Patterns))
 	return result, nil
 }
+
+// validateSecretMaskingPatterns checks that each secret-masking pattern is a valid regular expression.
+// Patterns are compiled with Go's RE2 engine, so lookaround and backreferences are rejected;
+// the same syntax is evaluated by JavaScript at runtime.
+func validateSecretMaskingPatterns(config *SecretMaskingConfig) error {
+	if config == nil {
+		return nil
+	}
+	for _, pattern := range config.Patterns {
+		if strings.Contains(pattern, "${{") {
+			return fmt.Errorf("secret-masking.patterns entry %q must not contain GitHub Actions expressions", pattern)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("secret-masking.patterns entry %q is not a valid regular expression: %w\n\nExample:\nsecret-masking:\n  patterns:\n    - \"acme_[A-Za-z0-9]{32}\"", pattern, err)
+		}
+		if re.MatchString("") {
+			return fmt.Errorf("secret-masking.patterns entry %q matches the empty string; use a pattern that requires at least one character", pattern)
+		}
+	}
+	return nil
+}
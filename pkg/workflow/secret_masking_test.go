@@ -3,8 +3,15 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExtractSecretMaskingConfig(t *testing.T) {
@@ -279,3 +286,81 @@ func TestSecretMaskingIntegration(t *testing.T) {
 		t.Error("Expected step name but got nil")
 	}
 }
+
+func TestExtractSecretMaskingPatterns(t *testing.T) {
+	c := NewCompiler()
+
+	config := c.extractSecretMaskingConfig(map[string]any{
+		"secret-masking": map[string]any{
+			"patterns": []any{"acme_[A-Za-z0-9]{32}", "", 42},
+		},
+	})
+	require.NotNil(t, config, "Patterns alone should produce a config")
+	assert.Equal(t, []string{"acme_[A-Za-z0-9]{32}"}, config.Patterns, "Only non-empty string patterns should be kept")
+	assert.Empty(t, config.Steps, "No steps should be extracted")
+}
+
+func TestMergeSecretMaskingPatterns(t *testing.T) {
+	c := NewCompiler()
+	top := &SecretMaskingConfig{Patterns: []string{"acme_[a-z]{8}"}}
+
+	result, err := c.MergeSecretMasking(top, `{"patterns":["acme_[a-z]{8}","corp-[0-9]{12}"]}`)
+	require.NoError(t, err, "Merge should succeed")
+	require.NotNil(t, result, "Merged config should not be nil")
+	assert.Equal(t, []string{"acme_[a-z]{8}", "corp-[0-9]{12}"}, result.Patterns, "Imported patterns should be appended without duplicates")
+	assert.Equal(t, []string{"acme_[a-z]{8}"}, top.Patterns, "Top-level config should not be modified")
+}
+
+func TestValidateSecretMaskingPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		wantErr  string
+	}{
+		{name: "valid patterns", patterns: []string{"acme_[A-Za-z0-9]{32}", `internal-key-[0-9a-f]{40}`}},
+		{name: "invalid regular expression", patterns: []string{"acme_[A-Z"}, wantErr: "is not a valid regular expression"},
+		{name: "lookahead is not supported", patterns: []string{"key(?=[0-9])"}, wantErr: "is not a valid regular expression"},
+		{name: "empty match", patterns: []string{"[0-9]*"}, wantErr: "matches the empty string"},
+		{name: "expression injection", patterns: []string{"${{ secrets.TOKEN }}"}, wantErr: "must not contain GitHub Actions expressions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecretMaskingPatterns(&SecretMaskingConfig{Patterns: tt.patterns})
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Patterns should be valid")
+				return
+			}
+			require.Error(t, err, "Patterns should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error should explain the invalid pattern")
+		})
+	}
+
+	assert.NoError(t, validateSecretMaskingPatterns(nil), "Missing config should be valid")
+}
+
+func TestSecretMaskingPatternsCompiled(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "secret-masking-patterns-*")
+
+	content := `---
+on: workflow_dispatch
+engine: copilot
+secret-masking:
+  patterns:
+    - "acme_[A-Za-z0-9]{32}"
+    - "it's-[0-9]{6}"
+---
+
+# Secret masking patterns test
+`
+	testFile := filepath.Join(tmpDir, "secret-patterns.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Lock file should be created")
+
+	assert.Contains(t, string(lockContent), `GH_AW_SECRET_PATTERNS: '["acme_[A-Za-z0-9]{32}","it''s-[0-9]{6}"]'`, "Patterns should be passed to the redaction step as a JSON array")
+}
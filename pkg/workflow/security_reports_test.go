@@ -3,7 +3,15 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestCodeScanningAlertsConfig tests the parsing of create-code-scanning-alert configuration
@@ -190,3 +198,42 @@ func TestParseCodeScanningAlertsConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCodeScanningAlertsSarifUpload(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           string
+		expectUpload     bool
+		expectedCategory string
+	}{
+		{name: "default category", config: "  create-code-scanning-alert:\n", expectUpload: true, expectedCategory: "category: gh-aw/security-review"},
+		{name: "custom category", config: "  create-code-scanning-alert:\n    category: agents/security\n", expectUpload: true, expectedCategory: "category: agents/security"},
+		{name: "cross-repository alerts are not uploaded", config: "  create-code-scanning-alert:\n    target-repo: my-org/other\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "code-scanning-sarif-test")
+			workflowPath := filepath.Join(tmpDir, "security-review.md")
+			content := "---\non: push\nengine: copilot\npermissions:\n  contents: read\nsafe-outputs:\n" + tt.config + "---\n\nReview the code for vulnerabilities.\n"
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+			require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+			require.NoError(t, err, "should read lock file")
+			lock := string(lockContent)
+
+			if !tt.expectUpload {
+				assert.NotContains(t, lock, "id: upload_code_scanning_sarif", "SARIF should not be uploaded")
+				return
+			}
+			uploadIndex := strings.Index(lock, "id: upload_code_scanning_sarif")
+			require.NotEqual(t, -1, uploadIndex, "SARIF upload step should be generated")
+			assert.Less(t, strings.Index(lock, "id: process_safe_outputs"), uploadIndex, "SARIF should be uploaded after the handlers write it")
+			assert.Contains(t, lock, "uses: github/codeql-action/upload-sarif@", "upload-sarif should be used")
+			assert.Contains(t, lock, "sarif_file: ${{ steps.process_safe_outputs.outputs.sarif_file }}", "handler SARIF file should be uploaded")
+			assert.Contains(t, lock, tt.expectedCategory, "analysis category should be set")
+			assert.Contains(t, lock, "code_scanning_sarif_id: ${{ steps.upload_code_scanning_sarif.outputs.sarif-id }}", "SARIF id should be exported")
+		})
+	}
+}
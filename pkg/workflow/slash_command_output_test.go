@@ -90,3 +90,57 @@ Test workflow content
 	assert.NotContains(t, slashCommand, "steps.check_command_position",
 		"Expected slash_command to NOT reference steps.check_command_position directly")
 }
+
+// TestSlashCommandArgsOutput ensures that command arguments are exposed as job outputs
+// and that prompt references to them resolve to the pre_activation output
+func TestSlashCommandArgsOutput(t *testing.T) {
+	tempDir := t.TempDir()
+
+	workflowContent := `---
+name: Test Slash Command Args
+on:
+  slash_command:
+    name: summarize
+    events: [issue_comment]
+permissions:
+  contents: read
+engine: copilot
+---
+
+Summarize the thread for: ${{ needs.activation.outputs.slash_command_args }}
+`
+
+	workflowPath := filepath.Join(tempDir, "test-workflow.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(workflowContent), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "Failed to compile workflow")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err)
+
+	var workflow map[string]any
+	require.NoError(t, yaml.Unmarshal(lockContent, &workflow))
+	jobs, ok := workflow["jobs"].(map[string]any)
+	require.True(t, ok, "Expected jobs to be a map")
+
+	preActivation, ok := jobs["pre_activation"].(map[string]any)
+	require.True(t, ok, "Expected pre_activation job to exist")
+	preActivationOutputs, ok := preActivation["outputs"].(map[string]any)
+	require.True(t, ok, "Expected pre_activation job to have outputs")
+	assert.Equal(t, "${{ steps.check_command_position.outputs.command_args }}", preActivationOutputs["command_args"],
+		"Expected command_args to reference check_command_position step output")
+
+	activation, ok := jobs["activation"].(map[string]any)
+	require.True(t, ok, "Expected activation job to exist")
+	activationOutputs, ok := activation["outputs"].(map[string]any)
+	require.True(t, ok, "Expected activation job to have outputs")
+	assert.Equal(t, "${{ needs.pre_activation.outputs.command_args }}", activationOutputs["slash_command_args"],
+		"Expected slash_command_args to reference needs.pre_activation.outputs.command_args")
+
+	lock := string(lockContent)
+	assert.Contains(t, lock, "GH_AW_NEEDS_PRE_ACTIVATION_OUTPUTS_COMMAND_ARGS: ${{ needs.pre_activation.outputs.command_args }}",
+		"Expected the prompt to read command arguments from pre_activation")
+	assert.NotContains(t, lock, "${{ needs.activation.outputs.slash_command_args }}",
+		"Expected the activation job not to reference its own outputs")
+}
@@ -0,0 +1,144 @@
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var splitScriptsLog = logger.New("workflow:split_scripts")
+
+const (
+	// SplitScriptsDir is the repository-relative directory that holds generated helper
+	// files factored out of lock files by compile --split-scripts
+	SplitScriptsDir = ".github/aw/scripts"
+
+	// splitScriptsStageDir is where the agent job stages the split files right after the
+	// repository checkout, before any step can switch the workspace to another ref
+	splitScriptsStageDir = "/opt/gh-aw/scripts"
+)
+
+// WithSplitScripts configures whether generated helper files (safe-output tool schemas,
+// safe-input tools and server) are written to versioned files under .github/aw/scripts/
+// instead of being inlined into the lock file
+func WithSplitScripts(split bool) CompilerOption {
+	return func(c *Compiler) { c.splitScripts = split }
+}
+
+// resetSplitScripts clears the per-workflow split file state before a compilation
+func (c *Compiler) resetSplitScripts() {
+	c.splitScriptFiles = nil
+	c.splitScriptsStaged = false
+}
+
+// splitScriptsWorkflowDir returns the repository-relative directory for the current workflow's split files
+func (c *Compiler) splitScriptsWorkflowDir() string {
+	return SplitScriptsDir + "/" + GetWorkflowIDFromPath(c.markdownPath)
+}
+
+// generateSplitScriptsStagingStep copies the current workflow's split files out of the
+// workspace. It runs directly after the default checkout so that later steps, such as the
+// PR branch checkout, cannot replace them with another ref's version.
+func (c *Compiler) generateSplitScriptsStagingStep(yaml *strings.Builder) {
+	if !c.splitScripts || c.trialMode {
+		return
+	}
+	splitScriptsLog.Print("Generating split scripts staging step")
+	dir := c.splitScriptsWorkflowDir()
+	yaml.WriteString("      - name: Stage compiled scripts\n")
+	yaml.WriteString("        run: |\n")
+	fmt.Fprintf(yaml, "          mkdir -p %s\n", splitScriptsStageDir)
+	fmt.Fprintf(yaml, "          if [ -d \"%s\" ]; then\n", dir)
+	fmt.Fprintf(yaml, "            cp -R \"%s/.\" %s/\n", dir, splitScriptsStageDir)
+	yaml.WriteString("          fi\n")
+	c.splitScriptsStaged = true
+}
+
+// writeSplitScriptFile records content for destPath as a versioned split file and emits the
+// command that installs it. It returns false, emitting nothing, when split mode is off or the
+// job never staged the split files; the caller then inlines the content as a heredoc.
+func (c *Compiler) writeSplitScriptFile(yaml *strings.Builder, destPath string, content string) bool {
+	if !c.splitScripts || !c.splitScriptsStaged {
+		return false
+	}
+
+	name := splitScriptFileName(destPath, content)
+	if c.splitScriptFiles == nil {
+		c.splitScriptFiles = make(map[string]string)
+	}
+	c.splitScriptFiles[name] = content
+	splitScriptsLog.Printf("Split %s into %s (%d bytes)", destPath, name, len(content))
+
+	fmt.Fprintf(yaml, "          cp %s/%s %s\n", splitScriptsStageDir, name, destPath)
+	return true
+}
+
+// splitScriptFileName derives a versioned file name from the destination and a content hash,
+// e.g. /opt/gh-aw/safeoutputs/tools.json -> safeoutputs-tools.1a2b3c4d.json. A lock file
+// therefore always references the exact content it was compiled with.
+func splitScriptFileName(destPath string, content string) string {
+	sum := sha256.Sum256([]byte(content))
+	ext := filepath.Ext(destPath)
+	stem := strings.TrimSuffix(filepath.Base(destPath), ext)
+	return fmt.Sprintf("%s-%s.%s%s", filepath.Base(filepath.Dir(destPath)), stem, hex.EncodeToString(sum[:4]), ext)
+}
+
+// writeSplitScriptFiles writes the split files for the compiled workflow next to the lock file's
+// .github directory and removes files left over from earlier compilations. Without split files
+// the workflow's directory is removed entirely.
+func (c *Compiler) writeSplitScriptFiles(lockFile string) error {
+	dir := filepath.Join(filepath.Dir(filepath.Dir(lockFile)), "aw", "scripts", GetWorkflowIDFromPath(c.markdownPath))
+
+	if len(c.splitScriptFiles) == 0 {
+		if _, err := os.Stat(dir); err == nil {
+			splitScriptsLog.Printf("Removing split scripts directory no longer used: %s", dir)
+			return os.RemoveAll(dir)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create split scripts directory: %w", err)
+	}
+
+	names := make([]string, 0, len(c.splitScriptFiles))
+	for name := range c.splitScriptFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			// Names are content-addressed, so an existing file already has this content
+			continue
+		}
+		if err := os.WriteFile(path, []byte(c.splitScriptFiles[name]), 0644); err != nil {
+			return fmt.Errorf("failed to write split script %s: %w", name, err)
+		}
+		if c.fileTracker != nil {
+			c.fileTracker.TrackCreated(path)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read split scripts directory: %w", err)
+	}
+	for _, entry := range entries {
+		if _, used := c.splitScriptFiles[entry.Name()]; !used && !entry.IsDir() {
+			splitScriptsLog.Printf("Removing stale split script: %s", entry.Name())
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+				return fmt.Errorf("failed to remove stale split script %s: %w", entry.Name(), err)
+			}
+		}
+	}
+
+	splitScriptsLog.Printf("Wrote %d split scripts to %s", len(names), dir)
+	return nil
+}
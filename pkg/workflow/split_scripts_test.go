@@ -0,0 +1,85 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitScriptFileName(t *testing.T) {
+	name := splitScriptFileName("/opt/gh-aw/safeoutputs/tools.json", "[]")
+	assert.Regexp(t, `^safeoutputs-tools\.[0-9a-f]{8}\.json$`, name, "name should combine directory, stem, hash and extension")
+	assert.Equal(t, name, splitScriptFileName("/opt/gh-aw/safeoutputs/tools.json", "[]"), "name should be stable for the same content")
+	assert.NotEqual(t, name, splitScriptFileName("/opt/gh-aw/safeoutputs/tools.json", "[{}]"), "name should change with the content")
+}
+
+func TestCompileWithSplitScripts(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "split-scripts-test")
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "should create workflows directory")
+	workflowPath := filepath.Join(workflowsDir, "split.md")
+	scriptsDir := filepath.Join(tmpDir, ".github", "aw", "scripts", "split")
+
+	content := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+safe-outputs:
+  add-comment:
+safe-inputs:
+  hello:
+    description: Say hello
+    run: echo hello
+---
+
+Comment on the issue.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+	compile := func(t *testing.T, split bool) string {
+		t.Helper()
+		require.NoError(t, NewCompiler(WithSplitScripts(split)).CompileWorkflow(workflowPath), "workflow should compile")
+		lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+		require.NoError(t, err, "should read lock file")
+		return string(lockContent)
+	}
+
+	inline := compile(t, false)
+	assert.Contains(t, inline, "cat > /opt/gh-aw/safeoutputs/tools.json", "tools should be inlined by default")
+	assert.NoDirExists(t, scriptsDir, "no scripts directory should be written by default")
+
+	split := compile(t, true)
+	assert.Less(t, len(split), len(inline), "split lock file should be smaller")
+	assert.NotContains(t, split, "cat > /opt/gh-aw/safeoutputs/tools.json", "tools should not be inlined")
+	assert.Contains(t, split, "- name: Stage compiled scripts", "agent job should stage the split files")
+	assert.Less(t, strings.Index(split, "- name: Checkout repository"), strings.Index(split, "- name: Stage compiled scripts"), "staging should follow the checkout")
+
+	entries, err := os.ReadDir(scriptsDir)
+	require.NoError(t, err, "scripts directory should be written")
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+		assert.Contains(t, split, "cp /opt/gh-aw/scripts/"+entry.Name()+" ", "lock file should reference %s", entry.Name())
+	}
+	assert.Len(t, names, 5, "should split safe-output tools and validation, safe-input tools, server and the hello tool")
+
+	// A stale version from an earlier compilation is removed
+	stale := filepath.Join(scriptsDir, "safeoutputs-tools.00000000.json")
+	require.NoError(t, os.WriteFile(stale, []byte("[]"), 0644), "should write stale file")
+	compile(t, true)
+	assert.NoFileExists(t, stale, "stale split file should be removed")
+
+	// Compiling without split mode inlines again and removes the directory
+	assert.Equal(t, inline, compile(t, false), "inline output should be unchanged")
+	assert.NoDirExists(t, scriptsDir, "scripts directory should be removed")
+}
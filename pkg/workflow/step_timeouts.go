@@ -0,0 +1,155 @@
+package workflow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var stepTimeoutsLog = logger.New("workflow:step_timeouts")
+
+// safeOutputsJobTimeoutMinutes is the timeout of the consolidated safe_outputs job
+const safeOutputsJobTimeoutMinutes = 15
+
+// timeoutsExample is appended to timeouts: parsing errors
+const timeoutsExample = "Example:\ntimeouts:\n  agent: 20m\n  safe-outputs: 5m\n  activation: 2m"
+
+// TimeoutsConfig holds per-phase timeouts in minutes (timeouts:). A zero value keeps the default.
+//
+// Example:
+//
+//	timeouts:
+//	  agent: 20m         # agent execution step; timeout-minutes becomes the agent job timeout
+//	  safe-outputs: 5m   # Process Safe Outputs step
+//	  activation: 2m     # activation job
+type TimeoutsConfig struct {
+	Agent       int `json:"agent,omitempty"`
+	SafeOutputs int `json:"safe-outputs,omitempty"`
+	Activation  int `json:"activation,omitempty"`
+}
+
+// extractTimeoutsConfig extracts the per-phase timeouts from frontmatter
+func (c *Compiler) extractTimeoutsConfig(frontmatter map[string]any) (*TimeoutsConfig, error) {
+	value, exists := frontmatter["timeouts"]
+	if !exists || value == nil {
+		return nil, nil
+	}
+
+	timeoutsMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("timeouts must be an object, got %T. %s", value, timeoutsExample)
+	}
+
+	config := &TimeoutsConfig{}
+	for key, dest := range map[string]*int{"agent": &config.Agent, "safe-outputs": &config.SafeOutputs, "activation": &config.Activation} {
+		raw, exists := timeoutsMap[key]
+		if !exists {
+			continue
+		}
+		minutes, err := parseTimeoutMinutes(raw)
+		if err != nil {
+			return nil, fmt.Errorf("timeouts.%s: %w. %s", key, err, timeoutsExample)
+		}
+		*dest = minutes
+	}
+
+	stepTimeoutsLog.Printf("Extracted timeouts: agent=%d, safe-outputs=%d, activation=%d", config.Agent, config.SafeOutputs, config.Activation)
+	return config, nil
+}
+
+// parseTimeoutMinutes parses a timeout given as a number of minutes or a duration string
+// in whole minutes (e.g. "20m", "1h30m")
+func parseTimeoutMinutes(value any) (int, error) {
+	if minutes, ok := parseIntValue(value); ok {
+		if minutes < 1 {
+			return 0, fmt.Errorf("must be at least 1 minute, got %d", minutes)
+		}
+		return minutes, nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("must be a number of minutes or a duration such as '20m', got %T", value)
+	}
+	duration, err := time.ParseDuration(str)
+	if err != nil || duration < time.Minute || duration%time.Minute != 0 {
+		return 0, fmt.Errorf("invalid duration '%s': must be whole minutes of at least 1m (e.g. '20m', '1h30m')", str)
+	}
+	return int(duration / time.Minute), nil
+}
+
+// workflowTimeoutMinutes returns the timeout-minutes value of the workflow, or the default
+// agentic workflow timeout when it is not set or not a number
+func workflowTimeoutMinutes(workflowData *WorkflowData) int {
+	value := strings.TrimSpace(strings.TrimPrefix(workflowData.TimeoutMinutes, "timeout-minutes:"))
+	if minutes, err := strconv.Atoi(value); err == nil {
+		return minutes
+	}
+	return int(constants.DefaultAgenticWorkflowTimeout / time.Minute)
+}
+
+// workflowTimeoutExpression returns the computed timeout-minutes expression of the workflow,
+// or "" when timeout-minutes is a number
+func workflowTimeoutExpression(workflowData *WorkflowData) string {
+	value := strings.TrimSpace(strings.TrimPrefix(workflowData.TimeoutMinutes, "timeout-minutes:"))
+	value = strings.Trim(value, `"'`)
+	if isComputedExpression(value) {
+		return value
+	}
+	return ""
+}
+
+// agentStepTimeoutLine returns the timeout-minutes line of the agent execution step:
+// timeouts.agent when set, and otherwise the workflow timeout-minutes
+func agentStepTimeoutLine(workflowData *WorkflowData) string {
+	if workflowData.Timeouts != nil && workflowData.Timeouts.Agent > 0 {
+		return fmt.Sprintf("        timeout-minutes: %d", workflowData.Timeouts.Agent)
+	}
+	if workflowData.TimeoutMinutes != "" {
+		// Strip timeout-minutes prefix
+		return "        timeout-minutes: " + strings.TrimPrefix(workflowData.TimeoutMinutes, "timeout-minutes: ")
+	}
+	return fmt.Sprintf("        timeout-minutes: %d", int(constants.DefaultAgenticWorkflowTimeout/time.Minute)) // Default timeout for agentic workflows
+}
+
+// agentJobTimeoutMinutes returns the timeout of the agent job. The workflow timeout-minutes
+// only moves to the job when timeouts.agent gives the agent step its own timeout; otherwise
+// the job keeps the GitHub Actions default.
+func agentJobTimeoutMinutes(workflowData *WorkflowData) int {
+	if workflowData.Timeouts == nil || workflowData.Timeouts.Agent == 0 || workflowTimeoutExpression(workflowData) != "" {
+		return 0
+	}
+	return workflowTimeoutMinutes(workflowData)
+}
+
+// agentJobTimeoutExpression returns the computed agent job timeout, set like agentJobTimeoutMinutes
+// when timeouts.agent is configured and timeout-minutes is a computed expression
+func agentJobTimeoutExpression(workflowData *WorkflowData) string {
+	if workflowData.Timeouts == nil || workflowData.Timeouts.Agent == 0 {
+		return ""
+	}
+	return workflowTimeoutExpression(workflowData)
+}
+
+// validateTimeouts checks that step timeouts do not exceed the timeout of their job
+func validateTimeouts(workflowData *WorkflowData) error {
+	timeouts := workflowData.Timeouts
+	if timeouts == nil {
+		return nil
+	}
+
+	// A computed job timeout is only known at run time, when GitHub Actions enforces it
+	if jobTimeout := agentJobTimeoutMinutes(workflowData); agentJobTimeoutExpression(workflowData) == "" && timeouts.Agent > jobTimeout {
+		return fmt.Errorf("timeouts.agent (%d minutes) exceeds the agent job timeout-minutes (%d minutes). Increase timeout-minutes or lower timeouts.agent", timeouts.Agent, jobTimeout)
+	}
+	if timeouts.SafeOutputs > safeOutputsJobTimeoutMinutes {
+		return fmt.Errorf("timeouts.safe-outputs (%d minutes) exceeds the safe_outputs job timeout (%d minutes)", timeouts.SafeOutputs, safeOutputsJobTimeoutMinutes)
+	}
+
+	stepTimeoutsLog.Print("Step timeouts are within their job timeouts")
+	return nil
+}
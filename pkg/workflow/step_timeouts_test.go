@@ -0,0 +1,131 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeoutMinutes(t *testing.T) {
+	for value, expected := range map[any]int{5: 5, uint64(10): 10, "20m": 20, "1h30m": 90} {
+		minutes, err := parseTimeoutMinutes(value)
+		require.NoError(t, err, "value %v should parse", value)
+		assert.Equal(t, expected, minutes, "minutes for %v", value)
+	}
+
+	for value, errorSubstring := range map[any]string{
+		0:       "must be at least 1 minute",
+		"30s":   "whole minutes of at least 1m",
+		"1m30s": "whole minutes of at least 1m",
+		"soon":  "invalid duration 'soon'",
+		true:    "got bool",
+	} {
+		_, err := parseTimeoutMinutes(value)
+		require.Error(t, err, "value %v should be rejected", value)
+		assert.Contains(t, err.Error(), errorSubstring, "error for %v", value)
+	}
+}
+
+func TestExtractTimeoutsConfig(t *testing.T) {
+	compiler := NewCompiler()
+
+	config, err := compiler.extractTimeoutsConfig(map[string]any{})
+	require.NoError(t, err, "missing timeouts should not fail")
+	assert.Nil(t, config, "missing timeouts should keep the defaults")
+
+	config, err = compiler.extractTimeoutsConfig(map[string]any{
+		"timeouts": map[string]any{"agent": "20m", "safe-outputs": 5, "activation": "2m"},
+	})
+	require.NoError(t, err, "valid timeouts should parse")
+	assert.Equal(t, &TimeoutsConfig{Agent: 20, SafeOutputs: 5, Activation: 2}, config, "timeouts")
+
+	_, err = compiler.extractTimeoutsConfig(map[string]any{"timeouts": "20m"})
+	require.Error(t, err, "timeouts must be an object")
+	assert.Contains(t, err.Error(), "timeouts must be an object", "error message")
+
+	_, err = compiler.extractTimeoutsConfig(map[string]any{"timeouts": map[string]any{"agent": "90s"}})
+	require.Error(t, err, "sub-minute timeouts should be rejected")
+	assert.Contains(t, err.Error(), "timeouts.agent", "error should name the field")
+}
+
+func TestValidateTimeouts(t *testing.T) {
+	assert.NoError(t, validateTimeouts(&WorkflowData{}), "no timeouts")
+	assert.NoError(t, validateTimeouts(&WorkflowData{
+		TimeoutMinutes: "timeout-minutes: 30",
+		Timeouts:       &TimeoutsConfig{Agent: 30, SafeOutputs: 15, Activation: 2},
+	}), "timeouts within their jobs")
+	assert.NoError(t, validateTimeouts(&WorkflowData{
+		Timeouts: &TimeoutsConfig{Agent: 20},
+	}), "agent timeout equal to the default job timeout")
+
+	err := validateTimeouts(&WorkflowData{
+		TimeoutMinutes: "timeout-minutes: 10",
+		Timeouts:       &TimeoutsConfig{Agent: 15},
+	})
+	require.Error(t, err, "agent timeout above timeout-minutes")
+	assert.Contains(t, err.Error(), "timeouts.agent (15 minutes) exceeds the agent job timeout-minutes (10 minutes)", "error message")
+
+	err = validateTimeouts(&WorkflowData{Timeouts: &TimeoutsConfig{SafeOutputs: 20}})
+	require.Error(t, err, "safe-outputs timeout above the safe_outputs job timeout")
+	assert.Contains(t, err.Error(), "timeouts.safe-outputs (20 minutes) exceeds the safe_outputs job timeout (15 minutes)", "error message")
+}
+
+func TestTimeoutsAppliedToLockFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "step-timeouts-test")
+	workflowFile := filepath.Join(tmpDir, "timeouts.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+timeout-minutes: 30
+timeouts:
+  agent: 25m
+  safe-outputs: 5m
+  activation: 2
+safe-outputs:
+  create-issue:
+---
+
+# Timeouts
+`), 0644), "should write workflow")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "should compile workflow")
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "timeouts.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+
+	var lock struct {
+		Jobs map[string]struct {
+			TimeoutMinutes int `yaml:"timeout-minutes"`
+			Steps          []struct {
+				Name           string `yaml:"name"`
+				TimeoutMinutes int    `yaml:"timeout-minutes"`
+			} `yaml:"steps"`
+		} `yaml:"jobs"`
+	}
+	require.NoError(t, yaml.Unmarshal(lockContent, &lock), "lock file should be valid YAML")
+
+	stepTimeout := func(job, stepPrefix string) int {
+		for _, step := range lock.Jobs[job].Steps {
+			if strings.HasPrefix(step.Name, stepPrefix) {
+				return step.TimeoutMinutes
+			}
+		}
+		t.Fatalf("step %q not found in job %s", stepPrefix, job)
+		return 0
+	}
+
+	assert.Equal(t, 30, lock.Jobs["agent"].TimeoutMinutes, "timeout-minutes should become the agent job timeout")
+	assert.Equal(t, 25, stepTimeout("agent", "Execute GitHub Copilot CLI"), "agent step timeout")
+	assert.Equal(t, 5, stepTimeout("safe_outputs", "Process Safe Outputs"), "safe outputs step timeout")
+	assert.Equal(t, 15, lock.Jobs["safe_outputs"].TimeoutMinutes, "safe_outputs job timeout")
+	assert.Equal(t, 2, lock.Jobs["activation"].TimeoutMinutes, "activation job timeout")
+}
@@ -7,8 +7,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/github/gh-aw/pkg/stringutil"
-
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -64,7 +62,7 @@ func (c *Compiler) processStopAfterConfiguration(frontmatter map[string]any, wor
 	if workflowData.StopTime != "" {
 		stopAfterLog.Printf("Stop-after value specified: %s", workflowData.StopTime)
 		// Check if there's already a lock file with a stop time (recompilation case)
-		lockFile := stringutil.MarkdownToLockFile(markdownPath)
+		lockFile := c.LockFilePath(markdownPath)
 		existingStopTime := ExtractStopTimeFromLockFile(lockFile)
 
 		// If refresh flag is set, always regenerate the stop time
@@ -106,6 +104,13 @@ func (c *Compiler) processStopAfterConfiguration(frontmatter map[string]any, wor
 				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Parsed absolute stop-after from '%s' to: %s", originalStopTime, resolvedStopTime)))
 			}
 		}
+
+		// Warn when the workflow is already expired, since every run will skip the agent job
+		if stopTime, err := time.Parse("2006-01-02 15:04:05", workflowData.StopTime); err == nil && !stopTime.After(time.Now().UTC()) {
+			stopAfterLog.Printf("Stop time %s is in the past", workflowData.StopTime)
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("stop-after time %s has already passed; the agent job will be skipped on every run. Update stop-after and recompile with --refresh-stop-time to extend the workflow.", workflowData.StopTime)))
+			c.IncrementWarningCount()
+		}
 	}
 
 	return nil
@@ -256,3 +256,38 @@ jobs:
 		}
 	})
 }
+
+// TestExpiredStopTimeWarning tests that a stop-after time in the past produces a compiler warning
+func TestExpiredStopTimeWarning(t *testing.T) {
+	mdFile := filepath.Join(t.TempDir(), "test.md")
+
+	tests := []struct {
+		name        string
+		stopAfter   string
+		wantWarning bool
+	}{
+		{name: "past absolute date", stopAfter: "2020-01-01 00:00:00", wantWarning: true},
+		{name: "relative future time", stopAfter: "+30d", wantWarning: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			frontmatter := map[string]any{
+				"on": map[string]any{
+					"schedule":   "daily",
+					"stop-after": tt.stopAfter,
+				},
+			}
+
+			workflowData := &WorkflowData{}
+			if err := compiler.processStopAfterConfiguration(frontmatter, workflowData, mdFile); err != nil {
+				t.Fatalf("processStopAfterConfiguration failed: %v", err)
+			}
+
+			if got := compiler.GetWarningCount() > 0; got != tt.wantWarning {
+				t.Errorf("Expected warning=%v for stop-after %q, got warning count %d", tt.wantWarning, tt.stopAfter, compiler.GetWarningCount())
+			}
+		})
+	}
+}
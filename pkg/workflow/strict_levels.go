@@ -0,0 +1,247 @@
+// This file provides per-category strict mode levels.
+//
+// # Strict Rule Levels
+//
+// Strict mode is enabled or disabled as a whole with `strict:` (or `compile --strict`).
+// `strict-rules:` in the frontmatter or in .aw/config.yml overrides the level of
+// individual rule categories:
+//
+//	strict-rules:
+//	  network: warn       # report network findings as warnings
+//	  pinning: error      # require exact action pins
+//	  experimental: error # refuse experimental features
+//
+// Levels are off (the rule is not checked), warn (findings are reported as warnings)
+// and error (findings fail compilation). Categories that are not overridden keep the
+// level implied by strict mode, see defaultStrictLevel.
+//
+// The levels and the findings of every compiled workflow are recorded for
+// `gh aw compile --explain-strict`.
+
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+)
+
+var strictLevelsLog = newValidationLogger("strict_levels")
+
+// StrictLevel is the enforcement level of a strict mode rule category
+type StrictLevel string
+
+const (
+	// StrictLevelOff disables the rules of a category
+	StrictLevelOff StrictLevel = "off"
+	// StrictLevelWarn reports findings as warnings
+	StrictLevelWarn StrictLevel = "warn"
+	// StrictLevelError reports findings as compilation errors
+	StrictLevelError StrictLevel = "error"
+)
+
+// Strict mode rule categories
+const (
+	StrictRuleNetwork      = "network"      // network.allowed wildcards, MCP container network, web-search, firewall and sandbox
+	StrictRulePermissions  = "permissions"  // write permissions on sensitive scopes
+	StrictRuleTools        = "tools"        // tool configurations such as repo-scoped cache-memory
+	StrictRuleDeprecated   = "deprecated"   // deprecated frontmatter fields
+	StrictRuleSecrets      = "secrets"      // secrets in env sections leaked to the agent container
+	StrictRulePinning      = "pinning"      // exact action pins instead of semver-compatible fallbacks
+	StrictRuleExperimental = "experimental" // experimental features and engines
+)
+
+// StrictRuleCategories lists the rule categories that can be configured with strict-rules
+var StrictRuleCategories = []string{
+	StrictRuleNetwork,
+	StrictRulePermissions,
+	StrictRuleTools,
+	StrictRuleDeprecated,
+	StrictRuleSecrets,
+	StrictRulePinning,
+	StrictRuleExperimental,
+}
+
+// StrictFinding is a strict mode rule that fired while compiling a workflow
+type StrictFinding struct {
+	Category string
+	Level    StrictLevel
+	Message  string
+}
+
+// StrictReport records the strict rule levels and findings of a workflow, for `compile --explain-strict`
+type StrictReport struct {
+	WorkflowPath string
+	Strict       bool
+	Levels       map[string]StrictLevel
+	Overrides    []string // Categories whose level was set by strict-rules
+	Findings     []StrictFinding
+}
+
+// ValidateStrictRules checks that every category and level in a strict-rules map is known
+func ValidateStrictRules(rules map[string]string) error {
+	var categories []string
+	for category := range rules {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		if !slices.Contains(StrictRuleCategories, category) {
+			return fmt.Errorf("strict-rules: unknown rule category '%s'. Valid categories: %s", category, strings.Join(StrictRuleCategories, ", "))
+		}
+		switch StrictLevel(rules[category]) {
+		case StrictLevelOff, StrictLevelWarn, StrictLevelError:
+		default:
+			return fmt.Errorf("strict-rules.%s: invalid level '%s'. Valid levels: off, warn, error", category, rules[category])
+		}
+	}
+	return nil
+}
+
+// parseStrictRules reads the strict-rules frontmatter field
+func parseStrictRules(frontmatter map[string]any) (map[string]string, error) {
+	value, exists := frontmatter["strict-rules"]
+	if !exists || value == nil {
+		return nil, nil
+	}
+	rulesMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("strict-rules: must be a map of rule categories to levels (off, warn, error)")
+	}
+	rules := make(map[string]string, len(rulesMap))
+	for category, level := range rulesMap {
+		levelStr, ok := level.(string)
+		if !ok {
+			return nil, fmt.Errorf("strict-rules.%s: level must be one of off, warn, error", category)
+		}
+		rules[category] = levelStr
+	}
+	if err := ValidateStrictRules(rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// defaultStrictLevel returns the level of a category that strict-rules does not override
+func defaultStrictLevel(category string, strict bool) StrictLevel {
+	switch category {
+	case StrictRuleExperimental:
+		// Experimental features have always been reported as warnings
+		return StrictLevelWarn
+	case StrictRuleSecrets:
+		// Secrets in env are reported even outside strict mode
+		if strict {
+			return StrictLevelError
+		}
+		return StrictLevelWarn
+	}
+	if strict {
+		return StrictLevelError
+	}
+	return StrictLevelOff
+}
+
+// resolveStrictRules merges the repository and frontmatter strict-rules for the workflow
+// being compiled (frontmatter wins) and starts its strict report. It must be called after
+// c.strictMode holds the effective strict mode of the workflow; cliStrict is the compile --strict setting.
+func (c *Compiler) resolveStrictRules(frontmatter map[string]any, workflowPath string, cliStrict bool) error {
+	frontmatterRules, err := parseStrictRules(frontmatter)
+	if err != nil {
+		return err
+	}
+
+	rules := make(map[string]StrictLevel)
+	if c.repoConfig != nil {
+		for category, level := range c.repoConfig.StrictRules {
+			rules[category] = StrictLevel(level)
+		}
+	}
+	for category, level := range frontmatterRules {
+		rules[category] = StrictLevel(level)
+	}
+	c.strictRules = rules
+	strictLevelsLog.Printf("Resolved strict rules: strict=%v, overrides=%v", c.strictMode, rules)
+
+	report := StrictReport{
+		WorkflowPath: workflowPath,
+		Strict:       c.strictMode,
+		Levels:       make(map[string]StrictLevel, len(StrictRuleCategories)),
+	}
+	for _, category := range StrictRuleCategories {
+		report.Levels[category] = c.strictLevel(category)
+		if _, ok := rules[category]; ok {
+			report.Overrides = append(report.Overrides, category)
+		}
+	}
+	// Pinning is only enforced by default with compile --strict, see actionPinningStrict
+	if _, ok := rules[StrictRulePinning]; !ok && !cliStrict {
+		report.Levels[StrictRulePinning] = StrictLevelWarn
+	}
+	c.strictReports = append(c.strictReports, report)
+	return nil
+}
+
+// strictLevel returns the level of a rule category for the workflow being compiled
+func (c *Compiler) strictLevel(category string) StrictLevel {
+	if level, ok := c.strictRules[category]; ok {
+		return level
+	}
+	return defaultStrictLevel(category, c.strictMode)
+}
+
+// actionPinningStrict reports whether actions must match a pin exactly. Without a pinning
+// rule this follows compile --strict (c.strictMode outside the per-workflow strict mode
+// evaluation), so frontmatter strict mode keeps the semver-compatible fallback.
+func (c *Compiler) actionPinningStrict() bool {
+	if level, ok := c.strictRules[StrictRulePinning]; ok {
+		return level == StrictLevelError
+	}
+	return c.strictMode
+}
+
+// actionPinWarningsDisabled reports whether pin fallbacks are resolved silently (pinning: off)
+func (c *Compiler) actionPinWarningsDisabled() bool {
+	return c.strictRules[StrictRulePinning] == StrictLevelOff
+}
+
+// applyStrictRule reports a strict mode finding at the level of its category. It returns the
+// finding as an error only at the error level; warnings are printed and counted.
+func (c *Compiler) applyStrictRule(category string, finding error) error {
+	if finding == nil {
+		return nil
+	}
+	level := c.strictLevel(category)
+	strictLevelsLog.Printf("Strict rule fired: category=%s, level=%s", category, level)
+	if level == StrictLevelOff {
+		return nil
+	}
+	if len(c.strictReports) > 0 {
+		report := &c.strictReports[len(c.strictReports)-1]
+		report.Findings = append(report.Findings, StrictFinding{Category: category, Level: level, Message: finding.Error()})
+	}
+	if level == StrictLevelError {
+		return finding
+	}
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(finding.Error()))
+	c.IncrementWarningCount()
+	return nil
+}
+
+// reportExperimentalFeature reports the use of an experimental feature according to the
+// experimental rule level (a warning by default)
+func (c *Compiler) reportExperimentalFeature(message string) error {
+	if c.strictLevel(StrictRuleExperimental) == StrictLevelError {
+		return c.applyStrictRule(StrictRuleExperimental, fmt.Errorf("strict mode: %s is not allowed (strict-rules.experimental: error)", strings.TrimPrefix(message, "Using ")))
+	}
+	return c.applyStrictRule(StrictRuleExperimental, errors.New(message))
+}
+
+// GetStrictReports returns the strict reports recorded by this compiler instance
+func (c *Compiler) GetStrictReports() []StrictReport {
+	return c.strictReports
+}
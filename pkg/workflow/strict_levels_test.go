@@ -0,0 +1,146 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStrictRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   map[string]string
+		wantErr string
+	}{
+		{name: "empty", rules: nil},
+		{name: "valid", rules: map[string]string{"network": "warn", "pinning": "error", "experimental": "off"}},
+		{name: "unknown category", rules: map[string]string{"netwrok": "warn"}, wantErr: "unknown rule category 'netwrok'"},
+		{name: "unknown level", rules: map[string]string{"network": "loud"}, wantErr: "strict-rules.network: invalid level 'loud'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStrictRules(tt.rules)
+			if tt.wantErr == "" {
+				require.NoError(t, err, "rules should be valid")
+				return
+			}
+			require.Error(t, err, "rules should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestParseStrictRules(t *testing.T) {
+	rules, err := parseStrictRules(map[string]any{"strict-rules": map[string]any{"network": "warn"}})
+	require.NoError(t, err, "valid strict-rules should parse")
+	assert.Equal(t, map[string]string{"network": "warn"}, rules, "rules should be returned")
+
+	rules, err = parseStrictRules(map[string]any{})
+	require.NoError(t, err, "missing strict-rules should not be an error")
+	assert.Nil(t, rules, "missing strict-rules should yield no rules")
+
+	_, err = parseStrictRules(map[string]any{"strict-rules": "warn"})
+	require.Error(t, err, "non-map strict-rules should be rejected")
+
+	_, err = parseStrictRules(map[string]any{"strict-rules": map[string]any{"network": true}})
+	require.Error(t, err, "non-string levels should be rejected")
+}
+
+func TestDefaultStrictLevel(t *testing.T) {
+	assert.Equal(t, StrictLevelError, defaultStrictLevel(StrictRuleNetwork, true), "strict mode should fail on network findings")
+	assert.Equal(t, StrictLevelOff, defaultStrictLevel(StrictRuleNetwork, false), "non-strict mode should skip network rules")
+	assert.Equal(t, StrictLevelWarn, defaultStrictLevel(StrictRuleSecrets, false), "secrets should warn outside strict mode")
+	assert.Equal(t, StrictLevelError, defaultStrictLevel(StrictRuleSecrets, true), "secrets should fail in strict mode")
+	assert.Equal(t, StrictLevelWarn, defaultStrictLevel(StrictRuleExperimental, true), "experimental features should only warn by default")
+}
+
+func TestCompileWithStrictRules(t *testing.T) {
+	tests := []struct {
+		name         string
+		frontmatter  string
+		wantErr      string
+		wantFindings []StrictFinding
+	}{
+		{
+			name: "network downgraded to warn",
+			frontmatter: `strict-rules:
+  network: warn
+network:
+  allowed:
+    - "*"`,
+			wantFindings: []StrictFinding{{Category: StrictRuleNetwork, Level: StrictLevelWarn}},
+		},
+		{
+			name: "network turned off",
+			frontmatter: `strict-rules:
+  network: off
+network:
+  allowed:
+    - "*"`,
+		},
+		{
+			name: "network wildcard fails by default",
+			frontmatter: `network:
+  allowed:
+    - "*"`,
+			wantErr: "wildcard",
+		},
+		{
+			name: "experimental features rejected",
+			frontmatter: `strict-rules:
+  experimental: error
+rate-limit:
+  max: 5
+  window: 60`,
+			wantErr: "strict mode: experimental feature: rate-limit is not allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "strict-levels-test")
+			workflowPath := filepath.Join(tmpDir, "workflow.md")
+			content := "---\non: workflow_dispatch\nengine: copilot\npermissions:\n  contents: read\n" + tt.frontmatter + "\n---\n\n# Test Workflow\n"
+			require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+
+			compiler := NewCompiler()
+			err := compiler.CompileWorkflow(workflowPath)
+			if tt.wantErr != "" {
+				require.Error(t, err, "workflow should fail to compile")
+				assert.Contains(t, err.Error(), tt.wantErr, "error should come from the strict rule")
+				return
+			}
+			require.NoError(t, err, "workflow should compile")
+
+			reports := compiler.GetStrictReports()
+			require.Len(t, reports, 1, "one strict report should be recorded")
+			assert.Equal(t, []string{StrictRuleNetwork}, reports[0].Overrides, "network should be reported as overridden")
+			require.Len(t, reports[0].Findings, len(tt.wantFindings), "findings should match")
+			for i, finding := range tt.wantFindings {
+				assert.Equal(t, finding.Category, reports[0].Findings[i].Category, "finding category should match")
+				assert.Equal(t, finding.Level, reports[0].Findings[i].Level, "finding level should match")
+			}
+		})
+	}
+}
+
+func TestStrictRulesFromRepoConfig(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.repoConfig = &RepoConfig{StrictRules: map[string]string{"network": "warn", "pinning": "off"}}
+	compiler.strictMode = true
+
+	require.NoError(t, compiler.resolveStrictRules(map[string]any{"strict-rules": map[string]any{"network": "error"}}, "workflow.md", false), "rules should resolve")
+
+	assert.Equal(t, StrictLevelError, compiler.strictLevel(StrictRuleNetwork), "frontmatter should take precedence over the repository config")
+	assert.Equal(t, StrictLevelOff, compiler.strictLevel(StrictRulePinning), "repository config should apply when frontmatter does not override")
+	assert.True(t, compiler.actionPinWarningsDisabled(), "pinning: off should silence pin fallbacks")
+	assert.False(t, compiler.actionPinningStrict(), "pinning: off should allow pin fallbacks")
+	assert.Equal(t, StrictLevelError, compiler.strictLevel(StrictRuleTools), "other categories should follow strict mode")
+}
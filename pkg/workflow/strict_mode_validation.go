@@ -339,28 +339,32 @@ func (c *Compiler) validateEnvSecretsSection(config map[string]any, sectionName
 
 	strictModeValidationLog.Printf("Found %d secret(s) in %s section: %v", len(secrets), sectionName, secretRefs)
 
-	// In strict mode, this is an error
-	if c.strictMode {
-		return fmt.Errorf("strict mode: secrets detected in '%s' section will be leaked to the agent container. Found: %s. Use engine-specific secret configuration instead. See: https://github.github.com/gh-aw/reference/engines/", sectionName, strings.Join(secretRefs, ", "))
+	level := c.strictLevel(StrictRuleSecrets)
+	if level == StrictLevelOff {
+		strictModeValidationLog.Printf("Strict secrets rules disabled, ignoring secrets in %s", sectionName)
+		return nil
 	}
 
-	// In non-strict mode, emit a warning
-	warningMsg := fmt.Sprintf("Warning: secrets detected in '%s' section will be leaked to the agent container. Found: %s. Consider using engine-specific secret configuration instead.", sectionName, strings.Join(secretRefs, ", "))
-	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
-	c.IncrementWarningCount()
+	// In strict mode (or with strict-rules.secrets: error), this is an error
+	if level == StrictLevelError {
+		return c.applyStrictRule(StrictRuleSecrets, fmt.Errorf("strict mode: secrets detected in '%s' section will be leaked to the agent container. Found: %s. Use engine-specific secret configuration instead. See: https://github.github.com/gh-aw/reference/engines/", sectionName, strings.Join(secretRefs, ", ")))
+	}
 
-	return nil
+	// In non-strict mode, emit a warning
+	return c.applyStrictRule(StrictRuleSecrets, fmt.Errorf("Warning: secrets detected in '%s' section will be leaked to the agent container. Found: %s. Consider using engine-specific secret configuration instead.", sectionName, strings.Join(secretRefs, ", ")))
 }
 
 // validateStrictMode performs strict mode validations on the workflow
 //
-// This is the main orchestrator that calls individual validation functions.
+// This is the main orchestrator that calls individual validation functions at the level
+// of their rule category (error in strict mode unless overridden by strict-rules).
 // It performs progressive validation:
 //  1. validateStrictPermissions() - Refuses write permissions on sensitive scopes
 //  2. validateStrictNetwork() - Requires explicit network configuration
 //  3. validateStrictMCPNetwork() - Requires top-level network config for container-based MCP servers
 //  4. validateStrictTools() - Validates tools configuration (e.g., serena local mode)
-//  5. validateStrictDeprecatedFields() - Refuses deprecated fields
+//  5. validateStrictWebSearch() - Requires a restricted network for web-search
+//  6. validateStrictDeprecatedFields() - Refuses deprecated fields
 //
 // Note: Env secrets validation (validateEnvSecrets) is called separately outside of strict mode
 // to emit warnings in non-strict mode and errors in strict mode.
@@ -369,48 +373,39 @@ func (c *Compiler) validateEnvSecretsSection(config map[string]any, sectionName
 // When zizmor is enabled with --zizmor flag, strict mode will treat any security
 // findings as compilation errors rather than warnings.
 func (c *Compiler) validateStrictMode(frontmatter map[string]any, networkPermissions *NetworkPermissions) error {
-	if !c.strictMode {
-		strictModeValidationLog.Printf("Strict mode disabled, skipping validation")
-		return nil
-	}
-
-	strictModeValidationLog.Printf("Starting strict mode validation")
+	strictModeValidationLog.Printf("Starting strict mode validation (strict=%v)", c.strictMode)
 
 	// Collect all strict mode validation errors
 	collector := NewErrorCollector(c.failFast)
 
-	// 1. Refuse write permissions
-	if err := c.validateStrictPermissions(frontmatter); err != nil {
-		if returnErr := collector.Add(err); returnErr != nil {
-			return returnErr // Fail-fast mode
-		}
-	}
-
-	// 2. Require network configuration and refuse "*" wildcard
-	if err := c.validateStrictNetwork(networkPermissions); err != nil {
-		if returnErr := collector.Add(err); returnErr != nil {
-			return returnErr // Fail-fast mode
-		}
-	}
-
-	// 3. Require network configuration on custom MCP servers
-	if err := c.validateStrictMCPNetwork(frontmatter, networkPermissions); err != nil {
-		if returnErr := collector.Add(err); returnErr != nil {
-			return returnErr // Fail-fast mode
-		}
-	}
-
-	// 4. Validate tools configuration
-	if err := c.validateStrictTools(frontmatter); err != nil {
-		if returnErr := collector.Add(err); returnErr != nil {
-			return returnErr // Fail-fast mode
+	// Each check belongs to a rule category; checks of categories set to "off" are skipped
+	// and findings of categories set to "warn" are reported as warnings (see strict_levels.go)
+	checks := []struct {
+		category string
+		check    func() error
+	}{
+		// 1. Refuse write permissions
+		{StrictRulePermissions, func() error { return c.validateStrictPermissions(frontmatter) }},
+		// 2. Require network configuration and refuse "*" wildcard
+		{StrictRuleNetwork, func() error { return c.validateStrictNetwork(networkPermissions) }},
+		// 3. Require network configuration on custom MCP servers
+		{StrictRuleNetwork, func() error { return c.validateStrictMCPNetwork(frontmatter, networkPermissions) }},
+		// 4. Validate tools configuration
+		{StrictRuleTools, func() error { return c.validateStrictTools(frontmatter) }},
+		// 5. Require a restricted network for web-search
+		{StrictRuleNetwork, func() error { return c.validateStrictWebSearch(frontmatter, networkPermissions) }},
+		// 6. Refuse deprecated fields
+		{StrictRuleDeprecated, func() error { return c.validateStrictDeprecatedFields(frontmatter) }},
+	}
+
+	for _, check := range checks {
+		if c.strictLevel(check.category) == StrictLevelOff {
+			continue
 		}
-	}
-
-	// 5. Refuse deprecated fields
-	if err := c.validateStrictDeprecatedFields(frontmatter); err != nil {
-		if returnErr := collector.Add(err); returnErr != nil {
-			return returnErr // Fail-fast mode
+		if err := c.applyStrictRule(check.category, check.check()); err != nil {
+			if returnErr := collector.Add(err); returnErr != nil {
+				return returnErr // Fail-fast mode
+			}
 		}
 	}
 
@@ -424,8 +419,8 @@ func (c *Compiler) validateStrictMode(frontmatter map[string]any, networkPermiss
 // In strict mode, ALL engines (regardless of LLM gateway support) require that network domains
 // must be defaults or from known ecosystems, and sandbox.agent must be enabled.
 func (c *Compiler) validateStrictFirewall(engineID string, networkPermissions *NetworkPermissions, sandboxConfig *SandboxConfig) error {
-	if !c.strictMode {
-		strictModeValidationLog.Printf("Strict mode disabled, skipping firewall validation")
+	if c.strictLevel(StrictRuleNetwork) == StrictLevelOff {
+		strictModeValidationLog.Printf("Strict network rules disabled, skipping firewall validation")
 		return nil
 	}
 
@@ -435,7 +430,7 @@ func (c *Compiler) validateStrictFirewall(engineID string, networkPermissions *N
 	// In strict mode, sandbox.agent: false is not allowed for any engine as it disables the agent sandbox firewall
 	if sandboxAgentDisabled {
 		strictModeValidationLog.Printf("sandbox.agent: false is set, refusing in strict mode")
-		return errors.New("strict mode: 'sandbox.agent: false' is not allowed because it disables the agent sandbox firewall. This removes important security protections. Remove 'sandbox.agent: false' or set 'strict: false' to disable strict mode. See: https://github.github.com/gh-aw/reference/sandbox/")
+		return c.applyStrictRule(StrictRuleNetwork, errors.New("strict mode: 'sandbox.agent: false' is not allowed because it disables the agent sandbox firewall. This removes important security protections. Remove 'sandbox.agent: false' or set 'strict: false' to disable strict mode. See: https://github.github.com/gh-aw/reference/sandbox/"))
 	}
 
 	// In strict mode, suggest using ecosystem identifiers for domains that belong to known ecosystems
@@ -527,7 +522,7 @@ func (c *Compiler) validateStrictFirewall(engineID string, networkPermissions *N
 	// In strict mode, firewall MUST be enabled
 	if networkPermissions.Firewall == nil || !networkPermissions.Firewall.Enabled {
 		strictModeValidationLog.Printf("Firewall validation failed: firewall not enabled in strict mode")
-		return fmt.Errorf("strict mode: firewall must be enabled for %s engine with network restrictions. The firewall should be enabled by default, but if you've explicitly disabled it with 'network.firewall: false' or 'sandbox.agent: false', this is not allowed in strict mode for security reasons. See: https://github.github.com/gh-aw/reference/network/", engineID)
+		return c.applyStrictRule(StrictRuleNetwork, fmt.Errorf("strict mode: firewall must be enabled for %s engine with network restrictions. The firewall should be enabled by default, but if you've explicitly disabled it with 'network.firewall: false' or 'sandbox.agent: false', this is not allowed in strict mode for security reasons. See: https://github.github.com/gh-aw/reference/network/", engineID))
 	}
 
 	strictModeValidationLog.Printf("Firewall validation passed")
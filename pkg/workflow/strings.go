@@ -76,6 +76,8 @@
 package workflow
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"regexp"
 	"slices"
 	"strings"
@@ -285,3 +287,48 @@ func GenerateHeredocDelimiter(name string) string {
 	}
 	return "GH_AW_" + strings.ToUpper(name) + "_EOF"
 }
+
+// GenerateContentHeredocDelimiter returns a heredoc delimiter that is safe to use for the given content.
+// It returns the same value as GenerateHeredocDelimiter unless a line of the content equals that
+// delimiter, in which case the delimiter is seeded from a hash of the content so that the heredoc
+// cannot be terminated early. Because the suffix is derived from the content rather than chosen at
+// random, compiling the same workflow always yields the same lock file.
+//
+// Example:
+//
+//	GenerateContentHeredocDelimiter("PROMPT", "hello")             // returns "GH_AW_PROMPT_EOF"
+//	GenerateContentHeredocDelimiter("PROMPT", "x\nGH_AW_PROMPT_EOF") // returns "GH_AW_PROMPT_<hash>_EOF"
+func GenerateContentHeredocDelimiter(name string, contents ...string) string {
+	delimiter := GenerateHeredocDelimiter(name)
+	if !heredocContentContainsLine(contents, delimiter) {
+		return delimiter
+	}
+
+	prefix := strings.TrimSuffix(delimiter, "_EOF")
+	hash := sha256.New()
+	for _, content := range contents {
+		hash.Write([]byte(content))
+		hash.Write([]byte{0})
+	}
+	for {
+		sum := hash.Sum(nil)
+		delimiter = prefix + "_" + strings.ToUpper(hex.EncodeToString(sum[:4])) + "_EOF"
+		if !heredocContentContainsLine(contents, delimiter) {
+			stringsLog.Printf("Content collides with default heredoc delimiter for %s, using %s", name, delimiter)
+			return delimiter
+		}
+		hash.Write(sum)
+	}
+}
+
+// heredocContentContainsLine reports whether any line of the contents equals the delimiter
+func heredocContentContainsLine(contents []string, delimiter string) bool {
+	for _, content := range contents {
+		for line := range strings.SplitSeq(content, "\n") {
+			if strings.TrimSpace(line) == delimiter {
+				return true
+			}
+		}
+	}
+	return false
+}
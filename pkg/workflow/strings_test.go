@@ -634,3 +634,24 @@ func TestGenerateHeredocDelimiter_Consistency(t *testing.T) {
 	assert.Equal(t, result2, result3, "GenerateHeredocDelimiter should be consistent")
 	assert.Equal(t, "GH_AW_CONSISTENT_TEST_EOF", result1)
 }
+
+func TestGenerateContentHeredocDelimiter(t *testing.T) {
+	t.Run("default delimiter when content does not collide", func(t *testing.T) {
+		delimiter := GenerateContentHeredocDelimiter("PROMPT", "hello\nworld", "GH_AW_PROMPT_EOF is mentioned inline")
+		assert.Equal(t, "GH_AW_PROMPT_EOF", delimiter, "non-colliding content should keep the default delimiter")
+	})
+
+	t.Run("hash-seeded delimiter when a line collides", func(t *testing.T) {
+		content := "before\n  GH_AW_PROMPT_EOF\nafter"
+		delimiter := GenerateContentHeredocDelimiter("PROMPT", content)
+		assert.NotEqual(t, "GH_AW_PROMPT_EOF", delimiter, "colliding content should change the delimiter")
+		assert.Regexp(t, `^GH_AW_PROMPT_[0-9A-F]{8}_EOF$`, delimiter, "delimiter should embed a content hash")
+		assert.Equal(t, delimiter, GenerateContentHeredocDelimiter("PROMPT", content), "delimiter should be stable for the same content")
+		assert.NotEqual(t, delimiter, GenerateContentHeredocDelimiter("PROMPT", content+"\nmore"), "delimiter should depend on the content")
+	})
+
+	t.Run("content split across chunks", func(t *testing.T) {
+		delimiter := GenerateContentHeredocDelimiter("PROMPT", "first chunk", "GH_AW_PROMPT_EOF")
+		assert.NotEqual(t, "GH_AW_PROMPT_EOF", delimiter, "collision in any chunk should change the delimiter")
+	})
+}
@@ -0,0 +1,61 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var summaryCommentLog = logger.New("workflow:summary_comment")
+
+// parseAgentLogsStepID is the ID of the agent log parsing step, whose outputs
+// (final_summary, token_usage) feed the run summary comment
+const parseAgentLogsStepID = "parse-agent-logs"
+
+// buildSummaryCommentAgentOutputs returns the agent job outputs consumed by the summary comment step
+func buildSummaryCommentAgentOutputs(data *WorkflowData) map[string]string {
+	if data.SafeOutputs == nil || !data.SafeOutputs.SummaryComment {
+		return nil
+	}
+	return map[string]string{
+		"final_summary": fmt.Sprintf("${{ steps.%s.outputs.final_summary }}", parseAgentLogsStepID),
+		"token_usage":   fmt.Sprintf("${{ steps.%s.outputs.token_usage }}", parseAgentLogsStepID),
+	}
+}
+
+// buildSummaryCommentStep generates the conclusion job step that posts (or updates) a sticky
+// comment on the triggering issue or pull request summarizing the run (safe-outputs.summary-comment).
+// The agent's final message is untrusted: the script sanitizes it before posting and, when threat
+// detection is enabled, the step only runs after detection passed.
+func (c *Compiler) buildSummaryCommentStep(data *WorkflowData, mainJobName string) []string {
+	if data.SafeOutputs == nil || !data.SafeOutputs.SummaryComment {
+		return nil
+	}
+
+	summaryCommentLog.Printf("Building summary comment step for workflow %s", data.WorkflowID)
+
+	var envVars []string
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_WORKFLOW_NAME: %q\n", data.Name))
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_WORKFLOW_ID: %q\n", data.WorkflowID))
+	envVars = append(envVars, "          GH_AW_RUN_URL: ${{ github.server_url }}/${{ github.repository }}/actions/runs/${{ github.run_id }}\n")
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_AGENT_CONCLUSION: ${{ needs.%s.result }}\n", mainJobName))
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_AGENT_SUMMARY: ${{ needs.%s.outputs.final_summary }}\n", mainJobName))
+	envVars = append(envVars, fmt.Sprintf("          GH_AW_TOKEN_USAGE: ${{ needs.%s.outputs.token_usage }}\n", mainJobName))
+
+	steps := c.buildGitHubScriptStepWithoutDownload(data, GitHubScriptStepConfig{
+		StepName:      "Post run summary comment",
+		StepID:        "summary_comment",
+		MainJobName:   mainJobName,
+		CustomEnvVars: envVars,
+		Script:        "const { main } = require('/opt/gh-aw/actions/summary_comment.cjs'); await main();",
+		ScriptFile:    "summary_comment.cjs",
+		CustomToken:   "", // Uses the safe outputs token chain
+	})
+
+	if data.SafeOutputs.ThreatDetection != nil && len(steps) > 2 {
+		// Insert the condition after the name and id lines
+		condition := fmt.Sprintf("        if: %s\n", buildDetectionSuccessCondition().Render())
+		steps = append(steps[:2], append([]string{condition}, steps[2:]...)...)
+	}
+	return steps
+}
@@ -0,0 +1,103 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryCommentConclusionStep(t *testing.T) {
+	tests := []struct {
+		name           string
+		summaryComment bool
+		expectStep     bool
+	}{
+		{
+			name:           "summary comment enabled",
+			summaryComment: true,
+			expectStep:     true,
+		},
+		{
+			name:           "summary comment disabled by default",
+			summaryComment: false,
+			expectStep:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			workflowData := &WorkflowData{
+				Name:       "Test Workflow",
+				WorkflowID: "test-workflow",
+				SafeOutputs: &SafeOutputsConfig{
+					MissingTool:    &MissingToolConfig{},
+					SummaryComment: tt.summaryComment,
+				},
+			}
+
+			job, err := compiler.buildConclusionJob(workflowData, string(constants.AgentJobName), []string{"missing_tool"})
+			require.NoError(t, err, "Conclusion job should build")
+			require.NotNil(t, job, "Conclusion job should be created")
+
+			steps := strings.Join(job.Steps, "")
+			if !tt.expectStep {
+				assert.NotContains(t, steps, "summary_comment.cjs", "Summary comment step should not be generated")
+				assert.NotContains(t, job.Permissions, "issues: write", "Conclusion job should not gain issues write")
+				return
+			}
+
+			assert.Contains(t, steps, "name: Post run summary comment", "Summary comment step should be generated")
+			assert.Contains(t, steps, "GH_AW_AGENT_SUMMARY: ${{ needs.agent.outputs.final_summary }}", "Step should read the final summary")
+			assert.Contains(t, steps, "GH_AW_TOKEN_USAGE: ${{ needs.agent.outputs.token_usage }}", "Step should read token usage")
+			assert.Contains(t, steps, `GH_AW_WORKFLOW_ID: "test-workflow"`, "Step should pass the workflow ID for the sticky marker")
+			assert.Contains(t, job.Permissions, "issues: write", "Conclusion job needs issues write")
+			assert.Contains(t, job.Permissions, "pull-requests: write", "Conclusion job needs pull-requests write")
+		})
+	}
+}
+
+func TestSummaryCommentGatedOnThreatDetection(t *testing.T) {
+	compiler := NewCompiler()
+	workflowData := &WorkflowData{
+		Name:       "Test Workflow",
+		WorkflowID: "test-workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			MissingTool:     &MissingToolConfig{},
+			SummaryComment:  true,
+			ThreatDetection: &ThreatDetectionConfig{},
+		},
+	}
+
+	steps := strings.Join(compiler.buildSummaryCommentStep(workflowData, string(constants.AgentJobName)), "")
+	assert.Contains(t, steps, "        id: summary_comment\n        if: needs.agent.outputs.detection_success == 'true'\n",
+		"Summary comment should only be posted after threat detection passed")
+
+	workflowData.SafeOutputs.ThreatDetection = nil
+	steps = strings.Join(compiler.buildSummaryCommentStep(workflowData, string(constants.AgentJobName)), "")
+	assert.NotContains(t, steps, "detection_success", "Summary comment should not be gated without threat detection")
+}
+
+func TestBuildSummaryCommentAgentOutputs(t *testing.T) {
+	assert.Nil(t, buildSummaryCommentAgentOutputs(&WorkflowData{SafeOutputs: &SafeOutputsConfig{}}), "No outputs without summary-comment")
+
+	outputs := buildSummaryCommentAgentOutputs(&WorkflowData{SafeOutputs: &SafeOutputsConfig{SummaryComment: true}})
+	assert.Equal(t, "${{ steps.parse-agent-logs.outputs.final_summary }}", outputs["final_summary"], "final_summary should come from the log parser")
+	assert.Equal(t, "${{ steps.parse-agent-logs.outputs.token_usage }}", outputs["token_usage"], "token_usage should come from the log parser")
+}
+
+func TestSummaryCommentConfigParsing(t *testing.T) {
+	compiler := NewCompiler()
+	config := compiler.extractSafeOutputsConfig(map[string]any{
+		"safe-outputs": map[string]any{
+			"summary-comment": true,
+		},
+	})
+	require.NotNil(t, config, "Safe outputs config should be extracted")
+	assert.True(t, config.SummaryComment, "summary-comment should be parsed")
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -93,8 +94,11 @@ func (c *Compiler) generateInterpolationAndTemplateStep(yaml *strings.Builder, e
 	hasGitHubContext := hasGitHubTool(data.ParsedTools)
 	hasTemplates := hasTemplatePattern || hasGitHubContext
 
+	// Check if the prompt has per-event trigger sections to select at runtime
+	hasTriggerSections := len(findTriggerSections(data.MarkdownContent)) > 0
+
 	// Skip if neither interpolation nor template rendering is needed
-	if !hasExpressions && !hasTemplates {
+	if !hasExpressions && !hasTemplates && !hasTriggerSections {
 		templateLog.Print("No interpolation or template rendering needed, skipping step generation")
 		return
 	}
@@ -106,6 +110,18 @@ func (c *Compiler) generateInterpolationAndTemplateStep(yaml *strings.Builder, e
 	fmt.Fprintf(yaml, "        uses: %s\n", GetActionPin("actions/github-script"))
 	yaml.WriteString("        env:\n")
 	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	// Keep only the trigger sections of the event that triggered the run
+	if hasTriggerSections {
+		yaml.WriteString("          GH_AW_TRIGGER_SECTIONS: \"true\"\n")
+	}
+	// Runtime imports name their placeholders with the same prefix as the compiler
+	if data.Expressions.hasCustomPrefix() {
+		fmt.Fprintf(yaml, "          GH_AW_EXPRESSION_PREFIX: %s\n", data.Expressions.envPrefix())
+	}
+	// Runtime imports evaluate github.event expressions against the event of the upstream run
+	if data.WorkflowNeeds != nil {
+		fmt.Fprintf(yaml, "          GH_AW_UPSTREAM_PAYLOAD: ${{ needs.%s.outputs.%s }}\n", constants.PreActivationJobName, constants.UpstreamPayloadOutput)
+	}
 
 	// Add environment variables for extracted expressions (deduplicated by EnvVar)
 	seen := make(map[string]bool)
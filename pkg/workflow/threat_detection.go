@@ -125,7 +125,7 @@ func (c *Compiler) buildInlineDetectionSteps(data *WorkflowData) []string {
 	steps = append(steps, c.buildDetectionGuardStep()...)
 
 	// Step 2: Clear MCP configuration files so the detection engine runs without MCP servers
-	steps = append(steps, c.buildClearMCPConfigStep()...)
+	steps = append(steps, c.buildClearMCPConfigStep(data)...)
 
 	// Step 3: Prepare files - copies agent output files to expected paths
 	steps = append(steps, c.buildPrepareDetectionFilesStep()...)
@@ -178,13 +178,13 @@ func (c *Compiler) buildDetectionGuardStep() []string {
 // buildClearMCPConfigStep creates a step that removes MCP configuration files written by
 // the main agent job. This ensures the detection engine runs without any MCP servers,
 // even if the main agent had MCP servers configured.
-func (c *Compiler) buildClearMCPConfigStep() []string {
+func (c *Compiler) buildClearMCPConfigStep(data *WorkflowData) []string {
 	return []string{
 		"      - name: Clear MCP configuration for detection\n",
 		fmt.Sprintf("        if: %s\n", detectionStepCondition),
 		"        run: |\n",
 		"          rm -f /tmp/gh-aw/mcp-config/mcp-servers.json\n",
-		"          rm -f /home/runner/.copilot/mcp-config.json\n",
+		"          rm -f " + runnerHomeDir(data) + "/.copilot/mcp-config.json\n",
 		"          rm -f \"$GITHUB_WORKSPACE/.gemini/settings.json\"\n",
 	}
 }
@@ -0,0 +1,202 @@
+// This file provides the engine-agnostic tool vocabulary for agentic workflows.
+//
+// Engines name their built-in tools differently (Bash vs run_shell_command vs
+// shell(...), Edit vs write_file, ...). Workflows can declare built-in tools with
+// neutral names that the compiler maps onto the canonical tool keys consumed by
+// each engine's tool configuration (claude_tools.go, copilot_engine_tools.go,
+// gemini_tools.go):
+//
+//	tools:
+//	  shell:
+//	    allowed: [git, npm]   # → bash: [git, npm]
+//	  file-edit: true         # → edit:
+//	  web-search: true        # → web-search:
+//	  web-fetch: true         # → web-fetch:
+//
+// Unlike the engine-flavoured keys, the neutral vocabulary is strict: when the
+// selected engine has no equivalent for a neutral tool, compilation fails instead
+// of silently dropping the tool.
+
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var toolAliasesLog = logger.New("workflow:tool_aliases")
+
+// toolAliases maps neutral tool names to the canonical tool keys
+var toolAliases = map[string]string{
+	"shell":     "bash",
+	"file-edit": "edit",
+}
+
+// neutralBooleanTools are canonical tools that accept `true`/`false` in the neutral vocabulary
+var neutralBooleanTools = []string{"edit", "web-fetch", "web-search"}
+
+// collectNeutralTools returns the canonical names of tools declared with the neutral
+// vocabulary (aliases or boolean values), sorted for deterministic error messages.
+func collectNeutralTools(tools map[string]any) []string {
+	var neutral []string
+	for alias, canonical := range toolAliases {
+		if _, exists := tools[alias]; exists {
+			neutral = append(neutral, canonical)
+		}
+	}
+	for _, name := range neutralBooleanTools {
+		if _, isBool := tools[name].(bool); isBool && !slices.Contains(neutral, name) {
+			neutral = append(neutral, name)
+		}
+	}
+	sort.Strings(neutral)
+	return neutral
+}
+
+// normalizeToolAliases rewrites neutral tool names into their canonical keys.
+// When both a neutral name and its canonical key are present, their configurations
+// are merged (for bash, the allowed commands are combined).
+func normalizeToolAliases(tools map[string]any) (map[string]any, error) {
+	if len(collectNeutralTools(tools)) == 0 {
+		return tools, nil
+	}
+
+	aliases := make([]string, 0, len(toolAliases))
+	for alias := range toolAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		value, exists := tools[alias]
+		if !exists {
+			continue
+		}
+		canonical := toolAliases[alias]
+		delete(tools, alias)
+
+		// file-edit: false simply leaves the tool disabled
+		if enabled, isBool := value.(bool); isBool && !enabled && canonical != "bash" {
+			continue
+		}
+
+		converted, err := convertNeutralToolValue(alias, canonical, value)
+		if err != nil {
+			return nil, err
+		}
+		if existing, hasCanonical := tools[canonical]; hasCanonical && canonical == "bash" {
+			converted = mergeBashToolValues(existing, converted)
+		}
+		toolAliasesLog.Printf("Mapped neutral tool %s to %s", alias, canonical)
+		tools[canonical] = converted
+	}
+
+	// Boolean form for tools whose canonical syntax is null/object
+	for _, name := range neutralBooleanTools {
+		enabled, isBool := tools[name].(bool)
+		if !isBool {
+			continue
+		}
+		if enabled {
+			tools[name] = nil
+		} else {
+			delete(tools, name)
+		}
+	}
+
+	return tools, nil
+}
+
+// convertNeutralToolValue converts the value of a neutral tool into the canonical tool syntax
+func convertNeutralToolValue(alias, canonical string, value any) (any, error) {
+	if canonical != "bash" {
+		// file-edit: true/null/{} → edit:
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return true, nil
+	case bool, []any:
+		return v, nil
+	case map[string]any:
+		allowed, hasAllowed := v["allowed"]
+		if !hasAllowed {
+			return true, nil
+		}
+		commands, ok := allowed.([]any)
+		if !ok {
+			return nil, fmt.Errorf("tools.%s.allowed must be an array of commands, got %T. Example:\ntools:\n  %s:\n    allowed: [git, npm]", alias, allowed, alias)
+		}
+		for _, cmd := range commands {
+			if _, isString := cmd.(string); !isString {
+				return nil, fmt.Errorf("tools.%s.allowed must only contain strings, got %T", alias, cmd)
+			}
+		}
+		return commands, nil
+	default:
+		return nil, fmt.Errorf("tools.%s must be true, an array of commands, or an object with 'allowed', got %T", alias, value)
+	}
+}
+
+// mergeBashToolValues combines two bash tool values, keeping the least restrictive setting
+func mergeBashToolValues(a, b any) any {
+	aCommands, aIsList := a.([]any)
+	bCommands, bIsList := b.([]any)
+	if aIsList && bIsList {
+		merged := slices.Clone(aCommands)
+		for _, cmd := range bCommands {
+			if !slices.Contains(merged, cmd) {
+				merged = append(merged, cmd)
+			}
+		}
+		return merged
+	}
+
+	// A disabled tool (false) never widens the other side
+	if enabled, isBool := a.(bool); isBool && !enabled {
+		return b
+	}
+	if enabled, isBool := b.(bool); isBool && !enabled {
+		return a
+	}
+
+	// One side allows all commands
+	return true
+}
+
+// bashHasCommandRestrictions reports whether the bash tool is limited to specific commands
+func bashHasCommandRestrictions(value any) bool {
+	commands, ok := value.([]any)
+	if !ok || len(commands) == 0 {
+		return false
+	}
+	for _, cmd := range commands {
+		if cmdStr, ok := cmd.(string); ok && (cmdStr == "*" || cmdStr == ":*") {
+			return false
+		}
+	}
+	return true
+}
+
+// validateNeutralToolSupport validates that the engine has an equivalent for every
+// tool declared with the neutral vocabulary.
+func validateNeutralToolSupport(neutralTools []string, tools map[string]any, engine CodingAgentEngine) error {
+	for _, name := range neutralTools {
+		switch name {
+		case "bash":
+			if bashHasCommandRestrictions(tools["bash"]) && !engine.SupportsBashAllowlist() {
+				return fmt.Errorf("tools.shell: engine '%s' cannot restrict shell access to specific commands. Use 'shell: true' or choose an engine with shell allow-listing (claude, copilot, gemini)", engine.GetID())
+			}
+		case "web-search":
+			if !engine.SupportsWebSearch() {
+				return fmt.Errorf("tools.web-search: engine '%s' has no built-in web search. See https://github.github.com/gh-aw/guides/web-search/ for MCP-based alternatives", engine.GetID())
+			}
+		}
+		// edit and web-fetch are available on every engine (web-fetch falls back to the MCP fetch server)
+	}
+	return nil
+}
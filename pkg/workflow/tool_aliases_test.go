@@ -0,0 +1,131 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeToolAliases(t *testing.T) {
+	tests := []struct {
+		name     string
+		tools    map[string]any
+		expected map[string]any
+		neutral  []string
+		wantErr  string
+	}{
+		{
+			name:     "canonical tools are unchanged",
+			tools:    map[string]any{"bash": []any{"echo"}, "edit": nil},
+			expected: map[string]any{"bash": []any{"echo"}, "edit": nil},
+		},
+		{
+			name:     "shell with allowed commands maps to bash list",
+			tools:    map[string]any{"shell": map[string]any{"allowed": []any{"git", "npm"}}},
+			expected: map[string]any{"bash": []any{"git", "npm"}},
+			neutral:  []string{"bash"},
+		},
+		{
+			name:     "shell true allows all commands",
+			tools:    map[string]any{"shell": true},
+			expected: map[string]any{"bash": true},
+			neutral:  []string{"bash"},
+		},
+		{
+			name:     "shell merges with bash commands",
+			tools:    map[string]any{"shell": []any{"git"}, "bash": []any{"echo", "git"}},
+			expected: map[string]any{"bash": []any{"echo", "git"}},
+			neutral:  []string{"bash"},
+		},
+		{
+			name:     "file-edit and boolean web tools map to canonical keys",
+			tools:    map[string]any{"file-edit": true, "web-search": true, "web-fetch": false},
+			expected: map[string]any{"edit": nil, "web-search": nil},
+			neutral:  []string{"edit", "web-fetch", "web-search"},
+		},
+		{
+			name:    "shell allowed must be an array",
+			tools:   map[string]any{"shell": map[string]any{"allowed": "git"}},
+			neutral: []string{"bash"},
+			wantErr: "tools.shell.allowed must be an array",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.neutral, collectNeutralTools(tt.tools), "Neutral tools should be detected")
+
+			result, err := normalizeToolAliases(tt.tools)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected normalization error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error should explain the invalid configuration")
+				return
+			}
+			require.NoError(t, err, "Normalization should succeed")
+			assert.Equal(t, tt.expected, result, "Tools should use canonical keys")
+		})
+	}
+}
+
+func TestValidateNeutralToolSupport(t *testing.T) {
+	tests := []struct {
+		name    string
+		engine  CodingAgentEngine
+		neutral []string
+		tools   map[string]any
+		wantErr string
+	}{
+		{
+			name:    "shell allow-list on copilot",
+			engine:  NewCopilotEngine(),
+			neutral: []string{"bash"},
+			tools:   map[string]any{"bash": []any{"git", "npm"}},
+		},
+		{
+			name:    "shell allow-list on codex",
+			engine:  NewCodexEngine(),
+			neutral: []string{"bash"},
+			tools:   map[string]any{"bash": []any{"git", "npm"}},
+			wantErr: "engine 'codex' cannot restrict shell access",
+		},
+		{
+			name:    "unrestricted shell on codex",
+			engine:  NewCodexEngine(),
+			neutral: []string{"bash"},
+			tools:   map[string]any{"bash": true},
+		},
+		{
+			name:    "web-search on claude",
+			engine:  NewClaudeEngine(),
+			neutral: []string{"web-search"},
+			tools:   map[string]any{"web-search": nil},
+		},
+		{
+			name:    "web-search on gemini",
+			engine:  NewGeminiEngine(),
+			neutral: []string{"web-search"},
+			tools:   map[string]any{"web-search": nil},
+			wantErr: "engine 'gemini' has no built-in web search",
+		},
+		{
+			name:   "canonical web-search is not checked",
+			engine: NewCopilotEngine(),
+			tools:  map[string]any{"web-search": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNeutralToolSupport(tt.neutral, tt.tools, tt.engine)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected unsupported tool error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error should name the engine")
+				return
+			}
+			assert.NoError(t, err, "Tool should be supported")
+		})
+	}
+}
@@ -84,6 +84,9 @@ func enhanceToolDescription(toolName, baseDescription string, safeOutputs *SafeO
 			if config.Category != "" {
 				constraints = append(constraints, fmt.Sprintf("Discussions will be created in category %q.", config.Category))
 			}
+			if len(config.AllowedCategories) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Only these categories are allowed: %v.", config.AllowedCategories))
+			}
 			if len(config.AllowedLabels) > 0 {
 				constraints = append(constraints, fmt.Sprintf("Only these labels are allowed: %s.", formatLabelList(config.AllowedLabels)))
 			}
@@ -105,6 +108,19 @@ func enhanceToolDescription(toolName, baseDescription string, safeOutputs *SafeO
 			}
 		}
 
+	case "answer_discussion":
+		if config := safeOutputs.AnswerDiscussion; config != nil {
+			if templatableIntValue(config.Max) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d discussion(s) can be answered.", templatableIntValue(config.Max)))
+			}
+			if config.Target != "" {
+				constraints = append(constraints, fmt.Sprintf("Target: %s.", config.Target))
+			}
+			if len(config.AllowedCategories) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Only discussions in these categories can be answered: %v.", config.AllowedCategories))
+			}
+		}
+
 	case "close_issue":
 		if config := safeOutputs.CloseIssues; config != nil {
 			if templatableIntValue(config.Max) > 0 {
@@ -241,6 +257,42 @@ func enhanceToolDescription(toolName, baseDescription string, safeOutputs *SafeO
 			}
 		}
 
+	case "request_review":
+		if config := safeOutputs.RequestReview; config != nil {
+			if templatableIntValue(config.Max) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d reviewer(s) can be requested.", templatableIntValue(config.Max)))
+			}
+			if config.Target != "" {
+				constraints = append(constraints, fmt.Sprintf("Target: %s.", config.Target))
+			}
+			if len(config.AllowedUsers) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Only these users can be requested: %v.", config.AllowedUsers))
+			}
+			if len(config.AllowedTeams) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Only these teams can be requested: %v.", config.AllowedTeams))
+			} else {
+				constraints = append(constraints, "Team reviews are not allowed.")
+			}
+		}
+
+	case "assign":
+		if config := safeOutputs.Assign; config != nil {
+			if templatableIntValue(config.Max) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d item(s) can be assigned.", templatableIntValue(config.Max)))
+			}
+			if config.Target != "" {
+				constraints = append(constraints, fmt.Sprintf("Target: %s.", config.Target))
+			}
+			if len(config.AllowedUsers) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Only these users can be assigned: %v.", config.AllowedUsers))
+			}
+			if len(config.AllowedTeams) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Only members of these teams can be assigned through teams: %v.", config.AllowedTeams))
+			} else {
+				constraints = append(constraints, "Teams cannot be assigned.")
+			}
+		}
+
 	case "update_issue":
 		if config := safeOutputs.UpdateIssues; config != nil {
 			if templatableIntValue(config.Max) > 0 {
@@ -261,6 +313,12 @@ func enhanceToolDescription(toolName, baseDescription string, safeOutputs *SafeO
 			if config.Status != nil && *config.Status {
 				constraints = append(constraints, "Status updates (open/closed) are allowed.")
 			}
+			if fields := config.AllowedFields(); len(fields) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Only these fields can be updated: %s. Other fields are ignored.", strings.Join(fields, ", ")))
+			}
+			if len(config.AllowedLabels) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Only these labels can be added or removed: %s. Other labels on the issue are kept.", formatLabelList(config.AllowedLabels)))
+			}
 		}
 
 	case "update_pull_request":
@@ -156,14 +156,17 @@ func (c *Compiler) applyDefaults(data *WorkflowData, markdownPath string) error
 	}
 	// Apply default tools
 	data.Tools = c.applyDefaultTools(data.Tools, data.SafeOutputs, data.SandboxConfig, data.NetworkPermissions)
+	// Use the auth: GitHub App wherever no explicit token or app is configured
+	applyAuthGitHubApp(data)
 	// Update ParsedTools to reflect changes made by applyDefaultTools
 	data.ParsedTools = NewTools(data.Tools)
 
 	// Check if permissions is explicitly empty ({}) - this means user wants no permissions
 	// In this case, we should NOT apply default read-all.
-	// Exception: if copilot-requests feature is enabled, we still need to fall through
-	// so the injection block below can add copilot-requests: write.
-	if data.Permissions == "permissions: {}" && !isFeatureEnabled(constants.CopilotRequestsFeatureFlag, data) {
+	// Exception: if copilot-requests feature is enabled or the github-models engine is used,
+	// we still need to fall through so the injection blocks below can add their permission.
+	usesGitHubModels := usesGitHubModelsEngine(data)
+	if data.Permissions == "permissions: {}" && !isFeatureEnabled(constants.CopilotRequestsFeatureFlag, data) && !usesGitHubModels {
 		// Explicitly empty permissions - preserve the empty state
 		// The agent job in dev mode will add contents: read if needed for local actions
 		return nil
@@ -207,9 +210,36 @@ func (c *Compiler) applyDefaults(data *WorkflowData, markdownPath string) error
 		data.Permissions = strings.Join(lines, "\n")
 	}
 
+	// When the github-models engine is used, inject models: read permission.
+	// The engine authenticates with the GitHub Models API using the GitHub Actions token.
+	if usesGitHubModels {
+		perms := NewPermissionsParser(data.Permissions).ToPermissions()
+		if level, ok := perms.Get(PermissionModels); !ok || level == PermissionNone {
+			perms.Set(PermissionModels, PermissionRead)
+		}
+		yaml := perms.RenderToYAML()
+		// Adjust from job-level indentation (6 spaces) to workflow-level (2 spaces)
+		lines := strings.Split(yaml, "\n")
+		for i := 1; i < len(lines); i++ {
+			if strings.HasPrefix(lines[i], "      ") {
+				lines[i] = "  " + lines[i][6:]
+			}
+		}
+		data.Permissions = strings.Join(lines, "\n")
+	}
+
 	return nil
 }
 
+// usesGitHubModelsEngine reports whether the workflow runs on the github-models engine
+func usesGitHubModelsEngine(data *WorkflowData) bool {
+	engineID := data.AI
+	if data.EngineConfig != nil && data.EngineConfig.ID != "" {
+		engineID = data.EngineConfig.ID
+	}
+	return engineID == string(constants.GitHubModelsEngine)
+}
+
 // mergeToolsAndMCPServers merges tools, mcp-servers, and included tools
 func (c *Compiler) mergeToolsAndMCPServers(topTools, mcpServers map[string]any, includedTools string) (map[string]any, error) {
 	toolsLog.Printf("Merging tools and MCP servers: topTools=%d, mcpServers=%d", len(topTools), len(mcpServers))
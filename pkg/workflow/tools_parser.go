@@ -410,8 +410,8 @@ func parseWebFetchTool(val any) *WebFetchToolConfig {
 
 // parseWebSearchTool converts raw web-search tool configuration
 func parseWebSearchTool(val any) *WebSearchToolConfig {
-	// web-search is either nil or an empty object
-	return &WebSearchToolConfig{}
+	// web-search is either nil or an object with optional allowed-domains
+	return &WebSearchToolConfig{AllowedDomains: webSearchAllowedDomains(map[string]any{"web-search": val})}
 }
 
 // parseEditTool converts raw edit tool configuration
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"maps"
+	"slices"
 
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/types"
@@ -336,7 +337,7 @@ type WebFetchToolConfig struct {
 
 // WebSearchToolConfig represents the configuration for the web-search tool
 type WebSearchToolConfig struct {
-	// Currently an empty object or nil
+	AllowedDomains []string `yaml:"allowed-domains,omitempty"` // Domains the agent may search and browse (empty = network allow-list)
 }
 
 // EditToolConfig represents the configuration for the edit tool
@@ -483,8 +484,8 @@ func (t *Tools) GetToolNames() []string {
 		names = append(names, "startup-timeout")
 	}
 
-	// Add custom tools
-	for name := range t.Custom {
+	// Add custom tools in name order so callers see a stable list
+	for _, name := range slices.Sorted(maps.Keys(t.Custom)) {
 		names = append(names, name)
 	}
 
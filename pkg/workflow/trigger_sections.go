@@ -0,0 +1,143 @@
+// This file provides trigger sections: per-event parts of a workflow prompt.
+//
+// # Trigger Sections
+//
+// A workflow with several triggers can give each event its own instructions by
+// starting a section with a heading of the form "## on: <event>[, <event>...]":
+//
+//	Shared instructions for every run.
+//
+//	## on: issues
+//
+//	Label the new issue.
+//
+//	## on: schedule, workflow_dispatch
+//
+//	Review the open issues.
+//
+// A trigger section ends at the next heading of the same or a higher level. When
+// the prompt is built, the activation job keeps the sections of the event that
+// triggered the run, drops the others, and removes the "on:" headings themselves
+// (see trigger_sections.cjs). Content outside trigger sections is always kept.
+//
+// # Validation Functions
+//
+//   - validateTriggerSections() - Checks that every section names a trigger of the workflow
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var triggerSectionsLog = newValidationLogger("trigger_sections")
+
+var (
+	// triggerSectionHeadingPattern matches "## on: issues, schedule" headings, capturing the marks and events
+	triggerSectionHeadingPattern = regexp.MustCompile(`(?i)^ {0,3}(#{1,6})[ \t]+on:[ \t]*(.+?)[ \t]*#*[ \t]*$`)
+	// headingMarksPattern matches the marks of any ATX heading
+	headingMarksPattern = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+`)
+)
+
+// triggerSection is a trigger section heading found in a prompt
+type triggerSection struct {
+	Heading string   // The heading line, e.g. "## on: issues"
+	Events  []string // The events named by the heading
+}
+
+// scanTriggerSections walks the markdown line by line and returns the trigger sections it
+// contains together with the markdown reduced to the sections for which keep returns true.
+// Trigger headings are removed along with a blank line that follows them. Headings inside
+// fenced code blocks and trigger headings nested in a section are ignored.
+func scanTriggerSections(markdown string, keep func(events []string) bool) (string, []triggerSection) {
+	var sections []triggerSection
+	var result []string
+	inFence := false
+	inSection := false
+	keepSection := false
+	sectionLevel := 0
+	afterHeading := false
+
+	for line := range strings.SplitSeq(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		// Drop the blank line after a removed trigger heading along with the heading
+		if afterHeading {
+			afterHeading = false
+			if trimmed == "" {
+				continue
+			}
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		} else if !inFence {
+			if heading := headingMarksPattern.FindStringSubmatch(line); heading != nil && inSection && len(heading[1]) <= sectionLevel {
+				inSection = false
+			}
+			if match := triggerSectionHeadingPattern.FindStringSubmatch(line); match != nil && !inSection {
+				var events []string
+				for event := range strings.SplitSeq(match[2], ",") {
+					events = append(events, strings.TrimSpace(event))
+				}
+				sections = append(sections, triggerSection{Heading: trimmed, Events: events})
+				inSection = true
+				keepSection = keep(events)
+				sectionLevel = len(match[1])
+				afterHeading = true
+				continue
+			}
+		}
+		if !inSection || keepSection {
+			result = append(result, line)
+		}
+	}
+
+	return strings.Join(result, "\n"), sections
+}
+
+// findTriggerSections returns the trigger sections of a prompt
+func findTriggerSections(markdown string) []triggerSection {
+	_, sections := scanTriggerSections(markdown, func([]string) bool { return true })
+	return sections
+}
+
+// filterTriggerSections keeps the trigger sections of eventName and removes the others,
+// matching filterTriggerSections in trigger_sections.cjs
+func filterTriggerSections(markdown, eventName string) string {
+	filtered, _ := scanTriggerSections(markdown, func(events []string) bool { return slices.Contains(events, eventName) })
+	return filtered
+}
+
+// validateTriggerSections checks that every event named by a trigger section is one of the
+// workflow's triggers, since a section for any other event would never be part of the prompt
+func (c *Compiler) validateTriggerSections(workflowData *WorkflowData, markdownPath string) error {
+	sections := findTriggerSections(workflowData.MarkdownContent)
+	if len(sections) == 0 {
+		return nil
+	}
+	triggers := extractTriggerEvents(workflowData.On)
+	if len(triggers) == 0 {
+		triggerSectionsLog.Print("Could not determine the workflow triggers, skipping trigger section validation")
+		return nil
+	}
+	triggerSectionsLog.Printf("Validating %d trigger sections against triggers: %v", len(sections), triggers)
+
+	for _, section := range sections {
+		for _, event := range section.Events {
+			if slices.Contains(triggers, event) {
+				continue
+			}
+			message := fmt.Sprintf("trigger section '%s' names event '%s', which does not trigger this workflow (triggers: %s)",
+				section.Heading, event, strings.Join(triggers, ", "))
+			if suggestions := parser.FindClosestMatches(event, triggers, 1); len(suggestions) > 0 {
+				message += fmt.Sprintf(". Did you mean '%s'?", suggestions[0])
+			}
+			return formatCompilerError(markdownPath, "error", message, nil)
+		}
+	}
+	return nil
+}
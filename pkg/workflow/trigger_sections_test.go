@@ -0,0 +1,93 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const triggerSectionsPrompt = `# Triage
+
+Shared instructions.
+
+## on: issues
+
+Label the issue.
+
+### Details
+
+Issue details.
+
+## on: schedule, workflow_dispatch
+
+Review open issues.
+
+` + "```markdown\n## on: pull_request\n```" + `
+
+## Output
+
+Be brief.`
+
+func TestFindTriggerSections(t *testing.T) {
+	assert.Equal(t, []triggerSection{
+		{Heading: "## on: issues", Events: []string{"issues"}},
+		{Heading: "## on: schedule, workflow_dispatch", Events: []string{"schedule", "workflow_dispatch"}},
+	}, findTriggerSections(triggerSectionsPrompt), "sections outside code blocks should be found")
+
+	assert.Empty(t, findTriggerSections("# Title\n\n## Online: docs\n"), "ordinary headings are not trigger sections")
+}
+
+func TestFilterTriggerSections(t *testing.T) {
+	assert.Equal(t, "# Triage\n\nShared instructions.\n\nLabel the issue.\n\n### Details\n\nIssue details.\n\n## Output\n\nBe brief.",
+		filterTriggerSections(triggerSectionsPrompt, "issues"), "issues run should keep the issues section")
+
+	filtered := filterTriggerSections(triggerSectionsPrompt, "workflow_dispatch")
+	assert.Contains(t, filtered, "Review open issues.", "section listing the event should be kept")
+	assert.Contains(t, filtered, "```markdown\n## on: pull_request\n```", "fenced code should be kept as is")
+	assert.NotContains(t, filtered, "Label the issue.", "sections of other events should be removed")
+	assert.NotContains(t, filtered, "Issue details.", "subsections of removed sections should be removed")
+	assert.NotContains(t, filtered, "## on: schedule", "trigger headings should be removed")
+}
+
+func TestCompileWorkflowWithTriggerSections(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "trigger-sections-test")
+	workflowFile := filepath.Join(tmpDir, "persona.md")
+	frontmatter := `---
+on:
+  issues:
+    types: [opened]
+  schedule:
+    - cron: "0 9 * * 1"
+permissions:
+  contents: read
+engine: copilot
+---
+`
+
+	require.NoError(t, os.WriteFile(workflowFile, []byte(frontmatter+"\nShared.\n\n## on: issues\n\nTriage.\n\n## on: schedule\n\nSummarize.\n"), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "workflow with valid trigger sections should compile")
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "persona.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	assert.Contains(t, string(lockContent), `GH_AW_TRIGGER_SECTIONS: "true"`, "interpolation step should select trigger sections")
+
+	require.NoError(t, os.WriteFile(workflowFile, []byte(frontmatter+"\n## on: issue_coment\n\nReply.\n"), 0644), "should write workflow")
+	err = NewCompiler().CompileWorkflow(workflowFile)
+	require.Error(t, err, "section for an event that is not a trigger should fail")
+	assert.Contains(t, err.Error(), "names event 'issue_coment', which does not trigger this workflow", "error should name the event")
+}
+
+func TestRenderPromptPreviewWithTriggerSections(t *testing.T) {
+	workflowsDir := filepath.Join(testutil.TempDir(t, "trigger-sections-preview-test"), ".github", "workflows")
+	markdown := "Shared.\n\n## on: issues\n\nTriage.\n\n## on: schedule\n\nSummarize.\n"
+	data := &WorkflowData{MainWorkflowMarkdown: markdown, MarkdownContent: markdown}
+
+	prompt, err := RenderPromptPreview(data, filepath.Join(workflowsDir, "persona.md"), map[string]any{"github": map[string]any{"event_name": "schedule"}})
+	require.NoError(t, err, "prompt should render")
+	assert.Equal(t, "Shared.\n\nSummarize.\n", prompt, "only the schedule section should be rendered")
+}
@@ -30,9 +30,6 @@ type PromptSection struct {
 func (c *Compiler) generateUnifiedPromptStep(yaml *strings.Builder, data *WorkflowData) {
 	unifiedPromptLog.Print("Generating unified prompt step")
 
-	// Get the heredoc delimiter for consistent usage
-	delimiter := GenerateHeredocDelimiter("PROMPT")
-
 	// Collect all prompt sections in order
 	sections := c.collectPromptSections(data)
 
@@ -41,6 +38,13 @@ func (c *Compiler) generateUnifiedPromptStep(yaml *strings.Builder, data *Workfl
 		return
 	}
 
+	// Get the heredoc delimiter for consistent usage; it only changes if a section contains it
+	sectionContents := make([]string, 0, len(sections))
+	for _, section := range sections {
+		sectionContents = append(sectionContents, section.Content)
+	}
+	delimiter := GenerateContentHeredocDelimiter("PROMPT", sectionContents...)
+
 	unifiedPromptLog.Printf("Collected %d prompt sections", len(sections))
 
 	// Collect all environment variables from all sections
@@ -360,6 +364,31 @@ func (c *Compiler) collectPromptSections(data *WorkflowData) []PromptSection {
 		})
 	}
 
+	// 10. Quarantined pull request head (if pull_request_target checkout-head is enabled)
+	if data.HasPullRequestTarget && data.PRTargetCheckoutHead {
+		unifiedPromptLog.Print("Adding untrusted PR head section with condition")
+		sections = append(sections, PromptSection{
+			Content:        pullRequestTargetHeadPrompt,
+			IsFile:         false,
+			ShellCondition: `[ "$GITHUB_EVENT_NAME" = "pull_request_target" ]`,
+		})
+	}
+
+	// 11. Web search domain policy (if tools.web-search.allowed-domains is set)
+	if webSearchPolicy := buildWebSearchPolicyPrompt(data.ParsedTools); webSearchPolicy != "" {
+		unifiedPromptLog.Print("Adding web search policy section")
+		sections = append(sections, PromptSection{
+			Content: webSearchPolicy,
+			IsFile:  false,
+		})
+	}
+
+	// 12. Continuation instructions (if enabled)
+	if section := buildContinuationPromptSection(data.Continuation); section != nil {
+		unifiedPromptLog.Print("Adding continuation section")
+		sections = append(sections, *section)
+	}
+
 	return sections
 }
 
@@ -376,8 +405,13 @@ func (c *Compiler) generateUnifiedPromptCreationStep(yaml *strings.Builder, buil
 	unifiedPromptLog.Print("Generating unified prompt creation step")
 	unifiedPromptLog.Printf("Built-in sections: %d, User prompt chunks: %d", len(builtinSections), len(userPromptChunks))
 
-	// Get the heredoc delimiter for consistent usage
-	delimiter := GenerateHeredocDelimiter("PROMPT")
+	// Get the heredoc delimiter for consistent usage; it only changes if the prompt contains it
+	promptContents := make([]string, 0, len(builtinSections)+len(userPromptChunks))
+	for _, section := range builtinSections {
+		promptContents = append(promptContents, section.Content)
+	}
+	promptContents = append(promptContents, userPromptChunks...)
+	delimiter := GenerateContentHeredocDelimiter("PROMPT", promptContents...)
 
 	// Collect all environment variables from built-in sections and user prompt expressions
 	allEnvVars := make(map[string]string)
@@ -392,6 +426,18 @@ func (c *Compiler) generateUnifiedPromptCreationStep(yaml *strings.Builder, buil
 			// This is needed for the substitution step
 			if strings.HasPrefix(value, "${{ ") && strings.HasSuffix(value, " }}") {
 				content := strings.TrimSpace(value[4 : len(value)-3])
+				if data.WorkflowNeeds != nil {
+					if upstream, ok := upstreamFallbackExpression(content); ok {
+						content = upstream
+						value = fmt.Sprintf("${{ %s }}", content)
+					}
+				}
+				if data.HasReplayInputs {
+					if replayed, ok := replayFallbackExpression(content); ok {
+						content = replayed
+						value = fmt.Sprintf("${{ %s }}", content)
+					}
+				}
 				// Add to both allEnvVars (for prompt creation step) and expressionMappingsMap (for substitution step)
 				allEnvVars[key] = value
 				// Only add if not already present (user prompt expressions take precedence)
@@ -633,6 +679,9 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	if safeOutputs.CloseDiscussions != nil {
 		tools = append(tools, "close_discussion")
 	}
+	if safeOutputs.AnswerDiscussion != nil {
+		tools = append(tools, "answer_discussion")
+	}
 	if safeOutputs.CreateAgentSessions != nil {
 		tools = append(tools, "create_agent_session")
 	}
@@ -669,6 +718,9 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	if safeOutputs.AddReviewer != nil {
 		tools = append(tools, "add_reviewer")
 	}
+	if safeOutputs.RequestReview != nil {
+		tools = append(tools, "request_review")
+	}
 	if safeOutputs.AssignMilestone != nil {
 		tools = append(tools, "assign_milestone")
 	}
@@ -678,6 +730,9 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	if safeOutputs.AssignToUser != nil {
 		tools = append(tools, "assign_to_user")
 	}
+	if safeOutputs.Assign != nil {
+		tools = append(tools, "assign")
+	}
 	if safeOutputs.UnassignFromUser != nil {
 		tools = append(tools, "unassign_from_user")
 	}
@@ -453,11 +453,41 @@ func parseUpdateEntityConfigTyped[T any](
 // UpdateIssuesConfig holds configuration for updating GitHub issues from agent output
 type UpdateIssuesConfig struct {
 	UpdateEntityConfig `yaml:",inline"`
-	Status             *bool   `yaml:"status,omitempty"`       // Allow updating issue status (open/closed) - presence indicates field can be updated
-	Title              *bool   `yaml:"title,omitempty"`        // Allow updating issue title - presence indicates field can be updated
-	Body               *bool   `yaml:"body,omitempty"`         // Allow updating issue body - boolean value controls permission (defaults to true)
-	Footer             *string `yaml:"footer,omitempty"`       // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
-	TitlePrefix        string  `yaml:"title-prefix,omitempty"` // Required title prefix for issue validation - only issues with this prefix can be updated
+	Status             *bool    `yaml:"status,omitempty"`         // Allow updating issue status (open/closed) - presence indicates field can be updated
+	Title              *bool    `yaml:"title,omitempty"`          // Allow updating issue title - presence indicates field can be updated
+	Body               *bool    `yaml:"body,omitempty"`           // Allow updating issue body - boolean value controls permission (defaults to true)
+	Labels             *bool    `yaml:"labels,omitempty"`         // Allow updating issue labels - presence indicates field can be updated
+	Assignees          *bool    `yaml:"assignees,omitempty"`      // Allow updating issue assignees - presence indicates field can be updated
+	Milestone          *bool    `yaml:"milestone,omitempty"`      // Allow updating issue milestone - presence indicates field can be updated
+	AllowedLabels      []string `yaml:"allowed-labels,omitempty"` // Optional list of labels the agent may add or remove; other labels on the issue are kept
+	Footer             *string  `yaml:"footer,omitempty"`         // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
+	TitlePrefix        string   `yaml:"title-prefix,omitempty"`   // Required title prefix for issue validation - only issues with this prefix can be updated
+}
+
+// AllowedFields returns the issue fields the agent may change, in agent output naming.
+// It returns nil when no field is enabled in the frontmatter, in which case every field
+// except a disabled body can be updated.
+func (c *UpdateIssuesConfig) AllowedFields() []string {
+	var fields []string
+	if c.Status != nil {
+		fields = append(fields, "status")
+	}
+	if c.Title != nil {
+		fields = append(fields, "title")
+	}
+	if c.Body != nil && *c.Body {
+		fields = append(fields, "body")
+	}
+	if c.Labels != nil {
+		fields = append(fields, "labels")
+	}
+	if c.Assignees != nil {
+		fields = append(fields, "assignees")
+	}
+	if c.Milestone != nil {
+		fields = append(fields, "milestone")
+	}
+	return fields
 }
 
 // parseUpdateIssuesConfig handles update-issue configuration
@@ -469,10 +499,21 @@ func (c *Compiler) parseUpdateIssuesConfig(outputMap map[string]any) *UpdateIssu
 				{Name: "status", Mode: FieldParsingKeyExistence, Dest: &cfg.Status},
 				{Name: "title", Mode: FieldParsingKeyExistence, Dest: &cfg.Title},
 				{Name: "body", Mode: FieldParsingBoolValue, Dest: &cfg.Body},
+				{Name: "labels", Mode: FieldParsingKeyExistence, Dest: &cfg.Labels},
+				{Name: "assignees", Mode: FieldParsingKeyExistence, Dest: &cfg.Assignees},
+				{Name: "milestone", Mode: FieldParsingKeyExistence, Dest: &cfg.Milestone},
 				{Name: "footer", Mode: FieldParsingTemplatableBool, StringDest: &cfg.Footer},
 			}
 		}, func(configMap map[string]any, cfg *UpdateIssuesConfig) {
 			cfg.TitlePrefix = parseTitlePrefixFromConfig(configMap)
+			cfg.AllowedLabels = parseAllowedLabelsFromConfig(configMap)
+			if len(cfg.AllowedLabels) > 0 {
+				updateIssueLog.Printf("Allowed labels configured: %v", cfg.AllowedLabels)
+				// If allowed-labels is specified, implicitly enable labels
+				if cfg.Labels == nil {
+					cfg.Labels = new(bool)
+				}
+			}
 		})
 }
 
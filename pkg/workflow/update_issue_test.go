@@ -407,3 +407,58 @@ This workflow tests the update-issue title-prefix configuration.
 		t.Fatalf("Expected title-prefix to be '[bot] ', got '%s'", workflowData.SafeOutputs.UpdateIssues.TitlePrefix)
 	}
 }
+
+func TestUpdateIssueFieldAllowList(t *testing.T) {
+	// Test that labels, assignees, milestone and allowed-labels restrict the updatable fields
+	tmpDir := testutil.TempDir(t, "output-update-issue-allow-list-test")
+
+	testContent := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+  issues: read
+  pull-requests: read
+engine: claude
+strict: false
+safe-outputs:
+  update-issue:
+    milestone:
+    allowed-labels: [triage, needs-info]
+---
+
+# Test Update Issue Allow List
+
+This workflow tests the update-issue field allow-list.
+`
+
+	testFile := filepath.Join(tmpDir, "test-update-issue-allow-list.md")
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	workflowData, err := compiler.ParseWorkflowFile(testFile)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing workflow with update-issue allow-list: %v", err)
+	}
+
+	if workflowData.SafeOutputs == nil || workflowData.SafeOutputs.UpdateIssues == nil {
+		t.Fatal("Expected update-issue configuration to be parsed")
+	}
+
+	config := workflowData.SafeOutputs.UpdateIssues
+	if len(config.AllowedLabels) != 2 || config.AllowedLabels[0] != "triage" || config.AllowedLabels[1] != "needs-info" {
+		t.Fatalf("Expected allowed-labels to be [triage needs-info], got %v", config.AllowedLabels)
+	}
+
+	fields := config.AllowedFields()
+	if len(fields) != 2 || fields[0] != "labels" || fields[1] != "milestone" {
+		t.Fatalf("Expected allowed fields to be [labels milestone], got %v", fields)
+	}
+
+	if fields := (&UpdateIssuesConfig{}).AllowedFields(); fields != nil {
+		t.Fatalf("Expected no allow-list when no field is enabled, got %v", fields)
+	}
+}
@@ -0,0 +1,162 @@
+// This file provides compatibility checks between a workflow and the compiler version.
+//
+// # Version Compatibility
+//
+// Two optional frontmatter fields tie a workflow to gh-aw versions:
+//
+//	gh-aw-version: v0.40.1   # version of gh-aw that created the workflow
+//	min-version: v0.40.0     # oldest compiler allowed to compile the workflow
+//
+// gh-aw-version is written by 'gh aw new' in release builds. When it is set, the
+// compiler warns if it is older than the version that created the workflow, since
+// the workflow may use fields the compiler does not know, and if it is much newer,
+// since the compiled behavior may have changed since the workflow was written.
+// min-version is enforced: an older compiler refuses to compile the workflow.
+//
+// Development builds are not release versions, so they skip these checks.
+//
+// # Validation Functions
+//
+//   - ExtractWorkflowVersions() - Reads and validates gh-aw-version and min-version
+//   - CheckWorkflowVersions() - Compares the workflow versions with a compiler version
+//   - validateWorkflowVersions() - Applies the checks while compiling
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"golang.org/x/mod/semver"
+)
+
+var versionCompatibilityLog = newValidationLogger("version_compatibility")
+
+// versionDriftMinorReleases is the number of minor releases after which a v0 compiler
+// is considered much newer than the version that created a workflow. For v1 and later,
+// a newer major version is considered much newer.
+const versionDriftMinorReleases = 10
+
+// WorkflowVersions holds the gh-aw versions declared in a workflow's frontmatter
+type WorkflowVersions struct {
+	CreatedWith string // gh-aw-version: the version of gh-aw that created the workflow
+	MinVersion  string // min-version: the oldest compiler version allowed to compile the workflow
+}
+
+// ExtractWorkflowVersions reads the gh-aw-version and min-version fields from frontmatter.
+// It returns an error when a field is not a semantic version string.
+func ExtractWorkflowVersions(frontmatter map[string]any) (WorkflowVersions, error) {
+	var versions WorkflowVersions
+	for _, field := range []struct {
+		key    string
+		target *string
+	}{
+		{"gh-aw-version", &versions.CreatedWith},
+		{"min-version", &versions.MinVersion},
+	} {
+		value, exists := frontmatter[field.key]
+		if !exists {
+			continue
+		}
+		str, ok := value.(string)
+		if !ok {
+			return versions, fmt.Errorf("%s must be a version string such as \"v0.40.0\", got %T", field.key, value)
+		}
+		str = strings.TrimSpace(str)
+		if !semver.IsValid(withVersionPrefix(str)) {
+			return versions, fmt.Errorf("%s must be a semantic version such as \"v0.40.0\", got %q", field.key, str)
+		}
+		*field.target = str
+	}
+	return versions, nil
+}
+
+// CheckWorkflowVersions compares the versions declared by a workflow with a compiler version.
+// It returns warnings when the compiler is older or much newer than the version that created
+// the workflow, and an error when the compiler is older than min-version. Compiler versions
+// that are not semantic versions are not checked.
+func CheckWorkflowVersions(versions WorkflowVersions, compilerVersion string) ([]string, error) {
+	if !semver.IsValid(withVersionPrefix(compilerVersion)) {
+		versionCompatibilityLog.Printf("Compiler version %q is not a release version, skipping version checks", compilerVersion)
+		return nil, nil
+	}
+	current := withVersionPrefix(compilerVersion)
+	upgrade := "Upgrade with: gh extension upgrade github/gh-aw"
+
+	if versions.MinVersion != "" && compareVersions(current, versions.MinVersion) < 0 {
+		return nil, fmt.Errorf("workflow requires gh-aw %s or later (min-version), but this compiler is %s. %s",
+			withVersionPrefix(versions.MinVersion), current, upgrade)
+	}
+
+	if versions.CreatedWith == "" {
+		return nil, nil
+	}
+	created := withVersionPrefix(versions.CreatedWith)
+	var warnings []string
+	switch {
+	case compareVersions(current, created) < 0:
+		warnings = append(warnings, fmt.Sprintf("workflow was created with gh-aw %s, which is newer than this compiler (%s); fields added in later versions may be reported as unknown. %s",
+			created, current, upgrade))
+	case isMuchNewerVersion(current, created):
+		warnings = append(warnings, fmt.Sprintf("workflow was created with gh-aw %s, and this compiler (%s) is several releases newer; compiled behavior may have changed. Review the changelog, then set gh-aw-version: %s",
+			created, current, current))
+	}
+	versionCompatibilityLog.Printf("Checked workflow versions %+v against %s: %d warnings", versions, current, len(warnings))
+	return warnings, nil
+}
+
+// isMuchNewerVersion reports whether current is a newer major version than created, or for
+// v0 releases, at least versionDriftMinorReleases minor releases newer
+func isMuchNewerVersion(current, created string) bool {
+	currentMajor, currentMinor := majorMinor(current)
+	createdMajor, createdMinor := majorMinor(created)
+	if currentMajor != createdMajor {
+		return currentMajor > createdMajor
+	}
+	return currentMajor == 0 && currentMinor-createdMinor >= versionDriftMinorReleases
+}
+
+// majorMinor returns the major and minor numbers of a valid semantic version
+func majorMinor(version string) (int, int) {
+	majorPart, minorPart, _ := strings.Cut(strings.TrimPrefix(semver.MajorMinor(version), "v"), ".")
+	major, _ := strconv.Atoi(majorPart)
+	minor, _ := strconv.Atoi(minorPart)
+	return major, minor
+}
+
+// withVersionPrefix adds the "v" prefix expected by golang.org/x/mod/semver
+func withVersionPrefix(version string) string {
+	if version == "" || strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
+
+// validateWorkflowVersions checks the workflow's gh-aw-version and min-version against the
+// compiler version, printing compatibility warnings and failing when min-version is not met
+func (c *Compiler) validateWorkflowVersions(frontmatter map[string]any, markdownPath string) error {
+	versions, err := ExtractWorkflowVersions(frontmatter)
+	if err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	if versions == (WorkflowVersions{}) {
+		return nil
+	}
+	if !IsRelease() {
+		versionCompatibilityLog.Printf("Not a release build (%s), skipping workflow version checks", GetVersion())
+		return nil
+	}
+
+	warnings, err := CheckWorkflowVersions(versions, GetVersion())
+	if err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warning))
+		c.IncrementWarningCount()
+	}
+	return nil
+}
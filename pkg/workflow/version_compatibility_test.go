@@ -0,0 +1,131 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractWorkflowVersions(t *testing.T) {
+	versions, err := ExtractWorkflowVersions(map[string]any{"gh-aw-version": " v0.40.1 ", "min-version": "0.38.0"})
+	require.NoError(t, err, "valid versions should be accepted")
+	assert.Equal(t, WorkflowVersions{CreatedWith: "v0.40.1", MinVersion: "0.38.0"}, versions, "versions should be trimmed")
+
+	_, err = ExtractWorkflowVersions(map[string]any{"min-version": 1.2})
+	require.Error(t, err, "non-string version should be rejected")
+	assert.Contains(t, err.Error(), "min-version must be a version string", "error should name the field")
+
+	_, err = ExtractWorkflowVersions(map[string]any{"gh-aw-version": "latest"})
+	require.Error(t, err, "non-semver version should be rejected")
+	assert.Contains(t, err.Error(), `gh-aw-version must be a semantic version such as "v0.40.0", got "latest"`, "error should show the value")
+}
+
+func TestCheckWorkflowVersions(t *testing.T) {
+	tests := []struct {
+		name            string
+		versions        WorkflowVersions
+		compilerVersion string
+		warning         string
+		errorSubstring  string
+	}{
+		{
+			name:            "same version",
+			versions:        WorkflowVersions{CreatedWith: "v0.40.1", MinVersion: "v0.40.0"},
+			compilerVersion: "v0.40.1",
+		},
+		{
+			name:            "slightly newer compiler",
+			versions:        WorkflowVersions{CreatedWith: "v0.35.0"},
+			compilerVersion: "v0.40.1",
+		},
+		{
+			name:            "older compiler",
+			versions:        WorkflowVersions{CreatedWith: "v0.42.0"},
+			compilerVersion: "v0.40.1",
+			warning:         "created with gh-aw v0.42.0, which is newer than this compiler (v0.40.1)",
+		},
+		{
+			name:            "much newer v0 compiler",
+			versions:        WorkflowVersions{CreatedWith: "0.30.0"},
+			compilerVersion: "v0.40.1",
+			warning:         "compiled behavior may have changed",
+		},
+		{
+			name:            "newer major compiler",
+			versions:        WorkflowVersions{CreatedWith: "v1.9.0"},
+			compilerVersion: "v2.0.0",
+			warning:         "set gh-aw-version: v2.0.0",
+		},
+		{
+			name:            "min-version not met",
+			versions:        WorkflowVersions{MinVersion: "v0.41.0"},
+			compilerVersion: "v0.40.1",
+			errorSubstring:  "workflow requires gh-aw v0.41.0 or later (min-version), but this compiler is v0.40.1",
+		},
+		{
+			name:            "development build",
+			versions:        WorkflowVersions{CreatedWith: "v0.42.0", MinVersion: "v0.41.0"},
+			compilerVersion: "dev",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := CheckWorkflowVersions(tt.versions, tt.compilerVersion)
+			if tt.errorSubstring != "" {
+				require.Error(t, err, "compilation should be refused")
+				assert.Contains(t, err.Error(), tt.errorSubstring, "error message")
+				return
+			}
+			require.NoError(t, err, "compilation should be allowed")
+			if tt.warning == "" {
+				assert.Empty(t, warnings, "no warnings expected")
+				return
+			}
+			require.Len(t, warnings, 1, "one warning expected")
+			assert.Contains(t, warnings[0], tt.warning, "warning message")
+		})
+	}
+}
+
+func TestCompileWorkflowWithMinVersion(t *testing.T) {
+	originalVersion := compilerVersion
+	originalIsRelease := isReleaseBuild
+	defer func() {
+		compilerVersion = originalVersion
+		isReleaseBuild = originalIsRelease
+	}()
+
+	tmpDir := testutil.TempDir(t, "min-version-test")
+	workflowFile := filepath.Join(tmpDir, "versioned.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+gh-aw-version: v0.40.1
+min-version: v0.40.0
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+---
+
+Do things.
+`), 0644), "should write workflow")
+
+	SetVersion("dev")
+	SetIsRelease(false)
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "development builds should skip the version checks")
+
+	SetVersion("v0.40.2")
+	SetIsRelease(true)
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "compiler meeting min-version should compile")
+
+	SetVersion("v0.39.0")
+	err := NewCompiler().CompileWorkflow(workflowFile)
+	require.Error(t, err, "compiler older than min-version should fail")
+	assert.Contains(t, err.Error(), "workflow requires gh-aw v0.40.0 or later", "error should name the required version")
+}
@@ -0,0 +1,234 @@
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var warmCacheLog = logger.New("workflow:warm_cache")
+
+// warmCacheWorkflowFile is the name of the generated warm cache workflow
+const warmCacheWorkflowFile = "agentics-warm-cache.yml"
+
+// warmCacheImagesPath is the archive of container images stored in the Actions cache
+const warmCacheImagesPath = "/tmp/gh-aw/warm-cache/images.tar"
+
+// warmCacheGroup is one job of the warm cache workflow. Workflows with the same runner,
+// engine installation and container images share a group, so a fleet of similar scheduled
+// workflows warms its caches once.
+type warmCacheGroup struct {
+	workflows    []string
+	runsOn       string
+	installSteps []GitHubActionStep
+	images       []string
+}
+
+// warmCacheImagesKeyPrefix returns the cache key prefix of the saved container images. Each warm
+// cache run saves a new entry under the prefix and agent jobs restore the most recent one, so
+// floating tags such as :latest are refreshed nightly.
+func warmCacheImagesKeyPrefix(images []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(images, "\n")))
+	return fmt.Sprintf("gh-aw-images-%s-${{ runner.os }}-${{ runner.arch }}-", hex.EncodeToString(sum[:])[:12])
+}
+
+// generateRestoreWarmCacheImagesSteps generates the steps that load the container images saved by
+// the warm cache workflow. The download step that follows then only pulls what is missing or stale.
+func generateRestoreWarmCacheImagesSteps(yaml *strings.Builder, dockerImages []string) {
+	if len(dockerImages) == 0 {
+		return
+	}
+
+	keyPrefix := warmCacheImagesKeyPrefix(dockerImages)
+	yaml.WriteString("      - name: Restore warm container images\n")
+	yaml.WriteString("        id: warm-cache-images\n")
+	yaml.WriteString("        uses: " + GetActionPin("actions/cache/restore") + "\n")
+	yaml.WriteString("        with:\n")
+	yaml.WriteString("          path: " + warmCacheImagesPath + "\n")
+	yaml.WriteString("          key: " + keyPrefix + "\n")
+	yaml.WriteString("          restore-keys: " + keyPrefix + "\n")
+	yaml.WriteString("      - name: Load warm container images\n")
+	yaml.WriteString("        if: steps.warm-cache-images.outputs.cache-matched-key != ''\n")
+	yaml.WriteString("        run: docker load --input " + warmCacheImagesPath + "\n")
+}
+
+// GenerateWarmCacheWorkflow generates the agentics-warm-cache.yml workflow if any workflow sets
+// warm-cache: true. The workflow runs nightly and pre-populates the Actions cache with the engine
+// CLI and the container images (MCP servers, firewall, gateway) those workflows need, so their
+// agent jobs restore them instead of downloading everything on every run.
+func GenerateWarmCacheWorkflow(workflowDataList []*WorkflowData, workflowDir string, version string, actionMode ActionMode, actionTag string, verbose bool) error {
+	warmCacheLog.Print("Checking if warm cache workflow is needed")
+
+	var groups []*warmCacheGroup
+	groupsByKey := make(map[string]*warmCacheGroup)
+	var resolver ActionSHAResolver
+	for _, workflowData := range workflowDataList {
+		if !workflowData.WarmCache {
+			continue
+		}
+		if resolver == nil {
+			resolver = workflowData.ActionResolver
+		}
+
+		group := newWarmCacheGroup(workflowData, actionMode)
+		key := warmCacheGroupKey(group)
+		if existing, ok := groupsByKey[key]; ok {
+			existing.workflows = append(existing.workflows, workflowData.WorkflowID)
+			continue
+		}
+		groupsByKey[key] = group
+		groups = append(groups, group)
+	}
+
+	warmCacheFile := filepath.Join(workflowDir, warmCacheWorkflowFile)
+	if len(groups) == 0 {
+		warmCacheLog.Print("No workflows use warm-cache, skipping warm cache workflow generation")
+
+		// Delete existing warm cache workflow file if it exists
+		if _, err := os.Stat(warmCacheFile); err == nil {
+			warmCacheLog.Printf("Deleting existing warm cache workflow: %s", warmCacheFile)
+			if err := os.Remove(warmCacheFile); err != nil {
+				return fmt.Errorf("failed to delete warm cache workflow: %w", err)
+			}
+		}
+		return nil
+	}
+
+	warmCacheLog.Printf("Generating warm cache workflow with %d jobs", len(groups))
+
+	var yaml strings.Builder
+	customInstructions := `Alternative regeneration methods:
+  make recompile
+
+Or use the gh-aw CLI directly:
+  ./gh-aw compile --validate --verbose
+
+The workflow is generated when any workflow sets 'warm-cache: true'.
+It pre-populates the Actions cache with the engine CLI and container images
+so the agent jobs of those workflows start faster.`
+	yaml.WriteString(GenerateWorkflowHeader("", "pkg/workflow/warm_cache.go", customInstructions))
+
+	yaml.WriteString(`name: Agentic Warm Cache
+
+on:
+  schedule:
+    - cron: "23 5 * * *"  # Nightly
+  workflow_dispatch:
+
+permissions: {}
+
+jobs:
+`)
+
+	setupActionRef := ResolveSetupActionReference(actionMode, version, actionTag, resolver)
+	for i, group := range groups {
+		if i > 0 {
+			yaml.WriteString("\n")
+		}
+		writeWarmCacheJob(&yaml, group, setupActionRef, actionMode)
+	}
+
+	warmCacheLog.Printf("Writing warm cache workflow to %s", warmCacheFile)
+	if err := os.WriteFile(warmCacheFile, []byte(yaml.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write warm cache workflow: %w", err)
+	}
+	return nil
+}
+
+// newWarmCacheGroup collects what the agent job of workflowData downloads at startup
+func newWarmCacheGroup(workflowData *WorkflowData, actionMode ActionMode) *warmCacheGroup {
+	// The agent job always pulls the MCP gateway, so collect images the same way
+	ensureDefaultMCPGatewayConfig(workflowData)
+	group := &warmCacheGroup{
+		workflows: []string{workflowData.WorkflowID},
+		runsOn:    workflowData.RunsOn,
+		images:    collectDockerImages(workflowData.Tools, workflowData, actionMode),
+	}
+	if group.runsOn == "" {
+		group.runsOn = "runs-on: ubuntu-latest"
+	}
+
+	engine, err := GetGlobalEngineRegistry().GetEngine(workflowData.AI)
+	if err != nil {
+		warmCacheLog.Printf("No engine installation to warm for %s: %v", workflowData.WorkflowID, err)
+		return group
+	}
+	// Plugins are installed with per-workflow tokens and are not cached, so leave them out
+	installData := *workflowData
+	installData.PluginInfo = nil
+	group.installSteps = engine.GetInstallationSteps(&installData)
+	return group
+}
+
+// warmCacheGroupKey identifies the runner, installation steps and images of a group
+func warmCacheGroupKey(group *warmCacheGroup) string {
+	var key strings.Builder
+	key.WriteString(group.runsOn + "\n")
+	for _, step := range group.installSteps {
+		key.WriteString(strings.Join(step, "\n") + "\n")
+	}
+	key.WriteString(strings.Join(group.images, "\n"))
+	return key.String()
+}
+
+// writeWarmCacheJob writes the job that warms the caches of one group
+func writeWarmCacheJob(yaml *strings.Builder, group *warmCacheGroup, setupActionRef string, actionMode ActionMode) {
+	sum := sha256.Sum256([]byte(warmCacheGroupKey(group)))
+	fmt.Fprintf(yaml, "  warm-%s:\n", hex.EncodeToString(sum[:])[:8])
+	fmt.Fprintf(yaml, "    name: Warm cache (%s)\n", strings.Join(group.workflows, ", "))
+	yaml.WriteString("    if: ${{ !github.event.repository.fork }}\n")
+	for line := range strings.SplitSeq(group.runsOn, "\n") {
+		yaml.WriteString("    " + line + "\n")
+	}
+	yaml.WriteString(`    permissions:
+      contents: read
+    steps:
+`)
+
+	// Add checkout step only in dev mode (for local action paths)
+	if actionMode == ActionModeDev {
+		yaml.WriteString(`      - name: Checkout actions folder
+        uses: ` + GetActionPin("actions/checkout") + `
+        with:
+          sparse-checkout: |
+            actions
+          persist-credentials: false
+
+`)
+	}
+
+	yaml.WriteString(`      - name: Setup Scripts
+        uses: ` + setupActionRef + `
+        with:
+          destination: /opt/gh-aw/actions
+`)
+
+	// The engine installation steps cache the CLI under the same keys as the agent job
+	for _, step := range group.installSteps {
+		for _, line := range step {
+			yaml.WriteString(line + "\n")
+		}
+	}
+
+	if len(group.images) == 0 {
+		return
+	}
+	images := strings.Join(group.images, " ")
+	yaml.WriteString(`      - name: Download container images
+        run: bash /opt/gh-aw/actions/download_docker_images.sh ` + images + `
+      - name: Archive container images
+        run: |
+          mkdir -p ` + filepath.Dir(warmCacheImagesPath) + `
+          docker save --output ` + warmCacheImagesPath + ` ` + images + `
+      - name: Save warm container images
+        uses: ` + GetActionPin("actions/cache/save") + `
+        with:
+          path: ` + warmCacheImagesPath + `
+          key: ` + warmCacheImagesKeyPrefix(group.images) + `${{ github.run_id }}
+`)
+}
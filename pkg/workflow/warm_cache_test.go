@@ -0,0 +1,104 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const warmCacheTestWorkflow = `---
+on:
+  schedule:
+    - cron: "*/30 * * * *"
+permissions:
+  contents: read
+engine: copilot
+warm-cache: true
+tools:
+  github:
+    toolsets: [issues]
+---
+
+# Warm cache
+`
+
+func TestWarmCacheImagesKeyPrefix(t *testing.T) {
+	prefix := warmCacheImagesKeyPrefix([]string{"ghcr.io/github/github-mcp-server:v1", "node:lts-alpine"})
+	assert.Regexp(t, `^gh-aw-images-[0-9a-f]{12}-\$\{\{ runner\.os \}\}-\$\{\{ runner\.arch \}\}-$`, prefix, "key prefix format")
+	assert.Equal(t, prefix, warmCacheImagesKeyPrefix([]string{"ghcr.io/github/github-mcp-server:v1", "node:lts-alpine"}), "same images give the same prefix")
+	assert.NotEqual(t, prefix, warmCacheImagesKeyPrefix([]string{"node:lts-alpine"}), "different images give a different prefix")
+}
+
+func TestWarmCacheLockFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "warm-cache-lock-test")
+	workflowFile := filepath.Join(tmpDir, "fleet.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(warmCacheTestWorkflow), 0644), "should write workflow")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "should compile workflow")
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "fleet.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "name: Cache copilot CLI", "engine CLI should be cached")
+	assert.Contains(t, lock, "id: warm-cache-images", "agent job should restore the warm container images")
+	assert.Contains(t, lock, "run: docker load --input "+warmCacheImagesPath, "agent job should load the warm container images")
+	assert.Less(t, strings.Index(lock, "id: warm-cache-images"), strings.Index(lock, "name: Download container images"), "images should be loaded before they are pulled")
+
+	withoutWarmCache := strings.Replace(warmCacheTestWorkflow, "warm-cache: true\n", "", 1)
+	require.NoError(t, os.WriteFile(workflowFile, []byte(withoutWarmCache), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "should compile workflow")
+	lockContent, err = os.ReadFile(filepath.Join(tmpDir, "fleet.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	assert.NotContains(t, string(lockContent), "warm-cache-images", "workflows without warm-cache should not restore images")
+	assert.NotContains(t, string(lockContent), "Cache copilot CLI", "workflows without warm-cache should not cache the CLI")
+}
+
+func TestGenerateWarmCacheWorkflow(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "warm-cache-workflow-test")
+	compiler := NewCompiler()
+
+	var workflowDataList []*WorkflowData
+	for _, name := range []string{"fleet-a", "fleet-b"} {
+		workflowFile := filepath.Join(tmpDir, name+".md")
+		require.NoError(t, os.WriteFile(workflowFile, []byte(warmCacheTestWorkflow), 0644), "should write workflow")
+		workflowData, err := compiler.ParseWorkflowFile(workflowFile)
+		require.NoError(t, err, "should parse workflow")
+		workflowDataList = append(workflowDataList, workflowData)
+	}
+	workflowDataList = append(workflowDataList, &WorkflowData{WorkflowID: "not-warmed"})
+
+	require.NoError(t, GenerateWarmCacheWorkflow(workflowDataList, tmpDir, "v1.0.0", ActionModeRelease, "", false), "should generate warm cache workflow")
+	content, err := os.ReadFile(filepath.Join(tmpDir, warmCacheWorkflowFile))
+	require.NoError(t, err, "warm cache workflow should be written")
+	warmCache := string(content)
+
+	assert.Contains(t, warmCache, "name: Agentic Warm Cache", "workflow name")
+	assert.Contains(t, warmCache, `- cron: "23 5 * * *"`, "workflow should run nightly")
+	assert.Equal(t, 1, strings.Count(warmCache, "    name: Warm cache ("), "identical workflows should share one job")
+	assert.Contains(t, warmCache, "name: Warm cache (fleet-a, fleet-b)", "job should name the warmed workflows")
+	assert.NotContains(t, warmCache, "not-warmed", "workflows without warm-cache should not be warmed")
+	assert.Contains(t, warmCache, "name: Cache copilot CLI", "engine CLI should be cached under the agent job key")
+	assert.Contains(t, warmCache, "ghcr.io/github/github-mcp-server:", "GitHub MCP server image should be pulled")
+	assert.Contains(t, warmCache, "docker save --output "+warmCacheImagesPath, "images should be archived")
+	assert.Contains(t, warmCache, "${{ github.run_id }}", "each run should save a fresh image archive")
+
+	// Agent jobs restore the image archive under the key prefix the warm cache workflow saves
+	require.NoError(t, compiler.CompileWorkflow(filepath.Join(tmpDir, "fleet-a.md")), "should compile workflow")
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "fleet-a.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	restoreKey := regexp.MustCompile(`restore-keys: (gh-aw-images-\S+ \S+ \S+ \S+ \S+-)\n`).FindStringSubmatch(string(lockContent))
+	require.Len(t, restoreKey, 2, "agent job should restore the image archive")
+	assert.Contains(t, warmCache, "key: "+restoreKey[1]+"${{ github.run_id }}", "warm cache workflow should save under the restored key prefix")
+
+	// The file is removed once no workflow sets warm-cache
+	require.NoError(t, GenerateWarmCacheWorkflow([]*WorkflowData{{WorkflowID: "not-warmed"}}, tmpDir, "v1.0.0", ActionModeRelease, "", false), "should not fail without warm-cache")
+	assert.NoFileExists(t, filepath.Join(tmpDir, warmCacheWorkflowFile), "warm cache workflow should be deleted")
+}
@@ -0,0 +1,104 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var webSearchLog = logger.New("workflow:web_search")
+
+// webSearchAllowedDomains returns tools.web-search.allowed-domains, or nil when web-search is
+// disabled or unrestricted
+func webSearchAllowedDomains(tools map[string]any) []string {
+	webSearch, ok := tools["web-search"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	rawDomains, ok := webSearch["allowed-domains"].([]any)
+	if !ok {
+		return nil
+	}
+	var domains []string
+	for _, domain := range rawDomains {
+		if domainStr, ok := domain.(string); ok {
+			domains = append(domains, domainStr)
+		}
+	}
+	return domains
+}
+
+// isWebSearchEnabled reports whether tools.web-search is present and not set to false
+func isWebSearchEnabled(tools map[string]any) bool {
+	value, exists := tools["web-search"]
+	if !exists {
+		return false
+	}
+	enabled, isBool := value.(bool)
+	return !isBool || enabled
+}
+
+// validateWebSearchDomains validates tools.web-search.allowed-domains
+func validateWebSearchDomains(tools map[string]any) error {
+	for i, domain := range webSearchAllowedDomains(tools) {
+		if err := validateDomainPattern(domain); err != nil {
+			return fmt.Errorf("tools.web-search.allowed-domains[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateStrictWebSearch refuses web-search in strict mode when the agent's network is not
+// restricted by the firewall, since search results could then lead the agent anywhere
+func (c *Compiler) validateStrictWebSearch(frontmatter map[string]any, networkPermissions *NetworkPermissions) error {
+	tools, _ := frontmatter["tools"].(map[string]any)
+	if !isWebSearchEnabled(tools) {
+		return nil
+	}
+
+	reason := ""
+	switch {
+	case networkPermissions != nil && slices.Contains(networkPermissions.Allowed, "*"):
+		reason = "network.allowed contains '*'"
+	case networkPermissions != nil && networkPermissions.Firewall != nil && !networkPermissions.Firewall.Enabled:
+		reason = "the firewall is disabled"
+	case isAgentSandboxDisabledInFrontmatter(frontmatter):
+		reason = "sandbox.agent is disabled"
+	default:
+		return nil
+	}
+
+	webSearchLog.Printf("Web search validation failed in strict mode: %s", reason)
+	return errors.New("strict mode: tools.web-search requires a restricted network, but " + reason +
+		". Keep the firewall enabled with an explicit network.allowed list and use tools.web-search.allowed-domains to limit which sites the agent may search and browse. " +
+		"See: https://github.github.com/gh-aw/reference/tools/#web-search-domains")
+}
+
+// isAgentSandboxDisabledInFrontmatter reports whether the raw frontmatter sets sandbox.agent: false
+func isAgentSandboxDisabledInFrontmatter(frontmatter map[string]any) bool {
+	sandbox, ok := frontmatter["sandbox"].(map[string]any)
+	if !ok {
+		return false
+	}
+	agent, ok := sandbox["agent"].(bool)
+	return ok && !agent
+}
+
+// buildWebSearchPolicyPrompt returns the prompt section listing the domains the agent may
+// search and browse, or an empty string when web-search is unrestricted
+func buildWebSearchPolicyPrompt(tools *Tools) string {
+	if tools == nil || tools.WebSearch == nil || len(tools.WebSearch.AllowedDomains) == 0 {
+		return ""
+	}
+	var prompt strings.Builder
+	prompt.WriteString("<web-search-policy>\n")
+	prompt.WriteString("Web searches and page fetches are limited to the following domains. Restrict every search to them (for example with site: filters) and do not open results from other domains.\n")
+	for _, domain := range tools.WebSearch.AllowedDomains {
+		fmt.Fprintf(&prompt, "- %s\n", domain)
+	}
+	prompt.WriteString("</web-search-policy>")
+	return prompt.String()
+}
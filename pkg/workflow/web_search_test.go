@@ -0,0 +1,114 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSearchAllowedDomains(t *testing.T) {
+	tools := map[string]any{"web-search": map[string]any{"allowed-domains": []any{"docs.python.org", "*.readthedocs.io"}}}
+	assert.Equal(t, []string{"docs.python.org", "*.readthedocs.io"}, webSearchAllowedDomains(tools), "domains should be extracted")
+	assert.Nil(t, webSearchAllowedDomains(map[string]any{"web-search": nil}), "web-search without configuration should be unrestricted")
+	assert.Nil(t, webSearchAllowedDomains(map[string]any{}), "missing web-search should have no domains")
+
+	parsed := NewTools(tools)
+	require.NotNil(t, parsed.WebSearch, "web-search should be parsed")
+	assert.Equal(t, []string{"docs.python.org", "*.readthedocs.io"}, parsed.WebSearch.AllowedDomains, "parsed tools should carry the domains")
+
+	assert.NoError(t, validateWebSearchDomains(tools), "valid domains should pass")
+	err := validateWebSearchDomains(map[string]any{"web-search": map[string]any{"allowed-domains": []any{"docs.python.org", "*"}}})
+	require.Error(t, err, "wildcard-only domain should be rejected")
+	assert.Contains(t, err.Error(), "tools.web-search.allowed-domains[1]", "error should point at the invalid entry")
+}
+
+func TestValidateStrictWebSearch(t *testing.T) {
+	webSearch := map[string]any{"web-search": nil}
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		network     *NetworkPermissions
+		errorMsg    string
+	}{
+		{
+			name:        "web-search with restricted network",
+			frontmatter: map[string]any{"tools": webSearch},
+			network:     &NetworkPermissions{Allowed: []string{"defaults"}},
+		},
+		{
+			name:        "web-search disabled",
+			frontmatter: map[string]any{"tools": map[string]any{"web-search": false}},
+			network:     &NetworkPermissions{Allowed: []string{"*"}},
+		},
+		{
+			name:        "wildcard network",
+			frontmatter: map[string]any{"tools": webSearch},
+			network:     &NetworkPermissions{Allowed: []string{"*"}},
+			errorMsg:    "network.allowed contains '*'",
+		},
+		{
+			name:        "firewall disabled",
+			frontmatter: map[string]any{"tools": webSearch},
+			network:     &NetworkPermissions{Allowed: []string{"defaults"}, Firewall: &FirewallConfig{Enabled: false}},
+			errorMsg:    "the firewall is disabled",
+		},
+		{
+			name:        "agent sandbox disabled",
+			frontmatter: map[string]any{"tools": map[string]any{"web-search": true}, "sandbox": map[string]any{"agent": false}},
+			network:     &NetworkPermissions{Allowed: []string{"defaults"}},
+			errorMsg:    "sandbox.agent is disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			compiler.strictMode = true
+
+			err := compiler.validateStrictWebSearch(tt.frontmatter, tt.network)
+			if tt.errorMsg != "" {
+				require.Error(t, err, "web-search should be refused")
+				assert.Contains(t, err.Error(), tt.errorMsg, "error should explain why the network is unrestricted")
+				return
+			}
+			assert.NoError(t, err, "web-search should be allowed")
+		})
+	}
+}
+
+func TestWebSearchAllowedDomainsCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "web-search-domains-test")
+	workflowPath := filepath.Join(tmpDir, "research.md")
+	content := `---
+on: workflow_dispatch
+engine: claude
+permissions:
+  contents: read
+tools:
+  web-fetch:
+  web-search:
+    allowed-domains: [docs.python.org]
+---
+
+Research the Python documentation.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowPath))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	assert.Contains(t, lock, "<web-search-policy>", "prompt should include the web search policy")
+	assert.Contains(t, lock, "          - docs.python.org\n", "policy should list the domain")
+	assert.Contains(t, lock, "WebFetch(domain:docs.python.org)", "Claude WebFetch should be limited to the domain")
+	assert.NotContains(t, lock, ",WebFetch,", "unrestricted WebFetch should not be allowed")
+	assert.Regexp(t, `--allow-domains "[^"]*docs\.python\.org`, lock, "firewall should allow the domain")
+}
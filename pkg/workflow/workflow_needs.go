@@ -0,0 +1,279 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var workflowNeedsLog = logger.New("workflow:workflow_needs")
+
+// upstreamArtifactDir is where the pre-activation job downloads the activation artifact of
+// the upstream run, which holds the event it was triggered by (event.json)
+const upstreamArtifactDir = "/tmp/gh-aw/upstream"
+
+// workflowNeedsItemEvents are the triggers that identify an issue, pull request or discussion.
+// They are replaced by the workflow_run trigger and correlated with the upstream event.
+var workflowNeedsItemEvents = []string{
+	"discussion",
+	"discussion_comment",
+	"issue_comment",
+	"issues",
+	"pull_request",
+	"pull_request_review",
+	"pull_request_review_comment",
+	"pull_request_target",
+}
+
+// workflowNeedsKeptOnKeys are the on: keys that still apply when the workflow is triggered
+// by workflow_run
+var workflowNeedsKeptOnKeys = []string{
+	"bots",
+	"github-app",
+	"github-token",
+	"manual-approval",
+	"roles",
+	"skip-bots",
+	"skip-if-match",
+	"skip-if-no-match",
+	"skip-roles",
+	"stop-after",
+	"workflow_dispatch",
+}
+
+// WorkflowNeedsConfig holds the upstream agentic workflow this workflow runs after (needs:)
+//
+// Example:
+//
+//	on:
+//	  issues:
+//	    types: [opened]
+//	needs: issue-triage
+type WorkflowNeedsConfig struct {
+	Workflow     string              // Upstream workflow file name without the .md extension
+	WorkflowName string              // Upstream workflow name, matched by the workflow_run trigger
+	Events       map[string][]string // Item triggers correlated with the upstream event, with their types (empty for all types)
+}
+
+// applyWorkflowNeeds expands the needs: field. The item triggers of the on: section are
+// replaced with a workflow_run trigger on the upstream workflow and recorded so that the
+// pre-activation job only activates when the upstream run handled one of them.
+func (c *Compiler) applyWorkflowNeeds(frontmatter map[string]any, markdownPath string) error {
+	// The upstream workflow is resolved again for each workflow
+	c.workflowNeeds = nil
+
+	value, exists := frontmatter["needs"]
+	if !exists || value == nil {
+		return nil
+	}
+	// Shared workflows report needs as a forbidden field instead
+	if _, hasOn := frontmatter["on"]; !hasOn {
+		return nil
+	}
+
+	upstream, ok := value.(string)
+	if !ok || upstream == "" {
+		return fmt.Errorf("needs must be the file name of an agentic workflow without the .md extension, got %T. Example:\nneeds: issue-triage", value)
+	}
+	if upstream == getCurrentWorkflowName(markdownPath) {
+		return fmt.Errorf("needs: workflow '%s' cannot depend on itself", upstream)
+	}
+
+	onMap, err := normalizeWorkflowNeedsOn(frontmatter["on"])
+	if err != nil {
+		return err
+	}
+
+	events := make(map[string][]string)
+	newOn := make(map[string]any)
+	for key, eventValue := range onMap {
+		switch {
+		case slices.Contains(workflowNeedsItemEvents, key):
+			types, err := extractWorkflowNeedsTypes(key, eventValue)
+			if err != nil {
+				return err
+			}
+			events[key] = types
+		case slices.Contains(workflowNeedsKeptOnKeys, key):
+			newOn[key] = eventValue
+		default:
+			return fmt.Errorf("needs: on.%s cannot be used with needs. Runs are triggered when the upstream workflow completes, so only the item triggers (%s) and workflow_dispatch are supported", key, strings.Join(workflowNeedsItemEvents, ", "))
+		}
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("needs: on: must include at least one item trigger to correlate with the upstream workflow (%s)", strings.Join(workflowNeedsItemEvents, ", "))
+	}
+
+	workflowName, err := resolveUpstreamWorkflowName(upstream, markdownPath)
+	if err != nil {
+		return err
+	}
+
+	newOn["workflow_run"] = map[string]any{
+		"workflows":   []any{workflowName},
+		"types":       []any{"completed"},
+		"conclusions": []any{"success"},
+	}
+	frontmatter["on"] = newOn
+
+	c.workflowNeeds = &WorkflowNeedsConfig{
+		Workflow:     upstream,
+		WorkflowName: workflowName,
+		Events:       events,
+	}
+	workflowNeedsLog.Printf("Workflow runs after '%s' (%s) for events: %v", upstream, workflowName, events)
+	return nil
+}
+
+// normalizeWorkflowNeedsOn returns the on: section as a map of triggers
+func normalizeWorkflowNeedsOn(onValue any) (map[string]any, error) {
+	switch on := onValue.(type) {
+	case map[string]any:
+		return on, nil
+	case string:
+		return map[string]any{on: nil}, nil
+	case []any:
+		onMap := make(map[string]any, len(on))
+		for _, event := range on {
+			name, ok := event.(string)
+			if !ok {
+				return nil, fmt.Errorf("needs: on: must list trigger names, got %T", event)
+			}
+			onMap[name] = nil
+		}
+		return onMap, nil
+	default:
+		return nil, fmt.Errorf("needs: on: must be a trigger name, a list or an object, got %T", onValue)
+	}
+}
+
+// extractWorkflowNeedsTypes returns the activity types of an item trigger. Other filters
+// cannot be applied to the upstream event and are rejected.
+func extractWorkflowNeedsTypes(event string, value any) ([]string, error) {
+	types := []string{}
+	if value == nil {
+		return types, nil
+	}
+	eventMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("needs: on.%s must be an object, got %T", event, value)
+	}
+	for key, typesValue := range eventMap {
+		if key != "types" {
+			return nil, fmt.Errorf("needs: on.%s.%s cannot be used with needs. Only types can be matched against the upstream event", event, key)
+		}
+		switch v := typesValue.(type) {
+		case string:
+			types = append(types, v)
+		case []any:
+			for _, item := range v {
+				typeName, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("needs: on.%s.types must be an array of strings, got %T item", event, item)
+				}
+				types = append(types, typeName)
+			}
+		default:
+			return nil, fmt.Errorf("needs: on.%s.types must be an array of strings, got %T", event, typesValue)
+		}
+	}
+	sort.Strings(types)
+	return types, nil
+}
+
+// resolveUpstreamWorkflowName reads the name of the upstream agentic workflow: the name
+// field of its frontmatter, or its title
+func resolveUpstreamWorkflowName(upstream string, markdownPath string) (string, error) {
+	result, err := findWorkflowFile(upstream, markdownPath)
+	if err != nil {
+		return "", fmt.Errorf("needs: %w", err)
+	}
+	if !result.mdExists {
+		return "", fmt.Errorf("needs: agentic workflow '%s' not found. Expected %s", upstream, result.mdPath)
+	}
+
+	content, err := os.ReadFile(result.mdPath)
+	if err != nil {
+		return "", fmt.Errorf("needs: failed to read %s: %w", result.mdPath, err)
+	}
+	parsed, err := parser.ExtractFrontmatterFromContent(string(content))
+	if err != nil {
+		return "", fmt.Errorf("needs: failed to parse %s: %w", result.mdPath, err)
+	}
+	if _, hasOn := parsed.Frontmatter["on"]; !hasOn {
+		return "", fmt.Errorf("needs: '%s' is a shared workflow without triggers and never runs on its own", upstream)
+	}
+	if _, hasNeeds := parsed.Frontmatter["needs"]; hasNeeds {
+		return "", fmt.Errorf("needs: '%s' itself declares needs. Chained dependencies are not supported because its runs are triggered by workflow_run rather than by the item", upstream)
+	}
+
+	if name, ok := parsed.Frontmatter["name"].(string); ok && name != "" {
+		return name, nil
+	}
+	return parser.ExtractWorkflowNameFromContent(string(content), result.mdPath)
+}
+
+// generateUpstreamWorkflowCheck generates the pre-activation steps that download the event
+// recorded by the upstream run and check it against the correlated item triggers
+func (c *Compiler) generateUpstreamWorkflowCheck(data *WorkflowData, steps []string) []string {
+	steps = append(steps, "      - name: Download upstream activation artifact\n")
+	steps = append(steps, "        if: github.event_name == 'workflow_run'\n")
+	steps = append(steps, "        continue-on-error: true\n")
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/download-artifact")))
+	steps = append(steps, "        with:\n")
+	// The pattern also matches activation artifacts renamed by artifacts.prefix
+	steps = append(steps, "          pattern: \"*activation\"\n")
+	steps = append(steps, "          merge-multiple: true\n")
+	steps = append(steps, fmt.Sprintf("          path: %s\n", upstreamArtifactDir))
+	steps = append(steps, "          run-id: ${{ github.event.workflow_run.id }}\n")
+	steps = append(steps, "          github-token: ${{ secrets.GITHUB_TOKEN }}\n")
+
+	eventsJSON, _ := json.Marshal(data.WorkflowNeeds.Events)
+	steps = append(steps, "      - name: Check upstream workflow\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckUpstreamWorkflowStepID))
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/github-script")))
+	steps = append(steps, "        env:\n")
+	steps = append(steps, fmt.Sprintf("          GH_AW_UPSTREAM_EVENTS: %q\n", string(eventsJSON)))
+	steps = append(steps, "        with:\n")
+	steps = append(steps, "          script: |\n")
+	steps = append(steps, generateGitHubScriptWithRequire("check_upstream_workflow.cjs"))
+	return steps
+}
+
+// applyUpstreamPayloadFallbacks makes prompt expressions read the event of the upstream run
+// when the workflow runs after another agentic workflow. Other expressions are left unchanged.
+//
+// The EnvVar field is intentionally left unchanged, as in applyWorkflowDispatchFallbacks.
+func applyUpstreamPayloadFallbacks(mappings []*ExpressionMapping, needs *WorkflowNeedsConfig) {
+	if needs == nil {
+		return
+	}
+	for _, mapping := range mappings {
+		if upstream, ok := upstreamFallbackExpression(mapping.Content); ok {
+			workflowNeedsLog.Printf("Applying upstream fallback: %s -> %s", mapping.Content, upstream)
+			mapping.Content = upstream
+		}
+	}
+}
+
+// upstreamFallbackExpression rewrites an expression to prefer the upstream event. Simple
+// github.event.* paths read from the upstream payload, falling back to the actual event for
+// manual runs, and github.event_name reads the upstream event name.
+func upstreamFallbackExpression(content string) (string, bool) {
+	content = strings.TrimSpace(content)
+	preActivation := string(constants.PreActivationJobName)
+	if content == "github.event_name" {
+		return fmt.Sprintf("needs.%s.outputs.%s || github.event_name", preActivation, constants.UpstreamEventOutput), true
+	}
+	if match := replayablePathPattern.FindStringSubmatch(content); match != nil {
+		return fmt.Sprintf("fromJSON(needs.%s.outputs.%s || toJSON(github.event))%s", preActivation, constants.UpstreamPayloadOutput, match[1]), true
+	}
+	return content, false
+}
@@ -0,0 +1,197 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const upstreamTriageWorkflow = `---
+name: Issue Triage
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: claude
+---
+
+# Triage
+`
+
+func setupWorkflowNeedsDir(t *testing.T) string {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "workflow-needs-*")
+	workflowsDir := filepath.Join(tmpDir, constants.GetWorkflowDir())
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "should create workflows directory")
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "triage.md"), []byte(upstreamTriageWorkflow), 0644), "should write upstream workflow")
+	return workflowsDir
+}
+
+func TestApplyWorkflowNeeds(t *testing.T) {
+	workflowsDir := setupWorkflowNeedsDir(t)
+	markdownPath := filepath.Join(workflowsDir, "summarize.md")
+
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *WorkflowNeedsConfig
+		wantErr     string
+	}{
+		{
+			name:        "no needs",
+			frontmatter: map[string]any{"on": "issues"},
+		},
+		{
+			name: "item triggers are correlated",
+			frontmatter: map[string]any{
+				"needs": "triage",
+				"on": map[string]any{
+					"issues":            map[string]any{"types": []any{"reopened", "opened"}},
+					"issue_comment":     nil,
+					"workflow_dispatch": nil,
+				},
+			},
+			expected: &WorkflowNeedsConfig{
+				Workflow:     "triage",
+				WorkflowName: "Issue Triage",
+				Events:       map[string][]string{"issues": {"opened", "reopened"}, "issue_comment": {}},
+			},
+		},
+		{
+			name:        "string trigger",
+			frontmatter: map[string]any{"needs": "triage", "on": "pull_request"},
+			expected:    &WorkflowNeedsConfig{Workflow: "triage", WorkflowName: "Issue Triage", Events: map[string][]string{"pull_request": {}}},
+		},
+		{
+			name:        "invalid type",
+			frontmatter: map[string]any{"needs": []any{"triage"}, "on": "issues"},
+			wantErr:     "needs must be the file name of an agentic workflow",
+		},
+		{
+			name:        "depends on itself",
+			frontmatter: map[string]any{"needs": "summarize", "on": "issues"},
+			wantErr:     "cannot depend on itself",
+		},
+		{
+			name:        "missing upstream workflow",
+			frontmatter: map[string]any{"needs": "missing", "on": "issues"},
+			wantErr:     "agentic workflow 'missing' not found",
+		},
+		{
+			name:        "non-item trigger",
+			frontmatter: map[string]any{"needs": "triage", "on": map[string]any{"issues": nil, "push": nil}},
+			wantErr:     "on.push cannot be used with needs",
+		},
+		{
+			name:        "event filter",
+			frontmatter: map[string]any{"needs": "triage", "on": map[string]any{"issues": map[string]any{"types": []any{"labeled"}, "names": []any{"bug"}}}},
+			wantErr:     "on.issues.names cannot be used with needs",
+		},
+		{
+			name:        "no item trigger",
+			frontmatter: map[string]any{"needs": "triage", "on": "workflow_dispatch"},
+			wantErr:     "must include at least one item trigger",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			err := compiler.applyWorkflowNeeds(tt.frontmatter, markdownPath)
+			if tt.wantErr != "" {
+				require.Error(t, err, "should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+				return
+			}
+			require.NoError(t, err, "should expand needs")
+			assert.Equal(t, tt.expected, compiler.workflowNeeds, "workflow needs config")
+			if tt.expected == nil {
+				return
+			}
+
+			onMap, ok := tt.frontmatter["on"].(map[string]any)
+			require.True(t, ok, "on should be an object")
+			for event := range tt.expected.Events {
+				assert.NotContains(t, onMap, event, "item trigger should be replaced")
+			}
+			assert.Equal(t, map[string]any{
+				"workflows":   []any{"Issue Triage"},
+				"types":       []any{"completed"},
+				"conclusions": []any{"success"},
+			}, onMap["workflow_run"], "workflow_run should follow the upstream workflow")
+		})
+	}
+}
+
+func TestUpstreamFallbackExpression(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected string
+		ok       bool
+	}{
+		{content: "github.event.issue.number", expected: "fromJSON(needs.pre_activation.outputs.upstream_payload || toJSON(github.event)).issue.number", ok: true},
+		{content: "github.event_name", expected: "needs.pre_activation.outputs.upstream_event || github.event_name", ok: true},
+		{content: "github.repository", expected: "github.repository"},
+		{content: "github.event.issue.title || 'untitled'", expected: "github.event.issue.title || 'untitled'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			result, ok := upstreamFallbackExpression(tt.content)
+			assert.Equal(t, tt.ok, ok, "rewrite flag")
+			assert.Equal(t, tt.expected, result, "rewritten expression")
+		})
+	}
+}
+
+func TestWorkflowNeedsCompilation(t *testing.T) {
+	workflowsDir := setupWorkflowNeedsDir(t)
+	workflowPath := filepath.Join(workflowsDir, "summarize.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+  workflow_dispatch:
+permissions:
+  contents: read
+engine: claude
+needs: triage
+---
+
+# Summarize
+
+Summarize issue #${{ github.event.issue.number }}.
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "should write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowPath), "workflow should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "summarize.lock.yml"))
+	require.NoError(t, err, "should read lock file")
+	lock := string(lockContent)
+
+	for _, expected := range []string{
+		"workflow_run:",
+		"- Issue Triage",
+		"- name: Download upstream activation artifact",
+		"run-id: ${{ github.event.workflow_run.id }}",
+		"- name: Check upstream workflow",
+		`GH_AW_UPSTREAM_EVENTS: "{\"issues\":[\"opened\"]}"`,
+		"steps.check_upstream_workflow.outputs.upstream_ok == 'true'",
+		"upstream_payload: ${{ steps.check_upstream_workflow.outputs.upstream_payload }}",
+		"GH_AW_UPSTREAM_PAYLOAD: ${{ needs.pre_activation.outputs.upstream_payload }}",
+		"fromJSON(needs.pre_activation.outputs.upstream_payload || toJSON(github.event)).issue.number",
+		"github.event.workflow_run.repository.id == github.repository_id",
+		"actions: read",
+	} {
+		assert.Contains(t, lock, expected, "lock file should contain %q", expected)
+	}
+	assert.NotContains(t, lock, "  issues:\n    types:", "issues trigger should be replaced by workflow_run")
+}
@@ -0,0 +1,80 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRunConclusionFilter(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "workflow-run-conclusion-test")
+
+	tests := []struct {
+		name        string
+		conclusions string
+		expectedIf  string
+		commented   []string // Expected commented-out lines in the on section
+	}{
+		{
+			name:        "single conclusion",
+			conclusions: "[failure]",
+			expectedIf:  "(github.event_name != 'workflow_run') || (github.event.workflow_run.conclusion == 'failure')",
+			commented:   []string{"- failure # Conclusion filtering applied via job conditions"},
+		},
+		{
+			name:        "multiple conclusions",
+			conclusions: "[failure, timed_out]",
+			expectedIf:  "(github.event_name != 'workflow_run') || (github.event.workflow_run.conclusion == 'failure' || github.event.workflow_run.conclusion == 'timed_out')",
+			commented: []string{
+				"- failure # Conclusion filtering applied via job conditions",
+				"- timed_out # Conclusion filtering applied via job conditions",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testContent := `---
+on:
+  workflow_run:
+    workflows: ["CI"]
+    types: [completed]
+    branches: [main]
+    conclusions: ` + tt.conclusions + `
+permissions:
+  contents: read
+  actions: read
+strict: false
+---
+
+# CI Doctor
+
+Investigate the failed CI run.
+`
+			testFile := filepath.Join(tmpDir, strings.ReplaceAll(tt.name, " ", "-")+".md")
+			require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "should write workflow")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(testFile), "should compile workflow")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+			require.NoError(t, err, "should read lock file")
+			lockStr := string(lockContent)
+
+			assert.Contains(t, lockStr, tt.expectedIf, "activation condition should filter on the conclusion")
+			assert.Contains(t, lockStr, "# conclusions: # Conclusion filtering applied via job conditions", "conclusions should be commented out of the on section")
+			for _, line := range tt.commented {
+				assert.Contains(t, lockStr, "# "+line, "conclusion items should be commented out of the on section")
+			}
+			assert.NotContains(t, lockStr, "\n    conclusions:", "conclusions must not reach the GitHub Actions trigger")
+		})
+	}
+}